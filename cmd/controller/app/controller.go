@@ -17,34 +17,48 @@ limitations under the License.
 package app
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apiserver/pkg/server/healthz"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
 	"github.com/cert-manager/cert-manager/cmd/controller/app/options"
 	cmdutil "github.com/cert-manager/cert-manager/cmd/util"
+	controllerconfig "github.com/cert-manager/cert-manager/internal/apis/config/controller"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/fips"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
 	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/notifications"
+	"github.com/cert-manager/cert-manager/pkg/ocspresponder"
+	"github.com/cert-manager/cert-manager/pkg/util/cmapichecker"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/profiling"
+	"github.com/cert-manager/cert-manager/pkg/util/tracing"
 )
 
 func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
@@ -54,6 +68,20 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 	log := logf.FromContext(rootCtx)
 	g, rootCtx := errgroup.WithContext(rootCtx)
 
+	if opts.CertificateShard < 0 {
+		shard, err := certificateShardFromHostname(opts.CertificateShards)
+		if err != nil {
+			return err
+		}
+		opts.CertificateShard = shard
+	}
+	if fips.BackendEnabled() {
+		log.Info("using a FIPS validated crypto module")
+	}
+	if opts.CertificateShards > 1 {
+		log.Info("running certificates controllers for a single shard", "shard", opts.CertificateShard, "shards", opts.CertificateShards)
+	}
+
 	ctxFactory, err := buildControllerContextFactory(rootCtx, opts)
 	if err != nil {
 		return err
@@ -95,6 +123,46 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 		return nil
 	})
 
+	// Start liveness and readiness server
+	healthzLn, err := net.Listen("tcp", opts.HealthzListenAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on healthz address %s: %v", opts.HealthzListenAddress, err)
+	}
+	controllersHealthz := newControllersHealthz()
+	leadership := &leadership{}
+	var leaderElectionHealthz *leaderelection.HealthzAdaptor
+	var livezLeaderElection healthz.HealthChecker
+	if opts.LeaderElect {
+		leaderElectionHealthz = leaderelection.NewLeaderHealthzAdaptor(leaderElectionHealthzTimeout)
+		livezLeaderElection = leaderElectionHealthz
+	}
+	webhookHealthz := &webhookHealthz{
+		newChecker: func() (cmapichecker.Interface, error) {
+			return cmapichecker.New(ctx.RESTConfig, runtime.NewScheme(), ctx.ClusterResourceNamespace)
+		},
+		clock: ctx.Clock,
+	}
+	healthzServer := newHealthzServer(healthzLn.Addr().String(), leadership, livezLeaderElection, controllersHealthz, webhookHealthz)
+
+	g.Go(func() error {
+		<-rootCtx.Done()
+		// allow a timeout for graceful shutdown
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := healthzServer.Shutdown(ctx); err != nil {
+			return err
+		}
+		return nil
+	})
+	g.Go(func() error {
+		log.V(logf.InfoLevel).Info("starting healthz server", "address", healthzLn.Addr())
+		if err := healthzServer.Serve(healthzLn); err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	})
+
 	// Start profiler if it is enabled
 	if opts.EnablePprof {
 		profilerLn, err := net.Listen("tcp", opts.PprofAddress)
@@ -104,6 +172,7 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 		profilerMux := http.NewServeMux()
 		// Add pprof endpoints to this mux
 		profiling.Install(profilerMux)
+		installDebugBundle(profilerMux, ctx, controllersHealthz)
 		profilerServer := &http.Server{
 			Handler: profilerMux,
 		}
@@ -128,6 +197,66 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 		})
 	}
 
+	// Start the OCSP responder if it is enabled. Every replica answers OCSP
+	// requests, not just the leader, so the informers it needs are started
+	// straight away rather than once leader election has been won.
+	if opts.OCSPResponderListenAddress != "" {
+		ocspLn, err := net.Listen("tcp", opts.OCSPResponderListenAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen on OCSP responder address %s: %v", opts.OCSPResponderListenAddress, err)
+		}
+		responder := ocspresponder.New(logf.FromContext(rootCtx, "ocsp-responder"), ctx.KubeSharedInformerFactory, ctx.SharedInformerFactory,
+			ctx.Namespace, ctx.IssuerOptions.ClusterResourceNamespace, opts.OCSPResponseValidity, ctx.Clock)
+		ctx.SharedInformerFactory.Start(rootCtx.Done())
+		ctx.KubeSharedInformerFactory.Start(rootCtx.Done())
+		ocspServer := &http.Server{
+			Handler:           responder,
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+
+		g.Go(func() error {
+			<-rootCtx.Done()
+			// allow a timeout for graceful shutdown
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if err := ocspServer.Shutdown(ctx); err != nil {
+				return err
+			}
+			return nil
+		})
+		g.Go(func() error {
+			log.V(logf.InfoLevel).Info("starting OCSP responder", "address", ocspLn.Addr())
+			if err := ocspServer.Serve(ocspLn); err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		})
+	}
+
+	// Export issuance spans if tracing is enabled
+	if opts.TracingOTLPEndpoint != "" {
+		shutdownTracing, err := tracing.Setup(rootCtx, tracing.Options{
+			ServiceName: "cert-manager-controller",
+			Endpoint:    opts.TracingOTLPEndpoint,
+			Insecure:    opts.TracingOTLPInsecure,
+			SampleRatio: opts.TracingSampleRatio,
+		})
+		if err != nil {
+			return err
+		}
+		log.V(logf.InfoLevel).Info("exporting issuance traces", "endpoint", opts.TracingOTLPEndpoint)
+
+		g.Go(func() error {
+			<-rootCtx.Done()
+			// allow a timeout to flush remaining spans
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			return shutdownTracing(ctx)
+		})
+	}
+
 	elected := make(chan struct{})
 	if opts.LeaderElect {
 		g.Go(func() error {
@@ -138,8 +267,9 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 			}
 
 			errorCh := make(chan error, 1)
-			if err := startLeaderElection(rootCtx, opts, ctx.Client, ctx.Recorder, leaderelection.LeaderCallbacks{
+			if err := startLeaderElection(rootCtx, opts, ctx.Client, ctx.Recorder, leaderElectionHealthz, leaderelection.LeaderCallbacks{
 				OnStartedLeading: func(_ context.Context) {
+					leadership.elected.Store(true)
 					close(elected)
 				},
 				OnStoppedLeading: func() {
@@ -163,6 +293,7 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 			}
 		})
 	} else {
+		leadership.elected.Store(true)
 		close(elected)
 	}
 
@@ -174,6 +305,11 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 		// Continue with setting up controller
 	}
 
+	// Expose the depth and retries of each controller's workqueue. This must
+	// be done before any of the controllers create their queue.
+	workqueue.SetProvider(ctx.Metrics.WorkqueueMetricsProvider())
+	controller.SetPriorityQueueMetricsProvider(ctx.Metrics.WorkqueueMetricsProvider())
+
 	for n, fn := range controller.Known() {
 		log := log.WithValues("controller", n)
 
@@ -183,6 +319,12 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 			continue
 		}
 
+		// controllers which are not sharded are only run by the first shard
+		if opts.CertificateShard > 0 && !controller.IsSharded(n) {
+			log.V(logf.InfoLevel).Info("not starting controller as it is only run by certificate shard 0")
+			continue
+		}
+
 		// don't run clusterissuers controller if scoped to a single namespace
 		if ctx.Namespace != "" && n == clusterissuers.ControllerName {
 			log.V(logf.InfoLevel).Info("not starting controller as cert-manager has been scoped to a single namespace")
@@ -200,12 +342,12 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 			}
 			return err
 		}
+		controllersHealthz.add(n, iface)
 
+		workers := ctx.WorkersFor(n)
 		g.Go(func() error {
-			log.V(logf.InfoLevel).Info("starting controller")
+			log.V(logf.InfoLevel).Info("starting controller", "workers", workers)
 
-			// TODO: make this either a constant or a command line flag
-			workers := 5
 			return iface.Run(workers, rootCtx.Done())
 		})
 	}
@@ -261,6 +403,34 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 		return nil, fmt.Errorf("error parsing ACMEHTTP01SolverResourceLimitsMemory: %w", err)
 	}
 
+	namespaceSelector, err := labels.Parse(opts.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing NamespaceSelector: %w", err)
+	}
+
+	watchLabelSelector, err := labels.Parse(opts.WatchLabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing WatchLabelSelector: %w", err)
+	}
+
+	issuerPlugins, err := opts.IssuerPluginAddresses()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing IssuerPlugins: %w", err)
+	}
+
+	var operatorWebhookNamespace, operatorWebhookName string
+	if opts.OperatorWebhookService != "" {
+		operatorWebhookNamespace, operatorWebhookName, err = cache.SplitMetaNamespaceKey(opts.OperatorWebhookService)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing OperatorWebhookService: %w", err)
+		}
+	}
+
+	notifier, notificationExpiryWarning, err := buildNotifier(ctx, opts.Notifications)
+	if err != nil {
+		return nil, fmt.Errorf("error configuring notifications: %w", err)
+	}
+
 	ACMEHTTP01SolverRunAsNonRoot := opts.ACMEHTTP01SolverRunAsNonRoot
 	acmeAccountRegistry := accounts.NewDefaultRegistry()
 
@@ -270,10 +440,16 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 		KubernetesAPIBurst: opts.KubernetesAPIBurst,
 		APIServerHost:      opts.APIServerHost,
 
-		Namespace: opts.Namespace,
+		Workers:     opts.Workers,
+		Controllers: opts.Controllers,
+
+		Namespace:          opts.Namespace,
+		NamespaceSelector:  namespaceSelector,
+		WatchLabelSelector: watchLabelSelector,
 
-		Clock:   clock.RealClock{},
-		Metrics: metrics.New(log, clock.RealClock{}),
+		Clock:       clock.RealClock{},
+		ClockSource: opts.ClockSource,
+		Metrics:     metrics.New(log, clock.RealClock{}),
 
 		ACMEOptions: controller.ACMEOptions{
 			HTTP01SolverResourceRequestCPU:    http01SolverResourceRequestCPU,
@@ -300,6 +476,7 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 			ClusterIssuerAmbientCredentials: opts.ClusterIssuerAmbientCredentials,
 			IssuerAmbientCredentials:        opts.IssuerAmbientCredentials,
 			ClusterResourceNamespace:        opts.ClusterResourceNamespace,
+			IssuerPlugins:                   issuerPlugins,
 		},
 
 		IngressShimOptions: controller.IngressShimOptions{
@@ -312,6 +489,29 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 		CertificateOptions: controller.CertificateOptions{
 			EnableOwnerRef:           opts.EnableCertificateOwnerRef,
 			CopiedAnnotationPrefixes: opts.CopiedAnnotationPrefixes,
+			CertificateShards:        opts.CertificateShards,
+			CertificateShard:         opts.CertificateShard,
+			ClockSkewTolerance:       opts.ClockSkewTolerance,
+			ExpiryAlertThresholds:    opts.ExpiryAlertThresholds,
+
+			Notifier:                  notifier,
+			NotificationExpiryWarning: notificationExpiryWarning,
+		},
+
+		CertificateSigningRequestOptions: controller.CertificateSigningRequestOptions{
+			KubeletServingSignerName: opts.KubeletServingSignerName,
+		},
+
+		OperatorOptions: controller.OperatorOptions{
+			OperatorWebhookServiceNamespace: operatorWebhookNamespace,
+			OperatorWebhookServiceName:      operatorWebhookName,
+			OperatorWebhookTimeout:          opts.OperatorWebhookTimeout,
+		},
+
+		EventOptions: controller.EventOptions{
+			EventTypes:          opts.EventTypes,
+			EventRateLimitQPS:   opts.EventRateLimitQPS,
+			EventRateLimitBurst: opts.EventRateLimitBurst,
 		},
 	})
 	if err != nil {
@@ -321,7 +521,53 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 	return ctxFactory, nil
 }
 
-func startLeaderElection(ctx context.Context, opts *options.ControllerOptions, leaderElectionClient kubernetes.Interface, recorder record.EventRecorder, callbacks leaderelection.LeaderCallbacks) error {
+// buildNotifier returns the Notifier sending certificate events to the
+// configured sinks, which runs until the context is cancelled, and how long
+// before certificates expire the Expiring event is sent.
+func buildNotifier(ctx context.Context, cfg *controllerconfig.NotificationsConfiguration) (notifications.Notifier, time.Duration, error) {
+	if cfg == nil || len(cfg.Sinks) == 0 {
+		return notifications.Discard, 0, nil
+	}
+
+	expiryWarning := notifications.DefaultExpiryWarning
+	if cfg.ExpiryWarning != nil {
+		expiryWarning = cfg.ExpiryWarning.Duration
+	}
+
+	sinks := make([]notifications.Sink, 0, len(cfg.Sinks))
+	for _, s := range cfg.Sinks {
+		sink := notifications.Sink{
+			Name:   s.Name,
+			URL:    s.URL,
+			Format: notifications.Format(s.Format),
+		}
+		if s.SigningKeyFile != "" {
+			key, err := os.ReadFile(s.SigningKeyFile)
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to read the signing key of sink %q: %w", s.Name, err)
+			}
+			sink.SigningKey = bytes.TrimSpace(key)
+		}
+		for _, event := range s.Events {
+			sink.Events = append(sink.Events, notifications.EventType(event))
+		}
+		if s.Timeout != nil {
+			sink.Timeout = s.Timeout.Duration
+		}
+		sinks = append(sinks, sink)
+	}
+
+	dispatcher := notifications.NewDispatcher(logf.FromContext(ctx), sinks)
+	go dispatcher.Run(ctx)
+
+	return dispatcher, expiryWarning, nil
+}
+
+// leaderElectionHealthzTimeout is how long the leader may fail to renew its
+// lease, beyond the lease duration, before /livez fails.
+const leaderElectionHealthzTimeout = 20 * time.Second
+
+func startLeaderElection(ctx context.Context, opts *options.ControllerOptions, leaderElectionClient kubernetes.Interface, recorder record.EventRecorder, watchDog *leaderelection.HealthzAdaptor, callbacks leaderelection.LeaderCallbacks) error {
 	// Identity used to distinguish between multiple controller manager instances
 	id, err := os.Hostname()
 	if err != nil {
@@ -329,6 +575,11 @@ func startLeaderElection(ctx context.Context, opts *options.ControllerOptions, l
 	}
 
 	lockName := "cert-manager-controller"
+	// Each shard elects its own leader. The lock of shard 0 keeps the
+	// unsharded name so that upgrades to a sharded deployment are safe.
+	if opts.CertificateShard > 0 {
+		lockName = fmt.Sprintf("%s-shard-%d", lockName, opts.CertificateShard)
+	}
 	lc := resourcelock.ResourceLockConfig{
 		Identity:      id + "-external-cert-manager-controller",
 		EventRecorder: recorder,
@@ -355,6 +606,7 @@ func startLeaderElection(ctx context.Context, opts *options.ControllerOptions, l
 		RetryPeriod:     opts.LeaderElectionRetryPeriod,
 		ReleaseOnCancel: true,
 		Callbacks:       callbacks,
+		WatchDog:        watchDog,
 	})
 	if err != nil {
 		return err
@@ -364,3 +616,25 @@ func startLeaderElection(ctx context.Context, opts *options.ControllerOptions, l
 
 	return nil
 }
+
+// certificateShardFromHostname returns the ordinal of the StatefulSet Pod the
+// controller is running in, which is the suffix of its hostname. Shard 0 is
+// returned if Certificates are not sharded.
+func certificateShardFromHostname(shards int) (int, error) {
+	if shards <= 1 {
+		return 0, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return 0, fmt.Errorf("error getting hostname: %v", err)
+	}
+	i := strings.LastIndex(hostname, "-")
+	shard, err := strconv.Atoi(hostname[i+1:])
+	if err != nil {
+		return 0, fmt.Errorf("certificate-shard must be set since the hostname %q does not end with a StatefulSet ordinal", hostname)
+	}
+	if shard >= shards {
+		return 0, fmt.Errorf("the StatefulSet ordinal %d of hostname %q must be less than certificate-shards (%d)", shard, hostname, shards)
+	}
+	return shard, nil
+}
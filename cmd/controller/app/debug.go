@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/util/profiling"
+)
+
+// debugBundle is a snapshot of the state of the controller, served by the
+// profiler to help diagnose high memory or CPU usage.
+type debugBundle struct {
+	Time time.Time `json:"time"`
+
+	// InformerCacheSizes is the number of objects cached for each type.
+	InformerCacheSizes map[string]int `json:"informerCacheSizes"`
+
+	// Controllers holds the workers and queue length of each controller.
+	Controllers map[string]controller.Stats `json:"controllers"`
+
+	// Goroutines is the total number of goroutines, and
+	// GoroutinesByController the number started by each controller.
+	Goroutines             int            `json:"goroutines"`
+	GoroutinesByController map[string]int `json:"goroutinesByController"`
+
+	Memory debugMemoryStats `json:"memory"`
+}
+
+type debugMemoryStats struct {
+	HeapAllocBytes uint64 `json:"heapAllocBytes"`
+	HeapObjects    uint64 `json:"heapObjects"`
+	SysBytes       uint64 `json:"sysBytes"`
+	NumGC          uint32 `json:"numGC"`
+}
+
+// installDebugBundle adds the /debug/bundle endpoint to the given mux.
+func installDebugBundle(mux *http.ServeMux, ctx *controller.Context, controllers *controllersHealthz) {
+	mux.HandleFunc("/debug/bundle", func(w http.ResponseWriter, _ *http.Request) {
+		goroutines, total, err := profiling.GoroutinesByLabel(controller.ProfilerLabel)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// Goroutines which were not started by a controller are only
+		// included in the total.
+		delete(goroutines, "")
+
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+
+		bundle := debugBundle{
+			Time:                   time.Now(),
+			InformerCacheSizes:     ctx.InformerCacheSizes(),
+			Controllers:            controllers.stats(),
+			Goroutines:             total,
+			GoroutinesByController: goroutines,
+			Memory: debugMemoryStats{
+				HeapAllocBytes: mem.HeapAlloc,
+				HeapObjects:    mem.HeapObjects,
+				SysBytes:       mem.Sys,
+				NumGC:          mem.NumGC,
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(bundle)
+	})
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apiserver/pkg/server/healthz"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/util/cmapichecker"
+)
+
+// controllersHealthz is a readiness check which fails if a worker of any of
+// the running controllers has stalled. Controllers are added once they have
+// been started, which only happens after leader election, so a replica which
+// is not the leader is always ready.
+type controllersHealthz struct {
+	lock        sync.RWMutex
+	controllers map[string]controller.Interface
+}
+
+func newControllersHealthz() *controllersHealthz {
+	return &controllersHealthz{controllers: make(map[string]controller.Interface)}
+}
+
+func (h *controllersHealthz) add(name string, iface controller.Interface) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.controllers[name] = iface
+}
+
+// unsynced returns the names of the running controllers whose informers have
+// not synced yet.
+func (h *controllersHealthz) unsynced() []string {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	var names []string
+	for name, iface := range h.controllers {
+		if !iface.HasSynced() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// stats returns the stats of each of the running controllers.
+func (h *controllersHealthz) stats() map[string]controller.Stats {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	stats := make(map[string]controller.Stats, len(h.controllers))
+	for name, iface := range h.controllers {
+		stats[name] = iface.Stats()
+	}
+	return stats
+}
+
+func (h *controllersHealthz) Name() string {
+	return "controllers"
+}
+
+func (h *controllersHealthz) Check(_ *http.Request) error {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	names := make([]string, 0, len(h.controllers))
+	for name := range h.controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if err := h.controllers[name].Check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// leadership records whether this replica has been elected leader, and so
+// is running the controllers.
+type leadership struct {
+	elected atomic.Bool
+}
+
+// informersHealthz is a readiness check which fails if this replica is the
+// leader but the informers of any of the running controllers have not synced
+// yet, during which time no resources are reconciled. A replica which is not
+// the leader is always ready.
+type informersHealthz struct {
+	leadership  *leadership
+	controllers *controllersHealthz
+}
+
+func (h *informersHealthz) Name() string {
+	return "informers"
+}
+
+func (h *informersHealthz) Check(_ *http.Request) error {
+	if !h.leadership.elected.Load() {
+		return nil
+	}
+	if unsynced := h.controllers.unsynced(); len(unsynced) > 0 {
+		return fmt.Errorf("leader but the informers of controller(s) %s have not synced", strings.Join(unsynced, ", "))
+	}
+	return nil
+}
+
+// webhookCheckInterval is how long the result of checking that the webhook is
+// reachable is reused for, so that frequent health checks do not each send a
+// request to the API server.
+const webhookCheckInterval = 30 * time.Second
+
+// webhookHealthz checks that the cert-manager webhook is reachable by the API
+// server. Without the webhook cert-manager resources cannot be changed, so
+// controllers continue to run but the replica is degraded. The check is not
+// part of /readyz, as every replica would become unready while the webhook is
+// unavailable.
+type webhookHealthz struct {
+	// newChecker creates the checker when the webhook is first checked, so
+	// that the API server is not contacted on start up.
+	newChecker func() (cmapichecker.Interface, error)
+	checker    cmapichecker.Interface
+	clock      clock.Clock
+
+	lock      sync.Mutex
+	checkedAt time.Time
+	err       error
+}
+
+func (h *webhookHealthz) Name() string {
+	return "webhook"
+}
+
+func (h *webhookHealthz) Check(req *http.Request) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.checkedAt.IsZero() || h.clock.Since(h.checkedAt) >= webhookCheckInterval {
+		h.err = h.check(req.Context())
+		h.checkedAt = h.clock.Now()
+	}
+	if h.err != nil {
+		return fmt.Errorf("degraded: the cert-manager webhook is unreachable: %w", h.err)
+	}
+	return nil
+}
+
+func (h *webhookHealthz) check(ctx context.Context) error {
+	if h.checker == nil {
+		checker, err := h.newChecker()
+		if err != nil {
+			return err
+		}
+		h.checker = checker
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	return h.checker.Check(ctx)
+}
+
+// healthState summarises the health of the replica, so that orchestration
+// and alerts can tell apart replicas which are waiting to become the leader
+// from those which are not working.
+type healthState string
+
+const (
+	// healthStateHealthy is the state of a leader which is reconciling
+	// resources normally.
+	healthStateHealthy healthState = "Healthy"
+	// healthStateNotLeader is the state of a replica which is running but
+	// waiting to be elected leader.
+	healthStateNotLeader healthState = "NotLeader"
+	// healthStateInformersNotSynced is the state of a leader whose
+	// controllers are waiting for their informers to sync.
+	healthStateInformersNotSynced healthState = "InformersNotSynced"
+	// healthStateStalled is the state of a leader with a stalled controller.
+	healthStateStalled healthState = "Stalled"
+	// healthStateDegraded is the state of a leader which cannot reach the
+	// cert-manager webhook.
+	healthStateDegraded healthState = "Degraded"
+)
+
+type healthStatus struct {
+	State   healthState `json:"state"`
+	Message string      `json:"message,omitempty"`
+}
+
+// status returns the health of the replica. The webhook is only checked once
+// the replica has been elected leader and is otherwise healthy.
+func status(req *http.Request, leadership *leadership, informers, controllers, webhook healthz.HealthChecker) healthStatus {
+	if !leadership.elected.Load() {
+		return healthStatus{State: healthStateNotLeader}
+	}
+	for _, c := range []struct {
+		checker healthz.HealthChecker
+		state   healthState
+	}{
+		{informers, healthStateInformersNotSynced},
+		{controllers, healthStateStalled},
+		{webhook, healthStateDegraded},
+	} {
+		if err := c.checker.Check(req); err != nil {
+			return healthStatus{State: c.state, Message: err.Error()}
+		}
+	}
+	return healthStatus{State: healthStateHealthy}
+}
+
+// newHealthzServer returns a server exposing:
+//   - /livez, which fails if the replica is the leader but has failed to
+//     renew its lease for longer than the leader election watchdog allows.
+//   - /readyz, which also fails if the replica is the leader and its
+//     informers have not synced yet, or any of its controllers have stalled.
+//   - /status, which reports the state of the replica as JSON, including
+//     whether the webhook is reachable, and responds with 503 Service
+//     Unavailable unless the replica is healthy or waiting to become the
+//     leader.
+func newHealthzServer(addr string, leadership *leadership, leaderElection healthz.HealthChecker, controllers *controllersHealthz, webhook healthz.HealthChecker) *http.Server {
+	informers := &informersHealthz{leadership: leadership, controllers: controllers}
+
+	mux := http.NewServeMux()
+	livez := []healthz.HealthChecker{healthz.PingHealthz}
+	if leaderElection != nil {
+		livez = append(livez, leaderElection)
+	}
+	healthz.InstallLivezHandler(mux, livez...)
+	healthz.InstallReadyzHandler(mux, healthz.PingHealthz, informers, controllers)
+	mux.HandleFunc("/status", func(w http.ResponseWriter, req *http.Request) {
+		st := status(req, leadership, informers, controllers, webhook)
+		w.Header().Set("Content-Type", "application/json")
+		if st.State != healthStateHealthy && st.State != healthStateNotLeader {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(st)
+	})
+	return &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+}
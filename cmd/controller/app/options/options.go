@@ -24,11 +24,18 @@ import (
 	"time"
 
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
 
 	cmdutil "github.com/cert-manager/cert-manager/cmd/util"
+	controllerconfig "github.com/cert-manager/cert-manager/internal/apis/config/controller"
+	"github.com/cert-manager/cert-manager/internal/controller/clocksource"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cm "github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	"github.com/cert-manager/cert-manager/pkg/controller"
 	challengescontroller "github.com/cert-manager/cert-manager/pkg/controller/acmechallenges"
 	orderscontroller "github.com/cert-manager/cert-manager/pkg/controller/acmeorders"
 	shimgatewaycontroller "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/gateways"
@@ -36,23 +43,37 @@ import (
 	cracmecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/acme"
 	crapprovercontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/approver"
 	crcacontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/ca"
+	crexternalsignercontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/externalsigner"
+	crhubcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/hub"
+	crplugincontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/plugin"
 	crselfsignedcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/selfsigned"
 	crvaultcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/vault"
 	crvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/venafi"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterevocationrequests"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/additionalprivatekeys"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/dryrun"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/expiryalerts"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/expirynotifications"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/keymanager"
 	certificatesmetricscontroller "github.com/cert-manager/cert-manager/pkg/controller/certificates/metrics"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/readiness"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/requestmanager"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/revisionmanager"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/secretstores"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/trigger"
 	csracmecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/acme"
 	csrcacontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/ca"
+	csrkubeletservingcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/kubeletserving"
 	csrselfsignedcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/selfsigned"
+	csrutil "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
 	csrvaultcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/vault"
 	csrvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/venafi"
 	clusterissuerscontroller "github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
+	"github.com/cert-manager/cert-manager/pkg/controller/crls"
 	issuerscontroller "github.com/cert-manager/cert-manager/pkg/controller/issuers"
+	operatorcontroller "github.com/cert-manager/cert-manager/pkg/controller/operator"
+	"github.com/cert-manager/cert-manager/pkg/controller/spiffebundles"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
@@ -64,8 +85,27 @@ type ControllerOptions struct {
 	KubernetesAPIQPS   float32
 	KubernetesAPIBurst int
 
+	// ConfigFile is the path to a ControllerConfiguration file. Workers and
+	// Controllers are loaded from it, and the values of flags which are not
+	// set are taken from it.
+	ConfigFile  string
+	Workers     int
+	Controllers map[string]controllerconfig.ControllerSettings
+
+	// EventTypes is the list of Kubernetes Event types which are emitted.
+	EventTypes []string
+	// EventRateLimitQPS and EventRateLimitBurst limit the rate of Events
+	// emitted about a single object.
+	EventRateLimitQPS   float32
+	EventRateLimitBurst int
+
 	ClusterResourceNamespace string
 	Namespace                string
+	// NamespaceSelector and WatchLabelSelector are label selectors limiting
+	// the resources which are processed, allowing multiple instances of
+	// cert-manager to share a cluster.
+	NamespaceSelector  string
+	WatchLabelSelector string
 
 	LeaderElect                 bool
 	LeaderElectionNamespace     string
@@ -87,6 +127,15 @@ type ControllerOptions struct {
 	ClusterIssuerAmbientCredentials bool
 	IssuerAmbientCredentials        bool
 
+	// IssuerPlugins maps issuer kinds of other API groups, written as
+	// kind.group, to the address of the issuer plugin which signs their
+	// CertificateRequests.
+	IssuerPlugins map[string]string
+
+	// Notifications configures the sinks notified of certificate events.
+	// It can only be set in the config file.
+	Notifications *controllerconfig.NotificationsConfiguration
+
 	// Default issuer/certificates details consumed by ingress-shim
 	DefaultIssuerName                 string
 	DefaultIssuerKind                 string
@@ -101,17 +150,67 @@ type ControllerOptions struct {
 
 	EnableCertificateOwnerRef bool
 
+	// CertificateShards is the number of shards Certificates are split
+	// between, and CertificateShard the index of the shard owned by this
+	// instance. A negative CertificateShard is replaced by the ordinal of the
+	// StatefulSet Pod running the controller.
+	CertificateShards int
+	CertificateShard  int
+
+	// ClockSource selects the clock used to decide when Certificates are
+	// renewed and whether they have expired: either the clock of the node, or
+	// the clock of the Kubernetes API server.
+	ClockSource string
+	// ClockSkewTolerance is how far behind the clock may be. Certificates are
+	// renewed, and considered to have expired, this much earlier.
+	ClockSkewTolerance time.Duration
+
+	// ExpiryAlertThresholds are the times before a certificate expires at
+	// which the expiry alerts controllers set the ExpiringSoon condition and
+	// fire a warning event.
+	ExpiryAlertThresholds []time.Duration
+
 	MaxConcurrentChallenges int
 
+	// KubeletServingSignerName is the signer name of the cert-manager issuer
+	// which signs kubelet serving CertificateSigningRequests. Setting it
+	// enables the kubelet serving approver controller.
+	KubeletServingSignerName string
+
+	// OperatorWebhookService is the namespace/name of the cert-manager
+	// webhook Service. Setting it enables the operator controller.
+	OperatorWebhookService string
+	// OperatorWebhookTimeout is the timeout of the admission webhooks
+	// reconciled by the operator controller.
+	OperatorWebhookTimeout time.Duration
+
 	// The host and port address, separated by a ':', that the Prometheus server
 	// should expose metrics on.
 	MetricsListenAddress string
+	// The host and port address, separated by a ':', that the liveness and
+	// readiness endpoints should be served on.
+	HealthzListenAddress string
 	// PprofAddress is the address on which Go profiler will run. Should be
 	// in form <host>:<port>.
 	PprofAddress string
 	// EnablePprof determines whether pprof should be enabled.
 	EnablePprof bool
 
+	// OCSPResponderListenAddress is the host and port that the OCSP
+	// responder for certificates issued by CA and SelfSigned issuers should
+	// listen on. The responder is disabled if empty.
+	OCSPResponderListenAddress string
+	// OCSPResponseValidity is how long OCSP responses are valid for.
+	OCSPResponseValidity time.Duration
+
+	// TracingOTLPEndpoint is the host and port of the OTLP gRPC receiver
+	// spans are exported to. Tracing is disabled if empty.
+	TracingOTLPEndpoint string
+	// TracingOTLPInsecure disables TLS when exporting spans.
+	TracingOTLPInsecure bool
+	// TracingSampleRatio is the fraction of issuances which are traced.
+	TracingSampleRatio float64
+
 	// DNSO1CheckRetryPeriod is the period of time after which to check if
 	// challenge URL can be reached by cert-manager controller. This is used
 	// for both DNS-01 and HTTP-01 challenges.
@@ -129,6 +228,13 @@ const (
 	defaultKubernetesAPIQPS   float32 = 20
 	defaultKubernetesAPIBurst         = 50
 
+	defaultWorkers = 5
+
+	// The defaults of the event rate limit are those used by client-go: a
+	// burst of 25 events, followed by one event every 5 minutes.
+	defaultEventRateLimitQPS   float32 = 1. / 300.
+	defaultEventRateLimitBurst         = 25
+
 	defaultClusterResourceNamespace = "kube-system"
 	defaultNamespace                = ""
 
@@ -140,11 +246,24 @@ const (
 	defaultTLSACMEIssuerGroup        = cm.GroupName
 	defaultEnableCertificateOwnerRef = false
 
+	defaultCertificateShards = 1
+	defaultCertificateShard  = -1
+
+	defaultClockSource        = clocksource.System
+	defaultClockSkewTolerance = time.Duration(0)
+
 	defaultDNS01RecursiveNameserversOnly = false
 
 	defaultMaxConcurrentChallenges = 60
 
+	defaultOperatorWebhookTimeout = 10 * time.Second
+
 	defaultPrometheusMetricsServerAddress = "0.0.0.0:9402"
+	defaultHealthzServerAddress           = "0.0.0.0:9403"
+
+	defaultOCSPResponseValidity = time.Hour
+
+	defaultTracingSampleRatio = 1.0
 
 	// default time period to wait between checking DNS01 and HTTP01 challenge propagation
 	defaultDNS01CheckRetryPeriod = 10 * time.Second
@@ -160,6 +279,10 @@ var (
 
 	defaultAutoCertificateAnnotations = []string{"kubernetes.io/tls-acme"}
 
+	defaultExpiryAlertThresholds = []time.Duration{30 * 24 * time.Hour, 14 * 24 * time.Hour, 7 * 24 * time.Hour}
+
+	defaultEventTypes = []string{corev1.EventTypeNormal, corev1.EventTypeWarning}
+
 	allControllers = []string{
 		issuerscontroller.ControllerName,
 		clusterissuerscontroller.ControllerName,
@@ -174,6 +297,9 @@ var (
 		crselfsignedcontroller.CRControllerName,
 		crvaultcontroller.CRControllerName,
 		crvenaficontroller.CRControllerName,
+		crhubcontroller.CRControllerName,
+		crexternalsignercontroller.CRControllerName,
+		crplugincontroller.CRControllerName,
 		// certificate controllers
 		trigger.ControllerName,
 		issuing.ControllerName,
@@ -181,6 +307,17 @@ var (
 		requestmanager.ControllerName,
 		readiness.ControllerName,
 		revisionmanager.ControllerName,
+		dryrun.ControllerName,
+		secretstores.ControllerName,
+		additionalprivatekeys.ControllerName,
+		certificaterevocationrequests.ControllerName,
+		crls.ControllerName,
+		spiffebundles.ControllerName,
+		expirynotifications.ControllerName,
+		expiryalerts.ControllerName,
+		expiryalerts.SecretsControllerName,
+		csrkubeletservingcontroller.ControllerName,
+		operatorcontroller.ControllerName,
 	}
 
 	defaultEnabledControllers = []string{
@@ -228,6 +365,10 @@ func NewControllerOptions() *ControllerOptions {
 		ClusterResourceNamespace:          defaultClusterResourceNamespace,
 		KubernetesAPIQPS:                  defaultKubernetesAPIQPS,
 		KubernetesAPIBurst:                defaultKubernetesAPIBurst,
+		Workers:                           defaultWorkers,
+		EventTypes:                        defaultEventTypes,
+		EventRateLimitQPS:                 defaultEventRateLimitQPS,
+		EventRateLimitBurst:               defaultEventRateLimitBurst,
 		Namespace:                         defaultNamespace,
 		LeaderElect:                       cmdutil.DefaultLeaderElect,
 		LeaderElectionNamespace:           cmdutil.DefaultLeaderElectionNamespace,
@@ -245,10 +386,18 @@ func NewControllerOptions() *ControllerOptions {
 		DNS01RecursiveNameservers:         []string{},
 		DNS01RecursiveNameserversOnly:     defaultDNS01RecursiveNameserversOnly,
 		EnableCertificateOwnerRef:         defaultEnableCertificateOwnerRef,
+		CertificateShards:                 defaultCertificateShards,
+		CertificateShard:                  defaultCertificateShard,
+		ClockSource:                       defaultClockSource,
+		ClockSkewTolerance:                defaultClockSkewTolerance,
+		ExpiryAlertThresholds:             defaultExpiryAlertThresholds,
 		MetricsListenAddress:              defaultPrometheusMetricsServerAddress,
+		HealthzListenAddress:              defaultHealthzServerAddress,
 		DNS01CheckRetryPeriod:             defaultDNS01CheckRetryPeriod,
 		EnablePprof:                       cmdutil.DefaultEnableProfiling,
 		PprofAddress:                      cmdutil.DefaultProfilerAddr,
+		OCSPResponseValidity:              defaultOCSPResponseValidity,
+		TracingSampleRatio:                defaultTracingSampleRatio,
 	}
 }
 
@@ -258,14 +407,33 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"will be attempted.")
 	fs.StringVar(&s.Kubeconfig, "kubeconfig", defaultKubeconfig, ""+
 		"Paths to a kubeconfig. Only required if out-of-cluster.")
+	fs.StringVar(&s.ConfigFile, "config", "", ""+
+		"Path to a ControllerConfiguration file, which configures the number of workers, the workqueue "+
+		"rate limiters and the Kubernetes API client rate limits of the controllers. Flags take precedence "+
+		"over the corresponding values in the file.")
 	fs.Float32Var(&s.KubernetesAPIQPS, "kube-api-qps", defaultKubernetesAPIQPS, "indicates the maximum queries-per-second requests to the Kubernetes apiserver")
 	fs.IntVar(&s.KubernetesAPIBurst, "kube-api-burst", defaultKubernetesAPIBurst, "the maximum burst queries-per-second of requests sent to the Kubernetes apiserver")
+	fs.StringSliceVar(&s.EventTypes, "event-types", defaultEventTypes, ""+
+		"The types of Kubernetes Events which should be emitted. Set to 'Warning' to only emit Events when issuance fails, "+
+		"reducing the load on event storage in large clusters.")
+	fs.Float32Var(&s.EventRateLimitQPS, "event-rate-limit-qps", defaultEventRateLimitQPS, ""+
+		"The maximum rate, in events per second, at which Events about a single resource are emitted once the burst has been used up.")
+	fs.IntVar(&s.EventRateLimitBurst, "event-rate-limit-burst", defaultEventRateLimitBurst, ""+
+		"The number of Events about a single resource which may be emitted before being rate limited.")
 	fs.StringVar(&s.ClusterResourceNamespace, "cluster-resource-namespace", defaultClusterResourceNamespace, ""+
 		"Namespace to store resources owned by cluster scoped resources such as ClusterIssuer in. "+
 		"This must be specified if ClusterIssuers are enabled.")
 	fs.StringVar(&s.Namespace, "namespace", defaultNamespace, ""+
 		"If set, this limits the scope of cert-manager to a single namespace and ClusterIssuers are disabled. "+
 		"If not specified, all namespaces will be watched")
+	fs.StringVar(&s.NamespaceSelector, "namespace-selector", "", ""+
+		"If set, only resources in namespaces whose labels match this label selector are processed. "+
+		"Resources in a namespace whose labels change to match are processed when they are next updated, or resynced. "+
+		"Requires permission to list and watch Namespaces.")
+	fs.StringVar(&s.WatchLabelSelector, "watch-label-selector", "", ""+
+		"If set, only cert-manager resources, Ingresses and Gateways whose labels match this label selector are processed. "+
+		"Certificates created for Ingresses and Gateways, and the CertificateRequests, Orders and Challenges created "+
+		"for them, inherit their labels. Issuers and ClusterIssuers must also match. Secrets are not filtered.")
 	fs.BoolVar(&s.LeaderElect, "leader-elect", cmdutil.DefaultLeaderElect, ""+
 		"If true, cert-manager will perform leader election between instances to ensure no more "+
 		"than one instance of cert-manager operates at a time")
@@ -329,6 +497,11 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"Whether an issuer may make use of ambient credentials. 'Ambient Credentials' are credentials drawn from the environment, metadata services, or local files which are not explicitly configured in the Issuer API object. "+
 		"When this flag is enabled, the following sources for credentials are also used: "+
 		"AWS - All sources the Go SDK defaults to, notably including any EC2 IAM roles available via instance metadata.")
+	fs.StringToStringVar(&s.IssuerPlugins, "issuer-plugins", nil, ""+
+		"Issuer plugins which sign the CertificateRequests referencing issuers of other API groups, as a list of "+
+		"kind.group=address pairs, e.g. ExampleIssuer.example.com=unix:///plugins/example.sock. The address is a unix "+
+		"socket or a host:port serving the issuer plugin gRPC API without TLS. If set, the "+crplugincontroller.CRControllerName+" "+
+		"controller is enabled.")
 	fs.StringSliceVar(&s.DefaultAutoCertificateAnnotations, "auto-certificate-annotations", defaultAutoCertificateAnnotations, ""+
 		"The annotation consumed by the ingress-shim controller to indicate a ingress is requesting a certificate")
 
@@ -353,6 +526,25 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&s.EnableCertificateOwnerRef, "enable-certificate-owner-ref", defaultEnableCertificateOwnerRef, ""+
 		"Whether to set the certificate resource as an owner of secret where the tls certificate is stored. "+
 		"When this flag is enabled, the secret will be automatically removed when the certificate resource is deleted.")
+	fs.IntVar(&s.CertificateShards, "certificate-shards", defaultCertificateShards, ""+
+		"The number of shards Certificates are split between by hashing their namespace and name. "+
+		"Each shard runs the certificates controllers for its own Certificates, and performs leader election "+
+		"separately. All other controllers are run by shard 0 only.")
+	fs.IntVar(&s.CertificateShard, "certificate-shard", defaultCertificateShard, ""+
+		"The index of the shard of Certificates owned by this instance, between 0 and certificate-shards - 1. "+
+		"If negative, the ordinal of the StatefulSet Pod is used, taken from the suffix of the hostname.")
+	fs.StringVar(&s.ClockSource, "clock-source", defaultClockSource, ""+
+		"The clock used to decide when Certificates should be renewed and whether they have expired. Either 'system', the clock "+
+		"of the node the controller runs on, or 'apiserver', which follows the clock of the Kubernetes API server and may be "+
+		"used on nodes whose clock is not synchronised reliably, such as in edge clusters.")
+	fs.DurationVar(&s.ClockSkewTolerance, "clock-skew-tolerance", defaultClockSkewTolerance, ""+
+		"How far behind the clock may be. Certificates are renewed, and are considered to have expired, this much earlier "+
+		"than they otherwise would be. Must be a whole number of seconds.")
+	fs.DurationSliceVar(&s.ExpiryAlertThresholds, "expiry-alert-thresholds", defaultExpiryAlertThresholds, ""+
+		"The times before a certificate expires at which Certificates are given the ExpiringSoon condition, and a warning "+
+		"event is fired for Certificates and for TLS Secrets not managed by cert-manager. The smallest threshold crossed "+
+		"is exposed by the certmanager_expiring_soon_threshold_seconds metric. Only used if the "+expiryalerts.ControllerName+
+		" or "+expiryalerts.SecretsControllerName+" controllers are enabled.")
 	fs.StringSliceVar(&s.CopiedAnnotationPrefixes, "copied-annotation-prefixes", defaultCopiedAnnotationPrefixes, "Specify which annotations should/shouldn't be copied"+
 		"from Certificate to CertificateRequest and Order, as well as from CertificateSigningRequest to Order, by passing a list of annotation key prefixes."+
 		"A prefix starting with a dash(-) specifies an annotation that shouldn't be copied. Example: '*,-kubectl.kuberenetes.io/'- all annotations"+
@@ -360,16 +552,50 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 
 	fs.IntVar(&s.MaxConcurrentChallenges, "max-concurrent-challenges", defaultMaxConcurrentChallenges, ""+
 		"The maximum number of challenges that can be scheduled as 'processing' at once.")
+	fs.StringVar(&s.KubeletServingSignerName, "kubelet-serving-signer-name", "", ""+
+		"The signer name of the cert-manager issuer, i.e 'clusterissuers.cert-manager.io/kubelet-ca', which signs "+
+		"kubelet serving CertificateSigningRequests (signer name 'kubernetes.io/kubelet-serving'). If set, kubelet serving "+
+		"CertificateSigningRequests are approved once the requesting node's identity and addresses have been validated, "+
+		"and are signed by the issuer's CertificateSigningRequest controller, which requires the ExperimentalCertificateSigningRequestControllers feature gate. "+
+		"The kube-controller-manager should not also be configured to sign for this signer.")
+	fs.StringVar(&s.OperatorWebhookService, "operator-webhook-service", "", ""+
+		"The namespace/name of the cert-manager webhook Service. If set, the controller keeps cert-manager's "+
+		"CustomResourceDefinitions, webhook configurations and conversion webhooks up to date with the running version, "+
+		"migrating stored resources to the storage version of each CustomResourceDefinition.")
+	fs.DurationVar(&s.OperatorWebhookTimeout, "operator-webhook-timeout", defaultOperatorWebhookTimeout, ""+
+		"The timeout of the admission webhooks configured when --operator-webhook-service is set. "+
+		"Must be between 1s and 30s.")
 	fs.DurationVar(&s.DNS01CheckRetryPeriod, "dns01-check-retry-period", defaultDNS01CheckRetryPeriod, ""+
 		"The duration the controller should wait between a propagation check. Despite the name, this flag is used to configure the wait period for both DNS01 and HTTP01 challenge propagation checks. For DNS01 challenges the propagation check verifies that a TXT record with the challenge token has been created. For HTTP01 challenges the propagation check verifies that the challenge token is served at the challenge URL."+
 		"This should be a valid duration string, for example 180s or 1h")
 
 	fs.StringVar(&s.MetricsListenAddress, "metrics-listen-address", defaultPrometheusMetricsServerAddress, ""+
 		"The host and port that the metrics endpoint should listen on.")
+	fs.StringVar(&s.HealthzListenAddress, "healthz-listen-address", defaultHealthzServerAddress, ""+
+		"The host and port that the /livez and /readyz endpoints should listen on. The readiness check fails if a worker "+
+		"of any controller has been processing the same item for more than "+controller.WorkerStallTimeout.String()+".")
 	fs.BoolVar(&s.EnablePprof, "enable-profiling", cmdutil.DefaultEnableProfiling, ""+
-		"Enable profiling for controller.")
+		"Enable profiling for controller. The profiler also serves a snapshot of the informer cache sizes, queue lengths and goroutines of each controller at /debug/bundle.")
 	fs.StringVar(&s.PprofAddress, "profiler-address", cmdutil.DefaultProfilerAddr,
 		"The host and port that Go profiler should listen on, i.e localhost:6060. Ensure that profiler is not exposed on a public address. Profiler will be served at /debug/pprof.")
+
+	fs.StringVar(&s.OCSPResponderListenAddress, "ocsp-responder-listen-address", "", ""+
+		"The host and port that the OCSP responder should listen on, i.e 0.0.0.0:9404. If set, every replica answers OCSP "+
+		"requests for the certificates issued by CA and SelfSigned issuers, reporting those marked as revoked by a "+
+		"CertificateRevocationRequest as revoked, and the certificaterevocationrequests controller is enabled.")
+	fs.DurationVar(&s.OCSPResponseValidity, "ocsp-response-validity", defaultOCSPResponseValidity, ""+
+		"How long OCSP responses are valid for. Clients may cache responses for this long, so this is the longest a "+
+		"revocation may take to be noticed.")
+
+	fs.StringVar(&s.TracingOTLPEndpoint, "tracing-otlp-endpoint", "", ""+
+		"The host and port of an OpenTelemetry (OTLP gRPC) receiver, i.e otel-collector:4317. If set, spans are exported "+
+		"for each phase of certificate issuance. The trace context is propagated to CertificateRequests, Orders and Challenges "+
+		"in the 'tracing.cert-manager.io/traceparent' annotation.")
+	fs.BoolVar(&s.TracingOTLPInsecure, "tracing-otlp-insecure", false, ""+
+		"If true, spans are exported to the OTLP receiver without TLS.")
+	fs.Float64Var(&s.TracingSampleRatio, "tracing-sample-ratio", defaultTracingSampleRatio, ""+
+		"The fraction of certificate issuances to trace, between 0 and 1. Issuances which continue a trace propagated "+
+		"in a Certificate's annotations follow the sampling decision of that trace.")
 }
 
 func (o *ControllerOptions) Validate() error {
@@ -389,6 +615,62 @@ func (o *ControllerOptions) Validate() error {
 		return fmt.Errorf("invalid value for kube-api-burst: %v must be higher or equal to kube-api-qps: %v", o.KubernetesAPIQPS, o.KubernetesAPIQPS)
 	}
 
+	for _, eventType := range o.EventTypes {
+		if eventType != corev1.EventTypeNormal && eventType != corev1.EventTypeWarning {
+			return fmt.Errorf("invalid value for event-types: %q must be one of %q or %q", eventType, corev1.EventTypeNormal, corev1.EventTypeWarning)
+		}
+	}
+
+	if o.EventRateLimitBurst <= 0 {
+		return fmt.Errorf("invalid value for event-rate-limit-burst: %v must be higher than 0", o.EventRateLimitBurst)
+	}
+
+	if o.EventRateLimitQPS <= 0 {
+		return fmt.Errorf("invalid value for event-rate-limit-qps: %v must be higher than 0", o.EventRateLimitQPS)
+	}
+
+	if _, err := labels.Parse(o.NamespaceSelector); err != nil {
+		return fmt.Errorf("invalid value for namespace-selector: %w", err)
+	}
+
+	if _, err := labels.Parse(o.WatchLabelSelector); err != nil {
+		return fmt.Errorf("invalid value for watch-label-selector: %w", err)
+	}
+
+	if o.CertificateShards < 1 {
+		return fmt.Errorf("invalid value for certificate-shards: %d must be at least 1", o.CertificateShards)
+	}
+
+	if o.CertificateShard >= o.CertificateShards {
+		return fmt.Errorf("invalid value for certificate-shard: %d must be less than certificate-shards (%d)", o.CertificateShard, o.CertificateShards)
+	}
+
+	if o.ClockSource != clocksource.System && o.ClockSource != clocksource.APIServer {
+		return fmt.Errorf("invalid value for clock-source: %q must be one of %q or %q", o.ClockSource, clocksource.System, clocksource.APIServer)
+	}
+
+	if o.ClockSkewTolerance < 0 || o.ClockSkewTolerance%time.Second != 0 {
+		return fmt.Errorf("invalid value for clock-skew-tolerance: %v must be zero or a positive whole number of seconds", o.ClockSkewTolerance)
+	}
+
+	for _, threshold := range o.ExpiryAlertThresholds {
+		if threshold <= 0 {
+			return fmt.Errorf("invalid value for expiry-alert-thresholds: %v must be greater than zero", threshold)
+		}
+	}
+
+	if _, err := o.IssuerPluginAddresses(); err != nil {
+		return fmt.Errorf("invalid value for issuer-plugins: %w", err)
+	}
+
+	if o.OCSPResponseValidity <= 0 {
+		return fmt.Errorf("invalid value for ocsp-response-validity: %v must be greater than zero", o.OCSPResponseValidity)
+	}
+
+	if o.TracingSampleRatio < 0 || o.TracingSampleRatio > 1 {
+		return fmt.Errorf("invalid value for tracing-sample-ratio: %v must be between 0 and 1", o.TracingSampleRatio)
+	}
+
 	for _, server := range append(o.DNS01RecursiveNameservers, o.ACMEHTTP01SolverNameservers...) {
 		// ensure all servers have a port number
 		_, _, err := net.SplitHostPort(server)
@@ -414,6 +696,36 @@ func (o *ControllerOptions) Validate() error {
 		return fmt.Errorf("validation failed for '--controllers': %v", errs)
 	}
 
+	if o.KubeletServingSignerName != "" {
+		ref, ok := csrutil.SignerIssuerRefFromSignerName(o.KubeletServingSignerName)
+		if _, isIssuerKind := csrutil.IssuerKindFromType(ref.Type); !ok || !isIssuerKind || ref.Group != cm.GroupName {
+			return fmt.Errorf("invalid value for kubelet-serving-signer-name: %q is not the signer name of a cert-manager Issuer or ClusterIssuer", o.KubeletServingSignerName)
+		}
+	}
+
+	if o.OperatorWebhookService != "" {
+		if namespace, name, err := cache.SplitMetaNamespaceKey(o.OperatorWebhookService); err != nil || namespace == "" || name == "" {
+			return fmt.Errorf("invalid value for operator-webhook-service: %q must be of the form namespace/name", o.OperatorWebhookService)
+		}
+		if o.OperatorWebhookTimeout < time.Second || o.OperatorWebhookTimeout > 30*time.Second {
+			return fmt.Errorf("invalid value for operator-webhook-timeout: %s must be between 1s and 30s", o.OperatorWebhookTimeout)
+		}
+	}
+
+	if o.Workers <= 0 {
+		return fmt.Errorf("invalid value for workers: %d must be higher than 0", o.Workers)
+	}
+
+	for _, controller := range sets.StringKeySet(o.Controllers).List() {
+		if !allControllersSet.Has(controller) {
+			errs = append(errs, fmt.Errorf("%q is not in the list of known controllers", controller))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed for 'controllers' in the config file: %v", errs)
+	}
+
 	return nil
 }
 
@@ -439,10 +751,62 @@ func (o *ControllerOptions) EnabledControllers() sets.String {
 		enabled = enabled.Insert(experimentalCertificateSigningRequestControllers...)
 	}
 
+	if o.KubeletServingSignerName != "" {
+		enabled = enabled.Insert(csrkubeletservingcontroller.ControllerName)
+	}
+
+	if o.OperatorWebhookService != "" {
+		enabled = enabled.Insert(operatorcontroller.ControllerName)
+	}
+
+	// CRLs only list the certificates of CertificateRevocationRequests
+	// which have been processed.
+	if o.OCSPResponderListenAddress != "" || enabled.Has(crls.ControllerName) {
+		enabled = enabled.Insert(certificaterevocationrequests.ControllerName)
+	}
+
+	if len(o.IssuerPlugins) > 0 {
+		enabled = enabled.Insert(crplugincontroller.CRControllerName)
+	}
+
+	if o.Notifications != nil && len(o.Notifications.Sinks) > 0 {
+		enabled = enabled.Insert(expirynotifications.ControllerName)
+	}
+
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalGatewayAPISupport) {
 		logf.Log.Info("enabling the sig-network Gateway API certificate-shim and HTTP-01 solver")
 		enabled = enabled.Insert(shimgatewaycontroller.ControllerName)
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ExternalSecretStores) {
+		logf.Log.Info("enabling the certificate secret stores controller")
+		enabled = enabled.Insert(secretstores.ControllerName)
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(feature.AdditionalPrivateKeys) {
+		logf.Log.Info("enabling the certificate additional private keys controller")
+		enabled = enabled.Insert(additionalprivatekeys.ControllerName)
+	}
+
 	return enabled
 }
+
+// IssuerPluginAddresses returns the addresses of the issuer plugins keyed
+// by the group and kind of the issuers they sign for.
+func (o *ControllerOptions) IssuerPluginAddresses() (map[schema.GroupKind]string, error) {
+	plugins := make(map[schema.GroupKind]string, len(o.IssuerPlugins))
+	for kindGroup, address := range o.IssuerPlugins {
+		gk := schema.ParseGroupKind(kindGroup)
+		if gk.Kind == "" || gk.Group == "" {
+			return nil, fmt.Errorf("%q must be of the form kind.group", kindGroup)
+		}
+		if gk.Group == cm.GroupName {
+			return nil, fmt.Errorf("%q: the issuers of the cert-manager.io group cannot be signed by plugins", kindGroup)
+		}
+		if address == "" {
+			return nil, fmt.Errorf("%q: the address of the plugin must not be empty", kindGroup)
+		}
+		plugins[gk] = address
+	}
+	return plugins, nil
+}
@@ -17,15 +17,27 @@ limitations under the License.
 package options
 
 import (
+	"reflect"
 	"testing"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
+
+	controllerconfig "github.com/cert-manager/cert-manager/internal/apis/config/controller"
+	crplugincontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/plugin"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterevocationrequests"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/expirynotifications"
+	csrkubeletservingcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/kubeletserving"
+	"github.com/cert-manager/cert-manager/pkg/controller/crls"
 )
 
 func TestEnabledControllers(t *testing.T) {
 	tests := map[string]struct {
-		controllers []string
-		expEnabled  sets.String
+		controllers              []string
+		kubeletServingSignerName string
+		issuerPlugins            map[string]string
+		notifications            *controllerconfig.NotificationsConfiguration
+		expEnabled               sets.String
 	}{
 		"if no controllers enabled, return empty": {
 			controllers: []string{},
@@ -47,12 +59,41 @@ func TestEnabledControllers(t *testing.T) {
 			controllers: []string{"*", "-clusterissuers", "-issuers"},
 			expEnabled:  sets.NewString(defaultEnabledControllers...).Delete("clusterissuers", "issuers"),
 		},
+		"if a kubelet serving signer is configured, enable the kubelet serving approver": {
+			controllers:              []string{"foo"},
+			kubeletServingSignerName: "clusterissuers.cert-manager.io/kubelet-ca",
+			expEnabled:               sets.NewString("foo", csrkubeletservingcontroller.ControllerName),
+		},
+		"if issuer plugins are configured, enable the issuer plugins controller": {
+			controllers:   []string{"foo"},
+			issuerPlugins: map[string]string{"ExampleIssuer.example.com": "unix:///plugins/example.sock"},
+			expEnabled:    sets.NewString("foo", crplugincontroller.CRControllerName),
+		},
+		"if the crls controller is enabled, enable the certificaterevocationrequests controller": {
+			controllers: []string{"foo", crls.ControllerName},
+			expEnabled:  sets.NewString("foo", crls.ControllerName, certificaterevocationrequests.ControllerName),
+		},
+		"if notification sinks are configured, enable the expiry notifications controller": {
+			controllers: []string{"foo"},
+			notifications: &controllerconfig.NotificationsConfiguration{
+				Sinks: []controllerconfig.NotificationSink{{Name: "hook", URL: "https://example.com"}},
+			},
+			expEnabled: sets.NewString("foo", expirynotifications.ControllerName),
+		},
+		"if no notification sinks are configured, do not enable the expiry notifications controller": {
+			controllers:   []string{"foo"},
+			notifications: &controllerconfig.NotificationsConfiguration{},
+			expEnabled:    sets.NewString("foo"),
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := ControllerOptions{
-				controllers: test.controllers,
+				controllers:              test.controllers,
+				KubeletServingSignerName: test.kubeletServingSignerName,
+				IssuerPlugins:            test.issuerPlugins,
+				Notifications:            test.notifications,
 			}
 
 			got := o.EnabledControllers()
@@ -63,3 +104,51 @@ func TestEnabledControllers(t *testing.T) {
 		})
 	}
 }
+
+func TestIssuerPluginAddresses(t *testing.T) {
+	tests := map[string]struct {
+		issuerPlugins map[string]string
+		expPlugins    map[schema.GroupKind]string
+		expErr        bool
+	}{
+		"no plugins": {
+			expPlugins: map[schema.GroupKind]string{},
+		},
+		"plugins keyed by kind and group": {
+			issuerPlugins: map[string]string{
+				"ExampleIssuer.example.com":       "unix:///plugins/example.sock",
+				"OtherIssuer.issuers.example.org": "localhost:9500",
+			},
+			expPlugins: map[schema.GroupKind]string{
+				{Group: "example.com", Kind: "ExampleIssuer"}:       "unix:///plugins/example.sock",
+				{Group: "issuers.example.org", Kind: "OtherIssuer"}: "localhost:9500",
+			},
+		},
+		"missing group": {
+			issuerPlugins: map[string]string{"ExampleIssuer": "unix:///plugins/example.sock"},
+			expErr:        true,
+		},
+		"cert-manager issuers cannot be signed by plugins": {
+			issuerPlugins: map[string]string{"Issuer.cert-manager.io": "unix:///plugins/example.sock"},
+			expErr:        true,
+		},
+		"missing address": {
+			issuerPlugins: map[string]string{"ExampleIssuer.example.com": ""},
+			expErr:        true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := ControllerOptions{IssuerPlugins: test.issuerPlugins}
+
+			got, err := o.IssuerPluginAddresses()
+			if test.expErr != (err != nil) {
+				t.Fatalf("expected error: %t, got: %v", test.expErr, err)
+			}
+			if !test.expErr && !reflect.DeepEqual(got, test.expPlugins) {
+				t.Errorf("got unexpected plugins, exp=%v got=%v", test.expPlugins, got)
+			}
+		})
+	}
+}
@@ -18,21 +18,28 @@ package app
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"github.com/cert-manager/cert-manager/cmd/controller/app/options"
+	config "github.com/cert-manager/cert-manager/internal/apis/config/controller"
+	configvalidation "github.com/cert-manager/cert-manager/internal/apis/config/controller/validation"
 	_ "github.com/cert-manager/cert-manager/pkg/controller/acmechallenges"
 	_ "github.com/cert-manager/cert-manager/pkg/controller/acmeorders"
 	_ "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/gateways"
 	_ "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/ingresses"
 	_ "github.com/cert-manager/cert-manager/pkg/controller/certificates/trigger"
 	_ "github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
+	"github.com/cert-manager/cert-manager/pkg/controller/configfile"
 	_ "github.com/cert-manager/cert-manager/pkg/controller/issuers"
 	_ "github.com/cert-manager/cert-manager/pkg/issuer/acme"
 	_ "github.com/cert-manager/cert-manager/pkg/issuer/ca"
+	_ "github.com/cert-manager/cert-manager/pkg/issuer/externalsigner"
+	_ "github.com/cert-manager/cert-manager/pkg/issuer/hub"
 	_ "github.com/cert-manager/cert-manager/pkg/issuer/selfsigned"
 	_ "github.com/cert-manager/cert-manager/pkg/issuer/vault"
 	_ "github.com/cert-manager/cert-manager/pkg/issuer/venafi"
@@ -68,6 +75,14 @@ It will ensure certificates are valid and up to date periodically, and attempt
 to renew certificates at an appropriate time before expiry.`,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if configFile := o.ControllerOptions.ConfigFile; configFile != "" {
+				cfg, err := loadConfigFile(configFile)
+				if err != nil {
+					return err
+				}
+				controllerConfigFlagPrecedence(o.ControllerOptions, cfg, cmd.Flags())
+			}
+
 			if err := o.Validate(args); err != nil {
 				return fmt.Errorf("error validating options: %s", err)
 			}
@@ -99,3 +114,40 @@ func (o CertManagerControllerOptions) Validate(args []string) error {
 func (o CertManagerControllerOptions) RunCertManagerController(stopCh <-chan struct{}) error {
 	return Run(o.ControllerOptions, stopCh)
 }
+
+func loadConfigFile(name string) (*config.ControllerConfiguration, error) {
+	const errFmt = "failed to load controller config file %s, error %v"
+	// compute absolute path based on current working dir
+	controllerConfigFile, err := filepath.Abs(name)
+	if err != nil {
+		return nil, fmt.Errorf(errFmt, name, err)
+	}
+	loader, err := configfile.NewFSLoader(configfile.NewRealFS(), controllerConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf(errFmt, name, err)
+	}
+	cfg, err := loader.Load()
+	if err != nil {
+		return nil, fmt.Errorf(errFmt, name, err)
+	}
+	if err := configvalidation.ValidateControllerConfiguration(cfg); err != nil {
+		return nil, fmt.Errorf(errFmt, name, err)
+	}
+	return cfg, nil
+}
+
+// controllerConfigFlagPrecedence copies the values of the config file into
+// opts, except for the values of flags which have been set explicitly.
+func controllerConfigFlagPrecedence(opts *options.ControllerOptions, cfg *config.ControllerConfiguration, fs *pflag.FlagSet) {
+	if cfg.KubernetesAPIQPS != nil && !fs.Changed("kube-api-qps") {
+		opts.KubernetesAPIQPS = *cfg.KubernetesAPIQPS
+	}
+	if cfg.KubernetesAPIBurst != nil && !fs.Changed("kube-api-burst") {
+		opts.KubernetesAPIBurst = *cfg.KubernetesAPIBurst
+	}
+	if cfg.Workers != nil {
+		opts.Workers = *cfg.Workers
+	}
+	opts.Controllers = cfg.Controllers
+	opts.Notifications = cfg.Notifications
+}
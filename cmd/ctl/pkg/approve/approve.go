@@ -30,6 +30,7 @@ import (
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/policy"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -45,6 +46,9 @@ var (
 
 # Approve a CertificateRequest giving a custom reason and message
 {{.BuildName}} approve my-cr --reason "ManualApproval" --reason "Approved by PKI department"
+
+# Explain which CertificateRequestPolicy would match 'my-cr', without approving it
+{{.BuildName}} approve my-cr --against-policy
 `)))
 )
 
@@ -56,6 +60,10 @@ type Options struct {
 	// Message is the string that will be set on the Message field of the
 	// Approved condition.
 	Message string
+	// AgainstPolicy will evaluate the CertificateRequest against the
+	// CertificateRequestPolicies in the cluster and explain the result,
+	// instead of updating the CertificateRequest.
+	AgainstPolicy bool
 
 	genericclioptions.IOStreams
 	*factory.Factory
@@ -87,6 +95,8 @@ func NewCmdApprove(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 		"The reason to give as to what approved this CertificateRequest.")
 	cmd.Flags().StringVar(&o.Message, "message", fmt.Sprintf("manually approved by %q", build.Name()),
 		"The message to give as to why this CertificateRequest was approved.")
+	cmd.Flags().BoolVar(&o.AgainstPolicy, "against-policy", false,
+		"Dry-run: evaluate the CertificateRequest against all CertificateRequestPolicies and explain which would match, without changing it.")
 
 	o.Factory = factory.New(ctx, cmd)
 
@@ -120,6 +130,10 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		return err
 	}
 
+	if o.AgainstPolicy {
+		return policy.Explain(ctx, o.RESTConfig, o.KubeClient, o.Out, cr)
+	}
+
 	if apiutil.CertificateRequestIsApproved(cr) {
 		return errors.New("CertificateRequest is already approved")
 	}
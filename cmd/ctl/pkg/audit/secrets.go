@@ -0,0 +1,297 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	k8sclock "k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/output"
+	statusutil "github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+var clock k8sclock.Clock = k8sclock.RealClock{}
+
+var (
+	secretsLong = templates.LongDesc(i18n.T(`
+Scan all kubernetes.io/tls Secrets, whether or not they are managed by cert-manager, and
+report certificates that are expired or close to expiry, use weak keys or SHA-1 signatures,
+and Secrets which are no longer referenced by any Certificate or Ingress.`))
+
+	secretsExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Audit all TLS Secrets in the current namespace
+{{.BuildName}} x audit secrets
+
+# Audit all TLS Secrets in all namespaces, flagging certificates expiring within 14 days
+{{.BuildName}} x audit secrets --all-namespaces --expiring-within 336h
+
+# Only show Secrets which have at least one finding
+{{.BuildName}} x audit secrets -A --only-findings
+
+# Print the report as JSON. The command exits with code 3 if there are any findings.
+{{.BuildName}} x audit secrets -A -o json
+`)))
+)
+
+// SecretsOptions is a struct to support the audit secrets command
+type SecretsOptions struct {
+	// AllNamespaces will audit Secrets in all namespaces.
+	AllNamespaces bool
+	// ExpiringWithin flags certificates which expire within this duration.
+	ExpiringWithin time.Duration
+	// MinRSAKeySize is the smallest RSA key size which is not flagged as weak.
+	MinRSAKeySize int
+	// MinECDSAKeySize is the smallest ECDSA key size which is not flagged as
+	// weak.
+	MinECDSAKeySize int
+	// OnlyFindings will only print Secrets which have at least one finding.
+	OnlyFindings bool
+	// Output is the target output format. This may be of value "", "json"
+	// or "yaml".
+	Output string
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// SecretReport is the result of auditing a single Secret.
+type SecretReport struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Managed is true if the Secret is managed by a cert-manager Certificate.
+	Managed bool `json:"managed"`
+	// NotAfter is the expiry of the leaf certificate, if it could be parsed.
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+	// Findings is the list of problems found with the Secret.
+	Findings []string `json:"findings,omitempty"`
+}
+
+// NewCmdAuditSecrets returns a cobra command for audit secrets
+func NewCmdAuditSecrets(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &SecretsOptions{IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:     "secrets",
+		Aliases: []string{"secret"},
+		Short:   "Find expiring, weak or orphaned TLS Secrets",
+		Long:    secretsLong,
+		Example: secretsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false,
+		"Audit TLS Secrets in all namespaces")
+	cmd.Flags().DurationVar(&o.ExpiringWithin, "expiring-within", 30*24*time.Hour,
+		"Flag certificates which expire within this duration, must include unit, e.g. 720h")
+	cmd.Flags().IntVar(&o.MinRSAKeySize, "min-rsa-key-size", pki.MinRSAKeySize,
+		"RSA keys smaller than this size are flagged as weak")
+	cmd.Flags().IntVar(&o.MinECDSAKeySize, "min-ecdsa-key-size", pki.ECCurve256,
+		"ECDSA keys smaller than this size are flagged as weak")
+	cmd.Flags().BoolVar(&o.OnlyFindings, "only-findings", false,
+		"Only print Secrets which have at least one finding")
+	output.AddFlag(cmd, &o.Output)
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *SecretsOptions) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("audit secrets does not accept arguments")
+	}
+	if o.ExpiringWithin < 0 {
+		return errors.New("--expiring-within must not be negative")
+	}
+	return output.Validate(o.Output)
+}
+
+// Run executes the audit secrets command
+func (o *SecretsOptions) Run(ctx context.Context) error {
+	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+
+	secrets, err := o.KubeClient.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeTLS)).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Secrets: %w", err)
+	}
+
+	referenced, err := o.referencedSecrets(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	reports := []SecretReport{}
+	findings := 0
+	for i := range secrets.Items {
+		report := o.auditSecret(&secrets.Items[i], referenced, clock.Now())
+		findings += len(report.Findings)
+		if o.OnlyFindings && len(report.Findings) == 0 {
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Namespace != reports[j].Namespace {
+			return reports[i].Namespace < reports[j].Namespace
+		}
+		return reports[i].Name < reports[j].Name
+	})
+
+	if o.Output == output.FormatText {
+		o.printReports(reports)
+	} else if err := output.Print(o.Out, o.Output, reports); err != nil {
+		return err
+	}
+
+	if findings > 0 {
+		return output.ExitError(output.ExitCodeFindings, "found %d issues in %d TLS Secrets", findings, len(secrets.Items))
+	}
+
+	return nil
+}
+
+// referencedSecrets returns the set of "namespace/name" keys of all Secrets
+// referenced by a Certificate or an Ingress.
+func (o *SecretsOptions) referencedSecrets(ctx context.Context, namespace string) (map[string]bool, error) {
+	referenced := make(map[string]bool)
+
+	crts, err := o.CMClient.CertmanagerV1().Certificates(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Certificates: %w", err)
+	}
+	for _, crt := range crts.Items {
+		referenced[crt.Namespace+"/"+crt.Spec.SecretName] = true
+	}
+
+	ingresses, err := o.KubeClient.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Ingresses: %w", err)
+	}
+	for _, ing := range ingresses.Items {
+		for _, tls := range ing.Spec.TLS {
+			referenced[ing.Namespace+"/"+tls.SecretName] = true
+		}
+	}
+
+	return referenced, nil
+}
+
+// auditSecret inspects the leaf certificate in the Secret and reports any
+// findings.
+func (o *SecretsOptions) auditSecret(secret *corev1.Secret, referenced map[string]bool, now time.Time) SecretReport {
+	report := SecretReport{
+		Namespace: secret.Namespace,
+		Name:      secret.Name,
+		Managed:   len(secret.Annotations[cmapi.CertificateNameKey]) > 0,
+	}
+
+	if !referenced[secret.Namespace+"/"+secret.Name] {
+		report.Findings = append(report.Findings, "not referenced by any Certificate or Ingress")
+	}
+
+	certPEM := secret.Data[corev1.TLSCertKey]
+	if len(certPEM) == 0 {
+		report.Findings = append(report.Findings, "tls.crt is empty")
+		return report
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		report.Findings = append(report.Findings, fmt.Sprintf("tls.crt cannot be parsed: %v", err))
+		return report
+	}
+
+	report.NotAfter = &cert.NotAfter
+	switch remaining := cert.NotAfter.Sub(now); {
+	case remaining <= 0:
+		report.Findings = append(report.Findings, fmt.Sprintf("expired %s ago", duration.HumanDuration(-remaining)))
+	case remaining < o.ExpiringWithin:
+		report.Findings = append(report.Findings, fmt.Sprintf("expires in %s", duration.HumanDuration(remaining)))
+	}
+
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if size := pub.N.BitLen(); size < o.MinRSAKeySize {
+			report.Findings = append(report.Findings, fmt.Sprintf("weak RSA key of size %d", size))
+		}
+	case *ecdsa.PublicKey:
+		if size := pub.Curve.Params().BitSize; size < o.MinECDSAKeySize {
+			report.Findings = append(report.Findings, fmt.Sprintf("weak ECDSA key of size %d", size))
+		}
+	}
+
+	switch cert.SignatureAlgorithm {
+	case x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1, x509.MD5WithRSA, x509.MD2WithRSA:
+		report.Findings = append(report.Findings, fmt.Sprintf("insecure signature algorithm %s", cert.SignatureAlgorithm))
+	}
+
+	return report
+}
+
+func (o *SecretsOptions) printReports(reports []SecretReport) {
+	if len(reports) == 0 {
+		fmt.Fprintln(o.Out, "No TLS Secrets found")
+		return
+	}
+
+	tw := statusutil.NewTabWriter(o.Out)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tMANAGED\tNOT AFTER\tFINDINGS")
+	for _, r := range reports {
+		notAfter := "<unknown>"
+		if r.NotAfter != nil {
+			notAfter = r.NotAfter.Format(time.RFC3339)
+		}
+		findings := "<none>"
+		if len(r.Findings) > 0 {
+			findings = strings.Join(r.Findings, "; ")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%t\t%s\t%s\n", r.Namespace, r.Name, r.Managed, notAfter, findings)
+	}
+	tw.Flush()
+}
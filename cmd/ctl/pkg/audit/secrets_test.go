@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func selfSignedPEM(t *testing.T, key crypto.Signer, notAfter time.Time) []byte {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    notAfter.Add(-time.Hour * 24 * 365),
+		NotAfter:     notAfter,
+	}
+	certPEM, _, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM
+}
+
+func TestAuditSecret(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	strongKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := func(name string, annotations map[string]string, certPEM []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: annotations},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: certPEM},
+		}
+	}
+
+	referenced := map[string]bool{"default/managed": true, "default/expired": true, "default/weak": true}
+	managed := map[string]string{cmapi.CertificateNameKey: "my-crt"}
+
+	tests := map[string]struct {
+		secret      *corev1.Secret
+		expManaged  bool
+		expFindings []string
+	}{
+		"healthy managed secret": {
+			secret:     secret("managed", managed, selfSignedPEM(t, strongKey, now.Add(90*24*time.Hour))),
+			expManaged: true,
+		},
+		"expired secret": {
+			secret:      secret("expired", nil, selfSignedPEM(t, strongKey, now.Add(-2*time.Hour))),
+			expFindings: []string{"expired 120m ago"},
+		},
+		"weak key expiring soon": {
+			secret:      secret("weak", nil, selfSignedPEM(t, weakKey, now.Add(48*time.Hour))),
+			expFindings: []string{"expires in 2d", "weak RSA key of size 1024"},
+		},
+		"orphaned unparseable secret": {
+			secret: secret("orphan", nil, []byte("not a certificate")),
+			expFindings: []string{
+				"not referenced by any Certificate or Ingress",
+				"tls.crt cannot be parsed: error decoding certificate PEM block",
+			},
+		},
+	}
+
+	o := &SecretsOptions{
+		ExpiringWithin:  30 * 24 * time.Hour,
+		MinRSAKeySize:   pki.MinRSAKeySize,
+		MinECDSAKeySize: pki.ECCurve256,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			report := o.auditSecret(test.secret, referenced, now)
+			assert.Equal(t, test.expManaged, report.Managed)
+			assert.Equal(t, test.expFindings, report.Findings)
+		})
+	}
+}
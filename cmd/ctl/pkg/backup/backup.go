@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func NewCmdBackup(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "backup",
+		Short: "Export and restore Certificates and their Secrets",
+		Long: `Export Certificates, the Secrets issued for them and the ACME account keys of their issuers
+into an encrypted bundle, and restore the bundle into a cluster without re-issuing the certificates.`,
+	}
+
+	cmds.AddCommand(NewCmdExport(ctx, ioStreams))
+	cmds.AddCommand(NewCmdRestore(ctx, ioStreams))
+
+	return cmds
+}
+
+// readPassphrase reads the passphrase from the given file, ignoring a
+// trailing newline.
+func readPassphrase(path string) ([]byte, error) {
+	if path == "" {
+		return nil, errors.New("--passphrase-file must be specified")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase file %q is empty", path)
+	}
+	return []byte(passphrase), nil
+}
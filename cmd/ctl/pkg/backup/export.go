@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/pkg/ctl/backup"
+)
+
+var (
+	exportLong = templates.LongDesc(i18n.T(`
+Export Certificates, the Secrets issued for them and the ACME account private keys of the
+Issuers and ClusterIssuers they reference into a bundle encrypted with a passphrase.
+
+The bundle contains private keys in plain text once decrypted, so the passphrase must be
+kept as safe as the Secrets themselves. Issuers and ClusterIssuers are not exported and
+must be restored separately, e.g. from version control.`))
+
+	exportExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Export all Certificates in all namespaces
+{{.BuildName}} x backup export -A --passphrase-file passphrase.txt -f backup.json
+
+# Export Certificates with the label 'app=my-service' in the current namespace
+{{.BuildName}} x backup export -l app=my-service --passphrase-file passphrase.txt -f backup.json
+`)))
+)
+
+// ExportOptions is a struct to support the backup export command
+type ExportOptions struct {
+	// Filename is the file the bundle is written to.
+	Filename string
+	// PassphraseFile is the file containing the passphrase the bundle is
+	// encrypted with.
+	PassphraseFile string
+	// LabelSelector restricts the export to matching Certificates.
+	LabelSelector string
+	// AllNamespaces exports Certificates in all namespaces.
+	AllNamespaces bool
+	// ClusterResourceNamespace is the namespace ClusterIssuers store their
+	// ACME account private keys in.
+	ClusterResourceNamespace string
+
+	passphrase []byte
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewCmdExport returns a cobra command for backup export
+func NewCmdExport(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &ExportOptions{IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Export Certificates and their Secrets into an encrypted bundle",
+		Long:    exportLong,
+		Example: exportExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", o.Filename, "Path to write the encrypted bundle to")
+	cmd.Flags().StringVar(&o.PassphraseFile, "passphrase-file", o.PassphraseFile, "Path to a file containing the passphrase to encrypt the bundle with")
+	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If present, export Certificates across namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	cmd.Flags().StringVar(&o.ClusterResourceNamespace, "cluster-resource-namespace", "cert-manager", "Namespace ClusterIssuers store their ACME account private keys in")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *ExportOptions) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("export does not accept arguments, use --selector to select Certificates")
+	}
+	if o.Filename == "" {
+		return errors.New("--filename must be specified")
+	}
+
+	var err error
+	o.passphrase, err = readPassphrase(o.PassphraseFile)
+	return err
+}
+
+// Run executes backup export command
+func (o *ExportOptions) Run(ctx context.Context) error {
+	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+
+	bundle, err := backup.Export(ctx, o.KubeClient, o.CMClient, backup.ExportOptions{
+		Namespace:                namespace,
+		LabelSelector:            o.LabelSelector,
+		ClusterResourceNamespace: o.ClusterResourceNamespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := backup.Encrypt(bundle, o.passphrase)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(o.Filename, data, 0600); err != nil {
+		return fmt.Errorf("failed to write backup bundle: %w", err)
+	}
+
+	fmt.Fprintf(o.Out, "Exported %d Certificates and %d Secrets to %s\n", len(bundle.Certificates), len(bundle.Secrets), o.Filename)
+	return nil
+}
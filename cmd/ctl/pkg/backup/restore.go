@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/pkg/ctl/backup"
+)
+
+var (
+	restoreLong = templates.LongDesc(i18n.T(`
+Restore the Certificates and Secrets of a bundle created by 'backup export'.
+
+Secrets are restored before Certificates, so cert-manager keeps the restored certificates
+until they are due for renewal instead of requesting new ones from the issuer. The
+revision and renewal time of each Certificate are restored to its status. Resources which
+already exist in the cluster are left unchanged.
+
+The Issuers and ClusterIssuers referenced by the Certificates should be restored before the
+bundle. ACME issuers reuse the restored account private keys, so no new ACME accounts are
+registered.`))
+
+	restoreExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Restore a bundle into the current cluster
+{{.BuildName}} x backup restore --passphrase-file passphrase.txt -f backup.json
+
+# Show the contents of a bundle without restoring it
+{{.BuildName}} x backup restore --passphrase-file passphrase.txt -f backup.json --dry-run
+`)))
+)
+
+// RestoreOptions is a struct to support the backup restore command
+type RestoreOptions struct {
+	// Filename is the file the bundle is read from.
+	Filename string
+	// PassphraseFile is the file containing the passphrase the bundle was
+	// encrypted with.
+	PassphraseFile string
+	// DryRun only prints the resources in the bundle.
+	DryRun bool
+
+	passphrase []byte
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewCmdRestore returns a cobra command for backup restore
+func NewCmdRestore(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &RestoreOptions{IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:     "restore",
+		Short:   "Restore Certificates and their Secrets from an encrypted bundle",
+		Long:    restoreLong,
+		Example: restoreExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", o.Filename, "Path to the encrypted bundle to restore")
+	cmd.Flags().StringVar(&o.PassphraseFile, "passphrase-file", o.PassphraseFile, "Path to a file containing the passphrase the bundle was encrypted with")
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", o.DryRun, "If true, only print the resources which would be restored")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *RestoreOptions) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("restore does not accept arguments, use --filename to specify the bundle")
+	}
+	if o.Filename == "" {
+		return errors.New("--filename must be specified")
+	}
+
+	var err error
+	o.passphrase, err = readPassphrase(o.PassphraseFile)
+	return err
+}
+
+// Run executes backup restore command
+func (o *RestoreOptions) Run(ctx context.Context) error {
+	data, err := os.ReadFile(o.Filename)
+	if err != nil {
+		return fmt.Errorf("failed to read backup bundle: %w", err)
+	}
+	bundle, err := backup.Decrypt(data, o.passphrase)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Bundle exported at %s contains %d Certificates and %d Secrets\n",
+		bundle.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"), len(bundle.Certificates), len(bundle.Secrets))
+	if o.DryRun {
+		for _, secret := range bundle.Secrets {
+			fmt.Fprintf(o.Out, "  Secret %s/%s\n", secret.Namespace, secret.Name)
+		}
+		for _, crt := range bundle.Certificates {
+			fmt.Fprintf(o.Out, "  Certificate %s/%s\n", crt.Namespace, crt.Name)
+		}
+		return nil
+	}
+
+	return backup.Restore(ctx, o.KubeClient, o.CMClient, bundle, o.Out)
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/ctl"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Create a cert-manager Certificate resource from a manifest file.
+
+With --dry-run=server nothing is persisted. Instead, the Certificate and the
+CertificateRequest that would be created for it are submitted to the API server
+as dry-run requests so that they are checked by the cert-manager webhook, the
+referenced issuer is checked to be Ready, and the CertificateRequest is evaluated
+against any approver-policy CertificateRequestPolicies. The command then reports
+whether issuance would likely succeed, and exits with code 2 if it would not.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Create a Certificate from a manifest file
+{{.BuildName}} create certificate -f my-certificate.yaml
+
+# Check whether a Certificate would likely be issued, without creating anything
+{{.BuildName}} create certificate -f my-certificate.yaml --dry-run=server
+`)))
+)
+
+var (
+	// Dedicated scheme used by the ctl tool that has the internal cert-manager types,
+	// and their conversion functions registered
+	scheme = ctl.Scheme
+)
+
+// Options is a struct to support create certificate command
+type Options struct {
+	// Path to a file containing the Certificate resource to create
+	// Required
+	InputFilename string
+	// DryRunStrategy is the value of the --dry-run flag. Only none and server
+	// are supported.
+	DryRunStrategy cmdutil.DryRunStrategy
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdCreateCertificate returns a cobra command for create Certificate
+func NewCmdCreateCertificate(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "certificate",
+		Aliases: []string{"cert"},
+		Short:   "Create a cert-manager Certificate resource, or simulate its issuance with --dry-run=server",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			o.DryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd)
+			cmdutil.CheckErr(err)
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.Flags().StringVarP(&o.InputFilename, "filename", "f", o.InputFilename,
+		"Path to a file containing the Certificate resource to create")
+	cmdutil.AddDryRunFlag(cmd)
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("create certificate does not accept arguments, the Certificate name is read from the manifest file")
+	}
+	if o.InputFilename == "" {
+		return errors.New("the path to a YAML manifest of a Certificate resource must be specified using --filename")
+	}
+	if o.DryRunStrategy == cmdutil.DryRunClient {
+		return errors.New("--dry-run=client is not supported, use --dry-run=server to simulate issuance")
+	}
+	return nil
+}
+
+// Run executes create certificate command
+func (o *Options) Run(ctx context.Context) error {
+	crt, err := o.readCertificate()
+	if err != nil {
+		return err
+	}
+
+	if crt.Namespace == "" {
+		crt.Namespace = o.Namespace
+	}
+
+	if o.DryRunStrategy == cmdutil.DryRunServer {
+		return o.simulate(ctx, crt)
+	}
+
+	crt, err = o.CMClient.CertmanagerV1().Certificates(crt.Namespace).Create(ctx, crt, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("error creating Certificate: %w", err)
+	}
+	fmt.Fprintf(o.Out, "Certificate %s has been created in namespace %s\n", crt.Name, crt.Namespace)
+
+	return nil
+}
+
+// readCertificate reads and decodes the single Certificate in the input file.
+func (o *Options) readCertificate() (*cmapi.Certificate, error) {
+	builder := new(resource.Builder)
+
+	// Read file as internal API version
+	r := builder.
+		WithScheme(scheme, schema.GroupVersion{Group: cmapi.SchemeGroupVersion.Group, Version: runtime.APIVersionInternal}).
+		LocalParam(true).ContinueOnError().
+		NamespaceParam(o.Namespace).DefaultNamespace().
+		FilenameParam(o.EnforceNamespace, &resource.FilenameOptions{Filenames: []string{o.InputFilename}}).Flatten().Do()
+
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+
+	singleItemImplied := false
+	infos, err := r.IntoSingleItemImplied(&singleItemImplied).Infos()
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure only one object per command
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("no objects found in manifest file %q. Expected one Certificate object", o.InputFilename)
+	}
+	if len(infos) > 1 {
+		return nil, fmt.Errorf("multiple objects found in manifest file %q. Expected only one Certificate object", o.InputFilename)
+	}
+
+	// Convert to v1 because that version is needed for functions that follow
+	crtObj, err := scheme.ConvertToVersion(infos[0].Object, cmapi.SchemeGroupVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object into version v1: %w", err)
+	}
+
+	crt, ok := crtObj.(*cmapi.Certificate)
+	if !ok {
+		return nil, errors.New("decoded object is not a v1 Certificate")
+	}
+
+	return crt.DeepCopy(), nil
+}
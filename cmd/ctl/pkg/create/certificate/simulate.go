@@ -0,0 +1,279 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/output"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/policy"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// checkResult is the outcome of a single simulation check.
+type checkResult string
+
+const (
+	checkPassed  checkResult = "PASS"
+	checkFailed  checkResult = "FAIL"
+	checkSkipped checkResult = "SKIP"
+)
+
+// check is a single step of the issuance simulation.
+type check struct {
+	Name    string
+	Result  checkResult
+	Message string
+}
+
+// simulate performs a server side dry run of the issuance of the
+// Certificate. No objects are persisted.
+func (o *Options) simulate(ctx context.Context, crt *cmapi.Certificate) error {
+	var checks []check
+
+	checks = append(checks, o.checkCertificateAdmission(ctx, crt))
+	checks = append(checks, checkIssuer(ctx, o.CMClient, crt))
+
+	cr, err := buildCertificateRequest(crt)
+	if err != nil {
+		checks = append(checks, check{Name: "CertificateRequest admission", Result: checkFailed,
+			Message: fmt.Sprintf("failed to build CertificateRequest: %v", err)})
+	} else {
+		checks = append(checks, o.checkCertificateRequestAdmission(ctx, cr))
+		checks = append(checks, o.checkApprovalPolicy(ctx, cr))
+	}
+
+	if !printChecks(o.Out, crt, checks) {
+		return output.ExitError(output.ExitCodeNotReady, "issuance of Certificate %s/%s would likely not succeed", crt.Namespace, crt.Name)
+	}
+
+	return nil
+}
+
+// checkCertificateAdmission submits the Certificate as a dry run so that it is
+// validated by the cert-manager webhook.
+func (o *Options) checkCertificateAdmission(ctx context.Context, crt *cmapi.Certificate) check {
+	c := check{Name: "Certificate admission"}
+	_, err := o.CMClient.CertmanagerV1().Certificates(crt.Namespace).Create(ctx, crt, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+	switch {
+	case apierrors.IsAlreadyExists(err):
+		c.Result, c.Message = checkFailed, fmt.Sprintf("Certificate %s/%s already exists", crt.Namespace, crt.Name)
+	case err != nil:
+		c.Result, c.Message = checkFailed, err.Error()
+	default:
+		c.Result, c.Message = checkPassed, "Certificate was accepted by the API server"
+	}
+	return c
+}
+
+// checkIssuer checks that the Issuer or ClusterIssuer referenced by the
+// Certificate exists and is Ready. Issuers of external API groups are skipped
+// since their readiness cannot be determined generically.
+func checkIssuer(ctx context.Context, client cmclient.Interface, crt *cmapi.Certificate) check {
+	ref := crt.Spec.IssuerRef
+	c := check{Name: "Issuer readiness"}
+
+	if ref.Group != "" && ref.Group != "cert-manager.io" {
+		c.Result, c.Message = checkSkipped, fmt.Sprintf("external issuer %s of group %s cannot be checked", ref.Name, ref.Group)
+		return c
+	}
+
+	var issuer cmapi.GenericIssuer
+	var err error
+	kind := apiutil.IssuerKind(ref)
+	switch kind {
+	case cmapi.IssuerKind:
+		issuer, err = client.CertmanagerV1().Issuers(crt.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case cmapi.ClusterIssuerKind:
+		issuer, err = client.CertmanagerV1().ClusterIssuers().Get(ctx, ref.Name, metav1.GetOptions{})
+	default:
+		c.Result, c.Message = checkFailed, fmt.Sprintf("unknown issuer kind %q", kind)
+		return c
+	}
+	if err != nil {
+		c.Result, c.Message = checkFailed, fmt.Sprintf("error when getting %s %q: %v", kind, ref.Name, err)
+		return c
+	}
+
+	if apiutil.IssuerHasCondition(issuer, cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		c.Result, c.Message = checkPassed, fmt.Sprintf("%s %q is Ready", kind, ref.Name)
+		return c
+	}
+
+	c.Result, c.Message = checkFailed, fmt.Sprintf("%s %q is not Ready", kind, ref.Name)
+	for _, cond := range issuer.GetStatus().Conditions {
+		if cond.Type == cmapi.IssuerConditionReady {
+			c.Message += fmt.Sprintf(": %s: %s", cond.Reason, cond.Message)
+		}
+	}
+	return c
+}
+
+// checkCertificateRequestAdmission submits the CertificateRequest which would
+// be created for the Certificate as a dry run, so that it is validated by the
+// cert-manager webhook and any other admission webhooks.
+func (o *Options) checkCertificateRequestAdmission(ctx context.Context, cr *cmapi.CertificateRequest) check {
+	c := check{Name: "CertificateRequest admission"}
+	_, err := o.CMClient.CertmanagerV1().CertificateRequests(cr.Namespace).Create(ctx, cr, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+	if err != nil {
+		c.Result, c.Message = checkFailed, err.Error()
+		return c
+	}
+	c.Result, c.Message = checkPassed, "CertificateRequest was accepted by the API server"
+	return c
+}
+
+// checkApprovalPolicy evaluates the CertificateRequest against the
+// approver-policy CertificateRequestPolicies in the cluster, if it is
+// installed.
+func (o *Options) checkApprovalPolicy(ctx context.Context, cr *cmapi.CertificateRequest) check {
+	c := check{Name: "Approval policy"}
+
+	dynamicClient, err := dynamic.NewForConfig(o.RESTConfig)
+	if err != nil {
+		c.Result, c.Message = checkFailed, err.Error()
+		return c
+	}
+
+	policies, err := policy.List(ctx, dynamicClient)
+	if apierrors.IsNotFound(err) {
+		c.Result, c.Message = checkSkipped, "approver-policy is not installed, the request must be approved by another approver"
+		return c
+	}
+	if err != nil {
+		c.Result, c.Message = checkFailed, err.Error()
+		return c
+	}
+
+	ns, err := o.KubeClient.CoreV1().Namespaces().Get(ctx, cr.Namespace, metav1.GetOptions{})
+	if err != nil {
+		c.Result, c.Message = checkFailed, fmt.Sprintf("error when getting namespace %q: %v", cr.Namespace, err)
+		return c
+	}
+
+	results, err := policy.Evaluate(cr, ns.Labels, policies)
+	if err != nil {
+		c.Result, c.Message = checkFailed, err.Error()
+		return c
+	}
+
+	return policyCheck(results)
+}
+
+// policyCheck summarises the policy evaluation results as a check.
+func policyCheck(results []policy.Result) check {
+	c := check{Name: "Approval policy"}
+
+	var permitted, denied []string
+	for _, result := range results {
+		switch {
+		case !result.Selected:
+		case result.Permits():
+			permitted = append(permitted, result.Policy)
+		default:
+			denied = append(denied, fmt.Sprintf("%s (%s)", result.Policy, strings.Join(result.Reasons, "; ")))
+		}
+	}
+
+	switch {
+	case len(permitted) > 0:
+		c.Result, c.Message = checkPassed, fmt.Sprintf("request would be approved by %s", strings.Join(permitted, ", "))
+	case len(denied) > 0:
+		c.Result, c.Message = checkFailed, fmt.Sprintf("request would be denied by %s", strings.Join(denied, ", "))
+	default:
+		c.Result, c.Message = checkFailed, "no CertificateRequestPolicy selects this request, it would be denied"
+	}
+	return c
+}
+
+// buildCertificateRequest builds the CertificateRequest which cert-manager
+// would create for the Certificate, signed with a newly generated private
+// key.
+func buildCertificateRequest(crt *cmapi.Certificate) (*cmapi.CertificateRequest, error) {
+	crt = crt.DeepCopy()
+	if crt.Spec.PrivateKey == nil {
+		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
+	}
+
+	signer, err := pki.GeneratePrivateKeyForCertificate(crt)
+	if err != nil {
+		return nil, fmt.Errorf("error when generating private key: %w", err)
+	}
+
+	csr, err := pki.GenerateCSR(crt)
+	if err != nil {
+		return nil, err
+	}
+
+	csrDER, err := pki.EncodeCSR(csr, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: crt.Name + "-",
+			Namespace:    crt.Namespace,
+			Annotations:  crt.Annotations,
+			Labels:       crt.Labels,
+		},
+		Spec: cmapi.CertificateRequestSpec{
+			Request:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}),
+			Duration:  crt.Spec.Duration,
+			IssuerRef: crt.Spec.IssuerRef,
+			IsCA:      crt.Spec.IsCA,
+			Usages:    apiutil.CertificateUsages(&crt.Spec),
+		},
+	}, nil
+}
+
+// printChecks prints the result of every check, and returns false if any of
+// them failed.
+func printChecks(w io.Writer, crt *cmapi.Certificate, checks []check) bool {
+	fmt.Fprintf(w, "Simulating issuance of Certificate %s/%s\n\n", crt.Namespace, crt.Name)
+
+	succeeded := true
+	for _, c := range checks {
+		fmt.Fprintf(w, "[%s] %s: %s\n", c.Result, c.Name, c.Message)
+		if c.Result == checkFailed {
+			succeeded = false
+		}
+	}
+
+	fmt.Fprintln(w)
+	if succeeded {
+		fmt.Fprintln(w, "Result: issuance would likely succeed")
+	} else {
+		fmt.Fprintln(w, "Result: issuance would likely not succeed")
+	}
+	return succeeded
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/policy"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCheckIssuer(t *testing.T) {
+	readyCondition := cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue}
+	notReadyCondition := cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionFalse,
+		Reason: "ErrInitIssuer", Message: "secret not found"}
+
+	tests := map[string]struct {
+		issuerRef   cmmeta.ObjectReference
+		objects     []runtime.Object
+		expResult   checkResult
+		expContains string
+	}{
+		"ready Issuer passes": {
+			issuerRef: cmmeta.ObjectReference{Name: "ca"},
+			objects:   []runtime.Object{gen.Issuer("ca", gen.SetIssuerNamespace("default"), gen.AddIssuerCondition(readyCondition))},
+			expResult: checkPassed,
+		},
+		"ready ClusterIssuer passes": {
+			issuerRef: cmmeta.ObjectReference{Name: "ca", Kind: cmapi.ClusterIssuerKind},
+			objects:   []runtime.Object{gen.ClusterIssuer("ca", gen.AddIssuerCondition(readyCondition))},
+			expResult: checkPassed,
+		},
+		"Issuer in another namespace fails": {
+			issuerRef:   cmmeta.ObjectReference{Name: "ca"},
+			objects:     []runtime.Object{gen.Issuer("ca", gen.SetIssuerNamespace("other"), gen.AddIssuerCondition(readyCondition))},
+			expResult:   checkFailed,
+			expContains: "not found",
+		},
+		"not ready Issuer fails with reason": {
+			issuerRef:   cmmeta.ObjectReference{Name: "ca"},
+			objects:     []runtime.Object{gen.Issuer("ca", gen.SetIssuerNamespace("default"), gen.AddIssuerCondition(notReadyCondition))},
+			expResult:   checkFailed,
+			expContains: "ErrInitIssuer: secret not found",
+		},
+		"external issuer is skipped": {
+			issuerRef: cmmeta.ObjectReference{Name: "aws", Kind: "AWSPCAIssuer", Group: "awspca.cert-manager.io"},
+			expResult: checkSkipped,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("test", gen.SetCertificateNamespace("default"), gen.SetCertificateIssuer(test.issuerRef))
+			c := checkIssuer(context.TODO(), fake.NewSimpleClientset(test.objects...), crt)
+			assert.Equal(t, test.expResult, c.Result, c.Message)
+			assert.Contains(t, c.Message, test.expContains)
+		})
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	tests := map[string]struct {
+		results     []policy.Result
+		expResult   checkResult
+		expContains string
+	}{
+		"no policies fails": {
+			expResult: checkFailed,
+		},
+		"policy which is not selected fails": {
+			results:   []policy.Result{{Policy: "a"}},
+			expResult: checkFailed,
+		},
+		"selected policy which permits passes": {
+			results: []policy.Result{
+				{Policy: "a", Selected: true, Reasons: []string{"spec.dnsNames: not allowed"}},
+				{Policy: "b", Selected: true},
+			},
+			expResult:   checkPassed,
+			expContains: "approved by b",
+		},
+		"selected policy which denies fails with reasons": {
+			results:     []policy.Result{{Policy: "a", Selected: true, Reasons: []string{"spec.dnsNames: not allowed"}}},
+			expResult:   checkFailed,
+			expContains: "a (spec.dnsNames: not allowed)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := policyCheck(test.results)
+			assert.Equal(t, test.expResult, c.Result, c.Message)
+			assert.Contains(t, c.Message, test.expContains)
+		})
+	}
+}
+
+func TestBuildCertificateRequest(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("default"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"}),
+	)
+
+	cr, err := buildCertificateRequest(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "test-", cr.GenerateName)
+	assert.Equal(t, "default", cr.Namespace)
+	assert.Equal(t, crt.Spec.IssuerRef, cr.Spec.IssuerRef)
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"example.com"}, csr.DNSNames)
+	assert.Equal(t, 0, crt.Spec.PrivateKey.Size, "the input Certificate must not be mutated")
+}
+
+func TestPrintChecks(t *testing.T) {
+	crt := gen.Certificate("test", gen.SetCertificateNamespace("default"))
+
+	tests := map[string]struct {
+		checks       []check
+		expSucceeded bool
+		expResult    string
+	}{
+		"all passed or skipped succeeds": {
+			checks: []check{
+				{Name: "one", Result: checkPassed, Message: "ok"},
+				{Name: "two", Result: checkSkipped, Message: "skipped"},
+			},
+			expSucceeded: true,
+			expResult:    "Result: issuance would likely succeed",
+		},
+		"any failure fails": {
+			checks: []check{
+				{Name: "one", Result: checkPassed, Message: "ok"},
+				{Name: "two", Result: checkFailed, Message: "broken"},
+			},
+			expSucceeded: false,
+			expResult:    "Result: issuance would likely not succeed",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.Equal(t, test.expSucceeded, printChecks(&buf, crt, test.checks))
+			for _, c := range test.checks {
+				assert.Contains(t, buf.String(), "["+string(c.Result)+"] "+c.Name+": "+c.Message)
+			}
+			assert.True(t, strings.HasSuffix(buf.String(), test.expResult+"\n"), buf.String())
+		})
+	}
+}
@@ -22,10 +22,13 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/audit"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/backup"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create/certificatesigningrequest"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/install"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/uninstall"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/x509gen"
 )
 
 func NewCmdExperimental(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
@@ -41,6 +44,9 @@ func NewCmdExperimental(ctx context.Context, ioStreams genericclioptions.IOStrea
 	cmds.AddCommand(create)
 	cmds.AddCommand(install.NewCmdInstall(ctx, ioStreams))
 	cmds.AddCommand(uninstall.NewCmd(ctx, ioStreams))
+	cmds.AddCommand(x509gen.NewCmdX509(ctx, ioStreams))
+	cmds.AddCommand(audit.NewCmdAudit(ctx, ioStreams))
+	cmds.AddCommand(backup.NewCmdBackup(ctx, ioStreams))
 
 	return cmds
 }
@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// FuzzyMatch returns the names which match the query, best match first. A
+// name which equals the query is the only match. Otherwise names containing
+// the query are returned, ordered by how early the query appears and then by
+// length. If no name contains the query, names containing all characters of
+// the query in order are returned. Matching is case insensitive.
+func FuzzyMatch(query string, names []string) []string {
+	for _, name := range names {
+		if name == query {
+			return []string{name}
+		}
+	}
+
+	query = strings.ToLower(query)
+
+	type match struct {
+		name  string
+		index int
+	}
+	var substring, subsequence []match
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		if i := strings.Index(lower, query); i >= 0 {
+			substring = append(substring, match{name, i})
+		} else if isSubsequence(query, lower) {
+			subsequence = append(subsequence, match{name, 0})
+		}
+	}
+
+	matches := substring
+	if len(matches) == 0 {
+		matches = subsequence
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].index != matches[j].index {
+			return matches[i].index < matches[j].index
+		}
+		if len(matches[i].name) != len(matches[j].name) {
+			return len(matches[i].name) < len(matches[j].name)
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	var result []string
+	for _, m := range matches {
+		result = append(result, m.name)
+	}
+	return result
+}
+
+// isSubsequence returns true if all characters of query appear in s in order.
+func isSubsequence(query, s string) bool {
+	for _, r := range query {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
+
+// ResolveCertificateName returns the name of the Certificate in the
+// Factory's namespace which best matches the given, possibly partial, name.
+// If a Certificate with exactly that name does not exist, the names of all
+// Certificates are fuzzy matched against it. When there are multiple matches
+// the user is asked to select one if stdin is a terminal, otherwise an error
+// listing the matches is returned.
+func (f *Factory) ResolveCertificateName(ctx context.Context, ioStreams genericclioptions.IOStreams, name string) (string, error) {
+	_, err := f.CMClient.CertmanagerV1().Certificates(f.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err == nil || !apierrors.IsNotFound(err) {
+		// Any other error is left to be reported by the caller when it
+		// gets the Certificate.
+		return name, nil
+	}
+	notFoundErr := err
+
+	crtList, err := f.CMClient.CertmanagerV1().Certificates(f.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error when listing Certificates: %w", err)
+	}
+
+	var names []string
+	for _, crt := range crtList.Items {
+		names = append(names, crt.Name)
+	}
+
+	matches := FuzzyMatch(name, names)
+	switch {
+	case len(matches) == 0:
+		return "", notFoundErr
+	case len(matches) == 1:
+		fmt.Fprintf(ioStreams.ErrOut, "Certificate %q not found, using %q\n", name, matches[0])
+		return matches[0], nil
+	case !isTerminal(ioStreams.In):
+		return "", fmt.Errorf("Certificate %q not found in namespace %s, it matches multiple Certificates: %s",
+			name, f.Namespace, strings.Join(matches, ", "))
+	default:
+		return selectName(ioStreams, "Certificate", matches)
+	}
+}
+
+// selectName asks the user to select one of the names.
+func selectName(ioStreams genericclioptions.IOStreams, kind string, names []string) (string, error) {
+	fmt.Fprintf(ioStreams.ErrOut, "Multiple %ss match:\n", kind)
+	for i, name := range names {
+		fmt.Fprintf(ioStreams.ErrOut, "  %d) %s\n", i+1, name)
+	}
+
+	reader := bufio.NewReader(ioStreams.In)
+	for {
+		fmt.Fprintf(ioStreams.ErrOut, "Select a %s [1-%d]: ", kind, len(names))
+		line, err := reader.ReadString('\n')
+		if n, convErr := strconv.Atoi(strings.TrimSpace(line)); convErr == nil && n >= 1 && n <= len(names) {
+			return names[n-1], nil
+		}
+		if err != nil {
+			if err == io.EOF {
+				return "", fmt.Errorf("no %s selected", kind)
+			}
+			return "", err
+		}
+	}
+}
+
+func isTerminal(r io.Reader) bool {
+	file, ok := r.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
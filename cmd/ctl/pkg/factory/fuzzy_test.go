@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	names := []string{"my-app-tls", "my-app-tls-staging", "other-tls", "web-my-app-tls", "MyApp"}
+
+	tests := map[string]struct {
+		query string
+		exp   []string
+	}{
+		"exact match is the only match": {
+			query: "my-app-tls",
+			exp:   []string{"my-app-tls"},
+		},
+		"substring matches are ordered by position then length": {
+			query: "app-tls",
+			exp:   []string{"my-app-tls", "my-app-tls-staging", "web-my-app-tls"},
+		},
+		"matching is case insensitive": {
+			query: "MYAPP",
+			exp:   []string{"MyApp"},
+		},
+		"subsequence matches are used if nothing contains the query": {
+			query: "oth-t",
+			exp:   []string{"other-tls"},
+		},
+		"no match": {
+			query: "nothing",
+			exp:   nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, FuzzyMatch(test.query, names))
+		})
+	}
+}
+
+func TestResolveCertificateName(t *testing.T) {
+	objects := []runtime.Object{
+		gen.Certificate("ingress-my-app-tls", gen.SetCertificateNamespace("default")),
+		gen.Certificate("ingress-other-tls", gen.SetCertificateNamespace("default")),
+		gen.Certificate("ingress-my-app-tls", gen.SetCertificateNamespace("other")),
+	}
+
+	tests := map[string]struct {
+		name    string
+		exp     string
+		expErr  string
+		expWarn string
+	}{
+		"exact name is returned": {
+			name: "ingress-other-tls",
+			exp:  "ingress-other-tls",
+		},
+		"single partial match is returned": {
+			name:    "my-app",
+			exp:     "ingress-my-app-tls",
+			expWarn: `Certificate "my-app" not found, using "ingress-my-app-tls"`,
+		},
+		"multiple matches error when not interactive": {
+			name:   "ingress",
+			expErr: "it matches multiple Certificates: ingress-other-tls, ingress-my-app-tls",
+		},
+		"no match returns not found error": {
+			name:   "nothing",
+			expErr: "not found",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := &Factory{Namespace: "default", CMClient: fake.NewSimpleClientset(objects...)}
+			errOut := new(bytes.Buffer)
+			streams := genericclioptions.IOStreams{In: new(bytes.Buffer), Out: new(bytes.Buffer), ErrOut: errOut}
+
+			got, err := f.ResolveCertificateName(context.TODO(), streams, test.name)
+			if test.expErr != "" {
+				assert.ErrorContains(t, err, test.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.exp, got)
+			assert.Contains(t, errOut.String(), test.expWarn)
+		})
+	}
+}
+
+func TestSelectName(t *testing.T) {
+	names := []string{"a", "b", "c"}
+
+	tests := map[string]struct {
+		input  string
+		exp    string
+		expErr bool
+	}{
+		"valid selection": {
+			input: "2\n",
+			exp:   "b",
+		},
+		"invalid selections are asked again": {
+			input: "x\n0\n4\n3\n",
+			exp:   "c",
+		},
+		"selection without trailing newline": {
+			input: "1",
+			exp:   "a",
+		},
+		"no selection errors": {
+			input:  "",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			errOut := new(bytes.Buffer)
+			streams := genericclioptions.IOStreams{In: strings.NewReader(test.input), Out: new(bytes.Buffer), ErrOut: errOut}
+
+			got, err := selectName(streams, "Certificate", names)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.exp, got)
+			assert.Contains(t, errOut.String(), "  3) c")
+		})
+	}
+}
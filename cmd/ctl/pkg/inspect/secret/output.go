@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"crypto/x509"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// Output is the stable machine readable representation of an inspected
+// Secret, printed with '-o json' or '-o yaml'. Fields may be added but must
+// not be renamed or removed.
+type Output struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Valid       bool              `json:"valid"`
+	Certificate CertificateOutput `json:"certificate"`
+	Debugging   *DebuggingOutput  `json:"debugging,omitempty"`
+}
+
+type CertificateOutput struct {
+	DNSNames           []string         `json:"dnsNames,omitempty"`
+	URIs               []string         `json:"uris,omitempty"`
+	IPAddresses        []string         `json:"ipAddresses,omitempty"`
+	EmailAddresses     []string         `json:"emailAddresses,omitempty"`
+	Usages             []cmapi.KeyUsage `json:"usages,omitempty"`
+	NotBefore          time.Time        `json:"notBefore"`
+	NotAfter           time.Time        `json:"notAfter"`
+	Subject            NameOutput       `json:"subject"`
+	Issuer             NameOutput       `json:"issuer"`
+	SignatureAlgorithm string           `json:"signatureAlgorithm"`
+	PublicKeyAlgorithm string           `json:"publicKeyAlgorithm"`
+	SerialNumber       string           `json:"serialNumber"`
+	Fingerprint        string           `json:"fingerprint"`
+	IsCA               bool             `json:"isCA"`
+	CRL                []string         `json:"crl,omitempty"`
+	OCSP               []string         `json:"ocsp,omitempty"`
+}
+
+type NameOutput struct {
+	CommonName         string   `json:"commonName,omitempty"`
+	Organization       []string `json:"organization,omitempty"`
+	OrganizationalUnit []string `json:"organizationalUnit,omitempty"`
+	Country            []string `json:"country,omitempty"`
+}
+
+type DebuggingOutput struct {
+	TrustedByThisComputer string `json:"trustedByThisComputer"`
+	CRLStatus             string `json:"crlStatus"`
+	OCSPStatus            string `json:"ocspStatus"`
+}
+
+// certificateIsValid returns true if now is within the validity period of
+// the certificate.
+func certificateIsValid(cert *x509.Certificate, now time.Time) bool {
+	return !now.Before(cert.NotBefore) && !now.After(cert.NotAfter)
+}
+
+func certificateOutput(cert *x509.Certificate) CertificateOutput {
+	return CertificateOutput{
+		DNSNames:       cert.DNSNames,
+		URIs:           pki.URLsToString(cert.URIs),
+		IPAddresses:    pki.IPAddressesToString(cert.IPAddresses),
+		EmailAddresses: cert.EmailAddresses,
+		Usages:         pki.BuildCertManagerKeyUsages(cert.KeyUsage, cert.ExtKeyUsage),
+		NotBefore:      cert.NotBefore,
+		NotAfter:       cert.NotAfter,
+		Subject: NameOutput{
+			CommonName:         cert.Subject.CommonName,
+			Organization:       cert.Subject.Organization,
+			OrganizationalUnit: cert.Subject.OrganizationalUnit,
+			Country:            cert.Subject.Country,
+		},
+		Issuer: NameOutput{
+			CommonName:         cert.Issuer.CommonName,
+			Organization:       cert.Issuer.Organization,
+			OrganizationalUnit: cert.Issuer.OrganizationalUnit,
+			Country:            cert.Issuer.Country,
+		},
+		SignatureAlgorithm: cert.SignatureAlgorithm.String(),
+		PublicKeyAlgorithm: cert.PublicKeyAlgorithm.String(),
+		SerialNumber:       cert.SerialNumber.String(),
+		Fingerprint:        fingerprintCert(cert),
+		IsCA:               cert.IsCA,
+		CRL:                cert.CRLDistributionPoints,
+		OCSP:               cert.OCSPServer,
+	}
+}
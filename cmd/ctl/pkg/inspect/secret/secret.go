@@ -38,6 +38,7 @@ import (
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/output"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
@@ -88,11 +89,18 @@ Get details about a kubernetes.io/tls typed secret`))
 	example = templates.Examples(i18n.T(build.WithTemplate(`
 # Query information about a secret with name 'my-crt' in namespace 'my-namespace'
 {{.BuildName}} inspect secret my-crt --namespace my-namespace
+
+# Print information about a secret as YAML. The command exits with code 2 if the certificate is not currently valid.
+{{.BuildName}} inspect secret my-crt -o yaml
 `)))
 )
 
 // Options is a struct to support status certificate command
 type Options struct {
+	// Output is the target output format. This may be of value "", "json"
+	// or "yaml".
+	Output string
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
@@ -120,6 +128,8 @@ func NewCmdInspectSecret(ctx context.Context, ioStreams genericclioptions.IOStre
 		},
 	}
 
+	output.AddFlag(cmd, &o.Output)
+
 	o.Factory = factory.New(ctx, cmd)
 
 	return cmd
@@ -133,7 +143,7 @@ func (o *Options) Validate(args []string) error {
 	if len(args) > 1 {
 		return errors.New("only one argument can be passed in: the name of the Secret")
 	}
-	return nil
+	return output.Validate(o.Output)
 }
 
 // Run executes status certificate command
@@ -163,16 +173,37 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("error when parsing 'tls.crt': %w", err)
 	}
 
-	out := []string{
-		describeValidFor(x509Cert),
-		describeValidityPeriod(x509Cert),
-		describeIssuedBy(x509Cert),
-		describeIssuedFor(x509Cert),
-		describeCertificate(x509Cert),
-		describeDebugging(x509Cert, intermediates, secret.Data[cmmeta.TLSCAKey]),
+	if o.Output == output.FormatText {
+		out := []string{
+			describeValidFor(x509Cert),
+			describeValidityPeriod(x509Cert),
+			describeIssuedBy(x509Cert),
+			describeIssuedFor(x509Cert),
+			describeCertificate(x509Cert),
+			describeDebugging(x509Cert, intermediates, secret.Data[cmmeta.TLSCAKey]),
+		}
+
+		fmt.Fprintln(o.Out, strings.Join(out, "\n\n"))
+	} else {
+		out := Output{
+			Name:        secret.Name,
+			Namespace:   secret.Namespace,
+			Valid:       certificateIsValid(x509Cert, clock.Now()),
+			Certificate: certificateOutput(x509Cert),
+			Debugging: &DebuggingOutput{
+				TrustedByThisComputer: describeTrusted(x509Cert, intermediates),
+				CRLStatus:             describeCRL(x509Cert),
+				OCSPStatus:            describeOCSP(x509Cert, intermediates, secret.Data[cmmeta.TLSCAKey]),
+			},
+		}
+		if err := output.Print(o.Out, o.Output, out); err != nil {
+			return err
+		}
 	}
 
-	fmt.Println(strings.Join(out, "\n\n"))
+	if !certificateIsValid(x509Cert, clock.Now()) {
+		return output.ExitError(output.ExitCodeNotReady, "certificate in Secret %s/%s is not currently valid", secret.Namespace, secret.Name)
+	}
 
 	return nil
 }
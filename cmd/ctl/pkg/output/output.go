@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package output contains helpers shared by commands which support machine
+// readable output with '-o json|yaml', and defines the exit codes those
+// commands use to signal results to scripts.
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	uexec "k8s.io/utils/exec"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// FormatText is the default human readable output.
+	FormatText = ""
+	// FormatJSON prints the output as indented JSON.
+	FormatJSON = "json"
+	// FormatYAML prints the output as YAML.
+	FormatYAML = "yaml"
+)
+
+// Exit codes used by commands to signal results. 1 is reserved for errors
+// running the command itself.
+const (
+	// ExitCodeNotReady is used when the inspected resource is not Ready, or
+	// the inspected certificate is not currently valid.
+	ExitCodeNotReady = 2
+	// ExitCodeFindings is used when an audit reports at least one finding.
+	ExitCodeFindings = 3
+)
+
+// AddFlag registers the '--output' / '-o' flag on the command.
+func AddFlag(cmd *cobra.Command, format *string) {
+	cmd.Flags().StringVarP(format, "output", "o", *format, "Output format. One of '', 'yaml' or 'json'.")
+}
+
+// Validate returns an error if format is not a supported output format.
+func Validate(format string) error {
+	switch format {
+	case FormatText, FormatJSON, FormatYAML:
+		return nil
+	default:
+		return errors.New(`--output must be '', 'yaml' or 'json'`)
+	}
+}
+
+// Print writes obj to w in the given machine readable format.
+func Print(w io.Writer, format string, obj interface{}) error {
+	switch format {
+	case FormatYAML:
+		marshalled, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(marshalled))
+	case FormatJSON:
+		marshalled, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(marshalled))
+	default:
+		return fmt.Errorf("output format %q is not a machine readable format", format)
+	}
+	return nil
+}
+
+// ExitError returns an error which causes the command to exit with the given
+// code when passed to cmdutil.CheckErr.
+func ExitError(code int, format string, args ...interface{}) error {
+	return uexec.CodeExitError{Err: fmt.Errorf(format, args...), Code: code}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	uexec "k8s.io/utils/exec"
+)
+
+func TestPrint(t *testing.T) {
+	obj := struct {
+		Name  string `json:"name"`
+		Ready bool   `json:"ready"`
+	}{Name: "my-crt", Ready: true}
+
+	tests := map[string]struct {
+		format    string
+		expOutput string
+		expErr    bool
+	}{
+		"json": {
+			format:    FormatJSON,
+			expOutput: "{\n  \"name\": \"my-crt\",\n  \"ready\": true\n}\n",
+		},
+		"yaml": {
+			format:    FormatYAML,
+			expOutput: "name: my-crt\nready: true\n",
+		},
+		"text is not machine readable": {
+			format: FormatText,
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Print(&buf, test.format, obj)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if buf.String() != test.expOutput {
+				t.Errorf("unexpected output, exp=%q got=%q", test.expOutput, buf.String())
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, format := range []string{FormatText, FormatJSON, FormatYAML} {
+		if err := Validate(format); err != nil {
+			t.Errorf("expected %q to be valid, got %v", format, err)
+		}
+	}
+	if err := Validate("table"); err == nil {
+		t.Error("expected 'table' to be rejected")
+	}
+}
+
+func TestExitError(t *testing.T) {
+	err := ExitError(ExitCodeNotReady, "Certificate %s is not Ready", "my-crt")
+	exitErr, ok := err.(uexec.ExitError)
+	if !ok {
+		t.Fatalf("expected an exec.ExitError, got %T", err)
+	}
+	if exitErr.ExitStatus() != ExitCodeNotReady {
+		t.Errorf("unexpected exit status %d", exitErr.ExitStatus())
+	}
+	if err.Error() != "Certificate my-crt is not Ready" {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+}
@@ -0,0 +1,490 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy implements a local, read-only evaluation of
+// CertificateRequestPolicy resources (policy.cert-manager.io) against a
+// CertificateRequest. It is used by the approve and deny commands to explain
+// which policy would match a request, without changing anything in the
+// cluster.
+//
+// Only a subset of the approver-policy API is understood: the issuerRef and
+// namespace selectors, the allowed block for the common name, SANs,
+// organizations, isCA and usages, and the duration and private key
+// constraints. Plugins are reported but never evaluated.
+package policy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// GroupVersionResource is the resource served by approver-policy.
+var GroupVersionResource = schema.GroupVersionResource{
+	Group:    "policy.cert-manager.io",
+	Version:  "v1alpha1",
+	Resource: "certificaterequestpolicies",
+}
+
+// CertificateRequestPolicy is the subset of the approver-policy
+// CertificateRequestPolicy type which can be evaluated locally.
+type CertificateRequestPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PolicySpec `json:"spec"`
+}
+
+type PolicySpec struct {
+	Allowed     *Allowed               `json:"allowed,omitempty"`
+	Constraints *Constraints           `json:"constraints,omitempty"`
+	Plugins     map[string]interface{} `json:"plugins,omitempty"`
+	Selector    Selector               `json:"selector"`
+}
+
+type Allowed struct {
+	CommonName     *AllowedString     `json:"commonName,omitempty"`
+	DNSNames       *AllowedStringList `json:"dnsNames,omitempty"`
+	IPAddresses    *AllowedStringList `json:"ipAddresses,omitempty"`
+	URIs           *AllowedStringList `json:"uris,omitempty"`
+	EmailAddresses *AllowedStringList `json:"emailAddresses,omitempty"`
+	IsCA           *bool              `json:"isCA,omitempty"`
+	Usages         *[]cmapi.KeyUsage  `json:"usages,omitempty"`
+	Subject        *AllowedSubject    `json:"subject,omitempty"`
+}
+
+type AllowedSubject struct {
+	Organizations *AllowedStringList `json:"organizations,omitempty"`
+}
+
+type AllowedString struct {
+	Value    *string `json:"value,omitempty"`
+	Required *bool   `json:"required,omitempty"`
+}
+
+type AllowedStringList struct {
+	Values   *[]string `json:"values,omitempty"`
+	Required *bool     `json:"required,omitempty"`
+}
+
+type Constraints struct {
+	MinDuration *metav1.Duration      `json:"minDuration,omitempty"`
+	MaxDuration *metav1.Duration      `json:"maxDuration,omitempty"`
+	PrivateKey  *ConstraintPrivateKey `json:"privateKey,omitempty"`
+}
+
+type ConstraintPrivateKey struct {
+	Algorithm *cmapi.PrivateKeyAlgorithm `json:"algorithm,omitempty"`
+	MinSize   *int                       `json:"minSize,omitempty"`
+	MaxSize   *int                       `json:"maxSize,omitempty"`
+}
+
+type Selector struct {
+	IssuerRef *IssuerRefSelector `json:"issuerRef,omitempty"`
+	Namespace *NamespaceSelector `json:"namespace,omitempty"`
+}
+
+type IssuerRefSelector struct {
+	Name  *string `json:"name,omitempty"`
+	Kind  *string `json:"kind,omitempty"`
+	Group *string `json:"group,omitempty"`
+}
+
+type NamespaceSelector struct {
+	MatchNames  []string          `json:"matchNames,omitempty"`
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+// Result is the outcome of evaluating a single policy against a request.
+type Result struct {
+	// Policy is the name of the evaluated CertificateRequestPolicy.
+	Policy string
+	// Selected is true if the policy's selector matches the request.
+	Selected bool
+	// Reasons explains why the policy was not selected, or which parts of the
+	// request violate the policy if it was.
+	Reasons []string
+	// SkippedPlugins lists plugins configured on the policy that could not be
+	// evaluated locally.
+	SkippedPlugins []string
+}
+
+// Permits returns true if the policy was selected and the request does not
+// violate any of its locally evaluable rules.
+func (r Result) Permits() bool {
+	return r.Selected && len(r.Reasons) == 0
+}
+
+// List fetches all CertificateRequestPolicies from the cluster.
+func List(ctx context.Context, client dynamic.Interface) ([]CertificateRequestPolicy, error) {
+	list, err := client.Resource(GroupVersionResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CertificateRequestPolicies, is approver-policy installed?: %w", err)
+	}
+
+	policies := make([]CertificateRequestPolicy, 0, len(list.Items))
+	for _, item := range list.Items {
+		policy, err := fromUnstructured(&item)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, *policy)
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+
+	return policies, nil
+}
+
+func fromUnstructured(obj *unstructured.Unstructured) (*CertificateRequestPolicy, error) {
+	policy := new(CertificateRequestPolicy)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), policy); err != nil {
+		return nil, fmt.Errorf("failed to decode CertificateRequestPolicy %q: %w", obj.GetName(), err)
+	}
+	return policy, nil
+}
+
+// Evaluate evaluates every policy against the given CertificateRequest.
+// namespaceLabels are the labels of the request's namespace and are used for
+// namespace matchLabels selectors.
+func Evaluate(cr *cmapi.CertificateRequest, namespaceLabels map[string]string, policies []CertificateRequestPolicy) ([]Result, error) {
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode CertificateRequest %s/%s request: %w", cr.Namespace, cr.Name, err)
+	}
+
+	results := make([]Result, 0, len(policies))
+	for _, policy := range policies {
+		result := Result{Policy: policy.Name}
+		for name := range policy.Spec.Plugins {
+			result.SkippedPlugins = append(result.SkippedPlugins, name)
+		}
+		sort.Strings(result.SkippedPlugins)
+
+		if reasons := evaluateSelector(policy.Spec.Selector, cr, namespaceLabels); len(reasons) > 0 {
+			result.Reasons = reasons
+			results = append(results, result)
+			continue
+		}
+
+		result.Selected = true
+		result.Reasons = append(result.Reasons, evaluateAllowed(policy.Spec.Allowed, cr, csr)...)
+		result.Reasons = append(result.Reasons, evaluateConstraints(policy.Spec.Constraints, cr, csr)...)
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func evaluateSelector(sel Selector, cr *cmapi.CertificateRequest, namespaceLabels map[string]string) []string {
+	var reasons []string
+
+	if sel.IssuerRef == nil && sel.Namespace == nil {
+		return []string{"spec.selector is empty and matches nothing"}
+	}
+
+	if ref := sel.IssuerRef; ref != nil {
+		issuerKind := cr.Spec.IssuerRef.Kind
+		if len(issuerKind) == 0 {
+			issuerKind = cmapi.IssuerKind
+		}
+		issuerGroup := cr.Spec.IssuerRef.Group
+		if len(issuerGroup) == 0 {
+			issuerGroup = "cert-manager.io"
+		}
+		if ref.Name != nil && !wildcardMatches(*ref.Name, cr.Spec.IssuerRef.Name) {
+			reasons = append(reasons, fmt.Sprintf("spec.selector.issuerRef.name %q does not match %q", *ref.Name, cr.Spec.IssuerRef.Name))
+		}
+		if ref.Kind != nil && !wildcardMatches(*ref.Kind, issuerKind) {
+			reasons = append(reasons, fmt.Sprintf("spec.selector.issuerRef.kind %q does not match %q", *ref.Kind, issuerKind))
+		}
+		if ref.Group != nil && !wildcardMatches(*ref.Group, issuerGroup) {
+			reasons = append(reasons, fmt.Sprintf("spec.selector.issuerRef.group %q does not match %q", *ref.Group, issuerGroup))
+		}
+	}
+
+	if ns := sel.Namespace; ns != nil {
+		if len(ns.MatchNames) > 0 && !wildcardMatchesAny(ns.MatchNames, cr.Namespace) {
+			reasons = append(reasons, fmt.Sprintf("spec.selector.namespace.matchNames %v does not match %q", ns.MatchNames, cr.Namespace))
+		}
+		if len(ns.MatchLabels) > 0 && !labels.SelectorFromSet(ns.MatchLabels).Matches(labels.Set(namespaceLabels)) {
+			reasons = append(reasons, fmt.Sprintf("spec.selector.namespace.matchLabels %v does not match the labels of namespace %q", ns.MatchLabels, cr.Namespace))
+		}
+	}
+
+	return reasons
+}
+
+func evaluateAllowed(allowed *Allowed, cr *cmapi.CertificateRequest, csr *x509.CertificateRequest) []string {
+	if allowed == nil {
+		allowed = new(Allowed)
+	}
+
+	var reasons []string
+
+	reasons = append(reasons, evaluateString("spec.allowed.commonName", allowed.CommonName, csr.Subject.CommonName)...)
+	reasons = append(reasons, evaluateStringList("spec.allowed.dnsNames", allowed.DNSNames, csr.DNSNames)...)
+	reasons = append(reasons, evaluateStringList("spec.allowed.ipAddresses", allowed.IPAddresses, pki.IPAddressesToString(csr.IPAddresses))...)
+	reasons = append(reasons, evaluateStringList("spec.allowed.uris", allowed.URIs, pki.URLsToString(csr.URIs))...)
+	reasons = append(reasons, evaluateStringList("spec.allowed.emailAddresses", allowed.EmailAddresses, csr.EmailAddresses)...)
+
+	var organizations *AllowedStringList
+	if allowed.Subject != nil {
+		organizations = allowed.Subject.Organizations
+	}
+	reasons = append(reasons, evaluateStringList("spec.allowed.subject.organizations", organizations, csr.Subject.Organization)...)
+
+	if cr.Spec.IsCA && (allowed.IsCA == nil || !*allowed.IsCA) {
+		reasons = append(reasons, "spec.allowed.isCA: request is for a CA but this is not allowed")
+	}
+
+	var allowedUsages []cmapi.KeyUsage
+	if allowed.Usages != nil {
+		allowedUsages = *allowed.Usages
+	}
+	for _, usage := range cr.Spec.Usages {
+		if !containsUsage(allowedUsages, usage) {
+			reasons = append(reasons, fmt.Sprintf("spec.allowed.usages: usage %q is not allowed", usage))
+		}
+	}
+
+	return reasons
+}
+
+func evaluateConstraints(constraints *Constraints, cr *cmapi.CertificateRequest, csr *x509.CertificateRequest) []string {
+	if constraints == nil {
+		return nil
+	}
+
+	var reasons []string
+
+	duration := apiutil.DefaultCertDuration(cr.Spec.Duration)
+	if constraints.MinDuration != nil && duration < constraints.MinDuration.Duration {
+		reasons = append(reasons, fmt.Sprintf("spec.constraints.minDuration: duration %s is less than %s", duration, constraints.MinDuration.Duration))
+	}
+	if constraints.MaxDuration != nil && duration > constraints.MaxDuration.Duration {
+		reasons = append(reasons, fmt.Sprintf("spec.constraints.maxDuration: duration %s is greater than %s", duration, constraints.MaxDuration.Duration))
+	}
+
+	if pk := constraints.PrivateKey; pk != nil {
+		alg, size := publicKeyAlgorithmAndSize(csr)
+		if pk.Algorithm != nil && *pk.Algorithm != alg {
+			reasons = append(reasons, fmt.Sprintf("spec.constraints.privateKey.algorithm: algorithm %q is not %q", alg, *pk.Algorithm))
+		}
+		if pk.MinSize != nil && size < *pk.MinSize {
+			reasons = append(reasons, fmt.Sprintf("spec.constraints.privateKey.minSize: key size %d is less than %d", size, *pk.MinSize))
+		}
+		if pk.MaxSize != nil && size > *pk.MaxSize {
+			reasons = append(reasons, fmt.Sprintf("spec.constraints.privateKey.maxSize: key size %d is greater than %d", size, *pk.MaxSize))
+		}
+	}
+
+	return reasons
+}
+
+func evaluateString(path string, allowed *AllowedString, value string) []string {
+	if allowed == nil {
+		if len(value) > 0 {
+			return []string{fmt.Sprintf("%s: %q is not allowed", path, value)}
+		}
+		return nil
+	}
+
+	if len(value) == 0 {
+		if allowed.Required != nil && *allowed.Required {
+			return []string{fmt.Sprintf("%s: value is required but not requested", path)}
+		}
+		return nil
+	}
+
+	if allowed.Value == nil || !wildcardMatches(*allowed.Value, value) {
+		return []string{fmt.Sprintf("%s: %q is not allowed", path, value)}
+	}
+
+	return nil
+}
+
+func evaluateStringList(path string, allowed *AllowedStringList, values []string) []string {
+	if allowed == nil {
+		if len(values) > 0 {
+			return []string{fmt.Sprintf("%s: %v are not allowed", path, values)}
+		}
+		return nil
+	}
+
+	if len(values) == 0 {
+		if allowed.Required != nil && *allowed.Required {
+			return []string{fmt.Sprintf("%s: values are required but none were requested", path)}
+		}
+		return nil
+	}
+
+	var patterns []string
+	if allowed.Values != nil {
+		patterns = *allowed.Values
+	}
+
+	var reasons []string
+	for _, value := range values {
+		if !wildcardMatchesAny(patterns, value) {
+			reasons = append(reasons, fmt.Sprintf("%s: %q is not allowed", path, value))
+		}
+	}
+
+	return reasons
+}
+
+func containsUsage(usages []cmapi.KeyUsage, usage cmapi.KeyUsage) bool {
+	for _, u := range usages {
+		if u == usage {
+			return true
+		}
+	}
+	return false
+}
+
+func publicKeyAlgorithmAndSize(csr *x509.CertificateRequest) (cmapi.PrivateKeyAlgorithm, int) {
+	switch pub := csr.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return cmapi.RSAKeyAlgorithm, pub.N.BitLen()
+	case *ecdsa.PublicKey:
+		return cmapi.ECDSAKeyAlgorithm, pub.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return cmapi.Ed25519KeyAlgorithm, 0
+	default:
+		return "", 0
+	}
+}
+
+func wildcardMatchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if wildcardMatches(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardMatches returns true if value matches pattern, where '*' in pattern
+// matches any sequence of characters, including the empty sequence.
+func wildcardMatches(pattern, value string) bool {
+	if pattern == "*" {
+		return true
+	}
+
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+
+	return strings.HasSuffix(value, last)
+}
+
+// PrintResults writes a human readable explanation of the evaluation results
+// to w.
+func PrintResults(w io.Writer, cr *cmapi.CertificateRequest, results []Result) {
+	fmt.Fprintf(w, "Evaluated CertificateRequest '%s/%s' against %d CertificateRequestPolicies\n", cr.Namespace, cr.Name, len(results))
+
+	var permitted []string
+	for _, result := range results {
+		switch {
+		case !result.Selected:
+			fmt.Fprintf(w, "\n%s: not selected\n", result.Policy)
+		case result.Permits():
+			fmt.Fprintf(w, "\n%s: selected, request would be permitted\n", result.Policy)
+			permitted = append(permitted, result.Policy)
+		default:
+			fmt.Fprintf(w, "\n%s: selected, request would be denied\n", result.Policy)
+		}
+		for _, reason := range result.Reasons {
+			fmt.Fprintf(w, "  - %s\n", reason)
+		}
+		for _, plugin := range result.SkippedPlugins {
+			fmt.Fprintf(w, "  ! plugin %q cannot be evaluated locally and was skipped\n", plugin)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if len(permitted) > 0 {
+		fmt.Fprintf(w, "Result: request would be approved by %s\n", strings.Join(permitted, ", "))
+	} else {
+		fmt.Fprintln(w, "Result: no policy permits this request, it would be denied")
+	}
+}
+
+// Explain evaluates the CertificateRequest against the
+// CertificateRequestPolicies in the cluster and writes the result to w. The
+// CertificateRequest is not modified.
+func Explain(ctx context.Context, restConfig *rest.Config, kubeClient kubernetes.Interface, w io.Writer, cr *cmapi.CertificateRequest) error {
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	policies, err := List(ctx, dynamicClient)
+	if err != nil {
+		return err
+	}
+
+	ns, err := kubeClient.CoreV1().Namespaces().Get(ctx, cr.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	results, err := Evaluate(cr, ns.Labels, policies)
+	if err != nil {
+		return err
+	}
+
+	PrintResults(w, cr, results)
+
+	return nil
+}
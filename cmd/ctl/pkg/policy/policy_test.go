@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"crypto/x509"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestWildcardMatches(t *testing.T) {
+	tests := map[string]struct {
+		pattern, value string
+		exp            bool
+	}{
+		"exact match":             {"example.com", "example.com", true},
+		"exact mismatch":          {"example.com", "example.org", false},
+		"wildcard only":           {"*", "anything", true},
+		"leading wildcard":        {"*.example.com", "foo.example.com", true},
+		"leading wildcard no dot": {"*.example.com", "example.com", false},
+		"middle wildcard":         {"foo-*-bar", "foo-123-bar", true},
+		"multiple wildcards":      {"*.*.com", "a.b.com", true},
+		"multiple wildcards miss": {"*.*.com", "ab.org", false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := wildcardMatches(test.pattern, test.value); got != test.exp {
+				t.Errorf("wildcardMatches(%q, %q) = %t, exp %t", test.pattern, test.value, got, test.exp)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	csr, _, err := gen.CSR(x509.ECDSA,
+		gen.SetCSRCommonName("foo.example.com"),
+		gen.SetCSRDNSNames("foo.example.com", "bar.example.org"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cr := gen.CertificateRequest("my-cr",
+		gen.SetCertificateRequestNamespace("default"),
+		gen.SetCertificateRequestCSR(csr),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer"}),
+		gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
+	)
+
+	strPtr := func(s string) *string { return &s }
+	listPtr := func(s ...string) *[]string { return &s }
+	ecdsa := cmapi.ECDSAKeyAlgorithm
+	rsa := cmapi.RSAKeyAlgorithm
+
+	tests := map[string]struct {
+		policy    PolicySpec
+		expResult Result
+	}{
+		"empty selector selects nothing": {
+			policy: PolicySpec{},
+			expResult: Result{
+				Reasons: []string{"spec.selector is empty and matches nothing"},
+			},
+		},
+		"issuer kind does not match": {
+			policy: PolicySpec{
+				Selector: Selector{IssuerRef: &IssuerRefSelector{Kind: strPtr("Issuer")}},
+			},
+			expResult: Result{
+				Reasons: []string{`spec.selector.issuerRef.kind "Issuer" does not match "ClusterIssuer"`},
+			},
+		},
+		"namespace labels do not match": {
+			policy: PolicySpec{
+				Selector: Selector{Namespace: &NamespaceSelector{MatchLabels: map[string]string{"team": "b"}}},
+			},
+			expResult: Result{
+				Reasons: []string{`spec.selector.namespace.matchLabels map[team:b] does not match the labels of namespace "default"`},
+			},
+		},
+		"selected and permitted": {
+			policy: PolicySpec{
+				Selector: Selector{IssuerRef: &IssuerRefSelector{Name: strPtr("*")}},
+				Allowed: &Allowed{
+					CommonName: &AllowedString{Value: strPtr("*.example.com")},
+					DNSNames:   &AllowedStringList{Values: listPtr("*.example.com", "*.example.org")},
+				},
+				Constraints: &Constraints{
+					MaxDuration: &metav1.Duration{Duration: 2 * time.Hour},
+					PrivateKey:  &ConstraintPrivateKey{Algorithm: &ecdsa},
+				},
+			},
+			expResult: Result{Selected: true},
+		},
+		"selected but violating": {
+			policy: PolicySpec{
+				Selector: Selector{Namespace: &NamespaceSelector{MatchNames: []string{"def*"}}},
+				Allowed: &Allowed{
+					DNSNames: &AllowedStringList{Values: listPtr("*.example.com")},
+				},
+				Constraints: &Constraints{
+					MinDuration: &metav1.Duration{Duration: 2 * time.Hour},
+					PrivateKey:  &ConstraintPrivateKey{Algorithm: &rsa},
+				},
+				Plugins: map[string]interface{}{"rego": nil},
+			},
+			expResult: Result{
+				Selected: true,
+				Reasons: []string{
+					`spec.allowed.commonName: "foo.example.com" is not allowed`,
+					`spec.allowed.dnsNames: "bar.example.org" is not allowed`,
+					"spec.constraints.minDuration: duration 1h0m0s is less than 2h0m0s",
+					`spec.constraints.privateKey.algorithm: algorithm "ECDSA" is not "RSA"`,
+				},
+				SkippedPlugins: []string{"rego"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy := CertificateRequestPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-policy"},
+				Spec:       test.policy,
+			}
+			results, err := Evaluate(cr, map[string]string{"team": "a"}, []CertificateRequestPolicy{policy})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			test.expResult.Policy = "test-policy"
+			if !reflect.DeepEqual(results, []Result{test.expResult}) {
+				t.Errorf("unexpected result, exp=%#+v got=%#+v", test.expResult, results[0])
+			}
+		})
+	}
+}
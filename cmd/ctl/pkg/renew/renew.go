@@ -170,6 +170,14 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 
 		default:
 			for _, crtName := range args {
+				if !o.AllNamespaces {
+					var err error
+					crtName, err = o.ResolveCertificateName(ctx, o.IOStreams, crtName)
+					if err != nil {
+						return err
+					}
+				}
+
 				crt, err := o.CMClient.CertmanagerV1().Certificates(ns.Name).Get(ctx, crtName, metav1.GetOptions{})
 				if err != nil {
 					return err
@@ -34,6 +34,7 @@ import (
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/output"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
@@ -48,11 +49,32 @@ Get details about the current status of a cert-manager Certificate resource, inc
 	example = templates.Examples(i18n.T(build.WithTemplate(`
 # Query status of Certificate with name 'my-crt' in namespace 'my-namespace'
 {{.BuildName}} status certificate my-crt --namespace my-namespace
+
+# Query status of the Certificate whose name contains 'my-app', e.g. one created by ingress-shim.
+# If multiple Certificates match, you are asked to select one.
+{{.BuildName}} status certificate my-app
+
+# Stream condition changes and events of Certificate 'my-crt' until it is Ready or fails
+{{.BuildName}} status certificate my-crt --watch --timeout 10m
+
+# Print the status of Certificate 'my-crt' as JSON. The command exits with code 2 if it is not Ready.
+{{.BuildName}} status certificate my-crt -o json
 `)))
 )
 
 // Options is a struct to support status certificate command
 type Options struct {
+	// Watch will stream condition transitions and events of related
+	// resources until the Certificate is Ready or fails to be issued.
+	Watch bool
+
+	// Timeout is the maximum length of time to watch the Certificate for.
+	Timeout time.Duration
+
+	// Output is the target output format for the status. This may be of
+	// value "", "json" or "yaml".
+	Output string
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
@@ -100,6 +122,12 @@ func NewCmdStatusCert(ctx context.Context, ioStreams genericclioptions.IOStreams
 		},
 	}
 
+	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", false,
+		"Stream condition transitions and related events until the Certificate is Ready or fails to be issued")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 5*time.Minute,
+		"Time to wait for the Certificate to become Ready when watching, must include unit, e.g. 10m or 1h")
+	output.AddFlag(cmd, &o.Output)
+
 	o.Factory = factory.New(ctx, cmd)
 
 	return cmd
@@ -113,20 +141,48 @@ func (o *Options) Validate(args []string) error {
 	if len(args) > 1 {
 		return errors.New("only one argument can be passed in: the name of the Certificate")
 	}
-	return nil
+	if o.Watch && o.Timeout <= 0 {
+		return errors.New("the timeout must be greater than zero when watching")
+	}
+	if o.Watch && o.Output != output.FormatText {
+		return errors.New("--output cannot be used together with --watch")
+	}
+	return output.Validate(o.Output)
 }
 
 // Run executes status certificate command
 func (o *Options) Run(ctx context.Context, args []string) error {
-	data, err := o.GetResources(ctx, args[0])
+	crtName, err := o.ResolveCertificateName(ctx, o.IOStreams, args[0])
+	if err != nil {
+		return err
+	}
+
+	if o.Watch {
+		err := o.watchCertificate(ctx, crtName)
+		if errors.Is(err, errIssuanceFailed) {
+			return output.ExitError(output.ExitCodeNotReady, "%v", err)
+		}
+		return err
+	}
+
+	data, err := o.GetResources(ctx, crtName)
 	if err != nil {
 		return err
 	}
 
 	// Build status of Certificate with data gathered
 	status := StatusFromResources(data)
+	ready := certificateIsReady(data.Certificate)
 
-	fmt.Fprintf(o.Out, status.String())
+	if o.Output == output.FormatText {
+		fmt.Fprint(o.Out, status.String())
+	} else if err := output.Print(o.Out, o.Output, status.Output(ready)); err != nil {
+		return err
+	}
+
+	if !ready {
+		return output.ExitError(output.ExitCodeNotReady, "Certificate %s/%s is not Ready", data.Certificate.Namespace, data.Certificate.Name)
+	}
 
 	return nil
 }
@@ -357,7 +357,11 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 					TypeMeta:   metav1.TypeMeta{},
 					ObjectMeta: metav1.ObjectMeta{Name: "example-order", Namespace: ns},
 					Spec:       cmacme.OrderSpec{Request: []byte("dummyCSR"), DNSNames: []string{"www.example.com"}},
-					Status:     cmacme.OrderStatus{},
+					Status: cmacme.OrderStatus{
+						URL:            "https://acme.example.com/order/1",
+						AccountURI:     "https://acme.example.com/acct/1",
+						CertificateURL: "https://acme.example.com/cert/1",
+					},
 				},
 				OrderError: nil,
 			},
@@ -369,6 +373,9 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 					Error:          nil,
 					Name:           "example-order",
 					State:          "",
+					URL:            "https://acme.example.com/order/1",
+					AccountURI:     "https://acme.example.com/acct/1",
+					CertificateURL: "https://acme.example.com/cert/1",
 					Reason:         "",
 					Authorizations: nil,
 					FailureTime:    nil,
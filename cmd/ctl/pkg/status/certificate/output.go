@@ -0,0 +1,217 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"encoding/hex"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// The types in this file are the stable machine readable representation of
+// the status of a Certificate, printed with '-o json' or '-o yaml'. Fields
+// may be added but must not be renamed or removed.
+
+// Output is the machine readable status of a Certificate.
+type Output struct {
+	Name         string                       `json:"name"`
+	Namespace    string                       `json:"namespace"`
+	CreationTime metav1.Time                  `json:"creationTime"`
+	Ready        bool                         `json:"ready"`
+	Conditions   []cmapi.CertificateCondition `json:"conditions,omitempty"`
+	DNSNames     []string                     `json:"dnsNames,omitempty"`
+	NotBefore    *metav1.Time                 `json:"notBefore,omitempty"`
+	NotAfter     *metav1.Time                 `json:"notAfter,omitempty"`
+	RenewalTime  *metav1.Time                 `json:"renewalTime,omitempty"`
+	Events       []EventOutput                `json:"events,omitempty"`
+
+	Issuer             *IssuerOutput             `json:"issuer,omitempty"`
+	Secret             *SecretOutput             `json:"secret,omitempty"`
+	CertificateRequest *CertificateRequestOutput `json:"certificateRequest,omitempty"`
+	Order              *OrderOutput              `json:"order,omitempty"`
+	Challenges         *ChallengesOutput         `json:"challenges,omitempty"`
+}
+
+type EventOutput struct {
+	Type          string      `json:"type"`
+	Reason        string      `json:"reason"`
+	Message       string      `json:"message"`
+	Count         int32       `json:"count,omitempty"`
+	LastTimestamp metav1.Time `json:"lastTimestamp,omitempty"`
+}
+
+type IssuerOutput struct {
+	Error      string                  `json:"error,omitempty"`
+	Name       string                  `json:"name,omitempty"`
+	Kind       string                  `json:"kind,omitempty"`
+	Conditions []cmapi.IssuerCondition `json:"conditions,omitempty"`
+	Events     []EventOutput           `json:"events,omitempty"`
+}
+
+type SecretOutput struct {
+	Error              string        `json:"error,omitempty"`
+	Name               string        `json:"name,omitempty"`
+	IssuerCountry      []string      `json:"issuerCountry,omitempty"`
+	IssuerOrganisation []string      `json:"issuerOrganisation,omitempty"`
+	IssuerCommonName   string        `json:"issuerCommonName,omitempty"`
+	KeyUsage           string        `json:"keyUsage,omitempty"`
+	ExtKeyUsage        string        `json:"extKeyUsage,omitempty"`
+	PublicKeyAlgorithm string        `json:"publicKeyAlgorithm,omitempty"`
+	SignatureAlgorithm string        `json:"signatureAlgorithm,omitempty"`
+	SubjectKeyID       string        `json:"subjectKeyID,omitempty"`
+	AuthorityKeyID     string        `json:"authorityKeyID,omitempty"`
+	SerialNumber       string        `json:"serialNumber,omitempty"`
+	Events             []EventOutput `json:"events,omitempty"`
+}
+
+type CertificateRequestOutput struct {
+	Error      string                              `json:"error,omitempty"`
+	Name       string                              `json:"name,omitempty"`
+	Namespace  string                              `json:"namespace,omitempty"`
+	Conditions []cmapi.CertificateRequestCondition `json:"conditions,omitempty"`
+	Events     []EventOutput                       `json:"events,omitempty"`
+}
+
+type OrderOutput struct {
+	Error          string                     `json:"error,omitempty"`
+	Name           string                     `json:"name,omitempty"`
+	State          cmacme.State               `json:"state,omitempty"`
+	URL            string                     `json:"url,omitempty"`
+	AccountURI     string                     `json:"accountURI,omitempty"`
+	CertificateURL string                     `json:"certificateURL,omitempty"`
+	Reason         string                     `json:"reason,omitempty"`
+	Authorizations []cmacme.ACMEAuthorization `json:"authorizations,omitempty"`
+	FailureTime    *metav1.Time               `json:"failureTime,omitempty"`
+}
+
+type ChallengesOutput struct {
+	Error string            `json:"error,omitempty"`
+	Items []ChallengeOutput `json:"items,omitempty"`
+}
+
+type ChallengeOutput struct {
+	Name       string                   `json:"name"`
+	Type       cmacme.ACMEChallengeType `json:"type"`
+	Token      string                   `json:"token"`
+	Key        string                   `json:"key"`
+	State      cmacme.State             `json:"state,omitempty"`
+	Reason     string                   `json:"reason,omitempty"`
+	Processing bool                     `json:"processing"`
+	Presented  bool                     `json:"presented"`
+}
+
+// certificateIsReady returns true if the Certificate has a Ready condition
+// set to True for its current generation.
+func certificateIsReady(crt *cmapi.Certificate) bool {
+	return apiutil.CertificateHasConditionWithObservedGeneration(crt, cmapi.CertificateCondition{
+		Type:               cmapi.CertificateConditionReady,
+		Status:             cmmeta.ConditionTrue,
+		ObservedGeneration: crt.Generation,
+	})
+}
+
+// Output returns the machine readable representation of the status.
+func (status *CertificateStatus) Output(ready bool) *Output {
+	out := &Output{
+		Name:         status.Name,
+		Namespace:    status.Namespace,
+		CreationTime: status.CreationTime,
+		Ready:        ready,
+		Conditions:   status.Conditions,
+		DNSNames:     status.DNSNames,
+		NotBefore:    status.NotBefore,
+		NotAfter:     status.NotAfter,
+		RenewalTime:  status.RenewalTime,
+		Events:       eventsOutput(status.Events),
+	}
+
+	if s := status.IssuerStatus; s != nil {
+		out.Issuer = &IssuerOutput{Error: errorString(s.Error), Name: s.Name, Kind: s.Kind,
+			Conditions: s.Conditions, Events: eventsOutput(s.Events)}
+	}
+
+	if s := status.SecretStatus; s != nil {
+		out.Secret = &SecretOutput{Error: errorString(s.Error), Name: s.Name}
+		if s.Error == nil {
+			extKeyUsage, err := extKeyUsageToString(s.ExtKeyUsage)
+			if err != nil {
+				extKeyUsage = err.Error()
+			}
+			out.Secret.IssuerCountry = s.IssuerCountry
+			out.Secret.IssuerOrganisation = s.IssuerOrganisation
+			out.Secret.IssuerCommonName = s.IssuerCommonName
+			out.Secret.KeyUsage = keyUsageToString(s.KeyUsage)
+			out.Secret.ExtKeyUsage = extKeyUsage
+			out.Secret.PublicKeyAlgorithm = s.PublicKeyAlgorithm.String()
+			out.Secret.SignatureAlgorithm = s.SignatureAlgorithm.String()
+			out.Secret.SubjectKeyID = hex.EncodeToString(s.SubjectKeyId)
+			out.Secret.AuthorityKeyID = hex.EncodeToString(s.AuthorityKeyId)
+			if s.SerialNumber != nil {
+				out.Secret.SerialNumber = hex.EncodeToString(s.SerialNumber.Bytes())
+			}
+			out.Secret.Events = eventsOutput(s.Events)
+		}
+	}
+
+	if s := status.CRStatus; s != nil {
+		out.CertificateRequest = &CertificateRequestOutput{Error: errorString(s.Error), Name: s.Name,
+			Namespace: s.Namespace, Conditions: s.Conditions, Events: eventsOutput(s.Events)}
+	}
+
+	if s := status.OrderStatus; s != nil {
+		out.Order = &OrderOutput{Error: errorString(s.Error), Name: s.Name, State: s.State,
+			URL: s.URL, AccountURI: s.AccountURI, CertificateURL: s.CertificateURL, Reason: s.Reason, Authorizations: s.Authorizations, FailureTime: s.FailureTime}
+	}
+
+	if s := status.ChallengeStatusList; s != nil {
+		out.Challenges = &ChallengesOutput{Error: errorString(s.Error)}
+		for _, c := range s.ChallengeStatuses {
+			out.Challenges.Items = append(out.Challenges.Items, ChallengeOutput{
+				Name: c.Name, Type: c.Type, Token: c.Token, Key: c.Key, State: c.State,
+				Reason: c.Reason, Processing: c.Processing, Presented: c.Presented,
+			})
+		}
+	}
+
+	return out
+}
+
+func eventsOutput(events *v1.EventList) []EventOutput {
+	if events == nil {
+		return nil
+	}
+	var out []EventOutput
+	for _, e := range events.Items {
+		out = append(out, EventOutput{Type: e.Type, Reason: e.Reason, Message: strings.TrimSpace(e.Message),
+			Count: e.Count, LastTimestamp: e.LastTimestamp})
+	}
+	return out
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return strings.TrimSpace(err.Error())
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func TestCertificateStatusOutput(t *testing.T) {
+	status := &CertificateStatus{
+		Name:      "my-crt",
+		Namespace: "default",
+		DNSNames:  []string{"example.com"},
+		Events: &corev1.EventList{Items: []corev1.Event{
+			{Type: "Normal", Reason: "Issuing", Message: " Issuing certificate \n", Count: 1},
+		}},
+		IssuerStatus: &IssuerStatus{Error: errors.New("issuer not found\n")},
+		SecretStatus: &SecretStatus{
+			Name:               "my-tls",
+			KeyUsage:           x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			ExtKeyUsage:        []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			PublicKeyAlgorithm: x509.ECDSA,
+			SignatureAlgorithm: x509.ECDSAWithSHA256,
+			SerialNumber:       big.NewInt(255),
+		},
+		ChallengeStatusList: &ChallengeStatusList{ChallengeStatuses: []*ChallengeStatus{
+			{Name: "my-challenge", Type: cmacme.ACMEChallengeTypeHTTP01, State: cmacme.Pending},
+		}},
+	}
+
+	assert.Equal(t, &Output{
+		Name:      "my-crt",
+		Namespace: "default",
+		Ready:     true,
+		DNSNames:  []string{"example.com"},
+		Events:    []EventOutput{{Type: "Normal", Reason: "Issuing", Message: "Issuing certificate", Count: 1}},
+		Issuer:    &IssuerOutput{Error: "issuer not found"},
+		Secret: &SecretOutput{
+			Name:               "my-tls",
+			KeyUsage:           "Digital Signature, Key Encipherment",
+			ExtKeyUsage:        "Server Authentication",
+			PublicKeyAlgorithm: "ECDSA",
+			SignatureAlgorithm: "ECDSA-SHA256",
+			SubjectKeyID:       "",
+			AuthorityKeyID:     "",
+			SerialNumber:       "ff",
+		},
+		Challenges: &ChallengesOutput{Items: []ChallengeOutput{
+			{Name: "my-challenge", Type: cmacme.ACMEChallengeTypeHTTP01, State: cmacme.Pending},
+		}},
+	}, status.Output(true))
+}
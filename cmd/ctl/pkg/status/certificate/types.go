@@ -131,6 +131,12 @@ type OrderStatus struct {
 	Name string
 	// State of Order resource
 	State cmacme.State
+	// URL of the order on the ACME server
+	URL string
+	// URI of the ACME account the order was created with
+	AccountURI string
+	// URL of the certificate issued for the order
+	CertificateURL string
 	// Reason why the Order resource is in its State
 	Reason string
 	// What authorizations must be completed to validate the DNS names specified on the Order
@@ -243,6 +249,7 @@ func (status *CertificateStatus) withOrder(order *cmacme.Order, err error) *Cert
 	}
 
 	status.OrderStatus = &OrderStatus{Name: order.Name, State: order.Status.State,
+		URL: order.Status.URL, AccountURI: order.Status.AccountURI, CertificateURL: order.Status.CertificateURL,
 		Reason: order.Status.Reason, Authorizations: order.Status.Authorizations,
 		FailureTime: order.Status.FailureTime}
 	return status
@@ -457,13 +464,22 @@ func (orderStatus *OrderStatus) String() string {
 	output := "Order:\n"
 	output += fmt.Sprintf("  Name: %s\n", orderStatus.Name)
 	output += fmt.Sprintf("  State: %s, Reason: %s\n", orderStatus.State, orderStatus.Reason)
+	if orderStatus.URL != "" {
+		output += fmt.Sprintf("  URL: %s\n", orderStatus.URL)
+	}
+	if orderStatus.AccountURI != "" {
+		output += fmt.Sprintf("  Account URI: %s\n", orderStatus.AccountURI)
+	}
+	if orderStatus.CertificateURL != "" {
+		output += fmt.Sprintf("  Certificate URL: %s\n", orderStatus.CertificateURL)
+	}
 	authString := ""
 	for _, auth := range orderStatus.Authorizations {
 		wildcardString := "nil (bool pointer not set)"
 		if auth.Wildcard != nil {
 			wildcardString = fmt.Sprintf("%t", *auth.Wildcard)
 		}
-		authString += fmt.Sprintf("    URL: %s, Identifier: %s, Initial State: %s, Wildcard: %s\n", auth.URL, auth.Identifier, auth.InitialState, wildcardString)
+		authString += fmt.Sprintf("    URL: %s, Identifier: %s, Initial State: %s, State: %s, Wildcard: %s\n", auth.URL, auth.Identifier, auth.InitialState, auth.State, wildcardString)
 	}
 	if authString == "" {
 		output += "  No Authorizations for this Order\n"
@@ -0,0 +1,210 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// errIssuanceFailed is returned by watchCertificate when the Certificate fails to be
+// issued.
+var errIssuanceFailed = errors.New("issuance of the Certificate failed")
+
+// watchCertificate streams condition transitions of the Certificate and events of
+// related resources until the Certificate becomes Ready, fails to be issued,
+// or the timeout expires.
+func (o *Options) watchCertificate(ctx context.Context, crtName string) error {
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, crtName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when getting Certificate resource: %v", err)
+	}
+
+	for _, line := range conditionTransitions(nil, crt.Status.Conditions) {
+		fmt.Fprintf(o.Out, "%s\t%s\n", formatEventTime(time.Now()), line)
+	}
+	if done, err := issuanceFinished(crt); done {
+		return err
+	}
+
+	crtWatch, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Watch(ctx, metav1.ListOptions{
+		FieldSelector:   fields.OneTermEqualSelector("metadata.name", crtName).String(),
+		ResourceVersion: crt.ResourceVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("error when watching Certificate resource: %v", err)
+	}
+	defer crtWatch.Stop()
+
+	// List events first so that only events emitted from now onwards are
+	// streamed.
+	events, err := o.KubeClient.CoreV1().Events(o.Namespace).List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return fmt.Errorf("error when listing Events: %v", err)
+	}
+	eventWatch, err := o.KubeClient.CoreV1().Events(o.Namespace).Watch(ctx, metav1.ListOptions{
+		ResourceVersion: events.ResourceVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("error when watching Events: %v", err)
+	}
+	defer eventWatch.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for Certificate %q to become Ready", crtName)
+
+		case ev, ok := <-crtWatch.ResultChan():
+			if !ok {
+				return errors.New("watch of Certificate closed unexpectedly")
+			}
+			switch ev.Type {
+			case watch.Deleted:
+				return fmt.Errorf("Certificate %q was deleted", crtName)
+			case watch.Error:
+				return fmt.Errorf("error watching Certificate: %v", ev.Object)
+			}
+			newCrt, ok := ev.Object.(*cmapi.Certificate)
+			if !ok {
+				continue
+			}
+			for _, line := range conditionTransitions(crt.Status.Conditions, newCrt.Status.Conditions) {
+				fmt.Fprintf(o.Out, "%s\t%s\n", formatEventTime(time.Now()), line)
+			}
+			crt = newCrt
+			if done, err := issuanceFinished(crt); done {
+				return err
+			}
+
+		case ev, ok := <-eventWatch.ResultChan():
+			if !ok {
+				return errors.New("watch of Events closed unexpectedly")
+			}
+			if ev.Type != watch.Added && ev.Type != watch.Modified {
+				continue
+			}
+			event, ok := ev.Object.(*corev1.Event)
+			if !ok || !isRelatedEvent(crtName, event) {
+				continue
+			}
+			fmt.Fprintf(o.Out, "%s\tEvent %s %s/%s %s: %s\n", formatEventTime(eventTime(event)),
+				event.Type, event.InvolvedObject.Kind, event.InvolvedObject.Name,
+				event.Reason, strings.TrimSpace(event.Message))
+		}
+	}
+}
+
+// conditionTransitions returns a human readable line for every condition in
+// newConds which was added or whose status, reason or message changed when
+// compared to oldConds.
+func conditionTransitions(oldConds, newConds []cmapi.CertificateCondition) []string {
+	var lines []string
+	for _, newCond := range newConds {
+		oldCond := findCondition(oldConds, newCond.Type)
+		switch {
+		case oldCond == nil:
+			lines = append(lines, fmt.Sprintf("Condition %s: %s (%s) %s",
+				newCond.Type, newCond.Status, newCond.Reason, newCond.Message))
+		case oldCond.Status != newCond.Status:
+			lines = append(lines, fmt.Sprintf("Condition %s: %s -> %s (%s) %s",
+				newCond.Type, oldCond.Status, newCond.Status, newCond.Reason, newCond.Message))
+		case oldCond.Reason != newCond.Reason || oldCond.Message != newCond.Message:
+			lines = append(lines, fmt.Sprintf("Condition %s: %s (%s) %s",
+				newCond.Type, newCond.Status, newCond.Reason, newCond.Message))
+		}
+	}
+
+	for _, oldCond := range oldConds {
+		if findCondition(newConds, oldCond.Type) == nil {
+			lines = append(lines, fmt.Sprintf("Condition %s: removed", oldCond.Type))
+		}
+	}
+
+	return lines
+}
+
+func findCondition(conds []cmapi.CertificateCondition, condType cmapi.CertificateConditionType) *cmapi.CertificateCondition {
+	for i := range conds {
+		if conds[i].Type == condType {
+			return &conds[i]
+		}
+	}
+	return nil
+}
+
+// issuanceFinished returns true if the Certificate is up to date and Ready, or
+// if the latest issuance failed. An error is returned in the latter case.
+func issuanceFinished(crt *cmapi.Certificate) (bool, error) {
+	if issuing := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); issuing != nil {
+		if issuing.Status == cmmeta.ConditionTrue {
+			return false, nil
+		}
+		if issuing.Status == cmmeta.ConditionFalse && issuing.Reason != "Issued" && crt.Status.LastFailureTime != nil {
+			return true, fmt.Errorf("%w: %s: %s", errIssuanceFailed, issuing.Reason, issuing.Message)
+		}
+	}
+
+	return certificateIsReady(crt), nil
+}
+
+// isRelatedEvent returns true if the Event involves the named Certificate or
+// one of the resources created for it. CertificateRequests, Orders and
+// Challenges are all named after their parent resource, so a name prefix
+// match is used for these.
+func isRelatedEvent(crtName string, event *corev1.Event) bool {
+	obj := event.InvolvedObject
+	switch obj.Kind {
+	case cmapi.CertificateKind:
+		return obj.Name == crtName
+	case cmapi.CertificateRequestKind, "Order", "Challenge":
+		return strings.HasPrefix(obj.Name, crtName+"-")
+	default:
+		return false
+	}
+}
+
+// eventTime returns the most recent time the Event was observed.
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func formatEventTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
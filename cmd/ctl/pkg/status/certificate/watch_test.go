@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestConditionTransitions(t *testing.T) {
+	readyFalse := cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse, Reason: "DoesNotExist", Message: "Issuing certificate"}
+	readyTrue := cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, Reason: "Ready", Message: "Certificate is up to date"}
+	issuing := cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue, Reason: "DoesNotExist", Message: "Issuing certificate"}
+
+	tests := map[string]struct {
+		old, new []cmapi.CertificateCondition
+		exp      []string
+	}{
+		"no change": {
+			old: []cmapi.CertificateCondition{readyFalse},
+			new: []cmapi.CertificateCondition{readyFalse},
+		},
+		"condition added": {
+			old: []cmapi.CertificateCondition{readyFalse},
+			new: []cmapi.CertificateCondition{readyFalse, issuing},
+			exp: []string{"Condition Issuing: True (DoesNotExist) Issuing certificate"},
+		},
+		"status changed and condition removed": {
+			old: []cmapi.CertificateCondition{readyFalse, issuing},
+			new: []cmapi.CertificateCondition{readyTrue},
+			exp: []string{
+				"Condition Ready: False -> True (Ready) Certificate is up to date",
+				"Condition Issuing: removed",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, conditionTransitions(test.old, test.new))
+		})
+	}
+}
+
+func TestIssuanceFinished(t *testing.T) {
+	now := metav1.Now()
+
+	tests := map[string]struct {
+		crt     *cmapi.Certificate
+		expDone bool
+		expErr  bool
+	}{
+		"ready with current generation": {
+			crt: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: cmapi.CertificateStatus{Conditions: []cmapi.CertificateCondition{
+					{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 2},
+				}},
+			},
+			expDone: true,
+		},
+		"ready with stale generation": {
+			crt: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: cmapi.CertificateStatus{Conditions: []cmapi.CertificateCondition{
+					{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 1},
+				}},
+			},
+		},
+		"ready but re-issuing": {
+			crt: &cmapi.Certificate{
+				Status: cmapi.CertificateStatus{Conditions: []cmapi.CertificateCondition{
+					{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue},
+					{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue},
+				}},
+			},
+		},
+		"issuance failed": {
+			crt: &cmapi.Certificate{
+				Status: cmapi.CertificateStatus{
+					LastFailureTime: &now,
+					Conditions: []cmapi.CertificateCondition{
+						{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: "Failed"},
+					},
+				},
+			},
+			expDone: true,
+			expErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			done, err := issuanceFinished(test.crt)
+			assert.Equal(t, test.expDone, done)
+			assert.Equal(t, test.expErr, errors.Is(err, errIssuanceFailed))
+		})
+	}
+}
+
+func TestIsRelatedEvent(t *testing.T) {
+	tests := map[string]struct {
+		obj corev1.ObjectReference
+		exp bool
+	}{
+		"the Certificate":           {obj: corev1.ObjectReference{Kind: "Certificate", Name: "my-crt"}, exp: true},
+		"another Certificate":       {obj: corev1.ObjectReference{Kind: "Certificate", Name: "my-crt-2"}},
+		"child CertificateRequest":  {obj: corev1.ObjectReference{Kind: "CertificateRequest", Name: "my-crt-x7k2p"}, exp: true},
+		"child Challenge":           {obj: corev1.ObjectReference{Kind: "Challenge", Name: "my-crt-x7k2p-1234-5678"}, exp: true},
+		"unrelated Order":           {obj: corev1.ObjectReference{Kind: "Order", Name: "other-x7k2p-1234"}},
+		"Secret with matching name": {obj: corev1.ObjectReference{Kind: "Secret", Name: "my-crt"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, isRelatedEvent("my-crt", &corev1.Event{InvolvedObject: test.obj}))
+		})
+	}
+}
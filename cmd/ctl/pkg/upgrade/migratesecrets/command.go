@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migratesecrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Re-key Certificates whose private key uses one algorithm or size to another, e.g. to migrate
+all RSA-2048 keys to ECDSA P-256.
+
+The private key spec of every matching Certificate is patched, and its rotation policy is
+set to 'Always' so that cert-manager generates a new private key. Certificates are migrated
+in stages of --batch-size, and each stage waits for all of its Certificates to be re-issued
+and Ready before the next stage begins. The migration stops after the first stage in
+which a Certificate fails to be re-issued.
+
+Key specs have the form ALGORITHM[-SIZE], e.g. RSA-2048, ECDSA-256 or Ed25519. If no size is
+given for --from, Certificates of any size using that algorithm are selected. If no size is
+given for --to, the cert-manager default size for the algorithm is used.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Show which Certificates in all namespaces would be migrated from RSA-2048 to ECDSA P-256
+{{.BuildName}} upgrade migrate-secrets --from RSA-2048 --to ECDSA-256 --all-namespaces --dry-run
+
+# Migrate Certificates with the label 'app=my-service' from RSA to Ed25519, two at a time
+{{.BuildName}} upgrade migrate-secrets --from RSA --to Ed25519 -l app=my-service --batch-size 2
+`)))
+)
+
+// Options is a struct to support migrate-secrets command
+type Options struct {
+	// From is the key spec of Certificates to migrate.
+	From string
+	// To is the key spec Certificates are migrated to.
+	To string
+	// LabelSelector restricts the migration to matching Certificates.
+	LabelSelector string
+	// AllNamespaces migrates Certificates in all namespaces.
+	AllNamespaces bool
+	// BatchSize is the number of Certificates re-issued in each stage.
+	BatchSize int
+	// Timeout is the time each stage may take to become Ready.
+	Timeout time.Duration
+	// DryRun only prints the Certificates which would be migrated.
+	DryRun bool
+
+	from, to keySpec
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdMigrateSecrets returns a cobra command for re-keying Certificates.
+func NewCmdMigrateSecrets(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:     "migrate-secrets",
+		Short:   "Re-key Certificates from one private key algorithm or size to another",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.From, "from", o.From, "Key spec of the Certificates to migrate, e.g. RSA-2048")
+	cmd.Flags().StringVar(&o.To, "to", o.To, "Key spec to migrate the Certificates to, e.g. ECDSA-256")
+	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If present, migrate Certificates across namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	cmd.Flags().IntVar(&o.BatchSize, "batch-size", 5, "Number of Certificates to re-issue in each stage")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 10*time.Minute, "Time to wait for each stage to be re-issued, must include unit, e.g. 10m or 1h")
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", o.DryRun, "If true, only print the Certificates which would be migrated")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("migrate-secrets does not accept arguments, use --selector to select Certificates")
+	}
+
+	var err error
+	if o.From == "" || o.To == "" {
+		return errors.New("both --from and --to must be specified")
+	}
+	if o.from, err = parseKeySpec(o.From); err != nil {
+		return fmt.Errorf("invalid --from: %w", err)
+	}
+	if o.to, err = parseKeySpec(o.To); err != nil {
+		return fmt.Errorf("invalid --to: %w", err)
+	}
+	o.to = o.to.withDefaults()
+
+	if o.BatchSize < 1 {
+		return errors.New("--batch-size must be at least 1")
+	}
+	if o.Timeout <= 0 {
+		return errors.New("--timeout must be greater than 0")
+	}
+
+	return nil
+}
+
+// Run executes migrate-secrets command
+func (o *Options) Run(ctx context.Context) error {
+	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+
+	crtList, err := o.CMClient.CertmanagerV1().Certificates(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: o.LabelSelector,
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Certificates: %w", err)
+	}
+
+	crts := selectCertificates(crtList.Items, o.from, o.to)
+	if len(crts) == 0 {
+		fmt.Fprintf(o.ErrOut, "No Certificates with key spec %s found\n", o.from)
+		return nil
+	}
+
+	fmt.Fprintf(o.Out, "Found %d Certificates to migrate from %s to %s\n", len(crts), o.from, o.to)
+	if o.DryRun {
+		for _, crt := range crts {
+			fmt.Fprintf(o.Out, "  %s/%s (%s)\n", crt.Namespace, crt.Name, specOf(&crt))
+		}
+		return nil
+	}
+
+	return newMigrator(o.CMClient, o.to, o.BatchSize, o.Timeout, o.Out).run(ctx, crts)
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migratesecrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// keySpec is a private key algorithm and size. A Size of 0 means any size
+// when selecting Certificates, or the default size when migrating.
+type keySpec struct {
+	Algorithm cmapi.PrivateKeyAlgorithm
+	Size      int
+}
+
+// parseKeySpec parses a key spec of the form ALGORITHM[-SIZE].
+func parseKeySpec(s string) (keySpec, error) {
+	algorithm, size, hasSize := strings.Cut(s, "-")
+
+	var spec keySpec
+	switch strings.ToLower(algorithm) {
+	case "rsa":
+		spec.Algorithm = cmapi.RSAKeyAlgorithm
+	case "ecdsa":
+		spec.Algorithm = cmapi.ECDSAKeyAlgorithm
+	case "ed25519":
+		spec.Algorithm = cmapi.Ed25519KeyAlgorithm
+	default:
+		return keySpec{}, fmt.Errorf("unsupported private key algorithm %q, must be one of RSA, ECDSA or Ed25519", algorithm)
+	}
+
+	if !hasSize {
+		return spec, nil
+	}
+	if spec.Algorithm == cmapi.Ed25519KeyAlgorithm {
+		return keySpec{}, fmt.Errorf("a size cannot be specified for %s keys", spec.Algorithm)
+	}
+
+	var err error
+	spec.Size, err = strconv.Atoi(strings.TrimPrefix(strings.ToUpper(size), "P"))
+	if err != nil {
+		return keySpec{}, fmt.Errorf("invalid key size %q", size)
+	}
+
+	switch spec.Algorithm {
+	case cmapi.RSAKeyAlgorithm:
+		if spec.Size < pki.MinRSAKeySize || spec.Size > pki.MaxRSAKeySize {
+			return keySpec{}, fmt.Errorf("RSA key size must be between %d and %d", pki.MinRSAKeySize, pki.MaxRSAKeySize)
+		}
+	case cmapi.ECDSAKeyAlgorithm:
+		if spec.Size != pki.ECCurve256 && spec.Size != pki.ECCurve384 && spec.Size != pki.ECCurve521 {
+			return keySpec{}, fmt.Errorf("ECDSA key size must be one of %d, %d or %d", pki.ECCurve256, pki.ECCurve384, pki.ECCurve521)
+		}
+	}
+
+	return spec, nil
+}
+
+// withDefaults returns the spec with the size cert-manager uses by default
+// for the algorithm if no size is set.
+func (s keySpec) withDefaults() keySpec {
+	if s.Algorithm == "" {
+		s.Algorithm = cmapi.RSAKeyAlgorithm
+	}
+	if s.Size == 0 {
+		switch s.Algorithm {
+		case cmapi.RSAKeyAlgorithm:
+			s.Size = pki.MinRSAKeySize
+		case cmapi.ECDSAKeyAlgorithm:
+			s.Size = pki.ECCurve256
+		}
+	}
+	return s
+}
+
+func (s keySpec) String() string {
+	if s.Size == 0 {
+		return string(s.Algorithm)
+	}
+	return fmt.Sprintf("%s-%d", s.Algorithm, s.Size)
+}
+
+// matches returns true if other, which must have defaults applied, is
+// selected by the spec.
+func (s keySpec) matches(other keySpec) bool {
+	return s.Algorithm == other.Algorithm && (s.Size == 0 || s.Size == other.Size)
+}
+
+// specOf returns the effective private key spec of the Certificate.
+func specOf(crt *cmapi.Certificate) keySpec {
+	var spec keySpec
+	if crt.Spec.PrivateKey != nil {
+		spec = keySpec{Algorithm: crt.Spec.PrivateKey.Algorithm, Size: crt.Spec.PrivateKey.Size}
+	}
+	return spec.withDefaults()
+}
+
+// selectCertificates returns the Certificates whose key spec matches from,
+// and which are not already using the spec to, sorted by namespace and name.
+func selectCertificates(crts []cmapi.Certificate, from, to keySpec) []cmapi.Certificate {
+	var selected []cmapi.Certificate
+	for _, crt := range crts {
+		spec := specOf(&crt)
+		if from.matches(spec) && spec != to {
+			selected = append(selected, crt)
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Namespace != selected[j].Namespace {
+			return selected[i].Namespace < selected[j].Namespace
+		}
+		return selected[i].Name < selected[j].Name
+	})
+
+	return selected
+}
+
+// privateKeyPatch returns a merge patch setting the Certificate's private key
+// spec to the given spec. The rotation policy is set to Always, since
+// cert-manager will not generate a new private key for a Certificate with a
+// rotation policy of Never.
+func privateKeyPatch(to keySpec) ([]byte, error) {
+	privateKey := map[string]interface{}{
+		"algorithm":      to.Algorithm,
+		"size":           nil,
+		"rotationPolicy": cmapi.RotationPolicyAlways,
+	}
+	if to.Size != 0 {
+		privateKey["size"] = to.Size
+	}
+	return json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"privateKey": privateKey,
+		},
+	})
+}
+
+// migrationStatus returns true once the Certificate has been re-issued for
+// the given generation and is Ready, and an error if re-issuance failed after
+// the given time.
+func migrationStatus(crt *cmapi.Certificate, generation int64, since time.Time) (bool, error) {
+	if crt.Status.LastFailureTime != nil && !crt.Status.LastFailureTime.Time.Before(since) {
+		msg := "re-issuance failed"
+		if issuing := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); issuing != nil && issuing.Status == cmmeta.ConditionFalse {
+			msg = fmt.Sprintf("%s: %s: %s", msg, issuing.Reason, issuing.Message)
+		}
+		return true, errors.New(msg)
+	}
+
+	if apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing) != nil {
+		return false, nil
+	}
+
+	return apiutil.CertificateHasConditionWithObservedGeneration(crt, cmapi.CertificateCondition{
+		Type:               cmapi.CertificateConditionReady,
+		Status:             cmmeta.ConditionTrue,
+		ObservedGeneration: generation,
+	}), nil
+}
+
+// migrator patches Certificates in stages and waits for each stage to be
+// re-issued.
+type migrator struct {
+	client    cmclient.Interface
+	to        keySpec
+	batchSize int
+	timeout   time.Duration
+	interval  time.Duration
+	out       io.Writer
+}
+
+func newMigrator(client cmclient.Interface, to keySpec, batchSize int, timeout time.Duration, out io.Writer) *migrator {
+	return &migrator{
+		client:    client,
+		to:        to,
+		batchSize: batchSize,
+		timeout:   timeout,
+		interval:  2 * time.Second,
+		out:       out,
+	}
+}
+
+// run migrates the Certificates, stopping after the first stage in which a
+// Certificate could not be migrated.
+func (m *migrator) run(ctx context.Context, crts []cmapi.Certificate) error {
+	patch, err := privateKeyPatch(m.to)
+	if err != nil {
+		return err
+	}
+
+	stages := (len(crts) + m.batchSize - 1) / m.batchSize
+	migrated := 0
+	for stage := 0; stage < stages; stage++ {
+		start := stage * m.batchSize
+		end := start + m.batchSize
+		if end > len(crts) {
+			end = len(crts)
+		}
+
+		fmt.Fprintf(m.out, "Stage %d/%d: migrating %d Certificates\n", stage+1, stages, end-start)
+		failed := m.runStage(ctx, crts[start:end], patch)
+		migrated += end - start - failed
+		fmt.Fprintf(m.out, "Progress: %d/%d Certificates migrated\n", migrated, len(crts))
+
+		if failed > 0 {
+			return fmt.Errorf("%d Certificates failed to migrate in stage %d, not continuing with remaining stages", failed, stage+1)
+		}
+	}
+
+	fmt.Fprintf(m.out, "All %d Certificates migrated to %s\n", len(crts), m.to)
+	return nil
+}
+
+// runStage patches every Certificate in the stage and waits for them to be
+// re-issued. It returns the number of Certificates which failed to migrate.
+func (m *migrator) runStage(ctx context.Context, crts []cmapi.Certificate, patch []byte) int {
+	since := time.Now().Truncate(time.Second)
+
+	type pendingCertificate struct {
+		crt        cmapi.Certificate
+		generation int64
+	}
+	var pending []pendingCertificate
+	failed := 0
+
+	for _, crt := range crts {
+		patched, err := m.client.CertmanagerV1().Certificates(crt.Namespace).Patch(ctx, crt.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			fmt.Fprintf(m.out, "  %s/%s: failed to patch: %v\n", crt.Namespace, crt.Name, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(m.out, "  %s/%s: patched %s -> %s\n", crt.Namespace, crt.Name, specOf(&crt), m.to)
+		pending = append(pending, pendingCertificate{crt: crt, generation: patched.Generation})
+	}
+
+	stageCtx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	for _, p := range pending {
+		var migrationErr error
+		err := wait.PollImmediateUntil(m.interval, func() (bool, error) {
+			current, err := m.client.CertmanagerV1().Certificates(p.crt.Namespace).Get(stageCtx, p.crt.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, nil
+			}
+			var done bool
+			done, migrationErr = migrationStatus(current, p.generation, since)
+			return done, nil
+		}, stageCtx.Done())
+
+		switch {
+		case migrationErr != nil:
+			fmt.Fprintf(m.out, "  %s/%s: %v\n", p.crt.Namespace, p.crt.Name, migrationErr)
+			failed++
+		case err != nil:
+			fmt.Fprintf(m.out, "  %s/%s: timed out waiting to be re-issued\n", p.crt.Namespace, p.crt.Name)
+			failed++
+		default:
+			fmt.Fprintf(m.out, "  %s/%s: re-issued and Ready\n", p.crt.Namespace, p.crt.Name)
+		}
+	}
+
+	return failed
+}
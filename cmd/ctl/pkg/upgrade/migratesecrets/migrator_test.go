@@ -0,0 +1,226 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migratesecrets
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestParseKeySpec(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		exp    keySpec
+		expErr bool
+	}{
+		"algorithm only": {
+			input: "rsa",
+			exp:   keySpec{Algorithm: cmapi.RSAKeyAlgorithm},
+		},
+		"RSA with size": {
+			input: "RSA-4096",
+			exp:   keySpec{Algorithm: cmapi.RSAKeyAlgorithm, Size: 4096},
+		},
+		"ECDSA with curve name": {
+			input: "ECDSA-P384",
+			exp:   keySpec{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384},
+		},
+		"Ed25519": {
+			input: "Ed25519",
+			exp:   keySpec{Algorithm: cmapi.Ed25519KeyAlgorithm},
+		},
+		"Ed25519 with size errors": {
+			input:  "Ed25519-256",
+			expErr: true,
+		},
+		"weak RSA size errors": {
+			input:  "RSA-1024",
+			expErr: true,
+		},
+		"unsupported ECDSA curve errors": {
+			input:  "ECDSA-224",
+			expErr: true,
+		},
+		"unknown algorithm errors": {
+			input:  "DSA-2048",
+			expErr: true,
+		},
+		"invalid size errors": {
+			input:  "RSA-big",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec, err := parseKeySpec(test.input)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.exp, spec)
+		})
+	}
+}
+
+func TestSelectCertificates(t *testing.T) {
+	defaultRSA := gen.Certificate("default-rsa", gen.SetCertificateNamespace("b"))
+	rsa2048 := gen.Certificate("rsa-2048", gen.SetCertificateNamespace("a"),
+		gen.SetCertificateKeyAlgorithm(cmapi.RSAKeyAlgorithm), gen.SetCertificateKeySize(2048))
+	rsa4096 := gen.Certificate("rsa-4096", gen.SetCertificateNamespace("a"),
+		gen.SetCertificateKeyAlgorithm(cmapi.RSAKeyAlgorithm), gen.SetCertificateKeySize(4096))
+	ecdsa := gen.Certificate("ecdsa", gen.SetCertificateNamespace("a"),
+		gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm))
+	crts := []cmapi.Certificate{*defaultRSA, *rsa2048, *rsa4096, *ecdsa}
+
+	tests := map[string]struct {
+		from, to keySpec
+		exp      []string
+	}{
+		"RSA-2048 includes Certificates using the default key spec": {
+			from: keySpec{Algorithm: cmapi.RSAKeyAlgorithm, Size: 2048},
+			to:   keySpec{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256},
+			exp:  []string{"a/rsa-2048", "b/default-rsa"},
+		},
+		"RSA of any size": {
+			from: keySpec{Algorithm: cmapi.RSAKeyAlgorithm},
+			to:   keySpec{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256},
+			exp:  []string{"a/rsa-2048", "a/rsa-4096", "b/default-rsa"},
+		},
+		"Certificates already using the target spec are skipped": {
+			from: keySpec{Algorithm: cmapi.RSAKeyAlgorithm},
+			to:   keySpec{Algorithm: cmapi.RSAKeyAlgorithm, Size: 4096},
+			exp:  []string{"a/rsa-2048", "b/default-rsa"},
+		},
+		"ECDSA with default size": {
+			from: keySpec{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256},
+			to:   keySpec{Algorithm: cmapi.Ed25519KeyAlgorithm},
+			exp:  []string{"a/ecdsa"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var names []string
+			for _, crt := range selectCertificates(crts, test.from, test.to) {
+				names = append(names, crt.Namespace+"/"+crt.Name)
+			}
+			assert.Equal(t, test.exp, names)
+		})
+	}
+}
+
+func TestPrivateKeyPatch(t *testing.T) {
+	patch, err := privateKeyPatch(keySpec{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"spec":{"privateKey":{"algorithm":"ECDSA","size":256,"rotationPolicy":"Always"}}}`, string(patch))
+
+	patch, err = privateKeyPatch(keySpec{Algorithm: cmapi.Ed25519KeyAlgorithm})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"spec":{"privateKey":{"algorithm":"Ed25519","size":null,"rotationPolicy":"Always"}}}`, string(patch))
+}
+
+func TestMigrationStatus(t *testing.T) {
+	since := time.Now()
+	ready := gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+		Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 2})
+
+	tests := map[string]struct {
+		crt     *cmapi.Certificate
+		expDone bool
+		expErr  bool
+	}{
+		"Ready for the patched generation is done": {
+			crt:     gen.Certificate("test", gen.SetCertificateGeneration(2), ready),
+			expDone: true,
+		},
+		"Ready for an older generation is not done": {
+			crt: gen.Certificate("test", gen.SetCertificateGeneration(3), gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 1})),
+		},
+		"Ready but still issuing is not done": {
+			crt: gen.Certificate("test", gen.SetCertificateGeneration(2), ready, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue, ObservedGeneration: 2})),
+		},
+		"failure before the stage started is ignored": {
+			crt: gen.Certificate("test", gen.SetCertificateGeneration(2), ready,
+				gen.SetCertificateLastFailureTime(metav1.NewTime(since.Add(-time.Hour)))),
+			expDone: true,
+		},
+		"failure after the stage started errors": {
+			crt: gen.Certificate("test", gen.SetCertificateGeneration(2), gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: "Failed", Message: "denied"}),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(since.Add(time.Second)))),
+			expDone: true,
+			expErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			done, err := migrationStatus(test.crt, test.crt.Generation, since)
+			assert.Equal(t, test.expDone, done)
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMigratorRun(t *testing.T) {
+	ready := gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+		Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue})
+
+	var objects []runtime.Object
+	var crts []cmapi.Certificate
+	for _, name := range []string{"a", "b", "c"} {
+		crt := gen.Certificate(name, gen.SetCertificateNamespace("default"), ready)
+		objects = append(objects, crt)
+		crts = append(crts, *crt)
+	}
+
+	client := fake.NewSimpleClientset(objects...)
+	to := keySpec{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384}
+	out := new(bytes.Buffer)
+	m := newMigrator(client, to, 2, time.Second, out)
+	m.interval = time.Millisecond
+
+	assert.NoError(t, m.run(context.TODO(), crts))
+	assert.Contains(t, out.String(), "Stage 2/2: migrating 1 Certificates")
+	assert.Contains(t, out.String(), "Progress: 3/3 Certificates migrated")
+
+	for _, crt := range crts {
+		patched, err := client.CertmanagerV1().Certificates(crt.Namespace).Get(context.TODO(), crt.Name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, to, specOf(patched))
+		assert.Equal(t, cmapi.RotationPolicyAlways, patched.Spec.PrivateKey.RotationPolicy)
+	}
+}
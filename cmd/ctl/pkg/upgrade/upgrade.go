@@ -23,6 +23,7 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/upgrade/migrateapiversion"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/upgrade/migratesecrets"
 )
 
 func NewCmdUpgrade(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
@@ -33,6 +34,7 @@ func NewCmdUpgrade(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 	}
 
 	cmds.AddCommand(migrateapiversion.NewCmdMigrate(ctx, ioStreams))
+	cmds.AddCommand(migratesecrets.NewCmdMigrateSecrets(ctx, ioStreams))
 
 	return cmds
 }
@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package x509gen
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+var caExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Generate a self-signed ECDSA root CA valid for one year
+{{.BuildName}} x x509 generate-ca --common-name "my-root-ca" --key-algorithm ECDSA --duration 8760h -k ca.key -o ca.crt
+
+# Generate a self-signed CA from a Certificate manifest, as the SelfSigned issuer would
+{{.BuildName}} x x509 generate-ca -f my-ca-certificate.yaml -k ca.key -o ca.crt
+`)))
+
+// caOptions is a struct to support the generate-ca command
+type caOptions struct {
+	certificateOptions
+
+	// KeyFile is an existing private key to use for the CA. If not set, a new
+	// private key is generated and written to OutputKeyFile.
+	KeyFile string
+	// OutputKeyFile is the file a generated private key is written to.
+	OutputKeyFile string
+	// OutputCertificateFile is the file the PEM encoded CA certificate is
+	// written to.
+	OutputCertificateFile string
+
+	genericclioptions.IOStreams
+}
+
+func newCmdGenerateCA(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &caOptions{IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:   "generate-ca",
+		Short: "Generate a self-signed CA certificate",
+		Long: `Generate a self-signed CA certificate, in the same way as the SelfSigned issuer
+would sign a Certificate with isCA set to true.`,
+		Example: caExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.KeyFile, "key-file", "",
+		"Path to an existing private key to use; if not set a new private key is generated")
+	cmd.Flags().StringVarP(&o.OutputKeyFile, "output-key-file", "k", "",
+		"Name of the file a newly generated private key will be written to")
+	cmd.Flags().StringVarP(&o.OutputCertificateFile, "output-certificate-file", "o", "",
+		"Name of the file the generated CA certificate will be written to")
+	cmd.Flags().DurationVar(&o.Duration, "duration", 0,
+		"The validity duration of the CA certificate; defaults to 90 days")
+	o.addSpecFlags(cmd.Flags())
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *caOptions) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("generate-ca does not accept arguments")
+	}
+	if len(o.OutputCertificateFile) == 0 {
+		return errors.New("the file to write the CA certificate to must be specified with --output-certificate-file")
+	}
+	return validateKeyFiles(o.KeyFile, o.OutputKeyFile, o.OutputCertificateFile)
+}
+
+// Run executes the generate-ca command
+func (o *caOptions) Run(ctx context.Context) error {
+	o.IsCA = true
+	crt, err := o.certificate()
+	if err != nil {
+		return err
+	}
+	crt.Spec.IsCA = true
+
+	signer, generated, err := loadOrGenerateKey(crt, o.KeyFile, o.OutputKeyFile)
+	if err != nil {
+		return err
+	}
+	if generated {
+		fmt.Fprintf(o.Out, "Private key written to file %s\n", o.OutputKeyFile)
+	}
+
+	certPEM, err := buildSelfSignedCA(crt, signer)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(o.OutputCertificateFile, certPEM, 0644); err != nil {
+		return fmt.Errorf("error when writing CA certificate to file: %w", err)
+	}
+	fmt.Fprintf(o.Out, "CA certificate written to file %s\n", o.OutputCertificateFile)
+
+	return nil
+}
+
+// buildSelfSignedCA generates a PEM encoded self-signed certificate for the
+// Certificate. The CSR is generated and parsed before signing so that the
+// resulting certificate is identical to one signed by the SelfSigned issuer.
+func buildSelfSignedCA(crt *cmapi.Certificate, signer crypto.Signer) ([]byte, error) {
+	csrPEM, err := buildCSR(crt, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := &cmapi.CertificateRequest{
+		Spec: cmapi.CertificateRequestSpec{
+			Request:  csrPEM,
+			Duration: crt.Spec.Duration,
+			IsCA:     crt.Spec.IsCA,
+			Usages:   crt.Spec.Usages,
+		},
+	}
+
+	template, err := pki.GenerateTemplateFromCertificateRequest(cr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificate template: %w", err)
+	}
+
+	certPEM, _, err := pki.SignCertificate(template, template, signer.Public(), signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return certPEM, nil
+}
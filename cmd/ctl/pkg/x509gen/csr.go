@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package x509gen
+
+import (
+	"context"
+	"crypto"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+var csrExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Generate a new private key and a CSR for example.com
+{{.BuildName}} x x509 generate-csr --dns-name example.com --output-key-file tls.key --output-csr-file tls.csr
+
+# Generate a CSR for an existing private key with client auth usage
+{{.BuildName}} x x509 generate-csr --common-name alice --usage "client auth" --key-file tls.key --output-csr-file tls.csr
+
+# Generate a CSR from a Certificate manifest, exactly as cert-manager would
+{{.BuildName}} x x509 generate-csr -f my-certificate.yaml -k tls.key -o tls.csr
+`)))
+
+// csrOptions is a struct to support the generate-csr command
+type csrOptions struct {
+	certificateOptions
+
+	// KeyFile is an existing private key to use for the CSR. If not set, a
+	// new private key is generated and written to OutputKeyFile.
+	KeyFile string
+	// OutputKeyFile is the file a generated private key is written to.
+	OutputKeyFile string
+	// OutputCSRFile is the file the PEM encoded CSR is written to.
+	OutputCSRFile string
+
+	genericclioptions.IOStreams
+}
+
+func newCmdGenerateCSR(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &csrOptions{IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:     "generate-csr",
+		Short:   "Generate an x509 certificate signing request",
+		Long:    "Generate a PEM encoded x509 certificate signing request, in the same format as cert-manager would for a Certificate.",
+		Example: csrExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.KeyFile, "key-file", "",
+		"Path to an existing private key to use; if not set a new private key is generated")
+	cmd.Flags().StringVarP(&o.OutputKeyFile, "output-key-file", "k", "",
+		"Name of the file a newly generated private key will be written to")
+	cmd.Flags().StringVarP(&o.OutputCSRFile, "output-csr-file", "o", "",
+		"Name of the file the generated CSR will be written to")
+	o.addSpecFlags(cmd.Flags())
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *csrOptions) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("generate-csr does not accept arguments")
+	}
+	if len(o.OutputCSRFile) == 0 {
+		return errors.New("the file to write the CSR to must be specified with --output-csr-file")
+	}
+	return validateKeyFiles(o.KeyFile, o.OutputKeyFile, o.OutputCSRFile)
+}
+
+// Run executes the generate-csr command
+func (o *csrOptions) Run(ctx context.Context) error {
+	crt, err := o.certificate()
+	if err != nil {
+		return err
+	}
+
+	signer, generated, err := loadOrGenerateKey(crt, o.KeyFile, o.OutputKeyFile)
+	if err != nil {
+		return err
+	}
+	if generated {
+		fmt.Fprintf(o.Out, "Private key written to file %s\n", o.OutputKeyFile)
+	}
+
+	csrPEM, err := buildCSR(crt, signer)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(o.OutputCSRFile, csrPEM, 0644); err != nil {
+		return fmt.Errorf("error when writing CSR to file: %w", err)
+	}
+	fmt.Fprintf(o.Out, "CSR written to file %s\n", o.OutputCSRFile)
+
+	return nil
+}
+
+// buildCSR generates a PEM encoded CSR for the Certificate, signed by signer.
+func buildCSR(crt *cmapi.Certificate, signer crypto.Signer) ([]byte, error) {
+	csr, err := pki.GenerateCSR(crt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CSR: %w", err)
+	}
+
+	csrDER, err := pki.EncodeCSR(csr, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}), nil
+}
+
+// validateKeyFiles ensures that exactly one of keyFile or outputKeyFile is
+// set, and that the output files do not collide.
+func validateKeyFiles(keyFile, outputKeyFile, outputFile string) error {
+	if len(keyFile) > 0 && len(outputKeyFile) > 0 {
+		return errors.New("cannot specify both --key-file and --output-key-file")
+	}
+	if len(keyFile) == 0 && len(outputKeyFile) == 0 {
+		return errors.New("either an existing private key must be given with --key-file, or a file to write a new private key to with --output-key-file")
+	}
+	if outputKeyFile == outputFile {
+		return errors.New("the file to store the private key cannot be the same as the output file")
+	}
+	return nil
+}
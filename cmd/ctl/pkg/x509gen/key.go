@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package x509gen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var keyExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Generate a 2048 bit RSA private key in PKCS#1 encoding
+{{.BuildName}} x x509 generate-key --output-key-file tls.key
+
+# Generate a P-384 ECDSA private key in PKCS#8 encoding
+{{.BuildName}} x x509 generate-key -k tls.key --key-algorithm ECDSA --key-size 384 --key-encoding PKCS8
+
+# Generate an Ed25519 private key
+{{.BuildName}} x x509 generate-key -k tls.key --key-algorithm Ed25519 --key-encoding PKCS8
+`)))
+
+// keyOptions is a struct to support the generate-key command
+type keyOptions struct {
+	certificateOptions
+
+	// OutputKeyFile is the file the generated private key is written to.
+	OutputKeyFile string
+
+	genericclioptions.IOStreams
+}
+
+func newCmdGenerateKey(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &keyOptions{IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:     "generate-key",
+		Short:   "Generate a private key",
+		Long:    "Generate a private key in the same format as cert-manager would for a Certificate's spec.privateKey.",
+		Example: keyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.OutputKeyFile, "output-key-file", "k", "",
+		"Name of the file the generated private key will be written to")
+	o.addKeyFlags(cmd.Flags())
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *keyOptions) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("generate-key does not accept arguments")
+	}
+	if len(o.OutputKeyFile) == 0 {
+		return errors.New("the file to write the private key to must be specified with --output-key-file")
+	}
+	return nil
+}
+
+// Run executes the generate-key command
+func (o *keyOptions) Run(ctx context.Context) error {
+	crt := &cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			PrivateKey: &cmapi.CertificatePrivateKey{
+				Algorithm: cmapi.PrivateKeyAlgorithm(o.KeyAlgorithm),
+				Size:      o.KeySize,
+				Encoding:  cmapi.PrivateKeyEncoding(o.KeyEncoding),
+			},
+		},
+	}
+
+	_, keyPEM, err := generateKey(crt)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(o.OutputKeyFile, keyPEM, 0600); err != nil {
+		return fmt.Errorf("error when writing private key to file: %w", err)
+	}
+	fmt.Fprintf(o.Out, "Private key written to file %s\n", o.OutputKeyFile)
+
+	return nil
+}
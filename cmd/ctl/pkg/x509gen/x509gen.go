@@ -0,0 +1,256 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package x509gen contains commands which generate private keys, certificate
+// signing requests and self-signed CAs locally, using the same code paths as
+// the cert-manager controllers so that the output is identical to what would
+// be produced in-cluster.
+package x509gen
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/ctl"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// NewCmdX509 returns the parent command for the local x509 generation
+// commands.
+func NewCmdX509(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "x509",
+		Short: "Generate private keys, CSRs and self-signed CAs locally",
+		Long: `Generate private keys, certificate signing requests and self-signed CAs locally,
+in the same formats that cert-manager produces in-cluster. Useful for test
+fixtures and bootstrapping CAs.`,
+	}
+
+	cmds.AddCommand(newCmdGenerateKey(ctx, ioStreams))
+	cmds.AddCommand(newCmdGenerateCSR(ctx, ioStreams))
+	cmds.AddCommand(newCmdGenerateCA(ctx, ioStreams))
+
+	return cmds
+}
+
+// certificateOptions are the options shared by all the generate commands,
+// used to build a Certificate resource either from a file or from flags.
+type certificateOptions struct {
+	// InputFilename is the path to a file containing a Certificate resource to
+	// use as a template. If set, the spec flags are ignored.
+	InputFilename string
+
+	CommonName     string
+	Organizations  []string
+	DNSNames       []string
+	IPAddresses    []string
+	URIs           []string
+	EmailAddresses []string
+	Usages         []string
+	IsCA           bool
+	Duration       time.Duration
+
+	KeyAlgorithm string
+	KeySize      int
+	KeyEncoding  string
+}
+
+func (o *certificateOptions) addKeyFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.KeyAlgorithm, "key-algorithm", string(cmapi.RSAKeyAlgorithm),
+		"The private key algorithm, one of RSA, ECDSA or Ed25519")
+	fs.IntVar(&o.KeySize, "key-size", 0,
+		"The private key size; defaults to 2048 for RSA and 256 for ECDSA, ignored for Ed25519")
+	fs.StringVar(&o.KeyEncoding, "key-encoding", string(cmapi.PKCS1),
+		"The private key encoding, one of PKCS1 or PKCS8")
+}
+
+func (o *certificateOptions) addSpecFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.InputFilename, "from-certificate-file", "f", "",
+		"Path to a file containing a Certificate resource used as a template; overrides all other spec flags")
+	fs.StringVar(&o.CommonName, "common-name", "", "The common name to request")
+	fs.StringSliceVar(&o.Organizations, "organization", nil, "Organizations to request in the subject")
+	fs.StringSliceVar(&o.DNSNames, "dns-name", nil, "DNS subject alternative names to request")
+	fs.StringSliceVar(&o.IPAddresses, "ip-address", nil, "IP address subject alternative names to request")
+	fs.StringSliceVar(&o.URIs, "uri", nil, "URI subject alternative names to request")
+	fs.StringSliceVar(&o.EmailAddresses, "email-address", nil, "Email subject alternative names to request")
+	fs.StringSliceVar(&o.Usages, "usage", nil, "Key usages to request, e.g. 'digital signature', 'server auth'")
+	fs.BoolVar(&o.IsCA, "is-ca", false, "Request a CA certificate")
+	o.addKeyFlags(fs)
+}
+
+// certificate returns the Certificate resource described by the options.
+func (o *certificateOptions) certificate() (*cmapi.Certificate, error) {
+	if len(o.InputFilename) > 0 {
+		return readCertificateFile(o.InputFilename)
+	}
+
+	crt := &cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			CommonName:     o.CommonName,
+			DNSNames:       o.DNSNames,
+			IPAddresses:    o.IPAddresses,
+			URIs:           o.URIs,
+			EmailAddresses: o.EmailAddresses,
+			IsCA:           o.IsCA,
+			PrivateKey: &cmapi.CertificatePrivateKey{
+				Algorithm: cmapi.PrivateKeyAlgorithm(o.KeyAlgorithm),
+				Size:      o.KeySize,
+				Encoding:  cmapi.PrivateKeyEncoding(o.KeyEncoding),
+			},
+		},
+	}
+	if len(o.Organizations) > 0 {
+		crt.Spec.Subject = &cmapi.X509Subject{Organizations: o.Organizations}
+	}
+	for _, usage := range o.Usages {
+		crt.Spec.Usages = append(crt.Spec.Usages, cmapi.KeyUsage(usage))
+	}
+	if o.Duration > 0 {
+		crt.Spec.Duration = &metav1.Duration{Duration: o.Duration}
+	}
+
+	return crt, nil
+}
+
+// readCertificateFile decodes a single Certificate resource from the given
+// file, in any API version known to cert-manager.
+func readCertificateFile(filename string) (*cmapi.Certificate, error) {
+	r := new(resource.Builder).
+		WithScheme(ctl.Scheme, schema.GroupVersion{Group: cmapi.SchemeGroupVersion.Group, Version: runtime.APIVersionInternal}).
+		LocalParam(true).ContinueOnError().
+		FilenameParam(false, &resource.FilenameOptions{Filenames: []string{filename}}).Flatten().Do()
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+
+	singleItemImplied := false
+	infos, err := r.IntoSingleItemImplied(&singleItemImplied).Infos()
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) != 1 {
+		return nil, fmt.Errorf("expected exactly one Certificate object in manifest file %q, found %d", filename, len(infos))
+	}
+
+	obj, err := ctl.Scheme.ConvertToVersion(infos[0].Object, cmapi.SchemeGroupVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object into version v1: %w", err)
+	}
+
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok {
+		return nil, errors.New("decoded object is not a v1 Certificate")
+	}
+
+	crt = crt.DeepCopy()
+	if crt.Spec.PrivateKey == nil {
+		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
+	}
+
+	return crt, nil
+}
+
+// loadOrGenerateKey reads the private key from keyFile if it is set,
+// otherwise generates a new private key for the Certificate and writes it to
+// outputKeyFile.
+func loadOrGenerateKey(crt *cmapi.Certificate, keyFile, outputKeyFile string) (crypto.Signer, bool, error) {
+	if len(keyFile) > 0 {
+		keyPEM, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read private key file: %w", err)
+		}
+		signer, err := pki.DecodePrivateKeyBytes(keyPEM)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to decode private key file %q: %w", keyFile, err)
+		}
+		if err := setPrivateKeyAlgorithm(crt, signer); err != nil {
+			return nil, false, err
+		}
+		return signer, false, nil
+	}
+
+	signer, keyPEM, err := generateKey(crt)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := os.WriteFile(outputKeyFile, keyPEM, 0600); err != nil {
+		return nil, false, fmt.Errorf("error when writing private key to file: %w", err)
+	}
+
+	return signer, true, nil
+}
+
+// setPrivateKeyAlgorithm updates the Certificate's private key spec to match
+// an existing private key, so that the generated CSR uses a matching
+// signature algorithm.
+func setPrivateKeyAlgorithm(crt *cmapi.Certificate, signer crypto.Signer) error {
+	if crt.Spec.PrivateKey == nil {
+		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
+	}
+
+	switch pub := signer.Public().(type) {
+	case *rsa.PublicKey:
+		crt.Spec.PrivateKey.Algorithm = cmapi.RSAKeyAlgorithm
+		crt.Spec.PrivateKey.Size = pub.N.BitLen()
+	case *ecdsa.PublicKey:
+		crt.Spec.PrivateKey.Algorithm = cmapi.ECDSAKeyAlgorithm
+		crt.Spec.PrivateKey.Size = pub.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		crt.Spec.PrivateKey.Algorithm = cmapi.Ed25519KeyAlgorithm
+		crt.Spec.PrivateKey.Size = 0
+	default:
+		return fmt.Errorf("unsupported private key type %T", pub)
+	}
+
+	return nil
+}
+
+// generateKey generates and encodes a private key for the Certificate.
+func generateKey(crt *cmapi.Certificate) (crypto.Signer, []byte, error) {
+	signer, err := pki.GeneratePrivateKeyForCertificate(crt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error when generating new private key: %w", err)
+	}
+
+	var encoding cmapi.PrivateKeyEncoding
+	if crt.Spec.PrivateKey != nil {
+		encoding = crt.Spec.PrivateKey.Encoding
+	}
+
+	keyPEM, err := pki.EncodePrivateKey(signer, encoding)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode new private key: %w", err)
+	}
+
+	return signer, keyPEM, nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package x509gen
+
+import (
+	"context"
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestValidateKeyFiles(t *testing.T) {
+	tests := map[string]struct {
+		keyFile, outputKeyFile, outputFile string
+		expErr                             bool
+	}{
+		"existing key":           {keyFile: "a.key", outputFile: "a.csr"},
+		"new key":                {outputKeyFile: "a.key", outputFile: "a.csr"},
+		"no key":                 {outputFile: "a.csr", expErr: true},
+		"both keys":              {keyFile: "a.key", outputKeyFile: "b.key", outputFile: "a.csr", expErr: true},
+		"key and output collide": {outputKeyFile: "a.pem", outputFile: "a.pem", expErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateKeyFiles(test.keyFile, test.outputKeyFile, test.outputFile)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+		})
+	}
+}
+
+func TestGenerateCSRAndCA(t *testing.T) {
+	dir := t.TempDir()
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+	keyFile := filepath.Join(dir, "ca.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	ca := &caOptions{
+		certificateOptions: certificateOptions{
+			CommonName:   "my-ca",
+			KeyAlgorithm: "Ed25519",
+			KeyEncoding:  "PKCS8",
+			Duration:     time.Hour,
+		},
+		OutputKeyFile:         keyFile,
+		OutputCertificateFile: caFile,
+		IOStreams:             streams,
+	}
+	if err := ca.Validate(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := ca.Run(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := pki.DecodeX509CertificateBytes(caPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !caCert.IsCA || caCert.Subject.CommonName != "my-ca" {
+		t.Errorf("unexpected CA certificate: isCA=%t commonName=%q", caCert.IsCA, caCert.Subject.CommonName)
+	}
+	if _, ok := caCert.PublicKey.(ed25519.PublicKey); !ok {
+		t.Errorf("expected Ed25519 public key, got %T", caCert.PublicKey)
+	}
+	if got := caCert.NotAfter.Sub(caCert.NotBefore); got != time.Hour {
+		t.Errorf("unexpected CA duration %s", got)
+	}
+
+	csrFile := filepath.Join(dir, "tls.csr")
+	csr := &csrOptions{
+		certificateOptions: certificateOptions{
+			DNSNames:    []string{"example.com"},
+			IPAddresses: []string{"10.0.0.1"},
+		},
+		KeyFile:       keyFile,
+		OutputCSRFile: csrFile,
+		IOStreams:     streams,
+	}
+	if err := csr.Validate(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := csr.Run(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	csrPEM, err := os.ReadFile(csrFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(req.DNSNames, []string{"example.com"}) {
+		t.Errorf("unexpected DNS names %v", req.DNSNames)
+	}
+	if ok, err := pki.PublicKeysEqual(req.PublicKey, caCert.PublicKey); err != nil || !ok {
+		t.Errorf("expected CSR to reuse the CA private key, equal=%t err=%v", ok, err)
+	}
+}
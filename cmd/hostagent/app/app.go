@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/hostagent"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util"
+)
+
+type options struct {
+	ConfigFile   string
+	NodeName     string
+	Kubeconfig   string
+	ResyncPeriod time.Duration
+}
+
+func NewHostAgentCommand(ctx context.Context) *cobra.Command {
+	o := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "hostagent",
+		Short: "Write certificates managed by cert-manager to the host.",
+		Long: `
+The cert-manager host agent watches the Certificates listed in its
+configuration file and writes their certificates and private keys to the
+host, running a reload hook whenever they change.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if o.NodeName == "" {
+				return fmt.Errorf("--node-name must be set")
+			}
+
+			cfg, err := hostagent.LoadConfig(o.ConfigFile, o.NodeName)
+			if err != nil {
+				return err
+			}
+
+			restConfig, err := clientcmd.BuildConfigFromFlags("", o.Kubeconfig)
+			if err != nil {
+				return fmt.Errorf("error creating rest config: %w", err)
+			}
+			restConfig = util.RestConfigWithUserAgent(restConfig, "hostagent")
+
+			kubeClient, err := kubernetes.NewForConfig(restConfig)
+			if err != nil {
+				return fmt.Errorf("error creating kubernetes client: %w", err)
+			}
+			cmClient, err := cmclient.NewForConfig(restConfig)
+			if err != nil {
+				return fmt.Errorf("error creating cert-manager client: %w", err)
+			}
+
+			ctx := logf.NewContext(ctx, logf.Log, "hostagent")
+			log := logf.FromContext(ctx).WithValues("node", o.NodeName)
+			log.Info("starting host agent", "version", util.AppVersion, "git-commit", util.AppGitCommit)
+
+			return hostagent.New(log, kubeClient, cmClient, cfg, o.ResyncPeriod).Run(ctx)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.ConfigFile, "config", "/etc/cert-manager/hostagent/config.yaml", "Path to the file listing the Certificates written to the host.")
+	cmd.Flags().StringVar(&o.NodeName, "node-name", os.Getenv("NODE_NAME"), "Name of the node the agent runs on, substituted for ${NODE_NAME} in the configuration. Defaults to the NODE_NAME environment variable.")
+	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	cmd.Flags().DurationVar(&o.ResyncPeriod, "resync-period", 10*time.Minute, "How often the files on the host are compared with the Certificates' Secrets, and restored if they were changed.")
+
+	return cmd
+}
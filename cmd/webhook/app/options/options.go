@@ -72,6 +72,9 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.WebhookConfiguration) {
 	fs.StringVar(&c.TLSConfig.Dynamic.SecretName, "dynamic-serving-ca-secret-name", c.TLSConfig.Dynamic.SecretName, "name of the secret used to store the CA that signs serving certificates certificates")
 	fs.StringSliceVar(&c.TLSConfig.Dynamic.DNSNames, "dynamic-serving-dns-names", c.TLSConfig.Dynamic.DNSNames, "DNS names that should be present on certificates generated by the dynamic serving CA")
 
+	fs.StringVar(&c.TLSConfig.Secret.SecretNamespace, "tls-secret-namespace", c.TLSConfig.Secret.SecretNamespace, "namespace of the externally managed secret containing the TLS certificate and private key to serve with")
+	fs.StringVar(&c.TLSConfig.Secret.SecretName, "tls-secret-name", c.TLSConfig.Secret.SecretName, "name of the externally managed secret containing the TLS certificate and private key to serve with. The certificate is reloaded whenever the secret changes")
+
 	fs.StringVar(&c.KubeConfig, "kubeconfig", c.KubeConfig, "optional path to the kubeconfig used to connect to the apiserver. If not specified, in-cluster-config will be used")
 	fs.StringVar(&c.APIServerHost, "api-server-host", c.APIServerHost, ""+
 		"Optional apiserver host address to connect to. If not specified, autoconfiguration "+
@@ -80,6 +83,13 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.WebhookConfiguration) {
 		"Enable profiling for webhook.")
 	fs.StringVar(&c.PprofAddress, "profiler-address", c.PprofAddress,
 		"Address of the Go profiler (pprof). This should never be exposed on a public interface. If this flag is not set, the profiler is not run.")
+	fs.StringVar(&c.ClusterResourceNamespace, "cluster-resource-namespace", c.ClusterResourceNamespace, ""+
+		"Namespace in which the Secrets referenced by ClusterIssuers are stored. This must match the "+
+		"cluster resource namespace of the controller, and is only used by the IssuerDeepValidation feature.")
+	fs.BoolVar(&c.DisablePolicyCoveredChecks, "disable-policy-covered-checks", c.DisablePolicyCoveredChecks, ""+
+		"Skip the checks which are also made by the ValidatingAdmissionPolicies installed by the Helm chart. "+
+		"This should only be set when the policies are installed. Checks which enforce security invariants, "+
+		"such as the immutability of CertificateRequests and the rules on their approval, are never skipped.")
 	tlsCipherPossibleValues := cliflag.TLSCipherPossibleValues()
 	fs.StringSliceVar(&c.TLSConfig.CipherSuites, "tls-cipher-suites", c.TLSConfig.CipherSuites,
 		"Comma-separated list of cipher suites for the server. "+
@@ -29,7 +29,9 @@ import (
 	cmdutil "github.com/cert-manager/cert-manager/cmd/util"
 	"github.com/cert-manager/cert-manager/cmd/webhook/app/options"
 	config "github.com/cert-manager/cert-manager/internal/apis/config/webhook"
+	"github.com/cert-manager/cert-manager/internal/fips"
 	cmwebhook "github.com/cert-manager/cert-manager/internal/webhook"
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
@@ -117,6 +119,10 @@ func NewServerCommand(stopCh <-chan struct{}) *cobra.Command {
 				}
 			}
 
+			if utilfeature.DefaultFeatureGate.Enabled(feature.FIPS) && !fips.BackendEnabled() {
+				log.Info("the FIPS feature gate is enabled, but the webhook was not built with a FIPS validated crypto module; only algorithms will be restricted")
+			}
+
 			srv, err := cmwebhook.NewCertManagerWebhookServer(log, *webhookFlags, *webhookConfig)
 			if err != nil {
 				log.Error(err, "Failed initialising server")
@@ -106,6 +106,27 @@ Create the name of the service account to use
 {{- end -}}
 {{- end -}}
 
+{{/*
+hostagent templates
+*/}}
+
+{{- define "hostagent.name" -}}
+{{- printf "hostagent" -}}
+{{- end -}}
+
+{{- define "hostagent.fullname" -}}
+{{- $trimmedName := printf "%s" (include "cert-manager.fullname" .) | trunc 53 | trimSuffix "-" -}}
+{{- printf "%s-hostagent" $trimmedName | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+
+{{- define "hostagent.serviceAccountName" -}}
+{{- if .Values.hostAgent.serviceAccount.create -}}
+    {{ default (include "hostagent.fullname" .) .Values.hostAgent.serviceAccount.name }}
+{{- else -}}
+    {{ default "default" .Values.hostAgent.serviceAccount.name }}
+{{- end -}}
+{{- end -}}
+
 {{/*
 startupapicheck templates
 */}}
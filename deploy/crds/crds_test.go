@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crds
+
+import (
+	"testing"
+)
+
+func TestCustomResourceDefinitions(t *testing.T) {
+	crds, err := CustomResourceDefinitions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(crds) == 0 {
+		t.Fatal("expected CustomResourceDefinitions to be embedded")
+	}
+	for _, crd := range crds {
+		if crd.Name != crd.Spec.Names.Plural+"."+crd.Spec.Group {
+			t.Errorf("unexpected name %q for CustomResourceDefinition of %s", crd.Name, crd.Spec.Names.Kind)
+		}
+		if crd.Labels != nil {
+			t.Errorf("expected templated labels of %s to be removed", crd.Name)
+		}
+		storage := 0
+		for _, v := range crd.Spec.Versions {
+			if v.Storage {
+				storage++
+			}
+		}
+		if storage != 1 {
+			t.Errorf("expected %s to have exactly one storage version, got %d", crd.Name, storage)
+		}
+	}
+}
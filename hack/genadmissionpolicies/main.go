@@ -0,0 +1,32 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// genadmissionpolicies writes the Helm chart template which installs the
+// ValidatingAdmissionPolicies defined in internal/webhook/admissionpolicy.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/cert-manager/cert-manager/internal/webhook/admissionpolicy"
+)
+
+func main() {
+	if err := admissionpolicy.Manifests(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
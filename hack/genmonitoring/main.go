@@ -0,0 +1,50 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// genmonitoring writes the Helm chart templates which install the Prometheus
+// alerting rules and Grafana dashboard defined in internal/monitoring.
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/cert-manager/cert-manager/internal/monitoring"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatalf("usage: %s <chart templates directory>", os.Args[0])
+	}
+	dir := os.Args[1]
+
+	for name, write := range map[string]func(*os.File) error{
+		"prometheusrule.yaml":    func(f *os.File) error { return monitoring.PrometheusRuleManifest(f) },
+		"grafana-dashboard.yaml": func(f *os.File) error { return monitoring.DashboardManifest(f) },
+	} {
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := write(f); err != nil {
+			log.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -57,6 +57,8 @@ deepcopy_inputs=(
   internal/apis/acme/v1beta1 \
   pkg/apis/acme/v1 \
   internal/apis/acme \
+  pkg/apis/config/controller/v1alpha1 \
+  internal/apis/config/controller \
   pkg/apis/config/webhook/v1alpha1 \
   internal/apis/config/webhook \
   pkg/apis/meta/v1 \
@@ -85,6 +87,7 @@ defaulter_inputs=(
   internal/apis/acme/v1alpha3 \
   internal/apis/acme/v1beta1 \
   internal/apis/acme/v1 \
+  internal/apis/config/controller/v1alpha1 \
   internal/apis/config/webhook/v1alpha1 \
   internal/apis/meta/v1 \
   pkg/webhook/handlers/testdata/apis/testgroup/v2 \
@@ -101,6 +104,7 @@ conversion_inputs=(
   internal/apis/acme/v1alpha3 \
   internal/apis/acme/v1beta1 \
   internal/apis/acme/v1 \
+  internal/apis/config/controller/v1alpha1 \
   internal/apis/config/webhook/v1alpha1 \
   internal/apis/meta/v1 \
   pkg/webhook/handlers/testdata/apis/testgroup/v2 \
@@ -149,6 +153,9 @@ gen-deepcopy() {
     --output-base ./
 }
 
+# CertificateDefaults is a singular noun, so is not pluralised.
+plural_exceptions="Endpoints:Endpoints,CertificateDefaults:CertificateDefaults"
+
 gen-clientsets() {
   clean "${client_subpackage}"/clientset '*.go'
   echo "+++ ${VERB} clientset..." >&2
@@ -156,6 +163,7 @@ gen-clientsets() {
   joined=$( IFS=$','; echo "${prefixed_inputs[*]}" )
   "$clientgen" \
     ${VERIFY_FLAGS} \
+    --plural-exceptions "${plural_exceptions}" \
     --go-header-file hack/boilerplate/boilerplate.generatego.txt \
     --clientset-name versioned \
     --input-base "" \
@@ -172,6 +180,7 @@ gen-listers() {
   joined=$( IFS=$','; echo "${prefixed_inputs[*]}" )
   "$listergen" \
     ${VERIFY_FLAGS} \
+    --plural-exceptions "${plural_exceptions}" \
     --go-header-file hack/boilerplate/boilerplate.generatego.txt \
     --input-dirs "$joined" \
     --trim-path-prefix="$module_name" \
@@ -186,6 +195,7 @@ gen-informers() {
   joined=$( IFS=$','; echo "${prefixed_inputs[*]}" )
   "$informergen" \
     ${VERIFY_FLAGS} \
+    --plural-exceptions "${plural_exceptions}" \
     --go-header-file hack/boilerplate/boilerplate.generatego.txt \
     --input-dirs "$joined" \
     --versioned-clientset-package "${client_package}"/clientset/versioned \
@@ -114,6 +114,16 @@ type ACMEExternalAccountBinding struct {
 	// so setting this field will have no effect.
 	// See https://github.com/cert-manager/cert-manager/issues/3220#issuecomment-809438314
 	KeyAlgorithm HMACKeyAlgorithm
+
+	// PerNamespace may only be set on ClusterIssuers. If true, keySecretRef is
+	// resolved in the namespace of each request rather than in the cluster
+	// resource namespace, and a separate ACME account is registered for
+	// every namespace containing the referenced Secret, so that tenants can
+	// use their own External Account Binding credentials. The private key of
+	// the account of a namespace is stored in that namespace, in the Secret
+	// referenced by privateKeySecretRef. Requests from namespaces without the
+	// referenced Secret are not processed.
+	PerNamespace bool
 }
 
 // HMACKeyAlgorithm is the name of a key algorithm used for HMAC encryption
@@ -221,6 +231,31 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 	// Optional ingress template used to configure the ACME challenge solver
 	// ingress used for HTTP01 challenges
 	IngressTemplate *ACMEChallengeSolverHTTP01IngressTemplate
+
+	// Optional burst profile. When set, challenges are served by a solver
+	// Deployment that is preallocated in the namespace of the challenge and
+	// scaled by the number of pending challenges, instead of by a pod created
+	// for every challenge. The controller must be permitted to manage
+	// Deployments and ConfigMaps in that namespace.
+	BurstProfile *ACMEChallengeSolverHTTP01BurstProfile
+}
+
+// ACMEChallengeSolverHTTP01BurstProfile configures the solver Deployment used
+// to serve all pending HTTP01 challenges of a solver within a namespace.
+// New challenges are served once the kubelet has refreshed the challenges
+// mounted into the solver pods, which may take up to a minute.
+type ACMEChallengeSolverHTTP01BurstProfile struct {
+	// The number of solver replicas to keep running while no challenges are
+	// pending. Defaults to 1.
+	MinReplicas *int32
+
+	// The maximum number of solver replicas, regardless of the number of
+	// pending challenges.
+	MaxReplicas int32
+
+	// The number of pending challenges each solver replica is expected to
+	// serve. Defaults to 10.
+	ChallengesPerReplica *int32
 }
 
 type ACMEChallengeSolverHTTP01GatewayHTTPRoute struct {
@@ -250,7 +285,8 @@ type ACMEChallengeSolverHTTP01IngressPodTemplate struct {
 
 	// PodSpec defines overrides for the HTTP01 challenge solver pod.
 	// Only the 'priorityClassName', 'nodeSelector', 'affinity',
-	// 'serviceAccountName' and 'tolerations' fields are supported currently.
+	// 'serviceAccountName', 'tolerations' and 'resources' fields are supported
+	// currently.
 	// All other fields will be ignored.
 	// +optional
 	Spec ACMEChallengeSolverHTTP01IngressPodSpec
@@ -282,6 +318,10 @@ type ACMEChallengeSolverHTTP01IngressPodSpec struct {
 	// If specified, the pod's service account
 	// +optional
 	ServiceAccountName string
+
+	// If specified, the compute resources of the solver container, overriding
+	// the defaults configured on the controller.
+	Resources *corev1.ResourceRequirements
 }
 
 type ACMEChallengeSolverHTTP01IngressTemplate struct {
@@ -308,6 +348,19 @@ type ACMEChallengeSolverDNS01 struct {
 	// records when found in DNS zones.
 	CNAMEStrategy CNAMEStrategy
 
+	// ValidationZone is the DNS zone in which challenge records are
+	// published when DNS01 validation is delegated to a dedicated zone, for
+	// example when _acme-challenge.apps.example.com is a CNAME record
+	// pointing into acme.example.com. It may only be set together with the
+	// Follow cnameStrategy.
+	// Challenge records are only presented if the CNAME records resolve to a
+	// name within the zone, which is passed to the externalDNS, hetzner,
+	// rfc2136 and webhook providers instead of the zone discovered with SOA
+	// queries. As the challenge records of a wildcard name and of its
+	// base domain are the same, the selector of this solver matches a domain
+	// if it matches either of them.
+	ValidationZone string
+
 	// Use the Akamai DNS zone management API to manage DNS01 challenge records.
 	Akamai *ACMEIssuerDNS01ProviderAkamai
 
@@ -326,10 +379,18 @@ type ACMEChallengeSolverDNS01 struct {
 	// Use the DigitalOcean DNS API to manage DNS01 challenge records.
 	DigitalOcean *ACMEIssuerDNS01ProviderDigitalOcean
 
+	// Use the Hetzner DNS API to manage DNS01 challenge records.
+	Hetzner *ACMEIssuerDNS01ProviderHetzner
+
 	// Use the 'ACME DNS' (https://github.com/joohoi/acme-dns) API to manage
 	// DNS01 challenge records.
 	AcmeDNS *ACMEIssuerDNS01ProviderAcmeDNS
 
+	// Use ExternalDNS (https://github.com/kubernetes-sigs/external-dns) to
+	// manage DNS01 challenge records, by creating DNSEndpoint resources for
+	// them. ExternalDNS must be running with the crd source enabled.
+	ExternalDNS *ACMEIssuerDNS01ProviderExternalDNS
+
 	// Use RFC2136 ("Dynamic Updates in the Domain Name System") (https://datatracker.ietf.org/doc/rfc2136/)
 	// to manage DNS01 challenge records.
 	RFC2136 *ACMEIssuerDNS01ProviderRFC2136
@@ -372,6 +433,17 @@ type ACMEIssuerDNS01ProviderCloudDNS struct {
 	ServiceAccount *cmmeta.SecretKeySelector
 	Project        string
 	HostedZoneName string
+
+	Zones []ACMEIssuerDNS01ProviderCloudDNSZone
+}
+
+// ACMEIssuerDNS01ProviderCloudDNSZone configures the credentials used to
+// solve challenges for domains within a single Google Cloud DNS zone.
+type ACMEIssuerDNS01ProviderCloudDNSZone struct {
+	Zone           string
+	ServiceAccount cmmeta.SecretKeySelector
+	Project        string
+	HostedZoneName string
 }
 
 // ACMEIssuerDNS01ProviderCloudflare is a structure containing the DNS
@@ -396,6 +468,13 @@ type ACMEIssuerDNS01ProviderDigitalOcean struct {
 	Token cmmeta.SecretKeySelector
 }
 
+// ACMEIssuerDNS01ProviderHetzner is a structure containing the DNS
+// configuration for Hetzner DNS
+type ACMEIssuerDNS01ProviderHetzner struct {
+	// API token used to authenticate with the Hetzner DNS API.
+	APIToken cmmeta.SecretKeySelector
+}
+
 // ACMEIssuerDNS01ProviderRoute53 is a structure containing the Route 53
 // configuration for AWS
 type ACMEIssuerDNS01ProviderRoute53 struct {
@@ -429,6 +508,22 @@ type ACMEIssuerDNS01ProviderRoute53 struct {
 
 	// Always set the region when using AccessKeyID and SecretAccessKey
 	Region string
+
+	Zones []ACMEIssuerDNS01ProviderRoute53Zone
+}
+
+// ACMEIssuerDNS01ProviderRoute53Zone configures the credentials used to
+// solve challenges for domains within a single Route 53 zone.
+type ACMEIssuerDNS01ProviderRoute53Zone struct {
+	Zone string
+
+	AccessKeyID *cmmeta.SecretKeySelector
+
+	SecretAccessKey *cmmeta.SecretKeySelector
+
+	Role string
+
+	HostedZoneID string
 }
 
 // ACMEIssuerDNS01ProviderAzureDNS is a structure containing the
@@ -452,6 +547,26 @@ type ACMEIssuerDNS01ProviderAzureDNS struct {
 	Environment AzureDNSEnvironment
 
 	ManagedIdentity *AzureManagedIdentity
+
+	Zones []ACMEIssuerDNS01ProviderAzureDNSZone
+}
+
+// ACMEIssuerDNS01ProviderAzureDNSZone configures the service principal used
+// to solve challenges for domains within a single Azure DNS zone.
+type ACMEIssuerDNS01ProviderAzureDNSZone struct {
+	Zone string
+
+	ClientID string
+
+	ClientSecret cmmeta.SecretKeySelector
+
+	TenantID string
+
+	SubscriptionID string
+
+	ResourceGroupName string
+
+	HostedZoneName string
 }
 
 type AzureManagedIdentity struct {
@@ -495,10 +610,54 @@ type ACMEIssuerDNS01ProviderRFC2136 struct {
 	TSIGKeyName string
 
 	// The TSIG Algorithm configured in the DNS supporting RFC2136. Used only
-	// when ``tsigSecretSecretRef`` and ``tsigKeyName`` are defined.
+	// when ``tsigSecretSecretRef`` and ``tsigKeyName`` are defined, unless it
+	// is ``GSS-TSIG``.
 	// Supported values are (case-insensitive): ``HMACMD5`` (default),
-	// ``HMACSHA1``, ``HMACSHA256`` or ``HMACSHA512``.
+	// ``HMACSHA1``, ``HMACSHA256``, ``HMACSHA512`` or ``GSS-TSIG``.
+	// ``GSS-TSIG`` authenticates updates with Kerberos, using the
+	// credentials configured in ``kerberos``, which must then be set.
 	TSIGAlgorithm string
+
+	// Kerberos configures the credentials with which updates are signed when
+	// ``tsigAlgorithm`` is ``GSS-TSIG``.
+	Kerberos *ACMEIssuerDNS01ProviderRFC2136Kerberos
+}
+
+// ACMEIssuerDNS01ProviderRFC2136Kerberos holds the Kerberos credentials used
+// to sign RFC2136 updates with GSS-TSIG. Exactly one of KeytabSecretRef and
+// PasswordSecretRef must be set.
+type ACMEIssuerDNS01ProviderRFC2136Kerberos struct {
+	// Realm is the Kerberos realm of the principal.
+	Realm string
+
+	// Username is the name of the principal without its realm.
+	Username string
+
+	// KDC is the address of the Key Distribution Center in the form host or
+	// host:port. Defaults to the host of the nameserver on port 88.
+	KDC string
+
+	// ServicePrincipalName is the name of the principal of the nameserver.
+	// Defaults to ``DNS/<host>`` where host is the host of the nameserver.
+	ServicePrincipalName string
+
+	// KeytabSecretRef references a keytab holding the keys of the principal.
+	KeytabSecretRef *cmmeta.SecretKeySelector
+
+	// PasswordSecretRef references the password of the principal.
+	PasswordSecretRef *cmmeta.SecretKeySelector
+}
+
+// ACMEIssuerDNS01ProviderExternalDNS is a structure containing the
+// configuration of DNSEndpoint resources created for ExternalDNS.
+type ACMEIssuerDNS01ProviderExternalDNS struct {
+	// Labels to add to the DNSEndpoint resources, e.g. to match the label
+	// filter of the ExternalDNS instance which manages the DNS zone.
+	Labels map[string]string
+
+	// The TTL of the challenge records in seconds. Defaults to the TTL
+	// configured in ExternalDNS.
+	RecordTTL *int64
 }
 
 // ACMEIssuerDNS01ProviderWebhook specifies configuration for a webhook DNS01
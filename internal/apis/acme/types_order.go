@@ -87,6 +87,15 @@ type OrderStatus struct {
 	// This is used to obtain certificates for this order once it has been completed.
 	FinalizeURL string
 
+	// AccountURI is the URI of the ACME account the Order was created with.
+	// Together with URL, it can be used to correlate the Order with the logs
+	// of the ACME server.
+	AccountURI string
+
+	// CertificateURL is the URL of the certificate issued for the Order.
+	// This field will be populated once the order has been finalized.
+	CertificateURL string
+
 	// Certificate is a copy of the PEM encoded certificate for this Order.
 	// This field will be populated after the order has been successfully
 	// finalized with the ACME server, and the order has transitioned to the
@@ -109,6 +118,18 @@ type OrderStatus struct {
 	// FailureTime stores the time that this order failed.
 	// This is used to influence garbage collection and back-off.
 	FailureTime *metav1.Time
+
+	// FinalizeAttempts is the number of attempts to finalize the order which
+	// failed with a transient error.
+	FinalizeAttempts int
+
+	// LastFinalizeAttemptTime is the time of the last attempt to finalize the
+	// order which failed with a transient error.
+	LastFinalizeAttemptTime *metav1.Time
+
+	// List of status conditions to indicate the status of the Order.
+	// Known condition types are `Ready`.
+	Conditions []metav1.Condition
 }
 
 // ACMEAuthorization contains data returned from the ACME server on an
@@ -139,6 +160,15 @@ type ACMEAuthorization struct {
 	// +optional
 	InitialState State
 
+	// State is the last observed state of the ACME authorization. It is set to
+	// InitialState when the authorization is first fetched, and then follows
+	// the state of the Challenge solving the authorization, so that the
+	// progress of the Order can be seen without listing its Challenges.
+	// A Challenge is not created for an authorization which is already
+	// 'valid'.
+	// +optional
+	State State
+
 	// Challenges specifies the challenge types offered by the ACME server.
 	// One of these challenge types will be selected when validating the DNS
 	// name and an appropriate Challenge resource will be created to perform
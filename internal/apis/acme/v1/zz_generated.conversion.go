@@ -94,6 +94,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01BurstProfile)(nil), (*acme.ACMEChallengeSolverHTTP01BurstProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01BurstProfile_To_acme_ACMEChallengeSolverHTTP01BurstProfile(a.(*v1.ACMEChallengeSolverHTTP01BurstProfile), b.(*acme.ACMEChallengeSolverHTTP01BurstProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01BurstProfile)(nil), (*v1.ACMEChallengeSolverHTTP01BurstProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01BurstProfile_To_v1_ACMEChallengeSolverHTTP01BurstProfile(a.(*acme.ACMEChallengeSolverHTTP01BurstProfile), b.(*v1.ACMEChallengeSolverHTTP01BurstProfile), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(a.(*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute), b.(*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute), scope)
 	}); err != nil {
@@ -204,6 +214,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderAzureDNSZone)(nil), (*acme.ACMEIssuerDNS01ProviderAzureDNSZone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderAzureDNSZone_To_acme_ACMEIssuerDNS01ProviderAzureDNSZone(a.(*v1.ACMEIssuerDNS01ProviderAzureDNSZone), b.(*acme.ACMEIssuerDNS01ProviderAzureDNSZone), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderAzureDNSZone)(nil), (*v1.ACMEIssuerDNS01ProviderAzureDNSZone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderAzureDNSZone_To_v1_ACMEIssuerDNS01ProviderAzureDNSZone(a.(*acme.ACMEIssuerDNS01ProviderAzureDNSZone), b.(*v1.ACMEIssuerDNS01ProviderAzureDNSZone), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderCloudDNS)(nil), (*acme.ACMEIssuerDNS01ProviderCloudDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderCloudDNS(a.(*v1.ACMEIssuerDNS01ProviderCloudDNS), b.(*acme.ACMEIssuerDNS01ProviderCloudDNS), scope)
 	}); err != nil {
@@ -214,6 +234,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderCloudDNSZone)(nil), (*acme.ACMEIssuerDNS01ProviderCloudDNSZone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderCloudDNSZone_To_acme_ACMEIssuerDNS01ProviderCloudDNSZone(a.(*v1.ACMEIssuerDNS01ProviderCloudDNSZone), b.(*acme.ACMEIssuerDNS01ProviderCloudDNSZone), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderCloudDNSZone)(nil), (*v1.ACMEIssuerDNS01ProviderCloudDNSZone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderCloudDNSZone_To_v1_ACMEIssuerDNS01ProviderCloudDNSZone(a.(*acme.ACMEIssuerDNS01ProviderCloudDNSZone), b.(*v1.ACMEIssuerDNS01ProviderCloudDNSZone), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderCloudflare)(nil), (*acme.ACMEIssuerDNS01ProviderCloudflare)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderCloudflare_To_acme_ACMEIssuerDNS01ProviderCloudflare(a.(*v1.ACMEIssuerDNS01ProviderCloudflare), b.(*acme.ACMEIssuerDNS01ProviderCloudflare), scope)
 	}); err != nil {
@@ -234,6 +264,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderExternalDNS)(nil), (*acme.ACMEIssuerDNS01ProviderExternalDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderExternalDNS_To_acme_ACMEIssuerDNS01ProviderExternalDNS(a.(*v1.ACMEIssuerDNS01ProviderExternalDNS), b.(*acme.ACMEIssuerDNS01ProviderExternalDNS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderExternalDNS)(nil), (*v1.ACMEIssuerDNS01ProviderExternalDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderExternalDNS_To_v1_ACMEIssuerDNS01ProviderExternalDNS(a.(*acme.ACMEIssuerDNS01ProviderExternalDNS), b.(*v1.ACMEIssuerDNS01ProviderExternalDNS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderHetzner)(nil), (*acme.ACMEIssuerDNS01ProviderHetzner)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderHetzner_To_acme_ACMEIssuerDNS01ProviderHetzner(a.(*v1.ACMEIssuerDNS01ProviderHetzner), b.(*acme.ACMEIssuerDNS01ProviderHetzner), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderHetzner)(nil), (*v1.ACMEIssuerDNS01ProviderHetzner)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderHetzner_To_v1_ACMEIssuerDNS01ProviderHetzner(a.(*acme.ACMEIssuerDNS01ProviderHetzner), b.(*v1.ACMEIssuerDNS01ProviderHetzner), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderRFC2136)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(a.(*v1.ACMEIssuerDNS01ProviderRFC2136), b.(*acme.ACMEIssuerDNS01ProviderRFC2136), scope)
 	}); err != nil {
@@ -244,6 +294,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderRFC2136Kerberos)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136Kerberos)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos(a.(*v1.ACMEIssuerDNS01ProviderRFC2136Kerberos), b.(*acme.ACMEIssuerDNS01ProviderRFC2136Kerberos), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderRFC2136Kerberos)(nil), (*v1.ACMEIssuerDNS01ProviderRFC2136Kerberos)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_v1_ACMEIssuerDNS01ProviderRFC2136Kerberos(a.(*acme.ACMEIssuerDNS01ProviderRFC2136Kerberos), b.(*v1.ACMEIssuerDNS01ProviderRFC2136Kerberos), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderRoute53)(nil), (*acme.ACMEIssuerDNS01ProviderRoute53)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01ProviderRoute53(a.(*v1.ACMEIssuerDNS01ProviderRoute53), b.(*acme.ACMEIssuerDNS01ProviderRoute53), scope)
 	}); err != nil {
@@ -254,6 +314,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderRoute53Zone)(nil), (*acme.ACMEIssuerDNS01ProviderRoute53Zone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderRoute53Zone_To_acme_ACMEIssuerDNS01ProviderRoute53Zone(a.(*v1.ACMEIssuerDNS01ProviderRoute53Zone), b.(*acme.ACMEIssuerDNS01ProviderRoute53Zone), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderRoute53Zone)(nil), (*v1.ACMEIssuerDNS01ProviderRoute53Zone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderRoute53Zone_To_v1_ACMEIssuerDNS01ProviderRoute53Zone(a.(*acme.ACMEIssuerDNS01ProviderRoute53Zone), b.(*v1.ACMEIssuerDNS01ProviderRoute53Zone), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderWebhook)(nil), (*acme.ACMEIssuerDNS01ProviderWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderWebhook_To_acme_ACMEIssuerDNS01ProviderWebhook(a.(*v1.ACMEIssuerDNS01ProviderWebhook), b.(*acme.ACMEIssuerDNS01ProviderWebhook), scope)
 	}); err != nil {
@@ -392,6 +462,7 @@ func autoConvert_v1_ACMEAuthorization_To_acme_ACMEAuthorization(in *v1.ACMEAutho
 	out.Identifier = in.Identifier
 	out.Wildcard = (*bool)(unsafe.Pointer(in.Wildcard))
 	out.InitialState = acme.State(in.InitialState)
+	out.State = acme.State(in.State)
 	out.Challenges = *(*[]acme.ACMEChallenge)(unsafe.Pointer(&in.Challenges))
 	return nil
 }
@@ -406,6 +477,7 @@ func autoConvert_acme_ACMEAuthorization_To_v1_ACMEAuthorization(in *acme.ACMEAut
 	out.Identifier = in.Identifier
 	out.Wildcard = (*bool)(unsafe.Pointer(in.Wildcard))
 	out.InitialState = v1.State(in.InitialState)
+	out.State = v1.State(in.State)
 	out.Challenges = *(*[]v1.ACMEChallenge)(unsafe.Pointer(&in.Challenges))
 	return nil
 }
@@ -481,6 +553,7 @@ func Convert_acme_ACMEChallengeSolver_To_v1_ACMEChallengeSolver(in *acme.ACMECha
 
 func autoConvert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *v1.ACMEChallengeSolverDNS01, out *acme.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = acme.CNAMEStrategy(in.CNAMEStrategy)
+	out.ValidationZone = in.ValidationZone
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(acme.ACMEIssuerDNS01ProviderAkamai)
@@ -535,6 +608,15 @@ func autoConvert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Hetzner != nil {
+		in, out := &in.Hetzner, &out.Hetzner
+		*out = new(acme.ACMEIssuerDNS01ProviderHetzner)
+		if err := Convert_v1_ACMEIssuerDNS01ProviderHetzner_To_acme_ACMEIssuerDNS01ProviderHetzner(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hetzner = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(acme.ACMEIssuerDNS01ProviderAcmeDNS)
@@ -544,6 +626,7 @@ func autoConvert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in
 	} else {
 		out.AcmeDNS = nil
 	}
+	out.ExternalDNS = (*acme.ACMEIssuerDNS01ProviderExternalDNS)(unsafe.Pointer(in.ExternalDNS))
 	if in.RFC2136 != nil {
 		in, out := &in.RFC2136, &out.RFC2136
 		*out = new(acme.ACMEIssuerDNS01ProviderRFC2136)
@@ -564,6 +647,7 @@ func Convert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *v1
 
 func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in *acme.ACMEChallengeSolverDNS01, out *v1.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = v1.CNAMEStrategy(in.CNAMEStrategy)
+	out.ValidationZone = in.ValidationZone
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(v1.ACMEIssuerDNS01ProviderAkamai)
@@ -618,6 +702,15 @@ func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Hetzner != nil {
+		in, out := &in.Hetzner, &out.Hetzner
+		*out = new(v1.ACMEIssuerDNS01ProviderHetzner)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderHetzner_To_v1_ACMEIssuerDNS01ProviderHetzner(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hetzner = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(v1.ACMEIssuerDNS01ProviderAcmeDNS)
@@ -627,6 +720,7 @@ func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in
 	} else {
 		out.AcmeDNS = nil
 	}
+	out.ExternalDNS = (*v1.ACMEIssuerDNS01ProviderExternalDNS)(unsafe.Pointer(in.ExternalDNS))
 	if in.RFC2136 != nil {
 		in, out := &in.RFC2136, &out.RFC2136
 		*out = new(v1.ACMEIssuerDNS01ProviderRFC2136)
@@ -667,6 +761,30 @@ func Convert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(in *
 	return autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(in, out, s)
 }
 
+func autoConvert_v1_ACMEChallengeSolverHTTP01BurstProfile_To_acme_ACMEChallengeSolverHTTP01BurstProfile(in *v1.ACMEChallengeSolverHTTP01BurstProfile, out *acme.ACMEChallengeSolverHTTP01BurstProfile, s conversion.Scope) error {
+	out.MinReplicas = (*int32)(unsafe.Pointer(in.MinReplicas))
+	out.MaxReplicas = in.MaxReplicas
+	out.ChallengesPerReplica = (*int32)(unsafe.Pointer(in.ChallengesPerReplica))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01BurstProfile_To_acme_ACMEChallengeSolverHTTP01BurstProfile is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01BurstProfile_To_acme_ACMEChallengeSolverHTTP01BurstProfile(in *v1.ACMEChallengeSolverHTTP01BurstProfile, out *acme.ACMEChallengeSolverHTTP01BurstProfile, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01BurstProfile_To_acme_ACMEChallengeSolverHTTP01BurstProfile(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01BurstProfile_To_v1_ACMEChallengeSolverHTTP01BurstProfile(in *acme.ACMEChallengeSolverHTTP01BurstProfile, out *v1.ACMEChallengeSolverHTTP01BurstProfile, s conversion.Scope) error {
+	out.MinReplicas = (*int32)(unsafe.Pointer(in.MinReplicas))
+	out.MaxReplicas = in.MaxReplicas
+	out.ChallengesPerReplica = (*int32)(unsafe.Pointer(in.ChallengesPerReplica))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01BurstProfile_To_v1_ACMEChallengeSolverHTTP01BurstProfile is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01BurstProfile_To_v1_ACMEChallengeSolverHTTP01BurstProfile(in *acme.ACMEChallengeSolverHTTP01BurstProfile, out *v1.ACMEChallengeSolverHTTP01BurstProfile, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01BurstProfile_To_v1_ACMEChallengeSolverHTTP01BurstProfile(in, out, s)
+}
+
 func autoConvert_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
 	out.ServiceType = corev1.ServiceType(in.ServiceType)
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
@@ -697,6 +815,7 @@ func autoConvert_v1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolver
 	out.Name = in.Name
 	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*acme.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
+	out.BurstProfile = (*acme.ACMEChallengeSolverHTTP01BurstProfile)(unsafe.Pointer(in.BurstProfile))
 	return nil
 }
 
@@ -711,6 +830,7 @@ func autoConvert_acme_ACMEChallengeSolverHTTP01Ingress_To_v1_ACMEChallengeSolver
 	out.Name = in.Name
 	out.PodTemplate = (*v1.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*v1.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
+	out.BurstProfile = (*v1.ACMEChallengeSolverHTTP01BurstProfile)(unsafe.Pointer(in.BurstProfile))
 	return nil
 }
 
@@ -769,6 +889,7 @@ func autoConvert_v1_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMEChalleng
 	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
+	out.Resources = (*corev1.ResourceRequirements)(unsafe.Pointer(in.Resources))
 	return nil
 }
 
@@ -783,6 +904,7 @@ func autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodSpec_To_v1_ACMEChalleng
 	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
+	out.Resources = (*corev1.ResourceRequirements)(unsafe.Pointer(in.Resources))
 	return nil
 }
 
@@ -851,6 +973,7 @@ func autoConvert_v1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBindin
 		return err
 	}
 	out.KeyAlgorithm = acme.HMACKeyAlgorithm(in.KeyAlgorithm)
+	out.PerNamespace = in.PerNamespace
 	return nil
 }
 
@@ -865,6 +988,7 @@ func autoConvert_acme_ACMEExternalAccountBinding_To_v1_ACMEExternalAccountBindin
 		return err
 	}
 	out.KeyAlgorithm = v1.HMACKeyAlgorithm(in.KeyAlgorithm)
+	out.PerNamespace = in.PerNamespace
 	return nil
 }
 
@@ -1020,6 +1144,17 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderAzureDNS_To_acme_ACMEIssuerDNS01Provi
 	out.HostedZoneName = in.HostedZoneName
 	out.Environment = acme.AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*acme.AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]acme.ACMEIssuerDNS01ProviderAzureDNSZone, len(*in))
+		for i := range *in {
+			if err := Convert_v1_ACMEIssuerDNS01ProviderAzureDNSZone_To_acme_ACMEIssuerDNS01ProviderAzureDNSZone(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Zones = nil
+	}
 	return nil
 }
 
@@ -1045,6 +1180,17 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01Provi
 	out.HostedZoneName = in.HostedZoneName
 	out.Environment = v1.AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*v1.AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]v1.ACMEIssuerDNS01ProviderAzureDNSZone, len(*in))
+		for i := range *in {
+			if err := Convert_acme_ACMEIssuerDNS01ProviderAzureDNSZone_To_v1_ACMEIssuerDNS01ProviderAzureDNSZone(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Zones = nil
+	}
 	return nil
 }
 
@@ -1053,6 +1199,42 @@ func Convert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01ProviderA
 	return autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01ProviderAzureDNS(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderAzureDNSZone_To_acme_ACMEIssuerDNS01ProviderAzureDNSZone(in *v1.ACMEIssuerDNS01ProviderAzureDNSZone, out *acme.ACMEIssuerDNS01ProviderAzureDNSZone, s conversion.Scope) error {
+	out.Zone = in.Zone
+	out.ClientID = in.ClientID
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.ClientSecret, &out.ClientSecret, s); err != nil {
+		return err
+	}
+	out.TenantID = in.TenantID
+	out.SubscriptionID = in.SubscriptionID
+	out.ResourceGroupName = in.ResourceGroupName
+	out.HostedZoneName = in.HostedZoneName
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderAzureDNSZone_To_acme_ACMEIssuerDNS01ProviderAzureDNSZone is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderAzureDNSZone_To_acme_ACMEIssuerDNS01ProviderAzureDNSZone(in *v1.ACMEIssuerDNS01ProviderAzureDNSZone, out *acme.ACMEIssuerDNS01ProviderAzureDNSZone, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderAzureDNSZone_To_acme_ACMEIssuerDNS01ProviderAzureDNSZone(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNSZone_To_v1_ACMEIssuerDNS01ProviderAzureDNSZone(in *acme.ACMEIssuerDNS01ProviderAzureDNSZone, out *v1.ACMEIssuerDNS01ProviderAzureDNSZone, s conversion.Scope) error {
+	out.Zone = in.Zone
+	out.ClientID = in.ClientID
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.ClientSecret, &out.ClientSecret, s); err != nil {
+		return err
+	}
+	out.TenantID = in.TenantID
+	out.SubscriptionID = in.SubscriptionID
+	out.ResourceGroupName = in.ResourceGroupName
+	out.HostedZoneName = in.HostedZoneName
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderAzureDNSZone_To_v1_ACMEIssuerDNS01ProviderAzureDNSZone is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderAzureDNSZone_To_v1_ACMEIssuerDNS01ProviderAzureDNSZone(in *acme.ACMEIssuerDNS01ProviderAzureDNSZone, out *v1.ACMEIssuerDNS01ProviderAzureDNSZone, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNSZone_To_v1_ACMEIssuerDNS01ProviderAzureDNSZone(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderCloudDNS(in *v1.ACMEIssuerDNS01ProviderCloudDNS, out *acme.ACMEIssuerDNS01ProviderCloudDNS, s conversion.Scope) error {
 	if in.ServiceAccount != nil {
 		in, out := &in.ServiceAccount, &out.ServiceAccount
@@ -1065,6 +1247,17 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01Provi
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]acme.ACMEIssuerDNS01ProviderCloudDNSZone, len(*in))
+		for i := range *in {
+			if err := Convert_v1_ACMEIssuerDNS01ProviderCloudDNSZone_To_acme_ACMEIssuerDNS01ProviderCloudDNSZone(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Zones = nil
+	}
 	return nil
 }
 
@@ -1085,6 +1278,17 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1_ACMEIssuerDNS01Provi
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]v1.ACMEIssuerDNS01ProviderCloudDNSZone, len(*in))
+		for i := range *in {
+			if err := Convert_acme_ACMEIssuerDNS01ProviderCloudDNSZone_To_v1_ACMEIssuerDNS01ProviderCloudDNSZone(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Zones = nil
+	}
 	return nil
 }
 
@@ -1093,6 +1297,36 @@ func Convert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1_ACMEIssuerDNS01ProviderC
 	return autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1_ACMEIssuerDNS01ProviderCloudDNS(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderCloudDNSZone_To_acme_ACMEIssuerDNS01ProviderCloudDNSZone(in *v1.ACMEIssuerDNS01ProviderCloudDNSZone, out *acme.ACMEIssuerDNS01ProviderCloudDNSZone, s conversion.Scope) error {
+	out.Zone = in.Zone
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.ServiceAccount, &out.ServiceAccount, s); err != nil {
+		return err
+	}
+	out.Project = in.Project
+	out.HostedZoneName = in.HostedZoneName
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderCloudDNSZone_To_acme_ACMEIssuerDNS01ProviderCloudDNSZone is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderCloudDNSZone_To_acme_ACMEIssuerDNS01ProviderCloudDNSZone(in *v1.ACMEIssuerDNS01ProviderCloudDNSZone, out *acme.ACMEIssuerDNS01ProviderCloudDNSZone, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderCloudDNSZone_To_acme_ACMEIssuerDNS01ProviderCloudDNSZone(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNSZone_To_v1_ACMEIssuerDNS01ProviderCloudDNSZone(in *acme.ACMEIssuerDNS01ProviderCloudDNSZone, out *v1.ACMEIssuerDNS01ProviderCloudDNSZone, s conversion.Scope) error {
+	out.Zone = in.Zone
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.ServiceAccount, &out.ServiceAccount, s); err != nil {
+		return err
+	}
+	out.Project = in.Project
+	out.HostedZoneName = in.HostedZoneName
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderCloudDNSZone_To_v1_ACMEIssuerDNS01ProviderCloudDNSZone is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderCloudDNSZone_To_v1_ACMEIssuerDNS01ProviderCloudDNSZone(in *acme.ACMEIssuerDNS01ProviderCloudDNSZone, out *v1.ACMEIssuerDNS01ProviderCloudDNSZone, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNSZone_To_v1_ACMEIssuerDNS01ProviderCloudDNSZone(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderCloudflare_To_acme_ACMEIssuerDNS01ProviderCloudflare(in *v1.ACMEIssuerDNS01ProviderCloudflare, out *acme.ACMEIssuerDNS01ProviderCloudflare, s conversion.Scope) error {
 	out.Email = in.Email
 	if in.APIKey != nil {
@@ -1173,6 +1407,52 @@ func Convert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1_ACMEIssuerDNS01Provi
 	return autoConvert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1_ACMEIssuerDNS01ProviderDigitalOcean(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderExternalDNS_To_acme_ACMEIssuerDNS01ProviderExternalDNS(in *v1.ACMEIssuerDNS01ProviderExternalDNS, out *acme.ACMEIssuerDNS01ProviderExternalDNS, s conversion.Scope) error {
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.RecordTTL = (*int64)(unsafe.Pointer(in.RecordTTL))
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderExternalDNS_To_acme_ACMEIssuerDNS01ProviderExternalDNS is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderExternalDNS_To_acme_ACMEIssuerDNS01ProviderExternalDNS(in *v1.ACMEIssuerDNS01ProviderExternalDNS, out *acme.ACMEIssuerDNS01ProviderExternalDNS, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderExternalDNS_To_acme_ACMEIssuerDNS01ProviderExternalDNS(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderExternalDNS_To_v1_ACMEIssuerDNS01ProviderExternalDNS(in *acme.ACMEIssuerDNS01ProviderExternalDNS, out *v1.ACMEIssuerDNS01ProviderExternalDNS, s conversion.Scope) error {
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.RecordTTL = (*int64)(unsafe.Pointer(in.RecordTTL))
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderExternalDNS_To_v1_ACMEIssuerDNS01ProviderExternalDNS is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderExternalDNS_To_v1_ACMEIssuerDNS01ProviderExternalDNS(in *acme.ACMEIssuerDNS01ProviderExternalDNS, out *v1.ACMEIssuerDNS01ProviderExternalDNS, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderExternalDNS_To_v1_ACMEIssuerDNS01ProviderExternalDNS(in, out, s)
+}
+
+func autoConvert_v1_ACMEIssuerDNS01ProviderHetzner_To_acme_ACMEIssuerDNS01ProviderHetzner(in *v1.ACMEIssuerDNS01ProviderHetzner, out *acme.ACMEIssuerDNS01ProviderHetzner, s conversion.Scope) error {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APIToken, &out.APIToken, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderHetzner_To_acme_ACMEIssuerDNS01ProviderHetzner is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderHetzner_To_acme_ACMEIssuerDNS01ProviderHetzner(in *v1.ACMEIssuerDNS01ProviderHetzner, out *acme.ACMEIssuerDNS01ProviderHetzner, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderHetzner_To_acme_ACMEIssuerDNS01ProviderHetzner(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderHetzner_To_v1_ACMEIssuerDNS01ProviderHetzner(in *acme.ACMEIssuerDNS01ProviderHetzner, out *v1.ACMEIssuerDNS01ProviderHetzner, s conversion.Scope) error {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APIToken, &out.APIToken, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderHetzner_To_v1_ACMEIssuerDNS01ProviderHetzner is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderHetzner_To_v1_ACMEIssuerDNS01ProviderHetzner(in *acme.ACMEIssuerDNS01ProviderHetzner, out *v1.ACMEIssuerDNS01ProviderHetzner, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderHetzner_To_v1_ACMEIssuerDNS01ProviderHetzner(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(in *v1.ACMEIssuerDNS01ProviderRFC2136, out *acme.ACMEIssuerDNS01ProviderRFC2136, s conversion.Scope) error {
 	out.Nameserver = in.Nameserver
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.TSIGSecret, &out.TSIGSecret, s); err != nil {
@@ -1180,6 +1460,15 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01Provid
 	}
 	out.TSIGKeyName = in.TSIGKeyName
 	out.TSIGAlgorithm = in.TSIGAlgorithm
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(acme.ACMEIssuerDNS01ProviderRFC2136Kerberos)
+		if err := Convert_v1_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Kerberos = nil
+	}
 	return nil
 }
 
@@ -1195,6 +1484,15 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1_ACMEIssuerDNS01Provid
 	}
 	out.TSIGKeyName = in.TSIGKeyName
 	out.TSIGAlgorithm = in.TSIGAlgorithm
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(v1.ACMEIssuerDNS01ProviderRFC2136Kerberos)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_v1_ACMEIssuerDNS01ProviderRFC2136Kerberos(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Kerberos = nil
+	}
 	return nil
 }
 
@@ -1203,6 +1501,68 @@ func Convert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1_ACMEIssuerDNS01ProviderRF
 	return autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1_ACMEIssuerDNS01ProviderRFC2136(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos(in *v1.ACMEIssuerDNS01ProviderRFC2136Kerberos, out *acme.ACMEIssuerDNS01ProviderRFC2136Kerberos, s conversion.Scope) error {
+	out.Realm = in.Realm
+	out.Username = in.Username
+	out.KDC = in.KDC
+	out.ServicePrincipalName = in.ServicePrincipalName
+	if in.KeytabSecretRef != nil {
+		in, out := &in.KeytabSecretRef, &out.KeytabSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KeytabSecretRef = nil
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PasswordSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos(in *v1.ACMEIssuerDNS01ProviderRFC2136Kerberos, out *acme.ACMEIssuerDNS01ProviderRFC2136Kerberos, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_v1_ACMEIssuerDNS01ProviderRFC2136Kerberos(in *acme.ACMEIssuerDNS01ProviderRFC2136Kerberos, out *v1.ACMEIssuerDNS01ProviderRFC2136Kerberos, s conversion.Scope) error {
+	out.Realm = in.Realm
+	out.Username = in.Username
+	out.KDC = in.KDC
+	out.ServicePrincipalName = in.ServicePrincipalName
+	if in.KeytabSecretRef != nil {
+		in, out := &in.KeytabSecretRef, &out.KeytabSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KeytabSecretRef = nil
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PasswordSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_v1_ACMEIssuerDNS01ProviderRFC2136Kerberos is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_v1_ACMEIssuerDNS01ProviderRFC2136Kerberos(in *acme.ACMEIssuerDNS01ProviderRFC2136Kerberos, out *v1.ACMEIssuerDNS01ProviderRFC2136Kerberos, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_v1_ACMEIssuerDNS01ProviderRFC2136Kerberos(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01ProviderRoute53(in *v1.ACMEIssuerDNS01ProviderRoute53, out *acme.ACMEIssuerDNS01ProviderRoute53, s conversion.Scope) error {
 	out.AccessKeyID = in.AccessKeyID
 	if in.SecretAccessKeyID != nil {
@@ -1220,6 +1580,17 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01Provid
 	out.Role = in.Role
 	out.HostedZoneID = in.HostedZoneID
 	out.Region = in.Region
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]acme.ACMEIssuerDNS01ProviderRoute53Zone, len(*in))
+		for i := range *in {
+			if err := Convert_v1_ACMEIssuerDNS01ProviderRoute53Zone_To_acme_ACMEIssuerDNS01ProviderRoute53Zone(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Zones = nil
+	}
 	return nil
 }
 
@@ -1245,6 +1616,17 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1_ACMEIssuerDNS01Provid
 	out.Role = in.Role
 	out.HostedZoneID = in.HostedZoneID
 	out.Region = in.Region
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]v1.ACMEIssuerDNS01ProviderRoute53Zone, len(*in))
+		for i := range *in {
+			if err := Convert_acme_ACMEIssuerDNS01ProviderRoute53Zone_To_v1_ACMEIssuerDNS01ProviderRoute53Zone(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Zones = nil
+	}
 	return nil
 }
 
@@ -1253,6 +1635,66 @@ func Convert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1_ACMEIssuerDNS01ProviderRo
 	return autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1_ACMEIssuerDNS01ProviderRoute53(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderRoute53Zone_To_acme_ACMEIssuerDNS01ProviderRoute53Zone(in *v1.ACMEIssuerDNS01ProviderRoute53Zone, out *acme.ACMEIssuerDNS01ProviderRoute53Zone, s conversion.Scope) error {
+	out.Zone = in.Zone
+	if in.AccessKeyID != nil {
+		in, out := &in.AccessKeyID, &out.AccessKeyID
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AccessKeyID = nil
+	}
+	if in.SecretAccessKey != nil {
+		in, out := &in.SecretAccessKey, &out.SecretAccessKey
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecretAccessKey = nil
+	}
+	out.Role = in.Role
+	out.HostedZoneID = in.HostedZoneID
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderRoute53Zone_To_acme_ACMEIssuerDNS01ProviderRoute53Zone is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderRoute53Zone_To_acme_ACMEIssuerDNS01ProviderRoute53Zone(in *v1.ACMEIssuerDNS01ProviderRoute53Zone, out *acme.ACMEIssuerDNS01ProviderRoute53Zone, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderRoute53Zone_To_acme_ACMEIssuerDNS01ProviderRoute53Zone(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53Zone_To_v1_ACMEIssuerDNS01ProviderRoute53Zone(in *acme.ACMEIssuerDNS01ProviderRoute53Zone, out *v1.ACMEIssuerDNS01ProviderRoute53Zone, s conversion.Scope) error {
+	out.Zone = in.Zone
+	if in.AccessKeyID != nil {
+		in, out := &in.AccessKeyID, &out.AccessKeyID
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AccessKeyID = nil
+	}
+	if in.SecretAccessKey != nil {
+		in, out := &in.SecretAccessKey, &out.SecretAccessKey
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecretAccessKey = nil
+	}
+	out.Role = in.Role
+	out.HostedZoneID = in.HostedZoneID
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderRoute53Zone_To_v1_ACMEIssuerDNS01ProviderRoute53Zone is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderRoute53Zone_To_v1_ACMEIssuerDNS01ProviderRoute53Zone(in *acme.ACMEIssuerDNS01ProviderRoute53Zone, out *v1.ACMEIssuerDNS01ProviderRoute53Zone, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderRoute53Zone_To_v1_ACMEIssuerDNS01ProviderRoute53Zone(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderWebhook_To_acme_ACMEIssuerDNS01ProviderWebhook(in *v1.ACMEIssuerDNS01ProviderWebhook, out *acme.ACMEIssuerDNS01ProviderWebhook, s conversion.Scope) error {
 	out.GroupName = in.GroupName
 	out.SolverName = in.SolverName
@@ -1600,11 +2042,16 @@ func Convert_acme_OrderSpec_To_v1_OrderSpec(in *acme.OrderSpec, out *v1.OrderSpe
 func autoConvert_v1_OrderStatus_To_acme_OrderStatus(in *v1.OrderStatus, out *acme.OrderStatus, s conversion.Scope) error {
 	out.URL = in.URL
 	out.FinalizeURL = in.FinalizeURL
+	out.AccountURI = in.AccountURI
+	out.CertificateURL = in.CertificateURL
 	out.Authorizations = *(*[]acme.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FinalizeAttempts = in.FinalizeAttempts
+	out.LastFinalizeAttemptTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastFinalizeAttemptTime))
+	out.Conditions = *(*[]pkgapismetav1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -1616,11 +2063,16 @@ func Convert_v1_OrderStatus_To_acme_OrderStatus(in *v1.OrderStatus, out *acme.Or
 func autoConvert_acme_OrderStatus_To_v1_OrderStatus(in *acme.OrderStatus, out *v1.OrderStatus, s conversion.Scope) error {
 	out.URL = in.URL
 	out.FinalizeURL = in.FinalizeURL
+	out.AccountURI = in.AccountURI
+	out.CertificateURL = in.CertificateURL
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = v1.State(in.State)
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]v1.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FinalizeAttempts = in.FinalizeAttempts
+	out.LastFinalizeAttemptTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastFinalizeAttemptTime))
+	out.Conditions = *(*[]pkgapismetav1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -124,6 +124,17 @@ type ACMEExternalAccountBinding struct {
 	// in golang/x/crypto/acme.
 	// +optional
 	KeyAlgorithm HMACKeyAlgorithm `json:"keyAlgorithm,omitempty"`
+
+	// PerNamespace may only be set on ClusterIssuers. If true, keySecretRef is
+	// resolved in the namespace of each request rather than in the cluster
+	// resource namespace, and a separate ACME account is registered for
+	// every namespace containing the referenced Secret, so that tenants can
+	// use their own External Account Binding credentials. The private key of
+	// the account of a namespace is stored in that namespace, in the Secret
+	// referenced by privateKeySecretRef. Requests from namespaces without the
+	// referenced Secret are not processed.
+	// +optional
+	PerNamespace bool `json:"perNamespace,omitempty"`
 }
 
 // HMACKeyAlgorithm is the name of a key algorithm used for HMAC encryption
@@ -244,6 +255,34 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 	// ingress used for HTTP01 challenges
 	// +optional
 	IngressTemplate *ACMEChallengeSolverHTTP01IngressTemplate `json:"ingressTemplate,omitempty"`
+
+	// Optional burst profile. When set, challenges are served by a solver
+	// Deployment that is preallocated in the namespace of the challenge and
+	// scaled by the number of pending challenges, instead of by a pod created
+	// for every challenge. The controller must be permitted to manage
+	// Deployments and ConfigMaps in that namespace.
+	// +optional
+	BurstProfile *ACMEChallengeSolverHTTP01BurstProfile `json:"burstProfile,omitempty"`
+}
+
+// ACMEChallengeSolverHTTP01BurstProfile configures the solver Deployment used
+// to serve all pending HTTP01 challenges of a solver within a namespace.
+// New challenges are served once the kubelet has refreshed the challenges
+// mounted into the solver pods, which may take up to a minute.
+type ACMEChallengeSolverHTTP01BurstProfile struct {
+	// The number of solver replicas to keep running while no challenges are
+	// pending. Defaults to 1.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// The maximum number of solver replicas, regardless of the number of
+	// pending challenges.
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// The number of pending challenges each solver replica is expected to
+	// serve. Defaults to 10.
+	// +optional
+	ChallengesPerReplica *int32 `json:"challengesPerReplica,omitempty"`
 }
 
 type ACMEChallengeSolverHTTP01GatewayHTTPRoute struct {
@@ -274,7 +313,8 @@ type ACMEChallengeSolverHTTP01IngressPodTemplate struct {
 
 	// PodSpec defines overrides for the HTTP01 challenge solver pod.
 	// Only the 'priorityClassName', 'nodeSelector', 'affinity',
-	// 'serviceAccountName' and 'tolerations' fields are supported currently.
+	// 'serviceAccountName', 'tolerations' and 'resources' fields are supported
+	// currently.
 	// All other fields will be ignored.
 	// +optional
 	Spec ACMEChallengeSolverHTTP01IngressPodSpec `json:"spec"`
@@ -312,6 +352,12 @@ type ACMEChallengeSolverHTTP01IngressPodSpec struct {
 	// If specified, the pod's service account
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// If specified, the compute resources of the solver container, overriding
+	// the defaults configured on the controller. This allows the solver to fit
+	// into namespaces whose ResourceQuota or LimitRange rejects the defaults.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 type ACMEChallengeSolverHTTP01IngressTemplate struct {
@@ -342,6 +388,20 @@ type ACMEChallengeSolverDNS01 struct {
 	// +optional
 	CNAMEStrategy CNAMEStrategy `json:"cnameStrategy,omitempty"`
 
+	// ValidationZone is the DNS zone in which challenge records are
+	// published when DNS01 validation is delegated to a dedicated zone, for
+	// example when _acme-challenge.apps.example.com is a CNAME record
+	// pointing into acme.example.com. It may only be set together with the
+	// Follow cnameStrategy.
+	// Challenge records are only presented if the CNAME records resolve to a
+	// name within the zone, which is passed to the externalDNS, hetzner,
+	// rfc2136 and webhook providers instead of the zone discovered with SOA
+	// queries. As the challenge records of a wildcard name and of its
+	// base domain are the same, the selector of this solver matches a domain
+	// if it matches either of them.
+	// +optional
+	ValidationZone string `json:"validationZone,omitempty"`
+
 	// Use the Akamai DNS zone management API to manage DNS01 challenge records.
 	// +optional
 	Akamai *ACMEIssuerDNS01ProviderAkamai `json:"akamai,omitempty"`
@@ -366,11 +426,21 @@ type ACMEChallengeSolverDNS01 struct {
 	// +optional
 	DigitalOcean *ACMEIssuerDNS01ProviderDigitalOcean `json:"digitalocean,omitempty"`
 
+	// Use the Hetzner DNS API to manage DNS01 challenge records.
+	// +optional
+	Hetzner *ACMEIssuerDNS01ProviderHetzner `json:"hetzner,omitempty"`
+
 	// Use the 'ACME DNS' (https://github.com/joohoi/acme-dns) API to manage
 	// DNS01 challenge records.
 	// +optional
 	AcmeDNS *ACMEIssuerDNS01ProviderAcmeDNS `json:"acmedns,omitempty"`
 
+	// Use ExternalDNS (https://github.com/kubernetes-sigs/external-dns) to
+	// manage DNS01 challenge records, by creating DNSEndpoint resources for
+	// them. ExternalDNS must be running with the crd source enabled.
+	// +optional
+	ExternalDNS *ACMEIssuerDNS01ProviderExternalDNS `json:"externalDNS,omitempty"`
+
 	// Use RFC2136 ("Dynamic Updates in the Domain Name System") (https://datatracker.ietf.org/doc/rfc2136/)
 	// to manage DNS01 challenge records.
 	// +optional
@@ -422,6 +492,35 @@ type ACMEIssuerDNS01ProviderCloudDNS struct {
 	// If left empty cert-manager will automatically choose a zone.
 	// +optional
 	HostedZoneName string `json:"hostedZoneName,omitempty"`
+
+	// Zones is a list of DNS zones which are managed using their own
+	// credentials. If the domain being validated is within one of these
+	// zones, the entry with the longest matching zone is used in place of
+	// the credentials configured on this provider.
+	// +optional
+	Zones []ACMEIssuerDNS01ProviderCloudDNSZone `json:"zones,omitempty"`
+}
+
+// ACMEIssuerDNS01ProviderCloudDNSZone configures the credentials used to
+// solve challenges for domains within a single Google Cloud DNS zone.
+type ACMEIssuerDNS01ProviderCloudDNSZone struct {
+	// Zone is the DNS name of the zone, e.g. `example.com`. Challenges for
+	// this domain and any of its subdomains use this entry.
+	Zone string `json:"zone"`
+
+	// ServiceAccount is used to authenticate with Cloud DNS for this zone.
+	ServiceAccount cmmeta.SecretKeySelector `json:"serviceAccountSecretRef"`
+
+	// Project the zone is located in. Defaults to the project configured on
+	// the provider.
+	// +optional
+	Project string `json:"project,omitempty"`
+
+	// HostedZoneName is an optional field that tells cert-manager in which
+	// Cloud DNS zone the challenge record has to be created.
+	// If left empty cert-manager will automatically choose a zone.
+	// +optional
+	HostedZoneName string `json:"hostedZoneName,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderCloudflare is a structure containing the DNS
@@ -449,6 +548,13 @@ type ACMEIssuerDNS01ProviderDigitalOcean struct {
 	Token cmmeta.SecretKeySelector `json:"tokenSecretRef"`
 }
 
+// ACMEIssuerDNS01ProviderHetzner is a structure containing the DNS
+// configuration for Hetzner DNS
+type ACMEIssuerDNS01ProviderHetzner struct {
+	// API token used to authenticate with the Hetzner DNS API.
+	APIToken cmmeta.SecretKeySelector `json:"apiTokenSecretRef"`
+}
+
 // ACMEIssuerDNS01ProviderRoute53 is a structure containing the Route 53
 // configuration for AWS
 type ACMEIssuerDNS01ProviderRoute53 struct {
@@ -478,6 +584,43 @@ type ACMEIssuerDNS01ProviderRoute53 struct {
 
 	// Always set the region when using AccessKeyID and SecretAccessKey
 	Region string `json:"region"`
+
+	// Zones is a list of DNS zones which are managed using their own
+	// credentials. If the domain being validated is within one of these
+	// zones, the entry with the longest matching zone is used in place of
+	// the credentials configured on this provider.
+	// +optional
+	Zones []ACMEIssuerDNS01ProviderRoute53Zone `json:"zones,omitempty"`
+}
+
+// ACMEIssuerDNS01ProviderRoute53Zone configures the credentials used to
+// solve challenges for domains within a single Route 53 zone.
+// Either both `accessKeyIDSecretRef` and `secretAccessKeySecretRef`, or
+// `role`, or all three must be provided.
+type ACMEIssuerDNS01ProviderRoute53Zone struct {
+	// Zone is the DNS name of the zone, e.g. `example.com`. Challenges for
+	// this domain and any of its subdomains use this entry.
+	Zone string `json:"zone"`
+
+	// The AccessKeyID is used for authentication. Pull the AWS access key ID
+	// from a key within a Kubernetes Secret.
+	// +optional
+	AccessKeyID *cmmeta.SecretKeySelector `json:"accessKeyIDSecretRef,omitempty"`
+
+	// The SecretAccessKey is used for authentication.
+	// +optional
+	SecretAccessKey *cmmeta.SecretKeySelector `json:"secretAccessKeySecretRef,omitempty"`
+
+	// Role is a Role ARN which the Route53 provider will assume for this
+	// zone, using either the explicit credentials of this entry or the
+	// inferred credentials from environment variables, shared credentials
+	// file or AWS Instance metadata.
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// If set, the provider will manage only this zone in Route53 and will not do an lookup using the route53:ListHostedZonesByName api call.
+	// +optional
+	HostedZoneID string `json:"hostedZoneID,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderAzureDNS is a structure containing the
@@ -512,6 +655,43 @@ type ACMEIssuerDNS01ProviderAzureDNS struct {
 	// managed identity configuration, can not be used at the same time as clientID, clientSecretSecretRef or tenantID
 	// +optional
 	ManagedIdentity *AzureManagedIdentity `json:"managedIdentity,omitempty"`
+
+	// Zones is a list of DNS zones which are managed using their own
+	// credentials. If the domain being validated is within one of these
+	// zones, the entry with the longest matching zone is used in place of
+	// the credentials configured on this provider.
+	// +optional
+	Zones []ACMEIssuerDNS01ProviderAzureDNSZone `json:"zones,omitempty"`
+}
+
+// ACMEIssuerDNS01ProviderAzureDNSZone configures the service principal used
+// to solve challenges for domains within a single Azure DNS zone.
+type ACMEIssuerDNS01ProviderAzureDNSZone struct {
+	// Zone is the DNS name of the zone, e.g. `example.com`. Challenges for
+	// this domain and any of its subdomains use this entry.
+	Zone string `json:"zone"`
+
+	// client ID of the service principal used for this zone
+	ClientID string `json:"clientID"`
+
+	// client secret of the service principal used for this zone
+	ClientSecret cmmeta.SecretKeySelector `json:"clientSecretSecretRef"`
+
+	// tenant of the service principal, defaults to the tenant configured on the provider
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+
+	// ID of the Azure subscription, defaults to the subscription configured on the provider
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// resource group the DNS zone is located in, defaults to the resource group configured on the provider
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// name of the DNS zone that should be used
+	// +optional
+	HostedZoneName string `json:"hostedZoneName,omitempty"`
 }
 
 type AzureManagedIdentity struct {
@@ -562,11 +742,68 @@ type ACMEIssuerDNS01ProviderRFC2136 struct {
 	TSIGKeyName string `json:"tsigKeyName,omitempty"`
 
 	// The TSIG Algorithm configured in the DNS supporting RFC2136. Used only
-	// when ``tsigSecretSecretRef`` and ``tsigKeyName`` are defined.
+	// when ``tsigSecretSecretRef`` and ``tsigKeyName`` are defined, unless it
+	// is ``GSS-TSIG``.
 	// Supported values are (case-insensitive): ``HMACMD5`` (default),
-	// ``HMACSHA1``, ``HMACSHA256`` or ``HMACSHA512``.
+	// ``HMACSHA1``, ``HMACSHA256``, ``HMACSHA512`` or ``GSS-TSIG``.
+	// ``GSS-TSIG`` authenticates updates with Kerberos, using the
+	// credentials configured in ``kerberos``, which must then be set.
 	// +optional
 	TSIGAlgorithm string `json:"tsigAlgorithm,omitempty"`
+
+	// Kerberos configures the credentials with which updates are signed when
+	// ``tsigAlgorithm`` is ``GSS-TSIG``, for example to update the zones of
+	// Active Directory-integrated DNS servers.
+	// +optional
+	Kerberos *ACMEIssuerDNS01ProviderRFC2136Kerberos `json:"kerberos,omitempty"`
+}
+
+// ACMEIssuerDNS01ProviderRFC2136Kerberos holds the Kerberos credentials used
+// to sign RFC2136 updates with GSS-TSIG. Exactly one of keytabSecretRef and
+// passwordSecretRef must be set. Only the AES encryption types are supported.
+type ACMEIssuerDNS01ProviderRFC2136Kerberos struct {
+	// Realm is the Kerberos realm of the principal, usually the upper-case
+	// name of the Active Directory domain, e.g. ``EXAMPLE.COM``.
+	Realm string `json:"realm"`
+
+	// Username is the name of the principal without its realm, e.g. the
+	// ``sAMAccountName`` of an Active Directory account.
+	Username string `json:"username"`
+
+	// KDC is the address of the Key Distribution Center in the form host or
+	// host:port. Defaults to the host of the nameserver on port 88, as the
+	// nameservers of Active Directory are usually its domain controllers.
+	// +optional
+	KDC string `json:"kdc,omitempty"`
+
+	// ServicePrincipalName is the name of the principal of the nameserver.
+	// Defaults to ``DNS/<host>`` where host is the host of the nameserver,
+	// which must then be its fully qualified domain name rather than an IP
+	// address.
+	// +optional
+	ServicePrincipalName string `json:"servicePrincipalName,omitempty"`
+
+	// KeytabSecretRef references a keytab holding the keys of the principal.
+	// +optional
+	KeytabSecretRef *cmmeta.SecretKeySelector `json:"keytabSecretRef,omitempty"`
+
+	// PasswordSecretRef references the password of the principal.
+	// +optional
+	PasswordSecretRef *cmmeta.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// ACMEIssuerDNS01ProviderExternalDNS is a structure containing the
+// configuration of DNSEndpoint resources created for ExternalDNS.
+type ACMEIssuerDNS01ProviderExternalDNS struct {
+	// Labels to add to the DNSEndpoint resources, e.g. to match the label
+	// filter of the ExternalDNS instance which manages the DNS zone.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// The TTL of the challenge records in seconds. Defaults to the TTL
+	// configured in ExternalDNS.
+	// +optional
+	RecordTTL *int64 `json:"recordTTL,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderWebhook specifies configuration for a webhook DNS01
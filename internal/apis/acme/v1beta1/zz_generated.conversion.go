@@ -93,6 +93,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01BurstProfile)(nil), (*acme.ACMEChallengeSolverHTTP01BurstProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01BurstProfile_To_acme_ACMEChallengeSolverHTTP01BurstProfile(a.(*ACMEChallengeSolverHTTP01BurstProfile), b.(*acme.ACMEChallengeSolverHTTP01BurstProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01BurstProfile)(nil), (*ACMEChallengeSolverHTTP01BurstProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01BurstProfile_To_v1beta1_ACMEChallengeSolverHTTP01BurstProfile(a.(*acme.ACMEChallengeSolverHTTP01BurstProfile), b.(*ACMEChallengeSolverHTTP01BurstProfile), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(a.(*ACMEChallengeSolverHTTP01GatewayHTTPRoute), b.(*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute), scope)
 	}); err != nil {
@@ -203,6 +213,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderAzureDNSZone)(nil), (*acme.ACMEIssuerDNS01ProviderAzureDNSZone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEIssuerDNS01ProviderAzureDNSZone_To_acme_ACMEIssuerDNS01ProviderAzureDNSZone(a.(*ACMEIssuerDNS01ProviderAzureDNSZone), b.(*acme.ACMEIssuerDNS01ProviderAzureDNSZone), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderAzureDNSZone)(nil), (*ACMEIssuerDNS01ProviderAzureDNSZone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderAzureDNSZone_To_v1beta1_ACMEIssuerDNS01ProviderAzureDNSZone(a.(*acme.ACMEIssuerDNS01ProviderAzureDNSZone), b.(*ACMEIssuerDNS01ProviderAzureDNSZone), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderCloudDNS)(nil), (*acme.ACMEIssuerDNS01ProviderCloudDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderCloudDNS(a.(*ACMEIssuerDNS01ProviderCloudDNS), b.(*acme.ACMEIssuerDNS01ProviderCloudDNS), scope)
 	}); err != nil {
@@ -213,6 +233,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderCloudDNSZone)(nil), (*acme.ACMEIssuerDNS01ProviderCloudDNSZone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEIssuerDNS01ProviderCloudDNSZone_To_acme_ACMEIssuerDNS01ProviderCloudDNSZone(a.(*ACMEIssuerDNS01ProviderCloudDNSZone), b.(*acme.ACMEIssuerDNS01ProviderCloudDNSZone), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderCloudDNSZone)(nil), (*ACMEIssuerDNS01ProviderCloudDNSZone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderCloudDNSZone_To_v1beta1_ACMEIssuerDNS01ProviderCloudDNSZone(a.(*acme.ACMEIssuerDNS01ProviderCloudDNSZone), b.(*ACMEIssuerDNS01ProviderCloudDNSZone), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderCloudflare)(nil), (*acme.ACMEIssuerDNS01ProviderCloudflare)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEIssuerDNS01ProviderCloudflare_To_acme_ACMEIssuerDNS01ProviderCloudflare(a.(*ACMEIssuerDNS01ProviderCloudflare), b.(*acme.ACMEIssuerDNS01ProviderCloudflare), scope)
 	}); err != nil {
@@ -233,6 +263,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderExternalDNS)(nil), (*acme.ACMEIssuerDNS01ProviderExternalDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEIssuerDNS01ProviderExternalDNS_To_acme_ACMEIssuerDNS01ProviderExternalDNS(a.(*ACMEIssuerDNS01ProviderExternalDNS), b.(*acme.ACMEIssuerDNS01ProviderExternalDNS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderExternalDNS)(nil), (*ACMEIssuerDNS01ProviderExternalDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderExternalDNS_To_v1beta1_ACMEIssuerDNS01ProviderExternalDNS(a.(*acme.ACMEIssuerDNS01ProviderExternalDNS), b.(*ACMEIssuerDNS01ProviderExternalDNS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderHetzner)(nil), (*acme.ACMEIssuerDNS01ProviderHetzner)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEIssuerDNS01ProviderHetzner_To_acme_ACMEIssuerDNS01ProviderHetzner(a.(*ACMEIssuerDNS01ProviderHetzner), b.(*acme.ACMEIssuerDNS01ProviderHetzner), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderHetzner)(nil), (*ACMEIssuerDNS01ProviderHetzner)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderHetzner_To_v1beta1_ACMEIssuerDNS01ProviderHetzner(a.(*acme.ACMEIssuerDNS01ProviderHetzner), b.(*ACMEIssuerDNS01ProviderHetzner), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderRFC2136)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(a.(*ACMEIssuerDNS01ProviderRFC2136), b.(*acme.ACMEIssuerDNS01ProviderRFC2136), scope)
 	}); err != nil {
@@ -243,6 +293,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderRFC2136Kerberos)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136Kerberos)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos(a.(*ACMEIssuerDNS01ProviderRFC2136Kerberos), b.(*acme.ACMEIssuerDNS01ProviderRFC2136Kerberos), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderRFC2136Kerberos)(nil), (*ACMEIssuerDNS01ProviderRFC2136Kerberos)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_v1beta1_ACMEIssuerDNS01ProviderRFC2136Kerberos(a.(*acme.ACMEIssuerDNS01ProviderRFC2136Kerberos), b.(*ACMEIssuerDNS01ProviderRFC2136Kerberos), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderRoute53)(nil), (*acme.ACMEIssuerDNS01ProviderRoute53)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01ProviderRoute53(a.(*ACMEIssuerDNS01ProviderRoute53), b.(*acme.ACMEIssuerDNS01ProviderRoute53), scope)
 	}); err != nil {
@@ -253,6 +313,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderRoute53Zone)(nil), (*acme.ACMEIssuerDNS01ProviderRoute53Zone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEIssuerDNS01ProviderRoute53Zone_To_acme_ACMEIssuerDNS01ProviderRoute53Zone(a.(*ACMEIssuerDNS01ProviderRoute53Zone), b.(*acme.ACMEIssuerDNS01ProviderRoute53Zone), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderRoute53Zone)(nil), (*ACMEIssuerDNS01ProviderRoute53Zone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderRoute53Zone_To_v1beta1_ACMEIssuerDNS01ProviderRoute53Zone(a.(*acme.ACMEIssuerDNS01ProviderRoute53Zone), b.(*ACMEIssuerDNS01ProviderRoute53Zone), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderWebhook)(nil), (*acme.ACMEIssuerDNS01ProviderWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEIssuerDNS01ProviderWebhook_To_acme_ACMEIssuerDNS01ProviderWebhook(a.(*ACMEIssuerDNS01ProviderWebhook), b.(*acme.ACMEIssuerDNS01ProviderWebhook), scope)
 	}); err != nil {
@@ -391,6 +461,7 @@ func autoConvert_v1beta1_ACMEAuthorization_To_acme_ACMEAuthorization(in *ACMEAut
 	out.Identifier = in.Identifier
 	out.Wildcard = (*bool)(unsafe.Pointer(in.Wildcard))
 	out.InitialState = acme.State(in.InitialState)
+	out.State = acme.State(in.State)
 	out.Challenges = *(*[]acme.ACMEChallenge)(unsafe.Pointer(&in.Challenges))
 	return nil
 }
@@ -405,6 +476,7 @@ func autoConvert_acme_ACMEAuthorization_To_v1beta1_ACMEAuthorization(in *acme.AC
 	out.Identifier = in.Identifier
 	out.Wildcard = (*bool)(unsafe.Pointer(in.Wildcard))
 	out.InitialState = State(in.InitialState)
+	out.State = State(in.State)
 	out.Challenges = *(*[]ACMEChallenge)(unsafe.Pointer(&in.Challenges))
 	return nil
 }
@@ -480,6 +552,7 @@ func Convert_acme_ACMEChallengeSolver_To_v1beta1_ACMEChallengeSolver(in *acme.AC
 
 func autoConvert_v1beta1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *ACMEChallengeSolverDNS01, out *acme.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = acme.CNAMEStrategy(in.CNAMEStrategy)
+	out.ValidationZone = in.ValidationZone
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(acme.ACMEIssuerDNS01ProviderAkamai)
@@ -534,6 +607,15 @@ func autoConvert_v1beta1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Hetzner != nil {
+		in, out := &in.Hetzner, &out.Hetzner
+		*out = new(acme.ACMEIssuerDNS01ProviderHetzner)
+		if err := Convert_v1beta1_ACMEIssuerDNS01ProviderHetzner_To_acme_ACMEIssuerDNS01ProviderHetzner(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hetzner = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(acme.ACMEIssuerDNS01ProviderAcmeDNS)
@@ -543,6 +625,7 @@ func autoConvert_v1beta1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS
 	} else {
 		out.AcmeDNS = nil
 	}
+	out.ExternalDNS = (*acme.ACMEIssuerDNS01ProviderExternalDNS)(unsafe.Pointer(in.ExternalDNS))
 	if in.RFC2136 != nil {
 		in, out := &in.RFC2136, &out.RFC2136
 		*out = new(acme.ACMEIssuerDNS01ProviderRFC2136)
@@ -563,6 +646,7 @@ func Convert_v1beta1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(i
 
 func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1beta1_ACMEChallengeSolverDNS01(in *acme.ACMEChallengeSolverDNS01, out *ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = CNAMEStrategy(in.CNAMEStrategy)
+	out.ValidationZone = in.ValidationZone
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(ACMEIssuerDNS01ProviderAkamai)
@@ -617,6 +701,15 @@ func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1beta1_ACMEChallengeSolverDNS
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Hetzner != nil {
+		in, out := &in.Hetzner, &out.Hetzner
+		*out = new(ACMEIssuerDNS01ProviderHetzner)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderHetzner_To_v1beta1_ACMEIssuerDNS01ProviderHetzner(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hetzner = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(ACMEIssuerDNS01ProviderAcmeDNS)
@@ -626,6 +719,7 @@ func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1beta1_ACMEChallengeSolverDNS
 	} else {
 		out.AcmeDNS = nil
 	}
+	out.ExternalDNS = (*ACMEIssuerDNS01ProviderExternalDNS)(unsafe.Pointer(in.ExternalDNS))
 	if in.RFC2136 != nil {
 		in, out := &in.RFC2136, &out.RFC2136
 		*out = new(ACMEIssuerDNS01ProviderRFC2136)
@@ -666,6 +760,30 @@ func Convert_acme_ACMEChallengeSolverHTTP01_To_v1beta1_ACMEChallengeSolverHTTP01
 	return autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1beta1_ACMEChallengeSolverHTTP01(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01BurstProfile_To_acme_ACMEChallengeSolverHTTP01BurstProfile(in *ACMEChallengeSolverHTTP01BurstProfile, out *acme.ACMEChallengeSolverHTTP01BurstProfile, s conversion.Scope) error {
+	out.MinReplicas = (*int32)(unsafe.Pointer(in.MinReplicas))
+	out.MaxReplicas = in.MaxReplicas
+	out.ChallengesPerReplica = (*int32)(unsafe.Pointer(in.ChallengesPerReplica))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01BurstProfile_To_acme_ACMEChallengeSolverHTTP01BurstProfile is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01BurstProfile_To_acme_ACMEChallengeSolverHTTP01BurstProfile(in *ACMEChallengeSolverHTTP01BurstProfile, out *acme.ACMEChallengeSolverHTTP01BurstProfile, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01BurstProfile_To_acme_ACMEChallengeSolverHTTP01BurstProfile(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01BurstProfile_To_v1beta1_ACMEChallengeSolverHTTP01BurstProfile(in *acme.ACMEChallengeSolverHTTP01BurstProfile, out *ACMEChallengeSolverHTTP01BurstProfile, s conversion.Scope) error {
+	out.MinReplicas = (*int32)(unsafe.Pointer(in.MinReplicas))
+	out.MaxReplicas = in.MaxReplicas
+	out.ChallengesPerReplica = (*int32)(unsafe.Pointer(in.ChallengesPerReplica))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01BurstProfile_To_v1beta1_ACMEChallengeSolverHTTP01BurstProfile is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01BurstProfile_To_v1beta1_ACMEChallengeSolverHTTP01BurstProfile(in *acme.ACMEChallengeSolverHTTP01BurstProfile, out *ACMEChallengeSolverHTTP01BurstProfile, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01BurstProfile_To_v1beta1_ACMEChallengeSolverHTTP01BurstProfile(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
 	out.ServiceType = v1.ServiceType(in.ServiceType)
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
@@ -696,6 +814,7 @@ func autoConvert_v1beta1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeS
 	out.Name = in.Name
 	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*acme.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
+	out.BurstProfile = (*acme.ACMEChallengeSolverHTTP01BurstProfile)(unsafe.Pointer(in.BurstProfile))
 	return nil
 }
 
@@ -710,6 +829,7 @@ func autoConvert_acme_ACMEChallengeSolverHTTP01Ingress_To_v1beta1_ACMEChallengeS
 	out.Name = in.Name
 	out.PodTemplate = (*ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
+	out.BurstProfile = (*ACMEChallengeSolverHTTP01BurstProfile)(unsafe.Pointer(in.BurstProfile))
 	return nil
 }
 
@@ -768,6 +888,7 @@ func autoConvert_v1beta1_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMECha
 	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
+	out.Resources = (*v1.ResourceRequirements)(unsafe.Pointer(in.Resources))
 	return nil
 }
 
@@ -782,6 +903,7 @@ func autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodSpec_To_v1beta1_ACMECha
 	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
+	out.Resources = (*v1.ResourceRequirements)(unsafe.Pointer(in.Resources))
 	return nil
 }
 
@@ -850,6 +972,7 @@ func autoConvert_v1beta1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountB
 		return err
 	}
 	out.KeyAlgorithm = acme.HMACKeyAlgorithm(in.KeyAlgorithm)
+	out.PerNamespace = in.PerNamespace
 	return nil
 }
 
@@ -864,6 +987,7 @@ func autoConvert_acme_ACMEExternalAccountBinding_To_v1beta1_ACMEExternalAccountB
 		return err
 	}
 	out.KeyAlgorithm = HMACKeyAlgorithm(in.KeyAlgorithm)
+	out.PerNamespace = in.PerNamespace
 	return nil
 }
 
@@ -1019,6 +1143,17 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderAzureDNS_To_acme_ACMEIssuerDNS01
 	out.HostedZoneName = in.HostedZoneName
 	out.Environment = acme.AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*acme.AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]acme.ACMEIssuerDNS01ProviderAzureDNSZone, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_ACMEIssuerDNS01ProviderAzureDNSZone_To_acme_ACMEIssuerDNS01ProviderAzureDNSZone(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Zones = nil
+	}
 	return nil
 }
 
@@ -1044,6 +1179,17 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1beta1_ACMEIssuerDNS01
 	out.HostedZoneName = in.HostedZoneName
 	out.Environment = AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ACMEIssuerDNS01ProviderAzureDNSZone, len(*in))
+		for i := range *in {
+			if err := Convert_acme_ACMEIssuerDNS01ProviderAzureDNSZone_To_v1beta1_ACMEIssuerDNS01ProviderAzureDNSZone(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Zones = nil
+	}
 	return nil
 }
 
@@ -1052,6 +1198,42 @@ func Convert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1beta1_ACMEIssuerDNS01Prov
 	return autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1beta1_ACMEIssuerDNS01ProviderAzureDNS(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEIssuerDNS01ProviderAzureDNSZone_To_acme_ACMEIssuerDNS01ProviderAzureDNSZone(in *ACMEIssuerDNS01ProviderAzureDNSZone, out *acme.ACMEIssuerDNS01ProviderAzureDNSZone, s conversion.Scope) error {
+	out.Zone = in.Zone
+	out.ClientID = in.ClientID
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.ClientSecret, &out.ClientSecret, s); err != nil {
+		return err
+	}
+	out.TenantID = in.TenantID
+	out.SubscriptionID = in.SubscriptionID
+	out.ResourceGroupName = in.ResourceGroupName
+	out.HostedZoneName = in.HostedZoneName
+	return nil
+}
+
+// Convert_v1beta1_ACMEIssuerDNS01ProviderAzureDNSZone_To_acme_ACMEIssuerDNS01ProviderAzureDNSZone is an autogenerated conversion function.
+func Convert_v1beta1_ACMEIssuerDNS01ProviderAzureDNSZone_To_acme_ACMEIssuerDNS01ProviderAzureDNSZone(in *ACMEIssuerDNS01ProviderAzureDNSZone, out *acme.ACMEIssuerDNS01ProviderAzureDNSZone, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEIssuerDNS01ProviderAzureDNSZone_To_acme_ACMEIssuerDNS01ProviderAzureDNSZone(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNSZone_To_v1beta1_ACMEIssuerDNS01ProviderAzureDNSZone(in *acme.ACMEIssuerDNS01ProviderAzureDNSZone, out *ACMEIssuerDNS01ProviderAzureDNSZone, s conversion.Scope) error {
+	out.Zone = in.Zone
+	out.ClientID = in.ClientID
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.ClientSecret, &out.ClientSecret, s); err != nil {
+		return err
+	}
+	out.TenantID = in.TenantID
+	out.SubscriptionID = in.SubscriptionID
+	out.ResourceGroupName = in.ResourceGroupName
+	out.HostedZoneName = in.HostedZoneName
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderAzureDNSZone_To_v1beta1_ACMEIssuerDNS01ProviderAzureDNSZone is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderAzureDNSZone_To_v1beta1_ACMEIssuerDNS01ProviderAzureDNSZone(in *acme.ACMEIssuerDNS01ProviderAzureDNSZone, out *ACMEIssuerDNS01ProviderAzureDNSZone, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNSZone_To_v1beta1_ACMEIssuerDNS01ProviderAzureDNSZone(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderCloudDNS(in *ACMEIssuerDNS01ProviderCloudDNS, out *acme.ACMEIssuerDNS01ProviderCloudDNS, s conversion.Scope) error {
 	if in.ServiceAccount != nil {
 		in, out := &in.ServiceAccount, &out.ServiceAccount
@@ -1064,6 +1246,17 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]acme.ACMEIssuerDNS01ProviderCloudDNSZone, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_ACMEIssuerDNS01ProviderCloudDNSZone_To_acme_ACMEIssuerDNS01ProviderCloudDNSZone(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Zones = nil
+	}
 	return nil
 }
 
@@ -1084,6 +1277,17 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1beta1_ACMEIssuerDNS01
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ACMEIssuerDNS01ProviderCloudDNSZone, len(*in))
+		for i := range *in {
+			if err := Convert_acme_ACMEIssuerDNS01ProviderCloudDNSZone_To_v1beta1_ACMEIssuerDNS01ProviderCloudDNSZone(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Zones = nil
+	}
 	return nil
 }
 
@@ -1092,6 +1296,36 @@ func Convert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1beta1_ACMEIssuerDNS01Prov
 	return autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1beta1_ACMEIssuerDNS01ProviderCloudDNS(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEIssuerDNS01ProviderCloudDNSZone_To_acme_ACMEIssuerDNS01ProviderCloudDNSZone(in *ACMEIssuerDNS01ProviderCloudDNSZone, out *acme.ACMEIssuerDNS01ProviderCloudDNSZone, s conversion.Scope) error {
+	out.Zone = in.Zone
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.ServiceAccount, &out.ServiceAccount, s); err != nil {
+		return err
+	}
+	out.Project = in.Project
+	out.HostedZoneName = in.HostedZoneName
+	return nil
+}
+
+// Convert_v1beta1_ACMEIssuerDNS01ProviderCloudDNSZone_To_acme_ACMEIssuerDNS01ProviderCloudDNSZone is an autogenerated conversion function.
+func Convert_v1beta1_ACMEIssuerDNS01ProviderCloudDNSZone_To_acme_ACMEIssuerDNS01ProviderCloudDNSZone(in *ACMEIssuerDNS01ProviderCloudDNSZone, out *acme.ACMEIssuerDNS01ProviderCloudDNSZone, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEIssuerDNS01ProviderCloudDNSZone_To_acme_ACMEIssuerDNS01ProviderCloudDNSZone(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNSZone_To_v1beta1_ACMEIssuerDNS01ProviderCloudDNSZone(in *acme.ACMEIssuerDNS01ProviderCloudDNSZone, out *ACMEIssuerDNS01ProviderCloudDNSZone, s conversion.Scope) error {
+	out.Zone = in.Zone
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.ServiceAccount, &out.ServiceAccount, s); err != nil {
+		return err
+	}
+	out.Project = in.Project
+	out.HostedZoneName = in.HostedZoneName
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderCloudDNSZone_To_v1beta1_ACMEIssuerDNS01ProviderCloudDNSZone is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderCloudDNSZone_To_v1beta1_ACMEIssuerDNS01ProviderCloudDNSZone(in *acme.ACMEIssuerDNS01ProviderCloudDNSZone, out *ACMEIssuerDNS01ProviderCloudDNSZone, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNSZone_To_v1beta1_ACMEIssuerDNS01ProviderCloudDNSZone(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEIssuerDNS01ProviderCloudflare_To_acme_ACMEIssuerDNS01ProviderCloudflare(in *ACMEIssuerDNS01ProviderCloudflare, out *acme.ACMEIssuerDNS01ProviderCloudflare, s conversion.Scope) error {
 	out.Email = in.Email
 	if in.APIKey != nil {
@@ -1172,6 +1406,52 @@ func Convert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1beta1_ACMEIssuerDNS01
 	return autoConvert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1beta1_ACMEIssuerDNS01ProviderDigitalOcean(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEIssuerDNS01ProviderExternalDNS_To_acme_ACMEIssuerDNS01ProviderExternalDNS(in *ACMEIssuerDNS01ProviderExternalDNS, out *acme.ACMEIssuerDNS01ProviderExternalDNS, s conversion.Scope) error {
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.RecordTTL = (*int64)(unsafe.Pointer(in.RecordTTL))
+	return nil
+}
+
+// Convert_v1beta1_ACMEIssuerDNS01ProviderExternalDNS_To_acme_ACMEIssuerDNS01ProviderExternalDNS is an autogenerated conversion function.
+func Convert_v1beta1_ACMEIssuerDNS01ProviderExternalDNS_To_acme_ACMEIssuerDNS01ProviderExternalDNS(in *ACMEIssuerDNS01ProviderExternalDNS, out *acme.ACMEIssuerDNS01ProviderExternalDNS, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEIssuerDNS01ProviderExternalDNS_To_acme_ACMEIssuerDNS01ProviderExternalDNS(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderExternalDNS_To_v1beta1_ACMEIssuerDNS01ProviderExternalDNS(in *acme.ACMEIssuerDNS01ProviderExternalDNS, out *ACMEIssuerDNS01ProviderExternalDNS, s conversion.Scope) error {
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.RecordTTL = (*int64)(unsafe.Pointer(in.RecordTTL))
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderExternalDNS_To_v1beta1_ACMEIssuerDNS01ProviderExternalDNS is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderExternalDNS_To_v1beta1_ACMEIssuerDNS01ProviderExternalDNS(in *acme.ACMEIssuerDNS01ProviderExternalDNS, out *ACMEIssuerDNS01ProviderExternalDNS, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderExternalDNS_To_v1beta1_ACMEIssuerDNS01ProviderExternalDNS(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEIssuerDNS01ProviderHetzner_To_acme_ACMEIssuerDNS01ProviderHetzner(in *ACMEIssuerDNS01ProviderHetzner, out *acme.ACMEIssuerDNS01ProviderHetzner, s conversion.Scope) error {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APIToken, &out.APIToken, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ACMEIssuerDNS01ProviderHetzner_To_acme_ACMEIssuerDNS01ProviderHetzner is an autogenerated conversion function.
+func Convert_v1beta1_ACMEIssuerDNS01ProviderHetzner_To_acme_ACMEIssuerDNS01ProviderHetzner(in *ACMEIssuerDNS01ProviderHetzner, out *acme.ACMEIssuerDNS01ProviderHetzner, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEIssuerDNS01ProviderHetzner_To_acme_ACMEIssuerDNS01ProviderHetzner(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderHetzner_To_v1beta1_ACMEIssuerDNS01ProviderHetzner(in *acme.ACMEIssuerDNS01ProviderHetzner, out *ACMEIssuerDNS01ProviderHetzner, s conversion.Scope) error {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APIToken, &out.APIToken, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderHetzner_To_v1beta1_ACMEIssuerDNS01ProviderHetzner is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderHetzner_To_v1beta1_ACMEIssuerDNS01ProviderHetzner(in *acme.ACMEIssuerDNS01ProviderHetzner, out *ACMEIssuerDNS01ProviderHetzner, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderHetzner_To_v1beta1_ACMEIssuerDNS01ProviderHetzner(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(in *ACMEIssuerDNS01ProviderRFC2136, out *acme.ACMEIssuerDNS01ProviderRFC2136, s conversion.Scope) error {
 	out.Nameserver = in.Nameserver
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.TSIGSecret, &out.TSIGSecret, s); err != nil {
@@ -1179,6 +1459,15 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01P
 	}
 	out.TSIGKeyName = in.TSIGKeyName
 	out.TSIGAlgorithm = in.TSIGAlgorithm
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(acme.ACMEIssuerDNS01ProviderRFC2136Kerberos)
+		if err := Convert_v1beta1_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Kerberos = nil
+	}
 	return nil
 }
 
@@ -1194,6 +1483,15 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1beta1_ACMEIssuerDNS01P
 	}
 	out.TSIGKeyName = in.TSIGKeyName
 	out.TSIGAlgorithm = in.TSIGAlgorithm
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(ACMEIssuerDNS01ProviderRFC2136Kerberos)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_v1beta1_ACMEIssuerDNS01ProviderRFC2136Kerberos(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Kerberos = nil
+	}
 	return nil
 }
 
@@ -1202,6 +1500,68 @@ func Convert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1beta1_ACMEIssuerDNS01Provi
 	return autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1beta1_ACMEIssuerDNS01ProviderRFC2136(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos(in *ACMEIssuerDNS01ProviderRFC2136Kerberos, out *acme.ACMEIssuerDNS01ProviderRFC2136Kerberos, s conversion.Scope) error {
+	out.Realm = in.Realm
+	out.Username = in.Username
+	out.KDC = in.KDC
+	out.ServicePrincipalName = in.ServicePrincipalName
+	if in.KeytabSecretRef != nil {
+		in, out := &in.KeytabSecretRef, &out.KeytabSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KeytabSecretRef = nil
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PasswordSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos is an autogenerated conversion function.
+func Convert_v1beta1_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos(in *ACMEIssuerDNS01ProviderRFC2136Kerberos, out *acme.ACMEIssuerDNS01ProviderRFC2136Kerberos, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_v1beta1_ACMEIssuerDNS01ProviderRFC2136Kerberos(in *acme.ACMEIssuerDNS01ProviderRFC2136Kerberos, out *ACMEIssuerDNS01ProviderRFC2136Kerberos, s conversion.Scope) error {
+	out.Realm = in.Realm
+	out.Username = in.Username
+	out.KDC = in.KDC
+	out.ServicePrincipalName = in.ServicePrincipalName
+	if in.KeytabSecretRef != nil {
+		in, out := &in.KeytabSecretRef, &out.KeytabSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KeytabSecretRef = nil
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PasswordSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_v1beta1_ACMEIssuerDNS01ProviderRFC2136Kerberos is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_v1beta1_ACMEIssuerDNS01ProviderRFC2136Kerberos(in *acme.ACMEIssuerDNS01ProviderRFC2136Kerberos, out *ACMEIssuerDNS01ProviderRFC2136Kerberos, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136Kerberos_To_v1beta1_ACMEIssuerDNS01ProviderRFC2136Kerberos(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01ProviderRoute53(in *ACMEIssuerDNS01ProviderRoute53, out *acme.ACMEIssuerDNS01ProviderRoute53, s conversion.Scope) error {
 	out.AccessKeyID = in.AccessKeyID
 	if in.SecretAccessKeyID != nil {
@@ -1219,6 +1579,17 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01P
 	out.Role = in.Role
 	out.HostedZoneID = in.HostedZoneID
 	out.Region = in.Region
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]acme.ACMEIssuerDNS01ProviderRoute53Zone, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_ACMEIssuerDNS01ProviderRoute53Zone_To_acme_ACMEIssuerDNS01ProviderRoute53Zone(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Zones = nil
+	}
 	return nil
 }
 
@@ -1244,6 +1615,17 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1beta1_ACMEIssuerDNS01P
 	out.Role = in.Role
 	out.HostedZoneID = in.HostedZoneID
 	out.Region = in.Region
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ACMEIssuerDNS01ProviderRoute53Zone, len(*in))
+		for i := range *in {
+			if err := Convert_acme_ACMEIssuerDNS01ProviderRoute53Zone_To_v1beta1_ACMEIssuerDNS01ProviderRoute53Zone(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Zones = nil
+	}
 	return nil
 }
 
@@ -1252,6 +1634,66 @@ func Convert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1beta1_ACMEIssuerDNS01Provi
 	return autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1beta1_ACMEIssuerDNS01ProviderRoute53(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEIssuerDNS01ProviderRoute53Zone_To_acme_ACMEIssuerDNS01ProviderRoute53Zone(in *ACMEIssuerDNS01ProviderRoute53Zone, out *acme.ACMEIssuerDNS01ProviderRoute53Zone, s conversion.Scope) error {
+	out.Zone = in.Zone
+	if in.AccessKeyID != nil {
+		in, out := &in.AccessKeyID, &out.AccessKeyID
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AccessKeyID = nil
+	}
+	if in.SecretAccessKey != nil {
+		in, out := &in.SecretAccessKey, &out.SecretAccessKey
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecretAccessKey = nil
+	}
+	out.Role = in.Role
+	out.HostedZoneID = in.HostedZoneID
+	return nil
+}
+
+// Convert_v1beta1_ACMEIssuerDNS01ProviderRoute53Zone_To_acme_ACMEIssuerDNS01ProviderRoute53Zone is an autogenerated conversion function.
+func Convert_v1beta1_ACMEIssuerDNS01ProviderRoute53Zone_To_acme_ACMEIssuerDNS01ProviderRoute53Zone(in *ACMEIssuerDNS01ProviderRoute53Zone, out *acme.ACMEIssuerDNS01ProviderRoute53Zone, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEIssuerDNS01ProviderRoute53Zone_To_acme_ACMEIssuerDNS01ProviderRoute53Zone(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53Zone_To_v1beta1_ACMEIssuerDNS01ProviderRoute53Zone(in *acme.ACMEIssuerDNS01ProviderRoute53Zone, out *ACMEIssuerDNS01ProviderRoute53Zone, s conversion.Scope) error {
+	out.Zone = in.Zone
+	if in.AccessKeyID != nil {
+		in, out := &in.AccessKeyID, &out.AccessKeyID
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AccessKeyID = nil
+	}
+	if in.SecretAccessKey != nil {
+		in, out := &in.SecretAccessKey, &out.SecretAccessKey
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecretAccessKey = nil
+	}
+	out.Role = in.Role
+	out.HostedZoneID = in.HostedZoneID
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderRoute53Zone_To_v1beta1_ACMEIssuerDNS01ProviderRoute53Zone is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderRoute53Zone_To_v1beta1_ACMEIssuerDNS01ProviderRoute53Zone(in *acme.ACMEIssuerDNS01ProviderRoute53Zone, out *ACMEIssuerDNS01ProviderRoute53Zone, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderRoute53Zone_To_v1beta1_ACMEIssuerDNS01ProviderRoute53Zone(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEIssuerDNS01ProviderWebhook_To_acme_ACMEIssuerDNS01ProviderWebhook(in *ACMEIssuerDNS01ProviderWebhook, out *acme.ACMEIssuerDNS01ProviderWebhook, s conversion.Scope) error {
 	out.GroupName = in.GroupName
 	out.SolverName = in.SolverName
@@ -1599,11 +2041,16 @@ func Convert_acme_OrderSpec_To_v1beta1_OrderSpec(in *acme.OrderSpec, out *OrderS
 func autoConvert_v1beta1_OrderStatus_To_acme_OrderStatus(in *OrderStatus, out *acme.OrderStatus, s conversion.Scope) error {
 	out.URL = in.URL
 	out.FinalizeURL = in.FinalizeURL
+	out.AccountURI = in.AccountURI
+	out.CertificateURL = in.CertificateURL
 	out.Authorizations = *(*[]acme.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FinalizeAttempts = in.FinalizeAttempts
+	out.LastFinalizeAttemptTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastFinalizeAttemptTime))
+	out.Conditions = *(*[]pkgapismetav1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -1615,11 +2062,16 @@ func Convert_v1beta1_OrderStatus_To_acme_OrderStatus(in *OrderStatus, out *acme.
 func autoConvert_acme_OrderStatus_To_v1beta1_OrderStatus(in *acme.OrderStatus, out *OrderStatus, s conversion.Scope) error {
 	out.URL = in.URL
 	out.FinalizeURL = in.FinalizeURL
+	out.AccountURI = in.AccountURI
+	out.CertificateURL = in.CertificateURL
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = State(in.State)
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FinalizeAttempts = in.FinalizeAttempts
+	out.LastFinalizeAttemptTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastFinalizeAttemptTime))
+	out.Conditions = *(*[]pkgapismetav1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -62,6 +62,14 @@ func ValidateOrderStatusUpdate(old, new cmacme.OrderStatus, fldPath *field.Path)
 	if old.FinalizeURL != "" && old.FinalizeURL != new.FinalizeURL {
 		el = append(el, field.Forbidden(fldPath.Child("finalizeURL"), "field is immutable once set"))
 	}
+	// once the AccountURI has been set, it cannot be changed
+	if old.AccountURI != "" && old.AccountURI != new.AccountURI {
+		el = append(el, field.Forbidden(fldPath.Child("accountURI"), "field is immutable once set"))
+	}
+	// once the CertificateURL has been set, it cannot be changed
+	if old.CertificateURL != "" && old.CertificateURL != new.CertificateURL {
+		el = append(el, field.Forbidden(fldPath.Child("certificateURL"), "field is immutable once set"))
+	}
 	// once the Certificate has been issued, it cannot be changed
 	if len(old.Certificate) > 0 && !bytes.Equal(old.Certificate, new.Certificate) {
 		el = append(el, field.Forbidden(fldPath.Child("certificate"), "field is immutable once set"))
@@ -114,6 +114,12 @@ func TestValidateOrderUpdate(t *testing.T) {
 	testImmutableOrderField(t, field.NewPath("status", "finalizeURL"), func(o *cmacme.Order, s testValue) {
 		o.Status.FinalizeURL = string(s)
 	})
+	testImmutableOrderField(t, field.NewPath("status", "accountURI"), func(o *cmacme.Order, s testValue) {
+		o.Status.AccountURI = string(s)
+	})
+	testImmutableOrderField(t, field.NewPath("status", "certificateURL"), func(o *cmacme.Order, s testValue) {
+		o.Status.CertificateURL = string(s)
+	})
 	testImmutableOrderField(t, field.NewPath("status", "certificate"), func(o *cmacme.Order, s testValue) {
 		if s == testValueNone {
 			o.Status.Certificate = nil
@@ -136,15 +136,25 @@ func (in *ACMEChallengeSolverDNS01) DeepCopyInto(out *ACMEChallengeSolverDNS01)
 		*out = new(ACMEIssuerDNS01ProviderDigitalOcean)
 		**out = **in
 	}
+	if in.Hetzner != nil {
+		in, out := &in.Hetzner, &out.Hetzner
+		*out = new(ACMEIssuerDNS01ProviderHetzner)
+		**out = **in
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(ACMEIssuerDNS01ProviderAcmeDNS)
 		**out = **in
 	}
+	if in.ExternalDNS != nil {
+		in, out := &in.ExternalDNS, &out.ExternalDNS
+		*out = new(ACMEIssuerDNS01ProviderExternalDNS)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RFC2136 != nil {
 		in, out := &in.RFC2136, &out.RFC2136
 		*out = new(ACMEIssuerDNS01ProviderRFC2136)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Webhook != nil {
 		in, out := &in.Webhook, &out.Webhook
@@ -190,6 +200,32 @@ func (in *ACMEChallengeSolverHTTP01) DeepCopy() *ACMEChallengeSolverHTTP01 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01BurstProfile) DeepCopyInto(out *ACMEChallengeSolverHTTP01BurstProfile) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ChallengesPerReplica != nil {
+		in, out := &in.ChallengesPerReplica, &out.ChallengesPerReplica
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01BurstProfile.
+func (in *ACMEChallengeSolverHTTP01BurstProfile) DeepCopy() *ACMEChallengeSolverHTTP01BurstProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01BurstProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEChallengeSolverHTTP01GatewayHTTPRoute) DeepCopyInto(out *ACMEChallengeSolverHTTP01GatewayHTTPRoute) {
 	*out = *in
@@ -238,6 +274,11 @@ func (in *ACMEChallengeSolverHTTP01Ingress) DeepCopyInto(out *ACMEChallengeSolve
 		*out = new(ACMEChallengeSolverHTTP01IngressTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BurstProfile != nil {
+		in, out := &in.BurstProfile, &out.BurstProfile
+		*out = new(ACMEChallengeSolverHTTP01BurstProfile)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -333,6 +374,11 @@ func (in *ACMEChallengeSolverHTTP01IngressPodSpec) DeepCopyInto(out *ACMEChallen
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -476,6 +522,11 @@ func (in *ACMEIssuerDNS01ProviderAzureDNS) DeepCopyInto(out *ACMEIssuerDNS01Prov
 		*out = new(AzureManagedIdentity)
 		**out = **in
 	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ACMEIssuerDNS01ProviderAzureDNSZone, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -489,6 +540,23 @@ func (in *ACMEIssuerDNS01ProviderAzureDNS) DeepCopy() *ACMEIssuerDNS01ProviderAz
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderAzureDNSZone) DeepCopyInto(out *ACMEIssuerDNS01ProviderAzureDNSZone) {
+	*out = *in
+	out.ClientSecret = in.ClientSecret
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderAzureDNSZone.
+func (in *ACMEIssuerDNS01ProviderAzureDNSZone) DeepCopy() *ACMEIssuerDNS01ProviderAzureDNSZone {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderAzureDNSZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerDNS01ProviderCloudDNS) DeepCopyInto(out *ACMEIssuerDNS01ProviderCloudDNS) {
 	*out = *in
@@ -497,6 +565,11 @@ func (in *ACMEIssuerDNS01ProviderCloudDNS) DeepCopyInto(out *ACMEIssuerDNS01Prov
 		*out = new(meta.SecretKeySelector)
 		**out = **in
 	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ACMEIssuerDNS01ProviderCloudDNSZone, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -510,6 +583,23 @@ func (in *ACMEIssuerDNS01ProviderCloudDNS) DeepCopy() *ACMEIssuerDNS01ProviderCl
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderCloudDNSZone) DeepCopyInto(out *ACMEIssuerDNS01ProviderCloudDNSZone) {
+	*out = *in
+	out.ServiceAccount = in.ServiceAccount
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderCloudDNSZone.
+func (in *ACMEIssuerDNS01ProviderCloudDNSZone) DeepCopy() *ACMEIssuerDNS01ProviderCloudDNSZone {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderCloudDNSZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerDNS01ProviderCloudflare) DeepCopyInto(out *ACMEIssuerDNS01ProviderCloudflare) {
 	*out = *in
@@ -553,10 +643,60 @@ func (in *ACMEIssuerDNS01ProviderDigitalOcean) DeepCopy() *ACMEIssuerDNS01Provid
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderExternalDNS) DeepCopyInto(out *ACMEIssuerDNS01ProviderExternalDNS) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RecordTTL != nil {
+		in, out := &in.RecordTTL, &out.RecordTTL
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderExternalDNS.
+func (in *ACMEIssuerDNS01ProviderExternalDNS) DeepCopy() *ACMEIssuerDNS01ProviderExternalDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderExternalDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderHetzner) DeepCopyInto(out *ACMEIssuerDNS01ProviderHetzner) {
+	*out = *in
+	out.APIToken = in.APIToken
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderHetzner.
+func (in *ACMEIssuerDNS01ProviderHetzner) DeepCopy() *ACMEIssuerDNS01ProviderHetzner {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderHetzner)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerDNS01ProviderRFC2136) DeepCopyInto(out *ACMEIssuerDNS01ProviderRFC2136) {
 	*out = *in
 	out.TSIGSecret = in.TSIGSecret
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(ACMEIssuerDNS01ProviderRFC2136Kerberos)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -570,6 +710,32 @@ func (in *ACMEIssuerDNS01ProviderRFC2136) DeepCopy() *ACMEIssuerDNS01ProviderRFC
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderRFC2136Kerberos) DeepCopyInto(out *ACMEIssuerDNS01ProviderRFC2136Kerberos) {
+	*out = *in
+	if in.KeytabSecretRef != nil {
+		in, out := &in.KeytabSecretRef, &out.KeytabSecretRef
+		*out = new(meta.SecretKeySelector)
+		**out = **in
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(meta.SecretKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderRFC2136Kerberos.
+func (in *ACMEIssuerDNS01ProviderRFC2136Kerberos) DeepCopy() *ACMEIssuerDNS01ProviderRFC2136Kerberos {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderRFC2136Kerberos)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerDNS01ProviderRoute53) DeepCopyInto(out *ACMEIssuerDNS01ProviderRoute53) {
 	*out = *in
@@ -579,6 +745,13 @@ func (in *ACMEIssuerDNS01ProviderRoute53) DeepCopyInto(out *ACMEIssuerDNS01Provi
 		**out = **in
 	}
 	out.SecretAccessKey = in.SecretAccessKey
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ACMEIssuerDNS01ProviderRoute53Zone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -592,6 +765,32 @@ func (in *ACMEIssuerDNS01ProviderRoute53) DeepCopy() *ACMEIssuerDNS01ProviderRou
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderRoute53Zone) DeepCopyInto(out *ACMEIssuerDNS01ProviderRoute53Zone) {
+	*out = *in
+	if in.AccessKeyID != nil {
+		in, out := &in.AccessKeyID, &out.AccessKeyID
+		*out = new(meta.SecretKeySelector)
+		**out = **in
+	}
+	if in.SecretAccessKey != nil {
+		in, out := &in.SecretAccessKey, &out.SecretAccessKey
+		*out = new(meta.SecretKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderRoute53Zone.
+func (in *ACMEIssuerDNS01ProviderRoute53Zone) DeepCopy() *ACMEIssuerDNS01ProviderRoute53Zone {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderRoute53Zone)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerDNS01ProviderWebhook) DeepCopyInto(out *ACMEIssuerDNS01ProviderWebhook) {
 	*out = *in
@@ -890,6 +1089,17 @@ func (in *OrderStatus) DeepCopyInto(out *OrderStatus) {
 		in, out := &in.FailureTime, &out.FailureTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastFinalizeAttemptTime != nil {
+		in, out := &in.LastFinalizeAttemptTime, &out.LastFinalizeAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -47,6 +47,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterIssuerList{},
 		&CertificateRequest{},
 		&CertificateRequestList{},
+		&CertificateDefaults{},
+		&CertificateDefaultsList{},
+		&CertificateProfile{},
+		&CertificateProfileList{},
+		&IssuerAlias{},
+		&IssuerAliasList{},
+		&CertificateRevocationRequest{},
+		&CertificateRevocationRequestList{},
 	)
 	return nil
 }
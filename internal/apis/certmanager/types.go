@@ -89,10 +89,12 @@ const (
 
 // Common/known resource kinds.
 const (
-	ClusterIssuerKind      = "ClusterIssuer"
-	IssuerKind             = "Issuer"
-	CertificateKind        = "Certificate"
-	CertificateRequestKind = "CertificateRequest"
+	ClusterIssuerKind                = "ClusterIssuer"
+	IssuerKind                       = "Issuer"
+	IssuerAliasKind                  = "IssuerAlias"
+	CertificateKind                  = "Certificate"
+	CertificateRequestKind           = "CertificateRequest"
+	CertificateRevocationRequestKind = "CertificateRevocationRequest"
 )
 
 const (
@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CertificateDefaultsName is the name of the CertificateDefaults resource
+// which is read by the webhook. CertificateDefaults with other names are
+// ignored.
+const CertificateDefaultsName = "default"
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateDefaults configures cluster-wide default values for Certificate
+// resources. When a Certificate is created, the webhook sets any of these
+// fields which the Certificate does not specify to the value configured in the
+// CertificateDefaults named `default`. Certificates which already exist are
+// not modified.
+type CertificateDefaults struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Desired state of the CertificateDefaults resource.
+	Spec CertificateDefaultsSpec
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateDefaultsList is a list of CertificateDefaults
+type CertificateDefaultsList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []CertificateDefaults
+}
+
+// CertificateDefaultsSpec defines the default values of Certificate fields.
+type CertificateDefaultsSpec struct {
+	// PrivateKey contains the defaults of the options controlling private keys
+	// of Certificates. Each option is only set on Certificates which do not
+	// specify it. If `size` is set, it is only used as a default for
+	// Certificates which also use the default `algorithm`.
+	PrivateKey *CertificatePrivateKey
+
+	// RevisionHistoryLimit is the default maximum number of CertificateRequest
+	// revisions that are maintained in a Certificate's history.
+	// If set, revisionHistoryLimit must be a value of `1` or greater.
+	RevisionHistoryLimit *int32
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateProfile is a reusable template of Certificate fields. A
+// Certificate references a CertificateProfile with its `profileRef` field.
+// When the Certificate is created, the webhook sets any of the profile's
+// fields which the Certificate does not specify. Changes to a
+// CertificateProfile do not modify Certificates which already exist.
+type CertificateProfile struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Desired state of the CertificateProfile resource.
+	Spec CertificateProfileSpec
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateProfileList is a list of CertificateProfiles
+type CertificateProfileList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []CertificateProfile
+}
+
+// CertificateProfileSpec defines the values of Certificate fields which are
+// set on Certificates referencing the profile.
+type CertificateProfileSpec struct {
+	// Options to control private keys used for Certificates referencing the
+	// profile. Each option is only set on Certificates which do not specify
+	// it. If `size` is set, it is only used for Certificates which also use
+	// the profile's `algorithm`.
+	PrivateKey *CertificatePrivateKey
+
+	// The requested 'duration' (i.e. lifetime) of Certificates referencing the
+	// profile. Minimum accepted duration is 1 hour.
+	Duration *metav1.Duration
+
+	// Usages is the set of x509 usages requested for Certificates referencing
+	// the profile which do not specify any usages.
+	Usages []KeyUsage
+
+	// Subject contains the X509 subject fields set on Certificates referencing
+	// the profile. Each field is only set on Certificates which do not
+	// specify it, and is not set on Certificates using `literalSubject`.
+	Subject *X509Subject
+
+	// Keystores configures the additional keystore output formats of
+	// Certificates referencing the profile. Each keystore is only set on
+	// Certificates which do not configure it. The password Secrets are read
+	// from the namespace of each Certificate.
+	Keystores *CertificateKeystores
+}
@@ -86,6 +86,15 @@ type CertificateRequestSpec struct {
 	// CA for signing.
 	Request []byte
 
+	// RequestSecretRef is a reference to a key of a Secret in the same
+	// namespace holding the PEM-encoded x509 certificate signing request, as
+	// an alternative to Request.
+	RequestSecretRef *cmmeta.SecretKeySelector
+
+	// RequestSHA256 is the hex-encoded SHA-256 digest of the request held in
+	// the Secret referenced by RequestSecretRef.
+	RequestSHA256 string
+
 	// IsCA will request to mark the certificate as valid for certificate signing
 	// when submitting to the issuer.
 	// This will automatically add the `cert sign` usage to the list of `usages`.
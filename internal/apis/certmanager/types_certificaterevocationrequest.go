@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A CertificateRevocationRequest marks a certificate as revoked. Certificates
+// issued by a CA or SelfSigned issuer are reported as revoked by the OCSP
+// responder of the cert-manager controller, if it is enabled, and deleting
+// the CertificateRevocationRequest withdraws the revocation. Certificates
+// issued by an ACME or Vault issuer are revoked by the CA which signed them.
+// If a Certificate is referenced, it is re-issued with a new private key once
+// its certificate has been revoked.
+type CertificateRevocationRequest struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Desired state of the CertificateRevocationRequest resource.
+	Spec CertificateRevocationRequestSpec
+
+	// Status of the CertificateRevocationRequest. This is set and managed
+	// automatically.
+	Status CertificateRevocationRequestStatus
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateRevocationRequestList is a list of CertificateRevocationRequests
+type CertificateRevocationRequestList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []CertificateRevocationRequest
+}
+
+// CertificateRevocationRequestSpec identifies the certificate to revoke.
+type CertificateRevocationRequestSpec struct {
+	// CertificateRef is a reference to a Certificate in the namespace of the
+	// CertificateRevocationRequest whose current certificate is revoked.
+	// The issuerRef and serialNumber are filled in from the Certificate and
+	// its Secret if they are not set, and the Certificate is re-issued with a
+	// new private key once its certificate has been revoked, or straight away
+	// if its issuer does not support revocation.
+	CertificateRef *cmmeta.LocalObjectReference
+
+	// IssuerRef is a reference to the CA, SelfSigned, ACME or Vault Issuer or
+	// ClusterIssuer which issued the certificate. If the `kind` field is not
+	// set, or set to `Issuer`, an Issuer with the given name in the namespace
+	// of the CertificateRevocationRequest is used. A ClusterIssuer can only be
+	// referenced from the cluster resource namespace. Required unless
+	// certificateRef is set.
+	IssuerRef cmmeta.ObjectReference
+
+	// SerialNumber of the certificate to revoke, as a hexadecimal string.
+	// Bytes may be separated by colons, as printed by `openssl x509 -serial`
+	// or `kubectl cert-manager inspect`. Required unless certificateRef is
+	// set.
+	SerialNumber string
+
+	// Reason the certificate is revoked, one of `Unspecified`,
+	// `KeyCompromise`, `CACompromise`, `AffiliationChanged`, `Superseded`,
+	// `CessationOfOperation` or `PrivilegeWithdrawn`. Defaults to
+	// `Unspecified`.
+	Reason RevocationReason
+}
+
+// RevocationReason is the reason a certificate is revoked, as defined in
+// RFC 5280 section 5.3.1.
+type RevocationReason string
+
+const (
+	RevocationReasonUnspecified          RevocationReason = "Unspecified"
+	RevocationReasonKeyCompromise        RevocationReason = "KeyCompromise"
+	RevocationReasonCACompromise         RevocationReason = "CACompromise"
+	RevocationReasonAffiliationChanged   RevocationReason = "AffiliationChanged"
+	RevocationReasonSuperseded           RevocationReason = "Superseded"
+	RevocationReasonCessationOfOperation RevocationReason = "CessationOfOperation"
+	RevocationReasonPrivilegeWithdrawn   RevocationReason = "PrivilegeWithdrawn"
+)
+
+// CertificateRevocationRequestStatus defines the observed state of a
+// CertificateRevocationRequest.
+type CertificateRevocationRequestStatus struct {
+	// List of status conditions to indicate the status of a
+	// CertificateRevocationRequest. The known condition type is `Revoked`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []CertificateRevocationRequestCondition
+
+	// RevocationTime is the time at which the certificate was first marked as
+	// revoked. It is reported by the OCSP responder as the revocation time.
+	RevocationTime *metav1.Time
+
+	// ReissuanceTime is the time at which the re-issuance of the Certificate
+	// referenced by certificateRef was triggered.
+	ReissuanceTime *metav1.Time
+}
+
+// CertificateRevocationRequestCondition contains condition information for a
+// CertificateRevocationRequest.
+type CertificateRevocationRequestCondition struct {
+	// Type of the condition, known values are (`Revoked`).
+	Type CertificateRevocationRequestConditionType
+
+	// Status of the condition, one of (`True`, `False`, `Unknown`).
+	Status cmmeta.ConditionStatus
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	LastTransitionTime *metav1.Time
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	Reason string
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	Message string
+}
+
+// CertificateRevocationRequestConditionType represents a
+// CertificateRevocationRequest condition value.
+type CertificateRevocationRequestConditionType string
+
+const (
+	// CertificateRevocationRequestConditionRevoked indicates that the
+	// certificate is reported as revoked by the OCSP responder. It is False
+	// if the referenced issuer does not exist or does not support
+	// revocation.
+	CertificateRevocationRequestConditionRevoked CertificateRevocationRequestConditionType = "Revoked"
+)
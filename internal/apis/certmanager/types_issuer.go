@@ -82,6 +82,85 @@ type IssuerList struct {
 // configuration required for the issuer.
 type IssuerSpec struct {
 	IssuerConfig
+
+	// AllowedURISchemes restricts the schemes of the URI subjectAltNames that
+	// this issuer will sign.
+	// If not set, URI SANs of any scheme are allowed.
+	AllowedURISchemes []string
+
+	// AllowedUsages restricts the key usages and extended key usages that
+	// this issuer will sign.
+	// If not set, all usages supported by the issuer type are allowed.
+	AllowedUsages []AllowedKeyUsage
+
+	// RenewalWindow restricts renewals of certificates issued by this issuer
+	// to the given days and hours.
+	RenewalWindow *RenewalWindow
+
+	// HTTPClient configures the HTTP requests that cert-manager makes to the
+	// ACME, Vault or Venafi server of this issuer, for example to identify
+	// the requests to a gateway in front of the server.
+	HTTPClient *IssuerHTTPClient
+}
+
+// IssuerHTTPClient configures the HTTP requests made to the server of an
+// issuer.
+type IssuerHTTPClient struct {
+	// UserAgentSuffix is appended to the User-Agent header of every request,
+	// separated by a space.
+	UserAgentSuffix string
+
+	// Headers are added to every request, for example to pass a trace ID or
+	// the name of a route to a gateway. Headers which cert-manager uses to
+	// authenticate or describe requests, such as `Authorization`,
+	// `Content-Type` or `User-Agent`, cannot be set.
+	Headers []HTTPHeader
+}
+
+// HTTPHeader is the name and value of an HTTP header.
+type HTTPHeader struct {
+	// Name of the header.
+	Name string
+
+	// Value of the header.
+	Value string
+}
+
+// RenewalWindow restricts the times at which renewals of certificates may be
+// started, for example to the approved change windows of the services using
+// them. Each of days and hours is matched against the local time in TimeZone.
+type RenewalWindow struct {
+	// Days are the days of the week on which renewals may be started, as
+	// either single days such as `Sat` or ranges such as `Mon-Fri`.
+	// If not set, renewals may be started on any day.
+	Days []string
+
+	// Hours is the range of hours of the day during which renewals may be
+	// started, such as `9-17`. Ranges wrap around midnight.
+	// If not set, renewals may be started at any hour.
+	Hours string
+
+	// TimeZone is the IANA time zone in which days and hours are
+	// interpreted. Defaults to `UTC`.
+	TimeZone string
+
+	// ExpiryOverride is the remaining lifetime of a certificate below which
+	// it is renewed regardless of the window.
+	// If not set, the window is ignored once half of the time between the
+	// certificate's renewal time and its expiry has passed.
+	ExpiryOverride *metav1.Duration
+}
+
+// AllowedKeyUsage is a key usage or extended key usage which may be requested
+// from an issuer.
+type AllowedKeyUsage struct {
+	// Usage is the key usage or extended key usage which is allowed.
+	Usage KeyUsage
+
+	// Namespaces restricts the usage to Certificates and CertificateRequests
+	// in the listed namespaces.
+	// If not set, the usage is allowed in all namespaces.
+	Namespaces []string
 }
 
 // IssuerConfig is a generic wrapper around custom issuer types
@@ -106,6 +185,68 @@ type IssuerConfig struct {
 	// Venafi configures this issuer to sign certificates using a Venafi TPP
 	// or Venafi Cloud policy zone.
 	Venafi *VenafiIssuer
+
+	// Hub configures this issuer to forward CertificateRequests to an issuer
+	// in another "hub" cluster, so that signing credentials only exist in
+	// that cluster. Requires the certificaterequests-issuer-hub controller,
+	// which is not enabled by default, to be enabled with
+	// `--controllers=*,certificaterequests-issuer-hub`.
+	Hub *HubIssuer
+
+	// ExternalSigner configures this issuer to delegate signing to an external
+	// signing service, for example one backed by an HSM, over gRPC with
+	// mutual TLS. Requires the certificaterequests-issuer-externalsigner
+	// controller, which is not enabled by default, to be enabled with
+	// `--controllers=*,certificaterequests-issuer-externalsigner`.
+	ExternalSigner *ExternalSignerIssuer
+}
+
+// HubIssuer configures an issuer to forward CertificateRequests to an issuer
+// in a hub cluster. A CertificateRequest for the hub issuer is created in the
+// hub cluster for each forwarded request, and the signed certificate is
+// copied back once it has been issued.
+type HubIssuer struct {
+	// Server is the URL of the hub cluster's Kubernetes API server, for
+	// example: "https://hub.example.com:6443".
+	Server string
+
+	// CABundle is a PEM encoded CA bundle used to validate the certificate of
+	// the hub cluster's API server. If not set, the system roots are used.
+	CABundle []byte
+
+	// TokenSecretRef references a key of a Secret containing the bearer
+	// token, for example of a ServiceAccount, used to authenticate to the hub
+	// cluster. It must be allowed to create, get and delete
+	// CertificateRequests in Namespace.
+	TokenSecretRef cmmeta.SecretKeySelector
+
+	// Namespace is the namespace of the hub cluster in which forwarded
+	// CertificateRequests are created.
+	Namespace string
+
+	// IssuerRef references the issuer of the hub cluster which signs
+	// forwarded CertificateRequests. Namespaced issuers must be in Namespace.
+	IssuerRef cmmeta.ObjectReference
+}
+
+// ExternalSignerIssuer configures an issuer to delegate the signing of
+// CertificateRequests to an external signing service. The service implements
+// the gRPC Signer service of cert-manager's issuer plugins, and is called over
+// mutual TLS, so that the signing key never leaves the service.
+type ExternalSignerIssuer struct {
+	// Address is the host and port of the external signer's gRPC endpoint,
+	// for example: "signer.hsm.svc:8443".
+	Address string
+
+	// ServerName is the name the certificate of the external signer is
+	// verified against. Defaults to the host of Address.
+	ServerName string
+
+	// TLSSecretRef references a Secret containing the client certificate and
+	// private key, in the 'tls.crt' and 'tls.key' keys, presented to the
+	// external signer, and the PEM encoded CA bundle, in the 'ca.crt' key,
+	// used to verify its certificate.
+	TLSSecretRef cmmeta.LocalObjectReference
 }
 
 // VenafiIssuer configures an issuer to sign certificates using a Venafi TPP
@@ -124,8 +265,44 @@ type VenafiIssuer struct {
 	// Cloud specifies the Venafi cloud configuration settings.
 	// Only one of TPP or Cloud may be specified.
 	Cloud *VenafiCloud
+
+	// CustomFields are sent to the Venafi platform with every certificate
+	// request made by this issuer, for zones whose policy requires them.
+	// Custom fields set on a Certificate with the
+	// `venafi.cert-manager.io/custom-fields` annotation are sent as well, and
+	// replace the issuer's custom field of the same name.
+	CustomFields []VenafiCustomField
+
+	// ZoneCacheDuration is how long the configuration and policy of the zone
+	// are cached for once retrieved from the Venafi platform. Caching them
+	// reduces the load on the Venafi API when many certificates are issued or
+	// renewed at once, at the cost of picking up changes to the zone later.
+	// If not set, the zone is retrieved for every certificate request.
+	ZoneCacheDuration *metav1.Duration
 }
 
+// VenafiCustomField is a custom field which is sent to the Venafi platform
+// with a certificate request.
+type VenafiCustomField struct {
+	// Name of the custom field.
+	Name string
+
+	// Value of the custom field.
+	Value string
+
+	// Type of the custom field. Only `Plain` is supported, which is also the
+	// default.
+	Type VenafiCustomFieldType
+}
+
+// VenafiCustomFieldType is the type of a Venafi custom field.
+type VenafiCustomFieldType string
+
+const (
+	// VenafiCustomFieldTypePlain is a custom field with a plain text value.
+	VenafiCustomFieldTypePlain VenafiCustomFieldType = "Plain"
+)
+
 // VenafiTPP defines connection configuration details for a Venafi TPP instance
 type VenafiTPP struct {
 	// URL is the base URL for the vedsdk endpoint of the Venafi TPP instance,
@@ -210,8 +387,9 @@ type VaultAuth struct {
 	// with the role and secret stored in a Kubernetes Secret resource.
 	AppRole *VaultAppRole
 
-	// Kubernetes authenticates with Vault by passing the ServiceAccount
-	// token stored in the named Secret resource to the Vault server.
+	// Kubernetes authenticates with Vault by passing a ServiceAccount token,
+	// either stored in the named Secret resource or requested for the named
+	// ServiceAccount, to the Vault server.
 	Kubernetes *VaultKubernetesAuth
 }
 
@@ -242,16 +420,31 @@ type VaultKubernetesAuth struct {
 	// default value "/v1/auth/kubernetes" will be used.
 	Path string
 
-	// The required Secret field containing a Kubernetes ServiceAccount JWT used
-	// for authenticating with Vault. Use of 'ambient credentials' is not
-	// supported.
+	// The Secret field containing a Kubernetes ServiceAccount JWT used for
+	// authenticating with Vault. Use of 'ambient credentials' is not
+	// supported. Exactly one of secretRef and serviceAccountRef must be set.
 	SecretRef cmmeta.SecretKeySelector
 
+	// A reference to a ServiceAccount for which cert-manager requests a
+	// short-lived, audience-bound token with the TokenRequest API each time
+	// it authenticates with Vault.
+	ServiceAccountRef *ServiceAccountRef
+
 	// A required field containing the Vault Role to assume. A Role binds a
 	// Kubernetes ServiceAccount with a set of Vault policies.
 	Role string
 }
 
+// ServiceAccountRef is a ServiceAccount for which cert-manager requests
+// tokens to authenticate with Vault.
+type ServiceAccountRef struct {
+	// Name of the ServiceAccount used to request a token.
+	Name string
+
+	// TokenAudiences are additional audiences of the requested token.
+	TokenAudiences []string
+}
+
 // CAIssuer configures an issuer that can issue certificates from its provided
 // CA certificate. It contains the name of the private key to sign certificates,
 // holds the location for Certificate Revocation Lists (CRL) distribution
@@ -273,6 +466,78 @@ type CAIssuer struct {
 	// certificate will be issued with no OCSP servers set. For example, an
 	// OCSP server URL could be "http://ocsp.int-x3.letsencrypt.org".
 	OCSPServers []string
+
+	// CRL configures the certificate revocation list which cert-manager
+	// signs for this issuer, listing the certificates revoked by
+	// CertificateRevocationRequests. If not set, no CRL is published.
+	// The CA certificate must have the "crl sign" key usage, or no key
+	// usages at all.
+	CRL *CACRL
+
+	// PKCS11 configures this issuer to sign with a private key stored in a
+	// PKCS#11 token, such as a hardware security module, instead of the
+	// private key in the Secret, so that the CA's private key never leaves
+	// the token. The Secret then only needs to hold the CA certificate.
+	// The PKCS#11 module must be available in the cert-manager controller's
+	// container, and the controller must be built with cgo. The OCSP
+	// responder does not answer for certificates signed by such issuers.
+	PKCS11 *CAPKCS11
+
+	// SPIFFE configures this issuer to issue SPIFFE X.509-SVIDs, so that
+	// workloads which cannot run a SPIFFE agent can obtain an SVID from
+	// cert-manager. Requests must have exactly one URI SAN, the SPIFFE ID
+	// `spiffe://<trust domain>/ns/<namespace>/sa/<service account>` of a
+	// service account in the namespace of the CertificateRequest, and must
+	// not be for a CA certificate. DNS names, IP addresses and email
+	// addresses are removed from the issued SVIDs. The CA certificates of
+	// all issuers of a trust domain are published as its trust bundle in the
+	// ConfigMap `spiffe-bundle-<trust domain>`, in the namespace of the
+	// Issuer, or in the cluster resource namespace for a ClusterIssuer.
+	SPIFFE *CASPIFFE
+}
+
+// CASPIFFE configures a CA issuer to issue SPIFFE X.509-SVIDs.
+type CASPIFFE struct {
+	// TrustDomain is the SPIFFE trust domain of the issued SVIDs, such as
+	// `cluster.local`. It must be a lowercase DNS subdomain.
+	TrustDomain string
+}
+
+// CACRL configures the certificate revocation list of a CA issuer.
+type CACRL struct {
+	// ConfigMapName is the name of the ConfigMap the DER encoded CRL is
+	// written to, under the key "ca.crl". The ConfigMap is in the namespace
+	// of the Issuer, or in the cluster resource namespace for a
+	// ClusterIssuer. It can be mounted by any HTTP server to serve the CRL
+	// at the issuer's CRL distribution points.
+	ConfigMapName string
+
+	// Validity is how long each CRL is valid for. A new CRL is signed
+	// whenever a certificate is revoked, and once two thirds of the
+	// validity of the current CRL has elapsed. Defaults to 24 hours.
+	Validity *metav1.Duration
+}
+
+// CAPKCS11 identifies the private key of a CA issuer in a PKCS#11 token.
+type CAPKCS11 struct {
+	// Module is the path of the PKCS#11 module, the shared library provided
+	// by the vendor of the token, in the cert-manager controller's container.
+	Module string
+
+	// TokenLabel is the label of the token holding the private key.
+	TokenLabel string
+
+	// KeyLabel is the label (CKA_LABEL) of the private key.
+	// At least one of keyLabel and keyID must be set.
+	KeyLabel string
+
+	// KeyID is the hex encoded ID (CKA_ID) of the private key.
+	// At least one of keyLabel and keyID must be set.
+	KeyID string
+
+	// PINSecretRef is a reference to the key of a Secret holding the PIN
+	// used to log into the token as a normal user.
+	PINSecretRef cmmeta.SecretKeySelector
 }
 
 // IssuerStatus contains status information about an Issuer
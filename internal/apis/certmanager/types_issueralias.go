@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// An IssuerAlias is a name which resolves to an Issuer or ClusterIssuer.
+// Certificates and CertificateRequests use an alias by setting the `kind` of
+// their `issuerRef` to `IssuerAlias`. The alias is looked up in the namespace
+// of the referencing resource, and otherwise in the cluster resource
+// namespace, so that an alias in the cluster resource namespace acts as a
+// cluster-wide default which namespaces can override.
+// Changing the target of an alias does not re-issue existing certificates:
+// the new target is used the next time they are issued.
+type IssuerAlias struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	// Desired state of the IssuerAlias resource.
+	Spec IssuerAliasSpec
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IssuerAliasList is a list of IssuerAliases
+type IssuerAliasList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []IssuerAlias
+}
+
+// IssuerAliasSpec defines the issuer an IssuerAlias resolves to.
+type IssuerAliasSpec struct {
+	// IssuerRef is a reference to the Issuer or ClusterIssuer which the alias
+	// resolves to. If the `kind` field is not set, or set to `Issuer`, an
+	// Issuer with the given name in the namespace of the resource using the
+	// alias is used. An IssuerAlias cannot refer to another IssuerAlias.
+	IssuerRef cmmeta.ObjectReference
+}
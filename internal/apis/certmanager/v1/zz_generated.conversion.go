@@ -28,11 +28,11 @@ import (
 	acmev1 "github.com/cert-manager/cert-manager/internal/apis/acme/v1"
 	certmanager "github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	meta "github.com/cert-manager/cert-manager/internal/apis/meta"
-	internalapismetav1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
+	metav1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
 	apisacmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	apismetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgapismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -44,6 +44,36 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1.AWSSecretsManagerSecretStore)(nil), (*certmanager.AWSSecretsManagerSecretStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_AWSSecretsManagerSecretStore_To_certmanager_AWSSecretsManagerSecretStore(a.(*v1.AWSSecretsManagerSecretStore), b.(*certmanager.AWSSecretsManagerSecretStore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.AWSSecretsManagerSecretStore)(nil), (*v1.AWSSecretsManagerSecretStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_AWSSecretsManagerSecretStore_To_v1_AWSSecretsManagerSecretStore(a.(*certmanager.AWSSecretsManagerSecretStore), b.(*v1.AWSSecretsManagerSecretStore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.AllowedKeyUsage)(nil), (*certmanager.AllowedKeyUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_AllowedKeyUsage_To_certmanager_AllowedKeyUsage(a.(*v1.AllowedKeyUsage), b.(*certmanager.AllowedKeyUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.AllowedKeyUsage)(nil), (*v1.AllowedKeyUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_AllowedKeyUsage_To_v1_AllowedKeyUsage(a.(*certmanager.AllowedKeyUsage), b.(*v1.AllowedKeyUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CACRL)(nil), (*certmanager.CACRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CACRL_To_certmanager_CACRL(a.(*v1.CACRL), b.(*certmanager.CACRL), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CACRL)(nil), (*v1.CACRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CACRL_To_v1_CACRL(a.(*certmanager.CACRL), b.(*v1.CACRL), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CAIssuer)(nil), (*certmanager.CAIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CAIssuer_To_certmanager_CAIssuer(a.(*v1.CAIssuer), b.(*certmanager.CAIssuer), scope)
 	}); err != nil {
@@ -54,6 +84,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CAPKCS11)(nil), (*certmanager.CAPKCS11)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CAPKCS11_To_certmanager_CAPKCS11(a.(*v1.CAPKCS11), b.(*certmanager.CAPKCS11), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAPKCS11)(nil), (*v1.CAPKCS11)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAPKCS11_To_v1_CAPKCS11(a.(*certmanager.CAPKCS11), b.(*v1.CAPKCS11), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CASPIFFE)(nil), (*certmanager.CASPIFFE)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CASPIFFE_To_certmanager_CASPIFFE(a.(*v1.CASPIFFE), b.(*certmanager.CASPIFFE), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CASPIFFE)(nil), (*v1.CASPIFFE)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CASPIFFE_To_v1_CASPIFFE(a.(*certmanager.CASPIFFE), b.(*v1.CASPIFFE), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Certificate_To_certmanager_Certificate(a.(*v1.Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -74,6 +124,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateAdditionalPrivateKey)(nil), (*certmanager.CertificateAdditionalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(a.(*v1.CertificateAdditionalPrivateKey), b.(*certmanager.CertificateAdditionalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAdditionalPrivateKey)(nil), (*v1.CertificateAdditionalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAdditionalPrivateKey_To_v1_CertificateAdditionalPrivateKey(a.(*certmanager.CertificateAdditionalPrivateKey), b.(*v1.CertificateAdditionalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateCondition)(nil), (*certmanager.CertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateCondition_To_certmanager_CertificateCondition(a.(*v1.CertificateCondition), b.(*certmanager.CertificateCondition), scope)
 	}); err != nil {
@@ -84,6 +144,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateDefaults)(nil), (*certmanager.CertificateDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateDefaults_To_certmanager_CertificateDefaults(a.(*v1.CertificateDefaults), b.(*certmanager.CertificateDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateDefaults)(nil), (*v1.CertificateDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateDefaults_To_v1_CertificateDefaults(a.(*certmanager.CertificateDefaults), b.(*v1.CertificateDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateDefaultsList)(nil), (*certmanager.CertificateDefaultsList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateDefaultsList_To_certmanager_CertificateDefaultsList(a.(*v1.CertificateDefaultsList), b.(*certmanager.CertificateDefaultsList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateDefaultsList)(nil), (*v1.CertificateDefaultsList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateDefaultsList_To_v1_CertificateDefaultsList(a.(*certmanager.CertificateDefaultsList), b.(*v1.CertificateDefaultsList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateDefaultsSpec)(nil), (*certmanager.CertificateDefaultsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateDefaultsSpec_To_certmanager_CertificateDefaultsSpec(a.(*v1.CertificateDefaultsSpec), b.(*certmanager.CertificateDefaultsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateDefaultsSpec)(nil), (*v1.CertificateDefaultsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateDefaultsSpec_To_v1_CertificateDefaultsSpec(a.(*certmanager.CertificateDefaultsSpec), b.(*v1.CertificateDefaultsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateKeystores)(nil), (*certmanager.CertificateKeystores)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateKeystores_To_certmanager_CertificateKeystores(a.(*v1.CertificateKeystores), b.(*certmanager.CertificateKeystores), scope)
 	}); err != nil {
@@ -114,6 +204,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateProfile)(nil), (*certmanager.CertificateProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateProfile_To_certmanager_CertificateProfile(a.(*v1.CertificateProfile), b.(*certmanager.CertificateProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateProfile)(nil), (*v1.CertificateProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateProfile_To_v1_CertificateProfile(a.(*certmanager.CertificateProfile), b.(*v1.CertificateProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateProfileList)(nil), (*certmanager.CertificateProfileList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateProfileList_To_certmanager_CertificateProfileList(a.(*v1.CertificateProfileList), b.(*certmanager.CertificateProfileList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateProfileList)(nil), (*v1.CertificateProfileList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateProfileList_To_v1_CertificateProfileList(a.(*certmanager.CertificateProfileList), b.(*v1.CertificateProfileList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateProfileSpec)(nil), (*certmanager.CertificateProfileSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateProfileSpec_To_certmanager_CertificateProfileSpec(a.(*v1.CertificateProfileSpec), b.(*certmanager.CertificateProfileSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateProfileSpec)(nil), (*v1.CertificateProfileSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateProfileSpec_To_v1_CertificateProfileSpec(a.(*certmanager.CertificateProfileSpec), b.(*v1.CertificateProfileSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateRequest_To_certmanager_CertificateRequest(a.(*v1.CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -164,6 +284,66 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRevocationRequest)(nil), (*certmanager.CertificateRevocationRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRevocationRequest_To_certmanager_CertificateRevocationRequest(a.(*v1.CertificateRevocationRequest), b.(*certmanager.CertificateRevocationRequest), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRevocationRequest)(nil), (*v1.CertificateRevocationRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRevocationRequest_To_v1_CertificateRevocationRequest(a.(*certmanager.CertificateRevocationRequest), b.(*v1.CertificateRevocationRequest), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRevocationRequestCondition)(nil), (*certmanager.CertificateRevocationRequestCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRevocationRequestCondition_To_certmanager_CertificateRevocationRequestCondition(a.(*v1.CertificateRevocationRequestCondition), b.(*certmanager.CertificateRevocationRequestCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRevocationRequestCondition)(nil), (*v1.CertificateRevocationRequestCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRevocationRequestCondition_To_v1_CertificateRevocationRequestCondition(a.(*certmanager.CertificateRevocationRequestCondition), b.(*v1.CertificateRevocationRequestCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRevocationRequestList)(nil), (*certmanager.CertificateRevocationRequestList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRevocationRequestList_To_certmanager_CertificateRevocationRequestList(a.(*v1.CertificateRevocationRequestList), b.(*certmanager.CertificateRevocationRequestList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRevocationRequestList)(nil), (*v1.CertificateRevocationRequestList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRevocationRequestList_To_v1_CertificateRevocationRequestList(a.(*certmanager.CertificateRevocationRequestList), b.(*v1.CertificateRevocationRequestList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRevocationRequestSpec)(nil), (*certmanager.CertificateRevocationRequestSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRevocationRequestSpec_To_certmanager_CertificateRevocationRequestSpec(a.(*v1.CertificateRevocationRequestSpec), b.(*certmanager.CertificateRevocationRequestSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRevocationRequestSpec)(nil), (*v1.CertificateRevocationRequestSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRevocationRequestSpec_To_v1_CertificateRevocationRequestSpec(a.(*certmanager.CertificateRevocationRequestSpec), b.(*v1.CertificateRevocationRequestSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRevocationRequestStatus)(nil), (*certmanager.CertificateRevocationRequestStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRevocationRequestStatus_To_certmanager_CertificateRevocationRequestStatus(a.(*v1.CertificateRevocationRequestStatus), b.(*certmanager.CertificateRevocationRequestStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRevocationRequestStatus)(nil), (*v1.CertificateRevocationRequestStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRevocationRequestStatus_To_v1_CertificateRevocationRequestStatus(a.(*certmanager.CertificateRevocationRequestStatus), b.(*v1.CertificateRevocationRequestStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateSecretStore)(nil), (*certmanager.CertificateSecretStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateSecretStore_To_certmanager_CertificateSecretStore(a.(*v1.CertificateSecretStore), b.(*certmanager.CertificateSecretStore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateSecretStore)(nil), (*v1.CertificateSecretStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSecretStore_To_v1_CertificateSecretStore(a.(*certmanager.CertificateSecretStore), b.(*v1.CertificateSecretStore), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateSecretTemplate)(nil), (*certmanager.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(a.(*v1.CertificateSecretTemplate), b.(*certmanager.CertificateSecretTemplate), scope)
 	}); err != nil {
@@ -204,6 +384,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ExternalSignerIssuer)(nil), (*certmanager.ExternalSignerIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ExternalSignerIssuer_To_certmanager_ExternalSignerIssuer(a.(*v1.ExternalSignerIssuer), b.(*certmanager.ExternalSignerIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ExternalSignerIssuer)(nil), (*v1.ExternalSignerIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ExternalSignerIssuer_To_v1_ExternalSignerIssuer(a.(*certmanager.ExternalSignerIssuer), b.(*v1.ExternalSignerIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.HubIssuer)(nil), (*certmanager.HubIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_HubIssuer_To_certmanager_HubIssuer(a.(*v1.HubIssuer), b.(*certmanager.HubIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.HubIssuer)(nil), (*v1.HubIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_HubIssuer_To_v1_HubIssuer(a.(*certmanager.HubIssuer), b.(*v1.HubIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.HTTPHeader)(nil), (*certmanager.HTTPHeader)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_HTTPHeader_To_certmanager_HTTPHeader(a.(*v1.HTTPHeader), b.(*certmanager.HTTPHeader), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.HTTPHeader)(nil), (*v1.HTTPHeader)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_HTTPHeader_To_v1_HTTPHeader(a.(*certmanager.HTTPHeader), b.(*v1.HTTPHeader), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.Issuer)(nil), (*certmanager.Issuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Issuer_To_certmanager_Issuer(a.(*v1.Issuer), b.(*certmanager.Issuer), scope)
 	}); err != nil {
@@ -214,6 +424,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerAlias)(nil), (*certmanager.IssuerAlias)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerAlias_To_certmanager_IssuerAlias(a.(*v1.IssuerAlias), b.(*certmanager.IssuerAlias), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerAlias)(nil), (*v1.IssuerAlias)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerAlias_To_v1_IssuerAlias(a.(*certmanager.IssuerAlias), b.(*v1.IssuerAlias), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerAliasList)(nil), (*certmanager.IssuerAliasList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerAliasList_To_certmanager_IssuerAliasList(a.(*v1.IssuerAliasList), b.(*certmanager.IssuerAliasList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerAliasList)(nil), (*v1.IssuerAliasList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerAliasList_To_v1_IssuerAliasList(a.(*certmanager.IssuerAliasList), b.(*v1.IssuerAliasList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerAliasSpec)(nil), (*certmanager.IssuerAliasSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerAliasSpec_To_certmanager_IssuerAliasSpec(a.(*v1.IssuerAliasSpec), b.(*certmanager.IssuerAliasSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerAliasSpec)(nil), (*v1.IssuerAliasSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerAliasSpec_To_v1_IssuerAliasSpec(a.(*certmanager.IssuerAliasSpec), b.(*v1.IssuerAliasSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.IssuerCondition)(nil), (*certmanager.IssuerCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_IssuerCondition_To_certmanager_IssuerCondition(a.(*v1.IssuerCondition), b.(*certmanager.IssuerCondition), scope)
 	}); err != nil {
@@ -234,6 +474,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerHTTPClient)(nil), (*certmanager.IssuerHTTPClient)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(a.(*v1.IssuerHTTPClient), b.(*certmanager.IssuerHTTPClient), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerHTTPClient)(nil), (*v1.IssuerHTTPClient)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerHTTPClient_To_v1_IssuerHTTPClient(a.(*certmanager.IssuerHTTPClient), b.(*v1.IssuerHTTPClient), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.IssuerList)(nil), (*certmanager.IssuerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_IssuerList_To_certmanager_IssuerList(a.(*v1.IssuerList), b.(*certmanager.IssuerList), scope)
 	}); err != nil {
@@ -284,6 +534,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.PrivateKeyRotationSchedule)(nil), (*certmanager.PrivateKeyRotationSchedule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(a.(*v1.PrivateKeyRotationSchedule), b.(*certmanager.PrivateKeyRotationSchedule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.PrivateKeyRotationSchedule)(nil), (*v1.PrivateKeyRotationSchedule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_PrivateKeyRotationSchedule_To_v1_PrivateKeyRotationSchedule(a.(*certmanager.PrivateKeyRotationSchedule), b.(*v1.PrivateKeyRotationSchedule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.RenewalWindow)(nil), (*certmanager.RenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_RenewalWindow_To_certmanager_RenewalWindow(a.(*v1.RenewalWindow), b.(*certmanager.RenewalWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.RenewalWindow)(nil), (*v1.RenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_RenewalWindow_To_v1_RenewalWindow(a.(*certmanager.RenewalWindow), b.(*v1.RenewalWindow), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -294,6 +564,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ServiceAccountRef)(nil), (*certmanager.ServiceAccountRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ServiceAccountRef_To_certmanager_ServiceAccountRef(a.(*v1.ServiceAccountRef), b.(*certmanager.ServiceAccountRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ServiceAccountRef)(nil), (*v1.ServiceAccountRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ServiceAccountRef_To_v1_ServiceAccountRef(a.(*certmanager.ServiceAccountRef), b.(*v1.ServiceAccountRef), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VaultAppRole)(nil), (*certmanager.VaultAppRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VaultAppRole_To_certmanager_VaultAppRole(a.(*v1.VaultAppRole), b.(*certmanager.VaultAppRole), scope)
 	}); err != nil {
@@ -334,6 +614,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VaultSecretStore)(nil), (*certmanager.VaultSecretStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VaultSecretStore_To_certmanager_VaultSecretStore(a.(*v1.VaultSecretStore), b.(*certmanager.VaultSecretStore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultSecretStore)(nil), (*v1.VaultSecretStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultSecretStore_To_v1_VaultSecretStore(a.(*certmanager.VaultSecretStore), b.(*v1.VaultSecretStore), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VenafiCloud_To_certmanager_VenafiCloud(a.(*v1.VenafiCloud), b.(*certmanager.VenafiCloud), scope)
 	}); err != nil {
@@ -344,6 +634,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiCustomField)(nil), (*certmanager.VenafiCustomField)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiCustomField_To_certmanager_VenafiCustomField(a.(*v1.VenafiCustomField), b.(*certmanager.VenafiCustomField), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiCustomField)(nil), (*v1.VenafiCustomField)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiCustomField_To_v1_VenafiCustomField(a.(*certmanager.VenafiCustomField), b.(*v1.VenafiCustomField), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VenafiIssuer)(nil), (*certmanager.VenafiIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VenafiIssuer_To_certmanager_VenafiIssuer(a.(*v1.VenafiIssuer), b.(*certmanager.VenafiIssuer), scope)
 	}); err != nil {
@@ -387,10 +687,125 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1_AWSSecretsManagerSecretStore_To_certmanager_AWSSecretsManagerSecretStore(in *v1.AWSSecretsManagerSecretStore, out *certmanager.AWSSecretsManagerSecretStore, s conversion.Scope) error {
+	out.Region = in.Region
+	out.SecretName = in.SecretName
+	if in.AccessKeyIDSecretRef != nil {
+		in, out := &in.AccessKeyIDSecretRef, &out.AccessKeyIDSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AccessKeyIDSecretRef = nil
+	}
+	if in.SecretAccessKeySecretRef != nil {
+		in, out := &in.SecretAccessKeySecretRef, &out.SecretAccessKeySecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecretAccessKeySecretRef = nil
+	}
+	out.Role = in.Role
+	return nil
+}
+
+// Convert_v1_AWSSecretsManagerSecretStore_To_certmanager_AWSSecretsManagerSecretStore is an autogenerated conversion function.
+func Convert_v1_AWSSecretsManagerSecretStore_To_certmanager_AWSSecretsManagerSecretStore(in *v1.AWSSecretsManagerSecretStore, out *certmanager.AWSSecretsManagerSecretStore, s conversion.Scope) error {
+	return autoConvert_v1_AWSSecretsManagerSecretStore_To_certmanager_AWSSecretsManagerSecretStore(in, out, s)
+}
+
+func autoConvert_certmanager_AWSSecretsManagerSecretStore_To_v1_AWSSecretsManagerSecretStore(in *certmanager.AWSSecretsManagerSecretStore, out *v1.AWSSecretsManagerSecretStore, s conversion.Scope) error {
+	out.Region = in.Region
+	out.SecretName = in.SecretName
+	if in.AccessKeyIDSecretRef != nil {
+		in, out := &in.AccessKeyIDSecretRef, &out.AccessKeyIDSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AccessKeyIDSecretRef = nil
+	}
+	if in.SecretAccessKeySecretRef != nil {
+		in, out := &in.SecretAccessKeySecretRef, &out.SecretAccessKeySecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecretAccessKeySecretRef = nil
+	}
+	out.Role = in.Role
+	return nil
+}
+
+// Convert_certmanager_AWSSecretsManagerSecretStore_To_v1_AWSSecretsManagerSecretStore is an autogenerated conversion function.
+func Convert_certmanager_AWSSecretsManagerSecretStore_To_v1_AWSSecretsManagerSecretStore(in *certmanager.AWSSecretsManagerSecretStore, out *v1.AWSSecretsManagerSecretStore, s conversion.Scope) error {
+	return autoConvert_certmanager_AWSSecretsManagerSecretStore_To_v1_AWSSecretsManagerSecretStore(in, out, s)
+}
+
+func autoConvert_v1_AllowedKeyUsage_To_certmanager_AllowedKeyUsage(in *v1.AllowedKeyUsage, out *certmanager.AllowedKeyUsage, s conversion.Scope) error {
+	out.Usage = certmanager.KeyUsage(in.Usage)
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_v1_AllowedKeyUsage_To_certmanager_AllowedKeyUsage is an autogenerated conversion function.
+func Convert_v1_AllowedKeyUsage_To_certmanager_AllowedKeyUsage(in *v1.AllowedKeyUsage, out *certmanager.AllowedKeyUsage, s conversion.Scope) error {
+	return autoConvert_v1_AllowedKeyUsage_To_certmanager_AllowedKeyUsage(in, out, s)
+}
+
+func autoConvert_certmanager_AllowedKeyUsage_To_v1_AllowedKeyUsage(in *certmanager.AllowedKeyUsage, out *v1.AllowedKeyUsage, s conversion.Scope) error {
+	out.Usage = v1.KeyUsage(in.Usage)
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_certmanager_AllowedKeyUsage_To_v1_AllowedKeyUsage is an autogenerated conversion function.
+func Convert_certmanager_AllowedKeyUsage_To_v1_AllowedKeyUsage(in *certmanager.AllowedKeyUsage, out *v1.AllowedKeyUsage, s conversion.Scope) error {
+	return autoConvert_certmanager_AllowedKeyUsage_To_v1_AllowedKeyUsage(in, out, s)
+}
+
+func autoConvert_v1_CACRL_To_certmanager_CACRL(in *v1.CACRL, out *certmanager.CACRL, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	out.Validity = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Validity))
+	return nil
+}
+
+// Convert_v1_CACRL_To_certmanager_CACRL is an autogenerated conversion function.
+func Convert_v1_CACRL_To_certmanager_CACRL(in *v1.CACRL, out *certmanager.CACRL, s conversion.Scope) error {
+	return autoConvert_v1_CACRL_To_certmanager_CACRL(in, out, s)
+}
+
+func autoConvert_certmanager_CACRL_To_v1_CACRL(in *certmanager.CACRL, out *v1.CACRL, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	out.Validity = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Validity))
+	return nil
+}
+
+// Convert_certmanager_CACRL_To_v1_CACRL is an autogenerated conversion function.
+func Convert_certmanager_CACRL_To_v1_CACRL(in *certmanager.CACRL, out *v1.CACRL, s conversion.Scope) error {
+	return autoConvert_certmanager_CACRL_To_v1_CACRL(in, out, s)
+}
+
 func autoConvert_v1_CAIssuer_To_certmanager_CAIssuer(in *v1.CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.CRL = (*certmanager.CACRL)(unsafe.Pointer(in.CRL))
+	if in.PKCS11 != nil {
+		in, out := &in.PKCS11, &out.PKCS11
+		*out = new(certmanager.CAPKCS11)
+		if err := Convert_v1_CAPKCS11_To_certmanager_CAPKCS11(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS11 = nil
+	}
+	out.SPIFFE = (*certmanager.CASPIFFE)(unsafe.Pointer(in.SPIFFE))
 	return nil
 }
 
@@ -403,6 +818,17 @@ func autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, o
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.CRL = (*v1.CACRL)(unsafe.Pointer(in.CRL))
+	if in.PKCS11 != nil {
+		in, out := &in.PKCS11, &out.PKCS11
+		*out = new(v1.CAPKCS11)
+		if err := Convert_certmanager_CAPKCS11_To_v1_CAPKCS11(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS11 = nil
+	}
+	out.SPIFFE = (*v1.CASPIFFE)(unsafe.Pointer(in.SPIFFE))
 	return nil
 }
 
@@ -411,6 +837,58 @@ func Convert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, out *
 	return autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1_CAPKCS11_To_certmanager_CAPKCS11(in *v1.CAPKCS11, out *certmanager.CAPKCS11, s conversion.Scope) error {
+	out.Module = in.Module
+	out.TokenLabel = in.TokenLabel
+	out.KeyLabel = in.KeyLabel
+	out.KeyID = in.KeyID
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PINSecretRef, &out.PINSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CAPKCS11_To_certmanager_CAPKCS11 is an autogenerated conversion function.
+func Convert_v1_CAPKCS11_To_certmanager_CAPKCS11(in *v1.CAPKCS11, out *certmanager.CAPKCS11, s conversion.Scope) error {
+	return autoConvert_v1_CAPKCS11_To_certmanager_CAPKCS11(in, out, s)
+}
+
+func autoConvert_certmanager_CAPKCS11_To_v1_CAPKCS11(in *certmanager.CAPKCS11, out *v1.CAPKCS11, s conversion.Scope) error {
+	out.Module = in.Module
+	out.TokenLabel = in.TokenLabel
+	out.KeyLabel = in.KeyLabel
+	out.KeyID = in.KeyID
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PINSecretRef, &out.PINSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CAPKCS11_To_v1_CAPKCS11 is an autogenerated conversion function.
+func Convert_certmanager_CAPKCS11_To_v1_CAPKCS11(in *certmanager.CAPKCS11, out *v1.CAPKCS11, s conversion.Scope) error {
+	return autoConvert_certmanager_CAPKCS11_To_v1_CAPKCS11(in, out, s)
+}
+
+func autoConvert_v1_CASPIFFE_To_certmanager_CASPIFFE(in *v1.CASPIFFE, out *certmanager.CASPIFFE, s conversion.Scope) error {
+	out.TrustDomain = in.TrustDomain
+	return nil
+}
+
+// Convert_v1_CASPIFFE_To_certmanager_CASPIFFE is an autogenerated conversion function.
+func Convert_v1_CASPIFFE_To_certmanager_CASPIFFE(in *v1.CASPIFFE, out *certmanager.CASPIFFE, s conversion.Scope) error {
+	return autoConvert_v1_CASPIFFE_To_certmanager_CASPIFFE(in, out, s)
+}
+
+func autoConvert_certmanager_CASPIFFE_To_v1_CASPIFFE(in *certmanager.CASPIFFE, out *v1.CASPIFFE, s conversion.Scope) error {
+	out.TrustDomain = in.TrustDomain
+	return nil
+}
+
+// Convert_certmanager_CASPIFFE_To_v1_CASPIFFE is an autogenerated conversion function.
+func Convert_certmanager_CASPIFFE_To_v1_CASPIFFE(in *certmanager.CASPIFFE, out *v1.CASPIFFE, s conversion.Scope) error {
+	return autoConvert_certmanager_CASPIFFE_To_v1_CASPIFFE(in, out, s)
+}
+
 func autoConvert_v1_Certificate_To_certmanager_Certificate(in *v1.Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -463,10 +941,32 @@ func Convert_certmanager_CertificateAdditionalOutputFormat_To_v1_CertificateAddi
 	return autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1_CertificateAdditionalOutputFormat(in, out, s)
 }
 
+func autoConvert_v1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in *v1.CertificateAdditionalPrivateKey, out *certmanager.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_v1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey is an autogenerated conversion function.
+func Convert_v1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in *v1.CertificateAdditionalPrivateKey, out *certmanager.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	return autoConvert_v1_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAdditionalPrivateKey_To_v1_CertificateAdditionalPrivateKey(in *certmanager.CertificateAdditionalPrivateKey, out *v1.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	out.Algorithm = v1.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_certmanager_CertificateAdditionalPrivateKey_To_v1_CertificateAdditionalPrivateKey is an autogenerated conversion function.
+func Convert_certmanager_CertificateAdditionalPrivateKey_To_v1_CertificateAdditionalPrivateKey(in *certmanager.CertificateAdditionalPrivateKey, out *v1.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAdditionalPrivateKey_To_v1_CertificateAdditionalPrivateKey(in, out, s)
+}
+
 func autoConvert_v1_CertificateCondition_To_certmanager_CertificateCondition(in *v1.CertificateCondition, out *certmanager.CertificateCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -481,7 +981,7 @@ func Convert_v1_CertificateCondition_To_certmanager_CertificateCondition(in *v1.
 func autoConvert_certmanager_CertificateCondition_To_v1_CertificateCondition(in *certmanager.CertificateCondition, out *v1.CertificateCondition, s conversion.Scope) error {
 	out.Type = v1.CertificateConditionType(in.Type)
 	out.Status = apismetav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -493,6 +993,76 @@ func Convert_certmanager_CertificateCondition_To_v1_CertificateCondition(in *cer
 	return autoConvert_certmanager_CertificateCondition_To_v1_CertificateCondition(in, out, s)
 }
 
+func autoConvert_v1_CertificateDefaults_To_certmanager_CertificateDefaults(in *v1.CertificateDefaults, out *certmanager.CertificateDefaults, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_CertificateDefaultsSpec_To_certmanager_CertificateDefaultsSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CertificateDefaults_To_certmanager_CertificateDefaults is an autogenerated conversion function.
+func Convert_v1_CertificateDefaults_To_certmanager_CertificateDefaults(in *v1.CertificateDefaults, out *certmanager.CertificateDefaults, s conversion.Scope) error {
+	return autoConvert_v1_CertificateDefaults_To_certmanager_CertificateDefaults(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateDefaults_To_v1_CertificateDefaults(in *certmanager.CertificateDefaults, out *v1.CertificateDefaults, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_CertificateDefaultsSpec_To_v1_CertificateDefaultsSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateDefaults_To_v1_CertificateDefaults is an autogenerated conversion function.
+func Convert_certmanager_CertificateDefaults_To_v1_CertificateDefaults(in *certmanager.CertificateDefaults, out *v1.CertificateDefaults, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateDefaults_To_v1_CertificateDefaults(in, out, s)
+}
+
+func autoConvert_v1_CertificateDefaultsList_To_certmanager_CertificateDefaultsList(in *v1.CertificateDefaultsList, out *certmanager.CertificateDefaultsList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]certmanager.CertificateDefaults)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1_CertificateDefaultsList_To_certmanager_CertificateDefaultsList is an autogenerated conversion function.
+func Convert_v1_CertificateDefaultsList_To_certmanager_CertificateDefaultsList(in *v1.CertificateDefaultsList, out *certmanager.CertificateDefaultsList, s conversion.Scope) error {
+	return autoConvert_v1_CertificateDefaultsList_To_certmanager_CertificateDefaultsList(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateDefaultsList_To_v1_CertificateDefaultsList(in *certmanager.CertificateDefaultsList, out *v1.CertificateDefaultsList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1.CertificateDefaults)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_certmanager_CertificateDefaultsList_To_v1_CertificateDefaultsList is an autogenerated conversion function.
+func Convert_certmanager_CertificateDefaultsList_To_v1_CertificateDefaultsList(in *certmanager.CertificateDefaultsList, out *v1.CertificateDefaultsList, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateDefaultsList_To_v1_CertificateDefaultsList(in, out, s)
+}
+
+func autoConvert_v1_CertificateDefaultsSpec_To_certmanager_CertificateDefaultsSpec(in *v1.CertificateDefaultsSpec, out *certmanager.CertificateDefaultsSpec, s conversion.Scope) error {
+	out.PrivateKey = (*certmanager.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
+	return nil
+}
+
+// Convert_v1_CertificateDefaultsSpec_To_certmanager_CertificateDefaultsSpec is an autogenerated conversion function.
+func Convert_v1_CertificateDefaultsSpec_To_certmanager_CertificateDefaultsSpec(in *v1.CertificateDefaultsSpec, out *certmanager.CertificateDefaultsSpec, s conversion.Scope) error {
+	return autoConvert_v1_CertificateDefaultsSpec_To_certmanager_CertificateDefaultsSpec(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateDefaultsSpec_To_v1_CertificateDefaultsSpec(in *certmanager.CertificateDefaultsSpec, out *v1.CertificateDefaultsSpec, s conversion.Scope) error {
+	out.PrivateKey = (*v1.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
+	return nil
+}
+
+// Convert_certmanager_CertificateDefaultsSpec_To_v1_CertificateDefaultsSpec is an autogenerated conversion function.
+func Convert_certmanager_CertificateDefaultsSpec_To_v1_CertificateDefaultsSpec(in *certmanager.CertificateDefaultsSpec, out *v1.CertificateDefaultsSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateDefaultsSpec_To_v1_CertificateDefaultsSpec(in, out, s)
+}
+
 func autoConvert_v1_CertificateKeystores_To_certmanager_CertificateKeystores(in *v1.CertificateKeystores, out *certmanager.CertificateKeystores, s conversion.Scope) error {
 	if in.JKS != nil {
 		in, out := &in.JKS, &out.JKS
@@ -591,6 +1161,7 @@ func Convert_certmanager_CertificateList_To_v1_CertificateList(in *certmanager.C
 
 func autoConvert_v1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *v1.CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.RotationSchedule = (*certmanager.PrivateKeyRotationSchedule)(unsafe.Pointer(in.RotationSchedule))
 	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
@@ -604,6 +1175,7 @@ func Convert_v1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *v
 
 func autoConvert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in *certmanager.CertificatePrivateKey, out *v1.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = v1.PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.RotationSchedule = (*v1.PrivateKeyRotationSchedule)(unsafe.Pointer(in.RotationSchedule))
 	out.Encoding = v1.PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = v1.PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
@@ -615,6 +1187,118 @@ func Convert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in *c
 	return autoConvert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in, out, s)
 }
 
+func autoConvert_v1_CertificateProfile_To_certmanager_CertificateProfile(in *v1.CertificateProfile, out *certmanager.CertificateProfile, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_CertificateProfileSpec_To_certmanager_CertificateProfileSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CertificateProfile_To_certmanager_CertificateProfile is an autogenerated conversion function.
+func Convert_v1_CertificateProfile_To_certmanager_CertificateProfile(in *v1.CertificateProfile, out *certmanager.CertificateProfile, s conversion.Scope) error {
+	return autoConvert_v1_CertificateProfile_To_certmanager_CertificateProfile(in, out, s)
+}
+
+func autoConvert_v1_CertificateProfileList_To_certmanager_CertificateProfileList(in *v1.CertificateProfileList, out *certmanager.CertificateProfileList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]certmanager.CertificateProfile, len(*in))
+		for i := range *in {
+			if err := Convert_v1_CertificateProfile_To_certmanager_CertificateProfile(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1_CertificateProfileList_To_certmanager_CertificateProfileList is an autogenerated conversion function.
+func Convert_v1_CertificateProfileList_To_certmanager_CertificateProfileList(in *v1.CertificateProfileList, out *certmanager.CertificateProfileList, s conversion.Scope) error {
+	return autoConvert_v1_CertificateProfileList_To_certmanager_CertificateProfileList(in, out, s)
+}
+
+func autoConvert_v1_CertificateProfileSpec_To_certmanager_CertificateProfileSpec(in *v1.CertificateProfileSpec, out *certmanager.CertificateProfileSpec, s conversion.Scope) error {
+	out.PrivateKey = (*certmanager.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.Subject = (*certmanager.X509Subject)(unsafe.Pointer(in.Subject))
+	if in.Keystores != nil {
+		in, out := &in.Keystores, &out.Keystores
+		*out = new(certmanager.CertificateKeystores)
+		if err := Convert_v1_CertificateKeystores_To_certmanager_CertificateKeystores(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Keystores = nil
+	}
+	return nil
+}
+
+// Convert_v1_CertificateProfileSpec_To_certmanager_CertificateProfileSpec is an autogenerated conversion function.
+func Convert_v1_CertificateProfileSpec_To_certmanager_CertificateProfileSpec(in *v1.CertificateProfileSpec, out *certmanager.CertificateProfileSpec, s conversion.Scope) error {
+	return autoConvert_v1_CertificateProfileSpec_To_certmanager_CertificateProfileSpec(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateProfile_To_v1_CertificateProfile(in *certmanager.CertificateProfile, out *v1.CertificateProfile, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_CertificateProfileSpec_To_v1_CertificateProfileSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateProfile_To_v1_CertificateProfile is an autogenerated conversion function.
+func Convert_certmanager_CertificateProfile_To_v1_CertificateProfile(in *certmanager.CertificateProfile, out *v1.CertificateProfile, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateProfile_To_v1_CertificateProfile(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateProfileList_To_v1_CertificateProfileList(in *certmanager.CertificateProfileList, out *v1.CertificateProfileList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.CertificateProfile, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_CertificateProfile_To_v1_CertificateProfile(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateProfileList_To_v1_CertificateProfileList is an autogenerated conversion function.
+func Convert_certmanager_CertificateProfileList_To_v1_CertificateProfileList(in *certmanager.CertificateProfileList, out *v1.CertificateProfileList, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateProfileList_To_v1_CertificateProfileList(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateProfileSpec_To_v1_CertificateProfileSpec(in *certmanager.CertificateProfileSpec, out *v1.CertificateProfileSpec, s conversion.Scope) error {
+	out.PrivateKey = (*v1.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Usages = *(*[]v1.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.Subject = (*v1.X509Subject)(unsafe.Pointer(in.Subject))
+	if in.Keystores != nil {
+		in, out := &in.Keystores, &out.Keystores
+		*out = new(v1.CertificateKeystores)
+		if err := Convert_certmanager_CertificateKeystores_To_v1_CertificateKeystores(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Keystores = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateProfileSpec_To_v1_CertificateProfileSpec is an autogenerated conversion function.
+func Convert_certmanager_CertificateProfileSpec_To_v1_CertificateProfileSpec(in *certmanager.CertificateProfileSpec, out *v1.CertificateProfileSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateProfileSpec_To_v1_CertificateProfileSpec(in, out, s)
+}
+
 func autoConvert_v1_CertificateRequest_To_certmanager_CertificateRequest(in *v1.CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -650,7 +1334,7 @@ func Convert_certmanager_CertificateRequest_To_v1_CertificateRequest(in *certman
 func autoConvert_v1_CertificateRequestCondition_To_certmanager_CertificateRequestCondition(in *v1.CertificateRequestCondition, out *certmanager.CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateRequestConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	return nil
@@ -664,7 +1348,7 @@ func Convert_v1_CertificateRequestCondition_To_certmanager_CertificateRequestCon
 func autoConvert_certmanager_CertificateRequestCondition_To_v1_CertificateRequestCondition(in *certmanager.CertificateRequestCondition, out *v1.CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = v1.CertificateRequestConditionType(in.Type)
 	out.Status = apismetav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	return nil
@@ -718,11 +1402,21 @@ func Convert_certmanager_CertificateRequestList_To_v1_CertificateRequestList(in
 }
 
 func autoConvert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(in *v1.CertificateRequestSpec, out *certmanager.CertificateRequestSpec, s conversion.Scope) error {
-	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
-	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
+	if in.RequestSecretRef != nil {
+		in, out := &in.RequestSecretRef, &out.RequestSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RequestSecretRef = nil
+	}
+	out.RequestSHA256 = in.RequestSHA256
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
 	out.Username = in.Username
@@ -738,11 +1432,21 @@ func Convert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(in
 }
 
 func autoConvert_certmanager_CertificateRequestSpec_To_v1_CertificateRequestSpec(in *certmanager.CertificateRequestSpec, out *v1.CertificateRequestSpec, s conversion.Scope) error {
-	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
-	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
+	if in.RequestSecretRef != nil {
+		in, out := &in.RequestSecretRef, &out.RequestSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RequestSecretRef = nil
+	}
+	out.RequestSHA256 = in.RequestSHA256
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]v1.KeyUsage)(unsafe.Pointer(&in.Usages))
 	out.Username = in.Username
@@ -761,26 +1465,216 @@ func autoConvert_v1_CertificateRequestStatus_To_certmanager_CertificateRequestSt
 	out.Conditions = *(*[]certmanager.CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
-	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	return nil
+}
+
+// Convert_v1_CertificateRequestStatus_To_certmanager_CertificateRequestStatus is an autogenerated conversion function.
+func Convert_v1_CertificateRequestStatus_To_certmanager_CertificateRequestStatus(in *v1.CertificateRequestStatus, out *certmanager.CertificateRequestStatus, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRequestStatus_To_certmanager_CertificateRequestStatus(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus(in *certmanager.CertificateRequestStatus, out *v1.CertificateRequestStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]v1.CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
+	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
+	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
+	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	return nil
+}
+
+// Convert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus is an autogenerated conversion function.
+func Convert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus(in *certmanager.CertificateRequestStatus, out *v1.CertificateRequestStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus(in, out, s)
+}
+
+func autoConvert_v1_CertificateRevocationRequest_To_certmanager_CertificateRevocationRequest(in *v1.CertificateRevocationRequest, out *certmanager.CertificateRevocationRequest, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_CertificateRevocationRequestSpec_To_certmanager_CertificateRevocationRequestSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1_CertificateRevocationRequestStatus_To_certmanager_CertificateRevocationRequestStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CertificateRevocationRequest_To_certmanager_CertificateRevocationRequest is an autogenerated conversion function.
+func Convert_v1_CertificateRevocationRequest_To_certmanager_CertificateRevocationRequest(in *v1.CertificateRevocationRequest, out *certmanager.CertificateRevocationRequest, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRevocationRequest_To_certmanager_CertificateRevocationRequest(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRevocationRequest_To_v1_CertificateRevocationRequest(in *certmanager.CertificateRevocationRequest, out *v1.CertificateRevocationRequest, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_CertificateRevocationRequestSpec_To_v1_CertificateRevocationRequestSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_certmanager_CertificateRevocationRequestStatus_To_v1_CertificateRevocationRequestStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateRevocationRequest_To_v1_CertificateRevocationRequest is an autogenerated conversion function.
+func Convert_certmanager_CertificateRevocationRequest_To_v1_CertificateRevocationRequest(in *certmanager.CertificateRevocationRequest, out *v1.CertificateRevocationRequest, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRevocationRequest_To_v1_CertificateRevocationRequest(in, out, s)
+}
+
+func autoConvert_v1_CertificateRevocationRequestCondition_To_certmanager_CertificateRevocationRequestCondition(in *v1.CertificateRevocationRequestCondition, out *certmanager.CertificateRevocationRequestCondition, s conversion.Scope) error {
+	out.Type = certmanager.CertificateRevocationRequestConditionType(in.Type)
+	out.Status = meta.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1_CertificateRevocationRequestCondition_To_certmanager_CertificateRevocationRequestCondition is an autogenerated conversion function.
+func Convert_v1_CertificateRevocationRequestCondition_To_certmanager_CertificateRevocationRequestCondition(in *v1.CertificateRevocationRequestCondition, out *certmanager.CertificateRevocationRequestCondition, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRevocationRequestCondition_To_certmanager_CertificateRevocationRequestCondition(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRevocationRequestCondition_To_v1_CertificateRevocationRequestCondition(in *certmanager.CertificateRevocationRequestCondition, out *v1.CertificateRevocationRequestCondition, s conversion.Scope) error {
+	out.Type = v1.CertificateRevocationRequestConditionType(in.Type)
+	out.Status = apismetav1.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_certmanager_CertificateRevocationRequestCondition_To_v1_CertificateRevocationRequestCondition is an autogenerated conversion function.
+func Convert_certmanager_CertificateRevocationRequestCondition_To_v1_CertificateRevocationRequestCondition(in *certmanager.CertificateRevocationRequestCondition, out *v1.CertificateRevocationRequestCondition, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRevocationRequestCondition_To_v1_CertificateRevocationRequestCondition(in, out, s)
+}
+
+func autoConvert_v1_CertificateRevocationRequestList_To_certmanager_CertificateRevocationRequestList(in *v1.CertificateRevocationRequestList, out *certmanager.CertificateRevocationRequestList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]certmanager.CertificateRevocationRequest)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1_CertificateRevocationRequestList_To_certmanager_CertificateRevocationRequestList is an autogenerated conversion function.
+func Convert_v1_CertificateRevocationRequestList_To_certmanager_CertificateRevocationRequestList(in *v1.CertificateRevocationRequestList, out *certmanager.CertificateRevocationRequestList, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRevocationRequestList_To_certmanager_CertificateRevocationRequestList(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRevocationRequestList_To_v1_CertificateRevocationRequestList(in *certmanager.CertificateRevocationRequestList, out *v1.CertificateRevocationRequestList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1.CertificateRevocationRequest)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_certmanager_CertificateRevocationRequestList_To_v1_CertificateRevocationRequestList is an autogenerated conversion function.
+func Convert_certmanager_CertificateRevocationRequestList_To_v1_CertificateRevocationRequestList(in *certmanager.CertificateRevocationRequestList, out *v1.CertificateRevocationRequestList, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRevocationRequestList_To_v1_CertificateRevocationRequestList(in, out, s)
+}
+
+func autoConvert_v1_CertificateRevocationRequestSpec_To_certmanager_CertificateRevocationRequestSpec(in *v1.CertificateRevocationRequestSpec, out *certmanager.CertificateRevocationRequestSpec, s conversion.Scope) error {
+	out.CertificateRef = (*meta.LocalObjectReference)(unsafe.Pointer(in.CertificateRef))
+	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	out.SerialNumber = in.SerialNumber
+	out.Reason = certmanager.RevocationReason(in.Reason)
+	return nil
+}
+
+// Convert_v1_CertificateRevocationRequestSpec_To_certmanager_CertificateRevocationRequestSpec is an autogenerated conversion function.
+func Convert_v1_CertificateRevocationRequestSpec_To_certmanager_CertificateRevocationRequestSpec(in *v1.CertificateRevocationRequestSpec, out *certmanager.CertificateRevocationRequestSpec, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRevocationRequestSpec_To_certmanager_CertificateRevocationRequestSpec(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRevocationRequestSpec_To_v1_CertificateRevocationRequestSpec(in *certmanager.CertificateRevocationRequestSpec, out *v1.CertificateRevocationRequestSpec, s conversion.Scope) error {
+	out.CertificateRef = (*apismetav1.LocalObjectReference)(unsafe.Pointer(in.CertificateRef))
+	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	out.SerialNumber = in.SerialNumber
+	out.Reason = v1.RevocationReason(in.Reason)
+	return nil
+}
+
+// Convert_certmanager_CertificateRevocationRequestSpec_To_v1_CertificateRevocationRequestSpec is an autogenerated conversion function.
+func Convert_certmanager_CertificateRevocationRequestSpec_To_v1_CertificateRevocationRequestSpec(in *certmanager.CertificateRevocationRequestSpec, out *v1.CertificateRevocationRequestSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRevocationRequestSpec_To_v1_CertificateRevocationRequestSpec(in, out, s)
+}
+
+func autoConvert_v1_CertificateRevocationRequestStatus_To_certmanager_CertificateRevocationRequestStatus(in *v1.CertificateRevocationRequestStatus, out *certmanager.CertificateRevocationRequestStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]certmanager.CertificateRevocationRequestCondition)(unsafe.Pointer(&in.Conditions))
+	out.RevocationTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.RevocationTime))
+	out.ReissuanceTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.ReissuanceTime))
+	return nil
+}
+
+// Convert_v1_CertificateRevocationRequestStatus_To_certmanager_CertificateRevocationRequestStatus is an autogenerated conversion function.
+func Convert_v1_CertificateRevocationRequestStatus_To_certmanager_CertificateRevocationRequestStatus(in *v1.CertificateRevocationRequestStatus, out *certmanager.CertificateRevocationRequestStatus, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRevocationRequestStatus_To_certmanager_CertificateRevocationRequestStatus(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRevocationRequestStatus_To_v1_CertificateRevocationRequestStatus(in *certmanager.CertificateRevocationRequestStatus, out *v1.CertificateRevocationRequestStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]v1.CertificateRevocationRequestCondition)(unsafe.Pointer(&in.Conditions))
+	out.RevocationTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.RevocationTime))
+	out.ReissuanceTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.ReissuanceTime))
+	return nil
+}
+
+// Convert_certmanager_CertificateRevocationRequestStatus_To_v1_CertificateRevocationRequestStatus is an autogenerated conversion function.
+func Convert_certmanager_CertificateRevocationRequestStatus_To_v1_CertificateRevocationRequestStatus(in *certmanager.CertificateRevocationRequestStatus, out *v1.CertificateRevocationRequestStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRevocationRequestStatus_To_v1_CertificateRevocationRequestStatus(in, out, s)
+}
+
+func autoConvert_v1_CertificateSecretStore_To_certmanager_CertificateSecretStore(in *v1.CertificateSecretStore, out *certmanager.CertificateSecretStore, s conversion.Scope) error {
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(certmanager.VaultSecretStore)
+		if err := Convert_v1_VaultSecretStore_To_certmanager_VaultSecretStore(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Vault = nil
+	}
+	if in.AWSSecretsManager != nil {
+		in, out := &in.AWSSecretsManager, &out.AWSSecretsManager
+		*out = new(certmanager.AWSSecretsManagerSecretStore)
+		if err := Convert_v1_AWSSecretsManagerSecretStore_To_certmanager_AWSSecretsManagerSecretStore(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AWSSecretsManager = nil
+	}
 	return nil
 }
 
-// Convert_v1_CertificateRequestStatus_To_certmanager_CertificateRequestStatus is an autogenerated conversion function.
-func Convert_v1_CertificateRequestStatus_To_certmanager_CertificateRequestStatus(in *v1.CertificateRequestStatus, out *certmanager.CertificateRequestStatus, s conversion.Scope) error {
-	return autoConvert_v1_CertificateRequestStatus_To_certmanager_CertificateRequestStatus(in, out, s)
+// Convert_v1_CertificateSecretStore_To_certmanager_CertificateSecretStore is an autogenerated conversion function.
+func Convert_v1_CertificateSecretStore_To_certmanager_CertificateSecretStore(in *v1.CertificateSecretStore, out *certmanager.CertificateSecretStore, s conversion.Scope) error {
+	return autoConvert_v1_CertificateSecretStore_To_certmanager_CertificateSecretStore(in, out, s)
 }
 
-func autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus(in *certmanager.CertificateRequestStatus, out *v1.CertificateRequestStatus, s conversion.Scope) error {
-	out.Conditions = *(*[]v1.CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
-	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
-	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
-	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
+func autoConvert_certmanager_CertificateSecretStore_To_v1_CertificateSecretStore(in *certmanager.CertificateSecretStore, out *v1.CertificateSecretStore, s conversion.Scope) error {
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(v1.VaultSecretStore)
+		if err := Convert_certmanager_VaultSecretStore_To_v1_VaultSecretStore(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Vault = nil
+	}
+	if in.AWSSecretsManager != nil {
+		in, out := &in.AWSSecretsManager, &out.AWSSecretsManager
+		*out = new(v1.AWSSecretsManagerSecretStore)
+		if err := Convert_certmanager_AWSSecretsManagerSecretStore_To_v1_AWSSecretsManagerSecretStore(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AWSSecretsManager = nil
+	}
 	return nil
 }
 
-// Convert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus is an autogenerated conversion function.
-func Convert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus(in *certmanager.CertificateRequestStatus, out *v1.CertificateRequestStatus, s conversion.Scope) error {
-	return autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus(in, out, s)
+// Convert_certmanager_CertificateSecretStore_To_v1_CertificateSecretStore is an autogenerated conversion function.
+func Convert_certmanager_CertificateSecretStore_To_v1_CertificateSecretStore(in *certmanager.CertificateSecretStore, out *v1.CertificateSecretStore, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateSecretStore_To_v1_CertificateSecretStore(in, out, s)
 }
 
 func autoConvert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *v1.CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
@@ -809,8 +1703,8 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	out.Subject = (*certmanager.X509Subject)(unsafe.Pointer(in.Subject))
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
-	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
-	out.RenewBefore = (*metav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*pkgapismetav1.Duration)(unsafe.Pointer(in.RenewBefore))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	// WARNING: in.URIs requires manual conversion: does not exist in peer-type
@@ -826,15 +1720,32 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	} else {
 		out.Keystores = nil
 	}
-	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.UsagePreset = certmanager.UsagePreset(in.UsagePreset)
 	out.PrivateKey = (*certmanager.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
+	out.PropagationQuorum = (*int32)(unsafe.Pointer(in.PropagationQuorum))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.SecretStores != nil {
+		in, out := &in.SecretStores, &out.SecretStores
+		*out = make([]certmanager.CertificateSecretStore, len(*in))
+		for i := range *in {
+			if err := Convert_v1_CertificateSecretStore_To_certmanager_CertificateSecretStore(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SecretStores = nil
+	}
+	out.ProfileRef = (*meta.LocalObjectReference)(unsafe.Pointer(in.ProfileRef))
+	out.RenewalWindow = (*certmanager.RenewalWindow)(unsafe.Pointer(in.RenewalWindow))
+	out.AdditionalPrivateKeys = *(*[]certmanager.CertificateAdditionalPrivateKey)(unsafe.Pointer(&in.AdditionalPrivateKeys))
+	out.SecretFormatProfile = certmanager.SecretFormatProfile(in.SecretFormatProfile)
 	return nil
 }
 
@@ -842,8 +1753,8 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	out.Subject = (*v1.X509Subject)(unsafe.Pointer(in.Subject))
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
-	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
-	out.RenewBefore = (*metav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*pkgapismetav1.Duration)(unsafe.Pointer(in.RenewBefore))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	// WARNING: in.URISANs requires manual conversion: does not exist in peer-type
@@ -859,27 +1770,52 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	} else {
 		out.Keystores = nil
 	}
-	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]v1.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.UsagePreset = v1.UsagePreset(in.UsagePreset)
 	out.PrivateKey = (*v1.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
+	out.PropagationQuorum = (*int32)(unsafe.Pointer(in.PropagationQuorum))
 	out.AdditionalOutputFormats = *(*[]v1.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.SecretStores != nil {
+		in, out := &in.SecretStores, &out.SecretStores
+		*out = make([]v1.CertificateSecretStore, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_CertificateSecretStore_To_v1_CertificateSecretStore(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SecretStores = nil
+	}
+	out.ProfileRef = (*apismetav1.LocalObjectReference)(unsafe.Pointer(in.ProfileRef))
+	out.RenewalWindow = (*v1.RenewalWindow)(unsafe.Pointer(in.RenewalWindow))
+	out.AdditionalPrivateKeys = *(*[]v1.CertificateAdditionalPrivateKey)(unsafe.Pointer(&in.AdditionalPrivateKeys))
+	out.SecretFormatProfile = v1.SecretFormatProfile(in.SecretFormatProfile)
 	return nil
 }
 
 func autoConvert_v1_CertificateStatus_To_certmanager_CertificateStatus(in *v1.CertificateStatus, out *certmanager.CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.CertificateCondition)(unsafe.Pointer(&in.Conditions))
-	out.LastFailureTime = (*metav1.Time)(unsafe.Pointer(in.LastFailureTime))
-	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
-	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
-	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.LastFailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastFailureTime))
+	out.NotBefore = (*pkgapismetav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*pkgapismetav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.RenewalTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.SerialNumber = in.SerialNumber
+	out.IssuerDN = in.IssuerDN
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.PrivateKeyIssuedTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.PrivateKeyIssuedTime))
+	out.PrivateKeyIssuances = (*int)(unsafe.Pointer(in.PrivateKeyIssuances))
 	return nil
 }
 
@@ -890,13 +1826,21 @@ func Convert_v1_CertificateStatus_To_certmanager_CertificateStatus(in *v1.Certif
 
 func autoConvert_certmanager_CertificateStatus_To_v1_CertificateStatus(in *certmanager.CertificateStatus, out *v1.CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]v1.CertificateCondition)(unsafe.Pointer(&in.Conditions))
-	out.LastFailureTime = (*metav1.Time)(unsafe.Pointer(in.LastFailureTime))
-	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
-	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
-	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.LastFailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastFailureTime))
+	out.NotBefore = (*pkgapismetav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*pkgapismetav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.RenewalTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.SerialNumber = in.SerialNumber
+	out.IssuerDN = in.IssuerDN
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.PrivateKeyIssuedTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.PrivateKeyIssuedTime))
+	out.PrivateKeyIssuances = (*int)(unsafe.Pointer(in.PrivateKeyIssuances))
 	return nil
 }
 
@@ -979,6 +1923,92 @@ func Convert_certmanager_ClusterIssuerList_To_v1_ClusterIssuerList(in *certmanag
 	return autoConvert_certmanager_ClusterIssuerList_To_v1_ClusterIssuerList(in, out, s)
 }
 
+func autoConvert_v1_ExternalSignerIssuer_To_certmanager_ExternalSignerIssuer(in *v1.ExternalSignerIssuer, out *certmanager.ExternalSignerIssuer, s conversion.Scope) error {
+	out.Address = in.Address
+	out.ServerName = in.ServerName
+	if err := metav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.TLSSecretRef, &out.TLSSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ExternalSignerIssuer_To_certmanager_ExternalSignerIssuer is an autogenerated conversion function.
+func Convert_v1_ExternalSignerIssuer_To_certmanager_ExternalSignerIssuer(in *v1.ExternalSignerIssuer, out *certmanager.ExternalSignerIssuer, s conversion.Scope) error {
+	return autoConvert_v1_ExternalSignerIssuer_To_certmanager_ExternalSignerIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_ExternalSignerIssuer_To_v1_ExternalSignerIssuer(in *certmanager.ExternalSignerIssuer, out *v1.ExternalSignerIssuer, s conversion.Scope) error {
+	out.Address = in.Address
+	out.ServerName = in.ServerName
+	if err := metav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.TLSSecretRef, &out.TLSSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_ExternalSignerIssuer_To_v1_ExternalSignerIssuer is an autogenerated conversion function.
+func Convert_certmanager_ExternalSignerIssuer_To_v1_ExternalSignerIssuer(in *certmanager.ExternalSignerIssuer, out *v1.ExternalSignerIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_ExternalSignerIssuer_To_v1_ExternalSignerIssuer(in, out, s)
+}
+
+func autoConvert_v1_HubIssuer_To_certmanager_HubIssuer(in *v1.HubIssuer, out *certmanager.HubIssuer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.TokenSecretRef, &out.TokenSecretRef, s); err != nil {
+		return err
+	}
+	out.Namespace = in.Namespace
+	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_HubIssuer_To_certmanager_HubIssuer is an autogenerated conversion function.
+func Convert_v1_HubIssuer_To_certmanager_HubIssuer(in *v1.HubIssuer, out *certmanager.HubIssuer, s conversion.Scope) error {
+	return autoConvert_v1_HubIssuer_To_certmanager_HubIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_HubIssuer_To_v1_HubIssuer(in *certmanager.HubIssuer, out *v1.HubIssuer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.TokenSecretRef, &out.TokenSecretRef, s); err != nil {
+		return err
+	}
+	out.Namespace = in.Namespace
+	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_HubIssuer_To_v1_HubIssuer is an autogenerated conversion function.
+func Convert_certmanager_HubIssuer_To_v1_HubIssuer(in *certmanager.HubIssuer, out *v1.HubIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_HubIssuer_To_v1_HubIssuer(in, out, s)
+}
+
+func autoConvert_v1_HTTPHeader_To_certmanager_HTTPHeader(in *v1.HTTPHeader, out *certmanager.HTTPHeader, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_v1_HTTPHeader_To_certmanager_HTTPHeader is an autogenerated conversion function.
+func Convert_v1_HTTPHeader_To_certmanager_HTTPHeader(in *v1.HTTPHeader, out *certmanager.HTTPHeader, s conversion.Scope) error {
+	return autoConvert_v1_HTTPHeader_To_certmanager_HTTPHeader(in, out, s)
+}
+
+func autoConvert_certmanager_HTTPHeader_To_v1_HTTPHeader(in *certmanager.HTTPHeader, out *v1.HTTPHeader, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_certmanager_HTTPHeader_To_v1_HTTPHeader is an autogenerated conversion function.
+func Convert_certmanager_HTTPHeader_To_v1_HTTPHeader(in *certmanager.HTTPHeader, out *v1.HTTPHeader, s conversion.Scope) error {
+	return autoConvert_certmanager_HTTPHeader_To_v1_HTTPHeader(in, out, s)
+}
+
 func autoConvert_v1_Issuer_To_certmanager_Issuer(in *v1.Issuer, out *certmanager.Issuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1011,10 +2041,82 @@ func Convert_certmanager_Issuer_To_v1_Issuer(in *certmanager.Issuer, out *v1.Iss
 	return autoConvert_certmanager_Issuer_To_v1_Issuer(in, out, s)
 }
 
+func autoConvert_v1_IssuerAlias_To_certmanager_IssuerAlias(in *v1.IssuerAlias, out *certmanager.IssuerAlias, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_IssuerAliasSpec_To_certmanager_IssuerAliasSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_IssuerAlias_To_certmanager_IssuerAlias is an autogenerated conversion function.
+func Convert_v1_IssuerAlias_To_certmanager_IssuerAlias(in *v1.IssuerAlias, out *certmanager.IssuerAlias, s conversion.Scope) error {
+	return autoConvert_v1_IssuerAlias_To_certmanager_IssuerAlias(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerAlias_To_v1_IssuerAlias(in *certmanager.IssuerAlias, out *v1.IssuerAlias, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_IssuerAliasSpec_To_v1_IssuerAliasSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_IssuerAlias_To_v1_IssuerAlias is an autogenerated conversion function.
+func Convert_certmanager_IssuerAlias_To_v1_IssuerAlias(in *certmanager.IssuerAlias, out *v1.IssuerAlias, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerAlias_To_v1_IssuerAlias(in, out, s)
+}
+
+func autoConvert_v1_IssuerAliasList_To_certmanager_IssuerAliasList(in *v1.IssuerAliasList, out *certmanager.IssuerAliasList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]certmanager.IssuerAlias)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1_IssuerAliasList_To_certmanager_IssuerAliasList is an autogenerated conversion function.
+func Convert_v1_IssuerAliasList_To_certmanager_IssuerAliasList(in *v1.IssuerAliasList, out *certmanager.IssuerAliasList, s conversion.Scope) error {
+	return autoConvert_v1_IssuerAliasList_To_certmanager_IssuerAliasList(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerAliasList_To_v1_IssuerAliasList(in *certmanager.IssuerAliasList, out *v1.IssuerAliasList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1.IssuerAlias)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_certmanager_IssuerAliasList_To_v1_IssuerAliasList is an autogenerated conversion function.
+func Convert_certmanager_IssuerAliasList_To_v1_IssuerAliasList(in *certmanager.IssuerAliasList, out *v1.IssuerAliasList, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerAliasList_To_v1_IssuerAliasList(in, out, s)
+}
+
+func autoConvert_v1_IssuerAliasSpec_To_certmanager_IssuerAliasSpec(in *v1.IssuerAliasSpec, out *certmanager.IssuerAliasSpec, s conversion.Scope) error {
+	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_IssuerAliasSpec_To_certmanager_IssuerAliasSpec is an autogenerated conversion function.
+func Convert_v1_IssuerAliasSpec_To_certmanager_IssuerAliasSpec(in *v1.IssuerAliasSpec, out *certmanager.IssuerAliasSpec, s conversion.Scope) error {
+	return autoConvert_v1_IssuerAliasSpec_To_certmanager_IssuerAliasSpec(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerAliasSpec_To_v1_IssuerAliasSpec(in *certmanager.IssuerAliasSpec, out *v1.IssuerAliasSpec, s conversion.Scope) error {
+	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_IssuerAliasSpec_To_v1_IssuerAliasSpec is an autogenerated conversion function.
+func Convert_certmanager_IssuerAliasSpec_To_v1_IssuerAliasSpec(in *certmanager.IssuerAliasSpec, out *v1.IssuerAliasSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerAliasSpec_To_v1_IssuerAliasSpec(in, out, s)
+}
+
 func autoConvert_v1_IssuerCondition_To_certmanager_IssuerCondition(in *v1.IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
 	out.Type = certmanager.IssuerConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -1029,7 +2131,7 @@ func Convert_v1_IssuerCondition_To_certmanager_IssuerCondition(in *v1.IssuerCond
 func autoConvert_certmanager_IssuerCondition_To_v1_IssuerCondition(in *certmanager.IssuerCondition, out *v1.IssuerCondition, s conversion.Scope) error {
 	out.Type = v1.IssuerConditionType(in.Type)
 	out.Status = apismetav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -1051,7 +2153,15 @@ func autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(certmanager.CAIssuer)
+		if err := Convert_v1_CAIssuer_To_certmanager_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(certmanager.VaultIssuer)
@@ -1071,6 +2181,24 @@ func autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig
 	} else {
 		out.Venafi = nil
 	}
+	if in.Hub != nil {
+		in, out := &in.Hub, &out.Hub
+		*out = new(certmanager.HubIssuer)
+		if err := Convert_v1_HubIssuer_To_certmanager_HubIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hub = nil
+	}
+	if in.ExternalSigner != nil {
+		in, out := &in.ExternalSigner, &out.ExternalSigner
+		*out = new(certmanager.ExternalSignerIssuer)
+		if err := Convert_v1_ExternalSignerIssuer_To_certmanager_ExternalSignerIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExternalSigner = nil
+	}
 	return nil
 }
 
@@ -1089,7 +2217,15 @@ func autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.Iss
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*v1.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(v1.CAIssuer)
+		if err := Convert_certmanager_CAIssuer_To_v1_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(v1.VaultIssuer)
@@ -1109,6 +2245,24 @@ func autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.Iss
 	} else {
 		out.Venafi = nil
 	}
+	if in.Hub != nil {
+		in, out := &in.Hub, &out.Hub
+		*out = new(v1.HubIssuer)
+		if err := Convert_certmanager_HubIssuer_To_v1_HubIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hub = nil
+	}
+	if in.ExternalSigner != nil {
+		in, out := &in.ExternalSigner, &out.ExternalSigner
+		*out = new(v1.ExternalSignerIssuer)
+		if err := Convert_certmanager_ExternalSignerIssuer_To_v1_ExternalSignerIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExternalSigner = nil
+	}
 	return nil
 }
 
@@ -1117,6 +2271,28 @@ func Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.IssuerC
 	return autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in, out, s)
 }
 
+func autoConvert_v1_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(in *v1.IssuerHTTPClient, out *certmanager.IssuerHTTPClient, s conversion.Scope) error {
+	out.UserAgentSuffix = in.UserAgentSuffix
+	out.Headers = *(*[]certmanager.HTTPHeader)(unsafe.Pointer(&in.Headers))
+	return nil
+}
+
+// Convert_v1_IssuerHTTPClient_To_certmanager_IssuerHTTPClient is an autogenerated conversion function.
+func Convert_v1_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(in *v1.IssuerHTTPClient, out *certmanager.IssuerHTTPClient, s conversion.Scope) error {
+	return autoConvert_v1_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerHTTPClient_To_v1_IssuerHTTPClient(in *certmanager.IssuerHTTPClient, out *v1.IssuerHTTPClient, s conversion.Scope) error {
+	out.UserAgentSuffix = in.UserAgentSuffix
+	out.Headers = *(*[]v1.HTTPHeader)(unsafe.Pointer(&in.Headers))
+	return nil
+}
+
+// Convert_certmanager_IssuerHTTPClient_To_v1_IssuerHTTPClient is an autogenerated conversion function.
+func Convert_certmanager_IssuerHTTPClient_To_v1_IssuerHTTPClient(in *certmanager.IssuerHTTPClient, out *v1.IssuerHTTPClient, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerHTTPClient_To_v1_IssuerHTTPClient(in, out, s)
+}
+
 func autoConvert_v1_IssuerList_To_certmanager_IssuerList(in *v1.IssuerList, out *certmanager.IssuerList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -1163,6 +2339,10 @@ func autoConvert_v1_IssuerSpec_To_certmanager_IssuerSpec(in *v1.IssuerSpec, out
 	if err := Convert_v1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.AllowedURISchemes = *(*[]string)(unsafe.Pointer(&in.AllowedURISchemes))
+	out.AllowedUsages = *(*[]certmanager.AllowedKeyUsage)(unsafe.Pointer(&in.AllowedUsages))
+	out.RenewalWindow = (*certmanager.RenewalWindow)(unsafe.Pointer(in.RenewalWindow))
+	out.HTTPClient = (*certmanager.IssuerHTTPClient)(unsafe.Pointer(in.HTTPClient))
 	return nil
 }
 
@@ -1175,6 +2355,10 @@ func autoConvert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerS
 	if err := Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.AllowedURISchemes = *(*[]string)(unsafe.Pointer(&in.AllowedURISchemes))
+	out.AllowedUsages = *(*[]v1.AllowedKeyUsage)(unsafe.Pointer(&in.AllowedUsages))
+	out.RenewalWindow = (*v1.RenewalWindow)(unsafe.Pointer(in.RenewalWindow))
+	out.HTTPClient = (*v1.IssuerHTTPClient)(unsafe.Pointer(in.HTTPClient))
 	return nil
 }
 
@@ -1207,7 +2391,7 @@ func Convert_certmanager_IssuerStatus_To_v1_IssuerStatus(in *certmanager.IssuerS
 
 func autoConvert_v1_JKSKeystore_To_certmanager_JKSKeystore(in *v1.JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1220,7 +2404,7 @@ func Convert_v1_JKSKeystore_To_certmanager_JKSKeystore(in *v1.JKSKeystore, out *
 
 func autoConvert_certmanager_JKSKeystore_To_v1_JKSKeystore(in *certmanager.JKSKeystore, out *v1.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1233,7 +2417,7 @@ func Convert_certmanager_JKSKeystore_To_v1_JKSKeystore(in *certmanager.JKSKeysto
 
 func autoConvert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1246,7 +2430,7 @@ func Convert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1.PKCS12Keysto
 
 func autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *v1.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1257,6 +2441,54 @@ func Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKC
 	return autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in *v1.PrivateKeyRotationSchedule, out *certmanager.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	out.Renewals = (*int32)(unsafe.Pointer(in.Renewals))
+	out.MaxAge = (*pkgapismetav1.Duration)(unsafe.Pointer(in.MaxAge))
+	return nil
+}
+
+// Convert_v1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule is an autogenerated conversion function.
+func Convert_v1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in *v1.PrivateKeyRotationSchedule, out *certmanager.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	return autoConvert_v1_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in, out, s)
+}
+
+func autoConvert_certmanager_PrivateKeyRotationSchedule_To_v1_PrivateKeyRotationSchedule(in *certmanager.PrivateKeyRotationSchedule, out *v1.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	out.Renewals = (*int32)(unsafe.Pointer(in.Renewals))
+	out.MaxAge = (*pkgapismetav1.Duration)(unsafe.Pointer(in.MaxAge))
+	return nil
+}
+
+// Convert_certmanager_PrivateKeyRotationSchedule_To_v1_PrivateKeyRotationSchedule is an autogenerated conversion function.
+func Convert_certmanager_PrivateKeyRotationSchedule_To_v1_PrivateKeyRotationSchedule(in *certmanager.PrivateKeyRotationSchedule, out *v1.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	return autoConvert_certmanager_PrivateKeyRotationSchedule_To_v1_PrivateKeyRotationSchedule(in, out, s)
+}
+
+func autoConvert_v1_RenewalWindow_To_certmanager_RenewalWindow(in *v1.RenewalWindow, out *certmanager.RenewalWindow, s conversion.Scope) error {
+	out.Days = *(*[]string)(unsafe.Pointer(&in.Days))
+	out.Hours = in.Hours
+	out.TimeZone = in.TimeZone
+	out.ExpiryOverride = (*pkgapismetav1.Duration)(unsafe.Pointer(in.ExpiryOverride))
+	return nil
+}
+
+// Convert_v1_RenewalWindow_To_certmanager_RenewalWindow is an autogenerated conversion function.
+func Convert_v1_RenewalWindow_To_certmanager_RenewalWindow(in *v1.RenewalWindow, out *certmanager.RenewalWindow, s conversion.Scope) error {
+	return autoConvert_v1_RenewalWindow_To_certmanager_RenewalWindow(in, out, s)
+}
+
+func autoConvert_certmanager_RenewalWindow_To_v1_RenewalWindow(in *certmanager.RenewalWindow, out *v1.RenewalWindow, s conversion.Scope) error {
+	out.Days = *(*[]string)(unsafe.Pointer(&in.Days))
+	out.Hours = in.Hours
+	out.TimeZone = in.TimeZone
+	out.ExpiryOverride = (*pkgapismetav1.Duration)(unsafe.Pointer(in.ExpiryOverride))
+	return nil
+}
+
+// Convert_certmanager_RenewalWindow_To_v1_RenewalWindow is an autogenerated conversion function.
+func Convert_certmanager_RenewalWindow_To_v1_RenewalWindow(in *certmanager.RenewalWindow, out *v1.RenewalWindow, s conversion.Scope) error {
+	return autoConvert_certmanager_RenewalWindow_To_v1_RenewalWindow(in, out, s)
+}
+
 func autoConvert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	return nil
@@ -1277,10 +2509,32 @@ func Convert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in *certmanager
 	return autoConvert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in, out, s)
 }
 
+func autoConvert_v1_ServiceAccountRef_To_certmanager_ServiceAccountRef(in *v1.ServiceAccountRef, out *certmanager.ServiceAccountRef, s conversion.Scope) error {
+	out.Name = in.Name
+	out.TokenAudiences = *(*[]string)(unsafe.Pointer(&in.TokenAudiences))
+	return nil
+}
+
+// Convert_v1_ServiceAccountRef_To_certmanager_ServiceAccountRef is an autogenerated conversion function.
+func Convert_v1_ServiceAccountRef_To_certmanager_ServiceAccountRef(in *v1.ServiceAccountRef, out *certmanager.ServiceAccountRef, s conversion.Scope) error {
+	return autoConvert_v1_ServiceAccountRef_To_certmanager_ServiceAccountRef(in, out, s)
+}
+
+func autoConvert_certmanager_ServiceAccountRef_To_v1_ServiceAccountRef(in *certmanager.ServiceAccountRef, out *v1.ServiceAccountRef, s conversion.Scope) error {
+	out.Name = in.Name
+	out.TokenAudiences = *(*[]string)(unsafe.Pointer(&in.TokenAudiences))
+	return nil
+}
+
+// Convert_certmanager_ServiceAccountRef_To_v1_ServiceAccountRef is an autogenerated conversion function.
+func Convert_certmanager_ServiceAccountRef_To_v1_ServiceAccountRef(in *certmanager.ServiceAccountRef, out *v1.ServiceAccountRef, s conversion.Scope) error {
+	return autoConvert_certmanager_ServiceAccountRef_To_v1_ServiceAccountRef(in, out, s)
+}
+
 func autoConvert_v1_VaultAppRole_To_certmanager_VaultAppRole(in *v1.VaultAppRole, out *certmanager.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1294,7 +2548,7 @@ func Convert_v1_VaultAppRole_To_certmanager_VaultAppRole(in *v1.VaultAppRole, ou
 func autoConvert_certmanager_VaultAppRole_To_v1_VaultAppRole(in *certmanager.VaultAppRole, out *v1.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1309,7 +2563,7 @@ func autoConvert_v1_VaultAuth_To_certmanager_VaultAuth(in *v1.VaultAuth, out *ce
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(meta.SecretKeySelector)
-		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1345,7 +2599,7 @@ func autoConvert_certmanager_VaultAuth_To_v1_VaultAuth(in *certmanager.VaultAuth
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(apismetav1.SecretKeySelector)
-		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1388,7 +2642,7 @@ func autoConvert_v1_VaultIssuer_To_certmanager_VaultIssuer(in *v1.VaultIssuer, o
 	if in.CABundleSecretRef != nil {
 		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
 		*out = new(meta.SecretKeySelector)
-		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1413,7 +2667,7 @@ func autoConvert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.Vault
 	if in.CABundleSecretRef != nil {
 		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
 		*out = new(apismetav1.SecretKeySelector)
-		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1429,9 +2683,10 @@ func Convert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.VaultIssu
 
 func autoConvert_v1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *v1.VaultKubernetesAuth, out *certmanager.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
+	out.ServiceAccountRef = (*certmanager.ServiceAccountRef)(unsafe.Pointer(in.ServiceAccountRef))
 	out.Role = in.Role
 	return nil
 }
@@ -1443,9 +2698,10 @@ func Convert_v1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *v1.Va
 
 func autoConvert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in *certmanager.VaultKubernetesAuth, out *v1.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
+	out.ServiceAccountRef = (*v1.ServiceAccountRef)(unsafe.Pointer(in.ServiceAccountRef))
 	out.Role = in.Role
 	return nil
 }
@@ -1455,9 +2711,61 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in *certm
 	return autoConvert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in, out, s)
 }
 
+func autoConvert_v1_VaultSecretStore_To_certmanager_VaultSecretStore(in *v1.VaultSecretStore, out *certmanager.VaultSecretStore, s conversion.Scope) error {
+	if err := Convert_v1_VaultAuth_To_certmanager_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
+	out.Server = in.Server
+	out.Mount = in.Mount
+	out.Path = in.Path
+	out.Namespace = in.Namespace
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1_VaultSecretStore_To_certmanager_VaultSecretStore is an autogenerated conversion function.
+func Convert_v1_VaultSecretStore_To_certmanager_VaultSecretStore(in *v1.VaultSecretStore, out *certmanager.VaultSecretStore, s conversion.Scope) error {
+	return autoConvert_v1_VaultSecretStore_To_certmanager_VaultSecretStore(in, out, s)
+}
+
+func autoConvert_certmanager_VaultSecretStore_To_v1_VaultSecretStore(in *certmanager.VaultSecretStore, out *v1.VaultSecretStore, s conversion.Scope) error {
+	if err := Convert_certmanager_VaultAuth_To_v1_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
+	out.Server = in.Server
+	out.Mount = in.Mount
+	out.Path = in.Path
+	out.Namespace = in.Namespace
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_VaultSecretStore_To_v1_VaultSecretStore is an autogenerated conversion function.
+func Convert_certmanager_VaultSecretStore_To_v1_VaultSecretStore(in *certmanager.VaultSecretStore, out *v1.VaultSecretStore, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultSecretStore_To_v1_VaultSecretStore(in, out, s)
+}
+
 func autoConvert_v1_VenafiCloud_To_certmanager_VenafiCloud(in *v1.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1470,7 +2778,7 @@ func Convert_v1_VenafiCloud_To_certmanager_VenafiCloud(in *v1.VenafiCloud, out *
 
 func autoConvert_certmanager_VenafiCloud_To_v1_VenafiCloud(in *certmanager.VenafiCloud, out *v1.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1481,6 +2789,30 @@ func Convert_certmanager_VenafiCloud_To_v1_VenafiCloud(in *certmanager.VenafiClo
 	return autoConvert_certmanager_VenafiCloud_To_v1_VenafiCloud(in, out, s)
 }
 
+func autoConvert_v1_VenafiCustomField_To_certmanager_VenafiCustomField(in *v1.VenafiCustomField, out *certmanager.VenafiCustomField, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	out.Type = certmanager.VenafiCustomFieldType(in.Type)
+	return nil
+}
+
+// Convert_v1_VenafiCustomField_To_certmanager_VenafiCustomField is an autogenerated conversion function.
+func Convert_v1_VenafiCustomField_To_certmanager_VenafiCustomField(in *v1.VenafiCustomField, out *certmanager.VenafiCustomField, s conversion.Scope) error {
+	return autoConvert_v1_VenafiCustomField_To_certmanager_VenafiCustomField(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiCustomField_To_v1_VenafiCustomField(in *certmanager.VenafiCustomField, out *v1.VenafiCustomField, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	out.Type = v1.VenafiCustomFieldType(in.Type)
+	return nil
+}
+
+// Convert_certmanager_VenafiCustomField_To_v1_VenafiCustomField is an autogenerated conversion function.
+func Convert_certmanager_VenafiCustomField_To_v1_VenafiCustomField(in *certmanager.VenafiCustomField, out *v1.VenafiCustomField, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiCustomField_To_v1_VenafiCustomField(in, out, s)
+}
+
 func autoConvert_v1_VenafiIssuer_To_certmanager_VenafiIssuer(in *v1.VenafiIssuer, out *certmanager.VenafiIssuer, s conversion.Scope) error {
 	out.Zone = in.Zone
 	if in.TPP != nil {
@@ -1501,6 +2833,8 @@ func autoConvert_v1_VenafiIssuer_To_certmanager_VenafiIssuer(in *v1.VenafiIssuer
 	} else {
 		out.Cloud = nil
 	}
+	out.CustomFields = *(*[]certmanager.VenafiCustomField)(unsafe.Pointer(&in.CustomFields))
+	out.ZoneCacheDuration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.ZoneCacheDuration))
 	return nil
 }
 
@@ -1529,6 +2863,8 @@ func autoConvert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in *certmanager.Ven
 	} else {
 		out.Cloud = nil
 	}
+	out.CustomFields = *(*[]v1.VenafiCustomField)(unsafe.Pointer(&in.CustomFields))
+	out.ZoneCacheDuration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.ZoneCacheDuration))
 	return nil
 }
 
@@ -1539,7 +2875,7 @@ func Convert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in *certmanager.VenafiI
 
 func autoConvert_v1_VenafiTPP_To_certmanager_VenafiTPP(in *v1.VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := internalapismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := metav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
@@ -1553,7 +2889,7 @@ func Convert_v1_VenafiTPP_To_certmanager_VenafiTPP(in *v1.VenafiTPP, out *certma
 
 func autoConvert_certmanager_VenafiTPP_To_v1_VenafiTPP(in *certmanager.VenafiTPP, out *v1.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := internalapismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := metav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
@@ -94,6 +94,20 @@ type CertificateRequestSpec struct {
 	// CA for signing.
 	CSRPEM []byte `json:"csr"`
 
+	// RequestSecretRef is a reference to a key of a Secret in the same
+	// namespace holding the PEM-encoded x509 certificate signing request, as
+	// an alternative to `csr`.
+	// +optional
+	RequestSecretRef *cmmeta.SecretKeySelector `json:"requestSecretRef,omitempty"`
+
+	// RequestSHA256 is the hex-encoded SHA-256 digest of the request held in
+	// the Secret referenced by `requestSecretRef`, which must be set with it.
+	// The request is only signed while the Secret holds a request with this
+	// digest, so that it cannot be changed once the CertificateRequest has
+	// been approved.
+	// +optional
+	RequestSHA256 string `json:"requestSHA256,omitempty"`
+
 	// IsCA will request to mark the certificate as valid for certificate signing
 	// when submitting to the issuer.
 	// This will automatically add the `cert sign` usage to the list of `usages`.
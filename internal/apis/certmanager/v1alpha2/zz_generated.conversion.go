@@ -28,9 +28,9 @@ import (
 	acmev1alpha2 "github.com/cert-manager/cert-manager/internal/apis/acme/v1alpha2"
 	certmanager "github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	meta "github.com/cert-manager/cert-manager/internal/apis/meta"
-	apismetav1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
+	v1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
 	metav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -42,6 +42,36 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AWSSecretsManagerSecretStore)(nil), (*certmanager.AWSSecretsManagerSecretStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSSecretsManagerSecretStore_To_certmanager_AWSSecretsManagerSecretStore(a.(*AWSSecretsManagerSecretStore), b.(*certmanager.AWSSecretsManagerSecretStore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.AWSSecretsManagerSecretStore)(nil), (*AWSSecretsManagerSecretStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_AWSSecretsManagerSecretStore_To_v1alpha2_AWSSecretsManagerSecretStore(a.(*certmanager.AWSSecretsManagerSecretStore), b.(*AWSSecretsManagerSecretStore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AllowedKeyUsage)(nil), (*certmanager.AllowedKeyUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AllowedKeyUsage_To_certmanager_AllowedKeyUsage(a.(*AllowedKeyUsage), b.(*certmanager.AllowedKeyUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.AllowedKeyUsage)(nil), (*AllowedKeyUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_AllowedKeyUsage_To_v1alpha2_AllowedKeyUsage(a.(*certmanager.AllowedKeyUsage), b.(*AllowedKeyUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CACRL)(nil), (*certmanager.CACRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CACRL_To_certmanager_CACRL(a.(*CACRL), b.(*certmanager.CACRL), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CACRL)(nil), (*CACRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CACRL_To_v1alpha2_CACRL(a.(*certmanager.CACRL), b.(*CACRL), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CAIssuer)(nil), (*certmanager.CAIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CAIssuer_To_certmanager_CAIssuer(a.(*CAIssuer), b.(*certmanager.CAIssuer), scope)
 	}); err != nil {
@@ -52,6 +82,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CAPKCS11)(nil), (*certmanager.CAPKCS11)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CAPKCS11_To_certmanager_CAPKCS11(a.(*CAPKCS11), b.(*certmanager.CAPKCS11), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAPKCS11)(nil), (*CAPKCS11)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAPKCS11_To_v1alpha2_CAPKCS11(a.(*certmanager.CAPKCS11), b.(*CAPKCS11), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CASPIFFE)(nil), (*certmanager.CASPIFFE)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CASPIFFE_To_certmanager_CASPIFFE(a.(*CASPIFFE), b.(*certmanager.CASPIFFE), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CASPIFFE)(nil), (*CASPIFFE)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CASPIFFE_To_v1alpha2_CASPIFFE(a.(*certmanager.CASPIFFE), b.(*CASPIFFE), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_Certificate_To_certmanager_Certificate(a.(*Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -72,6 +122,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateAdditionalPrivateKey)(nil), (*certmanager.CertificateAdditionalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(a.(*CertificateAdditionalPrivateKey), b.(*certmanager.CertificateAdditionalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateAdditionalPrivateKey)(nil), (*CertificateAdditionalPrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateAdditionalPrivateKey_To_v1alpha2_CertificateAdditionalPrivateKey(a.(*certmanager.CertificateAdditionalPrivateKey), b.(*CertificateAdditionalPrivateKey), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateCondition)(nil), (*certmanager.CertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateCondition_To_certmanager_CertificateCondition(a.(*CertificateCondition), b.(*certmanager.CertificateCondition), scope)
 	}); err != nil {
@@ -147,6 +207,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateSecretStore)(nil), (*certmanager.CertificateSecretStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateSecretStore_To_certmanager_CertificateSecretStore(a.(*CertificateSecretStore), b.(*certmanager.CertificateSecretStore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateSecretStore)(nil), (*CertificateSecretStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSecretStore_To_v1alpha2_CertificateSecretStore(a.(*certmanager.CertificateSecretStore), b.(*CertificateSecretStore), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateSecretTemplate)(nil), (*certmanager.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(a.(*CertificateSecretTemplate), b.(*certmanager.CertificateSecretTemplate), scope)
 	}); err != nil {
@@ -187,6 +257,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ExternalSignerIssuer)(nil), (*certmanager.ExternalSignerIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ExternalSignerIssuer_To_certmanager_ExternalSignerIssuer(a.(*ExternalSignerIssuer), b.(*certmanager.ExternalSignerIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ExternalSignerIssuer)(nil), (*ExternalSignerIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ExternalSignerIssuer_To_v1alpha2_ExternalSignerIssuer(a.(*certmanager.ExternalSignerIssuer), b.(*ExternalSignerIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HubIssuer)(nil), (*certmanager.HubIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_HubIssuer_To_certmanager_HubIssuer(a.(*HubIssuer), b.(*certmanager.HubIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.HubIssuer)(nil), (*HubIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_HubIssuer_To_v1alpha2_HubIssuer(a.(*certmanager.HubIssuer), b.(*HubIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HTTPHeader)(nil), (*certmanager.HTTPHeader)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_HTTPHeader_To_certmanager_HTTPHeader(a.(*HTTPHeader), b.(*certmanager.HTTPHeader), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.HTTPHeader)(nil), (*HTTPHeader)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_HTTPHeader_To_v1alpha2_HTTPHeader(a.(*certmanager.HTTPHeader), b.(*HTTPHeader), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Issuer)(nil), (*certmanager.Issuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_Issuer_To_certmanager_Issuer(a.(*Issuer), b.(*certmanager.Issuer), scope)
 	}); err != nil {
@@ -217,6 +317,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IssuerHTTPClient)(nil), (*certmanager.IssuerHTTPClient)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(a.(*IssuerHTTPClient), b.(*certmanager.IssuerHTTPClient), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerHTTPClient)(nil), (*IssuerHTTPClient)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerHTTPClient_To_v1alpha2_IssuerHTTPClient(a.(*certmanager.IssuerHTTPClient), b.(*IssuerHTTPClient), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IssuerList)(nil), (*certmanager.IssuerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_IssuerList_To_certmanager_IssuerList(a.(*IssuerList), b.(*certmanager.IssuerList), scope)
 	}); err != nil {
@@ -267,6 +377,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PrivateKeyRotationSchedule)(nil), (*certmanager.PrivateKeyRotationSchedule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(a.(*PrivateKeyRotationSchedule), b.(*certmanager.PrivateKeyRotationSchedule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.PrivateKeyRotationSchedule)(nil), (*PrivateKeyRotationSchedule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_PrivateKeyRotationSchedule_To_v1alpha2_PrivateKeyRotationSchedule(a.(*certmanager.PrivateKeyRotationSchedule), b.(*PrivateKeyRotationSchedule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RenewalWindow)(nil), (*certmanager.RenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RenewalWindow_To_certmanager_RenewalWindow(a.(*RenewalWindow), b.(*certmanager.RenewalWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.RenewalWindow)(nil), (*RenewalWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_RenewalWindow_To_v1alpha2_RenewalWindow(a.(*certmanager.RenewalWindow), b.(*RenewalWindow), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -277,6 +407,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ServiceAccountRef)(nil), (*certmanager.ServiceAccountRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ServiceAccountRef_To_certmanager_ServiceAccountRef(a.(*ServiceAccountRef), b.(*certmanager.ServiceAccountRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ServiceAccountRef)(nil), (*ServiceAccountRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ServiceAccountRef_To_v1alpha2_ServiceAccountRef(a.(*certmanager.ServiceAccountRef), b.(*ServiceAccountRef), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VaultAppRole)(nil), (*certmanager.VaultAppRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VaultAppRole_To_certmanager_VaultAppRole(a.(*VaultAppRole), b.(*certmanager.VaultAppRole), scope)
 	}); err != nil {
@@ -317,6 +457,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VaultSecretStore)(nil), (*certmanager.VaultSecretStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VaultSecretStore_To_certmanager_VaultSecretStore(a.(*VaultSecretStore), b.(*certmanager.VaultSecretStore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultSecretStore)(nil), (*VaultSecretStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultSecretStore_To_v1alpha2_VaultSecretStore(a.(*certmanager.VaultSecretStore), b.(*VaultSecretStore), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VenafiCloud_To_certmanager_VenafiCloud(a.(*VenafiCloud), b.(*certmanager.VenafiCloud), scope)
 	}); err != nil {
@@ -327,6 +477,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VenafiCustomField)(nil), (*certmanager.VenafiCustomField)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VenafiCustomField_To_certmanager_VenafiCustomField(a.(*VenafiCustomField), b.(*certmanager.VenafiCustomField), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiCustomField)(nil), (*VenafiCustomField)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiCustomField_To_v1alpha2_VenafiCustomField(a.(*certmanager.VenafiCustomField), b.(*VenafiCustomField), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VenafiIssuer)(nil), (*certmanager.VenafiIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VenafiIssuer_To_certmanager_VenafiIssuer(a.(*VenafiIssuer), b.(*certmanager.VenafiIssuer), scope)
 	}); err != nil {
@@ -385,10 +545,125 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha2_AWSSecretsManagerSecretStore_To_certmanager_AWSSecretsManagerSecretStore(in *AWSSecretsManagerSecretStore, out *certmanager.AWSSecretsManagerSecretStore, s conversion.Scope) error {
+	out.Region = in.Region
+	out.SecretName = in.SecretName
+	if in.AccessKeyIDSecretRef != nil {
+		in, out := &in.AccessKeyIDSecretRef, &out.AccessKeyIDSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AccessKeyIDSecretRef = nil
+	}
+	if in.SecretAccessKeySecretRef != nil {
+		in, out := &in.SecretAccessKeySecretRef, &out.SecretAccessKeySecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecretAccessKeySecretRef = nil
+	}
+	out.Role = in.Role
+	return nil
+}
+
+// Convert_v1alpha2_AWSSecretsManagerSecretStore_To_certmanager_AWSSecretsManagerSecretStore is an autogenerated conversion function.
+func Convert_v1alpha2_AWSSecretsManagerSecretStore_To_certmanager_AWSSecretsManagerSecretStore(in *AWSSecretsManagerSecretStore, out *certmanager.AWSSecretsManagerSecretStore, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSSecretsManagerSecretStore_To_certmanager_AWSSecretsManagerSecretStore(in, out, s)
+}
+
+func autoConvert_certmanager_AWSSecretsManagerSecretStore_To_v1alpha2_AWSSecretsManagerSecretStore(in *certmanager.AWSSecretsManagerSecretStore, out *AWSSecretsManagerSecretStore, s conversion.Scope) error {
+	out.Region = in.Region
+	out.SecretName = in.SecretName
+	if in.AccessKeyIDSecretRef != nil {
+		in, out := &in.AccessKeyIDSecretRef, &out.AccessKeyIDSecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AccessKeyIDSecretRef = nil
+	}
+	if in.SecretAccessKeySecretRef != nil {
+		in, out := &in.SecretAccessKeySecretRef, &out.SecretAccessKeySecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecretAccessKeySecretRef = nil
+	}
+	out.Role = in.Role
+	return nil
+}
+
+// Convert_certmanager_AWSSecretsManagerSecretStore_To_v1alpha2_AWSSecretsManagerSecretStore is an autogenerated conversion function.
+func Convert_certmanager_AWSSecretsManagerSecretStore_To_v1alpha2_AWSSecretsManagerSecretStore(in *certmanager.AWSSecretsManagerSecretStore, out *AWSSecretsManagerSecretStore, s conversion.Scope) error {
+	return autoConvert_certmanager_AWSSecretsManagerSecretStore_To_v1alpha2_AWSSecretsManagerSecretStore(in, out, s)
+}
+
+func autoConvert_v1alpha2_AllowedKeyUsage_To_certmanager_AllowedKeyUsage(in *AllowedKeyUsage, out *certmanager.AllowedKeyUsage, s conversion.Scope) error {
+	out.Usage = certmanager.KeyUsage(in.Usage)
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_v1alpha2_AllowedKeyUsage_To_certmanager_AllowedKeyUsage is an autogenerated conversion function.
+func Convert_v1alpha2_AllowedKeyUsage_To_certmanager_AllowedKeyUsage(in *AllowedKeyUsage, out *certmanager.AllowedKeyUsage, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AllowedKeyUsage_To_certmanager_AllowedKeyUsage(in, out, s)
+}
+
+func autoConvert_certmanager_AllowedKeyUsage_To_v1alpha2_AllowedKeyUsage(in *certmanager.AllowedKeyUsage, out *AllowedKeyUsage, s conversion.Scope) error {
+	out.Usage = KeyUsage(in.Usage)
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_certmanager_AllowedKeyUsage_To_v1alpha2_AllowedKeyUsage is an autogenerated conversion function.
+func Convert_certmanager_AllowedKeyUsage_To_v1alpha2_AllowedKeyUsage(in *certmanager.AllowedKeyUsage, out *AllowedKeyUsage, s conversion.Scope) error {
+	return autoConvert_certmanager_AllowedKeyUsage_To_v1alpha2_AllowedKeyUsage(in, out, s)
+}
+
+func autoConvert_v1alpha2_CACRL_To_certmanager_CACRL(in *CACRL, out *certmanager.CACRL, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	out.Validity = (*apismetav1.Duration)(unsafe.Pointer(in.Validity))
+	return nil
+}
+
+// Convert_v1alpha2_CACRL_To_certmanager_CACRL is an autogenerated conversion function.
+func Convert_v1alpha2_CACRL_To_certmanager_CACRL(in *CACRL, out *certmanager.CACRL, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CACRL_To_certmanager_CACRL(in, out, s)
+}
+
+func autoConvert_certmanager_CACRL_To_v1alpha2_CACRL(in *certmanager.CACRL, out *CACRL, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	out.Validity = (*apismetav1.Duration)(unsafe.Pointer(in.Validity))
+	return nil
+}
+
+// Convert_certmanager_CACRL_To_v1alpha2_CACRL is an autogenerated conversion function.
+func Convert_certmanager_CACRL_To_v1alpha2_CACRL(in *certmanager.CACRL, out *CACRL, s conversion.Scope) error {
+	return autoConvert_certmanager_CACRL_To_v1alpha2_CACRL(in, out, s)
+}
+
 func autoConvert_v1alpha2_CAIssuer_To_certmanager_CAIssuer(in *CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.CRL = (*certmanager.CACRL)(unsafe.Pointer(in.CRL))
+	if in.PKCS11 != nil {
+		in, out := &in.PKCS11, &out.PKCS11
+		*out = new(certmanager.CAPKCS11)
+		if err := Convert_v1alpha2_CAPKCS11_To_certmanager_CAPKCS11(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS11 = nil
+	}
+	out.SPIFFE = (*certmanager.CASPIFFE)(unsafe.Pointer(in.SPIFFE))
 	return nil
 }
 
@@ -401,6 +676,17 @@ func autoConvert_certmanager_CAIssuer_To_v1alpha2_CAIssuer(in *certmanager.CAIss
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.CRL = (*CACRL)(unsafe.Pointer(in.CRL))
+	if in.PKCS11 != nil {
+		in, out := &in.PKCS11, &out.PKCS11
+		*out = new(CAPKCS11)
+		if err := Convert_certmanager_CAPKCS11_To_v1alpha2_CAPKCS11(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS11 = nil
+	}
+	out.SPIFFE = (*CASPIFFE)(unsafe.Pointer(in.SPIFFE))
 	return nil
 }
 
@@ -409,6 +695,58 @@ func Convert_certmanager_CAIssuer_To_v1alpha2_CAIssuer(in *certmanager.CAIssuer,
 	return autoConvert_certmanager_CAIssuer_To_v1alpha2_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha2_CAPKCS11_To_certmanager_CAPKCS11(in *CAPKCS11, out *certmanager.CAPKCS11, s conversion.Scope) error {
+	out.Module = in.Module
+	out.TokenLabel = in.TokenLabel
+	out.KeyLabel = in.KeyLabel
+	out.KeyID = in.KeyID
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PINSecretRef, &out.PINSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_CAPKCS11_To_certmanager_CAPKCS11 is an autogenerated conversion function.
+func Convert_v1alpha2_CAPKCS11_To_certmanager_CAPKCS11(in *CAPKCS11, out *certmanager.CAPKCS11, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CAPKCS11_To_certmanager_CAPKCS11(in, out, s)
+}
+
+func autoConvert_certmanager_CAPKCS11_To_v1alpha2_CAPKCS11(in *certmanager.CAPKCS11, out *CAPKCS11, s conversion.Scope) error {
+	out.Module = in.Module
+	out.TokenLabel = in.TokenLabel
+	out.KeyLabel = in.KeyLabel
+	out.KeyID = in.KeyID
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PINSecretRef, &out.PINSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CAPKCS11_To_v1alpha2_CAPKCS11 is an autogenerated conversion function.
+func Convert_certmanager_CAPKCS11_To_v1alpha2_CAPKCS11(in *certmanager.CAPKCS11, out *CAPKCS11, s conversion.Scope) error {
+	return autoConvert_certmanager_CAPKCS11_To_v1alpha2_CAPKCS11(in, out, s)
+}
+
+func autoConvert_v1alpha2_CASPIFFE_To_certmanager_CASPIFFE(in *CASPIFFE, out *certmanager.CASPIFFE, s conversion.Scope) error {
+	out.TrustDomain = in.TrustDomain
+	return nil
+}
+
+// Convert_v1alpha2_CASPIFFE_To_certmanager_CASPIFFE is an autogenerated conversion function.
+func Convert_v1alpha2_CASPIFFE_To_certmanager_CASPIFFE(in *CASPIFFE, out *certmanager.CASPIFFE, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CASPIFFE_To_certmanager_CASPIFFE(in, out, s)
+}
+
+func autoConvert_certmanager_CASPIFFE_To_v1alpha2_CASPIFFE(in *certmanager.CASPIFFE, out *CASPIFFE, s conversion.Scope) error {
+	out.TrustDomain = in.TrustDomain
+	return nil
+}
+
+// Convert_certmanager_CASPIFFE_To_v1alpha2_CASPIFFE is an autogenerated conversion function.
+func Convert_certmanager_CASPIFFE_To_v1alpha2_CASPIFFE(in *certmanager.CASPIFFE, out *CASPIFFE, s conversion.Scope) error {
+	return autoConvert_certmanager_CASPIFFE_To_v1alpha2_CASPIFFE(in, out, s)
+}
+
 func autoConvert_v1alpha2_Certificate_To_certmanager_Certificate(in *Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -461,10 +799,32 @@ func Convert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha2_Certifica
 	return autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha2_CertificateAdditionalOutputFormat(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in *CertificateAdditionalPrivateKey, out *certmanager.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_v1alpha2_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in *CertificateAdditionalPrivateKey, out *certmanager.CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateAdditionalPrivateKey_To_certmanager_CertificateAdditionalPrivateKey(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateAdditionalPrivateKey_To_v1alpha2_CertificateAdditionalPrivateKey(in *certmanager.CertificateAdditionalPrivateKey, out *CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	out.Algorithm = string(in.Algorithm)
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_certmanager_CertificateAdditionalPrivateKey_To_v1alpha2_CertificateAdditionalPrivateKey is an autogenerated conversion function.
+func Convert_certmanager_CertificateAdditionalPrivateKey_To_v1alpha2_CertificateAdditionalPrivateKey(in *certmanager.CertificateAdditionalPrivateKey, out *CertificateAdditionalPrivateKey, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateAdditionalPrivateKey_To_v1alpha2_CertificateAdditionalPrivateKey(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateCondition_To_certmanager_CertificateCondition(in *CertificateCondition, out *certmanager.CertificateCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -479,7 +839,7 @@ func Convert_v1alpha2_CertificateCondition_To_certmanager_CertificateCondition(i
 func autoConvert_certmanager_CertificateCondition_To_v1alpha2_CertificateCondition(in *certmanager.CertificateCondition, out *CertificateCondition, s conversion.Scope) error {
 	out.Type = CertificateConditionType(in.Type)
 	out.Status = metav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -589,6 +949,7 @@ func Convert_certmanager_CertificateList_To_v1alpha2_CertificateList(in *certman
 
 func autoConvert_v1alpha2_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.RotationSchedule = (*certmanager.PrivateKeyRotationSchedule)(unsafe.Pointer(in.RotationSchedule))
 	return nil
 }
 
@@ -599,6 +960,7 @@ func Convert_v1alpha2_CertificatePrivateKey_To_certmanager_CertificatePrivateKey
 
 func autoConvert_certmanager_CertificatePrivateKey_To_v1alpha2_CertificatePrivateKey(in *certmanager.CertificatePrivateKey, out *CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.RotationSchedule = (*PrivateKeyRotationSchedule)(unsafe.Pointer(in.RotationSchedule))
 	// WARNING: in.Encoding requires manual conversion: does not exist in peer-type
 	// WARNING: in.Algorithm requires manual conversion: does not exist in peer-type
 	// WARNING: in.Size requires manual conversion: does not exist in peer-type
@@ -640,7 +1002,7 @@ func Convert_certmanager_CertificateRequest_To_v1alpha2_CertificateRequest(in *c
 func autoConvert_v1alpha2_CertificateRequestCondition_To_certmanager_CertificateRequestCondition(in *CertificateRequestCondition, out *certmanager.CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateRequestConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	return nil
@@ -654,7 +1016,7 @@ func Convert_v1alpha2_CertificateRequestCondition_To_certmanager_CertificateRequ
 func autoConvert_certmanager_CertificateRequestCondition_To_v1alpha2_CertificateRequestCondition(in *certmanager.CertificateRequestCondition, out *CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = CertificateRequestConditionType(in.Type)
 	out.Status = metav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	return nil
@@ -708,11 +1070,21 @@ func Convert_certmanager_CertificateRequestList_To_v1alpha2_CertificateRequestLi
 }
 
 func autoConvert_v1alpha2_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(in *CertificateRequestSpec, out *certmanager.CertificateRequestSpec, s conversion.Scope) error {
-	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	if err := v1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	// WARNING: in.CSRPEM requires manual conversion: does not exist in peer-type
+	if in.RequestSecretRef != nil {
+		in, out := &in.RequestSecretRef, &out.RequestSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RequestSecretRef = nil
+	}
+	out.RequestSHA256 = in.RequestSHA256
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
 	out.Username = in.Username
@@ -723,11 +1095,21 @@ func autoConvert_v1alpha2_CertificateRequestSpec_To_certmanager_CertificateReque
 }
 
 func autoConvert_certmanager_CertificateRequestSpec_To_v1alpha2_CertificateRequestSpec(in *certmanager.CertificateRequestSpec, out *CertificateRequestSpec, s conversion.Scope) error {
-	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	if err := v1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	// WARNING: in.Request requires manual conversion: does not exist in peer-type
+	if in.RequestSecretRef != nil {
+		in, out := &in.RequestSecretRef, &out.RequestSecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RequestSecretRef = nil
+	}
+	out.RequestSHA256 = in.RequestSHA256
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]KeyUsage)(unsafe.Pointer(&in.Usages))
 	out.Username = in.Username
@@ -741,7 +1123,7 @@ func autoConvert_v1alpha2_CertificateRequestStatus_To_certmanager_CertificateReq
 	out.Conditions = *(*[]certmanager.CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
-	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -754,7 +1136,7 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1alpha2_CertificateReq
 	out.Conditions = *(*[]CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
-	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -763,6 +1145,60 @@ func Convert_certmanager_CertificateRequestStatus_To_v1alpha2_CertificateRequest
 	return autoConvert_certmanager_CertificateRequestStatus_To_v1alpha2_CertificateRequestStatus(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateSecretStore_To_certmanager_CertificateSecretStore(in *CertificateSecretStore, out *certmanager.CertificateSecretStore, s conversion.Scope) error {
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(certmanager.VaultSecretStore)
+		if err := Convert_v1alpha2_VaultSecretStore_To_certmanager_VaultSecretStore(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Vault = nil
+	}
+	if in.AWSSecretsManager != nil {
+		in, out := &in.AWSSecretsManager, &out.AWSSecretsManager
+		*out = new(certmanager.AWSSecretsManagerSecretStore)
+		if err := Convert_v1alpha2_AWSSecretsManagerSecretStore_To_certmanager_AWSSecretsManagerSecretStore(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AWSSecretsManager = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_CertificateSecretStore_To_certmanager_CertificateSecretStore is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateSecretStore_To_certmanager_CertificateSecretStore(in *CertificateSecretStore, out *certmanager.CertificateSecretStore, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateSecretStore_To_certmanager_CertificateSecretStore(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateSecretStore_To_v1alpha2_CertificateSecretStore(in *certmanager.CertificateSecretStore, out *CertificateSecretStore, s conversion.Scope) error {
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultSecretStore)
+		if err := Convert_certmanager_VaultSecretStore_To_v1alpha2_VaultSecretStore(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Vault = nil
+	}
+	if in.AWSSecretsManager != nil {
+		in, out := &in.AWSSecretsManager, &out.AWSSecretsManager
+		*out = new(AWSSecretsManagerSecretStore)
+		if err := Convert_certmanager_AWSSecretsManagerSecretStore_To_v1alpha2_AWSSecretsManagerSecretStore(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AWSSecretsManager = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CertificateSecretStore_To_v1alpha2_CertificateSecretStore is an autogenerated conversion function.
+func Convert_certmanager_CertificateSecretStore_To_v1alpha2_CertificateSecretStore(in *certmanager.CertificateSecretStore, out *CertificateSecretStore, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateSecretStore_To_v1alpha2_CertificateSecretStore(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
 	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
@@ -798,8 +1234,8 @@ func autoConvert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
 	// WARNING: in.Organization requires manual conversion: does not exist in peer-type
-	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*apismetav1.Duration)(unsafe.Pointer(in.RenewBefore))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
@@ -815,11 +1251,12 @@ func autoConvert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	} else {
 		out.Keystores = nil
 	}
-	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := v1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.UsagePreset = certmanager.UsagePreset(in.UsagePreset)
 	// WARNING: in.KeySize requires manual conversion: does not exist in peer-type
 	// WARNING: in.KeyAlgorithm requires manual conversion: does not exist in peer-type
 	// WARNING: in.KeyEncoding requires manual conversion: does not exist in peer-type
@@ -834,7 +1271,23 @@ func autoConvert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	}
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
+	out.PropagationQuorum = (*int32)(unsafe.Pointer(in.PropagationQuorum))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.SecretStores != nil {
+		in, out := &in.SecretStores, &out.SecretStores
+		*out = make([]certmanager.CertificateSecretStore, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_CertificateSecretStore_To_certmanager_CertificateSecretStore(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SecretStores = nil
+	}
+	out.ProfileRef = (*meta.LocalObjectReference)(unsafe.Pointer(in.ProfileRef))
+	out.RenewalWindow = (*certmanager.RenewalWindow)(unsafe.Pointer(in.RenewalWindow))
+	out.AdditionalPrivateKeys = *(*[]certmanager.CertificateAdditionalPrivateKey)(unsafe.Pointer(&in.AdditionalPrivateKeys))
+	out.SecretFormatProfile = certmanager.SecretFormatProfile(in.SecretFormatProfile)
 	return nil
 }
 
@@ -850,8 +1303,8 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha2_CertificateSpec(in *cer
 	}
 	out.LiteralSubject = in.LiteralSubject
 	out.CommonName = in.CommonName
-	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*apismetav1.Duration)(unsafe.Pointer(in.RenewBefore))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
@@ -867,11 +1320,12 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha2_CertificateSpec(in *cer
 	} else {
 		out.Keystores = nil
 	}
-	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := v1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.UsagePreset = UsagePreset(in.UsagePreset)
 	if in.PrivateKey != nil {
 		in, out := &in.PrivateKey, &out.PrivateKey
 		*out = new(CertificatePrivateKey)
@@ -883,19 +1337,43 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha2_CertificateSpec(in *cer
 	}
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
+	out.PropagationQuorum = (*int32)(unsafe.Pointer(in.PropagationQuorum))
 	out.AdditionalOutputFormats = *(*[]CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.SecretStores != nil {
+		in, out := &in.SecretStores, &out.SecretStores
+		*out = make([]CertificateSecretStore, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_CertificateSecretStore_To_v1alpha2_CertificateSecretStore(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SecretStores = nil
+	}
+	out.ProfileRef = (*metav1.LocalObjectReference)(unsafe.Pointer(in.ProfileRef))
+	out.RenewalWindow = (*RenewalWindow)(unsafe.Pointer(in.RenewalWindow))
+	out.AdditionalPrivateKeys = *(*[]CertificateAdditionalPrivateKey)(unsafe.Pointer(&in.AdditionalPrivateKeys))
+	out.SecretFormatProfile = SecretFormatProfile(in.SecretFormatProfile)
 	return nil
 }
 
 func autoConvert_v1alpha2_CertificateStatus_To_certmanager_CertificateStatus(in *CertificateStatus, out *certmanager.CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.CertificateCondition)(unsafe.Pointer(&in.Conditions))
-	out.LastFailureTime = (*v1.Time)(unsafe.Pointer(in.LastFailureTime))
-	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
-	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
-	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.LastFailureTime = (*apismetav1.Time)(unsafe.Pointer(in.LastFailureTime))
+	out.NotBefore = (*apismetav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*apismetav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.RenewalTime = (*apismetav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.SerialNumber = in.SerialNumber
+	out.IssuerDN = in.IssuerDN
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.PrivateKeyIssuedTime = (*apismetav1.Time)(unsafe.Pointer(in.PrivateKeyIssuedTime))
+	out.PrivateKeyIssuances = (*int)(unsafe.Pointer(in.PrivateKeyIssuances))
 	return nil
 }
 
@@ -906,13 +1384,21 @@ func Convert_v1alpha2_CertificateStatus_To_certmanager_CertificateStatus(in *Cer
 
 func autoConvert_certmanager_CertificateStatus_To_v1alpha2_CertificateStatus(in *certmanager.CertificateStatus, out *CertificateStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]CertificateCondition)(unsafe.Pointer(&in.Conditions))
-	out.LastFailureTime = (*v1.Time)(unsafe.Pointer(in.LastFailureTime))
-	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
-	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
-	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.LastFailureTime = (*apismetav1.Time)(unsafe.Pointer(in.LastFailureTime))
+	out.NotBefore = (*apismetav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*apismetav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.RenewalTime = (*apismetav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.SerialNumber = in.SerialNumber
+	out.IssuerDN = in.IssuerDN
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.PrivateKeyIssuedTime = (*apismetav1.Time)(unsafe.Pointer(in.PrivateKeyIssuedTime))
+	out.PrivateKeyIssuances = (*int)(unsafe.Pointer(in.PrivateKeyIssuances))
 	return nil
 }
 
@@ -995,6 +1481,92 @@ func Convert_certmanager_ClusterIssuerList_To_v1alpha2_ClusterIssuerList(in *cer
 	return autoConvert_certmanager_ClusterIssuerList_To_v1alpha2_ClusterIssuerList(in, out, s)
 }
 
+func autoConvert_v1alpha2_ExternalSignerIssuer_To_certmanager_ExternalSignerIssuer(in *ExternalSignerIssuer, out *certmanager.ExternalSignerIssuer, s conversion.Scope) error {
+	out.Address = in.Address
+	out.ServerName = in.ServerName
+	if err := v1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.TLSSecretRef, &out.TLSSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_ExternalSignerIssuer_To_certmanager_ExternalSignerIssuer is an autogenerated conversion function.
+func Convert_v1alpha2_ExternalSignerIssuer_To_certmanager_ExternalSignerIssuer(in *ExternalSignerIssuer, out *certmanager.ExternalSignerIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ExternalSignerIssuer_To_certmanager_ExternalSignerIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_ExternalSignerIssuer_To_v1alpha2_ExternalSignerIssuer(in *certmanager.ExternalSignerIssuer, out *ExternalSignerIssuer, s conversion.Scope) error {
+	out.Address = in.Address
+	out.ServerName = in.ServerName
+	if err := v1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.TLSSecretRef, &out.TLSSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_ExternalSignerIssuer_To_v1alpha2_ExternalSignerIssuer is an autogenerated conversion function.
+func Convert_certmanager_ExternalSignerIssuer_To_v1alpha2_ExternalSignerIssuer(in *certmanager.ExternalSignerIssuer, out *ExternalSignerIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_ExternalSignerIssuer_To_v1alpha2_ExternalSignerIssuer(in, out, s)
+}
+
+func autoConvert_v1alpha2_HubIssuer_To_certmanager_HubIssuer(in *HubIssuer, out *certmanager.HubIssuer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.TokenSecretRef, &out.TokenSecretRef, s); err != nil {
+		return err
+	}
+	out.Namespace = in.Namespace
+	if err := v1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_HubIssuer_To_certmanager_HubIssuer is an autogenerated conversion function.
+func Convert_v1alpha2_HubIssuer_To_certmanager_HubIssuer(in *HubIssuer, out *certmanager.HubIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha2_HubIssuer_To_certmanager_HubIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_HubIssuer_To_v1alpha2_HubIssuer(in *certmanager.HubIssuer, out *HubIssuer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.TokenSecretRef, &out.TokenSecretRef, s); err != nil {
+		return err
+	}
+	out.Namespace = in.Namespace
+	if err := v1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_HubIssuer_To_v1alpha2_HubIssuer is an autogenerated conversion function.
+func Convert_certmanager_HubIssuer_To_v1alpha2_HubIssuer(in *certmanager.HubIssuer, out *HubIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_HubIssuer_To_v1alpha2_HubIssuer(in, out, s)
+}
+
+func autoConvert_v1alpha2_HTTPHeader_To_certmanager_HTTPHeader(in *HTTPHeader, out *certmanager.HTTPHeader, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_v1alpha2_HTTPHeader_To_certmanager_HTTPHeader is an autogenerated conversion function.
+func Convert_v1alpha2_HTTPHeader_To_certmanager_HTTPHeader(in *HTTPHeader, out *certmanager.HTTPHeader, s conversion.Scope) error {
+	return autoConvert_v1alpha2_HTTPHeader_To_certmanager_HTTPHeader(in, out, s)
+}
+
+func autoConvert_certmanager_HTTPHeader_To_v1alpha2_HTTPHeader(in *certmanager.HTTPHeader, out *HTTPHeader, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_certmanager_HTTPHeader_To_v1alpha2_HTTPHeader is an autogenerated conversion function.
+func Convert_certmanager_HTTPHeader_To_v1alpha2_HTTPHeader(in *certmanager.HTTPHeader, out *HTTPHeader, s conversion.Scope) error {
+	return autoConvert_certmanager_HTTPHeader_To_v1alpha2_HTTPHeader(in, out, s)
+}
+
 func autoConvert_v1alpha2_Issuer_To_certmanager_Issuer(in *Issuer, out *certmanager.Issuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1030,7 +1602,7 @@ func Convert_certmanager_Issuer_To_v1alpha2_Issuer(in *certmanager.Issuer, out *
 func autoConvert_v1alpha2_IssuerCondition_To_certmanager_IssuerCondition(in *IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
 	out.Type = certmanager.IssuerConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -1045,7 +1617,7 @@ func Convert_v1alpha2_IssuerCondition_To_certmanager_IssuerCondition(in *IssuerC
 func autoConvert_certmanager_IssuerCondition_To_v1alpha2_IssuerCondition(in *certmanager.IssuerCondition, out *IssuerCondition, s conversion.Scope) error {
 	out.Type = IssuerConditionType(in.Type)
 	out.Status = metav1.ConditionStatus(in.Status)
-	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.LastTransitionTime = (*apismetav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.ObservedGeneration = in.ObservedGeneration
@@ -1067,7 +1639,15 @@ func autoConvert_v1alpha2_IssuerConfig_To_certmanager_IssuerConfig(in *IssuerCon
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(certmanager.CAIssuer)
+		if err := Convert_v1alpha2_CAIssuer_To_certmanager_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(certmanager.VaultIssuer)
@@ -1087,6 +1667,24 @@ func autoConvert_v1alpha2_IssuerConfig_To_certmanager_IssuerConfig(in *IssuerCon
 	} else {
 		out.Venafi = nil
 	}
+	if in.Hub != nil {
+		in, out := &in.Hub, &out.Hub
+		*out = new(certmanager.HubIssuer)
+		if err := Convert_v1alpha2_HubIssuer_To_certmanager_HubIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hub = nil
+	}
+	if in.ExternalSigner != nil {
+		in, out := &in.ExternalSigner, &out.ExternalSigner
+		*out = new(certmanager.ExternalSignerIssuer)
+		if err := Convert_v1alpha2_ExternalSignerIssuer_To_certmanager_ExternalSignerIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExternalSigner = nil
+	}
 	return nil
 }
 
@@ -1105,7 +1703,15 @@ func autoConvert_certmanager_IssuerConfig_To_v1alpha2_IssuerConfig(in *certmanag
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CAIssuer)
+		if err := Convert_certmanager_CAIssuer_To_v1alpha2_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(VaultIssuer)
@@ -1125,6 +1731,24 @@ func autoConvert_certmanager_IssuerConfig_To_v1alpha2_IssuerConfig(in *certmanag
 	} else {
 		out.Venafi = nil
 	}
+	if in.Hub != nil {
+		in, out := &in.Hub, &out.Hub
+		*out = new(HubIssuer)
+		if err := Convert_certmanager_HubIssuer_To_v1alpha2_HubIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hub = nil
+	}
+	if in.ExternalSigner != nil {
+		in, out := &in.ExternalSigner, &out.ExternalSigner
+		*out = new(ExternalSignerIssuer)
+		if err := Convert_certmanager_ExternalSignerIssuer_To_v1alpha2_ExternalSignerIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExternalSigner = nil
+	}
 	return nil
 }
 
@@ -1133,6 +1757,28 @@ func Convert_certmanager_IssuerConfig_To_v1alpha2_IssuerConfig(in *certmanager.I
 	return autoConvert_certmanager_IssuerConfig_To_v1alpha2_IssuerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(in *IssuerHTTPClient, out *certmanager.IssuerHTTPClient, s conversion.Scope) error {
+	out.UserAgentSuffix = in.UserAgentSuffix
+	out.Headers = *(*[]certmanager.HTTPHeader)(unsafe.Pointer(&in.Headers))
+	return nil
+}
+
+// Convert_v1alpha2_IssuerHTTPClient_To_certmanager_IssuerHTTPClient is an autogenerated conversion function.
+func Convert_v1alpha2_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(in *IssuerHTTPClient, out *certmanager.IssuerHTTPClient, s conversion.Scope) error {
+	return autoConvert_v1alpha2_IssuerHTTPClient_To_certmanager_IssuerHTTPClient(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerHTTPClient_To_v1alpha2_IssuerHTTPClient(in *certmanager.IssuerHTTPClient, out *IssuerHTTPClient, s conversion.Scope) error {
+	out.UserAgentSuffix = in.UserAgentSuffix
+	out.Headers = *(*[]HTTPHeader)(unsafe.Pointer(&in.Headers))
+	return nil
+}
+
+// Convert_certmanager_IssuerHTTPClient_To_v1alpha2_IssuerHTTPClient is an autogenerated conversion function.
+func Convert_certmanager_IssuerHTTPClient_To_v1alpha2_IssuerHTTPClient(in *certmanager.IssuerHTTPClient, out *IssuerHTTPClient, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerHTTPClient_To_v1alpha2_IssuerHTTPClient(in, out, s)
+}
+
 func autoConvert_v1alpha2_IssuerList_To_certmanager_IssuerList(in *IssuerList, out *certmanager.IssuerList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -1179,6 +1825,10 @@ func autoConvert_v1alpha2_IssuerSpec_To_certmanager_IssuerSpec(in *IssuerSpec, o
 	if err := Convert_v1alpha2_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.AllowedURISchemes = *(*[]string)(unsafe.Pointer(&in.AllowedURISchemes))
+	out.AllowedUsages = *(*[]certmanager.AllowedKeyUsage)(unsafe.Pointer(&in.AllowedUsages))
+	out.RenewalWindow = (*certmanager.RenewalWindow)(unsafe.Pointer(in.RenewalWindow))
+	out.HTTPClient = (*certmanager.IssuerHTTPClient)(unsafe.Pointer(in.HTTPClient))
 	return nil
 }
 
@@ -1191,6 +1841,10 @@ func autoConvert_certmanager_IssuerSpec_To_v1alpha2_IssuerSpec(in *certmanager.I
 	if err := Convert_certmanager_IssuerConfig_To_v1alpha2_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.AllowedURISchemes = *(*[]string)(unsafe.Pointer(&in.AllowedURISchemes))
+	out.AllowedUsages = *(*[]AllowedKeyUsage)(unsafe.Pointer(&in.AllowedUsages))
+	out.RenewalWindow = (*RenewalWindow)(unsafe.Pointer(in.RenewalWindow))
+	out.HTTPClient = (*IssuerHTTPClient)(unsafe.Pointer(in.HTTPClient))
 	return nil
 }
 
@@ -1223,7 +1877,7 @@ func Convert_certmanager_IssuerStatus_To_v1alpha2_IssuerStatus(in *certmanager.I
 
 func autoConvert_v1alpha2_JKSKeystore_To_certmanager_JKSKeystore(in *JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1236,7 +1890,7 @@ func Convert_v1alpha2_JKSKeystore_To_certmanager_JKSKeystore(in *JKSKeystore, ou
 
 func autoConvert_certmanager_JKSKeystore_To_v1alpha2_JKSKeystore(in *certmanager.JKSKeystore, out *JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1249,7 +1903,7 @@ func Convert_certmanager_JKSKeystore_To_v1alpha2_JKSKeystore(in *certmanager.JKS
 
 func autoConvert_v1alpha2_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1262,7 +1916,7 @@ func Convert_v1alpha2_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *PKCS12Key
 
 func autoConvert_certmanager_PKCS12Keystore_To_v1alpha2_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1273,6 +1927,54 @@ func Convert_certmanager_PKCS12Keystore_To_v1alpha2_PKCS12Keystore(in *certmanag
 	return autoConvert_certmanager_PKCS12Keystore_To_v1alpha2_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1alpha2_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in *PrivateKeyRotationSchedule, out *certmanager.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	out.Renewals = (*int32)(unsafe.Pointer(in.Renewals))
+	out.MaxAge = (*apismetav1.Duration)(unsafe.Pointer(in.MaxAge))
+	return nil
+}
+
+// Convert_v1alpha2_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule is an autogenerated conversion function.
+func Convert_v1alpha2_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in *PrivateKeyRotationSchedule, out *certmanager.PrivateKeyRotationSchedule, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PrivateKeyRotationSchedule_To_certmanager_PrivateKeyRotationSchedule(in, out, s)
+}
+
+func autoConvert_certmanager_PrivateKeyRotationSchedule_To_v1alpha2_PrivateKeyRotationSchedule(in *certmanager.PrivateKeyRotationSchedule, out *PrivateKeyRotationSchedule, s conversion.Scope) error {
+	out.Renewals = (*int32)(unsafe.Pointer(in.Renewals))
+	out.MaxAge = (*apismetav1.Duration)(unsafe.Pointer(in.MaxAge))
+	return nil
+}
+
+// Convert_certmanager_PrivateKeyRotationSchedule_To_v1alpha2_PrivateKeyRotationSchedule is an autogenerated conversion function.
+func Convert_certmanager_PrivateKeyRotationSchedule_To_v1alpha2_PrivateKeyRotationSchedule(in *certmanager.PrivateKeyRotationSchedule, out *PrivateKeyRotationSchedule, s conversion.Scope) error {
+	return autoConvert_certmanager_PrivateKeyRotationSchedule_To_v1alpha2_PrivateKeyRotationSchedule(in, out, s)
+}
+
+func autoConvert_v1alpha2_RenewalWindow_To_certmanager_RenewalWindow(in *RenewalWindow, out *certmanager.RenewalWindow, s conversion.Scope) error {
+	out.Days = *(*[]string)(unsafe.Pointer(&in.Days))
+	out.Hours = in.Hours
+	out.TimeZone = in.TimeZone
+	out.ExpiryOverride = (*apismetav1.Duration)(unsafe.Pointer(in.ExpiryOverride))
+	return nil
+}
+
+// Convert_v1alpha2_RenewalWindow_To_certmanager_RenewalWindow is an autogenerated conversion function.
+func Convert_v1alpha2_RenewalWindow_To_certmanager_RenewalWindow(in *RenewalWindow, out *certmanager.RenewalWindow, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RenewalWindow_To_certmanager_RenewalWindow(in, out, s)
+}
+
+func autoConvert_certmanager_RenewalWindow_To_v1alpha2_RenewalWindow(in *certmanager.RenewalWindow, out *RenewalWindow, s conversion.Scope) error {
+	out.Days = *(*[]string)(unsafe.Pointer(&in.Days))
+	out.Hours = in.Hours
+	out.TimeZone = in.TimeZone
+	out.ExpiryOverride = (*apismetav1.Duration)(unsafe.Pointer(in.ExpiryOverride))
+	return nil
+}
+
+// Convert_certmanager_RenewalWindow_To_v1alpha2_RenewalWindow is an autogenerated conversion function.
+func Convert_certmanager_RenewalWindow_To_v1alpha2_RenewalWindow(in *certmanager.RenewalWindow, out *RenewalWindow, s conversion.Scope) error {
+	return autoConvert_certmanager_RenewalWindow_To_v1alpha2_RenewalWindow(in, out, s)
+}
+
 func autoConvert_v1alpha2_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	return nil
@@ -1293,10 +1995,32 @@ func Convert_certmanager_SelfSignedIssuer_To_v1alpha2_SelfSignedIssuer(in *certm
 	return autoConvert_certmanager_SelfSignedIssuer_To_v1alpha2_SelfSignedIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha2_ServiceAccountRef_To_certmanager_ServiceAccountRef(in *ServiceAccountRef, out *certmanager.ServiceAccountRef, s conversion.Scope) error {
+	out.Name = in.Name
+	out.TokenAudiences = *(*[]string)(unsafe.Pointer(&in.TokenAudiences))
+	return nil
+}
+
+// Convert_v1alpha2_ServiceAccountRef_To_certmanager_ServiceAccountRef is an autogenerated conversion function.
+func Convert_v1alpha2_ServiceAccountRef_To_certmanager_ServiceAccountRef(in *ServiceAccountRef, out *certmanager.ServiceAccountRef, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ServiceAccountRef_To_certmanager_ServiceAccountRef(in, out, s)
+}
+
+func autoConvert_certmanager_ServiceAccountRef_To_v1alpha2_ServiceAccountRef(in *certmanager.ServiceAccountRef, out *ServiceAccountRef, s conversion.Scope) error {
+	out.Name = in.Name
+	out.TokenAudiences = *(*[]string)(unsafe.Pointer(&in.TokenAudiences))
+	return nil
+}
+
+// Convert_certmanager_ServiceAccountRef_To_v1alpha2_ServiceAccountRef is an autogenerated conversion function.
+func Convert_certmanager_ServiceAccountRef_To_v1alpha2_ServiceAccountRef(in *certmanager.ServiceAccountRef, out *ServiceAccountRef, s conversion.Scope) error {
+	return autoConvert_certmanager_ServiceAccountRef_To_v1alpha2_ServiceAccountRef(in, out, s)
+}
+
 func autoConvert_v1alpha2_VaultAppRole_To_certmanager_VaultAppRole(in *VaultAppRole, out *certmanager.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1310,7 +2034,7 @@ func Convert_v1alpha2_VaultAppRole_To_certmanager_VaultAppRole(in *VaultAppRole,
 func autoConvert_certmanager_VaultAppRole_To_v1alpha2_VaultAppRole(in *certmanager.VaultAppRole, out *VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1325,7 +2049,7 @@ func autoConvert_v1alpha2_VaultAuth_To_certmanager_VaultAuth(in *VaultAuth, out
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(meta.SecretKeySelector)
-		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1361,7 +2085,7 @@ func autoConvert_certmanager_VaultAuth_To_v1alpha2_VaultAuth(in *certmanager.Vau
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(metav1.SecretKeySelector)
-		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1404,7 +2128,7 @@ func autoConvert_v1alpha2_VaultIssuer_To_certmanager_VaultIssuer(in *VaultIssuer
 	if in.CABundleSecretRef != nil {
 		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
 		*out = new(meta.SecretKeySelector)
-		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1429,7 +2153,7 @@ func autoConvert_certmanager_VaultIssuer_To_v1alpha2_VaultIssuer(in *certmanager
 	if in.CABundleSecretRef != nil {
 		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
 		*out = new(metav1.SecretKeySelector)
-		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1445,9 +2169,10 @@ func Convert_certmanager_VaultIssuer_To_v1alpha2_VaultIssuer(in *certmanager.Vau
 
 func autoConvert_v1alpha2_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *VaultKubernetesAuth, out *certmanager.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
+	out.ServiceAccountRef = (*certmanager.ServiceAccountRef)(unsafe.Pointer(in.ServiceAccountRef))
 	out.Role = in.Role
 	return nil
 }
@@ -1459,9 +2184,10 @@ func Convert_v1alpha2_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in
 
 func autoConvert_certmanager_VaultKubernetesAuth_To_v1alpha2_VaultKubernetesAuth(in *certmanager.VaultKubernetesAuth, out *VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
+	out.ServiceAccountRef = (*ServiceAccountRef)(unsafe.Pointer(in.ServiceAccountRef))
 	out.Role = in.Role
 	return nil
 }
@@ -1471,9 +2197,61 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1alpha2_VaultKubernetesAuth(in
 	return autoConvert_certmanager_VaultKubernetesAuth_To_v1alpha2_VaultKubernetesAuth(in, out, s)
 }
 
+func autoConvert_v1alpha2_VaultSecretStore_To_certmanager_VaultSecretStore(in *VaultSecretStore, out *certmanager.VaultSecretStore, s conversion.Scope) error {
+	if err := Convert_v1alpha2_VaultAuth_To_certmanager_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
+	out.Server = in.Server
+	out.Mount = in.Mount
+	out.Path = in.Path
+	out.Namespace = in.Namespace
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_VaultSecretStore_To_certmanager_VaultSecretStore is an autogenerated conversion function.
+func Convert_v1alpha2_VaultSecretStore_To_certmanager_VaultSecretStore(in *VaultSecretStore, out *certmanager.VaultSecretStore, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VaultSecretStore_To_certmanager_VaultSecretStore(in, out, s)
+}
+
+func autoConvert_certmanager_VaultSecretStore_To_v1alpha2_VaultSecretStore(in *certmanager.VaultSecretStore, out *VaultSecretStore, s conversion.Scope) error {
+	if err := Convert_certmanager_VaultAuth_To_v1alpha2_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
+	out.Server = in.Server
+	out.Mount = in.Mount
+	out.Path = in.Path
+	out.Namespace = in.Namespace
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_VaultSecretStore_To_v1alpha2_VaultSecretStore is an autogenerated conversion function.
+func Convert_certmanager_VaultSecretStore_To_v1alpha2_VaultSecretStore(in *certmanager.VaultSecretStore, out *VaultSecretStore, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultSecretStore_To_v1alpha2_VaultSecretStore(in, out, s)
+}
+
 func autoConvert_v1alpha2_VenafiCloud_To_certmanager_VenafiCloud(in *VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := v1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1486,7 +2264,7 @@ func Convert_v1alpha2_VenafiCloud_To_certmanager_VenafiCloud(in *VenafiCloud, ou
 
 func autoConvert_certmanager_VenafiCloud_To_v1alpha2_VenafiCloud(in *certmanager.VenafiCloud, out *VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := v1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1497,6 +2275,30 @@ func Convert_certmanager_VenafiCloud_To_v1alpha2_VenafiCloud(in *certmanager.Ven
 	return autoConvert_certmanager_VenafiCloud_To_v1alpha2_VenafiCloud(in, out, s)
 }
 
+func autoConvert_v1alpha2_VenafiCustomField_To_certmanager_VenafiCustomField(in *VenafiCustomField, out *certmanager.VenafiCustomField, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	out.Type = certmanager.VenafiCustomFieldType(in.Type)
+	return nil
+}
+
+// Convert_v1alpha2_VenafiCustomField_To_certmanager_VenafiCustomField is an autogenerated conversion function.
+func Convert_v1alpha2_VenafiCustomField_To_certmanager_VenafiCustomField(in *VenafiCustomField, out *certmanager.VenafiCustomField, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VenafiCustomField_To_certmanager_VenafiCustomField(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiCustomField_To_v1alpha2_VenafiCustomField(in *certmanager.VenafiCustomField, out *VenafiCustomField, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	out.Type = VenafiCustomFieldType(in.Type)
+	return nil
+}
+
+// Convert_certmanager_VenafiCustomField_To_v1alpha2_VenafiCustomField is an autogenerated conversion function.
+func Convert_certmanager_VenafiCustomField_To_v1alpha2_VenafiCustomField(in *certmanager.VenafiCustomField, out *VenafiCustomField, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiCustomField_To_v1alpha2_VenafiCustomField(in, out, s)
+}
+
 func autoConvert_v1alpha2_VenafiIssuer_To_certmanager_VenafiIssuer(in *VenafiIssuer, out *certmanager.VenafiIssuer, s conversion.Scope) error {
 	out.Zone = in.Zone
 	if in.TPP != nil {
@@ -1517,6 +2319,8 @@ func autoConvert_v1alpha2_VenafiIssuer_To_certmanager_VenafiIssuer(in *VenafiIss
 	} else {
 		out.Cloud = nil
 	}
+	out.CustomFields = *(*[]certmanager.VenafiCustomField)(unsafe.Pointer(&in.CustomFields))
+	out.ZoneCacheDuration = (*apismetav1.Duration)(unsafe.Pointer(in.ZoneCacheDuration))
 	return nil
 }
 
@@ -1545,6 +2349,8 @@ func autoConvert_certmanager_VenafiIssuer_To_v1alpha2_VenafiIssuer(in *certmanag
 	} else {
 		out.Cloud = nil
 	}
+	out.CustomFields = *(*[]VenafiCustomField)(unsafe.Pointer(&in.CustomFields))
+	out.ZoneCacheDuration = (*apismetav1.Duration)(unsafe.Pointer(in.ZoneCacheDuration))
 	return nil
 }
 
@@ -1555,7 +2361,7 @@ func Convert_certmanager_VenafiIssuer_To_v1alpha2_VenafiIssuer(in *certmanager.V
 
 func autoConvert_v1alpha2_VenafiTPP_To_certmanager_VenafiTPP(in *VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := v1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
@@ -1569,7 +2375,7 @@ func Convert_v1alpha2_VenafiTPP_To_certmanager_VenafiTPP(in *VenafiTPP, out *cer
 
 func autoConvert_certmanager_VenafiTPP_To_v1alpha2_VenafiTPP(in *certmanager.VenafiTPP, out *VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := v1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
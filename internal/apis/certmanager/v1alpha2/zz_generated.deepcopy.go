@@ -23,11 +23,79 @@ package v1alpha2
 
 import (
 	acmev1alpha2 "github.com/cert-manager/cert-manager/internal/apis/acme/v1alpha2"
-	metav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSecretsManagerSecretStore) DeepCopyInto(out *AWSSecretsManagerSecretStore) {
+	*out = *in
+	if in.AccessKeyIDSecretRef != nil {
+		in, out := &in.AccessKeyIDSecretRef, &out.AccessKeyIDSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.SecretAccessKeySecretRef != nil {
+		in, out := &in.SecretAccessKeySecretRef, &out.SecretAccessKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSecretsManagerSecretStore.
+func (in *AWSSecretsManagerSecretStore) DeepCopy() *AWSSecretsManagerSecretStore {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSecretsManagerSecretStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedKeyUsage) DeepCopyInto(out *AllowedKeyUsage) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedKeyUsage.
+func (in *AllowedKeyUsage) DeepCopy() *AllowedKeyUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedKeyUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CACRL) DeepCopyInto(out *CACRL) {
+	*out = *in
+	if in.Validity != nil {
+		in, out := &in.Validity, &out.Validity
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CACRL.
+func (in *CACRL) DeepCopy() *CACRL {
+	if in == nil {
+		return nil
+	}
+	out := new(CACRL)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CAIssuer) DeepCopyInto(out *CAIssuer) {
 	*out = *in
@@ -41,6 +109,21 @@ func (in *CAIssuer) DeepCopyInto(out *CAIssuer) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CRL != nil {
+		in, out := &in.CRL, &out.CRL
+		*out = new(CACRL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PKCS11 != nil {
+		in, out := &in.PKCS11, &out.PKCS11
+		*out = new(CAPKCS11)
+		**out = **in
+	}
+	if in.SPIFFE != nil {
+		in, out := &in.SPIFFE, &out.SPIFFE
+		*out = new(CASPIFFE)
+		**out = **in
+	}
 	return
 }
 
@@ -54,6 +137,39 @@ func (in *CAIssuer) DeepCopy() *CAIssuer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CAPKCS11) DeepCopyInto(out *CAPKCS11) {
+	*out = *in
+	out.PINSecretRef = in.PINSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAPKCS11.
+func (in *CAPKCS11) DeepCopy() *CAPKCS11 {
+	if in == nil {
+		return nil
+	}
+	out := new(CAPKCS11)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CASPIFFE) DeepCopyInto(out *CASPIFFE) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CASPIFFE.
+func (in *CASPIFFE) DeepCopy() *CASPIFFE {
+	if in == nil {
+		return nil
+	}
+	out := new(CASPIFFE)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Certificate) DeepCopyInto(out *Certificate) {
 	*out = *in
@@ -98,6 +214,22 @@ func (in *CertificateAdditionalOutputFormat) DeepCopy() *CertificateAdditionalOu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateAdditionalPrivateKey) DeepCopyInto(out *CertificateAdditionalPrivateKey) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateAdditionalPrivateKey.
+func (in *CertificateAdditionalPrivateKey) DeepCopy() *CertificateAdditionalPrivateKey {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateAdditionalPrivateKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateCondition) DeepCopyInto(out *CertificateCondition) {
 	*out = *in
@@ -180,6 +312,11 @@ func (in *CertificateList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificatePrivateKey) DeepCopyInto(out *CertificatePrivateKey) {
 	*out = *in
+	if in.RotationSchedule != nil {
+		in, out := &in.RotationSchedule, &out.RotationSchedule
+		*out = new(PrivateKeyRotationSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -279,7 +416,7 @@ func (in *CertificateRequestSpec) DeepCopyInto(out *CertificateRequestSpec) {
 	*out = *in
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	out.IssuerRef = in.IssuerRef
@@ -288,6 +425,11 @@ func (in *CertificateRequestSpec) DeepCopyInto(out *CertificateRequestSpec) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.RequestSecretRef != nil {
+		in, out := &in.RequestSecretRef, &out.RequestSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
 	if in.Usages != nil {
 		in, out := &in.Usages, &out.Usages
 		*out = make([]KeyUsage, len(*in))
@@ -363,6 +505,32 @@ func (in *CertificateRequestStatus) DeepCopy() *CertificateRequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateSecretStore) DeepCopyInto(out *CertificateSecretStore) {
+	*out = *in
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultSecretStore)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSSecretsManager != nil {
+		in, out := &in.AWSSecretsManager, &out.AWSSecretsManager
+		*out = new(AWSSecretsManagerSecretStore)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateSecretStore.
+func (in *CertificateSecretStore) DeepCopy() *CertificateSecretStore {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateSecretStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateSecretTemplate) DeepCopyInto(out *CertificateSecretTemplate) {
 	*out = *in
@@ -408,12 +576,12 @@ func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.RenewBefore != nil {
 		in, out := &in.RenewBefore, &out.RenewBefore
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.DNSNames != nil {
@@ -455,7 +623,7 @@ func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 	if in.PrivateKey != nil {
 		in, out := &in.PrivateKey, &out.PrivateKey
 		*out = new(CertificatePrivateKey)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.EncodeUsagesInRequest != nil {
 		in, out := &in.EncodeUsagesInRequest, &out.EncodeUsagesInRequest
@@ -467,11 +635,38 @@ func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.PropagationQuorum != nil {
+		in, out := &in.PropagationQuorum, &out.PropagationQuorum
+		*out = new(int32)
+		**out = **in
+	}
 	if in.AdditionalOutputFormats != nil {
 		in, out := &in.AdditionalOutputFormats, &out.AdditionalOutputFormats
 		*out = make([]CertificateAdditionalOutputFormat, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecretStores != nil {
+		in, out := &in.SecretStores, &out.SecretStores
+		*out = make([]CertificateSecretStore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProfileRef != nil {
+		in, out := &in.ProfileRef, &out.ProfileRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.RenewalWindow != nil {
+		in, out := &in.RenewalWindow, &out.RenewalWindow
+		*out = new(RenewalWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalPrivateKeys != nil {
+		in, out := &in.AdditionalPrivateKeys, &out.AdditionalPrivateKeys
+		*out = make([]CertificateAdditionalPrivateKey, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -511,6 +706,26 @@ func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 		in, out := &in.RenewalTime, &out.RenewalTime
 		*out = (*in).DeepCopy()
 	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.URIs != nil {
+		in, out := &in.URIs, &out.URIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EmailAddresses != nil {
+		in, out := &in.EmailAddresses, &out.EmailAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Revision != nil {
 		in, out := &in.Revision, &out.Revision
 		*out = new(int)
@@ -526,6 +741,15 @@ func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.PrivateKeyIssuedTime != nil {
+		in, out := &in.PrivateKeyIssuedTime, &out.PrivateKeyIssuedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PrivateKeyIssuances != nil {
+		in, out := &in.PrivateKeyIssuances, &out.PrivateKeyIssuances
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -600,6 +824,62 @@ func (in *ClusterIssuerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSignerIssuer) DeepCopyInto(out *ExternalSignerIssuer) {
+	*out = *in
+	out.TLSSecretRef = in.TLSSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSignerIssuer.
+func (in *ExternalSignerIssuer) DeepCopy() *ExternalSignerIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSignerIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HubIssuer) DeepCopyInto(out *HubIssuer) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	out.TokenSecretRef = in.TokenSecretRef
+	out.IssuerRef = in.IssuerRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubIssuer.
+func (in *HubIssuer) DeepCopy() *HubIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(HubIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeader) DeepCopyInto(out *HTTPHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeader.
+func (in *HTTPHeader) DeepCopy() *HTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Issuer) DeepCopyInto(out *Issuer) {
 	*out = *in
@@ -676,6 +956,16 @@ func (in *IssuerConfig) DeepCopyInto(out *IssuerConfig) {
 		*out = new(VenafiIssuer)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Hub != nil {
+		in, out := &in.Hub, &out.Hub
+		*out = new(HubIssuer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalSigner != nil {
+		in, out := &in.ExternalSigner, &out.ExternalSigner
+		*out = new(ExternalSignerIssuer)
+		**out = **in
+	}
 	return
 }
 
@@ -689,6 +979,27 @@ func (in *IssuerConfig) DeepCopy() *IssuerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerHTTPClient) DeepCopyInto(out *IssuerHTTPClient) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerHTTPClient.
+func (in *IssuerHTTPClient) DeepCopy() *IssuerHTTPClient {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerHTTPClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IssuerList) DeepCopyInto(out *IssuerList) {
 	*out = *in
@@ -726,6 +1037,28 @@ func (in *IssuerList) DeepCopyObject() runtime.Object {
 func (in *IssuerSpec) DeepCopyInto(out *IssuerSpec) {
 	*out = *in
 	in.IssuerConfig.DeepCopyInto(&out.IssuerConfig)
+	if in.AllowedURISchemes != nil {
+		in, out := &in.AllowedURISchemes, &out.AllowedURISchemes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedUsages != nil {
+		in, out := &in.AllowedUsages, &out.AllowedUsages
+		*out = make([]AllowedKeyUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RenewalWindow != nil {
+		in, out := &in.RenewalWindow, &out.RenewalWindow
+		*out = new(RenewalWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPClient != nil {
+		in, out := &in.HTTPClient, &out.HTTPClient
+		*out = new(IssuerHTTPClient)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -801,6 +1134,58 @@ func (in *PKCS12Keystore) DeepCopy() *PKCS12Keystore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateKeyRotationSchedule) DeepCopyInto(out *PrivateKeyRotationSchedule) {
+	*out = *in
+	if in.Renewals != nil {
+		in, out := &in.Renewals, &out.Renewals
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateKeyRotationSchedule.
+func (in *PrivateKeyRotationSchedule) DeepCopy() *PrivateKeyRotationSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateKeyRotationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RenewalWindow) DeepCopyInto(out *RenewalWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpiryOverride != nil {
+		in, out := &in.ExpiryOverride, &out.ExpiryOverride
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenewalWindow.
+func (in *RenewalWindow) DeepCopy() *RenewalWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(RenewalWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelfSignedIssuer) DeepCopyInto(out *SelfSignedIssuer) {
 	*out = *in
@@ -822,6 +1207,27 @@ func (in *SelfSignedIssuer) DeepCopy() *SelfSignedIssuer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountRef) DeepCopyInto(out *ServiceAccountRef) {
+	*out = *in
+	if in.TokenAudiences != nil {
+		in, out := &in.TokenAudiences, &out.TokenAudiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountRef.
+func (in *ServiceAccountRef) DeepCopy() *ServiceAccountRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultAppRole) DeepCopyInto(out *VaultAppRole) {
 	*out = *in
@@ -844,7 +1250,7 @@ func (in *VaultAuth) DeepCopyInto(out *VaultAuth) {
 	*out = *in
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
-		*out = new(metav1.SecretKeySelector)
+		*out = new(v1.SecretKeySelector)
 		**out = **in
 	}
 	if in.AppRole != nil {
@@ -855,7 +1261,7 @@ func (in *VaultAuth) DeepCopyInto(out *VaultAuth) {
 	if in.Kubernetes != nil {
 		in, out := &in.Kubernetes, &out.Kubernetes
 		*out = new(VaultKubernetesAuth)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -881,7 +1287,7 @@ func (in *VaultIssuer) DeepCopyInto(out *VaultIssuer) {
 	}
 	if in.CABundleSecretRef != nil {
 		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
-		*out = new(metav1.SecretKeySelector)
+		*out = new(v1.SecretKeySelector)
 		**out = **in
 	}
 	return
@@ -901,6 +1307,11 @@ func (in *VaultIssuer) DeepCopy() *VaultIssuer {
 func (in *VaultKubernetesAuth) DeepCopyInto(out *VaultKubernetesAuth) {
 	*out = *in
 	out.SecretRef = in.SecretRef
+	if in.ServiceAccountRef != nil {
+		in, out := &in.ServiceAccountRef, &out.ServiceAccountRef
+		*out = new(ServiceAccountRef)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -914,6 +1325,33 @@ func (in *VaultKubernetesAuth) DeepCopy() *VaultKubernetesAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecretStore) DeepCopyInto(out *VaultSecretStore) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretStore.
+func (in *VaultSecretStore) DeepCopy() *VaultSecretStore {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecretStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VenafiCloud) DeepCopyInto(out *VenafiCloud) {
 	*out = *in
@@ -931,6 +1369,22 @@ func (in *VenafiCloud) DeepCopy() *VenafiCloud {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VenafiCustomField) DeepCopyInto(out *VenafiCustomField) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VenafiCustomField.
+func (in *VenafiCustomField) DeepCopy() *VenafiCustomField {
+	if in == nil {
+		return nil
+	}
+	out := new(VenafiCustomField)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VenafiIssuer) DeepCopyInto(out *VenafiIssuer) {
 	*out = *in
@@ -944,6 +1398,16 @@ func (in *VenafiIssuer) DeepCopyInto(out *VenafiIssuer) {
 		*out = new(VenafiCloud)
 		**out = **in
 	}
+	if in.CustomFields != nil {
+		in, out := &in.CustomFields, &out.CustomFields
+		*out = make([]VenafiCustomField, len(*in))
+		copy(*out, *in)
+	}
+	if in.ZoneCacheDuration != nil {
+		in, out := &in.ZoneCacheDuration, &out.ZoneCacheDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
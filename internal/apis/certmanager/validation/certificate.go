@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"net"
 	"net/mail"
+	"net/url"
+	"reflect"
 	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -101,29 +103,24 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		el = append(el, field.TooLong(fldPath.Child("commonName"), crt.CommonName, 64))
 	}
 
+	if len(crt.DNSNames) > 0 {
+		el = append(el, validateDNSNames(crt, fldPath)...)
+	}
+
 	if len(crt.IPAddresses) > 0 {
 		el = append(el, validateIPAddresses(crt, fldPath)...)
 	}
 
+	if len(crt.URISANs) > 0 {
+		el = append(el, validateURISANs(crt, fldPath)...)
+	}
+
 	if len(crt.EmailSANs) > 0 {
 		el = append(el, validateEmailAddresses(crt, fldPath)...)
 	}
 
 	if crt.PrivateKey != nil {
-		switch crt.PrivateKey.Algorithm {
-		case "", internalcmapi.RSAKeyAlgorithm:
-			if crt.PrivateKey.Size > 0 && (crt.PrivateKey.Size < 2048 || crt.PrivateKey.Size > 8192) {
-				el = append(el, field.Invalid(fldPath.Child("privateKey", "size"), crt.PrivateKey.Size, "must be between 2048 & 8192 for rsa keyAlgorithm"))
-			}
-		case internalcmapi.ECDSAKeyAlgorithm:
-			if crt.PrivateKey.Size > 0 && crt.PrivateKey.Size != 256 && crt.PrivateKey.Size != 384 && crt.PrivateKey.Size != 521 {
-				el = append(el, field.NotSupported(fldPath.Child("privateKey", "size"), crt.PrivateKey.Size, []string{"256", "384", "521"}))
-			}
-		case internalcmapi.Ed25519KeyAlgorithm:
-			break
-		default:
-			el = append(el, field.Invalid(fldPath.Child("privateKey", "algorithm"), crt.PrivateKey.Algorithm, "must be either empty or one of rsa or ecdsa"))
-		}
+		el = append(el, validatePrivateKey(crt.PrivateKey, fldPath.Child("privateKey"))...)
 	}
 
 	if crt.Duration != nil || crt.RenewBefore != nil {
@@ -132,9 +129,15 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 	if len(crt.Usages) > 0 {
 		el = append(el, validateUsages(crt, fldPath)...)
 	}
+	if crt.UsagePreset != "" {
+		el = append(el, validateUsagePreset(crt, commonName, fldPath)...)
+	}
 	if crt.RevisionHistoryLimit != nil && *crt.RevisionHistoryLimit < 1 {
 		el = append(el, field.Invalid(fldPath.Child("revisionHistoryLimit"), *crt.RevisionHistoryLimit, "must not be less than 1"))
 	}
+	if crt.PropagationQuorum != nil && *crt.PropagationQuorum < 1 {
+		el = append(el, field.Invalid(fldPath.Child("propagationQuorum"), *crt.PropagationQuorum, "must not be less than 1"))
+	}
 
 	if crt.SecretTemplate != nil {
 		if len(crt.SecretTemplate.Labels) > 0 {
@@ -146,6 +149,17 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 	}
 
 	el = append(el, validateAdditionalOutputFormats(crt, fldPath)...)
+	el = append(el, validateSecretFormatProfile(crt, fldPath)...)
+	el = append(el, validateSecretStores(crt, fldPath)...)
+	el = append(el, validateAdditionalPrivateKeys(crt, fldPath)...)
+
+	if crt.ProfileRef != nil && crt.ProfileRef.Name == "" {
+		el = append(el, field.Required(fldPath.Child("profileRef", "name"), "must be specified"))
+	}
+
+	if crt.RenewalWindow != nil {
+		el = append(el, validateRenewalWindow(crt.RenewalWindow, fldPath.Child("renewalWindow"))...)
+	}
 
 	return el
 }
@@ -153,13 +167,114 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 func ValidateCertificate(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
 	crt := obj.(*internalcmapi.Certificate)
 	allErrs := ValidateCertificateSpec(&crt.Spec, field.NewPath("spec"))
-	return allErrs, nil
+	allErrs = append(allErrs, validateUsagesForCA(&crt.Spec, field.NewPath("spec"))...)
+	return allErrs, certificateSpecWarnings(&crt.Spec, field.NewPath("spec"))
 }
 
 func ValidateUpdateCertificate(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
-	crt := obj.(*internalcmapi.Certificate)
+	oldCrt, crt := oldObj.(*internalcmapi.Certificate), obj.(*internalcmapi.Certificate)
 	allErrs := ValidateCertificateSpec(&crt.Spec, field.NewPath("spec"))
-	return allErrs, nil
+	warnings := certificateSpecWarnings(&crt.Spec, field.NewPath("spec"))
+	// Profiles are only applied when a Certificate is created, so changing
+	// the reference would not change the Certificate.
+	if !reflect.DeepEqual(oldCrt.Spec.ProfileRef, crt.Spec.ProfileRef) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "profileRef"), "cannot change profileRef after creation"))
+	}
+	// Certificates stored before the usages were validated against isCA must
+	// remain editable, so only reject them if either field is changed.
+	caErrs := validateUsagesForCA(&crt.Spec, field.NewPath("spec"))
+	if oldCrt.Spec.IsCA != crt.Spec.IsCA || !reflect.DeepEqual(oldCrt.Spec.Usages, crt.Spec.Usages) {
+		allErrs = append(allErrs, caErrs...)
+	} else {
+		for _, err := range caErrs {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	return allErrs, warnings
+}
+
+// certificateSpecWarnings returns warnings for parts of the spec which are
+// valid, but which will not be issued exactly as written.
+func certificateSpecWarnings(crt *internalcmapi.CertificateSpec, fldPath *field.Path) []string {
+	var warnings []string
+	if crt.IsCA && !certificateSpecHasIdentifyingSubject(crt) {
+		warnings = append(warnings, fmt.Sprintf("%s is true but the subject has no common name, organization or organizational unit; %s",
+			fldPath.Child("isCA"), nearEmptyCASubject))
+	}
+	return warnings
+}
+
+// certificateSpecHasIdentifyingSubject returns true if the subject of the
+// certificate contains a common name, organization or organizational unit.
+// A literalSubject which cannot be parsed is reported as an error instead.
+func certificateSpecHasIdentifyingSubject(crt *internalcmapi.CertificateSpec) bool {
+	if crt.LiteralSubject != "" {
+		sequence, err := pki.ParseSubjectStringToRdnSequence(crt.LiteralSubject)
+		if err != nil {
+			return true
+		}
+		for _, rdns := range sequence {
+			for _, atv := range rdns {
+				if atv.Type.Equal(pki.OIDConstants.CommonName) ||
+					atv.Type.Equal(pki.OIDConstants.Organization) ||
+					atv.Type.Equal(pki.OIDConstants.OrganizationalUnit) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	if crt.CommonName != "" {
+		return true
+	}
+	return crt.Subject != nil && len(crt.Subject.Organizations)+len(crt.Subject.OrganizationalUnits) > 0
+}
+
+func validatePrivateKey(pk *internalcmapi.CertificatePrivateKey, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	switch pk.Algorithm {
+	case "", internalcmapi.RSAKeyAlgorithm:
+		if pk.Size > 0 && (pk.Size < 2048 || pk.Size > 8192) {
+			el = append(el, field.Invalid(fldPath.Child("size"), pk.Size, "must be between 2048 & 8192 for rsa keyAlgorithm"))
+		}
+	case internalcmapi.ECDSAKeyAlgorithm:
+		if pk.Size > 0 && pk.Size != 256 && pk.Size != 384 && pk.Size != 521 {
+			el = append(el, field.NotSupported(fldPath.Child("size"), pk.Size, []string{"256", "384", "521"}))
+		}
+	case internalcmapi.Ed25519KeyAlgorithm:
+		break
+	case internalcmapi.MLDSA65ECDSAP256KeyAlgorithm:
+		if !utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalCompositeKeys) {
+			el = append(el, field.Forbidden(fldPath.Child("algorithm"), "Feature gate ExperimentalCompositeKeys must be enabled on both webhook and controller to use the experimental `MLDSA65-ECDSA-P256` private key algorithm"))
+		}
+	default:
+		el = append(el, field.Invalid(fldPath.Child("algorithm"), pk.Algorithm, "must be either empty or one of RSA, ECDSA, Ed25519 or MLDSA65-ECDSA-P256"))
+	}
+	el = append(el, validateFIPSPrivateKey(pk, fldPath)...)
+	el = append(el, validatePrivateKeyRotationSchedule(pk, fldPath)...)
+	return el
+}
+
+func validatePrivateKeyRotationSchedule(pk *internalcmapi.CertificatePrivateKey, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	fldPath = fldPath.Child("rotationSchedule")
+	schedule := pk.RotationSchedule
+	if pk.RotationPolicy != internalcmapi.RotationPolicyScheduled {
+		if schedule != nil {
+			el = append(el, field.Forbidden(fldPath, "may only be set if rotationPolicy is Scheduled"))
+		}
+		return el
+	}
+	if schedule == nil || (schedule.Renewals == nil && schedule.MaxAge == nil) {
+		return append(el, field.Required(fldPath, "at least one of renewals or maxAge must be set if rotationPolicy is Scheduled"))
+	}
+	if schedule.Renewals != nil && *schedule.Renewals < 1 {
+		el = append(el, field.Invalid(fldPath.Child("renewals"), *schedule.Renewals, "must be greater than 0"))
+	}
+	if schedule.MaxAge != nil && schedule.MaxAge.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("maxAge"), schedule.MaxAge.Duration.String(), "must be greater than 0"))
+	}
+	return el
 }
 
 func validateIssuerRef(issuerRef cmmeta.ObjectReference, fldPath *field.Path) field.ErrorList {
@@ -172,9 +287,9 @@ func validateIssuerRef(issuerRef cmmeta.ObjectReference, fldPath *field.Path) fi
 	if issuerRef.Group == "" || issuerRef.Group == internalcmapi.SchemeGroupVersion.Group {
 		switch issuerRef.Kind {
 		case "":
-		case "Issuer", "ClusterIssuer":
+		case "Issuer", "ClusterIssuer", "IssuerAlias":
 		default:
-			el = append(el, field.Invalid(issuerRefPath.Child("kind"), issuerRef.Kind, "must be one of Issuer or ClusterIssuer"))
+			el = append(el, field.Invalid(issuerRefPath.Child("kind"), issuerRef.Kind, "must be one of Issuer, ClusterIssuer or IssuerAlias"))
 		}
 	}
 	return el
@@ -194,6 +309,48 @@ func validateIPAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Path)
 	return el
 }
 
+func validateDNSNames(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, d := range a.DNSNames {
+		switch {
+		case len(d) == 0:
+			el = append(el, field.Invalid(fldPath.Child("dnsNames").Index(i), d, "invalid DNS name: must not be empty"))
+		case strings.ContainsAny(d, " \t\r\n"):
+			el = append(el, field.Invalid(fldPath.Child("dnsNames").Index(i), d, "invalid DNS name: must not contain whitespace"))
+		case strings.Contains(strings.TrimPrefix(d, "*."), "*"):
+			el = append(el, field.Invalid(fldPath.Child("dnsNames").Index(i), d, "invalid DNS name: a wildcard is only allowed as the leftmost label, e.g. *.example.com"))
+		default:
+			if _, err := pki.NormalizeDNSName(d); err != nil {
+				el = append(el, field.Invalid(fldPath.Child("dnsNames").Index(i), d, fmt.Sprintf("invalid DNS name: %s", err)))
+			}
+		}
+	}
+	return el
+}
+
+func validateURISANs(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, d := range a.URISANs {
+		u, err := url.Parse(d)
+		if err != nil {
+			el = append(el, field.Invalid(fldPath.Child("uris").Index(i), d, fmt.Sprintf("invalid URI: %s", err)))
+			continue
+		}
+		// url.Parse accepts any string as a relative reference, but URI SANs
+		// must be absolute.
+		if !u.IsAbs() || u.Scheme == "" {
+			el = append(el, field.Invalid(fldPath.Child("uris").Index(i), d, "invalid URI: must be an absolute URI with a scheme, e.g. spiffe://cluster.local/ns/default/sa/default"))
+			continue
+		}
+		if pki.IsSPIFFEID(u) {
+			if err := pki.ValidateSPIFFEID(d); err != nil {
+				el = append(el, field.Invalid(fldPath.Child("uris").Index(i), d, fmt.Sprintf("invalid SPIFFE ID: %s", err)))
+			}
+		}
+	}
+	return el
+}
+
 func validateEmailAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	if len(a.EmailSANs) <= 0 {
 		return nil
@@ -224,6 +381,68 @@ func validateUsages(a *internalcmapi.CertificateSpec, fldPath *field.Path) field
 	return el
 }
 
+// validateUsagesForCA checks that the cert sign usage is requested by CA
+// certificates, and only by CA certificates.
+func validateUsagesForCA(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	// RFC 5280 section 4.2.1.9 requires certificates with the keyCertSign
+	// usage to be CA certificates.
+	for i, u := range crt.Usages {
+		if u == internalcmapi.UsageCertSign && !crt.IsCA {
+			el = append(el, field.Invalid(fldPath.Child("usages").Index(i), u, "the cert sign usage may only be requested by CA certificates: set isCA to true or remove this usage"))
+		}
+	}
+	// If no usages are set the defaults are used, to which cert sign is
+	// added for CA certificates.
+	if crt.IsCA && len(crt.Usages) > 0 && !hasUsage(crt.Usages, internalcmapi.UsageCertSign) {
+		el = append(el, field.Invalid(fldPath.Child("usages"), crt.Usages, fmt.Sprintf("CA certificates must request the %q usage: add it or set isCA to false", internalcmapi.UsageCertSign)))
+	}
+	return el
+}
+
+// validateUsagePreset checks that the preset is known, and that the
+// Certificate has a SAN or subject which can identify the kind of peer the
+// preset is intended for.
+func validateUsagePreset(crt *internalcmapi.CertificateSpec, commonName string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	presetPath := fldPath.Child("usagePreset")
+
+	switch crt.UsagePreset {
+	case internalcmapi.UsagePresetServerAuth, internalcmapi.UsagePresetMutual:
+		if len(crt.DNSNames) == 0 && len(crt.IPAddresses) == 0 {
+			el = append(el, field.Invalid(presetPath, crt.UsagePreset, "server certificates must have at least one of dnsNames or ipAddresses"))
+		}
+	case internalcmapi.UsagePresetClientAuth:
+		if len(commonName) == 0 && len(crt.URISANs) == 0 && len(crt.EmailSANs) == 0 {
+			el = append(el, field.Invalid(presetPath, crt.UsagePreset, "client certificates must have at least one of commonName, uris or emailAddresses"))
+		}
+	default:
+		el = append(el, field.NotSupported(presetPath, crt.UsagePreset, []string{
+			string(internalcmapi.UsagePresetServerAuth),
+			string(internalcmapi.UsagePresetClientAuth),
+			string(internalcmapi.UsagePresetMutual),
+		}))
+	}
+
+	if len(crt.Usages) > 0 {
+		el = append(el, field.Forbidden(fldPath.Child("usages"), "cannot be set together with usagePreset"))
+	}
+	if crt.IsCA {
+		el = append(el, field.Forbidden(fldPath.Child("isCA"), "cannot be set together with usagePreset"))
+	}
+
+	return el
+}
+
+func hasUsage(usages []internalcmapi.KeyUsage, usage internalcmapi.KeyUsage) bool {
+	for _, u := range usages {
+		if u == usage {
+			return true
+		}
+	}
+	return false
+}
+
 func validateSecretTemplateLabels(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	return metavalidation.ValidateLabels(crt.SecretTemplate.Labels, fldPath.Child("secretTemplate", "labels"))
 }
@@ -282,3 +501,138 @@ func validateAdditionalOutputFormats(crt *internalcmapi.CertificateSpec, fldPath
 
 	return el
 }
+
+func validateSecretFormatProfile(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if crt.SecretFormatProfile == "" {
+		return el
+	}
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.AdditionalCertificateOutputFormats) {
+		return append(el, field.Forbidden(fldPath.Child("secretFormatProfile"), "feature gate AdditionalCertificateOutputFormats must be enabled"))
+	}
+
+	switch crt.SecretFormatProfile {
+	case internalcmapi.SecretFormatProfileHAProxy, internalcmapi.SecretFormatProfileEnvoySDS:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("secretFormatProfile"), crt.SecretFormatProfile, []string{
+			string(internalcmapi.SecretFormatProfileHAProxy),
+			string(internalcmapi.SecretFormatProfileEnvoySDS),
+		}))
+	}
+
+	return el
+}
+
+func validateAdditionalPrivateKeys(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if len(crt.AdditionalPrivateKeys) == 0 {
+		return el
+	}
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.AdditionalPrivateKeys) {
+		return append(el, field.Forbidden(fldPath.Child("additionalPrivateKeys"), "feature gate AdditionalPrivateKeys must be enabled"))
+	}
+
+	// Each algorithm has its own keys in the Secret, so an algorithm may only
+	// be requested once, and not again for the main private key.
+	algorithms := sets.NewString(string(internalcmapi.RSAKeyAlgorithm))
+	if crt.PrivateKey != nil && crt.PrivateKey.Algorithm != "" {
+		algorithms = sets.NewString(string(crt.PrivateKey.Algorithm))
+	}
+	for i, pk := range crt.AdditionalPrivateKeys {
+		fldPath := fldPath.Child("additionalPrivateKeys").Index(i)
+		switch pk.Algorithm {
+		case internalcmapi.RSAKeyAlgorithm, internalcmapi.ECDSAKeyAlgorithm, internalcmapi.Ed25519KeyAlgorithm:
+		default:
+			el = append(el, field.NotSupported(fldPath.Child("algorithm"), pk.Algorithm, []string{
+				string(internalcmapi.RSAKeyAlgorithm), string(internalcmapi.ECDSAKeyAlgorithm), string(internalcmapi.Ed25519KeyAlgorithm),
+			}))
+			continue
+		}
+		if algorithms.Has(string(pk.Algorithm)) {
+			el = append(el, field.Duplicate(fldPath.Child("algorithm"), pk.Algorithm))
+			continue
+		}
+		algorithms.Insert(string(pk.Algorithm))
+		el = append(el, validatePrivateKey(&internalcmapi.CertificatePrivateKey{Algorithm: pk.Algorithm, Size: pk.Size}, fldPath)...)
+	}
+
+	return el
+}
+
+func validateSecretStores(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if len(crt.SecretStores) == 0 {
+		return el
+	}
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.ExternalSecretStores) {
+		return append(el, field.Forbidden(fldPath.Child("secretStores"), "feature gate ExternalSecretStores must be enabled"))
+	}
+
+	for i, store := range crt.SecretStores {
+		fldPath := fldPath.Child("secretStores").Index(i)
+		switch {
+		case store.Vault != nil && store.AWSSecretsManager != nil:
+			el = append(el, field.Forbidden(fldPath, "may not specify more than one store type"))
+		case store.Vault != nil:
+			el = append(el, validateVaultSecretStore(store.Vault, fldPath.Child("vault"))...)
+		case store.AWSSecretsManager != nil:
+			el = append(el, validateAWSSecretsManagerSecretStore(store.AWSSecretsManager, fldPath.Child("awsSecretsManager"))...)
+		default:
+			el = append(el, field.Required(fldPath, "no store type configured"))
+		}
+	}
+
+	return el
+}
+
+func validateVaultSecretStore(store *internalcmapi.VaultSecretStore, fldPath *field.Path) field.ErrorList {
+	el := ValidateVaultIssuerConfig(&internalcmapi.VaultIssuer{
+		Server:            store.Server,
+		Path:              store.Path,
+		CABundle:          store.CABundle,
+		CABundleSecretRef: store.CABundleSecretRef,
+	}, fldPath)
+	if len(store.Mount) == 0 {
+		el = append(el, field.Required(fldPath.Child("mount"), ""))
+	}
+
+	auth := store.Auth
+	configured := 0
+	for _, set := range []bool{auth.TokenSecretRef != nil, auth.AppRole != nil, auth.Kubernetes != nil} {
+		if set {
+			configured++
+		}
+	}
+	if configured != 1 {
+		el = append(el, field.Invalid(fldPath.Child("auth"), "", "exactly one of tokenSecretRef, appRole or kubernetes must be specified"))
+	}
+
+	return el
+}
+
+func validateAWSSecretsManagerSecretStore(store *internalcmapi.AWSSecretsManagerSecretStore, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+	if len(store.Region) == 0 {
+		el = append(el, field.Required(fldPath.Child("region"), ""))
+	}
+	if len(store.SecretName) == 0 {
+		el = append(el, field.Required(fldPath.Child("secretName"), ""))
+	}
+
+	// Either both or neither of the static credentials must be set; ambient
+	// credentials are used if neither are.
+	if (store.AccessKeyIDSecretRef == nil) != (store.SecretAccessKeySecretRef == nil) {
+		el = append(el, field.Required(fldPath, "accessKeyIDSecretRef and secretAccessKeySecretRef must be specified together"))
+	}
+	if store.AccessKeyIDSecretRef != nil {
+		el = append(el, ValidateSecretKeySelector(store.AccessKeyIDSecretRef, fldPath.Child("accessKeyIDSecretRef"))...)
+	}
+	if store.SecretAccessKeySecretRef != nil {
+		el = append(el, ValidateSecretKeySelector(store.SecretAccessKeySecretRef, fldPath.Child("secretAccessKeySecretRef"))...)
+	}
+
+	return el
+}
@@ -127,7 +127,7 @@ func TestValidateCertificate(t *testing.T) {
 			},
 			a: someAdmissionRequest,
 			errs: []*field.Error{
-				field.Invalid(fldPath.Child("issuerRef", "kind"), "invalid", "must be one of Issuer or ClusterIssuer"),
+				field.Invalid(fldPath.Child("issuerRef", "kind"), "invalid", "must be one of Issuer, ClusterIssuer or IssuerAlias"),
 			},
 		},
 		"certificate missing secretName": {
@@ -363,7 +363,7 @@ func TestValidateCertificate(t *testing.T) {
 			},
 			a: someAdmissionRequest,
 			errs: []*field.Error{
-				field.Invalid(fldPath.Child("privateKey", "algorithm"), internalcmapi.PrivateKeyAlgorithm("blah"), "must be either empty or one of rsa or ecdsa"),
+				field.Invalid(fldPath.Child("privateKey", "algorithm"), internalcmapi.PrivateKeyAlgorithm("blah"), "must be either empty or one of RSA, ECDSA, Ed25519 or MLDSA65-ECDSA-P256"),
 			},
 		},
 		"valid certificate with ipAddresses": {
@@ -484,12 +484,169 @@ func TestValidateCertificate(t *testing.T) {
 					SecretName: "abc",
 					IssuerRef:  validIssuerRef,
 					URISANs: []string{
-						"foo.bar",
+						"https://foo.bar",
 					},
 				},
 			},
 			a: someAdmissionRequest,
 		},
+		"invalid certificate with malformed SPIFFE IDs": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					URISANs:    []string{"spiffe://cluster.local/ns/test", "spiffe://Cluster.local/ns/test", "spiffe://cluster.local/ns/test/"},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("uris").Index(1), "spiffe://Cluster.local/ns/test", `invalid SPIFFE ID: SPIFFE ID trust domain "Cluster.local" may only contain lowercase letters, digits, dots, dashes and underscores`),
+				field.Invalid(fldPath.Child("uris").Index(2), "spiffe://cluster.local/ns/test/", "invalid SPIFFE ID: SPIFFE ID path must not contain empty segments or a trailing slash"),
+			},
+		},
+		"invalid certificate with malformed URI SAN": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					URISANs:    []string{"spiffe://cluster.local/ns/test", "http://[::1"},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("uris").Index(1), "http://[::1", `invalid URI: parse "http://[::1": missing ']' in host`),
+			},
+		},
+		"invalid certificate with relative URI SANs": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					URISANs:    []string{"spiffe://cluster.local/ns/test", "not a uri", "foo", "//example.com/path"},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("uris").Index(1), "not a uri", "invalid URI: must be an absolute URI with a scheme, e.g. spiffe://cluster.local/ns/default/sa/default"),
+				field.Invalid(fldPath.Child("uris").Index(2), "foo", "invalid URI: must be an absolute URI with a scheme, e.g. spiffe://cluster.local/ns/default/sa/default"),
+				field.Invalid(fldPath.Child("uris").Index(3), "//example.com/path", "invalid URI: must be an absolute URI with a scheme, e.g. spiffe://cluster.local/ns/default/sa/default"),
+			},
+		},
+		"invalid certificate with malformed DNS names": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					DNSNames:   []string{"*.example.com", "", "foo .example.com", "foo.*.example.com"},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("dnsNames").Index(1), "", "invalid DNS name: must not be empty"),
+				field.Invalid(fldPath.Child("dnsNames").Index(2), "foo .example.com", "invalid DNS name: must not contain whitespace"),
+				field.Invalid(fldPath.Child("dnsNames").Index(3), "foo.*.example.com", "invalid DNS name: a wildcard is only allowed as the leftmost label, e.g. *.example.com"),
+			},
+		},
+		"valid certificate with internationalized DNS names": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					DNSNames:   []string{"bücher.example", "*.xn--bcher-kva.example"},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid certificate with malformed internationalized DNS names": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					DNSNames:   []string{"xn--zz.example", "xn--bcher-kva.bücher.example"},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("dnsNames").Index(0), "xn--zz.example", `invalid DNS name: "xn--zz.example" contains an invalid punycode label "xn--zz": idna: invalid label "zz"`),
+				field.Invalid(fldPath.Child("dnsNames").Index(1), "xn--bcher-kva.bücher.example", `invalid DNS name: "xn--bcher-kva.bücher.example" mixes unicode and punycode labels`),
+			},
+		},
+		"invalid certificate requesting cert sign usage without isCA": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Usages:     []internalcmapi.KeyUsage{internalcmapi.UsageDigitalSignature, internalcmapi.UsageCertSign},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("usages").Index(1), internalcmapi.UsageCertSign, "the cert sign usage may only be requested by CA certificates: set isCA to true or remove this usage"),
+			},
+		},
+		"invalid CA certificate without cert sign usage": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					IsCA:       true,
+					Usages:     []internalcmapi.KeyUsage{internalcmapi.UsageDigitalSignature},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("usages"), []internalcmapi.KeyUsage{internalcmapi.UsageDigitalSignature}, `CA certificates must request the "cert sign" usage: add it or set isCA to false`),
+			},
+		},
+		"valid CA certificate with the default usages": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					IsCA:       true,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"CA certificate without a subject warns": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:   []string{"example.com"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					IsCA:       true,
+				},
+			},
+			a:        someAdmissionRequest,
+			warnings: []string{"spec.isCA is true but the subject has no common name, organization or organizational unit; clients identify CAs by their subject, which should not be empty"},
+		},
+		"CA certificate with only an organization does not warn": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:   []string{"example.com"},
+					Subject:    &internalcmapi.X509Subject{Organizations: []string{"example"}, Countries: []string{"GB"}},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					IsCA:       true,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"valid CA certificate with cert sign usage": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					IsCA:       true,
+					Usages:     []internalcmapi.KeyUsage{internalcmapi.UsageDigitalSignature, internalcmapi.UsageCertSign},
+				},
+			},
+			a: someAdmissionRequest,
+		},
 		"valid certificate with only email SAN": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -564,6 +721,31 @@ func TestValidateCertificate(t *testing.T) {
 				field.Invalid(fldPath.Child("revisionHistoryLimit"), int32(0), "must not be less than 1"),
 			},
 		},
+		"valid certificate with propagation quorum == 1": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:        "abc",
+					SecretName:        "abc",
+					IssuerRef:         validIssuerRef,
+					PropagationQuorum: int32Ptr(1),
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid certificate with propagation quorum < 1": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:        "abc",
+					SecretName:        "abc",
+					IssuerRef:         validIssuerRef,
+					PropagationQuorum: int32Ptr(0),
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("propagationQuorum"), int32(0), "must not be less than 1"),
+			},
+		},
 		"valid with empty secretTemplate": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -666,6 +848,152 @@ func TestValidateCertificate(t *testing.T) {
 						"alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')"),
 			},
 		},
+		"valid with profileRef": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					ProfileRef: &cmmeta.LocalObjectReference{Name: "server"},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid profileRef without a name": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					ProfileRef: &cmmeta.LocalObjectReference{},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("profileRef", "name"), "must be specified"),
+			},
+		},
+		"valid with renewalWindow": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:    "testcn",
+					SecretName:    "abc",
+					IssuerRef:     validIssuerRef,
+					RenewalWindow: &internalcmapi.RenewalWindow{Days: []string{"Sat-Sun"}},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid renewalWindow hours": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:    "testcn",
+					SecretName:    "abc",
+					IssuerRef:     validIssuerRef,
+					RenewalWindow: &internalcmapi.RenewalWindow{Hours: "9-9"},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("renewalWindow", "hours"), "9-9", `invalid hours "9-9", the range must not be empty`),
+			},
+		},
+		"valid serverAuth usagePreset with dnsNames": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:    []string{"example.com"},
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					UsagePreset: internalcmapi.UsagePresetServerAuth,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid serverAuth usagePreset without dnsNames or ipAddresses": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:  "testcn",
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					UsagePreset: internalcmapi.UsagePresetServerAuth,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("usagePreset"), internalcmapi.UsagePresetServerAuth, "server certificates must have at least one of dnsNames or ipAddresses"),
+			},
+		},
+		"invalid mutual usagePreset without dnsNames or ipAddresses": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					URISANs:     []string{"spiffe://cluster.local/ns/default/sa/client"},
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					UsagePreset: internalcmapi.UsagePresetMutual,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("usagePreset"), internalcmapi.UsagePresetMutual, "server certificates must have at least one of dnsNames or ipAddresses"),
+			},
+		},
+		"valid clientAuth usagePreset with a URI SAN": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					URISANs:     []string{"spiffe://cluster.local/ns/default/sa/client"},
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					UsagePreset: internalcmapi.UsagePresetClientAuth,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid clientAuth usagePreset with only dnsNames": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:    []string{"example.com"},
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					UsagePreset: internalcmapi.UsagePresetClientAuth,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("usagePreset"), internalcmapi.UsagePresetClientAuth, "client certificates must have at least one of commonName, uris or emailAddresses"),
+			},
+		},
+		"invalid unknown usagePreset": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:    []string{"example.com"},
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					UsagePreset: "codeSigning",
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("usagePreset"), internalcmapi.UsagePreset("codeSigning"), []string{"serverAuth", "clientAuth", "mutual"}),
+			},
+		},
+		"invalid usagePreset together with usages and isCA": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:  "testcn",
+					DNSNames:    []string{"example.com"},
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					IsCA:        true,
+					Usages:      []internalcmapi.KeyUsage{internalcmapi.UsageDigitalSignature, internalcmapi.UsageCertSign},
+					UsagePreset: internalcmapi.UsagePresetMutual,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("usages"), "cannot be set together with usagePreset"),
+				field.Forbidden(fldPath.Child("isCA"), "cannot be set together with usagePreset"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -676,9 +1004,86 @@ func TestValidateCertificate(t *testing.T) {
 	}
 }
 
+func TestValidateUpdateCertificate(t *testing.T) {
+	spec := func(profileRef *cmmeta.LocalObjectReference) *internalcmapi.Certificate {
+		return &internalcmapi.Certificate{
+			Spec: internalcmapi.CertificateSpec{
+				CommonName: "testcn",
+				SecretName: "abc",
+				IssuerRef:  validIssuerRef,
+				ProfileRef: profileRef,
+			},
+		}
+	}
+	profileRefChanged := []*field.Error{
+		field.Forbidden(field.NewPath("spec", "profileRef"), "cannot change profileRef after creation"),
+	}
+
+	withUsages := func(isCA bool, usages ...internalcmapi.KeyUsage) *internalcmapi.Certificate {
+		crt := spec(nil)
+		crt.Spec.IsCA = isCA
+		crt.Spec.Usages = usages
+		return crt
+	}
+	certSignWithoutCA := field.Invalid(field.NewPath("spec", "usages").Index(1), internalcmapi.UsageCertSign, "the cert sign usage may only be requested by CA certificates: set isCA to true or remove this usage")
+
+	scenarios := map[string]struct {
+		old, new *internalcmapi.Certificate
+		errs     []*field.Error
+		warnings []string
+	}{
+		"unchanged profileRef": {
+			old: spec(&cmmeta.LocalObjectReference{Name: "server"}),
+			new: spec(&cmmeta.LocalObjectReference{Name: "server"}),
+		},
+		"unchanged without profileRef": {
+			old: spec(nil),
+			new: spec(nil),
+		},
+		"profileRef is added": {
+			old:  spec(nil),
+			new:  spec(&cmmeta.LocalObjectReference{Name: "server"}),
+			errs: profileRefChanged,
+		},
+		"profileRef is changed": {
+			old:  spec(&cmmeta.LocalObjectReference{Name: "server"}),
+			new:  spec(&cmmeta.LocalObjectReference{Name: "client"}),
+			errs: profileRefChanged,
+		},
+		"profileRef is removed": {
+			old:  spec(&cmmeta.LocalObjectReference{Name: "server"}),
+			new:  spec(nil),
+			errs: profileRefChanged,
+		},
+		"existing cert sign usage without isCA only warns": {
+			old:      withUsages(false, internalcmapi.UsageDigitalSignature, internalcmapi.UsageCertSign),
+			new:      withUsages(false, internalcmapi.UsageDigitalSignature, internalcmapi.UsageCertSign),
+			warnings: []string{certSignWithoutCA.Error()},
+		},
+		"cert sign usage is added without isCA": {
+			old:  withUsages(false, internalcmapi.UsageDigitalSignature),
+			new:  withUsages(false, internalcmapi.UsageDigitalSignature, internalcmapi.UsageCertSign),
+			errs: []*field.Error{certSignWithoutCA},
+		},
+		"isCA is unset with the cert sign usage": {
+			old:  withUsages(true, internalcmapi.UsageDigitalSignature, internalcmapi.UsageCertSign),
+			new:  withUsages(false, internalcmapi.UsageDigitalSignature, internalcmapi.UsageCertSign),
+			errs: []*field.Error{certSignWithoutCA},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs, warnings := ValidateUpdateCertificate(someAdmissionRequest, s.old, s.new)
+			assert.ElementsMatch(t, errs, s.errs)
+			assert.ElementsMatch(t, warnings, s.warnings)
+		})
+	}
+}
+
 func TestValidateDuration(t *testing.T) {
 	usefulDurations := map[string]*metav1.Duration{
 		"one second":  {Duration: time.Second},
+		"one minute":  {Duration: time.Minute},
 		"ten minutes": {Duration: time.Minute * 10},
 		"half hour":   {Duration: time.Minute * 30},
 		"one hour":    {Duration: time.Hour},
@@ -770,14 +1175,17 @@ func TestValidateDuration(t *testing.T) {
 		"duration is less than the minimum permitted value": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
-					Duration:    usefulDurations["half hour"],
-					RenewBefore: usefulDurations["ten minutes"],
+					Duration:    usefulDurations["one minute"],
+					RenewBefore: usefulDurations["one second"],
 					CommonName:  "testcn",
 					SecretName:  "abc",
 					IssuerRef:   validIssuerRef,
 				},
 			},
-			errs: []*field.Error{field.Invalid(fldPath.Child("duration"), usefulDurations["half hour"].Duration, fmt.Sprintf("certificate duration must be greater than %s", cmapi.MinimumCertificateDuration))},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("duration"), usefulDurations["one minute"].Duration, fmt.Sprintf("certificate duration must be greater than %s", cmapi.MinimumCertificateDuration)),
+				field.Invalid(fldPath.Child("renewBefore"), usefulDurations["one second"].Duration, fmt.Sprintf("certificate renewBefore must be greater than %s", cmapi.MinimumRenewBefore)),
+			},
 		},
 	}
 	for n, s := range scenarios {
@@ -899,6 +1307,186 @@ func Test_validateAdditionalOutputFormats(t *testing.T) {
 	}
 }
 
+func Test_validateSecretStores(t *testing.T) {
+	fldPath := field.NewPath("spec", "secretStores")
+	vault := &internalcmapi.VaultSecretStore{
+		Server: "https://vault.example.com",
+		Mount:  "secret",
+		Path:   "my-app/tls",
+		Auth: internalcmapi.VaultAuth{
+			TokenSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "token"}},
+		},
+	}
+	aws := &internalcmapi.AWSSecretsManagerSecretStore{Region: "eu-west-1", SecretName: "my-app-tls"}
+
+	tests := map[string]struct {
+		featureEnabled bool
+		stores         []internalcmapi.CertificateSecretStore
+		expErr         field.ErrorList
+	}{
+		"if feature disabled and no stores defined, expect no error": {},
+		"if feature disabled and a store is defined, expect error": {
+			stores: []internalcmapi.CertificateSecretStore{{Vault: vault}},
+			expErr: field.ErrorList{
+				field.Forbidden(fldPath, "feature gate ExternalSecretStores must be enabled"),
+			},
+		},
+		"if feature enabled and valid stores defined, expect no error": {
+			featureEnabled: true,
+			stores:         []internalcmapi.CertificateSecretStore{{Vault: vault}, {AWSSecretsManager: aws}},
+		},
+		"if feature enabled and a store sets no or both types, expect error": {
+			featureEnabled: true,
+			stores:         []internalcmapi.CertificateSecretStore{{}, {Vault: vault, AWSSecretsManager: aws}},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Index(0), "no store type configured"),
+				field.Forbidden(fldPath.Index(1), "may not specify more than one store type"),
+			},
+		},
+		"if feature enabled and vault store has missing fields, expect error": {
+			featureEnabled: true,
+			stores:         []internalcmapi.CertificateSecretStore{{Vault: &internalcmapi.VaultSecretStore{}}},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Index(0).Child("vault", "server"), ""),
+				field.Required(fldPath.Index(0).Child("vault", "path"), ""),
+				field.Required(fldPath.Index(0).Child("vault", "mount"), ""),
+				field.Invalid(fldPath.Index(0).Child("vault", "auth"), "", "exactly one of tokenSecretRef, appRole or kubernetes must be specified"),
+			},
+		},
+		"if feature enabled and aws store has partial credentials, expect error": {
+			featureEnabled: true,
+			stores: []internalcmapi.CertificateSecretStore{{AWSSecretsManager: &internalcmapi.AWSSecretsManagerSecretStore{
+				AccessKeyIDSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "aws"}, Key: "id"},
+			}}},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Index(0).Child("awsSecretsManager", "region"), ""),
+				field.Required(fldPath.Index(0).Child("awsSecretsManager", "secretName"), ""),
+				field.Required(fldPath.Index(0).Child("awsSecretsManager"), "accessKeyIDSecretRef and secretAccessKeySecretRef must be specified together"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.ExternalSecretStores, test.featureEnabled)()
+			gotErr := validateSecretStores(&internalcmapi.CertificateSpec{SecretStores: test.stores}, field.NewPath("spec"))
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
+
+func Test_validateAdditionalPrivateKeys(t *testing.T) {
+	fldPath := field.NewPath("spec", "additionalPrivateKeys")
+
+	tests := map[string]struct {
+		featureEnabled bool
+		spec           *internalcmapi.CertificateSpec
+		expErr         field.ErrorList
+	}{
+		"if feature disabled and no additional keys defined, expect no error": {
+			spec: &internalcmapi.CertificateSpec{},
+		},
+		"if feature disabled and an additional key is defined, expect error": {
+			spec: &internalcmapi.CertificateSpec{
+				AdditionalPrivateKeys: []internalcmapi.CertificateAdditionalPrivateKey{{Algorithm: internalcmapi.ECDSAKeyAlgorithm}},
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(fldPath, "feature gate AdditionalPrivateKeys must be enabled"),
+			},
+		},
+		"if feature enabled and an ECDSA key is added to the default RSA key, expect no error": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				AdditionalPrivateKeys: []internalcmapi.CertificateAdditionalPrivateKey{{Algorithm: internalcmapi.ECDSAKeyAlgorithm, Size: 384}},
+			},
+		},
+		"if feature enabled and RSA and Ed25519 keys are added to an ECDSA key, expect no error": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				PrivateKey: &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.ECDSAKeyAlgorithm},
+				AdditionalPrivateKeys: []internalcmapi.CertificateAdditionalPrivateKey{
+					{Algorithm: internalcmapi.RSAKeyAlgorithm, Size: 4096},
+					{Algorithm: internalcmapi.Ed25519KeyAlgorithm},
+				},
+			},
+		},
+		"if feature enabled and an algorithm is repeated, expect error": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				AdditionalPrivateKeys: []internalcmapi.CertificateAdditionalPrivateKey{
+					{Algorithm: internalcmapi.RSAKeyAlgorithm},
+					{Algorithm: internalcmapi.ECDSAKeyAlgorithm},
+					{Algorithm: internalcmapi.ECDSAKeyAlgorithm},
+				},
+			},
+			expErr: field.ErrorList{
+				field.Duplicate(fldPath.Index(0).Child("algorithm"), internalcmapi.RSAKeyAlgorithm),
+				field.Duplicate(fldPath.Index(2).Child("algorithm"), internalcmapi.ECDSAKeyAlgorithm),
+			},
+		},
+		"if feature enabled and an algorithm or size is invalid, expect error": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				AdditionalPrivateKeys: []internalcmapi.CertificateAdditionalPrivateKey{
+					{Algorithm: internalcmapi.MLDSA65ECDSAP256KeyAlgorithm},
+					{Algorithm: internalcmapi.ECDSAKeyAlgorithm, Size: 512},
+				},
+			},
+			expErr: field.ErrorList{
+				field.NotSupported(fldPath.Index(0).Child("algorithm"), internalcmapi.MLDSA65ECDSAP256KeyAlgorithm, []string{"RSA", "ECDSA", "Ed25519"}),
+				field.NotSupported(fldPath.Index(1).Child("size"), 512, []string{"256", "384", "521"}),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.AdditionalPrivateKeys, test.featureEnabled)()
+			gotErr := validateAdditionalPrivateKeys(test.spec, field.NewPath("spec"))
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
+
+func Test_validateSecretFormatProfile(t *testing.T) {
+	fldPath := field.NewPath("spec", "secretFormatProfile")
+
+	tests := map[string]struct {
+		featureEnabled bool
+		spec           *internalcmapi.CertificateSpec
+		expErr         field.ErrorList
+	}{
+		"if feature disabled and no profile defined, expect no error": {
+			spec: &internalcmapi.CertificateSpec{},
+		},
+		"if feature disabled and a profile is defined, expect error": {
+			spec: &internalcmapi.CertificateSpec{SecretFormatProfile: internalcmapi.SecretFormatProfileHAProxy},
+			expErr: field.ErrorList{
+				field.Forbidden(fldPath, "feature gate AdditionalCertificateOutputFormats must be enabled"),
+			},
+		},
+		"if feature enabled and the EnvoySDS profile is defined, expect no error": {
+			featureEnabled: true,
+			spec:           &internalcmapi.CertificateSpec{SecretFormatProfile: internalcmapi.SecretFormatProfileEnvoySDS},
+		},
+		"if feature enabled and an unknown profile is defined, expect error": {
+			featureEnabled: true,
+			spec:           &internalcmapi.CertificateSpec{SecretFormatProfile: "Nginx"},
+			expErr: field.ErrorList{
+				field.NotSupported(fldPath, internalcmapi.SecretFormatProfile("Nginx"), []string{"HAProxy", "EnvoySDS"}),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.AdditionalCertificateOutputFormats, test.featureEnabled)()
+			gotErr := validateSecretFormatProfile(test.spec, field.NewPath("spec"))
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
+
 func Test_validateLiteralSubject(t *testing.T) {
 	fldPath := field.NewPath("spec")
 	tests := map[string]struct {
@@ -1019,3 +1607,105 @@ func Test_validateLiteralSubject(t *testing.T) {
 		})
 	}
 }
+
+func Test_validatePrivateKey(t *testing.T) {
+	fldPath := field.NewPath("spec", "privateKey")
+	tests := map[string]struct {
+		featureEnabled bool
+		pk             *internalcmapi.CertificatePrivateKey
+		expErr         field.ErrorList
+	}{
+		"composite keys are forbidden if the feature gate is disabled": {
+			featureEnabled: false,
+			pk:             &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.MLDSA65ECDSAP256KeyAlgorithm},
+			expErr: field.ErrorList{
+				field.Forbidden(fldPath.Child("algorithm"), "Feature gate ExperimentalCompositeKeys must be enabled on both webhook and controller to use the experimental `MLDSA65-ECDSA-P256` private key algorithm"),
+			},
+		},
+		"composite keys are allowed if the feature gate is enabled": {
+			featureEnabled: true,
+			pk:             &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.MLDSA65ECDSAP256KeyAlgorithm},
+			expErr:         field.ErrorList{},
+		},
+		"the feature gate does not affect other algorithms": {
+			featureEnabled: false,
+			pk:             &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.ECDSAKeyAlgorithm},
+			expErr:         field.ErrorList{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.ExperimentalCompositeKeys, test.featureEnabled)()
+			gotErr := validatePrivateKey(test.pk, fldPath)
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
+
+func Test_validatePrivateKeyRotationSchedule(t *testing.T) {
+	fldPath := field.NewPath("spec", "privateKey")
+	tests := map[string]struct {
+		pk     *internalcmapi.CertificatePrivateKey
+		expErr field.ErrorList
+	}{
+		"a schedule with renewals is valid": {
+			pk: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy:   internalcmapi.RotationPolicyScheduled,
+				RotationSchedule: &internalcmapi.PrivateKeyRotationSchedule{Renewals: int32Ptr(3)},
+			},
+			expErr: field.ErrorList{},
+		},
+		"a schedule with maxAge is valid": {
+			pk: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy:   internalcmapi.RotationPolicyScheduled,
+				RotationSchedule: &internalcmapi.PrivateKeyRotationSchedule{MaxAge: &metav1.Duration{Duration: 90 * 24 * time.Hour}},
+			},
+			expErr: field.ErrorList{},
+		},
+		"the Scheduled rotation policy requires a schedule": {
+			pk: &internalcmapi.CertificatePrivateKey{RotationPolicy: internalcmapi.RotationPolicyScheduled},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Child("rotationSchedule"), "at least one of renewals or maxAge must be set if rotationPolicy is Scheduled"),
+			},
+		},
+		"the Scheduled rotation policy requires a non-empty schedule": {
+			pk: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy:   internalcmapi.RotationPolicyScheduled,
+				RotationSchedule: &internalcmapi.PrivateKeyRotationSchedule{},
+			},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Child("rotationSchedule"), "at least one of renewals or maxAge must be set if rotationPolicy is Scheduled"),
+			},
+		},
+		"renewals and maxAge must be positive": {
+			pk: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy: internalcmapi.RotationPolicyScheduled,
+				RotationSchedule: &internalcmapi.PrivateKeyRotationSchedule{
+					Renewals: int32Ptr(0),
+					MaxAge:   &metav1.Duration{Duration: -time.Hour},
+				},
+			},
+			expErr: field.ErrorList{
+				field.Invalid(fldPath.Child("rotationSchedule", "renewals"), int32(0), "must be greater than 0"),
+				field.Invalid(fldPath.Child("rotationSchedule", "maxAge"), "-1h0m0s", "must be greater than 0"),
+			},
+		},
+		"a schedule is forbidden with other rotation policies": {
+			pk: &internalcmapi.CertificatePrivateKey{
+				RotationPolicy:   internalcmapi.RotationPolicyAlways,
+				RotationSchedule: &internalcmapi.PrivateKeyRotationSchedule{Renewals: int32Ptr(3)},
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(fldPath.Child("rotationSchedule"), "may only be set if rotationPolicy is Scheduled"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validatePrivateKeyRotationSchedule(test.pk, fldPath)
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
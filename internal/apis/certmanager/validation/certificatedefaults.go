@@ -0,0 +1,59 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+)
+
+// Validation functions for cert-manager CertificateDefaults types.
+
+func ValidateCertificateDefaults(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
+	defaults := obj.(*cmapi.CertificateDefaults)
+	return ValidateCertificateDefaultsSpec(&defaults.Spec, field.NewPath("spec")), certificateDefaultsWarnings(defaults)
+}
+
+func ValidateUpdateCertificateDefaults(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
+	defaults := obj.(*cmapi.CertificateDefaults)
+	return ValidateCertificateDefaultsSpec(&defaults.Spec, field.NewPath("spec")), certificateDefaultsWarnings(defaults)
+}
+
+func ValidateCertificateDefaultsSpec(spec *cmapi.CertificateDefaultsSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if spec.PrivateKey != nil {
+		el = append(el, validatePrivateKey(spec.PrivateKey, fldPath.Child("privateKey"))...)
+	}
+	if spec.RevisionHistoryLimit != nil && *spec.RevisionHistoryLimit < 1 {
+		el = append(el, field.Invalid(fldPath.Child("revisionHistoryLimit"), *spec.RevisionHistoryLimit, "must not be less than 1"))
+	}
+	return el
+}
+
+// certificateDefaultsWarnings warns when the resource will be ignored because
+// it is not named CertificateDefaultsName.
+func certificateDefaultsWarnings(defaults *cmapi.CertificateDefaults) []string {
+	if defaults.Name == cmapi.CertificateDefaultsName {
+		return nil
+	}
+	return []string{fmt.Sprintf("only the CertificateDefaults named %q is applied to Certificates, %q will be ignored", cmapi.CertificateDefaultsName, defaults.Name)}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+)
+
+func TestValidateCertificateDefaults(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	objectMeta := metav1.ObjectMeta{Name: cmapi.CertificateDefaultsName}
+
+	scenarios := map[string]struct {
+		defaults  *cmapi.CertificateDefaults
+		expectedE field.ErrorList
+		expectedW []string
+	}{
+		"valid defaults": {
+			defaults: &cmapi.CertificateDefaults{
+				ObjectMeta: objectMeta,
+				Spec: cmapi.CertificateDefaultsSpec{
+					PrivateKey: &cmapi.CertificatePrivateKey{
+						Algorithm:      cmapi.ECDSAKeyAlgorithm,
+						Size:           384,
+						RotationPolicy: cmapi.RotationPolicyAlways,
+					},
+					RevisionHistoryLimit: pointer.Int32(1),
+				},
+			},
+			expectedE: field.ErrorList{},
+		},
+		"invalid private key size and revision history limit": {
+			defaults: &cmapi.CertificateDefaults{
+				ObjectMeta: objectMeta,
+				Spec: cmapi.CertificateDefaultsSpec{
+					PrivateKey:           &cmapi.CertificatePrivateKey{Size: 1024},
+					RevisionHistoryLimit: pointer.Int32(0),
+				},
+			},
+			expectedE: field.ErrorList{
+				field.Invalid(fldPath.Child("privateKey", "size"), 1024, "must be between 2048 & 8192 for rsa keyAlgorithm"),
+				field.Invalid(fldPath.Child("revisionHistoryLimit"), int32(0), "must not be less than 1"),
+			},
+		},
+		"warns when the name is not default": {
+			defaults: &cmapi.CertificateDefaults{
+				ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			},
+			expectedE: field.ErrorList{},
+			expectedW: []string{`only the CertificateDefaults named "default" is applied to Certificates, "other" will be ignored`},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			gotE, gotW := ValidateCertificateDefaults(nil, s.defaults)
+			if !reflect.DeepEqual(gotE, s.expectedE) {
+				t.Errorf("Expected errors %v but got %v", s.expectedE, gotE)
+			}
+			if !reflect.DeepEqual(gotW, s.expectedW) {
+				t.Errorf("Expected warnings %v but got %v", s.expectedW, gotW)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package validation
+
+import (
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// Validation functions for cert-manager CertificateProfile types.
+
+func ValidateCertificateProfile(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
+	profile := obj.(*internalcmapi.CertificateProfile)
+	return ValidateCertificateProfileSpec(&profile.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateUpdateCertificateProfile(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
+	profile := obj.(*internalcmapi.CertificateProfile)
+	return ValidateCertificateProfileSpec(&profile.Spec, field.NewPath("spec")), nil
+}
+
+// ValidateCertificateProfileSpec validates the fields of a profile on their
+// own. Certificates referencing the profile are validated again once the
+// profile has been applied to them.
+func ValidateCertificateProfileSpec(spec *internalcmapi.CertificateProfileSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if spec.PrivateKey != nil {
+		el = append(el, validatePrivateKey(spec.PrivateKey, fldPath.Child("privateKey"))...)
+	}
+	if spec.Duration != nil && spec.Duration.Duration < cmapi.MinimumCertificateDuration {
+		el = append(el, field.Invalid(fldPath.Child("duration"), spec.Duration.Duration, fmt.Sprintf("certificate duration must be greater than %s", cmapi.MinimumCertificateDuration)))
+	}
+	for i, u := range spec.Usages {
+		_, kok := util.KeyUsageType(cmapi.KeyUsage(u))
+		_, ekok := util.ExtKeyUsageType(cmapi.KeyUsage(u))
+		if !kok && !ekok {
+			el = append(el, field.Invalid(fldPath.Child("usages").Index(i), u, "unknown keyusage"))
+		}
+	}
+	return el
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package validation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+)
+
+func TestValidateCertificateProfile(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	objectMeta := metav1.ObjectMeta{Name: "server"}
+
+	scenarios := map[string]struct {
+		profile   *cmapi.CertificateProfile
+		expectedE field.ErrorList
+	}{
+		"valid profile": {
+			profile: &cmapi.CertificateProfile{
+				ObjectMeta: objectMeta,
+				Spec: cmapi.CertificateProfileSpec{
+					PrivateKey: &cmapi.CertificatePrivateKey{
+						Algorithm: cmapi.ECDSAKeyAlgorithm,
+						Size:      384,
+					},
+					Duration: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+					Usages:   []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth},
+					Subject:  &cmapi.X509Subject{Organizations: []string{"Example Org"}},
+					Keystores: &cmapi.CertificateKeystores{
+						PKCS12: &cmapi.PKCS12Keystore{
+							Create:            true,
+							PasswordSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "keystore-password"}, Key: "password"},
+						},
+					},
+				},
+			},
+			expectedE: field.ErrorList{},
+		},
+		"empty profile": {
+			profile:   &cmapi.CertificateProfile{ObjectMeta: objectMeta},
+			expectedE: field.ErrorList{},
+		},
+		"invalid private key size, duration and usage": {
+			profile: &cmapi.CertificateProfile{
+				ObjectMeta: objectMeta,
+				Spec: cmapi.CertificateProfileSpec{
+					PrivateKey: &cmapi.CertificatePrivateKey{Size: 1024},
+					Duration:   &metav1.Duration{Duration: time.Minute},
+					Usages:     []cmapi.KeyUsage{cmapi.UsageServerAuth, "nonexistent"},
+				},
+			},
+			expectedE: field.ErrorList{
+				field.Invalid(fldPath.Child("privateKey", "size"), 1024, "must be between 2048 & 8192 for rsa keyAlgorithm"),
+				field.Invalid(fldPath.Child("duration"), time.Minute, "certificate duration must be greater than 5m0s"),
+				field.Invalid(fldPath.Child("usages").Index(1), cmapi.KeyUsage("nonexistent"), "unknown keyusage"),
+			},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			gotE, gotW := ValidateCertificateProfile(nil, s.profile)
+			if !reflect.DeepEqual(gotE, s.expectedE) {
+				t.Errorf("Expected errors %v but got %v", s.expectedE, gotE)
+			}
+			if len(gotW) > 0 {
+				t.Errorf("Expected no warnings but got %v", gotW)
+			}
+		})
+	}
+}
@@ -17,8 +17,12 @@ limitations under the License.
 package validation
 
 import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/asn1"
+	"encoding/hex"
 	"fmt"
 	"reflect"
 	"strings"
@@ -41,10 +45,11 @@ var defaultInternalKeyUsages = []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cma
 func ValidateCertificateRequest(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
 	cr := obj.(*cmapi.CertificateRequest)
 	allErrs := ValidateCertificateRequestSpec(&cr.Spec, field.NewPath("spec"), true)
+	allErrs = append(allErrs, validateFIPSCertificateRequestSpec(&cr.Spec, field.NewPath("spec"))...)
 	allErrs = append(allErrs,
 		ValidateCertificateRequestApprovalCondition(cr.Status.Conditions, field.NewPath("status", "conditions"))...)
 
-	return allErrs, nil
+	return allErrs, certificateRequestSpecWarnings(&cr.Spec, field.NewPath("spec"))
 }
 
 func ValidateUpdateCertificateRequest(a *admissionv1.AdmissionRequest, oldObj, newObj runtime.Object) (field.ErrorList, []string) {
@@ -83,14 +88,35 @@ func validateCertificateRequestAnnotations(objA, objB *cmapi.CertificateRequest,
 	return el
 }
 
+// validateRequestSHA256 validates the digest which pins the request loaded
+// from the Secret referenced by requestSecretRef. Without it, the request
+// could be changed after the CertificateRequest has been approved.
+func validateRequestSHA256(digest string, fldPath *field.Path) field.ErrorList {
+	if len(digest) == 0 {
+		return field.ErrorList{field.Required(fldPath, "must be specified together with requestSecretRef")}
+	}
+	if b, err := hex.DecodeString(digest); err != nil || len(b) != sha256.Size {
+		return field.ErrorList{field.Invalid(fldPath, digest, "must be a hex-encoded SHA-256 digest")}
+	}
+	return nil
+}
+
 func ValidateCertificateRequestSpec(crSpec *cmapi.CertificateRequestSpec, fldPath *field.Path, validateCSRContent bool) field.ErrorList {
 	el := field.ErrorList{}
 
 	el = append(el, validateIssuerRef(crSpec.IssuerRef, fldPath)...)
 
-	if len(crSpec.Request) == 0 {
+	switch {
+	case len(crSpec.Request) > 0 && crSpec.RequestSecretRef != nil:
+		el = append(el, field.Forbidden(fldPath.Child("requestSecretRef"), "may not be specified together with request"))
+	case crSpec.RequestSecretRef != nil:
+		// The CSR is loaded from the Secret when the request is signed, so
+		// its content cannot be validated here.
+		el = append(el, ValidateSecretKeySelector(crSpec.RequestSecretRef, fldPath.Child("requestSecretRef"))...)
+		el = append(el, validateRequestSHA256(crSpec.RequestSHA256, fldPath.Child("requestSHA256"))...)
+	case len(crSpec.Request) == 0:
 		el = append(el, field.Required(fldPath.Child("request"), "must be specified"))
-	} else {
+	default:
 		csr, err := pki.DecodeX509CertificateRequestBytes(crSpec.Request)
 		if err != nil {
 			el = append(el, field.Invalid(fldPath.Child("request"), crSpec.Request, fmt.Sprintf("failed to decode csr: %s", err)))
@@ -110,9 +136,42 @@ func ValidateCertificateRequestSpec(crSpec *cmapi.CertificateRequestSpec, fldPat
 		}
 	}
 
+	if crSpec.RequestSecretRef == nil && len(crSpec.RequestSHA256) > 0 {
+		el = append(el, field.Forbidden(fldPath.Child("requestSHA256"), "may only be specified together with requestSecretRef"))
+	}
+
 	return el
 }
 
+// certificateRequestSpecWarnings returns warnings for CSRs which are valid,
+// but which use weak keys or signature algorithms. CSRs generated by
+// cert-manager never do, so these are only raised for CSRs created by users.
+// Problems decoding the CSR are reported as errors by
+// ValidateCertificateRequestSpec.
+func certificateRequestSpecWarnings(crSpec *cmapi.CertificateRequestSpec, fldPath *field.Path) []string {
+	csr, err := pki.DecodeX509CertificateRequestBytes(crSpec.Request)
+	if err != nil {
+		return nil
+	}
+
+	var warnings []string
+	switch pub := csr.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if size := pub.N.BitLen(); size < minimumRSAKeySize {
+			warnings = append(warnings, fmt.Sprintf("%s has a %d bit RSA public key; %s", fldPath.Child("request"), size, weakKeySize))
+		}
+	case *ecdsa.PublicKey:
+		if size := pub.Curve.Params().BitSize; size < minimumECDSAKeySize {
+			warnings = append(warnings, fmt.Sprintf("%s has a %d bit ECDSA public key; %s", fldPath.Child("request"), size, weakKeySize))
+		}
+	}
+	switch csr.SignatureAlgorithm {
+	case x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+		warnings = append(warnings, fmt.Sprintf("%s is signed using %s; %s", fldPath.Child("request"), csr.SignatureAlgorithm, weakSignatureAlgorithm))
+	}
+	return warnings
+}
+
 // ValidateCertificateRequestApprovalCondition will ensure that only a single
 // 'Approved' or 'Denied' condition may exist, and that they are set to True.
 func ValidateCertificateRequestApprovalCondition(crConds []cmapi.CertificateRequestCondition, fldPath *field.Path) field.ErrorList {
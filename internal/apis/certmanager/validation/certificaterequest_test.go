@@ -18,6 +18,9 @@ package validation
 
 import (
 	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/pem"
 	"reflect"
 	"testing"
@@ -465,6 +468,7 @@ func TestValidateCertificateRequestUpdate(t *testing.T) {
 func TestValidateCertificateRequest(t *testing.T) {
 	fldPath := field.NewPath("spec")
 	fldPathConditions := field.NewPath("status", "conditions")
+	testRequestSHA256 := "d2a84f4b8b650937ec8f73cd8be2c74add5a911ba64df27458ed8229da804a26"
 
 	tests := map[string]struct {
 		cr    *cminternal.CertificateRequest
@@ -483,6 +487,117 @@ func TestValidateCertificateRequest(t *testing.T) {
 			a:     someAdmissionRequest,
 			wantE: []*field.Error{},
 		},
+		"Test csr referenced by requestSecretRef": {
+			cr: &cminternal.CertificateRequest{
+				Spec: cminternal.CertificateRequestSpec{
+					RequestSecretRef: &cminternalmeta.SecretKeySelector{
+						LocalObjectReference: cminternalmeta.LocalObjectReference{Name: "csr"},
+						Key:                  "tls.csr",
+					},
+					RequestSHA256: testRequestSHA256,
+					IssuerRef:     validIssuerRef,
+				},
+			},
+			a:     someAdmissionRequest,
+			wantE: []*field.Error{},
+		},
+		"Test requestSecretRef without a key errors": {
+			cr: &cminternal.CertificateRequest{
+				Spec: cminternal.CertificateRequestSpec{
+					RequestSecretRef: &cminternalmeta.SecretKeySelector{
+						LocalObjectReference: cminternalmeta.LocalObjectReference{Name: "csr"},
+					},
+					RequestSHA256: testRequestSHA256,
+					IssuerRef:     validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			wantE: []*field.Error{
+				{Type: field.ErrorTypeRequired, Field: fldPath.Child("requestSecretRef", "key").String(), Detail: "secret key is required"},
+			},
+		},
+		"Test request and requestSecretRef both specified errors": {
+			cr: &cminternal.CertificateRequest{
+				Spec: cminternal.CertificateRequestSpec{
+					Request: mustGenerateCSR(t, gen.Certificate("test", gen.SetCertificateDNSNames("example.com"))),
+					RequestSecretRef: &cminternalmeta.SecretKeySelector{
+						LocalObjectReference: cminternalmeta.LocalObjectReference{Name: "csr"},
+						Key:                  "tls.csr",
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			wantE: []*field.Error{
+				field.Forbidden(fldPath.Child("requestSecretRef"), "may not be specified together with request"),
+			},
+		},
+		"Test requestSecretRef without requestSHA256 errors": {
+			cr: &cminternal.CertificateRequest{
+				Spec: cminternal.CertificateRequestSpec{
+					RequestSecretRef: &cminternalmeta.SecretKeySelector{
+						LocalObjectReference: cminternalmeta.LocalObjectReference{Name: "csr"},
+						Key:                  "tls.csr",
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			wantE: []*field.Error{
+				{Type: field.ErrorTypeRequired, Field: fldPath.Child("requestSHA256").String(), Detail: "must be specified together with requestSecretRef"},
+			},
+		},
+		"Test requestSHA256 which is not a SHA-256 digest errors": {
+			cr: &cminternal.CertificateRequest{
+				Spec: cminternal.CertificateRequestSpec{
+					RequestSecretRef: &cminternalmeta.SecretKeySelector{
+						LocalObjectReference: cminternalmeta.LocalObjectReference{Name: "csr"},
+						Key:                  "tls.csr",
+					},
+					RequestSHA256: "abcd",
+					IssuerRef:     validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			wantE: []*field.Error{
+				{Type: field.ErrorTypeInvalid, Field: fldPath.Child("requestSHA256").String(), Detail: "must be a hex-encoded SHA-256 digest"},
+			},
+		},
+		"Test requestSHA256 without requestSecretRef errors": {
+			cr: &cminternal.CertificateRequest{
+				Spec: cminternal.CertificateRequestSpec{
+					Request:       mustGenerateCSR(t, gen.Certificate("test", gen.SetCertificateDNSNames("example.com"))),
+					RequestSHA256: testRequestSHA256,
+					IssuerRef:     validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			wantE: []*field.Error{
+				field.Forbidden(fldPath.Child("requestSHA256"), "may only be specified together with requestSecretRef"),
+			},
+		},
+		"Test csr with a weak RSA key warns": {
+			cr: &cminternal.CertificateRequest{
+				Spec: cminternal.CertificateRequestSpec{
+					Request:   mustGenerateCSRWithKey(t, gen.Certificate("test", gen.SetCertificateDNSNames("example.com")), 1024, x509.SHA256WithRSA),
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a:     someAdmissionRequest,
+			wantE: []*field.Error{},
+			wantW: []string{"spec.request has a 1024 bit RSA public key; keys of this size are considered weak and may be rejected by issuers and clients"},
+		},
+		"Test csr signed using SHA-1 warns": {
+			cr: &cminternal.CertificateRequest{
+				Spec: cminternal.CertificateRequestSpec{
+					Request:   mustGenerateCSRWithKey(t, gen.Certificate("test", gen.SetCertificateDNSNames("example.com")), 2048, x509.SHA1WithRSA),
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a:     someAdmissionRequest,
+			wantE: []*field.Error{},
+			wantW: []string{"spec.request is signed using SHA1-RSA; SHA-1 and MD5 signatures are considered insecure and may be rejected by issuers"},
+		},
 		"Test csr with double signature usages": {
 			cr: &cminternal.CertificateRequest{
 				Spec: cminternal.CertificateRequestSpec{
@@ -803,8 +918,15 @@ func TestValidateCertificateRequest(t *testing.T) {
 }
 
 func mustGenerateCSR(t *testing.T, crt *cmapi.Certificate) []byte {
-	// Create a new private key
-	pk, err := utilpki.GenerateRSAPrivateKey(2048)
+	return mustGenerateCSRWithKey(t, crt, 2048, x509.UnknownSignatureAlgorithm)
+}
+
+// mustGenerateCSRWithKey generates a CSR for the certificate using a new RSA
+// key of the given size, signed using the given algorithm.
+func mustGenerateCSRWithKey(t *testing.T, crt *cmapi.Certificate, keySize int, signatureAlgorithm x509.SignatureAlgorithm) []byte {
+	// Create a new private key. The key is not generated using
+	// utilpki.GenerateRSAPrivateKey, which refuses to generate weak keys.
+	pk, err := rsa.GenerateKey(rand.Reader, keySize)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -813,6 +935,9 @@ func mustGenerateCSR(t *testing.T, crt *cmapi.Certificate) []byte {
 	if err != nil {
 		t.Fatal(err)
 	}
+	if signatureAlgorithm != x509.UnknownSignatureAlgorithm {
+		x509CSR.SignatureAlgorithm = signatureAlgorithm
+	}
 	csrDER, err := utilpki.EncodeCSR(x509CSR, pk)
 	if err != nil {
 		t.Fatal(err)
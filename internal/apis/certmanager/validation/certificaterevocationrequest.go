@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// Validation functions for cert-manager CertificateRevocationRequest types.
+
+var supportedRevocationReasons = []string{
+	string(internalcmapi.RevocationReasonUnspecified),
+	string(internalcmapi.RevocationReasonKeyCompromise),
+	string(internalcmapi.RevocationReasonCACompromise),
+	string(internalcmapi.RevocationReasonAffiliationChanged),
+	string(internalcmapi.RevocationReasonSuperseded),
+	string(internalcmapi.RevocationReasonCessationOfOperation),
+	string(internalcmapi.RevocationReasonPrivilegeWithdrawn),
+}
+
+func ValidateCertificateRevocationRequest(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
+	crr := obj.(*internalcmapi.CertificateRevocationRequest)
+	return ValidateCertificateRevocationRequestSpec(&crr.Spec, field.NewPath("spec")), nil
+}
+
+// ValidateUpdateCertificateRevocationRequest forbids changing the spec, so
+// that the certificate a revocation applies to never changes once it has been
+// published. The only exception is the issuerRef and serialNumber of a
+// revocation which references a Certificate, which are filled in once from
+// the Certificate.
+func ValidateUpdateCertificateRevocationRequest(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
+	oldCRR, crr := oldObj.(*internalcmapi.CertificateRevocationRequest), obj.(*internalcmapi.CertificateRevocationRequest)
+
+	el := ValidateCertificateRevocationRequestSpec(&crr.Spec, field.NewPath("spec"))
+	oldSpec := oldCRR.Spec
+	if !certificateRevocationRequestResolved(&oldSpec) {
+		oldSpec.IssuerRef, oldSpec.SerialNumber = crr.Spec.IssuerRef, crr.Spec.SerialNumber
+	}
+	if !reflect.DeepEqual(oldSpec, crr.Spec) {
+		el = append(el, field.Forbidden(field.NewPath("spec"), "cannot change spec after creation"))
+	}
+	return el, nil
+}
+
+// ValidateCertificateRevocationRequestSpec checks that a revocation refers to
+// a cert-manager.io Issuer or ClusterIssuer, and that the serial number and
+// reason are valid. The issuer and serial number may be omitted together if
+// the revocation references a Certificate.
+func ValidateCertificateRevocationRequestSpec(spec *internalcmapi.CertificateRevocationRequestSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if spec.CertificateRef != nil && spec.CertificateRef.Name == "" {
+		el = append(el, field.Required(fldPath.Child("certificateRef", "name"), "must be specified"))
+	}
+
+	if certificateRevocationRequestResolved(spec) {
+		el = append(el, validateCertificateRevocationRequestTarget(spec, fldPath)...)
+	}
+
+	if spec.Reason != "" {
+		valid := false
+		for _, r := range supportedRevocationReasons {
+			if string(spec.Reason) == r {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			el = append(el, field.NotSupported(fldPath.Child("reason"), spec.Reason, supportedRevocationReasons))
+		}
+	}
+	return el
+}
+
+// certificateRevocationRequestResolved returns false if the revocation
+// references a Certificate and neither its issuerRef nor its serialNumber
+// have been filled in yet.
+func certificateRevocationRequestResolved(spec *internalcmapi.CertificateRevocationRequestSpec) bool {
+	return spec.CertificateRef == nil || spec.IssuerRef != (cmmeta.ObjectReference{}) || spec.SerialNumber != ""
+}
+
+func validateCertificateRevocationRequestTarget(spec *internalcmapi.CertificateRevocationRequestSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	issuerRefPath := fldPath.Child("issuerRef")
+	if spec.IssuerRef.Name == "" {
+		el = append(el, field.Required(issuerRefPath.Child("name"), "must be specified"))
+	}
+	if spec.IssuerRef.Group != "" && spec.IssuerRef.Group != internalcmapi.SchemeGroupVersion.Group {
+		el = append(el, field.Invalid(issuerRefPath.Child("group"), spec.IssuerRef.Group, "must be empty or "+internalcmapi.SchemeGroupVersion.Group))
+	}
+	switch spec.IssuerRef.Kind {
+	case "", internalcmapi.IssuerKind, internalcmapi.ClusterIssuerKind:
+	default:
+		el = append(el, field.Invalid(issuerRefPath.Child("kind"), spec.IssuerRef.Kind, "must be one of Issuer or ClusterIssuer"))
+	}
+
+	if spec.SerialNumber == "" {
+		el = append(el, field.Required(fldPath.Child("serialNumber"), "must be specified"))
+	} else if _, err := pki.ParseSerialNumber(spec.SerialNumber); err != nil {
+		el = append(el, field.Invalid(fldPath.Child("serialNumber"), spec.SerialNumber, "must be a hexadecimal string"))
+	}
+	return el
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+)
+
+func TestValidateCertificateRevocationRequest(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	objectMeta := metav1.ObjectMeta{Name: "revoke", Namespace: "default"}
+	validSpec := cmapi.CertificateRevocationRequestSpec{
+		IssuerRef:    cmmeta.ObjectReference{Name: "ca"},
+		SerialNumber: "1a:2b:3c",
+	}
+
+	scenarios := map[string]struct {
+		spec      func(*cmapi.CertificateRevocationRequestSpec)
+		expectedE field.ErrorList
+	}{
+		"revocation of a certificate issued by an Issuer": {
+			spec:      func(*cmapi.CertificateRevocationRequestSpec) {},
+			expectedE: field.ErrorList{},
+		},
+		"revocation of a certificate issued by a ClusterIssuer with a reason": {
+			spec: func(spec *cmapi.CertificateRevocationRequestSpec) {
+				spec.IssuerRef = cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer", Group: "cert-manager.io"}
+				spec.Reason = cmapi.RevocationReasonKeyCompromise
+			},
+			expectedE: field.ErrorList{},
+		},
+		"missing issuer name": {
+			spec: func(spec *cmapi.CertificateRevocationRequestSpec) {
+				spec.IssuerRef = cmmeta.ObjectReference{Kind: "Issuer"}
+			},
+			expectedE: field.ErrorList{
+				field.Required(fldPath.Child("issuerRef", "name"), "must be specified"),
+			},
+		},
+		"external issuer": {
+			spec: func(spec *cmapi.CertificateRevocationRequestSpec) {
+				spec.IssuerRef = cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "example.com"}
+			},
+			expectedE: field.ErrorList{
+				field.Invalid(fldPath.Child("issuerRef", "group"), "example.com", "must be empty or cert-manager.io"),
+			},
+		},
+		"issuer alias": {
+			spec: func(spec *cmapi.CertificateRevocationRequestSpec) {
+				spec.IssuerRef = cmmeta.ObjectReference{Name: "ca", Kind: "IssuerAlias"}
+			},
+			expectedE: field.ErrorList{
+				field.Invalid(fldPath.Child("issuerRef", "kind"), "IssuerAlias", "must be one of Issuer or ClusterIssuer"),
+			},
+		},
+		"missing serial number": {
+			spec: func(spec *cmapi.CertificateRevocationRequestSpec) {
+				spec.SerialNumber = ""
+			},
+			expectedE: field.ErrorList{
+				field.Required(fldPath.Child("serialNumber"), "must be specified"),
+			},
+		},
+		"serial number which is not hexadecimal": {
+			spec: func(spec *cmapi.CertificateRevocationRequestSpec) {
+				spec.SerialNumber = "xyz"
+			},
+			expectedE: field.ErrorList{
+				field.Invalid(fldPath.Child("serialNumber"), "xyz", "must be a hexadecimal string"),
+			},
+		},
+		"revocation of the certificate of a Certificate": {
+			spec: func(spec *cmapi.CertificateRevocationRequestSpec) {
+				spec.CertificateRef = &cmmeta.LocalObjectReference{Name: "example"}
+				spec.IssuerRef = cmmeta.ObjectReference{}
+				spec.SerialNumber = ""
+			},
+			expectedE: field.ErrorList{},
+		},
+		"revocation of the certificate of a Certificate with its issuer and serial number": {
+			spec: func(spec *cmapi.CertificateRevocationRequestSpec) {
+				spec.CertificateRef = &cmmeta.LocalObjectReference{Name: "example"}
+			},
+			expectedE: field.ErrorList{},
+		},
+		"revocation of the certificate of a Certificate with only a serial number": {
+			spec: func(spec *cmapi.CertificateRevocationRequestSpec) {
+				spec.CertificateRef = &cmmeta.LocalObjectReference{Name: "example"}
+				spec.IssuerRef = cmmeta.ObjectReference{}
+			},
+			expectedE: field.ErrorList{
+				field.Required(fldPath.Child("issuerRef", "name"), "must be specified"),
+			},
+		},
+		"missing certificate name": {
+			spec: func(spec *cmapi.CertificateRevocationRequestSpec) {
+				spec.CertificateRef = &cmmeta.LocalObjectReference{}
+				spec.IssuerRef = cmmeta.ObjectReference{}
+				spec.SerialNumber = ""
+			},
+			expectedE: field.ErrorList{
+				field.Required(fldPath.Child("certificateRef", "name"), "must be specified"),
+			},
+		},
+		"unknown reason": {
+			spec: func(spec *cmapi.CertificateRevocationRequestSpec) {
+				spec.Reason = "RemoveFromCRL"
+			},
+			expectedE: field.ErrorList{
+				field.NotSupported(fldPath.Child("reason"), cmapi.RevocationReason("RemoveFromCRL"), supportedRevocationReasons),
+			},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			crr := &cmapi.CertificateRevocationRequest{ObjectMeta: objectMeta, Spec: validSpec}
+			s.spec(&crr.Spec)
+			gotE, gotW := ValidateCertificateRevocationRequest(nil, crr)
+			if !reflect.DeepEqual(gotE, s.expectedE) {
+				t.Errorf("Expected errors %v but got %v", s.expectedE, gotE)
+			}
+			if len(gotW) > 0 {
+				t.Errorf("Expected no warnings but got %v", gotW)
+			}
+		})
+	}
+}
+
+func TestValidateUpdateCertificateRevocationRequest(t *testing.T) {
+	oldCRR := &cmapi.CertificateRevocationRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "revoke", Namespace: "default"},
+		Spec: cmapi.CertificateRevocationRequestSpec{
+			IssuerRef:    cmmeta.ObjectReference{Name: "ca"},
+			SerialNumber: "1a2b3c",
+		},
+	}
+
+	statusUpdate := oldCRR.DeepCopy()
+	statusUpdate.Status.Conditions = []cmapi.CertificateRevocationRequestCondition{{Type: cmapi.CertificateRevocationRequestConditionRevoked, Status: cmmeta.ConditionTrue}}
+	gotE, _ := ValidateUpdateCertificateRevocationRequest(nil, oldCRR, statusUpdate)
+	if len(gotE) > 0 {
+		t.Errorf("Expected no errors updating the status but got %v", gotE)
+	}
+
+	specUpdate := oldCRR.DeepCopy()
+	specUpdate.Spec.SerialNumber = "4d5e6f"
+	gotE, _ = ValidateUpdateCertificateRevocationRequest(nil, oldCRR, specUpdate)
+	expectedE := field.ErrorList{field.Forbidden(field.NewPath("spec"), "cannot change spec after creation")}
+	if !reflect.DeepEqual(gotE, expectedE) {
+		t.Errorf("Expected errors %v but got %v", expectedE, gotE)
+	}
+}
+
+func TestValidateUpdateCertificateRevocationRequestCertificateRef(t *testing.T) {
+	oldCRR := &cmapi.CertificateRevocationRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "revoke", Namespace: "default"},
+		Spec: cmapi.CertificateRevocationRequestSpec{
+			CertificateRef: &cmmeta.LocalObjectReference{Name: "example"},
+		},
+	}
+	forbidden := field.ErrorList{field.Forbidden(field.NewPath("spec"), "cannot change spec after creation")}
+
+	resolved := oldCRR.DeepCopy()
+	resolved.Spec.IssuerRef = cmmeta.ObjectReference{Name: "ca"}
+	resolved.Spec.SerialNumber = "1a2b3c"
+	if gotE, _ := ValidateUpdateCertificateRevocationRequest(nil, oldCRR, resolved); len(gotE) > 0 {
+		t.Errorf("Expected no errors filling in the issuer and serial number but got %v", gotE)
+	}
+
+	changed := resolved.DeepCopy()
+	changed.Spec.SerialNumber = "4d5e6f"
+	if gotE, _ := ValidateUpdateCertificateRevocationRequest(nil, resolved, changed); !reflect.DeepEqual(gotE, forbidden) {
+		t.Errorf("Expected errors %v changing the serial number but got %v", forbidden, gotE)
+	}
+
+	otherCertificate := resolved.DeepCopy()
+	otherCertificate.Spec.CertificateRef.Name = "other"
+	if gotE, _ := ValidateUpdateCertificateRevocationRequest(nil, oldCRR, otherCertificate); !reflect.DeepEqual(gotE, forbidden) {
+		t.Errorf("Expected errors %v changing the certificate but got %v", forbidden, gotE)
+	}
+}
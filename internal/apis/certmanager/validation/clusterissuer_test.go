@@ -23,6 +23,7 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
 	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
 )
 
@@ -32,7 +33,25 @@ func TestValidateClusterIssuer(t *testing.T) {
 		a         *admissionv1.AdmissionRequest
 		expectedE []*field.Error
 		expectedW []string
-	}{}
+	}{
+		"per-namespace external account binding": {
+			cfg: &cmapi.ClusterIssuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Server:     "https://acme.example.com/directory",
+							PrivateKey: validSecretKeyRef,
+							ExternalAccountBinding: &cmacme.ACMEExternalAccountBinding{
+								KeyID:        "kid",
+								Key:          validSecretKeyRef,
+								PerNamespace: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"crypto/dsa"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const notFIPSApproved = "not approved for FIPS 140-3"
+
+// validateFIPSCertificateRequestSpec rejects CSRs whose public key or
+// signature algorithm is not approved for FIPS 140-3, if the FIPS feature
+// gate is enabled. Problems decoding the CSR are reported as errors by
+// ValidateCertificateRequestSpec.
+func validateFIPSCertificateRequestSpec(crSpec *cmapi.CertificateRequestSpec, fldPath *field.Path) field.ErrorList {
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.FIPS) {
+		return nil
+	}
+	csr, err := pki.DecodeX509CertificateRequestBytes(crSpec.Request)
+	if err != nil {
+		return nil
+	}
+
+	el := field.ErrorList{}
+	fldPath = fldPath.Child("request")
+	switch pub := csr.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if size := pub.N.BitLen(); size < minimumRSAKeySize {
+			el = append(el, field.Forbidden(fldPath, fmt.Sprintf("%d bit RSA public keys are %s", size, notFIPSApproved)))
+		}
+	case *ecdsa.PublicKey:
+		if size := pub.Curve.Params().BitSize; size < minimumECDSAKeySize {
+			el = append(el, field.Forbidden(fldPath, fmt.Sprintf("%d bit ECDSA public keys are %s", size, notFIPSApproved)))
+		}
+	case *dsa.PublicKey, ed25519.PublicKey:
+		el = append(el, field.Forbidden(fldPath, fmt.Sprintf("%s public keys are %s", csr.PublicKeyAlgorithm, notFIPSApproved)))
+	}
+	switch csr.SignatureAlgorithm {
+	case x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.DSAWithSHA256, x509.ECDSAWithSHA1, x509.PureEd25519:
+		el = append(el, field.Forbidden(fldPath, fmt.Sprintf("%s signatures are %s", csr.SignatureAlgorithm, notFIPSApproved)))
+	}
+	return el
+}
+
+// validateFIPSPrivateKey rejects private key algorithms which are not
+// approved for FIPS 140-3, if the FIPS feature gate is enabled. The key sizes
+// allowed for the other algorithms are all approved.
+func validateFIPSPrivateKey(pk *cmapi.CertificatePrivateKey, fldPath *field.Path) field.ErrorList {
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.FIPS) {
+		return nil
+	}
+	if pk.Algorithm == cmapi.Ed25519KeyAlgorithm || pk.Algorithm == cmapi.MLDSA65ECDSAP256KeyAlgorithm {
+		return field.ErrorList{field.Forbidden(fldPath.Child("algorithm"), fmt.Sprintf("%s private keys are %s", pk.Algorithm, notFIPSApproved))}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	cminternal "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestValidateFIPSCertificateRequestSpec(t *testing.T) {
+	fldPath := field.NewPath("spec", "request")
+	crt := gen.Certificate("test", gen.SetCertificateDNSNames("example.com"))
+
+	tests := map[string]struct {
+		featureEnabled bool
+		request        []byte
+		wantE          field.ErrorList
+	}{
+		"weak RSA key with the feature disabled": {
+			request: mustGenerateCSRWithKey(t, crt, 1024, x509.SHA256WithRSA),
+			wantE:   nil,
+		},
+		"approved RSA key and signature": {
+			featureEnabled: true,
+			request:        mustGenerateCSRWithKey(t, crt, 2048, x509.SHA256WithRSA),
+			wantE:          field.ErrorList{},
+		},
+		"weak RSA key": {
+			featureEnabled: true,
+			request:        mustGenerateCSRWithKey(t, crt, 1024, x509.SHA256WithRSA),
+			wantE: field.ErrorList{
+				field.Forbidden(fldPath, "1024 bit RSA public keys are not approved for FIPS 140-3"),
+			},
+		},
+		"SHA-1 signature": {
+			featureEnabled: true,
+			request:        mustGenerateCSRWithKey(t, crt, 2048, x509.SHA1WithRSA),
+			wantE: field.ErrorList{
+				field.Forbidden(fldPath, "SHA1-RSA signatures are not approved for FIPS 140-3"),
+			},
+		},
+		"Ed25519 key": {
+			featureEnabled: true,
+			request:        mustGenerateEd25519CSR(t),
+			wantE: field.ErrorList{
+				field.Forbidden(fldPath, "Ed25519 public keys are not approved for FIPS 140-3"),
+				field.Forbidden(fldPath, "Ed25519 signatures are not approved for FIPS 140-3"),
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.FIPS, test.featureEnabled)()
+			gotE := validateFIPSCertificateRequestSpec(&cminternal.CertificateRequestSpec{Request: test.request}, field.NewPath("spec"))
+			if !reflect.DeepEqual(gotE, test.wantE) {
+				t.Errorf("errors from validateFIPSCertificateRequestSpec() = %v, want %v", gotE, test.wantE)
+			}
+		})
+	}
+}
+
+func TestValidateFIPSPrivateKey(t *testing.T) {
+	fldPath := field.NewPath("spec", "privateKey")
+
+	tests := map[string]struct {
+		featureEnabled bool
+		pk             *cminternal.CertificatePrivateKey
+		wantE          field.ErrorList
+	}{
+		"Ed25519 key with the feature disabled": {
+			pk:    &cminternal.CertificatePrivateKey{Algorithm: cminternal.Ed25519KeyAlgorithm},
+			wantE: nil,
+		},
+		"ECDSA key": {
+			featureEnabled: true,
+			pk:             &cminternal.CertificatePrivateKey{Algorithm: cminternal.ECDSAKeyAlgorithm, Size: 384},
+			wantE:          nil,
+		},
+		"Ed25519 key": {
+			featureEnabled: true,
+			pk:             &cminternal.CertificatePrivateKey{Algorithm: cminternal.Ed25519KeyAlgorithm},
+			wantE: field.ErrorList{
+				field.Forbidden(fldPath.Child("algorithm"), "Ed25519 private keys are not approved for FIPS 140-3"),
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.FIPS, test.featureEnabled)()
+			gotE := validateFIPSPrivateKey(test.pk, fldPath)
+			if !reflect.DeepEqual(gotE, test.wantE) {
+				t.Errorf("errors from validateFIPSPrivateKey() = %v, want %v", gotE, test.wantE)
+			}
+		})
+	}
+}
+
+func mustGenerateEd25519CSR(t *testing.T) []byte {
+	_, pk, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x509CSR, err := utilpki.GenerateCSR(gen.Certificate("test",
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateKeyAlgorithm("Ed25519"),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := utilpki.EncodeCSR(x509CSR, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+}
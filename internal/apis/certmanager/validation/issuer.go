@@ -18,18 +18,31 @@ package validation
 
 import (
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
 	"strings"
+	"time"
 
+	"golang.org/x/net/http/httpguts"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager/validation/util"
 	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 // Validation functions for cert-manager Issuer types.
@@ -37,18 +50,136 @@ import (
 func ValidateIssuer(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
 	iss := obj.(*certmanager.Issuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateNamespacedIssuerSpec(&iss.Spec, field.NewPath("spec"))...)
 	return allErrs, warnings
 }
 
 func ValidateUpdateIssuer(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
 	iss := obj.(*certmanager.Issuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateNamespacedIssuerSpec(&iss.Spec, field.NewPath("spec"))...)
 	// Admission request should never be nil
 	return allErrs, warnings
 }
 
 func ValidateIssuerSpec(iss *certmanager.IssuerSpec, fldPath *field.Path) (field.ErrorList, []string) {
-	return ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	el, warnings := ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	el = append(el, validateAllowedURISchemes(iss.AllowedURISchemes, fldPath.Child("allowedURISchemes"))...)
+	el = append(el, validateAllowedUsages(iss.AllowedUsages, fldPath.Child("allowedUsages"))...)
+	if iss.RenewalWindow != nil {
+		el = append(el, validateRenewalWindow(iss.RenewalWindow, fldPath.Child("renewalWindow"))...)
+	}
+	if iss.HTTPClient != nil {
+		el = append(el, validateIssuerHTTPClient(iss.HTTPClient, fldPath.Child("httpClient"))...)
+	}
+	return el, warnings
+}
+
+// validateNamespacedIssuerSpec forbids the fields of an IssuerSpec which may
+// only be set on ClusterIssuers.
+func validateNamespacedIssuerSpec(iss *certmanager.IssuerSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if iss.ACME != nil && iss.ACME.ExternalAccountBinding != nil && iss.ACME.ExternalAccountBinding.PerNamespace {
+		el = append(el, field.Forbidden(fldPath.Child("acme", "externalAccountBinding", "perNamespace"), "may only be set on ClusterIssuers"))
+	}
+	return el
+}
+
+// uriSchemeRegexp matches a URI scheme, as defined in RFC 3986 section 3.1.
+var uriSchemeRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*$`)
+
+func validateAllowedURISchemes(schemes []string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, scheme := range schemes {
+		if !uriSchemeRegexp.MatchString(scheme) {
+			el = append(el, field.Invalid(fldPath.Index(i), scheme, "must be a URI scheme without the trailing ':', e.g. spiffe"))
+		}
+	}
+	return el
+}
+
+func validateAllowedUsages(usages []certmanager.AllowedKeyUsage, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, u := range usages {
+		usagePath := fldPath.Index(i).Child("usage")
+		_, kok := apiutil.KeyUsageType(cmapi.KeyUsage(u.Usage))
+		_, ekok := apiutil.ExtKeyUsageType(cmapi.KeyUsage(u.Usage))
+		switch {
+		case u.Usage == "":
+			el = append(el, field.Required(usagePath, "must be specified"))
+		case !kok && !ekok:
+			el = append(el, field.Invalid(usagePath, u.Usage, "unknown keyusage"))
+		}
+		for j, ns := range u.Namespaces {
+			if ns == "" {
+				el = append(el, field.Invalid(fldPath.Index(i).Child("namespaces").Index(j), ns, "must not be empty"))
+			}
+		}
+	}
+	return el
+}
+
+// validateRenewalWindow validates the renewal window of an Issuer or a
+// Certificate.
+func validateRenewalWindow(w *certmanager.RenewalWindow, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, d := range w.Days {
+		if _, err := apiutil.ParseRenewalWindowDays([]string{d}); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("days").Index(i), d, err.Error()))
+		}
+	}
+	if _, err := apiutil.ParseRenewalWindowHours(w.Hours); err != nil {
+		el = append(el, field.Invalid(fldPath.Child("hours"), w.Hours, err.Error()))
+	}
+	if w.TimeZone != "" {
+		if _, err := time.LoadLocation(w.TimeZone); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("timeZone"), w.TimeZone, "must be an IANA time zone, e.g. Europe/London"))
+		}
+	}
+	if w.ExpiryOverride != nil && w.ExpiryOverride.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("expiryOverride"), w.ExpiryOverride.Duration, "must be greater than zero"))
+	}
+	return el
+}
+
+// reservedHTTPHeaders are the headers set by cert-manager or the clients of
+// the issuers to authenticate or describe requests, which must not be
+// overridden by the headers of an issuer's HTTP client.
+var reservedHTTPHeaders = map[string]bool{
+	"Authorization":     true,
+	"Content-Length":    true,
+	"Content-Type":      true,
+	"Host":              true,
+	"User-Agent":        true,
+	"X-Vault-Namespace": true,
+	"X-Vault-Token":     true,
+}
+
+func validateIssuerHTTPClient(c *certmanager.IssuerHTTPClient, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if !httpguts.ValidHeaderFieldValue(c.UserAgentSuffix) {
+		el = append(el, field.Invalid(fldPath.Child("userAgentSuffix"), c.UserAgentSuffix, "must be a valid HTTP header value"))
+	}
+	seen := make(map[string]bool)
+	for i, h := range c.Headers {
+		namePath := fldPath.Child("headers").Index(i).Child("name")
+		name := http.CanonicalHeaderKey(h.Name)
+		switch {
+		case h.Name == "":
+			el = append(el, field.Required(namePath, "must be specified"))
+		case !httpguts.ValidHeaderFieldName(h.Name):
+			el = append(el, field.Invalid(namePath, h.Name, "must be a valid HTTP header name"))
+		case reservedHTTPHeaders[name]:
+			el = append(el, field.Forbidden(namePath, fmt.Sprintf("the %s header is set by cert-manager", name)))
+		case seen[name]:
+			el = append(el, field.Duplicate(namePath, h.Name))
+		}
+		seen[name] = true
+		if !httpguts.ValidHeaderFieldValue(h.Value) {
+			el = append(el, field.Invalid(fldPath.Child("headers").Index(i).Child("value"), h.Value, "must be a valid HTTP header value"))
+		}
+	}
+	return el
 }
 
 func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (field.ErrorList, []string) {
@@ -96,6 +227,22 @@ func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (f
 			el = append(el, ValidateVenafiIssuerConfig(iss.Venafi, fldPath.Child("venafi"))...)
 		}
 	}
+	if iss.Hub != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("hub"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+			el = append(el, ValidateHubIssuerConfig(iss.Hub, fldPath.Child("hub"))...)
+		}
+	}
+	if iss.ExternalSigner != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("externalSigner"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+			el = append(el, ValidateExternalSignerIssuerConfig(iss.ExternalSigner, fldPath.Child("externalSigner"))...)
+		}
+	}
 	if numConfigs == 0 {
 		el = append(el, field.Required(fldPath, "at least one issuer must be configured"))
 	}
@@ -112,6 +259,9 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 	if len(iss.Server) == 0 {
 		el = append(el, field.Required(fldPath.Child("server"), "acme server URL is a required field"))
 	}
+	if iss.SkipTLSVerify {
+		warnings = append(warnings, insecureACMESkipTLSVerify)
+	}
 
 	if eab := iss.ExternalAccountBinding; eab != nil {
 		eabFldPath := fldPath.Child("externalAccountBinding")
@@ -189,6 +339,52 @@ func ValidateACMEIssuerChallengeSolverHTTP01IngressConfig(ingress *cmacme.ACMECh
 	default:
 		el = append(el, field.Invalid(fldPath.Child("serviceType"), ingress.ServiceType, `must be empty, "ClusterIP" or "NodePort"`))
 	}
+	if ingress.PodTemplate != nil && ingress.PodTemplate.Spec.Resources != nil {
+		el = append(el, validateHTTP01SolverResources(ingress.PodTemplate.Spec.Resources, fldPath.Child("podTemplate", "spec", "resources"))...)
+	}
+	if ingress.BurstProfile != nil {
+		el = append(el, validateHTTP01BurstProfile(ingress.BurstProfile, fldPath.Child("burstProfile"))...)
+	}
+
+	return el
+}
+
+func validateHTTP01SolverResources(res *corev1.ResourceRequirements, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	for name, request := range res.Requests {
+		if request.Sign() < 0 {
+			el = append(el, field.Invalid(fldPath.Child("requests").Key(string(name)), request.String(), "must not be negative"))
+		}
+		if limit, ok := res.Limits[name]; ok && request.Cmp(limit) > 0 {
+			el = append(el, field.Invalid(fldPath.Child("requests").Key(string(name)), request.String(), fmt.Sprintf("must be less than or equal to the %s limit", name)))
+		}
+	}
+	for name, limit := range res.Limits {
+		if limit.Sign() < 0 {
+			el = append(el, field.Invalid(fldPath.Child("limits").Key(string(name)), limit.String(), "must not be negative"))
+		}
+	}
+
+	return el
+}
+
+func validateHTTP01BurstProfile(p *cmacme.ACMEChallengeSolverHTTP01BurstProfile, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if p.MaxReplicas < 1 {
+		el = append(el, field.Invalid(fldPath.Child("maxReplicas"), p.MaxReplicas, "must be at least 1"))
+	}
+	if p.MinReplicas != nil {
+		if *p.MinReplicas < 0 {
+			el = append(el, field.Invalid(fldPath.Child("minReplicas"), *p.MinReplicas, "must not be negative"))
+		} else if *p.MinReplicas > p.MaxReplicas {
+			el = append(el, field.Invalid(fldPath.Child("minReplicas"), *p.MinReplicas, "must not be greater than maxReplicas"))
+		}
+	}
+	if p.ChallengesPerReplica != nil && *p.ChallengesPerReplica < 1 {
+		el = append(el, field.Invalid(fldPath.Child("challengesPerReplica"), *p.ChallengesPerReplica, "must be at least 1"))
+	}
 
 	return el
 }
@@ -217,6 +413,74 @@ func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) fiel
 			el = append(el, field.Invalid(fldPath.Child("ocspServer").Index(i), ocspURL, "must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org"))
 		}
 	}
+	if iss.CRL != nil {
+		el = append(el, validateCACRL(iss.CRL, fldPath.Child("crl"))...)
+	}
+	if iss.PKCS11 != nil {
+		el = append(el, validateCAPKCS11(iss.PKCS11, fldPath.Child("pkcs11"))...)
+	}
+	if iss.SPIFFE != nil {
+		el = append(el, validateCASPIFFE(iss.SPIFFE, fldPath.Child("spiffe"))...)
+	}
+	return el
+}
+
+// minimumCRLValidity is the minimum validity of the CRLs of CA issuers, so
+// that they are not re-signed too often.
+const minimumCRLValidity = time.Hour
+
+func validateCACRL(crl *certmanager.CACRL, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(crl.ConfigMapName) == 0 {
+		el = append(el, field.Required(fldPath.Child("configMapName"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(crl.ConfigMapName) {
+			el = append(el, field.Invalid(fldPath.Child("configMapName"), crl.ConfigMapName, msg))
+		}
+	}
+	if crl.Validity != nil && crl.Validity.Duration < minimumCRLValidity {
+		el = append(el, field.Invalid(fldPath.Child("validity"), crl.Validity.Duration.String(), fmt.Sprintf("must be at least %s", minimumCRLValidity)))
+	}
+	return el
+}
+
+func validateCAPKCS11(p *certmanager.CAPKCS11, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(p.Module) == 0 {
+		el = append(el, field.Required(fldPath.Child("module"), ""))
+	} else if !path.IsAbs(p.Module) {
+		el = append(el, field.Invalid(fldPath.Child("module"), p.Module, "must be an absolute path"))
+	}
+	if len(p.TokenLabel) == 0 {
+		el = append(el, field.Required(fldPath.Child("tokenLabel"), ""))
+	}
+	if len(p.KeyLabel) == 0 && len(p.KeyID) == 0 {
+		el = append(el, field.Required(fldPath.Child("keyLabel"), "at least one of keyLabel or keyID must be set"))
+	}
+	if len(p.KeyID) > 0 {
+		if _, err := hex.DecodeString(p.KeyID); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("keyID"), p.KeyID, "must be hex encoded"))
+		}
+	}
+	el = append(el, ValidateSecretKeySelector(&p.PINSecretRef, fldPath.Child("pinSecretRef"))...)
+	return el
+}
+
+func validateCASPIFFE(sp *certmanager.CASPIFFE, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(sp.TrustDomain) == 0 {
+		return append(el, field.Required(fldPath.Child("trustDomain"), ""))
+	}
+	// The trust domain is part of the name of the trust bundle ConfigMap.
+	if msgs := validation.IsDNS1123Subdomain(sp.TrustDomain); len(msgs) > 0 {
+		for _, msg := range msgs {
+			el = append(el, field.Invalid(fldPath.Child("trustDomain"), sp.TrustDomain, msg))
+		}
+		return el
+	}
+	if name := pki.SPIFFETrustBundleConfigMapName(sp.TrustDomain); len(name) > validation.DNS1123SubdomainMaxLength {
+		el = append(el, field.TooLong(fldPath.Child("trustDomain"), sp.TrustDomain, validation.DNS1123SubdomainMaxLength-len(name)+len(sp.TrustDomain)))
+	}
 	return el
 }
 
@@ -284,6 +548,56 @@ func ValidateVenafiIssuerConfig(iss *certmanager.VenafiIssuer, fldPath *field.Pa
 		el = append(el, field.Forbidden(fldPath, "please supply one of: tpp, cloud"))
 	}
 
+	names := make(map[string]struct{}, len(iss.CustomFields))
+	for i, customField := range iss.CustomFields {
+		fldPath := fldPath.Child("customFields").Index(i)
+		if customField.Name == "" {
+			el = append(el, field.Required(fldPath.Child("name"), ""))
+		} else if _, ok := names[customField.Name]; ok {
+			el = append(el, field.Duplicate(fldPath.Child("name"), customField.Name))
+		}
+		names[customField.Name] = struct{}{}
+		if customField.Type != "" && customField.Type != certmanager.VenafiCustomFieldTypePlain {
+			el = append(el, field.NotSupported(fldPath.Child("type"), customField.Type, []string{string(certmanager.VenafiCustomFieldTypePlain)}))
+		}
+	}
+	if iss.ZoneCacheDuration != nil && iss.ZoneCacheDuration.Duration < 0 {
+		el = append(el, field.Invalid(fldPath.Child("zoneCacheDuration"), iss.ZoneCacheDuration.Duration, "must not be negative"))
+	}
+
+	return el
+}
+
+func ValidateHubIssuerConfig(iss *certmanager.HubIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(iss.Server) == 0 {
+		el = append(el, field.Required(fldPath.Child("server"), ""))
+	} else if u, err := url.Parse(iss.Server); err != nil || u.Scheme != "https" || u.Host == "" {
+		el = append(el, field.Invalid(fldPath.Child("server"), iss.Server, "must be an https URL"))
+	}
+	if len(iss.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(iss.CABundle) {
+		el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"))
+	}
+	el = append(el, ValidateSecretKeySelector(&iss.TokenSecretRef, fldPath.Child("tokenSecretRef"))...)
+	if len(iss.Namespace) == 0 {
+		el = append(el, field.Required(fldPath.Child("namespace"), ""))
+	}
+	if len(iss.IssuerRef.Name) == 0 {
+		el = append(el, field.Required(fldPath.Child("issuerRef", "name"), ""))
+	}
+	return el
+}
+
+func ValidateExternalSignerIssuerConfig(iss *certmanager.ExternalSignerIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(iss.Address) == 0 {
+		el = append(el, field.Required(fldPath.Child("address"), ""))
+	} else if host, port, err := net.SplitHostPort(iss.Address); err != nil || host == "" || port == "" {
+		el = append(el, field.Invalid(fldPath.Child("address"), iss.Address, "must be of the form host:port"))
+	}
+	if len(iss.TLSSecretRef.Name) == 0 {
+		el = append(el, field.Required(fldPath.Child("tlsSecretRef", "name"), ""))
+	}
 	return el
 }
 
@@ -293,8 +607,12 @@ var supportedTSIGAlgorithms = []string{
 	"HMACSHA1",
 	"HMACSHA256",
 	"HMACSHA512",
+	gssTSIGAlgorithm,
 }
 
+// gssTSIGAlgorithm authenticates updates with GSS-TSIG instead of a TSIG key.
+const gssTSIGAlgorithm = "GSS-TSIG"
+
 func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
@@ -308,6 +626,16 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			el = append(el, field.Invalid(fldPath.Child("cnameStrategy"), p.CNAMEStrategy, fmt.Sprintf("must be one of %q or %q", cmacme.NoneStrategy, cmacme.FollowStrategy)))
 		}
 	}
+	if len(p.ValidationZone) > 0 {
+		// challenge records can only be published in a dedicated zone if the
+		// CNAME records pointing into it are followed
+		if p.CNAMEStrategy != cmacme.FollowStrategy {
+			el = append(el, field.Forbidden(fldPath.Child("validationZone"), fmt.Sprintf("may only be set when cnameStrategy is %q", cmacme.FollowStrategy)))
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(strings.TrimSuffix(p.ValidationZone, ".")) {
+			el = append(el, field.Invalid(fldPath.Child("validationZone"), p.ValidationZone, msg))
+		}
+	}
 	numProviders := 0
 	if p.Akamai != nil {
 		numProviders++
@@ -361,6 +689,7 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 				el = append(el, field.Invalid(fldPath.Child("azureDNS", "environment"), p.AzureDNS.Environment,
 					fmt.Sprintf("must be either empty or one of %s, %s, %s or %s", cmacme.AzurePublicCloud, cmacme.AzureChinaCloud, cmacme.AzureGermanCloud, cmacme.AzureUSGovernmentCloud)))
 			}
+			el = append(el, validateAzureDNSZones(p.AzureDNS, fldPath.Child("azureDNS", "zones"))...)
 		}
 	}
 	if p.CloudDNS != nil {
@@ -376,6 +705,7 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			if len(p.CloudDNS.Project) == 0 {
 				el = append(el, field.Required(fldPath.Child("cloudDNS", "project"), ""))
 			}
+			el = append(el, validateCloudDNSZones(p.CloudDNS.Zones, fldPath.Child("cloudDNS", "zones"))...)
 		}
 	}
 	if p.Cloudflare != nil {
@@ -419,6 +749,7 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			if p.Route53.SecretAccessKeyID != nil {
 				el = append(el, ValidateSecretKeySelector(p.Route53.SecretAccessKeyID, fldPath.Child("route53", "accessKeyIDSecretRef"))...)
 			}
+			el = append(el, validateRoute53Zones(p.Route53.Zones, fldPath.Child("route53", "zones"))...)
 		}
 	}
 	if p.AcmeDNS != nil {
@@ -437,6 +768,24 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			el = append(el, ValidateSecretKeySelector(&p.DigitalOcean.Token, fldPath.Child("digitalocean", "tokenSecretRef"))...)
 		}
 	}
+	if p.Hetzner != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("hetzner"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			el = append(el, ValidateSecretKeySelector(&p.Hetzner.APIToken, fldPath.Child("hetzner", "apiTokenSecretRef"))...)
+		}
+	}
+	if p.ExternalDNS != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("externalDNS"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			if p.ExternalDNS.RecordTTL != nil && *p.ExternalDNS.RecordTTL < 0 {
+				el = append(el, field.Invalid(fldPath.Child("externalDNS", "recordTTL"), *p.ExternalDNS.RecordTTL, "must not be negative"))
+			}
+		}
+	}
 	if p.RFC2136 != nil {
 		if numProviders > 0 {
 			el = append(el, field.Forbidden(fldPath.Child("rfc2136"), "may not specify more than one provider type"))
@@ -461,15 +810,32 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 					el = append(el, field.NotSupported(fldPath.Child("rfc2136", "tsigAlgorithm"), "", supportedTSIGAlgorithms))
 				}
 			}
-			if len(p.RFC2136.TSIGKeyName) > 0 {
-				el = append(el, ValidateSecretKeySelector(&p.RFC2136.TSIGSecret, fldPath.Child("rfc2136", "tsigSecretSecretRef"))...)
-			}
-
-			if len(ValidateSecretKeySelector(&p.RFC2136.TSIGSecret, fldPath.Child("rfc2136", "tsigSecretSecretRef"))) == 0 {
-				if len(p.RFC2136.TSIGKeyName) <= 0 {
-					el = append(el, field.Required(fldPath.Child("rfc2136", "tsigKeyName"), ""))
+			if strings.ToUpper(p.RFC2136.TSIGAlgorithm) == gssTSIGAlgorithm {
+				if len(p.RFC2136.TSIGKeyName) > 0 {
+					el = append(el, field.Forbidden(fldPath.Child("rfc2136", "tsigKeyName"), "may not be set when tsigAlgorithm is "+gssTSIGAlgorithm))
+				}
+				if len(p.RFC2136.TSIGSecret.Name) > 0 {
+					el = append(el, field.Forbidden(fldPath.Child("rfc2136", "tsigSecretSecretRef"), "may not be set when tsigAlgorithm is "+gssTSIGAlgorithm))
+				}
+				if p.RFC2136.Kerberos == nil {
+					el = append(el, field.Required(fldPath.Child("rfc2136", "kerberos"), "must be set when tsigAlgorithm is "+gssTSIGAlgorithm))
+				} else {
+					el = append(el, validateRFC2136Kerberos(p.RFC2136.Kerberos, fldPath.Child("rfc2136", "kerberos"))...)
+				}
+			} else {
+				if p.RFC2136.Kerberos != nil {
+					el = append(el, field.Forbidden(fldPath.Child("rfc2136", "kerberos"), "may only be set when tsigAlgorithm is "+gssTSIGAlgorithm))
 				}
+				if len(p.RFC2136.TSIGKeyName) > 0 {
+					el = append(el, ValidateSecretKeySelector(&p.RFC2136.TSIGSecret, fldPath.Child("rfc2136", "tsigSecretSecretRef"))...)
+				}
+
+				if len(ValidateSecretKeySelector(&p.RFC2136.TSIGSecret, fldPath.Child("rfc2136", "tsigSecretSecretRef"))) == 0 {
+					if len(p.RFC2136.TSIGKeyName) <= 0 {
+						el = append(el, field.Required(fldPath.Child("rfc2136", "tsigKeyName"), ""))
+					}
 
+				}
 			}
 		}
 	}
@@ -490,6 +856,103 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 	return el
 }
 
+func validateRFC2136Kerberos(k *cmacme.ACMEIssuerDNS01ProviderRFC2136Kerberos, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(k.Realm) == 0 {
+		el = append(el, field.Required(fldPath.Child("realm"), ""))
+	}
+	if len(k.Username) == 0 {
+		el = append(el, field.Required(fldPath.Child("username"), ""))
+	}
+	if len(k.KDC) > 0 {
+		if _, err := util.ValidNameserver(k.KDC); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("kdc"), k.KDC, "kdc must be set in the form host:port where host is an IPv4 address, an enclosed IPv6 address or a hostname and port is an optional port number."))
+		}
+	}
+	switch {
+	case k.KeytabSecretRef != nil && k.PasswordSecretRef != nil:
+		el = append(el, field.Forbidden(fldPath.Child("passwordSecretRef"), "may not be set when keytabSecretRef is set"))
+	case k.KeytabSecretRef != nil:
+		el = append(el, ValidateSecretKeySelector(k.KeytabSecretRef, fldPath.Child("keytabSecretRef"))...)
+	case k.PasswordSecretRef != nil:
+		el = append(el, ValidateSecretKeySelector(k.PasswordSecretRef, fldPath.Child("passwordSecretRef"))...)
+	default:
+		el = append(el, field.Required(fldPath, "one of keytabSecretRef or passwordSecretRef must be set"))
+	}
+	return el
+}
+
+// validateDNS01ProviderZone validates the zone name of an entry in the list
+// of zones of a DNS01 provider, and that it is not listed more than once.
+func validateDNS01ProviderZone(zone string, seen sets.String, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(zone) == 0 {
+		return append(el, field.Required(fldPath, ""))
+	}
+	normalized := strings.ToLower(strings.TrimSuffix(zone, "."))
+	for _, msg := range validation.IsDNS1123Subdomain(normalized) {
+		el = append(el, field.Invalid(fldPath, zone, msg))
+	}
+	if seen.Has(normalized) {
+		el = append(el, field.Duplicate(fldPath, zone))
+	}
+	seen.Insert(normalized)
+	return el
+}
+
+func validateRoute53Zones(zones []cmacme.ACMEIssuerDNS01ProviderRoute53Zone, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	seen := sets.NewString()
+	for i, zone := range zones {
+		zonePath := fldPath.Index(i)
+		el = append(el, validateDNS01ProviderZone(zone.Zone, seen, zonePath.Child("zone"))...)
+		if zone.AccessKeyID != nil {
+			el = append(el, ValidateSecretKeySelector(zone.AccessKeyID, zonePath.Child("accessKeyIDSecretRef"))...)
+		}
+		if zone.SecretAccessKey != nil {
+			el = append(el, ValidateSecretKeySelector(zone.SecretAccessKey, zonePath.Child("secretAccessKeySecretRef"))...)
+		}
+		switch {
+		case zone.AccessKeyID != nil && zone.SecretAccessKey == nil:
+			el = append(el, field.Required(zonePath.Child("secretAccessKeySecretRef"), "must be specified together with accessKeyIDSecretRef"))
+		case zone.AccessKeyID == nil && zone.SecretAccessKey != nil:
+			el = append(el, field.Required(zonePath.Child("accessKeyIDSecretRef"), "must be specified together with secretAccessKeySecretRef"))
+		case zone.AccessKeyID == nil && len(zone.Role) == 0:
+			el = append(el, field.Required(zonePath, "accessKeyIDSecretRef and secretAccessKeySecretRef or role is required"))
+		}
+	}
+	return el
+}
+
+func validateCloudDNSZones(zones []cmacme.ACMEIssuerDNS01ProviderCloudDNSZone, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	seen := sets.NewString()
+	for i, zone := range zones {
+		zonePath := fldPath.Index(i)
+		el = append(el, validateDNS01ProviderZone(zone.Zone, seen, zonePath.Child("zone"))...)
+		el = append(el, ValidateSecretKeySelector(&zone.ServiceAccount, zonePath.Child("serviceAccountSecretRef"))...)
+	}
+	return el
+}
+
+func validateAzureDNSZones(p *cmacme.ACMEIssuerDNS01ProviderAzureDNS, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	seen := sets.NewString()
+	for i, zone := range p.Zones {
+		zonePath := fldPath.Index(i)
+		el = append(el, validateDNS01ProviderZone(zone.Zone, seen, zonePath.Child("zone"))...)
+		if len(zone.ClientID) == 0 {
+			el = append(el, field.Required(zonePath.Child("clientID"), ""))
+		}
+		el = append(el, ValidateSecretKeySelector(&zone.ClientSecret, zonePath.Child("clientSecretSecretRef"))...)
+		// the tenant of the provider is used if none is set on the zone
+		if len(zone.TenantID) == 0 && len(p.TenantID) == 0 {
+			el = append(el, field.Required(zonePath.Child("tenantID"), "must be specified if no tenantID is set on the provider"))
+		}
+	}
+	return el
+}
+
 func ValidateSecretKeySelector(sks *cmmeta.SecretKeySelector, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if sks.Name == "" {
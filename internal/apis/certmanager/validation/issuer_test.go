@@ -18,13 +18,19 @@ package validation
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/pointer"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
@@ -232,6 +238,22 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 			},
 			warnings: []string{deprecatedACMEEABKeyAlgorithmField},
 		},
+		"acme issuer with skipTLSVerify set": {
+			spec: &cmacme.ACMEIssuer{
+				Email:         "valid-email",
+				Server:        "valid-server",
+				PrivateKey:    validSecretKeyRef,
+				SkipTLSVerify: true,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+			warnings: []string{insecureACMESkipTLSVerify},
+		},
 		"acme solver with missing http01 config type": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -525,6 +547,276 @@ func TestValidateIssuerSpec(t *testing.T) {
 				field.Invalid(fldPath.Child("ca", "ocspServer").Index(0), "", `must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org`),
 			},
 		},
+		"valid ca issuer with a CRL": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						CRL: &cmapi.CACRL{
+							ConfigMapName: "ca-crl",
+							Validity:      &metav1.Duration{Duration: 12 * time.Hour},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid CRL": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						CRL: &cmapi.CACRL{
+							ConfigMapName: "CA_CRL",
+							Validity:      &metav1.Duration{Duration: time.Minute},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "crl", "configMapName"), "CA_CRL", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+				field.Invalid(fldPath.Child("ca", "crl", "validity"), "1m0s", "must be at least 1h0m0s"),
+			},
+		},
+		"CRL without a ConfigMap name": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						CRL:        &cmapi.CACRL{},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("ca", "crl", "configMapName"), ""),
+			},
+		},
+		"valid ca issuer issuing SPIFFE SVIDs": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						SPIFFE:     &cmapi.CASPIFFE{TrustDomain: "cluster.local"},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"SPIFFE without a trust domain": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						SPIFFE:     &cmapi.CASPIFFE{},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("ca", "spiffe", "trustDomain"), ""),
+			},
+		},
+		"invalid SPIFFE trust domain": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						SPIFFE:     &cmapi.CASPIFFE{TrustDomain: "Cluster_Local"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "spiffe", "trustDomain"), "Cluster_Local", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+			},
+		},
+		"SPIFFE trust domain too long for the trust bundle ConfigMap": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						SPIFFE:     &cmapi.CASPIFFE{TrustDomain: strings.Repeat("a", 240) + ".org"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.TooLong(fldPath.Child("ca", "spiffe", "trustDomain"), strings.Repeat("a", 240)+".org", 239),
+			},
+		},
+		"valid ca issuer with a PKCS#11 token": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						PKCS11: &cmapi.CAPKCS11{
+							Module:       "/usr/lib/softhsm/libsofthsm2.so",
+							TokenLabel:   "ca",
+							KeyID:        "0a1b",
+							PINSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "pin"}, Key: "pin"},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid PKCS#11 token": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						PKCS11: &cmapi.CAPKCS11{
+							Module: "libsofthsm2.so",
+							KeyID:  "ca-key",
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "pkcs11", "module"), "libsofthsm2.so", "must be an absolute path"),
+				field.Required(fldPath.Child("ca", "pkcs11", "tokenLabel"), ""),
+				field.Invalid(fldPath.Child("ca", "pkcs11", "keyID"), "ca-key", "must be hex encoded"),
+				field.Required(fldPath.Child("ca", "pkcs11", "pinSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("ca", "pkcs11", "pinSecretRef", "key"), "secret key is required"),
+			},
+		},
+		"PKCS#11 token without a key label or ID": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						PKCS11: &cmapi.CAPKCS11{
+							Module:       "/usr/lib/softhsm/libsofthsm2.so",
+							TokenLabel:   "ca",
+							PINSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "pin"}, Key: "pin"},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("ca", "pkcs11", "keyLabel"), "at least one of keyLabel or keyID must be set"),
+			},
+		},
+		"valid allowed URI schemes": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				AllowedURISchemes: []string{"spiffe", "urn"},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid allowed URI schemes": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				AllowedURISchemes: []string{"spiffe://", ""},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("allowedURISchemes").Index(0), "spiffe://", "must be a URI scheme without the trailing ':', e.g. spiffe"),
+				field.Invalid(fldPath.Child("allowedURISchemes").Index(1), "", "must be a URI scheme without the trailing ':', e.g. spiffe"),
+			},
+		},
+		"valid allowed usages": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				AllowedUsages: []cmapi.AllowedKeyUsage{
+					{Usage: cmapi.UsageDigitalSignature},
+					{Usage: cmapi.UsageServerAuth, Namespaces: []string{"ingress"}},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid allowed usages": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				AllowedUsages: []cmapi.AllowedKeyUsage{
+					{Usage: "nonexistent"},
+					{},
+					{Usage: cmapi.UsageServerAuth, Namespaces: []string{""}},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("allowedUsages").Index(0).Child("usage"), cmapi.KeyUsage("nonexistent"), "unknown keyusage"),
+				field.Required(fldPath.Child("allowedUsages").Index(1).Child("usage"), "must be specified"),
+				field.Invalid(fldPath.Child("allowedUsages").Index(2).Child("namespaces").Index(0), "", "must not be empty"),
+			},
+		},
+		"valid renewal window": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				RenewalWindow: &cmapi.RenewalWindow{
+					Days:           []string{"Mon-Thu", "Sat"},
+					Hours:          "22-6",
+					TimeZone:       "Europe/London",
+					ExpiryOverride: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid renewal window": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				RenewalWindow: &cmapi.RenewalWindow{
+					Days:           []string{"Mon", "Monday-Fri"},
+					Hours:          "9",
+					TimeZone:       "Not/AZone",
+					ExpiryOverride: &metav1.Duration{Duration: -time.Hour},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("renewalWindow", "days").Index(1), "Monday-Fri", `invalid day "Monday", must be one of Mon, Tue, Wed, Thu, Fri, Sat or Sun`),
+				field.Invalid(fldPath.Child("renewalWindow", "hours"), "9", `invalid hours "9", must be a range such as 9-17`),
+				field.Invalid(fldPath.Child("renewalWindow", "timeZone"), "Not/AZone", "must be an IANA time zone, e.g. Europe/London"),
+				field.Invalid(fldPath.Child("renewalWindow", "expiryOverride"), -time.Hour, "must be greater than zero"),
+			},
+		},
+		"valid http client": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				HTTPClient: &cmapi.IssuerHTTPClient{
+					UserAgentSuffix: "team-a/1.0",
+					Headers: []cmapi.HTTPHeader{
+						{Name: "X-Trace-Id", Value: "abc"},
+						{Name: "X-Route", Value: ""},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid http client": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				HTTPClient: &cmapi.IssuerHTTPClient{
+					UserAgentSuffix: "bad\nsuffix",
+					Headers: []cmapi.HTTPHeader{
+						{Name: "", Value: "a"},
+						{Name: "X Trace", Value: "a"},
+						{Name: "authorization", Value: "Bearer abc"},
+						{Name: "X-Trace-Id", Value: "a"},
+						{Name: "x-trace-id", Value: "bad\rvalue"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("httpClient", "userAgentSuffix"), "bad\nsuffix", "must be a valid HTTP header value"),
+				field.Required(fldPath.Child("httpClient", "headers").Index(0).Child("name"), "must be specified"),
+				field.Invalid(fldPath.Child("httpClient", "headers").Index(1).Child("name"), "X Trace", "must be a valid HTTP header name"),
+				field.Forbidden(fldPath.Child("httpClient", "headers").Index(2).Child("name"), "the Authorization header is set by cert-manager"),
+				field.Duplicate(fldPath.Child("httpClient", "headers").Index(4).Child("name"), "x-trace-id"),
+				field.Invalid(fldPath.Child("httpClient", "headers").Index(4).Child("value"), "bad\rvalue", "must be a valid HTTP header value"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -605,6 +897,73 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 				field.Invalid(fldPath.Child("ingress", "serviceType"), corev1.ServiceType("InvalidServiceType"), `must be empty, "ClusterIP" or "NodePort"`),
 			},
 		},
+		"acme issuer with valid http01 solver resources": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+						Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+							Resources: &corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("5m")},
+								Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+							},
+						},
+					},
+				},
+			},
+		},
+		"acme issuer with http01 solver resource requests greater than limits": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+						Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+							Resources: &corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+								Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ingress", "podTemplate", "spec", "resources", "requests").Key("memory"), "128Mi", "must be less than or equal to the memory limit"),
+			},
+		},
+		"acme issuer with valid http01 burst profile": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					BurstProfile: &cmacme.ACMEChallengeSolverHTTP01BurstProfile{
+						MinReplicas:          int32Ptr(0),
+						MaxReplicas:          3,
+						ChallengesPerReplica: int32Ptr(20),
+					},
+				},
+			},
+		},
+		"acme issuer with invalid http01 burst profile": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					BurstProfile: &cmacme.ACMEChallengeSolverHTTP01BurstProfile{
+						MinReplicas:          int32Ptr(2),
+						MaxReplicas:          1,
+						ChallengesPerReplica: int32Ptr(0),
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ingress", "burstProfile", "minReplicas"), int32(2), "must not be greater than maxReplicas"),
+				field.Invalid(fldPath.Child("ingress", "burstProfile", "challengesPerReplica"), int32(0), "must be at least 1"),
+			},
+		},
+		"acme issuer with http01 burst profile without maxReplicas": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					BurstProfile: &cmacme.ACMEChallengeSolverHTTP01BurstProfile{},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ingress", "burstProfile", "maxReplicas"), int32(0), "must be at least 1"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -786,6 +1145,81 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Required(fldPath.Child("route53", "accessKeyIDSecretRef", "key"), "secret key is required"),
 			},
 		},
+		"valid route53 zones": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region: "valid",
+					Zones: []cmacme.ACMEIssuerDNS01ProviderRoute53Zone{
+						{Zone: "example.com", AccessKeyID: &validSecretKeyRef, SecretAccessKey: &validSecretKeyRef},
+						{Zone: "example.org.", Role: "arn:aws:iam::123456789012:role/dns"},
+					},
+				},
+			},
+		},
+		"route53 zones with missing, invalid and duplicate zone names": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region: "valid",
+					Zones: []cmacme.ACMEIssuerDNS01ProviderRoute53Zone{
+						{Role: "role"},
+						{Zone: "Example.com", Role: "role"},
+						{Zone: "example.com.", Role: "role"},
+						{Zone: "-invalid", Role: "role"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("route53", "zones").Index(0).Child("zone"), ""),
+				field.Duplicate(fldPath.Child("route53", "zones").Index(2).Child("zone"), "example.com."),
+				field.Invalid(fldPath.Child("route53", "zones").Index(3).Child("zone"), "-invalid",
+					"a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+			},
+		},
+		"route53 zone without credentials": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region: "valid",
+					Zones: []cmacme.ACMEIssuerDNS01ProviderRoute53Zone{
+						{Zone: "example.com"},
+						{Zone: "example.org", AccessKeyID: &validSecretKeyRef},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("route53", "zones").Index(0), "accessKeyIDSecretRef and secretAccessKeySecretRef or role is required"),
+				field.Required(fldPath.Child("route53", "zones").Index(1).Child("secretAccessKeySecretRef"), "must be specified together with accessKeyIDSecretRef"),
+			},
+		},
+		"clouddns zone with invalid service account": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
+					Project: "valid",
+					Zones: []cmacme.ACMEIssuerDNS01ProviderCloudDNSZone{
+						{Zone: "example.com", ServiceAccount: validSecretKeyRef},
+						{Zone: "example.org", ServiceAccount: cmmeta.SecretKeySelector{Key: "key"}},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("cloudDNS", "zones").Index(1).Child("serviceAccountSecretRef", "name"), "secret name is required"),
+			},
+		},
+		"azuredns zones use the tenant of the provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				AzureDNS: &cmacme.ACMEIssuerDNS01ProviderAzureDNS{
+					SubscriptionID:    "sub",
+					ResourceGroupName: "rg",
+					Zones: []cmacme.ACMEIssuerDNS01ProviderAzureDNSZone{
+						{Zone: "example.com", ClientID: "id", ClientSecret: validSecretKeyRef, TenantID: "tenant"},
+						{Zone: "example.org", ClientSecret: validSecretKeyRef},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("azureDNS", "zones").Index(1).Child("clientID"), ""),
+				field.Required(fldPath.Child("azureDNS", "zones").Index(1).Child("tenantID"), "must be specified if no tenantID is set on the provider"),
+			},
+		},
 		"missing provider config": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{},
 			errs: []*field.Error{
@@ -1070,6 +1504,60 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 			},
 			errs: []*field.Error{},
 		},
+		"valid externaldns provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				ExternalDNS: &cmacme.ACMEIssuerDNS01ProviderExternalDNS{
+					Labels:    map[string]string{"external-dns": "internal"},
+					RecordTTL: pointer.Int64(60),
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"externaldns provider with negative record ttl": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				ExternalDNS: &cmacme.ACMEIssuerDNS01ProviderExternalDNS{
+					RecordTTL: pointer.Int64(-1),
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("externalDNS", "recordTTL"), int64(-1), "must not be negative"),
+			},
+		},
+		"externaldns provider with another provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Cloudflare:  &cmacme.ACMEIssuerDNS01ProviderCloudflare{Email: "valid", APIToken: &validSecretKeyRef},
+				ExternalDNS: &cmacme.ACMEIssuerDNS01ProviderExternalDNS{},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("externalDNS"), "may not specify more than one provider type"),
+			},
+		},
+		"valid hetzner provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Hetzner: &cmacme.ACMEIssuerDNS01ProviderHetzner{
+					APIToken: validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"hetzner provider with missing api token": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Hetzner: &cmacme.ACMEIssuerDNS01ProviderHetzner{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("hetzner", "apiTokenSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("hetzner", "apiTokenSecretRef", "key"), "secret key is required"),
+			},
+		},
+		"hetzner provider with another provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{Email: "valid", APIToken: &validSecretKeyRef},
+				Hetzner:    &cmacme.ACMEIssuerDNS01ProviderHetzner{APIToken: validSecretKeyRef},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("hetzner"), "may not specify more than one provider type"),
+			},
+		},
 		"rfc2136 provider with missing nameserver": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
 				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{},
@@ -1183,6 +1671,98 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Required(fldPath.Child("rfc2136", "tsigKeyName"), ""),
 			},
 		},
+		"rfc2136 provider using GSS-TSIG without Kerberos credentials": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver:    "dc1.example.com",
+					TSIGAlgorithm: "gss-tsig",
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("rfc2136", "kerberos"), "must be set when tsigAlgorithm is GSS-TSIG"),
+			},
+		},
+		"rfc2136 provider using GSS-TSIG with a TSIG key": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver:    "dc1.example.com",
+					TSIGAlgorithm: "GSS-TSIG",
+					TSIGKeyName:   "some-name",
+					TSIGSecret:    validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("rfc2136", "tsigKeyName"), "may not be set when tsigAlgorithm is GSS-TSIG"),
+				field.Forbidden(fldPath.Child("rfc2136", "tsigSecretSecretRef"), "may not be set when tsigAlgorithm is GSS-TSIG"),
+				field.Required(fldPath.Child("rfc2136", "kerberos"), "must be set when tsigAlgorithm is GSS-TSIG"),
+			},
+		},
+		"rfc2136 provider using GSS-TSIG with a Kerberos keytab": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver:    "dc1.example.com",
+					TSIGAlgorithm: "GSS-TSIG",
+					Kerberos: &cmacme.ACMEIssuerDNS01ProviderRFC2136Kerberos{
+						Realm:           "EXAMPLE.COM",
+						Username:        "cert-manager",
+						KDC:             "dc2.example.com:88",
+						KeytabSecretRef: &validSecretKeyRef,
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"rfc2136 provider using GSS-TSIG with incomplete Kerberos credentials": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver:    "dc1.example.com",
+					TSIGAlgorithm: "GSS-TSIG",
+					Kerberos: &cmacme.ACMEIssuerDNS01ProviderRFC2136Kerberos{
+						KDC: ":88",
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("rfc2136", "kerberos", "realm"), ""),
+				field.Required(fldPath.Child("rfc2136", "kerberos", "username"), ""),
+				field.Invalid(fldPath.Child("rfc2136", "kerberos", "kdc"), ":88", "kdc must be set in the form host:port where host is an IPv4 address, an enclosed IPv6 address or a hostname and port is an optional port number."),
+				field.Required(fldPath.Child("rfc2136", "kerberos"), "one of keytabSecretRef or passwordSecretRef must be set"),
+			},
+		},
+		"rfc2136 provider using GSS-TSIG with both a Kerberos keytab and password": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver:    "dc1.example.com",
+					TSIGAlgorithm: "GSS-TSIG",
+					Kerberos: &cmacme.ACMEIssuerDNS01ProviderRFC2136Kerberos{
+						Realm:             "EXAMPLE.COM",
+						Username:          "cert-manager",
+						KeytabSecretRef:   &validSecretKeyRef,
+						PasswordSecretRef: &validSecretKeyRef,
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("rfc2136", "kerberos", "passwordSecretRef"), "may not be set when keytabSecretRef is set"),
+			},
+		},
+		"rfc2136 provider with Kerberos credentials but not using GSS-TSIG": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver:  "127.0.0.1",
+					TSIGKeyName: "some-name",
+					TSIGSecret:  validSecretKeyRef,
+					Kerberos: &cmacme.ACMEIssuerDNS01ProviderRFC2136Kerberos{
+						Realm:             "EXAMPLE.COM",
+						Username:          "cert-manager",
+						PasswordSecretRef: &validSecretKeyRef,
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("rfc2136", "kerberos"), "may only be set when tsigAlgorithm is GSS-TSIG"),
+			},
+		},
 		"multiple providers configured": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
 				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
@@ -1194,6 +1774,38 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Forbidden(fldPath.Child("cloudflare"), "may not specify more than one provider type"),
 			},
 		},
+		"valid validation zone": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				CNAMEStrategy:  cmacme.FollowStrategy,
+				ValidationZone: "acme.example.com.",
+				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
+					Project: "something",
+				},
+			},
+		},
+		"validation zone requires the Follow cnameStrategy": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				ValidationZone: "acme.example.com",
+				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
+					Project: "something",
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("validationZone"), `may only be set when cnameStrategy is "Follow"`),
+			},
+		},
+		"invalid validation zone": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				CNAMEStrategy:  cmacme.FollowStrategy,
+				ValidationZone: "*.example.com",
+				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
+					Project: "something",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("validationZone"), "*.example.com", validation.IsDNS1123Subdomain("*.example.com")[0]),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -1322,6 +1934,39 @@ func TestValidateVenafiIssuerConfig(t *testing.T) {
 				field.Forbidden(fldPath, "please supply one of: tpp, cloud"),
 			},
 		},
+		"valid custom fields and zone cache duration": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL: "https://tpp.example.com/vedsdk",
+				},
+				CustomFields: []cmapi.VenafiCustomField{
+					{Name: "cost-center", Value: "1234"},
+					{Name: "owner", Value: "team-a", Type: cmapi.VenafiCustomFieldTypePlain},
+				},
+				ZoneCacheDuration: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+		"invalid custom fields and zone cache duration": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL: "https://tpp.example.com/vedsdk",
+				},
+				CustomFields: []cmapi.VenafiCustomField{
+					{Value: "1234"},
+					{Name: "owner", Value: "team-a", Type: "Bool"},
+					{Name: "owner", Value: "team-b"},
+				},
+				ZoneCacheDuration: &metav1.Duration{Duration: -time.Minute},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("customFields").Index(0).Child("name"), ""),
+				field.NotSupported(fldPath.Child("customFields").Index(1).Child("type"), cmapi.VenafiCustomFieldType("Bool"), []string{"Plain"}),
+				field.Duplicate(fldPath.Child("customFields").Index(2).Child("name"), "owner"),
+				field.Invalid(fldPath.Child("zoneCacheDuration"), -time.Minute, "must not be negative"),
+			},
+		},
 	}
 
 	for n, s := range scenarios {
@@ -1340,6 +1985,129 @@ func TestValidateVenafiIssuerConfig(t *testing.T) {
 	}
 }
 
+func TestValidateHubIssuerConfig(t *testing.T) {
+	fldPath := field.NewPath("test")
+	valid := func() *cmapi.HubIssuer {
+		return &cmapi.HubIssuer{
+			Server:         "https://hub.example.com:6443",
+			TokenSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "hub-token"}, Key: "token"},
+			Namespace:      "spoke-a",
+			IssuerRef:      cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer"},
+		}
+	}
+	scenarios := map[string]struct {
+		cfg  func(*cmapi.HubIssuer)
+		errs []*field.Error
+	}{
+		"valid": {
+			cfg: func(*cmapi.HubIssuer) {},
+		},
+		"missing server": {
+			cfg: func(iss *cmapi.HubIssuer) { iss.Server = "" },
+			errs: []*field.Error{
+				field.Required(fldPath.Child("server"), ""),
+			},
+		},
+		"plain http server": {
+			cfg: func(iss *cmapi.HubIssuer) { iss.Server = "http://hub.example.com" },
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("server"), "http://hub.example.com", "must be an https URL"),
+			},
+		},
+		"invalid CA bundle": {
+			cfg: func(iss *cmapi.HubIssuer) { iss.CABundle = []byte("not a certificate") },
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
+			},
+		},
+		"missing token key, namespace and issuer name": {
+			cfg: func(iss *cmapi.HubIssuer) {
+				iss.TokenSecretRef.Key = ""
+				iss.Namespace = ""
+				iss.IssuerRef.Name = ""
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("tokenSecretRef", "key"), "secret key is required"),
+				field.Required(fldPath.Child("namespace"), ""),
+				field.Required(fldPath.Child("issuerRef", "name"), ""),
+			},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			cfg := valid()
+			s.cfg(cfg)
+			errs := ValidateHubIssuerConfig(cfg, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateExternalSignerIssuerConfig(t *testing.T) {
+	fldPath := field.NewPath("test")
+	valid := func() *cmapi.ExternalSignerIssuer {
+		return &cmapi.ExternalSignerIssuer{
+			Address:      "signer.hsm.svc:8443",
+			TLSSecretRef: cmmeta.LocalObjectReference{Name: "signer-client-tls"},
+		}
+	}
+	scenarios := map[string]struct {
+		cfg  func(*cmapi.ExternalSignerIssuer)
+		errs []*field.Error
+	}{
+		"valid": {
+			cfg: func(*cmapi.ExternalSignerIssuer) {},
+		},
+		"valid with server name": {
+			cfg: func(iss *cmapi.ExternalSignerIssuer) { iss.ServerName = "signer.example.com" },
+		},
+		"missing address": {
+			cfg: func(iss *cmapi.ExternalSignerIssuer) { iss.Address = "" },
+			errs: []*field.Error{
+				field.Required(fldPath.Child("address"), ""),
+			},
+		},
+		"address without port": {
+			cfg: func(iss *cmapi.ExternalSignerIssuer) { iss.Address = "signer.hsm.svc" },
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("address"), "signer.hsm.svc", "must be of the form host:port"),
+			},
+		},
+		"missing TLS secret name": {
+			cfg: func(iss *cmapi.ExternalSignerIssuer) { iss.TLSSecretRef.Name = "" },
+			errs: []*field.Error{
+				field.Required(fldPath.Child("tlsSecretRef", "name"), ""),
+			},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			cfg := valid()
+			s.cfg(cfg)
+			errs := ValidateExternalSignerIssuerConfig(cfg, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateVenafiTPP(t *testing.T) {
 	fldPath := field.NewPath("test")
 	scenarios := map[string]struct {
@@ -1376,12 +2144,39 @@ func TestValidateVenafiTPP(t *testing.T) {
 }
 
 func TestValidateIssuer(t *testing.T) {
+	acmeIssuerWithEAB := func(perNamespace bool) *cmapi.Issuer {
+		return &cmapi.Issuer{
+			Spec: cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					ACME: &cmacme.ACMEIssuer{
+						Server:     "https://acme.example.com/directory",
+						PrivateKey: validSecretKeyRef,
+						ExternalAccountBinding: &cmacme.ACMEExternalAccountBinding{
+							KeyID:        "kid",
+							Key:          validSecretKeyRef,
+							PerNamespace: perNamespace,
+						},
+					},
+				},
+			},
+		}
+	}
 	scenarios := map[string]struct {
 		cfg       *cmapi.Issuer
 		a         *admissionv1.AdmissionRequest
 		expectedE []*field.Error
 		expectedW []string
-	}{}
+	}{
+		"ACME issuer with external account binding": {
+			cfg: acmeIssuerWithEAB(false),
+		},
+		"per-namespace external account binding is forbidden on Issuers": {
+			cfg: acmeIssuerWithEAB(true),
+			expectedE: []*field.Error{
+				field.Forbidden(field.NewPath("spec", "acme", "externalAccountBinding", "perNamespace"), "may only be set on ClusterIssuers"),
+			},
+		},
+	}
 
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -0,0 +1,58 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+)
+
+// Validation functions for cert-manager IssuerAlias types.
+
+func ValidateIssuerAlias(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
+	alias := obj.(*internalcmapi.IssuerAlias)
+	return ValidateIssuerAliasSpec(&alias.Spec, field.NewPath("spec")), nil
+}
+
+func ValidateUpdateIssuerAlias(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
+	alias := obj.(*internalcmapi.IssuerAlias)
+	return ValidateIssuerAliasSpec(&alias.Spec, field.NewPath("spec")), nil
+}
+
+// ValidateIssuerAliasSpec checks that an alias refers to a cert-manager.io
+// Issuer or ClusterIssuer. Aliases of aliases and external issuers are not
+// supported.
+func ValidateIssuerAliasSpec(spec *internalcmapi.IssuerAliasSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	issuerRefPath := fldPath.Child("issuerRef")
+	if spec.IssuerRef.Name == "" {
+		el = append(el, field.Required(issuerRefPath.Child("name"), "must be specified"))
+	}
+	if spec.IssuerRef.Group != "" && spec.IssuerRef.Group != internalcmapi.SchemeGroupVersion.Group {
+		el = append(el, field.Invalid(issuerRefPath.Child("group"), spec.IssuerRef.Group, "must be empty or "+internalcmapi.SchemeGroupVersion.Group))
+	}
+	switch spec.IssuerRef.Kind {
+	case "", internalcmapi.IssuerKind, internalcmapi.ClusterIssuerKind:
+	default:
+		el = append(el, field.Invalid(issuerRefPath.Child("kind"), spec.IssuerRef.Kind, "must be one of Issuer or ClusterIssuer"))
+	}
+	return el
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+)
+
+func TestValidateIssuerAlias(t *testing.T) {
+	fldPath := field.NewPath("spec", "issuerRef")
+	objectMeta := metav1.ObjectMeta{Name: "default", Namespace: "cert-manager"}
+
+	scenarios := map[string]struct {
+		ref       cmmeta.ObjectReference
+		expectedE field.ErrorList
+	}{
+		"alias of an Issuer": {
+			ref:       cmmeta.ObjectReference{Name: "ca"},
+			expectedE: field.ErrorList{},
+		},
+		"alias of a ClusterIssuer": {
+			ref:       cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer", Group: "cert-manager.io"},
+			expectedE: field.ErrorList{},
+		},
+		"missing name": {
+			ref: cmmeta.ObjectReference{Kind: "ClusterIssuer"},
+			expectedE: field.ErrorList{
+				field.Required(fldPath.Child("name"), "must be specified"),
+			},
+		},
+		"alias of an alias": {
+			ref: cmmeta.ObjectReference{Name: "other", Kind: "IssuerAlias"},
+			expectedE: field.ErrorList{
+				field.Invalid(fldPath.Child("kind"), "IssuerAlias", "must be one of Issuer or ClusterIssuer"),
+			},
+		},
+		"external issuer": {
+			ref: cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "example.com"},
+			expectedE: field.ErrorList{
+				field.Invalid(fldPath.Child("group"), "example.com", "must be empty or cert-manager.io"),
+			},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			alias := &cmapi.IssuerAlias{
+				ObjectMeta: objectMeta,
+				Spec:       cmapi.IssuerAliasSpec{IssuerRef: s.ref},
+			}
+			gotE, gotW := ValidateIssuerAlias(nil, alias)
+			if !reflect.DeepEqual(gotE, s.expectedE) {
+				t.Errorf("Expected errors %v but got %v", s.expectedE, gotE)
+			}
+			if len(gotW) > 0 {
+				t.Errorf("Expected no warnings but got %v", gotW)
+			}
+		})
+	}
+}
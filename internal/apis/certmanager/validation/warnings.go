@@ -21,4 +21,23 @@ package validation
 const (
 	// deprecatedACMEEABKeyAlgorithmField is raised when the deprecated keyAlgorithm field for an ACME issuer's external account binding (EAB) is set.
 	deprecatedACMEEABKeyAlgorithmField = "ACME issuer spec field 'externalAccount.keyAlgorithm' is deprecated. The value of this field will be ignored."
+
+	// insecureACMESkipTLSVerify is raised when TLS verification of the ACME server is disabled.
+	insecureACMESkipTLSVerify = "ACME issuer spec field 'skipTLSVerify' is true. Connections to the ACME server are not verified and are vulnerable to man-in-the-middle attacks."
+
+	// weakKeySize is raised when a CSR contains a public key which is smaller than minimumRSAKeySize or minimumECDSAKeySize.
+	weakKeySize = "keys of this size are considered weak and may be rejected by issuers and clients"
+
+	// weakSignatureAlgorithm is raised when a CSR is signed using SHA-1 or MD5.
+	weakSignatureAlgorithm = "SHA-1 and MD5 signatures are considered insecure and may be rejected by issuers"
+
+	// nearEmptyCASubject is raised when a CA Certificate has no common name, organization or organizational unit.
+	nearEmptyCASubject = "clients identify CAs by their subject, which should not be empty"
+)
+
+const (
+	// minimumRSAKeySize is the smallest RSA key size which does not raise weakKeySize.
+	minimumRSAKeySize = 2048
+	// minimumECDSAKeySize is the smallest ECDSA curve size which does not raise weakKeySize.
+	minimumECDSAKeySize = 256
 )
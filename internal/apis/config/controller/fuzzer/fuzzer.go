@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fuzzer
+
+import (
+	fuzz "github.com/google/gofuzz"
+	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/utils/pointer"
+
+	"github.com/cert-manager/cert-manager/internal/apis/config/controller"
+)
+
+// Funcs returns the fuzzer functions for the controller config api group.
+var Funcs = func(codecs runtimeserializer.CodecFactory) []interface{} {
+	return []interface{}{
+		func(s *controller.ControllerConfiguration, c fuzz.Continue) {
+			c.FuzzNoCustom(s) // fuzz self without calling this function again
+
+			if s.KubernetesAPIQPS == nil {
+				s.KubernetesAPIQPS = pointer.Float32(10)
+			}
+			if s.KubernetesAPIBurst == nil {
+				s.KubernetesAPIBurst = pointer.Int(12)
+			}
+			if s.Workers == nil {
+				s.Workers = pointer.Int(3)
+			}
+		},
+	}
+}
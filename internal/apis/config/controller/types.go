@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ControllerConfiguration struct {
+	metav1.TypeMeta
+
+	// kubernetesAPIQPS is the maximum queries-per-second of requests sent to
+	// the Kubernetes apiserver.
+	// Ignored if --kube-api-qps is set.
+	// Defaults to 20.
+	KubernetesAPIQPS *float32
+
+	// kubernetesAPIBurst is the maximum burst of requests sent to the
+	// Kubernetes apiserver.
+	// Ignored if --kube-api-burst is set.
+	// Defaults to 50.
+	KubernetesAPIBurst *int
+
+	// workers is the number of items each controller processes concurrently.
+	// Defaults to 5.
+	Workers *int
+
+	// controllers overrides the settings of individual controllers, keyed by
+	// the controller name as used in --controllers, e.g.
+	// 'certificates-issuing'.
+	// +optional
+	Controllers map[string]ControllerSettings
+
+	// notifications configures HTTP sinks which are notified when
+	// certificates are issued, renewed, fail to be issued or are about to
+	// expire.
+	// +optional
+	Notifications *NotificationsConfiguration
+}
+
+// ControllerSettings overrides the settings of a single controller. Unset
+// fields default to the top level settings of the ControllerConfiguration.
+type ControllerSettings struct {
+	// workers is the number of items the controller processes concurrently.
+	// +optional
+	Workers *int
+
+	// kubernetesAPIQPS is the maximum queries-per-second of requests sent to
+	// the Kubernetes apiserver by the controller. If set, the controller's
+	// requests are rate limited separately from those of the other
+	// controllers.
+	// +optional
+	KubernetesAPIQPS *float32
+
+	// kubernetesAPIBurst is the maximum burst of requests sent to the
+	// Kubernetes apiserver by the controller. Only used together with
+	// kubernetesAPIQPS.
+	// +optional
+	KubernetesAPIBurst *int
+
+	// rateLimiter configures how quickly items are retried by the
+	// controller's workqueue.
+	// +optional
+	RateLimiter *RateLimiterConfiguration
+}
+
+// RateLimiterConfiguration configures the rate limiter of a workqueue. Items
+// which fail to be processed are retried with an exponential backoff between
+// baseDelay and maxDelay. Unset fields default to the values built into the
+// controller.
+type RateLimiterConfiguration struct {
+	// baseDelay is the delay before the first retry of an item.
+	// +optional
+	BaseDelay *metav1.Duration
+
+	// maxDelay is the maximum delay between retries of an item.
+	// +optional
+	MaxDelay *metav1.Duration
+
+	// qps limits the overall rate at which items are taken from the
+	// workqueue, regardless of the backoff of individual items.
+	// If unset, the overall rate is not limited.
+	// +optional
+	QPS *float32
+
+	// burst is the maximum burst of items taken from the workqueue when qps
+	// is set.
+	// +optional
+	Burst *int
+}
+
+// NotificationsConfiguration configures the sinks notified of certificate
+// events.
+type NotificationsConfiguration struct {
+	// sinks are the HTTP endpoints notified of certificate events.
+	Sinks []NotificationSink
+
+	// expiryWarning is how long before a certificate expires the Expiring
+	// notification is sent. Certificates are normally renewed well before,
+	// so the notification means that renewal is failing or misconfigured.
+	// Defaults to 168h (7 days).
+	// +optional
+	ExpiryWarning *metav1.Duration
+}
+
+// NotificationSink is an HTTP endpoint which certificate events are POSTed
+// to.
+type NotificationSink struct {
+	// name identifies the sink in logs.
+	Name string
+
+	// url is the HTTP or HTTPS URL events are POSTed to.
+	URL string
+
+	// format is the format of the payload, either 'JSON' for the plain
+	// event, or 'CloudEvents' for a CloudEvents 1.0 event in structured
+	// mode. Defaults to 'JSON'.
+	// +optional
+	Format string
+
+	// signingKeyFile is the path to a file holding the key payloads are
+	// signed with. The HMAC-SHA256 of the payload is sent hex encoded in
+	// the X-Cert-Manager-Signature header, as 'sha256=<hmac>'. Payloads are
+	// not signed if unset.
+	// +optional
+	SigningKeyFile string
+
+	// events are the types of the events sent to the sink: Issued, Renewed,
+	// Failed or Expiring. All events are sent if empty.
+	// +optional
+	Events []string
+
+	// timeout is the timeout of each request. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/config/controller/v1alpha1"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+func SetDefaults_ControllerConfiguration(obj *v1alpha1.ControllerConfiguration) {
+	if obj.KubernetesAPIQPS == nil {
+		obj.KubernetesAPIQPS = pointer.Float32(20)
+	}
+	if obj.KubernetesAPIBurst == nil {
+		obj.KubernetesAPIBurst = pointer.Int(50)
+	}
+	if obj.Workers == nil {
+		obj.Workers = pointer.Int(5)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	config "github.com/cert-manager/cert-manager/internal/apis/config/controller"
+	"github.com/cert-manager/cert-manager/pkg/notifications"
+)
+
+func ValidateControllerConfiguration(cfg *config.ControllerConfiguration) error {
+	var allErrors []error
+	if cfg.KubernetesAPIQPS != nil && *cfg.KubernetesAPIQPS <= 0 {
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: kubernetesAPIQPS must be greater than 0"))
+	}
+	if cfg.KubernetesAPIBurst != nil && *cfg.KubernetesAPIBurst <= 0 {
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: kubernetesAPIBurst must be greater than 0"))
+	}
+	if cfg.Workers != nil && *cfg.Workers <= 0 {
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: workers must be greater than 0"))
+	}
+
+	names := make([]string, 0, len(cfg.Controllers))
+	for name := range cfg.Controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		allErrors = append(allErrors, validateControllerSettings(fmt.Sprintf("controllers[%s]", name), cfg.Controllers[name])...)
+	}
+	if cfg.Notifications != nil {
+		allErrors = append(allErrors, validateNotifications(cfg.Notifications)...)
+	}
+	return utilerrors.NewAggregate(allErrors)
+}
+
+func validateControllerSettings(path string, settings config.ControllerSettings) []error {
+	var allErrors []error
+	if settings.Workers != nil && *settings.Workers <= 0 {
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.workers must be greater than 0", path))
+	}
+	if settings.KubernetesAPIQPS != nil {
+		if *settings.KubernetesAPIQPS <= 0 {
+			allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.kubernetesAPIQPS must be greater than 0", path))
+		}
+		if settings.KubernetesAPIBurst == nil || *settings.KubernetesAPIBurst <= 0 {
+			allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.kubernetesAPIBurst must be greater than 0 when kubernetesAPIQPS is set", path))
+		}
+	} else if settings.KubernetesAPIBurst != nil {
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.kubernetesAPIBurst can only be set together with kubernetesAPIQPS", path))
+	}
+
+	if rl := settings.RateLimiter; rl != nil {
+		if rl.BaseDelay != nil && rl.BaseDelay.Duration <= 0 {
+			allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.rateLimiter.baseDelay must be greater than 0", path))
+		}
+		if rl.MaxDelay != nil && rl.MaxDelay.Duration <= 0 {
+			allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.rateLimiter.maxDelay must be greater than 0", path))
+		}
+		if rl.BaseDelay != nil && rl.MaxDelay != nil && rl.BaseDelay.Duration > rl.MaxDelay.Duration {
+			allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.rateLimiter.baseDelay must not be greater than maxDelay", path))
+		}
+		if rl.QPS != nil {
+			if *rl.QPS <= 0 {
+				allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.rateLimiter.qps must be greater than 0", path))
+			}
+			if rl.Burst == nil || *rl.Burst <= 0 {
+				allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.rateLimiter.burst must be greater than 0 when qps is set", path))
+			}
+		} else if rl.Burst != nil {
+			allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.rateLimiter.burst can only be set together with qps", path))
+		}
+	}
+	return allErrors
+}
+
+func validateNotifications(cfg *config.NotificationsConfiguration) []error {
+	var allErrors []error
+	if cfg.ExpiryWarning != nil && cfg.ExpiryWarning.Duration <= 0 {
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: notifications.expiryWarning must be greater than 0"))
+	}
+
+	names := make(map[string]bool, len(cfg.Sinks))
+	for i, sink := range cfg.Sinks {
+		path := fmt.Sprintf("notifications.sinks[%d]", i)
+		if sink.Name == "" {
+			allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.name must be set", path))
+		} else if names[sink.Name] {
+			allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.name %q is used by another sink", path, sink.Name))
+		}
+		names[sink.Name] = true
+
+		if u, err := url.Parse(sink.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.url must be an http or https URL", path))
+		}
+
+		switch notifications.Format(sink.Format) {
+		case "", notifications.FormatJSON, notifications.FormatCloudEvents:
+		default:
+			allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.format must be one of %q or %q", path, notifications.FormatJSON, notifications.FormatCloudEvents))
+		}
+
+		for j, event := range sink.Events {
+			if !isEventType(event) {
+				allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.events[%d] must be one of %q", path, j, notifications.EventTypes))
+			}
+		}
+
+		if sink.Timeout != nil && sink.Timeout.Duration <= 0 {
+			allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.timeout must be greater than 0", path))
+		}
+	}
+	return allErrors
+}
+
+func isEventType(event string) bool {
+	for _, t := range notifications.EventTypes {
+		if string(t) == event {
+			return true
+		}
+	}
+	return false
+}
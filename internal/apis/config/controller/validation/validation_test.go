@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	config "github.com/cert-manager/cert-manager/internal/apis/config/controller"
+)
+
+func TestValidateControllerConfiguration(t *testing.T) {
+	tests := map[string]struct {
+		cfg       config.ControllerConfiguration
+		expectErr bool
+	}{
+		"empty configuration is valid": {},
+		"valid controller settings": {
+			cfg: config.ControllerConfiguration{
+				Workers: pointer.Int(5),
+				Controllers: map[string]config.ControllerSettings{
+					"certificates-issuing": {
+						Workers:            pointer.Int(10),
+						KubernetesAPIQPS:   pointer.Float32(5),
+						KubernetesAPIBurst: pointer.Int(10),
+						RateLimiter: &config.RateLimiterConfiguration{
+							BaseDelay: &metav1.Duration{Duration: time.Second},
+							MaxDelay:  &metav1.Duration{Duration: time.Minute},
+						},
+					},
+				},
+			},
+		},
+		"workers must be positive": {
+			cfg:       config.ControllerConfiguration{Workers: pointer.Int(0)},
+			expectErr: true,
+		},
+		"controller kubernetesAPIQPS requires kubernetesAPIBurst": {
+			cfg: config.ControllerConfiguration{
+				Controllers: map[string]config.ControllerSettings{
+					"issuers": {KubernetesAPIQPS: pointer.Float32(5)},
+				},
+			},
+			expectErr: true,
+		},
+		"rate limiter baseDelay must not exceed maxDelay": {
+			cfg: config.ControllerConfiguration{
+				Controllers: map[string]config.ControllerSettings{
+					"issuers": {RateLimiter: &config.RateLimiterConfiguration{
+						BaseDelay: &metav1.Duration{Duration: time.Minute},
+						MaxDelay:  &metav1.Duration{Duration: time.Second},
+					}},
+				},
+			},
+			expectErr: true,
+		},
+		"rate limiter burst requires qps": {
+			cfg: config.ControllerConfiguration{
+				Controllers: map[string]config.ControllerSettings{
+					"issuers": {RateLimiter: &config.RateLimiterConfiguration{Burst: pointer.Int(10)}},
+				},
+			},
+			expectErr: true,
+		},
+		"valid notification sinks": {
+			cfg: config.ControllerConfiguration{
+				Notifications: &config.NotificationsConfiguration{
+					ExpiryWarning: &metav1.Duration{Duration: 72 * time.Hour},
+					Sinks: []config.NotificationSink{
+						{Name: "json", URL: "https://example.com/hook"},
+						{
+							Name:    "cloudevents",
+							URL:     "http://broker.knative-eventing.svc/default",
+							Format:  "CloudEvents",
+							Events:  []string{"Failed", "Expiring"},
+							Timeout: &metav1.Duration{Duration: 5 * time.Second},
+						},
+					},
+				},
+			},
+		},
+		"notification sink names must be unique": {
+			cfg: config.ControllerConfiguration{
+				Notifications: &config.NotificationsConfiguration{
+					Sinks: []config.NotificationSink{
+						{Name: "hook", URL: "https://example.com/a"},
+						{Name: "hook", URL: "https://example.com/b"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"notification sink url must be http or https": {
+			cfg: config.ControllerConfiguration{
+				Notifications: &config.NotificationsConfiguration{
+					Sinks: []config.NotificationSink{{Name: "hook", URL: "ftp://example.com"}},
+				},
+			},
+			expectErr: true,
+		},
+		"notification sink format must be known": {
+			cfg: config.ControllerConfiguration{
+				Notifications: &config.NotificationsConfiguration{
+					Sinks: []config.NotificationSink{{Name: "hook", URL: "https://example.com", Format: "XML"}},
+				},
+			},
+			expectErr: true,
+		},
+		"notification sink events must be known": {
+			cfg: config.ControllerConfiguration{
+				Notifications: &config.NotificationsConfiguration{
+					Sinks: []config.NotificationSink{{Name: "hook", URL: "https://example.com", Events: []string{"Deleted"}}},
+				},
+			},
+			expectErr: true,
+		},
+		"notification expiryWarning must be positive": {
+			cfg: config.ControllerConfiguration{
+				Notifications: &config.NotificationsConfiguration{
+					ExpiryWarning: &metav1.Duration{},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateControllerConfiguration(&test.cfg)
+			if test.expectErr != (err != nil) {
+				t.Errorf("expected error=%t, got %v", test.expectErr, err)
+			}
+		})
+	}
+}
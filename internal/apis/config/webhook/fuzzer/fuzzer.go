@@ -39,6 +39,9 @@ var Funcs = func(codecs runtimeserializer.CodecFactory) []interface{} {
 			if s.PprofAddress == "" {
 				s.PprofAddress = "something:1234"
 			}
+			if s.ClusterResourceNamespace == "" {
+				s.ClusterResourceNamespace = "some-namespace"
+			}
 		},
 	}
 }
@@ -51,6 +51,18 @@ type WebhookConfiguration struct {
 	// Defaults to 'localhost:6060'.
 	PprofAddress string
 
+	// clusterResourceNamespace is the namespace in which the Secrets referenced
+	// by ClusterIssuers are stored. It must match the cluster resource namespace
+	// of the controller, and is used when validating ClusterIssuers against
+	// their issuing backend.
+	// Defaults to 'kube-system'.
+	ClusterResourceNamespace string
+
+	// disablePolicyCoveredChecks disables the checks which are also made by
+	// the ValidatingAdmissionPolicies installed by the Helm chart. It must
+	// only be set when the policies are installed.
+	DisablePolicyCoveredChecks bool
+
 	// featureGates is a map of feature names to bools that enable or disable experimental
 	// features.
 	// Default: nil
@@ -59,7 +71,7 @@ type WebhookConfiguration struct {
 }
 
 // TLSConfig configures how TLS certificates are sourced for serving.
-// Only one of 'filesystem' or 'dynamic' may be specified.
+// Only one of 'filesystem', 'dynamic' or 'secret' may be specified.
 type TLSConfig struct {
 	// cipherSuites is the list of allowed cipher suites for the server.
 	// Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants).
@@ -82,6 +94,12 @@ type TLSConfig struct {
 	// The CAs certificate can then be copied into the appropriate Validating, Mutating and Conversion
 	// webhook configuration objects (typically by cainjector).
 	Dynamic DynamicServingConfig
+
+	// Secret enables using a certificate and private key stored in a
+	// Kubernetes Secret resource which is managed externally, such as by a
+	// central PKI. The Secret is watched and the certificate is reloaded
+	// whenever it changes.
+	Secret SecretServingConfig
 }
 
 func (c *TLSConfig) FilesystemConfigProvided() bool {
@@ -91,6 +109,13 @@ func (c *TLSConfig) FilesystemConfigProvided() bool {
 	return false
 }
 
+func (c *TLSConfig) SecretConfigProvided() bool {
+	if c.Secret.SecretNamespace != "" || c.Secret.SecretName != "" {
+		return true
+	}
+	return false
+}
+
 func (c *TLSConfig) DynamicConfigProvided() bool {
 	if c.Dynamic.SecretNamespace != "" || c.Dynamic.SecretName != "" || len(c.Dynamic.DNSNames) > 0 {
 		return true
@@ -122,3 +147,15 @@ type FilesystemServingConfig struct {
 	// Path to a file containing a TLS private key to server with
 	KeyFile string
 }
+
+// SecretServingConfig enables using a certificate and private key stored in a
+// Kubernetes Secret resource.
+type SecretServingConfig struct {
+	// Namespace of the Kubernetes Secret resource containing the TLS
+	// certificate and private key to serve with.
+	SecretNamespace string
+
+	// Name of the Kubernetes Secret resource containing the TLS certificate
+	// and private key to serve with, in its `tls.crt` and `tls.key` keys.
+	SecretName string
+}
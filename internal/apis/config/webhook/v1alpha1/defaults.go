@@ -37,4 +37,7 @@ func SetDefaults_WebhookConfiguration(obj *v1alpha1.WebhookConfiguration) {
 	if obj.PprofAddress == "" {
 		obj.PprofAddress = "localhost:6060"
 	}
+	if obj.ClusterResourceNamespace == "" {
+		obj.ClusterResourceNamespace = "kube-system"
+	}
 }
@@ -57,6 +57,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SecretServingConfig)(nil), (*webhook.SecretServingConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SecretServingConfig_To_webhook_SecretServingConfig(a.(*v1alpha1.SecretServingConfig), b.(*webhook.SecretServingConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*webhook.SecretServingConfig)(nil), (*v1alpha1.SecretServingConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_webhook_SecretServingConfig_To_v1alpha1_SecretServingConfig(a.(*webhook.SecretServingConfig), b.(*v1alpha1.SecretServingConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.TLSConfig)(nil), (*webhook.TLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_TLSConfig_To_webhook_TLSConfig(a.(*v1alpha1.TLSConfig), b.(*webhook.TLSConfig), scope)
 	}); err != nil {
@@ -126,6 +136,28 @@ func Convert_webhook_FilesystemServingConfig_To_v1alpha1_FilesystemServingConfig
 	return autoConvert_webhook_FilesystemServingConfig_To_v1alpha1_FilesystemServingConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_SecretServingConfig_To_webhook_SecretServingConfig(in *v1alpha1.SecretServingConfig, out *webhook.SecretServingConfig, s conversion.Scope) error {
+	out.SecretNamespace = in.SecretNamespace
+	out.SecretName = in.SecretName
+	return nil
+}
+
+// Convert_v1alpha1_SecretServingConfig_To_webhook_SecretServingConfig is an autogenerated conversion function.
+func Convert_v1alpha1_SecretServingConfig_To_webhook_SecretServingConfig(in *v1alpha1.SecretServingConfig, out *webhook.SecretServingConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SecretServingConfig_To_webhook_SecretServingConfig(in, out, s)
+}
+
+func autoConvert_webhook_SecretServingConfig_To_v1alpha1_SecretServingConfig(in *webhook.SecretServingConfig, out *v1alpha1.SecretServingConfig, s conversion.Scope) error {
+	out.SecretNamespace = in.SecretNamespace
+	out.SecretName = in.SecretName
+	return nil
+}
+
+// Convert_webhook_SecretServingConfig_To_v1alpha1_SecretServingConfig is an autogenerated conversion function.
+func Convert_webhook_SecretServingConfig_To_v1alpha1_SecretServingConfig(in *webhook.SecretServingConfig, out *v1alpha1.SecretServingConfig, s conversion.Scope) error {
+	return autoConvert_webhook_SecretServingConfig_To_v1alpha1_SecretServingConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_TLSConfig_To_webhook_TLSConfig(in *v1alpha1.TLSConfig, out *webhook.TLSConfig, s conversion.Scope) error {
 	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
 	out.MinTLSVersion = in.MinTLSVersion
@@ -135,6 +167,9 @@ func autoConvert_v1alpha1_TLSConfig_To_webhook_TLSConfig(in *v1alpha1.TLSConfig,
 	if err := Convert_v1alpha1_DynamicServingConfig_To_webhook_DynamicServingConfig(&in.Dynamic, &out.Dynamic, s); err != nil {
 		return err
 	}
+	if err := Convert_v1alpha1_SecretServingConfig_To_webhook_SecretServingConfig(&in.Secret, &out.Secret, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -152,6 +187,9 @@ func autoConvert_webhook_TLSConfig_To_v1alpha1_TLSConfig(in *webhook.TLSConfig,
 	if err := Convert_webhook_DynamicServingConfig_To_v1alpha1_DynamicServingConfig(&in.Dynamic, &out.Dynamic, s); err != nil {
 		return err
 	}
+	if err := Convert_webhook_SecretServingConfig_To_v1alpha1_SecretServingConfig(&in.Secret, &out.Secret, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -170,6 +208,8 @@ func autoConvert_v1alpha1_WebhookConfiguration_To_webhook_WebhookConfiguration(i
 	out.APIServerHost = in.APIServerHost
 	out.EnablePprof = in.EnablePprof
 	out.PprofAddress = in.PprofAddress
+	out.ClusterResourceNamespace = in.ClusterResourceNamespace
+	out.DisablePolicyCoveredChecks = in.DisablePolicyCoveredChecks
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
 	return nil
 }
@@ -189,6 +229,8 @@ func autoConvert_webhook_WebhookConfiguration_To_v1alpha1_WebhookConfiguration(i
 	out.APIServerHost = in.APIServerHost
 	out.EnablePprof = in.EnablePprof
 	out.PprofAddress = in.PprofAddress
+	out.ClusterResourceNamespace = in.ClusterResourceNamespace
+	out.DisablePolicyCoveredChecks = in.DisablePolicyCoveredChecks
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
 	return nil
 }
@@ -28,6 +28,8 @@ func ValidateWebhookConfiguration(cfg *config.WebhookConfiguration) error {
 	var allErrors []error
 	if cfg.TLSConfig.FilesystemConfigProvided() && cfg.TLSConfig.DynamicConfigProvided() {
 		allErrors = append(allErrors, fmt.Errorf("invalid configuration: cannot specify both filesystem based and dynamic TLS configuration"))
+	} else if cfg.TLSConfig.SecretConfigProvided() && (cfg.TLSConfig.FilesystemConfigProvided() || cfg.TLSConfig.DynamicConfigProvided()) {
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: cannot specify Secret based TLS configuration together with filesystem based or dynamic TLS configuration"))
 	} else {
 		if cfg.TLSConfig.FilesystemConfigProvided() {
 			if cfg.TLSConfig.Filesystem.KeyFile == "" {
@@ -46,6 +48,13 @@ func ValidateWebhookConfiguration(cfg *config.WebhookConfiguration) error {
 			if len(cfg.TLSConfig.Dynamic.DNSNames) == 0 {
 				allErrors = append(allErrors, fmt.Errorf("invalid configuration: tlsConfig.dynamic.dnsNames (--dynamic-serving-dns-names) must be specified when using dynamic TLS config"))
 			}
+		} else if cfg.TLSConfig.SecretConfigProvided() {
+			if cfg.TLSConfig.Secret.SecretNamespace == "" {
+				allErrors = append(allErrors, fmt.Errorf("invalid configuration: tlsConfig.secret.secretNamespace (--tls-secret-namespace) must be specified when using Secret based TLS config"))
+			}
+			if cfg.TLSConfig.Secret.SecretName == "" {
+				allErrors = append(allErrors, fmt.Errorf("invalid configuration: tlsConfig.secret.secretName (--tls-secret-name) must be specified when using Secret based TLS config"))
+			}
 		}
 	}
 	if cfg.HealthzPort == nil {
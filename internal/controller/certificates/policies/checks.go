@@ -35,6 +35,7 @@ import (
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
@@ -98,6 +99,14 @@ func SecretIssuerAnnotationsNotUpToDate(input Input) (string, string, bool) {
 	name := input.Secret.Annotations[cmapi.IssuerNameAnnotationKey]
 	kind := input.Secret.Annotations[cmapi.IssuerKindAnnotationKey]
 	group := input.Secret.Annotations[cmapi.IssuerGroupAnnotationKey]
+	if name == "" && kind == "" && group == "" && SecretIssuedByRequest(input.Secret, input.CurrentRevisionRequest) &&
+		requestIssuerMatchesSpec(input.CurrentRevisionRequest, input.Certificate) {
+		// The annotations have been stripped, for example by restoring the
+		// Secret from a backup, but the certificate was issued by the current
+		// request for the same issuer. The annotations are restored by the
+		// SecretIssuanceMetadataMissing post issuance check instead.
+		return "", "", false
+	}
 	if name != input.Certificate.Spec.IssuerRef.Name ||
 		!issuerKindsEqual(kind, input.Certificate.Spec.IssuerRef.Kind) ||
 		!issuerGroupsEqual(group, input.Certificate.Spec.IssuerRef.Group) {
@@ -182,6 +191,21 @@ func CurrentCertificateNearingExpiry(c clock.Clock) Func {
 	}
 }
 
+// PrivateKeyReachedMaxAge triggers the re-issuance of Certificates using the
+// Scheduled rotation policy once their private key has reached the maximum
+// age allowed by their rotation schedule, even if the certificate itself is
+// not yet due for renewal. The private key is then rotated by the keymanager
+// controller.
+func PrivateKeyReachedMaxAge(c clock.Clock) Func {
+	return func(input Input) (string, string, bool) {
+		rotationTime := certificates.PrivateKeyRotationTime(input.Certificate)
+		if rotationTime == nil || c.Now().Before(rotationTime.Time) {
+			return "", "", false
+		}
+		return PrivateKeyRotationDue, fmt.Sprintf("Rotating private key as it reached the maximum age allowed by its rotation schedule at %s", rotationTime), true
+	}
+}
+
 // CurrentCertificateHasExpired is used exclusively to check if the current
 // issued certificate has actually expired rather than just nearing expiry.
 func CurrentCertificateHasExpired(c clock.Clock) Func {
@@ -266,6 +290,78 @@ func SecretTemplateMismatchesSecret(input Input) (string, string, bool) {
 	return "", "", false
 }
 
+// SecretManagedLabelsAreMissing returns true if the Secret does not have the
+// label marking it as managed by cert-manager, such as Secrets which were
+// issued before the label was introduced.
+func SecretManagedLabelsAreMissing(input Input) (string, string, bool) {
+	if input.Secret.Labels[cmapi.PartOfCertManagerControllerLabelKey] != "true" {
+		return SecretManagedLabelsMissing, fmt.Sprintf("Secret is missing the %s label", cmapi.PartOfCertManagerControllerLabelKey), true
+	}
+	return "", "", false
+}
+
+// SecretIssuanceMetadataMissing returns true if the Secret is missing the
+// annotations which record how its certificate was issued, or the CA returned
+// by the issuer, and the certificate was issued by the current revision's
+// CertificateRequest. This happens when a Secret is restored from a backup
+// which strips annotations or drops the `ca.crt` key, and is repaired from the
+// CertificateRequest rather than by re-issuing the certificate.
+func SecretIssuanceMetadataMissing(input Input) (string, string, bool) {
+	if !SecretIssuedByRequest(input.Secret, input.CurrentRevisionRequest) {
+		return "", "", false
+	}
+
+	var missing []string
+	for _, key := range append([]string{
+		cmapi.CertificateNameKey,
+		cmapi.IssuerNameAnnotationKey,
+		cmapi.IssuerKindAnnotationKey,
+		cmapi.IssuerGroupAnnotationKey,
+	}, internalcertificates.CertificateRequestAnnotationKeys...) {
+		if _, ok := input.Secret.Annotations[key]; !ok {
+			// The revision can only be restored if the CertificateRequest
+			// records it.
+			if key == cmapi.CertificateRequestRevisionAnnotationKey && input.CurrentRevisionRequest.Annotations[key] == "" {
+				continue
+			}
+			missing = append(missing, key)
+		}
+	}
+	if len(input.CurrentRevisionRequest.Status.CA) > 0 && len(input.Secret.Data[cmmeta.TLSCAKey]) == 0 {
+		missing = append(missing, cmmeta.TLSCAKey)
+	}
+
+	if len(missing) > 0 {
+		return SecretIncomplete, fmt.Sprintf("Secret is missing %s of the issued certificate", strings.Join(missing, ", ")), true
+	}
+	return "", "", false
+}
+
+// SecretIssuedByRequest returns true if the certificate stored in the Secret
+// is the certificate issued for the CertificateRequest.
+func SecretIssuedByRequest(secret *corev1.Secret, req *cmapi.CertificateRequest) bool {
+	if secret == nil || req == nil || len(req.Status.Certificate) == 0 {
+		return false
+	}
+	secretCert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return false
+	}
+	reqCert, err := pki.DecodeX509CertificateBytes(req.Status.Certificate)
+	if err != nil {
+		return false
+	}
+	return secretCert.Equal(reqCert)
+}
+
+// requestIssuerMatchesSpec returns true if the CertificateRequest was made
+// to the issuer referenced by the Certificate.
+func requestIssuerMatchesSpec(req *cmapi.CertificateRequest, crt *cmapi.Certificate) bool {
+	return req.Spec.IssuerRef.Name == crt.Spec.IssuerRef.Name &&
+		issuerKindsEqual(req.Spec.IssuerRef.Kind, crt.Spec.IssuerRef.Kind) &&
+		issuerGroupsEqual(req.Spec.IssuerRef.Group, crt.Spec.IssuerRef.Group)
+}
+
 // SecretTemplateMismatchesSecretManagedFields will inspect the given Secret's
 // managed fields for its Annotations and Labels, and compare this against the
 // SecretTemplate on the given Certificate. Returns false if Annotations and
@@ -329,6 +425,8 @@ func SecretTemplateMismatchesSecretManagedFields(fieldManager string) Func {
 		for k := range baseAnnotations {
 			managedAnnotations = managedAnnotations.Delete(k)
 		}
+		managedAnnotations = managedAnnotations.Delete(internalcertificates.CertificateRequestAnnotationKeys...)
+		managedLabels = managedLabels.Delete(cmapi.PartOfCertManagerControllerLabelKey)
 
 		// Check early for Secret Template being nil, and whether managed
 		// labels/annotations are not.
@@ -401,6 +499,108 @@ func SecretAdditionalOutputFormatsDataMismatch(input Input) (string, string, boo
 	return "", "", false
 }
 
+// SecretFormatProfileDataMismatch validates that the Secret has the keys
+// arranged by the Certificate's SecretFormatProfile, so that they are written
+// when a profile is set on a certificate which has already been issued.
+// Returns true (violation) if any of the profile's keys are missing or don't
+// match the Secret's private key, certificate chain and CA.
+func SecretFormatProfileDataMismatch(input Input) (string, string, bool) {
+	expected := internalcertificates.SecretFormatProfileData(input.Certificate.Spec.SecretFormatProfile,
+		input.Secret.Data[corev1.TLSPrivateKeyKey],
+		input.Secret.Data[corev1.TLSCertKey],
+		input.Secret.Data[cmmeta.TLSCAKey],
+	)
+	for k, v := range expected {
+		if actual, ok := input.Secret.Data[k]; !ok || !bytes.Equal(actual, v) {
+			return SecretFormatProfileMismatch, fmt.Sprintf("Secret key %q doesn't match Certificate's SecretFormatProfile %s", k, input.Certificate.Spec.SecretFormatProfile), true
+		}
+	}
+
+	return "", "", false
+}
+
+// SecretFormatProfileOwnerMismatch validates that the field manager doesn't
+// own keys of a SecretFormatProfile which is not set on the Certificate, so
+// that they are removed from the Secret when the profile is changed or unset.
+// A violation with the reason `ManagedFieldsParseError` should be considered a
+// non re-triable error.
+func SecretFormatProfileOwnerMismatch(fieldManager string) Func {
+	profileKeys := []string{
+		cmapi.SecretFormatProfileHAProxyKey,
+		cmapi.SecretFormatProfileEnvoySDSCertKey,
+		cmapi.SecretFormatProfileEnvoySDSKeyKey,
+		cmapi.SecretFormatProfileEnvoySDSCAKey,
+	}
+	return func(input Input) (string, string, bool) {
+		expected := internalcertificates.SecretFormatProfileData(input.Certificate.Spec.SecretFormatProfile,
+			input.Secret.Data[corev1.TLSPrivateKeyKey],
+			input.Secret.Data[corev1.TLSCertKey],
+			input.Secret.Data[cmmeta.TLSCAKey],
+		)
+
+		for _, managedField := range input.Secret.ManagedFields {
+			if managedField.Manager != fieldManager || managedField.FieldsV1 == nil {
+				continue
+			}
+
+			var fieldset fieldpath.Set
+			if err := fieldset.FromJSON(bytes.NewReader(managedField.FieldsV1.Raw)); err != nil {
+				return ManagedFieldsParseError, fmt.Sprintf("failed to decode managed fields on Secret: %s", err), true
+			}
+
+			for _, k := range profileKeys {
+				if _, ok := expected[k]; ok {
+					continue
+				}
+				if fieldset.Has(fieldpath.Path{
+					{FieldName: pointer.String("data")},
+					{FieldName: pointer.String(k)},
+				}) {
+					return SecretFormatProfileMismatch, fmt.Sprintf("Secret key %q is not part of Certificate's SecretFormatProfile", k), true
+				}
+			}
+		}
+
+		return "", "", false
+	}
+}
+
+// SecretKeystoresMismatch validates that the Secret has the keystores
+// configured by the Certificate's Keystores, so that they are created when
+// they are enabled for a certificate which has already been issued, or
+// restored when they are removed from the Secret, without waiting for the
+// certificate to be renewed.
+// Returns true (violation) if a keystore is enabled and any of the following:
+//   - the keystore is missing
+//   - the truststore is missing, while the Secret has a CA
+func SecretKeystoresMismatch(input Input) (string, string, bool) {
+	keystores := input.Certificate.Spec.Keystores
+	if keystores == nil {
+		return "", "", false
+	}
+	hasCA := len(input.Secret.Data[cmmeta.TLSCAKey]) > 0
+
+	if keystores.PKCS12 != nil && keystores.PKCS12.Create {
+		if len(input.Secret.Data[cmapi.PKCS12SecretKey]) == 0 {
+			return KeystoresMismatch, fmt.Sprintf("Secret is missing the PKCS12 keystore %q", cmapi.PKCS12SecretKey), true
+		}
+		if hasCA && len(input.Secret.Data[cmapi.PKCS12TruststoreKey]) == 0 {
+			return KeystoresMismatch, fmt.Sprintf("Secret is missing the PKCS12 truststore %q", cmapi.PKCS12TruststoreKey), true
+		}
+	}
+
+	if keystores.JKS != nil && keystores.JKS.Create {
+		if len(input.Secret.Data[cmapi.JKSSecretKey]) == 0 {
+			return KeystoresMismatch, fmt.Sprintf("Secret is missing the JKS keystore %q", cmapi.JKSSecretKey), true
+		}
+		if hasCA && len(input.Secret.Data[cmapi.JKSTruststoreKey]) == 0 {
+			return KeystoresMismatch, fmt.Sprintf("Secret is missing the JKS truststore %q", cmapi.JKSTruststoreKey), true
+		}
+	}
+
+	return "", "", false
+}
+
 // SecretAdditionalOutputFormatsOwnerMismatch validates that the field manager
 // owns the correct Certificate's AdditionalOutputFormats in the Secret.
 // Returns true (violation) if:
@@ -42,6 +42,49 @@ import (
 func Test_NewTriggerPolicyChain(t *testing.T) {
 	clock := &fakeclock.FakeClock{}
 	staticFixedPrivateKey := testcrypto.MustCreatePEMPrivateKey(t)
+	restoredCert := testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+		&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+	)
+	scheduledRotationCertificate := func(privateKeyIssuedTime time.Time) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+				PrivateKey: &cmapi.CertificatePrivateKey{
+					RotationPolicy: cmapi.RotationPolicyScheduled,
+					RotationSchedule: &cmapi.PrivateKeyRotationSchedule{
+						MaxAge: &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			Status: cmapi.CertificateStatus{
+				RenewalTime:          &metav1.Time{Time: clock.Now().Add(time.Hour)},
+				PrivateKeyIssuedTime: &metav1.Time{Time: privateKeyIssuedTime},
+			},
+		}
+	}
+	scheduledRotationSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "something",
+			Annotations: map[string]string{
+				cmapi.IssuerNameAnnotationKey:  "testissuer",
+				cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+				cmapi.IssuerGroupAnnotationKey: "group.example.com",
+			},
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+			corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, staticFixedPrivateKey,
+				&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+				clock.Now().Add(time.Hour*-1),
+				// expires in 2 hours time
+				clock.Now().Add(time.Hour*2),
+			),
+		},
+	}
 	tests := map[string]struct {
 		// policy inputs
 		certificate *cmapi.Certificate
@@ -213,6 +256,71 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			message: "Issuing certificate as Secret was previously issued by IssuerKind.new.example.com/testissuer",
 			reissue: true,
 		},
+		"do nothing if issuer annotations were stripped from a Secret issued by the current CertificateRequest": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something"},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey:       restoredCert,
+				},
+			},
+			request: &cmapi.CertificateRequest{
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+					Request: testcrypto.MustGenerateCSRImpl(t, staticFixedPrivateKey, &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+						CommonName: "example.com",
+					}}),
+				},
+				Status: cmapi.CertificateRequestStatus{Certificate: restoredCert},
+			},
+		},
+		"trigger issuance if issuer annotations were stripped and the Secret was not issued by the current CertificateRequest": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something"},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey:       restoredCert,
+				},
+			},
+			request: &cmapi.CertificateRequest{
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+					Request: testcrypto.MustGenerateCSRImpl(t, staticFixedPrivateKey, &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+						CommonName: "example.com",
+					}}),
+				},
+				Status: cmapi.CertificateRequestStatus{Certificate: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+					&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "other.example.com"}},
+				)},
+			},
+			reason:  IncorrectIssuer,
+			message: "Issuing certificate as Secret was previously issued by Issuer.cert-manager.io/",
+			reissue: true,
+		},
 		// we only have a basic test here for this as unit tests for the
 		// `certificates.RequestMatchesSpec` function cover all other cases.
 		"trigger issuance when CertificateRequest does not match certificate spec": {
@@ -489,6 +597,17 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 				},
 			},
 		},
+		"trigger issuance if the private key has reached its maximum age": {
+			certificate: scheduledRotationCertificate(clock.Now().Add(-time.Hour)),
+			secret:      scheduledRotationSecret,
+			reason:      PrivateKeyRotationDue,
+			message:     "Rotating private key as it reached the maximum age allowed by its rotation schedule at 0001-01-01 00:00:00 +0000 UTC",
+			reissue:     true,
+		},
+		"does not trigger issuance if the private key has not reached its maximum age": {
+			certificate: scheduledRotationCertificate(clock.Now().Add(-time.Minute)),
+			secret:      scheduledRotationSecret,
+		},
 	}
 	policyChain := NewTriggerPolicyChain(clock)
 	for name, test := range tests {
@@ -719,6 +838,22 @@ func Test_SecretTemplateMismatchesSecretManagedFields(t *testing.T) {
 			expMessage:          "Certificate's SecretTemplate doesn't match Secret",
 			expViolation:        true,
 		},
+		"if template is nil and managed fields only contain the CertificateRequest annotations, should return false": {
+			tmpl: nil,
+			secretManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager: fieldManager, FieldsV1: &metav1.FieldsV1{
+					Raw: []byte(`{"f:metadata": {
+							"f:annotations": {
+								"f:cert-manager.io/certificate-request-name": {},
+								"f:cert-manager.io/certificate-request-uid": {}
+							}
+						}}`),
+				}},
+			},
+			expReason:    "",
+			expMessage:   "",
+			expViolation: false,
+		},
 		"if template is nil but managed fields is not nil, should return true": {
 			tmpl: nil,
 			secretManagedFields: []metav1.ManagedFieldsEntry{{
@@ -1729,6 +1864,139 @@ func Test_SecretAdditionalOutputFormatsOwnerMismatch(t *testing.T) {
 	}
 }
 
+func Test_SecretFormatProfileDataMismatch(t *testing.T) {
+	cert := []byte("cert")
+	pk := []byte("key")
+	ca := []byte("ca")
+
+	tests := map[string]struct {
+		input        Input
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"if secret format profile is empty, should return false": {
+			input: Input{
+				Certificate: &cmapi.Certificate{},
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": cert, "tls.key": pk}},
+			},
+		},
+		"if HAProxy profile and haproxy.pem is missing, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretFormatProfile: cmapi.SecretFormatProfileHAProxy}},
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": cert, "tls.key": pk}},
+			},
+			expReason:    "SecretFormatProfileMismatch",
+			expMessage:   `Secret key "haproxy.pem" doesn't match Certificate's SecretFormatProfile HAProxy`,
+			expViolation: true,
+		},
+		"if HAProxy profile and haproxy.pem has the key before the certificate, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretFormatProfile: cmapi.SecretFormatProfileHAProxy}},
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": cert, "tls.key": pk, "haproxy.pem": []byte("key\ncert")}},
+			},
+			expReason:    "SecretFormatProfileMismatch",
+			expMessage:   `Secret key "haproxy.pem" doesn't match Certificate's SecretFormatProfile HAProxy`,
+			expViolation: true,
+		},
+		"if HAProxy profile and haproxy.pem is correct, should return false": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretFormatProfile: cmapi.SecretFormatProfileHAProxy}},
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": cert, "tls.key": pk, "haproxy.pem": []byte("cert\nkey")}},
+			},
+		},
+		"if EnvoySDS profile and cacert is missing while the Secret has a CA, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretFormatProfile: cmapi.SecretFormatProfileEnvoySDS}},
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": cert, "tls.key": pk, "ca.crt": ca, "cert": cert, "key": pk}},
+			},
+			expReason:    "SecretFormatProfileMismatch",
+			expMessage:   `Secret key "cacert" doesn't match Certificate's SecretFormatProfile EnvoySDS`,
+			expViolation: true,
+		},
+		"if EnvoySDS profile and the Secret has no CA, should return false": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretFormatProfile: cmapi.SecretFormatProfileEnvoySDS}},
+				Secret:      &corev1.Secret{Data: map[string][]byte{"tls.crt": cert, "tls.key": pk, "cert": cert, "key": pk}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := SecretFormatProfileDataMismatch(test.input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_SecretFormatProfileOwnerMismatch(t *testing.T) {
+	const fieldManager = "cert-manager-test"
+
+	haproxyManagedFields := []metav1.ManagedFieldsEntry{
+		{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{
+			Raw: []byte(`{"f:data": {".": {}, "f:tls.crt": {}, "f:tls.key": {}, "f:haproxy.pem": {}}}`),
+		}},
+	}
+
+	tests := map[string]struct {
+		input        Input
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"if secret format profile is empty and secret has no managed fields, should return false": {
+			input: Input{
+				Certificate: &cmapi.Certificate{},
+				Secret:      &corev1.Secret{},
+			},
+		},
+		"if HAProxy profile and field manager owns haproxy.pem, should return false": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretFormatProfile: cmapi.SecretFormatProfileHAProxy}},
+				Secret:      &corev1.Secret{ObjectMeta: metav1.ObjectMeta{ManagedFields: haproxyManagedFields}},
+			},
+		},
+		"if secret format profile is empty and field manager owns haproxy.pem, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{},
+				Secret:      &corev1.Secret{ObjectMeta: metav1.ObjectMeta{ManagedFields: haproxyManagedFields}},
+			},
+			expReason:    "SecretFormatProfileMismatch",
+			expMessage:   `Secret key "haproxy.pem" is not part of Certificate's SecretFormatProfile`,
+			expViolation: true,
+		},
+		"if EnvoySDS profile and field manager owns haproxy.pem, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretFormatProfile: cmapi.SecretFormatProfileEnvoySDS}},
+				Secret:      &corev1.Secret{ObjectMeta: metav1.ObjectMeta{ManagedFields: haproxyManagedFields}},
+			},
+			expReason:    "SecretFormatProfileMismatch",
+			expMessage:   `Secret key "haproxy.pem" is not part of Certificate's SecretFormatProfile`,
+			expViolation: true,
+		},
+		"if secret format profile is empty and another manager owns haproxy.pem, should return false": {
+			input: Input{
+				Certificate: &cmapi.Certificate{},
+				Secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{ManagedFields: []metav1.ManagedFieldsEntry{
+					{Manager: "not-cert-manager", FieldsV1: haproxyManagedFields[0].FieldsV1},
+				}}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := SecretFormatProfileOwnerMismatch(fieldManager)(test.input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
 func Test_SecretOwnerReferenceManagedFieldMismatch(t *testing.T) {
 	const fieldManager = "cert-manager-test"
 
@@ -2164,3 +2432,220 @@ func Test_SecretOwnerReferenceValueMismatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_SecretManagedLabelsAreMissing(t *testing.T) {
+	tests := map[string]struct {
+		labels map[string]string
+
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"secret with the managed label should return false": {
+			labels:       map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
+			expViolation: false,
+		},
+		"secret without labels should return true": {
+			labels:       nil,
+			expReason:    SecretManagedLabelsMissing,
+			expMessage:   "Secret is missing the controller.cert-manager.io/fao label",
+			expViolation: true,
+		},
+		"secret with wrong value of the managed label should return true": {
+			labels:       map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "false"},
+			expReason:    SecretManagedLabelsMissing,
+			expMessage:   "Secret is missing the controller.cert-manager.io/fao label",
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := SecretManagedLabelsAreMissing(Input{
+				Certificate: gen.Certificate("test-certificate"),
+				Secret:      &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: test.labels}},
+			})
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_SecretIssuanceMetadataMissing(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	cert := testcrypto.MustCreateCert(t, pk,
+		&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+	)
+	otherCert := testcrypto.MustCreateCert(t, pk,
+		&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "other.example.com"}},
+	)
+	ca := testcrypto.MustCreateCert(t, pk,
+		&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "ca.example.com", IsCA: true}},
+	)
+	completeAnnotations := map[string]string{
+		cmapi.CertificateNameKey:                  "test-certificate",
+		cmapi.IssuerNameAnnotationKey:             "testissuer",
+		cmapi.IssuerKindAnnotationKey:             "Issuer",
+		cmapi.IssuerGroupAnnotationKey:            "cert-manager.io",
+		cmapi.CertificateRequestNameAnnotationKey: "test-certificate-1",
+		cmapi.CertificateRequestUIDAnnotationKey:  "uid",
+	}
+
+	tests := map[string]struct {
+		request *cmapi.CertificateRequest
+		secret  *corev1.Secret
+
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"no CertificateRequest should return false": {
+			secret: &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: cert}},
+		},
+		"Secret not issued by the CertificateRequest should return false": {
+			request: &cmapi.CertificateRequest{Status: cmapi.CertificateRequestStatus{Certificate: otherCert}},
+			secret:  &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: cert}},
+		},
+		"Secret with complete metadata should return false": {
+			request: &cmapi.CertificateRequest{Status: cmapi.CertificateRequestStatus{Certificate: cert, CA: ca}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: completeAnnotations},
+				Data:       map[string][]byte{corev1.TLSCertKey: cert, cmmeta.TLSCAKey: ca},
+			},
+		},
+		"Secret without annotations should return true": {
+			request:      &cmapi.CertificateRequest{Status: cmapi.CertificateRequestStatus{Certificate: cert}},
+			secret:       &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: cert}},
+			expReason:    SecretIncomplete,
+			expMessage:   "Secret is missing cert-manager.io/certificate-name, cert-manager.io/issuer-name, cert-manager.io/issuer-kind, cert-manager.io/issuer-group, cert-manager.io/certificate-request-name, cert-manager.io/certificate-request-uid of the issued certificate",
+			expViolation: true,
+		},
+		"Secret missing the revision recorded on the CertificateRequest should return true": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{cmapi.CertificateRequestRevisionAnnotationKey: "1"}},
+				Status:     cmapi.CertificateRequestStatus{Certificate: cert},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: completeAnnotations},
+				Data:       map[string][]byte{corev1.TLSCertKey: cert},
+			},
+			expReason:    SecretIncomplete,
+			expMessage:   "Secret is missing cert-manager.io/certificate-revision of the issued certificate",
+			expViolation: true,
+		},
+		"Secret missing ca.crt should return true": {
+			request: &cmapi.CertificateRequest{Status: cmapi.CertificateRequestStatus{Certificate: cert, CA: ca}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: completeAnnotations},
+				Data:       map[string][]byte{corev1.TLSCertKey: cert},
+			},
+			expReason:    SecretIncomplete,
+			expMessage:   "Secret is missing ca.crt of the issued certificate",
+			expViolation: true,
+		},
+		"Secret without ca.crt should return false if the CertificateRequest has no CA": {
+			request: &cmapi.CertificateRequest{Status: cmapi.CertificateRequestStatus{Certificate: cert}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: completeAnnotations},
+				Data:       map[string][]byte{corev1.TLSCertKey: cert},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := SecretIssuanceMetadataMissing(Input{
+				Certificate:            gen.Certificate("test-certificate"),
+				CurrentRevisionRequest: test.request,
+				Secret:                 test.secret,
+			})
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_SecretKeystoresMismatch(t *testing.T) {
+	jks := &cmapi.JKSKeystore{Create: true, PasswordSecretRef: cmmeta.SecretKeySelector{Key: "password"}}
+	pkcs12 := &cmapi.PKCS12Keystore{Create: true, PasswordSecretRef: cmmeta.SecretKeySelector{Key: "password"}}
+
+	tests := map[string]struct {
+		keystores *cmapi.CertificateKeystores
+		data      map[string][]byte
+
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"no keystores should return false": {
+			keystores:    nil,
+			data:         map[string][]byte{"tls.crt": []byte("a")},
+			expViolation: false,
+		},
+		"disabled keystores should return false": {
+			keystores: &cmapi.CertificateKeystores{
+				JKS:    &cmapi.JKSKeystore{Create: false},
+				PKCS12: &cmapi.PKCS12Keystore{Create: false},
+			},
+			data:         map[string][]byte{"tls.crt": []byte("a")},
+			expViolation: false,
+		},
+		"all keystores and truststores present should return false": {
+			keystores: &cmapi.CertificateKeystores{JKS: jks, PKCS12: pkcs12},
+			data: map[string][]byte{
+				"ca.crt": []byte("a"), "keystore.jks": []byte("b"), "truststore.jks": []byte("c"),
+				"keystore.p12": []byte("d"), "truststore.p12": []byte("e"),
+			},
+			expViolation: false,
+		},
+		"truststores are not expected without a CA": {
+			keystores:    &cmapi.CertificateKeystores{JKS: jks, PKCS12: pkcs12},
+			data:         map[string][]byte{"keystore.jks": []byte("b"), "keystore.p12": []byte("d")},
+			expViolation: false,
+		},
+		"missing PKCS12 keystore should return true": {
+			keystores:    &cmapi.CertificateKeystores{PKCS12: pkcs12},
+			data:         map[string][]byte{"keystore.jks": []byte("b")},
+			expReason:    KeystoresMismatch,
+			expMessage:   `Secret is missing the PKCS12 keystore "keystore.p12"`,
+			expViolation: true,
+		},
+		"missing PKCS12 truststore with a CA should return true": {
+			keystores:    &cmapi.CertificateKeystores{PKCS12: pkcs12},
+			data:         map[string][]byte{"ca.crt": []byte("a"), "keystore.p12": []byte("d")},
+			expReason:    KeystoresMismatch,
+			expMessage:   `Secret is missing the PKCS12 truststore "truststore.p12"`,
+			expViolation: true,
+		},
+		"missing JKS keystore should return true": {
+			keystores:    &cmapi.CertificateKeystores{JKS: jks, PKCS12: pkcs12},
+			data:         map[string][]byte{"keystore.p12": []byte("d")},
+			expReason:    KeystoresMismatch,
+			expMessage:   `Secret is missing the JKS keystore "keystore.jks"`,
+			expViolation: true,
+		},
+		"missing JKS truststore with a CA should return true": {
+			keystores:    &cmapi.CertificateKeystores{JKS: jks},
+			data:         map[string][]byte{"ca.crt": []byte("a"), "keystore.jks": []byte("b")},
+			expReason:    KeystoresMismatch,
+			expMessage:   `Secret is missing the JKS truststore "truststore.jks"`,
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("test-certificate")
+			crt.Spec.Keystores = test.keystores
+			gotReason, gotMessage, gotViolation := SecretKeystoresMismatch(Input{
+				Certificate: crt,
+				Secret:      &corev1.Secret{Data: test.data},
+			})
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
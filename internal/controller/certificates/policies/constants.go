@@ -52,6 +52,14 @@ const (
 	// Certificate's AdditionalOutputFormats is not reflected on the target
 	// Secret, either by having extra, missing, or wrong values.
 	AdditionalOutputFormatsMismatch string = "AdditionalOutputFormatsMismatch"
+	// SecretFormatProfileMismatch is a policy violation whereby the
+	// Certificate's SecretFormatProfile is not reflected on the target Secret,
+	// by the profile's keys being missing or out of date.
+	SecretFormatProfileMismatch string = "SecretFormatProfileMismatch"
+	// KeystoresMismatch is a policy violation whereby the Certificate's
+	// Keystores are not reflected on the target Secret, by the keystores or
+	// truststores being missing.
+	KeystoresMismatch string = "KeystoresMismatch"
 	// ManagedFieldsParseError is a policy violation whereby cert-manager was
 	// unable to decode the managed fields on a resource.
 	ManagedFieldsParseError string = "ManagedFieldsParseError"
@@ -59,4 +67,16 @@ const (
 	// a missing owner reference to the Certificate, or has an owner reference it
 	// shouldn't have.
 	SecretOwnerRefMismatch string = "SecretOwnerRefMismatch"
+	// SecretManagedLabelsMissing is a policy violation whereby the Secret is
+	// missing the labels which cert-manager sets on the Secrets it manages.
+	SecretManagedLabelsMissing string = "SecretManagedLabelsMissing"
+	// SecretIncomplete is a policy violation whereby the Secret is missing
+	// metadata or the CA of the certificate it stores, for example after it
+	// was restored from a backup, which can be restored from the
+	// CertificateRequest that issued the certificate.
+	SecretIncomplete string = "SecretIncomplete"
+	// PrivateKeyRotationDue is a policy violation reason for a scenario where
+	// the private key of a Certificate using the Scheduled rotation policy has
+	// reached the maximum age allowed by its rotation schedule.
+	PrivateKeyRotationDue string = "PrivateKeyRotationDue"
 )
@@ -74,6 +74,7 @@ func NewTriggerPolicyChain(c clock.Clock) Chain {
 		SecretIssuerAnnotationsNotUpToDate,
 		CurrentCertificateRequestNotValidForSpec,
 		CurrentCertificateNearingExpiry(c),
+		PrivateKeyReachedMaxAge(c),
 	}
 }
 
@@ -94,10 +95,15 @@ func NewReadinessPolicyChain(c clock.Clock) Chain {
 // correctness of metadata and output formats of Certificate's Secrets.
 func NewSecretPostIssuancePolicyChain(ownerRefEnabled bool, fieldManager string) Chain {
 	return Chain{
+		SecretManagedLabelsAreMissing,
+		SecretIssuanceMetadataMissing,
 		SecretTemplateMismatchesSecret,
 		SecretTemplateMismatchesSecretManagedFields(fieldManager),
 		SecretAdditionalOutputFormatsDataMismatch,
 		SecretAdditionalOutputFormatsOwnerMismatch(fieldManager),
+		SecretFormatProfileDataMismatch,
+		SecretFormatProfileOwnerMismatch(fieldManager),
+		SecretKeystoresMismatch,
 		SecretOwnerReferenceManagedFieldMismatch(ownerRefEnabled, fieldManager),
 		SecretOwnerReferenceValueMismatch(ownerRefEnabled),
 	}
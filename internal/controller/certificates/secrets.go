@@ -18,10 +18,15 @@ package certificates
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -31,7 +36,8 @@ import (
 // Certificate Secret's Annotations when issued. These annotations contain
 // information about the Issuer and Certificate.
 // If the X.509 certificate is not-nil, additional annotations will be added
-// relating to its Common Name and Subject Alternative Names.
+// relating to its Common Name, Subject Alternative Names, serial number and
+// fingerprint.
 func AnnotationsForCertificateSecret(crt *cmapi.Certificate, certificate *x509.Certificate) map[string]string {
 	annotations := make(map[string]string)
 
@@ -46,11 +52,66 @@ func AnnotationsForCertificateSecret(crt *cmapi.Certificate, certificate *x509.C
 		annotations[cmapi.AltNamesAnnotationKey] = strings.Join(certificate.DNSNames, ",")
 		annotations[cmapi.IPSANAnnotationKey] = strings.Join(utilpki.IPAddressesToString(certificate.IPAddresses), ",")
 		annotations[cmapi.URISANAnnotationKey] = strings.Join(utilpki.URLsToString(certificate.URIs), ",")
+		if certificate.SerialNumber != nil {
+			annotations[cmapi.SerialNumberAnnotationKey] = certificate.SerialNumber.Text(16)
+		}
+		if len(certificate.Raw) > 0 {
+			fingerprint := sha256.Sum256(certificate.Raw)
+			annotations[cmapi.FingerprintSHA256AnnotationKey] = hex.EncodeToString(fingerprint[:])
+		}
 	}
 
 	return annotations
 }
 
+// AnnotationsForCertificateRequest returns the annotations which are set on a
+// Certificate Secret to record the CertificateRequest that its certificate was
+// issued from. As these cannot be derived from the Secret itself, they are
+// carried over from the existing Secret when its metadata is re-applied. The
+// revision annotation is omitted if revision is empty.
+func AnnotationsForCertificateRequest(name string, uid types.UID, revision string) map[string]string {
+	annotations := map[string]string{
+		cmapi.CertificateRequestNameAnnotationKey: name,
+		cmapi.CertificateRequestUIDAnnotationKey:  string(uid),
+	}
+	if len(revision) > 0 {
+		annotations[cmapi.CertificateRequestRevisionAnnotationKey] = revision
+	}
+	return annotations
+}
+
+// CertificateRequestAnnotationKeys are the keys of the annotations returned
+// by AnnotationsForCertificateRequest.
+var CertificateRequestAnnotationKeys = []string{
+	cmapi.CertificateRequestNameAnnotationKey,
+	cmapi.CertificateRequestUIDAnnotationKey,
+	cmapi.CertificateRequestRevisionAnnotationKey,
+}
+
+// PropagationAcknowledgements returns the number of consumers which have
+// acknowledged the revision of the certificate stored in the Secret, and
+// whether that number reaches the Certificate's `spec.propagationQuorum`.
+// The quorum is always reached if it is unset, or if the Secret does not
+// record the revision of its certificate, as there is nothing for consumers to
+// acknowledge.
+func PropagationAcknowledgements(crt *cmapi.Certificate, secret *corev1.Secret) (int, bool) {
+	if crt.Spec.PropagationQuorum == nil || secret == nil {
+		return 0, true
+	}
+	revision := secret.Annotations[cmapi.CertificateRequestRevisionAnnotationKey]
+	if len(revision) == 0 {
+		return 0, true
+	}
+
+	var acks int
+	for k, v := range secret.Annotations {
+		if strings.HasPrefix(k, cmapi.PropagationAcknowledgementAnnotationPrefix) && v == revision {
+			acks++
+		}
+	}
+	return acks, acks >= int(*crt.Spec.PropagationQuorum)
+}
+
 // OutputFormatDER returns the byte slice of the private key in DER format. To
 // be used for Certificate's Additional Output Format DER.
 func OutputFormatDER(privateKey []byte) []byte {
@@ -64,3 +125,27 @@ func OutputFormatDER(privateKey []byte) []byte {
 func OutputFormatCombinedPEM(privateKey, certificate []byte) []byte {
 	return bytes.Join([][]byte{privateKey, certificate}, []byte("\n"))
 }
+
+// SecretFormatProfileData returns the Secret Data keys and values that are
+// written for the given SecretFormatProfile, using the PEM encoded private
+// key, signed certificate chain and CA. Returns nil if the profile is empty
+// or unknown.
+func SecretFormatProfileData(profile cmapi.SecretFormatProfile, privateKey, certificate, ca []byte) map[string][]byte {
+	switch profile {
+	case cmapi.SecretFormatProfileHAProxy:
+		return map[string][]byte{
+			cmapi.SecretFormatProfileHAProxyKey: bytes.Join([][]byte{certificate, privateKey}, []byte("\n")),
+		}
+	case cmapi.SecretFormatProfileEnvoySDS:
+		data := map[string][]byte{
+			cmapi.SecretFormatProfileEnvoySDSCertKey: certificate,
+			cmapi.SecretFormatProfileEnvoySDSKeyKey:  privateKey,
+		}
+		if len(ca) > 0 {
+			data[cmapi.SecretFormatProfileEnvoySDSCAKey] = ca
+		}
+		return data
+	default:
+		return nil
+	}
+}
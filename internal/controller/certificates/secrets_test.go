@@ -19,11 +19,14 @@ package certificates
 import (
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"math/big"
 	"net"
 	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -140,6 +143,27 @@ func Test_AnnotationsForCertificateSecret(t *testing.T) {
 				"cert-manager.io/uri-sans":         "",
 			},
 		},
+		"if pass a parsed certificate, expect its serial number and fingerprint to be present": {
+			crt: gen.Certificate("test-certificate",
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer", Group: "cert-manager.io"}),
+			),
+			certificate: &x509.Certificate{
+				SerialNumber: big.NewInt(0xc0ffee),
+				Raw:          []byte("certificate"),
+			},
+			expAnnotations: map[string]string{
+				"cert-manager.io/certificate-name":   "test-certificate",
+				"cert-manager.io/issuer-name":        "test-issuer",
+				"cert-manager.io/issuer-kind":        "Issuer",
+				"cert-manager.io/issuer-group":       "cert-manager.io",
+				"cert-manager.io/common-name":        "",
+				"cert-manager.io/alt-names":          "",
+				"cert-manager.io/ip-sans":            "",
+				"cert-manager.io/uri-sans":           "",
+				"cert-manager.io/serial-number":      "c0ffee",
+				"cert-manager.io/fingerprint-sha256": "03d66dd08835c1ca3f128cceacd1f31ac94163096b20f445ae84285bc0832d72",
+			},
+		},
 		"if no certificate data, then expect no X.509 related annotations": {
 			crt: gen.Certificate("test-certificate",
 				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "", Group: "cert-manager.io"}),
@@ -161,3 +185,67 @@ func Test_AnnotationsForCertificateSecret(t *testing.T) {
 		})
 	}
 }
+
+func Test_PropagationAcknowledgements(t *testing.T) {
+	secretWithAnnotations := func(annotations map[string]string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+	}
+
+	tests := map[string]struct {
+		crt        *cmapi.Certificate
+		secret     *corev1.Secret
+		expAcks    int
+		expReached bool
+	}{
+		"if no quorum is set, it is always reached": {
+			crt: gen.Certificate("test"),
+			secret: secretWithAnnotations(map[string]string{
+				cmapi.CertificateRequestRevisionAnnotationKey: "2",
+			}),
+			expAcks:    0,
+			expReached: true,
+		},
+		"if the Secret does not exist, the quorum is reached": {
+			crt:        gen.Certificate("test", gen.SetCertificatePropagationQuorum(1)),
+			expAcks:    0,
+			expReached: true,
+		},
+		"if the Secret does not record its revision, the quorum is reached": {
+			crt: gen.Certificate("test", gen.SetCertificatePropagationQuorum(1)),
+			secret: secretWithAnnotations(map[string]string{
+				cmapi.PropagationAcknowledgementAnnotationPrefix + "gateway": "1",
+			}),
+			expAcks:    0,
+			expReached: true,
+		},
+		"acknowledgements of other revisions are not counted": {
+			crt: gen.Certificate("test", gen.SetCertificatePropagationQuorum(2)),
+			secret: secretWithAnnotations(map[string]string{
+				cmapi.CertificateRequestRevisionAnnotationKey:                "2",
+				cmapi.PropagationAcknowledgementAnnotationPrefix + "gateway": "2",
+				cmapi.PropagationAcknowledgementAnnotationPrefix + "sidecar": "1",
+				"example.com/unrelated":                                      "2",
+			}),
+			expAcks:    1,
+			expReached: false,
+		},
+		"the quorum is reached once enough consumers acknowledge the revision": {
+			crt: gen.Certificate("test", gen.SetCertificatePropagationQuorum(2)),
+			secret: secretWithAnnotations(map[string]string{
+				cmapi.CertificateRequestRevisionAnnotationKey:                "2",
+				cmapi.PropagationAcknowledgementAnnotationPrefix + "gateway": "2",
+				cmapi.PropagationAcknowledgementAnnotationPrefix + "sidecar": "2",
+			}),
+			expAcks:    2,
+			expReached: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			acks, reached := PropagationAcknowledgements(test.crt, test.secret)
+			assert.Equal(t, test.expAcks, acks)
+			assert.Equal(t, test.expReached, reached)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clocksource provides the clocks which the controller can use to
+// decide when certificates should be renewed, and whether they have expired.
+package clocksource
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/clock"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// System is the clock source which uses the clock of the node the
+	// controller is running on.
+	System = "system"
+
+	// APIServer is the clock source which follows the clock of the Kubernetes
+	// API server, for nodes whose clock can't be trusted.
+	APIServer = "apiserver"
+)
+
+// apiServerSyncPeriod is how often the offset between the local clock and
+// the clock of the API server is measured.
+const apiServerSyncPeriod = 5 * time.Minute
+
+// APIServerClock is a clock.Clock which follows the clock of the Kubernetes
+// API server, as reported by the Date header of its responses. The offset
+// from the local clock is measured periodically, so timers and the
+// durations measured by Since are still based on the local clock.
+type APIServerClock struct {
+	clock.RealClock
+
+	client *http.Client
+	url    string
+
+	// offset is the time.Duration to add to the local time.
+	offset int64
+}
+
+var _ clock.Clock = &APIServerClock{}
+
+// NewAPIServerClock returns an APIServerClock for the API server of the
+// given rest.Config. The offset from the local clock is measured
+// immediately, and then every five minutes until ctx is cancelled.
+func NewAPIServerClock(ctx context.Context, restConfig *rest.Config) (*APIServerClock, error) {
+	client, err := rest.HTTPClientFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating API server clock client: %w", err)
+	}
+	serverURL, _, err := rest.DefaultServerURL(restConfig.Host, "", schema.GroupVersion{}, rest.IsConfigTransportTLS(*restConfig))
+	if err != nil {
+		return nil, fmt.Errorf("error creating API server clock client: %w", err)
+	}
+	serverURL.Path = path.Join(serverURL.Path, "/version")
+
+	c := &APIServerClock{client: client, url: serverURL.String()}
+	if err := c.sync(ctx); err != nil {
+		return nil, fmt.Errorf("error reading the API server clock: %w", err)
+	}
+
+	log := logf.FromContext(ctx, "apiserver-clock")
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.sync(ctx); err != nil {
+			log.Error(err, "error reading the API server clock, using the last measured offset", "offset", c.Offset())
+			return
+		}
+		log.V(logf.DebugLevel).Info("measured API server clock offset", "offset", c.Offset())
+	}, apiServerSyncPeriod)
+
+	return c, nil
+}
+
+// Now returns the current time of the API server.
+func (c *APIServerClock) Now() time.Time {
+	return c.RealClock.Now().Add(c.Offset())
+}
+
+// Since returns the time elapsed since t, according to the API server.
+func (c *APIServerClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Offset returns the last measured offset of the API server clock from the
+// local clock.
+func (c *APIServerClock) Offset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.offset))
+}
+
+func (c *APIServerClock) sync(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return err
+	}
+
+	sent := c.RealClock.Now()
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	received := c.RealClock.Now()
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return fmt.Errorf("invalid Date header in API server response: %w", err)
+	}
+
+	// The Date header has a resolution of one second, so assume that the
+	// response was sent half way through that second, and half way through
+	// the round trip.
+	serverTime := date.Add(500 * time.Millisecond)
+	localTime := sent.Add(received.Sub(sent) / 2)
+	atomic.StoreInt64(&c.offset, int64(serverTime.Sub(localTime)))
+
+	return nil
+}
+
+// skewTolerantClock is a clock.Clock which is ahead of another clock by the
+// clock skew tolerance.
+type skewTolerantClock struct {
+	clock.Clock
+
+	tolerance time.Duration
+}
+
+// WithSkewTolerance returns a clock.Clock which is ahead of the given clock
+// by tolerance. Using it to decide whether certificates should be renewed or
+// have expired makes those decisions tolerance earlier, as if the clock
+// could be behind by up to tolerance.
+func WithSkewTolerance(c clock.Clock, tolerance time.Duration) clock.Clock {
+	if tolerance == 0 {
+		return c
+	}
+	return &skewTolerantClock{Clock: c, tolerance: tolerance}
+}
+
+func (c *skewTolerantClock) Now() time.Time {
+	return c.Clock.Now().Add(c.tolerance)
+}
+
+func (c *skewTolerantClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
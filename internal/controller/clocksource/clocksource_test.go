@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clocksource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestAPIServerClock(t *testing.T) {
+	// The API server clock is an hour ahead of the local clock.
+	const offset = time.Hour
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/version", r.URL.Path)
+		w.Header().Set("Date", time.Now().Add(offset).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, err := NewAPIServerClock(ctx, &rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	// The Date header has a resolution of one second.
+	assert.InDelta(t, offset, c.Offset(), float64(time.Second))
+	assert.WithinDuration(t, time.Now().Add(offset), c.Now(), time.Second)
+	assert.InDelta(t, time.Minute, c.Since(c.Now().Add(-time.Minute)), float64(time.Second))
+}
+
+func TestAPIServerClockInvalidDate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", "yesterday")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := NewAPIServerClock(context.Background(), &rest.Config{Host: server.URL})
+	assert.Error(t, err)
+}
+
+func TestWithSkewTolerance(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	fakeClock := fakeclock.NewFakeClock(now)
+
+	assert.Equal(t, fakeClock, WithSkewTolerance(fakeClock, 0))
+
+	c := WithSkewTolerance(fakeClock, time.Minute)
+	assert.Equal(t, now.Add(time.Minute), c.Now())
+	assert.Equal(t, 2*time.Minute, c.Since(now.Add(-time.Minute)))
+}
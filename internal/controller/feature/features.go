@@ -70,6 +70,42 @@ const (
 	// This feature will add BasicConstraints section with CA field defaulting to false; CA field will be set true if the Certificate resource spec has isCA as true
 	// Github Issue: https://github.com/cert-manager/cert-manager/issues/5539
 	UseCertificateRequestBasicConstraints featuregate.Feature = "UseCertificateRequestBasicConstraints"
+
+	// Alpha: v1.11
+	// MetadataOnlySecretInformers will cache only the metadata of Secrets for the controllers which do not read the data
+	// of Secrets, such as those which only requeue the issuers and requests referencing a Secret. Controllers and issuers
+	// which read the data of Secrets get them from the API server when needed, so no Secret is fully cached.
+	MetadataOnlySecretInformers featuregate.Feature = "MetadataOnlySecretInformers"
+
+	// Alpha: v1.11
+	// SecretsFilteredCaching will fully cache only the Secrets labelled with controller.cert-manager.io/fao, which
+	// cert-manager sets on the Secrets it manages. Only the metadata of other Secrets is cached, and controllers and
+	// issuers which read the data of an unlabelled Secret, such as a CA key pair or credentials, get it from the API
+	// server when needed.
+	// Takes precedence over MetadataOnlySecretInformers.
+	SecretsFilteredCaching featuregate.Feature = "SecretsFilteredCaching"
+
+	// Alpha: v1.11
+	// ExternalSecretStores will push the private key and certificate of Certificates which set `spec.secretStores`
+	// to the configured external stores, such as Vault or AWS Secrets Manager, after they are written to the Secret.
+	// This feature gate must be used together with the ExternalSecretStores webhook feature gate.
+	ExternalSecretStores featuregate.Feature = "ExternalSecretStores"
+
+	// Alpha: v1.11
+	// ExperimentalCompositeKeys allows Certificates to use the `MLDSA65-ECDSA-P256` private key algorithm, which
+	// generates composite ML-DSA-65 and ECDSA P-256 keys, and allows the SelfSigned and CA issuers to sign
+	// certificates with composite keys. Composite keys are only supported when cert-manager is built with Go 1.27
+	// or later, and the encoding follows a draft IETF specification which may still change. They are intended for
+	// interoperability testing only.
+	// This feature gate must be used together with the ExperimentalCompositeKeys webhook feature gate.
+	ExperimentalCompositeKeys featuregate.Feature = "ExperimentalCompositeKeys"
+
+	// Alpha: v1.11
+	// AdditionalPrivateKeys will issue a certificate for each private key algorithm in `spec.additionalPrivateKeys`
+	// of a Certificate alongside its main certificate, and write them to the `tls-<algorithm>.crt` and
+	// `tls-<algorithm>.key` keys of its Secret.
+	// This feature gate must be used together with the AdditionalPrivateKeys webhook feature gate.
+	AdditionalPrivateKeys featuregate.Feature = "AdditionalPrivateKeys"
 )
 
 func init() {
@@ -88,4 +124,9 @@ var defaultCertManagerFeatureGates = map[featuregate.Feature]featuregate.Feature
 	LiteralCertificateSubject:                        {Default: false, PreRelease: featuregate.Alpha},
 	StableCertificateRequestName:                     {Default: false, PreRelease: featuregate.Alpha},
 	UseCertificateRequestBasicConstraints:            {Default: false, PreRelease: featuregate.Alpha},
+	MetadataOnlySecretInformers:                      {Default: false, PreRelease: featuregate.Alpha},
+	SecretsFilteredCaching:                           {Default: false, PreRelease: featuregate.Alpha},
+	ExternalSecretStores:                             {Default: false, PreRelease: featuregate.Alpha},
+	ExperimentalCompositeKeys:                        {Default: false, PreRelease: featuregate.Alpha},
+	AdditionalPrivateKeys:                            {Default: false, PreRelease: featuregate.Alpha},
 }
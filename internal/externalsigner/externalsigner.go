@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package externalsigner builds clients for the external signers of external
+// signer issuers, to which CertificateRequests are sent to be signed.
+package externalsigner
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	corev1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	issuerplugin "github.com/cert-manager/cert-manager/pkg/issuer/plugin"
+)
+
+// Client calls the Signer service of an external signer.
+type Client interface {
+	issuerplugin.Signer
+	Close() error
+}
+
+// ClientBuilder returns a client for the external signer of the given
+// external signer issuer, reading its TLS credentials from a Secret in
+// namespace.
+type ClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
+	issuer v1.GenericIssuer) (Client, error)
+
+// New returns a client for the external signer of the given external signer
+// issuer, authenticated with the client certificate in its tlsSecretRef. The
+// Secret is read from namespace: for Issuers, the namespace of the Issuer and
+// for ClusterIssuers, the cluster resource namespace.
+func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Client, error) {
+	cfg, err := TLSConfig(namespace, secretsLister, issuer)
+	if err != nil {
+		return nil, err
+	}
+	client, err := issuerplugin.Dial(issuer.GetSpec().ExternalSigner.Address,
+		grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// TLSConfig returns the mutual TLS config of a connection to the external
+// signer of the given external signer issuer.
+func TLSConfig(namespace string, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (*tls.Config, error) {
+	spec := issuer.GetSpec().ExternalSigner
+	if spec == nil {
+		return nil, fmt.Errorf("issuer %s/%s is not an external signer issuer", issuer.GetObjectMeta().Namespace, issuer.GetObjectMeta().Name)
+	}
+
+	secret, err := secretsLister.Secrets(namespace).Get(spec.TLSSecretRef.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPair, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the client certificate from secret '%s/%s': %w", namespace, spec.TLSSecretRef.Name, err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(secret.Data[cmmeta.TLSCAKey]) {
+		return nil, fmt.Errorf("no valid CA certificate for %q in secret '%s/%s'", cmmeta.TLSCAKey, namespace, spec.TLSSecretRef.Name)
+	}
+
+	serverName := spec.ServerName
+	if serverName == "" {
+		serverName, _, err = net.SplitHostPort(spec.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", spec.Address, err)
+		}
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+		RootCAs:      roots,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsigner
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	issuerplugin "github.com/cert-manager/cert-manager/pkg/issuer/plugin"
+	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+// keyPair is a certificate and its private key.
+type keyPair struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func (k keyPair) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: k.cert.Raw})
+}
+
+func (k keyPair) keyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(k.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// newKeyPair returns a key pair signed by parent, or a self signed CA if
+// parent is nil.
+func newKeyPair(t *testing.T, parent *keyPair, dnsName string) keyPair {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{dnsName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerCert := crypto.Signer(key), template
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		signer, signerCert = parent.key, parent.cert
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, key.Public(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyPair{cert: cert, key: key}
+}
+
+type signerFunc func(context.Context, *issuerplugin.SignRequest) (*issuerplugin.SignResponse, error)
+
+func (f signerFunc) Sign(ctx context.Context, req *issuerplugin.SignRequest) (*issuerplugin.SignResponse, error) {
+	return f(ctx, req)
+}
+
+func TestNew(t *testing.T) {
+	ca := newKeyPair(t, nil, "ca")
+	server := newKeyPair(t, &ca, "signer.example.com")
+	client := newKeyPair(t, &ca, "cert-manager")
+	otherCA := newKeyPair(t, nil, "other-ca")
+	untrustedClient := newKeyPair(t, &otherCA, "cert-manager")
+
+	// The external signer requires client certificates signed by ca.
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{server.cert.Raw}, PrivateKey: server.key}},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	issuerplugin.Register(srv, signerFunc(func(_ context.Context, req *issuerplugin.SignRequest) (*issuerplugin.SignResponse, error) {
+		return &issuerplugin.SignResponse{Certificate: []byte("signed " + req.Name)}, nil
+	}))
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Serve(ln)
+	defer srv.Stop()
+
+	issuer := &v1.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "hsm"},
+		Spec: v1.IssuerSpec{IssuerConfig: v1.IssuerConfig{ExternalSigner: &v1.ExternalSignerIssuer{
+			Address:      ln.Addr().String(),
+			ServerName:   "signer.example.com",
+			TLSSecretRef: cmmeta.LocalObjectReference{Name: "signer-client-tls"},
+		}}},
+	}
+
+	tests := map[string]struct {
+		secretData map[string][]byte
+		expDialErr bool
+		expSignErr bool
+	}{
+		"a trusted client certificate is accepted by the signer": {
+			secretData: map[string][]byte{
+				corev1.TLSCertKey:       client.certPEM(),
+				corev1.TLSPrivateKeyKey: client.keyPEM(t),
+				cmmeta.TLSCAKey:         ca.certPEM(),
+			},
+		},
+		"an untrusted client certificate is rejected by the signer": {
+			secretData: map[string][]byte{
+				corev1.TLSCertKey:       untrustedClient.certPEM(),
+				corev1.TLSPrivateKeyKey: untrustedClient.keyPEM(t),
+				cmmeta.TLSCAKey:         ca.certPEM(),
+			},
+			expSignErr: true,
+		},
+		"a signer certificate which is not signed by the CA is rejected": {
+			secretData: map[string][]byte{
+				corev1.TLSCertKey:       client.certPEM(),
+				corev1.TLSPrivateKeyKey: client.keyPEM(t),
+				cmmeta.TLSCAKey:         otherCA.certPEM(),
+			},
+			expSignErr: true,
+		},
+		"a missing CA fails": {
+			secretData: map[string][]byte{
+				corev1.TLSCertKey:       client.certPEM(),
+				corev1.TLSPrivateKeyKey: client.keyPEM(t),
+			},
+			expDialErr: true,
+		},
+		"a mismatched private key fails": {
+			secretData: map[string][]byte{
+				corev1.TLSCertKey:       client.certPEM(),
+				corev1.TLSPrivateKeyKey: untrustedClient.keyPEM(t),
+				cmmeta.TLSCAKey:         ca.certPEM(),
+			},
+			expDialErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secretsLister := testlisters.FakeSecretListerFrom(testlisters.NewFakeSecretLister(),
+				testlisters.SetFakeSecretNamespaceListerGet(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "signer-client-tls"},
+					Data:       test.secretData,
+				}, nil),
+			)
+
+			c, err := New("default", secretsLister, issuer)
+			if test.expDialErr != (err != nil) {
+				t.Fatalf("expected dial error: %t, got: %v", test.expDialErr, err)
+			}
+			if err != nil {
+				return
+			}
+			defer c.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			resp, err := c.Sign(ctx, &issuerplugin.SignRequest{Name: "test"})
+			if test.expSignErr != (err != nil) {
+				t.Fatalf("expected sign error: %t, got: %v", test.expSignErr, err)
+			}
+			if err == nil && string(resp.Certificate) != "signed test" {
+				t.Errorf("unexpected certificate: %q", resp.Certificate)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fips reports whether cert-manager was built with a FIPS 140-3
+// validated cryptographic module.
+//
+// Binaries built with GOEXPERIMENT=boringcrypto use the BoringCrypto module
+// for all supported cryptographic operations, and importing this package
+// restricts their TLS connections to FIPS approved versions, cipher suites,
+// curves and signature algorithms.
+package fips
+
+// BackendEnabled reports whether cryptographic operations are handled by a
+// FIPS 140-3 validated module.
+func BackendEnabled() bool {
+	return backendEnabled()
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hub builds clients for the hub clusters of hub issuers, to which
+// CertificateRequests are forwarded to be signed.
+package hub
+
+import (
+	"fmt"
+	"strings"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/util"
+)
+
+// ClientBuilder returns a cert-manager client for the hub cluster of the given
+// hub issuer, reading its token from a Secret in namespace.
+type ClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
+	issuer v1.GenericIssuer) (cmclient.Interface, error)
+
+// New returns a cert-manager client for the hub cluster of the given hub
+// issuer, authenticated with the token in its tokenSecretRef. The Secret is
+// read from namespace: for Issuers, the namespace of the Issuer and for
+// ClusterIssuers, the cluster resource namespace.
+func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (cmclient.Interface, error) {
+	cfg, err := RESTConfig(namespace, secretsLister, issuer)
+	if err != nil {
+		return nil, err
+	}
+	return cmclient.NewForConfig(cfg)
+}
+
+// RESTConfig returns the config of a client for the hub cluster of the given
+// hub issuer, authenticated with the token in its tokenSecretRef.
+func RESTConfig(namespace string, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (*rest.Config, error) {
+	spec := issuer.GetSpec().Hub
+	if spec == nil {
+		return nil, fmt.Errorf("issuer %s/%s is not a hub issuer", issuer.GetObjectMeta().Namespace, issuer.GetObjectMeta().Name)
+	}
+
+	ref := spec.TokenSecretRef
+	secret, err := secretsLister.Secrets(namespace).Get(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	token, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("no data for %q in secret '%s/%s'", ref.Key, namespace, ref.Name)
+	}
+
+	return util.RestConfigWithUserAgent(&rest.Config{
+		Host:            spec.Server,
+		BearerToken:     strings.TrimSpace(string(token)),
+		TLSClientConfig: rest.TLSClientConfig{CAData: spec.CABundle},
+	}, "hub"), nil
+}
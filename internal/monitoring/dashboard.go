@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+// Panel is a panel of the Grafana dashboard.
+type Panel struct {
+	Title string
+	// Type is the Grafana panel type, either "stat" or "timeseries".
+	Type string
+	Expr string
+	// LegendFormat names the series of the panel using their labels.
+	LegendFormat string
+	Unit         string
+}
+
+// DashboardPanels are the panels of the Grafana dashboard generated into the
+// chart, in the order in which they are laid out.
+var DashboardPanels = []Panel{
+	{
+		Title: "Ready certificates",
+		Type:  "stat",
+		Expr:  `sum(certmanager_certificate_ready_status{condition="True"}) or vector(0)`,
+	},
+	{
+		Title: "Certificates not ready",
+		Type:  "stat",
+		Expr:  `sum(certmanager_certificate_ready_status{condition!="True"}) or vector(0)`,
+	},
+	{
+		Title: "Certificates expiring within 21 days",
+		Type:  "stat",
+		Expr:  "count((certmanager_certificate_expiration_timestamp_seconds > 0) - time() < 21 * 24 * 3600) or vector(0)",
+	},
+	{
+		Title: "Failed issuances in the last hour",
+		Type:  "stat",
+		Expr:  `sum(increase(certmanager_certificate_issuance_count{result="failure"}[1h])) or vector(0)`,
+	},
+	{
+		Title:        "Time until expiry of the 10 certificates expiring first",
+		Type:         "timeseries",
+		Expr:         "bottomk(10, (certmanager_certificate_expiration_timestamp_seconds > 0) - time())",
+		LegendFormat: "{{namespace}}/{{name}}",
+		Unit:         "s",
+	},
+	{
+		Title:        "Certificates scheduled for renewal",
+		Type:         "timeseries",
+		Expr:         "sum by (window) (certmanager_certificate_renewals_scheduled)",
+		LegendFormat: "within {{window}}",
+	},
+	{
+		Title:        "Issuances by result",
+		Type:         "timeseries",
+		Expr:         "sum by (result) (rate(certmanager_certificate_issuance_count[$__rate_interval]))",
+		LegendFormat: "{{result}}",
+		Unit:         "ops",
+	},
+	{
+		Title:        "Issuance duration (p95)",
+		Type:         "timeseries",
+		Expr:         "histogram_quantile(0.95, sum by (le, issuer_kind, issuer_name) (rate(certmanager_certificate_issuance_duration_seconds_bucket[$__rate_interval])))",
+		LegendFormat: "{{issuer_kind}} {{issuer_name}}",
+		Unit:         "s",
+	},
+	{
+		Title:        "ACME requests by status",
+		Type:         "timeseries",
+		Expr:         "sum by (host, status) (rate(certmanager_http_acme_client_request_count[$__rate_interval]))",
+		LegendFormat: "{{host}} {{status}}",
+		Unit:         "reqps",
+	},
+	{
+		Title:        "ACME challenge propagation wait (p95)",
+		Type:         "timeseries",
+		Expr:         "histogram_quantile(0.95, sum by (le, type, provider) (rate(certmanager_acme_challenge_propagation_wait_seconds_bucket[$__rate_interval])))",
+		LegendFormat: "{{type}} {{provider}}",
+		Unit:         "s",
+	},
+	{
+		Title:        "Controller sync errors",
+		Type:         "timeseries",
+		Expr:         "sum by (controller) (rate(certmanager_controller_sync_error_count[$__rate_interval]))",
+		LegendFormat: "{{controller}}",
+		Unit:         "ops",
+	},
+	{
+		Title:        "Workqueue depth",
+		Type:         "timeseries",
+		Expr:         "sum by (controller) (certmanager_workqueue_depth)",
+		LegendFormat: "{{controller}}",
+	},
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const generatedHeader = "# Code generated by hack/genmonitoring. DO NOT EDIT.\n"
+
+// metadata is the metadata of the generated resources, in which
+// %[1]s is the name of the resource's values in the chart.
+const metadata = `metadata:
+  name: {{ template "cert-manager.fullname" . }}
+  namespace: {{ .Values.prometheus.%[1]s.namespace | default (include "cert-manager.namespace" .) }}
+  labels:
+    app: {{ include "cert-manager.name" . }}
+    app.kubernetes.io/name: {{ include "cert-manager.name" . }}
+    app.kubernetes.io/instance: {{ .Release.Name }}
+    app.kubernetes.io/component: "controller"
+    {{- include "labels" . | nindent 4 }}
+    {{- with .Values.prometheus.%[1]s.labels }}
+    {{- toYaml . | nindent 4 }}
+    {{- end }}
+  {{- with .Values.prometheus.%[1]s.annotations }}
+  annotations:
+    {{- toYaml . | nindent 4 }}
+  {{- end }}
+`
+
+// escaper escapes the Prometheus and Grafana templates in the generated
+// resources, which use the same delimiters as Helm.
+var escaper = strings.NewReplacer("{{", "{{`{{", "}}", "}}`}}")
+
+// PrometheusRuleManifest writes a Helm chart template which installs a
+// PrometheusRule holding the AlertingRules.
+func PrometheusRuleManifest(w io.Writer) error {
+	spec := map[string]interface{}{
+		"groups": []interface{}{
+			map[string]interface{}{
+				"name":  "cert-manager",
+				"rules": AlertingRules,
+			},
+		},
+	}
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal PrometheusRule: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(generatedHeader)
+	buf.WriteString("{{- if and .Values.prometheus.enabled .Values.prometheus.prometheusrule.enabled }}\n")
+	buf.WriteString("apiVersion: monitoring.coreos.com/v1\nkind: PrometheusRule\n")
+	fmt.Fprintf(buf, metadata, "prometheusrule")
+	buf.WriteString("spec:\n")
+	buf.WriteString(indent(escaper.Replace(string(data)), "  "))
+	buf.WriteString("{{- end }}\n")
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// DashboardManifest writes a Helm chart template which installs a ConfigMap
+// holding a Grafana dashboard of the DashboardPanels.
+func DashboardManifest(w io.Writer) error {
+	data, err := json.MarshalIndent(dashboard(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal dashboard: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(generatedHeader)
+	buf.WriteString("{{- if and .Values.prometheus.enabled .Values.prometheus.grafanadashboard.enabled }}\n")
+	buf.WriteString("apiVersion: v1\nkind: ConfigMap\n")
+	fmt.Fprintf(buf, metadata, "grafanadashboard")
+	buf.WriteString("data:\n  cert-manager.json: |-\n")
+	buf.WriteString(indent(escaper.Replace(string(data))+"\n", "    "))
+	buf.WriteString("{{- end }}\n")
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func indent(s, prefix string) string {
+	lines := strings.SplitAfter(s, "\n")
+	for i, line := range lines {
+		if line != "" && line != "\n" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// The fields of the Grafana dashboard model which are generated.
+
+type grafanaDashboard struct {
+	Title         string            `json:"title"`
+	UID           string            `json:"uid"`
+	Tags          []string          `json:"tags"`
+	SchemaVersion int               `json:"schemaVersion"`
+	Refresh       string            `json:"refresh"`
+	Time          grafanaTimeRange  `json:"time"`
+	Templating    grafanaTemplating `json:"templating"`
+	Panels        []grafanaPanel    `json:"panels"`
+}
+
+type grafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type grafanaTemplating struct {
+	List []grafanaVariable `json:"list"`
+}
+
+type grafanaVariable struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Query string `json:"query"`
+}
+
+type grafanaPanel struct {
+	ID          int                `json:"id"`
+	Title       string             `json:"title"`
+	Type        string             `json:"type"`
+	GridPos     grafanaGridPos     `json:"gridPos"`
+	Datasource  grafanaDatasource  `json:"datasource"`
+	Targets     []grafanaTarget    `json:"targets"`
+	FieldConfig grafanaFieldConfig `json:"fieldConfig"`
+}
+
+type grafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type grafanaDatasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type grafanaTarget struct {
+	RefID        string            `json:"refId"`
+	Datasource   grafanaDatasource `json:"datasource"`
+	Expr         string            `json:"expr"`
+	LegendFormat string            `json:"legendFormat,omitempty"`
+}
+
+type grafanaFieldConfig struct {
+	Defaults grafanaFieldDefaults `json:"defaults"`
+}
+
+type grafanaFieldDefaults struct {
+	Unit string `json:"unit,omitempty"`
+}
+
+// dashboard lays out the DashboardPanels with stat panels in rows of four
+// and time series panels in rows of two.
+func dashboard() grafanaDashboard {
+	datasource := grafanaDatasource{Type: "prometheus", UID: "${datasource}"}
+	d := grafanaDashboard{
+		Title:         "cert-manager",
+		UID:           "cert-manager",
+		Tags:          []string{"cert-manager"},
+		SchemaVersion: 36,
+		Refresh:       "1m",
+		Time:          grafanaTimeRange{From: "now-6h", To: "now"},
+		Templating: grafanaTemplating{List: []grafanaVariable{
+			{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"},
+		}},
+	}
+
+	x, y, rowHeight := 0, 0, 0
+	for i, p := range DashboardPanels {
+		w, h := 12, 8
+		if p.Type == "stat" {
+			w, h = 6, 4
+		}
+		if x+w > 24 {
+			x, y = 0, y+rowHeight
+		}
+		d.Panels = append(d.Panels, grafanaPanel{
+			ID:         i + 1,
+			Title:      p.Title,
+			Type:       p.Type,
+			GridPos:    grafanaGridPos{H: h, W: w, X: x, Y: y},
+			Datasource: datasource,
+			Targets: []grafanaTarget{
+				{RefID: "A", Datasource: datasource, Expr: p.Expr, LegendFormat: p.LegendFormat},
+			},
+			FieldConfig: grafanaFieldConfig{Defaults: grafanaFieldDefaults{Unit: p.Unit}},
+		})
+		x, rowHeight = x+w, h
+	}
+	return d
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+func TestManifestsUpToDate(t *testing.T) {
+	const dir = "../../deploy/charts/cert-manager/templates/"
+
+	for name, write := range map[string]func(io.Writer) error{
+		"prometheusrule.yaml":    PrometheusRuleManifest,
+		"grafana-dashboard.yaml": DashboardManifest,
+	} {
+		existing, err := os.ReadFile(dir + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := &bytes.Buffer{}
+		if err := write(buf); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(existing, buf.Bytes()) {
+			t.Errorf("%s is out of date, run 'make update-monitoring'", dir+name)
+		}
+	}
+}
+
+var metricNameRegexp = regexp.MustCompile(`certmanager_[a-z0-9_]+`)
+
+// TestMetricNames checks that the rules and dashboard only query metrics
+// which are exposed by cert-manager, so that renaming or removing a metric
+// requires updating them.
+func TestMetricNames(t *testing.T) {
+	exposed := make(map[string]bool)
+	for _, name := range metrics.New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now())).Names() {
+		exposed[name] = true
+	}
+	isExposed := func(name string) bool {
+		if exposed[name] {
+			return true
+		}
+		// The series of histograms and summaries have suffixed names.
+		for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+			if strings.HasSuffix(name, suffix) && exposed[strings.TrimSuffix(name, suffix)] {
+				return true
+			}
+		}
+		return false
+	}
+
+	exprs := make(map[string]string)
+	for _, rule := range AlertingRules {
+		exprs["alert "+rule.Alert] = rule.Expr
+	}
+	for _, panel := range DashboardPanels {
+		exprs["panel "+panel.Title] = panel.Expr
+	}
+	for name, expr := range exprs {
+		names := metricNameRegexp.FindAllString(expr, -1)
+		if len(names) == 0 {
+			t.Errorf("%s does not query any cert-manager metric", name)
+		}
+		for _, metric := range names {
+			if !isExposed(metric) {
+				t.Errorf("%s queries %s, which is not exposed by cert-manager", name, metric)
+			}
+		}
+	}
+}
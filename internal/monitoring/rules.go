@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package monitoring defines the Prometheus alerting rules and Grafana
+// dashboard shipped in the Helm chart for cert-manager's metrics. They are
+// generated into the chart by hack/genmonitoring, and checked against the
+// metrics exposed by pkg/metrics so that they are changed in step with them.
+package monitoring
+
+// AlertingRule is a Prometheus alerting rule.
+type AlertingRule struct {
+	Alert       string            `json:"alert"`
+	Expr        string            `json:"expr"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// AlertingRules are the rules generated into the chart's PrometheusRule.
+var AlertingRules = []AlertingRule{
+	{
+		Alert: "CertManagerAbsent",
+		Expr:  "absent(certmanager_clock_time_seconds_gauge)",
+		For:   "10m",
+		Labels: map[string]string{
+			"severity": "critical",
+		},
+		Annotations: map[string]string{
+			"summary":     "cert-manager has disappeared from Prometheus service discovery.",
+			"description": "No metrics have been scraped from the cert-manager controller for 10 minutes. Certificates will not be renewed while it is down.",
+		},
+	},
+	{
+		Alert: "CertManagerCertificateExpiringSoon",
+		Expr:  "(certmanager_certificate_expiration_timestamp_seconds > 0) - time() < 21 * 24 * 3600",
+		For:   "1h",
+		Labels: map[string]string{
+			"severity": "warning",
+		},
+		Annotations: map[string]string{
+			"summary":     "The certificate {{ $labels.namespace }}/{{ $labels.name }} expires in less than 21 days.",
+			"description": "The certificate {{ $labels.namespace }}/{{ $labels.name }} issued by {{ $labels.issuer_kind }} {{ $labels.issuer_name }} has not been renewed and expires in {{ $value | humanizeDuration }}.",
+		},
+	},
+	{
+		Alert: "CertManagerUnmanagedSecretExpiringSoon",
+		Expr:  `certmanager_expiring_soon_threshold_seconds{kind="Secret"}`,
+		Labels: map[string]string{
+			"severity": "warning",
+		},
+		Annotations: map[string]string{
+			"summary":     "The TLS Secret {{ $labels.namespace }}/{{ $labels.name }} expires soon.",
+			"description": "The certificate of the TLS Secret {{ $labels.namespace }}/{{ $labels.name }}, which is not managed by cert-manager, has crossed an expiry alert threshold and must be renewed by hand.",
+		},
+	},
+	{
+		Alert: "CertManagerCertificateNotReady",
+		Expr:  `max by (name, namespace, issuer_name, issuer_kind, issuer_group) (certmanager_certificate_ready_status{condition!="True"} == 1)`,
+		For:   "15m",
+		Labels: map[string]string{
+			"severity": "critical",
+		},
+		Annotations: map[string]string{
+			"summary":     "The certificate {{ $labels.namespace }}/{{ $labels.name }} is not ready.",
+			"description": "The certificate {{ $labels.namespace }}/{{ $labels.name }} has not been Ready for 15 minutes. Check its events and the status of its CertificateRequests.",
+		},
+	},
+	{
+		Alert: "CertManagerIssuerFailing",
+		Expr: `sum by (issuer_name, issuer_kind, issuer_group) (increase(certmanager_certificate_issuance_count{result="failure"}[1h])) > 0` +
+			` unless sum by (issuer_name, issuer_kind, issuer_group) (increase(certmanager_certificate_issuance_count{result="success"}[1h])) > 0`,
+		Labels: map[string]string{
+			"severity": "warning",
+		},
+		Annotations: map[string]string{
+			"summary":     "The {{ $labels.issuer_kind }} {{ $labels.issuer_name }} has failed to issue any certificates in the last hour.",
+			"description": "Every issuance by the {{ $labels.issuer_kind }} {{ $labels.issuer_name }} in the last hour has failed. Check the Ready condition of the issuer and the events of its CertificateRequests.",
+		},
+	},
+	{
+		Alert: "CertManagerACMERateLimited",
+		Expr:  `sum by (host) (rate(certmanager_http_acme_client_request_count{status="429"}[5m])) > 0`,
+		For:   "5m",
+		Labels: map[string]string{
+			"severity": "critical",
+		},
+		Annotations: map[string]string{
+			"summary":     "cert-manager is being rate limited by the ACME server {{ $labels.host }}.",
+			"description": "Requests to the ACME server {{ $labels.host }} have been rejected with 429 Too Many Requests for 5 minutes. Certificates issued by the server may not be renewed until the rate limit is lifted.",
+		},
+	},
+}
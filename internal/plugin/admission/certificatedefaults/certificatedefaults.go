@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificatedefaults
+
+// CertificateDefaults is a plugin that sets fields which are not specified on
+// newly created Certificates to the cluster-wide defaults configured in the
+// CertificateDefaults resource named `default`.
+// If there is no such resource, Certificates are not modified.
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
+)
+
+const PluginName = "CertificateDefaults"
+
+type certificateDefaults struct {
+	*admission.Handler
+
+	cmClient cmclient.Interface
+}
+
+var _ admission.MutationInterface = &certificateDefaults{}
+var _ initializer.WantsCertManagerClientSet = &certificateDefaults{}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+func NewPlugin() admission.Interface {
+	return &certificateDefaults{
+		Handler: admission.NewHandler(admissionv1.Create),
+	}
+}
+
+func (p *certificateDefaults) Mutate(ctx context.Context, request admissionv1.AdmissionRequest, obj runtime.Object) error {
+	// Only run this admission plugin when Certificate resources are created
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		request.Operation != admissionv1.Create {
+		return nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+
+	defaults, err := p.cmClient.CertmanagerV1().CertificateDefaults().Get(ctx, cmapi.CertificateDefaultsName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get CertificateDefaults %q: %w", cmapi.CertificateDefaultsName, err)
+	}
+
+	applyDefaults(&crt.Spec, &defaults.Spec)
+	return nil
+}
+
+// applyDefaults sets each field of spec which is not specified to its value in
+// defaults.
+func applyDefaults(spec *certmanager.CertificateSpec, defaults *cmapi.CertificateDefaultsSpec) {
+	if spec.RevisionHistoryLimit == nil && defaults.RevisionHistoryLimit != nil {
+		limit := *defaults.RevisionHistoryLimit
+		spec.RevisionHistoryLimit = &limit
+	}
+
+	if defaults.PrivateKey == nil {
+		return
+	}
+	pk := spec.PrivateKey
+	if pk == nil {
+		pk = &certmanager.CertificatePrivateKey{}
+	}
+
+	// The default size only applies to keys of the default algorithm, as
+	// sizes are not valid across algorithms.
+	if pk.Size == 0 && defaults.PrivateKey.Size != 0 &&
+		(pk.Algorithm == "" || keyAlgorithm(pk.Algorithm) == keyAlgorithm(certmanager.PrivateKeyAlgorithm(defaults.PrivateKey.Algorithm))) {
+		pk.Size = defaults.PrivateKey.Size
+	}
+	if pk.Algorithm == "" {
+		pk.Algorithm = certmanager.PrivateKeyAlgorithm(defaults.PrivateKey.Algorithm)
+	}
+	if pk.Encoding == "" {
+		pk.Encoding = certmanager.PrivateKeyEncoding(defaults.PrivateKey.Encoding)
+	}
+	if pk.RotationPolicy == "" {
+		pk.RotationPolicy = certmanager.PrivateKeyRotationPolicy(defaults.PrivateKey.RotationPolicy)
+	}
+	// The default schedule only applies to Certificates using the Scheduled
+	// rotation policy, as it is not valid with any other policy.
+	if pk.RotationSchedule == nil && defaults.PrivateKey.RotationSchedule != nil &&
+		pk.RotationPolicy == certmanager.RotationPolicyScheduled {
+		schedule := defaults.PrivateKey.RotationSchedule.DeepCopy()
+		pk.RotationSchedule = &certmanager.PrivateKeyRotationSchedule{
+			Renewals: schedule.Renewals,
+			MaxAge:   schedule.MaxAge,
+		}
+	}
+
+	if spec.PrivateKey != nil || *pk != (certmanager.CertificatePrivateKey{}) {
+		spec.PrivateKey = pk
+	}
+}
+
+// keyAlgorithm returns the algorithm used for keys of the given algorithm,
+// which is RSA if none is specified.
+func keyAlgorithm(alg certmanager.PrivateKeyAlgorithm) certmanager.PrivateKeyAlgorithm {
+	if alg == "" {
+		return certmanager.RSAKeyAlgorithm
+	}
+	return alg
+}
+
+func (p *certificateDefaults) SetCertManagerClientSet(client cmclient.Interface) {
+	p.cmClient = client
+}
+
+func (p *certificateDefaults) ValidateInitialization() error {
+	if p.cmClient == nil {
+		return fmt.Errorf("cert-manager client not set")
+	}
+	return nil
+}
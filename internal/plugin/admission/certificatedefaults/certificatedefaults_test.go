@@ -0,0 +1,217 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificatedefaults
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+)
+
+var certificateResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+func TestMutate(t *testing.T) {
+	defaults := func(spec cmapi.CertificateDefaultsSpec) *cmapi.CertificateDefaults {
+		return &cmapi.CertificateDefaults{
+			ObjectMeta: metav1.ObjectMeta{Name: cmapi.CertificateDefaultsName},
+			Spec:       spec,
+		}
+	}
+	ecdsa384 := cmapi.CertificateDefaultsSpec{
+		PrivateKey: &cmapi.CertificatePrivateKey{
+			Algorithm:      cmapi.ECDSAKeyAlgorithm,
+			Size:           384,
+			RotationPolicy: cmapi.RotationPolicyAlways,
+		},
+		RevisionHistoryLimit: pointer.Int32(3),
+	}
+
+	tests := map[string]struct {
+		defaults *cmapi.CertificateDefaults
+		resource *metav1.GroupVersionResource
+		op       admissionv1.Operation
+		spec     certmanager.CertificateSpec
+		expSpec  certmanager.CertificateSpec
+	}{
+		"unset fields are defaulted": {
+			defaults: defaults(ecdsa384),
+			expSpec: certmanager.CertificateSpec{
+				PrivateKey: &certmanager.CertificatePrivateKey{
+					Algorithm:      certmanager.ECDSAKeyAlgorithm,
+					Size:           384,
+					RotationPolicy: certmanager.RotationPolicyAlways,
+				},
+				RevisionHistoryLimit: pointer.Int32(3),
+			},
+		},
+		"fields which are set are not defaulted": {
+			defaults: defaults(ecdsa384),
+			spec: certmanager.CertificateSpec{
+				PrivateKey: &certmanager.CertificatePrivateKey{
+					Algorithm:      certmanager.ECDSAKeyAlgorithm,
+					Size:           256,
+					RotationPolicy: certmanager.RotationPolicyNever,
+				},
+				RevisionHistoryLimit: pointer.Int32(1),
+			},
+			expSpec: certmanager.CertificateSpec{
+				PrivateKey: &certmanager.CertificatePrivateKey{
+					Algorithm:      certmanager.ECDSAKeyAlgorithm,
+					Size:           256,
+					RotationPolicy: certmanager.RotationPolicyNever,
+				},
+				RevisionHistoryLimit: pointer.Int32(1),
+			},
+		},
+		"size is not defaulted for a different algorithm": {
+			defaults: defaults(ecdsa384),
+			spec: certmanager.CertificateSpec{
+				PrivateKey: &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm},
+			},
+			expSpec: certmanager.CertificateSpec{
+				PrivateKey: &certmanager.CertificatePrivateKey{
+					Algorithm:      certmanager.RSAKeyAlgorithm,
+					RotationPolicy: certmanager.RotationPolicyAlways,
+				},
+				RevisionHistoryLimit: pointer.Int32(3),
+			},
+		},
+		"size is defaulted for RSA when no algorithm is specified": {
+			defaults: defaults(cmapi.CertificateDefaultsSpec{
+				PrivateKey: &cmapi.CertificatePrivateKey{Size: 4096},
+			}),
+			spec: certmanager.CertificateSpec{
+				PrivateKey: &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm},
+			},
+			expSpec: certmanager.CertificateSpec{
+				PrivateKey: &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm, Size: 4096},
+			},
+		},
+		"rotation schedule is defaulted together with the Scheduled rotation policy": {
+			defaults: defaults(cmapi.CertificateDefaultsSpec{
+				PrivateKey: &cmapi.CertificatePrivateKey{
+					RotationPolicy:   cmapi.RotationPolicyScheduled,
+					RotationSchedule: &cmapi.PrivateKeyRotationSchedule{Renewals: pointer.Int32(3)},
+				},
+			}),
+			expSpec: certmanager.CertificateSpec{
+				PrivateKey: &certmanager.CertificatePrivateKey{
+					RotationPolicy:   certmanager.RotationPolicyScheduled,
+					RotationSchedule: &certmanager.PrivateKeyRotationSchedule{Renewals: pointer.Int32(3)},
+				},
+			},
+		},
+		"rotation schedule is not defaulted for other rotation policies": {
+			defaults: defaults(cmapi.CertificateDefaultsSpec{
+				PrivateKey: &cmapi.CertificatePrivateKey{
+					RotationPolicy:   cmapi.RotationPolicyScheduled,
+					RotationSchedule: &cmapi.PrivateKeyRotationSchedule{Renewals: pointer.Int32(3)},
+				},
+			}),
+			spec: certmanager.CertificateSpec{
+				PrivateKey: &certmanager.CertificatePrivateKey{RotationPolicy: certmanager.RotationPolicyAlways},
+			},
+			expSpec: certmanager.CertificateSpec{
+				PrivateKey: &certmanager.CertificatePrivateKey{RotationPolicy: certmanager.RotationPolicyAlways},
+			},
+		},
+		"private key is not set if there are no private key defaults": {
+			defaults: defaults(cmapi.CertificateDefaultsSpec{
+				PrivateKey:           &cmapi.CertificatePrivateKey{},
+				RevisionHistoryLimit: pointer.Int32(3),
+			}),
+			expSpec: certmanager.CertificateSpec{
+				RevisionHistoryLimit: pointer.Int32(3),
+			},
+		},
+		"nothing is defaulted without a CertificateDefaults resource": {},
+		"CertificateDefaults with other names are ignored": {
+			defaults: &cmapi.CertificateDefaults{
+				ObjectMeta: metav1.ObjectMeta{Name: "other"},
+				Spec:       ecdsa384,
+			},
+		},
+		"updates are not modified": {
+			defaults: defaults(ecdsa384),
+			op:       admissionv1.Update,
+		},
+		"other resources are not modified": {
+			defaults: defaults(ecdsa384),
+			resource: &metav1.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "issuers"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// The CertificateDefaults are created through the client, as the
+			// fake clientset would otherwise guess the wrong resource name.
+			client := cmfake.NewSimpleClientset()
+			if test.defaults != nil {
+				if _, err := client.CertmanagerV1().CertificateDefaults().Create(context.Background(), test.defaults, metav1.CreateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			plugin := NewPlugin().(*certificateDefaults)
+			plugin.SetCertManagerClientSet(client)
+
+			request := admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Create,
+				RequestResource: certificateResource,
+			}
+			if test.op != "" {
+				request.Operation = test.op
+			}
+			if test.resource != nil {
+				request.RequestResource = test.resource
+			}
+
+			crt := &certmanager.Certificate{Spec: test.spec}
+			assert.NoError(t, plugin.Mutate(context.Background(), request, crt))
+			assert.Equal(t, test.expSpec, crt.Spec)
+		})
+	}
+}
+
+func TestMutateError(t *testing.T) {
+	client := cmfake.NewSimpleClientset()
+	client.PrependReactor("get", "certificatedefaults", func(action coretesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	plugin := NewPlugin().(*certificateDefaults)
+	plugin.SetCertManagerClientSet(client)
+
+	err := plugin.Mutate(context.Background(), admissionv1.AdmissionRequest{
+		Operation:       admissionv1.Create,
+		RequestResource: certificateResource,
+	}, &certmanager.Certificate{})
+	assert.EqualError(t, err, `failed to get CertificateDefaults "default": connection refused`)
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package certificateprofiles
+
+// CertificateProfiles is a plugin that sets fields which are not specified on
+// newly created Certificates to the values in the CertificateProfile referenced
+// by their profileRef. Certificates referencing a CertificateProfile which does
+// not exist are rejected.
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	internalcmapiv1 "github.com/cert-manager/cert-manager/internal/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
+)
+
+const PluginName = "CertificateProfiles"
+
+type certificateProfiles struct {
+	*admission.Handler
+
+	cmClient cmclient.Interface
+}
+
+var _ admission.MutationInterface = &certificateProfiles{}
+var _ initializer.WantsCertManagerClientSet = &certificateProfiles{}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+func NewPlugin() admission.Interface {
+	return &certificateProfiles{
+		Handler: admission.NewHandler(admissionv1.Create),
+	}
+}
+
+func (p *certificateProfiles) Mutate(ctx context.Context, request admissionv1.AdmissionRequest, obj runtime.Object) error {
+	// Only run this admission plugin when Certificate resources are created
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		request.Operation != admissionv1.Create {
+		return nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+	// A profileRef without a name is reported by the validation plugin.
+	if crt.Spec.ProfileRef == nil || crt.Spec.ProfileRef.Name == "" {
+		return nil
+	}
+
+	name := crt.Spec.ProfileRef.Name
+	profile, err := p.cmClient.CertmanagerV1().CertificateProfiles().Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("spec.profileRef: CertificateProfile %q not found", name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get CertificateProfile %q: %w", name, err)
+	}
+
+	var spec certmanager.CertificateProfileSpec
+	if err := internalcmapiv1.Convert_v1_CertificateProfileSpec_To_certmanager_CertificateProfileSpec(&profile.Spec, &spec, nil); err != nil {
+		return fmt.Errorf("internal error: failed to convert CertificateProfile %q: %w", name, err)
+	}
+
+	applyProfile(&crt.Spec, &spec)
+	return nil
+}
+
+// applyProfile sets each field of spec which is not specified to its value in
+// profile.
+func applyProfile(spec *certmanager.CertificateSpec, profile *certmanager.CertificateProfileSpec) {
+	if spec.Duration == nil && profile.Duration != nil {
+		duration := *profile.Duration
+		spec.Duration = &duration
+	}
+	// A usagePreset cannot be combined with usages.
+	if len(spec.Usages) == 0 && spec.UsagePreset == "" && len(profile.Usages) > 0 {
+		spec.Usages = append([]certmanager.KeyUsage(nil), profile.Usages...)
+	}
+	if profile.PrivateKey != nil {
+		applyPrivateKey(spec, profile.PrivateKey)
+	}
+	// Subject fields cannot be combined with a literalSubject.
+	if profile.Subject != nil && spec.LiteralSubject == "" {
+		applySubject(spec, profile.Subject)
+	}
+	if profile.Keystores != nil {
+		applyKeystores(spec, profile.Keystores)
+	}
+}
+
+func applyPrivateKey(spec *certmanager.CertificateSpec, profile *certmanager.CertificatePrivateKey) {
+	pk := spec.PrivateKey
+	if pk == nil {
+		pk = &certmanager.CertificatePrivateKey{}
+	}
+
+	// The profile's size only applies to keys of the profile's algorithm, as
+	// sizes are not valid across algorithms.
+	if pk.Size == 0 && profile.Size != 0 &&
+		(pk.Algorithm == "" || keyAlgorithm(pk.Algorithm) == keyAlgorithm(profile.Algorithm)) {
+		pk.Size = profile.Size
+	}
+	if pk.Algorithm == "" {
+		pk.Algorithm = profile.Algorithm
+	}
+	if pk.Encoding == "" {
+		pk.Encoding = profile.Encoding
+	}
+	if pk.RotationPolicy == "" {
+		pk.RotationPolicy = profile.RotationPolicy
+	}
+	// The profile's schedule only applies to Certificates using the Scheduled
+	// rotation policy, as it is not valid with any other policy.
+	if pk.RotationSchedule == nil && profile.RotationSchedule != nil &&
+		pk.RotationPolicy == certmanager.RotationPolicyScheduled {
+		pk.RotationSchedule = profile.RotationSchedule.DeepCopy()
+	}
+
+	if spec.PrivateKey != nil || *pk != (certmanager.CertificatePrivateKey{}) {
+		spec.PrivateKey = pk
+	}
+}
+
+func applySubject(spec *certmanager.CertificateSpec, profile *certmanager.X509Subject) {
+	if spec.Subject == nil {
+		spec.Subject = profile.DeepCopy()
+		return
+	}
+	subject := spec.Subject
+	setIfEmpty(&subject.Organizations, profile.Organizations)
+	setIfEmpty(&subject.Countries, profile.Countries)
+	setIfEmpty(&subject.OrganizationalUnits, profile.OrganizationalUnits)
+	setIfEmpty(&subject.Localities, profile.Localities)
+	setIfEmpty(&subject.Provinces, profile.Provinces)
+	setIfEmpty(&subject.StreetAddresses, profile.StreetAddresses)
+	setIfEmpty(&subject.PostalCodes, profile.PostalCodes)
+	if subject.SerialNumber == "" {
+		subject.SerialNumber = profile.SerialNumber
+	}
+}
+
+func setIfEmpty(field *[]string, value []string) {
+	if len(*field) == 0 && len(value) > 0 {
+		*field = append([]string(nil), value...)
+	}
+}
+
+func applyKeystores(spec *certmanager.CertificateSpec, profile *certmanager.CertificateKeystores) {
+	if spec.Keystores == nil {
+		spec.Keystores = profile.DeepCopy()
+		return
+	}
+	if spec.Keystores.JKS == nil && profile.JKS != nil {
+		spec.Keystores.JKS = profile.JKS.DeepCopy()
+	}
+	if spec.Keystores.PKCS12 == nil && profile.PKCS12 != nil {
+		spec.Keystores.PKCS12 = profile.PKCS12.DeepCopy()
+	}
+}
+
+// keyAlgorithm returns the algorithm used for keys of the given algorithm,
+// which is RSA if none is specified.
+func keyAlgorithm(alg certmanager.PrivateKeyAlgorithm) certmanager.PrivateKeyAlgorithm {
+	if alg == "" {
+		return certmanager.RSAKeyAlgorithm
+	}
+	return alg
+}
+
+func (p *certificateProfiles) SetCertManagerClientSet(client cmclient.Interface) {
+	p.cmClient = client
+}
+
+func (p *certificateProfiles) ValidateInitialization() error {
+	if p.cmClient == nil {
+		return fmt.Errorf("cert-manager client not set")
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificateprofiles
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	internalcmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+)
+
+var certificateResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+func TestMutate(t *testing.T) {
+	profileRef := &internalcmmeta.LocalObjectReference{Name: "server"}
+	server := &cmapi.CertificateProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "server"},
+		Spec: cmapi.CertificateProfileSpec{
+			PrivateKey: &cmapi.CertificatePrivateKey{
+				Algorithm: cmapi.ECDSAKeyAlgorithm,
+				Size:      384,
+			},
+			Duration: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+			Usages:   []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth},
+			Subject: &cmapi.X509Subject{
+				Organizations: []string{"Example Org"},
+				Countries:     []string{"GB"},
+			},
+			Keystores: &cmapi.CertificateKeystores{
+				PKCS12: &cmapi.PKCS12Keystore{
+					Create: true,
+					PasswordSecretRef: cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{Name: "keystore-password"},
+						Key:                  "password",
+					},
+				},
+			},
+		},
+	}
+	pkcs12 := &certmanager.PKCS12Keystore{
+		Create: true,
+		PasswordSecretRef: internalcmmeta.SecretKeySelector{
+			LocalObjectReference: internalcmmeta.LocalObjectReference{Name: "keystore-password"},
+			Key:                  "password",
+		},
+	}
+
+	tests := map[string]struct {
+		resource *metav1.GroupVersionResource
+		op       admissionv1.Operation
+		spec     certmanager.CertificateSpec
+		expSpec  certmanager.CertificateSpec
+	}{
+		"unset fields are set from the profile": {
+			spec: certmanager.CertificateSpec{ProfileRef: profileRef},
+			expSpec: certmanager.CertificateSpec{
+				ProfileRef: profileRef,
+				PrivateKey: &certmanager.CertificatePrivateKey{
+					Algorithm: certmanager.ECDSAKeyAlgorithm,
+					Size:      384,
+				},
+				Duration: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+				Usages:   []certmanager.KeyUsage{certmanager.UsageDigitalSignature, certmanager.UsageServerAuth},
+				Subject: &certmanager.X509Subject{
+					Organizations: []string{"Example Org"},
+					Countries:     []string{"GB"},
+				},
+				Keystores: &certmanager.CertificateKeystores{PKCS12: pkcs12},
+			},
+		},
+		"fields which are set are not overridden": {
+			spec: certmanager.CertificateSpec{
+				ProfileRef: profileRef,
+				PrivateKey: &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm},
+				Duration:   &metav1.Duration{Duration: 24 * time.Hour},
+				Usages:     []certmanager.KeyUsage{certmanager.UsageClientAuth},
+				Subject:    &certmanager.X509Subject{Organizations: []string{"Other Org"}},
+				Keystores:  &certmanager.CertificateKeystores{JKS: &certmanager.JKSKeystore{Create: true}},
+			},
+			expSpec: certmanager.CertificateSpec{
+				ProfileRef: profileRef,
+				PrivateKey: &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm},
+				Duration:   &metav1.Duration{Duration: 24 * time.Hour},
+				Usages:     []certmanager.KeyUsage{certmanager.UsageClientAuth},
+				Subject: &certmanager.X509Subject{
+					Organizations: []string{"Other Org"},
+					Countries:     []string{"GB"},
+				},
+				Keystores: &certmanager.CertificateKeystores{
+					JKS:    &certmanager.JKSKeystore{Create: true},
+					PKCS12: pkcs12,
+				},
+			},
+		},
+		"subject is not set on Certificates using a literal subject": {
+			spec: certmanager.CertificateSpec{
+				ProfileRef:     profileRef,
+				LiteralSubject: "CN=example",
+				Usages:         []certmanager.KeyUsage{certmanager.UsageClientAuth},
+				PrivateKey:     &certmanager.CertificatePrivateKey{Algorithm: certmanager.ECDSAKeyAlgorithm, Size: 256},
+				Duration:       &metav1.Duration{Duration: 24 * time.Hour},
+				Keystores:      &certmanager.CertificateKeystores{PKCS12: pkcs12},
+			},
+			expSpec: certmanager.CertificateSpec{
+				ProfileRef:     profileRef,
+				LiteralSubject: "CN=example",
+				Usages:         []certmanager.KeyUsage{certmanager.UsageClientAuth},
+				PrivateKey:     &certmanager.CertificatePrivateKey{Algorithm: certmanager.ECDSAKeyAlgorithm, Size: 256},
+				Duration:       &metav1.Duration{Duration: 24 * time.Hour},
+				Keystores:      &certmanager.CertificateKeystores{PKCS12: pkcs12},
+			},
+		},
+		"Certificates without a profileRef are not modified": {},
+		"updates are not modified": {
+			op:      admissionv1.Update,
+			spec:    certmanager.CertificateSpec{ProfileRef: profileRef},
+			expSpec: certmanager.CertificateSpec{ProfileRef: profileRef},
+		},
+		"other resources are not modified": {
+			resource: &metav1.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "issuers"},
+			spec:     certmanager.CertificateSpec{ProfileRef: profileRef},
+			expSpec:  certmanager.CertificateSpec{ProfileRef: profileRef},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			plugin := NewPlugin().(*certificateProfiles)
+			plugin.SetCertManagerClientSet(cmfake.NewSimpleClientset(server))
+
+			request := admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Create,
+				RequestResource: certificateResource,
+			}
+			if test.op != "" {
+				request.Operation = test.op
+			}
+			if test.resource != nil {
+				request.RequestResource = test.resource
+			}
+
+			crt := &certmanager.Certificate{Spec: test.spec}
+			assert.NoError(t, plugin.Mutate(context.Background(), request, crt))
+			assert.Equal(t, test.expSpec, crt.Spec)
+		})
+	}
+}
+
+func TestMutateError(t *testing.T) {
+	request := admissionv1.AdmissionRequest{
+		Operation:       admissionv1.Create,
+		RequestResource: certificateResource,
+	}
+	crt := &certmanager.Certificate{
+		Spec: certmanager.CertificateSpec{
+			ProfileRef: &internalcmmeta.LocalObjectReference{Name: "server"},
+		},
+	}
+
+	plugin := NewPlugin().(*certificateProfiles)
+	plugin.SetCertManagerClientSet(cmfake.NewSimpleClientset())
+	err := plugin.Mutate(context.Background(), request, crt.DeepCopy())
+	assert.EqualError(t, err, `spec.profileRef: CertificateProfile "server" not found`)
+
+	client := cmfake.NewSimpleClientset()
+	client.PrependReactor("get", "certificateprofiles", func(action coretesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	plugin.SetCertManagerClientSet(client)
+	err = plugin.Mutate(context.Background(), request, crt.DeepCopy())
+	assert.EqualError(t, err, `failed to get CertificateProfile "server": connection refused`)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsnamenormalization
+
+// DNSNameNormalization is a plugin that converts internationalized DNS names
+// of Certificates to their A-label (punycode) form, which is the form used in
+// issued certificates and ACME orders.
+// Names which cannot be converted are left unchanged so that they are
+// rejected by validation.
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+)
+
+const PluginName = "DNSNameNormalization"
+
+type dnsNameNormalization struct {
+	*admission.Handler
+}
+
+var _ admission.MutationInterface = &dnsNameNormalization{}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+func NewPlugin() admission.Interface {
+	return &dnsNameNormalization{
+		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+	}
+}
+
+func (p *dnsNameNormalization) Mutate(ctx context.Context, request admissionv1.AdmissionRequest, obj runtime.Object) error {
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" {
+		return nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+
+	dnsNames, err := pki.NormalizeDNSNames(crt.Spec.DNSNames)
+	if err != nil {
+		return nil
+	}
+	crt.Spec.DNSNames = dnsNames
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsnamenormalization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+)
+
+func TestMutate(t *testing.T) {
+	certificateResource := &metav1.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+	tests := map[string]struct {
+		resource *metav1.GroupVersionResource
+		dnsNames []string
+		expNames []string
+	}{
+		"no dnsNames": {},
+		"ascii names are not modified": {
+			dnsNames: []string{"example.com", "*.example.com", "xn--bcher-kva.example"},
+			expNames: []string{"example.com", "*.example.com", "xn--bcher-kva.example"},
+		},
+		"internationalized names are converted to A-labels": {
+			dnsNames: []string{"bücher.example", "*.Bücher.example"},
+			expNames: []string{"xn--bcher-kva.example", "*.xn--bcher-kva.example"},
+		},
+		"invalid names are left for validation to reject": {
+			dnsNames: []string{"bücher.example", "xn--bcher-kva.bücher.example"},
+			expNames: []string{"bücher.example", "xn--bcher-kva.bücher.example"},
+		},
+		"other resources are not modified": {
+			resource: &metav1.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificaterequests"},
+			dnsNames: []string{"bücher.example"},
+			expNames: []string{"bücher.example"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Create,
+				RequestResource: certificateResource,
+			}
+			if test.resource != nil {
+				request.RequestResource = test.resource
+			}
+
+			crt := &certmanager.Certificate{Spec: certmanager.CertificateSpec{DNSNames: test.dnsNames}}
+			assert.NoError(t, NewPlugin().(*dnsNameNormalization).Mutate(context.Background(), request, crt))
+			assert.Equal(t, test.expNames, crt.Spec.DNSNames)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerdeepvalidation
+
+// IssuerDeepValidation is a plugin that checks that Issuers and ClusterIssuers
+// can connect and authenticate to their issuing backend when they are created,
+// or when their spec is updated: the ACME directory is fetched, Vault is logged
+// into and its health is checked, and the credentials of Venafi issuers are
+// verified. Issuers which fail the check are rejected with the upstream error.
+// The plugin does nothing unless the IssuerDeepValidation feature gate is
+// enabled.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/component-base/featuregate"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cminstall "github.com/cert-manager/cert-manager/internal/apis/certmanager/install"
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
+)
+
+const PluginName = "IssuerDeepValidation"
+
+// probeTimeout is the maximum time spent checking an issuer, which must leave
+// enough of the webhook timeout for the rest of the admission chain.
+const probeTimeout = 5 * time.Second
+
+// probeFunc checks that the issuer can connect and authenticate to its
+// backend, using Secrets in the given namespace. The returned error is nil if
+// the issuer is valid, or if its type is not checked.
+type probeFunc func(ctx context.Context, namespace string, secretsLister corelisters.SecretLister, iss cmapi.GenericIssuer) *field.Error
+
+type issuerDeepValidation struct {
+	*admission.Handler
+
+	enabled                  bool
+	client                   kubernetes.Interface
+	clusterResourceNamespace string
+
+	// probe is replaced in tests.
+	probe probeFunc
+}
+
+var _ admission.ValidationInterface = &issuerDeepValidation{}
+var _ initializer.WantsFeatures = &issuerDeepValidation{}
+var _ initializer.WantsExternalKubeClientSet = &issuerDeepValidation{}
+var _ initializer.WantsClusterResourceNamespace = &issuerDeepValidation{}
+
+// scheme is used to convert the internal Issuer types to the v1 types used by
+// the issuer clients.
+var scheme = runtime.NewScheme()
+
+func init() {
+	cminstall.Install(scheme)
+}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+func NewPlugin() admission.Interface {
+	return &issuerDeepValidation{
+		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+		probe:   probeIssuer,
+	}
+}
+
+func (p *issuerDeepValidation) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
+	// Only run this admission plugin for the spec of Issuer and ClusterIssuer resources
+	if !p.enabled ||
+		request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestSubResource != "" {
+		return nil, nil
+	}
+
+	var namespace string
+	var iss cmapi.GenericIssuer
+	switch request.RequestResource.Resource {
+	case "issuers":
+		namespace, iss = request.Namespace, &cmapi.Issuer{}
+	case "clusterissuers":
+		namespace, iss = p.clusterResourceNamespace, &cmapi.ClusterIssuer{}
+	default:
+		return nil, nil
+	}
+
+	// Issuers are only checked again if their spec changes, so that they can
+	// still be modified while their backend is unavailable.
+	if request.Operation == admissionv1.Update && reflect.DeepEqual(issuerSpec(oldObj), issuerSpec(obj)) {
+		return nil, nil
+	}
+
+	if err := scheme.Convert(obj, iss, nil); err != nil {
+		return nil, fmt.Errorf("internal error: failed to convert %T: %w", obj, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	// The issuer clients do not all accept a context, so the probe is
+	// abandoned rather than cancelled once the timeout is reached.
+	result := make(chan *field.Error, 1)
+	go func() {
+		result <- p.probe(ctx, namespace, newSecretLister(ctx, p.client), iss)
+	}()
+	select {
+	case err := <-result:
+		if err != nil {
+			return nil, field.ErrorList{err}.ToAggregate()
+		}
+		return nil, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timed out after %s verifying that the issuer can connect to its server", probeTimeout)
+	}
+}
+
+// issuerSpec returns the spec of the internal Issuer or ClusterIssuer, or nil
+// if obj is neither.
+func issuerSpec(obj runtime.Object) *certmanager.IssuerSpec {
+	switch iss := obj.(type) {
+	case *certmanager.Issuer:
+		return &iss.Spec
+	case *certmanager.ClusterIssuer:
+		return &iss.Spec
+	}
+	return nil
+}
+
+func (p *issuerDeepValidation) InspectFeatureGates(features featuregate.FeatureGate) {
+	p.enabled = features != nil && features.Enabled(feature.IssuerDeepValidation)
+}
+
+func (p *issuerDeepValidation) SetExternalKubeClientSet(client kubernetes.Interface) {
+	p.client = client
+}
+
+func (p *issuerDeepValidation) SetClusterResourceNamespace(namespace string) {
+	p.clusterResourceNamespace = namespace
+}
+
+func (p *issuerDeepValidation) ValidateInitialization() error {
+	if !p.enabled {
+		return nil
+	}
+	if p.client == nil {
+		return fmt.Errorf("kubernetes client not set")
+	}
+	if p.clusterResourceNamespace == "" {
+		return fmt.Errorf("cluster resource namespace not set")
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerdeepvalidation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/component-base/featuregate"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func newFeatureGate(t *testing.T, enabled bool) featuregate.FeatureGate {
+	gate := featuregate.NewFeatureGate()
+	if err := gate.Add(map[featuregate.Feature]featuregate.FeatureSpec{
+		feature.IssuerDeepValidation: {Default: enabled, PreRelease: featuregate.Alpha},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return gate
+}
+
+func TestValidate(t *testing.T) {
+	selfSigned := certmanager.IssuerSpec{IssuerConfig: certmanager.IssuerConfig{SelfSigned: &certmanager.SelfSignedIssuer{}}}
+	ca := certmanager.IssuerSpec{IssuerConfig: certmanager.IssuerConfig{CA: &certmanager.CAIssuer{SecretName: "ca"}}}
+	issuer := func(spec certmanager.IssuerSpec) *certmanager.Issuer {
+		return &certmanager.Issuer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"}, Spec: spec}
+	}
+	resource := func(r string) *metav1.GroupVersionResource {
+		return &metav1.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: r}
+	}
+
+	tests := map[string]struct {
+		disabled    bool
+		request     admissionv1.AdmissionRequest
+		oldObj, obj runtime.Object
+		probeErr    *field.Error
+
+		expProbeNamespace string
+		expErr            string
+	}{
+		"issuers are checked with their namespace": {
+			request:           admissionv1.AdmissionRequest{Operation: admissionv1.Create, RequestResource: resource("issuers"), Namespace: "test-ns"},
+			obj:               issuer(selfSigned),
+			expProbeNamespace: "test-ns",
+		},
+		"cluster issuers are checked with the cluster resource namespace": {
+			request:           admissionv1.AdmissionRequest{Operation: admissionv1.Create, RequestResource: resource("clusterissuers")},
+			obj:               &certmanager.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Spec: selfSigned},
+			expProbeNamespace: "cert-manager",
+		},
+		"issuers which fail the check are rejected": {
+			request:           admissionv1.AdmissionRequest{Operation: admissionv1.Create, RequestResource: resource("issuers"), Namespace: "test-ns"},
+			obj:               issuer(selfSigned),
+			probeErr:          field.Invalid(field.NewPath("spec", "acme", "server"), "https://acme.example.com", "failed to fetch ACME directory: 404"),
+			expProbeNamespace: "test-ns",
+			expErr:            `spec.acme.server: Invalid value: "https://acme.example.com": failed to fetch ACME directory: 404`,
+		},
+		"updates which change the spec are checked": {
+			request:           admissionv1.AdmissionRequest{Operation: admissionv1.Update, RequestResource: resource("issuers"), Namespace: "test-ns"},
+			oldObj:            issuer(selfSigned),
+			obj:               issuer(ca),
+			expProbeNamespace: "test-ns",
+		},
+		"updates which do not change the spec are not checked": {
+			request: admissionv1.AdmissionRequest{Operation: admissionv1.Update, RequestResource: resource("issuers"), Namespace: "test-ns"},
+			oldObj:  issuer(selfSigned),
+			obj:     issuer(selfSigned),
+		},
+		"status updates are not checked": {
+			request: admissionv1.AdmissionRequest{Operation: admissionv1.Update, RequestResource: resource("issuers"), RequestSubResource: "status", Namespace: "test-ns"},
+			oldObj:  issuer(selfSigned),
+			obj:     issuer(ca),
+		},
+		"other resources are not checked": {
+			request: admissionv1.AdmissionRequest{Operation: admissionv1.Create, RequestResource: resource("certificates"), Namespace: "test-ns"},
+			obj:     &certmanager.Certificate{},
+		},
+		"nothing is checked if the feature gate is disabled": {
+			disabled: true,
+			request:  admissionv1.AdmissionRequest{Operation: admissionv1.Create, RequestResource: resource("issuers"), Namespace: "test-ns"},
+			obj:      issuer(selfSigned),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var probeNamespace string
+			plugin := NewPlugin().(*issuerDeepValidation)
+			plugin.InspectFeatureGates(newFeatureGate(t, !test.disabled))
+			plugin.SetExternalKubeClientSet(fake.NewSimpleClientset())
+			plugin.SetClusterResourceNamespace("cert-manager")
+			plugin.probe = func(_ context.Context, namespace string, _ corelisters.SecretLister, _ cmapi.GenericIssuer) *field.Error {
+				probeNamespace = namespace
+				return test.probeErr
+			}
+			if err := plugin.ValidateInitialization(); err != nil {
+				t.Fatal(err)
+			}
+
+			warnings, err := plugin.Validate(context.Background(), test.request, test.oldObj, test.obj)
+			assert.Empty(t, warnings)
+			if test.expErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expErr)
+			}
+			assert.Equal(t, test.expProbeNamespace, probeNamespace)
+		})
+	}
+}
+
+func TestProbeACME(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/directory" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"newNonce":"https://acme.example.com/new-nonce","newAccount":"https://acme.example.com/new-account","newOrder":"https://acme.example.com/new-order"}`)
+	}))
+	defer server.Close()
+
+	acmeIssuer := func(url string) cmapi.GenericIssuer {
+		return &cmapi.ClusterIssuer{Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{ACME: &cmacme.ACMEIssuer{Server: url}}}}
+	}
+
+	assert.Nil(t, probeIssuer(context.Background(), "", nil, acmeIssuer(server.URL+"/directory")))
+
+	err := probeIssuer(context.Background(), "", nil, acmeIssuer(server.URL+"/not-found"))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "spec.acme.server", err.Field)
+		assert.Contains(t, err.Detail, "failed to fetch ACME directory")
+	}
+}
+
+func TestProbeVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/sys/health" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"initialized":true,"sealed":false}`)
+	}))
+	defer server.Close()
+
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "test-ns"},
+		Data:       map[string][]byte{"token": []byte("test-token")},
+	})
+	vaultIssuer := func(secretName string) cmapi.GenericIssuer {
+		return &cmapi.Issuer{Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{Vault: &cmapi.VaultIssuer{
+			Server: server.URL,
+			Path:   "pki/sign/example",
+			Auth: cmapi.VaultAuth{
+				TokenSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: secretName}, Key: "token"},
+			},
+		}}}}
+	}
+	secretsLister := newSecretLister(context.Background(), client)
+
+	assert.Nil(t, probeIssuer(context.Background(), "test-ns", secretsLister, vaultIssuer("vault-token")))
+
+	err := probeIssuer(context.Background(), "test-ns", secretsLister, vaultIssuer("does-not-exist"))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "spec.vault.server", err.Field)
+		assert.Contains(t, err.Detail, `secrets "does-not-exist" not found`)
+	}
+}
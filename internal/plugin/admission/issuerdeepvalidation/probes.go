@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerdeepvalidation
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/internal/vault"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+var _ probeFunc = probeIssuer
+
+// probeIssuer checks ACME, Vault and Venafi issuers. Other issuer types are
+// not checked.
+func probeIssuer(ctx context.Context, namespace string, secretsLister corelisters.SecretLister, iss cmapi.GenericIssuer) *field.Error {
+	spec := iss.GetSpec()
+	fldPath := field.NewPath("spec")
+	switch {
+	case spec.ACME != nil:
+		return probeACME(ctx, iss, fldPath.Child("acme"))
+	case spec.Vault != nil:
+		return probeVault(namespace, secretsLister, iss, fldPath.Child("vault"))
+	case spec.Venafi != nil:
+		return probeVenafi(namespace, secretsLister, iss, fldPath.Child("venafi"))
+	}
+	return nil
+}
+
+// probeACME fetches the directory of the ACME server.
+func probeACME(ctx context.Context, iss cmapi.GenericIssuer, fldPath *field.Path) *field.Error {
+	acme := iss.GetSpec().ACME
+	client := &acmeapi.Client{
+		DirectoryURL: acme.Server,
+		HTTPClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: acme.SkipTLSVerify},
+			},
+		},
+	}
+	if _, err := client.Discover(ctx); err != nil {
+		return field.Invalid(fldPath.Child("server"), acme.Server, fmt.Sprintf("failed to fetch ACME directory: %v", err))
+	}
+	return nil
+}
+
+// probeVault logs into Vault and checks that it is initialized and unsealed.
+func probeVault(namespace string, secretsLister corelisters.SecretLister, iss cmapi.GenericIssuer, fldPath *field.Path) *field.Error {
+	server := iss.GetSpec().Vault.Server
+	if kubernetesAuth := iss.GetSpec().Vault.Auth.Kubernetes; kubernetesAuth != nil && kubernetesAuth.ServiceAccountRef != nil {
+		// The webhook is not allowed to request tokens for ServiceAccounts,
+		// so the login is only verified when the controller sets up the
+		// issuer.
+		return nil
+	}
+	client, err := vault.New(namespace, nil, secretsLister, iss)
+	if err != nil {
+		return field.Invalid(fldPath.Child("server"), server, fmt.Sprintf("failed to authenticate with Vault: %v", err))
+	}
+	if err := client.IsVaultInitializedAndUnsealed(); err != nil {
+		return field.Invalid(fldPath.Child("server"), server, fmt.Sprintf("failed to verify Vault status: %v", err))
+	}
+	return nil
+}
+
+// probeVenafi verifies the credentials of the Venafi TPP or Cloud issuer.
+func probeVenafi(namespace string, secretsLister corelisters.SecretLister, iss cmapi.GenericIssuer, fldPath *field.Path) *field.Error {
+	venafi := iss.GetSpec().Venafi
+	urlPath, url := fldPath.Child("cloud", "url"), ""
+	if venafi.TPP != nil {
+		urlPath, url = fldPath.Child("tpp", "url"), venafi.TPP.URL
+	} else if venafi.Cloud != nil {
+		url = venafi.Cloud.URL
+	}
+
+	log := logf.Log.WithName(PluginName)
+	client, err := venaficlient.New(namespace, secretsLister, iss, metrics.New(log, clock.RealClock{}), log)
+	if err != nil {
+		return field.Invalid(urlPath, url, fmt.Sprintf("failed to build Venafi client: %v", err))
+	}
+	if err := client.Ping(); err != nil {
+		return field.Invalid(urlPath, url, fmt.Sprintf("failed to connect to Venafi: %v", err))
+	}
+	if err := client.VerifyCredentials(); err != nil {
+		return field.Invalid(urlPath, url, fmt.Sprintf("failed to authenticate with Venafi: %v", err))
+	}
+	return nil
+}
+
+// secretLister implements SecretLister by getting Secrets from the API server,
+// so that the webhook does not need to watch every Secret in the cluster.
+type secretLister struct {
+	ctx    context.Context
+	client kubernetes.Interface
+}
+
+var _ corelisters.SecretLister = &secretLister{}
+
+func newSecretLister(ctx context.Context, client kubernetes.Interface) corelisters.SecretLister {
+	return &secretLister{ctx: ctx, client: client}
+}
+
+func (l *secretLister) List(selector labels.Selector) ([]*corev1.Secret, error) {
+	return l.Secrets(metav1.NamespaceAll).List(selector)
+}
+
+func (l *secretLister) Secrets(namespace string) corelisters.SecretNamespaceLister {
+	return &secretNamespaceLister{ctx: l.ctx, client: l.client, namespace: namespace}
+}
+
+type secretNamespaceLister struct {
+	ctx       context.Context
+	client    kubernetes.Interface
+	namespace string
+}
+
+func (l *secretNamespaceLister) List(selector labels.Selector) ([]*corev1.Secret, error) {
+	list, err := l.client.CoreV1().Secrets(l.namespace).List(l.ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]*corev1.Secret, len(list.Items))
+	for i := range list.Items {
+		secrets[i] = &list.Items[i]
+	}
+	return secrets, nil
+}
+
+func (l *secretNamespaceLister) Get(name string) (*corev1.Secret, error) {
+	return l.client.CoreV1().Secrets(l.namespace).Get(l.ctx, name, metav1.GetOptions{})
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyusagepolicy
+
+// KeyUsagePolicy is a plugin that rejects Certificates and CertificateRequests
+// which request key usages that their issuer does not allow, either because
+// of the type of the issuer or because of its allowedUsages.
+// Resources referencing an issuer which does not exist, or which is not a
+// cert-manager.io issuer, are not checked.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	internalcmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
+)
+
+const PluginName = "KeyUsagePolicy"
+
+type keyUsagePolicy struct {
+	*admission.Handler
+
+	cmClient cmclient.Interface
+}
+
+var _ admission.ValidationInterface = &keyUsagePolicy{}
+var _ initializer.WantsCertManagerClientSet = &keyUsagePolicy{}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+func NewPlugin() admission.Interface {
+	return &keyUsagePolicy{
+		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+	}
+}
+
+// requestedUsages are the fields of a Certificate or CertificateRequest which
+// determine the usages requested from its issuer.
+type requestedUsages struct {
+	issuerRef   internalcmmeta.ObjectReference
+	usages      []certmanager.KeyUsage
+	usagePreset certmanager.UsagePreset
+	isCA        bool
+}
+
+func (p *keyUsagePolicy) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
+	if request.RequestResource.Group != "cert-manager.io" {
+		return nil, nil
+	}
+
+	var newReq, oldReq *requestedUsages
+	var err error
+	switch request.RequestResource.Resource {
+	case "certificates":
+		newReq, err = certificateUsages(obj)
+		if err == nil && oldObj != nil {
+			oldReq, err = certificateUsages(oldObj)
+		}
+	case "certificaterequests":
+		newReq, err = certificateRequestUsages(obj)
+		if err == nil && oldObj != nil {
+			oldReq, err = certificateRequestUsages(oldObj)
+		}
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Updates are only checked if they change the requested usages, so that
+	// changes to an issuer's policy do not prevent other updates.
+	if request.Operation == admissionv1.Update && reflect.DeepEqual(oldReq, newReq) {
+		return nil, nil
+	}
+
+	issuer, err := p.getIssuer(ctx, request.Namespace, newReq.issuerRef)
+	if err != nil || issuer == nil {
+		return nil, err
+	}
+
+	usages := make([]cmapi.KeyUsage, len(newReq.usages))
+	for i, u := range newReq.usages {
+		usages[i] = cmapi.KeyUsage(u)
+	}
+	if len(usages) == 0 && newReq.usagePreset != "" {
+		usages = apiutil.UsagesForPreset(cmapi.UsagePreset(newReq.usagePreset))
+	}
+	if err := apiutil.CheckIssuerUsages(issuer, request.Namespace, apiutil.RequestedUsages(usages, newReq.isCA)); err != nil {
+		return nil, fmt.Errorf("spec.usages: %w", err)
+	}
+	return nil, nil
+}
+
+func certificateUsages(obj runtime.Object) (*requestedUsages, error) {
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+	return &requestedUsages{
+		issuerRef:   crt.Spec.IssuerRef,
+		usages:      crt.Spec.Usages,
+		usagePreset: crt.Spec.UsagePreset,
+		isCA:        crt.Spec.IsCA,
+	}, nil
+}
+
+func certificateRequestUsages(obj runtime.Object) (*requestedUsages, error) {
+	cr, ok := obj.(*certmanager.CertificateRequest)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.CertificateRequest")
+	}
+	return &requestedUsages{
+		issuerRef: cr.Spec.IssuerRef,
+		usages:    cr.Spec.Usages,
+		isCA:      cr.Spec.IsCA,
+	}, nil
+}
+
+// getIssuer returns the cert-manager.io issuer referenced by ref, or nil if
+// ref is not a cert-manager.io issuer or the issuer does not exist.
+func (p *keyUsagePolicy) getIssuer(ctx context.Context, namespace string, ref internalcmmeta.ObjectReference) (cmapi.GenericIssuer, error) {
+	if ref.Group != "" && ref.Group != "cert-manager.io" {
+		return nil, nil
+	}
+
+	kind := ref.Kind
+	if kind == "" {
+		kind = cmapi.IssuerKind
+	}
+
+	var issuer cmapi.GenericIssuer
+	var err error
+	switch kind {
+	case cmapi.IssuerKind:
+		issuer, err = p.cmClient.CertmanagerV1().Issuers(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case cmapi.ClusterIssuerKind:
+		issuer, err = p.cmClient.CertmanagerV1().ClusterIssuers().Get(ctx, ref.Name, metav1.GetOptions{})
+	default:
+		return nil, nil
+	}
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %q: %w", kind, ref.Name, err)
+	}
+	return issuer, nil
+}
+
+func (p *keyUsagePolicy) SetCertManagerClientSet(client cmclient.Interface) {
+	p.cmClient = client
+}
+
+func (p *keyUsagePolicy) ValidateInitialization() error {
+	if p.cmClient == nil {
+		return fmt.Errorf("cert-manager client not set")
+	}
+	return nil
+}
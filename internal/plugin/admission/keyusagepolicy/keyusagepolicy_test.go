@@ -0,0 +1,212 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyusagepolicy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	internalcmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+)
+
+var (
+	certificateResource = &metav1.GroupVersionResource{
+		Group:    "cert-manager.io",
+		Version:  "v1",
+		Resource: "certificates",
+	}
+	certificateRequestResource = &metav1.GroupVersionResource{
+		Group:    "cert-manager.io",
+		Version:  "v1",
+		Resource: "certificaterequests",
+	}
+)
+
+func TestValidate(t *testing.T) {
+	acmeIssuer := &cmapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "letsencrypt"},
+		Spec:       cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{ACME: &cmacme.ACMEIssuer{}}},
+	}
+	caIssuer := &cmapi.ClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal-ca"},
+		Spec: cmapi.IssuerSpec{
+			IssuerConfig: cmapi.IssuerConfig{CA: &cmapi.CAIssuer{}},
+			AllowedUsages: []cmapi.AllowedKeyUsage{
+				{Usage: cmapi.UsageDigitalSignature},
+				{Usage: cmapi.UsageKeyEncipherment},
+				{Usage: cmapi.UsageClientAuth},
+				{Usage: cmapi.UsageServerAuth, Namespaces: []string{"ingress"}},
+			},
+		},
+	}
+	acmeRef := internalcmmeta.ObjectReference{Name: "letsencrypt"}
+	caRef := internalcmmeta.ObjectReference{Name: "internal-ca", Kind: "ClusterIssuer", Group: "cert-manager.io"}
+
+	tests := map[string]struct {
+		resource  *metav1.GroupVersionResource
+		op        admissionv1.Operation
+		namespace string
+		oldObj    runtime.Object
+		obj       runtime.Object
+		wantErr   string
+	}{
+		"Certificates may request usages allowed by the issuer type": {
+			obj: &certmanager.Certificate{Spec: certmanager.CertificateSpec{
+				IssuerRef: acmeRef,
+				Usages:    []certmanager.KeyUsage{certmanager.UsageServerAuth},
+			}},
+		},
+		"Certificates may not request code signing from ACME issuers": {
+			obj: &certmanager.Certificate{Spec: certmanager.CertificateSpec{
+				IssuerRef: acmeRef,
+				Usages:    []certmanager.KeyUsage{certmanager.UsageDigitalSignature, certmanager.UsageCodeSigning},
+			}},
+			wantErr: `spec.usages: usage "code signing" cannot be requested from acme issuers`,
+		},
+		"CertificateRequests may not request code signing from ACME issuers": {
+			resource: certificateRequestResource,
+			obj: &certmanager.CertificateRequest{Spec: certmanager.CertificateRequestSpec{
+				IssuerRef: acmeRef,
+				Usages:    []certmanager.KeyUsage{certmanager.UsageCodeSigning},
+			}},
+			wantErr: `spec.usages: usage "code signing" cannot be requested from acme issuers`,
+		},
+		"usage presets are checked": {
+			namespace: "default",
+			obj: &certmanager.Certificate{Spec: certmanager.CertificateSpec{
+				IssuerRef:   caRef,
+				UsagePreset: certmanager.UsagePresetServerAuth,
+			}},
+			wantErr: `spec.usages: usage "server auth" is not allowed by the issuer in namespace "default"`,
+		},
+		"usages restricted to a namespace are allowed in that namespace": {
+			namespace: "ingress",
+			obj: &certmanager.Certificate{Spec: certmanager.CertificateSpec{
+				IssuerRef:   caRef,
+				UsagePreset: certmanager.UsagePresetServerAuth,
+			}},
+		},
+		"CA certificates request cert sign": {
+			resource: certificateRequestResource,
+			obj: &certmanager.CertificateRequest{Spec: certmanager.CertificateRequestSpec{
+				IssuerRef: caRef,
+				IsCA:      true,
+			}},
+			wantErr: `spec.usages: usage "cert sign" is not allowed by the issuer in namespace "default"`,
+		},
+		"updates which do not change the requested usages are not checked": {
+			op: admissionv1.Update,
+			oldObj: &certmanager.Certificate{Spec: certmanager.CertificateSpec{
+				IssuerRef: acmeRef,
+				Usages:    []certmanager.KeyUsage{certmanager.UsageCodeSigning},
+			}},
+			obj: &certmanager.Certificate{Spec: certmanager.CertificateSpec{
+				CommonName: "example.com",
+				IssuerRef:  acmeRef,
+				Usages:     []certmanager.KeyUsage{certmanager.UsageCodeSigning},
+			}},
+		},
+		"updates which change the requested usages are checked": {
+			op: admissionv1.Update,
+			oldObj: &certmanager.Certificate{Spec: certmanager.CertificateSpec{
+				IssuerRef: acmeRef,
+			}},
+			obj: &certmanager.Certificate{Spec: certmanager.CertificateSpec{
+				IssuerRef: acmeRef,
+				Usages:    []certmanager.KeyUsage{certmanager.UsageCodeSigning},
+			}},
+			wantErr: `spec.usages: usage "code signing" cannot be requested from acme issuers`,
+		},
+		"issuers which do not exist are not checked": {
+			obj: &certmanager.Certificate{Spec: certmanager.CertificateSpec{
+				IssuerRef: internalcmmeta.ObjectReference{Name: "does-not-exist"},
+				Usages:    []certmanager.KeyUsage{certmanager.UsageCodeSigning},
+			}},
+		},
+		"external issuers are not checked": {
+			obj: &certmanager.Certificate{Spec: certmanager.CertificateSpec{
+				IssuerRef: internalcmmeta.ObjectReference{Name: "letsencrypt", Kind: "Issuer", Group: "example.com"},
+				Usages:    []certmanager.KeyUsage{certmanager.UsageCodeSigning},
+			}},
+		},
+		"other resources are not checked": {
+			resource: &metav1.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "issuers"},
+			obj:      &certmanager.Issuer{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			plugin := NewPlugin().(*keyUsagePolicy)
+			plugin.SetCertManagerClientSet(cmfake.NewSimpleClientset(acmeIssuer, caIssuer))
+
+			request := admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Create,
+				RequestResource: certificateResource,
+				Namespace:       "default",
+			}
+			if test.op != "" {
+				request.Operation = test.op
+			}
+			if test.resource != nil {
+				request.RequestResource = test.resource
+			}
+			if test.namespace != "" {
+				request.Namespace = test.namespace
+			}
+
+			warnings, err := plugin.Validate(context.Background(), request, test.oldObj, test.obj)
+			assert.Empty(t, warnings)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateError(t *testing.T) {
+	client := cmfake.NewSimpleClientset()
+	client.PrependReactor("get", "issuers", func(action coretesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+
+	plugin := NewPlugin().(*keyUsagePolicy)
+	plugin.SetCertManagerClientSet(client)
+	request := admissionv1.AdmissionRequest{
+		Operation:       admissionv1.Create,
+		RequestResource: certificateResource,
+		Namespace:       "default",
+	}
+	crt := &certmanager.Certificate{Spec: certmanager.CertificateSpec{
+		IssuerRef: internalcmmeta.ObjectReference{Name: "letsencrypt"},
+	}}
+	_, err := plugin.Validate(context.Background(), request, nil, crt)
+	assert.EqualError(t, err, `failed to get Issuer "letsencrypt": connection refused`)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacedefaultissuer
+
+// NamespaceDefaultIssuer is a plugin that sets the issuerRef of newly created
+// Certificates which do not specify one to the default issuer declared by the
+// annotations on their Namespace.
+// If the Namespace has no default issuer, Certificates are not modified and
+// are rejected by validation as before.
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
+)
+
+const PluginName = "NamespaceDefaultIssuer"
+
+type namespaceDefaultIssuer struct {
+	*admission.Handler
+
+	kubeClient kubernetes.Interface
+}
+
+var _ admission.MutationInterface = &namespaceDefaultIssuer{}
+var _ initializer.WantsExternalKubeClientSet = &namespaceDefaultIssuer{}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+func NewPlugin() admission.Interface {
+	return &namespaceDefaultIssuer{
+		Handler: admission.NewHandler(admissionv1.Create),
+	}
+}
+
+func (p *namespaceDefaultIssuer) Mutate(ctx context.Context, request admissionv1.AdmissionRequest, obj runtime.Object) error {
+	// Only run this admission plugin when Certificate resources are created
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		request.Operation != admissionv1.Create {
+		return nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+
+	// A Certificate which names an issuer is never modified, even if the
+	// rest of its issuerRef is empty.
+	if crt.Spec.IssuerRef.Name != "" {
+		return nil
+	}
+
+	ns, err := p.kubeClient.CoreV1().Namespaces().Get(ctx, request.Namespace, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get Namespace %q: %w", request.Namespace, err)
+	}
+
+	name := ns.Annotations[cmapi.NamespaceDefaultIssuerNameAnnotationKey]
+	if name == "" {
+		return nil
+	}
+	crt.Spec.IssuerRef.Name = name
+	crt.Spec.IssuerRef.Kind = ns.Annotations[cmapi.NamespaceDefaultIssuerKindAnnotationKey]
+	crt.Spec.IssuerRef.Group = ns.Annotations[cmapi.NamespaceDefaultIssuerGroupAnnotationKey]
+	return nil
+}
+
+func (p *namespaceDefaultIssuer) SetExternalKubeClientSet(client kubernetes.Interface) {
+	p.kubeClient = client
+}
+
+func (p *namespaceDefaultIssuer) ValidateInitialization() error {
+	if p.kubeClient == nil {
+		return fmt.Errorf("kubernetes client not set")
+	}
+	return nil
+}
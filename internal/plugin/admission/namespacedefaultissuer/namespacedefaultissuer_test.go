@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacedefaultissuer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var certificateResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+func TestMutate(t *testing.T) {
+	namespace := func(annotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a", Annotations: annotations},
+		}
+	}
+	clusterIssuerDefault := namespace(map[string]string{
+		cmapi.NamespaceDefaultIssuerNameAnnotationKey:  "team-a-ca",
+		cmapi.NamespaceDefaultIssuerKindAnnotationKey:  "ClusterIssuer",
+		cmapi.NamespaceDefaultIssuerGroupAnnotationKey: "cert-manager.io",
+	})
+
+	tests := map[string]struct {
+		namespace *corev1.Namespace
+		resource  *metav1.GroupVersionResource
+		op        admissionv1.Operation
+		issuerRef cmmeta.ObjectReference
+		expRef    cmmeta.ObjectReference
+	}{
+		"empty issuerRef is defaulted": {
+			namespace: clusterIssuerDefault,
+			expRef:    cmmeta.ObjectReference{Name: "team-a-ca", Kind: "ClusterIssuer", Group: "cert-manager.io"},
+		},
+		"only the name annotation is required": {
+			namespace: namespace(map[string]string{cmapi.NamespaceDefaultIssuerNameAnnotationKey: "team-a-ca"}),
+			expRef:    cmmeta.ObjectReference{Name: "team-a-ca"},
+		},
+		"issuerRef with a name is not modified": {
+			namespace: clusterIssuerDefault,
+			issuerRef: cmmeta.ObjectReference{Name: "own-issuer"},
+			expRef:    cmmeta.ObjectReference{Name: "own-issuer"},
+		},
+		"namespace without annotations": {
+			namespace: namespace(nil),
+		},
+		"kind annotation without a name is ignored": {
+			namespace: namespace(map[string]string{cmapi.NamespaceDefaultIssuerKindAnnotationKey: "ClusterIssuer"}),
+		},
+		"missing namespace": {},
+		"updates are not modified": {
+			namespace: clusterIssuerDefault,
+			op:        admissionv1.Update,
+		},
+		"other resources are not modified": {
+			namespace: clusterIssuerDefault,
+			resource:  &metav1.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificaterequests"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var objects []runtime.Object
+			if test.namespace != nil {
+				objects = append(objects, test.namespace)
+			}
+			plugin := NewPlugin().(*namespaceDefaultIssuer)
+			plugin.SetExternalKubeClientSet(kubefake.NewSimpleClientset(objects...))
+
+			request := admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Create,
+				RequestResource: certificateResource,
+				Namespace:       "team-a",
+			}
+			if test.op != "" {
+				request.Operation = test.op
+			}
+			if test.resource != nil {
+				request.RequestResource = test.resource
+			}
+
+			crt := &certmanager.Certificate{Spec: certmanager.CertificateSpec{IssuerRef: test.issuerRef}}
+			assert.NoError(t, plugin.Mutate(context.Background(), request, crt))
+			assert.Equal(t, test.expRef, crt.Spec.IssuerRef)
+		})
+	}
+}
+
+func TestMutateError(t *testing.T) {
+	client := kubefake.NewSimpleClientset()
+	client.PrependReactor("get", "namespaces", func(action coretesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	plugin := NewPlugin().(*namespaceDefaultIssuer)
+	plugin.SetExternalKubeClientSet(client)
+
+	err := plugin.Mutate(context.Background(), admissionv1.AdmissionRequest{
+		Operation:       admissionv1.Create,
+		RequestResource: certificateResource,
+		Namespace:       "team-a",
+	}, &certmanager.Certificate{})
+	assert.EqualError(t, err, `failed to get Namespace "team-a": connection refused`)
+}
@@ -26,9 +26,11 @@ import (
 
 	acmevalidation "github.com/cert-manager/cert-manager/internal/apis/acme/validation"
 	cmvalidation "github.com/cert-manager/cert-manager/internal/apis/certmanager/validation"
+	"github.com/cert-manager/cert-manager/internal/webhook/admissionpolicy"
 	acmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	admission "github.com/cert-manager/cert-manager/pkg/webhook/admission"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
 )
 
 const PluginName = "ResourceValidation"
@@ -37,6 +39,10 @@ type resourceValidation struct {
 	*admission.Handler
 
 	validationMappings map[schema.GroupVersionResource]validationPair
+
+	// disablePolicyCoveredChecks drops the errors which are also returned by
+	// the ValidatingAdmissionPolicies of cert-manager.
+	disablePolicyCoveredChecks bool
 }
 
 // Register registers a plugin
@@ -47,10 +53,15 @@ func Register(plugins *admission.Plugins) {
 }
 
 var _ admission.ValidationInterface = &resourceValidation{}
+var _ initializer.WantsDisablePolicyCoveredChecks = &resourceValidation{}
 
 var certificateGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificates")
 var certificateRequestGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificaterequests")
+var certificateRevocationRequestGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificaterevocationrequests")
+var certificateDefaultsGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificatedefaults")
+var certificateProfileGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificateprofiles")
 var issuerGVR = certmanagerv1.SchemeGroupVersion.WithResource("issuers")
+var issuerAliasGVR = certmanagerv1.SchemeGroupVersion.WithResource("issueraliases")
 var clusterIssuerGVR = certmanagerv1.SchemeGroupVersion.WithResource("clusterissuers")
 var orderGVR = acmev1.SchemeGroupVersion.WithResource("orders")
 var challengeGVR = acmev1.SchemeGroupVersion.WithResource("challenges")
@@ -68,12 +79,16 @@ func newValidationPair(create validateCreateFunc, update validateUpdateFunc) val
 }
 
 var validationMapping = map[schema.GroupVersionResource]validationPair{
-	certificateGVR:        newValidationPair(cmvalidation.ValidateCertificate, cmvalidation.ValidateUpdateCertificate),
-	certificateRequestGVR: newValidationPair(cmvalidation.ValidateCertificateRequest, cmvalidation.ValidateUpdateCertificateRequest),
-	issuerGVR:             newValidationPair(cmvalidation.ValidateIssuer, cmvalidation.ValidateUpdateIssuer),
-	clusterIssuerGVR:      newValidationPair(cmvalidation.ValidateClusterIssuer, cmvalidation.ValidateUpdateClusterIssuer),
-	orderGVR:              newValidationPair(acmevalidation.ValidateOrder, acmevalidation.ValidateOrderUpdate),
-	challengeGVR:          newValidationPair(acmevalidation.ValidateChallenge, acmevalidation.ValidateChallengeUpdate),
+	certificateGVR:                  newValidationPair(cmvalidation.ValidateCertificate, cmvalidation.ValidateUpdateCertificate),
+	certificateRequestGVR:           newValidationPair(cmvalidation.ValidateCertificateRequest, cmvalidation.ValidateUpdateCertificateRequest),
+	certificateRevocationRequestGVR: newValidationPair(cmvalidation.ValidateCertificateRevocationRequest, cmvalidation.ValidateUpdateCertificateRevocationRequest),
+	certificateDefaultsGVR:          newValidationPair(cmvalidation.ValidateCertificateDefaults, cmvalidation.ValidateUpdateCertificateDefaults),
+	certificateProfileGVR:           newValidationPair(cmvalidation.ValidateCertificateProfile, cmvalidation.ValidateUpdateCertificateProfile),
+	issuerGVR:                       newValidationPair(cmvalidation.ValidateIssuer, cmvalidation.ValidateUpdateIssuer),
+	issuerAliasGVR:                  newValidationPair(cmvalidation.ValidateIssuerAlias, cmvalidation.ValidateUpdateIssuerAlias),
+	clusterIssuerGVR:                newValidationPair(cmvalidation.ValidateClusterIssuer, cmvalidation.ValidateUpdateClusterIssuer),
+	orderGVR:                        newValidationPair(acmevalidation.ValidateOrder, acmevalidation.ValidateOrderUpdate),
+	challengeGVR:                    newValidationPair(acmevalidation.ValidateChallenge, acmevalidation.ValidateChallengeUpdate),
 }
 
 func NewPlugin() admission.Interface {
@@ -82,7 +97,15 @@ func NewPlugin() admission.Interface {
 	}
 }
 
-func (p resourceValidation) Validate(_ context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
+func (p *resourceValidation) SetDisablePolicyCoveredChecks(disabled bool) {
+	p.disablePolicyCoveredChecks = disabled
+}
+
+func (p *resourceValidation) ValidateInitialization() error {
+	return nil
+}
+
+func (p *resourceValidation) Validate(_ context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
 	requestResource := schema.GroupVersionResource{
 		Group:    request.RequestResource.Group,
 		Version:  request.RequestResource.Version,
@@ -100,14 +123,27 @@ func (p resourceValidation) Validate(_ context.Context, request admissionv1.Admi
 			return nil, nil
 		}
 		errs, warnings := pair.create(&request, obj)
-		return warnings, errs.ToAggregate()
+		return warnings, p.filterErrors(requestResource, errs).ToAggregate()
 	case admissionv1.Update:
 		if pair.update == nil {
 			return nil, nil
 		}
 		errs, warnings := pair.update(&request, oldObj, obj)
-		return warnings, errs.ToAggregate()
+		return warnings, p.filterErrors(requestResource, errs).ToAggregate()
 	}
 
 	return nil, nil
 }
+
+// filterErrors removes the errors which are also returned by the
+// ValidatingAdmissionPolicies of cert-manager if policy covered checks are
+// disabled.
+func (p *resourceValidation) filterErrors(gvr schema.GroupVersionResource, errs field.ErrorList) field.ErrorList {
+	if !p.disablePolicyCoveredChecks {
+		return errs
+	}
+	return errs.Filter(func(err error) bool {
+		fieldErr, ok := err.(*field.Error)
+		return ok && admissionpolicy.Covers(gvr, fieldErr)
+	})
+}
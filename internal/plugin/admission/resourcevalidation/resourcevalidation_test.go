@@ -20,12 +20,18 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
+	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
 )
 
 var (
@@ -92,6 +98,123 @@ func TestResourceValidation(t *testing.T) {
 	}
 }
 
+func TestResourceValidationDisablePolicyCoveredChecks(t *testing.T) {
+	// Each object fails every check of the policy for its resource, and one
+	// check which is not covered by the policy.
+	crt := &internalcmapi.Certificate{
+		Spec: internalcmapi.CertificateSpec{
+			DNSNames:             []string{"*.*.example.com"},
+			IssuerRef:            cmmeta.ObjectReference{Kind: "Unknown"},
+			RevisionHistoryLimit: pointer.Int32(0),
+			Duration:             &metav1.Duration{Duration: time.Minute},
+			RenewBefore:          &metav1.Duration{Duration: time.Minute},
+			PrivateKey:           &internalcmapi.CertificatePrivateKey{Algorithm: internalcmapi.ECDSAKeyAlgorithm, Size: 123},
+		},
+	}
+	cr := &internalcmapi.CertificateRequest{
+		Spec: internalcmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{Kind: "Unknown"}},
+		Status: internalcmapi.CertificateRequestStatus{
+			Conditions: []internalcmapi.CertificateRequestCondition{
+				{Type: internalcmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionFalse},
+			},
+		},
+	}
+	iss := &internalcmapi.ClusterIssuer{
+		Spec: internalcmapi.IssuerSpec{
+			IssuerConfig: internalcmapi.IssuerConfig{
+				ACME: &cmacme.ACMEIssuer{
+					ExternalAccountBinding: &cmacme.ACMEExternalAccountBinding{
+						Key: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "eab"}, Key: "key"},
+					},
+				},
+				SelfSigned: &internalcmapi.SelfSignedIssuer{},
+				CA:         &internalcmapi.CAIssuer{},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		gvr         schema.GroupVersionResource
+		oldObj, obj runtime.Object
+		operation   admissionv1.Operation
+
+		expectedError error
+	}{
+		"certificates": {
+			gvr:           certificateGVR,
+			obj:           crt,
+			operation:     admissionv1.Create,
+			expectedError: field.ErrorList{field.Invalid(field.NewPath("spec", "dnsNames").Index(0), "*.*.example.com", "invalid DNS name: a wildcard is only allowed as the leftmost label, e.g. *.example.com")}.ToAggregate(),
+		},
+		"certificaterequests": {
+			gvr:           certificateRequestGVR,
+			obj:           cr,
+			operation:     admissionv1.Create,
+			expectedError: field.ErrorList{field.Invalid(field.NewPath("status", "conditions").Child("Approved"), cmmeta.ConditionFalse, `"Approved" condition may only be set to True`)}.ToAggregate(),
+		},
+		"clusterissuers": {
+			gvr:           clusterIssuerGVR,
+			obj:           iss,
+			operation:     admissionv1.Create,
+			expectedError: field.ErrorList{field.Required(field.NewPath("spec", "acme", "externalAccountBinding", "keyID"), "the keyID field is required when using externalAccountBinding")}.ToAggregate(),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := admissionv1.AdmissionRequest{
+				Operation:       test.operation,
+				RequestResource: &metav1.GroupVersionResource{Group: test.gvr.Group, Version: test.gvr.Version, Resource: test.gvr.Resource},
+			}
+			p := NewPlugin().(*resourceValidation)
+			_, err := p.Validate(context.Background(), req, test.oldObj, test.obj)
+			if err == nil || err.Error() == test.expectedError.Error() {
+				t.Fatalf("expected more errors when policy covered checks are enabled, got %v", err)
+			}
+
+			p.SetDisablePolicyCoveredChecks(true)
+			_, err = p.Validate(context.Background(), req, test.oldObj, test.obj)
+			compareErrors(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestResourceValidationCertificateRequestImmutability(t *testing.T) {
+	oldCR := &internalcmapi.CertificateRequest{
+		Spec: internalcmapi.CertificateRequestSpec{
+			IssuerRef: cmmeta.ObjectReference{Name: "issuer"},
+			Username:  "alice",
+			Groups:    []string{"system:authenticated"},
+		},
+	}
+	cr := oldCR.DeepCopy()
+	cr.Annotations = map[string]string{internalcmapi.CertificateNameKey: "changed"}
+	cr.Spec.Username = "mallory"
+	cr.Spec.Groups = []string{"system:masters"}
+
+	// Changes to the spec of a CertificateRequest, including the identity
+	// of the requester, must be rejected by the webhook even if the policy
+	// covered checks are disabled.
+	expectedError := field.ErrorList{
+		field.Forbidden(field.NewPath("metadata", "annotations").Child(internalcmapi.CertificateNameKey), "cannot change cert-manager annotation after creation"),
+		field.Forbidden(field.NewPath("spec"), "cannot change spec after creation"),
+	}.ToAggregate()
+
+	req := admissionv1.AdmissionRequest{
+		Operation: admissionv1.Update,
+		RequestResource: &metav1.GroupVersionResource{
+			Group:    certificateRequestGVR.Group,
+			Version:  certificateRequestGVR.Version,
+			Resource: certificateRequestGVR.Resource,
+		},
+	}
+	for _, disabled := range []bool{false, true} {
+		p := NewPlugin().(*resourceValidation)
+		p.SetDisablePolicyCoveredChecks(disabled)
+		_, err := p.Validate(context.Background(), req, oldCR, cr)
+		compareErrors(t, expectedError, err)
+	}
+}
+
 func compareErrors(t *testing.T, exp, act error) {
 	if exp == nil && act == nil {
 		return
@@ -18,36 +18,76 @@ package plugin
 
 import (
 	"github.com/cert-manager/cert-manager/internal/plugin/admission/apideprecation"
+	"github.com/cert-manager/cert-manager/internal/plugin/admission/certificatedefaults"
+	"github.com/cert-manager/cert-manager/internal/plugin/admission/certificateprofiles"
 	certificaterequestapproval "github.com/cert-manager/cert-manager/internal/plugin/admission/certificaterequest/approval"
 	certificaterequestidentity "github.com/cert-manager/cert-manager/internal/plugin/admission/certificaterequest/identity"
+	"github.com/cert-manager/cert-manager/internal/plugin/admission/dnsnamenormalization"
+	"github.com/cert-manager/cert-manager/internal/plugin/admission/issuerdeepvalidation"
+	"github.com/cert-manager/cert-manager/internal/plugin/admission/keyusagepolicy"
+	"github.com/cert-manager/cert-manager/internal/plugin/admission/namespacedefaultissuer"
 	"github.com/cert-manager/cert-manager/internal/plugin/admission/resourcevalidation"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// AllOrderedPlugins lists all admission plugins in the order in which they are
+// run. CertificateProfiles runs before CertificateDefaults so that fields set
+// by a Certificate's profile take precedence over the cluster-wide defaults.
 var AllOrderedPlugins = []string{
 	apideprecation.PluginName,
+	certificateprofiles.PluginName,
+	certificatedefaults.PluginName,
+	namespacedefaultissuer.PluginName,
+	dnsnamenormalization.PluginName,
 	resourcevalidation.PluginName,
+	keyusagepolicy.PluginName,
+	issuerdeepvalidation.PluginName,
 	certificaterequestidentity.PluginName,
 	certificaterequestapproval.PluginName,
 }
 
 func RegisterAllPlugins(plugins *admission.Plugins) {
 	apideprecation.Register(plugins)
+	certificatedefaults.Register(plugins)
+	certificateprofiles.Register(plugins)
 	certificaterequestidentity.Register(plugins)
 	certificaterequestapproval.Register(plugins)
 	resourcevalidation.Register(plugins)
+	issuerdeepvalidation.Register(plugins)
+	namespacedefaultissuer.Register(plugins)
+	dnsnamenormalization.Register(plugins)
+	keyusagepolicy.Register(plugins)
 }
 
 func DefaultOnAdmissionPlugins() sets.String {
 	return sets.NewString(
 		apideprecation.PluginName,
+		certificateprofiles.PluginName,
+		certificatedefaults.PluginName,
+		namespacedefaultissuer.PluginName,
+		dnsnamenormalization.PluginName,
 		resourcevalidation.PluginName,
+		keyusagepolicy.PluginName,
+		issuerdeepvalidation.PluginName,
 		certificaterequestidentity.PluginName,
 		certificaterequestapproval.PluginName,
 	)
 }
 
+// OrderedDefaultOnAdmissionPlugins returns the admission plugins which are
+// on by default, in the order of AllOrderedPlugins.
+func OrderedDefaultOnAdmissionPlugins() []string {
+	on := DefaultOnAdmissionPlugins()
+	var ordered []string
+	for _, name := range AllOrderedPlugins {
+		if on.Has(name) {
+			ordered = append(ordered, name)
+		}
+	}
+	return ordered
+}
+
 // DefaultOffAdmissionPlugins gets admission plugins off by default for the webhook.
 func DefaultOffAdmissionPlugins() sets.String {
 	return sets.NewString(AllOrderedPlugins...).Difference(DefaultOnAdmissionPlugins())
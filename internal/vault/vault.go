@@ -17,9 +17,11 @@ limitations under the License.
 package vault
 
 import (
+	"context"
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"path"
 	"path/filepath"
@@ -28,19 +30,40 @@ import (
 
 	vault "github.com/hashicorp/vault/api"
 	"github.com/hashicorp/vault/sdk/helper/certutil"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/pointer"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 var _ Interface = &Vault{}
+var _ KV = &Vault{}
+var _ RoleReader = &Vault{}
+var _ Revoker = &Vault{}
 
 // ClientBuilder is a function type that returns a new Interface.
 // Can be used in tests to create a mock signer of Vault certificate requests.
-type ClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
-	issuer v1.GenericIssuer) (Interface, error)
+type ClientBuilder func(namespace string, createTokenFn func(ns string) CreateToken,
+	secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error)
+
+// CreateToken requests a token for the named ServiceAccount with the
+// TokenRequest API. It is implemented by the CreateToken method of the
+// clientset's ServiceAccounts client.
+type CreateToken func(ctx context.Context, saName string, req *authv1.TokenRequest, opts metav1.CreateOptions) (*authv1.TokenRequest, error)
+
+// CreateTokenFromClient returns a function which returns the CreateToken of
+// the given clientset for a namespace, to be passed to New and friends.
+func CreateTokenFromClient(client kubernetes.Interface) func(ns string) CreateToken {
+	return func(ns string) CreateToken {
+		return client.CoreV1().ServiceAccounts(ns).CreateToken
+	}
+}
 
 // Interface implements various high level functionality related to connecting
 // with a Vault server, verifying its status and signing certificate request for
@@ -52,6 +75,40 @@ type Interface interface {
 	IsVaultInitializedAndUnsealed() error
 }
 
+// KV reads and writes secrets of a KV version 2 secrets engine.
+type KV interface {
+	ReadKV(mount, secretPath string) (map[string]string, error)
+	WriteKV(mount, secretPath string, data map[string]string) error
+}
+
+// RoleReader reads the configuration of the PKI role which an issuer signs
+// certificates with.
+type RoleReader interface {
+	ReadRole() (*Role, error)
+}
+
+// Revoker revokes certificates issued by the PKI secrets engine which an
+// issuer signs certificates with.
+type Revoker interface {
+	RevokeCertificate(serialNumber *big.Int) error
+}
+
+// Role is the subset of the configuration of a PKI secrets engine role which
+// determines whether Vault accepts a certificate request.
+type Role struct {
+	AllowAnyName              bool     `json:"allow_any_name"`
+	AllowedDomains            []string `json:"allowed_domains"`
+	AllowBareDomains          bool     `json:"allow_bare_domains"`
+	AllowSubdomains           bool     `json:"allow_subdomains"`
+	AllowGlobDomains          bool     `json:"allow_glob_domains"`
+	AllowWildcardCertificates *bool    `json:"allow_wildcard_certificates"`
+	AllowLocalhost            bool     `json:"allow_localhost"`
+	AllowIPSANs               bool     `json:"allow_ip_sans"`
+	AllowedURISANs            []string `json:"allowed_uri_sans"`
+	KeyType                   string   `json:"key_type"`
+	KeyBits                   int      `json:"key_bits"`
+}
+
 // Client implements functionality to talk to a Vault server.
 type Client interface {
 	NewRequest(method, requestPath string) *vault.Request
@@ -68,15 +125,54 @@ type Vault struct {
 	issuer        v1.GenericIssuer
 	namespace     string
 
+	// createToken requests ServiceAccount tokens in namespace. It is nil
+	// where requesting tokens is not supported.
+	createToken CreateToken
+
 	client Client
 }
 
 // New returns a new Vault instance with the given namespace, issuer and
-// secrets lister.
+// secrets lister. createTokenFn is used to request ServiceAccount tokens for
+// Kubernetes auth with a serviceAccountRef, and may be nil where this is not
+// supported.
+// Returned errors may be network failures and should be considered for
+// retrying.
+func New(namespace string, createTokenFn func(ns string) CreateToken, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
+	return newVault(namespace, createTokenFn, secretsLister, issuer)
+}
+
+// NewKV returns a KV for the Vault server of the given issuer. The issuer's
+// Path is not used.
 // Returned errors may be network failures and should be considered for
 // retrying.
-func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
+func NewKV(namespace string, createTokenFn func(ns string) CreateToken, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (KV, error) {
+	return newVault(namespace, createTokenFn, secretsLister, issuer)
+}
+
+// NewRoleReader returns a RoleReader for the role of the given issuer's Path.
+// Returned errors may be network failures and should be considered for
+// retrying.
+func NewRoleReader(namespace string, createTokenFn func(ns string) CreateToken, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (RoleReader, error) {
+	return newVault(namespace, createTokenFn, secretsLister, issuer)
+}
+
+// NewRevoker returns a Revoker for the PKI secrets engine mounted at the given
+// issuer's Path.
+// Returned errors may be network failures and should be considered for
+// retrying.
+func NewRevoker(namespace string, createTokenFn func(ns string) CreateToken, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Revoker, error) {
+	return newVault(namespace, createTokenFn, secretsLister, issuer)
+}
+
+func newVault(namespace string, createTokenFn func(ns string) CreateToken, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (*Vault, error) {
+	var createToken CreateToken
+	if createTokenFn != nil {
+		createToken = createTokenFn(namespace)
+	}
+
 	v := &Vault{
+		createToken:   createToken,
 		secretsLister: secretsLister,
 		namespace:     namespace,
 		issuer:        issuer,
@@ -86,12 +182,20 @@ func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.Gen
 	if err != nil {
 		return nil, err
 	}
+	cfg.HttpClient.Transport = util.IssuerHTTPClientRoundTripper(cfg.HttpClient.Transport, issuer.GetSpec().HTTPClient)
 
 	client, err := vault.NewClient(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing Vault client: %s", err.Error())
 	}
 
+	// The client replaces the namespace header of every request with its own
+	// namespace, so it must be set on the client for addVaultNamespaceToRequest
+	// to have any effect.
+	if ns := issuer.GetSpec().Vault.Namespace; ns != "" {
+		client.SetNamespace(ns)
+	}
+
 	if err := v.setToken(client); err != nil {
 		return nil, err
 	}
@@ -108,11 +212,18 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 		return nil, nil, fmt.Errorf("failed to decode CSR for signing: %s", err)
 	}
 
-	parameters := map[string]string{
+	// URI SANs are sent as a list rather than comma-separated, as URIs may
+	// themselves contain commas.
+	uriSANs := pki.URLsToString(csr.URIs)
+	if uriSANs == nil {
+		uriSANs = []string{}
+	}
+
+	parameters := map[string]interface{}{
 		"common_name": csr.Subject.CommonName,
 		"alt_names":   strings.Join(csr.DNSNames, ","),
 		"ip_sans":     strings.Join(pki.IPAddressesToString(csr.IPAddresses), ","),
-		"uri_sans":    strings.Join(pki.URLsToString(csr.URIs), ","),
+		"uri_sans":    uriSANs,
 		"ttl":         duration.String(),
 		"csr":         string(csrPEM),
 
@@ -146,6 +257,161 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 	return extractCertificatesFromVaultCertificateSecret(&vaultResult)
 }
 
+// ReadRole returns the configuration of the role which the issuer's Path signs
+// certificates with, or nil if the Path does not sign certificates with a
+// role, such as the `sign-verbatim` endpoint without a role.
+func (v *Vault) ReadRole() (*Role, error) {
+	rolePath := rolePathForSignPath(v.issuer.GetSpec().Vault.Path)
+	if rolePath == "" {
+		return nil, nil
+	}
+
+	request := v.client.NewRequest("GET", path.Join("/v1", rolePath))
+	v.addVaultNamespaceToRequest(request)
+
+	resp, err := v.client.RawRequest(request)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read role from vault: %w", err)
+	}
+
+	var result struct {
+		Data Role `json:"data"`
+	}
+	if err := resp.DecodeJSON(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response returned by vault: %w", err)
+	}
+
+	return &result.Data, nil
+}
+
+// rolePathForSignPath returns the path of the role used by the given PKI
+// signing path, which is one of `<mount>/sign/<role>`,
+// `<mount>/issuer/<issuer>/sign/<role>` or `<mount>/sign-verbatim/<role>`.
+// An empty string is returned if the path does not name a role.
+func rolePathForSignPath(signPath string) string {
+	segments := strings.Split(strings.Trim(signPath, "/"), "/")
+	n := len(segments)
+	if n < 3 {
+		return ""
+	}
+	if op := segments[n-2]; op != "sign" && op != "sign-verbatim" {
+		return ""
+	}
+	mount := segments[:n-2]
+	if len(mount) > 2 && mount[len(mount)-2] == "issuer" {
+		mount = mount[:len(mount)-2]
+	}
+	return path.Join(append(mount, "roles", segments[n-1])...)
+}
+
+// RevokeCertificate revokes the certificate with the given serial number, which
+// must have been issued by the PKI secrets engine of the issuer's Path.
+// Revoking a certificate which is already revoked succeeds.
+func (v *Vault) RevokeCertificate(serialNumber *big.Int) error {
+	mount := mountForSignPath(v.issuer.GetSpec().Vault.Path)
+	if mount == "" {
+		return fmt.Errorf("cannot find the PKI secrets engine mount of the vault path %q", v.issuer.GetSpec().Vault.Path)
+	}
+
+	request := v.client.NewRequest("POST", path.Join("/v1", mount, "revoke"))
+	v.addVaultNamespaceToRequest(request)
+
+	if err := request.SetJSONBody(map[string]interface{}{
+		"serial_number": certutil.GetHexFormatted(serialNumber.Bytes(), ":"),
+	}); err != nil {
+		return fmt.Errorf("failed to build vault request: %w", err)
+	}
+
+	resp, err := v.client.RawRequest(request)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to revoke certificate in vault: %w", err)
+	}
+
+	return nil
+}
+
+// mountForSignPath returns the mount of the PKI secrets engine of the given
+// signing path, which is one of `<mount>/sign/<role>`,
+// `<mount>/issuer/<issuer>/sign/<role>`, `<mount>/sign-verbatim` or
+// `<mount>/sign-verbatim/<role>`. An empty string is returned if the path is
+// not a signing path.
+func mountForSignPath(signPath string) string {
+	segments := strings.Split(strings.Trim(signPath, "/"), "/")
+	n := len(segments)
+	for i := n - 1; i >= 1 && i >= n-2; i-- {
+		if segments[i] != "sign" && segments[i] != "sign-verbatim" {
+			continue
+		}
+		if i == n-1 && segments[i] == "sign" {
+			// sign requires a role
+			return ""
+		}
+		mount := segments[:i]
+		if len(mount) > 2 && mount[len(mount)-2] == "issuer" {
+			mount = mount[:len(mount)-2]
+		}
+		return path.Join(mount...)
+	}
+	return ""
+}
+
+// ReadKV returns the data of the latest version of the secret at secretPath
+// of the KV version 2 secrets engine mounted at mount, or nil if the secret
+// does not exist or its latest version has been deleted.
+func (v *Vault) ReadKV(mount, secretPath string) (map[string]string, error) {
+	request := v.client.NewRequest("GET", path.Join("/v1", mount, "data", secretPath))
+	v.addVaultNamespaceToRequest(request)
+
+	resp, err := v.client.RawRequest(request)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret from vault: %w", err)
+	}
+
+	var result struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := resp.DecodeJSON(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response returned by vault: %w", err)
+	}
+
+	return result.Data.Data, nil
+}
+
+// WriteKV writes a new version of the secret at secretPath of the KV version 2
+// secrets engine mounted at mount.
+func (v *Vault) WriteKV(mount, secretPath string, data map[string]string) error {
+	request := v.client.NewRequest("POST", path.Join("/v1", mount, "data", secretPath))
+	v.addVaultNamespaceToRequest(request)
+
+	if err := request.SetJSONBody(map[string]interface{}{"data": data}); err != nil {
+		return fmt.Errorf("failed to build vault request: %w", err)
+	}
+
+	resp, err := v.client.RawRequest(request)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write secret to vault: %w", err)
+	}
+
+	return nil
+}
+
 func (v *Vault) setToken(client Client) error {
 	tokenRef := v.issuer.GetSpec().Vault.Auth.TokenSecretRef
 	if tokenRef != nil {
@@ -173,6 +439,9 @@ func (v *Vault) setToken(client Client) error {
 	if kubernetesAuth != nil {
 		token, err := v.requestTokenWithKubernetesAuth(client, kubernetesAuth)
 		if err != nil {
+			if kubernetesAuth.ServiceAccountRef != nil {
+				return fmt.Errorf("error requesting Kubernetes service account token for %s: %s", kubernetesAuth.ServiceAccountRef.Name, err.Error())
+			}
 			return fmt.Errorf("error reading Kubernetes service account token from %s: %s", kubernetesAuth.SecretRef.Name, err.Error())
 		}
 		client.SetToken(token)
@@ -339,23 +608,17 @@ func (v *Vault) requestTokenWithAppRoleRef(client Client, appRole *v1.VaultAppRo
 }
 
 func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1.VaultKubernetesAuth) (string, error) {
-	secret, err := v.secretsLister.Secrets(v.namespace).Get(kubernetesAuth.SecretRef.Name)
+	var jwt string
+	var err error
+	if kubernetesAuth.ServiceAccountRef != nil {
+		jwt, err = v.requestServiceAccountToken(kubernetesAuth.ServiceAccountRef)
+	} else {
+		jwt, err = v.kubernetesAuthSecretRef(kubernetesAuth.SecretRef)
+	}
 	if err != nil {
 		return "", err
 	}
 
-	key := kubernetesAuth.SecretRef.Key
-	if key == "" {
-		key = v1.DefaultVaultTokenAuthSecretKey
-	}
-
-	keyBytes, ok := secret.Data[key]
-	if !ok {
-		return "", fmt.Errorf("no data for %q in secret '%s/%s'", key, v.namespace, kubernetesAuth.SecretRef.Name)
-	}
-
-	jwt := string(keyBytes)
-
 	parameters := map[string]string{
 		"role": kubernetesAuth.Role,
 		"jwt":  jwt,
@@ -395,6 +658,70 @@ func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1
 	return token, nil
 }
 
+// kubernetesAuthSecretRef reads the ServiceAccount JWT stored in the given
+// Secret key.
+func (v *Vault) kubernetesAuthSecretRef(ref cmmeta.SecretKeySelector) (string, error) {
+	secret, err := v.secretsLister.Secrets(v.namespace).Get(ref.Name)
+	if err != nil {
+		return "", err
+	}
+
+	key := ref.Key
+	if key == "" {
+		key = v1.DefaultVaultTokenAuthSecretKey
+	}
+
+	keyBytes, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("no data for %q in secret '%s/%s'", key, v.namespace, ref.Name)
+	}
+
+	return string(keyBytes), nil
+}
+
+// serviceAccountTokenExpirationSeconds is the lifetime of the tokens requested
+// for a serviceAccountRef. A token is only used to log in to Vault once, so it
+// is requested with the shortest expiration the API server accepts.
+const serviceAccountTokenExpirationSeconds = 600
+
+// requestServiceAccountToken requests a token for the given ServiceAccount
+// with the TokenRequest API, bound to an audience which is unique to the
+// issuer so that the token cannot be replayed to log in as another issuer.
+// A new token is requested every time the Vault client logs in, so an expired
+// token is never presented.
+func (v *Vault) requestServiceAccountToken(ref *v1.ServiceAccountRef) (string, error) {
+	if v.createToken == nil {
+		return "", errors.New("requesting ServiceAccount tokens is not supported here, use secretRef instead")
+	}
+
+	audiences := append([]string{v.serviceAccountTokenAudience()}, ref.TokenAudiences...)
+	tokenRequest, err := v.createToken(context.TODO(), ref.Name, &authv1.TokenRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ref.Name,
+			Namespace: v.namespace,
+		},
+		Spec: authv1.TokenRequestSpec{
+			Audiences:         audiences,
+			ExpirationSeconds: pointer.Int64(serviceAccountTokenExpirationSeconds),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("while requesting a token for the service account %s/%s: %w", v.namespace, ref.Name, err)
+	}
+
+	return tokenRequest.Status.Token, nil
+}
+
+// serviceAccountTokenAudience returns the audience of the tokens requested for
+// a serviceAccountRef: `vault://<namespace>/<name>` for an Issuer and
+// `vault://<name>` for a ClusterIssuer.
+func (v *Vault) serviceAccountTokenAudience() string {
+	if ns := v.issuer.GetObjectMeta().Namespace; ns != "" {
+		return fmt.Sprintf("vault://%s/%s", ns, v.issuer.GetObjectMeta().Name)
+	}
+	return fmt.Sprintf("vault://%s", v.issuer.GetObjectMeta().Name)
+}
+
 func (v *Vault) Sys() *vault.Sys {
 	return v.client.Sys()
 }
@@ -18,13 +18,18 @@ package vault
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -32,7 +37,10 @@ import (
 	vault "github.com/hashicorp/vault/api"
 	"github.com/hashicorp/vault/sdk/helper/certutil"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
+	"github.com/stretchr/testify/assert"
+	authv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientcorev1 "k8s.io/client-go/listers/core/v1"
 
 	vaultfake "github.com/cert-manager/cert-manager/internal/vault/fake"
@@ -325,6 +333,69 @@ type testExtractCertificatesFromVaultCertT struct {
 	expectedCA   string
 }
 
+func TestSignURISANs(t *testing.T) {
+	uris := []string{"spiffe://cluster.local/ns/default/sa/foo", "https://example.com/a,b"}
+	csrPEM, err := gen.CSRWithSigner(generateRSAPrivateKey(t), gen.SetCSRURIsFromStrings(uris...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var parameters struct {
+		URISANs []string `json:"uri_sans"`
+	}
+	client := vaultfake.NewFakeClient()
+	client.RawRequestFn = func(r *vault.Request) (*vault.Response, error) {
+		if err := jsonutil.DecodeJSON(r.BodyBytes, &parameters); err != nil {
+			t.Fatal(err)
+		}
+		return nil, errors.New("request failed")
+	}
+	v := &Vault{
+		issuer: gen.Issuer("vault-issuer", gen.SetIssuerVault(cmapi.VaultIssuer{})),
+		client: client,
+	}
+
+	if _, _, err := v.Sign(csrPEM, time.Minute); err == nil {
+		t.Fatal("expected the failed request to return an error")
+	}
+	if !reflect.DeepEqual(uris, parameters.URISANs) {
+		t.Errorf("unexpected uri_sans, exp=%v got=%v", uris, parameters.URISANs)
+	}
+}
+
+func TestSignEd25519(t *testing.T) {
+	privateKey, err := pki.GenerateEd25519PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM, err := gen.CSRWithSigner(privateKey, gen.SetCSRCommonName("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var parameters struct {
+		CSR string `json:"csr"`
+	}
+	client := vaultfake.NewFakeClient()
+	client.RawRequestFn = func(r *vault.Request) (*vault.Response, error) {
+		if err := jsonutil.DecodeJSON(r.BodyBytes, &parameters); err != nil {
+			t.Fatal(err)
+		}
+		return nil, errors.New("request failed")
+	}
+	v := &Vault{
+		issuer: gen.Issuer("vault-issuer", gen.SetIssuerVault(cmapi.VaultIssuer{})),
+		client: client,
+	}
+
+	if _, _, err := v.Sign(csrPEM, time.Minute); err == nil {
+		t.Fatal("expected the failed request to return an error")
+	}
+	if parameters.CSR != string(csrPEM) {
+		t.Errorf("expected the Ed25519 CSR to be sent to vault unchanged, exp=%s got=%s", csrPEM, parameters.CSR)
+	}
+}
+
 func TestExtractCertificatesFromVaultCertificateSecret(t *testing.T) {
 	tests := map[string]testExtractCertificatesFromVaultCertT{
 		"when a Vault engine is a root CA": {
@@ -665,6 +736,70 @@ func TestSetToken(t *testing.T) {
 	}
 }
 
+func TestRequestServiceAccountToken(t *testing.T) {
+	serviceAccountRef := &cmapi.ServiceAccountRef{Name: "vault-auth", TokenAudiences: []string{"https://vault.example.com"}}
+
+	tests := map[string]struct {
+		issuer       cmapi.GenericIssuer
+		createToken  CreateToken
+		expAudiences []string
+		expToken     string
+		expErr       string
+	}{
+		"an Issuer requests a token bound to its namespace and name": {
+			issuer:       gen.Issuer("vault-issuer", gen.SetIssuerNamespace("issuer-namespace")),
+			expAudiences: []string{"vault://issuer-namespace/vault-issuer", "https://vault.example.com"},
+			expToken:     "minted-token",
+		},
+		"a ClusterIssuer requests a token bound to its name": {
+			issuer:       gen.ClusterIssuer("vault-issuer"),
+			expAudiences: []string{"vault://vault-issuer", "https://vault.example.com"},
+			expToken:     "minted-token",
+		},
+		"requesting the token fails": {
+			issuer: gen.Issuer("vault-issuer", gen.SetIssuerNamespace("issuer-namespace")),
+			createToken: func(context.Context, string, *authv1.TokenRequest, metav1.CreateOptions) (*authv1.TokenRequest, error) {
+				return nil, errors.New("forbidden")
+			},
+			expErr: "while requesting a token for the service account test-namespace/vault-auth: forbidden",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			createToken := test.createToken
+			if createToken == nil {
+				createToken = func(_ context.Context, saName string, req *authv1.TokenRequest, _ metav1.CreateOptions) (*authv1.TokenRequest, error) {
+					assert.Equal(t, "vault-auth", saName)
+					assert.Equal(t, "test-namespace", req.Namespace)
+					assert.Equal(t, test.expAudiences, req.Spec.Audiences)
+					assert.Equal(t, int64(600), *req.Spec.ExpirationSeconds)
+					return &authv1.TokenRequest{Status: authv1.TokenRequestStatus{Token: "minted-token"}}, nil
+				}
+			}
+			v := &Vault{
+				namespace:   "test-namespace",
+				issuer:      test.issuer,
+				createToken: createToken,
+			}
+
+			token, err := v.requestServiceAccountToken(serviceAccountRef)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expToken, token)
+		})
+	}
+}
+
+func TestRequestServiceAccountTokenNotSupported(t *testing.T) {
+	v := &Vault{namespace: "test-namespace", issuer: gen.Issuer("vault-issuer")}
+	_, err := v.requestServiceAccountToken(&cmapi.ServiceAccountRef{Name: "vault-auth"})
+	assert.EqualError(t, err, "requesting ServiceAccount tokens is not supported here, use secretRef instead")
+}
+
 type testAppRoleRefT struct {
 	expectedRoleID   string
 	expectedSecretID string
@@ -1169,3 +1304,232 @@ func TestRequestTokenWithAppRoleRef(t *testing.T) {
 		})
 	}
 }
+
+func TestKV(t *testing.T) {
+	stored := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/data/my-app/tls" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got := r.Header.Get("X-Vault-Namespace"); got != "ns1" {
+			t.Errorf("unexpected vault namespace %q", got)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"data":{"data":{"tls.crt":%q}}}`, stored["tls.crt"])
+		case http.MethodPut, http.MethodPost:
+			var body struct {
+				Data map[string]string `json:"data"`
+			}
+			if err := jsonutil.DecodeJSONFromReader(r.Body, &body); err != nil {
+				t.Errorf("failed to decode request: %s", err)
+			}
+			stored = body.Data
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	secretsLister := listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+		listers.SetFakeSecretNamespaceListerGet(&corev1.Secret{Data: map[string][]byte{"token": []byte("my-token")}}, nil),
+	)
+	v, err := NewKV("test-namespace", nil, secretsLister, gen.Issuer("vault-issuer", gen.SetIssuerVault(cmapi.VaultIssuer{
+		Server:    server.URL,
+		Namespace: "ns1",
+		Auth: cmapi.VaultAuth{
+			TokenSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "vault-token"}, Key: "token"},
+		},
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.WriteKV("secret", "my-app/tls", map[string]string{"tls.crt": "cert"}); err != nil {
+		t.Fatalf("unexpected error writing secret: %s", err)
+	}
+	data, err := v.ReadKV("secret", "my-app/tls")
+	if err != nil {
+		t.Fatalf("unexpected error reading secret: %s", err)
+	}
+	if data["tls.crt"] != "cert" {
+		t.Errorf("unexpected data read, exp=%q got=%q", "cert", data["tls.crt"])
+	}
+
+	data, err = v.ReadKV("secret", "other")
+	if err != nil || data != nil {
+		t.Errorf("expected missing secret to be read as nil, got data=%v err=%v", data, err)
+	}
+}
+
+func TestReadRole(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/pki_int/roles/example-dot-com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":{"allowed_domains":["example.com"],"allow_subdomains":true,"allow_ip_sans":false,"key_type":"rsa","key_bits":2048}}`)
+	}))
+	defer server.Close()
+
+	secretsLister := listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+		listers.SetFakeSecretNamespaceListerGet(&corev1.Secret{Data: map[string][]byte{"token": []byte("my-token")}}, nil),
+	)
+	newRoleReader := func(path string) RoleReader {
+		v, err := NewRoleReader("test-namespace", nil, secretsLister, gen.Issuer("vault-issuer", gen.SetIssuerVault(cmapi.VaultIssuer{
+			Server: server.URL,
+			Path:   path,
+			Auth: cmapi.VaultAuth{
+				TokenSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "vault-token"}, Key: "token"},
+			},
+		})))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	role, err := newRoleReader("pki_int/issuer/default/sign/example-dot-com").ReadRole()
+	if err != nil {
+		t.Fatalf("unexpected error reading role: %s", err)
+	}
+	exp := &Role{
+		AllowedDomains:  []string{"example.com"},
+		AllowSubdomains: true,
+		KeyType:         "rsa",
+		KeyBits:         2048,
+	}
+	if !reflect.DeepEqual(role, exp) {
+		t.Errorf("unexpected role, exp=%+v got=%+v", exp, role)
+	}
+
+	role, err = newRoleReader("pki_int/sign-verbatim").ReadRole()
+	if err != nil || role != nil {
+		t.Errorf("expected no role for sign-verbatim, got role=%+v err=%v", role, err)
+	}
+
+	if _, err := newRoleReader("pki_int/sign/other").ReadRole(); err == nil {
+		t.Errorf("expected error reading missing role")
+	}
+}
+
+func TestIssuerHTTPClient(t *testing.T) {
+	var userAgent, traceID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent, traceID = r.Header.Get("User-Agent"), r.Header.Get("X-Trace-Id")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	secretsLister := listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+		listers.SetFakeSecretNamespaceListerGet(&corev1.Secret{Data: map[string][]byte{"token": []byte("my-token")}}, nil),
+	)
+	v, err := NewRoleReader("test-namespace", nil, secretsLister, gen.Issuer("vault-issuer",
+		gen.SetIssuerVault(cmapi.VaultIssuer{
+			Server: server.URL,
+			Path:   "pki_int/sign/example-dot-com",
+			Auth: cmapi.VaultAuth{
+				TokenSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "vault-token"}, Key: "token"},
+			},
+		}),
+		gen.SetIssuerHTTPClient(cmapi.IssuerHTTPClient{
+			UserAgentSuffix: "team-a/1.0",
+			Headers:         []cmapi.HTTPHeader{{Name: "X-Trace-Id", Value: "abc"}},
+		}),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the role is missing, but the request has been sent
+	_, _ = v.ReadRole()
+
+	if !strings.HasSuffix(userAgent, " team-a/1.0") {
+		t.Errorf("expected User-Agent with suffix 'team-a/1.0', got %q", userAgent)
+	}
+	if traceID != "abc" {
+		t.Errorf("expected X-Trace-Id header 'abc', got %q", traceID)
+	}
+}
+
+func TestRolePathForSignPath(t *testing.T) {
+	tests := map[string]string{
+		"pki/sign/my-role":                       "pki/roles/my-role",
+		"/pki/sign/my-role/":                     "pki/roles/my-role",
+		"nested/pki/sign/my-role":                "nested/pki/roles/my-role",
+		"pki/issuer/my-issuer/sign/my-role":      "pki/roles/my-role",
+		"pki/sign-verbatim/my-role":              "pki/roles/my-role",
+		"pki/issuer/default/sign-verbatim/role1": "pki/roles/role1",
+		"pki/sign-verbatim":                      "",
+		"pki/issue/my-role":                      "",
+		"my-role":                                "",
+	}
+	for signPath, exp := range tests {
+		if got := rolePathForSignPath(signPath); got != exp {
+			t.Errorf("unexpected role path for %q, exp=%q got=%q", signPath, exp, got)
+		}
+	}
+}
+
+func TestRevokeCertificate(t *testing.T) {
+	var gotPath, gotSerial string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			SerialNumber string `json:"serial_number"`
+		}
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&body) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		gotPath, gotSerial = r.URL.Path, body.SerialNumber
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":{"revocation_time":1667260800}}`)
+	}))
+	defer server.Close()
+
+	secretsLister := listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+		listers.SetFakeSecretNamespaceListerGet(&corev1.Secret{Data: map[string][]byte{"token": []byte("my-token")}}, nil),
+	)
+	v, err := NewRevoker("test-namespace", nil, secretsLister, gen.Issuer("vault-issuer", gen.SetIssuerVault(cmapi.VaultIssuer{
+		Server: server.URL,
+		Path:   "pki_int/issuer/default/sign/example-dot-com",
+		Auth: cmapi.VaultAuth{
+			TokenSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "vault-token"}, Key: "token"},
+		},
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.RevokeCertificate(big.NewInt(0x1a2b3c)); err != nil {
+		t.Fatalf("unexpected error revoking certificate: %s", err)
+	}
+	if gotPath != "/v1/pki_int/revoke" {
+		t.Errorf("unexpected request path %q", gotPath)
+	}
+	if gotSerial != "1a:2b:3c" {
+		t.Errorf("unexpected serial number %q", gotSerial)
+	}
+}
+
+func TestMountForSignPath(t *testing.T) {
+	tests := map[string]string{
+		"pki/sign/my-role":                       "pki",
+		"/pki/sign/my-role/":                     "pki",
+		"nested/pki/sign/my-role":                "nested/pki",
+		"pki/issuer/my-issuer/sign/my-role":      "pki",
+		"pki/sign-verbatim/my-role":              "pki",
+		"pki/issuer/default/sign-verbatim/role1": "pki",
+		"pki/sign-verbatim":                      "pki",
+		"pki/issuer/default/sign-verbatim":       "pki",
+		"pki/sign":                               "",
+		"pki/issue/my-role":                      "",
+		"my-role":                                "",
+	}
+	for signPath, exp := range tests {
+		if got := mountForSignPath(signPath); got != exp {
+			t.Errorf("unexpected mount for %q, exp=%q got=%q", signPath, exp, got)
+		}
+	}
+}
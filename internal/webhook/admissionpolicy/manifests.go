@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admissionpolicy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+)
+
+// The admissionregistration.k8s.io/v1 ValidatingAdmissionPolicy types are not
+// available in the version of k8s.io/api used by cert-manager, so the fields
+// which are generated are declared here.
+
+type manifest struct {
+	APIVersion string      `json:"apiVersion"`
+	Kind       string      `json:"kind"`
+	Metadata   metadata    `json:"metadata"`
+	Spec       interface{} `json:"spec"`
+}
+
+type metadata struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+}
+
+type policySpec struct {
+	FailurePolicy    string             `json:"failurePolicy"`
+	MatchConstraints matchResources     `json:"matchConstraints"`
+	Validations      []policyValidation `json:"validations"`
+}
+
+type matchResources struct {
+	NamespaceSelector *labelSelector `json:"namespaceSelector,omitempty"`
+	ResourceRules     []resourceRule `json:"resourceRules,omitempty"`
+}
+
+type resourceRule struct {
+	APIGroups   []string `json:"apiGroups"`
+	APIVersions []string `json:"apiVersions"`
+	Operations  []string `json:"operations"`
+	Resources   []string `json:"resources"`
+}
+
+type policyValidation struct {
+	Expression string `json:"expression"`
+	Message    string `json:"message"`
+}
+
+type bindingSpec struct {
+	PolicyName        string         `json:"policyName"`
+	ValidationActions []string       `json:"validationActions"`
+	MatchResources    matchResources `json:"matchResources"`
+}
+
+type labelSelector struct {
+	MatchExpressions []labelSelectorRequirement `json:"matchExpressions"`
+}
+
+type labelSelectorRequirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
+}
+
+const (
+	// chartCondition is the Helm chart value which enables installing the
+	// policies.
+	chartCondition = ".Values.webhook.validatingAdmissionPolicies.enabled"
+
+	webhookName = `{{ include "webhook.fullname" . }}`
+)
+
+var labels = map[string]string{
+	"app":                          `{{ include "webhook.name" . }}`,
+	"app.kubernetes.io/name":       `{{ include "webhook.name" . }}`,
+	"app.kubernetes.io/instance":   `{{ .Release.Name }}`,
+	"app.kubernetes.io/component":  "webhook",
+	"app.kubernetes.io/managed-by": `{{ .Release.Service }}`,
+}
+
+// namespaceSelector matches the same namespaces as the validating webhook.
+var namespaceSelector = &labelSelector{
+	MatchExpressions: []labelSelectorRequirement{
+		{Key: "cert-manager.io/disable-validation", Operator: "NotIn", Values: []string{"true"}},
+		{Key: "name", Operator: "NotIn", Values: []string{`{{ include "cert-manager.namespace" . }}`}},
+	},
+}
+
+// Manifests writes a Helm chart template which installs a
+// ValidatingAdmissionPolicy and ValidatingAdmissionPolicyBinding for each of
+// the Policies.
+func Manifests(w io.Writer) error {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "# Code generated by hack/genadmissionpolicies. DO NOT EDIT.\n")
+	fmt.Fprintf(buf, "{{- if %s }}\n", chartCondition)
+	for _, policy := range Policies {
+		name := webhookName + "-" + policy.Name
+
+		rule := resourceRule{
+			Operations: []string{"CREATE", "UPDATE"},
+		}
+		for _, gvr := range policy.Resources {
+			rule.APIGroups = appendUnique(rule.APIGroups, gvr.Group)
+			rule.APIVersions = appendUnique(rule.APIVersions, gvr.Version)
+			rule.Resources = appendUnique(rule.Resources, gvr.Resource)
+		}
+		spec := policySpec{
+			FailurePolicy:    "Fail",
+			MatchConstraints: matchResources{ResourceRules: []resourceRule{rule}},
+		}
+		for _, validation := range policy.Validations {
+			spec.Validations = append(spec.Validations, policyValidation{
+				Expression: validation.Expression,
+				Message:    validation.Message,
+			})
+		}
+
+		docs := []manifest{
+			{
+				APIVersion: "admissionregistration.k8s.io/v1",
+				Kind:       "ValidatingAdmissionPolicy",
+				Metadata:   metadata{Name: name, Labels: labels},
+				Spec:       spec,
+			},
+			{
+				APIVersion: "admissionregistration.k8s.io/v1",
+				Kind:       "ValidatingAdmissionPolicyBinding",
+				Metadata:   metadata{Name: name, Labels: labels},
+				Spec: bindingSpec{
+					PolicyName:        name,
+					ValidationActions: []string{"Deny"},
+					MatchResources:    matchResources{NamespaceSelector: namespaceSelector},
+				},
+			},
+		}
+		for _, doc := range docs {
+			data, err := yaml.Marshal(doc)
+			if err != nil {
+				return fmt.Errorf("failed to marshal %s %s: %w", doc.Kind, policy.Name, err)
+			}
+			fmt.Fprintf(buf, "---\n%s", data)
+		}
+	}
+	fmt.Fprintf(buf, "{{- end }}\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, item := range list {
+		if item == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package admissionpolicy defines ValidatingAdmissionPolicies which use CEL
+// to perform a subset of the checks made by the cert-manager webhook. When
+// the policies are installed, the API server keeps enforcing those checks
+// while the webhook is unavailable, and the webhook can be configured to
+// skip them.
+package admissionpolicy
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// Policy is a ValidatingAdmissionPolicy applying to one or more cert-manager
+// resources.
+type Policy struct {
+	// Name of the policy, which is prefixed with the name of the webhook
+	// when installed.
+	Name string
+	// Resources are the resources the policy applies to.
+	Resources []schema.GroupVersionResource
+	// Validations are the checks made by the policy.
+	Validations []Validation
+}
+
+// Validation is a single CEL check made by a policy.
+type Validation struct {
+	// Expression must evaluate to true for the resource to be admitted.
+	Expression string
+	// Message is returned to the user when Expression evaluates to false.
+	Message string
+	// Covers are the errors returned by the webhook which Expression
+	// rejects. The webhook may skip returning these errors when the
+	// policies are installed.
+	Covers []CoveredError
+}
+
+// CoveredError identifies an error returned by the webhook by its type and
+// field path, e.g. 'spec.secretName'.
+type CoveredError struct {
+	Type  field.ErrorType
+	Field string
+}
+
+var (
+	certificateGVR        = cmapi.SchemeGroupVersion.WithResource("certificates")
+	certificateRequestGVR = cmapi.SchemeGroupVersion.WithResource("certificaterequests")
+	issuerGVR             = cmapi.SchemeGroupVersion.WithResource("issuers")
+	clusterIssuerGVR      = cmapi.SchemeGroupVersion.WithResource("clusterissuers")
+)
+
+// issuerRefValidations are the checks made on the issuerRef of Certificates
+// and CertificateRequests.
+var issuerRefValidations = []Validation{
+	{
+		Expression: "object.spec.issuerRef.name != ''",
+		Message:    "spec.issuerRef.name must be specified",
+		Covers:     []CoveredError{{field.ErrorTypeRequired, "spec.issuerRef.name"}},
+	},
+	{
+		Expression: "(has(object.spec.issuerRef.group) && !(object.spec.issuerRef.group in ['', 'cert-manager.io'])) || " +
+			"!has(object.spec.issuerRef.kind) || object.spec.issuerRef.kind in ['', 'Issuer', 'ClusterIssuer', 'IssuerAlias']",
+		Message: "spec.issuerRef.kind must be one of Issuer, ClusterIssuer or IssuerAlias",
+		Covers:  []CoveredError{{field.ErrorTypeInvalid, "spec.issuerRef.kind"}},
+	},
+}
+
+// Policies are the policies generated into the Helm chart by
+// hack/genadmissionpolicies.
+var Policies = []Policy{
+	{
+		Name:      "certificates",
+		Resources: []schema.GroupVersionResource{certificateGVR},
+		Validations: append([]Validation{
+			{
+				Expression: "object.spec.secretName != ''",
+				Message:    "spec.secretName must be specified",
+				Covers:     []CoveredError{{field.ErrorTypeRequired, "spec.secretName"}},
+			},
+			{
+				Expression: "!has(object.spec.revisionHistoryLimit) || object.spec.revisionHistoryLimit >= 1",
+				Message:    "spec.revisionHistoryLimit must not be less than 1",
+				Covers:     []CoveredError{{field.ErrorTypeInvalid, "spec.revisionHistoryLimit"}},
+			},
+			{
+				Expression: "!has(object.spec.privateKey) || !has(object.spec.privateKey.size) || object.spec.privateKey.size == 0 || " +
+					"(has(object.spec.privateKey.algorithm) && !(object.spec.privateKey.algorithm in ['', 'RSA'])) || " +
+					"(object.spec.privateKey.size >= 2048 && object.spec.privateKey.size <= 8192)",
+				Message: "spec.privateKey.size must be between 2048 & 8192 for rsa keyAlgorithm",
+				Covers:  []CoveredError{{field.ErrorTypeInvalid, "spec.privateKey.size"}},
+			},
+			{
+				Expression: "!has(object.spec.privateKey) || !has(object.spec.privateKey.size) || object.spec.privateKey.size == 0 || " +
+					"!has(object.spec.privateKey.algorithm) || object.spec.privateKey.algorithm != 'ECDSA' || " +
+					"object.spec.privateKey.size in [256, 384, 521]",
+				Message: "spec.privateKey.size must be one of 256, 384 or 521 for ecdsa keyAlgorithm",
+				Covers:  []CoveredError{{field.ErrorTypeNotSupported, "spec.privateKey.size"}},
+			},
+			{
+				Expression: fmt.Sprintf("!has(object.spec.duration) || duration(object.spec.duration) >= duration('%s')", cmapi.MinimumCertificateDuration),
+				Message:    fmt.Sprintf("spec.duration must be greater than %s", cmapi.MinimumCertificateDuration),
+				Covers:     []CoveredError{{field.ErrorTypeInvalid, "spec.duration"}},
+			},
+			{
+				Expression: fmt.Sprintf("!has(object.spec.renewBefore) || (duration(object.spec.renewBefore) >= duration('%s') && "+
+					"duration(object.spec.renewBefore) < (has(object.spec.duration) ? duration(object.spec.duration) : duration('%s')))",
+					cmapi.MinimumRenewBefore, cmapi.DefaultCertificateDuration),
+				Message: fmt.Sprintf("spec.renewBefore must be greater than %s and less than spec.duration", cmapi.MinimumRenewBefore),
+				Covers:  []CoveredError{{field.ErrorTypeInvalid, "spec.renewBefore"}},
+			},
+		}, issuerRefValidations...),
+	},
+	{
+		Name:      "certificaterequests",
+		Resources: []schema.GroupVersionResource{certificateRequestGVR},
+		Validations: append([]Validation{
+			{
+				Expression: "(has(object.spec.request) && size(object.spec.request) > 0) || has(object.spec.requestSecretRef)",
+				Message:    "spec.request or spec.requestSecretRef must be specified",
+				Covers:     []CoveredError{{field.ErrorTypeRequired, "spec.request"}},
+			},
+			// The spec of a CertificateRequest, including the identity of
+			// the requester, is what gets approved and signed, so the
+			// webhook keeps enforcing its immutability even when policy
+			// covered checks are disabled. The policy only adds a second
+			// line of defence.
+			{
+				Expression: "request.operation != 'UPDATE' || object.spec == oldObject.spec",
+				Message:    "spec cannot be changed after creation",
+			},
+		}, issuerRefValidations...),
+	},
+	{
+		Name:      "issuers",
+		Resources: []schema.GroupVersionResource{issuerGVR, clusterIssuerGVR},
+		Validations: []Validation{
+			{
+				Expression: "has(object.spec.acme) || has(object.spec.ca) || has(object.spec.selfSigned) || has(object.spec.vault) || has(object.spec.venafi)",
+				Message:    "at least one issuer must be configured",
+				Covers:     []CoveredError{{field.ErrorTypeRequired, "spec"}},
+			},
+			{
+				Expression: "[has(object.spec.acme), has(object.spec.ca), has(object.spec.selfSigned), has(object.spec.vault), has(object.spec.venafi)].filter(c, c).size() <= 1",
+				Message:    "may not specify more than one issuer type",
+				Covers: []CoveredError{
+					{field.ErrorTypeForbidden, "spec.ca"},
+					{field.ErrorTypeForbidden, "spec.selfSigned"},
+					{field.ErrorTypeForbidden, "spec.vault"},
+					{field.ErrorTypeForbidden, "spec.venafi"},
+				},
+			},
+			{
+				Expression: "!has(object.spec.acme) || object.spec.acme.server != ''",
+				Message:    "spec.acme.server must be specified",
+				Covers:     []CoveredError{{field.ErrorTypeRequired, "spec.acme.server"}},
+			},
+			{
+				Expression: "!has(object.spec.acme) || object.spec.acme.privateKeySecretRef.name != ''",
+				Message:    "spec.acme.privateKeySecretRef.name must be specified",
+				Covers:     []CoveredError{{field.ErrorTypeRequired, "spec.acme.privateKeySecretRef.name"}},
+			},
+		},
+	},
+}
+
+// isSecurityInvariant returns true if err is returned by the webhook to
+// enforce a security invariant: the immutability of the spec and annotations
+// of CertificateRequests, which is what gets approved and signed, and the
+// rules on their approval conditions. The webhook always enforces these, so
+// they must never be covered by a policy, which could be removed or fail to
+// be installed.
+func isSecurityInvariant(gvr schema.GroupVersionResource, err CoveredError) bool {
+	if gvr != certificateRequestGVR {
+		return false
+	}
+	return (err.Type == field.ErrorTypeForbidden && (err.Field == "spec" || strings.HasPrefix(err.Field, "metadata.annotations"))) ||
+		err.Field == "status.conditions" || strings.HasPrefix(err.Field, "status.conditions.")
+}
+
+// covered indexes the errors covered by Policies by resource.
+var covered = func() map[schema.GroupVersionResource]map[CoveredError]bool {
+	covered := make(map[schema.GroupVersionResource]map[CoveredError]bool)
+	for _, policy := range Policies {
+		for _, gvr := range policy.Resources {
+			if covered[gvr] == nil {
+				covered[gvr] = make(map[CoveredError]bool)
+			}
+			for _, validation := range policy.Validations {
+				for _, err := range validation.Covers {
+					if isSecurityInvariant(gvr, err) {
+						panic(fmt.Sprintf("policy %q must not cover the security invariant %s %s", policy.Name, err.Type, err.Field))
+					}
+					covered[gvr][err] = true
+				}
+			}
+		}
+	}
+	return covered
+}()
+
+// Covers returns true if err, returned by the webhook when validating the
+// given resource, is also returned by one of the Policies. Errors enforcing
+// security invariants are never covered.
+func Covers(gvr schema.GroupVersionResource, err *field.Error) bool {
+	coveredErr := CoveredError{Type: err.Type, Field: err.Field}
+	return !isSecurityInvariant(gvr, coveredErr) && covered[gvr][coveredErr]
+}
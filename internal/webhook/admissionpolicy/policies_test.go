@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admissionpolicy
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestManifestsUpToDate(t *testing.T) {
+	const path = "../../../deploy/charts/cert-manager/templates/webhook-validating-admission-policies.yaml"
+
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if err := Manifests(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(existing, buf.Bytes()) {
+		t.Errorf("%s is out of date, run 'make update-admission-policies'", path)
+	}
+}
+
+func TestCovers(t *testing.T) {
+	tests := map[string]struct {
+		err *field.Error
+		exp bool
+	}{
+		"error covered by a policy": {
+			err: field.Required(field.NewPath("spec", "secretName"), "must be specified"),
+			exp: true,
+		},
+		"error with a different type is not covered": {
+			err: field.Invalid(field.NewPath("spec", "secretName"), "", "is invalid"),
+			exp: false,
+		},
+		"error covered by the policy of another resource is not covered": {
+			err: field.Required(field.NewPath("spec", "acme", "server"), "acme server URL is a required field"),
+			exp: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Covers(certificateGVR, test.err); got != test.exp {
+				t.Errorf("Covers() = %t, expected %t", got, test.exp)
+			}
+		})
+	}
+
+	for _, err := range []*field.Error{
+		field.Forbidden(field.NewPath("spec"), "cannot change spec after creation"),
+		field.Forbidden(field.NewPath("metadata", "annotations", "cert-manager.io/certificate-name"), "cannot change cert-manager annotation after creation"),
+		field.Forbidden(field.NewPath("status", "conditions"), "'Approved' condition may not be modified once set"),
+		field.Invalid(field.NewPath("status", "conditions", "Approved"), "False", "must be True"),
+	} {
+		if Covers(certificateRequestGVR, err) {
+			t.Errorf("expected the security invariant %q to never be covered by a policy", err)
+		}
+	}
+
+	if !Covers(clusterIssuerGVR, field.Forbidden(field.NewPath("spec", "vault"), "may not specify more than one issuer type")) {
+		t.Errorf("expected the issuers policy to cover ClusterIssuers")
+	}
+}
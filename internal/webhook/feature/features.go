@@ -43,6 +43,39 @@ const (
 	// This feature gate must be used together with LiteralCertificateSubject webhook feature gate.
 	// See https://github.com/cert-manager/cert-manager/issues/3203 and https://github.com/cert-manager/cert-manager/issues/4424 for context.
 	LiteralCertificateSubject featuregate.Feature = "LiteralCertificateSubject"
+
+	// Alpha: v1.11
+	// IssuerDeepValidation will make the webhook check that Issuers and
+	// ClusterIssuers can connect and authenticate to their ACME, Vault or
+	// Venafi server when they are created or updated, and reject them with the
+	// error returned by the server if they cannot.
+	IssuerDeepValidation featuregate.Feature = "IssuerDeepValidation"
+
+	// Alpha: v1.11
+	// ExternalSecretStores will allow Certificates to set `spec.secretStores`.
+	// This feature gate must be used together with the ExternalSecretStores controller feature gate.
+	ExternalSecretStores featuregate.Feature = "ExternalSecretStores"
+
+	// Alpha: v1.11
+	// FIPS will make the webhook reject Certificates which use Ed25519
+	// private keys, and CertificateRequests whose CSR uses a public key or
+	// signature algorithm which is not approved for FIPS 140-3: DSA or
+	// Ed25519 keys, RSA keys smaller than 2048 bits, ECDSA keys smaller than
+	// 256 bits, or MD5 or SHA-1 signatures.
+	// It should be used with binaries built with GOEXPERIMENT=boringcrypto, so
+	// that cryptographic operations use a FIPS validated module.
+	FIPS featuregate.Feature = "FIPS"
+
+	// Alpha: v1.11
+	// ExperimentalCompositeKeys will allow Certificates to use the
+	// `MLDSA65-ECDSA-P256` composite private key algorithm.
+	// This feature gate must be used together with the ExperimentalCompositeKeys controller feature gate.
+	ExperimentalCompositeKeys featuregate.Feature = "ExperimentalCompositeKeys"
+
+	// Alpha: v1.11
+	// AdditionalPrivateKeys will allow Certificates to set `spec.additionalPrivateKeys`.
+	// This feature gate must be used together with the AdditionalPrivateKeys controller feature gate.
+	AdditionalPrivateKeys featuregate.Feature = "AdditionalPrivateKeys"
 )
 
 func init() {
@@ -59,4 +92,9 @@ func init() {
 var webhookFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	AdditionalCertificateOutputFormats: {Default: false, PreRelease: featuregate.Alpha},
 	LiteralCertificateSubject:          {Default: false, PreRelease: featuregate.Alpha},
+	IssuerDeepValidation:               {Default: false, PreRelease: featuregate.Alpha},
+	ExternalSecretStores:               {Default: false, PreRelease: featuregate.Alpha},
+	FIPS:                               {Default: false, PreRelease: featuregate.Alpha},
+	ExperimentalCompositeKeys:          {Default: false, PreRelease: featuregate.Alpha},
+	AdditionalPrivateKeys:              {Default: false, PreRelease: featuregate.Alpha},
 }
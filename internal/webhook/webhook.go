@@ -33,7 +33,9 @@ import (
 	config "github.com/cert-manager/cert-manager/internal/apis/config/webhook"
 	metainstall "github.com/cert-manager/cert-manager/internal/apis/meta/install"
 	"github.com/cert-manager/cert-manager/internal/plugin"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
 	"github.com/cert-manager/cert-manager/pkg/webhook/authority"
@@ -65,8 +67,13 @@ func NewCertManagerWebhookServer(log logr.Logger, _ options.WebhookFlags, opts c
 		return nil, fmt.Errorf("error creating kubernetes client: %s", err)
 	}
 
+	cmClient, err := cmclient.NewForConfig(restcfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cert-manager client: %s", err)
+	}
+
 	// Set up the admission chain
-	admissionHandler, err := buildAdmissionChain(cl)
+	admissionHandler, err := buildAdmissionChain(cl, cmClient, opts.ClusterResourceNamespace, opts.DisablePolicyCoveredChecks)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +96,7 @@ func NewCertManagerWebhookServer(log logr.Logger, _ options.WebhookFlags, opts c
 	return s, nil
 }
 
-func buildAdmissionChain(client kubernetes.Interface) (*admission.RequestHandler, error) {
+func buildAdmissionChain(client kubernetes.Interface, cmClient cmclient.Interface, clusterResourceNamespace string, disablePolicyCoveredChecks bool) (*admission.RequestHandler, error) {
 	// Set up the admission chain
 	pluginHandler := admission.NewPlugins(Scheme)
 	plugin.RegisterAllPlugins(pluginHandler)
@@ -109,8 +116,8 @@ func buildAdmissionChain(client kubernetes.Interface) (*admission.RequestHandler
 	if err != nil {
 		return nil, fmt.Errorf("error creating authorization handler: %v", err)
 	}
-	pluginInitializer := initializer.New(client, nil, authorizer, nil)
-	pluginChain, err := pluginHandler.NewFromPlugins(plugin.DefaultOnAdmissionPlugins().List(), pluginInitializer)
+	pluginInitializer := initializer.New(client, nil, cmClient, authorizer, utilfeature.DefaultFeatureGate, clusterResourceNamespace, disablePolicyCoveredChecks)
+	pluginChain, err := pluginHandler.NewFromPlugins(plugin.OrderedDefaultOnAdmissionPlugins(), pluginInitializer)
 	if err != nil {
 		return nil, fmt.Errorf("error building admission chain: %v", err)
 	}
@@ -125,6 +132,13 @@ func buildCertificateSource(log logr.Logger, tlsConfig config.TLSConfig, restCfg
 			CertPath: tlsConfig.Filesystem.CertFile,
 			KeyPath:  tlsConfig.Filesystem.KeyFile,
 		}
+	case tlsConfig.SecretConfigProvided():
+		log.V(logf.InfoLevel).Info("using TLS certificate from Secret resource", "secret_namespace", tlsConfig.Secret.SecretNamespace, "secret_name", tlsConfig.Secret.SecretName)
+		return &tls.SecretCertificateSource{
+			SecretNamespace: tlsConfig.Secret.SecretNamespace,
+			SecretName:      tlsConfig.Secret.SecretName,
+			RESTConfig:      restCfg,
+		}
 	case tlsConfig.DynamicConfigProvided():
 		log.V(logf.InfoLevel).Info("using dynamic certificate generating using CA stored in Secret resource", "secret_namespace", tlsConfig.Dynamic.SecretNamespace, "secret_name", tlsConfig.Dynamic.SecretName)
 		return &tls.DynamicSource{
@@ -75,10 +75,18 @@ update-codegen: | k8s-codegen-tools $(NEEDS_GO)
 		./$(BINDIR)/tools/defaulter-gen \
 		./$(BINDIR)/tools/conversion-gen
 
+.PHONY: update-admission-policies
+update-admission-policies: | $(NEEDS_GO)
+	$(GO) run ./hack/genadmissionpolicies > deploy/charts/cert-manager/templates/webhook-validating-admission-policies.yaml
+
+.PHONY: update-monitoring
+update-monitoring: | $(NEEDS_GO)
+	$(GO) run ./hack/genmonitoring deploy/charts/cert-manager/templates
+
 .PHONY: update-all
 ## Update CRDs, code generation and licenses to the latest versions.
 ## This is provided as a convenience to run locally before creating a PR, to ensure
 ## that everything is up-to-date.
 ##
 ## @category Development
-update-all: update-crds update-codegen update-licenses
+update-all: update-crds update-codegen update-licenses update-admission-policies update-monitoring
@@ -2,7 +2,7 @@
 BASE_IMAGE_TYPE:=STATIC
 
 ARCHS = amd64 arm64 s390x ppc64le arm
-BINS = controller acmesolver cainjector webhook ctl
+BINS = controller acmesolver cainjector webhook ctl hostagent
 
 BASE_IMAGE_controller-linux-amd64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_amd64)
 BASE_IMAGE_controller-linux-arm64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm64)
@@ -28,6 +28,12 @@ BASE_IMAGE_cainjector-linux-s390x:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_s390x)
 BASE_IMAGE_cainjector-linux-ppc64le:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_ppc64le)
 BASE_IMAGE_cainjector-linux-arm:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm)
 
+BASE_IMAGE_hostagent-linux-amd64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_amd64)
+BASE_IMAGE_hostagent-linux-arm64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm64)
+BASE_IMAGE_hostagent-linux-s390x:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_s390x)
+BASE_IMAGE_hostagent-linux-ppc64le:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_ppc64le)
+BASE_IMAGE_hostagent-linux-arm:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm)
+
 BASE_IMAGE_cmctl-linux-amd64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_amd64)
 BASE_IMAGE_cmctl-linux-arm64:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm64)
 BASE_IMAGE_cmctl-linux-s390x:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_s390x)
@@ -35,7 +41,7 @@ BASE_IMAGE_cmctl-linux-ppc64le:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_ppc64le)
 BASE_IMAGE_cmctl-linux-arm:=$($(BASE_IMAGE_TYPE)_BASE_IMAGE_arm)
 
 .PHONY: all-containers
-all-containers: cert-manager-controller-linux cert-manager-webhook-linux cert-manager-acmesolver-linux cert-manager-cainjector-linux cert-manager-ctl-linux
+all-containers: cert-manager-controller-linux cert-manager-webhook-linux cert-manager-acmesolver-linux cert-manager-cainjector-linux cert-manager-ctl-linux cert-manager-hostagent-linux
 
 .PHONY: cert-manager-controller-linux
 cert-manager-controller-linux: $(BINDIR)/containers/cert-manager-controller-linux-amd64.tar.gz $(BINDIR)/containers/cert-manager-controller-linux-arm64.tar.gz $(BINDIR)/containers/cert-manager-controller-linux-s390x.tar.gz $(BINDIR)/containers/cert-manager-controller-linux-ppc64le.tar.gz $(BINDIR)/containers/cert-manager-controller-linux-arm.tar.gz
@@ -76,6 +82,19 @@ $(BINDIR)/containers/cert-manager-cainjector-linux-amd64.tar $(BINDIR)/container
 		$(dir $<) >/dev/null
 	$(CTR) save $(TAG) -o $@ >/dev/null
 
+.PHONY: cert-manager-hostagent-linux
+cert-manager-hostagent-linux: $(BINDIR)/containers/cert-manager-hostagent-linux-amd64.tar.gz $(BINDIR)/containers/cert-manager-hostagent-linux-arm64.tar.gz $(BINDIR)/containers/cert-manager-hostagent-linux-s390x.tar.gz $(BINDIR)/containers/cert-manager-hostagent-linux-ppc64le.tar.gz $(BINDIR)/containers/cert-manager-hostagent-linux-arm.tar.gz
+
+$(BINDIR)/containers/cert-manager-hostagent-linux-amd64.tar $(BINDIR)/containers/cert-manager-hostagent-linux-arm64.tar $(BINDIR)/containers/cert-manager-hostagent-linux-s390x.tar $(BINDIR)/containers/cert-manager-hostagent-linux-ppc64le.tar $(BINDIR)/containers/cert-manager-hostagent-linux-arm.tar: $(BINDIR)/containers/cert-manager-hostagent-linux-%.tar: $(BINDIR)/scratch/build-context/cert-manager-hostagent-linux-%/hostagent hack/containers/Containerfile.hostagent $(BINDIR)/scratch/build-context/cert-manager-hostagent-linux-%/cert-manager.license $(BINDIR)/scratch/build-context/cert-manager-hostagent-linux-%/cert-manager.licenses_notice $(BINDIR)/release-version | $(BINDIR)/containers
+	@$(eval TAG := cert-manager-hostagent-$*:$(RELEASE_VERSION))
+	@$(eval BASE := BASE_IMAGE_hostagent-linux-$*)
+	$(CTR) build --quiet \
+		-f hack/containers/Containerfile.hostagent \
+		--build-arg BASE_IMAGE=$($(BASE)) \
+		-t $(TAG) \
+		$(dir $<) >/dev/null
+	$(CTR) save $(TAG) -o $@ >/dev/null
+
 .PHONY: cert-manager-acmesolver-linux
 cert-manager-acmesolver-linux: $(BINDIR)/containers/cert-manager-acmesolver-linux-amd64.tar.gz $(BINDIR)/containers/cert-manager-acmesolver-linux-arm64.tar.gz $(BINDIR)/containers/cert-manager-acmesolver-linux-s390x.tar.gz $(BINDIR)/containers/cert-manager-acmesolver-linux-ppc64le.tar.gz $(BINDIR)/containers/cert-manager-acmesolver-linux-arm.tar.gz
 
@@ -131,7 +150,7 @@ $(BINDIR)/scratch/build-context/cert-manager-%/cert-manager.license: $(BINDIR)/s
 $(BINDIR)/scratch/build-context/cert-manager-%/cert-manager.licenses_notice: $(BINDIR)/scratch/cert-manager.licenses_notice | $(BINDIR)/scratch/build-context/cert-manager-%
 	@ln -f $< $@
 
-$(BINDIR)/scratch/build-context/cert-manager-%/controller $(BINDIR)/scratch/build-context/cert-manager-%/acmesolver $(BINDIR)/scratch/build-context/cert-manager-%/cainjector $(BINDIR)/scratch/build-context/cert-manager-%/webhook: $(BINDIR)/server/% | $(BINDIR)/scratch/build-context/cert-manager-%
+$(BINDIR)/scratch/build-context/cert-manager-%/controller $(BINDIR)/scratch/build-context/cert-manager-%/acmesolver $(BINDIR)/scratch/build-context/cert-manager-%/cainjector $(BINDIR)/scratch/build-context/cert-manager-%/webhook $(BINDIR)/scratch/build-context/cert-manager-%/hostagent: $(BINDIR)/server/% | $(BINDIR)/scratch/build-context/cert-manager-%
 	@ln -f $< $@
 
 $(BINDIR)/scratch/build-context/cert-manager-ctl-%/ctl: $(BINDIR)/cmctl/cmctl-% | $(BINDIR)/scratch/build-context/cert-manager-ctl-%
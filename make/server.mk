@@ -1,11 +1,11 @@
 .PHONY: server-binaries
-server-binaries: controller acmesolver webhook cainjector
+server-binaries: controller acmesolver webhook cainjector hostagent
 
 $(BINDIR)/server:
 	@mkdir -p $@
 
 .PHONY: controller
-controller: $(BINDIR)/server/controller-linux-amd64 $(BINDIR)/server/controller-linux-arm64 $(BINDIR)/server/controller-linux-s390x $(BINDIR)/server/controller-linux-ppc64le $(BINDIR)/server/controller-linux-arm | $(NEEDS_GO) $(BINDIR)/server
+controller: $(BINDIR)/server/controller-linux-amd64 $(BINDIR)/server/controller-linux-arm64 $(BINDIR)/server/controller-linux-s390x $(BINDIR)/server/controller-linux-ppc64le $(BINDIR)/server/controller-linux-arm $(BINDIR)/server/controller-windows-amd64.exe | $(NEEDS_GO) $(BINDIR)/server
 
 $(BINDIR)/server/controller-linux-amd64: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
 	GOOS=linux GOARCH=amd64 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/controller/main.go
@@ -22,8 +22,11 @@ $(BINDIR)/server/controller-linux-ppc64le: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/se
 $(BINDIR)/server/controller-linux-arm: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
 	GOOS=linux GOARCH=arm GOARM=7 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/controller/main.go
 
+$(BINDIR)/server/controller-windows-amd64.exe: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=windows GOARCH=amd64 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/controller/main.go
+
 .PHONY: acmesolver
-acmesolver: $(BINDIR)/server/acmesolver-linux-amd64 $(BINDIR)/server/acmesolver-linux-arm64 $(BINDIR)/server/acmesolver-linux-s390x $(BINDIR)/server/acmesolver-linux-ppc64le $(BINDIR)/server/acmesolver-linux-arm | $(NEEDS_GO) $(BINDIR)/server
+acmesolver: $(BINDIR)/server/acmesolver-linux-amd64 $(BINDIR)/server/acmesolver-linux-arm64 $(BINDIR)/server/acmesolver-linux-s390x $(BINDIR)/server/acmesolver-linux-ppc64le $(BINDIR)/server/acmesolver-linux-arm $(BINDIR)/server/acmesolver-windows-amd64.exe | $(NEEDS_GO) $(BINDIR)/server
 
 $(BINDIR)/server/acmesolver-linux-amd64: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
 	GOOS=linux GOARCH=amd64 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/acmesolver/main.go
@@ -40,6 +43,9 @@ $(BINDIR)/server/acmesolver-linux-ppc64le: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/se
 $(BINDIR)/server/acmesolver-linux-arm: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
 	GOOS=linux GOARCH=arm GOARM=7 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/acmesolver/main.go
 
+$(BINDIR)/server/acmesolver-windows-amd64.exe: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=windows GOARCH=amd64 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/acmesolver/main.go
+
 .PHONY: webhook
 webhook: $(BINDIR)/server/webhook-linux-amd64 $(BINDIR)/server/webhook-linux-arm64 $(BINDIR)/server/webhook-linux-s390x $(BINDIR)/server/webhook-linux-ppc64le $(BINDIR)/server/webhook-linux-arm | $(NEEDS_GO) $(BINDIR)/server
 
@@ -75,3 +81,21 @@ $(BINDIR)/server/cainjector-linux-ppc64le: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/se
 
 $(BINDIR)/server/cainjector-linux-arm: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
 	GOOS=linux GOARCH=arm GOARM=7 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/cainjector/main.go
+
+.PHONY: hostagent
+hostagent: $(BINDIR)/server/hostagent-linux-amd64 $(BINDIR)/server/hostagent-linux-arm64 $(BINDIR)/server/hostagent-linux-s390x $(BINDIR)/server/hostagent-linux-ppc64le $(BINDIR)/server/hostagent-linux-arm | $(NEEDS_GO) $(BINDIR)/server
+
+$(BINDIR)/server/hostagent-linux-amd64: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=amd64 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/hostagent/main.go
+
+$(BINDIR)/server/hostagent-linux-arm64: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=arm64 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/hostagent/main.go
+
+$(BINDIR)/server/hostagent-linux-s390x: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=s390x $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/hostagent/main.go
+
+$(BINDIR)/server/hostagent-linux-ppc64le: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=ppc64le $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/hostagent/main.go
+
+$(BINDIR)/server/hostagent-linux-arm: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	GOOS=linux GOARCH=arm GOARM=7 $(GOBUILD) -o $@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' cmd/hostagent/main.go
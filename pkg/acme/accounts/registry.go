@@ -24,6 +24,7 @@ import (
 
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
 // ErrNotFound is returned by GetClient if there is no ACME client registered.
@@ -177,3 +178,30 @@ func (r *registry) ListClients() map[string]acmecl.Interface {
 	}
 	return out
 }
+
+// UsesNamespacedAccounts returns true if the issuer is a ClusterIssuer which
+// resolves External Account Binding credentials in the namespace of each
+// request, and so uses a separate ACME account for every namespace.
+func UsesNamespacedAccounts(issuer cmapi.GenericIssuer) bool {
+	if _, ok := issuer.(*cmapi.ClusterIssuer); !ok {
+		return false
+	}
+	config := issuer.GetSpec().ACME
+	return config != nil && config.ExternalAccountBinding != nil && config.ExternalAccountBinding.PerNamespace
+}
+
+// NamespacedUID returns the UID under which the client of the ACME account
+// used by the ClusterIssuer with the given UID for requests from namespace
+// is registered, if the ClusterIssuer uses namespaced accounts.
+func NamespacedUID(uid, namespace string) string {
+	return uid + "/" + namespace
+}
+
+// ClientUID returns the UID of the client to use for requests from namespace
+// to the given issuer.
+func ClientUID(issuer cmapi.GenericIssuer, namespace string) string {
+	if UsesNamespacedAccounts(issuer) {
+		return NamespacedUID(string(issuer.GetUID()), namespace)
+	}
+	return string(issuer.GetUID())
+}
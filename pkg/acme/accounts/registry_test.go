@@ -20,7 +20,10 @@ import (
 	"net/http"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -145,3 +148,39 @@ func TestRegistry_AddClient_UpdatesExistingWhenPrivateKeyChanges(t *testing.T) {
 		t.Errorf("expected ListClients to have 1 item but it has %d", len(l))
 	}
 }
+
+func TestClientUID(t *testing.T) {
+	eab := func(perNamespace bool) cmapi.IssuerSpec {
+		return cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{ACME: &cmacme.ACMEIssuer{
+			ExternalAccountBinding: &cmacme.ACMEExternalAccountBinding{PerNamespace: perNamespace},
+		}}}
+	}
+	tests := map[string]struct {
+		issuer cmapi.GenericIssuer
+		want   string
+	}{
+		"Issuer": {
+			issuer: &cmapi.Issuer{ObjectMeta: metav1.ObjectMeta{UID: "abc", Namespace: "tenant"}, Spec: eab(true)},
+			want:   "abc",
+		},
+		"ClusterIssuer": {
+			issuer: &cmapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{UID: "abc"}, Spec: eab(false)},
+			want:   "abc",
+		},
+		"ClusterIssuer with per-namespace external account binding": {
+			issuer: &cmapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{UID: "abc"}, Spec: eab(true)},
+			want:   "abc/tenant",
+		},
+		"ClusterIssuer without ACME configuration": {
+			issuer: &cmapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{UID: "abc"}},
+			want:   "abc",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := ClientUID(test.issuer, "tenant"); got != test.want {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
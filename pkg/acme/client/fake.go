@@ -18,6 +18,7 @@ package client
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 
 	"golang.org/x/crypto/acme"
@@ -44,6 +45,7 @@ type FakeACME struct {
 	FakeDNS01ChallengeRecord    func(token string) (string, error)
 	FakeDiscover                func(ctx context.Context) (acme.Directory, error)
 	FakeUpdateReg               func(ctx context.Context, a *acme.Account) (*acme.Account, error)
+	FakeRevokeCert              func(ctx context.Context, key crypto.Signer, cert []byte, reason acme.CRLReasonCode) error
 }
 
 var _ Interface = &FakeACME{}
@@ -155,6 +157,13 @@ func (f *FakeACME) UpdateReg(ctx context.Context, a *acme.Account) (*acme.Accoun
 	return nil, fmt.Errorf("UpdateReg not implemented")
 }
 
+func (f *FakeACME) RevokeCert(ctx context.Context, key crypto.Signer, cert []byte, reason acme.CRLReasonCode) error {
+	if f.FakeRevokeCert != nil {
+		return f.FakeRevokeCert(ctx, key, cert, reason)
+	}
+	return fmt.Errorf("RevokeCert not implemented")
+}
+
 func (f *FakeACME) ListCertAlternates(ctx context.Context, url string) ([]string, error) {
 	if f.FakeListCertAlternates != nil {
 		return f.FakeListCertAlternates(ctx, url)
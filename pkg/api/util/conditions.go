@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/clock"
 
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -372,3 +373,93 @@ func CertificateRequestIsDenied(cr *cmapi.CertificateRequest) bool {
 
 	return false
 }
+
+func GetCertificateRevocationRequestCondition(crr *cmapi.CertificateRevocationRequest, conditionType cmapi.CertificateRevocationRequestConditionType) *cmapi.CertificateRevocationRequestCondition {
+	for _, cond := range crr.Status.Conditions {
+		if cond.Type == conditionType {
+			return &cond
+		}
+	}
+	return nil
+}
+
+// SetCertificateRevocationRequestCondition will set a 'condition' on the given
+// CertificateRevocationRequest.
+//   - If no condition of the same type already exists, the condition will be
+//     inserted with the LastTransitionTime set to the current time.
+//   - If a condition of the same type and state already exists, the condition
+//     will be updated but the LastTransitionTime will not be modified.
+//   - If a condition of the same type and different state already exists, the
+//     condition will be updated with the LastTransitionTime set to the current
+//     time.
+func SetCertificateRevocationRequestCondition(crr *cmapi.CertificateRevocationRequest, conditionType cmapi.CertificateRevocationRequestConditionType, status cmmeta.ConditionStatus, reason, message string) {
+	nowTime := metav1.NewTime(Clock.Now())
+	newCondition := cmapi.CertificateRevocationRequestCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: &nowTime,
+	}
+
+	for idx, cond := range crr.Status.Conditions {
+		if cond.Type != conditionType {
+			continue
+		}
+		if cond.Status == status {
+			newCondition.LastTransitionTime = cond.LastTransitionTime
+		}
+		crr.Status.Conditions[idx] = newCondition
+		return
+	}
+
+	crr.Status.Conditions = append(crr.Status.Conditions, newCondition)
+}
+
+// CertificateRevocationRequestIsRevoked returns true if the
+// CertificateRevocationRequest has the Revoked condition set to True, and so
+// the certificate it refers to is reported as revoked.
+func CertificateRevocationRequestIsRevoked(crr *cmapi.CertificateRevocationRequest) bool {
+	cond := GetCertificateRevocationRequestCondition(crr, cmapi.CertificateRevocationRequestConditionRevoked)
+	return cond != nil && cond.Status == cmmeta.ConditionTrue
+}
+
+// SetOrderCondition will set a 'condition' on the given Order.
+//   - If no condition of the same type already exists, the condition will be
+//     inserted with the LastTransitionTime set to the current time.
+//   - If a condition of the same type and state already exists, the condition
+//     will be updated but the LastTransitionTime will not be modified.
+//   - If a condition of the same type and different state already exists, the
+//     condition will be updated with the LastTransitionTime set to the current
+//     time.
+//
+// The ObservedGeneration of the condition is always set to the generation of
+// the Order, whether the lastTransitionTime is modified or not.
+func SetOrderCondition(o *cmacme.Order, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	newCondition := metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: o.Generation,
+		LastTransitionTime: metav1.NewTime(Clock.Now()),
+		Reason:             reason,
+		Message:            message,
+	}
+
+	for idx, cond := range o.Status.Conditions {
+		if cond.Type != conditionType {
+			continue
+		}
+
+		if cond.Status == status {
+			newCondition.LastTransitionTime = cond.LastTransitionTime
+		} else {
+			logf.V(logf.InfoLevel).Infof("Found status change for Order %q condition %q: %q -> %q; setting lastTransitionTime to %v", o.Name, conditionType, cond.Status, status, newCondition.LastTransitionTime.Time)
+		}
+
+		o.Status.Conditions[idx] = newCondition
+		return
+	}
+
+	o.Status.Conditions = append(o.Status.Conditions, newCondition)
+	logf.V(logf.InfoLevel).Infof("Setting lastTransitionTime for Order %q condition %q to %v", o.Name, conditionType, newCondition.LastTransitionTime.Time)
+}
@@ -34,6 +34,10 @@ const (
 	IssuerSelfSigned string = "selfsigned"
 	// IssuerVenafi uses Venafi Trust Protection Platform and Venafi Cloud
 	IssuerVenafi string = "venafi"
+	// IssuerHub forwards requests to an issuer in a hub cluster
+	IssuerHub string = "hub"
+	// IssuerExternalSigner delegates signing to an external gRPC signer
+	IssuerExternalSigner string = "externalsigner"
 )
 
 // NameForIssuer determines the name of the Issuer implementation given an
@@ -50,6 +54,10 @@ func NameForIssuer(i cmapi.GenericIssuer) (string, error) {
 		return IssuerSelfSigned, nil
 	case i.GetSpec().Venafi != nil:
 		return IssuerVenafi, nil
+	case i.GetSpec().Hub != nil:
+		return IssuerHub, nil
+	case i.GetSpec().ExternalSigner != nil:
+		return IssuerExternalSigner, nil
 	}
 	return "", fmt.Errorf("no issuer specified for Issuer '%s/%s'", i.GetObjectMeta().Namespace, i.GetObjectMeta().Name)
 }
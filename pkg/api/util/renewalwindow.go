@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// RenewalWindowSchedule is a parsed RenewalWindow, which reports whether
+// renewals may be started at a given time.
+type RenewalWindowSchedule struct {
+	days     [7]bool
+	hours    [24]bool
+	location *time.Location
+}
+
+// NewRenewalWindowSchedule parses the given RenewalWindow.
+func NewRenewalWindowSchedule(w *cmapi.RenewalWindow) (*RenewalWindowSchedule, error) {
+	days, err := ParseRenewalWindowDays(w.Days)
+	if err != nil {
+		return nil, err
+	}
+	hours, err := ParseRenewalWindowHours(w.Hours)
+	if err != nil {
+		return nil, err
+	}
+	location := time.UTC
+	if w.TimeZone != "" {
+		location, err = time.LoadLocation(w.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", w.TimeZone, err)
+		}
+	}
+	return &RenewalWindowSchedule{days: days, hours: hours, location: location}, nil
+}
+
+// ParseRenewalWindowDays parses the days of a RenewalWindow, each of which is
+// either a single day such as `Sat` or a range of days such as `Mon-Fri`.
+// Ranges may wrap around the end of the week, so `Fri-Mon` includes the
+// weekend. The returned array is indexed by time.Weekday. If no days are
+// given, all days are included.
+func ParseRenewalWindowDays(days []string) ([7]bool, error) {
+	var included [7]bool
+	if len(days) == 0 {
+		for i := range included {
+			included[i] = true
+		}
+		return included, nil
+	}
+	for _, d := range days {
+		start, end, isRange := strings.Cut(d, "-")
+		first, ok := weekdays[strings.ToLower(strings.TrimSpace(start))]
+		if !ok {
+			return included, fmt.Errorf("invalid day %q, must be one of Mon, Tue, Wed, Thu, Fri, Sat or Sun", start)
+		}
+		last := first
+		if isRange {
+			if last, ok = weekdays[strings.ToLower(strings.TrimSpace(end))]; !ok {
+				return included, fmt.Errorf("invalid day %q, must be one of Mon, Tue, Wed, Thu, Fri, Sat or Sun", end)
+			}
+		}
+		for day := first; ; day = (day + 1) % 7 {
+			included[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+	return included, nil
+}
+
+// ParseRenewalWindowHours parses the hours of a RenewalWindow, which are a
+// range such as `9-17` including the hours from 09:00 until 17:00. The end
+// of the range is excluded, and ranges may wrap around midnight, so `22-6`
+// includes the hours from 22:00 until 06:00. The returned array is indexed by
+// the hour of the day. If hours is empty, all hours are included.
+func ParseRenewalWindowHours(hours string) ([24]bool, error) {
+	var included [24]bool
+	if hours == "" {
+		for i := range included {
+			included[i] = true
+		}
+		return included, nil
+	}
+	start, end, ok := strings.Cut(hours, "-")
+	if !ok {
+		return included, fmt.Errorf("invalid hours %q, must be a range such as 9-17", hours)
+	}
+	first, err := strconv.Atoi(strings.TrimSpace(start))
+	if err != nil || first < 0 || first > 23 {
+		return included, fmt.Errorf("invalid hours %q, the start of the range must be between 0 and 23", hours)
+	}
+	last, err := strconv.Atoi(strings.TrimSpace(end))
+	if err != nil || last < 0 || last > 24 {
+		return included, fmt.Errorf("invalid hours %q, the end of the range must be between 0 and 24", hours)
+	}
+	if first == last {
+		return included, fmt.Errorf("invalid hours %q, the range must not be empty", hours)
+	}
+	n := last - first
+	if n < 0 {
+		n += 24
+	}
+	for i := 0; i < n; i++ {
+		included[(first+i)%24] = true
+	}
+	return included, nil
+}
+
+// Contains returns true if renewals may be started at the given time.
+func (s *RenewalWindowSchedule) Contains(t time.Time) bool {
+	local := t.In(s.location)
+	return s.days[local.Weekday()] && s.hours[local.Hour()]
+}
+
+// Next returns the earliest time, at or after the given time, at which
+// renewals may be started.
+func (s *RenewalWindowSchedule) Next(t time.Time) time.Time {
+	if s.Contains(t) {
+		return t
+	}
+	local := t.In(s.location)
+	next := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, s.location)
+	// Every schedule includes at least one hour of one day of the week, so
+	// the window opens within the next 8 days.
+	for i := 0; i < 8*24; i++ {
+		next = next.Add(time.Hour)
+		if s.Contains(next) {
+			return next
+		}
+	}
+	return t
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestParseRenewalWindowDays(t *testing.T) {
+	tests := map[string]struct {
+		days    []string
+		want    []time.Weekday
+		wantErr bool
+	}{
+		"no days includes every day": {
+			want: []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+		},
+		"single days": {
+			days: []string{"Sat", "sun"},
+			want: []time.Weekday{time.Sunday, time.Saturday},
+		},
+		"range": {
+			days: []string{"Mon-Fri"},
+			want: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		},
+		"range wrapping around the end of the week": {
+			days: []string{"Fri-Mon"},
+			want: []time.Weekday{time.Sunday, time.Monday, time.Friday, time.Saturday},
+		},
+		"unknown day": {
+			days:    []string{"Monday"},
+			wantErr: true,
+		},
+		"unknown end of range": {
+			days:    []string{"Mon-"},
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseRenewalWindowDays(test.days)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var want [7]bool
+			for _, d := range test.want {
+				want[d] = true
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestParseRenewalWindowHours(t *testing.T) {
+	tests := map[string]struct {
+		hours   string
+		want    []int
+		wantErr bool
+	}{
+		"no hours includes every hour": {
+			want: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23},
+		},
+		"range excludes its end": {
+			hours: "9-12",
+			want:  []int{9, 10, 11},
+		},
+		"range until midnight": {
+			hours: "21-24",
+			want:  []int{21, 22, 23},
+		},
+		"range wrapping around midnight": {
+			hours: "22-2",
+			want:  []int{0, 1, 22, 23},
+		},
+		"whole day": {
+			hours: "0-24",
+			want:  []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23},
+		},
+		"not a range": {
+			hours:   "9",
+			wantErr: true,
+		},
+		"empty range": {
+			hours:   "9-9",
+			wantErr: true,
+		},
+		"start out of bounds": {
+			hours:   "24-6",
+			wantErr: true,
+		},
+		"end out of bounds": {
+			hours:   "9-25",
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseRenewalWindowHours(test.hours)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var want [24]bool
+			for _, h := range test.want {
+				want[h] = true
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestRenewalWindowSchedule(t *testing.T) {
+	// Wednesday 5 January 2022
+	wednesday := time.Date(2022, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		window       cmapi.RenewalWindow
+		now          time.Time
+		wantContains bool
+		wantNext     time.Time
+	}{
+		"empty window is always open": {
+			now:          wednesday.Add(3*time.Hour + 25*time.Minute),
+			wantContains: true,
+			wantNext:     wednesday.Add(3*time.Hour + 25*time.Minute),
+		},
+		"within the hours of the window": {
+			window:       cmapi.RenewalWindow{Days: []string{"Mon-Fri"}, Hours: "9-17"},
+			now:          wednesday.Add(16*time.Hour + 59*time.Minute),
+			wantContains: true,
+			wantNext:     wednesday.Add(16*time.Hour + 59*time.Minute),
+		},
+		"after the hours of the window": {
+			window:   cmapi.RenewalWindow{Days: []string{"Mon-Fri"}, Hours: "9-17"},
+			now:      wednesday.Add(17*time.Hour + 30*time.Minute),
+			wantNext: wednesday.Add(24*time.Hour + 9*time.Hour),
+		},
+		"days and hours are matched independently": {
+			window:   cmapi.RenewalWindow{Days: []string{"Sat"}, Hours: "22-2"},
+			now:      wednesday.Add(23 * time.Hour),
+			wantNext: wednesday.Add(3 * 24 * time.Hour),
+		},
+		"hours are interpreted in the time zone": {
+			window:   cmapi.RenewalWindow{Hours: "9-17", TimeZone: "America/New_York"},
+			now:      wednesday.Add(9 * time.Hour),
+			wantNext: wednesday.Add(14 * time.Hour),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewRenewalWindowSchedule(&test.window)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantContains, s.Contains(test.now))
+			assert.True(t, test.wantNext.Equal(s.Next(test.now)), "expected next %s, got %s", test.wantNext, s.Next(test.now))
+		})
+	}
+
+	_, err := NewRenewalWindowSchedule(&cmapi.RenewalWindow{TimeZone: "Not/AZone"})
+	assert.Error(t, err)
+}
@@ -18,6 +18,7 @@ package util
 
 import (
 	"crypto/x509"
+	"fmt"
 	"math/bits"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -112,3 +113,121 @@ func extKeyUsageString(usage x509.ExtKeyUsage) cmapi.KeyUsage {
 
 	return "unknown"
 }
+
+// UsagesForPreset returns the key usages requested by the given usage preset,
+// or nil if the preset is not known.
+func UsagesForPreset(preset cmapi.UsagePreset) []cmapi.KeyUsage {
+	switch preset {
+	case cmapi.UsagePresetServerAuth:
+		return []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth}
+	case cmapi.UsagePresetClientAuth:
+		return []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageClientAuth}
+	case cmapi.UsagePresetMutual:
+		return []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth, cmapi.UsageClientAuth}
+	}
+	return nil
+}
+
+// CertificateUsages returns the key usages requested by a Certificate, which
+// are the usages of its usage preset if one is set.
+func CertificateUsages(spec *cmapi.CertificateSpec) []cmapi.KeyUsage {
+	if len(spec.Usages) == 0 && spec.UsagePreset != "" {
+		return UsagesForPreset(spec.UsagePreset)
+	}
+	return spec.Usages
+}
+
+// issuerTypeUsages are the only key usages which may be requested from issuers
+// of the given types, whatever their allowedUsages. Issuers of types which are
+// not listed may be asked for any usage.
+var issuerTypeUsages = map[string][]cmapi.KeyUsage{
+	// ACME servers only issue certificates for TLS, so must never be asked
+	// for other usages such as code signing.
+	IssuerACME: {
+		cmapi.UsageSigning,
+		cmapi.UsageDigitalSignature,
+		cmapi.UsageKeyEncipherment,
+		cmapi.UsageKeyAgreement,
+		cmapi.UsageServerAuth,
+		cmapi.UsageClientAuth,
+	},
+}
+
+// RequestedUsages returns the key usages which are signed for a request with
+// the given usages, which are the default usages if none are given. CA
+// certificates are also signed for the `cert sign` usage.
+func RequestedUsages(usages []cmapi.KeyUsage, isCA bool) []cmapi.KeyUsage {
+	if len(usages) == 0 {
+		usages = cmapi.DefaultKeyUsages()
+	}
+	if isCA && !usageListed(cmapi.UsageCertSign, usages) {
+		usages = append(append([]cmapi.KeyUsage(nil), usages...), cmapi.UsageCertSign)
+	}
+	return usages
+}
+
+// CheckIssuerUsages returns an error if any of the given usages may not be
+// requested from the issuer by a resource in the given namespace. A usage must
+// be supported by the type of the issuer, and be listed in the issuer's
+// allowedUsages if it has any.
+func CheckIssuerUsages(issuer cmapi.GenericIssuer, namespace string, usages []cmapi.KeyUsage) error {
+	// Issuers without a type are reported by the issuer controllers.
+	issuerType, _ := NameForIssuer(issuer)
+	allowed := issuer.GetSpec().AllowedUsages
+
+	for _, usage := range usages {
+		if supported, ok := issuerTypeUsages[issuerType]; ok && !usageListed(usage, supported) {
+			return fmt.Errorf("usage %q cannot be requested from %s issuers", usage, issuerType)
+		}
+		if len(allowed) > 0 && !usageAllowed(usage, namespace, allowed) {
+			return fmt.Errorf("usage %q is not allowed by the issuer in namespace %q", usage, namespace)
+		}
+	}
+	return nil
+}
+
+// usageAllowed returns true if usage is allowed in namespace by any of
+// allowed.
+func usageAllowed(usage cmapi.KeyUsage, namespace string, allowed []cmapi.AllowedKeyUsage) bool {
+	for _, a := range allowed {
+		if !usagesEqual(usage, a.Usage) {
+			continue
+		}
+		if len(a.Namespaces) == 0 {
+			return true
+		}
+		for _, ns := range a.Namespaces {
+			if ns == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// usageListed returns true if usage is one of usages.
+func usageListed(usage cmapi.KeyUsage, usages []cmapi.KeyUsage) bool {
+	for _, u := range usages {
+		if usagesEqual(usage, u) {
+			return true
+		}
+	}
+	return false
+}
+
+// usagesEqual returns true if a and b are the same usage, including aliases
+// such as `signing` and `digital signature`.
+func usagesEqual(a, b cmapi.KeyUsage) bool {
+	if a == b {
+		return true
+	}
+	if ka, ok := KeyUsageType(a); ok {
+		kb, ok := KeyUsageType(b)
+		return ok && ka == kb
+	}
+	if ea, ok := ExtKeyUsageType(a); ok {
+		eb, ok := ExtKeyUsageType(b)
+		return ok && ea == eb
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestCertificateUsages(t *testing.T) {
+	tests := map[string]struct {
+		spec cmapi.CertificateSpec
+		want []cmapi.KeyUsage
+	}{
+		"no usages or preset": {
+			spec: cmapi.CertificateSpec{},
+			want: nil,
+		},
+		"usages are returned as written": {
+			spec: cmapi.CertificateSpec{Usages: []cmapi.KeyUsage{cmapi.UsageServerAuth}},
+			want: []cmapi.KeyUsage{cmapi.UsageServerAuth},
+		},
+		"clientAuth preset": {
+			spec: cmapi.CertificateSpec{UsagePreset: cmapi.UsagePresetClientAuth},
+			want: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageClientAuth},
+		},
+		"serverAuth preset": {
+			spec: cmapi.CertificateSpec{UsagePreset: cmapi.UsagePresetServerAuth},
+			want: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth},
+		},
+		"mutual preset": {
+			spec: cmapi.CertificateSpec{UsagePreset: cmapi.UsagePresetMutual},
+			want: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth, cmapi.UsageClientAuth},
+		},
+		"usages take precedence over a preset": {
+			spec: cmapi.CertificateSpec{Usages: []cmapi.KeyUsage{cmapi.UsageCodeSigning}, UsagePreset: cmapi.UsagePresetMutual},
+			want: []cmapi.KeyUsage{cmapi.UsageCodeSigning},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, CertificateUsages(&test.spec))
+		})
+	}
+}
+
+func TestRequestedUsages(t *testing.T) {
+	tests := map[string]struct {
+		usages []cmapi.KeyUsage
+		isCA   bool
+		want   []cmapi.KeyUsage
+	}{
+		"no usages requests the default usages": {
+			want: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment},
+		},
+		"usages are returned as written": {
+			usages: []cmapi.KeyUsage{cmapi.UsageServerAuth},
+			want:   []cmapi.KeyUsage{cmapi.UsageServerAuth},
+		},
+		"CA certificates request cert sign": {
+			usages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature},
+			isCA:   true,
+			want:   []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageCertSign},
+		},
+		"cert sign is not requested twice": {
+			usages: []cmapi.KeyUsage{cmapi.UsageCertSign},
+			isCA:   true,
+			want:   []cmapi.KeyUsage{cmapi.UsageCertSign},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, RequestedUsages(test.usages, test.isCA))
+		})
+	}
+}
+
+func TestCheckIssuerUsages(t *testing.T) {
+	acme := &cmapi.Issuer{Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{ACME: &cmacme.ACMEIssuer{}}}}
+	ca := func(allowed ...cmapi.AllowedKeyUsage) *cmapi.ClusterIssuer {
+		return &cmapi.ClusterIssuer{Spec: cmapi.IssuerSpec{
+			IssuerConfig:  cmapi.IssuerConfig{CA: &cmapi.CAIssuer{}},
+			AllowedUsages: allowed,
+		}}
+	}
+
+	tests := map[string]struct {
+		issuer    cmapi.GenericIssuer
+		namespace string
+		usages    []cmapi.KeyUsage
+		wantErr   string
+	}{
+		"ACME issuers can sign TLS usages": {
+			issuer: acme,
+			usages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth, cmapi.UsageClientAuth},
+		},
+		"ACME issuers can never sign code signing": {
+			issuer:  acme,
+			usages:  []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageCodeSigning},
+			wantErr: `usage "code signing" cannot be requested from acme issuers`,
+		},
+		"issuers without allowedUsages can sign any usage": {
+			issuer: ca(),
+			usages: []cmapi.KeyUsage{cmapi.UsageCodeSigning, cmapi.UsageCertSign},
+		},
+		"usages must be listed in allowedUsages": {
+			issuer:    ca(cmapi.AllowedKeyUsage{Usage: cmapi.UsageDigitalSignature}),
+			namespace: "default",
+			usages:    []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment},
+			wantErr:   `usage "key encipherment" is not allowed by the issuer in namespace "default"`,
+		},
+		"aliases of allowed usages are allowed": {
+			issuer: ca(cmapi.AllowedKeyUsage{Usage: cmapi.UsageDigitalSignature}, cmapi.AllowedKeyUsage{Usage: cmapi.UsageEmailProtection}),
+			usages: []cmapi.KeyUsage{cmapi.UsageSigning, cmapi.UsageSMIME},
+		},
+		"usages restricted to namespaces are allowed in those namespaces": {
+			issuer:    ca(cmapi.AllowedKeyUsage{Usage: cmapi.UsageServerAuth, Namespaces: []string{"ingress", "gateway"}}),
+			namespace: "gateway",
+			usages:    []cmapi.KeyUsage{cmapi.UsageServerAuth},
+		},
+		"usages restricted to namespaces are not allowed in other namespaces": {
+			issuer:    ca(cmapi.AllowedKeyUsage{Usage: cmapi.UsageServerAuth, Namespaces: []string{"ingress"}}),
+			namespace: "default",
+			usages:    []cmapi.KeyUsage{cmapi.UsageServerAuth},
+			wantErr:   `usage "server auth" is not allowed by the issuer in namespace "default"`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := CheckIssuerUsages(test.issuer, test.namespace, test.usages)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
@@ -49,6 +49,16 @@ const (
 	// SolverIdentificationLabelKey is added to the labels of a Pod serving an ACME challenge.
 	// Its value will be the "true" if the Pod is an HTTP-01 solver.
 	SolverIdentificationLabelKey = "acme.cert-manager.io/http01-solver"
+
+	// BurstSolverLabelKey is added to the labels of the Deployment and
+	// ConfigMap of an HTTP-01 burst solver, and of the Pods serving its
+	// challenges. Its value will be the name of the burst solver.
+	BurstSolverLabelKey = "acme.cert-manager.io/http01-burst-solver"
+
+	// BurstSolverIssuerLabelKey is added to the labels of the Deployment and
+	// ConfigMap of an HTTP-01 burst solver. Its value will be the hash of the
+	// kind and name of the issuer that the burst solver belongs to.
+	BurstSolverIssuerLabelKey = "acme.cert-manager.io/http01-burst-solver-issuer"
 )
 
 const (
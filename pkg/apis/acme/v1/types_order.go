@@ -97,6 +97,17 @@ type OrderStatus struct {
 	// +optional
 	FinalizeURL string `json:"finalizeURL,omitempty"`
 
+	// AccountURI is the URI of the ACME account the Order was created with.
+	// Together with URL, it can be used to correlate the Order with the logs
+	// of the ACME server.
+	// +optional
+	AccountURI string `json:"accountURI,omitempty"`
+
+	// CertificateURL is the URL of the certificate issued for the Order.
+	// This field will be populated once the order has been finalized.
+	// +optional
+	CertificateURL string `json:"certificateURL,omitempty"`
+
 	// Authorizations contains data returned from the ACME server on what
 	// authorizations must be completed in order to validate the DNS names
 	// specified on the Order.
@@ -124,6 +135,28 @@ type OrderStatus struct {
 	// This is used to influence garbage collection and back-off.
 	// +optional
 	FailureTime *metav1.Time `json:"failureTime,omitempty"`
+
+	// FinalizeAttempts is the number of attempts to finalize the order which
+	// failed with a transient error, such as a bad nonce or a rate limit.
+	// Finalization is retried with back-off rather than failing the order and
+	// discarding its valid authorizations.
+	// +optional
+	FinalizeAttempts int `json:"finalizeAttempts,omitempty"`
+
+	// LastFinalizeAttemptTime is the time of the last attempt to finalize the
+	// order which failed with a transient error.
+	// +optional
+	LastFinalizeAttemptTime *metav1.Time `json:"lastFinalizeAttemptTime,omitempty"`
+
+	// List of status conditions to indicate the status of the Order.
+	// Known condition types are `Ready`.
+	// Each condition records the generation of the Order it was observed
+	// for, so tools such as `kubectl wait` can tell whether a condition
+	// applies to the current spec.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // ACMEAuthorization contains data returned from the ACME server on an
@@ -156,6 +189,15 @@ type ACMEAuthorization struct {
 	// +optional
 	InitialState State `json:"initialState,omitempty"`
 
+	// State is the last observed state of the ACME authorization. It is set to
+	// InitialState when the authorization is first fetched, and then follows
+	// the state of the Challenge solving the authorization, so that the
+	// progress of the Order can be seen without listing its Challenges.
+	// A Challenge is not created for an authorization which is already
+	// 'valid'.
+	// +optional
+	State State `json:"state,omitempty"`
+
 	// Challenges specifies the challenge types offered by the ACME server.
 	// One of these challenge types will be selected when validating the DNS
 	// name and an appropriate Challenge resource will be created to perform
@@ -238,3 +280,11 @@ const (
 	// This is a final state.
 	Errored State = "errored"
 )
+
+const (
+	// OrderConditionReady indicates that an Order has been finalized and the
+	// signed certificate is available in its status.
+	// The reason of the condition is the CamelCase form of the Order's state,
+	// e.g. `Valid`, `Pending` or `Invalid`.
+	OrderConditionReady = "Ready"
+)
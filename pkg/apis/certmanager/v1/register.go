@@ -56,6 +56,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterIssuerList{},
 		&CertificateRequest{},
 		&CertificateRequestList{},
+		&CertificateDefaults{},
+		&CertificateDefaultsList{},
+		&CertificateProfile{},
+		&CertificateProfileList{},
+		&IssuerAlias{},
+		&IssuerAliasList{},
+		&CertificateRevocationRequest{},
+		&CertificateRevocationRequestList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -51,10 +51,53 @@ const (
 	// Annotation key for the name of the certificate that a resource is related to.
 	CertificateNameKey = "cert-manager.io/certificate-name"
 
+	// Annotation key for the name of the CertificateRequest that the
+	// certificate stored in a Secret was issued from.
+	CertificateRequestNameAnnotationKey = "cert-manager.io/certificate-request-name"
+
+	// Annotation key for the UID of the CertificateRequest that the
+	// certificate stored in a Secret was issued from. ACME Orders are owned by
+	// the CertificateRequest with this UID.
+	CertificateRequestUIDAnnotationKey = "cert-manager.io/certificate-request-uid"
+
+	// Annotation key for the hex encoded serial number of the certificate
+	// stored in a Secret.
+	SerialNumberAnnotationKey = "cert-manager.io/serial-number"
+
+	// Annotation key for the hex encoded SHA-256 fingerprint of the
+	// certificate stored in a Secret.
+	FingerprintSHA256AnnotationKey = "cert-manager.io/fingerprint-sha256"
+
 	// Annotation key used to denote whether a Secret is named on a Certificate
 	// as a 'next private key' Secret resource.
 	IsNextPrivateKeySecretLabelKey = "cert-manager.io/next-private-key"
 
+	// Label key set to "true" on Secrets which are created and managed by the
+	// cert-manager controller. When the SecretsFilteredCaching feature gate is
+	// enabled, only the Secrets with this label are fully cached, and other
+	// Secrets are read from the API server when needed.
+	PartOfCertManagerControllerLabelKey = "controller.cert-manager.io/fao"
+
+	// Label key set on the CertificateRequests and private key Secrets which
+	// are created for the additional private keys of a Certificate. Its value
+	// is the lower case algorithm of the key, e.g. "ecdsa".
+	AdditionalPrivateKeyLabelKey = "cert-manager.io/additional-private-key"
+
+	// Label key set by the controller on approved CertificateRequests which
+	// have an owner. Its value identifies the identical requests of the same
+	// owner, of which only the oldest is sent to the issuer while the others
+	// wait to share its certificate.
+	SignBatchLabelKey = "cert-manager.io/sign-batch"
+
+	// Prefix of the annotation keys which record, for each additional
+	// private key algorithm of a Certificate, the hex encoded SHA-256
+	// fingerprint of the certificate in `tls.crt` that the additional
+	// certificate was issued alongside, e.g.
+	// "additional-private-key.cert-manager.io/ecdsa". They are set on the
+	// Certificate's Secret and on the CertificateRequests for the additional
+	// private keys.
+	AdditionalPrivateKeyAnnotationPrefix = "additional-private-key.cert-manager.io/"
+
 	// Annotation key used to limit the number of CertificateRequests to be kept for a Certificate.
 	// Minimum value is 1.
 	// If unset all CertificateRequests will be kept.
@@ -106,6 +149,20 @@ const (
 	IngressClassAnnotationKey = "kubernetes.io/ingress.class"
 )
 
+// Annotation keys which may be set on a Namespace to declare the issuer used by
+// Certificates which are created in that namespace without an issuerRef.
+const (
+	// Annotation key for the 'name' of the default issuer of a Namespace.
+	// Certificates are only defaulted if this annotation is set.
+	NamespaceDefaultIssuerNameAnnotationKey = "cert-manager.io/default-issuer-name"
+
+	// Annotation key for the 'kind' of the default issuer of a Namespace.
+	NamespaceDefaultIssuerKindAnnotationKey = "cert-manager.io/default-issuer-kind"
+
+	// Annotation key for the 'group' of the default issuer of a Namespace.
+	NamespaceDefaultIssuerGroupAnnotationKey = "cert-manager.io/default-issuer-group"
+)
+
 // Annotation names for CertificateRequests
 const (
 	// Annotation added to CertificateRequest resources to denote the name of
@@ -115,8 +172,16 @@ const (
 	// issuer type to self-sign certificates.
 	CertificateRequestPrivateKeyAnnotationKey = "cert-manager.io/private-key-secret-name"
 
-	// Annotation to declare the CertificateRequest "revision", belonging to a Certificate Resource
+	// Annotation to declare the CertificateRequest "revision", belonging to a Certificate Resource.
+	// It is also set on the Certificate's Secret to the revision of the
+	// CertificateRequest that the stored certificate was issued from.
 	CertificateRequestRevisionAnnotationKey = "cert-manager.io/certificate-revision"
+
+	// Prefix of the annotation keys which consumers of a Certificate's Secret
+	// set to the revision they have loaded, e.g.
+	// "propagation.cert-manager.io/ingress-gateway": "3". They are counted
+	// against the Certificate's `spec.propagationQuorum`.
+	PropagationAcknowledgementAnnotationPrefix = "propagation.cert-manager.io/"
 )
 
 const (
@@ -126,14 +191,26 @@ const (
 	// stored in the target Secret resource whilst the real Issuer is processing
 	// the certificate request.
 	IssueTemporaryCertificateAnnotation = "cert-manager.io/issue-temporary-certificate"
+
+	// IssuanceDryRunAnnotation is an annotation that can be added to
+	// Certificate resources.
+	// If it is set to "true", the Certificate will not be issued. Instead, the
+	// Certificate spec is checked against the policy of its issuer (for
+	// example a Vault role, a Venafi zone or the ACME solvers), and the result
+	// is reported in the `IssuanceDryRun` condition.
+	// Requires the certificates-dryrun controller, which is not enabled by
+	// default, to be enabled with `--controllers=*,certificates-dryrun`.
+	IssuanceDryRunAnnotation = "cert-manager.io/issuance-dry-run"
 )
 
 // Common/known resource kinds.
 const (
-	ClusterIssuerKind      = "ClusterIssuer"
-	IssuerKind             = "Issuer"
-	CertificateKind        = "Certificate"
-	CertificateRequestKind = "CertificateRequest"
+	ClusterIssuerKind                = "ClusterIssuer"
+	IssuerKind                       = "Issuer"
+	IssuerAliasKind                  = "IssuerAlias"
+	CertificateKind                  = "Certificate"
+	CertificateRequestKind           = "CertificateRequest"
+	CertificateRevocationRequestKind = "CertificateRevocationRequest"
 )
 
 const (
@@ -173,6 +250,12 @@ const (
 	// Venafi Pickup ID of a certificate signing request that has been submitted
 	// to the Venafi API for collection later.
 	VenafiPickupIDAnnotationKey = "venafi.cert-manager.io/pickup-id"
+
+	// HubSpokeRequestAnnotationKey is the annotation set on CertificateRequests
+	// forwarded to a hub cluster by a hub issuer, recording the namespace and
+	// name of the CertificateRequest in the spoke cluster, in the form
+	// "namespace/name".
+	HubSpokeRequestAnnotationKey = "hub.cert-manager.io/spoke-request"
 )
 
 // KeyUsage specifies valid usage contexts for keys.
@@ -53,7 +53,7 @@ type CertificateList struct {
 	Items []Certificate `json:"items"`
 }
 
-// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519
+// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519;MLDSA65-ECDSA-P256
 type PrivateKeyAlgorithm string
 
 const (
@@ -65,6 +65,11 @@ const (
 
 	// Denotes the Ed25519 private key type.
 	Ed25519KeyAlgorithm PrivateKeyAlgorithm = "Ed25519"
+
+	// Denotes an experimental composite private key type, combining an
+	// ML-DSA-65 and an ECDSA P-256 key. Requires the ExperimentalCompositeKeys
+	// feature gate.
+	MLDSA65ECDSAP256KeyAlgorithm PrivateKeyAlgorithm = "MLDSA65-ECDSA-P256"
 )
 
 // +kubebuilder:validation:Enum=PKCS1;PKCS8
@@ -162,7 +167,11 @@ type CertificateSpec struct {
 	// with the given name in the same namespace as the Certificate will be used.
 	// If the `kind` field is set to `ClusterIssuer`, a ClusterIssuer with the
 	// provided name will be used.
-	// The `name` field in this stanza is required at all times.
+	// If the `kind` field is set to `IssuerAlias`, the issuer referenced by the
+	// IssuerAlias with the given name will be used.
+	// The `name` field in this stanza is required at all times. If it is not
+	// set when the Certificate is created, the issuerRef is defaulted from the
+	// `cert-manager.io/default-issuer-*` annotations of the Namespace.
 	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
 
 	// IsCA will mark this Certificate as valid for certificate signing.
@@ -175,6 +184,18 @@ type CertificateSpec struct {
 	// +optional
 	Usages []KeyUsage `json:"usages,omitempty"`
 
+	// UsagePreset requests the key usages of a common kind of certificate
+	// instead of listing them in `usages`, and checks that the certificate
+	// has the subject alternative names that kind of certificate needs.
+	// `serverAuth` requests `digital signature`, `key encipherment` and
+	// `server auth`, and requires a DNS name or IP address. `clientAuth`
+	// requests `digital signature`, `key encipherment` and `client auth`, and
+	// requires a common name, URI or email address. `mutual` requests both
+	// `server auth` and `client auth`, and requires a DNS name or IP address.
+	// Cannot be set together with `usages`.
+	// +optional
+	UsagePreset UsagePreset `json:"usagePreset,omitempty"`
+
 	// Options to control private keys used for the Certificate.
 	// +optional
 	PrivateKey *CertificatePrivateKey `json:"privateKey,omitempty"`
@@ -195,6 +216,20 @@ type CertificateSpec struct {
 	// +optional
 	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"` // Validated by the validating webhook.
 
+	// propagationQuorum is the number of consumers of the Certificate's Secret
+	// which must acknowledge that they have loaded its current revision before
+	// older CertificateRequest revisions are garbage collected or the
+	// certificate is renewed. A consumer acknowledges a revision by setting the
+	// annotation `propagation.cert-manager.io/<consumer name>` on the Secret to
+	// the value of the Secret's `cert-manager.io/certificate-revision`
+	// annotation. Renewals are never deferred past the midpoint between the
+	// renewal time and the expiry of the certificate, so that slow or absent
+	// consumers cannot let it expire. If set, propagationQuorum must be a value
+	// of `1` or greater. If unset (`nil`), cert-manager does not wait for
+	// consumers. Default value is `nil`.
+	// +optional
+	PropagationQuorum *int32 `json:"propagationQuorum,omitempty"` // Validated by the validating webhook.
+
 	// AdditionalOutputFormats defines extra output formats of the private key
 	// and signed certificate chain to be written to this Certificate's target
 	// Secret. This is an Alpha Feature and is only enabled with the
@@ -202,6 +237,138 @@ type CertificateSpec struct {
 	// the controller and webhook components.
 	// +optional
 	AdditionalOutputFormats []CertificateAdditionalOutputFormat `json:"additionalOutputFormats,omitempty"`
+
+	// SecretStores are external stores to which the private key and signed
+	// certificate chain are pushed after they have been written to the
+	// Certificate's target Secret, for workloads which consume secrets
+	// outside of the cluster. This is an Alpha Feature and is only enabled
+	// with the `--feature-gates=ExternalSecretStores=true` option on both the
+	// controller and webhook components.
+	// +optional
+	SecretStores []CertificateSecretStore `json:"secretStores,omitempty"`
+
+	// ProfileRef is a reference to a cluster-scoped CertificateProfile. When
+	// the Certificate is created, fields it does not specify are set to the
+	// values in the referenced profile. The profile must exist when the
+	// Certificate is created, and profileRef cannot be changed afterwards.
+	// +optional
+	ProfileRef *cmmeta.LocalObjectReference `json:"profileRef,omitempty"`
+
+	// RenewalWindow restricts renewals of this Certificate to the given days
+	// and hours, taking precedence over the renewal window of its issuer.
+	// Initial issuance and reissuance due to changes of the spec are not
+	// restricted.
+	// +optional
+	RenewalWindow *RenewalWindow `json:"renewalWindow,omitempty"`
+
+	// AdditionalPrivateKeys requests a certificate for each listed private
+	// key algorithm in addition to the certificate for spec.privateKey. The
+	// additional certificates are issued by the same issuer for the same
+	// subject and SANs whenever the Certificate is issued, so that servers
+	// can negotiate between them during the TLS handshake. Each pair is
+	// written to the `tls-<algorithm>.crt` and `tls-<algorithm>.key` keys
+	// of the target Secret, e.g. `tls-ecdsa.crt` and `tls-ecdsa.key`. This
+	// is an Alpha Feature and is only enabled with the
+	// `--feature-gates=AdditionalPrivateKeys=true` option on both the
+	// controller and webhook components.
+	// +optional
+	AdditionalPrivateKeys []CertificateAdditionalPrivateKey `json:"additionalPrivateKeys,omitempty"`
+
+	// SecretFormatProfile arranges additional keys in the Certificate's
+	// target Secret so that it can be consumed directly by a specific proxy,
+	// without reassembling the key material in an init container. `HAProxy`
+	// writes the signed certificate chain followed by the private key to the
+	// `haproxy.pem` key. `EnvoySDS` writes the certificate chain, private key
+	// and CA to the `cert`, `key` and `cacert` keys read from generic Secrets
+	// by Envoy SDS servers such as Istio. The `tls.crt`, `tls.key` and
+	// `ca.crt` keys are always written, and are consumed as-is by
+	// ingress-nginx. This is an Alpha Feature and is only enabled with the
+	// `--feature-gates=AdditionalCertificateOutputFormats=true` option on both
+	// the controller and webhook components.
+	// +optional
+	SecretFormatProfile SecretFormatProfile `json:"secretFormatProfile,omitempty"`
+}
+
+// CertificateSecretStore is an external store to which the private key and
+// signed certificate chain of a Certificate are pushed. The `tls.crt`,
+// `tls.key` and `ca.crt` keys of the Certificate's Secret are stored as the
+// fields of the same name of the external secret.
+// Exactly one of `vault` or `awsSecretsManager` must be set.
+type CertificateSecretStore struct {
+	// Vault pushes to a secret in a HashiCorp Vault KV version 2 secrets
+	// engine.
+	// +optional
+	Vault *VaultSecretStore `json:"vault,omitempty"`
+
+	// AWSSecretsManager pushes to a secret in AWS Secrets Manager.
+	// +optional
+	AWSSecretsManager *AWSSecretsManagerSecretStore `json:"awsSecretsManager,omitempty"`
+}
+
+// VaultSecretStore configures a secret in a HashiCorp Vault KV version 2
+// secrets engine. Secrets referenced by the store are read from the
+// Certificate's namespace.
+type VaultSecretStore struct {
+	// Auth configures how cert-manager authenticates with the Vault server.
+	Auth VaultAuth `json:"auth"`
+
+	// Server is the connection address for the Vault server, e.g: "https://vault.example.com:8200".
+	Server string `json:"server"`
+
+	// Mount is the mount path of the KV version 2 secrets engine, e.g:
+	// "secret".
+	Mount string `json:"mount"`
+
+	// Path is the path of the secret within the secrets engine, e.g:
+	// "my-app/tls".
+	Path string `json:"path"`
+
+	// Name of the vault namespace. Namespaces is a set of features within Vault Enterprise that allows Vault environments to support Secure Multi-tenancy. e.g: "ns1"
+	// More about namespaces can be found here https://www.vaultproject.io/docs/enterprise/namespaces
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// PEM-encoded CA bundle (base64-encoded) used to validate Vault server
+	// certificate. Only used if the Server URL is using HTTPS protocol.
+	// Mutually exclusive with CABundleSecretRef. If neither CABundle nor
+	// CABundleSecretRef are defined, the cert-manager controller system root
+	// certificates are used to validate the TLS connection.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// CABundleSecretRef is a reference to a Secret which contains the CABundle
+	// which will be used when connecting to Vault when using HTTPS.
+	// Mutually exclusive with CABundle. If no key for the Secret is specified,
+	// cert-manager will default to 'ca.crt'.
+	// +optional
+	CABundleSecretRef *cmmeta.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+}
+
+// AWSSecretsManagerSecretStore configures a secret in AWS Secrets Manager. The
+// secret is created if it does not exist, and its value is a JSON object.
+// Secrets referenced by the store are read from the Certificate's namespace.
+type AWSSecretsManagerSecretStore struct {
+	// Region is the AWS region of the secret.
+	Region string `json:"region"`
+
+	// SecretName is the name or ARN of the secret.
+	SecretName string `json:"secretName"`
+
+	// AccessKeyIDSecretRef is a reference to a Secret containing the access
+	// key ID used to authenticate with AWS. If neither this nor
+	// SecretAccessKeySecretRef are set, ambient credentials are used when
+	// the controller is started with `--issuer-ambient-credentials`.
+	// +optional
+	AccessKeyIDSecretRef *cmmeta.SecretKeySelector `json:"accessKeyIDSecretRef,omitempty"`
+
+	// SecretAccessKeySecretRef is a reference to a Secret containing the
+	// secret access key used to authenticate with AWS.
+	// +optional
+	SecretAccessKeySecretRef *cmmeta.SecretKeySelector `json:"secretAccessKeySecretRef,omitempty"`
+
+	// Role is the ARN of an IAM role assumed before pushing to the secret.
+	// +optional
+	Role string `json:"role,omitempty"`
 }
 
 // CertificatePrivateKey contains configuration options for private keys
@@ -216,11 +383,22 @@ type CertificatePrivateKey struct {
 	// to await user intervention.
 	// If set to Always, a private key matching the specified requirements
 	// will be generated whenever a re-issuance occurs.
+	// If set to Scheduled, the private key is kept across re-issuances and
+	// only regenerated once it is due for rotation according to
+	// `rotationSchedule`.
 	// Default is 'Never' for backward compatibility.
 	// +optional
-	// +kubebuilder:validation:Enum=Never;Always
+	// +kubebuilder:validation:Enum=Never;Always;Scheduled
 	RotationPolicy PrivateKeyRotationPolicy `json:"rotationPolicy,omitempty"`
 
+	// RotationSchedule configures when the private key is rotated if
+	// `rotationPolicy` is set to Scheduled. It is independent of the renewal
+	// of the certificate, so that keys can be rotated at a different cadence
+	// than certificates are renewed.
+	// Must be set if and only if `rotationPolicy` is Scheduled.
+	// +optional
+	RotationSchedule *PrivateKeyRotationSchedule `json:"rotationSchedule,omitempty"`
+
 	// The private key cryptography standards (PKCS) encoding for this
 	// certificate's private key to be encoded in.
 	// If provided, allowed values are `PKCS1` and `PKCS8` standing for PKCS#1
@@ -235,6 +413,8 @@ type CertificatePrivateKey struct {
 	// key size of 256 will be used for `ECDSA` key algorithm and
 	// key size of 2048 will be used for `RSA` key algorithm.
 	// key size is ignored when using the `Ed25519` key algorithm.
+	// The experimental `MLDSA65-ECDSA-P256` composite key algorithm may be
+	// used if the ExperimentalCompositeKeys feature gate is enabled.
 	// +optional
 	Algorithm PrivateKeyAlgorithm `json:"algorithm,omitempty"`
 
@@ -243,7 +423,7 @@ type CertificatePrivateKey struct {
 	// and will default to `2048` if not specified.
 	// If `algorithm` is set to `ECDSA`, valid values are `256`, `384` or `521`,
 	// and will default to `256` if not specified.
-	// If `algorithm` is set to `Ed25519`, Size is ignored.
+	// If `algorithm` is set to `Ed25519` or `MLDSA65-ECDSA-P256`, Size is ignored.
 	// No other values are allowed.
 	// +optional
 	Size int `json:"size,omitempty"` // Validated by webhook. Be mindful of adding OpenAPI validation- see https://github.com/cert-manager/cert-manager/issues/3644
@@ -263,8 +443,34 @@ var (
 	// RotationPolicyAlways means a private key matching the specified
 	// requirements will be generated whenever a re-issuance occurs.
 	RotationPolicyAlways PrivateKeyRotationPolicy = "Always"
+
+	// RotationPolicyScheduled means the private key will be reused across
+	// re-issuances until it is due for rotation according to the
+	// Certificate's private key rotation schedule.
+	RotationPolicyScheduled PrivateKeyRotationPolicy = "Scheduled"
 )
 
+// PrivateKeyRotationSchedule configures when a private key is rotated if the
+// Scheduled rotation policy is used. The private key is rotated as soon as
+// any of the configured limits is reached. At least one of the fields must be
+// set.
+type PrivateKeyRotationSchedule struct {
+	// Renewals is the number of certificates which may be issued for the
+	// same private key. Once the private key has been used for this many
+	// issuances, a new private key is generated for the next one.
+	// For example, a value of `3` rotates the private key on every third
+	// renewal. If set, renewals must be a value of `1` or greater.
+	// +optional
+	Renewals *int32 `json:"renewals,omitempty"`
+
+	// MaxAge is the maximum amount of time for which a private key is used,
+	// measured from the first issuance with that key. Once a private key is
+	// older than maxAge, the certificate is re-issued with a new private key,
+	// even if it is not yet due for renewal. If set, maxAge must be positive.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
 // CertificateOutputFormatType specifies which additional output formats should
 // be written to the Certificate's target Secret.
 // Allowed values are `DER` or `CombinedPEM`.
@@ -298,6 +504,22 @@ const (
 	CertificateOutputFormatCombinedPEM CertificateOutputFormatType = "CombinedPEM"
 )
 
+const (
+	// PKCS12SecretKey is the name of the data entry in the Secret resource
+	// used to store the PKCS#12 keystore.
+	PKCS12SecretKey = "keystore.p12"
+	// PKCS12TruststoreKey is the name of the data entry in the Secret
+	// resource used to store the PKCS#12 truststore containing the CA.
+	PKCS12TruststoreKey = "truststore.p12"
+
+	// JKSSecretKey is the name of the data entry in the Secret resource used
+	// to store the JKS keystore.
+	JKSSecretKey = "keystore.jks"
+	// JKSTruststoreKey is the name of the data entry in the Secret resource
+	// used to store the JKS truststore containing the CA.
+	JKSTruststoreKey = "truststore.jks"
+)
+
 // CertificateAdditionalOutputFormat defines an additional output format of a
 // Certificate resource. These contain supplementary data formats of the signed
 // certificate chain and paired private key.
@@ -307,6 +529,49 @@ type CertificateAdditionalOutputFormat struct {
 	Type CertificateOutputFormatType `json:"type"`
 }
 
+// SecretFormatProfile is the name of a consumer of Certificate Secrets for
+// which additional keys are written to the Secret.
+// +kubebuilder:validation:Enum=HAProxy;EnvoySDS
+type SecretFormatProfile string
+
+const (
+	// SecretFormatProfileHAProxy writes the Certificate's signed certificate
+	// chain followed by its private key, in PEM format, to the `haproxy.pem`
+	// target Secret Data key.
+	SecretFormatProfileHAProxy SecretFormatProfile = "HAProxy"
+
+	// SecretFormatProfileEnvoySDS writes the Certificate's signed
+	// certificate chain, private key and CA, in PEM format, to the `cert`,
+	// `key` and `cacert` target Secret Data keys.
+	SecretFormatProfileEnvoySDS SecretFormatProfile = "EnvoySDS"
+
+	// SecretFormatProfileHAProxyKey is the name of the data entry in the
+	// Secret resource used to store the combined PEM for HAProxy.
+	SecretFormatProfileHAProxyKey string = "haproxy.pem"
+
+	// SecretFormatProfileEnvoySDSCertKey, SecretFormatProfileEnvoySDSKeyKey
+	// and SecretFormatProfileEnvoySDSCAKey are the names of the data entries
+	// in the Secret resource used to store the certificate chain, private key
+	// and CA for Envoy SDS servers.
+	SecretFormatProfileEnvoySDSCertKey string = "cert"
+	SecretFormatProfileEnvoySDSKeyKey  string = "key"
+	SecretFormatProfileEnvoySDSCAKey   string = "cacert"
+)
+
+// CertificateAdditionalPrivateKey is a private key for which a certificate is
+// issued alongside the main certificate of a Certificate resource.
+type CertificateAdditionalPrivateKey struct {
+	// Algorithm is the algorithm of the additional private key, one of
+	// `RSA`, `ECDSA` or `Ed25519`. It must differ from the algorithm of
+	// spec.privateKey and of the other additional private keys.
+	Algorithm PrivateKeyAlgorithm `json:"algorithm"`
+
+	// Size is the key bit size of the additional private key, with the
+	// same defaults and allowed values as spec.privateKey.size.
+	// +optional
+	Size int `json:"size,omitempty"`
+}
+
 // X509Subject Full X509 name specification
 type X509Subject struct {
 	// Organizations to be used on the Certificate.
@@ -418,6 +683,36 @@ type CertificateStatus struct {
 	// +optional
 	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
 
+	// The serial number of the certificate stored in the secret named by
+	// this resource in `spec.secretName`, encoded as a hexadecimal string.
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// The distinguished name of the issuer of the certificate stored in the
+	// secret named by this resource in `spec.secretName`.
+	// +optional
+	IssuerDN string `json:"issuerDN,omitempty"`
+
+	// The DNS subjectAltNames of the certificate stored in the secret named
+	// by this resource in `spec.secretName`.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// The IP address subjectAltNames of the certificate stored in the secret
+	// named by this resource in `spec.secretName`.
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// The URI subjectAltNames of the certificate stored in the secret named
+	// by this resource in `spec.secretName`.
+	// +optional
+	URIs []string `json:"uris,omitempty"`
+
+	// The email subjectAltNames of the certificate stored in the secret
+	// named by this resource in `spec.secretName`.
+	// +optional
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
 	// The current 'revision' of the certificate as issued.
 	//
 	// When a CertificateRequest resource is created, it will have the
@@ -451,6 +746,18 @@ type CertificateStatus struct {
 	// time.Hour * 2 ^ (failedIssuanceAttempts - 1).
 	// +optional
 	FailedIssuanceAttempts *int `json:"failedIssuanceAttempts,omitempty"`
+
+	// The time at which the private key stored in the Secret was first used
+	// to issue a certificate. This field is used to determine when the private
+	// key is due for rotation if the Scheduled rotation policy is used.
+	// +optional
+	PrivateKeyIssuedTime *metav1.Time `json:"privateKeyIssuedTime,omitempty"`
+
+	// The number of certificates which have been issued for the private key
+	// stored in the Secret. This field is used to determine when the private
+	// key is due for rotation if the Scheduled rotation policy is used.
+	// +optional
+	PrivateKeyIssuances *int `json:"privateKeyIssuances,omitempty"`
 }
 
 // CertificateCondition contains condition information for an Certificate.
@@ -514,8 +821,46 @@ const (
 	//
 	// It will be removed by the 'issuing' controller upon completing issuance.
 	CertificateConditionIssuing CertificateConditionType = "Issuing"
+
+	// A condition added to Certificate resources which name the same
+	// `spec.secretName` as an older Certificate in the same namespace.
+	// Only the oldest Certificate is issued into the Secret; issuance of the
+	// others is paused whilst this condition is true, so that the Certificates
+	// do not continuously overwrite each other's Secret.
+	//
+	// It will be removed by the 'trigger' controller once the Certificate is
+	// the only, or the oldest, Certificate naming the Secret.
+	CertificateConditionDuplicateSecretName CertificateConditionType = "DuplicateSecretName"
+
+	// A condition added to Certificate resources which have the
+	// `cert-manager.io/issuance-dry-run` annotation set to "true".
+	// It is true if the issuer's policy would accept the Certificate spec, and
+	// false if the issuer would refuse to sign it, in which case the message
+	// describes why. It is unknown if the issuer does not support dry-runs or
+	// its policy could not be retrieved.
+	//
+	// It is managed by the 'certificates-dryrun' controller, which is not
+	// enabled by default, and removed once the annotation is removed.
+	CertificateConditionIssuanceDryRun CertificateConditionType = "IssuanceDryRun"
+
+	// A condition added to Certificate resources whose certificate expires
+	// within one of the controller's expiry alert thresholds, which default
+	// to 30, 14 and 7 days. It is true, and its message names the smallest
+	// threshold crossed, until the certificate is renewed. A Certificate
+	// which is being renewed normally never has this condition set to true,
+	// so it can be used to alert on misconfigured or failing renewals.
+	//
+	// It is managed by the 'certificates-expiry-alerts' controller, which is not
+	// enabled by default.
+	CertificateConditionExpiringSoon CertificateConditionType = "ExpiringSoon"
 )
 
+// CertificateReasonRevoked is the reason of the Issuing condition added by the
+// 'certificaterevocationrequests' controller once the current certificate of
+// a Certificate has been revoked. A new private key is always generated for
+// the re-issuance, regardless of the Certificate's rotation policy.
+const CertificateReasonRevoked = "Revoked"
+
 // CertificateSecretTemplate defines the default labels and annotations
 // to be copied to the Kubernetes Secret resource named in `CertificateSpec.secretName`.
 type CertificateSecretTemplate struct {
@@ -527,3 +872,20 @@ type CertificateSecretTemplate struct {
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
 }
+
+// UsagePreset is a named set of key usages for a common kind of certificate.
+// +kubebuilder:validation:Enum=serverAuth;clientAuth;mutual
+type UsagePreset string
+
+const (
+	// UsagePresetServerAuth requests a certificate for TLS servers.
+	UsagePresetServerAuth UsagePreset = "serverAuth"
+
+	// UsagePresetClientAuth requests a certificate for TLS clients, such as
+	// the clients of a server requiring mutual TLS.
+	UsagePresetClientAuth UsagePreset = "clientAuth"
+
+	// UsagePresetMutual requests a certificate which may be used both as a
+	// TLS server and as a TLS client.
+	UsagePresetMutual UsagePreset = "mutual"
+)
@@ -94,7 +94,26 @@ type CertificateRequestSpec struct {
 
 	// The PEM-encoded x509 certificate signing request to be submitted to the
 	// CA for signing.
-	Request []byte `json:"request"`
+	// Exactly one of `request` or `requestSecretRef` must be specified.
+	// +optional
+	Request []byte `json:"request,omitempty"`
+
+	// RequestSecretRef is a reference to a key of a Secret in the same
+	// namespace holding the PEM-encoded x509 certificate signing request, as
+	// an alternative to `request` for CSRs which are too large to be inlined
+	// or which should not be readable by everyone able to read the
+	// CertificateRequest.
+	// Exactly one of `request` or `requestSecretRef` must be specified.
+	// +optional
+	RequestSecretRef *cmmeta.SecretKeySelector `json:"requestSecretRef,omitempty"`
+
+	// RequestSHA256 is the hex-encoded SHA-256 digest of the request held in
+	// the Secret referenced by `requestSecretRef`, which must be set with it.
+	// The request is only signed while the Secret holds a request with this
+	// digest, so that it cannot be changed once the CertificateRequest has
+	// been approved.
+	// +optional
+	RequestSHA256 string `json:"requestSHA256,omitempty"`
 
 	// IsCA will request to mark the certificate as valid for certificate signing
 	// when submitting to the issuer.
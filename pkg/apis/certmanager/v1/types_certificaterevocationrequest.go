@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
+
+// A CertificateRevocationRequest marks a certificate as revoked. Certificates
+// issued by a CA or SelfSigned issuer are reported as revoked by the OCSP
+// responder of the cert-manager controller, if it is enabled, and deleting
+// the CertificateRevocationRequest withdraws the revocation. Certificates
+// issued by an ACME or Vault issuer are revoked by the CA which signed them.
+// If a Certificate is referenced, it is re-issued with a new private key once
+// its certificate has been revoked.
+type CertificateRevocationRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Desired state of the CertificateRevocationRequest resource.
+	Spec CertificateRevocationRequestSpec `json:"spec"`
+
+	// Status of the CertificateRevocationRequest. This is set and managed
+	// automatically.
+	// +optional
+	Status CertificateRevocationRequestStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateRevocationRequestList is a list of CertificateRevocationRequests
+type CertificateRevocationRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CertificateRevocationRequest `json:"items"`
+}
+
+// CertificateRevocationRequestSpec identifies the certificate to revoke.
+type CertificateRevocationRequestSpec struct {
+	// CertificateRef is a reference to a Certificate in the namespace of the
+	// CertificateRevocationRequest whose current certificate is revoked.
+	// The issuerRef and serialNumber are filled in from the Certificate and
+	// its Secret if they are not set, and the Certificate is re-issued with a
+	// new private key once its certificate has been revoked, or straight away
+	// if its issuer does not support revocation.
+	// +optional
+	CertificateRef *cmmeta.LocalObjectReference `json:"certificateRef,omitempty"`
+
+	// IssuerRef is a reference to the CA, SelfSigned, ACME or Vault Issuer or
+	// ClusterIssuer which issued the certificate. If the `kind` field is not
+	// set, or set to `Issuer`, an Issuer with the given name in the namespace
+	// of the CertificateRevocationRequest is used. A ClusterIssuer can only be
+	// referenced from the cluster resource namespace. Required unless
+	// certificateRef is set.
+	// +optional
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef,omitempty"`
+
+	// SerialNumber of the certificate to revoke, as a hexadecimal string.
+	// Bytes may be separated by colons, as printed by `openssl x509 -serial`
+	// or `kubectl cert-manager inspect`. Required unless certificateRef is
+	// set.
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// Reason the certificate is revoked, one of `Unspecified`,
+	// `KeyCompromise`, `CACompromise`, `AffiliationChanged`, `Superseded`,
+	// `CessationOfOperation` or `PrivilegeWithdrawn`. Defaults to
+	// `Unspecified`.
+	// +optional
+	Reason RevocationReason `json:"reason,omitempty"`
+}
+
+// RevocationReason is the reason a certificate is revoked, as defined in
+// RFC 5280 section 5.3.1.
+// +kubebuilder:validation:Enum=Unspecified;KeyCompromise;CACompromise;AffiliationChanged;Superseded;CessationOfOperation;PrivilegeWithdrawn
+type RevocationReason string
+
+const (
+	RevocationReasonUnspecified          RevocationReason = "Unspecified"
+	RevocationReasonKeyCompromise        RevocationReason = "KeyCompromise"
+	RevocationReasonCACompromise         RevocationReason = "CACompromise"
+	RevocationReasonAffiliationChanged   RevocationReason = "AffiliationChanged"
+	RevocationReasonSuperseded           RevocationReason = "Superseded"
+	RevocationReasonCessationOfOperation RevocationReason = "CessationOfOperation"
+	RevocationReasonPrivilegeWithdrawn   RevocationReason = "PrivilegeWithdrawn"
+)
+
+// CertificateRevocationRequestStatus defines the observed state of a
+// CertificateRevocationRequest.
+type CertificateRevocationRequestStatus struct {
+	// List of status conditions to indicate the status of a
+	// CertificateRevocationRequest. The known condition type is `Revoked`.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []CertificateRevocationRequestCondition `json:"conditions,omitempty"`
+
+	// RevocationTime is the time at which the certificate was first marked as
+	// revoked. It is reported by the OCSP responder as the revocation time.
+	// +optional
+	RevocationTime *metav1.Time `json:"revocationTime,omitempty"`
+
+	// ReissuanceTime is the time at which the re-issuance of the Certificate
+	// referenced by certificateRef was triggered.
+	// +optional
+	ReissuanceTime *metav1.Time `json:"reissuanceTime,omitempty"`
+}
+
+// CertificateRevocationRequestCondition contains condition information for a
+// CertificateRevocationRequest.
+type CertificateRevocationRequestCondition struct {
+	// Type of the condition, known values are (`Revoked`).
+	Type CertificateRevocationRequestConditionType `json:"type"`
+
+	// Status of the condition, one of (`True`, `False`, `Unknown`).
+	Status cmmeta.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CertificateRevocationRequestConditionType represents a
+// CertificateRevocationRequest condition value.
+type CertificateRevocationRequestConditionType string
+
+const (
+	// CertificateRevocationRequestConditionRevoked indicates that the
+	// certificate is reported as revoked by the OCSP responder. It is False
+	// if the referenced issuer does not exist or does not support
+	// revocation.
+	CertificateRevocationRequestConditionRevoked CertificateRevocationRequestConditionType = "Revoked"
+)
@@ -49,6 +49,18 @@ type WebhookConfiguration struct {
 	// Defaults to 'localhost:6060'.
 	PprofAddress string `json:"pprofAddress,omitempty"`
 
+	// clusterResourceNamespace is the namespace in which the Secrets referenced
+	// by ClusterIssuers are stored. It must match the cluster resource namespace
+	// of the controller, and is used when validating ClusterIssuers against
+	// their issuing backend.
+	// Defaults to 'kube-system'.
+	ClusterResourceNamespace string `json:"clusterResourceNamespace,omitempty"`
+
+	// disablePolicyCoveredChecks disables the checks which are also made by
+	// the ValidatingAdmissionPolicies installed by the Helm chart. It must
+	// only be set when the policies are installed.
+	DisablePolicyCoveredChecks bool `json:"disablePolicyCoveredChecks"`
+
 	// featureGates is a map of feature names to bools that enable or disable experimental
 	// features.
 	// Default: nil
@@ -57,7 +69,7 @@ type WebhookConfiguration struct {
 }
 
 // TLSConfig configures how TLS certificates are sourced for serving.
-// Only one of 'filesystem' or 'dynamic' may be specified.
+// Only one of 'filesystem', 'dynamic' or 'secret' may be specified.
 type TLSConfig struct {
 	// cipherSuites is the list of allowed cipher suites for the server.
 	// Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants).
@@ -80,6 +92,12 @@ type TLSConfig struct {
 	// The CAs certificate can then be copied into the appropriate Validating, Mutating and Conversion
 	// webhook configuration objects (typically by cainjector).
 	Dynamic DynamicServingConfig `json:"dynamic"`
+
+	// Secret enables using a certificate and private key stored in a
+	// Kubernetes Secret resource which is managed externally, such as by a
+	// central PKI. The Secret is watched and the certificate is reloaded
+	// whenever it changes.
+	Secret SecretServingConfig `json:"secret"`
 }
 
 // DynamicServingConfig makes the webhook generate a CA and persist it into Secret resources.
@@ -106,3 +124,15 @@ type FilesystemServingConfig struct {
 	// Path to a file containing a TLS private key to server with
 	KeyFile string `json:"keyFile,omitempty"`
 }
+
+// SecretServingConfig enables using a certificate and private key stored in a
+// Kubernetes Secret resource.
+type SecretServingConfig struct {
+	// Namespace of the Kubernetes Secret resource containing the TLS
+	// certificate and private key to serve with.
+	SecretNamespace string `json:"secretNamespace,omitempty"`
+
+	// Name of the Kubernetes Secret resource containing the TLS certificate
+	// and private key to serve with, in its `tls.crt` and `tls.key` keys.
+	SecretName string `json:"secretName,omitempty"`
+}
@@ -62,6 +62,22 @@ func (in *FilesystemServingConfig) DeepCopy() *FilesystemServingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretServingConfig) DeepCopyInto(out *SecretServingConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretServingConfig.
+func (in *SecretServingConfig) DeepCopy() *SecretServingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretServingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
@@ -72,6 +88,7 @@ func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	}
 	out.Filesystem = in.Filesystem
 	in.Dynamic.DeepCopyInto(&out.Dynamic)
+	out.Secret = in.Secret
 	return
 }
 
@@ -29,9 +29,13 @@ import (
 type CertmanagerV1Interface interface {
 	RESTClient() rest.Interface
 	CertificatesGetter
+	CertificateDefaultsGetter
+	CertificateProfilesGetter
 	CertificateRequestsGetter
+	CertificateRevocationRequestsGetter
 	ClusterIssuersGetter
 	IssuersGetter
+	IssuerAliasesGetter
 }
 
 // CertmanagerV1Client is used to interact with features provided by the cert-manager.io group.
@@ -43,10 +47,22 @@ func (c *CertmanagerV1Client) Certificates(namespace string) CertificateInterfac
 	return newCertificates(c, namespace)
 }
 
+func (c *CertmanagerV1Client) CertificateDefaults() CertificateDefaultsInterface {
+	return newCertificateDefaults(c)
+}
+
+func (c *CertmanagerV1Client) CertificateProfiles() CertificateProfileInterface {
+	return newCertificateProfiles(c)
+}
+
 func (c *CertmanagerV1Client) CertificateRequests(namespace string) CertificateRequestInterface {
 	return newCertificateRequests(c, namespace)
 }
 
+func (c *CertmanagerV1Client) CertificateRevocationRequests(namespace string) CertificateRevocationRequestInterface {
+	return newCertificateRevocationRequests(c, namespace)
+}
+
 func (c *CertmanagerV1Client) ClusterIssuers() ClusterIssuerInterface {
 	return newClusterIssuers(c)
 }
@@ -55,6 +71,10 @@ func (c *CertmanagerV1Client) Issuers(namespace string) IssuerInterface {
 	return newIssuers(c, namespace)
 }
 
+func (c *CertmanagerV1Client) IssuerAliases(namespace string) IssuerAliasInterface {
+	return newIssuerAliases(c, namespace)
+}
+
 // NewForConfig creates a new CertmanagerV1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
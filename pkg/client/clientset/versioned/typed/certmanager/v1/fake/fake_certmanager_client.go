@@ -32,10 +32,22 @@ func (c *FakeCertmanagerV1) Certificates(namespace string) v1.CertificateInterfa
 	return &FakeCertificates{c, namespace}
 }
 
+func (c *FakeCertmanagerV1) CertificateDefaults() v1.CertificateDefaultsInterface {
+	return &FakeCertificateDefaults{c}
+}
+
+func (c *FakeCertmanagerV1) CertificateProfiles() v1.CertificateProfileInterface {
+	return &FakeCertificateProfiles{c}
+}
+
 func (c *FakeCertmanagerV1) CertificateRequests(namespace string) v1.CertificateRequestInterface {
 	return &FakeCertificateRequests{c, namespace}
 }
 
+func (c *FakeCertmanagerV1) CertificateRevocationRequests(namespace string) v1.CertificateRevocationRequestInterface {
+	return &FakeCertificateRevocationRequests{c, namespace}
+}
+
 func (c *FakeCertmanagerV1) ClusterIssuers() v1.ClusterIssuerInterface {
 	return &FakeClusterIssuers{c}
 }
@@ -44,6 +56,10 @@ func (c *FakeCertmanagerV1) Issuers(namespace string) v1.IssuerInterface {
 	return &FakeIssuers{c, namespace}
 }
 
+func (c *FakeCertmanagerV1) IssuerAliases(namespace string) v1.IssuerAliasInterface {
+	return &FakeIssuerAliases{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeCertmanagerV1) RESTClient() rest.Interface {
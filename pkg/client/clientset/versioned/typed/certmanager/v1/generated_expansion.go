@@ -20,8 +20,16 @@ package v1
 
 type CertificateExpansion interface{}
 
+type CertificateDefaultsExpansion interface{}
+
+type CertificateProfileExpansion interface{}
+
 type CertificateRequestExpansion interface{}
 
+type CertificateRevocationRequestExpansion interface{}
+
 type ClusterIssuerExpansion interface{}
 
+type IssuerAliasExpansion interface{}
+
 type IssuerExpansion interface{}
@@ -26,12 +26,20 @@ import (
 type Interface interface {
 	// Certificates returns a CertificateInformer.
 	Certificates() CertificateInformer
+	// CertificateDefaults returns a CertificateDefaultsInformer.
+	CertificateDefaults() CertificateDefaultsInformer
+	// CertificateProfiles returns a CertificateProfileInformer.
+	CertificateProfiles() CertificateProfileInformer
 	// CertificateRequests returns a CertificateRequestInformer.
 	CertificateRequests() CertificateRequestInformer
+	// CertificateRevocationRequests returns a CertificateRevocationRequestInformer.
+	CertificateRevocationRequests() CertificateRevocationRequestInformer
 	// ClusterIssuers returns a ClusterIssuerInformer.
 	ClusterIssuers() ClusterIssuerInformer
 	// Issuers returns a IssuerInformer.
 	Issuers() IssuerInformer
+	// IssuerAliases returns a IssuerAliasInformer.
+	IssuerAliases() IssuerAliasInformer
 }
 
 type version struct {
@@ -50,11 +58,26 @@ func (v *version) Certificates() CertificateInformer {
 	return &certificateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CertificateDefaults returns a CertificateDefaultsInformer.
+func (v *version) CertificateDefaults() CertificateDefaultsInformer {
+	return &certificateDefaultsInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// CertificateProfiles returns a CertificateProfileInformer.
+func (v *version) CertificateProfiles() CertificateProfileInformer {
+	return &certificateProfileInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // CertificateRequests returns a CertificateRequestInformer.
 func (v *version) CertificateRequests() CertificateRequestInformer {
 	return &certificateRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CertificateRevocationRequests returns a CertificateRevocationRequestInformer.
+func (v *version) CertificateRevocationRequests() CertificateRevocationRequestInformer {
+	return &certificateRevocationRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterIssuers returns a ClusterIssuerInformer.
 func (v *version) ClusterIssuers() ClusterIssuerInformer {
 	return &clusterIssuerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -64,3 +87,8 @@ func (v *version) ClusterIssuers() ClusterIssuerInformer {
 func (v *version) Issuers() IssuerInformer {
 	return &issuerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// IssuerAliases returns a IssuerAliasInformer.
+func (v *version) IssuerAliases() IssuerAliasInformer {
+	return &issuerAliasInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
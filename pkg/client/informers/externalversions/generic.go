@@ -62,12 +62,20 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=cert-manager.io, Version=v1
 	case certmanagerv1.SchemeGroupVersion.WithResource("certificates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().Certificates().Informer()}, nil
+	case certmanagerv1.SchemeGroupVersion.WithResource("certificatedefaults"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().CertificateDefaults().Informer()}, nil
+	case certmanagerv1.SchemeGroupVersion.WithResource("certificateprofiles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().CertificateProfiles().Informer()}, nil
 	case certmanagerv1.SchemeGroupVersion.WithResource("certificaterequests"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().CertificateRequests().Informer()}, nil
+	case certmanagerv1.SchemeGroupVersion.WithResource("certificaterevocationrequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().CertificateRevocationRequests().Informer()}, nil
 	case certmanagerv1.SchemeGroupVersion.WithResource("clusterissuers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().ClusterIssuers().Informer()}, nil
 	case certmanagerv1.SchemeGroupVersion.WithResource("issuers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().Issuers().Informer()}, nil
+	case certmanagerv1.SchemeGroupVersion.WithResource("issueraliases"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().IssuerAliases().Informer()}, nil
 
 	}
 
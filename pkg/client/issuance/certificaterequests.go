@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuance
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// RequestCertificate creates the CertificateRequest and waits for it to be
+// signed. The signed certificate is in the status of the returned
+// CertificateRequest.
+func (c *Client) RequestCertificate(ctx context.Context, cr *cmapi.CertificateRequest) (*cmapi.CertificateRequest, error) {
+	cr, err := c.CMClient.CertmanagerV1().CertificateRequests(cr.Namespace).Create(ctx, cr, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CertificateRequest: %w", err)
+	}
+	return c.WaitForCertificateRequestSigned(ctx, cr.Namespace, cr.Name)
+}
+
+// WaitForCertificateRequestSigned waits for the CertificateRequest to be
+// signed and returns it. It returns an error wrapping ErrDenied or
+// ErrFailed if the CertificateRequest is denied or fails, since it will
+// never be signed.
+func (c *Client) WaitForCertificateRequestSigned(ctx context.Context, namespace, name string) (*cmapi.CertificateRequest, error) {
+	var signed *cmapi.CertificateRequest
+	err := c.poll(ctx, func(ctx context.Context) (bool, string, error) {
+		cr, err := c.CMClient.CertmanagerV1().CertificateRequests(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		if cond := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionDenied); cond != nil && cond.Status == cmmeta.ConditionTrue {
+			return false, "", fmt.Errorf("%w: %s", ErrDenied, cond.Message)
+		}
+		cond := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady)
+		if cond != nil && cond.Reason == cmapi.CertificateRequestReasonFailed {
+			return false, "", fmt.Errorf("%w: %s", ErrFailed, cond.Message)
+		}
+		if len(cr.Status.Certificate) == 0 {
+			if cond != nil {
+				return false, cond.Message, nil
+			}
+			return false, "", nil
+		}
+		signed = cr
+		return true, "", nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for CertificateRequest %s/%s to be signed: %w", namespace, name, err)
+	}
+	return signed, nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestWaitForCertificateRequestSigned(t *testing.T) {
+	pending := gen.CertificateRequest("cr",
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:    cmapi.CertificateRequestConditionReady,
+			Status:  cmmeta.ConditionFalse,
+			Reason:  cmapi.CertificateRequestReasonPending,
+			Message: "Waiting on certificate issuance",
+		}),
+	)
+
+	tests := map[string]struct {
+		cr *cmapi.CertificateRequest
+		// signAfter signs the CertificateRequest after the given delay,
+		// if not zero.
+		signAfter time.Duration
+
+		expectedErr error
+	}{
+		"return a signed CertificateRequest": {
+			cr: gen.CertificateRequestFrom(pending, gen.SetCertificateRequestCertificate([]byte("cert"))),
+		},
+		"wait for the CertificateRequest to be signed": {
+			cr:        pending,
+			signAfter: 50 * time.Millisecond,
+		},
+		"return ErrDenied if the CertificateRequest is denied": {
+			cr: gen.CertificateRequestFrom(pending, gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:    cmapi.CertificateRequestConditionDenied,
+				Status:  cmmeta.ConditionTrue,
+				Reason:  "Denied",
+				Message: "not allowed",
+			})),
+			expectedErr: ErrDenied,
+		},
+		"return ErrFailed if the CertificateRequest failed": {
+			cr: gen.CertificateRequestFrom(pending, gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:    cmapi.CertificateRequestConditionReady,
+				Status:  cmmeta.ConditionFalse,
+				Reason:  cmapi.CertificateRequestReasonFailed,
+				Message: "issuer error",
+			})),
+			expectedErr: ErrFailed,
+		},
+		"time out if the CertificateRequest is not signed": {
+			cr:          pending,
+			expectedErr: context.DeadlineExceeded,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmClient := cmfake.NewSimpleClientset(test.cr)
+			c := NewClient(cmClient, nil)
+			c.PollInterval = 10 * time.Millisecond
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			if test.signAfter > 0 {
+				go func() {
+					time.Sleep(test.signAfter)
+					signed := gen.CertificateRequestFrom(test.cr, gen.SetCertificateRequestCertificate([]byte("cert")))
+					if _, err := cmClient.CertmanagerV1().CertificateRequests(signed.Namespace).UpdateStatus(ctx, signed, metav1.UpdateOptions{}); err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+
+			cr, err := c.WaitForCertificateRequestSigned(ctx, test.cr.Namespace, test.cr.Name)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected error %v, got %v", test.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if string(cr.Status.Certificate) != "cert" {
+				t.Errorf("expected the signed CertificateRequest, got %v", cr.Status)
+			}
+		})
+	}
+}
+
+func TestRequestCertificate(t *testing.T) {
+	cmClient := cmfake.NewSimpleClientset()
+	c := NewClient(cmClient, nil)
+	c.PollInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	go func() {
+		// Sign the CertificateRequest once it has been created.
+		for ctx.Err() == nil {
+			cr, err := cmClient.CertmanagerV1().CertificateRequests(gen.DefaultTestNamespace).Get(ctx, "cr", metav1.GetOptions{})
+			if err != nil {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			signed := gen.CertificateRequestFrom(cr, gen.SetCertificateRequestCertificate([]byte("cert")))
+			if _, err := cmClient.CertmanagerV1().CertificateRequests(signed.Namespace).UpdateStatus(ctx, signed, metav1.UpdateOptions{}); err != nil {
+				t.Error(err)
+			}
+			return
+		}
+	}()
+
+	cr, err := c.RequestCertificate(ctx, gen.CertificateRequest("cr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(cr.Status.Certificate) != "cert" {
+		t.Errorf("expected the signed CertificateRequest, got %v", cr.Status)
+	}
+}
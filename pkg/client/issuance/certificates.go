@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuance
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// reasonManuallyTriggered is the reason of the Issuing condition set to
+// renew a Certificate, which is the same as `cmctl renew`.
+const reasonManuallyTriggered = "ManuallyTriggered"
+
+// IssueCertificate creates the Certificate, waits for it to be issued and
+// returns it along with its Secret.
+func (c *Client) IssueCertificate(ctx context.Context, crt *cmapi.Certificate) (*cmapi.Certificate, *corev1.Secret, error) {
+	crt, err := c.CMClient.CertmanagerV1().Certificates(crt.Namespace).Create(ctx, crt, metav1.CreateOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Certificate: %w", err)
+	}
+	crt, err = c.WaitForCertificateReady(ctx, crt.Namespace, crt.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret, err := c.GetCertificateSecret(ctx, crt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return crt, secret, nil
+}
+
+// WaitForCertificateReady waits for the Certificate to be Ready for its
+// current generation, with no issuance in progress, and returns it.
+// It returns an error wrapping ErrFailed if the Certificate is not Ready
+// because its last issuance failed.
+func (c *Client) WaitForCertificateReady(ctx context.Context, namespace, name string) (*cmapi.Certificate, error) {
+	var ready *cmapi.Certificate
+	err := c.poll(ctx, func(ctx context.Context) (bool, string, error) {
+		crt, err := c.CMClient.CertmanagerV1().Certificates(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		done, status, err := certificateReady(crt)
+		if done {
+			ready = crt
+		}
+		return done, status, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for Certificate %s/%s to be ready: %w", namespace, name, err)
+	}
+	return ready, nil
+}
+
+// RenewCertificate triggers the issuance of a new certificate for the
+// Certificate, in the same way as `cmctl renew`, waits for it to be issued
+// and returns the Certificate. It returns an error wrapping ErrFailed if the
+// issuance fails.
+func (c *Client) RenewCertificate(ctx context.Context, namespace, name string) (*cmapi.Certificate, error) {
+	var revision int
+	var lastFailureTime *metav1.Time
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crt, err := c.CMClient.CertmanagerV1().Certificates(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		revision, lastFailureTime = 0, crt.Status.LastFailureTime
+		if crt.Status.Revision != nil {
+			revision = *crt.Status.Revision
+		}
+		crt = crt.DeepCopy()
+		apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, reasonManuallyTriggered, "Certificate re-issuance manually triggered")
+		_, err = c.CMClient.CertmanagerV1().Certificates(namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to trigger issuance of Certificate %s/%s: %w", namespace, name, err)
+	}
+
+	var renewed *cmapi.Certificate
+	err = c.poll(ctx, func(ctx context.Context) (bool, string, error) {
+		crt, err := c.CMClient.CertmanagerV1().Certificates(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		// The Certificate may still be Ready with its previous certificate
+		// if the renewal failed.
+		if crt.Status.LastFailureTime != nil && !crt.Status.LastFailureTime.Equal(lastFailureTime) {
+			return false, "", fmt.Errorf("%w: %s", ErrFailed, issuingMessage(crt))
+		}
+		if crt.Status.Revision == nil || *crt.Status.Revision <= revision {
+			return false, issuingMessage(crt), nil
+		}
+		done, status, err := certificateReady(crt)
+		if done {
+			renewed = crt
+		}
+		return done, status, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for Certificate %s/%s to be renewed: %w", namespace, name, err)
+	}
+	return renewed, nil
+}
+
+// GetCertificateSecret returns the Secret of a Ready Certificate, after
+// checking that it holds a private key and the certificate which was
+// issued for the Certificate.
+func (c *Client) GetCertificateSecret(ctx context.Context, crt *cmapi.Certificate) (*corev1.Secret, error) {
+	secret, err := c.KubeClient.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("Secret %s/%s of Certificate %s does not exist: %w", crt.Namespace, crt.Spec.SecretName, crt.Name, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Secret %s/%s of Certificate %s: %w", crt.Namespace, crt.Spec.SecretName, crt.Name, err)
+	}
+
+	if len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return nil, fmt.Errorf("Secret %s/%s of Certificate %s has no private key", secret.Namespace, secret.Name, crt.Name)
+	}
+	x509Cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, fmt.Errorf("Secret %s/%s of Certificate %s has an invalid certificate: %w", secret.Namespace, secret.Name, crt.Name, err)
+	}
+	// The Secret may be updated between the Certificate and the Secret
+	// being read, in which case it holds a newer certificate.
+	if crt.Status.NotAfter != nil && x509Cert.NotAfter.Before(crt.Status.NotAfter.Time) {
+		return nil, fmt.Errorf("Secret %s/%s of Certificate %s holds a certificate older than the one issued", secret.Namespace, secret.Name, crt.Name)
+	}
+
+	return secret, nil
+}
+
+// certificateReady returns true if the Certificate is Ready for its current
+// generation and no issuance is in progress. Otherwise it returns the
+// message of its conditions.
+func certificateReady(crt *cmapi.Certificate) (bool, string, error) {
+	issuing := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	if issuing != nil && issuing.Status == cmmeta.ConditionTrue {
+		return false, issuing.Message, nil
+	}
+
+	if apiutil.CertificateHasConditionWithObservedGeneration(crt, cmapi.CertificateCondition{
+		Type:               cmapi.CertificateConditionReady,
+		Status:             cmmeta.ConditionTrue,
+		ObservedGeneration: crt.Generation,
+	}) {
+		return true, "", nil
+	}
+
+	if issuing != nil && crt.Status.LastFailureTime != nil {
+		return false, "", fmt.Errorf("%w: %s", ErrFailed, issuing.Message)
+	}
+	if ready := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); ready != nil {
+		return false, ready.Message, nil
+	}
+	return false, "", nil
+}
+
+// issuingMessage returns the message of the Issuing condition of the
+// Certificate, if any.
+func issuingMessage(crt *cmapi.Certificate) string {
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
+		return cond.Message
+	}
+	return ""
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var (
+	readyCondition = cmapi.CertificateCondition{
+		Type:               cmapi.CertificateConditionReady,
+		Status:             cmmeta.ConditionTrue,
+		ObservedGeneration: 2,
+	}
+	issuedCondition = cmapi.CertificateCondition{
+		Type:    cmapi.CertificateConditionIssuing,
+		Status:  cmmeta.ConditionFalse,
+		Reason:  "Issued",
+		Message: "The certificate has been successfully issued",
+	}
+	failedCondition = cmapi.CertificateCondition{
+		Type:    cmapi.CertificateConditionIssuing,
+		Status:  cmmeta.ConditionFalse,
+		Reason:  cmapi.CertificateRequestReasonFailed,
+		Message: "The certificate request has failed to complete and will be retried: issuer error",
+	}
+)
+
+func TestWaitForCertificateReady(t *testing.T) {
+	failureTime := metav1.NewTime(time.Now())
+	baseCrt := gen.Certificate("crt", gen.SetCertificateGeneration(2))
+
+	tests := map[string]struct {
+		crt         *cmapi.Certificate
+		expectedErr error
+	}{
+		"return a Ready Certificate": {
+			crt: gen.CertificateFrom(baseCrt, gen.SetCertificateStatusCondition(readyCondition)),
+		},
+		"time out if the Certificate is Ready for a previous generation": {
+			crt: gen.CertificateFrom(baseCrt, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				ObservedGeneration: 1,
+			})),
+			expectedErr: context.DeadlineExceeded,
+		},
+		"time out if the Certificate is being issued": {
+			crt: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateStatusCondition(readyCondition),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:   cmapi.CertificateConditionIssuing,
+					Status: cmmeta.ConditionTrue,
+				}),
+			),
+			expectedErr: context.DeadlineExceeded,
+		},
+		"return ErrFailed if the issuance of a Certificate which is not Ready failed": {
+			crt: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateStatusCondition(failedCondition),
+				gen.SetCertificateLastFailureTime(failureTime),
+			),
+			expectedErr: ErrFailed,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := NewClient(cmfake.NewSimpleClientset(test.crt), nil)
+			c.PollInterval = 10 * time.Millisecond
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			_, err := c.WaitForCertificateReady(ctx, test.crt.Namespace, test.crt.Name)
+			if !errors.Is(err, test.expectedErr) {
+				t.Errorf("expected error %v, got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRenewCertificate(t *testing.T) {
+	failureTime := metav1.NewTime(time.Now())
+	crt := gen.Certificate("crt",
+		gen.SetCertificateGeneration(2),
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateStatusCondition(readyCondition),
+		gen.SetCertificateStatusCondition(issuedCondition),
+	)
+
+	tests := map[string]struct {
+		// issue simulates the outcome of the issuance triggered by the
+		// renewal.
+		issue       func(*cmapi.Certificate) *cmapi.Certificate
+		expectedErr error
+	}{
+		"wait for the Certificate to be renewed": {
+			issue: func(crt *cmapi.Certificate) *cmapi.Certificate {
+				return gen.CertificateFrom(crt,
+					gen.SetCertificateRevision(2),
+					gen.SetCertificateStatusCondition(issuedCondition),
+				)
+			},
+		},
+		"return ErrFailed if the renewal failed": {
+			issue: func(crt *cmapi.Certificate) *cmapi.Certificate {
+				return gen.CertificateFrom(crt,
+					gen.SetCertificateStatusCondition(failedCondition),
+					gen.SetCertificateLastFailureTime(failureTime),
+				)
+			},
+			expectedErr: ErrFailed,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmClient := cmfake.NewSimpleClientset(crt)
+			c := NewClient(cmClient, nil)
+			c.PollInterval = 10 * time.Millisecond
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			go func() {
+				// Complete the issuance once it has been triggered.
+				for ctx.Err() == nil {
+					current, err := cmClient.CertmanagerV1().Certificates(crt.Namespace).Get(ctx, crt.Name, metav1.GetOptions{})
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					if certificateIssuing(current) {
+						if _, err := cmClient.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, test.issue(current), metav1.UpdateOptions{}); err != nil {
+							t.Error(err)
+						}
+						return
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+			}()
+
+			renewed, err := c.RenewCertificate(ctx, crt.Namespace, crt.Name)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("expected error %v, got %v", test.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if *renewed.Status.Revision != 2 {
+				t.Errorf("expected the renewed Certificate, got revision %d", *renewed.Status.Revision)
+			}
+		})
+	}
+}
+
+func certificateIssuing(crt *cmapi.Certificate) bool {
+	for _, cond := range crt.Status.Conditions {
+		if cond.Type == cmapi.CertificateConditionIssuing {
+			return cond.Status == cmmeta.ConditionTrue
+		}
+	}
+	return false
+}
+
+func TestGetCertificateSecret(t *testing.T) {
+	crt := gen.Certificate("crt",
+		gen.SetCertificateSecretName("crt-tls"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fakeclock.NewFakeClock(time.Now()))
+	crt = gen.CertificateFrom(crt, gen.SetCertificateNotAfter(metav1.NewTime(bundle.Cert.NotAfter)))
+
+	secret := func(data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: "crt-tls"},
+			Data:       data,
+		}
+	}
+
+	tests := map[string]struct {
+		secret      *corev1.Secret
+		crt         *cmapi.Certificate
+		expectedErr bool
+	}{
+		"return the Secret of the Certificate": {
+			secret: secret(map[string][]byte{corev1.TLSCertKey: bundle.CertBytes, corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes}),
+			crt:    crt,
+		},
+		"error if the Secret does not exist": {
+			crt:         crt,
+			expectedErr: true,
+		},
+		"error if the Secret has no private key": {
+			secret:      secret(map[string][]byte{corev1.TLSCertKey: bundle.CertBytes}),
+			crt:         crt,
+			expectedErr: true,
+		},
+		"error if the Secret holds an older certificate": {
+			secret:      secret(map[string][]byte{corev1.TLSCertKey: bundle.CertBytes, corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes}),
+			crt:         gen.CertificateFrom(crt, gen.SetCertificateNotAfter(metav1.NewTime(bundle.Cert.NotAfter.Add(time.Hour)))),
+			expectedErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var objects []runtime.Object
+			if test.secret != nil {
+				objects = append(objects, test.secret)
+			}
+			c := NewClient(cmfake.NewSimpleClientset(), kubefake.NewSimpleClientset(objects...))
+
+			got, err := c.GetCertificateSecret(context.Background(), test.crt)
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("expected error %t, got %v", test.expectedErr, err)
+			}
+			if err == nil && got.Name != "crt-tls" {
+				t.Errorf("unexpected Secret %s", got.Name)
+			}
+			if test.secret == nil && !apierrors.IsNotFound(errors.Unwrap(err)) {
+				t.Errorf("expected a not found error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package issuance provides helpers to issue certificates with cert-manager
+// from Go programs, such as operators and platform controllers, without
+// having to reimplement the logic of waiting for Certificates and
+// CertificateRequests.
+//
+// All functions block until the operation has completed or the context is
+// done, so a timeout is set with context.WithTimeout. Resources are polled
+// rather than watched, so that callers do not need permission to watch
+// them.
+package issuance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+)
+
+// DefaultPollInterval is the interval at which resources are polled while
+// waiting for them, if the Client does not set one.
+const DefaultPollInterval = time.Second
+
+var (
+	// ErrDenied is returned, wrapped, when a CertificateRequest has been
+	// denied by an approver.
+	ErrDenied = errors.New("request denied")
+	// ErrFailed is returned, wrapped, when a CertificateRequest or the
+	// issuance of a Certificate has failed. cert-manager retries failed
+	// issuances of Certificates after a backoff, so waiting for the
+	// Certificate again may succeed.
+	ErrFailed = errors.New("issuance failed")
+)
+
+// Client issues certificates using a cert-manager clientset, and reads the
+// Secrets of Certificates using a Kubernetes clientset.
+type Client struct {
+	CMClient   cmclient.Interface
+	KubeClient kubernetes.Interface
+
+	// PollInterval is the interval at which resources are polled while
+	// waiting for them. Defaults to DefaultPollInterval.
+	PollInterval time.Duration
+}
+
+// NewClient returns a Client using the given clientsets. The Kubernetes
+// clientset is only used to read the Secrets of Certificates, and may be
+// nil if only CertificateRequests are used.
+func NewClient(cmClient cmclient.Interface, kubeClient kubernetes.Interface) *Client {
+	return &Client{
+		CMClient:   cmClient,
+		KubeClient: kubeClient,
+	}
+}
+
+// poll calls condition immediately, then at every poll interval, until it
+// returns true or an error, or the context is done. If the context is done,
+// the returned error includes the last status reported by condition.
+func (c *Client) poll(ctx context.Context, condition func(ctx context.Context) (done bool, status string, err error)) error {
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	var lastStatus string
+	err := wait.PollImmediateUntilWithContext(ctx, interval, func(ctx context.Context) (bool, error) {
+		done, status, err := condition(ctx)
+		lastStatus = status
+		return done, err
+	})
+	if err != nil && ctx.Err() != nil && lastStatus != "" {
+		return fmt.Errorf("%w: %s", ctx.Err(), lastStatus)
+	}
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
@@ -26,6 +26,14 @@ type CertificateListerExpansion interface{}
 // CertificateNamespaceLister.
 type CertificateNamespaceListerExpansion interface{}
 
+// CertificateDefaultsListerExpansion allows custom methods to be added to
+// CertificateDefaultsLister.
+type CertificateDefaultsListerExpansion interface{}
+
+// CertificateProfileListerExpansion allows custom methods to be added to
+// CertificateProfileLister.
+type CertificateProfileListerExpansion interface{}
+
 // CertificateRequestListerExpansion allows custom methods to be added to
 // CertificateRequestLister.
 type CertificateRequestListerExpansion interface{}
@@ -34,10 +42,26 @@ type CertificateRequestListerExpansion interface{}
 // CertificateRequestNamespaceLister.
 type CertificateRequestNamespaceListerExpansion interface{}
 
+// CertificateRevocationRequestListerExpansion allows custom methods to be added to
+// CertificateRevocationRequestLister.
+type CertificateRevocationRequestListerExpansion interface{}
+
+// CertificateRevocationRequestNamespaceListerExpansion allows custom methods to be added to
+// CertificateRevocationRequestNamespaceLister.
+type CertificateRevocationRequestNamespaceListerExpansion interface{}
+
 // ClusterIssuerListerExpansion allows custom methods to be added to
 // ClusterIssuerLister.
 type ClusterIssuerListerExpansion interface{}
 
+// IssuerAliasListerExpansion allows custom methods to be added to
+// IssuerAliasLister.
+type IssuerAliasListerExpansion interface{}
+
+// IssuerAliasNamespaceListerExpansion allows custom methods to be added to
+// IssuerAliasNamespaceLister.
+type IssuerAliasNamespaceListerExpansion interface{}
+
 // IssuerListerExpansion allows custom methods to be added to
 // IssuerLister.
 type IssuerListerExpansion interface{}
@@ -65,6 +65,9 @@ type controller struct {
 	// used to record Events about resources to the API
 	recorder record.EventRecorder
 
+	// records where time is spent while solving challenges
+	challengeMetrics *challengeMetrics
+
 	// maintain a reference to the workqueue for this controller
 	// so the handleOwnedResource method can enqueue resources
 	queue workqueue.RateLimitingInterface
@@ -86,12 +89,12 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.log = logf.FromContext(ctx.RootContext, ControllerName)
 
 	// create a queue used to queue up items to be processed
-	c.queue = workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*30), ControllerName)
+	c.queue = workqueue.NewNamedRateLimitingQueue(ctx.RateLimiterFor(ControllerName, time.Second*5, time.Minute*30), ControllerName)
 
 	// obtain references to all the informers used by this controller
 	challengeInformer := ctx.SharedInformerFactory.Acme().V1().Challenges()
 	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
-	secretInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
+	secretInformer := controllerpkg.SecretsMetadataInformer(ctx.KubeSharedInformerFactory)
 	// we register these informers here so the HTTP01 solver has a synced
 	// cache when managing pod/service/ingress resources
 	podInformer := ctx.KubeSharedInformerFactory.Core().V1().Pods()
@@ -103,11 +106,14 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	mustSync := []cache.InformerSynced{
 		challengeInformer.Informer().HasSynced,
 		issuerInformer.Informer().HasSynced,
-		secretInformer.Informer().HasSynced,
+		secretInformer.HasSynced,
 		podInformer.Informer().HasSynced,
 		serviceInformer.Informer().HasSynced,
 		ingressInformer.Informer().HasSynced,
 	}
+	// the HTTP01 solver also caches the Deployments and ConfigMaps of its
+	// burst solvers
+	mustSync = append(mustSync, http.BurstSolverInformersSynced(ctx)...)
 
 	if ctx.GatewaySolverEnabled {
 		gwAPIHTTPRouteInformer := ctx.GWShared.Gateway().V1alpha2().HTTPRoutes()
@@ -117,7 +123,7 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// set all the references to the listers for used by the Sync function
 	c.challengeLister = challengeInformer.Lister()
 	c.issuerLister = issuerInformer.Lister()
-	c.secretLister = secretInformer.Lister()
+	c.secretLister = controllerpkg.NewSecretGetter(ctx.KubeSharedInformerFactory)
 
 	// if we are running in non-namespaced mode (i.e. --namespace=""), we also
 	// register event handlers and obtain a lister for clusterissuers.
@@ -133,6 +139,7 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.helper = issuer.NewHelper(c.issuerLister, c.clusterIssuerLister)
 	c.scheduler = scheduler.New(logf.NewContext(ctx.RootContext, c.log), c.challengeLister, ctx.SchedulerOptions.MaxConcurrentChallenges)
 	c.recorder = ctx.Recorder
+	c.challengeMetrics = newChallengeMetrics(ctx.Metrics, ctx.Clock, podInformer.Lister())
 	c.accountRegistry = ctx.ACMEOptions.AccountRegistry
 
 	var err error
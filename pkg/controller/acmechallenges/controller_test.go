@@ -21,7 +21,6 @@ import (
 	"testing"
 
 	"k8s.io/apimachinery/pkg/runtime"
-	coretesting "k8s.io/client-go/testing"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
@@ -53,22 +52,18 @@ func TestRunScheduler(t *testing.T) {
 					),
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(
-						coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
-							"status",
-							gen.DefaultTestNamespace,
-							gen.Challenge("ch1",
-								gen.SetChallengeDNSName("host1.example.com"),
-								gen.SetChallengeProcessing(true),
-							))),
-					testpkg.NewAction(
-						coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
-							"status",
-							gen.DefaultTestNamespace,
-							gen.Challenge("ch2",
-								gen.SetChallengeDNSName("host2.example.com"),
-								gen.SetChallengeProcessing(true),
-							))),
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						gen.DefaultTestNamespace,
+						gen.Challenge("ch1",
+							gen.SetChallengeDNSName("host1.example.com"),
+							gen.SetChallengeProcessing(true),
+						)),
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						gen.DefaultTestNamespace,
+						gen.Challenge("ch2",
+							gen.SetChallengeDNSName("host2.example.com"),
+							gen.SetChallengeProcessing(true),
+						)),
 				},
 				ExpectedEvents: []string{
 					"Normal Started Challenge scheduled for processing",
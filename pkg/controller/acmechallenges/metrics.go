@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+// challengeMetrics records where time is spent while solving challenges.
+// The time at which each challenge was presented is only kept in memory, so
+// the propagation wait of challenges presented before the controller was
+// restarted is not observed.
+type challengeMetrics struct {
+	metrics   *metrics.Metrics
+	clock     clock.Clock
+	podLister corelisters.PodLister
+
+	lock        sync.Mutex
+	presentedAt map[types.UID]time.Time
+}
+
+func newChallengeMetrics(m *metrics.Metrics, clock clock.Clock, podLister corelisters.PodLister) *challengeMetrics {
+	return &challengeMetrics{
+		metrics:     m,
+		clock:       clock,
+		podLister:   podLister,
+		presentedAt: make(map[types.UID]time.Time),
+	}
+}
+
+// presented records that the challenge was presented now.
+func (m *challengeMetrics) presented(ch *cmacme.Challenge) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.presentedAt[ch.UID] = m.clock.Now()
+}
+
+// presentFailed records that presenting the challenge failed and will be
+// retried.
+func (m *challengeMetrics) presentFailed(ch *cmacme.Challenge) {
+	m.metrics.IncrementACMEChallengeRetryCount(string(ch.Spec.Type), solverProvider(ch), metrics.ChallengeRetryReasonPresent)
+}
+
+// selfChecked records a self check of the challenge which started at the
+// given time. Once the self check has passed, the time taken for the
+// challenge to propagate, and for an HTTP01 solver pod to become ready, are
+// observed.
+func (m *challengeMetrics) selfChecked(ch *cmacme.Challenge, start time.Time, err error) {
+	challengeType, provider := string(ch.Spec.Type), solverProvider(ch)
+	now := m.clock.Now()
+	m.metrics.ObserveACMEChallengeSelfCheck(challengeType, provider, now.Sub(start), err)
+
+	if err != nil {
+		m.metrics.IncrementACMEChallengeRetryCount(challengeType, provider, metrics.ChallengeRetryReasonSelfCheck)
+		return
+	}
+
+	m.lock.Lock()
+	presentedAt, ok := m.presentedAt[ch.UID]
+	delete(m.presentedAt, ch.UID)
+	m.lock.Unlock()
+	if !ok {
+		return
+	}
+
+	m.metrics.ObserveACMEChallengePropagationWait(challengeType, provider, now.Sub(presentedAt))
+	if ch.Spec.Type == cmacme.ACMEChallengeTypeHTTP01 {
+		if pending, ok := m.solverPodPending(ch); ok {
+			m.metrics.ObserveACMEChallengeSolverPodPending(provider, pending)
+		}
+	}
+}
+
+// forget removes any state kept about the challenge.
+func (m *challengeMetrics) forget(ch *cmacme.Challenge) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.presentedAt, ch.UID)
+}
+
+// solverPodPending returns the time taken for the challenge's HTTP01 solver
+// pod to become ready, and false if there is no ready solver pod.
+func (m *challengeMetrics) solverPodPending(ch *cmacme.Challenge) (time.Duration, bool) {
+	if m.podLister == nil {
+		return 0, false
+	}
+	pods, err := m.podLister.Pods(ch.Namespace).List(labels.SelectorFromSet(labels.Set{
+		cmacme.SolverIdentificationLabelKey: "true",
+	}))
+	if err != nil {
+		return 0, false
+	}
+	for _, pod := range pods {
+		if !metav1.IsControlledBy(pod, ch) {
+			continue
+		}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+				return cond.LastTransitionTime.Sub(pod.CreationTimestamp.Time), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// solverProvider returns the name of the mechanism used to solve the
+// challenge, e.g. the DNS provider of DNS01 challenges.
+func solverProvider(ch *cmacme.Challenge) string {
+	solver := ch.Spec.Solver
+	switch {
+	case solver.HTTP01 != nil && solver.HTTP01.Ingress != nil:
+		return "ingress"
+	case solver.HTTP01 != nil && solver.HTTP01.GatewayHTTPRoute != nil:
+		return "gatewayHTTPRoute"
+	case solver.DNS01 == nil:
+		return "unknown"
+	case solver.DNS01.Akamai != nil:
+		return "akamai"
+	case solver.DNS01.CloudDNS != nil:
+		return "cloudDNS"
+	case solver.DNS01.Cloudflare != nil:
+		return "cloudflare"
+	case solver.DNS01.Route53 != nil:
+		return "route53"
+	case solver.DNS01.AzureDNS != nil:
+		return "azureDNS"
+	case solver.DNS01.DigitalOcean != nil:
+		return "digitalocean"
+	case solver.DNS01.Hetzner != nil:
+		return "hetzner"
+	case solver.DNS01.AcmeDNS != nil:
+		return "acmeDNS"
+	case solver.DNS01.RFC2136 != nil:
+		return "rfc2136"
+	case solver.DNS01.ExternalDNS != nil:
+		return "externalDNS"
+	case solver.DNS01.Webhook != nil:
+		return "webhook/" + solver.DNS01.Webhook.SolverName
+	}
+	return "unknown"
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSolverProvider(t *testing.T) {
+	tests := map[string]struct {
+		solver cmacme.ACMEChallengeSolver
+		exp    string
+	}{
+		"http01 ingress": {
+			solver: cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{}}},
+			exp:    "ingress",
+		},
+		"http01 gateway": {
+			solver: cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{}}},
+			exp:    "gatewayHTTPRoute",
+		},
+		"dns01 route53": {
+			solver: cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{}}},
+			exp:    "route53",
+		},
+		"dns01 webhook includes the solver name": {
+			solver: cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{SolverName: "example"}}},
+			exp:    "webhook/example",
+		},
+		"no solver configured": {
+			exp: "unknown",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ch := gen.Challenge("test")
+			ch.Spec.Solver = test.solver
+			assert.Equal(t, test.exp, solverProvider(ch))
+		})
+	}
+}
+
+func TestChallengeMetricsPresentedAt(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	m := newChallengeMetrics(metrics.New(logtesting.NewTestLogger(t), clock), clock, nil)
+
+	ch := gen.Challenge("test", gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01))
+	ch.UID = types.UID("uid")
+
+	m.presented(ch)
+	clock.Step(time.Second)
+	m.selfChecked(ch, clock.Now(), errors.New("not propagated"))
+	assert.Contains(t, m.presentedAt, ch.UID, "expected presented time to be kept until the self check passes")
+
+	m.selfChecked(ch, clock.Now(), nil)
+	assert.NotContains(t, m.presentedAt, ch.UID)
+
+	m.presented(ch)
+	m.forget(ch)
+	assert.NotContains(t, m.presentedAt, ch.UID)
+}
+
+func TestChallengeMetricsSolverPodPending(t *testing.T) {
+	created := time.Now().Truncate(time.Second)
+	ch := gen.Challenge("test", gen.SetChallengeNamespace("default"), gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01))
+	ch.UID = types.UID("uid")
+
+	solverPod := func(name string, owner *cmacme.Challenge, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				Labels:            map[string]string{cmacme.SolverIdentificationLabelKey: "true"},
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+				Type:               corev1.PodReady,
+				Status:             status,
+				LastTransitionTime: metav1.NewTime(created.Add(7 * time.Second)),
+			}}},
+		}
+		if owner != nil {
+			pod.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(owner, cmacme.SchemeGroupVersion.WithKind("Challenge"))}
+		}
+		return pod
+	}
+
+	other := ch.DeepCopy()
+	other.UID = types.UID("other")
+
+	tests := map[string]struct {
+		pods    []*corev1.Pod
+		exp     time.Duration
+		expOkay bool
+	}{
+		"ready solver pod": {
+			pods:    []*corev1.Pod{solverPod("ready", ch, true)},
+			exp:     7 * time.Second,
+			expOkay: true,
+		},
+		"solver pod not ready": {
+			pods: []*corev1.Pod{solverPod("pending", ch, false)},
+		},
+		"solver pod of another challenge": {
+			pods: []*corev1.Pod{solverPod("other", other, true)},
+		},
+		"no solver pods": {},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, pod := range test.pods {
+				if err := indexer.Add(pod); err != nil {
+					t.Fatal(err)
+				}
+			}
+			clock := fakeclock.NewFakeClock(created)
+			m := newChallengeMetrics(metrics.New(logtesting.NewTestLogger(t), clock), clock, corelisters.NewPodLister(indexer))
+
+			pending, ok := m.solverPodPending(ch)
+			assert.Equal(t, test.expOkay, ok)
+			assert.Equal(t, test.exp, pending)
+		})
+	}
+}
@@ -21,12 +21,14 @@ import (
 	"errors"
 	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
 	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme"
+	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -34,6 +36,7 @@ import (
 	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/tracing"
 )
 
 const (
@@ -64,6 +67,15 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 	ctx = logf.NewContext(ctx, log)
 	ch := chOriginal.DeepCopy()
 
+	ctx, span := tracing.Start(ctx, ch, "Challenge.Sync",
+		attribute.String("cert-manager.acme.challenge.type", string(ch.Spec.Type)),
+		attribute.String("cert-manager.acme.challenge.dns_name", ch.Spec.DNSName),
+	)
+	defer func() {
+		span.SetAttributes(attribute.String("cert-manager.acme.challenge.state", string(ch.Status.State)))
+		tracing.End(span, err)
+	}()
+
 	defer func() {
 		if updateError := c.updateObject(ctx, chOriginal, ch); updateError != nil {
 			if errors.Is(updateError, argumentError) {
@@ -119,11 +131,12 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 		}
 
 		ch.Status.Processing = false
+		c.challengeMetrics.forget(ch)
 
 		return nil
 	}
 
-	cl, err := c.accountRegistry.GetClient(string(genericIssuer.GetUID()))
+	cl, err := c.accountRegistry.GetClient(accounts.ClientUID(genericIssuer, ch.Namespace))
 	if err != nil {
 		return err
 	}
@@ -178,14 +191,18 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 		if err != nil {
 			c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonPresentError, "Error presenting challenge: %v", err)
 			ch.Status.Reason = err.Error()
+			c.challengeMetrics.presentFailed(ch)
 			return err
 		}
 
 		ch.Status.Presented = true
+		c.challengeMetrics.presented(ch)
 		c.recorder.Eventf(ch, corev1.EventTypeNormal, reasonPresented, "Presented challenge using %s challenge mechanism", ch.Spec.Type)
 	}
 
+	checkStart := c.challengeMetrics.clock.Now()
 	err = solver.Check(ctx, genericIssuer, ch)
+	c.challengeMetrics.selfChecked(ch, checkStart, err)
 	if err != nil {
 		log.Error(err, "propagation check failed")
 		ch.Status.Reason = fmt.Sprintf("Waiting for %s challenge propagation: %s", ch.Spec.Type, err)
@@ -260,6 +277,8 @@ func (c *controller) handleFinalizer(ctx context.Context, ch *cmacme.Challenge)
 		ch.Finalizers = ch.Finalizers[1:]
 	}()
 
+	c.challengeMetrics.forget(ch)
+
 	if !ch.Status.Processing {
 		return nil
 	}
@@ -411,9 +430,9 @@ func (c *controller) handleAuthorizationError(ch *cmacme.Challenge, err error) e
 func (c *controller) solverFor(challengeType cmacme.ACMEChallengeType) (solver, error) {
 	switch challengeType {
 	case cmacme.ACMEChallengeTypeHTTP01:
-		return c.httpSolver, nil
+		return tracedSolver{c.httpSolver}, nil
 	case cmacme.ACMEChallengeTypeDNS01:
-		return c.dnsSolver, nil
+		return tracedSolver{c.dnsSolver}, nil
 	}
 	return nil, fmt.Errorf("no solver for %q implemented", challengeType)
 }
@@ -154,17 +154,15 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeFinalizers([]string{}),
 							gen.SetChallengeReason(simulatedCleanupError.Error()),
 						))),
-					testpkg.NewAction(
-						coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
-							"status",
-							gen.DefaultTestNamespace,
-							gen.ChallengeFrom(deletedChallenge,
-								gen.SetChallengeProcessing(true),
-								gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
-								gen.SetChallengeURL("testurl"),
-								gen.SetChallengeFinalizers([]string{}),
-								gen.SetChallengeReason(simulatedCleanupError.Error()),
-							))),
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(deletedChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeFinalizers([]string{}),
+							gen.SetChallengeReason(simulatedCleanupError.Error()),
+						)),
 				},
 				ExpectedEvents: []string{
 					fmt.Sprintf("Warning CleanUpError Error cleaning up challenge: %s", simulatedCleanupError),
@@ -227,15 +225,13 @@ func TestSyncHappyPath(t *testing.T) {
 					gen.SetChallengeURL("testurl"),
 				), testIssuerHTTP01Enabled},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(
-						coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
-							"status",
-							gen.DefaultTestNamespace,
-							gen.ChallengeFrom(baseChallenge,
-								gen.SetChallengeProcessing(true),
-								gen.SetChallengeURL("testurl"),
-								gen.SetChallengeState(cmacme.Ready),
-							))),
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Ready),
+						)),
 				},
 			},
 			acmeClient: &acmecl.FakeACME{
@@ -259,15 +255,13 @@ func TestSyncHappyPath(t *testing.T) {
 					gen.SetChallengeURL("testurl"),
 				), testIssuerHTTP01Enabled},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(
-						coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
-							"status",
-							gen.DefaultTestNamespace,
-							gen.ChallengeFrom(baseChallenge,
-								gen.SetChallengeProcessing(true),
-								gen.SetChallengeURL("testurl"),
-								gen.SetChallengeState(cmacme.Pending),
-							))),
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Pending),
+						)),
 				},
 			},
 			acmeClient: &acmecl.FakeACME{
@@ -303,8 +297,7 @@ func TestSyncHappyPath(t *testing.T) {
 					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 				), testIssuerHTTP01Enabled},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
-						"status",
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
 						gen.DefaultTestNamespace,
 						gen.ChallengeFrom(baseChallenge,
 							gen.SetChallengeProcessing(true),
@@ -313,7 +306,7 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengePresented(true),
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengeReason("Waiting for HTTP-01 challenge propagation: some error"),
-						))),
+						)),
 				},
 				ExpectedEvents: []string{
 					"Normal Presented Presented challenge using HTTP-01 challenge mechanism",
@@ -347,8 +340,7 @@ func TestSyncHappyPath(t *testing.T) {
 					gen.SetChallengePresented(true),
 				), testIssuerHTTP01Enabled},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
-						"status",
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
 						gen.DefaultTestNamespace,
 						gen.ChallengeFrom(baseChallenge,
 							gen.SetChallengeProcessing(true),
@@ -358,7 +350,7 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengePresented(true),
 							gen.SetChallengeReason("Successfully authorized domain"),
-						))),
+						)),
 				},
 				ExpectedEvents: []string{
 					`Normal DomainVerified Domain "test.com" verified with "HTTP-01" validation`,
@@ -401,8 +393,7 @@ func TestSyncHappyPath(t *testing.T) {
 					gen.SetChallengePresented(true),
 				), testIssuerHTTP01Enabled},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
-						"status",
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
 						gen.DefaultTestNamespace,
 						gen.ChallengeFrom(baseChallenge,
 							gen.SetChallengeProcessing(true),
@@ -411,7 +402,7 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengePresented(true),
 							gen.SetChallengeReason("Error accepting authorization: acme: authorization error for example.com: an error happened"),
-						))),
+						)),
 				},
 				ExpectedEvents: []string{
 					"Warning Failed Accepting challenge authorization failed: acme: authorization error for example.com: an error happened",
@@ -460,8 +451,7 @@ func TestSyncHappyPath(t *testing.T) {
 					gen.SetChallengePresented(true),
 				), testIssuerHTTP01Enabled},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
-						"status",
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
 						gen.DefaultTestNamespace,
 						gen.ChallengeFrom(baseChallenge,
 							gen.SetChallengeProcessing(true),
@@ -470,7 +460,7 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengePresented(true),
 							gen.SetChallengeReason("Error accepting authorization: acme: authorization error for example.com: 400 fakeerror: this is a very detailed error"),
-						))),
+						)),
 				},
 				ExpectedEvents: []string{
 					"Warning Failed Accepting challenge authorization failed: acme: authorization error for example.com: 400 fakeerror: this is a very detailed error",
@@ -520,8 +510,7 @@ func TestSyncHappyPath(t *testing.T) {
 					gen.SetChallengePresented(true),
 				), testIssuerHTTP01Enabled},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
-						"status",
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
 						gen.DefaultTestNamespace,
 						gen.ChallengeFrom(baseChallenge,
 							gen.SetChallengeProcessing(false),
@@ -529,7 +518,7 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeState(cmacme.Valid),
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengePresented(false),
-						))),
+						)),
 				},
 			},
 		},
@@ -555,8 +544,7 @@ func TestSyncHappyPath(t *testing.T) {
 					gen.SetChallengePresented(true),
 				), testIssuerHTTP01Enabled},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
-						"status",
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
 						gen.DefaultTestNamespace,
 						gen.ChallengeFrom(baseChallenge,
 							gen.SetChallengeProcessing(false),
@@ -564,7 +552,7 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeState(cmacme.Invalid),
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengePresented(false),
-						))),
+						)),
 				},
 			},
 		},
@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"context"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/tracing"
+)
+
+// tracedSolver records a span for every call to the wrapped solver.
+type tracedSolver struct {
+	solver
+}
+
+func (s tracedSolver) Present(ctx context.Context, issuer cmapi.GenericIssuer, ch *cmacme.Challenge) (err error) {
+	ctx, span := tracing.Start(ctx, ch, "Challenge.Present")
+	defer func() { tracing.End(span, err) }()
+	return s.solver.Present(ctx, issuer, ch)
+}
+
+func (s tracedSolver) Check(ctx context.Context, issuer cmapi.GenericIssuer, ch *cmacme.Challenge) (err error) {
+	ctx, span := tracing.Start(ctx, ch, "Challenge.Check")
+	defer func() { tracing.End(span, err) }()
+	return s.solver.Check(ctx, issuer, ch)
+}
+
+func (s tracedSolver) CleanUp(ctx context.Context, issuer cmapi.GenericIssuer, ch *cmacme.Challenge) (err error) {
+	ctx, span := tracing.Start(ctx, ch, "Challenge.CleanUp")
+	defer func() { tracing.End(span, err) }()
+	return s.solver.CleanUp(ctx, issuer, ch)
+}
@@ -52,7 +52,7 @@ type defaultObjectUpdater struct {
 
 func newObjectUpdater(cl versioned.Interface, fieldManager string) objectUpdater {
 	o := &defaultObjectUpdater{
-		objectUpdateClient: &objectUpdateClientDefault{cl: cl},
+		objectUpdateClient: &objectUpdateClientDefault{cl: cl, fieldManager: fieldManager},
 	}
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
 		o.objectUpdateClient = &objectUpdateClientSSA{
@@ -64,8 +64,8 @@ func newObjectUpdater(cl versioned.Interface, fieldManager string) objectUpdater
 }
 
 // updateObject updates the Finalizers if they have changed and updates the Status if it has changed.
-// Finalizers are updated using the Update method while Status is updated using
-// the UpdateStatus method.
+// Finalizers are updated using the Update method, or applied if the
+// ServerSideApply feature is enabled, while Status is always applied.
 // Both updates will be attempted, even if one fails, except in the case where
 // one of the updates fails with a Not Found error.
 // If the any of the API operations results in a Not Found error, updateObject
@@ -113,14 +113,21 @@ func (o *defaultObjectUpdater) updateObject(ctx context.Context, old, new *cmacm
 				return nil
 			}
 		} else {
+			// The returned object holds the finalizers stored in the API
+			// server, which may not have been updated yet.
+			o.Finalizers = new.Finalizers
 			new = o
 		}
 	}
 	return utilerrors.NewAggregate(errors)
 }
 
+// objectUpdateClientDefault updates the finalizers using the Update method.
+// The status is always applied, so that the fields owned by this controller
+// do not conflict with writes from other actors.
 type objectUpdateClientDefault struct {
-	cl versioned.Interface
+	cl           versioned.Interface
+	fieldManager string
 }
 
 func (o *objectUpdateClientDefault) update(ctx context.Context, new *cmacme.Challenge) (*cmacme.Challenge, error) {
@@ -128,7 +135,7 @@ func (o *objectUpdateClientDefault) update(ctx context.Context, new *cmacme.Chal
 }
 
 func (o *objectUpdateClientDefault) updateStatus(ctx context.Context, new *cmacme.Challenge) (*cmacme.Challenge, error) {
-	return o.cl.AcmeV1().Challenges(new.Namespace).UpdateStatus(ctx, new, metav1.UpdateOptions{})
+	return internalchallenges.ApplyStatus(ctx, o.cl, o.fieldManager, new)
 }
 
 type objectUpdateClientSSA struct {
@@ -31,6 +31,7 @@ import (
 
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
@@ -40,11 +41,6 @@ func TestUpdateObjectStandard(t *testing.T) {
 }
 
 func TestUpdateObjectSSA(t *testing.T) {
-	t.Skip(
-		"Server Side Apply cannot be tested because PatchType is not supported by the fake versioned client. See:",
-		"https://github.com/kubernetes/client-go/issues/970",
-		"https://github.com/kubernetes/client-go/issues/992",
-	)
 	defer featuretesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, feature.ServerSideApply, true)()
 	runUpdateObjectTests(t)
 }
@@ -137,14 +133,19 @@ func runUpdateObjectTests(t *testing.T) {
 				objects = nil
 			}
 			cl := fake.NewSimpleClientset(objects...)
+			cl.PrependReactor("patch", "*", testpkg.ApplyPatchReactor(cl.Tracker()))
 			if tt.updateError != nil {
-				cl.PrependReactor("update", "challenges", func(action clienttesting.Action) (handled bool, ret runtime.Object, err error) {
+				verb := "update"
+				if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+					verb = "patch"
+				}
+				cl.PrependReactor(verb, "challenges", func(action clienttesting.Action) (handled bool, ret runtime.Object, err error) {
 					t.Log("Simulating a challenge update error")
 					return true, nil, tt.updateError
 				})
 			}
 			if tt.updateStatusError != nil {
-				cl.PrependReactor("update", "challenges/status", func(action clienttesting.Action) (handled bool, ret runtime.Object, err error) {
+				cl.PrependReactor("patch", "challenges/status", func(action clienttesting.Action) (handled bool, ret runtime.Object, err error) {
 					t.Log("Simulating a challenge/status update error")
 					return true, nil, tt.updateStatusError
 				})
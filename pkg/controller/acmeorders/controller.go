@@ -88,13 +88,11 @@ func NewController(
 	clock clock.Clock,
 	isNamespaced bool,
 	fieldManager string,
+	rateLimiter workqueue.RateLimiter,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
 
 	// Create a queue used to queue up Orders to be processed.
-	queue := workqueue.NewNamedRateLimitingQueue(
-		workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*30),
-		ControllerName,
-	)
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ControllerName)
 
 	// Create a scheduledWorkQueue to schedule Orders for re-processing.
 	scheduledWorkQueue := scheduler.NewScheduledWorkQueue(clock, queue.Add)
@@ -103,7 +101,7 @@ func NewController(
 	orderInformer := cmInformerFactory.Acme().V1().Orders()
 	issuerInformer := cmInformerFactory.Certmanager().V1().Issuers()
 	challengeInformer := cmInformerFactory.Acme().V1().Challenges()
-	secretInformer := kubeInformerFactory.Core().V1().Secrets()
+	secretInformer := controllerpkg.SecretsMetadataInformer(kubeInformerFactory)
 
 	// Build a list of InformerSynced functions. The controller will only begin
 	// processing items once all of these informers have synced.
@@ -111,14 +109,14 @@ func NewController(
 		orderInformer.Informer().HasSynced,
 		issuerInformer.Informer().HasSynced,
 		challengeInformer.Informer().HasSynced,
-		secretInformer.Informer().HasSynced,
+		secretInformer.HasSynced,
 	}
 
 	// Build all the listers.
 	orderLister := orderInformer.Lister()
 	issuerLister := issuerInformer.Lister()
 	challengeLister := challengeInformer.Lister()
-	secretLister := secretInformer.Lister()
+	secretLister := controllerpkg.NewSecretGetter(kubeInformerFactory)
 
 	// If we are running in non-namespaced mode, we also
 	// register event handlers and obtain a lister for ClusterIssuers.
@@ -223,6 +221,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.Clock,
 		isNamespaced,
 		ctx.FieldManager,
+		ctx.RateLimiterFor(ControllerName, time.Second*5, time.Minute*30),
 	)
 	c.controller = ctrl
 
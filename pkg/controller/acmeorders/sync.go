@@ -22,8 +22,10 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"net/http"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -34,19 +36,27 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 
-	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	internalorders "github.com/cert-manager/cert-manager/internal/controller/orders"
 	"github.com/cert-manager/cert-manager/pkg/acme"
+	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
-	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/tracing"
 )
 
 const (
 	reasonSolver  = "Solver"
 	reasonCreated = "Created"
+
+	// maxFinalizeAttempts is the number of times finalizing an Order may fail
+	// with a transient error before the Order is marked as failed.
+	maxFinalizeAttempts = 5
+	// maxFinalizeBackoff is the longest period to wait before retrying to
+	// finalize an Order.
+	maxFinalizeBackoff = 5 * time.Minute
 )
 
 var (
@@ -59,16 +69,23 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	log := logf.FromContext(ctx)
 	dbg := log.V(logf.DebugLevel)
 
+	ctx, span := tracing.Start(ctx, o, "Order.Sync", tracing.IssuerRefAttributes(o.Spec.IssuerRef)...)
+	defer func() {
+		span.SetAttributes(attribute.String("cert-manager.acme.order.state", string(o.Status.State)))
+		tracing.End(span, err)
+	}()
+
 	oldOrder := o
 	o = o.DeepCopy()
 
 	defer func() {
+		setOrderReadyCondition(o)
 		if apiequality.Semantic.DeepEqual(oldOrder.Status, o.Status) {
 			dbg.Info("skipping updating resource as new status == existing status")
 			return
 		}
 		log.V(logf.DebugLevel).Info("updating Order resource status")
-		updateErr := c.updateOrApplyStatus(ctx, o)
+		updateErr := c.applyStatus(ctx, o)
 		if updateErr != nil {
 			log.Error(err, "failed to update status")
 			err = utilerrors.NewAggregate([]error{err, updateErr})
@@ -81,7 +98,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	if err != nil {
 		return fmt.Errorf("error reading (cluster)issuer %q: %v", o.Spec.IssuerRef.Name, err)
 	}
-	cl, err := c.accountRegistry.GetClient(string(genericIssuer.GetUID()))
+	cl, err := c.accountRegistry.GetClient(accounts.ClientUID(genericIssuer, o.Namespace))
 	if err != nil {
 		return err
 	}
@@ -93,7 +110,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		return nil
 	case o.Status.URL == "":
 		log.V(logf.DebugLevel).Info("Creating new ACME order as status.url is not set")
-		return c.createOrder(ctx, cl, o)
+		return c.createOrder(ctx, cl, o, genericIssuer)
 	case o.Status.FinalizeURL == "":
 		log.V(logf.DebugLevel).Info("Updating Order status as status.finalizeURL is not set")
 		_, err := c.updateOrderStatus(ctx, cl, o)
@@ -129,7 +146,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	}
 
 	dbg.Info("Determining if any challenge resources need to be created")
-	needToCreateChallenges, err := c.anyRequiredChallengesDoNotExist(requiredChallenges)
+	challengesToCreate, err := c.challengesToCreate(o, requiredChallenges)
 	if err != nil {
 		return err
 	}
@@ -140,9 +157,9 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	}
 
 	switch {
-	case needToCreateChallenges:
+	case len(challengesToCreate) > 0:
 		log.V(logf.DebugLevel).Info("Creating additional Challenge resources to complete Order")
-		return c.createRequiredChallenges(ctx, o, requiredChallenges)
+		return c.createRequiredChallenges(ctx, o, challengesToCreate)
 	case needToDeleteChallenges:
 		log.V(logf.DebugLevel).Info("Deleting leftover Challenge resources no longer required by Order")
 		return c.deleteLeftoverChallenges(ctx, o, requiredChallenges)
@@ -155,6 +172,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	if err != nil {
 		return err
 	}
+	syncAuthorizationStates(o, challenges)
 
 	if o.Status.State == cmacme.Ready {
 		log.V(logf.DebugLevel).Info("Finalizing Order as order state is 'Ready'")
@@ -242,7 +260,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	return nil
 }
 
-func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) error {
+func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order, issuer cmapi.GenericIssuer) error {
 	log := logf.FromContext(ctx)
 
 	if o.Status.URL != "" {
@@ -283,10 +301,13 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 
 	o.Status.URL = acmeOrder.URI
 	o.Status.FinalizeURL = acmeOrder.FinalizeURL
+	o.Status.AccountURI = accountURI(ctx, cl, issuer)
 	o.Status.Authorizations = constructAuthorizations(acmeOrder)
 	c.setOrderState(&o.Status, acmeOrder.Status)
 
-	return nil
+	// Fetch the authorizations straight away so that the new order and the
+	// state of its authorizations are stored with a single status update.
+	return c.fetchMetadataForAuthorizations(ctx, o, cl)
 }
 
 func (c *controller) updateOrderStatus(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) (*acmeapi.Order, error) {
@@ -306,6 +327,9 @@ func (c *controller) updateOrderStatusFromACMEOrder(ctx context.Context, cl acme
 		o.Status.URL = acmeOrder.URI
 	}
 	o.Status.FinalizeURL = acmeOrder.FinalizeURL
+	if acmeOrder.CertURL != "" {
+		o.Status.CertificateURL = acmeOrder.CertURL
+	}
 	c.setOrderState(&o.Status, acmeOrder.Status)
 	// once the 'authorizations' slice contains at least one item, it cannot be
 	// updated. If it does not contain any items, update it containing the list
@@ -329,6 +353,40 @@ func (c *controller) setOrderState(o *cmacme.OrderStatus, s string) {
 	}
 }
 
+// setOrderReadyCondition sets the Ready condition of the Order to reflect its
+// current state. The reason of the condition is the state in CamelCase, and
+// the message is the Order's status reason if it has one.
+func setOrderReadyCondition(o *cmacme.Order) {
+	status, reason, message := metav1.ConditionFalse, "", ""
+	switch o.Status.State {
+	case cmacme.Valid:
+		reason = "Valid"
+		if len(o.Status.Certificate) > 0 {
+			status, message = metav1.ConditionTrue, "Order has been finalized and the certificate has been issued"
+		} else {
+			message = "Waiting for the certificate to be retrieved from the ACME server"
+		}
+	case cmacme.Ready:
+		reason, message = "Ready", "Order is ready to be finalized"
+	case cmacme.Pending:
+		reason, message = "Pending", "Waiting for the authorizations of the Order to be completed"
+	case cmacme.Processing:
+		reason, message = "Processing", "Order is being processed by the ACME server"
+	case cmacme.Invalid:
+		reason, message = "Invalid", "Order is invalid"
+	case cmacme.Expired:
+		reason, message = "Expired", "Order has expired"
+	case cmacme.Errored:
+		reason, message = "Errored", "Order has errored"
+	default:
+		status, reason, message = metav1.ConditionUnknown, "Unknown", "Order has not been processed yet"
+	}
+	if o.Status.Reason != "" {
+		message = o.Status.Reason
+	}
+	apiutil.SetOrderCondition(o, cmacme.OrderConditionReady, status, reason, message)
+}
+
 // constructAuthorizations will construct a slice of ACMEAuthorizations must be
 // completed for the given ACME order.
 // It does *not* perform a query against the ACME server for each authorization
@@ -373,6 +431,7 @@ func (c *controller) fetchMetadataForAuthorizations(ctx context.Context, o *cmac
 		}
 
 		authz.InitialState = cmacme.State(acmeAuthz.Status)
+		authz.State = authz.InitialState
 		authz.Identifier = acmeAuthz.Identifier.Value
 		authz.Wildcard = &acmeAuthz.Wildcard
 		authz.Challenges = make([]cmacme.ACMEChallenge, len(acmeAuthz.Challenges))
@@ -386,21 +445,31 @@ func (c *controller) fetchMetadataForAuthorizations(ctx context.Context, o *cmac
 	return nil
 }
 
-func (c *controller) anyRequiredChallengesDoNotExist(requiredChallenges []cmacme.Challenge) (bool, error) {
+// challengesToCreate returns the required Challenges which do not exist yet.
+// Challenges are not created for authorizations which the Order has already
+// observed to be valid, as there is nothing left for a solver to do.
+func (c *controller) challengesToCreate(o *cmacme.Order, requiredChallenges []cmacme.Challenge) ([]cmacme.Challenge, error) {
+	var toCreate []cmacme.Challenge
 	for _, ch := range requiredChallenges {
 		_, err := c.challengeLister.Challenges(ch.Namespace).Get(ch.Name)
-		if apierrors.IsNotFound(err) {
-			return true, nil
+		if err == nil {
+			continue
 		}
-		if err != nil {
-			return false, err
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if authz := authorizationForChallenge(o, ch.Spec.AuthorizationURL); authz != nil && authz.State == cmacme.Valid {
+			continue
 		}
+		toCreate = append(toCreate, ch)
 	}
-	return false, nil
+	return toCreate, nil
 }
 
 func (c *controller) createRequiredChallenges(ctx context.Context, o *cmacme.Order, requiredChallenges []cmacme.Challenge) error {
 	for _, ch := range requiredChallenges {
+		// Continue the trace of this Order in the challenges controller.
+		ch.Annotations = tracing.AnnotationsWithTraceContext(ctx, ch.Annotations)
 		_, err := c.cmClient.AcmeV1().Challenges(ch.Namespace).Create(ctx, &ch, metav1.CreateOptions{})
 		if apierrors.IsAlreadyExists(err) {
 			continue
@@ -509,11 +578,55 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 		derBytes = block.Bytes
 	}
 
+	if o.Status.FinalizeAttempts > 0 {
+		if backoff := c.finalizeBackoffRemaining(o); backoff > 0 {
+			log.V(logf.DebugLevel).Info("Waiting before retrying to finalize Order", "backoff", backoff)
+			c.requeueOrder(ctx, o, backoff)
+			return nil
+		}
+		// A previous attempt to finalize the order failed with a transient
+		// error, but the ACME server may have processed it regardless. Check
+		// the state of the ACME order so that it is only finalized again if
+		// it is still ready.
+		acmeOrder, err := getACMEOrder(ctx, cl, o)
+		if acmeErr, ok := err.(*acmeapi.Error); ok && acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+			log.Error(err, "failed to retrieve the ACME order (4xx error) marking Order as failed")
+			c.setOrderState(&o.Status, string(cmacme.Errored))
+			o.Status.Reason = fmt.Sprintf("Failed to retrieve Order resource: %v", err)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch acmeOrder.Status {
+		case acmeapi.StatusReady:
+			log.V(logf.DebugLevel).Info("Retrying to finalize Order", "attempts", o.Status.FinalizeAttempts)
+		case acmeapi.StatusProcessing:
+			log.V(logf.DebugLevel).Info("Order is being processed by the ACME server after a previous attempt to finalize it")
+			c.requeueOrder(ctx, o, RequeuePeriod)
+			return nil
+		case acmeapi.StatusValid:
+			log.V(logf.DebugLevel).Info("Order was finalized by a previous attempt, marking the order as valid and fetching certificate data")
+			c.setOrderState(&o.Status, string(cmacme.Valid))
+			o.Status.Reason = ""
+			return c.syncCertificateDataWithOrder(ctx, cl, *acmeOrder, o, issuer)
+		default:
+			_, err := c.updateOrderStatusFromACMEOrder(ctx, cl, o, acmeOrder)
+			return err
+		}
+	}
+
 	// Call to CreateOrderCert finalizes the ACME order. This call can only be made once.
 	certSlice, certURL, err := cl.CreateOrderCert(ctx, o.Status.FinalizeURL, derBytes, true)
 
 	acmeErr, ok := err.(*acmeapi.Error)
 
+	// Transient errors are retried rather than failing the Order, so that
+	// its valid authorizations are not wasted.
+	if ok && isTransientFinalizeError(acmeErr) {
+		return c.retryFinalizeOrder(ctx, o, err)
+	}
+
 	// If finalizing the order returns a 403 error, the order may already be finalized.
 	// This scenario is possible if the ACME order has already been
 	// finalized in an earlier reconcile, but the reconciler failed
@@ -566,6 +679,11 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 	if err != nil {
 		return fmt.Errorf("error finalizing order: %v", err)
 	}
+	if certURL != "" {
+		o.Status.CertificateURL = certURL
+	}
+	// clear the reason recorded by any previous failed attempt
+	o.Status.Reason = ""
 
 	if issuer.GetSpec().ACME != nil && issuer.GetSpec().ACME.PreferredChain != "" {
 		preferredChain := issuer.GetSpec().ACME.PreferredChain
@@ -584,6 +702,76 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 	return c.storeCertificateOnStatus(ctx, o, certSlice)
 }
 
+// isTransientFinalizeError returns true if finalizing an Order failed with an
+// error which may not occur when the request is retried.
+func isTransientFinalizeError(err *acmeapi.Error) bool {
+	switch err.ProblemType {
+	case "urn:ietf:params:acme:error:badNonce",
+		"urn:ietf:params:acme:error:rateLimited":
+		return true
+	}
+	return err.StatusCode == http.StatusTooManyRequests
+}
+
+// retryFinalizeOrder records a failed attempt to finalize the Order and
+// schedules it to be retried with exponential back-off. Once
+// maxFinalizeAttempts is reached the Order is marked as failed.
+func (c *controller) retryFinalizeOrder(ctx context.Context, o *cmacme.Order, err error) error {
+	log := logf.FromContext(ctx)
+
+	now := metav1.NewTime(c.clock.Now())
+	o.Status.FinalizeAttempts++
+	o.Status.LastFinalizeAttemptTime = &now
+	if o.Status.FinalizeAttempts >= maxFinalizeAttempts {
+		log.Error(err, "failed to finalize Order too many times, marking Order as failed", "attempts", o.Status.FinalizeAttempts)
+		c.setOrderState(&o.Status, string(cmacme.Errored))
+		o.Status.Reason = fmt.Sprintf("Failed to finalize Order after %d attempts: %v", o.Status.FinalizeAttempts, err)
+		return nil
+	}
+
+	backoff := finalizeBackoff(o.Status.FinalizeAttempts)
+	log.Error(err, "failed to finalize Order due to a transient error, retrying", "attempts", o.Status.FinalizeAttempts, "backoff", backoff)
+	o.Status.Reason = fmt.Sprintf("Failed to finalize Order, retrying in %s: %v", backoff, err)
+	c.requeueOrder(ctx, o, backoff)
+	return nil
+}
+
+// finalizeBackoff returns the period to wait before retrying to finalize an
+// Order which has failed to be finalized the given number of times.
+func finalizeBackoff(attempts int) time.Duration {
+	backoff := RequeuePeriod
+	for i := 1; i < attempts && backoff < maxFinalizeBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxFinalizeBackoff {
+		return maxFinalizeBackoff
+	}
+	return backoff
+}
+
+// finalizeBackoffRemaining returns how long to wait before the Order may be
+// finalized again after its last failed attempt.
+func (c *controller) finalizeBackoffRemaining(o *cmacme.Order) time.Duration {
+	if o.Status.LastFinalizeAttemptTime == nil {
+		return 0
+	}
+	next := o.Status.LastFinalizeAttemptTime.Add(finalizeBackoff(o.Status.FinalizeAttempts))
+	return next.Sub(c.clock.Now())
+}
+
+// requeueOrder schedules the Order to be processed again after the given
+// period.
+func (c *controller) requeueOrder(ctx context.Context, o *cmacme.Order, after time.Duration) {
+	key, err := cache.MetaNamespaceKeyFunc(o)
+	if err != nil {
+		// This error would have been encountered in the informer's
+		// callback already, so it cannot be fixed by re-queueing.
+		logf.FromContext(ctx).Error(err, "failed to construct key for Order")
+		return
+	}
+	c.scheduledWorkQueue.Add(key, after)
+}
+
 func (c *controller) storeCertificateOnStatus(ctx context.Context, o *cmacme.Order, certs [][]byte) error {
 	log := logf.FromContext(ctx)
 	// encode the retrieved certificates (including the chain)
@@ -634,6 +822,9 @@ func (c *controller) syncCertificateDataWithOrder(ctx context.Context, cl acmecl
 	if acmeOrder.Status != acmeapi.StatusValid {
 		return nil
 	}
+	if acmeOrder.CertURL != "" {
+		o.Status.CertificateURL = acmeOrder.CertURL
+	}
 
 	if issuer.GetSpec().ACME != nil && issuer.GetSpec().ACME.PreferredChain != "" {
 		found, altCerts, err := getAltCertChain(ctx, cl, acmeOrder.CertURL, issuer.GetSpec().ACME.PreferredChain)
@@ -666,6 +857,27 @@ func (c *controller) syncCertificateDataWithOrder(ctx context.Context, cl acmecl
 	return nil
 }
 
+// accountURI returns the URI of the ACME account used by the client. The URI
+// is recorded on the status of the issuer, unless the issuer registers a
+// separate account for each namespace, in which case it is looked up on the
+// ACME server. An empty URI is returned if the account cannot be looked up,
+// since it is only recorded for informational purposes.
+func accountURI(ctx context.Context, cl acmecl.Interface, issuer cmapi.GenericIssuer) string {
+	if !accounts.UsesNamespacedAccounts(issuer) {
+		if status := issuer.GetStatus(); status != nil && status.ACME != nil {
+			return status.ACME.URI
+		}
+		return ""
+	}
+
+	account, err := cl.GetReg(ctx, "")
+	if err != nil {
+		logf.FromContext(ctx).V(logf.WarnLevel).Info("failed to look up the URI of the ACME account", "error", err.Error())
+		return ""
+	}
+	return account.URI
+}
+
 // getACMEOrder returns the ACME Order for an Order Custom Resource.
 func getACMEOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) (*acmeapi.Order, error) {
 	log := logf.FromContext(ctx)
@@ -714,17 +926,10 @@ func getAltCertChain(ctx context.Context, cl acmecl.Interface, certURL string, p
 
 }
 
-// updateOrApplyStatus will update the order status.
-// If the ServerSideApply feature is enabled, the managed fields will instead
-// get applied using the relevant Patch API call.
-func (c *controller) updateOrApplyStatus(ctx context.Context, order *cmacme.Order) error {
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		return internalorders.ApplyStatus(ctx, c.cmClient, c.fieldManager, &cmacme.Order{
-			ObjectMeta: metav1.ObjectMeta{Namespace: order.Namespace, Name: order.Name},
-			Status:     *order.Status.DeepCopy(),
-		})
-	} else {
-		_, err := c.cmClient.AcmeV1().Orders(order.Namespace).UpdateStatus(ctx, order, metav1.UpdateOptions{})
-		return err
-	}
+// applyStatus applies the order status.
+func (c *controller) applyStatus(ctx context.Context, order *cmacme.Order) error {
+	return internalorders.ApplyStatus(ctx, c.cmClient, c.fieldManager, &cmacme.Order{
+		ObjectMeta: metav1.ObjectMeta{Namespace: order.Namespace, Name: order.Name},
+		Status:     *order.Status.DeepCopy(),
+	})
 }
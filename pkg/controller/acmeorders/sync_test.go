@@ -24,14 +24,18 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	acmeapi "golang.org/x/crypto/acme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/utils/clock"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 
 	accountstest "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
@@ -44,6 +48,22 @@ func TestSync(t *testing.T) {
 	nowMetaTime := metav1.NewTime(nowTime)
 	fixedClock := fakeclock.NewFakeClock(nowTime)
 
+	// readyCondition sets the Ready condition which Sync sets on the Order.
+	readyCondition := func(status metav1.ConditionStatus, reason, message string) gen.OrderModifier {
+		return gen.SetOrderCondition(metav1.Condition{
+			Type:               cmacme.OrderConditionReady,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: nowMetaTime,
+		})
+	}
+	authorizationState := func(state cmacme.State) gen.OrderModifier {
+		return func(o *cmacme.Order) {
+			o.Status.Authorizations[0].State = state
+		}
+	}
+
 	testIssuerHTTP01 := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
 		Solvers: []cmacme.ACMEChallengeSolver{
 			{
@@ -118,7 +138,7 @@ func TestSync(t *testing.T) {
 		FailureTime: &nowMetaTime,
 		URL:         "http://testurl.com/abcde",
 		FinalizeURL: "http://testurl.com/abcde/finalize",
-		Reason:      "Failed to finalize Order: 429 : some error",
+		Reason:      "Failed to finalize Order: 413 : some error",
 		Authorizations: []cmacme.ACMEAuthorization{
 			{
 				URL:          "http://authzurl",
@@ -143,6 +163,15 @@ func TestSync(t *testing.T) {
 		StatusCode: 403,
 		Detail:     "some error",
 	}
+	acmeErrorBadNonce := acmeapi.Error{
+		StatusCode:  400,
+		ProblemType: "urn:ietf:params:acme:error:badNonce",
+		Detail:      "some error",
+	}
+	acmeError413 := acmeapi.Error{
+		StatusCode: 413,
+		Detail:     "some error",
+	}
 
 	testOrderPending := gen.OrderFrom(testOrder, gen.SetOrderStatus(pendingStatus))
 	testOrderInvalid := testOrderPending.DeepCopy()
@@ -255,13 +284,12 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 	testACMEOrderInvalid.Status = acmeapi.StatusInvalid
 
 	tests := map[string]testT{
-		"create a new order with the acme server, set the order url on the status resource and return nil to avoid cache timing issues": {
+		"create a new order with the acme server, set the order url and authorizations on the status resource and return nil to avoid cache timing issues": {
 			order: testOrder,
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrder},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
-						"status",
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
 						testOrderPending.Namespace,
 						gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
 							State:       cmacme.Pending,
@@ -269,10 +297,15 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 							FinalizeURL: "http://testurl.com/abcde/finalize",
 							Authorizations: []cmacme.ACMEAuthorization{
 								{
-									URL: "http://authzurl",
+									URL:          "http://authzurl",
+									Identifier:   "test.com",
+									Wildcard:     pointer.Bool(false),
+									InitialState: cmacme.Pending,
+									State:        cmacme.Pending,
+									Challenges:   []cmacme.ACMEChallenge{{Token: "token", Type: "http-01"}},
 								},
 							},
-						})))),
+						}), readyCondition(metav1.ConditionFalse, "Pending", "Waiting for the authorizations of the Order to be completed"))),
 				},
 			},
 			acmeClient: &acmecl.FakeACME{
@@ -291,13 +324,49 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"create a new order and record the URI of the ACME account it was created with": {
+			order: testOrder,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.IssuerFrom(testIssuerHTTP01TestCom, gen.SetIssuerACMEAccountURL("http://testurl.com/acct/1")), testOrder},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Pending,
+							URL:         "http://testurl.com/abcde",
+							FinalizeURL: "http://testurl.com/abcde/finalize",
+							AccountURI:  "http://testurl.com/acct/1",
+							Authorizations: []cmacme.ACMEAuthorization{
+								{
+									URL:          "http://authzurl",
+									Identifier:   "test.com",
+									Wildcard:     pointer.Bool(false),
+									InitialState: cmacme.Pending,
+									State:        cmacme.Pending,
+									Challenges:   []cmacme.ACMEChallenge{{Token: "token", Type: "http-01"}},
+								},
+							},
+						}), readyCondition(metav1.ConditionFalse, "Pending", "Waiting for the authorizations of the Order to be completed"))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return testACMEOrderPending, nil
+				},
+				FakeGetAuthorization: func(ctx context.Context, url string) (*acmeapi.Authorization, error) {
+					return testACMEAuthorizationPending, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
 		"create a new order with the acme server with an IP address": {
 			order: testOrderIP,
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{testIssuerHTTP01, testOrderIP},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
-						"status",
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
 						testOrderPending.Namespace,
 						gen.OrderFrom(testOrderIP, gen.SetOrderStatus(cmacme.OrderStatus{
 							State:       cmacme.Pending,
@@ -305,10 +374,15 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 							FinalizeURL: "http://testurl.com/abcde/finalize",
 							Authorizations: []cmacme.ACMEAuthorization{
 								{
-									URL: "http://authzurl",
+									URL:          "http://authzurl",
+									Identifier:   "test.com",
+									Wildcard:     pointer.Bool(false),
+									InitialState: cmacme.Pending,
+									State:        cmacme.Pending,
+									Challenges:   []cmacme.ACMEChallenge{{Token: "token", Type: "http-01"}},
 								},
 							},
-						})))),
+						}), readyCondition(metav1.ConditionFalse, "Pending", "Waiting for the authorizations of the Order to be completed"))),
 				},
 			},
 			acmeClient: &acmecl.FakeACME{
@@ -418,6 +492,23 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 			},
 			shouldSchedule: true,
 		},
+		"skip re-creating a Challenge for an authorization which has been observed as valid": {
+			order: gen.OrderFrom(testOrderPending, authorizationState(cmacme.Valid)),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, gen.OrderFrom(testOrderPending, authorizationState(cmacme.Valid))},
+				ExpectedActions:    []testpkg.Action{},
+				ExpectedEvents:     []string{},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(ctx context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderPending, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+			shouldSchedule: true,
+		},
 		"skip creating a Challenge for an already valid authorization": {
 			order: gen.OrderFrom(testOrder, gen.SetOrderStatus(
 				cmacme.OrderStatus{
@@ -443,8 +534,7 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
-						"status",
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
 						testOrder.Namespace, gen.OrderFrom(testOrder, gen.SetOrderStatus(
 							cmacme.OrderStatus{
 								// The 'state' field should be updated to reflect the
@@ -467,8 +557,8 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 									},
 								},
 							},
-						)),
-					)),
+						), readyCondition(metav1.ConditionFalse, "Valid", "Waiting for the certificate to be retrieved from the ACME server")),
+					),
 				},
 				ExpectedEvents: []string{},
 			},
@@ -504,9 +594,8 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending, testAuthorizationChallengeValid},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
-						"status",
-						testOrderReady.Namespace, testOrderReady)),
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderReady.Namespace, gen.OrderFrom(testOrderReady, authorizationState(cmacme.Valid), readyCondition(metav1.ConditionFalse, "Ready", "Order is ready to be finalized"))),
 				},
 			},
 			acmeClient: &acmecl.FakeACME{
@@ -524,9 +613,8 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderReady, testAuthorizationChallengeValid},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
-						"status",
-						testOrderValid.Namespace, testOrderValid)),
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderValid.Namespace, gen.OrderFrom(testOrderValid, authorizationState(cmacme.Valid), gen.SetOrderCertificateURL("http://testurl"), readyCondition(metav1.ConditionTrue, "Valid", "Order has been finalized and the certificate has been issued"))),
 				},
 				ExpectedEvents: []string{
 					"Normal Complete Order completed successfully",
@@ -546,14 +634,13 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
-		"call FinalizeOrder and update the order state to 'errored' if finalize fails with a 4xx ACME error": {
+		"call FinalizeOrder and update the order state to 'errored' if finalize fails with a 4xx ACME error which is not transient": {
 			order: gen.OrderFrom(testOrderErroredWithDetail, gen.SetOrderState(cmacme.Ready)),
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, gen.OrderFrom(testOrderErroredWithDetail, gen.SetOrderState(cmacme.Ready))},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
-						"status",
-						testOrderErroredWithDetail.Namespace, testOrderErroredWithDetail)),
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderErroredWithDetail.Namespace, gen.OrderFrom(testOrderErroredWithDetail, readyCondition(metav1.ConditionFalse, "Errored", "Failed to finalize Order: 413 : some error"))),
 				},
 			},
 			acmeClient: &acmecl.FakeACME{
@@ -561,7 +648,7 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 					return testACMEOrderReady, nil
 				},
 				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
-					return nil, "", &acmeError429
+					return nil, "", &acmeError413
 				},
 				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
 					// TODO: assert s = "token"
@@ -594,9 +681,8 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderReady, testAuthorizationChallengeValid},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
-						"status",
-						testOrderValid.Namespace, testOrderValid)),
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderValid.Namespace, gen.OrderFrom(testOrderValid, authorizationState(cmacme.Valid), readyCondition(metav1.ConditionTrue, "Valid", "Order has been finalized and the certificate has been issued"))),
 				},
 				ExpectedEvents: []string{
 					"Normal Complete Order completed successfully",
@@ -624,9 +710,8 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestComPreferredChain, testOrderReady, testAuthorizationChallengeValid},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
-						"status",
-						testOrderValid.Namespace, testOrderValidAltCert)),
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderValid.Namespace, gen.OrderFrom(testOrderValidAltCert, authorizationState(cmacme.Valid), readyCondition(metav1.ConditionTrue, "Valid", "Order has been finalized and the certificate has been issued"))),
 				},
 				ExpectedEvents: []string{
 					"Normal Complete Order completed successfully",
@@ -671,9 +756,8 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestComPreferredChain, testOrderReady, testAuthorizationChallengeValid},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
-						"status",
-						testOrderValid.Namespace, testOrderValidAltCert)),
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderValid.Namespace, gen.OrderFrom(testOrderValidAltCert, authorizationState(cmacme.Valid), gen.SetOrderCertificateURL("http://testurl"), readyCondition(metav1.ConditionTrue, "Valid", "Order has been finalized and the certificate has been issued"))),
 				},
 				ExpectedEvents: []string{
 					"Normal Complete Order completed successfully",
@@ -716,14 +800,113 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"call FinalizeOrder, record the attempt and re-queue the order if finalize fails with a bad nonce error": {
+			order: testOrderReady,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderReady, testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderReady.Namespace, gen.OrderFrom(testOrderReady, authorizationState(cmacme.Valid),
+							gen.SetOrderFinalizeAttempts(1, nowMetaTime),
+							gen.SetOrderReason("Failed to finalize Order, retrying in 5s: 400 urn:ietf:params:acme:error:badNonce: some error"),
+							readyCondition(metav1.ConditionFalse, "Ready", "Failed to finalize Order, retrying in 5s: 400 urn:ietf:params:acme:error:badNonce: some error"))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					return nil, "", &acmeErrorBadNonce
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					// TODO: assert s = "token"
+					return "key", nil
+				},
+			},
+			shouldSchedule: true,
+		},
+		"call FinalizeOrder, re-queue the order without finalizing it if the back-off of the last attempt has not passed": {
+			order: gen.OrderFrom(testOrderReady, gen.SetOrderFinalizeAttempts(1, nowMetaTime)),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, gen.OrderFrom(testOrderReady, gen.SetOrderFinalizeAttempts(1, nowMetaTime)), testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderReady.Namespace, gen.OrderFrom(testOrderReady, authorizationState(cmacme.Valid), gen.SetOrderFinalizeAttempts(1, nowMetaTime), readyCondition(metav1.ConditionFalse, "Ready", "Order is ready to be finalized"))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					return nil, "", errors.New("finalize should not be retried before the back-off has passed")
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					// TODO: assert s = "token"
+					return "key", nil
+				},
+			},
+			shouldSchedule: true,
+		},
+		"call FinalizeOrder, fetch the certificate without finalizing again if a previous attempt was processed by the ACME server": {
+			order: gen.OrderFrom(testOrderReady, gen.SetOrderFinalizeAttempts(1, metav1.NewTime(nowTime.Add(-time.Hour))), gen.SetOrderReason("Failed to finalize Order, retrying in 5s: 429 : some error")),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderReady, testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderValid.Namespace, gen.OrderFrom(testOrderValid, authorizationState(cmacme.Valid),
+							gen.SetOrderFinalizeAttempts(1, metav1.NewTime(nowTime.Add(-time.Hour))),
+							readyCondition(metav1.ConditionTrue, "Valid", "Order has been finalized and the certificate has been issued"))),
+				},
+				ExpectedEvents: []string{
+					"Normal Complete Order completed successfully",
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderValid, nil
+				},
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					return nil, "", errors.New("an order which has already been finalized should not be finalized again")
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					// TODO: assert s = "token"
+					return "key", nil
+				},
+				FakeFetchCert: func(_ context.Context, url string, bundle bool) ([][]byte, error) {
+					return [][]byte{[]byte("test")}, nil
+				},
+			},
+		},
+		"call FinalizeOrder and update the order state to 'errored' once finalize has failed with transient errors too many times": {
+			order: gen.OrderFrom(testOrderReady, gen.SetOrderFinalizeAttempts(maxFinalizeAttempts-1, metav1.NewTime(nowTime.Add(-time.Hour)))),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderReady, testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderReady.Namespace, gen.OrderFrom(testOrderReady, authorizationState(cmacme.Valid),
+							gen.SetOrderState(cmacme.Errored),
+							gen.SetOrderFailureTime(nowMetaTime),
+							gen.SetOrderFinalizeAttempts(maxFinalizeAttempts, nowMetaTime),
+							gen.SetOrderReason("Failed to finalize Order after 5 attempts: 429 : some error"),
+							readyCondition(metav1.ConditionFalse, "Errored", "Failed to finalize Order after 5 attempts: 429 : some error"))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderReady, nil
+				},
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					return nil, "", &acmeError429
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					// TODO: assert s = "token"
+					return "key", nil
+				},
+			},
+		},
 		"call GetOrder and update the order state if the challenge is 'failed'": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending, testAuthorizationChallengeInvalid},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
-						"status",
-						testOrderInvalid.Namespace, testOrderInvalid)),
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderInvalid.Namespace, gen.OrderFrom(testOrderInvalid, authorizationState(cmacme.Invalid), readyCondition(metav1.ConditionFalse, "Invalid", "Order is invalid"))),
 				},
 			},
 			acmeClient: &acmecl.FakeACME{
@@ -735,11 +918,14 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
-		"should leave the order state as-is if the challenge is marked invalid but the acme order is pending": {
+		"should leave the order state as-is if the challenge is marked invalid but the acme order is pending, and record the state of the authorization": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending, testAuthorizationChallengeInvalid},
-				ExpectedActions:    []testpkg.Action{},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						testOrderPending.Namespace, gen.OrderFrom(testOrderPending, authorizationState(cmacme.Invalid), readyCondition(metav1.ConditionFalse, "Pending", "Waiting for the authorizations of the Order to be completed"))),
+				},
 			},
 			acmeClient: &acmecl.FakeACME{
 				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
@@ -823,9 +1009,23 @@ func runTest(t *testing.T, test testT) {
 	}
 	cw.scheduledWorkQueue = &fakeScheduler
 
+	// The Ready condition is derived from the state of the Order, so rather
+	// than repeating it in every fixture, treat the Order under test as
+	// having been synced before and expect updated Orders to carry the
+	// condition matching their state.
+	order := test.order.DeepCopy()
+	setOrderReadyCondition(order)
+	for _, a := range test.builder.ExpectedActions {
+		if update, ok := a.Action().(coretesting.UpdateAction); ok {
+			if o, ok := update.GetObject().(*cmacme.Order); ok {
+				setOrderReadyCondition(o)
+			}
+		}
+	}
+
 	test.builder.Start()
 
-	err = cw.Sync(context.Background(), test.order)
+	err = cw.Sync(context.Background(), order)
 	if err != nil && !test.expectErr {
 		t.Errorf("Expected function to not error, but got: %v", err)
 	}
@@ -838,3 +1038,87 @@ func runTest(t *testing.T, test testT) {
 
 	test.builder.CheckAndFinish(err)
 }
+
+func TestSetOrderReadyCondition(t *testing.T) {
+	nowTime := time.Now()
+	fixedClock := fakeclock.NewFakeClock(nowTime)
+	apiutil.Clock = fixedClock
+	defer func() { apiutil.Clock = clock.RealClock{} }()
+
+	tests := map[string]struct {
+		status     cmacme.OrderStatus
+		expStatus  metav1.ConditionStatus
+		expReason  string
+		expMessage string
+	}{
+		"new Order has an Unknown condition": {
+			expStatus:  metav1.ConditionUnknown,
+			expReason:  "Unknown",
+			expMessage: "Order has not been processed yet",
+		},
+		"pending Order is not ready": {
+			status:     cmacme.OrderStatus{State: cmacme.Pending},
+			expStatus:  metav1.ConditionFalse,
+			expReason:  "Pending",
+			expMessage: "Waiting for the authorizations of the Order to be completed",
+		},
+		"valid Order without a certificate is not ready": {
+			status:     cmacme.OrderStatus{State: cmacme.Valid},
+			expStatus:  metav1.ConditionFalse,
+			expReason:  "Valid",
+			expMessage: "Waiting for the certificate to be retrieved from the ACME server",
+		},
+		"valid Order with a certificate is ready": {
+			status:     cmacme.OrderStatus{State: cmacme.Valid, Certificate: []byte("cert")},
+			expStatus:  metav1.ConditionTrue,
+			expReason:  "Valid",
+			expMessage: "Order has been finalized and the certificate has been issued",
+		},
+		"failed Order uses the status reason as message": {
+			status:     cmacme.OrderStatus{State: cmacme.Errored, Reason: "Failed to finalize Order: some error"},
+			expStatus:  metav1.ConditionFalse,
+			expReason:  "Errored",
+			expMessage: "Failed to finalize Order: some error",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(nowTime)
+			o := gen.Order("test", gen.SetOrderStatus(test.status))
+			o.Generation = 2
+
+			setOrderReadyCondition(o)
+			if len(o.Status.Conditions) != 1 {
+				t.Fatalf("expected 1 condition, got %d", len(o.Status.Conditions))
+			}
+			cond := o.Status.Conditions[0]
+			assert.Equal(t, cmacme.OrderConditionReady, cond.Type)
+			assert.Equal(t, test.expStatus, cond.Status)
+			assert.Equal(t, test.expReason, cond.Reason)
+			assert.Equal(t, test.expMessage, cond.Message)
+			assert.Equal(t, int64(2), cond.ObservedGeneration)
+			assert.True(t, cond.LastTransitionTime.Time.Equal(nowTime))
+
+			// The observed generation follows the Order, but the transition
+			// time is only updated when the status of the condition changes.
+			fixedClock.Step(time.Minute)
+			o.Generation = 3
+			setOrderReadyCondition(o)
+			cond = o.Status.Conditions[0]
+			assert.Equal(t, int64(3), cond.ObservedGeneration)
+			assert.True(t, cond.LastTransitionTime.Time.Equal(nowTime))
+
+			o.Status.State = cmacme.Invalid
+			setOrderReadyCondition(o)
+			cond = o.Status.Conditions[0]
+			assert.Equal(t, metav1.ConditionFalse, cond.Status)
+			assert.Equal(t, "Invalid", cond.Reason)
+			if test.expStatus == metav1.ConditionFalse {
+				assert.True(t, cond.LastTransitionTime.Time.Equal(nowTime))
+			} else {
+				assert.True(t, cond.LastTransitionTime.Time.Equal(nowTime.Add(time.Minute)))
+			}
+		})
+	}
+}
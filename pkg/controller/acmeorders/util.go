@@ -70,8 +70,11 @@ func buildChallenge(ctx context.Context, cl acmecl.Interface, issuer cmapi.Gener
 
 	return &cmacme.Challenge{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            chName,
-			Namespace:       o.Namespace,
+			Name:      chName,
+			Namespace: o.Namespace,
+			// Labels are inherited so that Challenges match the
+			// --watch-label-selector of the controller processing the Order.
+			Labels:          o.Labels,
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(o, orderGvk)},
 		},
 		Spec: *chSpec,
@@ -131,9 +134,16 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 			continue
 		}
 
-		labelsMatch, numLabelsMatch := selectors.Labels(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
-		dnsNamesMatch, numDNSNamesMatch := selectors.DNSNames(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
-		dnsZonesMatch, numDNSZonesMatch := selectors.DNSZones(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
+		var labelsMatch, dnsNamesMatch, dnsZonesMatch bool
+		var numLabelsMatch, numDNSNamesMatch, numDNSZonesMatch int
+		for _, domain := range domainsForSolver(&cfg, authz.Identifier, wc) {
+			labelsMatch, numLabelsMatch = selectors.Labels(*cfg.Selector).Matches(o.ObjectMeta, domain)
+			dnsNamesMatch, numDNSNamesMatch = selectors.DNSNames(*cfg.Selector).Matches(o.ObjectMeta, domain)
+			dnsZonesMatch, numDNSZonesMatch = selectors.DNSZones(*cfg.Selector).Matches(o.ObjectMeta, domain)
+			if labelsMatch && dnsNamesMatch && dnsZonesMatch {
+				break
+			}
+		}
 
 		if !labelsMatch || !dnsNamesMatch || !dnsZonesMatch {
 			dbg.Info("not selecting solver", "labels_match", labelsMatch, "dnsnames_match", dnsNamesMatch, "dnszones_match", dnsZonesMatch)
@@ -284,6 +294,24 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 	}, nil
 }
 
+// domainsForSolver returns the domains the selector of a solver is matched
+// against for an authorization of identifier.
+// The challenge records of a wildcard name and of its base domain are the
+// same, so when a DNS01 solver delegates them to a validation zone it must
+// solve both, and is matched against either of them. Otherwise a solver
+// selected only for *.example.com would have to share the CNAME record of
+// _acme-challenge.example.com with the solver selected for example.com.
+func domainsForSolver(solver *cmacme.ACMEChallengeSolver, identifier string, wildcard bool) []string {
+	domain, other := identifier, "*."+identifier
+	if wildcard {
+		domain, other = other, domain
+	}
+	if solver.DNS01 == nil || len(solver.DNS01.ValidationZone) == 0 {
+		return []string{domain}
+	}
+	return []string{domain, other}
+}
+
 func challengeType(t string) (cmacme.ACMEChallengeType, error) {
 	switch t {
 	case "http-01":
@@ -341,6 +369,30 @@ func anyChallengesFailed(chs []*cmacme.Challenge) bool {
 	return false
 }
 
+// syncAuthorizationStates records the state of each of the given Challenges
+// on the authorization of the Order which the Challenge is solving.
+func syncAuthorizationStates(o *cmacme.Order, chs []*cmacme.Challenge) {
+	for _, ch := range chs {
+		if ch.Status.State == "" {
+			continue
+		}
+		if authz := authorizationForChallenge(o, ch.Spec.AuthorizationURL); authz != nil {
+			authz.State = ch.Status.State
+		}
+	}
+}
+
+// authorizationForChallenge returns the authorization of the Order with the
+// given URL, or nil if there is none.
+func authorizationForChallenge(o *cmacme.Order, authzURL string) *cmacme.ACMEAuthorization {
+	for i := range o.Status.Authorizations {
+		if o.Status.Authorizations[i].URL == authzURL {
+			return &o.Status.Authorizations[i]
+		}
+	}
+	return nil
+}
+
 func allChallengesFinal(chs []*cmacme.Challenge) bool {
 	for _, ch := range chs {
 		if !acme.IsFinalState(ch.Status.State) {
@@ -1256,6 +1256,108 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 				Solver:  exampleComDNSNameSelectorSolver,
 			},
 		},
+		"wildcard dnsName solver with a validation zone should be used for the base domain": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								{
+									Selector: &cmacme.CertificateDNSNameSelector{
+										DNSZones: []string{"example.com"},
+									},
+									DNS01: &cmacme.ACMEChallengeSolverDNS01{
+										Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+											Email: "example-com-dnszone-selector-solver",
+										},
+									},
+								},
+								{
+									Selector: &cmacme.CertificateDNSNameSelector{
+										DNSNames: []string{"*.apps.example.com"},
+									},
+									DNS01: &cmacme.ACMEChallengeSolverDNS01{
+										CNAMEStrategy:  cmacme.FollowStrategy,
+										ValidationZone: "acme.example.com",
+										Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+											Email: "validation-zone-solver",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"apps.example.com", "*.apps.example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "apps.example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeDNS01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeDNS01,
+				DNSName: "apps.example.com",
+				Token:   acmeChallengeDNS01.Token,
+				Key:     "dns01",
+				Solver: cmacme.ACMEChallengeSolver{
+					Selector: &cmacme.CertificateDNSNameSelector{
+						DNSNames: []string{"*.apps.example.com"},
+					},
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						CNAMEStrategy:  cmacme.FollowStrategy,
+						ValidationZone: "acme.example.com",
+						Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+							Email: "validation-zone-solver",
+						},
+					},
+				},
+			},
+		},
+		"wildcard dnsName solver without a validation zone should not be used for the base domain": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								emptySelectorSolverDNS01,
+								{
+									Selector: &cmacme.CertificateDNSNameSelector{
+										DNSNames: []string{"*.apps.example.com"},
+									},
+									DNS01: &cmacme.ACMEChallengeSolverDNS01{
+										Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+											Email: "apps-example-com-wc-dnsname-selector-solver",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"apps.example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "apps.example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeDNS01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeDNS01,
+				DNSName: "apps.example.com",
+				Token:   acmeChallengeDNS01.Token,
+				Key:     "dns01",
+				Solver:  emptySelectorSolverDNS01,
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -95,5 +95,15 @@ func (b *Builder) Complete() (Interface, error) {
 		return nil, fmt.Errorf("error registering controller: %v", err)
 	}
 
-	return NewController(ctx, b.name, controllerctx.Metrics, b.impl.ProcessItem, mustSync, b.runDurationFuncs, queue), nil
+	processItem := b.impl.ProcessItem
+	if !selectsEverything(controllerctx.NamespaceSelector) {
+		namespaces := controllerctx.KubeSharedInformerFactory.Core().V1().Namespaces()
+		mustSync = append(mustSync, namespaces.Informer().HasSynced)
+		processItem = filterByNamespace(namespaces.Lister(), controllerctx.NamespaceSelector, processItem)
+	}
+	if controllerctx.CertificateShards > 1 && IsSharded(b.name) {
+		processItem = filterByShard(controllerctx.CertificateShards, controllerctx.CertificateShard, processItem)
+	}
+
+	return NewController(ctx, b.name, controllerctx.Metrics, processItem, mustSync, b.runDurationFuncs, queue), nil
 }
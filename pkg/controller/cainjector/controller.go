@@ -43,6 +43,9 @@ func dropNotFound(err error) error {
 	return err
 }
 
+// fieldManager is the field manager used when applying CA data to targets.
+const fieldManager = "cert-manager-cainjector"
+
 // OwningCertForSecret gets the name of the owning certificate for a
 // given secret, returning nil if no such object exists.
 // Right now, this actually uses a label instead of owner refs,
@@ -69,6 +72,11 @@ type InjectTarget interface {
 	// PEM format used across Kubernetes).  In cases where multiple CA fields exist per
 	// target (like admission webhook configs), all CAs are set to the given value.
 	SetCA(data []byte)
+
+	// AsApplyObject returns an object containing only the CA fields of this
+	// target, which is applied so that the injector takes ownership of those
+	// fields alone.
+	AsApplyObject() client.Object
 }
 
 // Injectable is a point in a Kubernetes API object that represents a Kubernetes Service
@@ -170,8 +178,9 @@ func (r *genericInjectReconciler) Reconcile(_ context.Context, req ctrl.Request)
 	// actually do the injection
 	target.SetCA(caData)
 
-	// actually update with injected CA data
-	if err := r.Client.Update(ctx, target.AsObject()); err != nil {
+	// actually apply the injected CA data. Only the CA fields are sent, so
+	// that writes to the rest of the object by other actors do not conflict.
+	if err := r.Client.Patch(ctx, target.AsApplyObject(), client.Apply, client.FieldOwner(fieldManager), client.ForceOwnership); err != nil {
 		log.Error(err, "unable to update target object with new CA data")
 		return ctrl.Result{}, err
 	}
@@ -17,8 +17,12 @@ limitations under the License.
 package cainjector
 
 import (
+	"encoding/base64"
+
 	admissionreg "k8s.io/api/admissionregistration/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	apireg "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -55,6 +59,16 @@ func (t *mutatingWebhookTarget) SetCA(data []byte) {
 	}
 }
 
+func (t *mutatingWebhookTarget) AsApplyObject() client.Object {
+	webhooks := make([]interface{}, len(t.obj.Webhooks))
+	for ind, webhook := range t.obj.Webhooks {
+		webhooks[ind] = webhookApplyConfiguration(webhook.Name, webhook.ClientConfig.CABundle)
+	}
+	obj := newApplyObject(admissionreg.SchemeGroupVersion.WithKind("MutatingWebhookConfiguration"), t.obj.Name)
+	obj.Object["webhooks"] = webhooks
+	return obj
+}
+
 // validatingWebhookInjector knows how to create an InjectTarget a ValidatingWebhookConfiguration.
 type validatingWebhookInjector struct{}
 
@@ -82,6 +96,16 @@ func (t *validatingWebhookTarget) SetCA(data []byte) {
 	}
 }
 
+func (t *validatingWebhookTarget) AsApplyObject() client.Object {
+	webhooks := make([]interface{}, len(t.obj.Webhooks))
+	for ind, webhook := range t.obj.Webhooks {
+		webhooks[ind] = webhookApplyConfiguration(webhook.Name, webhook.ClientConfig.CABundle)
+	}
+	obj := newApplyObject(admissionreg.SchemeGroupVersion.WithKind("ValidatingWebhookConfiguration"), t.obj.Name)
+	obj.Object["webhooks"] = webhooks
+	return obj
+}
+
 // apiServiceInjector knows how to create an InjectTarget for APICAReferences
 type apiServiceInjector struct{}
 
@@ -107,6 +131,14 @@ func (t *apiServiceTarget) SetCA(data []byte) {
 	t.obj.Spec.CABundle = data
 }
 
+func (t *apiServiceTarget) AsApplyObject() client.Object {
+	obj := newApplyObject(apireg.SchemeGroupVersion.WithKind("APIService"), t.obj.Name)
+	obj.Object["spec"] = map[string]interface{}{
+		"caBundle": base64.StdEncoding.EncodeToString(t.obj.Spec.CABundle),
+	}
+	return obj
+}
+
 // TODO(directxman12): conversion webhooks
 // crdConversionInjector knows how to create an InjectTarget for CRD conversion webhooks
 type crdConversionInjector struct{}
@@ -140,3 +172,41 @@ func (t *crdConversionTarget) SetCA(data []byte) {
 	}
 	t.obj.Spec.Conversion.Webhook.ClientConfig.CABundle = data
 }
+
+func (t *crdConversionTarget) AsApplyObject() client.Object {
+	obj := newApplyObject(apiext.SchemeGroupVersion.WithKind("CustomResourceDefinition"), t.obj.Name)
+	if t.obj.Spec.Conversion == nil || t.obj.Spec.Conversion.Strategy != apiext.WebhookConverter ||
+		t.obj.Spec.Conversion.Webhook == nil || t.obj.Spec.Conversion.Webhook.ClientConfig == nil {
+		return obj
+	}
+	obj.Object["spec"] = map[string]interface{}{
+		"conversion": map[string]interface{}{
+			"webhook": map[string]interface{}{
+				"clientConfig": map[string]interface{}{
+					"caBundle": base64.StdEncoding.EncodeToString(t.obj.Spec.Conversion.Webhook.ClientConfig.CABundle),
+				},
+			},
+		},
+	}
+	return obj
+}
+
+// newApplyObject returns an empty object of the given kind and name, to be
+// populated with the fields owned by the injector.
+func newApplyObject(gvk schema.GroupVersionKind, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	return obj
+}
+
+// webhookApplyConfiguration returns the entry of an admission webhook
+// configuration's webhooks which sets the CA bundle of the named webhook.
+func webhookApplyConfiguration(name string, caBundle []byte) map[string]interface{} {
+	return map[string]interface{}{
+		"name": name,
+		"clientConfig": map[string]interface{}{
+			"caBundle": base64.StdEncoding.EncodeToString(caBundle),
+		},
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cainjector
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionreg "k8s.io/api/admissionregistration/v1"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apireg "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+)
+
+func Test_AsApplyObject(t *testing.T) {
+	ca := []byte("ca")
+	sideEffects := admissionreg.SideEffectClassNone
+
+	tests := map[string]struct {
+		target InjectTarget
+		exp    string
+	}{
+		"mutating webhook configuration only contains the CA bundles": {
+			target: &mutatingWebhookTarget{obj: admissionreg.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Webhooks: []admissionreg.MutatingWebhook{
+					{Name: "a", SideEffects: &sideEffects, AdmissionReviewVersions: []string{"v1"}},
+					{Name: "b"},
+				},
+			}},
+			exp: `{"apiVersion":"admissionregistration.k8s.io/v1","kind":"MutatingWebhookConfiguration","metadata":{"name":"test"},"webhooks":[{"clientConfig":{"caBundle":"Y2E="},"name":"a"},{"clientConfig":{"caBundle":"Y2E="},"name":"b"}]}`,
+		},
+		"validating webhook configuration only contains the CA bundles": {
+			target: &validatingWebhookTarget{obj: admissionreg.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Webhooks:   []admissionreg.ValidatingWebhook{{Name: "a", SideEffects: &sideEffects}},
+			}},
+			exp: `{"apiVersion":"admissionregistration.k8s.io/v1","kind":"ValidatingWebhookConfiguration","metadata":{"name":"test"},"webhooks":[{"clientConfig":{"caBundle":"Y2E="},"name":"a"}]}`,
+		},
+		"api service only contains the CA bundle": {
+			target: &apiServiceTarget{obj: apireg.APIService{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       apireg.APIServiceSpec{Group: "example.com", GroupPriorityMinimum: 10},
+			}},
+			exp: `{"apiVersion":"apiregistration.k8s.io/v1","kind":"APIService","metadata":{"name":"test"},"spec":{"caBundle":"Y2E="}}`,
+		},
+		"crd with a conversion webhook only contains the CA bundle": {
+			target: &crdConversionTarget{obj: apiext.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: apiext.CustomResourceDefinitionSpec{
+					Group: "example.com",
+					Conversion: &apiext.CustomResourceConversion{
+						Strategy: apiext.WebhookConverter,
+						Webhook:  &apiext.WebhookConversion{ConversionReviewVersions: []string{"v1"}},
+					},
+				},
+			}},
+			exp: `{"apiVersion":"apiextensions.k8s.io/v1","kind":"CustomResourceDefinition","metadata":{"name":"test"},"spec":{"conversion":{"webhook":{"clientConfig":{"caBundle":"Y2E="}}}}}`,
+		},
+		"crd without a conversion webhook contains no fields": {
+			target: &crdConversionTarget{obj: apiext.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: apiext.CustomResourceDefinitionSpec{
+					Group:      "example.com",
+					Conversion: &apiext.CustomResourceConversion{Strategy: apiext.NoneConverter},
+				},
+			}},
+			exp: `{"apiVersion":"apiextensions.k8s.io/v1","kind":"CustomResourceDefinition","metadata":{"name":"test"}}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.target.SetCA(ca)
+			data, err := json.Marshal(test.target.AsApplyObject())
+			assert.NoError(t, err)
+			assert.JSONEq(t, test.exp, string(data))
+		})
+	}
+}
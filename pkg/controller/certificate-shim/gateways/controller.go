@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwlisters "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1alpha2"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -46,16 +47,25 @@ const (
 
 type controller struct {
 	gatewayLister gwlisters.GatewayLister
+	routeLister   gwlisters.HTTPRouteLister
 	sync          shimhelper.SyncFn
 
-	// For testing purposes.
+	// matchesWatchLabelSelector filters the Gateways which are processed.
+	matchesWatchLabelSelector func(map[string]string) bool
+
+	// Created in Register unless set for testing purposes.
 	queue workqueue.RateLimitingInterface
 }
 
 func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	if c.queue == nil {
+		c.queue = workqueue.NewNamedRateLimitingQueue(ctx.DefaultRateLimiterFor(ControllerName), ControllerName)
+	}
 	c.gatewayLister = ctx.GWShared.Gateway().V1alpha2().Gateways().Lister()
+	c.routeLister = ctx.GWShared.Gateway().V1alpha2().HTTPRoutes().Lister()
 	log := logf.FromContext(ctx.RootContext, ControllerName)
-	c.sync = shimhelper.SyncFnFor(ctx.Recorder, log, ctx.CMClient, ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(), ctx.IngressShimOptions, ctx.FieldManager)
+	c.matchesWatchLabelSelector = ctx.MatchesWatchLabelSelector
+	c.sync = shimhelper.SyncFnFor(ctx.Recorder, log, ctx.CMClient, ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(), c.routeLister, ctx.IngressShimOptions, ctx.FieldManager)
 
 	// We don't need to requeue Gateways on "Deleted" events, since our Sync
 	// function does nothing when the Gateway lister returns "not found". But we
@@ -78,8 +88,24 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 		WorkFunc: certificateHandler(c.queue),
 	})
 
+	// The listeners of a Gateway which have no hostname get the hostnames of
+	// the HTTPRoutes attached to them, so the parent Gateways of an HTTPRoute
+	// are re-queued when it changes. On updates, the Gateways the HTTPRoute
+	// was attached to are re-queued as well, so that its hostnames are
+	// removed from their Certificates.
+	routeHandler := httpRouteHandler(c.queue)
+	ctx.GWShared.Gateway().V1alpha2().HTTPRoutes().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: routeHandler,
+		UpdateFunc: func(old, new interface{}) {
+			routeHandler(old)
+			routeHandler(new)
+		},
+		DeleteFunc: routeHandler,
+	})
+
 	mustSync := []cache.InformerSynced{
 		ctx.GWShared.Gateway().V1alpha2().Gateways().Informer().HasSynced,
+		ctx.GWShared.Gateway().V1alpha2().HTTPRoutes().Informer().HasSynced,
 		ctx.SharedInformerFactory.Certmanager().V1().Certificates().Informer().HasSynced,
 	}
 
@@ -104,6 +130,10 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !c.matchesWatchLabelSelector(gateway.Labels) {
+		return nil
+	}
+
 	return c.sync(ctx, gateway)
 }
 
@@ -148,10 +178,39 @@ func certificateHandler(queue workqueue.RateLimitingInterface) func(obj interfac
 	}
 }
 
+// httpRouteHandler re-queues the Gateways an HTTPRoute refers to in its
+// parentRefs.
+func httpRouteHandler(queue workqueue.RateLimitingInterface) func(obj interface{}) {
+	return func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		route, ok := obj.(*gwapi.HTTPRoute)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("not an HTTPRoute object: %#v", obj))
+			return
+		}
+
+		for _, ref := range route.Spec.ParentRefs {
+			if ref.Group != nil && *ref.Group != gwapi.GroupName {
+				continue
+			}
+			if ref.Kind != nil && *ref.Kind != "Gateway" {
+				continue
+			}
+			namespace := route.Namespace
+			if ref.Namespace != nil && *ref.Namespace != "" {
+				namespace = string(*ref.Namespace)
+			}
+			queue.Add(namespace + "/" + string(ref.Name))
+		}
+	}
+}
+
 func init() {
 	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
 		return controllerpkg.NewBuilder(ctx, ControllerName).
-			For(&controller{queue: workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)}).
+			For(&controller{}).
 			Complete()
 	})
 }
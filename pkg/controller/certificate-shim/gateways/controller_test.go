@@ -86,6 +86,42 @@ func Test_controller_Register(t *testing.T) {
 			expectAddCalls: []interface{}{"namespace-1/gateway-1", "namespace-1/gateway-1"},
 			//                                <----- Create ------>    <------ Delete ----->
 		},
+		{
+			name: "gateways are re-queued when an 'Added' event is received for an HTTPRoute attached to them",
+			givenCall: func(t *testing.T, _ cmclient.Interface, c gwclient.Interface) {
+				otherNamespace := gwapi.Namespace("namespace-2")
+				serviceKind := gwapi.Kind("Service")
+				_, err := c.GatewayV1alpha2().HTTPRoutes("namespace-1").Create(context.Background(), &gwapi.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-1", Name: "route-1"},
+					Spec: gwapi.HTTPRouteSpec{CommonRouteSpec: gwapi.CommonRouteSpec{ParentRefs: []gwapi.ParentReference{
+						{Name: "gateway-1"},
+						{Namespace: &otherNamespace, Name: "gateway-2"},
+						{Kind: &serviceKind, Name: "service-1"},
+					}}},
+				}, metav1.CreateOptions{})
+				require.NoError(t, err)
+			},
+			expectAddCalls: []interface{}{"namespace-1/gateway-1", "namespace-2/gateway-2"},
+		},
+		{
+			name: "gateways are re-queued when an HTTPRoute is moved from one gateway to another",
+			givenCall: func(t *testing.T, _ cmclient.Interface, c gwclient.Interface) {
+				route := &gwapi.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-1", Name: "route-1"},
+					Spec: gwapi.HTTPRouteSpec{CommonRouteSpec: gwapi.CommonRouteSpec{ParentRefs: []gwapi.ParentReference{
+						{Name: "gateway-1"},
+					}}},
+				}
+				_, err := c.GatewayV1alpha2().HTTPRoutes("namespace-1").Create(context.Background(), route, metav1.CreateOptions{})
+				require.NoError(t, err)
+
+				route.Spec.ParentRefs[0].Name = "gateway-2"
+				_, err = c.GatewayV1alpha2().HTTPRoutes("namespace-1").Update(context.Background(), route, metav1.UpdateOptions{})
+				require.NoError(t, err)
+			},
+			expectAddCalls: []interface{}{"namespace-1/gateway-1", "namespace-1/gateway-1", "namespace-1/gateway-2"},
+			//                                <----- Create ------>    <------------------ Update ------------------->
+		},
 		{
 			name: "gateway is re-queued when an 'Added' event is received for its child Certificate",
 			givenCall: func(t *testing.T, c cmclient.Interface, _ gwclient.Interface) {
@@ -173,7 +209,8 @@ func Test_controller_Register(t *testing.T) {
 			time.Sleep(50 * time.Millisecond)
 
 			// We only expect 0 or 1 keys received in the queue, or 2 keys when
-			// we have to create a Gateway before deleting or updating it.
+			// we have to create a Gateway before deleting or updating it. An
+			// HTTPRoute re-queues each of its parent Gateways.
 			assert.Equal(t, test.expectAddCalls, mock.callsToAdd)
 		})
 	}
@@ -40,6 +40,9 @@ const (
 type controller struct {
 	ingressLister networkingv1listers.IngressLister
 	sync          shimhelper.SyncFn
+
+	// matchesWatchLabelSelector filters the Ingresses which are processed.
+	matchesWatchLabelSelector func(map[string]string) bool
 }
 
 func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
@@ -49,9 +52,10 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.ingressLister = ingressInformer.Lister()
 
 	log := logf.FromContext(ctx.RootContext, ControllerName)
-	c.sync = shimhelper.SyncFnFor(ctx.Recorder, log, ctx.CMClient, cmShared.Certmanager().V1().Certificates().Lister(), ctx.IngressShimOptions, ctx.FieldManager)
+	c.matchesWatchLabelSelector = ctx.MatchesWatchLabelSelector
+	c.sync = shimhelper.SyncFnFor(ctx.Recorder, log, ctx.CMClient, cmShared.Certmanager().V1().Certificates().Lister(), nil, ctx.IngressShimOptions, ctx.FieldManager)
 
-	queue := workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
+	queue := workqueue.NewNamedRateLimitingQueue(ctx.DefaultRateLimiterFor(ControllerName), ControllerName)
 
 	mustSync := []cache.InformerSynced{
 		ingressInformer.Informer().HasSynced,
@@ -102,6 +106,10 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if !c.matchesWatchLabelSelector(ingress.Labels) {
+		return nil
+	}
+
 	return c.sync(ctx, ingress)
 }
 
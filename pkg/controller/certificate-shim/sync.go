@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -35,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/record"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwlisters "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1alpha2"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
@@ -69,11 +71,16 @@ type SyncFn func(context.Context, metav1.Object) error
 // common. Reconciling an Ingress-like object means looking at its annotations
 // and creating a Certificate with matching DNS names and secretNames from the
 // TLS configuration of the Ingress-like object.
+//
+// The routeLister is used to find the HTTPRoutes attached to the listeners of
+// a Gateway which have no hostname. It may be nil when only Ingresses are
+// reconciled.
 func SyncFnFor(
 	rec record.EventRecorder,
 	log logr.Logger,
 	cmClient clientset.Interface,
 	cmLister cmlisters.CertificateLister,
+	routeLister gwlisters.HTTPRouteLister,
 	defaults controller.IngressShimOptions,
 	fieldManager string,
 ) SyncFn {
@@ -116,7 +123,7 @@ func SyncFnFor(
 			return nil
 		}
 
-		newCrts, updateCrts, err := buildCertificates(rec, log, cmLister, ingLike, issuerName, issuerKind, issuerGroup)
+		newCrts, updateCrts, err := buildCertificates(rec, log, cmLister, routeLister, ingLike, issuerName, issuerKind, issuerGroup)
 		if err != nil {
 			return err
 		}
@@ -239,10 +246,13 @@ func validateIngressTLSBlock(path *field.Path, tlsBlock networkingv1.IngressTLS)
 	return errs
 }
 
-func validateGatewayListenerBlock(path *field.Path, l gwapi.Listener) field.ErrorList {
+// validateGatewayListenerBlock validates a listener of a Gateway. A listener
+// without a hostname is only valid if HTTPRoutes with hostnames are attached
+// to it, as given by routeHostnames.
+func validateGatewayListenerBlock(path *field.Path, l gwapi.Listener, routeHostnames []string) field.ErrorList {
 	var errs field.ErrorList
 
-	if l.Hostname == nil || *l.Hostname == "" {
+	if (l.Hostname == nil || *l.Hostname == "") && len(routeHostnames) == 0 {
 		errs = append(errs, field.Required(path.Child("hostname"), "the hostname cannot be empty"))
 	}
 
@@ -257,12 +267,13 @@ func validateGatewayListenerBlock(path *field.Path, l gwapi.Listener) field.Erro
 	} else {
 		// check that each CertificateRef is valid
 		for i, secretRef := range l.TLS.CertificateRefs {
-			if *secretRef.Group != "core" && *secretRef.Group != "" {
+			// Group and Kind default to "" and "Secret" respectively.
+			if secretRef.Group != nil && *secretRef.Group != "core" && *secretRef.Group != "" {
 				errs = append(errs, field.NotSupported(path.Child("tls").Child("certificateRef").Index(i).Child("group"),
 					*secretRef.Group, []string{"core", ""}))
 			}
 
-			if *secretRef.Kind != "Secret" && *secretRef.Kind != "" {
+			if secretRef.Kind != nil && *secretRef.Kind != "Secret" && *secretRef.Kind != "" {
 				errs = append(errs, field.NotSupported(path.Child("tls").Child("certificateRef").Index(i).Child("kind"),
 					*secretRef.Kind, []string{"Secret", ""}))
 			}
@@ -286,6 +297,7 @@ func buildCertificates(
 	rec record.EventRecorder,
 	log logr.Logger,
 	cmLister cmlisters.CertificateLister,
+	routeLister gwlisters.HTTPRouteLister,
 	ingLike metav1.Object,
 	issuerName, issuerKind, issuerGroup string,
 ) (new, update []*cmapi.Certificate, _ error) {
@@ -310,7 +322,22 @@ func buildCertificates(
 		}
 	case *gwapi.Gateway:
 		for i, l := range ingLike.Spec.Listeners {
-			err := validateGatewayListenerBlock(field.NewPath("spec", "listeners").Index(i), l).ToAggregate()
+			// The hostnames of the attached HTTPRoutes are only used for
+			// listeners which do not set a hostname themselves.
+			var hosts []string
+			if l.Hostname != nil && *l.Hostname != "" {
+				// Gateway API hostname explicitly disallows IP addresses, so
+				// this should be OK.
+				hosts = []string{string(*l.Hostname)}
+			} else {
+				var err error
+				hosts, err = attachedRouteHostnames(routeLister, ingLike, l)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+
+			err := validateGatewayListenerBlock(field.NewPath("spec", "listeners").Index(i), l, hosts).ToAggregate()
 			if err != nil {
 				rec.Eventf(ingLike, corev1.EventTypeWarning, reasonBadConfig, "Skipped a listener block: "+err.Error())
 				continue
@@ -325,9 +352,11 @@ func buildCertificates(
 				} else {
 					secretRef.Namespace = ingLike.GetNamespace()
 				}
-				// Gateway API hostname explicitly disallows IP addresses, so this
-				// should be OK.
-				tlsHosts[secretRef] = append(tlsHosts[secretRef], fmt.Sprintf("%s", *l.Hostname))
+				for _, host := range hosts {
+					if !containsString(tlsHosts[secretRef], host) {
+						tlsHosts[secretRef] = append(tlsHosts[secretRef], host)
+					}
+				}
 			}
 		}
 	default:
@@ -416,6 +445,94 @@ func buildCertificates(
 	return newCrts, updateCrts, nil
 }
 
+// attachedRouteHostnames returns the sorted hostnames of the HTTPRoutes
+// attached to the given listener of a Gateway. An HTTPRoute is attached to
+// the listener if one of its parentRefs refers to the Gateway and, when set,
+// to the name and port of the listener, and if the listener allows routes
+// from the namespace of the HTTPRoute. Routes from other namespaces are only
+// allowed with `allowedRoutes.namespaces.from: All`; namespace selectors are
+// not supported and only allow routes from the namespace of the Gateway.
+func attachedRouteHostnames(routeLister gwlisters.HTTPRouteLister, gw *gwapi.Gateway, l gwapi.Listener) ([]string, error) {
+	if routeLister == nil {
+		return nil, nil
+	}
+
+	namespace := gw.Namespace
+	if l.AllowedRoutes != nil {
+		if len(l.AllowedRoutes.Kinds) > 0 {
+			allowsHTTPRoutes := false
+			for _, kind := range l.AllowedRoutes.Kinds {
+				if kind.Kind == "HTTPRoute" && (kind.Group == nil || *kind.Group == gwapi.GroupName) {
+					allowsHTTPRoutes = true
+				}
+			}
+			if !allowsHTTPRoutes {
+				return nil, nil
+			}
+		}
+		if ns := l.AllowedRoutes.Namespaces; ns != nil && ns.From != nil && *ns.From == gwapi.NamespacesFromAll {
+			namespace = metav1.NamespaceAll
+		}
+	}
+
+	routes, err := routeLister.HTTPRoutes(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []string
+	for _, route := range routes {
+		if !routeAttachedTo(route, gw, l) {
+			continue
+		}
+		for _, host := range route.Spec.Hostnames {
+			if host != "" && !containsString(hosts, string(host)) {
+				hosts = append(hosts, string(host))
+			}
+		}
+	}
+	// The lister returns the routes in no particular order.
+	sort.Strings(hosts)
+	return hosts, nil
+}
+
+// routeAttachedTo returns true if one of the parentRefs of the HTTPRoute
+// refers to the given listener of the Gateway.
+func routeAttachedTo(route *gwapi.HTTPRoute, gw *gwapi.Gateway, l gwapi.Listener) bool {
+	for _, ref := range route.Spec.ParentRefs {
+		if ref.Group != nil && *ref.Group != gwapi.GroupName {
+			continue
+		}
+		if ref.Kind != nil && *ref.Kind != "Gateway" {
+			continue
+		}
+		namespace := route.Namespace
+		if ref.Namespace != nil && *ref.Namespace != "" {
+			namespace = string(*ref.Namespace)
+		}
+		if namespace != gw.Namespace || string(ref.Name) != gw.Name {
+			continue
+		}
+		if ref.SectionName != nil && *ref.SectionName != l.Name {
+			continue
+		}
+		if ref.Port != nil && *ref.Port != l.Port {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
 func findCertificatesToBeRemoved(certs []*cmapi.Certificate, ingLike metav1.Object) []string {
 	var toBeRemoved []string
 	for _, crt := range certs {
@@ -85,6 +85,7 @@ func TestSync(t *testing.T) {
 		IssuerLister        []runtime.Object
 		ClusterIssuerLister []runtime.Object
 		CertificateLister   []runtime.Object
+		HTTPRouteLister     []runtime.Object
 		DefaultIssuerName   string
 		DefaultIssuerKind   string
 		DefaultIssuerGroup  string
@@ -1462,7 +1463,129 @@ func TestSync(t *testing.T) {
 		},
 	}
 
+	// httpRoute returns an HTTPRoute attached to the given listener of the
+	// Gateway "gateway-name".
+	httpRoute := func(namespace, name string, sectionName *gwapi.SectionName, hostnames ...gwapi.Hostname) *gwapi.HTTPRoute {
+		gwNamespace := gwapi.Namespace(gen.DefaultTestNamespace)
+		return &gwapi.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: gwapi.HTTPRouteSpec{
+				CommonRouteSpec: gwapi.CommonRouteSpec{
+					ParentRefs: []gwapi.ParentReference{{
+						Namespace:   &gwNamespace,
+						Name:        "gateway-name",
+						SectionName: sectionName,
+					}},
+				},
+				Hostnames: hostnames,
+			},
+		}
+	}
+	httpsSection := gwapi.SectionName("https")
+	otherSection := gwapi.SectionName("other")
+	// gatewayWithoutHostname returns a Gateway with a single listener which
+	// has no hostname.
+	gatewayWithoutHostname := func(allowedRoutes *gwapi.AllowedRoutes) *gwapi.Gateway {
+		return &gwapi.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gateway-name",
+				Namespace: gen.DefaultTestNamespace,
+				Annotations: map[string]string{
+					cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+				},
+				UID: types.UID("gateway-name"),
+			},
+			Spec: gwapi.GatewaySpec{
+				GatewayClassName: "test-gateway",
+				Listeners: []gwapi.Listener{
+					{
+						Name:          "https",
+						Port:          443,
+						Protocol:      "HTTPS",
+						AllowedRoutes: allowedRoutes,
+						TLS: &gwapi.GatewayTLSConfig{
+							Mode: ptrMode(gwapi.TLSModeTerminate),
+							CertificateRefs: []gwapi.SecretObjectReference{
+								{Name: "example-com-tls"},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	fromAll := gwapi.NamespacesFromAll
 	testGatewayShim := []testT{
+		{
+			Name:        "return a Certificate with the hostnames of the HTTPRoutes attached to a listener without hostname",
+			Issuer:      acmeClusterIssuer,
+			IngressLike: gatewayWithoutHostname(nil),
+			HTTPRouteLister: []runtime.Object{
+				httpRoute(gen.DefaultTestNamespace, "route-1", &httpsSection, "www.example.com", "example.com"),
+				httpRoute(gen.DefaultTestNamespace, "route-2", nil, "api.example.com", "example.com"),
+				httpRoute(gen.DefaultTestNamespace, "other-listener", &otherSection, "other.example.com"),
+				httpRoute("other-namespace", "not-allowed", nil, "not-allowed.example.com"),
+			},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			ExpectedEvents:      []string{`Normal CreateCertificate Successfully created Certificate "example-com-tls"`},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildGatewayOwnerReferences("gateway-name", gen.DefaultTestNamespace),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"api.example.com", "example.com", "www.example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+						Usages: cmapi.DefaultKeyUsages(),
+					},
+				},
+			},
+		},
+		{
+			Name:        "return a Certificate with the hostnames of HTTPRoutes in other namespaces if the listener allows routes from all namespaces",
+			Issuer:      acmeClusterIssuer,
+			IngressLike: gatewayWithoutHostname(&gwapi.AllowedRoutes{Namespaces: &gwapi.RouteNamespaces{From: &fromAll}}),
+			HTTPRouteLister: []runtime.Object{
+				httpRoute(gen.DefaultTestNamespace, "route-1", nil, "www.example.com"),
+				httpRoute("other-namespace", "route-2", nil, "app.example.com"),
+			},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			ExpectedEvents:      []string{`Normal CreateCertificate Successfully created Certificate "example-com-tls"`},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildGatewayOwnerReferences("gateway-name", gen.DefaultTestNamespace),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"app.example.com", "www.example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+						Usages: cmapi.DefaultKeyUsages(),
+					},
+				},
+			},
+		},
+		{
+			Name:        "skip a listener without hostname if no HTTPRoute with hostnames is attached to it",
+			Issuer:      acmeClusterIssuer,
+			IngressLike: gatewayWithoutHostname(nil),
+			HTTPRouteLister: []runtime.Object{
+				httpRoute(gen.DefaultTestNamespace, "no-hostnames", nil),
+			},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			ExpectedEvents:      []string{`Warning BadConfig Skipped a listener block: spec.listeners[0].hostname: Required value: the hostname cannot be empty`},
+		},
 		{
 			Name:   "return a single Certificate for a Gateway with a single valid TLS entry and common-name annotation",
 			Issuer: acmeClusterIssuer,
@@ -2836,12 +2959,13 @@ func TestSync(t *testing.T) {
 			b := &testpkg.Builder{
 				T:                  t,
 				CertManagerObjects: allCMObjects,
+				GWObjects:          test.HTTPRouteLister,
 				ExpectedActions:    expectedActions,
 				ExpectedEvents:     test.ExpectedEvents,
 			}
 			b.Init()
 			defer b.Stop()
-			sync := SyncFnFor(b.Recorder, logr.Discard(), b.CMClient, b.SharedInformerFactory.Certmanager().V1().Certificates().Lister(), controller.IngressShimOptions{
+			sync := SyncFnFor(b.Recorder, logr.Discard(), b.CMClient, b.SharedInformerFactory.Certmanager().V1().Certificates().Lister(), b.GWShared.Gateway().V1alpha2().HTTPRoutes().Lister(), controller.IngressShimOptions{
 				DefaultIssuerName:                 test.DefaultIssuerName,
 				DefaultIssuerKind:                 test.DefaultIssuerKind,
 				DefaultIssuerGroup:                test.DefaultIssuerGroup,
@@ -3038,9 +3162,10 @@ func ptrMode(mode gwapi.TLSModeType) *gwapi.TLSModeType {
 
 func Test_validateGatewayListenerBlock(t *testing.T) {
 	tests := []struct {
-		name     string
-		listener gwapi.Listener
-		wantErr  string
+		name           string
+		listener       gwapi.Listener
+		routeHostnames []string
+		wantErr        string
 	}{
 		{
 			name: "empty TLS block",
@@ -3070,6 +3195,25 @@ func Test_validateGatewayListenerBlock(t *testing.T) {
 			},
 			wantErr: "spec.listeners[0].hostname: Required value: the hostname cannot be empty",
 		},
+		{
+			name: "empty hostname with attached HTTPRoutes",
+			listener: gwapi.Listener{
+				Port:     gwapi.PortNumber(443),
+				Protocol: gwapi.HTTPSProtocolType,
+				TLS: &gwapi.GatewayTLSConfig{
+					Mode: ptrMode(gwapi.TLSModeTerminate),
+					CertificateRefs: []gwapi.SecretObjectReference{
+						{
+							Group: func() *gwapi.Group { g := gwapi.Group("core"); return &g }(),
+							Kind:  func() *gwapi.Kind { k := gwapi.Kind("Secret"); return &k }(),
+							Name:  "example-com",
+						},
+					},
+				},
+			},
+			routeHostnames: []string{"example.com"},
+			wantErr:        "",
+		},
 		{
 			name: "empty group",
 			listener: gwapi.Listener{
@@ -3131,7 +3275,7 @@ func Test_validateGatewayListenerBlock(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			gotErr := validateGatewayListenerBlock(field.NewPath("spec", "listeners").Index(0), test.listener).ToAggregate()
+			gotErr := validateGatewayListenerBlock(field.NewPath("spec", "listeners").Index(0), test.listener, test.routeHostnames).ToAggregate()
 			if test.wantErr == "" {
 				assert.NoError(t, gotErr)
 			} else {
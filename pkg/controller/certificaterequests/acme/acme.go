@@ -40,6 +40,7 @@ import (
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/tracing"
 	"github.com/go-logr/logr"
 )
 
@@ -116,7 +117,7 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 	log := logf.FromContext(ctx, "sign")
 
 	// If we can't decode the CSR PEM we have to hard fail
-	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	csr, err := crutil.ParseCSR(cr)
 	if err != nil {
 		message := "Failed to decode CSR in spec.request"
 
@@ -138,8 +139,29 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 		return nil, nil
 	}
 
+	// ACME servers can only validate DNS names and IP addresses, so an order
+	// for a request with URI SANs would only fail when it is finalized.
+	if len(csr.URIs) > 0 {
+		err = fmt.Errorf("requested URI SANs %s", pki.URLsToString(csr.URIs))
+		message := "The CSR PEM requests URI SANs, which ACME servers cannot issue"
+
+		a.reporter.Failed(cr, err, "InvalidOrder", message)
+
+		log.V(logf.DebugLevel).Info(fmt.Sprintf("%s: %s", message, err))
+
+		return nil, nil
+	}
+
+	// Orders and Challenges are always processed against the issuer an
+	// IssuerAlias resolved to when the request was signed.
+	orderCR := cr
+	if cr.Spec.IssuerRef.Kind == cmapi.IssuerAliasKind {
+		orderCR = cr.DeepCopy()
+		orderCR.Spec.IssuerRef = issuerpkg.ReferenceFor(issuer)
+	}
+
 	// If we fail to build the order we have to hard fail.
-	expectedOrder, err := buildOrder(cr, csr, issuer.GetSpec().ACME.EnableDurationFeature)
+	expectedOrder, err := buildOrder(orderCR, csr, issuer.GetSpec().ACME.EnableDurationFeature)
 	if err != nil {
 		message := "Failed to build order"
 
@@ -151,6 +173,9 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 
 	order, err := a.orderLister.Orders(expectedOrder.Namespace).Get(expectedOrder.Name)
 	if k8sErrors.IsNotFound(err) {
+		// Continue the trace of this sign call in the orders controller.
+		expectedOrder.Annotations = tracing.AnnotationsWithTraceContext(ctx, expectedOrder.Annotations)
+
 		// Failing to create the order here is most likely network related.
 		// We should backoff and keep trying.
 		_, err = a.acmeClientV.Orders(expectedOrder.Namespace).Create(ctx, expectedOrder, metav1.CreateOptions{FieldManager: a.fieldManager})
@@ -189,6 +189,14 @@ func TestSign(t *testing.T) {
 		t.Fatalf("failed to build order during testing: %s", err)
 	}
 
+	csrPEMWithURI, err := gen.CSRWithSigner(sk,
+		gen.SetCSRDNSNames("example.com"),
+		gen.SetCSRURIsFromStrings("spiffe://cluster.local/ns/default"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	baseOrder, err := buildOrder(baseCR, csr, baseIssuer.GetSpec().ACME.EnableDurationFeature)
 	if err != nil {
 		t.Fatalf("failed to build order during testing: %s", err)
@@ -212,9 +220,8 @@ func TestSign(t *testing.T) {
 				CertManagerObjects: []runtime.Object{baseCRDenied.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedEvents:     []string{},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCRDenied,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -226,7 +233,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -241,9 +248,8 @@ func TestSign(t *testing.T) {
 					"Warning RequestParsingError Failed to decode CSR in spec.request: error decoding certificate request PEM block",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCSR([]byte("a bad csr")),
@@ -256,7 +262,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -270,9 +276,8 @@ func TestSign(t *testing.T) {
 					`Warning InvalidOrder The CSR PEM requests a commonName that is not present in the list of dnsNames or ipAddresses. If a commonName is set, ACME requires that the value is also present in the list of dnsNames or ipAddresses: "example.com" does not exist in [foo.com] or []`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCSR(csrPEMExampleNotPresent),
@@ -285,7 +290,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -300,9 +305,8 @@ func TestSign(t *testing.T) {
 					`Warning InvalidOrder The CSR PEM requests a commonName that is not present in the list of dnsNames or ipAddresses. If a commonName is set, ACME requires that the value is also present in the list of dnsNames or ipAddresses: "10.0.0.1" does not exist in [example.com] or []`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCSR(generateCSR(t, sk, "10.0.0.1", "example.com")),
@@ -315,7 +319,36 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
+				},
+			},
+		},
+
+		"if URI SANs are requested then should hard fail": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestCSR(csrPEMWithURI),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning InvalidOrder The CSR PEM requests URI SANs, which ACME servers cannot issue: requested URI SANs [spiffe://cluster.local/ns/default]`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCSR(csrPEMWithURI),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `The CSR PEM requests URI SANs, which ACME servers cannot issue: requested URI SANs [spiffe://cluster.local/ns/default]`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					),
 				},
 			},
 		},
@@ -335,9 +368,8 @@ func TestSign(t *testing.T) {
 						gen.DefaultTestNamespace,
 						ipBaseOrder,
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(ipBaseCR,
 							gen.SetCertificateRequestCSR(ipCSRPEM),
@@ -349,7 +381,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -369,9 +401,8 @@ func TestSign(t *testing.T) {
 						gen.DefaultTestNamespace,
 						baseOrder,
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -382,7 +413,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -398,9 +429,8 @@ func TestSign(t *testing.T) {
 					"Normal IssuerNotReady Referenced issuer does not have a Ready status condition",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -411,7 +441,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -424,9 +454,8 @@ func TestSign(t *testing.T) {
 				},
 				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -437,7 +466,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 			fakeOrderLister: &testlisters.FakeOrderLister{
@@ -465,9 +494,8 @@ func TestSign(t *testing.T) {
 					),
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -479,7 +507,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -496,9 +524,8 @@ func TestSign(t *testing.T) {
 					),
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -509,7 +536,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -524,9 +551,8 @@ func TestSign(t *testing.T) {
 					gen.SetOrderState(cmacme.Valid),
 				), baseCR.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -537,7 +563,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -587,9 +613,8 @@ func TestSign(t *testing.T) {
 					gen.SetOrderCertificate(certBundle.ChainPEM),
 				), baseCR.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -601,7 +626,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestCertificate(certBundle.ChainPEM),
 						),
-					)),
+					),
 				},
 			},
 		},
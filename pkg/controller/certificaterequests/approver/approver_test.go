@@ -22,7 +22,6 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -190,12 +189,11 @@ func TestProcessItem(t *testing.T) {
 				expectedRequest := test.request.DeepCopy()
 				expectedRequest.Status.Conditions = test.expectedConditions
 				builder.ExpectedActions = append(builder.ExpectedActions,
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						test.request.Namespace,
 						expectedRequest,
-					)),
+					),
 				)
 			}
 			if test.expectedEvent != "" {
@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// batchKey returns a hash of everything which determines the certificate
+// issued for the CertificateRequest, and of the owner of the request: its
+// controller owner reference if it has one, or else the user who created it.
+// Identical requests from the same owner, for example created by the
+// replicas of a controller racing each other, are signed only once.
+// The hash is truncated to fit in the value of the SignBatchLabelKey label.
+// An empty key is returned for requests without an owner, and for requests
+// whose CSR is held in a Secret rather than in the request.
+func batchKey(cr *cmapi.CertificateRequest) (string, error) {
+	if cr.Spec.RequestSecretRef != nil {
+		return "", nil
+	}
+
+	var owner string
+	if ref := metav1.GetControllerOf(cr); ref != nil {
+		owner = string(ref.UID)
+	} else if cr.Spec.Username != "" {
+		owner = "user:" + cr.Spec.Username
+	} else {
+		return "", nil
+	}
+
+	csr, err := util.ParseCSR(cr)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(struct {
+		Namespace string
+		Owner     string
+		CSR       []byte
+		IssuerRef cmmeta.ObjectReference
+		Duration  string
+		Usages    []cmapi.KeyUsage
+		IsCA      bool
+	}{
+		Namespace: cr.Namespace,
+		Owner:     owner,
+		CSR:       csr.RawTBSCertificateRequest,
+		IssuerRef: cr.Spec.IssuerRef,
+		Duration:  fmt.Sprint(cr.Spec.Duration),
+		Usages:    cr.Spec.Usages,
+		IsCA:      cr.Spec.IsCA,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:28]), nil
+}
+
+// signBatchLeader returns the request of the batch of identical requests
+// whose certificate the given request shares, which is the request itself if
+// it is to be signed. Requests are batched by the SignBatchLabelKey label,
+// which is set on each of them before any is signed, so that requests
+// processed by other replicas of the controller or before a restart are
+// batched too.
+// The certificate of a request of the batch which has already been issued
+// is shared. Otherwise, the oldest request which has not failed is signed
+// and the others wait for it, even if the issuer signs it asynchronously.
+func (c *Controller) signBatchLeader(cr *cmapi.CertificateRequest, key string) (*cmapi.CertificateRequest, error) {
+	reqs, err := c.certificateRequestLister.CertificateRequests(cr.Namespace).List(labels.SelectorFromSet(labels.Set{cmapi.SignBatchLabelKey: key}))
+	if err != nil {
+		return nil, err
+	}
+
+	leader := cr
+	for _, req := range reqs {
+		if req.Name == cr.Name || !apiutil.CertificateRequestIsApproved(req) || apiutil.CertificateRequestIsDenied(req) {
+			continue
+		}
+		// The label can be set by anyone able to create a request, so it is
+		// only trusted if the request is identical.
+		if reqKey, err := batchKey(req); err != nil || reqKey != key {
+			continue
+		}
+
+		switch apiutil.CertificateRequestReadyReason(req) {
+		case cmapi.CertificateRequestReasonIssued:
+			cert, err := pki.DecodeX509CertificateBytes(req.Status.Certificate)
+			if err == nil && c.clock.Now().Before(cert.NotAfter) {
+				return req, nil
+			}
+		case cmapi.CertificateRequestReasonFailed:
+		default:
+			if signedBefore(req, leader) {
+				leader = req
+			}
+		}
+	}
+	return leader, nil
+}
+
+// signedBefore returns true if a is signed before b when both are in the same
+// batch: the oldest request is signed, and the name breaks ties between
+// requests created in the same second.
+func signedBefore(a, b *cmapi.CertificateRequest) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
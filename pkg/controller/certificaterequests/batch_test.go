@@ -0,0 +1,194 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestBatchKey(t *testing.T) {
+	sk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	otherSK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	owner := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "app", UID: "uid-1", Controller: pointer.Bool(true)}
+	otherOwner := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "other", UID: "uid-2", Controller: pointer.Bool(true)}
+
+	base := gen.CertificateRequest("replica-1",
+		gen.SetCertificateRequestCSR(generateCSR(t, sk)),
+		gen.AddCertificateRequestOwnerReferences(owner),
+	)
+	fromUser := gen.CertificateRequest("from-user-1",
+		gen.SetCertificateRequestCSR(generateCSR(t, sk)),
+		gen.SetCertificateRequestUsername("system:serviceaccount:default:app"),
+	)
+
+	key := func(cr *cmapi.CertificateRequest) string {
+		key, err := batchKey(cr)
+		require.NoError(t, err)
+		return key
+	}
+	baseKey := key(base)
+	assert.NotEmpty(t, baseKey)
+	assert.LessOrEqual(t, len(baseKey), 63, "expected the key to fit in a label value")
+
+	assert.Equal(t, baseKey, key(gen.CertificateRequestFrom(base, gen.SetCertificateRequestName("replica-2"))))
+	assert.Equal(t, baseKey, key(gen.CertificateRequestFrom(base, gen.SetCertificateRequestCSR(generateCSR(t, sk)))),
+		"expected a new signature of the same CSR to be identical")
+	assert.Equal(t, key(fromUser), key(gen.CertificateRequestFrom(fromUser, gen.SetCertificateRequestName("from-user-2"))),
+		"expected requests of the same user to be identical")
+
+	for name, cr := range map[string]*cmapi.CertificateRequest{
+		"other owner": gen.CertificateRequest("other-1",
+			gen.SetCertificateRequestCSR(generateCSR(t, sk)),
+			gen.AddCertificateRequestOwnerReferences(otherOwner),
+		),
+		"other key":      gen.CertificateRequestFrom(base, gen.SetCertificateRequestCSR(generateCSR(t, otherSK))),
+		"other duration": gen.CertificateRequestFrom(base, gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour})),
+		"other issuer":   gen.CertificateRequestFrom(base, gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "other"})),
+	} {
+		assert.NotEqual(t, baseKey, key(cr), "expected request with %s not to be identical", name)
+	}
+
+	assert.Empty(t, key(gen.CertificateRequest("no-owner", gen.SetCertificateRequestCSR(generateCSR(t, sk)))),
+		"expected requests without an owner not to be batched")
+	assert.Empty(t, key(gen.CertificateRequestFrom(base, gen.SetCertificateRequestCSRSecretRef("csr", "tls.csr", nil))),
+		"expected requests reading their CSR from a Secret not to be batched")
+}
+
+func TestSignBatchLeader(t *testing.T) {
+	now := time.Now()
+	sk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	otherSK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	owner := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "app", UID: "uid-1", Controller: pointer.Bool(true)}
+	base := gen.CertificateRequest("base",
+		gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateRequestCSR(generateCSR(t, sk)),
+		gen.AddCertificateRequestOwnerReferences(owner),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionApproved,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+	key, err := batchKey(base)
+	require.NoError(t, err)
+
+	request := func(name string, created time.Time, mods ...gen.CertificateRequestModifier) *cmapi.CertificateRequest {
+		cr := gen.CertificateRequestFrom(base, append([]gen.CertificateRequestModifier{gen.SetCertificateRequestName(name)}, mods...)...)
+		cr.CreationTimestamp = metav1.NewTime(created)
+		cr.Labels = map[string]string{cmapi.SignBatchLabelKey: key}
+		return cr
+	}
+	readyCondition := func(reason string) gen.CertificateRequestModifier {
+		return gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionReady,
+			Status: cmmeta.ConditionFalse,
+			Reason: reason,
+		})
+	}
+	issued := func(notAfter time.Time) gen.CertificateRequestModifier {
+		return func(cr *cmapi.CertificateRequest) {
+			gen.SetCertificateRequestCertificate(generateSelfSignedCert(t, cr, sk, now.Add(-time.Hour), notAfter))(cr)
+			gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:   cmapi.CertificateRequestConditionReady,
+				Status: cmmeta.ConditionTrue,
+				Reason: cmapi.CertificateRequestReasonIssued,
+			})(cr)
+		}
+	}
+
+	cr := request("request", now)
+	tests := map[string]struct {
+		existing   []runtime.Object
+		wantLeader string
+	}{
+		"a request without identical requests is signed": {
+			wantLeader: "request",
+		},
+		"a request waits for an older identical request which is pending": {
+			existing:   []runtime.Object{request("older", now.Add(-time.Minute), readyCondition(cmapi.CertificateRequestReasonPending))},
+			wantLeader: "older",
+		},
+		"a request created in the same second as an identical request is ordered by name": {
+			existing:   []runtime.Object{request("another", now), request("zzz", now)},
+			wantLeader: "another",
+		},
+		"a request is signed rather than waiting for a newer identical request": {
+			existing:   []runtime.Object{request("newer", now.Add(time.Minute))},
+			wantLeader: "request",
+		},
+		"a request shares the certificate of a newer identical request which has been issued": {
+			existing: []runtime.Object{
+				request("older", now.Add(-time.Minute)),
+				request("newer", now.Add(time.Minute), issued(now.Add(time.Hour))),
+			},
+			wantLeader: "newer",
+		},
+		"a request doesn't share an expired certificate": {
+			existing:   []runtime.Object{request("older", now.Add(-time.Minute), issued(now.Add(-time.Minute)))},
+			wantLeader: "request",
+		},
+		"a request doesn't wait for a failed identical request": {
+			existing:   []runtime.Object{request("older", now.Add(-time.Minute), readyCondition(cmapi.CertificateRequestReasonFailed))},
+			wantLeader: "request",
+		},
+		"a request doesn't wait for a request which hasn't been approved": {
+			existing: []runtime.Object{request("older", now.Add(-time.Minute), func(cr *cmapi.CertificateRequest) {
+				cr.Status.Conditions = nil
+			})},
+			wantLeader: "request",
+		},
+		"a request doesn't wait for a request labelled with its batch which isn't identical": {
+			existing:   []runtime.Object{request("older", now.Add(-time.Minute), gen.SetCertificateRequestCSR(generateCSR(t, otherSK)))},
+			wantLeader: "request",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := &testpkg.Builder{T: t, CertManagerObjects: append([]runtime.Object{cr}, test.existing...)}
+			b.Init()
+			c := &Controller{
+				certificateRequestLister: b.FakeCMInformerFactory().Certmanager().V1().CertificateRequests().Lister(),
+				clock:                    fakeclock.NewFakeClock(now),
+			}
+			b.Start()
+			defer b.Stop()
+
+			leader, err := c.signBatchLeader(cr, key)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantLeader, leader.Name)
+		})
+	}
+}
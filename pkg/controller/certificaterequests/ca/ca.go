@@ -31,9 +31,9 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	issuerca "github.com/cert-manager/cert-manager/pkg/issuer/ca"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
-	"github.com/cert-manager/cert-manager/pkg/util/kube"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -67,9 +67,9 @@ func init() {
 func NewCA(ctx *controllerpkg.Context) certificaterequests.Issuer {
 	return &CA{
 		issuerOptions:     ctx.IssuerOptions,
-		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		secretsLister:     controllerpkg.NewSecretGetter(ctx.KubeSharedInformerFactory),
 		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
-		templateGenerator: pki.GenerateTemplateFromCertificateRequest,
+		templateGenerator: crutil.GenerateTemplate,
 		signingFn:         pki.SignCSRTemplate,
 	}
 }
@@ -84,7 +84,7 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
 	// get a copy of the CA certificate named on the Issuer
-	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA.SecretName)
+	caCerts, caKey, err := issuerca.SigningKeyPair(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA)
 	if k8sErrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
 
@@ -105,6 +105,9 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	if err != nil {
 		// We are probably in a network error here so we should backoff and retry
 		message := fmt.Sprintf("Failed to get certificate key pair from secret %s/%s", resourceNamespace, secretName)
+		if pkcs11 := issuerObj.GetSpec().CA.PKCS11; pkcs11 != nil {
+			message = fmt.Sprintf("Failed to get certificate from secret %s/%s and private key from PKCS#11 token %q", resourceNamespace, secretName, pkcs11.TokenLabel)
+		}
 		c.reporter.Pending(cr, err, "SecretGetError", message)
 		log.Error(err, message)
 		return nil, err
@@ -118,6 +121,15 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 		return nil, nil
 	}
 
+	if spiffe := issuerObj.GetSpec().CA.SPIFFE; spiffe != nil {
+		if err := issuerca.SVIDTemplate(template, spiffe, cr.Namespace, cr.Spec.Username); err != nil {
+			message := "Request is not valid for a SPIFFE SVID"
+			c.reporter.Failed(cr, err, "InvalidSVIDRequest", message)
+			log.Error(err, message)
+			return nil, nil
+		}
+	}
+
 	template.CRLDistributionPoints = issuerObj.GetSpec().CA.CRLDistributionPoints
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
 
@@ -19,7 +19,6 @@ package ca
 import (
 	"context"
 	"crypto"
-	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -35,7 +34,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientcorev1 "k8s.io/client-go/listers/core/v1"
-	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
@@ -161,6 +159,16 @@ func TestSign(t *testing.T) {
 	badDataSecret := rsaCASecret.DeepCopy()
 	badDataSecret.Data[corev1.TLSPrivateKeyKey] = []byte("bad key")
 
+	pinSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hsm-pin",
+			Namespace: gen.DefaultTestNamespace,
+		},
+		Data: map[string][]byte{
+			"user-pin": []byte("1234"),
+		},
+	}
+
 	template, err := pki.GenerateTemplateFromCertificateRequest(baseCR)
 	if err != nil {
 		t.Fatal(err)
@@ -188,9 +196,8 @@ func TestSign(t *testing.T) {
 				CertManagerObjects: []runtime.Object{baseCRDenied.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedEvents:     []string{},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCRDenied,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -202,7 +209,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -215,9 +222,8 @@ func TestSign(t *testing.T) {
 					`Normal SecretMissing Referenced secret default-unit-test-ns/root-ca-secret not found: secret "root-ca-secret" not found`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR.DeepCopy(),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -228,7 +234,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -245,9 +251,8 @@ func TestSign(t *testing.T) {
 					"Normal SecretInvalidData Failed to parse signing CA keypair from secret default-unit-test-ns/root-ca-secret: error decoding private key PEM block",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR.DeepCopy(),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -258,7 +263,47 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
+				},
+			},
+		},
+		"a PKCS#11 CA whose PIN secret is missing the PIN should set condition to pending and wait for re-sync": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{rsaCASecret, pinSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(),
+					gen.IssuerFrom(baseIssuer.DeepCopy(),
+						gen.SetIssuerCA(cmapi.CAIssuer{
+							SecretName: rsaCASecret.Name,
+							PKCS11: &cmapi.CAPKCS11{
+								Module:     "/usr/lib/softhsm/libsofthsm2.so",
+								TokenLabel: "ca",
+								KeyLabel:   "root",
+								PINSecretRef: cmmeta.SecretKeySelector{
+									LocalObjectReference: cmmeta.LocalObjectReference{Name: pinSecret.Name},
+									Key:                  "pin",
+								},
+							},
+						}),
+					),
+				},
+				ExpectedEvents: []string{
+					`Normal SecretInvalidData Failed to parse signing CA keypair from secret default-unit-test-ns/root-ca-secret: no data for "pin" in secret 'default-unit-test-ns/hsm-pin'`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR.DeepCopy(),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            `Failed to parse signing CA keypair from secret default-unit-test-ns/root-ca-secret: no data for "pin" in secret 'default-unit-test-ns/hsm-pin'`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					),
 				},
 			},
 		},
@@ -271,9 +316,8 @@ func TestSign(t *testing.T) {
 					`Normal SecretGetError Failed to get certificate key pair from secret default-unit-test-ns/root-ca-secret: this is a network error`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -284,7 +328,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 			fakeLister: &testlisters.FakeSecretLister{
@@ -310,9 +354,8 @@ func TestSign(t *testing.T) {
 					"Normal IssuerNotReady Referenced issuer does not have a Ready status condition",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -323,7 +366,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -339,9 +382,8 @@ func TestSign(t *testing.T) {
 					"Warning SigningError Error generating certificate template: this is a template generate error",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR.DeepCopy(),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -353,7 +395,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -377,9 +419,8 @@ func TestSign(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -392,7 +433,7 @@ func TestSign(t *testing.T) {
 							gen.SetCertificateRequestCertificate(certBundle.ChainPEM),
 							gen.SetCertificateRequestCA(rootCertPEM),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -468,12 +509,55 @@ func TestCA_Sign(t *testing.T) {
 	}
 	testCSR := generateCSR(t, testpk)
 
+	ed25519RootPK, err := pki.GenerateEd25519PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519RootCert, _ := generateSelfSignedCACert(t, ed25519RootPK, "root")
+	ed25519TestPK, err := pki.GenerateEd25519PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519TestCSR := generateCSR(t, ed25519TestPK)
+
+	svidCSR, err := gen.CSRWithSigner(testpk,
+		gen.SetCSRCommonName("app"),
+		gen.SetCSRDNSNames("app.default-unit-test-ns.svc"),
+		gen.SetCSRURIsFromStrings("spiffe://cluster.local/ns/default-unit-test-ns/sa/app"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherNamespaceSVIDCSR, err := gen.CSRWithSigner(testpk,
+		gen.SetCSRURIsFromStrings("spiffe://cluster.local/ns/kube-system/sa/app"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spiffeIssuer := gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+		SecretName: "secret-1",
+		SPIFFE:     &cmapi.CASPIFFE{TrustDomain: "cluster.local"},
+	}))
+	svidCR := func(csr []byte, mods ...gen.CertificateRequestModifier) *cmapi.CertificateRequest {
+		return gen.CertificateRequest("cr-1", append([]gen.CertificateRequestModifier{
+			gen.SetCertificateRequestCSR(csr),
+			gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+				Name:  "issuer-1",
+				Group: certmanager.GroupName,
+				Kind:  "Issuer",
+			}),
+		}, mods...)...)
+	}
+
 	tests := map[string]struct {
 		givenCASecret    *corev1.Secret
 		givenCAIssuer    cmapi.GenericIssuer
 		givenCR          *cmapi.CertificateRequest
 		assertSignedCert func(t *testing.T, got *x509.Certificate)
 		wantErr          string
+		// wantEvent is the event fired if the request is failed without
+		// returning an error.
+		wantEvent string
 	}{
 		"when the CertificateRequest has the duration field set, it should appear as notAfter on the signed ca": {
 			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
@@ -571,6 +655,87 @@ func TestCA_Sign(t *testing.T) {
 				assert.Equal(t, []string{"http://www.example.com/crl/test.crl"}, gotCA.CRLDistributionPoints)
 			},
 		},
+		"when the CertificateRequest has an Ed25519 public key, it should appear on the signed certificate": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(ed25519TestCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.Equal(t, x509.Ed25519, got.PublicKeyAlgorithm)
+				assert.Equal(t, ed25519TestPK.Public(), got.PublicKey)
+			},
+		},
+		"when the CA has an Ed25519 private key, it should sign the certificate using Ed25519": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, ed25519RootPK, ed25519RootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.Equal(t, x509.PureEd25519, got.SignatureAlgorithm)
+				assert.NoError(t, got.CheckSignatureFrom(ed25519RootCert))
+			},
+		},
+		"when the Issuer is in SPIFFE mode, it should sign an SVID without DNS names": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: spiffeIssuer,
+			givenCR:       svidCR(svidCSR, gen.SetCertificateRequestUsername("system:serviceaccount:default-unit-test-ns:app")),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				require.Len(t, got.URIs, 1)
+				assert.Equal(t, "spiffe://cluster.local/ns/default-unit-test-ns/sa/app", got.URIs[0].String())
+				assert.Empty(t, got.DNSNames)
+				assert.False(t, got.IsCA)
+				assert.NotZero(t, got.KeyUsage&x509.KeyUsageDigitalSignature)
+			},
+		},
+		"when the Issuer is in SPIFFE mode, users other than service accounts of the namespace may request any service account": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: spiffeIssuer,
+			givenCR:       svidCR(svidCSR, gen.SetCertificateRequestUsername("system:serviceaccount:cert-manager:cert-manager")),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				require.Len(t, got.URIs, 1)
+				assert.Equal(t, "spiffe://cluster.local/ns/default-unit-test-ns/sa/app", got.URIs[0].String())
+			},
+		},
+		"when the Issuer is in SPIFFE mode, it should fail requests for the SPIFFE ID of another service account": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: spiffeIssuer,
+			givenCR:       svidCR(svidCSR, gen.SetCertificateRequestUsername("system:serviceaccount:default-unit-test-ns:other")),
+			wantEvent:     `Warning InvalidSVIDRequest Request is not valid for a SPIFFE SVID: service account "other" may only request an SVID for its own SPIFFE ID, not "spiffe://cluster.local/ns/default-unit-test-ns/sa/app"`,
+		},
+		"when the Issuer is in SPIFFE mode, it should fail requests for a service account of another namespace": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: spiffeIssuer,
+			givenCR:       svidCR(otherNamespaceSVIDCSR),
+			wantEvent:     `Warning InvalidSVIDRequest Request is not valid for a SPIFFE SVID: SPIFFE ID "spiffe://cluster.local/ns/kube-system/sa/app" must be for a service account in the namespace "default-unit-test-ns" of the request`,
+		},
+		"when the Issuer is in SPIFFE mode, it should fail requests without a SPIFFE ID": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: spiffeIssuer,
+			givenCR:       svidCR(testCSR),
+			wantEvent:     "Warning InvalidSVIDRequest Request is not valid for a SPIFFE SVID: SVIDs must have exactly one URI SAN, the SPIFFE ID, but 0 were requested",
+		},
+		"when the Issuer is in SPIFFE mode, it should fail requests for a CA": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: spiffeIssuer,
+			givenCR:       svidCR(svidCSR, gen.SetCertificateRequestIsCA(true)),
+			wantEvent:     "Warning InvalidSVIDRequest Request is not valid for a SPIFFE SVID: SVIDs must not be CA certificates",
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -593,6 +758,10 @@ func TestCA_Sign(t *testing.T) {
 			gotIssueResp, gotErr := c.Sign(context.Background(), test.givenCR, test.givenCAIssuer)
 			if test.wantErr != "" {
 				require.EqualError(t, gotErr, test.wantErr)
+			} else if test.wantEvent != "" {
+				require.NoError(t, gotErr)
+				assert.Nil(t, gotIssueResp)
+				assert.Equal(t, []string{test.wantEvent}, rec.Events)
 			} else {
 				require.NoError(t, gotErr)
 
@@ -608,12 +777,12 @@ func TestCA_Sign(t *testing.T) {
 
 // Returns a map that is meant to be used for creating a certificate Secret
 // that contains the fields "tls.crt" and "tls.key".
-func secretDataFor(t *testing.T, caKey *ecdsa.PrivateKey, caCrt *x509.Certificate) (secretData map[string][]byte) {
+func secretDataFor(t *testing.T, caKey crypto.Signer, caCrt *x509.Certificate) (secretData map[string][]byte) {
 	rootCADER, err := x509.CreateCertificate(rand.Reader, caCrt, caCrt, caKey.Public(), caKey)
 	require.NoError(t, err)
 	caCrt, err = x509.ParseCertificate(rootCADER)
 	require.NoError(t, err)
-	caKeyPEM, err := pki.EncodeECPrivateKey(caKey)
+	caKeyPEM, err := pki.EncodePrivateKey(caKey, cmapi.PKCS1)
 	require.NoError(t, err)
 	caCrtPEM, err := pki.EncodeX509(caCrt)
 	require.NoError(t, err)
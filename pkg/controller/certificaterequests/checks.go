@@ -19,7 +19,9 @@ package certificaterequests
 import (
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -62,6 +64,21 @@ func (c *Controller) certificatesRequestsForGenericIssuer(iss cmapi.GenericIssue
 
 	var affected []*cmapi.CertificateRequest
 	for _, crt := range crts {
+		if crt.Spec.IssuerRef.Kind == cmapi.IssuerAliasKind {
+			// requests using an alias are affected if the alias currently
+			// resolves to the changed issuer
+			resolved, err := c.helper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+			if err != nil {
+				continue
+			}
+			_, resolvedClusterIssuer := resolved.(*cmapi.ClusterIssuer)
+			if resolvedClusterIssuer == isClusterIssuer &&
+				resolved.GetObjectMeta().Namespace == iss.GetObjectMeta().Namespace &&
+				resolved.GetObjectMeta().Name == iss.GetObjectMeta().Name {
+				affected = append(affected, crt)
+			}
+			continue
+		}
 		if isClusterIssuer && crt.Spec.IssuerRef.Kind != cmapi.ClusterIssuerKind {
 			continue
 		}
@@ -78,3 +95,129 @@ func (c *Controller) certificatesRequestsForGenericIssuer(iss cmapi.GenericIssue
 
 	return affected, nil
 }
+
+func (c *Controller) handleIssuerAlias(obj interface{}) {
+	log := c.log.WithName("handleIssuerAlias")
+
+	alias, ok := obj.(*cmapi.IssuerAlias)
+	if !ok {
+		log.Error(nil, "object is not an IssuerAlias")
+		return
+	}
+
+	log = logf.WithResource(log, alias)
+	crs, err := c.certificateRequestsForIssuerAlias(alias)
+	if err != nil {
+		log.Error(err, "error looking up certificate requests observing issuer alias")
+		return
+	}
+	for _, cr := range crs {
+		log := logf.WithRelatedResource(log, cr)
+		key, err := keyFunc(cr)
+		if err != nil {
+			log.Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// certificateRequestsForIssuerAlias returns the CertificateRequests which
+// reference an IssuerAlias with the name of the given alias and which may
+// resolve it. An alias in the cluster resource namespace applies to requests
+// in every namespace.
+func (c *Controller) certificateRequestsForIssuerAlias(alias *cmapi.IssuerAlias) ([]*cmapi.CertificateRequest, error) {
+	crs, err := c.certificateRequestLister.List(labels.NewSelector())
+	if err != nil {
+		return nil, fmt.Errorf("error listing certificate requests: %s", err.Error())
+	}
+
+	clusterWide := len(c.clusterResourceNamespace) > 0 && alias.Namespace == c.clusterResourceNamespace
+
+	var affected []*cmapi.CertificateRequest
+	for _, cr := range crs {
+		if cr.Spec.IssuerRef.Kind != cmapi.IssuerAliasKind || cr.Spec.IssuerRef.Name != alias.Name {
+			continue
+		}
+		if !clusterWide && cr.Namespace != alias.Namespace {
+			continue
+		}
+		affected = append(affected, cr)
+	}
+
+	return affected, nil
+}
+
+// handleRequestSecret requeues the CertificateRequests which reference the
+// Secret holding their request, so that those waiting for the Secret to be
+// created or updated are signed.
+// Only the metadata of the Secret is read, since the Secret informer may
+// deliver *metav1.PartialObjectMetadata rather than *corev1.Secret.
+func (c *Controller) handleRequestSecret(obj interface{}) {
+	log := c.log.WithName("handleRequestSecret")
+
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	secret, err := meta.Accessor(obj)
+	if err != nil {
+		log.Error(err, "object is not a Secret")
+		return
+	}
+
+	log = logf.WithResource(log, secret)
+	crs, err := c.certificateRequestLister.CertificateRequests(secret.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		log.Error(err, "error listing certificate requests")
+		return
+	}
+	for _, cr := range crs {
+		if cr.Spec.RequestSecretRef == nil || cr.Spec.RequestSecretRef.Name != secret.GetName() || len(cr.Status.Certificate) > 0 {
+			continue
+		}
+		log := logf.WithRelatedResource(log, cr)
+		key, err := keyFunc(cr)
+		if err != nil {
+			log.Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// handleSignBatch requeues the other CertificateRequests of the batch of a
+// request which has changed, so that those waiting for it share its
+// certificate once it has been signed, or one of them is signed if it has
+// failed or been deleted.
+func (c *Controller) handleSignBatch(obj interface{}) {
+	log := c.log.WithName("handleSignBatch")
+
+	cr, ok := obj.(*cmapi.CertificateRequest)
+	if !ok {
+		log.Error(nil, "object is not a CertificateRequest")
+		return
+	}
+	batch := cr.Labels[cmapi.SignBatchLabelKey]
+	if batch == "" {
+		return
+	}
+
+	log = logf.WithResource(log, cr)
+	crs, err := c.certificateRequestLister.CertificateRequests(cr.Namespace).List(labels.SelectorFromSet(labels.Set{cmapi.SignBatchLabelKey: batch}))
+	if err != nil {
+		log.Error(err, "error listing certificate requests")
+		return
+	}
+	for _, other := range crs {
+		if other.Name == cr.Name || len(other.Status.Certificate) > 0 {
+			continue
+		}
+		log := logf.WithRelatedResource(log, other)
+		key, err := keyFunc(other)
+		if err != nil {
+			log.Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
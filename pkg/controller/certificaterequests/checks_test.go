@@ -0,0 +1,172 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCertificateRequestsForIssuerAlias(t *testing.T) {
+	const clusterResourceNamespace = "cert-manager"
+
+	aliasRef := cmmeta.ObjectReference{Name: "default", Kind: cmapi.IssuerAliasKind}
+	issuerAlias := func(namespace string, ref cmmeta.ObjectReference) *cmapi.IssuerAlias {
+		return &cmapi.IssuerAlias{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: namespace},
+			Spec:       cmapi.IssuerAliasSpec{IssuerRef: ref},
+		}
+	}
+
+	localAlias := issuerAlias("ns-1", cmmeta.ObjectReference{Name: "ca", Kind: cmapi.IssuerKind})
+	clusterAlias := issuerAlias(clusterResourceNamespace, cmmeta.ObjectReference{Name: "ca", Kind: cmapi.ClusterIssuerKind})
+
+	objects := []runtime.Object{
+		localAlias,
+		clusterAlias,
+		gen.Issuer("ca", gen.SetIssuerNamespace("ns-1")),
+		gen.ClusterIssuer("ca"),
+		gen.CertificateRequest("cr-1", gen.SetCertificateRequestNamespace("ns-1"), gen.SetCertificateRequestIssuer(aliasRef)),
+		gen.CertificateRequest("cr-2", gen.SetCertificateRequestNamespace("ns-2"), gen.SetCertificateRequestIssuer(aliasRef)),
+		gen.CertificateRequest("cr-3", gen.SetCertificateRequestNamespace("ns-2"),
+			gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "other", Kind: cmapi.IssuerAliasKind})),
+		gen.CertificateRequest("cr-4", gen.SetCertificateRequestNamespace("ns-2"),
+			gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca", Kind: cmapi.ClusterIssuerKind})),
+	}
+
+	b := &testpkg.Builder{T: t, CertManagerObjects: objects}
+	b.Init()
+	informers := b.FakeCMInformerFactory().Certmanager().V1()
+	c := &Controller{
+		certificateRequestLister: informers.CertificateRequests().Lister(),
+		clusterResourceNamespace: clusterResourceNamespace,
+		helper: issuer.NewAliasingHelper(informers.Issuers().Lister(), informers.ClusterIssuers().Lister(),
+			informers.IssuerAliases().Lister(), clusterResourceNamespace),
+	}
+	b.Start()
+	defer b.Stop()
+
+	names := func(crs []*cmapi.CertificateRequest) []string {
+		var names []string
+		for _, cr := range crs {
+			names = append(names, cr.Namespace+"/"+cr.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	check := func(name string, crs []*cmapi.CertificateRequest, err error, exp []string) {
+		t.Run(name, func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := names(crs); !reflect.DeepEqual(got, exp) {
+				t.Errorf("expected %v, got %v", exp, got)
+			}
+		})
+	}
+
+	crs, err := c.certificateRequestsForIssuerAlias(localAlias)
+	check("namespaced alias only affects its own namespace", crs, err, []string{"ns-1/cr-1"})
+
+	crs, err = c.certificateRequestsForIssuerAlias(clusterAlias)
+	check("alias in cluster resource namespace affects all namespaces", crs, err, []string{"ns-1/cr-1", "ns-2/cr-2"})
+
+	crs, err = c.certificatesRequestsForGenericIssuer(gen.Issuer("ca", gen.SetIssuerNamespace("ns-1")))
+	check("issuer is resolved through the namespaced alias", crs, err, []string{"ns-1/cr-1"})
+
+	crs, err = c.certificatesRequestsForGenericIssuer(gen.ClusterIssuer("ca"))
+	check("cluster issuer is resolved through the cluster-wide alias", crs, err, []string{"ns-2/cr-2", "ns-2/cr-4"})
+}
+
+func TestHandleRequestSecretWithMetadataInformer(t *testing.T) {
+	b := &testpkg.Builder{T: t, CertManagerObjects: []runtime.Object{
+		gen.CertificateRequest("waiting", gen.SetCertificateRequestNamespace("ns-1"), gen.SetCertificateRequestCSRSecretRef("csr", "tls.csr", nil)),
+		gen.CertificateRequest("other-secret", gen.SetCertificateRequestNamespace("ns-1"), gen.SetCertificateRequestCSRSecretRef("other", "tls.csr", nil)),
+		gen.CertificateRequest("other-namespace", gen.SetCertificateRequestNamespace("ns-2"), gen.SetCertificateRequestCSRSecretRef("csr", "tls.csr", nil)),
+	}}
+	b.Init()
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	c := &Controller{
+		log:                      logr.Discard(),
+		queue:                    queue,
+		certificateRequestLister: b.FakeCMInformerFactory().Certmanager().V1().CertificateRequests().Lister(),
+	}
+	b.Start()
+	defer b.Stop()
+
+	// The metadata informer delivers *metav1.PartialObjectMetadata rather
+	// than *corev1.Secret.
+	scheme := metadatafake.NewTestScheme()
+	if err := metav1.AddMetaToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	secret := &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "csr"},
+	}
+	metadataFactory := metadatainformer.NewSharedInformerFactory(metadatafake.NewSimpleMetadataClient(scheme, secret), 0)
+	informer := metadataFactory.ForResource(corev1.SchemeGroupVersion.WithResource("secrets")).Informer()
+	informer.AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleRequestSecret})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	metadataFactory.Start(stopCh)
+	metadataFactory.WaitForCacheSync(stopCh)
+
+	expectQueued := func(name string) {
+		t.Helper()
+		if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+			return queue.Len() > 0, nil
+		}); err != nil {
+			t.Fatalf("expected %s to be queued", name)
+		}
+		key, _ := queue.Get()
+		queue.Done(key)
+		queue.Forget(key)
+		if key != name {
+			t.Errorf("expected %s to be queued, got %v", name, key)
+		}
+		if queue.Len() != 0 {
+			t.Errorf("expected only %s to be queued, got %d more items", name, queue.Len())
+		}
+	}
+	expectQueued("ns-1/waiting")
+
+	// Secrets whose final state is unknown are unwrapped.
+	c.handleRequestSecret(cache.DeletedFinalStateUnknown{Key: "ns-1/csr", Obj: secret})
+	expectQueued("ns-1/waiting")
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// circuitBreakerWindow is the period over which the results of signing
+	// requests with an issuer are counted.
+	circuitBreakerWindow = 10 * time.Minute
+	// circuitBreakerMinRequests is the number of results in the window
+	// needed for the circuit breaker of an issuer to open.
+	circuitBreakerMinRequests = 10
+	// circuitBreakerFailureRatio is the fraction of failed results in the
+	// window from which the circuit breaker of an issuer opens.
+	circuitBreakerFailureRatio = 0.5
+	// circuitBreakerCooldown is how long no requests are signed by an issuer
+	// once its circuit breaker has opened.
+	circuitBreakerCooldown = 5 * time.Minute
+	// circuitBreakerProbeTimeout is how long other requests wait for the
+	// result of the first request signed once the cooldown has passed.
+	circuitBreakerProbeTimeout = time.Minute
+)
+
+// circuitBreaker pauses the signing of requests by issuers which fail to
+// sign most of the requests sent to them, so that cert-manager does not get
+// itself rate limited or banned by the CA while many requests are failing.
+// Once the cooldown has passed a single request is signed, the result of
+// which decides whether the breaker closes again or stays open for another
+// cooldown.
+type circuitBreaker struct {
+	clock clock.Clock
+
+	lock     sync.Mutex
+	breakers map[types.NamespacedName]*issuerBreaker
+}
+
+type issuerBreaker struct {
+	// results holds the time of each result in the window and whether it
+	// was a failure.
+	results []signResult
+	// openUntil is the time until which no requests are signed.
+	openUntil time.Time
+	// halfOpen is true from the time the breaker opened until the next
+	// result once the cooldown has passed.
+	halfOpen bool
+	// probeStarted is the time at which the request deciding whether the
+	// breaker closes was allowed.
+	probeStarted time.Time
+}
+
+type signResult struct {
+	time   time.Time
+	failed bool
+}
+
+func newCircuitBreaker(clock clock.Clock) *circuitBreaker {
+	return &circuitBreaker{
+		clock:    clock,
+		breakers: make(map[types.NamespacedName]*issuerBreaker),
+	}
+}
+
+// allow returns whether requests may be signed by the issuer, or else how long
+// until they may be.
+// Issuers are identified by their namespace and name, the namespace of
+// ClusterIssuers being empty.
+func (c *circuitBreaker) allow(issuer types.NamespacedName) (bool, time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	b, ok := c.breakers[issuer]
+	if !ok {
+		return true, 0
+	}
+	now := c.clock.Now()
+	if wait := b.openUntil.Sub(now); wait > 0 {
+		return false, wait
+	}
+	if b.halfOpen {
+		if wait := b.probeStarted.Add(circuitBreakerProbeTimeout).Sub(now); wait > 0 {
+			return false, wait
+		}
+		b.probeStarted = now
+	}
+	return true, 0
+}
+
+// record records the result of signing a request with the issuer, and
+// returns true if this opened the circuit breaker of the issuer.
+func (c *circuitBreaker) record(issuer types.NamespacedName, failed bool) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.clock.Now()
+	b, ok := c.breakers[issuer]
+	if !ok {
+		if !failed {
+			return false
+		}
+		b = &issuerBreaker{}
+		c.breakers[issuer] = b
+	}
+
+	if b.halfOpen {
+		b.halfOpen = false
+		if failed {
+			b.open(now)
+			return true
+		}
+		delete(c.breakers, issuer)
+		return false
+	}
+
+	// Drop the results which are no longer in the window.
+	i := 0
+	for i < len(b.results) && now.Sub(b.results[i].time) > circuitBreakerWindow {
+		i++
+	}
+	b.results = append(b.results[i:], signResult{time: now, failed: failed})
+
+	failures := 0
+	for _, r := range b.results {
+		if r.failed {
+			failures++
+		}
+	}
+	if failures == 0 {
+		delete(c.breakers, issuer)
+		return false
+	}
+	if len(b.results) >= circuitBreakerMinRequests && float64(failures)/float64(len(b.results)) >= circuitBreakerFailureRatio {
+		b.open(now)
+		return true
+	}
+	return false
+}
+
+func (b *issuerBreaker) open(now time.Time) {
+	b.results = nil
+	b.openUntil = now.Add(circuitBreakerCooldown)
+	b.halfOpen = true
+	b.probeStarted = time.Time{}
+}
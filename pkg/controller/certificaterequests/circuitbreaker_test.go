@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	cb := newCircuitBreaker(clock)
+	issuer := types.NamespacedName{Namespace: "ns", Name: "issuer"}
+	other := types.NamespacedName{Name: "cluster-issuer"}
+
+	// Fewer failures than successes keep the breaker closed.
+	for i := 0; i < circuitBreakerMinRequests; i++ {
+		assert.False(t, cb.record(issuer, i%3 == 0))
+	}
+	ok, _ := cb.allow(issuer)
+	assert.True(t, ok)
+
+	// Failures which are no longer in the window are not counted.
+	clock.Step(circuitBreakerWindow + time.Second)
+	for i := 0; i < circuitBreakerMinRequests-1; i++ {
+		assert.False(t, cb.record(issuer, true))
+	}
+	assert.True(t, cb.record(issuer, true), "expected the breaker to open")
+
+	ok, wait := cb.allow(issuer)
+	assert.False(t, ok)
+	assert.Equal(t, circuitBreakerCooldown, wait)
+	ok, _ = cb.allow(other)
+	assert.True(t, ok, "expected other issuers not to be affected")
+
+	// Once the cooldown has passed a single request is allowed, a failure of
+	// which opens the breaker again.
+	clock.Step(circuitBreakerCooldown)
+	ok, _ = cb.allow(issuer)
+	assert.True(t, ok)
+	ok, wait = cb.allow(issuer)
+	assert.False(t, ok)
+	assert.Equal(t, circuitBreakerProbeTimeout, wait)
+	assert.True(t, cb.record(issuer, true), "expected the breaker to open again")
+	ok, _ = cb.allow(issuer)
+	assert.False(t, ok)
+
+	// A success once the cooldown has passed closes the breaker.
+	clock.Step(circuitBreakerCooldown)
+	ok, _ = cb.allow(issuer)
+	assert.True(t, ok)
+	assert.False(t, cb.record(issuer, false))
+	ok, _ = cb.allow(issuer)
+	assert.True(t, ok)
+	ok, _ = cb.allow(issuer)
+	assert.True(t, ok)
+}
@@ -22,7 +22,6 @@ import (
 
 	"github.com/go-logr/logr"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
-	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -72,7 +71,7 @@ type Controller struct {
 	// we need to wait for Secrets to be synced to avoid a situation where CA issuer's Secret
 	// is not yet in cached at a time when issuance is attempted,
 	// more details at https://github.com/cert-manager/cert-manager/issues/5216
-	secretLister corelisters.SecretLister
+	secretGetter *controllerpkg.SecretGetter
 
 	queue workqueue.RateLimitingInterface
 
@@ -87,6 +86,11 @@ type Controller struct {
 
 	issuerLister        cmlisters.IssuerLister
 	clusterIssuerLister cmlisters.ClusterIssuerLister
+	issuerAliasLister   cmlisters.IssuerAliasLister
+
+	// clusterResourceNamespace holds the IssuerAliases which apply to all
+	// namespaces
+	clusterResourceNamespace string
 
 	//registerExtraInformers is a list of functions that CertificateRequest
 	//controllers can use to register custom informers.
@@ -100,6 +104,9 @@ type Controller struct {
 	clock clock.Clock
 
 	reporter *util.Reporter
+
+	// circuitBreaker pauses signing with issuers which fail most requests
+	circuitBreaker *circuitBreaker
 }
 
 // New will construct a new certificaterequest controller using the given
@@ -130,12 +137,17 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.log = logf.FromContext(ctx.RootContext, componentName)
 
 	// create a queue used to queue up items to be processed
-	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), componentName)
+	c.queue = workqueue.NewNamedRateLimitingQueue(ctx.DefaultRateLimiterFor(componentName), componentName)
 
-	secretsInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
+	// only the metadata of Secrets is needed to requeue the requests waiting
+	// for the Secret holding their request
+	secretsMetadataInformer := controllerpkg.SecretsMetadataInformer(ctx.KubeSharedInformerFactory)
 	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	issuerAliasInformer := ctx.SharedInformerFactory.Certmanager().V1().IssuerAliases()
 	c.issuerLister = issuerInformer.Lister()
-	c.secretLister = secretsInformer.Lister()
+	c.issuerAliasLister = issuerAliasInformer.Lister()
+	c.clusterResourceNamespace = ctx.IssuerOptions.ClusterResourceNamespace
+	c.secretGetter = controllerpkg.NewSecretGetter(ctx.KubeSharedInformerFactory)
 
 	// obtain references to all the informers used by this controller
 	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
@@ -147,7 +159,8 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	mustSync := []cache.InformerSynced{
 		certificateRequestInformer.Informer().HasSynced,
 		issuerInformer.Informer().HasSynced,
-		secretsInformer.Informer().HasSynced,
+		issuerAliasInformer.Informer().HasSynced,
+		secretsMetadataInformer.HasSynced,
 	}
 	for _, reg := range c.registerExtraInformers {
 		ms, err := reg(ctx, c.log, c.queue)
@@ -173,15 +186,19 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 
 	// register handler functions
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleSignBatch})
 	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
+	issuerAliasInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleIssuerAlias})
+	secretsMetadataInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleRequestSecret})
 	// create an issuer helper for reading generic issuers
-	c.helper = issuer.NewHelper(c.issuerLister, c.clusterIssuerLister)
+	c.helper = issuer.NewAliasingHelper(c.issuerLister, c.clusterIssuerLister, c.issuerAliasLister, c.clusterResourceNamespace)
 
 	// clock is used to set the FailureTime of failed CertificateRequests
 	c.clock = ctx.Clock
 	// recorder records events about resources to the Kubernetes api
 	c.recorder = ctx.Recorder
 	c.reporter = util.NewReporter(c.clock, c.recorder)
+	c.circuitBreaker = newCircuitBreaker(c.clock)
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
 
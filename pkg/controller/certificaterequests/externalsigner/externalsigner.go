@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsigner
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	externalsignerinternal "github.com/cert-manager/cert-manager/internal/externalsigner"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	issuerplugin "github.com/cert-manager/cert-manager/pkg/issuer/plugin"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// CRControllerName is the name of external signer certificate requests
+	// controller.
+	CRControllerName = "certificaterequests-issuer-externalsigner"
+
+	// signTimeout is the maximum time waited for the external signer to
+	// answer a Sign call.
+	signTimeout = 30 * time.Second
+
+	// defaultPollInterval is how often the external signer is asked again
+	// for the certificates of requests which it is still signing.
+	defaultPollInterval = 10 * time.Second
+)
+
+// ExternalSigner is an external signer specific implementation of
+// pkg/controller/certificaterequests.Issuer interface, which delegates
+// signing to a gRPC signing service over mutual TLS.
+type ExternalSigner struct {
+	issuerOptions controllerpkg.IssuerOptions
+	secretsLister corelisters.SecretLister
+	reporter      *crutil.Reporter
+
+	// queue is the queue of the CertificateRequest controller, used to ask
+	// the external signer again for requests it is still signing.
+	queue        workqueue.RateLimitingInterface
+	pollInterval time.Duration
+
+	clientBuilder externalsignerinternal.ClientBuilder
+}
+
+func init() {
+	// create certificate request controller for external signer issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		e := &ExternalSigner{}
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(apiutil.IssuerExternalSigner, e.build, e.registerQueue)).
+			Complete()
+	})
+}
+
+// build sets up the ExternalSigner with the given controller context. It is
+// called after registerQueue.
+func (e *ExternalSigner) build(ctx *controllerpkg.Context) certificaterequests.Issuer {
+	e.issuerOptions = ctx.IssuerOptions
+	e.secretsLister = controllerpkg.NewSecretGetter(ctx.KubeSharedInformerFactory)
+	e.reporter = crutil.NewReporter(ctx.Clock, ctx.Recorder)
+	e.clientBuilder = externalsignerinternal.New
+	e.pollInterval = defaultPollInterval
+	return e
+}
+
+// registerQueue keeps the controller's queue, which is only available to
+// the functions registering extra informers.
+func (e *ExternalSigner) registerQueue(_ *controllerpkg.Context, _ logr.Logger, queue workqueue.RateLimitingInterface) ([]cache.InformerSynced, error) {
+	e.queue = queue
+	return nil, nil
+}
+
+// Sign sends the CertificateRequest to the external signer of the issuer,
+// and returns the certificate once it has been signed.
+//
+// Like Kubernetes CSR signers, the external signer may fail a request
+// permanently by returning an InvalidArgument or PermissionDenied error.
+// Any other error is returned, so that the request is retried with the
+// controller's backoff, and the signer may answer that a request is still
+// being signed, in which case it is asked again later.
+func (e *ExternalSigner) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+	log = logf.WithRelatedResource(log, issuerObj)
+
+	client, err := e.clientBuilder(e.issuerOptions.ResourceNamespace(issuerObj), e.secretsLister, issuerObj)
+	if k8sErrors.IsNotFound(err) {
+		message := "Required secret resource not found"
+
+		e.reporter.Pending(cr, err, "SecretMissing", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	if err != nil {
+		message := "Failed to initialise external signer client for signing"
+
+		e.reporter.Pending(cr, err, "ExternalSignerInitError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+	defer client.Close()
+
+	signCtx, cancel := context.WithTimeout(ctx, signTimeout)
+	defer cancel()
+	resp, err := client.Sign(signCtx, &issuerplugin.SignRequest{
+		Namespace: cr.Namespace,
+		Name:      cr.Name,
+		UID:       string(cr.UID),
+		IssuerRef: cr.Spec.IssuerRef,
+		Request:   cr.Spec.Request,
+		Duration:  durationOf(cr),
+		IsCA:      cr.Spec.IsCA,
+		Usages:    cr.Spec.Usages,
+	})
+	if issuerplugin.IsFailed(err) {
+		message := "The external signer refused to sign the certificate"
+
+		e.reporter.Failed(cr, err, "SigningError", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+	if err != nil {
+		message := "Failed to call the external signer"
+
+		e.reporter.Pending(cr, err, "ExternalSignerError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	if len(resp.Certificate) == 0 {
+		message := resp.PendingMessage
+		if message == "" {
+			message = "Waiting for the external signer to sign the certificate"
+		}
+		e.reporter.Pending(cr, nil, "IssuancePending", message)
+		e.poll(cr)
+		return nil, nil
+	}
+
+	log.V(logf.DebugLevel).Info("certificate issued")
+
+	return &issuer.IssueResponse{
+		Certificate: resp.Certificate,
+		CA:          resp.CA,
+	}, nil
+}
+
+// poll asks the external signer again for the certificate after
+// pollInterval.
+func (e *ExternalSigner) poll(cr *cmapi.CertificateRequest) {
+	if e.queue == nil {
+		return
+	}
+	if key, err := controllerpkg.KeyFunc(cr); err == nil {
+		e.queue.AddAfter(key, e.pollInterval)
+	}
+}
+
+// durationOf returns the duration requested by the CertificateRequest, or
+// zero if it did not request one.
+func durationOf(cr *cmapi.CertificateRequest) time.Duration {
+	if cr.Spec.Duration == nil {
+		return 0
+	}
+	return cr.Spec.Duration.Duration
+}
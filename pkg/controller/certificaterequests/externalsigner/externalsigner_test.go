@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsigner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	externalsignerinternal "github.com/cert-manager/cert-manager/internal/externalsigner"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	issuerplugin "github.com/cert-manager/cert-manager/pkg/issuer/plugin"
+)
+
+// fakeClient is an external signer client returning the given response.
+type fakeClient struct {
+	resp *issuerplugin.SignResponse
+	err  error
+
+	req    *issuerplugin.SignRequest
+	closed bool
+}
+
+func (f *fakeClient) Sign(_ context.Context, req *issuerplugin.SignRequest) (*issuerplugin.SignResponse, error) {
+	f.req = req
+	return f.resp, f.err
+}
+
+func (f *fakeClient) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestSign(t *testing.T) {
+	issuer := &cmapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "hsm"},
+		Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{ExternalSigner: &cmapi.ExternalSignerIssuer{
+			Address:      "signer.hsm.svc:8443",
+			TLSSecretRef: cmmeta.LocalObjectReference{Name: "signer-client-tls"},
+		}}},
+	}
+	cr := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-1", UID: "uid"},
+		Spec: cmapi.CertificateRequestSpec{
+			Request:   []byte("csr"),
+			Duration:  &metav1.Duration{Duration: time.Hour},
+			Usages:    []cmapi.KeyUsage{cmapi.UsageServerAuth},
+			IssuerRef: cmmeta.ObjectReference{Name: "hsm"},
+		},
+	}
+
+	tests := map[string]struct {
+		client      *fakeClient
+		builderErr  error
+		expResp     bool
+		expErr      bool
+		expReason   string
+		expPollings int
+	}{
+		"a missing TLS secret leaves the request pending": {
+			builderErr: k8sErrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "signer-client-tls"),
+			expReason:  cmapi.CertificateRequestReasonPending,
+		},
+		"invalid TLS credentials are retried": {
+			builderErr: errors.New("invalid key pair"),
+			expReason:  cmapi.CertificateRequestReasonPending,
+			expErr:     true,
+		},
+		"the certificate returned by the signer is returned": {
+			client:  &fakeClient{resp: &issuerplugin.SignResponse{Certificate: []byte("cert"), CA: []byte("ca")}},
+			expResp: true,
+		},
+		"a request still being signed is polled": {
+			client:      &fakeClient{resp: &issuerplugin.SignResponse{PendingMessage: "waiting for HSM quorum"}},
+			expReason:   cmapi.CertificateRequestReasonPending,
+			expPollings: 1,
+		},
+		"a request refused by the signer fails": {
+			client:    &fakeClient{err: issuerplugin.Failed("key usage not allowed")},
+			expReason: cmapi.CertificateRequestReasonFailed,
+		},
+		"a permission denied error fails the request": {
+			client:    &fakeClient{err: status.Error(codes.PermissionDenied, "client not authorized")},
+			expReason: cmapi.CertificateRequestReasonFailed,
+		},
+		"an unavailable signer is retried": {
+			client:    &fakeClient{err: status.Error(codes.Unavailable, "connection refused")},
+			expReason: cmapi.CertificateRequestReasonPending,
+			expErr:    true,
+		},
+	}
+
+	for n, test := range tests {
+		t.Run(n, func(t *testing.T) {
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+			e := &ExternalSigner{
+				// Requests polled without delay are added to the queue
+				// immediately.
+				queue:    queue,
+				reporter: crutil.NewReporter(fakeclock.NewFakeClock(time.Now()), record.NewFakeRecorder(10)),
+				clientBuilder: func(string, corelisters.SecretLister, cmapi.GenericIssuer) (externalsignerinternal.Client, error) {
+					if test.builderErr != nil {
+						return nil, test.builderErr
+					}
+					return test.client, nil
+				},
+			}
+
+			cr := cr.DeepCopy()
+			resp, err := e.Sign(context.TODO(), cr, issuer)
+			assert.Equal(t, test.expErr, err != nil, "unexpected error: %v", err)
+			if test.expResp {
+				require.NotNil(t, resp)
+				assert.Equal(t, []byte("cert"), resp.Certificate)
+				assert.Equal(t, []byte("ca"), resp.CA)
+			} else {
+				assert.Nil(t, resp)
+			}
+			assert.Equal(t, test.expReason, apiutil.CertificateRequestReadyReason(cr))
+			assert.Equal(t, test.expPollings, queue.Len())
+
+			if test.client != nil {
+				assert.True(t, test.client.closed, "expected the client to be closed")
+				require.NotNil(t, test.client.req)
+				assert.Equal(t, "uid", test.client.req.UID)
+				assert.Equal(t, cr.Spec.Request, test.client.req.Request)
+				assert.Equal(t, time.Hour, test.client.req.Duration)
+				assert.Equal(t, cr.Spec.Usages, test.client.req.Usages)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	hubinternal "github.com/cert-manager/cert-manager/internal/hub"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclientv1 "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/tracing"
+)
+
+const (
+	// CRControllerName is the name of hub certificate requests controller.
+	CRControllerName = "certificaterequests-issuer-hub"
+
+	// defaultPollInterval is how often the CertificateRequest forwarded to
+	// the hub cluster is checked while it is pending.
+	defaultPollInterval = 15 * time.Second
+)
+
+// Hub is a hub-specific implementation of
+// pkg/controller/certificaterequests.Issuer interface, which forwards
+// CertificateRequests to an issuer in a hub cluster.
+type Hub struct {
+	issuerOptions controllerpkg.IssuerOptions
+	secretsLister corelisters.SecretLister
+	reporter      *crutil.Reporter
+
+	// queue is the queue of the CertificateRequest controller, used to poll
+	// the hub cluster until forwarded requests have been signed.
+	queue        workqueue.RateLimitingInterface
+	pollInterval time.Duration
+
+	clientBuilder hubinternal.ClientBuilder
+}
+
+func init() {
+	// create certificate request controller for hub issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		h := &Hub{}
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(apiutil.IssuerHub, h.build, h.registerQueue)).
+			Complete()
+	})
+}
+
+// build sets up the Hub with the given controller context. It is called
+// after registerQueue.
+func (h *Hub) build(ctx *controllerpkg.Context) certificaterequests.Issuer {
+	h.issuerOptions = ctx.IssuerOptions
+	h.secretsLister = controllerpkg.NewSecretGetter(ctx.KubeSharedInformerFactory)
+	h.reporter = crutil.NewReporter(ctx.Clock, ctx.Recorder)
+	h.clientBuilder = hubinternal.New
+	h.pollInterval = defaultPollInterval
+	return h
+}
+
+// registerQueue keeps the controller's queue, which is only available to
+// the functions registering extra informers.
+func (h *Hub) registerQueue(_ *controllerpkg.Context, _ logr.Logger, queue workqueue.RateLimitingInterface) ([]cache.InformerSynced, error) {
+	h.queue = queue
+	return nil, nil
+}
+
+// Sign forwards the CertificateRequest to the hub cluster of the issuer, and
+// returns the certificate once the forwarded request has been signed. The
+// forwarded request is deleted once it is no longer needed.
+func (h *Hub) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+	log = logf.WithRelatedResource(log, issuerObj)
+	spec := issuerObj.GetSpec().Hub
+
+	client, err := h.clientBuilder(h.issuerOptions.ResourceNamespace(issuerObj), h.secretsLister, issuerObj)
+	if k8sErrors.IsNotFound(err) {
+		message := "Required secret resource not found"
+
+		h.reporter.Pending(cr, err, "SecretMissing", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	if err != nil {
+		message := "Failed to initialise hub cluster client for signing"
+
+		h.reporter.Pending(cr, err, "HubInitError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	name, err := forwardedName(cr)
+	if err != nil {
+		return nil, err
+	}
+	requests := client.CertmanagerV1().CertificateRequests(spec.Namespace)
+
+	forwarded, err := requests.Get(ctx, name, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		_, err := requests.Create(ctx, forwardedRequest(ctx, cr, name, spec), metav1.CreateOptions{})
+		if err != nil {
+			message := "Failed to forward request to the hub cluster"
+
+			h.reporter.Pending(cr, err, "HubForwardError", message)
+			log.Error(err, message)
+			return nil, err
+		}
+
+		log.V(logf.DebugLevel).Info("forwarded request to the hub cluster", "hub_request", spec.Namespace+"/"+name)
+		h.reporter.Pending(cr, nil, "IssuancePending",
+			fmt.Sprintf("Forwarded to the hub cluster as CertificateRequest %s/%s", spec.Namespace, name))
+		h.poll(cr)
+		return nil, nil
+	}
+	if err != nil {
+		message := "Failed to get the request forwarded to the hub cluster"
+
+		h.reporter.Pending(cr, err, "HubForwardError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	switch {
+	case apiutil.CertificateRequestIsDenied(forwarded):
+		h.reporter.Failed(cr, nil, "HubDenied",
+			fmt.Sprintf("The request forwarded to the hub cluster was denied: %s", conditionMessage(forwarded, cmapi.CertificateRequestConditionDenied)))
+
+	case apiutil.CertificateRequestHasInvalidRequest(forwarded):
+		h.reporter.Failed(cr, nil, "HubInvalidRequest",
+			fmt.Sprintf("The request forwarded to the hub cluster is invalid: %s", apiutil.CertificateRequestInvalidRequestMessage(forwarded)))
+
+	case apiutil.CertificateRequestReadyReason(forwarded) == cmapi.CertificateRequestReasonFailed:
+		h.reporter.Failed(cr, nil, "HubFailed",
+			fmt.Sprintf("The request forwarded to the hub cluster failed: %s", conditionMessage(forwarded, cmapi.CertificateRequestConditionReady)))
+
+	case len(forwarded.Status.Certificate) > 0:
+		log.V(logf.DebugLevel).Info("certificate issued")
+		h.deleteForwarded(ctx, log, requests, forwarded)
+		return &issuer.IssueResponse{
+			Certificate: forwarded.Status.Certificate,
+			CA:          forwarded.Status.CA,
+		}, nil
+
+	default:
+		message := "Waiting for the request forwarded to the hub cluster to be signed"
+		if reason := apiutil.CertificateRequestReadyReason(forwarded); reason != "" {
+			message = fmt.Sprintf("%s: %s", message, conditionMessage(forwarded, cmapi.CertificateRequestConditionReady))
+		}
+		h.reporter.Pending(cr, nil, "IssuancePending", message)
+		h.poll(cr)
+		return nil, nil
+	}
+
+	// The forwarded request has failed and will not be signed.
+	h.deleteForwarded(ctx, log, requests, forwarded)
+	return nil, nil
+}
+
+// poll checks the forwarded request again after pollInterval.
+func (h *Hub) poll(cr *cmapi.CertificateRequest) {
+	if h.queue == nil {
+		return
+	}
+	if key, err := controllerpkg.KeyFunc(cr); err == nil {
+		h.queue.AddAfter(key, h.pollInterval)
+	}
+}
+
+func (h *Hub) deleteForwarded(ctx context.Context, log logr.Logger, requests cmclientv1.CertificateRequestInterface, forwarded *cmapi.CertificateRequest) {
+	// Failing to delete the forwarded request does not affect the spoke
+	// request, so the error is only logged.
+	err := requests.Delete(ctx, forwarded.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &forwarded.UID},
+	})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		log.Error(err, "failed to delete the request forwarded to the hub cluster", "hub_request", forwarded.Namespace+"/"+forwarded.Name)
+	}
+}
+
+// forwardedName returns the name of the CertificateRequest forwarded to the
+// hub cluster for cr. It is derived from the UID of cr, so that it is unique
+// among requests forwarded from several spoke clusters to the same
+// namespace.
+func forwardedName(cr *cmapi.CertificateRequest) (string, error) {
+	return apiutil.ComputeName(cr.Namespace+"-"+cr.Name, cr.UID)
+}
+
+// forwardedRequest returns the CertificateRequest forwarded to the hub
+// cluster for cr. Its user info is set by the hub cluster to that of the
+// issuer's token, and the request is approved by the hub cluster's approvers.
+func forwardedRequest(ctx context.Context, cr *cmapi.CertificateRequest, name string, spec *cmapi.HubIssuer) *cmapi.CertificateRequest {
+	return &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: spec.Namespace,
+			Name:      name,
+			Annotations: tracing.AnnotationsWithTraceContext(ctx, map[string]string{
+				cmapi.HubSpokeRequestAnnotationKey: cr.Namespace + "/" + cr.Name,
+			}),
+		},
+		Spec: cmapi.CertificateRequestSpec{
+			Request:   cr.Spec.Request,
+			Duration:  cr.Spec.Duration,
+			IsCA:      cr.Spec.IsCA,
+			Usages:    cr.Spec.Usages,
+			IssuerRef: spec.IssuerRef,
+		},
+	}
+}
+
+func conditionMessage(cr *cmapi.CertificateRequest, conditionType cmapi.CertificateRequestConditionType) string {
+	if c := apiutil.GetCertificateRequestCondition(cr, conditionType); c != nil {
+		return c.Message
+	}
+	return ""
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+)
+
+func TestSign(t *testing.T) {
+	issuer := &cmapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "hub"},
+		Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{Hub: &cmapi.HubIssuer{
+			Server:         "https://hub.example.com",
+			TokenSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "hub-token"}, Key: "token"},
+			Namespace:      "spoke-a",
+			IssuerRef:      cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer"},
+		}}},
+	}
+	cr := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-1", UID: "uid"},
+		Spec: cmapi.CertificateRequestSpec{
+			Request:   []byte("csr"),
+			Duration:  &metav1.Duration{Duration: time.Hour},
+			Usages:    []cmapi.KeyUsage{cmapi.UsageServerAuth},
+			IssuerRef: cmmeta.ObjectReference{Name: "hub"},
+		},
+	}
+	name, err := forwardedName(cr)
+	require.NoError(t, err)
+
+	forwardedWith := func(mod func(*cmapi.CertificateRequest)) *cmapi.CertificateRequest {
+		forwarded := forwardedRequest(context.TODO(), cr, name, issuer.Spec.Hub)
+		mod(forwarded)
+		return forwarded
+	}
+
+	tests := map[string]struct {
+		forwarded   *cmapi.CertificateRequest
+		builderErr  error
+		expResp     bool
+		expErr      bool
+		expReason   string
+		expDeleted  bool
+		expCreated  bool
+		expPollings int
+	}{
+		"a request not yet forwarded is created in the hub cluster": {
+			expReason:   cmapi.CertificateRequestReasonPending,
+			expCreated:  true,
+			expPollings: 1,
+		},
+		"a missing token secret leaves the request pending": {
+			builderErr: k8sErrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "hub-token"),
+			expReason:  cmapi.CertificateRequestReasonPending,
+		},
+		"a pending forwarded request is polled": {
+			forwarded: forwardedWith(func(cr *cmapi.CertificateRequest) {
+				apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady, cmmeta.ConditionFalse, cmapi.CertificateRequestReasonPending, "waiting for approval")
+			}),
+			expReason:   cmapi.CertificateRequestReasonPending,
+			expPollings: 1,
+		},
+		"the certificate of a signed forwarded request is returned": {
+			forwarded: forwardedWith(func(cr *cmapi.CertificateRequest) {
+				cr.Status.Certificate = []byte("cert")
+				cr.Status.CA = []byte("ca")
+			}),
+			expResp:    true,
+			expDeleted: true,
+		},
+		"a denied forwarded request fails the request": {
+			forwarded: forwardedWith(func(cr *cmapi.CertificateRequest) {
+				apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionDenied, cmmeta.ConditionTrue, "Denied", "not allowed")
+			}),
+			expReason:  cmapi.CertificateRequestReasonFailed,
+			expDeleted: true,
+		},
+		"a failed forwarded request fails the request": {
+			forwarded: forwardedWith(func(cr *cmapi.CertificateRequest) {
+				apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady, cmmeta.ConditionFalse, cmapi.CertificateRequestReasonFailed, "signing failed")
+			}),
+			expReason:  cmapi.CertificateRequestReasonFailed,
+			expDeleted: true,
+		},
+	}
+
+	for n, test := range tests {
+		t.Run(n, func(t *testing.T) {
+			var objs []runtime.Object
+			if test.forwarded != nil {
+				objs = append(objs, test.forwarded)
+			}
+			client := cmfake.NewSimpleClientset(objs...)
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+			h := &Hub{
+				// Requests polled without delay are added to the queue
+				// immediately.
+				queue:    queue,
+				reporter: crutil.NewReporter(fakeclock.NewFakeClock(time.Now()), record.NewFakeRecorder(10)),
+				clientBuilder: func(string, corelisters.SecretLister, cmapi.GenericIssuer) (cmclient.Interface, error) {
+					return client, test.builderErr
+				},
+			}
+
+			cr := cr.DeepCopy()
+			resp, err := h.Sign(context.TODO(), cr, issuer)
+			assert.Equal(t, test.expErr, err != nil, "unexpected error: %v", err)
+			if test.expResp {
+				require.NotNil(t, resp)
+				assert.Equal(t, []byte("cert"), resp.Certificate)
+				assert.Equal(t, []byte("ca"), resp.CA)
+			} else {
+				assert.Nil(t, resp)
+			}
+			assert.Equal(t, test.expReason, apiutil.CertificateRequestReadyReason(cr))
+			assert.Equal(t, test.expPollings, queue.Len())
+
+			forwarded, err := client.CertmanagerV1().CertificateRequests("spoke-a").Get(context.TODO(), name, metav1.GetOptions{})
+			switch {
+			case test.expDeleted:
+				assert.True(t, k8sErrors.IsNotFound(err), "expected forwarded request to be deleted")
+			case test.expCreated:
+				require.NoError(t, err)
+				assert.Equal(t, "default/test-1", forwarded.Annotations[cmapi.HubSpokeRequestAnnotationKey])
+				assert.Equal(t, cr.Spec.Request, forwarded.Spec.Request)
+				assert.Equal(t, cr.Spec.Usages, forwarded.Spec.Usages)
+				assert.Equal(t, issuer.Spec.Hub.IssuerRef, forwarded.Spec.IssuerRef)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// issuanceKey returns a hash of everything which determines the certificate
+// issued for the CertificateRequest: the CSR without its signature, the
+// requested issuer, duration, usages and isCA, and the Certificate and
+// revision the request was created for.
+// An empty key is returned for requests which were not created for a
+// Certificate, since those are expected to be signed each time.
+func issuanceKey(cr *cmapi.CertificateRequest) (string, error) {
+	crtName := cr.Annotations[cmapi.CertificateNameKey]
+	revision := cr.Annotations[cmapi.CertificateRequestRevisionAnnotationKey]
+	if crtName == "" || revision == "" {
+		return "", nil
+	}
+
+	csr, err := util.ParseCSR(cr)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(struct {
+		CSR         []byte
+		IssuerRef   cmmeta.ObjectReference
+		Duration    string
+		Usages      []cmapi.KeyUsage
+		IsCA        bool
+		Certificate string
+		Revision    string
+	}{
+		CSR:         csr.RawTBSCertificateRequest,
+		IssuerRef:   cr.Spec.IssuerRef,
+		Duration:    fmt.Sprint(cr.Spec.Duration),
+		Usages:      cr.Spec.Usages,
+		IsCA:        cr.Spec.IsCA,
+		Certificate: crtName,
+		Revision:    revision,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// issuedResponse returns the certificate already issued for another
+// CertificateRequest with the same issuance key, or nil if there is none.
+// This avoids signing the same CSR twice when a duplicate request is created
+// for a Certificate, for example when the controller is restarted during
+// issuance. The requests themselves hold the state, so that it survives
+// restarts.
+func (c *Controller) issuedResponse(cr *cmapi.CertificateRequest) (*issuer.IssueResponse, *cmapi.CertificateRequest, error) {
+	crtName := cr.Annotations[cmapi.CertificateNameKey]
+	revision := cr.Annotations[cmapi.CertificateRequestRevisionAnnotationKey]
+	if crtName == "" || revision == "" {
+		return nil, nil, nil
+	}
+
+	reqs, err := c.certificateRequestLister.CertificateRequests(cr.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The issuance key is only computed once a request for the same
+	// Certificate revision has been found, since decoding the CSRs of every
+	// request in the namespace would make each sync O(N).
+	var key string
+	for _, req := range reqs {
+		if req.Name == cr.Name || len(req.Status.Certificate) == 0 ||
+			req.Annotations[cmapi.CertificateNameKey] != crtName ||
+			req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey] != revision ||
+			req.Spec.IssuerRef != cr.Spec.IssuerRef ||
+			apiutil.CertificateRequestReadyReason(req) != cmapi.CertificateRequestReasonIssued {
+			continue
+		}
+
+		if key == "" {
+			if key, err = issuanceKey(cr); err != nil {
+				return nil, nil, err
+			}
+		}
+		if reqKey, err := issuanceKey(req); err != nil || reqKey != key {
+			continue
+		}
+
+		cert, err := pki.DecodeX509CertificateBytes(req.Status.Certificate)
+		if err != nil || !c.clock.Now().Before(cert.NotAfter) {
+			continue
+		}
+
+		return &issuer.IssueResponse{
+			Certificate: req.Status.Certificate,
+			CA:          req.Status.CA,
+		}, req, nil
+	}
+
+	return nil, nil, nil
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalcertificaterequests "github.com/cert-manager/cert-manager/internal/controller/certificaterequests"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	issuerplugin "github.com/cert-manager/cert-manager/pkg/issuer/plugin"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// CRControllerName is the name of the controller which signs
+	// CertificateRequests with issuer plugins.
+	CRControllerName = "certificaterequests-issuer-plugins"
+
+	// signTimeout is the maximum time waited for a plugin to answer a
+	// Sign call.
+	signTimeout = 30 * time.Second
+
+	// pendingRetryInterval is the interval at which plugins are asked again
+	// for the certificates of requests which they are still signing.
+	pendingRetryInterval = 10 * time.Second
+)
+
+// This controller signs the CertificateRequests which reference an issuer
+// whose group and kind has been registered with an issuer plugin, by
+// calling the plugin's Signer service. CertificateRequests of cert-manager's
+// own issuers are left to the other certificaterequests controllers.
+type controller struct {
+	log          logr.Logger
+	crLister     cmlisters.CertificateRequestLister
+	client       cmclient.Interface
+	fieldManager string
+	recorder     record.EventRecorder
+	reporter     *crutil.Reporter
+	queue        workqueue.RateLimitingInterface
+
+	signers map[schema.GroupKind]issuerplugin.Signer
+}
+
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	signers map[schema.GroupKind]issuerplugin.Signer,
+	fieldManager string,
+	rateLimiter workqueue.RateLimiter,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, CRControllerName)
+
+	// obtain references to all the informers used by this controller
+	crInformer := cmFactory.Certmanager().V1().CertificateRequests()
+	crInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		crInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		log:          log,
+		crLister:     crInformer.Lister(),
+		client:       client,
+		fieldManager: fieldManager,
+		recorder:     recorder,
+		reporter:     crutil.NewReporter(clock, recorder),
+		queue:        queue,
+		signers:      signers,
+	}, queue, mustSync
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) (err error) {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	dbg := log.V(logf.DebugLevel)
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	cr, err := c.crLister.CertificateRequests(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		dbg.Info("certificaterequest not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	signer, ok := c.signers[schema.GroupKind{Group: cr.Spec.IssuerRef.Group, Kind: cr.Spec.IssuerRef.Kind}]
+	if !ok {
+		return nil
+	}
+
+	switch {
+	case apiutil.CertificateRequestIsDenied(cr):
+		crCopy := cr.DeepCopy()
+		c.reporter.Denied(crCopy)
+		return c.updateStatus(ctx, cr, crCopy)
+
+	case !apiutil.CertificateRequestIsApproved(cr):
+		dbg.Info("certificate request has not been approved")
+		return nil
+
+	case apiutil.CertificateRequestReadyReason(cr) == cmapi.CertificateRequestReasonFailed,
+		apiutil.CertificateRequestReadyReason(cr) == cmapi.CertificateRequestReasonIssued,
+		len(cr.Status.Certificate) > 0:
+		dbg.Info("certificate request has already been signed or has failed so skipping processing")
+		return nil
+	}
+
+	crCopy := cr.DeepCopy()
+	defer func() {
+		if updateErr := c.updateStatus(ctx, cr, crCopy); updateErr != nil && err == nil {
+			err = updateErr
+		}
+	}()
+
+	signCtx, cancel := context.WithTimeout(ctx, signTimeout)
+	defer cancel()
+	resp, err := signer.Sign(signCtx, &issuerplugin.SignRequest{
+		Namespace: cr.Namespace,
+		Name:      cr.Name,
+		UID:       string(cr.UID),
+		IssuerRef: cr.Spec.IssuerRef,
+		Request:   cr.Spec.Request,
+		Duration:  durationOf(cr),
+		IsCA:      cr.Spec.IsCA,
+		Usages:    cr.Spec.Usages,
+	})
+	if issuerplugin.IsFailed(err) {
+		c.reporter.Failed(crCopy, err, "SigningError", "The issuer plugin failed to sign the certificate")
+		return nil
+	}
+	if err != nil {
+		// The error is returned so that the request is retried with a
+		// backoff.
+		c.reporter.Pending(crCopy, err, "PluginError", "Failed to call the issuer plugin")
+		return err
+	}
+
+	if len(resp.Certificate) == 0 {
+		message := resp.PendingMessage
+		if message == "" {
+			message = "Waiting for the issuer plugin to sign the certificate"
+		}
+		c.reporter.Pending(crCopy, nil, "IssuancePending", message)
+		c.queue.AddAfter(key, pendingRetryInterval)
+		return nil
+	}
+
+	if _, err := pki.DecodeX509CertificateChainBytes(resp.Certificate); err != nil {
+		c.reporter.Failed(crCopy, err, "InvalidCertificate", "The issuer plugin returned an invalid certificate")
+		return nil
+	}
+
+	crCopy.Status.Certificate = resp.Certificate
+	crCopy.Status.CA = resp.CA
+	c.reporter.Ready(crCopy)
+	return nil
+}
+
+// durationOf returns the duration requested by the CertificateRequest, or
+// zero if it did not request one.
+func durationOf(cr *cmapi.CertificateRequest) time.Duration {
+	if cr.Spec.Duration == nil {
+		return 0
+	}
+	return cr.Spec.Duration.Duration
+}
+
+func (c *controller) updateStatus(ctx context.Context, old, new *cmapi.CertificateRequest) error {
+	if apiequality.Semantic.DeepEqual(old.Status, new.Status) {
+		return nil
+	}
+	return internalcertificaterequests.ApplyStatus(ctx, c.client, c.fieldManager, new)
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, CRControllerName)
+
+	signers := make(map[schema.GroupKind]issuerplugin.Signer, len(ctx.IssuerOptions.IssuerPlugins))
+	for gk, address := range ctx.IssuerOptions.IssuerPlugins {
+		client, err := issuerplugin.Dial(address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to the issuer plugin for %s at %q: %w", gk, address, err)
+		}
+		go func() {
+			<-ctx.RootContext.Done()
+			client.Close()
+		}()
+		log.V(logf.InfoLevel).Info("signing certificate requests with issuer plugin", "issuer", gk.String(), "address", address)
+		signers[gk] = client
+	}
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		signers,
+		ctx.FieldManager,
+		ctx.DefaultRateLimiterFor(CRControllerName),
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	issuerplugin "github.com/cert-manager/cert-manager/pkg/issuer/plugin"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+type signerFunc func(context.Context, *issuerplugin.SignRequest) (*issuerplugin.SignResponse, error)
+
+func (f signerFunc) Sign(ctx context.Context, req *issuerplugin.SignRequest) (*issuerplugin.SignResponse, error) {
+	return f(ctx, req)
+}
+
+func mustSelfSignedCertificate(t *testing.T) []byte {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certPEM, _, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM
+}
+
+func Test_controller_ProcessItem(t *testing.T) {
+	fixedNow := metav1.NewTime(time.Now())
+	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+	certPEM := mustSelfSignedCertificate(t)
+
+	pluginRef := cmmeta.ObjectReference{Name: "my-issuer", Kind: "ExampleIssuer", Group: "example.com"}
+	approved := gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+		Type:               cmapi.CertificateRequestConditionApproved,
+		Status:             cmmeta.ConditionTrue,
+		Reason:             "cert-manager.io",
+		LastTransitionTime: &fixedNow,
+	})
+	baseCR := gen.CertificateRequest("test",
+		gen.SetCertificateRequestIssuer(pluginRef),
+		gen.SetCertificateRequestCSR([]byte("csr")),
+		gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
+		gen.SetCertificateRequestIsCA(true),
+		gen.SetCertificateRequestKeyUsages(cmapi.UsageServerAuth),
+	)
+	approvedCR := gen.CertificateRequestFrom(baseCR, approved)
+	ready := func(status cmmeta.ConditionStatus, reason, message string) gen.CertificateRequestModifier {
+		return gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionReady,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: &fixedNow,
+		})
+	}
+
+	tests := map[string]struct {
+		existingCR *cmapi.CertificateRequest
+		// signer is the plugin's Sign function. If nil, the plugin is not
+		// expected to be called.
+		signer signerFunc
+
+		expectedCR    *cmapi.CertificateRequest
+		expectedEvent string
+		expectedErr   bool
+	}{
+		"ignore requests for issuers without a plugin": {
+			existingCR: gen.CertificateRequestFrom(approvedCR,
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca", Kind: cmapi.IssuerKind, Group: "cert-manager.io"})),
+		},
+		"do not sign requests which have not been approved": {
+			existingCR: baseCR,
+		},
+		"mark denied requests as failed": {
+			existingCR: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:               cmapi.CertificateRequestConditionDenied,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "policy",
+					LastTransitionTime: &fixedNow,
+				})),
+			expectedCR: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:               cmapi.CertificateRequestConditionDenied,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "policy",
+					LastTransitionTime: &fixedNow,
+				}),
+				ready(cmmeta.ConditionFalse, cmapi.CertificateRequestReasonDenied, "The CertificateRequest was denied by an approval controller"),
+				gen.SetCertificateRequestFailureTime(fixedNow),
+			),
+		},
+		"do not sign requests which have already been issued": {
+			existingCR: gen.CertificateRequestFrom(approvedCR,
+				gen.SetCertificateRequestCertificate(certPEM),
+				ready(cmmeta.ConditionTrue, cmapi.CertificateRequestReasonIssued, "Certificate fetched from issuer successfully"),
+			),
+		},
+		"store the certificate signed by the plugin": {
+			existingCR: approvedCR,
+			signer: func(_ context.Context, req *issuerplugin.SignRequest) (*issuerplugin.SignResponse, error) {
+				assert.Equal(t, &issuerplugin.SignRequest{
+					Namespace: gen.DefaultTestNamespace,
+					Name:      "test",
+					IssuerRef: pluginRef,
+					Request:   []byte("csr"),
+					Duration:  time.Hour,
+					IsCA:      true,
+					Usages:    []cmapi.KeyUsage{cmapi.UsageServerAuth},
+				}, req)
+				return &issuerplugin.SignResponse{Certificate: certPEM, CA: certPEM}, nil
+			},
+			expectedCR: gen.CertificateRequestFrom(approvedCR,
+				gen.SetCertificateRequestCertificate(certPEM),
+				gen.SetCertificateRequestCA(certPEM),
+				ready(cmmeta.ConditionTrue, cmapi.CertificateRequestReasonIssued, "Certificate fetched from issuer successfully"),
+			),
+			expectedEvent: "Normal CertificateIssued Certificate fetched from issuer successfully",
+		},
+		"mark the request as pending while the plugin is signing it": {
+			existingCR: approvedCR,
+			signer: func(context.Context, *issuerplugin.SignRequest) (*issuerplugin.SignResponse, error) {
+				return &issuerplugin.SignResponse{PendingMessage: "Waiting for an operator"}, nil
+			},
+			expectedCR:    gen.CertificateRequestFrom(approvedCR, ready(cmmeta.ConditionFalse, cmapi.CertificateRequestReasonPending, "Waiting for an operator")),
+			expectedEvent: "Normal IssuancePending Waiting for an operator",
+		},
+		"fail the request if the plugin fails it": {
+			existingCR: approvedCR,
+			signer: func(context.Context, *issuerplugin.SignRequest) (*issuerplugin.SignResponse, error) {
+				return nil, issuerplugin.Failed("subject not allowed")
+			},
+			expectedCR: gen.CertificateRequestFrom(approvedCR,
+				ready(cmmeta.ConditionFalse, cmapi.CertificateRequestReasonFailed,
+					"The issuer plugin failed to sign the certificate: rpc error: code = InvalidArgument desc = subject not allowed"),
+				gen.SetCertificateRequestFailureTime(fixedNow),
+			),
+			expectedEvent: "Warning SigningError The issuer plugin failed to sign the certificate: rpc error: code = InvalidArgument desc = subject not allowed",
+		},
+		"retry the request if the plugin cannot be reached": {
+			existingCR: approvedCR,
+			signer: func(context.Context, *issuerplugin.SignRequest) (*issuerplugin.SignResponse, error) {
+				return nil, status.Error(codes.Unavailable, "connection refused")
+			},
+			expectedCR: gen.CertificateRequestFrom(approvedCR,
+				ready(cmmeta.ConditionFalse, cmapi.CertificateRequestReasonPending,
+					"Failed to call the issuer plugin: rpc error: code = Unavailable desc = connection refused"),
+			),
+			expectedEvent: "Normal PluginError Failed to call the issuer plugin: rpc error: code = Unavailable desc = connection refused",
+			expectedErr:   true,
+		},
+		"fail the request if the plugin returns an invalid certificate": {
+			existingCR: approvedCR,
+			signer: func(context.Context, *issuerplugin.SignRequest) (*issuerplugin.SignResponse, error) {
+				return &issuerplugin.SignResponse{Certificate: []byte("not a certificate")}, nil
+			},
+			expectedCR: gen.CertificateRequestFrom(approvedCR,
+				ready(cmmeta.ConditionFalse, cmapi.CertificateRequestReasonFailed,
+					"The issuer plugin returned an invalid certificate: error decoding certificate PEM block"),
+				gen.SetCertificateRequestFailureTime(fixedNow),
+			),
+			expectedEvent: "Warning InvalidCertificate The issuer plugin returned an invalid certificate: error decoding certificate PEM block",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{test.existingCR},
+			}
+			if test.expectedCR != nil {
+				builder.ExpectedActions = []testpkg.Action{
+					testpkg.NewApplyStatusAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), gen.DefaultTestNamespace, test.expectedCR),
+				}
+			}
+			if test.expectedEvent != "" {
+				builder.ExpectedEvents = []string{test.expectedEvent}
+			}
+			builder.Init()
+
+			called := false
+			signer := signerFunc(func(ctx context.Context, req *issuerplugin.SignRequest) (*issuerplugin.SignResponse, error) {
+				called = true
+				if test.signer == nil {
+					return nil, errors.New("unexpected call to the plugin")
+				}
+				return test.signer(ctx, req)
+			})
+			c, _, _ := NewController(logf.Log, builder.CMClient, builder.SharedInformerFactory, builder.Recorder, builder.Clock,
+				map[schema.GroupKind]issuerplugin.Signer{{Group: "example.com", Kind: "ExampleIssuer"}: signer},
+				builder.FieldManager, controllerpkg.DefaultItemBasedRateLimiter())
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.existingCR)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = c.ProcessItem(context.Background(), key)
+			if test.expectedErr != (err != nil) {
+				t.Errorf("expected error: %t, got: %v", test.expectedErr, err)
+			}
+			assert.Equal(t, test.signer != nil, called, "unexpected call to the plugin")
+
+			builder.CheckAndFinish()
+		})
+	}
+}
@@ -20,8 +20,8 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
-	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/util/workqueue"
 
@@ -44,7 +44,7 @@ func handleSecretReferenceWorkFunc(log logr.Logger,
 ) func(obj any) {
 	return func(obj any) {
 		log := log.WithName("handleSecretReference")
-		secret, ok := obj.(*corev1.Secret)
+		secret, ok := obj.(metav1.Object)
 		if !ok {
 			log.Error(nil, "object is not a secret")
 			return
@@ -74,10 +74,10 @@ func handleSecretReferenceWorkFunc(log logr.Logger,
 func certificateRequestsForSecret(log logr.Logger,
 	lister clientv1.CertificateRequestLister,
 	helper issuer.Helper,
-	secret *corev1.Secret,
+	secret metav1.Object,
 ) ([]*cmapi.CertificateRequest, error) {
 	dbg := log.V(logf.DebugLevel)
-	requests, err := lister.CertificateRequests(secret.Namespace).List(labels.Everything())
+	requests, err := lister.CertificateRequests(secret.GetNamespace()).List(labels.Everything())
 	if err != nil {
 		return nil, fmt.Errorf("failed to list certificate requests: %w", err)
 	}
@@ -106,7 +106,7 @@ func certificateRequestsForSecret(log logr.Logger,
 		}
 
 		if issuerType == apiutil.IssuerSelfSigned &&
-			request.GetAnnotations()[cmapi.CertificateRequestPrivateKeyAnnotationKey] == secret.Name {
+			request.GetAnnotations()[cmapi.CertificateRequestPrivateKeyAnnotationKey] == secret.GetName() {
 			dbg.Info("certificate request references secret, syncing")
 			affected = append(affected, request)
 		}
@@ -72,11 +72,13 @@ func init() {
 				// Handle informed Secrets which may be referenced by the
 				// "cert-manager.io/private-key-secret-name" annotation.
 				func(ctx *controllerpkg.Context, log logr.Logger, queue workqueue.RateLimitingInterface) ([]cache.InformerSynced, error) {
-					secretInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets().Informer()
+					secretInformer := controllerpkg.SecretsMetadataInformer(ctx.KubeSharedInformerFactory)
 					certificateRequestLister := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister()
-					helper := issuer.NewHelper(
+					helper := issuer.NewAliasingHelper(
 						ctx.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
 						ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister(),
+						ctx.SharedInformerFactory.Certmanager().V1().IssuerAliases().Lister(),
+						ctx.IssuerOptions.ClusterResourceNamespace,
 					)
 					secretInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
 						WorkFunc: handleSecretReferenceWorkFunc(log, certificateRequestLister, helper, queue),
@@ -85,6 +87,7 @@ func init() {
 						secretInformer.HasSynced,
 						ctx.SharedInformerFactory.Certmanager().V1().Issuers().Informer().HasSynced,
 						ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Informer().HasSynced,
+						ctx.SharedInformerFactory.Certmanager().V1().IssuerAliases().Informer().HasSynced,
 					}, nil
 				},
 			)).
@@ -95,7 +98,7 @@ func init() {
 func NewSelfSigned(ctx *controllerpkg.Context) certificaterequests.Issuer {
 	return &SelfSigned{
 		issuerOptions: ctx.IssuerOptions,
-		secretsLister: ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		secretsLister: controllerpkg.NewSecretGetter(ctx.KubeSharedInformerFactory),
 		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
 		recorder:      ctx.Recorder,
 		signingFn:     pki.SignCertificate,
@@ -147,7 +150,7 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 		return nil, err
 	}
 
-	template, err := pki.GenerateTemplateFromCertificateRequest(cr)
+	template, err := crutil.GenerateTemplate(cr)
 	if err != nil {
 		message := "Error generating certificate template"
 		s.reporter.Failed(cr, err, "ErrorGenerating", message)
@@ -29,7 +29,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientcorev1 "k8s.io/client-go/listers/core/v1"
-	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
@@ -118,6 +117,27 @@ func TestSign(t *testing.T) {
 	}
 	csrECPEM := generateCSR(t, skEC, "test-ec")
 
+	skEd25519, err := pki.GenerateEd25519PrivateKey()
+	if err != nil {
+		t.Errorf("failed to generate Ed25519 private key: %s", err)
+		t.FailNow()
+	}
+	skEd25519PEM, err := pki.EncodePKCS8PrivateKey(skEd25519)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	ed25519KeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rsaKeySecret.Name,
+			Namespace: gen.DefaultTestNamespace,
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: skEd25519PEM,
+		},
+	}
+	csrEd25519PEM := generateCSR(t, skEd25519, "test-ed25519")
+
 	csrEmptyCertPEM := generateCSR(t, skEC, "")
 
 	baseCRNotApproved := gen.CertificateRequest("test-cr",
@@ -154,6 +174,9 @@ func TestSign(t *testing.T) {
 	ecCR := gen.CertificateRequestFrom(baseCR,
 		gen.SetCertificateRequestCSR(csrECPEM),
 	)
+	ed25519CR := gen.CertificateRequestFrom(baseCR,
+		gen.SetCertificateRequestCSR(csrEd25519PEM),
+	)
 	emptyCR := gen.CertificateRequestFrom(baseCR,
 		gen.SetCertificateRequestCSR(csrEmptyCertPEM),
 	)
@@ -180,6 +203,17 @@ func TestSign(t *testing.T) {
 		t.FailNow()
 	}
 
+	templateEd25519, err := pki.GenerateTemplateFromCertificateRequest(ed25519CR)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	certEd25519PEM, _, err := pki.SignCertificate(templateEd25519, templateEd25519, skEd25519.Public(), skEd25519)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
 	templateEmptyCert, err := pki.GenerateTemplateFromCertificateRequest(emptyCR)
 	if err != nil {
 		t.Error(err)
@@ -210,9 +244,8 @@ func TestSign(t *testing.T) {
 				CertManagerObjects: []runtime.Object{baseCRDenied.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedEvents:     []string{},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCRDenied,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -224,7 +257,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -243,9 +276,8 @@ func TestSign(t *testing.T) {
 					`Warning MissingAnnotation Annotation "cert-manager.io/private-key-secret-name" missing or reference empty: secret name missing`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.DeleteCertificateRequestAnnotation(cmapi.CertificateRequestPrivateKeyAnnotationKey),
@@ -258,7 +290,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -277,9 +309,8 @@ func TestSign(t *testing.T) {
 					`Warning MissingAnnotation Annotation "cert-manager.io/private-key-secret-name" missing or reference empty: secret name missing`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestPrivateKeyAnnotationKey: ""}),
@@ -292,7 +323,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -305,9 +336,8 @@ func TestSign(t *testing.T) {
 					`Normal MissingSecret Referenced secret default-unit-test-ns/test-rsa-key not found: secret "test-rsa-key" not found`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -318,7 +348,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -331,9 +361,8 @@ func TestSign(t *testing.T) {
 					`Normal ErrorParsingKey Failed to get key "test-rsa-key" referenced in annotation "cert-manager.io/private-key-secret-name": error decoding private key PEM block`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -344,7 +373,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -360,9 +389,8 @@ func TestSign(t *testing.T) {
 					"Normal IssuerNotReady Referenced issuer does not have a Ready status condition",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -373,7 +401,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -386,9 +414,8 @@ func TestSign(t *testing.T) {
 					`Normal ErrorGettingSecret Failed to get certificate key pair from secret default-unit-test-ns/test-rsa-key: this is a network error`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -399,7 +426,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 			fakeLister: &listersfake.FakeSecretLister{
@@ -422,9 +449,8 @@ func TestSign(t *testing.T) {
 					"Warning ErrorKeyMatch Error generating certificate template: CSR not signed by referenced private key",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(ecCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -436,7 +462,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -452,9 +478,8 @@ func TestSign(t *testing.T) {
 					"Warning ErrorSigning Error signing certificate: this is a signing error",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -466,7 +491,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -489,9 +514,8 @@ func TestSign(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -504,7 +528,7 @@ func TestSign(t *testing.T) {
 							gen.SetCertificateRequestCertificate(certRSAPEM),
 							gen.SetCertificateRequestCA(certRSAPEM),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -527,9 +551,8 @@ func TestSign(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(ecCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -542,7 +565,44 @@ func TestSign(t *testing.T) {
 							gen.SetCertificateRequestCertificate(certECPEM),
 							gen.SetCertificateRequestCA(certECPEM),
 						),
-					)),
+					),
+				},
+			},
+		},
+		"should sign an Ed25519 key set condition to Ready": {
+			certificateRequest: ed25519CR.DeepCopy(),
+			signingFn: func(c1 *x509.Certificate, c2 *x509.Certificate, pk crypto.PublicKey, sk interface{}) ([]byte, *x509.Certificate, error) {
+				// We still check that it will sign and not error
+				// Return error if we do
+				_, _, err := pki.SignCertificate(c1, c2, pk, sk)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				return certEd25519PEM, nil, nil
+			},
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{ed25519KeySecret},
+				CertManagerObjects: []runtime.Object{ed25519CR.DeepCopy(), baseIssuer},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(ed25519CR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(certEd25519PEM),
+							gen.SetCertificateRequestCA(certEd25519PEM),
+						),
+					),
 				},
 			},
 		},
@@ -570,9 +630,8 @@ func TestSign(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(
 							emptyCR,
@@ -586,7 +645,7 @@ func TestSign(t *testing.T) {
 							gen.SetCertificateRequestCertificate(emptyCertPEM),
 							gen.SetCertificateRequestCA(emptyCertPEM),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -18,14 +18,20 @@ package certificaterequests
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/kr/pretty"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	internalcertificaterequests "github.com/cert-manager/cert-manager/internal/controller/certificaterequests"
@@ -34,9 +40,12 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/tracing"
 )
 
 func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (err error) {
@@ -51,6 +60,10 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	crCopy := cr.DeepCopy()
 
 	defer func() {
+		// A request loaded from the Secret referenced by requestSecretRef is
+		// only held in memory, and must never be written back to the
+		// CertificateRequest.
+		crCopy.Spec.Request = cr.Spec.Request
 		if saveErr := c.updateCertificateRequestStatusAndAnnotations(ctx, cr, crCopy); saveErr != nil {
 			err = utilerrors.NewAggregate([]error{saveErr, err})
 		}
@@ -129,13 +142,131 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
-	dbg.Info("invoking sign function as existing certificate does not exist")
+	if ref := crCopy.Spec.RequestSecretRef; ref != nil {
+		dbg.Info("loading request from referenced Secret", "secret", ref.Name)
+		secret, err := c.secretGetter.Get(ctx, crCopy.Namespace, ref.Name)
+		if k8sErrors.IsNotFound(err) {
+			c.reporter.Pending(crCopy, err, "RequestSecretNotFound",
+				fmt.Sprintf("Referenced Secret %q holding the request was not found", ref.Name))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(secret.Data[ref.Key]) == 0 {
+			c.reporter.Pending(crCopy, nil, "RequestSecretMissingKey",
+				fmt.Sprintf("Referenced Secret %q does not hold a request in key %q", ref.Name, ref.Key))
+			return nil
+		}
+		// The Secret may have been changed since the CertificateRequest was
+		// approved, so only the request pinned by requestSHA256 is signed.
+		if digest := sha256.Sum256(secret.Data[ref.Key]); !strings.EqualFold(hex.EncodeToString(digest[:]), crCopy.Spec.RequestSHA256) {
+			c.reporter.Failed(crCopy, fmt.Errorf("request has digest %x", digest), "RequestSecretMismatch",
+				fmt.Sprintf("Referenced Secret %q does not hold the request with the digest in requestSHA256", ref.Name))
+			return nil
+		}
+		crCopy.Spec.Request = secret.Data[ref.Key]
+	}
 
-	// Attempt to call the Sign function on our issuer
-	resp, err := c.issuer.Sign(ctx, crCopy, issuerObj)
+	if err := checkURISchemes(crCopy, issuerObj); err != nil {
+		c.reporter.Failed(crCopy, err, "URISchemeNotAllowed",
+			fmt.Sprintf("Referenced %q does not allow the URI SANs of the request", apiutil.IssuerKind(crCopy.Spec.IssuerRef)))
+		return nil
+	}
+
+	if err := apiutil.CheckIssuerUsages(issuerObj, crCopy.Namespace, apiutil.RequestedUsages(crCopy.Spec.Usages, crCopy.Spec.IsCA)); err != nil {
+		c.reporter.Failed(crCopy, err, "UsageNotAllowed",
+			fmt.Sprintf("Referenced %q does not allow the usages of the request", apiutil.IssuerKind(crCopy.Spec.IssuerRef)))
+		return nil
+	}
+
+	// Reuse the certificate if the same CSR has already been signed for
+	// another request of the same Certificate revision, rather than signing
+	// it again.
+	resp, issuedCR, err := c.issuedResponse(crCopy)
 	if err != nil {
-		log.Error(err, "error issuing certificate request")
-		return err
+		log.Error(err, "failed to check for a certificate already issued for the request, signing it instead")
+	}
+
+	// Share the certificate of an identical request from the same owner
+	// rather than sending the request to the issuer too.
+	var batchLeader *cmapi.CertificateRequest
+	if issuedCR == nil {
+		key, err := batchKey(crCopy)
+		if err != nil {
+			log.Error(err, "failed to batch the request with identical requests, signing it on its own")
+		}
+		if key != "" && crCopy.Labels[cmapi.SignBatchLabelKey] != key {
+			// The request is labelled before it is signed, so that an
+			// identical request processed at the same time finds it. The
+			// update re-queues the request.
+			metav1.SetMetaDataLabel(&crCopy.ObjectMeta, cmapi.SignBatchLabelKey, key)
+			return nil
+		}
+		if key != "" {
+			leader, err := c.signBatchLeader(crCopy, key)
+			if err != nil {
+				return err
+			}
+			if leader.Name != crCopy.Name && len(leader.Status.Certificate) == 0 {
+				// The request is re-queued once the identical request has
+				// been signed, has failed or has been deleted.
+				c.reporter.Pending(crCopy, nil, "WaitingForIdenticalRequest",
+					fmt.Sprintf("Waiting for identical CertificateRequest %q to be signed", leader.Name))
+				return nil
+			}
+			if leader.Name != crCopy.Name {
+				batchLeader = leader
+				resp = &issuer.IssueResponse{Certificate: leader.Status.Certificate, CA: leader.Status.CA}
+			}
+		}
+	}
+
+	switch {
+	case issuedCR != nil:
+		dbg.Info("reusing certificate already issued for identical request", "issued_request", issuedCR.Name)
+		c.recorder.Eventf(crCopy, corev1.EventTypeNormal, "ReusedCertificate",
+			"Reused the certificate issued for identical CertificateRequest %q", issuedCR.Name)
+	case batchLeader != nil:
+		dbg.Info("sharing certificate issued for identical request", "signing_request", batchLeader.Name)
+		c.recorder.Eventf(crCopy, corev1.EventTypeNormal, "SharedCertificate",
+			"Shared the certificate issued for identical CertificateRequest %q", batchLeader.Name)
+	default:
+		issuerKey := types.NamespacedName{Namespace: issuerObj.GetNamespace(), Name: issuerObj.GetName()}
+		if ok, wait := c.circuitBreaker.allow(issuerKey); !ok {
+			c.reporter.Pending(crCopy, nil, "IssuerCircuitOpen",
+				fmt.Sprintf("Not signing CertificateRequest as most recent requests to the issuer have failed, retrying in %s", wait.Round(time.Second)))
+			if key, err := controllerpkg.KeyFunc(cr); err == nil {
+				c.queue.AddAfter(key, wait)
+			}
+			return nil
+		}
+		defer func() {
+			failed := err != nil || apiutil.CertificateRequestReadyReason(crCopy) == cmapi.CertificateRequestReasonFailed
+			if !failed && len(crCopy.Status.Certificate) == 0 {
+				// The request is still pending.
+				return
+			}
+			if c.circuitBreaker.record(issuerKey, failed) {
+				log.Info("pausing the signing of requests by the issuer as most recent requests have failed",
+					"issuer", issuerKey, "cooldown", circuitBreakerCooldown)
+			}
+		}()
+
+		dbg.Info("invoking sign function as existing certificate does not exist")
+
+		// Attempt to call the Sign function on our issuer. The span context is
+		// passed to the issuer, so that any resources it creates continue the
+		// trace.
+		signCtx, span := tracing.Start(ctx, crCopy, "CertificateRequest.Sign",
+			append(tracing.IssuerRefAttributes(crCopy.Spec.IssuerRef), attribute.String("cert-manager.issuer.type", c.issuerType))...)
+		resp, err = c.issuer.Sign(signCtx, crCopy, issuerObj)
+		span.SetAttributes(attribute.Bool("cert-manager.issued", resp != nil))
+		tracing.End(span, err)
+		if err != nil {
+			log.Error(err, "error issuing certificate request")
+			return err
+		}
 	}
 
 	// If the issuer has not returned any data we may be pending or failed. The
@@ -165,9 +296,10 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 func (c *Controller) updateCertificateRequestStatusAndAnnotations(ctx context.Context, old, new *cmapi.CertificateRequest) error {
 	log := logf.FromContext(ctx, "updateStatus")
 
-	// if annotations changed we have to call .Update() and not .UpdateStatus()
-	if !reflect.DeepEqual(old.Annotations, new.Annotations) {
-		log.V(logf.DebugLevel).Info("updating resource due to change in annotations", "diff", pretty.Diff(old.Annotations, new.Annotations))
+	// if annotations or labels changed we have to call .Update() and not .UpdateStatus()
+	if !reflect.DeepEqual(old.Annotations, new.Annotations) || !reflect.DeepEqual(old.Labels, new.Labels) {
+		log.V(logf.DebugLevel).Info("updating resource due to change in annotations or labels",
+			"annotations", pretty.Diff(old.Annotations, new.Annotations), "labels", pretty.Diff(old.Labels, new.Labels))
 		return c.updateOrApply(ctx, new)
 	}
 
@@ -176,7 +308,7 @@ func (c *Controller) updateCertificateRequestStatusAndAnnotations(ctx context.Co
 	}
 
 	log.V(logf.DebugLevel).Info("updating resource due to change in status", "diff", pretty.Diff(old.Status, new.Status))
-	return c.updateStatusOrApply(ctx, new)
+	return c.applyStatus(ctx, new)
 }
 
 func (c *Controller) updateOrApply(ctx context.Context, cr *cmapi.CertificateRequest) error {
@@ -189,11 +321,6 @@ func (c *Controller) updateOrApply(ctx context.Context, cr *cmapi.CertificateReq
 	}
 }
 
-func (c *Controller) updateStatusOrApply(ctx context.Context, cr *cmapi.CertificateRequest) error {
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		return internalcertificaterequests.ApplyStatus(ctx, c.cmClient, c.fieldManager, cr)
-	} else {
-		_, err := c.cmClient.CertmanagerV1().CertificateRequests(cr.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{})
-		return err
-	}
+func (c *Controller) applyStatus(ctx context.Context, cr *cmapi.CertificateRequest) error {
+	return internalcertificaterequests.ApplyStatus(ctx, c.cmClient, c.fieldManager, cr)
 }
@@ -21,16 +21,20 @@ import (
 	"context"
 	"crypto"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 
 	"github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -154,6 +158,32 @@ func TestSync(t *testing.T) {
 	certRSAPEM := generateSelfSignedCert(t, baseCR, skRSA, fixedClockStart, fixedClockStart.Add(time.Hour*12))
 	certRSAPEMExpired := generateSelfSignedCert(t, baseCR, skRSA, fixedClockStart.Add(-time.Hour*13), fixedClockStart.Add(-time.Hour*12))
 
+	certificateAnnotations := map[string]string{
+		cmapi.CertificateNameKey:                      "test-crt",
+		cmapi.CertificateRequestRevisionAnnotationKey: "2",
+	}
+	issuedCR := gen.CertificateRequestFrom(baseCR,
+		gen.SetCertificateRequestName("test-cr-issued"),
+		gen.SetCertificateRequestCertificate(certRSAPEM),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionReady,
+			Status: cmmeta.ConditionTrue,
+			Reason: cmapi.CertificateRequestReasonIssued,
+		}),
+	)
+
+	ownedCR := gen.CertificateRequestFrom(baseCR, gen.AddCertificateRequestOwnerReferences(metav1.OwnerReference{
+		APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "app", UID: "uid-1", Controller: pointer.Bool(true),
+	}))
+	ownedCR.CreationTimestamp = nowMetaTime
+	signBatch, err := batchKey(ownedCR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batchedCR := gen.CertificateRequestFrom(ownedCR, gen.SetCertificateRequestLabels(map[string]string{cmapi.SignBatchLabelKey: signBatch}))
+	olderBatchedCR := gen.CertificateRequestFrom(batchedCR, gen.SetCertificateRequestName("test-cr-older"))
+	olderBatchedCR.CreationTimestamp = metav1.NewTime(fixedClockStart.Add(-time.Minute))
+
 	certECPEM := generateSelfSignedCert(t, baseCREC, skEC, fixedClockStart, fixedClockStart.Add(time.Hour*12))
 	certECPEMExpired := generateSelfSignedCert(t, baseCREC, skEC, fixedClockStart.Add(-time.Hour*13), fixedClockStart.Add(-time.Hour*12))
 
@@ -194,9 +224,8 @@ func TestSync(t *testing.T) {
 				CertManagerObjects: []runtime.Object{baseIssuer, baseCR},
 				ExpectedEvents:     []string{},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCRNotApproved,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -215,7 +244,7 @@ func TestSync(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(nowMetaTime),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -241,9 +270,8 @@ func TestSync(t *testing.T) {
 				CertManagerObjects: []runtime.Object{baseIssuer, baseCR},
 				ExpectedEvents:     []string{},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCRNotApproved,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -262,7 +290,7 @@ func TestSync(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(nowMetaTime),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -329,9 +357,8 @@ func TestSync(t *testing.T) {
 				CertManagerObjects: []runtime.Object{baseIssuer, baseCR},
 				ExpectedEvents:     []string{},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCRNotApproved,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -350,7 +377,7 @@ func TestSync(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(nowMetaTime),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -412,9 +439,8 @@ func TestSync(t *testing.T) {
 					`Normal IssuerNotFound Referenced "Issuer" not found: issuer.cert-manager.io "test-issuer" not found`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -425,7 +451,7 @@ func TestSync(t *testing.T) {
 								LastTransitionTime: &nowMetaTime,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -451,9 +477,8 @@ func TestSync(t *testing.T) {
 					gen.Issuer(baseIssuer.Name),
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -464,7 +489,7 @@ func TestSync(t *testing.T) {
 								LastTransitionTime: &nowMetaTime,
 							}),
 						),
-					)),
+					),
 				},
 				ExpectedEvents: []string{
 					"Normal IssuerTypeMissing Missing issuer type: no issuer specified for Issuer 'default-unit-test-ns/test-issuer'",
@@ -480,9 +505,8 @@ func TestSync(t *testing.T) {
 					),
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -493,7 +517,7 @@ func TestSync(t *testing.T) {
 								LastTransitionTime: &nowMetaTime,
 							}),
 						),
-					)),
+					),
 				},
 				ExpectedEvents: []string{
 					"Normal IssuerNotReady Referenced issuer does not have a Ready status condition",
@@ -572,9 +596,8 @@ func TestSync(t *testing.T) {
 					"Warning DecodeError Failed to decode returned certificate: error decoding certificate PEM block",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCertificate([]byte("a bad certificate")),
@@ -587,7 +610,7 @@ func TestSync(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(nowMetaTime),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -606,11 +629,56 @@ func TestSync(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCertificate(certRSAPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					),
+				},
+			},
+		},
+		"if the request is referenced by requestSecretRef, sign it with the request loaded from the Secret": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestCSRSecretRef("test-csr", "tls.csr", csrRSAPEM),
+			),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(_ context.Context, cr *cmapi.CertificateRequest, _ cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					if !bytes.Equal(cr.Spec.Request, csrRSAPEM) {
+						return nil, errors.New("request was not loaded from the Secret")
+					}
+					return &issuer.IssueResponse{
+						Certificate: certRSAPEM,
+					}, nil
+				},
+			},
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: "test-csr", Namespace: gen.DefaultTestNamespace},
+						Data:       map[string][]byte{"tls.csr": csrRSAPEM},
+					},
+				},
+				CertManagerObjects: []runtime.Object{baseIssuer, gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestCSRSecretRef("test-csr", "tls.csr", csrRSAPEM),
+				)},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCSRSecretRef("test-csr", "tls.csr", csrRSAPEM),
 							gen.SetCertificateRequestCertificate(certRSAPEM),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
@@ -620,10 +688,249 @@ func TestSync(t *testing.T) {
 								LastTransitionTime: &nowMetaTime,
 							}),
 						),
+					),
+				},
+			},
+		},
+		"if the request has an owner, label it with its batch before signing it": {
+			certificateRequest: ownedCR,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer, ownedCR},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						batchedCR,
 					)),
 				},
 			},
 		},
+		"if an older identical request of the same owner is being signed, wait for it": {
+			certificateRequest: batchedCR,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer, batchedCR, olderBatchedCR},
+				ExpectedEvents: []string{
+					`Normal WaitingForIdenticalRequest Waiting for identical CertificateRequest "test-cr-older" to be signed`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(batchedCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Pending",
+								Message:            `Waiting for identical CertificateRequest "test-cr-older" to be signed`,
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					),
+				},
+			},
+		},
+		"if an identical request of the same owner has been issued, share its certificate": {
+			certificateRequest: batchedCR,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer, batchedCR, gen.CertificateRequestFrom(olderBatchedCR,
+					gen.SetCertificateRequestCertificate(certRSAPEM),
+					gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionTrue,
+						Reason: cmapi.CertificateRequestReasonIssued,
+					}),
+				)},
+				ExpectedEvents: []string{
+					`Normal SharedCertificate Shared the certificate issued for identical CertificateRequest "test-cr-older"`,
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(batchedCR,
+							gen.SetCertificateRequestCertificate(certRSAPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					),
+				},
+			},
+		},
+		"if the Secret referenced by requestSecretRef holds a request other than the one pinned by requestSHA256, set status failed": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestCSRSecretRef("test-csr", "tls.csr", csrRSAPEM),
+			),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: "test-csr", Namespace: gen.DefaultTestNamespace},
+						Data:       map[string][]byte{"tls.csr": csrECPEM},
+					},
+				},
+				CertManagerObjects: []runtime.Object{baseIssuer, gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestCSRSecretRef("test-csr", "tls.csr", csrRSAPEM),
+				)},
+				ExpectedEvents: []string{
+					fmt.Sprintf(`Warning RequestSecretMismatch Referenced Secret "test-csr" does not hold the request with the digest in requestSHA256: request has digest %x`, sha256.Sum256(csrECPEM)),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCSRSecretRef("test-csr", "tls.csr", csrRSAPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Failed",
+								Message:            fmt.Sprintf(`Referenced Secret "test-csr" does not hold the request with the digest in requestSHA256: request has digest %x`, sha256.Sum256(csrECPEM)),
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestFailureTime(nowMetaTime),
+						),
+					),
+				},
+			},
+		},
+		"if the Secret referenced by requestSecretRef does not exist, set status pending": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestCSRSecretRef("test-csr", "tls.csr", csrRSAPEM),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer, gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestCSRSecretRef("test-csr", "tls.csr", csrRSAPEM),
+				)},
+				ExpectedEvents: []string{
+					`Normal RequestSecretNotFound Referenced Secret "test-csr" holding the request was not found: secret "test-csr" not found`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCSRSecretRef("test-csr", "tls.csr", csrRSAPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Pending",
+								Message:            `Referenced Secret "test-csr" holding the request was not found: secret "test-csr" not found`,
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					),
+				},
+			},
+		},
+		"if the Secret referenced by requestSecretRef does not hold the key, set status pending": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestCSRSecretRef("test-csr", "tls.csr", csrRSAPEM),
+			),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: "test-csr", Namespace: gen.DefaultTestNamespace},
+					},
+				},
+				CertManagerObjects: []runtime.Object{baseIssuer, gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestCSRSecretRef("test-csr", "tls.csr", csrRSAPEM),
+				)},
+				ExpectedEvents: []string{
+					`Normal RequestSecretMissingKey Referenced Secret "test-csr" does not hold a request in key "tls.csr"`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCSRSecretRef("test-csr", "tls.csr", csrRSAPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Pending",
+								Message:            `Referenced Secret "test-csr" does not hold a request in key "tls.csr"`,
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					),
+				},
+			},
+		},
+		"if an identical request for the same Certificate revision has been issued, reuse its certificate without signing": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestAnnotations(certificateAnnotations),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer,
+					gen.CertificateRequestFrom(baseCR,
+						gen.SetCertificateRequestAnnotations(certificateAnnotations),
+					),
+					gen.CertificateRequestFrom(issuedCR,
+						gen.SetCertificateRequestAnnotations(certificateAnnotations),
+					),
+				},
+				ExpectedEvents: []string{
+					`Normal ReusedCertificate Reused the certificate issued for identical CertificateRequest "test-cr-issued"`,
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestAnnotations(certificateAnnotations),
+							gen.SetCertificateRequestCertificate(certRSAPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					),
+				},
+			},
+		},
+		"if an identical request has been issued for a different Certificate revision, call sign": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestAnnotations(certificateAnnotations),
+			),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return nil, nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer,
+					gen.CertificateRequestFrom(baseCR,
+						gen.SetCertificateRequestAnnotations(certificateAnnotations),
+					),
+					gen.CertificateRequestFrom(issuedCR,
+						gen.SetCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateNameKey:                      "test-crt",
+							cmapi.CertificateRequestRevisionAnnotationKey: "1",
+						}),
+					),
+				},
+			},
+		},
+		"if an identical request not created for a Certificate has been issued, call sign": {
+			certificateRequest: baseCR.DeepCopy(),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return nil, nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer, baseCR.DeepCopy(), issuedCR},
+			},
+		},
 		"if calling sign returns a response with an expired RSA certificate then set condition Ready": {
 			certificateRequest: baseCR.DeepCopy(),
 			issuerImpl: &fake.Issuer{
@@ -639,9 +946,8 @@ func TestSync(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCertificate(certRSAPEMExpired),
@@ -653,7 +959,7 @@ func TestSync(t *testing.T) {
 								LastTransitionTime: &nowMetaTime,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -672,9 +978,8 @@ func TestSync(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCertificate(certECPEM),
@@ -686,7 +991,7 @@ func TestSync(t *testing.T) {
 								LastTransitionTime: &nowMetaTime,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -705,9 +1010,8 @@ func TestSync(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCertificate(certECPEMExpired),
@@ -719,7 +1023,7 @@ func TestSync(t *testing.T) {
 								LastTransitionTime: &nowMetaTime,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
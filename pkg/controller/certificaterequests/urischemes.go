@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"fmt"
+	"strings"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// checkURISchemes returns an error if the CertificateRequest contains a URI
+// SAN whose scheme is not one of the issuer's allowedURISchemes, or a SPIFFE
+// ID which is malformed. Requests are not checked if the issuer allows URI
+// SANs of any scheme, or if their CSR cannot be decoded, which is reported
+// by the issuer itself.
+func checkURISchemes(cr *cmapi.CertificateRequest, issuer cmapi.GenericIssuer) error {
+	allowed := issuer.GetSpec().AllowedURISchemes
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		return nil
+	}
+
+	for _, uri := range csr.URIs {
+		if !uriSchemeAllowed(uri.Scheme, allowed) {
+			return fmt.Errorf("URI SAN %q has scheme %q, but the issuer only allows the schemes: %s",
+				uri, uri.Scheme, strings.Join(allowed, ", "))
+		}
+		if pki.IsSPIFFEID(uri) {
+			if err := pki.ValidateSPIFFEID(uri.String()); err != nil {
+				return fmt.Errorf("URI SAN %q is not a valid SPIFFE ID: %w", uri, err)
+			}
+		}
+	}
+	return nil
+}
+
+// uriSchemeAllowed returns true if scheme is one of allowed. URI schemes are
+// case-insensitive.
+func uriSchemeAllowed(scheme string, allowed []string) bool {
+	for _, a := range allowed {
+		if strings.EqualFold(scheme, a) {
+			return true
+		}
+	}
+	return false
+}
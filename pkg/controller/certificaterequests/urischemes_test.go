@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"crypto/x509"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCheckURISchemes(t *testing.T) {
+	csrWithURIs := func(uris ...string) []byte {
+		csr, _, err := gen.CSR(x509.ECDSA, gen.SetCSRURIsFromStrings(uris...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return csr
+	}
+
+	tests := map[string]struct {
+		allowed []string
+		request []byte
+		wantErr string
+	}{
+		"any scheme is allowed if the issuer does not restrict schemes": {
+			request: csrWithURIs("https://example.com", "spiffe://Cluster.local"),
+		},
+		"allowed scheme": {
+			allowed: []string{"spiffe"},
+			request: csrWithURIs("spiffe://cluster.local/ns/default/sa/foo"),
+		},
+		"schemes are case-insensitive": {
+			allowed: []string{"URN"},
+			request: csrWithURIs("urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6"),
+		},
+		"request without URI SANs": {
+			allowed: []string{"spiffe"},
+			request: csrWithURIs(),
+		},
+		"scheme which is not allowed": {
+			allowed: []string{"spiffe"},
+			request: csrWithURIs("spiffe://cluster.local/ns/default/sa/foo", "https://example.com"),
+			wantErr: `URI SAN "https://example.com" has scheme "https", but the issuer only allows the schemes: spiffe`,
+		},
+		"malformed SPIFFE ID": {
+			allowed: []string{"spiffe"},
+			request: csrWithURIs("spiffe://cluster.local/ns/default/"),
+			wantErr: `URI SAN "spiffe://cluster.local/ns/default/" is not a valid SPIFFE ID: SPIFFE ID path must not contain empty segments or a trailing slash`,
+		},
+		"requests which cannot be decoded are left to the issuer": {
+			allowed: []string{"spiffe"},
+			request: []byte("not a csr"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}))
+			issuer.Spec.AllowedURISchemes = test.allowed
+			cr := gen.CertificateRequest("test", gen.SetCertificateRequestCSR(test.request))
+
+			err := checkURISchemes(cr, issuer)
+			switch {
+			case test.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case test.wantErr != "" && (err == nil || err.Error() != test.wantErr):
+				t.Errorf("expected error %q, got: %v", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/x509"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	csrCacheSize = 4096
+	csrCacheTTL  = time.Hour
+)
+
+// csrCache holds the CSRs parsed from, and certificate templates generated
+// for, CertificateRequests. Pending requests are reconciled repeatedly and
+// the spec of a request cannot be changed, so there is no need to parse the
+// same PEM each time.
+var csrCache = cache.NewLRUExpireCache(csrCacheSize)
+
+type csrCacheKey struct {
+	uid        types.UID
+	generation int64
+	template   bool
+}
+
+// ParseCSR returns the CSR in the spec of the CertificateRequest. The
+// returned CSR is shared and must not be modified.
+func ParseCSR(cr *cmapi.CertificateRequest) (*x509.CertificateRequest, error) {
+	key, ok := csrKey(cr, false)
+	if ok {
+		if csr, ok := csrCache.Get(key); ok {
+			return csr.(*x509.CertificateRequest), nil
+		}
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		csrCache.Add(key, csr, csrCacheTTL)
+	}
+	return csr, nil
+}
+
+// GenerateTemplate returns a certificate template for the CertificateRequest,
+// as returned by pki.GenerateTemplateFromCertificateRequest. The template is
+// a copy which may be modified, is valid from now and has a new serial
+// number.
+func GenerateTemplate(cr *cmapi.CertificateRequest) (*x509.Certificate, error) {
+	key, ok := csrKey(cr, true)
+	if ok {
+		if template, ok := csrCache.Get(key); ok {
+			copy := *template.(*x509.Certificate)
+			// Each attempt to sign the request must use a new serial number,
+			// in case a previous attempt succeeded without being recorded.
+			serialNumber, err := pki.GenerateSerialNumber()
+			if err != nil {
+				return nil, err
+			}
+			copy.SerialNumber = serialNumber
+			duration := copy.NotAfter.Sub(copy.NotBefore)
+			copy.NotBefore = time.Now()
+			copy.NotAfter = copy.NotBefore.Add(duration)
+			return &copy, nil
+		}
+	}
+
+	template, err := pki.GenerateTemplateFromCertificateRequest(cr)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		copy := *template
+		csrCache.Add(key, &copy, csrCacheTTL)
+	}
+	return template, nil
+}
+
+// csrKey returns the cache key of the CertificateRequest, or false if it
+// cannot be cached since it has not been created yet.
+func csrKey(cr *cmapi.CertificateRequest, template bool) (csrCacheKey, bool) {
+	if cr.UID == "" {
+		return csrCacheKey{}, false
+	}
+	return csrCacheKey{uid: cr.UID, generation: cr.Generation, template: template}, true
+}
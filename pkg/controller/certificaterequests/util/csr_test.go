@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCSRCache(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	csrPEM := testcrypto.MustGenerateCSRImpl(t, pk, gen.Certificate("test", gen.SetCertificateDNSNames("example.com")))
+	cr := gen.CertificateRequest("test", gen.SetCertificateRequestCSR(csrPEM))
+	cr.UID = "test-uid"
+	cr.Generation = 1
+
+	csr1, err := ParseCSR(cr)
+	require.NoError(t, err)
+	csr2, err := ParseCSR(cr)
+	require.NoError(t, err)
+	assert.Same(t, csr1, csr2, "expected the parsed CSR to be cached")
+
+	template1, err := GenerateTemplate(cr)
+	require.NoError(t, err)
+	template1.OCSPServer = []string{"http://ocsp.example.com"}
+	template2, err := GenerateTemplate(cr)
+	require.NoError(t, err)
+	assert.NotSame(t, template1, template2)
+	assert.Equal(t, []string{"example.com"}, template2.DNSNames)
+	assert.Empty(t, template2.OCSPServer, "expected changes to a returned template not to be cached")
+	assert.NotEqual(t, template1.SerialNumber, template2.SerialNumber, "expected each template to have a new serial number")
+	assert.False(t, template2.NotBefore.Before(template1.NotBefore))
+	assert.Equal(t, template1.NotAfter.Sub(template1.NotBefore), template2.NotAfter.Sub(template2.NotBefore))
+
+	// Requests which have not been created are never cached.
+	cr.UID = ""
+	csr3, err := ParseCSR(cr)
+	require.NoError(t, err)
+	assert.NotSame(t, csr1, csr3)
+}
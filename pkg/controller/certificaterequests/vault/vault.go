@@ -44,6 +44,7 @@ type Vault struct {
 	secretsLister corelisters.SecretLister
 	reporter      *crutil.Reporter
 
+	createTokenFn      func(ns string) vaultinternal.CreateToken
 	vaultClientBuilder vaultinternal.ClientBuilder
 }
 
@@ -60,8 +61,9 @@ func init() {
 func NewVault(ctx *controllerpkg.Context) certificaterequests.Issuer {
 	return &Vault{
 		issuerOptions:      ctx.IssuerOptions,
-		secretsLister:      ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		secretsLister:      controllerpkg.NewSecretGetter(ctx.KubeSharedInformerFactory),
 		reporter:           crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		createTokenFn:      vaultinternal.CreateTokenFromClient(ctx.Client),
 		vaultClientBuilder: vaultinternal.New,
 	}
 }
@@ -74,7 +76,7 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
-	client, err := v.vaultClientBuilder(resourceNamespace, v.secretsLister, issuerObj)
+	client, err := v.vaultClientBuilder(resourceNamespace, v.createTokenFn, v.secretsLister, issuerObj)
 	if k8sErrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 
@@ -32,7 +32,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	corelisters "k8s.io/client-go/listers/core/v1"
-	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
 	internalvault "github.com/cert-manager/cert-manager/internal/vault"
@@ -176,9 +175,8 @@ func TestSign(t *testing.T) {
 				CertManagerObjects: []runtime.Object{baseCRDenied.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedEvents:     []string{},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCRDenied,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -190,7 +188,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -203,9 +201,8 @@ func TestSign(t *testing.T) {
 					"Normal VaultInitError Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -216,7 +213,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -240,9 +237,8 @@ func TestSign(t *testing.T) {
 					`Normal SecretMissing Required secret resource not found: secret "non-existing-secret" not found`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -253,7 +249,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -280,9 +276,8 @@ func TestSign(t *testing.T) {
 					`Normal SecretMissing Required secret resource not found: secret "non-existing-secret" not found`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -293,7 +288,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -309,9 +304,8 @@ func TestSign(t *testing.T) {
 					"Normal IssuerNotReady Referenced issuer does not have a Ready status condition",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -322,7 +316,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -346,9 +340,8 @@ func TestSign(t *testing.T) {
 					"Warning SigningError Vault failed to sign certificate: failed to sign",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -360,7 +353,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 			fakeVault: fakevault.New().WithSign(nil, nil, errors.New("failed to sign")),
@@ -388,9 +381,8 @@ func TestSign(t *testing.T) {
 					`Warning SigningError Vault failed to sign certificate: failed to sign`,
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -402,7 +394,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 			fakeVault: fakevault.New().WithSign(nil, nil, errors.New("failed to sign")),
@@ -427,9 +419,8 @@ func TestSign(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCertificate(rsaPEMCert),
@@ -442,7 +433,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 			fakeVault: fakevault.New().WithSign(rsaPEMCert, rsaPEMCert, nil),
@@ -470,9 +461,8 @@ func TestSign(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCertificate(rsaPEMCert),
@@ -485,7 +475,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 			fakeVault: fakevault.New().WithSign(rsaPEMCert, rsaPEMCert, nil),
@@ -518,7 +508,7 @@ func runTest(t *testing.T, test testT) {
 	vault := NewVault(test.builder.Context).(*Vault)
 
 	if test.fakeVault != nil {
-		vault.vaultClientBuilder = func(ns string, sl corelisters.SecretLister,
+		vault.vaultClientBuilder = func(ns string, _ func(ns string) internalvault.CreateToken, sl corelisters.SecretLister,
 			iss cmapi.GenericIssuer) (internalvault.Interface, error) {
 			return test.fakeVault.New(ns, sl, iss)
 		}
@@ -281,9 +281,8 @@ func TestSign(t *testing.T) {
 				CertManagerObjects: []runtime.Object{baseCRDenied.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedEvents:     []string{},
 				ExpectedActions: []controllertest.Action{
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCRDenied,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -295,7 +294,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -307,9 +306,8 @@ func TestSign(t *testing.T) {
 					`Normal SecretMissing Required secret resource not found: secret "test-tpp-secret" not found`,
 				},
 				ExpectedActions: []controllertest.Action{
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(tppCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -320,7 +318,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -332,9 +330,8 @@ func TestSign(t *testing.T) {
 					`Normal VenafiInitError Failed to initialise venafi client for signing: this is a network error`,
 				},
 				ExpectedActions: []controllertest.Action{
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(tppCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -345,7 +342,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
@@ -359,9 +356,8 @@ func TestSign(t *testing.T) {
 					`Normal SecretMissing Required secret resource not found: secret "test-cloud-secret" not found`,
 				},
 				ExpectedActions: []controllertest.Action{
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -372,7 +368,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -384,9 +380,8 @@ func TestSign(t *testing.T) {
 					`Normal VenafiInitError Failed to initialise venafi client for signing: this is a network error`,
 				},
 				ExpectedActions: []controllertest.Action{
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -397,7 +392,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
@@ -415,9 +410,8 @@ func TestSign(t *testing.T) {
 					"Normal IssuerNotReady Referenced issuer does not have a Ready status condition",
 				},
 				ExpectedActions: []controllertest.Action{
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -428,7 +422,7 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -457,9 +451,8 @@ func TestSign(t *testing.T) {
 							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
 						),
 					)),
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -471,7 +464,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
 						),
-					)),
+					),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
@@ -503,9 +496,8 @@ func TestSign(t *testing.T) {
 							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
 						),
 					)),
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -517,7 +509,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
 						),
-					)),
+					),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
@@ -533,9 +525,8 @@ func TestSign(t *testing.T) {
 					"Warning RequestError Failed to request venafi certificate: this is an error",
 				},
 				ExpectedActions: []controllertest.Action{
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(tppCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -547,7 +538,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 			fakeSecretLister:   failGetSecretLister,
@@ -564,9 +555,8 @@ func TestSign(t *testing.T) {
 					"Warning RequestError Failed to request venafi certificate: this is an error",
 				},
 				ExpectedActions: []controllertest.Action{
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -578,7 +568,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 			fakeSecretLister:   failGetSecretLister,
@@ -611,9 +601,8 @@ func TestSign(t *testing.T) {
 							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
 						),
 					)),
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(tppCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -627,7 +616,7 @@ func TestSign(t *testing.T) {
 							gen.SetCertificateRequestCA(rootPEM),
 							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
 						),
-					)),
+					),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
@@ -658,9 +647,8 @@ func TestSign(t *testing.T) {
 							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
 						),
 					)),
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -674,7 +662,7 @@ func TestSign(t *testing.T) {
 							gen.SetCertificateRequestCA(rootPEM),
 							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
 						),
-					)),
+					),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
@@ -704,9 +692,8 @@ func TestSign(t *testing.T) {
 							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
 						),
 					)),
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(tppCRWithCustomFields,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -720,7 +707,7 @@ func TestSign(t *testing.T) {
 							gen.SetCertificateRequestCA(rootPEM),
 							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
 						),
-					)),
+					),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
@@ -735,9 +722,8 @@ func TestSign(t *testing.T) {
 					`Warning CustomFieldsError Failed to parse "venafi.cert-manager.io/custom-fields" annotation: invalid character 'c' looking for beginning of value`,
 				},
 				ExpectedActions: []controllertest.Action{
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(tppCRWithInvalidCustomFields,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -749,7 +735,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 			fakeSecretLister:   failGetSecretLister,
@@ -765,9 +751,8 @@ func TestSign(t *testing.T) {
 					`Warning CustomFieldsError certificate request contains an invalid Venafi custom fields type: "Bool": certificate request contains an invalid Venafi custom fields type: "Bool"`,
 				},
 				ExpectedActions: []controllertest.Action{
-					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+					controllertest.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(tppCRWithInvalidCustomFieldType,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
@@ -779,7 +764,7 @@ func TestSign(t *testing.T) {
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
-					)),
+					),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
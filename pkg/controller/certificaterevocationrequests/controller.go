@@ -0,0 +1,589 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterevocationrequests
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalvault "github.com/cert-manager/cert-manager/internal/vault"
+	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	ControllerName = "certificaterevocationrequests"
+
+	reasonRevoked                 = "Revoked"
+	reasonIssuerNotFound          = "IssuerNotFound"
+	reasonUnsupportedIssuer       = "UnsupportedIssuer"
+	reasonClusterIssuerNotAllowed = "ClusterIssuerNotAllowed"
+	reasonInvalidSerialNumber     = "InvalidSerialNumber"
+	reasonCertificateNotFound     = "CertificateNotFound"
+	reasonCertificateNotIssued    = "CertificateNotIssued"
+	reasonCertificateMismatch     = "CertificateMismatch"
+	reasonRevocationFailed        = "RevocationFailed"
+	reasonReissuing               = "Reissuing"
+
+	// acmeAlreadyRevoked is the ACME problem type returned when revoking a
+	// certificate which has already been revoked.
+	acmeAlreadyRevoked = "urn:ietf:params:acme:error:alreadyRevoked"
+)
+
+var acmeRevocationReasons = map[cmapi.RevocationReason]acme.CRLReasonCode{
+	"":                                         acme.CRLReasonUnspecified,
+	cmapi.RevocationReasonUnspecified:          acme.CRLReasonUnspecified,
+	cmapi.RevocationReasonKeyCompromise:        acme.CRLReasonKeyCompromise,
+	cmapi.RevocationReasonCACompromise:         acme.CRLReasonCACompromise,
+	cmapi.RevocationReasonAffiliationChanged:   acme.CRLReasonAffiliationChanged,
+	cmapi.RevocationReasonSuperseded:           acme.CRLReasonSuperseded,
+	cmapi.RevocationReasonCessationOfOperation: acme.CRLReasonCessationOfOperation,
+	cmapi.RevocationReasonPrivilegeWithdrawn:   acme.CRLReasonPrivilegeWithdrawn,
+}
+
+// This controller marks the certificates referenced by
+// CertificateRevocationRequests as revoked. Certificates issued by a CA or
+// SelfSigned issuer are revoked by the OCSP responder, which only reports
+// certificates as revoked if the Revoked condition of their
+// CertificateRevocationRequest is True. Certificates issued by an ACME or
+// Vault issuer are revoked by asking the CA to revoke them.
+// If a CertificateRevocationRequest references a Certificate, its serial
+// number and issuer are taken from the Certificate, and the Certificate is
+// re-issued with a new private key once its certificate has been revoked.
+type controller struct {
+	log               logr.Logger
+	crrLister         cmlisters.CertificateRevocationRequestLister
+	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
+	helper            issuer.Helper
+	client            cmclient.Interface
+	recorder          record.EventRecorder
+	queue             workqueue.RateLimitingInterface
+	clock             clock.Clock
+	accountRegistry   accounts.Registry
+	issuerOptions     controllerpkg.IssuerOptions
+
+	// The following are used for testing purposes.
+	// createTokenFn requests ServiceAccount tokens for Vault issuers using
+	// Kubernetes auth with a serviceAccountRef.
+	createTokenFn       func(ns string) internalvault.CreateToken
+	vaultRevokerBuilder func(string, func(ns string) internalvault.CreateToken, corelisters.SecretLister, cmapi.GenericIssuer) (internalvault.Revoker, error)
+}
+
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	issuerOptions controllerpkg.IssuerOptions,
+	accountRegistry accounts.Registry,
+	namespace string,
+	rateLimiter workqueue.RateLimiter,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ControllerName)
+
+	// obtain references to all the informers used by this controller
+	crrInformer := cmFactory.Certmanager().V1().CertificateRevocationRequests()
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	secretsInformer := controllerpkg.SecretsMetadataInformer(factory)
+
+	c := &controller{
+		log:               log,
+		crrLister:         crrInformer.Lister(),
+		certificateLister: certificateInformer.Lister(),
+		secretLister:      controllerpkg.NewSecretGetter(factory),
+		client:            client,
+		recorder:          recorder,
+		queue:             queue,
+		clock:             clock,
+		accountRegistry:   accountRegistry,
+		issuerOptions:     issuerOptions,
+
+		vaultRevokerBuilder: internalvault.NewRevoker,
+	}
+
+	crrInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// Revocations which were waiting for their issuer are processed again
+	// once it is created or changed.
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
+	// Revocations which were waiting for their Certificate to be issued are
+	// processed again once it is.
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleCertificate})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		crrInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		secretsInformer.HasSynced,
+	}
+
+	// ClusterIssuers are only watched if we are not scoped to a single
+	// namespace.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	c.helper = issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister)
+
+	return c, queue, mustSync
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crr, err := c.crrLister.CertificateRevocationRequests(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificaterevocationrequest not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if crr.Spec.CertificateRef != nil && crr.Spec.SerialNumber == "" {
+		return c.resolveCertificateRef(ctx, crr)
+	}
+
+	status, reason, message, err := c.check(ctx, crr)
+	if err != nil {
+		return err
+	}
+
+	updated := crr.DeepCopy()
+	apiutil.SetCertificateRevocationRequestCondition(updated, cmapi.CertificateRevocationRequestConditionRevoked, status, reason, message)
+	if status == cmmeta.ConditionTrue && updated.Status.RevocationTime == nil {
+		now := metav1.NewTime(c.clock.Now())
+		updated.Status.RevocationTime = &now
+	}
+
+	// The Certificate is re-issued once its certificate has been revoked,
+	// or straight away if its issuer does not support revocation.
+	if crr.Spec.CertificateRef != nil && crr.Status.ReissuanceTime == nil &&
+		(status == cmmeta.ConditionTrue || reason == reasonUnsupportedIssuer) {
+		reissued, err := c.reissue(ctx, crr)
+		if err != nil {
+			return err
+		}
+		if reissued {
+			now := metav1.NewTime(c.clock.Now())
+			updated.Status.ReissuanceTime = &now
+		}
+	}
+
+	if err := c.updateStatus(ctx, crr, updated); err != nil {
+		return err
+	}
+
+	// Failed requests to the CA are retried with back-off.
+	if reason == reasonRevocationFailed {
+		return errors.New(message)
+	}
+
+	return nil
+}
+
+// updateStatus updates the status of a CertificateRevocationRequest if it
+// has changed, and records an event when its Revoked condition changes or
+// the re-issuance of its Certificate is triggered.
+func (c *controller) updateStatus(ctx context.Context, crr, updated *cmapi.CertificateRevocationRequest) error {
+	if reflect.DeepEqual(crr.Status, updated.Status) {
+		return nil
+	}
+
+	if _, err := c.client.CertmanagerV1().CertificateRevocationRequests(updated.Namespace).UpdateStatus(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	cond := apiutil.GetCertificateRevocationRequestCondition(updated, cmapi.CertificateRevocationRequestConditionRevoked)
+	oldCond := apiutil.GetCertificateRevocationRequestCondition(crr, cmapi.CertificateRevocationRequestConditionRevoked)
+	if oldCond == nil || oldCond.Status != cond.Status || oldCond.Reason != cond.Reason || oldCond.Message != cond.Message {
+		eventType := corev1.EventTypeNormal
+		if cond.Status != cmmeta.ConditionTrue {
+			eventType = corev1.EventTypeWarning
+		}
+		c.recorder.Event(updated, eventType, cond.Reason, cond.Message)
+	}
+	if crr.Status.ReissuanceTime == nil && updated.Status.ReissuanceTime != nil {
+		c.recorder.Eventf(updated, corev1.EventTypeNormal, reasonReissuing, "Triggered the re-issuance of Certificate %q with a new private key", updated.Spec.CertificateRef.Name)
+	}
+
+	return nil
+}
+
+// resolveCertificateRef fills in the issuerRef and serialNumber of a
+// CertificateRevocationRequest from the Certificate it references and the
+// certificate currently stored in its Secret. The CertificateRevocationRequest
+// is processed again once it has been updated.
+func (c *controller) resolveCertificateRef(ctx context.Context, crr *cmapi.CertificateRevocationRequest) error {
+	crt, cert, reason, message, err := c.certificateFor(crr)
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		reason, message = unsupportedCertificateIssuer(crt)
+	}
+	if reason != "" {
+		updated := crr.DeepCopy()
+		apiutil.SetCertificateRevocationRequestCondition(updated, cmapi.CertificateRevocationRequestConditionRevoked, cmmeta.ConditionFalse, reason, message)
+		return c.updateStatus(ctx, crr, updated)
+	}
+
+	updated := crr.DeepCopy()
+	updated.Spec.IssuerRef = crt.Spec.IssuerRef
+	updated.Spec.SerialNumber = formatSerialNumber(cert.SerialNumber)
+	_, err = c.client.CertmanagerV1().CertificateRevocationRequests(updated.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// certificateFor returns the Certificate referenced by a
+// CertificateRevocationRequest, along with the certificate currently stored
+// in its Secret. If either does not exist, the reason and message of the
+// Revoked condition are returned instead.
+func (c *controller) certificateFor(crr *cmapi.CertificateRevocationRequest) (*cmapi.Certificate, *x509.Certificate, string, string, error) {
+	name := crr.Spec.CertificateRef.Name
+	crt, err := c.certificateLister.Certificates(crr.Namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		// The CertificateRevocationRequest is re-queued once the
+		// Certificate is created.
+		return nil, nil, reasonCertificateNotFound, fmt.Sprintf("Certificate %q does not exist", name), nil
+	}
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+
+	secret, err := c.secretLister.Secrets(crr.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil, reasonCertificateNotIssued, fmt.Sprintf("Certificate %q has not been issued yet", name), nil
+	}
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, nil, reasonCertificateNotIssued, fmt.Sprintf("Secret %q of Certificate %q does not hold a valid certificate: %v", secret.Name, name, err), nil
+	}
+
+	return crt, cert, "", "", nil
+}
+
+// unsupportedCertificateIssuer returns the reason and message of the Revoked
+// condition if the Certificate is not issued by a cert-manager.io Issuer or
+// ClusterIssuer, and empty strings otherwise.
+func unsupportedCertificateIssuer(crt *cmapi.Certificate) (string, string) {
+	ref := crt.Spec.IssuerRef
+	if (ref.Group == "" || ref.Group == cmapi.SchemeGroupVersion.Group) &&
+		(ref.Kind == "" || ref.Kind == cmapi.IssuerKind || ref.Kind == cmapi.ClusterIssuerKind) {
+		return "", ""
+	}
+	return reasonUnsupportedIssuer, fmt.Sprintf("Certificate %q is not issued by an Issuer or ClusterIssuer, so its certificate cannot be revoked by cert-manager", crt.Name)
+}
+
+// check returns the Revoked condition of a CertificateRevocationRequest.
+// Certificates issued by a CA or SelfSigned issuer are revoked by the OCSP
+// responder, which needs the key which signed them. Certificates issued by an
+// ACME or Vault issuer are revoked by the CA, which is only asked once.
+func (c *controller) check(ctx context.Context, crr *cmapi.CertificateRevocationRequest) (cmmeta.ConditionStatus, string, string, error) {
+	serialNumber, err := pki.ParseSerialNumber(crr.Spec.SerialNumber)
+	if err != nil {
+		return cmmeta.ConditionFalse, reasonInvalidSerialNumber, err.Error(), nil
+	}
+
+	// Any namespace may reference a ClusterIssuer, so revoking the
+	// certificates it issued by serial number is reserved to the cluster
+	// resource namespace. Other namespaces can only revoke the certificates
+	// of their own Certificates, and only if the CA revokes them, since the
+	// OCSP responder only reports revocations from the cluster resource
+	// namespace.
+	clusterIssuerNotAllowed := crr.Spec.IssuerRef.Kind == cmapi.ClusterIssuerKind && crr.Namespace != c.issuerOptions.ClusterResourceNamespace
+	notAllowedMessage := fmt.Sprintf("Certificates issued by a ClusterIssuer can only be revoked from the cluster resource namespace %q", c.issuerOptions.ClusterResourceNamespace)
+	if clusterIssuerNotAllowed && crr.Spec.CertificateRef == nil {
+		return cmmeta.ConditionFalse, reasonClusterIssuerNotAllowed, notAllowedMessage, nil
+	}
+
+	genericIssuer, err := c.helper.GetGenericIssuer(crr.Spec.IssuerRef, crr.Namespace)
+	if apierrors.IsNotFound(err) {
+		// The CertificateRevocationRequest is re-queued once the issuer is
+		// created.
+		return cmmeta.ConditionFalse, reasonIssuerNotFound, err.Error(), nil
+	}
+	if err != nil {
+		return "", "", "", err
+	}
+
+	spec := genericIssuer.GetSpec()
+	switch {
+	case spec.CA != nil, spec.SelfSigned != nil:
+		if clusterIssuerNotAllowed {
+			return cmmeta.ConditionFalse, reasonClusterIssuerNotAllowed, notAllowedMessage, nil
+		}
+		return cmmeta.ConditionTrue, reasonRevoked, "The certificate is reported as revoked by the OCSP responder", nil
+	case spec.ACME != nil, spec.Vault != nil:
+		if crr.Status.RevocationTime != nil {
+			return cmmeta.ConditionTrue, reasonRevoked, "The certificate has been revoked by the CA", nil
+		}
+		return c.revokeUpstream(ctx, crr, genericIssuer, serialNumber)
+	default:
+		kind := crr.Spec.IssuerRef.Kind
+		if kind == "" {
+			kind = cmapi.IssuerKind
+		}
+		return cmmeta.ConditionFalse, reasonUnsupportedIssuer,
+			fmt.Sprintf("%s %q is not a CA, SelfSigned, ACME or Vault issuer, so its certificates cannot be revoked by cert-manager", kind, genericIssuer.GetObjectMeta().Name), nil
+	}
+}
+
+// revokeUpstream asks the ACME server or Vault which issued a certificate to
+// revoke it. If the CertificateRevocationRequest references a Certificate,
+// the certificate must be the one stored in its Secret, which shows that it
+// was issued for the namespace of the CertificateRevocationRequest.
+func (c *controller) revokeUpstream(ctx context.Context, crr *cmapi.CertificateRevocationRequest, iss cmapi.GenericIssuer, serialNumber *big.Int) (cmmeta.ConditionStatus, string, string, error) {
+	var der []byte
+	if crr.Spec.CertificateRef != nil {
+		_, cert, reason, message, err := c.certificateFor(crr)
+		if err != nil {
+			return "", "", "", err
+		}
+		if reason != "" {
+			return cmmeta.ConditionFalse, reason, message, nil
+		}
+		if cert.SerialNumber.Cmp(serialNumber) != 0 {
+			return cmmeta.ConditionFalse, reasonCertificateMismatch,
+				fmt.Sprintf("Certificate %q no longer holds the certificate with serial number %s", crr.Spec.CertificateRef.Name, crr.Spec.SerialNumber), nil
+		}
+		der = cert.Raw
+	}
+
+	var err error
+	if iss.GetSpec().ACME != nil {
+		// ACME servers need the whole certificate to revoke it.
+		if der == nil {
+			return cmmeta.ConditionFalse, reasonUnsupportedIssuer,
+				"Certificates issued by an ACME issuer can only be revoked by referencing their Certificate", nil
+		}
+		err = c.revokeACME(ctx, crr, iss, der)
+	} else {
+		err = c.revokeVault(iss, serialNumber)
+	}
+	if err != nil {
+		return cmmeta.ConditionFalse, reasonRevocationFailed, fmt.Sprintf("Failed to revoke the certificate: %v", err), nil
+	}
+
+	return cmmeta.ConditionTrue, reasonRevoked, "The certificate has been revoked by the CA", nil
+}
+
+func (c *controller) revokeACME(ctx context.Context, crr *cmapi.CertificateRevocationRequest, iss cmapi.GenericIssuer, der []byte) error {
+	cl, err := c.accountRegistry.GetClient(accounts.ClientUID(iss, crr.Namespace))
+	if err != nil {
+		return err
+	}
+
+	err = cl.RevokeCert(ctx, nil, der, acmeRevocationReasons[crr.Spec.Reason])
+	var acmeErr *acme.Error
+	if errors.As(err, &acmeErr) && acmeErr.ProblemType == acmeAlreadyRevoked {
+		return nil
+	}
+	return err
+}
+
+func (c *controller) revokeVault(iss cmapi.GenericIssuer, serialNumber *big.Int) error {
+	revoker, err := c.vaultRevokerBuilder(c.issuerOptions.ResourceNamespace(iss), c.createTokenFn, c.secretLister, iss)
+	if err != nil {
+		return err
+	}
+	return revoker.RevokeCertificate(serialNumber)
+}
+
+// reissue triggers the re-issuance of the Certificate referenced by a
+// CertificateRevocationRequest, unless the revoked certificate has already
+// been replaced in its Secret. It returns true if the re-issuance has been
+// triggered.
+func (c *controller) reissue(ctx context.Context, crr *cmapi.CertificateRevocationRequest) (bool, error) {
+	crt, cert, reason, _, err := c.certificateFor(crr)
+	if err != nil || reason != "" {
+		return false, err
+	}
+	serialNumber, err := pki.ParseSerialNumber(crr.Spec.SerialNumber)
+	if err != nil || cert.SerialNumber.Cmp(serialNumber) != 0 {
+		return false, nil
+	}
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, cmapi.CertificateReasonRevoked,
+		fmt.Sprintf("Re-issuing the certificate with a new private key as it has been revoked by CertificateRevocationRequest %q", crr.Name))
+	if _, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// formatSerialNumber formats a serial number as colon separated hexadecimal
+// bytes, as printed by `kubectl cert-manager inspect`.
+func formatSerialNumber(serialNumber *big.Int) string {
+	b := serialNumber.Bytes()
+	hex := make([]string, len(b))
+	for i := range b {
+		hex[i] = fmt.Sprintf("%02x", b[i])
+	}
+	return strings.Join(hex, ":")
+}
+
+// handleGenericIssuer re-queues the CertificateRevocationRequests which
+// reference an Issuer or ClusterIssuer when it changes.
+func (c *controller) handleGenericIssuer(obj interface{}) {
+	log := c.log.WithName("handleGenericIssuer")
+
+	iss, ok := obj.(cmapi.GenericIssuer)
+	if !ok {
+		log.Error(nil, "object does not implement GenericIssuer")
+		return
+	}
+
+	log = logf.WithResource(log, iss)
+	crrs, err := c.crrLister.List(labels.Everything())
+	if err != nil {
+		log.Error(err, "error listing certificaterevocationrequests")
+		return
+	}
+	for _, crr := range crrs {
+		ref := crr.Spec.IssuerRef
+		if ref.Name != iss.GetObjectMeta().Name {
+			continue
+		}
+		if _, isClusterIssuer := iss.(*cmapi.ClusterIssuer); isClusterIssuer {
+			if ref.Kind != cmapi.ClusterIssuerKind {
+				continue
+			}
+		} else if ref.Kind == cmapi.ClusterIssuerKind || crr.Namespace != iss.GetObjectMeta().Namespace {
+			continue
+		}
+		key, err := controllerpkg.KeyFunc(crr)
+		if err != nil {
+			logf.WithRelatedResource(log, crr).Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// handleCertificate re-queues the CertificateRevocationRequests which
+// reference a Certificate when it changes.
+func (c *controller) handleCertificate(obj interface{}) {
+	log := c.log.WithName("handleCertificate")
+
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok {
+		log.Error(nil, "object is not a Certificate")
+		return
+	}
+
+	log = logf.WithResource(log, crt)
+	crrs, err := c.crrLister.CertificateRevocationRequests(crt.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Error(err, "error listing certificaterevocationrequests")
+		return
+	}
+	for _, crr := range crrs {
+		if crr.Spec.CertificateRef == nil || crr.Spec.CertificateRef.Name != crt.Name {
+			continue
+		}
+		key, err := controllerpkg.KeyFunc(crr)
+		if err != nil {
+			logf.WithRelatedResource(log, crr).Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.IssuerOptions,
+		ctx.ACMEOptions.AccountRegistry,
+		ctx.Namespace,
+		ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30),
+	)
+	ctrl.createTokenFn = internalvault.CreateTokenFromClient(ctx.Client)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,461 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterevocationrequests
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	internalvault "github.com/cert-manager/cert-manager/internal/vault"
+	accountstest "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+type fakeRevoker struct {
+	revoked *big.Int
+	err     error
+}
+
+func (f *fakeRevoker) RevokeCertificate(serialNumber *big.Int) error {
+	f.revoked = serialNumber
+	return f.err
+}
+
+func mustCreateCertificate(t *testing.T, serialNumber int64) *x509.Certificate {
+	pk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serialNumber),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(nil, template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func Test_controller_ProcessItem(t *testing.T) {
+	fixedNow := metav1.NewTime(time.Now())
+	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+	earlier := metav1.NewTime(fixedNow.Add(-time.Hour))
+
+	caIssuer := gen.Issuer("ca-issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}))
+	selfSignedClusterIssuer := gen.ClusterIssuer("selfsigned", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}))
+	acmeIssuer := gen.Issuer("acme-issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerACME(cmacme.ACMEIssuer{}))
+	acmeClusterIssuer := gen.ClusterIssuer("acme", gen.SetIssuerACME(cmacme.ACMEIssuer{}))
+	vaultIssuer := gen.Issuer("vault-issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerVault(cmapi.VaultIssuer{Path: "pki/sign/example"}))
+	venafiIssuer := gen.Issuer("venafi-issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerVenafi(cmapi.VenafiIssuer{}))
+
+	cert := mustCreateCertificate(t, 0x1a2b3c)
+	certPEM, err := pki.EncodeX509(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renewedCertPEM, err := pki.EncodeX509(mustCreateCertificate(t, 0x4d5e6f))
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate := func(ref cmmeta.ObjectReference) *cmapi.Certificate {
+		return gen.Certificate("example",
+			gen.SetCertificateNamespace("testns"),
+			gen.SetCertificateSecretName("example-tls"),
+			gen.SetCertificateIssuer(ref),
+		)
+	}
+	secret := func(certPEM []byte) *corev1.Secret {
+		return gen.Secret("example-tls", gen.SetSecretNamespace("testns"), gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: certPEM}))
+	}
+
+	crr := func(namespace string, ref cmmeta.ObjectReference, status cmapi.CertificateRevocationRequestStatus) *cmapi.CertificateRevocationRequest {
+		return &cmapi.CertificateRevocationRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "revoke"},
+			Spec: cmapi.CertificateRevocationRequestSpec{
+				IssuerRef:    ref,
+				SerialNumber: "1a:2b:3c",
+			},
+			Status: status,
+		}
+	}
+	crrForCertificate := func(ref cmmeta.ObjectReference, status cmapi.CertificateRevocationRequestStatus) *cmapi.CertificateRevocationRequest {
+		crr := crr("testns", ref, status)
+		crr.Spec.CertificateRef = &cmmeta.LocalObjectReference{Name: "example"}
+		// The serial number is filled in along with the issuer.
+		if ref.Name == "" {
+			crr.Spec.SerialNumber = ""
+		}
+		return crr
+	}
+	revoked := func(status cmmeta.ConditionStatus, reason, message string, revocationTime *metav1.Time) cmapi.CertificateRevocationRequestStatus {
+		return cmapi.CertificateRevocationRequestStatus{
+			Conditions: []cmapi.CertificateRevocationRequestCondition{{
+				Type:               cmapi.CertificateRevocationRequestConditionRevoked,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: &fixedNow,
+			}},
+			RevocationTime: revocationTime,
+		}
+	}
+	reissued := func(status cmapi.CertificateRevocationRequestStatus, reissuanceTime *metav1.Time) cmapi.CertificateRevocationRequestStatus {
+		status.ReissuanceTime = reissuanceTime
+		return status
+	}
+	const (
+		revokedMessage     = "The certificate is reported as revoked by the OCSP responder"
+		revokedByCAMessage = "The certificate has been revoked by the CA"
+		reissuingMessage   = `Re-issuing the certificate with a new private key as it has been revoked by CertificateRevocationRequest "revoke"`
+		reissuingEvent     = `Normal Reissuing Triggered the re-issuance of Certificate "example" with a new private key`
+	)
+
+	tests := map[string]struct {
+		existingCRR          *cmapi.CertificateRevocationRequest
+		existingIssuers      []runtime.Object
+		existingCertificates []runtime.Object
+		existingSecrets      []runtime.Object
+
+		acmeClient *acmecl.FakeACME
+		vaultErr   error
+
+		// wantSpec is the expected spec of the CertificateRevocationRequest
+		// if it is updated.
+		wantSpec *cmapi.CertificateRevocationRequestSpec
+		// wantStatus is the expected status of the
+		// CertificateRevocationRequest if it is updated. If nil, no update
+		// is expected.
+		wantStatus *cmapi.CertificateRevocationRequestStatus
+		// wantReissuance is true if the re-issuance of the Certificate is
+		// expected to be triggered.
+		wantReissuance bool
+		// wantRevoked is the serial number expected to be revoked by Vault.
+		wantRevoked *big.Int
+		wantEvents  []string
+		wantErr     string
+	}{
+		"revoke a certificate issued by a CA Issuer": {
+			existingCRR:     crr("testns", cmmeta.ObjectReference{Name: "ca-issuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers: []runtime.Object{caIssuer},
+			wantStatus:      ptr(revoked(cmmeta.ConditionTrue, reasonRevoked, revokedMessage, &fixedNow)),
+			wantEvents:      []string{"Normal Revoked " + revokedMessage},
+		},
+		"revoke a certificate issued by a SelfSigned ClusterIssuer from the cluster resource namespace": {
+			existingCRR:     crr("cert-manager", cmmeta.ObjectReference{Name: "selfsigned", Kind: "ClusterIssuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers: []runtime.Object{selfSignedClusterIssuer},
+			wantStatus:      ptr(revoked(cmmeta.ConditionTrue, reasonRevoked, revokedMessage, &fixedNow)),
+			wantEvents:      []string{"Normal Revoked " + revokedMessage},
+		},
+		"do nothing if the certificate is already revoked": {
+			existingCRR:     crr("testns", cmmeta.ObjectReference{Name: "ca-issuer"}, revoked(cmmeta.ConditionTrue, reasonRevoked, revokedMessage, &earlier)),
+			existingIssuers: []runtime.Object{caIssuer},
+		},
+		"do not revoke a certificate issued by a ClusterIssuer from another namespace": {
+			existingCRR:     crr("testns", cmmeta.ObjectReference{Name: "selfsigned", Kind: "ClusterIssuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers: []runtime.Object{selfSignedClusterIssuer},
+			wantStatus: ptr(revoked(cmmeta.ConditionFalse, reasonClusterIssuerNotAllowed,
+				`Certificates issued by a ClusterIssuer can only be revoked from the cluster resource namespace "cert-manager"`, nil)),
+			wantEvents: []string{`Warning ClusterIssuerNotAllowed Certificates issued by a ClusterIssuer can only be revoked from the cluster resource namespace "cert-manager"`},
+		},
+		"do not revoke a certificate if the issuer does not exist": {
+			existingCRR: crr("testns", cmmeta.ObjectReference{Name: "ca-issuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			wantStatus:  ptr(revoked(cmmeta.ConditionFalse, reasonIssuerNotFound, `issuer.cert-manager.io "ca-issuer" not found`, nil)),
+			wantEvents:  []string{`Warning IssuerNotFound issuer.cert-manager.io "ca-issuer" not found`},
+		},
+		"do not revoke a certificate issued by an ACME Issuer without a reference to its Certificate": {
+			existingCRR:     crr("testns", cmmeta.ObjectReference{Name: "acme-issuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers: []runtime.Object{acmeIssuer},
+			wantStatus: ptr(revoked(cmmeta.ConditionFalse, reasonUnsupportedIssuer,
+				"Certificates issued by an ACME issuer can only be revoked by referencing their Certificate", nil)),
+			wantEvents: []string{"Warning UnsupportedIssuer Certificates issued by an ACME issuer can only be revoked by referencing their Certificate"},
+		},
+		"do not revoke a certificate issued by a Venafi Issuer": {
+			existingCRR:     crr("testns", cmmeta.ObjectReference{Name: "venafi-issuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers: []runtime.Object{venafiIssuer},
+			wantStatus: ptr(revoked(cmmeta.ConditionFalse, reasonUnsupportedIssuer,
+				`Issuer "venafi-issuer" is not a CA, SelfSigned, ACME or Vault issuer, so its certificates cannot be revoked by cert-manager`, nil)),
+			wantEvents: []string{`Warning UnsupportedIssuer Issuer "venafi-issuer" is not a CA, SelfSigned, ACME or Vault issuer, so its certificates cannot be revoked by cert-manager`},
+		},
+		"keep the revocation time if the issuer is deleted after the revocation": {
+			existingCRR: crr("testns", cmmeta.ObjectReference{Name: "ca-issuer"}, revoked(cmmeta.ConditionTrue, reasonRevoked, revokedMessage, &earlier)),
+			wantStatus:  ptr(revoked(cmmeta.ConditionFalse, reasonIssuerNotFound, `issuer.cert-manager.io "ca-issuer" not found`, &earlier)),
+			wantEvents:  []string{`Warning IssuerNotFound issuer.cert-manager.io "ca-issuer" not found`},
+		},
+		"revoke a certificate issued by a Vault Issuer by its serial number": {
+			existingCRR:     crr("testns", cmmeta.ObjectReference{Name: "vault-issuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers: []runtime.Object{vaultIssuer},
+			wantStatus:      ptr(revoked(cmmeta.ConditionTrue, reasonRevoked, revokedByCAMessage, &fixedNow)),
+			wantRevoked:     big.NewInt(0x1a2b3c),
+			wantEvents:      []string{"Normal Revoked " + revokedByCAMessage},
+		},
+		"retry if Vault fails to revoke the certificate": {
+			existingCRR:     crr("testns", cmmeta.ObjectReference{Name: "vault-issuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers: []runtime.Object{vaultIssuer},
+			vaultErr:        errors.New("permission denied"),
+			wantStatus:      ptr(revoked(cmmeta.ConditionFalse, reasonRevocationFailed, "Failed to revoke the certificate: permission denied", nil)),
+			wantRevoked:     big.NewInt(0x1a2b3c),
+			wantEvents:      []string{"Warning RevocationFailed Failed to revoke the certificate: permission denied"},
+			wantErr:         "Failed to revoke the certificate: permission denied",
+		},
+		"fill in the serial number and issuer from the referenced Certificate": {
+			existingCRR:          crrForCertificate(cmmeta.ObjectReference{}, cmapi.CertificateRevocationRequestStatus{}),
+			existingCertificates: []runtime.Object{certificate(cmmeta.ObjectReference{Name: "vault-issuer"})},
+			existingSecrets:      []runtime.Object{secret(certPEM)},
+			wantSpec: &cmapi.CertificateRevocationRequestSpec{
+				CertificateRef: &cmmeta.LocalObjectReference{Name: "example"},
+				IssuerRef:      cmmeta.ObjectReference{Name: "vault-issuer"},
+				SerialNumber:   "1a:2b:3c",
+			},
+		},
+		"wait for the referenced Certificate to be created": {
+			existingCRR: crrForCertificate(cmmeta.ObjectReference{}, cmapi.CertificateRevocationRequestStatus{}),
+			wantStatus:  ptr(revoked(cmmeta.ConditionFalse, reasonCertificateNotFound, `Certificate "example" does not exist`, nil)),
+			wantEvents:  []string{`Warning CertificateNotFound Certificate "example" does not exist`},
+		},
+		"wait for the referenced Certificate to be issued": {
+			existingCRR:          crrForCertificate(cmmeta.ObjectReference{}, cmapi.CertificateRevocationRequestStatus{}),
+			existingCertificates: []runtime.Object{certificate(cmmeta.ObjectReference{Name: "vault-issuer"})},
+			wantStatus:           ptr(revoked(cmmeta.ConditionFalse, reasonCertificateNotIssued, `Certificate "example" has not been issued yet`, nil)),
+			wantEvents:           []string{`Warning CertificateNotIssued Certificate "example" has not been issued yet`},
+		},
+		"do not fill in the issuer of a Certificate issued by an external issuer": {
+			existingCRR:          crrForCertificate(cmmeta.ObjectReference{}, cmapi.CertificateRevocationRequestStatus{}),
+			existingCertificates: []runtime.Object{certificate(cmmeta.ObjectReference{Name: "external", Group: "example.com"})},
+			existingSecrets:      []runtime.Object{secret(certPEM)},
+			wantStatus: ptr(revoked(cmmeta.ConditionFalse, reasonUnsupportedIssuer,
+				`Certificate "example" is not issued by an Issuer or ClusterIssuer, so its certificate cannot be revoked by cert-manager`, nil)),
+			wantEvents: []string{`Warning UnsupportedIssuer Certificate "example" is not issued by an Issuer or ClusterIssuer, so its certificate cannot be revoked by cert-manager`},
+		},
+		"revoke the certificate of a Certificate issued by a Vault Issuer and re-issue it": {
+			existingCRR:          crrForCertificate(cmmeta.ObjectReference{Name: "vault-issuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers:      []runtime.Object{vaultIssuer},
+			existingCertificates: []runtime.Object{certificate(cmmeta.ObjectReference{Name: "vault-issuer"})},
+			existingSecrets:      []runtime.Object{secret(certPEM)},
+			wantStatus:           ptr(reissued(revoked(cmmeta.ConditionTrue, reasonRevoked, revokedByCAMessage, &fixedNow), &fixedNow)),
+			wantReissuance:       true,
+			wantRevoked:          big.NewInt(0x1a2b3c),
+			wantEvents:           []string{"Normal Revoked " + revokedByCAMessage, reissuingEvent},
+		},
+		"revoke the certificate of a Certificate issued by an ACME ClusterIssuer from its namespace and re-issue it": {
+			existingCRR:          crrForCertificate(cmmeta.ObjectReference{Name: "acme", Kind: "ClusterIssuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers:      []runtime.Object{acmeClusterIssuer},
+			existingCertificates: []runtime.Object{certificate(cmmeta.ObjectReference{Name: "acme", Kind: "ClusterIssuer"})},
+			existingSecrets:      []runtime.Object{secret(certPEM)},
+			acmeClient: &acmecl.FakeACME{
+				FakeRevokeCert: func(_ context.Context, key crypto.Signer, der []byte, reason acme.CRLReasonCode) error {
+					if key != nil || string(der) != string(cert.Raw) || reason != acme.CRLReasonUnspecified {
+						return errors.New("unexpected revocation")
+					}
+					return nil
+				},
+			},
+			wantStatus:     ptr(reissued(revoked(cmmeta.ConditionTrue, reasonRevoked, revokedByCAMessage, &fixedNow), &fixedNow)),
+			wantReissuance: true,
+			wantEvents:     []string{"Normal Revoked " + revokedByCAMessage, reissuingEvent},
+		},
+		"treat a certificate already revoked by the ACME server as revoked": {
+			existingCRR:          crrForCertificate(cmmeta.ObjectReference{Name: "acme-issuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers:      []runtime.Object{acmeIssuer},
+			existingCertificates: []runtime.Object{certificate(cmmeta.ObjectReference{Name: "acme-issuer"})},
+			existingSecrets:      []runtime.Object{secret(certPEM)},
+			acmeClient: &acmecl.FakeACME{
+				FakeRevokeCert: func(context.Context, crypto.Signer, []byte, acme.CRLReasonCode) error {
+					return &acme.Error{ProblemType: acmeAlreadyRevoked}
+				},
+			},
+			wantStatus:     ptr(reissued(revoked(cmmeta.ConditionTrue, reasonRevoked, revokedByCAMessage, &fixedNow), &fixedNow)),
+			wantReissuance: true,
+			wantEvents:     []string{"Normal Revoked " + revokedByCAMessage, reissuingEvent},
+		},
+		"do not revoke a certificate which is no longer stored in the Secret of the Certificate": {
+			existingCRR:          crrForCertificate(cmmeta.ObjectReference{Name: "vault-issuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers:      []runtime.Object{vaultIssuer},
+			existingCertificates: []runtime.Object{certificate(cmmeta.ObjectReference{Name: "vault-issuer"})},
+			existingSecrets:      []runtime.Object{secret(renewedCertPEM)},
+			wantStatus: ptr(revoked(cmmeta.ConditionFalse, reasonCertificateMismatch,
+				`Certificate "example" no longer holds the certificate with serial number 1a:2b:3c`, nil)),
+			wantEvents: []string{`Warning CertificateMismatch Certificate "example" no longer holds the certificate with serial number 1a:2b:3c`},
+		},
+		"re-issue a Certificate whose certificate is revoked by the OCSP responder": {
+			existingCRR:          crrForCertificate(cmmeta.ObjectReference{Name: "ca-issuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers:      []runtime.Object{caIssuer},
+			existingCertificates: []runtime.Object{certificate(cmmeta.ObjectReference{Name: "ca-issuer"})},
+			existingSecrets:      []runtime.Object{secret(certPEM)},
+			wantStatus:           ptr(reissued(revoked(cmmeta.ConditionTrue, reasonRevoked, revokedMessage, &fixedNow), &fixedNow)),
+			wantReissuance:       true,
+			wantEvents:           []string{"Normal Revoked " + revokedMessage, reissuingEvent},
+		},
+		"re-issue a Certificate whose issuer does not support revocation": {
+			existingCRR:          crrForCertificate(cmmeta.ObjectReference{Name: "venafi-issuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers:      []runtime.Object{venafiIssuer},
+			existingCertificates: []runtime.Object{certificate(cmmeta.ObjectReference{Name: "venafi-issuer"})},
+			existingSecrets:      []runtime.Object{secret(certPEM)},
+			wantStatus: ptr(reissued(revoked(cmmeta.ConditionFalse, reasonUnsupportedIssuer,
+				`Issuer "venafi-issuer" is not a CA, SelfSigned, ACME or Vault issuer, so its certificates cannot be revoked by cert-manager`, nil), &fixedNow)),
+			wantReissuance: true,
+			wantEvents: []string{
+				`Warning UnsupportedIssuer Issuer "venafi-issuer" is not a CA, SelfSigned, ACME or Vault issuer, so its certificates cannot be revoked by cert-manager`,
+				reissuingEvent,
+			},
+		},
+		"do not re-issue a Certificate which has already been renewed": {
+			existingCRR:          crrForCertificate(cmmeta.ObjectReference{Name: "vault-issuer"}, revoked(cmmeta.ConditionTrue, reasonRevoked, revokedByCAMessage, &earlier)),
+			existingIssuers:      []runtime.Object{vaultIssuer},
+			existingCertificates: []runtime.Object{certificate(cmmeta.ObjectReference{Name: "vault-issuer"})},
+			existingSecrets:      []runtime.Object{secret(renewedCertPEM)},
+		},
+		"do not re-issue a Certificate twice": {
+			existingCRR:          crrForCertificate(cmmeta.ObjectReference{Name: "vault-issuer"}, reissued(revoked(cmmeta.ConditionTrue, reasonRevoked, revokedByCAMessage, &earlier), &earlier)),
+			existingIssuers:      []runtime.Object{vaultIssuer},
+			existingCertificates: []runtime.Object{certificate(cmmeta.ObjectReference{Name: "vault-issuer"})},
+			existingSecrets:      []runtime.Object{secret(certPEM)},
+		},
+		"do not revoke the certificate of a Certificate issued by a CA ClusterIssuer from another namespace": {
+			existingCRR:          crrForCertificate(cmmeta.ObjectReference{Name: "selfsigned", Kind: "ClusterIssuer"}, cmapi.CertificateRevocationRequestStatus{}),
+			existingIssuers:      []runtime.Object{selfSignedClusterIssuer},
+			existingCertificates: []runtime.Object{certificate(cmmeta.ObjectReference{Name: "selfsigned", Kind: "ClusterIssuer"})},
+			existingSecrets:      []runtime.Object{secret(certPEM)},
+			wantStatus: ptr(revoked(cmmeta.ConditionFalse, reasonClusterIssuerNotAllowed,
+				`Certificates issued by a ClusterIssuer can only be revoked from the cluster resource namespace "cert-manager"`, nil)),
+			wantEvents: []string{`Warning ClusterIssuerNotAllowed Certificates issued by a ClusterIssuer can only be revoked from the cluster resource namespace "cert-manager"`},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: append(append([]runtime.Object{test.existingCRR}, test.existingIssuers...), test.existingCertificates...),
+				KubeObjects:        test.existingSecrets,
+			}
+			builder.Init()
+			builder.Context.IssuerOptions.ClusterResourceNamespace = "cert-manager"
+			builder.Context.ACMEOptions.AccountRegistry = &accountstest.FakeRegistry{
+				GetClientFunc: func(string) (acmecl.Interface, error) {
+					return test.acmeClient, nil
+				},
+			}
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			revoker := &fakeRevoker{err: test.vaultErr}
+			w.controller.vaultRevokerBuilder = func(string, func(ns string) internalvault.CreateToken, corelisters.SecretLister, cmapi.GenericIssuer) (internalvault.Revoker, error) {
+				return revoker, nil
+			}
+
+			if test.wantSpec != nil {
+				expected := test.existingCRR.DeepCopy()
+				expected.Spec = *test.wantSpec
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterevocationrequests"),
+						expected.Namespace,
+						expected,
+					)),
+				)
+			}
+			if test.wantReissuance {
+				expected := test.existingCertificates[0].(*cmapi.Certificate).DeepCopy()
+				apiutil.SetCertificateCondition(expected, expected.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, cmapi.CertificateReasonRevoked, reissuingMessage)
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						expected.Namespace,
+						expected,
+					)),
+				)
+			}
+			if test.wantStatus != nil {
+				expected := test.existingCRR.DeepCopy()
+				expected.Status = *test.wantStatus
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterevocationrequests"),
+						"status",
+						expected.Namespace,
+						expected,
+					)),
+				)
+			}
+			builder.ExpectedEvents = test.wantEvents
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.existingCRR)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = w.controller.ProcessItem(context.Background(), key)
+			switch {
+			case err != nil && err.Error() != test.wantErr:
+				t.Errorf("unexpected error: %v", err)
+			case err == nil && test.wantErr != "":
+				t.Errorf("expected error %q but got none", test.wantErr)
+			}
+
+			if test.wantRevoked == nil && revoker.revoked != nil {
+				t.Errorf("unexpected revocation of serial number %x", revoker.revoked)
+			}
+			if test.wantRevoked != nil && (revoker.revoked == nil || revoker.revoked.Cmp(test.wantRevoked) != 0) {
+				t.Errorf("expected revocation of serial number %x, got %x", test.wantRevoked, revoker.revoked)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
+
+func Test_formatSerialNumber(t *testing.T) {
+	if got := formatSerialNumber(big.NewInt(0x01ab02)); got != "01:ab:02" {
+		t.Errorf("unexpected serial number %q", got)
+	}
+}
+
+func ptr(status cmapi.CertificateRevocationRequestStatus) *cmapi.CertificateRevocationRequestStatus {
+	return &status
+}
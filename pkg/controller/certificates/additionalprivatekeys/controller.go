@@ -0,0 +1,622 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package additionalprivatekeys
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
+	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+const (
+	// ControllerName is the name of the certificate additional private keys
+	// controller.
+	ControllerName = "certificates-additional-private-keys"
+
+	reasonRequested = "AdditionalKeyRequested"
+	reasonIssued    = "AdditionalKeyIssued"
+	reasonFailed    = "AdditionalKeyFailed"
+
+	// retryPeriod is the time after which a failed CertificateRequest for an
+	// additional private key is deleted and requested again.
+	retryPeriod = time.Hour
+)
+
+var (
+	certificateGvk = cmapi.SchemeGroupVersion.WithKind("Certificate")
+	secretGvk      = corev1.SchemeGroupVersion.WithKind("Secret")
+)
+
+type controller struct {
+	certificateLister        cmlisters.CertificateLister
+	certificateRequestLister cmlisters.CertificateRequestLister
+	secretLister             corelisters.SecretLister
+	client                   cmclient.Interface
+	coreClient               kubernetes.Interface
+	recorder                 record.EventRecorder
+	clock                    clock.Clock
+	queue                    workqueue.RateLimitingInterface
+
+	// fieldManager is the manager name used for the Apply operations of the
+	// additional key pairs on Secrets. It differs from the field manager of
+	// the issuing controller, so that the fields of both are left alone when
+	// the other writes to the Secret.
+	fieldManager string
+}
+
+// NewController returns a new controller which issues a certificate for each
+// private key algorithm in the spec.additionalPrivateKeys of a Certificate,
+// and writes them to the Certificate's Secret alongside its main certificate.
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	coreClient kubernetes.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	fieldManager string,
+	rateLimiter workqueue.RateLimiter,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ControllerName)
+
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
+	secretsInformer := controllerpkg.SecretsMetadataInformer(factory)
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
+	secretsInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+	})
+	// When a CertificateRequest for an additional private key changes,
+	// enqueue the Certificate it was created for.
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueCertificateForRequest(queue),
+	})
+
+	mustSync := []cache.InformerSynced{
+		secretsInformer.HasSynced,
+		certificateInformer.Informer().HasSynced,
+		certificateRequestInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		certificateLister:        certificateInformer.Lister(),
+		certificateRequestLister: certificateRequestInformer.Lister(),
+		secretLister:             controllerpkg.NewSecretGetter(factory),
+		client:                   client,
+		coreClient:               coreClient,
+		recorder:                 recorder,
+		clock:                    clock,
+		queue:                    queue,
+		fieldManager:             fieldManager,
+	}, queue, mustSync
+}
+
+func enqueueCertificateForRequest(queue workqueue.Interface) func(obj interface{}) {
+	return func(obj interface{}) {
+		req, ok := obj.(*cmapi.CertificateRequest)
+		if !ok {
+			return
+		}
+		if _, ok := req.Labels[cmapi.AdditionalPrivateKeyLabelKey]; !ok {
+			return
+		}
+		if name := req.Annotations[cmapi.CertificateNameKey]; name != "" {
+			queue.Add(req.Namespace + "/" + name)
+		}
+	}
+}
+
+// keyPair is a PEM encoded additional certificate and its private key,
+// together with the fingerprint of the main certificate it was issued
+// alongside.
+type keyPair struct {
+	cert, key   []byte
+	fingerprint string
+}
+
+// ProcessItem issues an additional certificate for each private key in the
+// Certificate's spec.additionalPrivateKeys which does not have one for the
+// current main certificate in the Secret, and writes the issued key pairs
+// to the Secret.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	secret, err := c.secretLister.Secrets(namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Wait for an issuance in progress to complete, so that the additional
+	// certificates are only issued alongside the new main certificate.
+	if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionIssuing,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		log.V(logf.DebugLevel).Info("certificate is being issued, waiting for issuance to complete")
+		return nil
+	}
+
+	fingerprint := certificateFingerprint(secret)
+
+	pairs := make(map[string]keyPair)
+	var completed []*cmapi.CertificateRequest
+	for _, pk := range crt.Spec.AdditionalPrivateKeys {
+		alg := algorithmName(pk.Algorithm)
+		existing, current := existingKeyPair(secret, crt, pk)
+		if existing != nil {
+			// Keep serving the existing key pair until its replacement has
+			// been issued.
+			pairs[alg] = *existing
+		}
+		if current && existing.fingerprint == fingerprint || fingerprint == "" {
+			continue
+		}
+
+		req, err := c.ensureRequest(ctx, crt, secret, pk, fingerprint)
+		if err != nil {
+			return err
+		}
+		if req == nil {
+			continue
+		}
+
+		issued, err := c.issuedKeyPair(ctx, crt, pk, req)
+		if err != nil {
+			return err
+		}
+		if issued == nil {
+			continue
+		}
+		issued.fingerprint = fingerprint
+		pairs[alg] = *issued
+		completed = append(completed, req)
+	}
+
+	if len(completed) == 0 && !hasRemovedKeyPairs(secret, crt) {
+		return nil
+	}
+
+	if err := c.applyKeyPairs(ctx, secret, pairs); err != nil {
+		return err
+	}
+
+	for _, req := range completed {
+		alg := req.Labels[cmapi.AdditionalPrivateKeyLabelKey]
+		c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonIssued, "Wrote the %s certificate issued for CertificateRequest %q to Secret %q", alg, req.Name, secret.Name)
+		if err := c.deleteRequest(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureRequest returns the CertificateRequest for the additional private
+// key issued alongside the main certificate with the given fingerprint.
+// If it does not exist yet, a new private key and CertificateRequest are
+// created and nil is returned.
+func (c *controller) ensureRequest(ctx context.Context, crt *cmapi.Certificate, secret *corev1.Secret, pk cmapi.CertificateAdditionalPrivateKey, fingerprint string) (*cmapi.CertificateRequest, error) {
+	log := logf.FromContext(ctx)
+	alg := algorithmName(pk.Algorithm)
+
+	req, err := c.certificateRequestLister.CertificateRequests(crt.Namespace).Get(requestName(crt, alg))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if req != nil {
+		if req.Annotations[annotationKey(alg)] == fingerprint {
+			return req, nil
+		}
+		log.V(logf.DebugLevel).Info("deleting CertificateRequest issued alongside a previous certificate", "name", req.Name)
+		if err := c.deleteRequest(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	keyCrt := certificateForKey(crt, pk)
+	signer, err := pki.GeneratePrivateKeyForCertificate(keyCrt)
+	if err != nil {
+		return nil, err
+	}
+	keySecret, err := c.storePrivateKey(ctx, crt, alg, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	x509CSR, err := pki.GenerateCSR(keyCrt)
+	if err != nil {
+		log.Error(err, "Failed to generate CSR - will not retry")
+		return nil, nil
+	}
+	csrDER, err := pki.EncodeCSR(x509CSR, signer)
+	if err != nil {
+		return nil, err
+	}
+	csrPEM := bytes.NewBuffer([]byte{})
+	if err := pem.Encode(csrPEM, &pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}); err != nil {
+		return nil, err
+	}
+
+	requestLabels := make(map[string]string, len(crt.Labels)+1)
+	for k, v := range crt.Labels {
+		requestLabels[k] = v
+	}
+	requestLabels[cmapi.AdditionalPrivateKeyLabelKey] = alg
+
+	req = &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: crt.Namespace,
+			Name:      requestName(crt, alg),
+			Annotations: map[string]string{
+				cmapi.CertificateNameKey:                        crt.Name,
+				cmapi.CertificateRequestPrivateKeyAnnotationKey: keySecret,
+				annotationKey(alg):                              fingerprint,
+			},
+			Labels: requestLabels,
+			// The requests are owned by the Secret rather than the
+			// Certificate, so that they are left alone by the controllers
+			// managing the requests for the main certificate.
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(secret, secretGvk)},
+		},
+		Spec: cmapi.CertificateRequestSpec{
+			Duration:  crt.Spec.Duration,
+			IssuerRef: crt.Spec.IssuerRef,
+			Request:   csrPEM.Bytes(),
+			IsCA:      crt.Spec.IsCA,
+			Usages:    apiutil.CertificateUsages(&crt.Spec),
+		},
+	}
+
+	req, err = c.client.CertmanagerV1().CertificateRequests(req.Namespace).Create(ctx, req, metav1.CreateOptions{FieldManager: c.fieldManager})
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonFailed, "Failed to create CertificateRequest for the %s private key: %v", alg, err)
+		return nil, err
+	}
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRequested, "Created new CertificateRequest resource %q for the %s private key", req.Name, alg)
+
+	return nil, nil
+}
+
+// storePrivateKey stores the private key of a new CertificateRequest for an
+// additional private key in a Secret, from which it is read once the request
+// has been issued. The Secret is owned by the Certificate, and an existing
+// Secret with the same name is only replaced if the Certificate owns it.
+func (c *controller) storePrivateKey(ctx context.Context, crt *cmapi.Certificate, alg string, signer crypto.Signer) (string, error) {
+	pkData, err := pki.EncodePrivateKey(signer, cmapi.PKCS8)
+	if err != nil {
+		return "", err
+	}
+
+	// The Secret is read from the API server rather than the cache, since
+	// Secrets created by users may not be cached.
+	name := keySecretName(crt, alg)
+	existing, err := c.coreClient.CoreV1().Secrets(crt.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", err
+	}
+	if err == nil && !metav1.IsControlledBy(existing, crt) {
+		return "", fmt.Errorf("cannot store the %s private key in Secret %q which is not owned by the Certificate", alg, name)
+	}
+
+	ref := *metav1.NewControllerRef(crt, certificateGvk)
+	applyCnf := applycorev1.Secret(name, crt.Namespace).
+		WithLabels(map[string]string{
+			cmapi.AdditionalPrivateKeyLabelKey:        alg,
+			cmapi.PartOfCertManagerControllerLabelKey: "true",
+		}).
+		WithOwnerReferences(&applymetav1.OwnerReferenceApplyConfiguration{
+			APIVersion: &ref.APIVersion, Kind: &ref.Kind,
+			Name: &ref.Name, UID: &ref.UID,
+			Controller: ref.Controller, BlockOwnerDeletion: ref.BlockOwnerDeletion,
+		}).
+		WithData(map[string][]byte{corev1.TLSPrivateKeyKey: pkData})
+	applyOpts := metav1.ApplyOptions{FieldManager: c.fieldManager, Force: true}
+	if _, err := c.coreClient.CoreV1().Secrets(crt.Namespace).Apply(ctx, applyCnf, applyOpts); err != nil {
+		return "", fmt.Errorf("failed to apply secret %s/%s: %w", crt.Namespace, name, err)
+	}
+	return name, nil
+}
+
+// issuedKeyPair returns the key pair of the CertificateRequest for an
+// additional private key, or nil if the request has not been issued.
+// Failed requests are retried after the retry period.
+func (c *controller) issuedKeyPair(ctx context.Context, crt *cmapi.Certificate, pk cmapi.CertificateAdditionalPrivateKey, req *cmapi.CertificateRequest) (*keyPair, error) {
+	log := logf.WithRelatedResource(logf.FromContext(ctx), req)
+	alg := algorithmName(pk.Algorithm)
+
+	switch apiutil.CertificateRequestReadyReason(req) {
+	case cmapi.CertificateRequestReasonFailed, cmapi.CertificateRequestReasonDenied:
+		cond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+		retryAt := cond.LastTransitionTime.Add(retryPeriod)
+		if wait := retryAt.Sub(c.clock.Now()); wait > 0 {
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonFailed, "CertificateRequest %q for the %s private key failed, retrying at %s: %s",
+				req.Name, alg, retryAt.UTC().Format(time.RFC3339), cond.Message)
+			c.queue.AddAfter(crt.Namespace+"/"+crt.Name, wait)
+			return nil, nil
+		}
+		log.V(logf.DebugLevel).Info("retrying failed CertificateRequest")
+		return nil, c.deleteRequest(ctx, req)
+	case cmapi.CertificateRequestReasonIssued:
+	default:
+		return nil, nil
+	}
+	if len(req.Status.Certificate) == 0 {
+		return nil, nil
+	}
+
+	keySecret, err := c.secretLister.Secrets(req.Namespace).Get(req.Annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey])
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("private key Secret of CertificateRequest not found, requesting again")
+		return nil, c.deleteRequest(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := pki.DecodePrivateKeyBytes(keySecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		log.V(logf.DebugLevel).Info("private key Secret of CertificateRequest is invalid, requesting again", "reason", err.Error())
+		return nil, c.deleteRequest(ctx, req)
+	}
+	cert, err := pki.DecodeX509CertificateBytes(req.Status.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := pki.PublicKeyMatchesCertificate(signer.Public(), cert)
+	if err != nil {
+		return nil, err
+	}
+	violations, err := certificates.PrivateKeyMatchesSpec(signer, certificateForKey(crt, pk).Spec)
+	if err != nil {
+		return nil, err
+	}
+	if !matches || len(violations) > 0 {
+		log.V(logf.DebugLevel).Info("issued certificate does not match the private key or spec, requesting again", "violations", violations)
+		return nil, c.deleteRequest(ctx, req)
+	}
+
+	keyPEM, err := pki.EncodePrivateKey(signer, encoding(crt))
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyPair{cert: req.Status.Certificate, key: keyPEM}, nil
+}
+
+// applyKeyPairs writes the given key pairs to the Secret using an Apply
+// call, which also removes the key pairs of algorithms no longer requested.
+func (c *controller) applyKeyPairs(ctx context.Context, secret *corev1.Secret, pairs map[string]keyPair) error {
+	data := make(map[string][]byte, 2*len(pairs))
+	annotations := make(map[string]string, len(pairs))
+	for alg, pair := range pairs {
+		data[certKey(alg)] = pair.cert
+		data[privateKeyKey(alg)] = pair.key
+		annotations[annotationKey(alg)] = pair.fingerprint
+	}
+
+	applyCnf := applycorev1.Secret(secret.Name, secret.Namespace).
+		WithAnnotations(annotations).WithData(data)
+	applyOpts := metav1.ApplyOptions{FieldManager: c.fieldManager, Force: true}
+	if _, err := c.coreClient.CoreV1().Secrets(secret.Namespace).Apply(ctx, applyCnf, applyOpts); err != nil {
+		return fmt.Errorf("failed to apply secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	return nil
+}
+
+// deleteRequest deletes a CertificateRequest for an additional private key
+// together with the Secret holding its private key.
+func (c *controller) deleteRequest(ctx context.Context, req *cmapi.CertificateRequest) error {
+	err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if name := req.Annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey]; name != "" {
+		err := c.coreClient.CoreV1().Secrets(req.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// existingKeyPair returns the key pair for the additional private key in the
+// Secret, or nil if it does not hold a valid one. It also returns whether
+// the key pair is current, i.e. it matches the spec and the Certificate's
+// subject and SANs.
+func existingKeyPair(secret *corev1.Secret, crt *cmapi.Certificate, pk cmapi.CertificateAdditionalPrivateKey) (*keyPair, bool) {
+	alg := algorithmName(pk.Algorithm)
+	fingerprint, ok := secret.Annotations[annotationKey(alg)]
+	if !ok {
+		return nil, false
+	}
+	certPEM, keyPEM := secret.Data[certKey(alg)], secret.Data[privateKeyKey(alg)]
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		return nil, false
+	}
+	signer, err := pki.DecodePrivateKeyBytes(keyPEM)
+	if err != nil {
+		return nil, false
+	}
+	if matches, err := pki.PublicKeyMatchesCertificate(signer.Public(), cert); err != nil || !matches {
+		return nil, false
+	}
+
+	pair := &keyPair{cert: certPEM, key: keyPEM, fingerprint: fingerprint}
+	violations, err := certificates.PrivateKeyMatchesSpec(signer, certificateForKey(crt, pk).Spec)
+	return pair, err == nil && len(violations) == 0
+}
+
+// hasRemovedKeyPairs returns true if the Secret holds key pairs for private
+// key algorithms which are no longer in the Certificate's spec.
+func hasRemovedKeyPairs(secret *corev1.Secret, crt *cmapi.Certificate) bool {
+	requested := make(map[string]bool, len(crt.Spec.AdditionalPrivateKeys))
+	for _, pk := range crt.Spec.AdditionalPrivateKeys {
+		requested[algorithmName(pk.Algorithm)] = true
+	}
+	for k := range secret.Annotations {
+		if strings.HasPrefix(k, cmapi.AdditionalPrivateKeyAnnotationPrefix) &&
+			!requested[strings.TrimPrefix(k, cmapi.AdditionalPrivateKeyAnnotationPrefix)] {
+			return true
+		}
+	}
+	return false
+}
+
+// certificateFingerprint returns the hex encoded SHA-256 fingerprint of the
+// main certificate in the Secret, or an empty string if it holds none.
+func certificateFingerprint(secret *corev1.Secret) string {
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return ""
+	}
+	fingerprint := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(fingerprint[:])
+}
+
+// certificateForKey returns a copy of the Certificate requesting the given
+// additional private key instead of its main private key.
+func certificateForKey(crt *cmapi.Certificate, pk cmapi.CertificateAdditionalPrivateKey) *cmapi.Certificate {
+	crt = crt.DeepCopy()
+	if crt.Spec.PrivateKey == nil {
+		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
+	}
+	crt.Spec.PrivateKey.Algorithm = pk.Algorithm
+	crt.Spec.PrivateKey.Size = pk.Size
+	return crt
+}
+
+func encoding(crt *cmapi.Certificate) cmapi.PrivateKeyEncoding {
+	if crt.Spec.PrivateKey == nil {
+		return cmapi.PKCS1
+	}
+	return crt.Spec.PrivateKey.Encoding
+}
+
+// algorithmName returns the name of the algorithm used in the Secret keys,
+// e.g. "ecdsa".
+func algorithmName(alg cmapi.PrivateKeyAlgorithm) string {
+	return strings.ToLower(string(alg))
+}
+
+func certKey(alg string) string {
+	return "tls-" + alg + ".crt"
+}
+
+func privateKeyKey(alg string) string {
+	return "tls-" + alg + ".key"
+}
+
+func annotationKey(alg string) string {
+	return cmapi.AdditionalPrivateKeyAnnotationPrefix + alg
+}
+
+func requestName(crt *cmapi.Certificate, alg string) string {
+	return apiutil.DNSSafeShortenTo52Characters(crt.Name) + "-" + alg
+}
+
+func keySecretName(crt *cmapi.Certificate, alg string) string {
+	return apiutil.DNSSafeShortenTo52Characters(crt.Name) + "-" + alg + "-key"
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.Client,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.FieldManager+"-additional-private-keys",
+		ctx.RateLimiterFor(ControllerName, time.Second*5, time.Minute*5),
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.RegisterSharded(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package additionalprivatekeys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// createdNameMatcher matches create actions by the name of the created
+// object, since the private keys and CSRs they contain are random.
+func createdNameMatcher(exp, act coretesting.Action) error {
+	expObj := exp.(coretesting.CreateAction).GetObject().(interface{ GetName() string })
+	actObj := act.(coretesting.CreateAction).GetObject().(interface{ GetName() string })
+	if expObj.GetName() != actObj.GetName() {
+		return fmt.Errorf("expected %q to be created, got %q", expObj.GetName(), actObj.GetName())
+	}
+	return nil
+}
+
+// appliedSecretMatcher returns a matcher of the apply of the Secret which
+// expects exactly the given data and annotations to be applied.
+func appliedSecretMatcher(data map[string][]byte, annotations map[string]string) testpkg.ActionMatchFn {
+	return func(_, act coretesting.Action) error {
+		patch, ok := act.(coretesting.PatchAction)
+		if !ok || patch.GetPatchType() != types.ApplyPatchType {
+			return fmt.Errorf("expected an apply patch action, got %v", act)
+		}
+		var applied corev1.Secret
+		if err := json.Unmarshal(patch.GetPatch(), &applied); err != nil {
+			return err
+		}
+		if len(applied.Data) != len(data) || (len(data) > 0 && !reflect.DeepEqual(applied.Data, data)) {
+			return fmt.Errorf("unexpected data applied: %v", applied.Data)
+		}
+		if len(applied.Annotations) != len(annotations) || (len(annotations) > 0 && !reflect.DeepEqual(applied.Annotations, annotations)) {
+			return fmt.Errorf("unexpected annotations applied: %v", applied.Annotations)
+		}
+		return nil
+	}
+}
+
+// appliedKeySecretMatcher matches the apply of a private key Secret, which
+// must be owned by the Certificate. The private key is random so it is only
+// checked to be set.
+func appliedKeySecretMatcher(crt *cmapi.Certificate) testpkg.ActionMatchFn {
+	return func(_, act coretesting.Action) error {
+		patch, ok := act.(coretesting.PatchAction)
+		if !ok || patch.GetPatchType() != types.ApplyPatchType {
+			return fmt.Errorf("expected an apply patch action, got %v", act)
+		}
+		var applied corev1.Secret
+		if err := json.Unmarshal(patch.GetPatch(), &applied); err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(&applied, crt) {
+			return fmt.Errorf("expected the private key Secret to be owned by the Certificate, got %v", applied.OwnerReferences)
+		}
+		if applied.Labels[cmapi.AdditionalPrivateKeyLabelKey] != "ecdsa" || len(applied.Data[corev1.TLSPrivateKeyKey]) == 0 {
+			return fmt.Errorf("unexpected private key Secret applied: %v", applied)
+		}
+		return nil
+	}
+}
+
+func TestProcessItem(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC))
+	ecdsaKey := cmapi.CertificateAdditionalPrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm}
+
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}),
+		gen.SetCertificateAdditionalPrivateKeys(ecdsaKey),
+		gen.SetCertificateUID("test-uid"),
+	)
+	mainBundle := testcrypto.MustCreateCryptoBundle(t, baseCrt, fixedClock)
+	ecdsaBundle := testcrypto.MustCreateCryptoBundle(t, certificateForKey(baseCrt, ecdsaKey), fixedClock)
+
+	secret := gen.Secret("output",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSCertKey:       mainBundle.CertBytes,
+			corev1.TLSPrivateKeyKey: mainBundle.PrivateKeyBytes,
+		}),
+	)
+	secret.UID = "secret-uid"
+	fingerprint := certificateFingerprint(secret)
+
+	secretRef := *metav1.NewControllerRef(secret, secretGvk)
+	keySecret := gen.Secret("test-ecdsa-key",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{corev1.TLSPrivateKeyKey: ecdsaBundle.PrivateKeyBytes}),
+	)
+	keySecret.Labels = map[string]string{
+		cmapi.AdditionalPrivateKeyLabelKey:        "ecdsa",
+		cmapi.PartOfCertManagerControllerLabelKey: "true",
+	}
+	keySecret.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(baseCrt, certificateGvk)}
+	// userSecret has the name and labels of the private key Secret, but is
+	// not owned by the Certificate.
+	userSecret := keySecret.DeepCopy()
+	userSecret.OwnerReferences = nil
+
+	request := func(fingerprint string, mods ...gen.CertificateRequestModifier) *cmapi.CertificateRequest {
+		req := gen.CertificateRequest("test-ecdsa", append([]gen.CertificateRequestModifier{
+			gen.SetCertificateRequestNamespace("testns"),
+			gen.SetCertificateRequestCSR(ecdsaBundle.CSRBytes),
+			gen.SetCertificateRequestAnnotations(map[string]string{
+				cmapi.CertificateNameKey:                        "test",
+				cmapi.CertificateRequestPrivateKeyAnnotationKey: "test-ecdsa-key",
+				annotationKey("ecdsa"):                          fingerprint,
+			}),
+		}, mods...)...)
+		req.Labels = map[string]string{cmapi.AdditionalPrivateKeyLabelKey: "ecdsa"}
+		req.OwnerReferences = []metav1.OwnerReference{secretRef}
+		return req
+	}
+	issued := []gen.CertificateRequestModifier{
+		gen.SetCertificateRequestCertificate(ecdsaBundle.CertBytes),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionReady,
+			Status: cmmeta.ConditionTrue,
+			Reason: cmapi.CertificateRequestReasonIssued,
+		}),
+	}
+	failedAt := func(t time.Time) gen.CertificateRequestModifier {
+		return gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionReady,
+			Status:             cmmeta.ConditionFalse,
+			Reason:             cmapi.CertificateRequestReasonFailed,
+			Message:            "issuer unavailable",
+			LastTransitionTime: &metav1.Time{Time: t},
+		})
+	}
+
+	ecdsaKeyPEM := ecdsaBundle.PrivateKeyBytes
+	secretWithPair := gen.SecretFrom(secret,
+		gen.SetSecretAnnotations(map[string]string{annotationKey("ecdsa"): fingerprint}),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSCertKey:       mainBundle.CertBytes,
+			corev1.TLSPrivateKeyKey: mainBundle.PrivateKeyBytes,
+			"tls-ecdsa.crt":         ecdsaBundle.CertBytes,
+			"tls-ecdsa.key":         ecdsaKeyPEM,
+		}),
+	)
+
+	crGVR := cmapi.SchemeGroupVersion.WithResource("certificaterequests")
+	secretGVR := corev1.SchemeGroupVersion.WithResource("secrets")
+	getKeySecret := testpkg.NewAction(coretesting.NewGetAction(secretGVR, "testns", "test-ecdsa-key"))
+	applyKeySecret := testpkg.NewCustomMatch(coretesting.NewPatchAction(secretGVR, "testns", "test-ecdsa-key", types.ApplyPatchType, nil),
+		appliedKeySecretMatcher(baseCrt))
+	createRequest := testpkg.NewCustomMatch(coretesting.NewCreateAction(crGVR, "testns", request(fingerprint)), createdNameMatcher)
+	deleteRequest := testpkg.NewAction(coretesting.NewDeleteAction(crGVR, "testns", "test-ecdsa"))
+	deleteKeySecret := testpkg.NewAction(coretesting.NewDeleteAction(secretGVR, "testns", "test-ecdsa-key"))
+
+	tests := map[string]struct {
+		certificate     *cmapi.Certificate
+		secrets         []runtime.Object
+		requests        []*cmapi.CertificateRequest
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+		expectedErr     bool
+	}{
+		"do nothing if the Certificate requests no additional private keys": {
+			certificate: gen.CertificateFrom(baseCrt, gen.SetCertificateAdditionalPrivateKeys()),
+			secrets:     []runtime.Object{secret},
+		},
+		"do nothing if the Secret does not exist": {
+			certificate: baseCrt,
+		},
+		"wait for an issuance in progress to complete": {
+			certificate: gen.CertificateFrom(baseCrt, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionIssuing,
+				Status: cmmeta.ConditionTrue,
+			})),
+			secrets: []runtime.Object{secret},
+		},
+		"create a private key and CertificateRequest for a missing key pair": {
+			certificate:     baseCrt,
+			secrets:         []runtime.Object{secret},
+			expectedActions: []testpkg.Action{getKeySecret, applyKeySecret, createRequest},
+			expectedEvents:  []string{`Normal AdditionalKeyRequested Created new CertificateRequest resource "test-ecdsa" for the ecdsa private key`},
+		},
+		"replace the private key of a leftover Secret owned by the Certificate": {
+			certificate:     baseCrt,
+			secrets:         []runtime.Object{secret, keySecret},
+			expectedActions: []testpkg.Action{getKeySecret, applyKeySecret, createRequest},
+			expectedEvents:  []string{`Normal AdditionalKeyRequested Created new CertificateRequest resource "test-ecdsa" for the ecdsa private key`},
+		},
+		"do not overwrite a Secret which is not owned by the Certificate": {
+			certificate:     baseCrt,
+			secrets:         []runtime.Object{secret, userSecret},
+			expectedActions: []testpkg.Action{getKeySecret},
+			expectedErr:     true,
+		},
+		"wait for a pending CertificateRequest": {
+			certificate: baseCrt,
+			secrets:     []runtime.Object{secret, keySecret},
+			requests:    []*cmapi.CertificateRequest{request(fingerprint)},
+		},
+		"write the issued key pair to the Secret and clean up": {
+			certificate: baseCrt,
+			secrets:     []runtime.Object{secret, keySecret},
+			requests:    []*cmapi.CertificateRequest{request(fingerprint, issued...)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewPatchAction(secretGVR, "testns", "output", types.ApplyPatchType, nil),
+					appliedSecretMatcher(map[string][]byte{
+						"tls-ecdsa.crt": ecdsaBundle.CertBytes,
+						"tls-ecdsa.key": ecdsaKeyPEM,
+					}, map[string]string{annotationKey("ecdsa"): fingerprint})),
+				deleteRequest,
+				deleteKeySecret,
+			},
+			expectedEvents: []string{`Normal AdditionalKeyIssued Wrote the ecdsa certificate issued for CertificateRequest "test-ecdsa" to Secret "output"`},
+		},
+		"do nothing if the Secret holds a current key pair": {
+			certificate: baseCrt,
+			secrets:     []runtime.Object{secretWithPair},
+		},
+		"request a new key pair when the main certificate changes": {
+			certificate:     baseCrt,
+			secrets:         []runtime.Object{gen.SecretFrom(secretWithPair, gen.SetSecretAnnotations(map[string]string{annotationKey("ecdsa"): "old"})), keySecret},
+			requests:        []*cmapi.CertificateRequest{request("old", issued...)},
+			expectedActions: []testpkg.Action{deleteRequest, deleteKeySecret, getKeySecret, applyKeySecret, createRequest},
+			expectedEvents:  []string{`Normal AdditionalKeyRequested Created new CertificateRequest resource "test-ecdsa" for the ecdsa private key`},
+		},
+		"remove key pairs of algorithms no longer requested": {
+			certificate: gen.CertificateFrom(baseCrt, gen.SetCertificateAdditionalPrivateKeys()),
+			secrets:     []runtime.Object{secretWithPair},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewPatchAction(secretGVR, "testns", "output", types.ApplyPatchType, nil),
+					appliedSecretMatcher(nil, nil)),
+			},
+		},
+		"wait before retrying a failed CertificateRequest": {
+			certificate:    baseCrt,
+			secrets:        []runtime.Object{secret, keySecret},
+			requests:       []*cmapi.CertificateRequest{request(fingerprint, failedAt(fixedClock.Now().Add(-time.Minute)))},
+			expectedEvents: []string{`Warning AdditionalKeyFailed CertificateRequest "test-ecdsa" for the ecdsa private key failed, retrying at 2022-10-01T00:59:00Z: issuer unavailable`},
+		},
+		"delete a failed CertificateRequest after the retry period": {
+			certificate:     baseCrt,
+			secrets:         []runtime.Object{secret, keySecret},
+			requests:        []*cmapi.CertificateRequest{request(fingerprint, failedAt(fixedClock.Now().Add(-2*time.Hour)))},
+			expectedActions: []testpkg.Action{deleteRequest, deleteKeySecret},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:               t,
+				Clock:           fixedClock,
+				ExpectedEvents:  test.expectedEvents,
+				ExpectedActions: test.expectedActions,
+			}
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.certificate)
+			builder.KubeObjects = append(builder.KubeObjects, test.secrets...)
+			for _, req := range test.requests {
+				builder.CertManagerObjects = append(builder.CertManagerObjects, req)
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			err := w.controller.ProcessItem(context.Background(), "testns/test")
+			if test.expectedErr != (err != nil) {
+				t.Errorf("expected error: %t, got %v", test.expectedErr, err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"github.com/cert-manager/cert-manager/internal/vault"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/acmeorders/selectors"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	"github.com/cert-manager/cert-manager/pkg/util"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// errUnsupported is returned for issuers whose policy cannot be checked
+// without issuing a certificate.
+var errUnsupported = errors.New("the issuer does not support issuance dry-runs")
+
+// rejection is returned when the issuer would refuse to sign the
+// Certificate. Any other error returned by the checks is transient.
+type rejection struct {
+	message string
+}
+
+func (r *rejection) Error() string {
+	return r.message
+}
+
+func rejectf(format string, args ...interface{}) error {
+	return &rejection{message: fmt.Sprintf(format, args...)}
+}
+
+// dryRun checks whether the given issuer would sign the Certificate.
+func (c *controller) dryRun(ctx context.Context, log logr.Logger, crt *cmapi.Certificate, issuer cmapi.GenericIssuer) error {
+	tmpl, err := pki.GenerateTemplate(crt)
+	if err != nil {
+		return rejectf("The Certificate spec cannot be turned into a certificate request: %v", err)
+	}
+
+	if err := apiutil.CheckIssuerUsages(issuer, crt.Namespace, apiutil.RequestedUsages(crt.Spec.Usages, crt.Spec.IsCA)); err != nil {
+		return rejectf("Requested usages are not allowed: %v", err)
+	}
+	if allowed := issuer.GetSpec().AllowedURISchemes; len(allowed) > 0 {
+		for _, uri := range tmpl.URIs {
+			if !containsFold(allowed, uri.Scheme) {
+				return rejectf("URI SAN %q has scheme %q, but the issuer only allows the schemes: %s",
+					uri, uri.Scheme, strings.Join(allowed, ", "))
+			}
+		}
+	}
+
+	issuerType, err := apiutil.NameForIssuer(issuer)
+	if err != nil {
+		return rejectf("The issuer is not configured: %v", err)
+	}
+	switch issuerType {
+	case apiutil.IssuerACME:
+		return checkACME(crt, tmpl, issuer.GetSpec().ACME)
+	case apiutil.IssuerVault:
+		return c.checkVault(crt, tmpl, issuer)
+	case apiutil.IssuerVenafi:
+		return c.checkVenafi(log, crt, tmpl, issuer)
+	case apiutil.IssuerCA, apiutil.IssuerSelfSigned:
+		// CA and SelfSigned issuers have no policy beyond the checks above.
+		return nil
+	default:
+		return errUnsupported
+	}
+}
+
+// checkACME checks that the identifiers of the Certificate can be requested
+// from an ACME server, and that the issuer has a solver for each of them.
+func checkACME(crt *cmapi.Certificate, tmpl *x509.Certificate, acme *cmacme.ACMEIssuer) error {
+	if len(tmpl.URIs) > 0 {
+		return rejectf("ACME servers cannot issue certificates with URI SANs: %s", strings.Join(pki.URLsToString(tmpl.URIs), ", "))
+	}
+
+	ips := pki.IPAddressesToString(tmpl.IPAddresses)
+	if cn := tmpl.Subject.CommonName; cn != "" && !util.Contains(tmpl.DNSNames, cn) && !util.Contains(ips, cn) {
+		return rejectf("The common name %q must also be listed in the DNS names or IP addresses of the Certificate", cn)
+	}
+
+	for _, identifier := range append(append([]string(nil), tmpl.DNSNames...), ips...) {
+		if !acmeHasSolverFor(crt, identifier, acme.Solvers) {
+			return rejectf("None of the issuer's ACME solvers can solve challenges for %q", identifier)
+		}
+	}
+	return nil
+}
+
+// acmeHasSolverFor returns true if one of the solvers could be selected for
+// the challenges of the given identifier. ACME servers only offer DNS01
+// challenges for wildcard identifiers.
+func acmeHasSolverFor(crt *cmapi.Certificate, identifier string, solvers []cmacme.ACMEChallengeSolver) bool {
+	wildcard := strings.HasPrefix(identifier, "*.")
+	for _, solver := range solvers {
+		if wildcard && solver.DNS01 == nil {
+			continue
+		}
+		if solver.Selector == nil {
+			return true
+		}
+		labelsMatch, _ := selectors.Labels(*solver.Selector).Matches(crt.ObjectMeta, identifier)
+		dnsNamesMatch, _ := selectors.DNSNames(*solver.Selector).Matches(crt.ObjectMeta, identifier)
+		dnsZonesMatch, _ := selectors.DNSZones(*solver.Selector).Matches(crt.ObjectMeta, identifier)
+		if labelsMatch && dnsNamesMatch && dnsZonesMatch {
+			return true
+		}
+	}
+	return false
+}
+
+// checkVault checks the Certificate against the configuration of the Vault
+// PKI role the issuer signs certificates with.
+func (c *controller) checkVault(crt *cmapi.Certificate, tmpl *x509.Certificate, issuer cmapi.GenericIssuer) error {
+	roleReader, err := c.vaultRoleReaderBuilder(c.issuerOptions.ResourceNamespace(issuer), c.createTokenFn, c.secretLister, issuer)
+	if err != nil {
+		return err
+	}
+	role, err := roleReader.ReadRole()
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		// The issuer signs certificates without a role.
+		return nil
+	}
+
+	names := tmpl.DNSNames
+	if cn := tmpl.Subject.CommonName; cn != "" && !util.Contains(names, cn) {
+		names = append([]string{cn}, names...)
+	}
+	for _, name := range names {
+		if !vaultRoleAllowsName(role, name) {
+			return rejectf("The Vault role does not allow the name %q", name)
+		}
+	}
+
+	if len(tmpl.IPAddresses) > 0 && !role.AllowIPSANs {
+		return rejectf("The Vault role does not allow IP SANs")
+	}
+	for _, uri := range pki.URLsToString(tmpl.URIs) {
+		if !matchesAnyGlob(role.AllowedURISANs, uri) {
+			return rejectf("The Vault role does not allow the URI SAN %q", uri)
+		}
+	}
+
+	return vaultRoleAllowsKey(role, crt.Spec.PrivateKey)
+}
+
+// vaultRoleAllowsName returns true if the Vault role allows the given common
+// name or DNS name, following the rules of the `allowed_domains` options of
+// PKI roles.
+func vaultRoleAllowsName(role *vault.Role, name string) bool {
+	if role.AllowAnyName {
+		return true
+	}
+	if strings.HasPrefix(name, "*.") && role.AllowWildcardCertificates != nil && !*role.AllowWildcardCertificates {
+		return false
+	}
+	if role.AllowLocalhost && (name == "localhost" || name == "localdomain" ||
+		strings.HasSuffix(name, ".localhost") || strings.HasSuffix(name, ".localdomain")) {
+		return true
+	}
+	for _, domain := range role.AllowedDomains {
+		switch {
+		case role.AllowBareDomains && strings.EqualFold(name, domain):
+			return true
+		case role.AllowSubdomains && strings.HasSuffix(strings.ToLower(name), "."+strings.ToLower(domain)):
+			return true
+		case role.AllowGlobDomains && strings.Contains(domain, "*") && globMatch(strings.ToLower(domain), strings.ToLower(name)):
+			return true
+		}
+	}
+	return false
+}
+
+// vaultRoleAllowsKey returns a rejection if the private key algorithm or size
+// requested by the Certificate is not allowed by the Vault role.
+func vaultRoleAllowsKey(role *vault.Role, privateKey *cmapi.CertificatePrivateKey) error {
+	algorithm, size := cmapi.RSAKeyAlgorithm, 0
+	if privateKey != nil {
+		if privateKey.Algorithm != "" {
+			algorithm = privateKey.Algorithm
+		}
+		size = privateKey.Size
+	}
+
+	var keyType string
+	switch algorithm {
+	case cmapi.RSAKeyAlgorithm:
+		keyType = "rsa"
+		if size == 0 {
+			size = pki.MinRSAKeySize
+		}
+	case cmapi.ECDSAKeyAlgorithm:
+		keyType = "ec"
+	case cmapi.Ed25519KeyAlgorithm:
+		keyType = "ed25519"
+	default:
+		keyType = strings.ToLower(string(algorithm))
+	}
+
+	if role.KeyType == "" || role.KeyType == "any" {
+		return nil
+	}
+	if role.KeyType != keyType {
+		return rejectf("The Vault role requires %q keys, but the Certificate requests a %s private key", role.KeyType, algorithm)
+	}
+	if keyType == "rsa" && size < role.KeyBits {
+		return rejectf("The Vault role requires RSA keys of at least %d bits, but the Certificate requests %d bits", role.KeyBits, size)
+	}
+	return nil
+}
+
+// checkVenafi checks the Certificate against the policy of the issuer's
+// Venafi zone. A throwaway private key is generated so that the key policy of
+// the zone is checked as well.
+func (c *controller) checkVenafi(log logr.Logger, crt *cmapi.Certificate, tmpl *x509.Certificate, issuer cmapi.GenericIssuer) error {
+	var customFields []api.CustomField
+	if annotation := crt.Annotations[cmapi.VenafiCustomFieldsAnnotationKey]; annotation != "" {
+		if err := json.Unmarshal([]byte(annotation), &customFields); err != nil {
+			return rejectf("Failed to parse %q annotation: %v", cmapi.VenafiCustomFieldsAnnotationKey, err)
+		}
+	}
+
+	pk, err := pki.GeneratePrivateKeyForCertificate(crt)
+	if err != nil {
+		return rejectf("Failed to generate private key: %v", err)
+	}
+	tmpl.PublicKey = pk.Public()
+
+	client, err := c.venafiClientBuilder(c.issuerOptions.ResourceNamespace(issuer), c.secretLister, issuer, c.metrics, log)
+	if err != nil {
+		return err
+	}
+	zoneCfg, err := client.ReadZoneConfiguration()
+	if err != nil {
+		return err
+	}
+
+	if err := venaficlient.ValidateTemplate(zoneCfg, tmpl, venaficlient.CustomFieldsForIssuer(issuer, customFields)); err != nil {
+		return rejectf("The Venafi zone policy does not allow the Certificate: %v", err)
+	}
+	return nil
+}
+
+// containsFold returns true if s is one of ss, ignoring case.
+func containsFold(ss []string, s string) bool {
+	for _, candidate := range ss {
+		if strings.EqualFold(candidate, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyGlob returns true if s matches one of the glob patterns.
+func matchesAnyGlob(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		if globMatch(pattern, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// globMatch returns true if s matches the pattern, in which each `*` matches
+// any sequence of characters.
+func globMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"testing"
+
+	"k8s.io/utils/pointer"
+
+	internalvault "github.com/cert-manager/cert-manager/internal/vault"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func Test_vaultRoleAllowsName(t *testing.T) {
+	tests := map[string]struct {
+		role *internalvault.Role
+		name string
+		want bool
+	}{
+		"any name is allowed": {
+			role: &internalvault.Role{AllowAnyName: true},
+			name: "foo.example.org",
+			want: true,
+		},
+		"bare domain is allowed": {
+			role: &internalvault.Role{AllowedDomains: []string{"example.com"}, AllowBareDomains: true},
+			name: "example.com",
+			want: true,
+		},
+		"bare domain is not allowed": {
+			role: &internalvault.Role{AllowedDomains: []string{"example.com"}, AllowSubdomains: true},
+			name: "example.com",
+			want: false,
+		},
+		"subdomain is allowed": {
+			role: &internalvault.Role{AllowedDomains: []string{"example.com"}, AllowSubdomains: true},
+			name: "foo.bar.example.com",
+			want: true,
+		},
+		"subdomain is not allowed": {
+			role: &internalvault.Role{AllowedDomains: []string{"example.com"}, AllowBareDomains: true},
+			name: "foo.example.com",
+			want: false,
+		},
+		"wildcard subdomain is allowed": {
+			role: &internalvault.Role{AllowedDomains: []string{"example.com"}, AllowSubdomains: true},
+			name: "*.example.com",
+			want: true,
+		},
+		"wildcard certificates are not allowed": {
+			role: &internalvault.Role{AllowedDomains: []string{"example.com"}, AllowSubdomains: true, AllowWildcardCertificates: pointer.Bool(false)},
+			name: "*.example.com",
+			want: false,
+		},
+		"glob domain is allowed": {
+			role: &internalvault.Role{AllowedDomains: []string{"*.svc.cluster.local"}, AllowGlobDomains: true},
+			name: "foo.bar.svc.cluster.local",
+			want: true,
+		},
+		"glob domain is not used without allow_glob_domains": {
+			role: &internalvault.Role{AllowedDomains: []string{"*.svc.cluster.local"}},
+			name: "foo.bar.svc.cluster.local",
+			want: false,
+		},
+		"localhost is allowed": {
+			role: &internalvault.Role{AllowLocalhost: true},
+			name: "localhost",
+			want: true,
+		},
+		"other domain is not allowed": {
+			role: &internalvault.Role{AllowedDomains: []string{"example.com"}, AllowBareDomains: true, AllowSubdomains: true},
+			name: "foo.example.org",
+			want: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := vaultRoleAllowsName(test.role, test.name); got != test.want {
+				t.Errorf("vaultRoleAllowsName() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_vaultRoleAllowsKey(t *testing.T) {
+	tests := map[string]struct {
+		role       *internalvault.Role
+		privateKey *cmapi.CertificatePrivateKey
+		wantErr    bool
+	}{
+		"any key type is allowed": {
+			role:       &internalvault.Role{KeyType: "any"},
+			privateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.Ed25519KeyAlgorithm},
+		},
+		"default RSA key is allowed": {
+			role: &internalvault.Role{KeyType: "rsa", KeyBits: 2048},
+		},
+		"RSA key is too small": {
+			role:       &internalvault.Role{KeyType: "rsa", KeyBits: 4096},
+			privateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm, Size: 2048},
+			wantErr:    true,
+		},
+		"ECDSA key is allowed": {
+			role:       &internalvault.Role{KeyType: "ec", KeyBits: 256},
+			privateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+		},
+		"ECDSA key is not allowed": {
+			role:       &internalvault.Role{KeyType: "rsa"},
+			privateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+			wantErr:    true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := vaultRoleAllowsKey(test.role, test.privateKey)
+			if (err != nil) != test.wantErr {
+				t.Errorf("vaultRoleAllowsKey() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func Test_acmeHasSolverFor(t *testing.T) {
+	http01 := cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}}
+	dns01ForZone := cmacme.ACMEChallengeSolver{
+		DNS01:    &cmacme.ACMEChallengeSolverDNS01{},
+		Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}},
+	}
+	http01ForLabel := cmacme.ACMEChallengeSolver{
+		HTTP01:   &cmacme.ACMEChallengeSolverHTTP01{},
+		Selector: &cmacme.CertificateDNSNameSelector{MatchLabels: map[string]string{"solver": "http"}},
+	}
+
+	tests := map[string]struct {
+		crt        *cmapi.Certificate
+		identifier string
+		solvers    []cmacme.ACMEChallengeSolver
+		want       bool
+	}{
+		"solver without selector matches any identifier": {
+			crt:        gen.Certificate("cert"),
+			identifier: "foo.example.org",
+			solvers:    []cmacme.ACMEChallengeSolver{http01},
+			want:       true,
+		},
+		"wildcard identifier requires a DNS01 solver": {
+			crt:        gen.Certificate("cert"),
+			identifier: "*.example.com",
+			solvers:    []cmacme.ACMEChallengeSolver{http01},
+			want:       false,
+		},
+		"DNS01 solver matches wildcard identifier in its zone": {
+			crt:        gen.Certificate("cert"),
+			identifier: "*.example.com",
+			solvers:    []cmacme.ACMEChallengeSolver{http01, dns01ForZone},
+			want:       true,
+		},
+		"solver selecting another zone does not match": {
+			crt:        gen.Certificate("cert"),
+			identifier: "foo.example.org",
+			solvers:    []cmacme.ACMEChallengeSolver{dns01ForZone},
+			want:       false,
+		},
+		"solver selecting the labels of the Certificate matches": {
+			crt:        gen.Certificate("cert", gen.AddCertificateLabels(map[string]string{"solver": "http"})),
+			identifier: "foo.example.org",
+			solvers:    []cmacme.ACMEChallengeSolver{http01ForLabel},
+			want:       true,
+		},
+		"solver selecting other labels does not match": {
+			crt:        gen.Certificate("cert"),
+			identifier: "foo.example.org",
+			solvers:    []cmacme.ACMEChallengeSolver{http01ForLabel},
+			want:       false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := acmeHasSolverFor(test.crt, test.identifier, test.solvers); got != test.want {
+				t.Errorf("acmeHasSolverFor() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_globMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"foo", "foo", true},
+		{"foo", "bar", false},
+		{"*", "anything", true},
+		{"*.example.com", "a.b.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"spiffe://cluster.local/ns/*/sa/*", "spiffe://cluster.local/ns/default/sa/app", true},
+		{"spiffe://cluster.local/ns/*/sa/*", "spiffe://other/ns/default/sa/app", false},
+		{"a*a", "a", false},
+	}
+	for _, test := range tests {
+		if got := globMatch(test.pattern, test.s); got != test.want {
+			t.Errorf("globMatch(%q, %q) = %v, want %v", test.pattern, test.s, got, test.want)
+		}
+	}
+}
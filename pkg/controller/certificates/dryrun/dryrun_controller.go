@@ -0,0 +1,305 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	internalvault "github.com/cert-manager/cert-manager/internal/vault"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+const (
+	ControllerName = "certificates-dryrun"
+
+	reasonAccepted       = "Accepted"
+	reasonRejected       = "Rejected"
+	reasonUnsupported    = "Unsupported"
+	reasonIssuerNotFound = "IssuerNotFound"
+	reasonError          = "Error"
+)
+
+// This controller checks Certificates which have the
+// `cert-manager.io/issuance-dry-run` annotation set to "true" against the
+// policy of their issuer, and reports whether the issuer would sign them in
+// the `IssuanceDryRun` status condition. The 'trigger' controller never
+// issues these Certificates.
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
+	helper            issuer.Helper
+	client            cmclient.Interface
+	recorder          record.EventRecorder
+	queue             workqueue.RateLimitingInterface
+	issuerOptions     controllerpkg.IssuerOptions
+	metrics           *metrics.Metrics
+
+	// fieldManager is the string which will be used as the Field Manager on
+	// fields created or edited by the cert-manager Kubernetes client during
+	// Apply API calls.
+	fieldManager string
+
+	// The following are used for testing purposes.
+	// createTokenFn requests ServiceAccount tokens for Vault issuers using
+	// Kubernetes auth with a serviceAccountRef.
+	createTokenFn          func(ns string) internalvault.CreateToken
+	vaultRoleReaderBuilder func(string, func(ns string) internalvault.CreateToken, corelisters.SecretLister, cmapi.GenericIssuer) (internalvault.RoleReader, error)
+	venafiClientBuilder    venaficlient.VenafiClientBuilder
+}
+
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	issuerOptions controllerpkg.IssuerOptions,
+	namespace string,
+	metrics *metrics.Metrics,
+	fieldManager string,
+	rateLimiter workqueue.RateLimiter,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	issuerAliasInformer := cmFactory.Certmanager().V1().IssuerAliases()
+	secretsInformer := controllerpkg.SecretsMetadataInformer(factory)
+
+	c := &controller{
+		certificateLister: certificateInformer.Lister(),
+		secretLister:      controllerpkg.NewSecretGetter(factory),
+		client:            client,
+		recorder:          recorder,
+		queue:             queue,
+		issuerOptions:     issuerOptions,
+		metrics:           metrics,
+		fieldManager:      fieldManager,
+
+		vaultRoleReaderBuilder: internalvault.NewRoleReader,
+		venafiClientBuilder:    venaficlient.New,
+	}
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When the policy of an issuer changes, the result of the dry-runs of the
+	// Certificates using it may change as well.
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
+	issuerAliasInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		issuerAliasInformer.Informer().HasSynced,
+		secretsInformer.HasSynced,
+	}
+
+	// ClusterIssuers are only watched if we are not scoped to a single
+	// namespace.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	c.helper = issuer.NewAliasingHelper(issuerInformer.Lister(), clusterIssuerLister, issuerAliasInformer.Lister(), issuerOptions.ClusterResourceNamespace)
+
+	return c, queue, mustSync
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !isDryRun(crt) {
+		if apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuanceDryRun) == nil {
+			return nil
+		}
+		// The annotation has been removed, so the result of the last
+		// dry-run no longer applies.
+		crt = crt.DeepCopy()
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionIssuanceDryRun)
+		return c.applyStatus(ctx, crt)
+	}
+
+	genericIssuer, err := c.helper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if apierrors.IsNotFound(err) {
+		// The Certificate is re-queued once the issuer is created.
+		return c.setCondition(ctx, crt, cmmeta.ConditionUnknown, reasonIssuerNotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	err = c.dryRun(ctx, logf.WithRelatedResource(log, genericIssuer), crt, genericIssuer)
+	var rejected *rejection
+	switch {
+	case err == nil:
+		return c.setCondition(ctx, crt, cmmeta.ConditionTrue, reasonAccepted, "The Certificate would be accepted by the issuer")
+	case errors.As(err, &rejected):
+		return c.setCondition(ctx, crt, cmmeta.ConditionFalse, reasonRejected, rejected.message)
+	case errors.Is(err, errUnsupported):
+		return c.setCondition(ctx, crt, cmmeta.ConditionUnknown, reasonUnsupported, err.Error())
+	default:
+		// Failing to reach the issuer's backend is transient, so the
+		// dry-run is retried.
+		if condErr := c.setCondition(ctx, crt, cmmeta.ConditionUnknown, reasonError, "Failed to check the Certificate against the issuer's policy: "+err.Error()); condErr != nil {
+			return condErr
+		}
+		return err
+	}
+}
+
+// setCondition sets the IssuanceDryRun condition of the Certificate, and
+// fires an event if the result of the dry-run changed.
+func (c *controller) setCondition(ctx context.Context, crt *cmapi.Certificate, status cmmeta.ConditionStatus, reason, message string) error {
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuanceDryRun); cond != nil &&
+		cond.Status == status && cond.Reason == reason && cond.Message == message && cond.ObservedGeneration == crt.Generation {
+		return nil
+	}
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuanceDryRun, status, reason, message)
+	if err := c.applyStatus(ctx, crt); err != nil {
+		return err
+	}
+
+	eventType := corev1.EventTypeNormal
+	if status != cmmeta.ConditionTrue {
+		eventType = corev1.EventTypeWarning
+	}
+	c.recorder.Event(crt, eventType, "IssuanceDryRun"+reason, message)
+
+	return nil
+}
+
+// applyStatus applies the fields of the status which are managed by this
+// controller.
+func (c *controller) applyStatus(ctx context.Context, crt *cmapi.Certificate) error {
+	var conditions []cmapi.CertificateCondition
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuanceDryRun); cond != nil {
+		conditions = append(conditions, *cond)
+	}
+	return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+		Status:     cmapi.CertificateStatus{Conditions: conditions},
+	})
+}
+
+// handleGenericIssuer re-queues all Certificates in issuance dry-run mode
+// when an Issuer, ClusterIssuer or IssuerAlias changes. Dry-run mode is
+// only used for a handful of Certificates, so they are not filtered by the
+// issuer they reference.
+func (c *controller) handleGenericIssuer(obj interface{}) {
+	crts, err := c.certificateLister.List(labels.Everything())
+	if err != nil {
+		return
+	}
+	for _, crt := range crts {
+		if !isDryRun(crt) {
+			continue
+		}
+		key, err := controllerpkg.KeyFunc(crt)
+		if err != nil {
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// isDryRun returns true if the Certificate is in issuance dry-run mode.
+func isDryRun(crt *cmapi.Certificate) bool {
+	return crt.Annotations[cmapi.IssuanceDryRunAnnotation] == "true"
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.IssuerOptions,
+		ctx.Namespace,
+		ctx.Metrics,
+		ctx.FieldManager,
+		ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30),
+	)
+	ctrl.createTokenFn = internalvault.CreateTokenFromClient(ctx.Client)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.RegisterSharded(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	internalvault "github.com/cert-manager/cert-manager/internal/vault"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+type fakeRoleReader struct {
+	role *internalvault.Role
+	err  error
+}
+
+func (f *fakeRoleReader) ReadRole() (*internalvault.Role, error) {
+	return f.role, f.err
+}
+
+func Test_controller_ProcessItem(t *testing.T) {
+	fixedNow := metav1.NewTime(time.Now())
+	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+
+	dryRunAnnotation := map[string]string{"cert-manager.io/issuance-dry-run": "true"}
+	caIssuer := gen.Issuer("ca-issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}))
+	acmeIssuer := gen.Issuer("acme-issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerACME(cmacme.ACMEIssuer{
+		Solvers: []cmacme.ACMEChallengeSolver{{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}}},
+	}))
+	vaultIssuer := gen.Issuer("vault-issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerVault(cmapi.VaultIssuer{Path: "pki/sign/example"}))
+	certificate := func(issuerName string, mods ...gen.CertificateModifier) *cmapi.Certificate {
+		return gen.Certificate("cert-1", append([]gen.CertificateModifier{
+			gen.SetCertificateNamespace("testns"),
+			gen.SetCertificateGeneration(42),
+			gen.SetCertificateSecretName("secret-1"),
+			gen.SetCertificateDNSNames("foo.example.com"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: issuerName, Kind: "Issuer"}),
+		}, mods...)...)
+	}
+	condition := func(status cmmeta.ConditionStatus, reason, message string) cmapi.CertificateCondition {
+		return cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuanceDryRun,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: &fixedNow,
+			ObservedGeneration: 42,
+		}
+	}
+
+	tests := map[string]struct {
+		existingCertificate *cmapi.Certificate
+		existingIssuers     []*cmapi.Issuer
+		vaultRole           *internalvault.Role
+		vaultErr            error
+
+		// wantConditions is the expected set of conditions on the Certificate
+		// resource if an Update is made.
+		// If nil, no update is expected.
+		// If empty, an update to the empty set/nil is expected.
+		wantConditions []cmapi.CertificateCondition
+		wantEvent      string
+		wantErr        string
+	}{
+		"do nothing if the Certificate is not in dry-run mode": {
+			existingCertificate: certificate("ca-issuer"),
+			existingIssuers:     []*cmapi.Issuer{caIssuer},
+		},
+		"remove the IssuanceDryRun condition once the annotation is removed": {
+			existingCertificate: certificate("ca-issuer",
+				gen.SetCertificateStatusCondition(condition(cmmeta.ConditionTrue, reasonAccepted, "The Certificate would be accepted by the issuer"))),
+			existingIssuers: []*cmapi.Issuer{caIssuer},
+			wantConditions:  []cmapi.CertificateCondition{},
+		},
+		"set IssuanceDryRun=Unknown if the issuer does not exist": {
+			existingCertificate: certificate("ca-issuer", gen.AddCertificateAnnotations(dryRunAnnotation)),
+			wantConditions: []cmapi.CertificateCondition{
+				condition(cmmeta.ConditionUnknown, reasonIssuerNotFound, `issuer.cert-manager.io "ca-issuer" not found`),
+			},
+			wantEvent: `Warning IssuanceDryRunIssuerNotFound issuer.cert-manager.io "ca-issuer" not found`,
+		},
+		"set IssuanceDryRun=True if the CA issuer would sign the Certificate": {
+			existingCertificate: certificate("ca-issuer", gen.AddCertificateAnnotations(dryRunAnnotation)),
+			existingIssuers:     []*cmapi.Issuer{caIssuer},
+			wantConditions: []cmapi.CertificateCondition{
+				condition(cmmeta.ConditionTrue, reasonAccepted, "The Certificate would be accepted by the issuer"),
+			},
+			wantEvent: "Normal IssuanceDryRunAccepted The Certificate would be accepted by the issuer",
+		},
+		"do nothing if the IssuanceDryRun condition is up to date": {
+			existingCertificate: certificate("ca-issuer", gen.AddCertificateAnnotations(dryRunAnnotation),
+				gen.SetCertificateStatusCondition(condition(cmmeta.ConditionTrue, reasonAccepted, "The Certificate would be accepted by the issuer"))),
+			existingIssuers: []*cmapi.Issuer{caIssuer},
+		},
+		"set IssuanceDryRun=False if the ACME issuer cannot issue URI SANs": {
+			existingCertificate: certificate("acme-issuer", gen.AddCertificateAnnotations(dryRunAnnotation),
+				gen.SetCertificateURIs("spiffe://example.com/foo")),
+			existingIssuers: []*cmapi.Issuer{acmeIssuer},
+			wantConditions: []cmapi.CertificateCondition{
+				condition(cmmeta.ConditionFalse, reasonRejected, "ACME servers cannot issue certificates with URI SANs: spiffe://example.com/foo"),
+			},
+			wantEvent: "Warning IssuanceDryRunRejected ACME servers cannot issue certificates with URI SANs: spiffe://example.com/foo",
+		},
+		"set IssuanceDryRun=False if the Vault role does not allow the DNS names": {
+			existingCertificate: certificate("vault-issuer", gen.AddCertificateAnnotations(dryRunAnnotation)),
+			existingIssuers:     []*cmapi.Issuer{vaultIssuer},
+			vaultRole:           &internalvault.Role{AllowedDomains: []string{"example.org"}, AllowSubdomains: true},
+			wantConditions: []cmapi.CertificateCondition{
+				condition(cmmeta.ConditionFalse, reasonRejected, `The Vault role does not allow the name "foo.example.com"`),
+			},
+			wantEvent: `Warning IssuanceDryRunRejected The Vault role does not allow the name "foo.example.com"`,
+		},
+		"set IssuanceDryRun=Unknown and retry if the Vault role cannot be read": {
+			existingCertificate: certificate("vault-issuer", gen.AddCertificateAnnotations(dryRunAnnotation)),
+			existingIssuers:     []*cmapi.Issuer{vaultIssuer},
+			vaultErr:            errors.New("connection refused"),
+			wantConditions: []cmapi.CertificateCondition{
+				condition(cmmeta.ConditionUnknown, reasonError, "Failed to check the Certificate against the issuer's policy: connection refused"),
+			},
+			wantEvent: "Warning IssuanceDryRunError Failed to check the Certificate against the issuer's policy: connection refused",
+			wantErr:   "connection refused",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:     t,
+				Clock: fixedClock,
+			}
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.existingCertificate)
+			for _, iss := range test.existingIssuers {
+				builder.CertManagerObjects = append(builder.CertManagerObjects, iss)
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.vaultRoleReaderBuilder = func(string, func(ns string) internalvault.CreateToken, corelisters.SecretLister, cmapi.GenericIssuer) (internalvault.RoleReader, error) {
+				return &fakeRoleReader{role: test.vaultRole, err: test.vaultErr}, nil
+			}
+
+			if test.wantConditions != nil {
+				expectedCert := test.existingCertificate.DeepCopy()
+				expectedCert.Status.Conditions = test.wantConditions
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						test.existingCertificate.Namespace,
+						expectedCert,
+					),
+				)
+			}
+			if test.wantEvent != "" {
+				builder.ExpectedEvents = []string{test.wantEvent}
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.existingCertificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotErr := w.controller.ProcessItem(context.Background(), key)
+			switch {
+			case gotErr != nil:
+				if test.wantErr != gotErr.Error() {
+					t.Errorf("error text did not match, got=%s, exp=%s", gotErr.Error(), test.wantErr)
+				}
+			default:
+				if test.wantErr != "" {
+					t.Errorf("got no error but expected: %s", test.wantErr)
+				}
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
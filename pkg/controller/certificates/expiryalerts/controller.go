@@ -0,0 +1,223 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expiryalerts
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/clocksource"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/scheduler"
+)
+
+const (
+	// ControllerName is the name of the controller setting the ExpiringSoon
+	// condition of Certificates.
+	ControllerName = "certificates-expiry-alerts"
+)
+
+// controller sets the ExpiringSoon condition of Certificates whose
+// certificate expires within one of the expiry alert thresholds, and fires
+// a warning event each time a smaller threshold is crossed. The expiry is
+// taken from the Certificate's status, so Certificates whose renewal is
+// failing or is scheduled too late are alerted on as well.
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	client            cmclient.Interface
+	recorder          record.EventRecorder
+	metrics           *metrics.Metrics
+	thresholds        []time.Duration
+
+	clock              clock.Clock
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
+
+	// fieldManager is the string which will be used as the Field Manager on
+	// fields created or edited by the cert-manager Kubernetes client during
+	// Apply API calls.
+	fieldManager string
+}
+
+// NewController returns a new Certificate expiry alerts controller.
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	metrics *metrics.Metrics,
+	thresholds []time.Duration,
+	clock clock.Clock,
+	fieldManager string,
+	rateLimiter workqueue.RateLimiter,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ControllerName)
+
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+
+	return &controller{
+		certificateLister:  certificateInformer.Lister(),
+		client:             client,
+		recorder:           recorder,
+		metrics:            metrics,
+		thresholds:         sortThresholds(thresholds),
+		clock:              clock,
+		scheduledWorkQueue: scheduler.NewScheduledWorkQueue(clock, queue.Add),
+		fieldManager:       fieldManager,
+	}, queue, []cache.InformerSynced{certificateInformer.Informer().HasSynced}
+}
+
+// ProcessItem sets the ExpiringSoon condition of the Certificate, and
+// schedules it to be processed again when its certificate crosses the next
+// threshold.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		c.metrics.RemoveExpiringSoon(cmapi.CertificateKind, namespace, name)
+		c.scheduledWorkQueue.Forget(key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if crt.Status.NotAfter == nil {
+		// The Certificate has not been issued yet, or its Secret has been
+		// deleted, which its Ready condition already reports.
+		c.metrics.RemoveExpiringSoon(cmapi.CertificateKind, namespace, name)
+		if apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionExpiringSoon) == nil {
+			return nil
+		}
+		crt = crt.DeepCopy()
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionExpiringSoon)
+		return c.applyStatus(ctx, crt)
+	}
+	notAfter := crt.Status.NotAfter.Time
+
+	now := c.clock.Now()
+	e := checkExpiry(c.thresholds, notAfter, now)
+	if !e.next.IsZero() {
+		log.V(logf.DebugLevel).Info("scheduling expiry alert check", "notAfter", notAfter, "duration", e.next.Sub(now).String())
+		c.scheduledWorkQueue.Add(key, e.next.Sub(now))
+	}
+
+	if e.within {
+		c.metrics.UpdateExpiringSoon(cmapi.CertificateKind, namespace, name, e.threshold)
+	} else {
+		c.metrics.RemoveExpiringSoon(cmapi.CertificateKind, namespace, name)
+	}
+
+	status := cmmeta.ConditionFalse
+	if e.within {
+		status = cmmeta.ConditionTrue
+	}
+	return c.setCondition(ctx, crt, status, e.reason(), e.message(notAfter))
+}
+
+// setCondition sets the ExpiringSoon condition of the Certificate, and
+// fires a warning event if the certificate has crossed a new threshold.
+func (c *controller) setCondition(ctx context.Context, crt *cmapi.Certificate, status cmmeta.ConditionStatus, reason, message string) error {
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionExpiringSoon); cond != nil &&
+		cond.Status == status && cond.Reason == reason && cond.Message == message && cond.ObservedGeneration == crt.Generation {
+		return nil
+	}
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionExpiringSoon, status, reason, message)
+	if err := c.applyStatus(ctx, crt); err != nil {
+		return err
+	}
+
+	if status == cmmeta.ConditionTrue {
+		c.recorder.Event(crt, corev1.EventTypeWarning, reason, message)
+	}
+
+	return nil
+}
+
+// applyStatus applies the fields of the status which are managed by this
+// controller.
+func (c *controller) applyStatus(ctx context.Context, crt *cmapi.Certificate) error {
+	var conditions []cmapi.CertificateCondition
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionExpiringSoon); cond != nil {
+		conditions = append(conditions, *cond)
+	}
+	return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+		Status:     cmapi.CertificateStatus{Conditions: conditions},
+	})
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Metrics,
+		ctx.CertificateOptions.ExpiryAlertThresholds,
+		clocksource.WithSkewTolerance(ctx.Clock, ctx.ClockSkewTolerance),
+		ctx.FieldManager,
+		ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30),
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.RegisterSharded(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expiryalerts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var testThresholds = []time.Duration{7 * 24 * time.Hour, 30 * 24 * time.Hour, 14 * 24 * time.Hour}
+
+func Test_controller_ProcessItem(t *testing.T) {
+	fixedNow := metav1.NewTime(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+
+	certificate := func(mods ...gen.CertificateModifier) *cmapi.Certificate {
+		return gen.Certificate("cert-1", append([]gen.CertificateModifier{
+			gen.SetCertificateNamespace("testns"),
+			gen.SetCertificateGeneration(42),
+			gen.SetCertificateSecretName("secret-1"),
+		}, mods...)...)
+	}
+	expiresIn := func(d time.Duration) gen.CertificateModifier {
+		return gen.SetCertificateNotAfter(metav1.NewTime(fixedNow.Add(d)))
+	}
+	condition := func(status cmmeta.ConditionStatus, reason, message string) cmapi.CertificateCondition {
+		return cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionExpiringSoon,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: &fixedNow,
+			ObservedGeneration: 42,
+		}
+	}
+
+	tests := map[string]struct {
+		existingCertificate *cmapi.Certificate
+
+		// wantConditions is the expected set of conditions on the Certificate
+		// resource if an Update is made.
+		// If nil, no update is expected.
+		// If empty, an update to the empty set/nil is expected.
+		wantConditions []cmapi.CertificateCondition
+		wantEvent      string
+	}{
+		"do nothing if the Certificate has not been issued": {
+			existingCertificate: certificate(),
+		},
+		"remove the ExpiringSoon condition if the Certificate no longer has a certificate": {
+			existingCertificate: certificate(
+				gen.SetCertificateStatusCondition(condition(cmmeta.ConditionTrue, reasonExpired, "The certificate expired at 2022-05-31T12:00:00Z"))),
+			wantConditions: []cmapi.CertificateCondition{},
+		},
+		"set ExpiringSoon=False if the certificate does not expire within any threshold": {
+			existingCertificate: certificate(expiresIn(60 * 24 * time.Hour)),
+			wantConditions: []cmapi.CertificateCondition{
+				condition(cmmeta.ConditionFalse, reasonNotExpiringSoon, "The certificate expires at 2022-07-31T12:00:00Z, which is not within any expiry alert threshold"),
+			},
+		},
+		"set ExpiringSoon=True and fire an event if the certificate expires within 30 days": {
+			existingCertificate: certificate(expiresIn(20 * 24 * time.Hour)),
+			wantConditions: []cmapi.CertificateCondition{
+				condition(cmmeta.ConditionTrue, reasonWithinThreshold, "The certificate expires at 2022-06-21T12:00:00Z, which is within the 30 days expiry alert threshold"),
+			},
+			wantEvent: "Warning WithinThreshold The certificate expires at 2022-06-21T12:00:00Z, which is within the 30 days expiry alert threshold",
+		},
+		"fire another event once the certificate crosses a smaller threshold": {
+			existingCertificate: certificate(expiresIn(6*24*time.Hour),
+				gen.SetCertificateStatusCondition(condition(cmmeta.ConditionTrue, reasonWithinThreshold, "The certificate expires at 2022-06-07T12:00:00Z, which is within the 14 days expiry alert threshold"))),
+			wantConditions: []cmapi.CertificateCondition{
+				condition(cmmeta.ConditionTrue, reasonWithinThreshold, "The certificate expires at 2022-06-07T12:00:00Z, which is within the 7 days expiry alert threshold"),
+			},
+			wantEvent: "Warning WithinThreshold The certificate expires at 2022-06-07T12:00:00Z, which is within the 7 days expiry alert threshold",
+		},
+		"do nothing if the ExpiringSoon condition is up to date": {
+			existingCertificate: certificate(expiresIn(20*24*time.Hour),
+				gen.SetCertificateStatusCondition(condition(cmmeta.ConditionTrue, reasonWithinThreshold, "The certificate expires at 2022-06-21T12:00:00Z, which is within the 30 days expiry alert threshold"))),
+		},
+		"set ExpiringSoon=False without an event once the certificate is renewed": {
+			existingCertificate: certificate(expiresIn(90*24*time.Hour),
+				gen.SetCertificateStatusCondition(condition(cmmeta.ConditionTrue, reasonWithinThreshold, "The certificate expires at 2022-06-07T12:00:00Z, which is within the 7 days expiry alert threshold"))),
+			wantConditions: []cmapi.CertificateCondition{
+				condition(cmmeta.ConditionFalse, reasonNotExpiringSoon, "The certificate expires at 2022-08-30T12:00:00Z, which is not within any expiry alert threshold"),
+			},
+		},
+		"set ExpiringSoon=True with the Expired reason if the certificate has expired": {
+			existingCertificate: certificate(expiresIn(-time.Hour)),
+			wantConditions: []cmapi.CertificateCondition{
+				condition(cmmeta.ConditionTrue, reasonExpired, "The certificate expired at 2022-06-01T11:00:00Z"),
+			},
+			wantEvent: "Warning Expired The certificate expired at 2022-06-01T11:00:00Z",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:     t,
+				Clock: fixedClock,
+			}
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.existingCertificate)
+			builder.Init()
+			builder.Context.CertificateOptions.ExpiryAlertThresholds = testThresholds
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			if test.wantConditions != nil {
+				expectedCert := test.existingCertificate.DeepCopy()
+				expectedCert.Status.Conditions = test.wantConditions
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						test.existingCertificate.Namespace,
+						expectedCert,
+					),
+				)
+			}
+			if test.wantEvent != "" {
+				builder.ExpectedEvents = []string{test.wantEvent}
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), "testns/cert-1"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expiryalerts
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/internal/controller/clocksource"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/scheduler"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// SecretsControllerName is the name of the controller alerting on the
+	// expiry of TLS Secrets which are not managed by cert-manager.
+	SecretsControllerName = "secrets-expiry-alerts"
+
+	secretKind = "Secret"
+)
+
+// alert is the expiry alert last fired for a Secret.
+type alert struct {
+	notAfter  time.Time
+	threshold time.Duration
+}
+
+// secretsController fires a warning event on TLS Secrets which are not
+// managed by a Certificate each time their certificate crosses a smaller
+// expiry alert threshold. Secrets have no status, so unlike Certificates the
+// alerts are only exposed through events and metrics.
+type secretsController struct {
+	secretLister corelisters.SecretLister
+	recorder     record.EventRecorder
+	metrics      *metrics.Metrics
+	thresholds   []time.Duration
+
+	clock              clock.Clock
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
+
+	// alerted holds the alert last fired for each Secret, so that the event
+	// is only fired once per threshold. It is not persisted: the event is
+	// fired again for Secrets within a threshold when the controller
+	// restarts.
+	alertedLock sync.Mutex
+	alerted     map[string]alert
+}
+
+// NewSecretsController returns a new unmanaged TLS Secret expiry alerts
+// controller.
+func NewSecretsController(
+	log logr.Logger,
+	factory informers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	metrics *metrics.Metrics,
+	thresholds []time.Duration,
+	clock clock.Clock,
+	rateLimiter workqueue.RateLimiter,
+) (*secretsController, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, SecretsControllerName)
+
+	secretsInformer := controllerpkg.SecretsMetadataInformer(factory)
+	secretsInformer.AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+
+	return &secretsController{
+		secretLister:       controllerpkg.NewSecretGetter(factory),
+		recorder:           recorder,
+		metrics:            metrics,
+		thresholds:         sortThresholds(thresholds),
+		clock:              clock,
+		scheduledWorkQueue: scheduler.NewScheduledWorkQueue(clock, queue.Add),
+		alerted:            make(map[string]alert),
+	}, queue, []cache.InformerSynced{secretsInformer.HasSynced}
+}
+
+// ProcessItem fires a warning event for the Secret if it is an unmanaged TLS
+// Secret whose certificate has crossed a new threshold, and schedules it to
+// be processed again when it crosses the next one.
+func (c *secretsController) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	secret, err := c.secretLister.Secrets(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		c.forget(key, namespace, name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !isUnmanagedTLSSecret(secret) {
+		// The expiry of Secrets managed by a Certificate is alerted on by
+		// the Certificate expiry alerts controller.
+		c.forget(key, namespace, name)
+		return nil
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		log.V(logf.DebugLevel).Info("ignoring TLS Secret which does not hold a valid certificate", "error", err.Error())
+		c.forget(key, namespace, name)
+		return nil
+	}
+
+	now := c.clock.Now()
+	e := checkExpiry(c.thresholds, cert.NotAfter, now)
+	if !e.next.IsZero() {
+		log.V(logf.DebugLevel).Info("scheduling expiry alert check", "notAfter", cert.NotAfter, "duration", e.next.Sub(now).String())
+		c.scheduledWorkQueue.Add(key, e.next.Sub(now))
+	}
+
+	if !e.within {
+		c.metrics.RemoveExpiringSoon(secretKind, namespace, name)
+		c.setAlerted(key, nil)
+		return nil
+	}
+
+	c.metrics.UpdateExpiringSoon(secretKind, namespace, name, e.threshold)
+	if c.setAlerted(key, &alert{notAfter: cert.NotAfter, threshold: e.threshold}) {
+		c.recorder.Event(secret, corev1.EventTypeWarning, e.reason(), e.message(cert.NotAfter))
+	}
+
+	return nil
+}
+
+// setAlerted records the alert last fired for the Secret, or that it has no
+// alert if nil. It returns true if the alert differs from the last one.
+func (c *secretsController) setAlerted(key string, a *alert) bool {
+	c.alertedLock.Lock()
+	defer c.alertedLock.Unlock()
+	last, ok := c.alerted[key]
+	if a == nil {
+		delete(c.alerted, key)
+		return ok
+	}
+	if ok && last.notAfter.Equal(a.notAfter) && last.threshold == a.threshold {
+		return false
+	}
+	c.alerted[key] = *a
+	return true
+}
+
+func (c *secretsController) forget(key, namespace, name string) {
+	c.setAlerted(key, nil)
+	c.metrics.RemoveExpiringSoon(secretKind, namespace, name)
+	c.scheduledWorkQueue.Forget(key)
+}
+
+// isUnmanagedTLSSecret returns true if the Secret is a TLS Secret which is
+// not managed by a Certificate.
+func isUnmanagedTLSSecret(secret *corev1.Secret) bool {
+	if secret.Type != corev1.SecretTypeTLS {
+		return false
+	}
+	_, managed := secret.Annotations[cmapi.CertificateNameKey]
+	return !managed
+}
+
+// secretsControllerWrapper wraps the `secretsController` structure to make
+// it implement the controllerpkg.queueingController interface
+type secretsControllerWrapper struct {
+	*secretsController
+}
+
+func (c *secretsControllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, SecretsControllerName)
+
+	ctrl, queue, mustSync := NewSecretsController(log,
+		ctx.KubeSharedInformerFactory,
+		ctx.Recorder,
+		ctx.Metrics,
+		ctx.CertificateOptions.ExpiryAlertThresholds,
+		clocksource.WithSkewTolerance(ctx.Clock, ctx.ClockSkewTolerance),
+		ctx.RateLimiterFor(SecretsControllerName, time.Second*1, time.Second*30),
+	)
+	c.secretsController = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(SecretsControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, SecretsControllerName).
+			For(&secretsControllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expiryalerts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func Test_secretsController_ProcessItem(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	tlsSecret := func(notAfter time.Time, mods ...gen.SecretModifier) *corev1.Secret {
+		cert := testcrypto.MustCreateCertWithNotBeforeAfter(t, pk,
+			gen.Certificate("test", gen.SetCertificateCommonName("example.com")),
+			notAfter.Add(-90*24*time.Hour), notAfter)
+		secret := gen.Secret("secret-1", append([]gen.SecretModifier{
+			gen.SetSecretNamespace("testns"),
+			gen.SetSecretData(map[string][]byte{
+				corev1.TLSCertKey:       cert,
+				corev1.TLSPrivateKeyKey: pk,
+			}),
+		}, mods...)...)
+		secret.Type = corev1.SecretTypeTLS
+		return secret
+	}
+
+	tests := map[string]struct {
+		existingSecret *corev1.Secret
+		// alerted is the alert already fired for the Secret.
+		alerted *alert
+
+		wantEvent string
+	}{
+		"do nothing if the Secret does not exist": {},
+		"do nothing if the Secret is managed by a Certificate": {
+			existingSecret: tlsSecret(now.Add(time.Hour),
+				gen.SetSecretAnnotations(map[string]string{cmapi.CertificateNameKey: "cert-1"})),
+		},
+		"do nothing if the Secret is not a TLS Secret": {
+			existingSecret: func() *corev1.Secret {
+				secret := tlsSecret(now.Add(time.Hour))
+				secret.Type = corev1.SecretTypeOpaque
+				return secret
+			}(),
+		},
+		"do nothing if the Secret does not hold a valid certificate": {
+			existingSecret: func() *corev1.Secret {
+				secret := tlsSecret(now.Add(time.Hour))
+				secret.Data[corev1.TLSCertKey] = []byte("not a certificate")
+				return secret
+			}(),
+		},
+		"do nothing if the certificate does not expire within any threshold": {
+			existingSecret: tlsSecret(now.Add(60 * 24 * time.Hour)),
+		},
+		"fire an event if the certificate expires within 14 days": {
+			existingSecret: tlsSecret(now.Add(10 * 24 * time.Hour)),
+			wantEvent:      "Warning WithinThreshold The certificate expires at 2022-06-11T12:00:00Z, which is within the 14 days expiry alert threshold",
+		},
+		"do not fire the event again for the same threshold": {
+			existingSecret: tlsSecret(now.Add(10 * 24 * time.Hour)),
+			alerted:        &alert{notAfter: now.Add(10 * 24 * time.Hour), threshold: 14 * 24 * time.Hour},
+		},
+		"fire another event once the certificate crosses a smaller threshold": {
+			existingSecret: tlsSecret(now.Add(5 * 24 * time.Hour)),
+			alerted:        &alert{notAfter: now.Add(5 * 24 * time.Hour), threshold: 14 * 24 * time.Hour},
+			wantEvent:      "Warning WithinThreshold The certificate expires at 2022-06-06T12:00:00Z, which is within the 7 days expiry alert threshold",
+		},
+		"fire an event if the certificate has expired": {
+			existingSecret: tlsSecret(now.Add(-time.Hour)),
+			wantEvent:      "Warning Expired The certificate expired at 2022-06-01T11:00:00Z",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:     t,
+				Clock: fakeclock.NewFakeClock(now),
+			}
+			if test.existingSecret != nil {
+				builder.KubeObjects = []runtime.Object{test.existingSecret}
+			}
+			if test.wantEvent != "" {
+				builder.ExpectedEvents = []string{test.wantEvent}
+			}
+			builder.Init()
+			builder.Context.CertificateOptions.ExpiryAlertThresholds = testThresholds
+
+			w := &secretsControllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			if test.alerted != nil {
+				w.secretsController.alerted["testns/secret-1"] = *test.alerted
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.secretsController.ProcessItem(context.Background(), "testns/secret-1"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
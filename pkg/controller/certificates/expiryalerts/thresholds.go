@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expiryalerts
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	reasonWithinThreshold = "WithinThreshold"
+	reasonExpired         = "Expired"
+	reasonNotExpiringSoon = "NotExpiringSoon"
+)
+
+// expiry describes how close a certificate is to expiring.
+type expiry struct {
+	// within is true if the certificate expires within one of the
+	// thresholds, or has already expired.
+	within bool
+	// threshold is the smallest threshold the certificate expires within,
+	// or zero if it has expired.
+	threshold time.Duration
+	// next is the time at which the certificate crosses the next threshold
+	// or expires. It is zero once the certificate has expired.
+	next time.Time
+}
+
+// sortThresholds returns the positive thresholds ordered from the largest
+// to the smallest, without duplicates.
+func sortThresholds(thresholds []time.Duration) []time.Duration {
+	sorted := make([]time.Duration, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		if threshold > 0 {
+			sorted = append(sorted, threshold)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+
+	deduped := sorted[:0]
+	for i, threshold := range sorted {
+		if i > 0 && threshold == sorted[i-1] {
+			continue
+		}
+		deduped = append(deduped, threshold)
+	}
+	return deduped
+}
+
+// checkExpiry returns how close a certificate expiring at notAfter is to
+// expiring at the time now. The thresholds must be sorted by
+// sortThresholds.
+func checkExpiry(thresholds []time.Duration, notAfter, now time.Time) expiry {
+	if !now.Before(notAfter) {
+		return expiry{within: true}
+	}
+
+	e := expiry{next: notAfter}
+	for _, threshold := range thresholds {
+		crossAt := notAfter.Add(-threshold)
+		if now.Before(crossAt) {
+			e.next = crossAt
+			break
+		}
+		e.within = true
+		e.threshold = threshold
+	}
+	return e
+}
+
+// reason returns the reason of the condition or event describing the
+// expiry.
+func (e expiry) reason() string {
+	switch {
+	case !e.within:
+		return reasonNotExpiringSoon
+	case e.threshold == 0:
+		return reasonExpired
+	default:
+		return reasonWithinThreshold
+	}
+}
+
+// message returns a human readable description of the expiry. It does not
+// change until a threshold is crossed, so that it can be compared to decide
+// whether to fire an event.
+func (e expiry) message(notAfter time.Time) string {
+	expires := notAfter.UTC().Format(time.RFC3339)
+	switch {
+	case !e.within:
+		return fmt.Sprintf("The certificate expires at %s, which is not within any expiry alert threshold", expires)
+	case e.threshold == 0:
+		return fmt.Sprintf("The certificate expired at %s", expires)
+	default:
+		return fmt.Sprintf("The certificate expires at %s, which is within the %s expiry alert threshold", expires, formatThreshold(e.threshold))
+	}
+}
+
+// formatThreshold formats thresholds which are a whole number of days in
+// days, as they usually are.
+func formatThreshold(threshold time.Duration) string {
+	const day = 24 * time.Hour
+	if threshold%day != 0 {
+		return threshold.String()
+	}
+	if threshold == day {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", threshold/day)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expiryalerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sortThresholds(t *testing.T) {
+	const day = 24 * time.Hour
+	assert.Equal(t,
+		[]time.Duration{30 * day, 14 * day, 7 * day},
+		sortThresholds([]time.Duration{7 * day, 30 * day, 0, 14 * day, 7 * day, -day}))
+}
+
+func Test_checkExpiry(t *testing.T) {
+	const day = 24 * time.Hour
+	thresholds := []time.Duration{30 * day, 14 * day, 7 * day}
+	notAfter := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		now  time.Time
+		want expiry
+	}{
+		"not within any threshold": {
+			now:  notAfter.Add(-60 * day),
+			want: expiry{next: notAfter.Add(-30 * day)},
+		},
+		"exactly at the largest threshold": {
+			now:  notAfter.Add(-30 * day),
+			want: expiry{within: true, threshold: 30 * day, next: notAfter.Add(-14 * day)},
+		},
+		"within the smallest threshold": {
+			now:  notAfter.Add(-day),
+			want: expiry{within: true, threshold: 7 * day, next: notAfter},
+		},
+		"expired": {
+			now:  notAfter,
+			want: expiry{within: true},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, checkExpiry(thresholds, notAfter, test.now))
+		})
+	}
+}
+
+func Test_formatThreshold(t *testing.T) {
+	assert.Equal(t, "30 days", formatThreshold(30*24*time.Hour))
+	assert.Equal(t, "1 day", formatThreshold(24*time.Hour))
+	assert.Equal(t, "36h0m0s", formatThreshold(36*time.Hour))
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expirynotifications
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/internal/controller/clocksource"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/notifications"
+	"github.com/cert-manager/cert-manager/pkg/scheduler"
+)
+
+const (
+	// ControllerName is the name of the controller notifying sinks of
+	// certificates which are about to expire.
+	ControllerName = "certificates-expiry-notifications"
+)
+
+// controller sends an Expiring event once the certificate of a Certificate
+// is within the expiry warning period. Certificates are normally renewed
+// before then, so the event means that renewal is failing.
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	notifier          notifications.Notifier
+	expiryWarning     time.Duration
+
+	clock              clock.Clock
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
+
+	// notified holds the expiry time of the certificate each Certificate was
+	// last notified about, so that the event is only sent once per
+	// certificate. It is not persisted: the event is sent again for
+	// Certificates which have not expired when the controller restarts.
+	notifiedLock sync.Mutex
+	notified     map[string]time.Time
+}
+
+// NewController returns a new expiry notifications controller.
+func NewController(
+	log logr.Logger,
+	cmFactory cminformers.SharedInformerFactory,
+	notifier notifications.Notifier,
+	expiryWarning time.Duration,
+	clock clock.Clock,
+	rateLimiter workqueue.RateLimiter,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ControllerName)
+
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+
+	if notifier == nil {
+		notifier = notifications.Discard
+	}
+	if expiryWarning <= 0 {
+		expiryWarning = notifications.DefaultExpiryWarning
+	}
+
+	return &controller{
+		certificateLister:  certificateInformer.Lister(),
+		notifier:           notifier,
+		expiryWarning:      expiryWarning,
+		clock:              clock,
+		scheduledWorkQueue: scheduler.NewScheduledWorkQueue(clock, queue.Add),
+		notified:           make(map[string]time.Time),
+	}, queue, []cache.InformerSynced{certificateInformer.Informer().HasSynced}
+}
+
+// ProcessItem sends an Expiring event for the Certificate if its
+// certificate is within the expiry warning period, or schedules the
+// Certificate to be processed again once it is.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		c.forget(key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if crt.Status.NotAfter == nil {
+		return nil
+	}
+	notAfter := crt.Status.NotAfter.Time
+
+	now := c.clock.Now()
+	if !now.Before(notAfter) {
+		// The Certificate's Ready condition already reports that the
+		// certificate has expired.
+		return nil
+	}
+
+	warnAt := notAfter.Add(-c.expiryWarning)
+	if now.Before(warnAt) {
+		log.V(logf.DebugLevel).Info("scheduling expiry notification", "notAfter", notAfter, "duration", warnAt.Sub(now).String())
+		c.scheduledWorkQueue.Add(key, warnAt.Sub(now))
+		return nil
+	}
+
+	if !c.markNotified(key, notAfter) {
+		return nil
+	}
+
+	log.V(logf.DebugLevel).Info("certificate is about to expire, sending notification", "notAfter", notAfter)
+	event := notifications.NewEvent(notifications.EventExpiring, now, crt, nil)
+	event.Reason = "Expiring"
+	event.Message = "The certificate expires on " + notAfter.UTC().Format(time.RFC3339) + " and has not been renewed"
+	c.notifier.Notify(event)
+
+	return nil
+}
+
+// markNotified records that the Certificate has been notified about the
+// certificate expiring at notAfter. It returns false if it already had.
+func (c *controller) markNotified(key string, notAfter time.Time) bool {
+	c.notifiedLock.Lock()
+	defer c.notifiedLock.Unlock()
+	if last, ok := c.notified[key]; ok && last.Equal(notAfter) {
+		return false
+	}
+	c.notified[key] = notAfter
+	return true
+}
+
+func (c *controller) forget(key string) {
+	c.notifiedLock.Lock()
+	defer c.notifiedLock.Unlock()
+	delete(c.notified, key)
+	c.scheduledWorkQueue.Forget(key)
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.SharedInformerFactory,
+		ctx.CertificateOptions.Notifier,
+		ctx.CertificateOptions.NotificationExpiryWarning,
+		clocksource.WithSkewTolerance(ctx.Clock, ctx.ClockSkewTolerance),
+		ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30),
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.RegisterSharded(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
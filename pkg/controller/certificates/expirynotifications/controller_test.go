@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expirynotifications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/notifications"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// recordingNotifier records the events it is notified of.
+type recordingNotifier struct {
+	events []notifications.Event
+}
+
+func (n *recordingNotifier) Notify(event notifications.Event) {
+	n.events = append(n.events, event)
+}
+
+func TestProcessItem(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	const expiryWarning = 72 * time.Hour
+
+	withNotAfter := func(notAfter time.Time) *cmapi.Certificate {
+		return gen.Certificate("test",
+			gen.SetCertificateNamespace("default"),
+			gen.SetCertificateSecretName("test-tls"),
+			gen.SetCertificateNotAfter(metav1.NewTime(notAfter)),
+		)
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		// notified is the expiry time of the certificate the Certificate
+		// has already been notified about.
+		notified *time.Time
+
+		expectNotification bool
+	}{
+		"do nothing if the Certificate does not exist": {},
+		"do nothing if the Certificate has no certificate": {
+			certificate: gen.Certificate("test", gen.SetCertificateNamespace("default")),
+		},
+		"do nothing if the certificate does not expire within the warning period": {
+			certificate: withNotAfter(now.Add(expiryWarning + time.Hour)),
+		},
+		"notify if the certificate expires within the warning period": {
+			certificate:        withNotAfter(now.Add(expiryWarning - time.Hour)),
+			expectNotification: true,
+		},
+		"do not notify again about the same certificate": {
+			certificate: withNotAfter(now.Add(time.Hour)),
+			notified:    timePtr(now.Add(time.Hour)),
+		},
+		"notify about a certificate which replaced one already notified about": {
+			certificate:        withNotAfter(now.Add(2 * time.Hour)),
+			notified:           timePtr(now.Add(time.Hour)),
+			expectNotification: true,
+		},
+		"do nothing if the certificate has expired": {
+			certificate: withNotAfter(now.Add(-time.Hour)),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:     t,
+				Clock: fakeclock.NewFakeClock(now),
+			}
+			if test.certificate != nil {
+				builder.CertManagerObjects = []runtime.Object{test.certificate}
+			}
+			builder.Init()
+
+			notifier := &recordingNotifier{}
+			builder.Context.CertificateOptions.Notifier = notifier
+			builder.Context.CertificateOptions.NotificationExpiryWarning = expiryWarning
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			if test.notified != nil {
+				w.controller.notified["default/test"] = *test.notified
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), "default/test"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !test.expectNotification {
+				if len(notifier.events) > 0 {
+					t.Errorf("expected no notification, got %+v", notifier.events)
+				}
+				return
+			}
+			if len(notifier.events) != 1 {
+				t.Fatalf("expected one notification, got %d", len(notifier.events))
+			}
+			event := notifier.events[0]
+			if event.Type != notifications.EventExpiring {
+				t.Errorf("expected an %s event, got %s", notifications.EventExpiring, event.Type)
+			}
+			if event.Certificate.Name != "test" || event.Certificate.SecretName != "test-tls" ||
+				event.Certificate.NotAfter == nil || !event.Certificate.NotAfter.Equal(test.certificate.Status.NotAfter.Time) {
+				t.Errorf("unexpected certificate in event: %+v", event.Certificate)
+			}
+
+			// The Certificate is only notified about once.
+			if err := w.controller.ProcessItem(context.Background(), "default/test"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(notifier.events) != 1 {
+				t.Errorf("expected the Certificate to be notified about once, got %d notifications", len(notifier.events))
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -24,15 +24,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	coreclient "k8s.io/client-go/kubernetes/typed/core/v1"
-	corelisters "k8s.io/client-go/listers/core/v1"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -45,7 +46,7 @@ var (
 // SecretsManager creates and updates secrets with certificate and key data.
 type SecretsManager struct {
 	secretClient coreclient.SecretsGetter
-	secretLister corelisters.SecretLister
+	secretGetter *controllerpkg.SecretGetter
 
 	// fieldManager is the manager name used for the Apply operations on Secrets.
 	fieldManager string
@@ -60,6 +61,13 @@ type SecretsManager struct {
 // SecretData is a structure wrapping private key, Certificate and CA data
 type SecretData struct {
 	PrivateKey, Certificate, CA []byte
+
+	// CertificateRequestName, CertificateRequestUID and
+	// CertificateRequestRevision identify the CertificateRequest that the
+	// certificate was issued from. They are empty for temporary certificates.
+	CertificateRequestName     string
+	CertificateRequestUID      types.UID
+	CertificateRequestRevision string
 }
 
 // NewSecretsManager returns a new SecretsManager. Setting
@@ -67,13 +75,13 @@ type SecretData struct {
 // when the corresponding Certificate is deleted.
 func NewSecretsManager(
 	secretClient coreclient.SecretsGetter,
-	secretLister corelisters.SecretLister,
+	secretGetter *controllerpkg.SecretGetter,
 	fieldManager string,
 	enableSecretOwnerReferences bool,
 ) *SecretsManager {
 	return &SecretsManager{
 		secretClient:                secretClient,
-		secretLister:                secretLister,
+		secretGetter:                secretGetter,
 		fieldManager:                fieldManager,
 		enableSecretOwnerReferences: enableSecretOwnerReferences,
 	}
@@ -92,7 +100,7 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 	log := logf.FromContext(ctx).WithName("secrets_manager")
 	log = logf.WithResource(log, secret)
 
-	if err := s.setValues(crt, secret, data); err != nil {
+	if err := s.setValues(ctx, crt, secret, data); err != nil {
 		return err
 	}
 
@@ -132,8 +140,8 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 // setValues will NOT actually update the resource in the apiserver.
 // It will also update depreciated issuer name and kind annotations if they
 // exist.
-func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret, data SecretData) error {
-	if err := s.setKeystores(crt, secret, data); err != nil {
+func (s *SecretsManager) setValues(ctx context.Context, crt *cmapi.Certificate, secret *corev1.Secret, data SecretData) error {
+	if err := s.setKeystores(ctx, crt, secret, data); err != nil {
 		return fmt.Errorf("failed to add keystores to Secret: %w", err)
 	}
 
@@ -142,6 +150,9 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 		if err := setAdditionalOutputFormats(crt, secret, data); err != nil {
 			return fmt.Errorf("failed to add additional output formats to Secret: %w", err)
 		}
+		for k, v := range certificates.SecretFormatProfileData(crt.Spec.SecretFormatProfile, data.PrivateKey, data.Certificate, data.CA) {
+			secret.Data[k] = v
+		}
 	}
 
 	secret.Data[corev1.TLSPrivateKeyKey] = data.PrivateKey
@@ -161,9 +172,15 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 	}
 
 	secret.Annotations = certificates.AnnotationsForCertificateSecret(crt, certificate)
+	if len(data.CertificateRequestName) > 0 {
+		for k, v := range certificates.AnnotationsForCertificateRequest(data.CertificateRequestName, data.CertificateRequestUID, data.CertificateRequestRevision) {
+			secret.Annotations[k] = v
+		}
+	}
 	if secret.Labels == nil {
 		secret.Labels = make(map[string]string)
 	}
+	secret.Labels[cmapi.PartOfCertManagerControllerLabelKey] = "true"
 
 	if crt.Spec.SecretTemplate != nil {
 		for k, v := range crt.Spec.SecretTemplate.Labels {
@@ -181,7 +198,7 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 // applied. Only the Secret Type will be persisted from the original Secret.
 func (s *SecretsManager) getCertificateSecret(ctx context.Context, crt *cmapi.Certificate) (*corev1.Secret, error) {
 	// Get existing secret if it exists.
-	existingSecret, err := s.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	existingSecret, err := s.secretGetter.Get(ctx, crt.Namespace, crt.Spec.SecretName)
 
 	// If secret doesn't exist yet, return an empty secret that should be
 	// created.
@@ -218,11 +235,11 @@ func (s *SecretsManager) getCertificateSecret(ctx context.Context, crt *cmapi.Ce
 
 // setKeystores will set extra Secret Data keys according to any Keystores
 // which have been configured.
-func (s *SecretsManager) setKeystores(crt *cmapi.Certificate, secret *corev1.Secret, data SecretData) error {
+func (s *SecretsManager) setKeystores(ctx context.Context, crt *cmapi.Certificate, secret *corev1.Secret, data SecretData) error {
 	// Handle the experimental PKCS12 support
 	if crt.Spec.Keystores != nil && crt.Spec.Keystores.PKCS12 != nil && crt.Spec.Keystores.PKCS12.Create {
 		ref := crt.Spec.Keystores.PKCS12.PasswordSecretRef
-		pwSecret, err := s.secretLister.Secrets(crt.Namespace).Get(ref.Name)
+		pwSecret, err := s.secretGetter.Get(ctx, crt.Namespace, ref.Name)
 		if err != nil {
 			return fmt.Errorf("fetching PKCS12 keystore password from Secret: %v", err)
 		}
@@ -235,7 +252,7 @@ func (s *SecretsManager) setKeystores(crt *cmapi.Certificate, secret *corev1.Sec
 			return fmt.Errorf("error encoding PKCS12 bundle: %w", err)
 		}
 		// always overwrite the keystore entry for now
-		secret.Data[pkcs12SecretKey] = keystoreData
+		secret.Data[cmapi.PKCS12SecretKey] = keystoreData
 
 		if len(data.CA) > 0 {
 			truststoreData, err := encodePKCS12Truststore(string(pw), data.CA)
@@ -243,14 +260,14 @@ func (s *SecretsManager) setKeystores(crt *cmapi.Certificate, secret *corev1.Sec
 				return fmt.Errorf("error encoding PKCS12 trust store bundle: %w", err)
 			}
 			// always overwrite the truststore entry
-			secret.Data[pkcs12TruststoreKey] = truststoreData
+			secret.Data[cmapi.PKCS12TruststoreKey] = truststoreData
 		}
 	}
 
 	// Handle the experimental JKS support
 	if crt.Spec.Keystores != nil && crt.Spec.Keystores.JKS != nil && crt.Spec.Keystores.JKS.Create {
 		ref := crt.Spec.Keystores.JKS.PasswordSecretRef
-		pwSecret, err := s.secretLister.Secrets(crt.Namespace).Get(ref.Name)
+		pwSecret, err := s.secretGetter.Get(ctx, crt.Namespace, ref.Name)
 		if err != nil {
 			return fmt.Errorf("fetching JKS keystore password from Secret: %v", err)
 		}
@@ -263,7 +280,7 @@ func (s *SecretsManager) setKeystores(crt *cmapi.Certificate, secret *corev1.Sec
 			return fmt.Errorf("error encoding JKS bundle: %w", err)
 		}
 		// always overwrite the keystore entry
-		secret.Data[jksSecretKey] = keystoreData
+		secret.Data[cmapi.JKSSecretKey] = keystoreData
 
 		if len(data.CA) > 0 {
 			truststoreData, err := encodeJKSTruststore(pw, data.CA)
@@ -271,7 +288,7 @@ func (s *SecretsManager) setKeystores(crt *cmapi.Certificate, secret *corev1.Sec
 				return fmt.Errorf("error encoding JKS trust store bundle: %w", err)
 			}
 			// always overwrite the keystore entry
-			secret.Data[jksTruststoreKey] = truststoreData
+			secret.Data[cmapi.JKSTruststoreKey] = truststoreData
 		}
 	}
 
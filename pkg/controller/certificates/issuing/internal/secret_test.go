@@ -18,6 +18,8 @@ package internal
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/pem"
 	"errors"
 	"strings"
@@ -71,6 +73,9 @@ func Test_SecretsManager(t *testing.T) {
 	baseCertBundle := testcrypto.MustCreateCryptoBundle(t, gen.CertificateFrom(baseCert,
 		gen.SetCertificateDNSNames("example.com"),
 	), fixedClock)
+	baseCertSerialNumber := baseCertBundle.Cert.SerialNumber.Text(16)
+	baseCertFingerprint := sha256.Sum256(baseCertBundle.Cert.Raw)
+	baseCertFingerprintSHA256 := hex.EncodeToString(baseCertFingerprint[:])
 
 	baseCertWithSecretTemplate := gen.CertificateFrom(baseCertBundle.Certificate,
 		gen.SetCertificateSecretTemplate(map[string]string{
@@ -87,6 +92,9 @@ func Test_SecretsManager(t *testing.T) {
 	baseCertWithAdditionalOutputFormatCombinedPEM := gen.CertificateFrom(baseCertBundle.Certificate,
 		gen.SetCertificateAdditionalOutputFormats(cmapi.CertificateAdditionalOutputFormat{Type: "CombinedPEM"}),
 	)
+	baseCertWithHAProxyProfile := gen.CertificateFrom(baseCertBundle.Certificate,
+		gen.SetCertificateSecretFormatProfile(cmapi.SecretFormatProfileHAProxy),
+	)
 	baseCertWithAdditionalOutputFormats := gen.CertificateFrom(baseCertBundle.Certificate,
 		gen.SetCertificateAdditionalOutputFormats(
 			cmapi.CertificateAdditionalOutputFormat{Type: "DER"},
@@ -134,10 +142,11 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
 								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName, cmapi.AltNamesAnnotationKey: strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:  strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey: strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:       baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey: []byte("test-key"),
@@ -155,6 +164,44 @@ func Test_SecretsManager(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if the CertificateRequest is known, record it in the Secret's annotations": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			existingSecret:     nil,
+			secretData: SecretData{
+				Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key"),
+				CertificateRequestName: "test-1", CertificateRequestUID: apitypes.UID("cr-uid"), CertificateRequestRevision: "1",
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
+						WithAnnotations(
+							map[string]string{
+								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
+								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
+
+								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName, cmapi.AltNamesAnnotationKey: strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
+
+								cmapi.CertificateRequestNameAnnotationKey: "test-1", cmapi.CertificateRequestUIDAnnotationKey: "cr-uid",
+								cmapi.CertificateRequestRevisionAnnotationKey: "1",
+							}).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
+						WithData(map[string][]byte{
+							corev1.TLSCertKey:       baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey: []byte("test-key"),
+							cmmeta.TLSCAKey:         []byte("test-ca"),
+						}).
+						WithType(corev1.SecretTypeTLS)
+					assert.Equal(t, expCnf, gotCnf)
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
 		"if secret does not exist, create new Secret, with owner enabled": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: true},
 			certificate:        baseCertBundle.Certificate,
@@ -170,9 +217,10 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io", cmapi.IssuerKindAnnotationKey: "Issuer",
 								cmapi.IssuerNameAnnotationKey: "ca-issuer", cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
 								cmapi.AltNamesAnnotationKey: strings.Join(baseCertBundle.Cert.DNSNames, ","), cmapi.IPSANAnnotationKey: strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey: strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{corev1.TLSCertKey: baseCertBundle.CertBytes, corev1.TLSPrivateKeyKey: []byte("test-key"), cmmeta.TLSCAKey: []byte("test-ca")}).
 						WithType(corev1.SecretTypeTLS).
 						WithOwnerReferences(&applymetav1.OwnerReferenceApplyConfiguration{
@@ -213,12 +261,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:       baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey: []byte("test-key"),
@@ -258,12 +307,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:       baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey: []byte("test-key"),
@@ -310,12 +360,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
 							}).
-						WithLabels(map[string]string{"template": "label"}).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true", "template": "label"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:       baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey: []byte("test-key"),
@@ -349,12 +400,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
 							}).
-						WithLabels(map[string]string{"template": "label"}).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true", "template": "label"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:       baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey: []byte("test-key"),
@@ -390,12 +442,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:                   baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey:             baseCertBundle.PrivateKeyBytes,
@@ -427,12 +480,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:                           baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey:                     baseCertBundle.PrivateKeyBytes,
@@ -451,6 +505,44 @@ func Test_SecretsManager(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if secret does not exist, create new Secret with the HAProxy secret format profile": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertWithHAProxyProfile,
+			existingSecret:     nil,
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
+						WithAnnotations(
+							map[string]string{
+								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
+								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
+
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
+							}).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
+						WithData(map[string][]byte{
+							corev1.TLSCertKey:                   baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey:             baseCertBundle.PrivateKeyBytes,
+							cmmeta.TLSCAKey:                     []byte("test-ca"),
+							cmapi.SecretFormatProfileHAProxyKey: []byte(strings.Join([]string{string(baseCertBundle.CertBytes), string(baseCertBundle.PrivateKeyBytes)}, "\n")),
+						}).
+						WithType(corev1.SecretTypeTLS)
+					assert.Equal(t, expCnf, gotCnf)
+
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					assert.Equal(t, expOpts, gotOpts)
+
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
 		"if secret does not exist, create new Secret with additional output format DER and CombinedPEM": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
 			certificate:        baseCertWithAdditionalOutputFormats,
@@ -464,12 +556,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:                           baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey:                     baseCertBundle.PrivateKeyBytes,
@@ -519,12 +612,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:       baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey: baseCertBundle.PrivateKeyBytes,
@@ -572,12 +666,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:                   baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey:             baseCertBundle.PrivateKeyBytes,
@@ -626,12 +721,13 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.SerialNumberAnnotationKey: baseCertSerialNumber, cmapi.FingerprintSHA256AnnotationKey: baseCertFingerprintSHA256,
 							}).
-						WithLabels(make(map[string]string)).
+						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
 							corev1.TLSCertKey:                           baseCertBundle.CertBytes,
 							corev1.TLSPrivateKeyKey:                     baseCertBundle.PrivateKeyBytes,
@@ -678,7 +774,7 @@ func Test_SecretsManager(t *testing.T) {
 			secretLister := testcorelisters.NewFakeSecretLister(mod)
 
 			testManager := NewSecretsManager(
-				secretClient, secretLister,
+				secretClient, controllerpkg.NewCachedSecretGetter(secretLister),
 				"cert-manager-test",
 				test.certificateOptions.EnableOwnerRef,
 			)
@@ -763,7 +859,7 @@ func Test_getCertificateSecret(t *testing.T) {
 
 			s := SecretsManager{
 				secretClient: builder.Client.CoreV1(),
-				secretLister: builder.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+				secretGetter: controllerpkg.NewCachedSecretGetter(builder.KubeSharedInformerFactory.Core().V1().Secrets().Lister()),
 				fieldManager: "cert-manager-test",
 			}
 
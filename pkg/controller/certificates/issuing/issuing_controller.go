@@ -20,16 +20,17 @@ import (
 	"context"
 	"crypto"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
-	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -37,7 +38,6 @@ import (
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
-	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -48,14 +48,24 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
-	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/notifications"
 	utilkube "github.com/cert-manager/cert-manager/pkg/util/kube"
 	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+	"github.com/cert-manager/cert-manager/pkg/util/tracing"
 )
 
 const (
 	ControllerName = "certificates-issuing"
+
+	// reasonMalformedIssuerResponse is the reason of the Issuing condition
+	// when the certificate returned by an issuer cannot be stored.
+	reasonMalformedIssuerResponse = "MalformedIssuerResponse"
+
+	// reasonIssued is the reason of the Issuing condition, and of the event,
+	// once the certificate has been stored in the Secret.
+	reasonIssued = "Issued"
 )
 
 type localTemporarySignerFn func(crt *cmapi.Certificate, pk []byte) ([]byte, error)
@@ -66,9 +76,10 @@ type localTemporarySignerFn func(crt *cmapi.Certificate, pk []byte) ([]byte, err
 type controller struct {
 	certificateLister        cmlisters.CertificateLister
 	certificateRequestLister cmlisters.CertificateRequestLister
-	secretLister             corelisters.SecretLister
+	secretGetter             *controllerpkg.SecretGetter
 	recorder                 record.EventRecorder
 	clock                    clock.Clock
+	metrics                  *metrics.Metrics
 
 	client cmclient.Interface
 
@@ -88,6 +99,10 @@ type controller struct {
 
 	// localTemporarySigner signs a certificate that is stored temporarily
 	localTemporarySigner localTemporarySignerFn
+
+	// notifier is notified when certificates are issued, renewed or fail to
+	// be issued.
+	notifier notifications.Notifier
 }
 
 func NewController(
@@ -98,29 +113,34 @@ func NewController(
 	cmFactory cminformers.SharedInformerFactory,
 	recorder record.EventRecorder,
 	clock clock.Clock,
+	metrics *metrics.Metrics,
 	certificateControllerOptions controllerpkg.CertificateOptions,
 	fieldManager string,
+	rateLimiter workqueue.RateLimiter,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
 
-	// create a queue used to queue up items to be processed
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
-
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
-	secretsInformer := factory.Core().V1().Secrets()
+	// The data of Secrets is read with a SecretGetter, so the handlers are
+	// registered against the metadata of all Secrets.
+	secretsMetadataInformer := controllerpkg.SecretsMetadataInformer(factory)
+
+	// create a queue used to queue up items to be processed, in which
+	// Certificates which are about to expire are processed first
+	queue := certificates.NewPriorityQueue(rateLimiter, ControllerName, certificateInformer.Lister(), clock)
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(), predicate.ResourceOwnerOf),
 	})
-	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+	secretsMetadataInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Issuer reconciles on changes to the Secret named `spec.nextPrivateKeySecretName`
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
 			predicate.ResourceOwnerOf,
 			predicate.ExtractResourceName(predicate.CertificateNextPrivateKeySecretName)),
 	})
-	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+	secretsMetadataInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Issuer reconciles on changes to the Secret named `spec.secretName`
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
 			predicate.ExtractResourceName(predicate.CertificateSecretName)),
@@ -130,22 +150,29 @@ func NewController(
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		certificateRequestInformer.Informer().HasSynced,
-		secretsInformer.Informer().HasSynced,
+		secretsMetadataInformer.HasSynced,
 		certificateInformer.Informer().HasSynced,
 	}
 
+	notifier := certificateControllerOptions.Notifier
+	if notifier == nil {
+		notifier = notifications.Discard
+	}
+
+	secretGetter := controllerpkg.NewSecretGetter(factory)
 	secretsManager := internal.NewSecretsManager(
-		kubeClient.CoreV1(), secretsInformer.Lister(),
+		kubeClient.CoreV1(), secretGetter,
 		fieldManager, certificateControllerOptions.EnableOwnerRef,
 	)
 
 	return &controller{
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
-		secretLister:             secretsInformer.Lister(),
+		secretGetter:             secretGetter,
 		client:                   client,
 		recorder:                 recorder,
 		clock:                    clock,
+		metrics:                  metrics,
 		secretsUpdateData:        secretsManager.UpdateData,
 		postIssuancePolicyChain: policies.NewSecretPostIssuancePolicyChain(
 			certificateControllerOptions.EnableOwnerRef,
@@ -153,6 +180,7 @@ func NewController(
 		),
 		fieldManager:         fieldManager,
 		localTemporarySigner: certificates.GenerateLocallySignedTemporaryCertificate,
+		notifier:             notifier,
 	}, queue, mustSync
 }
 
@@ -198,7 +226,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	// Fetch and parse the 'next private key secret'
-	nextPrivateKeySecret, err := c.secretLister.Secrets(crt.Namespace).Get(*crt.Status.NextPrivateKeySecretName)
+	nextPrivateKeySecret, err := c.secretGetter.Get(ctx, crt.Namespace, *crt.Status.NextPrivateKeySecretName)
 	if apierrors.IsNotFound(err) {
 		log.V(logf.DebugLevel).Info("Next private key secret does not exist, waiting for keymanager controller")
 		// If secret does not exist, do nothing (keymanager will handle this).
@@ -325,6 +353,14 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	// If the CertificateRequest is valid and ready, verify its status and issue
 	// accordingly.
 	if crReadyCond.Reason == cmapi.CertificateRequestReasonIssued {
+		// Don't store a certificate which cannot be used with the private
+		// key, or which doesn't chain to the returned CA, in the Secret.
+		if err := verifyIssuedCertificate(req, pk); err != nil {
+			return c.failIssueCertificate(ctx, log, crt, &cmapi.CertificateRequestCondition{
+				Reason:  reasonMalformedIssuerResponse,
+				Message: err.Error(),
+			})
+		}
 		return c.issueCertificate(ctx, nextRevision, crt, req, pk)
 	}
 
@@ -345,6 +381,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 // an appropriate event. The reason and message of the Issuing condition will be that of
 // the CertificateRequest condition passed.
 func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger, crt *cmapi.Certificate, condition *cmapi.CertificateRequestCondition) error {
+	issuanceDuration := c.issuanceDuration(crt)
 	nowTime := metav1.NewTime(c.clock.Now())
 	crt.Status.LastFailureTime = &nowTime
 
@@ -364,11 +401,16 @@ func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger,
 	crt = crt.DeepCopy()
 	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, reason, message)
 
-	if err := c.updateOrApplyStatus(ctx, crt, false); err != nil {
+	if err := c.applyStatus(ctx, crt); err != nil {
 		return err
 	}
 
 	c.recorder.Event(crt, corev1.EventTypeWarning, reason, message)
+	c.metrics.ObserveCertificateIssuance(crt.Spec.IssuerRef, metrics.IssuanceResultFailure, reason, issuanceDuration)
+
+	event := notifications.NewEvent(notifications.EventFailed, nowTime.Time, crt, nil)
+	event.Reason, event.Message = reason, message
+	c.notifier.Notify(event)
 
 	return nil
 }
@@ -376,7 +418,13 @@ func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger,
 // issueCertificate will ensure the public key of the CSR matches the signed
 // certificate, and then store the certificate, CA and private key into the
 // Secret in the appropriate format type.
-func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt *cmapi.Certificate, req *cmapi.CertificateRequest, pk crypto.Signer) error {
+func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt *cmapi.Certificate, req *cmapi.CertificateRequest, pk crypto.Signer) (err error) {
+	// Continue the trace started when the CertificateRequest was created.
+	ctx, span := tracing.Start(tracing.Extract(ctx, req.Annotations), crt, "Certificate.Issue",
+		append(tracing.IssuerRefAttributes(crt.Spec.IssuerRef), attribute.Int("cert-manager.revision", nextRevision))...)
+	defer func() { tracing.End(span, err) }()
+
+	issuanceDuration := c.issuanceDuration(crt)
 	crt = crt.DeepCopy()
 	if crt.Spec.PrivateKey == nil {
 		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
@@ -386,10 +434,20 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 	if err != nil {
 		return err
 	}
+
+	// The usage of the private key has to be determined before the Secret is
+	// updated with the new private key.
+	if err := c.recordPrivateKeyIssuance(ctx, crt, pk); err != nil {
+		return err
+	}
+
 	secretData := internal.SecretData{
-		PrivateKey:  pkData,
-		Certificate: req.Status.Certificate,
-		CA:          req.Status.CA,
+		PrivateKey:                 pkData,
+		Certificate:                req.Status.Certificate,
+		CA:                         req.Status.CA,
+		CertificateRequestName:     req.Name,
+		CertificateRequestUID:      req.UID,
+		CertificateRequestRevision: strconv.Itoa(nextRevision),
 	}
 
 	if err := c.secretsUpdateData(ctx, crt, secretData); err != nil {
@@ -399,10 +457,8 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 	//Set status.revision to revision of the CertificateRequest
 	crt.Status.Revision = &nextRevision
 
-	// Remove Issuing status condition
-	// TODO @joshvanl: Once we move to only server-side apply API calls, this
-	// should be changed to setting the Issuing condition to False.
-	apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	// Set the Issuing status condition to False
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, reasonIssued, "The certificate has been successfully issued")
 
 	// Clear status.failedIssuanceAttempts (if set)
 	crt.Status.FailedIssuanceAttempts = nil
@@ -410,51 +466,98 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 	// Clear status.lastFailureTime (if set)
 	crt.Status.LastFailureTime = nil
 
-	if err := c.updateOrApplyStatus(ctx, crt, true); err != nil {
+	if err := c.applyStatus(ctx, crt); err != nil {
 		return err
 	}
 
 	message := "The certificate has been successfully issued"
 	c.recorder.Event(crt, corev1.EventTypeNormal, "Issuing", message)
+	c.metrics.ObserveCertificateIssuance(crt.Spec.IssuerRef, metrics.IssuanceResultSuccess, cmapi.CertificateRequestReasonIssued, issuanceDuration)
 
-	return nil
+	eventType := notifications.EventRenewed
+	if nextRevision == 1 {
+		eventType = notifications.EventIssued
+	}
+	// The event is sent without the details of the certificate if it cannot
+	// be decoded.
+	x509Cert, _ := utilpki.DecodeX509CertificateBytes(req.Status.Certificate)
+	event := notifications.NewEvent(eventType, c.clock.Now(), crt, x509Cert)
+	event.Reason, event.Message = reasonIssued, message
+	c.notifier.Notify(event)
 
+	return nil
 }
 
-// updateOrApplyStatus will update the controller status. If the
-// ServerSideApply feature is enabled, the managed fields will instead get
-// applied using the relevant Patch API call.
-// conditionRemove should be true if the Issuing condition has been removed by
-// this controller. If the ServerSideApply feature is enabled and condition
-// have been removed, the Issuing condition will be set to False before
-// applying.
-func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate, conditionRemoved bool) error {
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		// TODO @joshvanl: Once we move to only server-side apply API calls,
-		// `conditionRemoved` can be removed and setting the Issuing condition to
-		// False can be moved to the `issueCertificate` func.
-		if conditionRemoved {
-			message := "The certificate has been successfully issued"
-			apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, "Issued", message)
-		}
+// recordPrivateKeyIssuance records the issuance of a certificate for the
+// given private key on the status of Certificates using the Scheduled rotation
+// policy, so that it can be determined when the private key is due for
+// rotation. The usage is tracked from scratch whenever the private key differs
+// from the one stored in the Certificate's Secret. It is not tracked for other
+// rotation policies.
+func (c *controller) recordPrivateKeyIssuance(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer) error {
+	if crt.Spec.PrivateKey.RotationPolicy != cmapi.RotationPolicyScheduled {
+		crt.Status.PrivateKeyIssuedTime = nil
+		crt.Status.PrivateKeyIssuances = nil
+		return nil
+	}
 
-		var conditions []cmapi.CertificateCondition
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
-			conditions = []cmapi.CertificateCondition{*cond}
+	reused := false
+	secret, err := c.secretGetter.Get(ctx, crt.Namespace, crt.Spec.SecretName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if secret != nil {
+		// A private key which cannot be decoded is replaced, so it is
+		// treated like a different private key.
+		if current, err := utilpki.DecodePrivateKeyBytes(secret.Data[corev1.TLSPrivateKeyKey]); err == nil {
+			reused, err = utilpki.PublicKeysEqual(current.Public(), pk.Public())
+			if err != nil {
+				return err
+			}
 		}
+	}
 
-		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
-			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
-			Status: cmapi.CertificateStatus{
-				Revision:        crt.Status.Revision,
-				LastFailureTime: crt.Status.LastFailureTime,
-				Conditions:      conditions,
-			},
-		})
-	} else {
-		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
-		return err
+	if reused && crt.Status.PrivateKeyIssuedTime != nil && crt.Status.PrivateKeyIssuances != nil {
+		issuances := *crt.Status.PrivateKeyIssuances + 1
+		crt.Status.PrivateKeyIssuances = &issuances
+		return nil
+	}
+	issuances := 1
+	issuedTime := metav1.NewTime(c.clock.Now())
+	crt.Status.PrivateKeyIssuances = &issuances
+	crt.Status.PrivateKeyIssuedTime = &issuedTime
+	return nil
+}
+
+// issuanceDuration returns the time since the Certificate's Issuing condition
+// was set to True, or 0 if it is not known.
+func (c *controller) issuanceDuration(crt *cmapi.Certificate) time.Duration {
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	if cond == nil || cond.Status != cmmeta.ConditionTrue || cond.LastTransitionTime == nil {
+		return 0
 	}
+	return c.clock.Since(cond.LastTransitionTime.Time)
+}
+
+// applyStatus applies the fields of the status which are managed by this
+// controller.
+func (c *controller) applyStatus(ctx context.Context, crt *cmapi.Certificate) error {
+	var conditions []cmapi.CertificateCondition
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
+		conditions = []cmapi.CertificateCondition{*cond}
+	}
+
+	return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+		Status: cmapi.CertificateStatus{
+			Revision:               crt.Status.Revision,
+			LastFailureTime:        crt.Status.LastFailureTime,
+			FailedIssuanceAttempts: crt.Status.FailedIssuanceAttempts,
+			PrivateKeyIssuedTime:   crt.Status.PrivateKeyIssuedTime,
+			PrivateKeyIssuances:    crt.Status.PrivateKeyIssuances,
+			Conditions:             conditions,
+		},
+	})
 }
 
 // controllerWrapper wraps the `controller` structure to make it implement
@@ -474,8 +577,10 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.SharedInformerFactory,
 		ctx.Recorder,
 		ctx.Clock,
+		ctx.Metrics,
 		ctx.CertificateOptions,
 		ctx.FieldManager,
+		ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30),
 	)
 	c.controller = ctrl
 
@@ -483,7 +588,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 }
 
 func init() {
-	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+	controllerpkg.RegisterSharded(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
 		return controllerpkg.NewBuilder(ctx, ControllerName).
 			For(&controllerWrapper{}).
 			Complete()
@@ -18,6 +18,7 @@ package issuing
 
 import (
 	"context"
+	"crypto/rsa"
 	"fmt"
 	"testing"
 	"time"
@@ -27,15 +28,18 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	coretesting "k8s.io/client-go/testing"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/notifications"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
@@ -57,6 +61,9 @@ func TestIssuingController(t *testing.T) {
 
 		certificate             *cmapi.Certificate
 		expSecretUpdateDataCall *internal.SecretData
+		// expNotifications are the types of the events the notifier is
+		// expected to be notified of.
+		expNotifications []notifications.EventType
 
 		expectedErr bool
 	}
@@ -277,9 +284,8 @@ func TestIssuingController(t *testing.T) {
 					},
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
-						"status",
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
@@ -293,13 +299,14 @@ func TestIssuingController(t *testing.T) {
 							gen.SetCertificateLastFailureTime(metaFixedClockStart),
 							gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
 						),
-					)),
+					),
 				},
 				ExpectedEvents: []string{
 					"Warning Failed The certificate request has failed to complete and will be retried: The certificate request failed because of reasons",
 				},
 			},
-			expectedErr: false,
+			expNotifications: []notifications.EventType{notifications.EventFailed},
+			expectedErr:      false,
 		},
 		"if certificate is in Issuing state, one CertificateRequest, and has failed for the fifth time during this series of attempts, set failed state with five issuance attempts and log event": {
 			certificate: exampleBundle.Certificate,
@@ -329,9 +336,8 @@ func TestIssuingController(t *testing.T) {
 					},
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
-						"status",
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
@@ -345,13 +351,14 @@ func TestIssuingController(t *testing.T) {
 							gen.SetCertificateLastFailureTime(metaFixedClockStart),
 							gen.SetCertificateIssuanceAttempts(pointer.Int(5)),
 						),
-					)),
+					),
 				},
 				ExpectedEvents: []string{
 					"Warning Failed The certificate request has failed to complete and will be retried: The certificate request failed because of reasons",
 				},
 			},
-			expectedErr: false,
+			expNotifications: []notifications.EventType{notifications.EventFailed},
+			expectedErr:      false,
 		},
 		"if certificate is in Issuing state, one CertificateRequest, but has failed, but the private key does not exist, do nothing": {
 			certificate: exampleBundle.Certificate,
@@ -493,25 +500,84 @@ func TestIssuingController(t *testing.T) {
 					},
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
-						"status",
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateRevision(2),
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Issued",
+								Message:            "The certificate has been successfully issued",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
 						),
-					)),
+					),
 				},
 				ExpectedEvents: []string{
 					"Normal Issuing The certificate has been successfully issued",
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
-				PrivateKey:  exampleBundle.PrivateKeyBytes,
-				CA:          nil,
+				Certificate:                exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:                 exampleBundle.PrivateKeyBytes,
+				CA:                         nil,
+				CertificateRequestName:     exampleBundle.CertificateRequestReady.Name,
+				CertificateRequestUID:      exampleBundle.CertificateRequestReady.UID,
+				CertificateRequestRevision: "2",
 			},
-			expectedErr: false,
+			expNotifications: []notifications.EventType{notifications.EventRenewed},
+			expectedErr:      false,
+		},
+
+		"if certificate is in Issuing state, one CertificateRequest, and is ready, but the signed certificate does not match the private key, set failed state and log event": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestCertificate(exampleBundleAlt.CertBytes),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewApplyStatusAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "MalformedIssuerResponse",
+								Message:            "The certificate request has failed to complete and will be retried: the issued certificate does not match the private key",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+						),
+					),
+				},
+				ExpectedEvents: []string{
+					"Warning MalformedIssuerResponse The certificate request has failed to complete and will be retried: the issued certificate does not match the private key",
+				},
+			},
+			expNotifications: []notifications.EventType{notifications.EventFailed},
+			expectedErr:      false,
 		},
 
 		"if certificate is in Issuing state, one CertificateRequests, and is ready, store the signed certificate, ca, and private key to an existing secret, and log an event": {
@@ -547,25 +613,36 @@ func TestIssuingController(t *testing.T) {
 					},
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
-						"status",
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateRevision(2),
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Issued",
+								Message:            "The certificate has been successfully issued",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
 						),
-					)),
+					),
 				},
 				ExpectedEvents: []string{
 					"Normal Issuing The certificate has been successfully issued",
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
-				PrivateKey:  exampleBundle.PrivateKeyBytes,
-				CA:          nil,
+				Certificate:                exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:                 exampleBundle.PrivateKeyBytes,
+				CA:                         nil,
+				CertificateRequestName:     exampleBundle.CertificateRequestReady.Name,
+				CertificateRequestUID:      exampleBundle.CertificateRequestReady.UID,
+				CertificateRequestRevision: "2",
 			},
-			expectedErr: false,
+			expNotifications: []notifications.EventType{notifications.EventRenewed},
+			expectedErr:      false,
 		},
 		"if certificate is in Issuing state, one ready CertificateRequest and has last failure time set from previous issuance, set the Issuing condition to true, remove last failure time and store the signed certificate, ca, and private key to an existing secret, and log an event": {
 			certificate: exampleBundle.Certificate,
@@ -600,25 +677,36 @@ func TestIssuingController(t *testing.T) {
 					},
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
-						"status",
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateRevision(2),
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Issued",
+								Message:            "The certificate has been successfully issued",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
 						),
-					)),
+					),
 				},
 				ExpectedEvents: []string{
 					"Normal Issuing The certificate has been successfully issued",
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
-				PrivateKey:  exampleBundle.PrivateKeyBytes,
-				CA:          nil,
+				Certificate:                exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:                 exampleBundle.PrivateKeyBytes,
+				CA:                         nil,
+				CertificateRequestName:     exampleBundle.CertificateRequestReady.Name,
+				CertificateRequestUID:      exampleBundle.CertificateRequestReady.UID,
+				CertificateRequestRevision: "2",
 			},
-			expectedErr: false,
+			expNotifications: []notifications.EventType{notifications.EventRenewed},
+			expectedErr:      false,
 		},
 		"if certificate is in Issuing state, one ready CertificateRequest and has last failure time and issuance attempts set from a previous issuance, set the Issuing condition to true, remove last failure time and issuance attempts and store the signed certificate, ca, and private key to an existing secret, and log an event": {
 			certificate: exampleBundle.Certificate,
@@ -654,25 +742,36 @@ func TestIssuingController(t *testing.T) {
 					},
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
-						"status",
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateRevision(2),
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Issued",
+								Message:            "The certificate has been successfully issued",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
 						),
-					)),
+					),
 				},
 				ExpectedEvents: []string{
 					"Normal Issuing The certificate has been successfully issued",
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
-				PrivateKey:  exampleBundle.PrivateKeyBytes,
-				CA:          nil,
+				Certificate:                exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:                 exampleBundle.PrivateKeyBytes,
+				CA:                         nil,
+				CertificateRequestName:     exampleBundle.CertificateRequestReady.Name,
+				CertificateRequestUID:      exampleBundle.CertificateRequestReady.UID,
+				CertificateRequestRevision: "2",
 			},
-			expectedErr: false,
+			expNotifications: []notifications.EventType{notifications.EventRenewed},
+			expectedErr:      false,
 		},
 
 		"if certificate is in Issuing state with temp annotation, one CertificateRequest Pending, no target Secret, create target secret with temporary certificate": {
@@ -928,28 +1027,39 @@ func TestIssuingController(t *testing.T) {
 					},
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
-						"status",
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.AddCertificateAnnotations(map[string]string{
 								cmapi.IssueTemporaryCertificateAnnotation: "true",
 							}),
 							gen.SetCertificateRevision(2),
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Issued",
+								Message:            "The certificate has been successfully issued",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
 						),
-					)),
+					),
 				},
 				ExpectedEvents: []string{
 					"Normal Issuing The certificate has been successfully issued",
 				},
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
-				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
-				PrivateKey:  exampleBundle.PrivateKeyBytes,
-				CA:          nil,
+				Certificate:                exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:                 exampleBundle.PrivateKeyBytes,
+				CA:                         nil,
+				CertificateRequestName:     exampleBundle.CertificateRequestReady.Name,
+				CertificateRequestUID:      exampleBundle.CertificateRequestReady.UID,
+				CertificateRequestRevision: "2",
 			},
-			expectedErr: false,
+			expNotifications: []notifications.EventType{notifications.EventRenewed},
+			expectedErr:      false,
 		},
 		"if certificate is in Issuing state with temp annotation, one CertificateRequest Failed, a target Secret does not exist, mark the Certificate as failed": {
 			certificate: exampleBundle.Certificate,
@@ -983,9 +1093,8 @@ func TestIssuingController(t *testing.T) {
 					},
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
-						"status",
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.AddCertificateAnnotations(map[string]string{
@@ -1002,13 +1111,14 @@ func TestIssuingController(t *testing.T) {
 							gen.SetCertificateLastFailureTime(metaFixedClockStart),
 							gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
 						),
-					)),
+					),
 				},
 				ExpectedEvents: []string{
 					"Warning Failed The certificate request has failed to complete and will be retried: The certificate request failed because of reasons",
 				},
 			},
-			expectedErr: false,
+			expNotifications: []notifications.EventType{notifications.EventFailed},
+			expectedErr:      false,
 		},
 		"if certificate is in Issuing state, one CertificateRequest, but has been denied, report denial and set last failed time and issuance attempts": {
 			certificate: exampleBundle.Certificate,
@@ -1038,9 +1148,8 @@ func TestIssuingController(t *testing.T) {
 					},
 				},
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
-						"status",
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
 							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
@@ -1054,13 +1163,14 @@ func TestIssuingController(t *testing.T) {
 							gen.SetCertificateLastFailureTime(metaFixedClockStart),
 							gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
 						),
-					)),
+					),
 				},
 				ExpectedEvents: []string{
 					"Warning DeniedReason The certificate request has failed to complete and will be retried: The certificate request has been denied",
 				},
 			},
-			expectedErr: false,
+			expNotifications: []notifications.EventType{notifications.EventFailed},
+			expectedErr:      false,
 		},
 	}
 
@@ -1076,6 +1186,8 @@ func TestIssuingController(t *testing.T) {
 			_, _, err := w.Register(test.builder.Context)
 			require.NoError(t, err)
 			w.controller.localTemporarySigner = testLocalTemporarySignerFn(exampleBundle.LocalTemporaryCertificateBytes)
+			notifier := &recordingNotifier{}
+			w.controller.notifier = notifier
 
 			var secretsUpdateDataCalled bool
 			w.controller.secretsUpdateData = func(_ context.Context, _ *cmapi.Certificate, secretData internal.SecretData) error {
@@ -1103,7 +1215,99 @@ func TestIssuingController(t *testing.T) {
 			if err == nil && test.expectedErr {
 				t.Errorf("expected to get an error but did not get one")
 			}
+			var notified []notifications.EventType
+			for _, event := range notifier.events {
+				notified = append(notified, event.Type)
+			}
+			assert.Equal(t, test.expNotifications, notified, "unexpected notifications")
 			test.builder.CheckAndFinish(err)
 		})
 	}
 }
+
+// recordingNotifier records the events it is notified of.
+type recordingNotifier struct {
+	events []notifications.Event
+}
+
+func (n *recordingNotifier) Notify(event notifications.Event) {
+	n.events = append(n.events, event)
+}
+
+func TestRecordPrivateKeyIssuance(t *testing.T) {
+	currentKey, err := utilpki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	currentKeyData := utilpki.EncodePKCS1PrivateKey(currentKey)
+	newKey, err := utilpki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+
+	issuedTime := metav1.NewTime(fixedClockStart.Add(-time.Hour))
+	scheduledCert := func(issuances *int) *cmapi.Certificate {
+		crt := gen.Certificate("test",
+			gen.SetCertificateNamespace("testns"),
+			gen.SetCertificateSecretName("output"),
+		)
+		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{
+			RotationPolicy:   cmapi.RotationPolicyScheduled,
+			RotationSchedule: &cmapi.PrivateKeyRotationSchedule{Renewals: pointer.Int32(3)},
+		}
+		if issuances != nil {
+			crt.Status.PrivateKeyIssuances = issuances
+			crt.Status.PrivateKeyIssuedTime = &issuedTime
+		}
+		return crt
+	}
+	nowTime := metav1.NewTime(fixedClockStart)
+
+	tests := map[string]struct {
+		crt           *cmapi.Certificate
+		pk            *rsa.PrivateKey
+		expIssuances  *int
+		expIssuedTime *metav1.Time
+	}{
+		"count an issuance with the private key stored in the Secret": {
+			crt:           scheduledCert(pointer.Int(1)),
+			pk:            currentKey,
+			expIssuances:  pointer.Int(2),
+			expIssuedTime: &issuedTime,
+		},
+		"start tracking a new private key": {
+			crt:           scheduledCert(pointer.Int(2)),
+			pk:            newKey,
+			expIssuances:  pointer.Int(1),
+			expIssuedTime: &nowTime,
+		},
+		"start tracking a private key whose usage is not known": {
+			crt:           scheduledCert(nil),
+			pk:            currentKey,
+			expIssuances:  pointer.Int(1),
+			expIssuedTime: &nowTime,
+		},
+		"do not track private keys for other rotation policies": {
+			crt: func() *cmapi.Certificate {
+				crt := scheduledCert(pointer.Int(1))
+				crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{RotationPolicy: cmapi.RotationPolicyNever}
+				return crt
+			}(),
+			pk: currentKey,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			require.NoError(t, indexer.Add(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "output"},
+				Data:       map[string][]byte{corev1.TLSPrivateKeyKey: currentKeyData},
+			}))
+			c := &controller{
+				secretGetter: controllerpkg.NewCachedSecretGetter(corelisters.NewSecretLister(indexer)),
+				clock:        fakeclock.NewFakeClock(fixedClockStart),
+			}
+
+			crt := test.crt.DeepCopy()
+			require.NoError(t, c.recordPrivateKeyIssuance(context.Background(), crt, test.pk))
+			assert.Equal(t, test.expIssuances, crt.Status.PrivateKeyIssuances)
+			assert.Equal(t, test.expIssuedTime, crt.Status.PrivateKeyIssuedTime)
+		})
+	}
+}
@@ -23,12 +23,16 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
 )
 
 // ensureSecretData ensures that the Certificate's Secret is up to date with
@@ -37,7 +41,7 @@ import (
 // AdditionalOutputFormats.
 func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt *cmapi.Certificate) error {
 	// Retrieve the Secret which is associated with this Certificate.
-	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	secret, err := c.secretGetter.Get(ctx, crt.Namespace, crt.Spec.SecretName)
 
 	// Secret doesn't exist so we can't do anything. The Certificate will be
 	// marked for a re-issuance and the resulting Secret will be evaluated again.
@@ -69,13 +73,40 @@ func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt
 		PrivateKey:  secret.Data[corev1.TLSPrivateKeyKey],
 		Certificate: secret.Data[corev1.TLSCertKey],
 		CA:          secret.Data[cmmeta.TLSCAKey],
+		// Keep the record of the CertificateRequest the stored certificate
+		// was issued from, which cannot be derived from the Secret's data.
+		CertificateRequestName:     secret.Annotations[cmapi.CertificateRequestNameAnnotationKey],
+		CertificateRequestUID:      types.UID(secret.Annotations[cmapi.CertificateRequestUIDAnnotationKey]),
+		CertificateRequestRevision: secret.Annotations[cmapi.CertificateRequestRevisionAnnotationKey],
+	}
+
+	currentReq, err := c.currentCertificateRequest(crt)
+	if err != nil {
+		return err
+	}
+
+	// Restore data which was lost from the Secret, for example by restoring
+	// it from a backup, from the CertificateRequest which issued the stored
+	// certificate.
+	if policies.SecretIssuedByRequest(secret, currentReq) {
+		if len(data.CA) == 0 {
+			data.CA = currentReq.Status.CA
+		}
+		if data.CertificateRequestName == "" {
+			data.CertificateRequestName = currentReq.Name
+			data.CertificateRequestUID = currentReq.UID
+		}
+		if data.CertificateRequestRevision == "" {
+			data.CertificateRequestRevision = currentReq.Annotations[cmapi.CertificateRequestRevisionAnnotationKey]
+		}
 	}
 
 	// Check whether the Certificate's Secret has correct output format and
 	// metadata.
 	reason, message, isViolation := c.postIssuancePolicyChain.Evaluate(policies.Input{
-		Certificate: crt,
-		Secret:      secret,
+		Certificate:            crt,
+		Secret:                 secret,
+		CurrentRevisionRequest: currentReq,
 	})
 
 	if isViolation {
@@ -97,6 +128,23 @@ func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt
 	}
 
 	// No Secret violations, nothing to do.
-
 	return nil
 }
+
+// currentCertificateRequest returns the CertificateRequest of the Certificate's
+// current revision, or nil if there is no single such request.
+func (c *controller) currentCertificateRequest(crt *cmapi.Certificate) (*cmapi.CertificateRequest, error) {
+	if crt.Status.Revision == nil {
+		return nil, nil
+	}
+
+	reqs, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace),
+		labels.Everything(),
+		predicate.CertificateRequestRevision(*crt.Status.Revision),
+		predicate.ResourceOwnedBy(crt),
+	)
+	if err != nil || len(reqs) != 1 {
+		return nil, err
+	}
+	return reqs[0], nil
+}
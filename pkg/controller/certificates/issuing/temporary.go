@@ -47,7 +47,7 @@ func (c *controller) ensureTemporaryCertificate(ctx context.Context, crt *cmapi.
 	}
 
 	// Attempt to fetch the Secret being managed but tolerate NotFound errors.
-	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	secret, err := c.secretGetter.Get(ctx, crt.Namespace, crt.Spec.SecretName)
 	if err != nil && !apierrors.IsNotFound(err) {
 		return false, err
 	}
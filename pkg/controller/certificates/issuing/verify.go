@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuing
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// verifyIssuedCertificate checks that the certificate returned by the issuer
+// of the given CertificateRequest can be stored along with the given private
+// key: the certificate must match the private key, chain to the returned CA,
+// and allow the usages which were explicitly requested. This protects the
+// Secret from being overwritten with unusable data by a buggy issuer.
+func verifyIssuedCertificate(req *cmapi.CertificateRequest, pk crypto.Signer) error {
+	chain, err := utilpki.DecodeX509CertificateChainBytes(req.Status.Certificate)
+	if err != nil {
+		return fmt.Errorf("failed to decode the issued certificate: %w", err)
+	}
+	leaf := chain[0]
+
+	matches, err := utilpki.PublicKeyMatchesCertificate(pk.Public(), leaf)
+	if err != nil {
+		return fmt.Errorf("failed to compare the issued certificate to the private key: %w", err)
+	}
+	if !matches {
+		return errors.New("the issued certificate does not match the private key")
+	}
+
+	var cas []*x509.Certificate
+	if len(req.Status.CA) > 0 {
+		cas, err = utilpki.DecodeX509CertificateChainBytes(req.Status.CA)
+		if err != nil {
+			return fmt.Errorf("failed to decode the CA certificate: %w", err)
+		}
+	}
+	if err := utilpki.VerifyCertificateChain(chain, cas); err != nil {
+		return fmt.Errorf("the issued certificate chain is not valid: %w", err)
+	}
+
+	return verifyIssuedCertificateUsages(leaf, req.Spec.Usages)
+}
+
+// verifyIssuedCertificateUsages checks that the given certificate allows the
+// given usages. Usages are only checked if they were explicitly requested,
+// since issuers are free to pick the usages of certificates for which none
+// were requested.
+func verifyIssuedCertificateUsages(cert *x509.Certificate, usages []cmapi.KeyUsage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	ku, ekus, err := utilpki.BuildKeyUsages(usages, false)
+	if err != nil {
+		return err
+	}
+
+	// An empty key usage extension doesn't restrict the usages of the key.
+	if cert.KeyUsage != 0 {
+		missing := ku &^ cert.KeyUsage
+		// Encipherment doesn't apply to non-RSA keys, and issuers commonly
+		// drop these usages for them.
+		if _, ok := cert.PublicKey.(*rsa.PublicKey); !ok {
+			missing &^= x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment
+		}
+		if missing != 0 {
+			return fmt.Errorf("the issued certificate does not allow the requested usages %v", apiutil.KeyUsageStrings(missing))
+		}
+	}
+
+	// An empty extended key usage extension doesn't restrict the usages of
+	// the key either.
+	if len(cert.ExtKeyUsage) == 0 || hasExtKeyUsage(cert.ExtKeyUsage, x509.ExtKeyUsageAny) {
+		return nil
+	}
+	var missing []x509.ExtKeyUsage
+	for _, eku := range ekus {
+		if !hasExtKeyUsage(cert.ExtKeyUsage, eku) {
+			missing = append(missing, eku)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the issued certificate does not allow the requested usages %v", apiutil.ExtKeyUsageStrings(missing))
+	}
+
+	return nil
+}
+
+func hasExtKeyUsage(ekus []x509.ExtKeyUsage, eku x509.ExtKeyUsage) bool {
+	for _, e := range ekus {
+		if e == eku {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuing
+
+import (
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func mustSignCertificate(t *testing.T, template *x509.Certificate, pub crypto.PublicKey, issuer *x509.Certificate, issuerKey crypto.Signer) ([]byte, *x509.Certificate) {
+	if issuer == nil {
+		issuer = template
+	}
+	certPEM, cert, err := pki.SignCertificate(template, issuer, pub, issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM, cert
+}
+
+func mustGenerateECKey(t *testing.T) crypto.Signer {
+	pk, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pk
+}
+
+func TestVerifyIssuedCertificate(t *testing.T) {
+	now := time.Now()
+
+	caKey := mustGenerateECKey(t)
+	caPEM, caCert := mustSignCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             now,
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, caKey.Public(), nil, caKey)
+
+	otherCAKey := mustGenerateECKey(t)
+	otherCAPEM, _ := mustSignCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "other-ca"},
+		NotBefore:             now,
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, otherCAKey.Public(), nil, otherCAKey)
+
+	leafKey := mustGenerateECKey(t)
+	leafPEM, _ := mustSignCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    now,
+		NotAfter:     now.Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, leafKey.Public(), caCert, caKey)
+
+	tests := map[string]struct {
+		certificate []byte
+		ca          []byte
+		usages      []cmapi.KeyUsage
+		pk          crypto.Signer
+		expErr      bool
+	}{
+		"certificate signed by the CA and matching the private key should not error": {
+			certificate: leafPEM,
+			ca:          caPEM,
+			pk:          leafKey,
+		},
+		"certificate chain including the CA should not error": {
+			certificate: append(append([]byte{}, leafPEM...), caPEM...),
+			ca:          caPEM,
+			pk:          leafKey,
+		},
+		"certificate without a CA should not error": {
+			certificate: leafPEM,
+			pk:          leafKey,
+		},
+		"certificate which cannot be decoded should error": {
+			certificate: []byte("not a certificate"),
+			ca:          caPEM,
+			pk:          leafKey,
+			expErr:      true,
+		},
+		"certificate not matching the private key should error": {
+			certificate: leafPEM,
+			ca:          caPEM,
+			pk:          caKey,
+			expErr:      true,
+		},
+		"certificate not signed by the CA should error": {
+			certificate: leafPEM,
+			ca:          otherCAPEM,
+			pk:          leafKey,
+			expErr:      true,
+		},
+		"certificate allowing the requested usages should not error": {
+			certificate: leafPEM,
+			ca:          caPEM,
+			usages:      []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth},
+			pk:          leafKey,
+		},
+		"key encipherment requested for an ECDSA certificate should not error": {
+			certificate: leafPEM,
+			ca:          caPEM,
+			usages:      []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment},
+			pk:          leafKey,
+		},
+		"certificate missing a requested key usage should error": {
+			certificate: leafPEM,
+			ca:          caPEM,
+			usages:      []cmapi.KeyUsage{cmapi.UsageCertSign},
+			pk:          leafKey,
+			expErr:      true,
+		},
+		"certificate missing a requested extended key usage should error": {
+			certificate: leafPEM,
+			ca:          caPEM,
+			usages:      []cmapi.KeyUsage{cmapi.UsageClientAuth},
+			pk:          leafKey,
+			expErr:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := &cmapi.CertificateRequest{
+				Spec: cmapi.CertificateRequestSpec{Usages: test.usages},
+				Status: cmapi.CertificateRequestStatus{
+					Certificate: test.certificate,
+					CA:          test.ca,
+				},
+			}
+			err := verifyIssuedCertificate(req, test.pk)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+		})
+	}
+}
@@ -34,9 +34,9 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
-	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -46,7 +46,6 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
-	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
 )
@@ -56,6 +55,7 @@ const (
 	reasonDecodeFailed        = "DecodeFailed"
 	reasonCannotRegenerateKey = "CannotRegenerateKey"
 	reasonDeleted             = "Deleted"
+	reasonRotationDue         = "RotationDue"
 )
 
 var (
@@ -68,6 +68,7 @@ type controller struct {
 	client            cmclient.Interface
 	coreClient        kubernetes.Interface
 	recorder          record.EventRecorder
+	clock             clock.Clock
 
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
@@ -82,24 +83,27 @@ func NewController(
 	factory informers.SharedInformerFactory,
 	cmFactory cminformers.SharedInformerFactory,
 	recorder record.EventRecorder,
+	clock clock.Clock,
 	fieldManager string,
+	rateLimiter workqueue.RateLimiter,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
-	// create a queue used to queue up items to be processed
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
-
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
-	secretsInformer := factory.Core().V1().Secrets()
+	secretsInformer := controllerpkg.SecretsMetadataInformer(factory)
+
+	// create a queue used to queue up items to be processed, in which
+	// Certificates which are about to expire are processed first
+	queue := certificates.NewPriorityQueue(rateLimiter, ControllerName, certificateInformer.Lister(), clock)
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 
-	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+	secretsInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Trigger reconciles on changes to any 'owned' secret resources
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
 			predicate.ResourceOwnerOf,
 		),
 	})
-	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+	secretsInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Trigger reconciles on changes to certificates named as spec.secretName
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
 			predicate.ExtractResourceName(predicate.CertificateSecretName),
@@ -109,16 +113,17 @@ func NewController(
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
-		secretsInformer.Informer().HasSynced,
+		secretsInformer.HasSynced,
 		certificateInformer.Informer().HasSynced,
 	}
 
 	return &controller{
 		certificateLister: certificateInformer.Lister(),
-		secretLister:      secretsInformer.Lister(),
+		secretLister:      controllerpkg.NewSecretGetter(factory),
 		client:            client,
 		coreClient:        coreClient,
 		recorder:          recorder,
+		clock:             clock,
 		fieldManager:      fieldManager,
 	}, queue, mustSync
 }
@@ -177,12 +182,19 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		if crt.Spec.PrivateKey != nil && crt.Spec.PrivateKey.RotationPolicy != "" {
 			rotationPolicy = crt.Spec.PrivateKey.RotationPolicy
 		}
+		// The private key of a revoked certificate may be compromised, so it
+		// is never reused.
+		if reissuingRevokedCertificate(crt) {
+			rotationPolicy = cmapi.RotationPolicyAlways
+		}
 		switch rotationPolicy {
 		case cmapi.RotationPolicyNever:
 			return c.createNextPrivateKeyRotationPolicyNever(ctx, crt)
 		case cmapi.RotationPolicyAlways:
 			log.V(logf.DebugLevel).Info("Creating new nextPrivateKeySecretName Secret because no existing Secret found")
 			return c.createAndSetNextPrivateKey(ctx, crt)
+		case cmapi.RotationPolicyScheduled:
+			return c.createNextPrivateKeyRotationPolicyScheduled(ctx, crt)
 		default:
 			log.V(logf.WarnLevel).Info("Certificate with unknown certificate.spec.privateKey.rotationPolicy value", "rotation_policy", rotationPolicy)
 			return nil
@@ -231,9 +243,46 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return c.deleteSecretResources(ctx, secrets)
 	}
 
+	// The next private key may have been created before the certificate was
+	// revoked, reusing the private key of the revoked certificate.
+	if reissuingRevokedCertificate(crt) {
+		reused, err := c.isCurrentPrivateKey(crt, pk)
+		if err != nil {
+			return err
+		}
+		if reused {
+			c.recorder.Event(crt, corev1.EventTypeNormal, reasonDeleted, "Regenerating private key as the certificate using it has been revoked")
+			return c.deleteSecretResources(ctx, secrets)
+		}
+	}
+
 	return nil
 }
 
+// reissuingRevokedCertificate returns true if the Certificate is being issued
+// because its current certificate has been revoked.
+func reissuingRevokedCertificate(crt *cmapi.Certificate) bool {
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	return cond != nil && cond.Status == cmmeta.ConditionTrue && cond.Reason == cmapi.CertificateReasonRevoked
+}
+
+// isCurrentPrivateKey returns true if the given private key is the one stored
+// in the Certificate's Secret.
+func (c *controller) isCurrentPrivateKey(crt *cmapi.Certificate, pk crypto.Signer) (bool, error) {
+	s, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	current, err := pki.DecodePrivateKeyBytes(s.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return false, nil
+	}
+	return pki.PublicKeysEqual(current.Public(), pk.Public())
+}
+
 func (c *controller) createNextPrivateKeyRotationPolicyNever(ctx context.Context, crt *cmapi.Certificate) error {
 	log := logf.FromContext(ctx)
 	s, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
@@ -274,6 +323,38 @@ func (c *controller) createNextPrivateKeyRotationPolicyNever(ctx context.Context
 	return c.setNextPrivateKeySecretName(ctx, crt, &nextPkSecret.Name)
 }
 
+// createNextPrivateKeyRotationPolicyScheduled reuses the private key stored
+// in the Certificate's Secret unless it is due for rotation according to the
+// Certificate's rotation schedule. Unlike with the Never rotation policy, a
+// private key which does not match the Certificate's spec is regenerated, as
+// the user has opted in to cert-manager rotating their private keys.
+func (c *controller) createNextPrivateKeyRotationPolicyScheduled(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx)
+	if msg, due := certificates.PrivateKeyRotationDue(crt, c.clock.Now()); due {
+		log.V(logf.DebugLevel).Info("Creating new nextPrivateKeySecretName Secret because the private key is due for rotation", "reason", msg)
+		c.recorder.Event(crt, corev1.EventTypeNormal, reasonRotationDue, msg)
+		return c.createAndSetNextPrivateKey(ctx, crt)
+	}
+
+	s, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if s != nil && len(s.Data[corev1.TLSPrivateKeyKey]) > 0 {
+		if pk, err := pki.DecodePrivateKeyBytes(s.Data[corev1.TLSPrivateKeyKey]); err == nil {
+			violations, err := certificates.PrivateKeyMatchesSpec(pk, crt.Spec)
+			if err == nil && len(violations) > 0 {
+				log.V(logf.DebugLevel).Info("Creating new nextPrivateKeySecretName Secret because the existing private key does not match the spec", "violations", violations)
+				return c.createAndSetNextPrivateKey(ctx, crt)
+			}
+		}
+	}
+
+	// The remaining cases are handled the same way as for the Never rotation
+	// policy, which reuses the existing private key if there is one.
+	return c.createNextPrivateKeyRotationPolicyNever(ctx, crt)
+}
+
 func (c *controller) createAndSetNextPrivateKey(ctx context.Context, crt *cmapi.Certificate) error {
 	pk, err := pki.GeneratePrivateKeyForCertificate(crt)
 	if err != nil {
@@ -314,22 +395,16 @@ func (c *controller) setNextPrivateKeySecretName(ctx context.Context, crt *cmapi
 	}
 	crt = crt.DeepCopy()
 	crt.Status.NextPrivateKeySecretName = name
-	return c.updateOrApplyStatus(ctx, crt)
+	return c.applyStatus(ctx, crt)
 }
 
-// updateOrApplyStatus will update the controller status. If the
-// ServerSideApply feature is enabled, the managed fields will instead get
-// applied using the relevant Patch API call.
-func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
-			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
-			Status:     cmapi.CertificateStatus{NextPrivateKeySecretName: crt.Status.NextPrivateKeySecretName},
-		})
-	} else {
-		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
-		return err
-	}
+// applyStatus applies the fields of the status which are managed by this
+// controller.
+func (c *controller) applyStatus(ctx context.Context, crt *cmapi.Certificate) error {
+	return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+		Status:     cmapi.CertificateStatus{NextPrivateKeySecretName: crt.Status.NextPrivateKeySecretName},
+	})
 }
 
 func (c *controller) createNewPrivateKeySecret(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer) (*corev1.Secret, error) {
@@ -351,7 +426,8 @@ func (c *controller) createNewPrivateKeySecret(ctx context.Context, crt *cmapi.C
 			Name:            name,
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(crt, certificateGvk)},
 			Labels: map[string]string{
-				"cert-manager.io/next-private-key": "true",
+				cmapi.IsNextPrivateKeySecretLabelKey:      "true",
+				cmapi.PartOfCertManagerControllerLabelKey: "true",
 			},
 		},
 		Data: map[string][]byte{
@@ -385,7 +461,9 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
 		ctx.Recorder,
+		ctx.Clock,
 		ctx.FieldManager,
+		ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30),
 	)
 	c.controller = ctrl
 
@@ -393,7 +471,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 }
 
 func init() {
-	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+	controllerpkg.RegisterSharded(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
 		return controllerpkg.NewBuilder(ctx, ControllerName).
 			For(&controllerWrapper{}).
 			Complete()
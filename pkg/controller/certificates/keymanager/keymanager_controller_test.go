@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/kr/pretty"
 	corev1 "k8s.io/api/core/v1"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -37,6 +39,11 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
+var (
+	fixedClockStart = time.Now()
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
 func mustGenerateRSA(t *testing.T, keySize int) []byte {
 	pk, err := pki.GenerateRSAPrivateKey(keySize)
 	if err != nil {
@@ -93,6 +100,84 @@ func TestProcessItem(t *testing.T) {
 			Data: data,
 		}
 	}
+	revokedKey := mustGenerateRSA(t, 2048)
+	revokedCertificate := func(nextPrivateKeySecretName *string) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+			Spec: cmapi.CertificateSpec{
+				SecretName: "test-tls",
+				PrivateKey: &cmapi.CertificatePrivateKey{RotationPolicy: cmapi.RotationPolicyNever},
+			},
+			Status: cmapi.CertificateStatus{
+				NextPrivateKeySecretName: nextPrivateKeySecretName,
+				Conditions: []cmapi.CertificateCondition{
+					{
+						Type:   cmapi.CertificateConditionIssuing,
+						Status: cmmeta.ConditionTrue,
+						Reason: cmapi.CertificateReasonRevoked,
+					},
+				},
+			},
+		}
+	}
+	revokedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-tls"},
+		Data:       map[string][]byte{"tls.key": revokedKey},
+	}
+	scheduledKey := mustGenerateRSA(t, 2048)
+	scheduledCertificate := func(issuances int) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+			Spec: cmapi.CertificateSpec{
+				SecretName: "test-tls",
+				PrivateKey: &cmapi.CertificatePrivateKey{
+					RotationPolicy:   cmapi.RotationPolicyScheduled,
+					RotationSchedule: &cmapi.PrivateKeyRotationSchedule{Renewals: pointer.Int32(3)},
+				},
+			},
+			Status: cmapi.CertificateStatus{
+				PrivateKeyIssuedTime: &metav1.Time{Time: fixedClockStart},
+				PrivateKeyIssuances:  &issuances,
+				Conditions: []cmapi.CertificateCondition{
+					{
+						Type:   cmapi.CertificateConditionIssuing,
+						Status: cmmeta.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+	scheduledSecret := func(key []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-tls"},
+			Data:       map[string][]byte{"tls.key": key},
+		}
+	}
+	nextPrivateKeyActions := func(crt *cmapi.Certificate) []testpkg.Action {
+		crt = crt.DeepCopy()
+		crt.Spec = cmapi.CertificateSpec{}
+		crt.Status.NextPrivateKeySecretName = pointer.StringPtr("test-notrandom")
+		return []testpkg.Action{
+			testpkg.NewApplyStatusAction(
+				cmapi.SchemeGroupVersion.WithResource("certificates"),
+				"testns",
+				crt,
+			),
+			testpkg.NewCustomMatch(coretesting.NewCreateAction(
+				corev1.SchemeGroupVersion.WithResource("secrets"),
+				"testns",
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:       "testns",
+						GenerateName:    "test-",
+						Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true", cmapi.PartOfCertManagerControllerLabelKey: "true"},
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")}}, certificateGvk)},
+					},
+					Data: map[string][]byte{"tls.key": nil},
+				},
+			), relaxedSecretMatcher),
+		}
+	}
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem.
 		// if not set, the 'namespace/name' of the 'Certificate' field will be used.
@@ -157,9 +242,8 @@ func TestProcessItem(t *testing.T) {
 			},
 			expectedEvents: []string{`Normal Generated Stored new private key in temporary Secret resource "test-notrandom"`},
 			expectedActions: []testpkg.Action{
-				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+				testpkg.NewApplyStatusAction(
 					cmapi.SchemeGroupVersion.WithResource("certificates"),
-					"status",
 					"testns",
 					&cmapi.Certificate{
 						ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
@@ -173,7 +257,7 @@ func TestProcessItem(t *testing.T) {
 							},
 						},
 					},
-				)),
+				),
 				testpkg.NewCustomMatch(coretesting.NewCreateAction(
 					corev1.SchemeGroupVersion.WithResource("secrets"),
 					"testns",
@@ -181,7 +265,7 @@ func TestProcessItem(t *testing.T) {
 						ObjectMeta: metav1.ObjectMeta{
 							Namespace:       "testns",
 							GenerateName:    "test-",
-							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true"},
+							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true", cmapi.PartOfCertManagerControllerLabelKey: "true"},
 							OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"}}, certificateGvk)},
 						},
 						Data: map[string][]byte{"tls.key": nil},
@@ -211,7 +295,7 @@ func TestProcessItem(t *testing.T) {
 						ObjectMeta: metav1.ObjectMeta{
 							Namespace:       "testns",
 							Name:            "fixed-name",
-							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true"},
+							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true", cmapi.PartOfCertManagerControllerLabelKey: "true"},
 							OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"}}, certificateGvk)},
 						},
 						Data: map[string][]byte{"tls.key": nil},
@@ -243,7 +327,7 @@ func TestProcessItem(t *testing.T) {
 						ObjectMeta: metav1.ObjectMeta{
 							Namespace:       "testns",
 							Name:            "fixed-name",
-							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true"},
+							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true", cmapi.PartOfCertManagerControllerLabelKey: "true"},
 							OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"}}, certificateGvk)},
 						},
 						Data: map[string][]byte{"tls.key": nil},
@@ -352,9 +436,8 @@ func TestProcessItem(t *testing.T) {
 				ownedSecretWithName("testns", "fixed-name", "test", nil),
 			},
 			expectedActions: []testpkg.Action{
-				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+				testpkg.NewApplyStatusAction(
 					cmapi.SchemeGroupVersion.WithResource("certificates"),
-					"status",
 					"testns",
 					&cmapi.Certificate{
 						ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
@@ -368,7 +451,7 @@ func TestProcessItem(t *testing.T) {
 							},
 						},
 					},
-				)),
+				),
 			},
 		},
 		"if an owned secret exists but has a different name to nextPrivateKeySecretName, delete it": {
@@ -461,6 +544,80 @@ func TestProcessItem(t *testing.T) {
 				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateRSA(t, 2048)}),
 			},
 		},
+		"create a new private key for a revoked certificate even if the rotation policy is Never": {
+			certificate:    revokedCertificate(nil),
+			secrets:        []runtime.Object{revokedSecret},
+			expectedEvents: []string{`Normal Generated Stored new private key in temporary Secret resource "test-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewApplyStatusAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"testns",
+					&cmapi.Certificate{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+						Status: cmapi.CertificateStatus{
+							NextPrivateKeySecretName: pointer.StringPtr("test-notrandom"),
+							Conditions:               revokedCertificate(nil).Status.Conditions,
+						},
+					},
+				),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:       "testns",
+							GenerateName:    "test-",
+							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true", cmapi.PartOfCertManagerControllerLabelKey: "true"},
+							OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")}}, certificateGvk)},
+						},
+						Data: map[string][]byte{"tls.key": nil},
+					},
+				), relaxedSecretMatcher),
+			},
+		},
+		"reuse the existing private key if the Scheduled rotation policy is not due": {
+			certificate:     scheduledCertificate(2),
+			secrets:         []runtime.Object{scheduledSecret(scheduledKey)},
+			expectedEvents:  []string{`Normal Reused Reusing private key stored in existing Secret resource "test-tls"`},
+			expectedActions: nextPrivateKeyActions(scheduledCertificate(2)),
+		},
+		"create a new private key if the Scheduled rotation policy is due": {
+			certificate: scheduledCertificate(3),
+			secrets:     []runtime.Object{scheduledSecret(scheduledKey)},
+			expectedEvents: []string{
+				"Normal RotationDue The private key has been used for 3 issuances, the maximum allowed by its rotation schedule is 3",
+				`Normal Generated Stored new private key in temporary Secret resource "test-notrandom"`,
+			},
+			expectedActions: nextPrivateKeyActions(scheduledCertificate(3)),
+		},
+		"create a new private key with the Scheduled rotation policy if the existing one does not match the spec": {
+			certificate:     scheduledCertificate(1),
+			secrets:         []runtime.Object{scheduledSecret(mustGenerateECDSA(t, pki.ECCurve256))},
+			expectedEvents:  []string{`Normal Generated Stored new private key in temporary Secret resource "test-notrandom"`},
+			expectedActions: nextPrivateKeyActions(scheduledCertificate(1)),
+		},
+		"if an owned secret reuses the private key of a revoked certificate, delete it": {
+			certificate: revokedCertificate(pointer.StringPtr("fixed-name")),
+			secrets: []runtime.Object{
+				revokedSecret,
+				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": revokedKey}),
+			},
+			expectedEvents: []string{"Normal Deleted Regenerating private key as the certificate using it has been revoked"},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					"fixed-name",
+				)),
+			},
+		},
+		"if an owned secret holds a new private key for a revoked certificate, do nothing": {
+			certificate: revokedCertificate(pointer.StringPtr("fixed-name")),
+			secrets: []runtime.Object{
+				revokedSecret,
+				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateRSA(t, 2048)}),
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -470,6 +627,7 @@ func TestProcessItem(t *testing.T) {
 				ExpectedEvents:  test.expectedEvents,
 				ExpectedActions: test.expectedActions,
 				StringGenerator: func(i int) string { return "notrandom" },
+				Clock:           fixedClock,
 			}
 			if test.certificate != nil {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.certificate)
@@ -20,15 +20,21 @@ import (
 	"context"
 	"time"
 
+	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
 	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
 )
 
 const (
@@ -44,38 +50,51 @@ type controllerWrapper struct {
 }
 
 // This controller is synced on all Certificate 'create', 'update', and
-// 'delete' events which will update the metrics for that Certificate.
+// 'delete' events, and on events of the Certificate's Secret, which will
+// update the metrics for that Certificate.
 type controller struct {
 	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
 
+	log     logr.Logger
 	metrics *metrics.Metrics
 }
 
 func NewController(
+	log logr.Logger,
 	factory informers.SharedInformerFactory,
 	cmFactory cminformers.SharedInformerFactory,
 	metrics *metrics.Metrics,
+	rateLimiter workqueue.RateLimiter,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
 	// create a queue used to queue up items to be processed
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ControllerName)
 
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	secretsInformer := controllerpkg.SecretsMetadataInformer(factory)
 
-	// Reconcile over all Certificate events. We do _not_ reconcile on Secret
-	// events that are related to Certificates. It is the responsibility of the
-	// Certificates controllers to update accordingly.
+	// Reconcile over all Certificate events, and events of the Secret named
+	// `spec.secretName` so that the metrics of the certificates stored in
+	// the Secret are kept up to date.
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	secretsInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+	})
 
 	// build a list of InformerSynced functions that will be returned by the
 	// Register method.  the controller will only begin processing items once all
 	// of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		certificateInformer.Informer().HasSynced,
+		secretsInformer.HasSynced,
 	}
 
 	return &controller{
 		certificateLister: certificateInformer.Lister(),
+		secretLister:      controllerpkg.NewSecretGetter(factory),
+		log:               log,
 		metrics:           metrics,
 	}, queue, mustSync
 }
@@ -103,14 +122,31 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	// Update that Certificates metrics
 	c.metrics.UpdateCertificate(ctx, crt)
 
+	// Update the metrics of the certificates stored in its Secret
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	var secretCerts []metrics.SecretCertificate
+	if secret != nil && err == nil {
+		log := logf.WithRelatedResource(c.log, crt)
+		secretCerts = secretCertificates(log, crt, secret, c.secretPassword(crt.Namespace))
+	}
+	c.metrics.UpdateCertificateSecret(crt, secretCerts)
+
 	return nil
 }
 
 func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
 	ctrl, queue, mustSync := NewController(
+		log,
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
 		ctx.Metrics,
+		ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30),
 	)
 	c.controller = ctrl
 
@@ -118,7 +154,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 }
 
 func init() {
-	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+	controllerpkg.RegisterSharded(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
 		return controllerpkg.NewBuilder(ctx, ControllerName).
 			For(&controllerWrapper{}).
 			Complete()
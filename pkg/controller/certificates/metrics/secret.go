@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	jks "github.com/pavlo-v-chernykh/keystore-go/v4"
+	corev1 "k8s.io/api/core/v1"
+	"software.sslmate.com/src/go-pkcs12"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// The aliases used by the issuing controller for JKS keystore entries.
+	jksCertificateAlias = "certificate"
+	jksCAAlias          = "ca"
+)
+
+// passwordFunc returns the password referenced by the selector in the
+// Certificate's namespace.
+type passwordFunc func(ref cmmeta.SecretKeySelector) ([]byte, error)
+
+// secretCertificates returns the validity of the leaf and CA certificates
+// stored in the Secret, both in PEM format and in any keystores. Keys which
+// are empty or cannot be decoded are skipped.
+func secretCertificates(log logr.Logger, crt *cmapi.Certificate, secret *corev1.Secret, password passwordFunc) []metrics.SecretCertificate {
+	var certs []metrics.SecretCertificate
+
+	add := func(key string, decode func(data []byte) (*x509.Certificate, error)) {
+		data := secret.Data[key]
+		if len(data) == 0 {
+			return
+		}
+		cert, err := decode(data)
+		if err != nil {
+			log.V(logf.DebugLevel).Info("failed to decode certificate stored in Secret", "key", key, "error", err.Error())
+			return
+		}
+		certs = append(certs, metrics.SecretCertificate{Key: key, NotBefore: cert.NotBefore, NotAfter: cert.NotAfter})
+	}
+
+	add(corev1.TLSCertKey, func(data []byte) (*x509.Certificate, error) {
+		chain, err := pki.DecodeX509CertificateChainBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		return chain[0], nil
+	})
+	add(cmmeta.TLSCAKey, func(data []byte) (*x509.Certificate, error) {
+		cas, err := pki.DecodeX509CertificateChainBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		return firstToExpire(cas), nil
+	})
+
+	if ks := crt.Spec.Keystores; ks != nil && ks.PKCS12 != nil && ks.PKCS12.Create {
+		ref := ks.PKCS12.PasswordSecretRef
+		add(cmapi.PKCS12SecretKey, func(data []byte) (*x509.Certificate, error) {
+			pw, err := password(ref)
+			if err != nil {
+				return nil, err
+			}
+			_, cert, _, err := pkcs12.DecodeChain(data, string(pw))
+			return cert, err
+		})
+		add(cmapi.PKCS12TruststoreKey, func(data []byte) (*x509.Certificate, error) {
+			pw, err := password(ref)
+			if err != nil {
+				return nil, err
+			}
+			cas, err := pkcs12.DecodeTrustStore(data, string(pw))
+			if err != nil {
+				return nil, err
+			}
+			if len(cas) == 0 {
+				return nil, errors.New("truststore contains no certificates")
+			}
+			return firstToExpire(cas), nil
+		})
+	}
+
+	if ks := crt.Spec.Keystores; ks != nil && ks.JKS != nil && ks.JKS.Create {
+		ref := ks.JKS.PasswordSecretRef
+		add(cmapi.JKSSecretKey, func(data []byte) (*x509.Certificate, error) {
+			pw, err := password(ref)
+			if err != nil {
+				return nil, err
+			}
+			store := jks.New()
+			if err := store.Load(bytes.NewReader(data), pw); err != nil {
+				return nil, err
+			}
+			entry, err := store.GetPrivateKeyEntry(jksCertificateAlias, pw)
+			if err != nil {
+				return nil, err
+			}
+			if len(entry.CertificateChain) == 0 {
+				return nil, errors.New("keystore entry contains no certificates")
+			}
+			return x509.ParseCertificate(entry.CertificateChain[0].Content)
+		})
+		add(cmapi.JKSTruststoreKey, func(data []byte) (*x509.Certificate, error) {
+			pw, err := password(ref)
+			if err != nil {
+				return nil, err
+			}
+			store := jks.New()
+			if err := store.Load(bytes.NewReader(data), pw); err != nil {
+				return nil, err
+			}
+			entry, err := store.GetTrustedCertificateEntry(jksCAAlias)
+			if err != nil {
+				return nil, err
+			}
+			return x509.ParseCertificate(entry.Certificate.Content)
+		})
+	}
+
+	return certs
+}
+
+// firstToExpire returns the certificate with the earliest NotAfter.
+func firstToExpire(certs []*x509.Certificate) *x509.Certificate {
+	first := certs[0]
+	for _, cert := range certs[1:] {
+		if cert.NotAfter.Before(first.NotAfter) {
+			first = cert
+		}
+	}
+	return first
+}
+
+// secretPassword returns a passwordFunc which reads passwords from Secrets in
+// the given namespace using the controller's Secret lister.
+func (c *controller) secretPassword(namespace string) passwordFunc {
+	return func(ref cmmeta.SecretKeySelector) ([]byte, error) {
+		secret, err := c.secretLister.Secrets(namespace).Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		pw, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("no data for %q in Secret %q", ref.Key, ref.Name)
+		}
+		return pw, nil
+	}
+}
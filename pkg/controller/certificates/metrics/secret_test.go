@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	jks "github.com/pavlo-v-chernykh/keystore-go/v4"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"software.sslmate.com/src/go-pkcs12"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func mustCert(t *testing.T, commonName string, notBefore, notAfter time.Time) ([]byte, *x509.Certificate) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	certPEM := testcrypto.MustCreateCertWithNotBeforeAfter(t, pk, gen.Certificate(commonName, gen.SetCertificateCommonName(commonName)), notBefore, notAfter)
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM, cert
+}
+
+func mustJKSTruststore(t *testing.T, password []byte, ca *x509.Certificate) []byte {
+	ks := jks.New()
+	if err := ks.SetTrustedCertificateEntry(jksCAAlias, jks.TrustedCertificateEntry{
+		CreationTime: time.Now(),
+		Certificate:  jks.Certificate{Type: "X509", Content: ca.Raw},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if err := ks.Store(buf, password); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSecretCertificates(t *testing.T) {
+	now := time.Unix(time.Now().Unix(), 0)
+	leafPEM, _ := mustCert(t, "leaf", now, now.Add(time.Hour))
+	ca1PEM, ca1 := mustCert(t, "ca-1", now.Add(-time.Hour), now.Add(48*time.Hour))
+	ca2PEM, ca2 := mustCert(t, "ca-2", now.Add(-2*time.Hour), now.Add(24*time.Hour))
+
+	p12Truststore, err := pkcs12.EncodeTrustStore(rand.Reader, []*x509.Certificate{ca1, ca2}, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jksTruststore := mustJKSTruststore(t, []byte("password"), ca1)
+
+	passwordRef := cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "keystore-password"}, Key: "password"}
+	withKeystores := func(create bool) *cmapi.Certificate {
+		crt := gen.Certificate("test")
+		crt.Spec.Keystores = &cmapi.CertificateKeystores{
+			PKCS12: &cmapi.PKCS12Keystore{Create: create, PasswordSecretRef: passwordRef},
+			JKS:    &cmapi.JKSKeystore{Create: create, PasswordSecretRef: passwordRef},
+		}
+		return crt
+	}
+	password := func(ref cmmeta.SecretKeySelector) ([]byte, error) {
+		return []byte("password"), nil
+	}
+
+	tests := map[string]struct {
+		crt      *cmapi.Certificate
+		data     map[string][]byte
+		password passwordFunc
+		exp      []metrics.SecretCertificate
+	}{
+		"leaf and the CA which expires first": {
+			crt: gen.Certificate("test"),
+			data: map[string][]byte{
+				corev1.TLSCertKey: append(append([]byte{}, leafPEM...), ca1PEM...),
+				cmmeta.TLSCAKey:   append(append([]byte{}, ca1PEM...), ca2PEM...),
+			},
+			exp: []metrics.SecretCertificate{
+				{Key: corev1.TLSCertKey, NotBefore: now, NotAfter: now.Add(time.Hour)},
+				{Key: cmmeta.TLSCAKey, NotBefore: now.Add(-2 * time.Hour), NotAfter: now.Add(24 * time.Hour)},
+			},
+		},
+		"empty and invalid keys are skipped": {
+			crt: gen.Certificate("test"),
+			data: map[string][]byte{
+				corev1.TLSCertKey: []byte("not a certificate"),
+				cmmeta.TLSCAKey:   {},
+			},
+		},
+		"truststores are read when keystores are enabled": {
+			crt:      withKeystores(true),
+			password: password,
+			data: map[string][]byte{
+				cmapi.PKCS12TruststoreKey: p12Truststore,
+				cmapi.JKSTruststoreKey:    jksTruststore,
+			},
+			exp: []metrics.SecretCertificate{
+				{Key: cmapi.PKCS12TruststoreKey, NotBefore: now.Add(-2 * time.Hour), NotAfter: now.Add(24 * time.Hour)},
+				{Key: cmapi.JKSTruststoreKey, NotBefore: now.Add(-time.Hour), NotAfter: now.Add(48 * time.Hour)},
+			},
+		},
+		"keystores are ignored when not enabled": {
+			crt:      withKeystores(false),
+			password: password,
+			data: map[string][]byte{
+				cmapi.PKCS12TruststoreKey: p12Truststore,
+				cmapi.JKSTruststoreKey:    jksTruststore,
+			},
+		},
+		"keystores are skipped if the password cannot be read": {
+			crt: withKeystores(true),
+			password: func(ref cmmeta.SecretKeySelector) ([]byte, error) {
+				return nil, errors.New("not found")
+			},
+			data: map[string][]byte{
+				cmapi.PKCS12TruststoreKey: p12Truststore,
+				cmapi.JKSTruststoreKey:    jksTruststore,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secret := &corev1.Secret{Data: test.data}
+			certs := secretCertificates(logtesting.NewTestLogger(t), test.crt, secret, test.password)
+			assert.Len(t, certs, len(test.exp))
+			for i := range test.exp {
+				if i >= len(certs) {
+					break
+				}
+				assert.Equal(t, test.exp[i].Key, certs[i].Key)
+				assert.True(t, test.exp[i].NotBefore.Equal(certs[i].NotBefore), "unexpected NotBefore for %s", certs[i].Key)
+				assert.True(t, test.exp[i].NotAfter.Equal(certs[i].NotAfter), "unexpected NotAfter for %s", certs[i].Key)
+			}
+		})
+	}
+}
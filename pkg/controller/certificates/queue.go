@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+)
+
+// UrgentRenewalWindow is the time before expiry from which a Certificate is
+// processed ahead of all other Certificates by the controllers which renew
+// it, so that it does not expire while the controllers work through a
+// backlog, such as after an outage.
+const UrgentRenewalWindow = 6 * time.Hour
+
+// NewPriorityQueue returns the queue of a Certificate controller, in which
+// Certificates expiring within UrgentRenewalWindow are processed first.
+func NewPriorityQueue(rateLimiter workqueue.RateLimiter, name string, lister cmlisters.CertificateLister, clock clock.Clock) workqueue.RateLimitingInterface {
+	return controllerpkg.NewPriorityRateLimitingQueue(rateLimiter, name, clock, func(item interface{}) bool {
+		key, ok := item.(string)
+		if !ok {
+			return false
+		}
+		return isUrgent(lister, clock, key)
+	})
+}
+
+// isUrgent returns true if the Certificate with the given key expires within
+// UrgentRenewalWindow, or has already expired.
+// For short-lived certificates the window is reduced to the last third of
+// the certificate's lifetime, as they would otherwise always be urgent.
+func isUrgent(lister cmlisters.CertificateLister, clock clock.Clock, key string) bool {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return false
+	}
+	crt, err := lister.Certificates(namespace).Get(name)
+	if err != nil || crt.Status.NotAfter == nil {
+		return false
+	}
+	window := UrgentRenewalWindow
+	if crt.Status.NotBefore != nil {
+		if lifetime := crt.Status.NotAfter.Sub(crt.Status.NotBefore.Time); lifetime/3 < window {
+			window = lifetime / 3
+		}
+	}
+	return clock.Now().Add(window).After(crt.Status.NotAfter.Time)
+}
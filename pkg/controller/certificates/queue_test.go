@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+)
+
+func TestIsUrgent(t *testing.T) {
+	now := time.Now()
+	clock := fakeclock.NewFakeClock(now)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for name, notAfter := range map[string]*metav1.Time{
+		"expired":       {Time: now.Add(-time.Hour)},
+		"expiring-soon": {Time: now.Add(time.Hour)},
+		"expiring-late": {Time: now.Add(UrgentRenewalWindow + time.Hour)},
+		"not-issued":    nil,
+	} {
+		crt := &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Status:     cmapi.CertificateStatus{NotAfter: notAfter},
+		}
+		if err := indexer.Add(crt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lister := cmlisters.NewCertificateLister(indexer)
+
+	tests := map[string]bool{
+		"ns/expired":       true,
+		"ns/expiring-soon": true,
+		"ns/expiring-late": false,
+		"ns/not-issued":    false,
+		"ns/missing":       false,
+	}
+	for key, exp := range tests {
+		t.Run(key, func(t *testing.T) {
+			if got := isUrgent(lister, clock, key); got != exp {
+				t.Errorf("expected urgent=%t, got %t", exp, got)
+			}
+		})
+	}
+
+	// Certificates become urgent as the clock approaches their expiry.
+	clock.Step(2 * time.Hour)
+	if !isUrgent(lister, clock, "ns/expiring-late") {
+		t.Errorf("expected Certificate to be urgent once it expires within %s", UrgentRenewalWindow)
+	}
+}
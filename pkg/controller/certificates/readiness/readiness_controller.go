@@ -18,6 +18,7 @@ package readiness
 
 import (
 	"context"
+	"crypto/x509"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -30,10 +31,11 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
-	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/clocksource"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -43,7 +45,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
-	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/scheduler"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
 )
@@ -68,6 +70,13 @@ type controller struct {
 	// renewalTimeCalculator calculates renewal time of a certificate
 	renewalTimeCalculator certificates.RenewalTimeFunc
 
+	// clock and scheduledWorkQueue are used to re-evaluate a Certificate
+	// right after its current certificate expires, so that the Ready
+	// condition doesn't depend on the informer resync period. This matters
+	// for short-lived certificates which can expire within minutes.
+	clock              clock.Clock
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
@@ -83,18 +92,21 @@ func NewController(
 	client cmclient.Interface,
 	factory informers.SharedInformerFactory,
 	cmFactory cminformers.SharedInformerFactory,
+	clock clock.Clock,
 	chain policies.Chain,
 	renewalTimeCalculator certificates.RenewalTimeFunc,
 	policyEvaluator policyEvaluatorFunc,
 	fieldManager string,
+	rateLimiter workqueue.RateLimiter,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
 	// create a queue used to queue up items to be processed
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ControllerName)
 
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
-	secretsInformer := factory.Core().V1().Secrets()
+	secretsInformer := controllerpkg.SecretsMetadataInformer(factory)
+	secretGetter := controllerpkg.NewSecretGetter(factory)
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 
@@ -103,7 +115,7 @@ func NewController(
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(), predicate.ResourceOwnerOf),
 	})
 	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
-	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+	secretsInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Trigger reconciles on changes to the Secret named `spec.secretName`
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
 			predicate.ExtractResourceName(predicate.CertificateSecretName)),
@@ -113,7 +125,7 @@ func NewController(
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		certificateRequestInformer.Informer().HasSynced,
-		secretsInformer.Informer().HasSynced,
+		secretsInformer.HasSynced,
 		certificateInformer.Informer().HasSynced,
 	}
 
@@ -121,14 +133,16 @@ func NewController(
 		policyChain:              chain,
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
-		secretLister:             secretsInformer.Lister(),
+		secretLister:             secretGetter,
 		client:                   client,
 		gatherer: &policies.Gatherer{
 			CertificateRequestLister: certificateRequestInformer.Lister(),
-			SecretLister:             secretsInformer.Lister(),
+			SecretLister:             secretGetter,
 		},
 		policyEvaluator:       policyEvaluator,
 		renewalTimeCalculator: renewalTimeCalculator,
+		clock:                 clock,
+		scheduledWorkQueue:    scheduler.NewScheduledWorkQueue(clock, queue.Add),
 		fieldManager:          fieldManager,
 	}, queue, mustSync
 }
@@ -173,6 +187,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 			crt.Status.NotAfter = nil
 			crt.Status.NotBefore = nil
 			crt.Status.RenewalTime = nil
+			clearCertificateDetails(&crt.Status)
 			break
 		}
 
@@ -185,44 +200,86 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		crt.Status.NotBefore = &notBefore
 		crt.Status.NotAfter = &notAfter
 		crt.Status.RenewalTime = renewalTime
+		setCertificateDetails(&crt.Status, x509cert)
+
+		c.scheduleRecheckOnExpiry(log, key, x509cert.NotAfter)
 
 	default:
 		// clear status fields if the secret does not have any data
 		crt.Status.NotAfter = nil
 		crt.Status.NotBefore = nil
 		crt.Status.RenewalTime = nil
+		clearCertificateDetails(&crt.Status)
 	}
 	if !apiequality.Semantic.DeepEqual(oldCrt.Status, crt.Status) {
 		log.V(logf.DebugLevel).Info("updating status fields", "notAfter",
 			crt.Status.NotAfter, "notBefore", crt.Status.NotBefore, "renewalTime",
 			crt.Status.RenewalTime)
-		return c.updateOrApplyStatus(ctx, crt)
+		return c.applyStatus(ctx, crt)
 	}
 	return nil
 }
 
-// updateOrApplyStatus will update the controller status. If the
-// ServerSideApply feature is enabled, the managed fields will instead get
-// applied using the relevant Patch API call.
-func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		var conditions []cmapi.CertificateCondition
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
-			conditions = []cmapi.CertificateCondition{*cond}
-		}
-		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
-			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
-			Status: cmapi.CertificateStatus{
-				NotAfter:    crt.Status.NotAfter,
-				NotBefore:   crt.Status.NotBefore,
-				RenewalTime: crt.Status.RenewalTime,
-				Conditions:  conditions,
-			},
-		})
-	} else {
-		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
-		return err
+// scheduleRecheckOnExpiry schedules the Certificate with the given key to be
+// processed again just after the given expiry time, so that its Ready
+// condition is updated as soon as the certificate expires. Nothing is
+// scheduled if the certificate has already expired.
+func (c *controller) scheduleRecheckOnExpiry(log logr.Logger, key string, notAfter time.Time) {
+	durationUntilExpiry := notAfter.Sub(c.clock.Now())
+	if durationUntilExpiry < 0 {
+		return
+	}
+
+	log.V(logf.DebugLevel).Info("scheduling readiness check on expiry", "duration_until_expiry", durationUntilExpiry.String())
+
+	c.scheduledWorkQueue.Add(key, durationUntilExpiry+time.Second)
+}
+
+// applyStatus applies the fields of the status which are managed by this
+// controller.
+func (c *controller) applyStatus(ctx context.Context, crt *cmapi.Certificate) error {
+	var conditions []cmapi.CertificateCondition
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
+		conditions = []cmapi.CertificateCondition{*cond}
+	}
+	return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+		Status: cmapi.CertificateStatus{
+			NotAfter:       crt.Status.NotAfter,
+			NotBefore:      crt.Status.NotBefore,
+			RenewalTime:    crt.Status.RenewalTime,
+			SerialNumber:   crt.Status.SerialNumber,
+			IssuerDN:       crt.Status.IssuerDN,
+			DNSNames:       crt.Status.DNSNames,
+			IPAddresses:    crt.Status.IPAddresses,
+			URIs:           crt.Status.URIs,
+			EmailAddresses: crt.Status.EmailAddresses,
+			Conditions:     conditions,
+		},
+	})
+}
+
+// setCertificateDetails sets the status fields which describe the issued
+// certificate, so that users don't need to decode the Secret to read them.
+func setCertificateDetails(status *cmapi.CertificateStatus, x509cert *x509.Certificate) {
+	status.SerialNumber = ""
+	if x509cert.SerialNumber != nil {
+		status.SerialNumber = x509cert.SerialNumber.Text(16)
 	}
+	status.IssuerDN = x509cert.Issuer.String()
+	status.DNSNames = x509cert.DNSNames
+	status.IPAddresses = pki.IPAddressesToString(x509cert.IPAddresses)
+	status.URIs = pki.URLsToString(x509cert.URIs)
+	status.EmailAddresses = x509cert.EmailAddresses
+}
+
+func clearCertificateDetails(status *cmapi.CertificateStatus) {
+	status.SerialNumber = ""
+	status.IssuerDN = ""
+	status.DNSNames = nil
+	status.IPAddresses = nil
+	status.URIs = nil
+	status.EmailAddresses = nil
 }
 
 // BuildReadyConditionFromChain builds Certificate's Ready condition using the result of policy chain evaluation
@@ -258,10 +315,12 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.CMClient,
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
-		policies.NewReadinessPolicyChain(ctx.Clock),
-		certificates.RenewalTime,
+		clocksource.WithSkewTolerance(ctx.Clock, ctx.ClockSkewTolerance),
+		policies.NewReadinessPolicyChain(clocksource.WithSkewTolerance(ctx.Clock, ctx.ClockSkewTolerance)),
+		certificates.RenewalTimeWithClockSkewTolerance(ctx.ClockSkewTolerance),
 		BuildReadyConditionFromChain,
 		ctx.FieldManager,
+		ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30),
 	)
 	c.controller = ctrl
 
@@ -269,7 +328,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 }
 
 func init() {
-	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+	controllerpkg.RegisterSharded(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
 		return controllerpkg.NewBuilder(ctx, ControllerName).
 			For(&controllerWrapper{}).
 			Complete()
@@ -18,13 +18,14 @@ package readiness
 
 import (
 	"context"
+	"crypto/x509"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -32,6 +33,8 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	schedulertest "github.com/cert-manager/cert-manager/pkg/scheduler/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
@@ -101,6 +104,10 @@ func TestProcessItem(t *testing.T) {
 		// renewalTime will be the updated Certificate's status.renewalTime
 		renewalTime *metav1.Time
 
+		// expectedRecheck is the delay after which the Certificate is
+		// expected to be re-queued, if any.
+		expectedRecheck *time.Duration
+
 		wantsErr bool
 	}{
 		"do nothing if an empty 'key' is used": {},
@@ -124,6 +131,7 @@ func TestProcessItem(t *testing.T) {
 			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
 			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
 			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			expectedRecheck:   pointer.Duration(now.Add(time.Hour*2).Truncate(time.Second).Sub(now) + time.Second),
 		},
 		"update status for a Certificate that is evaluated as not Ready and whose spec.secretName secret contains a valid X509 cert": {
 			condition: cmapi.CertificateCondition{
@@ -139,6 +147,22 @@ func TestProcessItem(t *testing.T) {
 			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
 			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
 			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			expectedRecheck:   pointer.Duration(now.Add(time.Hour*2).Truncate(time.Second).Sub(now) + time.Second),
+		},
+		"update status but do not schedule a re-check for a Certificate whose X509 cert has already expired": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionFalse,
+				Reason:             "Expired",
+				Message:            "some message",
+				LastTransitionTime: &metaNow,
+			},
+			cert:              gen.CertificateFrom(cert),
+			certShouldUpdate:  true,
+			secretShouldExist: true,
+			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(-time.Minute * 5).Truncate(time.Second))),
+			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(-time.Minute * 20).Truncate(time.Second))),
+			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(-time.Minute * 10))),
 		},
 		"update status for a Certificate whose spec.secretName secret does not exist": {
 			condition: cmapi.CertificateCondition{
@@ -249,11 +273,18 @@ func TestProcessItem(t *testing.T) {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.cert)
 			}
 
+			// issuedCert is the X509 cert stored in the secret, if any.
+			var issuedCert *x509.Certificate
 			if test.secretShouldExist {
 				mods := make([]gen.SecretModifier, 0)
 				// If the test scenario needs a secret with a valid X509 cert.
 				if test.notBefore != nil && test.notAfter != nil {
 					x509Bytes := testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey, cert, test.notBefore.Time, test.notAfter.Time)
+					decoded, err := pki.DecodeX509CertificateBytes(x509Bytes)
+					if err != nil {
+						t.Fatal(err)
+					}
+					issuedCert = decoded
 					mods = append(mods,
 						gen.SetSecretData(map[string][]byte{
 							"tls.crt": x509Bytes,
@@ -279,6 +310,14 @@ func TestProcessItem(t *testing.T) {
 			// Override controller's renewalTime func with a fake that returns test.renewalTime.
 			w.controller.renewalTimeCalculator = renewalTimeBuilder(test.renewalTime)
 
+			// Record the re-check scheduled by the controller, if any.
+			var gotRecheck *time.Duration
+			w.controller.scheduledWorkQueue = &schedulertest.FakeScheduler{
+				AddFunc: func(_ interface{}, d time.Duration) {
+					gotRecheck = &d
+				},
+			}
+
 			// If Certificate's status should be updated,
 			// build the expected Certificate and use it to set the expected update action on builder.
 			if test.certShouldUpdate {
@@ -289,13 +328,17 @@ func TestProcessItem(t *testing.T) {
 				c.Status.NotAfter = test.notAfter
 				c.Status.NotBefore = test.notBefore
 				c.Status.RenewalTime = test.renewalTime
+				if issuedCert != nil {
+					c.Status.SerialNumber = issuedCert.SerialNumber.Text(16)
+					c.Status.IssuerDN = issuedCert.Issuer.String()
+					c.Status.DNSNames = []string{"example.com"}
+				}
 
 				builder.ExpectedActions = append(builder.ExpectedActions,
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
-						"status",
 						c.Namespace,
-						c)))
+						c))
 			}
 
 			// Start the informers and begin processing updates.
@@ -322,6 +365,14 @@ func TestProcessItem(t *testing.T) {
 			if err := builder.AllReactorsCalled(); err != nil {
 				builder.T.Error(err)
 			}
+			switch {
+			case test.expectedRecheck == nil && gotRecheck != nil:
+				t.Errorf("expected no re-check to be scheduled, got one in %s", *gotRecheck)
+			case test.expectedRecheck != nil && gotRecheck == nil:
+				t.Errorf("expected a re-check to be scheduled in %s, got none", *test.expectedRecheck)
+			case test.expectedRecheck != nil && *test.expectedRecheck != *gotRecheck:
+				t.Errorf("expected a re-check to be scheduled in %s, got %s", *test.expectedRecheck, *gotRecheck)
+			}
 		})
 	}
 }
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -51,6 +52,7 @@ import (
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+	"github.com/cert-manager/cert-manager/pkg/util/tracing"
 )
 
 const (
@@ -87,14 +89,16 @@ func NewController(
 	clock clock.Clock,
 	certificateControllerOptions controllerpkg.CertificateOptions,
 	fieldManager string,
+	rateLimiter workqueue.RateLimiter,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
-	// create a queue used to queue up items to be processed
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
-
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
-	secretsInformer := factory.Core().V1().Secrets()
+	secretsInformer := controllerpkg.SecretsMetadataInformer(factory)
+
+	// create a queue used to queue up items to be processed, in which
+	// Certificates which are about to expire are processed first
+	queue := certificates.NewPriorityQueue(rateLimiter, ControllerName, certificateInformer.Lister(), clock)
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
@@ -103,7 +107,7 @@ func NewController(
 			predicate.ResourceOwnerOf,
 		),
 	})
-	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+	secretsInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Trigger reconciles on changes to any 'owned' secret resources
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
 			predicate.ResourceOwnerOf,
@@ -113,7 +117,7 @@ func NewController(
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
-		secretsInformer.Informer().HasSynced,
+		secretsInformer.HasSynced,
 		certificateRequestInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
 	}
@@ -121,7 +125,7 @@ func NewController(
 	return &controller{
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
-		secretLister:             secretsInformer.Lister(),
+		secretLister:             controllerpkg.NewSecretGetter(factory),
 		client:                   client,
 		recorder:                 recorder,
 		clock:                    clock,
@@ -357,7 +361,11 @@ func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cma
 	return remaining, nil
 }
 
-func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer, nextRevision int, nextPrivateKeySecretName string) error {
+func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer, nextRevision int, nextPrivateKeySecretName string) (err error) {
+	ctx, span := tracing.Start(ctx, crt, "Certificate.CreateCertificateRequest",
+		append(tracing.IssuerRefAttributes(crt.Spec.IssuerRef), attribute.Int("cert-manager.revision", nextRevision))...)
+	defer func() { tracing.End(span, err) }()
+
 	log := logf.FromContext(ctx)
 	x509CSR, err := pki.GenerateCSR(crt)
 	if err != nil {
@@ -379,6 +387,8 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 	annotations[cmapi.CertificateRequestRevisionAnnotationKey] = strconv.Itoa(nextRevision)
 	annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey] = nextPrivateKeySecretName
 	annotations[cmapi.CertificateNameKey] = crt.Name
+	// Continue the trace in the controllers processing the request.
+	tracing.Inject(ctx, annotations)
 
 	cr := &cmapi.CertificateRequest{
 		ObjectMeta: metav1.ObjectMeta{
@@ -393,7 +403,7 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 			IssuerRef: crt.Spec.IssuerRef,
 			Request:   csrPEM.Bytes(),
 			IsCA:      crt.Spec.IsCA,
-			Usages:    crt.Spec.Usages,
+			Usages:    apiutil.CertificateUsages(&crt.Spec),
 		},
 	}
 
@@ -454,6 +464,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.Clock,
 		ctx.CertificateOptions,
 		ctx.FieldManager,
+		ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30),
 	)
 	c.controller = ctrl
 
@@ -461,7 +472,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 }
 
 func init() {
-	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+	controllerpkg.RegisterSharded(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
 		return controllerpkg.NewBuilder(ctx, ControllerName).
 			For(&controllerWrapper{}).
 			Complete()
@@ -28,9 +28,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -50,6 +53,7 @@ const (
 type controller struct {
 	certificateLister        cmlisters.CertificateLister
 	certificateRequestLister cmlisters.CertificateRequestLister
+	secretLister             corelisters.SecretLister
 	client                   cmclient.Interface
 }
 
@@ -58,13 +62,14 @@ type revision struct {
 	types.NamespacedName
 }
 
-func NewController(log logr.Logger, client cmclient.Interface, cmFactory cminformers.SharedInformerFactory) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+func NewController(log logr.Logger, client cmclient.Interface, factory informers.SharedInformerFactory, cmFactory cminformers.SharedInformerFactory, rateLimiter workqueue.RateLimiter) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
 	// create a queue used to queue up items to be processed
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ControllerName)
 
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
+	secretsInformer := controllerpkg.SecretsMetadataInformer(factory)
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
@@ -73,24 +78,35 @@ func NewController(log logr.Logger, client cmclient.Interface, cmFactory cminfor
 			predicate.ResourceOwnerOf,
 		),
 	})
+	secretsInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Trigger reconciles on changes to the Secret named `spec.secretName`,
+		// so that garbage collection resumes once its consumers acknowledge
+		// the current revision.
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		certificateRequestInformer.Informer().HasSynced,
+		secretsInformer.HasSynced,
 		certificateInformer.Informer().HasSynced,
 	}
 
 	return &controller{
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
+		secretLister:             controllerpkg.NewSecretGetter(factory),
 		client:                   client,
 	}, queue, mustSync
 }
 
 // ProcessItem will attempt to garbage collect old CertificateRequests based
 // upon `spec.revisionHistoryLimit`. This controller will only act on
-// Certificates which are in a Ready state and this value is set.
+// Certificates which are in a Ready state and this value is set. If
+// `spec.propagationQuorum` is set, garbage collection waits until enough
+// consumers of the Secret have acknowledged the current revision.
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	log := logf.FromContext(ctx).WithValues("key", key)
 
@@ -126,6 +142,20 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
+	// Keep older revisions around until the consumers of the Secret have
+	// moved on from them.
+	if crt.Spec.PropagationQuorum != nil {
+		secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if acks, reached := internalcertificates.PropagationAcknowledgements(crt, secret); !reached {
+			log.V(logf.DebugLevel).Info("waiting for consumers to acknowledge the current revision before garbage collecting",
+				"acknowledgements", acks, "quorum", *crt.Spec.PropagationQuorum)
+			return nil
+		}
+	}
+
 	// Get all CertificateRequests that are owned by this Certificate
 	requests, err := certificates.ListCertificateRequestsMatchingPredicates(
 		c.certificateRequestLister.CertificateRequests(crt.Namespace), labels.Everything(), predicate.ResourceOwnedBy(crt))
@@ -205,14 +235,14 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 	// construct a new named logger to be reused throughout the controller
 	log := logf.FromContext(ctx.RootContext, ControllerName)
 
-	ctrl, queue, mustSync := NewController(log, ctx.CMClient, ctx.SharedInformerFactory)
+	ctrl, queue, mustSync := NewController(log, ctx.CMClient, ctx.KubeSharedInformerFactory, ctx.SharedInformerFactory, ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30))
 	c.controller = ctrl
 
 	return queue, mustSync, nil
 }
 
 func init() {
-	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+	controllerpkg.RegisterSharded(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
 		return controllerpkg.NewBuilder(ctx, ControllerName).
 			For(&controllerWrapper{}).
 			Complete()
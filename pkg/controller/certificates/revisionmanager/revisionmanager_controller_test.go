@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	logtesting "github.com/go-logr/logr/testing"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -61,6 +62,9 @@ func TestProcessItem(t *testing.T) {
 		// Request, if set, will exist in the apiserver before the test is run.
 		requests []runtime.Object
 
+		// secret, if set, will exist in the apiserver before the test is run.
+		secret *corev1.Secret
+
 		expectedActions []testpkg.Action
 
 		// err is the expected error text returned by the controller, if any.
@@ -156,6 +160,63 @@ func TestProcessItem(t *testing.T) {
 				),
 			},
 		},
+		"do nothing if the propagation quorum has not acknowledged the current revision": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+				gen.SetCertificateRevisionHistoryLimit(1),
+				gen.SetCertificatePropagationQuorum(2),
+				gen.SetCertificateSecretName("test-secret"),
+			),
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "testns",
+				Name:      "test-secret",
+				Annotations: map[string]string{
+					cmapi.CertificateRequestRevisionAnnotationKey:                "2",
+					cmapi.PropagationAcknowledgementAnnotationPrefix + "gateway": "2",
+					cmapi.PropagationAcknowledgementAnnotationPrefix + "sidecar": "1",
+				},
+			}},
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("cr-1"),
+					gen.SetCertificateRequestRevision("1"),
+				),
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("cr-2"),
+					gen.SetCertificateRequestRevision("2"),
+				),
+			},
+		},
+		"delete 1 request if the propagation quorum has acknowledged the current revision": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+				gen.SetCertificateRevisionHistoryLimit(1),
+				gen.SetCertificatePropagationQuorum(2),
+				gen.SetCertificateSecretName("test-secret"),
+			),
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "testns",
+				Name:      "test-secret",
+				Annotations: map[string]string{
+					cmapi.CertificateRequestRevisionAnnotationKey:                "2",
+					cmapi.PropagationAcknowledgementAnnotationPrefix + "gateway": "2",
+					cmapi.PropagationAcknowledgementAnnotationPrefix + "sidecar": "2",
+				},
+			}},
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("cr-1"),
+					gen.SetCertificateRequestRevision("1"),
+				),
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("cr-2"),
+					gen.SetCertificateRequestRevision("2"),
+				),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "cr-1")),
+			},
+		},
 		"delete 1 request if limit is 1 and 2 requests exist": {
 			certificate: gen.CertificateFrom(baseCrt,
 				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
@@ -226,6 +287,9 @@ func TestProcessItem(t *testing.T) {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.certificate)
 			}
 			builder.CertManagerObjects = append(builder.CertManagerObjects, test.requests...)
+			if test.secret != nil {
+				builder.KubeObjects = append(builder.KubeObjects, test.secret)
+			}
 			builder.Init()
 
 			// Register informers used by the controller using the registration wrapper
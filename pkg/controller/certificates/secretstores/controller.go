@@ -0,0 +1,295 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstores
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	internalvault "github.com/cert-manager/cert-manager/internal/vault"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+const (
+	// ControllerName is the name of the certificate secret stores controller.
+	ControllerName = "certificates-secret-stores"
+
+	reasonPushed = "SecretStorePushed"
+	reasonFailed = "SecretStoreFailed"
+)
+
+// store is an external secret store configured on a Certificate.
+type store interface {
+	// push writes data to the store unless it already holds it, returning
+	// whether it was written.
+	push(ctx context.Context, data map[string]string) (bool, error)
+}
+
+// storeBuilder returns the store configured in spec for the Certificate.
+type storeBuilder func(ctx context.Context, crt *cmapi.Certificate, spec cmapi.CertificateSecretStore) (store, error)
+
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
+	recorder          record.EventRecorder
+	newStore          storeBuilder
+
+	// pushed holds the hash of the data last pushed to each store, keyed by
+	// the Certificate's key and the index of the store, so that stores are
+	// not read on every resync. It is only kept in memory, so every store is
+	// read once after the controller restarts.
+	lock   sync.Mutex
+	pushed map[string][32]byte
+}
+
+// NewController returns a new controller which pushes the key pair in the
+// Secret of each Certificate to the external stores in its spec.secretStores.
+func NewController(
+	log logr.Logger,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	newStore storeBuilder,
+	rateLimiter workqueue.RateLimiter,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ControllerName)
+
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	secretsInformer := controllerpkg.SecretsMetadataInformer(factory)
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
+	secretsInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+	})
+
+	mustSync := []cache.InformerSynced{
+		secretsInformer.HasSynced,
+		certificateInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		certificateLister: certificateInformer.Lister(),
+		secretLister:      controllerpkg.NewSecretGetter(factory),
+		recorder:          recorder,
+		newStore:          newStore,
+		pushed:            make(map[string][32]byte),
+	}, queue, mustSync
+}
+
+// ProcessItem pushes the key pair in the Certificate's Secret to each of its
+// external stores which does not already hold it.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		c.forget(key, 0)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	c.forget(key, len(crt.Spec.SecretStores))
+	if len(crt.Spec.SecretStores) == 0 {
+		return nil
+	}
+
+	secret, err := c.secretLister.Secrets(namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("secret not found, waiting for it to be issued")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := secretData(secret)
+	if err != nil {
+		log.V(logf.DebugLevel).Info("secret does not contain a valid key pair, waiting for it to be issued", "reason", err.Error())
+		return nil
+	}
+	hash, err := hashData(data)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i, spec := range crt.Spec.SecretStores {
+		storeKey := key + "/" + strconv.Itoa(i)
+		if c.lastPushed(storeKey) == hash {
+			continue
+		}
+
+		written, err := c.pushTo(ctx, crt, spec, data)
+		if err != nil {
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonFailed, "Failed to push to secretStores[%d]: %v", i, err)
+			errs = append(errs, fmt.Errorf("secretStores[%d]: %w", i, err))
+			continue
+		}
+		if written {
+			c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonPushed, "Pushed the certificate in Secret %q to secretStores[%d]", secret.Name, i)
+		}
+		c.setPushed(storeKey, hash)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to push to secret stores: %v", errs)
+	}
+	return nil
+}
+
+func (c *controller) pushTo(ctx context.Context, crt *cmapi.Certificate, spec cmapi.CertificateSecretStore, data map[string]string) (bool, error) {
+	s, err := c.newStore(ctx, crt, spec)
+	if err != nil {
+		return false, err
+	}
+	return s.push(ctx, data)
+}
+
+func (c *controller) lastPushed(storeKey string) [32]byte {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.pushed[storeKey]
+}
+
+func (c *controller) setPushed(storeKey string, hash [32]byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.pushed[storeKey] = hash
+}
+
+// forget removes the state of stores of the Certificate with the given key
+// at or beyond index keep, which are no longer configured.
+func (c *controller) forget(key string, keep int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i := keep; ; i++ {
+		storeKey := key + "/" + strconv.Itoa(i)
+		if _, ok := c.pushed[storeKey]; !ok {
+			return
+		}
+		delete(c.pushed, storeKey)
+	}
+}
+
+// secretData returns the fields pushed to external stores, and an error if
+// the Secret does not hold a matching private key and certificate.
+func secretData(secret *corev1.Secret) (map[string]string, error) {
+	certPEM, keyPEM := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	key, err := pki.DecodePrivateKeyBytes(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := pki.PublicKeyMatchesCertificate(key.Public(), cert)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return nil, fmt.Errorf("private key does not match certificate")
+	}
+
+	data := map[string]string{
+		corev1.TLSCertKey:       string(certPEM),
+		corev1.TLSPrivateKeyKey: string(keyPEM),
+	}
+	if ca := secret.Data[cmmeta.TLSCAKey]; len(ca) > 0 {
+		data[cmmeta.TLSCAKey] = string(ca)
+	}
+	return data, nil
+}
+
+func hashData(data map[string]string) ([32]byte, error) {
+	// json.Marshal sorts map keys, so equal data has an equal hash.
+	b, err := json.Marshal(data)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sha256.Sum256(b), nil
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	builder := &stores{
+		secretLister:       controllerpkg.NewSecretGetter(ctx.KubeSharedInformerFactory),
+		createTokenFn:      internalvault.CreateTokenFromClient(ctx.Client),
+		ambientCredentials: ctx.IssuerAmbientCredentials,
+		userAgent:          ctx.RESTConfig.UserAgent,
+	}
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		builder.New,
+		ctx.RateLimiterFor(ControllerName, time.Second*5, time.Minute*5),
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.RegisterSharded(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
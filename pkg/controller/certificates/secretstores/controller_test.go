@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstores
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+type fakeStore struct {
+	pushes int
+	err    error
+}
+
+func (f *fakeStore) push(_ context.Context, _ map[string]string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	f.pushes++
+	return true, nil
+}
+
+func TestProcessItem(t *testing.T) {
+	keyPEM := testcrypto.MustCreatePEMPrivateKey(t)
+	certPEM := testcrypto.MustCreateCert(t, keyPEM, gen.Certificate("test", gen.SetCertificateCommonName("example.com")))
+	otherKeyPEM := testcrypto.MustCreatePEMPrivateKey(t)
+
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("default"),
+		gen.SetCertificateSecretName("test-tls"),
+	)
+	crt.Spec.SecretStores = []cmapi.CertificateSecretStore{
+		{Vault: &cmapi.VaultSecretStore{Mount: "secret", Path: "test"}},
+		{AWSSecretsManager: &cmapi.AWSSecretsManagerSecretStore{Region: "eu-west-1", SecretName: "test"}},
+	}
+	secretWith := func(key []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-tls"},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: key,
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		secret      *corev1.Secret
+		storeErr    error
+		expPushes   int
+		expEvents   int
+		expErr      bool
+		expRepushed bool
+	}{
+		"pushes to every store and skips them once pushed": {
+			secret:    secretWith(keyPEM),
+			expPushes: 2,
+			expEvents: 2,
+		},
+		"failed pushes are retried": {
+			secret:      secretWith(keyPEM),
+			storeErr:    errors.New("unavailable"),
+			expEvents:   2,
+			expErr:      true,
+			expRepushed: true,
+		},
+		"nothing is pushed if the secret does not exist": {},
+		"nothing is pushed if the key does not match the certificate": {
+			secret: secretWith(otherKeyPEM),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crtIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			assert.NoError(t, crtIndexer.Add(crt))
+			if test.secret != nil {
+				assert.NoError(t, secretIndexer.Add(test.secret))
+			}
+
+			fake := &fakeStore{err: test.storeErr}
+			builds := 0
+			recorder := record.NewFakeRecorder(10)
+			c := &controller{
+				certificateLister: cmlisters.NewCertificateLister(crtIndexer),
+				secretLister:      corelisters.NewSecretLister(secretIndexer),
+				recorder:          recorder,
+				newStore: func(context.Context, *cmapi.Certificate, cmapi.CertificateSecretStore) (store, error) {
+					builds++
+					return fake, nil
+				},
+				pushed: make(map[string][32]byte),
+			}
+
+			err := c.ProcessItem(context.TODO(), "default/test")
+			assert.Equal(t, test.expErr, err != nil, "unexpected error: %v", err)
+			assert.Equal(t, test.expPushes, fake.pushes)
+			assert.Len(t, recorder.Events, test.expEvents)
+
+			buildsBefore := builds
+			_ = c.ProcessItem(context.TODO(), "default/test")
+			assert.Equal(t, test.expRepushed, builds > buildsBefore, "unexpected push on second sync")
+		})
+	}
+}
+
+func TestForget(t *testing.T) {
+	c := &controller{pushed: map[string][32]byte{
+		"default/test/0":  {1},
+		"default/test/1":  {2},
+		"default/other/0": {3},
+	}}
+	c.forget("default/test", 1)
+	assert.Equal(t, map[string][32]byte{
+		"default/test/0":  {1},
+		"default/other/0": {3},
+	}, c.pushed)
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstores
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	internalvault "github.com/cert-manager/cert-manager/internal/vault"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// stores builds the stores configured on Certificates. Secrets referenced by
+// a store are read from the Certificate's namespace.
+type stores struct {
+	secretLister corelisters.SecretLister
+	// createTokenFn requests ServiceAccount tokens for Vault stores using
+	// Kubernetes auth with a serviceAccountRef.
+	createTokenFn func(ns string) internalvault.CreateToken
+	// ambientCredentials allows AWS stores which do not reference
+	// credentials to use those of the controller.
+	ambientCredentials bool
+	userAgent          string
+
+	// newVaultKV and newSecretsManager may be replaced in tests.
+	newVaultKV        func(namespace string, createTokenFn func(ns string) internalvault.CreateToken, secretsLister corelisters.SecretLister, issuer cmapi.GenericIssuer) (internalvault.KV, error)
+	newSecretsManager func(sess *session.Session) secretsmanageriface.SecretsManagerAPI
+}
+
+// New implements storeBuilder.
+func (s *stores) New(ctx context.Context, crt *cmapi.Certificate, spec cmapi.CertificateSecretStore) (store, error) {
+	switch {
+	case spec.Vault != nil:
+		return s.vault(crt, spec.Vault)
+	case spec.AWSSecretsManager != nil:
+		return s.awsSecretsManager(crt, spec.AWSSecretsManager)
+	}
+	return nil, errors.New("no store type configured")
+}
+
+func (s *stores) vault(crt *cmapi.Certificate, spec *cmapi.VaultSecretStore) (store, error) {
+	newKV := s.newVaultKV
+	if newKV == nil {
+		newKV = internalvault.NewKV
+	}
+
+	// The Vault client is configured from an issuer, so build one in the
+	// Certificate's namespace holding the store's connection settings.
+	issuer := &cmapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+		Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{Vault: &cmapi.VaultIssuer{
+			Auth:              spec.Auth,
+			Server:            spec.Server,
+			Namespace:         spec.Namespace,
+			CABundle:          spec.CABundle,
+			CABundleSecretRef: spec.CABundleSecretRef,
+		}}},
+	}
+	kv, err := newKV(crt.Namespace, s.createTokenFn, s.secretLister, issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vaultStore{kv: kv, mount: spec.Mount, path: spec.Path}, nil
+}
+
+func (s *stores) awsSecretsManager(crt *cmapi.Certificate, spec *cmapi.AWSSecretsManagerSecretStore) (store, error) {
+	sessionOpts := session.Options{Config: *aws.NewConfig().WithRegion(spec.Region)}
+
+	if spec.AccessKeyIDSecretRef != nil || spec.SecretAccessKeySecretRef != nil {
+		accessKeyID, err := s.secretValue(crt.Namespace, spec.AccessKeyIDSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		secretAccessKey, err := s.secretValue(crt.Namespace, spec.SecretAccessKeySecretRef)
+		if err != nil {
+			return nil, err
+		}
+		sessionOpts.Config.Credentials = credentials.NewStaticCredentials(accessKeyID, secretAccessKey, "")
+		// also disable 'ambient' configuration sources
+		sessionOpts.SharedConfigState = session.SharedConfigDisable
+	} else if !s.ambientCredentials {
+		return nil, errors.New("no credentials configured and ambient credentials are not enabled")
+	}
+
+	sess, err := session.NewSessionWithOptions(sessionOpts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create aws session: %w", err)
+	}
+	if spec.Role != "" {
+		sess.Config.Credentials = stscreds.NewCredentials(sess, spec.Role, func(p *stscreds.AssumeRoleProvider) {
+			p.RoleSessionName = "cert-manager"
+		})
+	}
+	sess.Handlers.Build.PushBack(request.WithAppendUserAgent(s.userAgent))
+
+	newClient := s.newSecretsManager
+	if newClient == nil {
+		newClient = func(sess *session.Session) secretsmanageriface.SecretsManagerAPI { return secretsmanager.New(sess) }
+	}
+
+	return &awsSecretsManagerStore{client: newClient(sess), secretName: spec.SecretName}, nil
+}
+
+func (s *stores) secretValue(namespace string, ref *cmmeta.SecretKeySelector) (string, error) {
+	if ref == nil {
+		return "", errors.New("accessKeyIDSecretRef and secretAccessKeySecretRef must be specified together")
+	}
+	secret, err := s.secretLister.Secrets(namespace).Get(ref.Name)
+	if err != nil {
+		return "", err
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("no data for %q in secret '%s/%s'", ref.Key, namespace, ref.Name)
+	}
+	return string(value), nil
+}
+
+// vaultStore pushes to a secret of a Vault KV version 2 secrets engine.
+type vaultStore struct {
+	kv    internalvault.KV
+	mount string
+	path  string
+}
+
+func (v *vaultStore) push(_ context.Context, data map[string]string) (bool, error) {
+	existing, err := v.kv.ReadKV(v.mount, v.path)
+	if err != nil {
+		return false, err
+	}
+	// Every write creates a new version of the secret, so only write when
+	// the data has changed.
+	if reflect.DeepEqual(existing, data) {
+		return false, nil
+	}
+	if err := v.kv.WriteKV(v.mount, v.path, data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// awsSecretsManagerStore pushes to a secret in AWS Secrets Manager, storing
+// the data as a JSON object.
+type awsSecretsManagerStore struct {
+	client     secretsmanageriface.SecretsManagerAPI
+	secretName string
+}
+
+func (a *awsSecretsManagerStore) push(ctx context.Context, data map[string]string) (bool, error) {
+	value, err := json.Marshal(data)
+	if err != nil {
+		return false, err
+	}
+
+	current, err := a.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(a.secretName),
+	})
+	var aerr awserr.Error
+	if errors.As(err, &aerr) && aerr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+		if _, err := a.client.CreateSecretWithContext(ctx, &secretsmanager.CreateSecretInput{
+			Name:         aws.String(a.secretName),
+			SecretString: aws.String(string(value)),
+		}); err != nil {
+			return false, fmt.Errorf("failed to create secret: %w", err)
+		}
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get secret: %w", err)
+	}
+
+	var existing map[string]string
+	if current.SecretString != nil && json.Unmarshal([]byte(*current.SecretString), &existing) == nil && reflect.DeepEqual(existing, data) {
+		return false, nil
+	}
+
+	if _, err := a.client.PutSecretValueWithContext(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(a.secretName),
+		SecretString: aws.String(string(value)),
+	}); err != nil {
+		return false, fmt.Errorf("failed to put secret value: %w", err)
+	}
+	return true, nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -27,6 +28,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
@@ -36,8 +38,9 @@ import (
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
-	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/controller/clocksource"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
@@ -45,9 +48,9 @@ import (
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/scheduler"
-	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
 )
 
@@ -57,6 +60,9 @@ const (
 	stopIncreaseBackoff = 6 // 2 ^ (6 - 1) = 32 = maxDelay
 	// maxDelay is the maximum backoff period
 	maxDelay = 32 * time.Hour
+	// reasonDuplicateSecretName is the reason used for the DuplicateSecretName
+	// condition and the event fired when it is added
+	reasonDuplicateSecretName = "DuplicateSecretName"
 )
 
 // This controller observes the state of the certificate's currently
@@ -72,6 +78,10 @@ type controller struct {
 	recorder                 record.EventRecorder
 	scheduledWorkQueue       scheduler.ScheduledWorkQueue
 
+	// helper is used to look up the renewal window of a Certificate's issuer.
+	// If nil, only the renewal windows of Certificates themselves are used.
+	helper issuer.Helper
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
@@ -92,23 +102,35 @@ func NewController(
 	clock clock.Clock,
 	shouldReissue policies.Func,
 	fieldManager string,
+	rateLimiter workqueue.RateLimiter,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
-	// create a queue used to queue up items to be processed
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
-
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
-	secretsInformer := factory.Core().V1().Secrets()
+	secretsInformer := controllerpkg.SecretsMetadataInformer(factory)
+	secretGetter := controllerpkg.NewSecretGetter(factory)
+
+	// create a queue used to queue up items to be processed, in which
+	// Certificates which are about to expire are processed first
+	queue := certificates.NewPriorityQueue(rateLimiter, ControllerName, certificateInformer.Lister(), clock)
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Certificate resource changes, enqueue any other Certificate resources
+	// that name the same spec.secretName, so that duplicates are re-evaluated
+	// when the oldest Certificate is deleted or changes its spec.secretName.
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			func(obj runtime.Object) predicate.Func {
+				return predicate.CertificateSecretName(obj.(*cmapi.Certificate).Spec.SecretName)
+			}),
+	})
 
 	// When a CertificateRequest resource changes, enqueue the Certificate resource that owns it.
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(), predicate.ResourceOwnerOf),
 	})
 	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
-	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+	secretsInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Trigger reconciles on changes to the Secret named `spec.secretName`
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
 			predicate.ExtractResourceName(predicate.CertificateSecretName)),
@@ -118,14 +140,14 @@ func NewController(
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		certificateRequestInformer.Informer().HasSynced,
-		secretsInformer.Informer().HasSynced,
+		secretsInformer.HasSynced,
 		certificateInformer.Informer().HasSynced,
 	}
 
 	return &controller{
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
-		secretLister:             secretsInformer.Lister(),
+		secretLister:             secretGetter,
 		client:                   client,
 		recorder:                 recorder,
 		scheduledWorkQueue:       scheduler.NewScheduledWorkQueue(clock, queue.Add),
@@ -136,7 +158,7 @@ func NewController(
 		shouldReissue: shouldReissue,
 		dataForCertificate: (&policies.Gatherer{
 			CertificateRequestLister: certificateRequestInformer.Lister(),
-			SecretLister:             secretsInformer.Lister(),
+			SecretLister:             secretGetter,
 		}).DataForCertificate,
 	}, queue, mustSync
 }
@@ -165,6 +187,29 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		// Do nothing if an issuance is already in progress.
 		return nil
 	}
+	if crt.Annotations[cmapi.IssuanceDryRunAnnotation] == "true" {
+		// Certificates in dry-run mode are only checked against the policy
+		// of their issuer by the 'dryrun' controller, and never issued.
+		log.V(logf.DebugLevel).Info("Certificate is in issuance dry-run mode, not triggering issuance")
+		return nil
+	}
+
+	// Only the oldest Certificate naming a Secret is issued into it, otherwise
+	// the Certificates would continuously re-issue to overwrite each other.
+	owner, err := c.secretNameOwner(crt)
+	if err != nil {
+		return err
+	}
+	if owner != nil {
+		return c.setDuplicateSecretName(ctx, crt, owner)
+	}
+	if apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionDuplicateSecretName) != nil {
+		crt = crt.DeepCopy()
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionDuplicateSecretName)
+		if err := c.applyStatus(ctx, crt); err != nil {
+			return err
+		}
+	}
 
 	input, err := c.dataForCertificate(ctx, crt)
 	if err != nil {
@@ -181,10 +226,16 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
-	if crt.Status.RenewalTime != nil {
-		// ensure a resync is scheduled in the future so that we re-check
-		// Certificate resources and trigger them near expiry time
-		c.scheduleRecheckOfCertificateIfRequired(log, key, crt.Status.RenewalTime.Time.Sub(c.clock.Now()))
+	// ensure a resync is scheduled in the future so that we re-check
+	// Certificate resources and trigger them near expiry time, or when their
+	// private key is due for rotation if that is sooner
+	recheckTime := crt.Status.RenewalTime
+	if rotationTime := certificates.PrivateKeyRotationTime(crt); rotationTime != nil &&
+		(recheckTime == nil || rotationTime.Before(recheckTime)) {
+		recheckTime = rotationTime
+	}
+	if recheckTime != nil {
+		c.scheduleRecheckOfCertificateIfRequired(log, key, recheckTime.Time.Sub(c.clock.Now()))
 	}
 
 	reason, message, reissue := c.shouldReissue(input)
@@ -193,6 +244,22 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
+	// Renewals are deferred until the renewal window of the Certificate or
+	// its issuer opens, and until enough consumers of the Secret have loaded
+	// the current revision. Any other reason for issuance, such as a change of
+	// the spec or a missing Secret, is acted upon immediately.
+	if reason == policies.Renewing {
+		if next, deferred := c.deferRenewal(log, crt); deferred {
+			log.V(logf.InfoLevel).Info("Renewal deferred until the renewal window opens", "next_attempt", next)
+			c.scheduleRecheckOfCertificateIfRequired(log, key, next.Sub(c.clock.Now()))
+			return nil
+		}
+		if next, deferred := c.deferRenewalForPropagation(log, crt, input.Secret); deferred {
+			c.scheduleRecheckOfCertificateIfRequired(log, key, next.Sub(c.clock.Now()))
+			return nil
+		}
+	}
+
 	// Although the below recorder.Event already logs the event, the log
 	// line is quite unreadable (very long). Since this information is very
 	// important for the user and the operator, we log the following
@@ -201,7 +268,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 
 	crt = crt.DeepCopy()
 	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, reason, message)
-	if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+	if err := c.applyStatus(ctx, crt); err != nil {
 		return err
 	}
 	c.recorder.Event(crt, corev1.EventTypeNormal, "Issuing", message)
@@ -209,23 +276,76 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	return nil
 }
 
-// updateOrApplyStatus will update the controller status. If the
-// ServerSideApply feature is enabled, the managed fields will instead get
-// applied using the relevant Patch API call.
-func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		var conditions []cmapi.CertificateCondition
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
-			conditions = []cmapi.CertificateCondition{*cond}
+// secretNameOwner returns the oldest Certificate in the namespace of the given
+// Certificate which names the same spec.secretName, or nil if the given
+// Certificate is the oldest. Certificates are ordered by their creation
+// timestamp, and then by name.
+func (c *controller) secretNameOwner(crt *cmapi.Certificate) (*cmapi.Certificate, error) {
+	if crt.Spec.SecretName == "" {
+		return nil, nil
+	}
+	crts, err := certificates.ListCertificatesMatchingPredicates(c.certificateLister.Certificates(crt.Namespace), labels.Everything(),
+		predicate.CertificateSecretName(crt.Spec.SecretName))
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*cmapi.Certificate
+	for _, other := range crts {
+		if other.Name != crt.Name && other.DeletionTimestamp != nil {
+			continue
 		}
-		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
-			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
-			Status:     cmapi.CertificateStatus{Conditions: conditions},
-		})
-	} else {
-		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+		candidates = append(candidates, other)
+	}
+	if len(candidates) < 2 {
+		return nil, nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].CreationTimestamp.Equal(&candidates[j].CreationTimestamp) {
+			return candidates[i].CreationTimestamp.Before(&candidates[j].CreationTimestamp)
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	if candidates[0].Name == crt.Name {
+		return nil, nil
+	}
+	return candidates[0], nil
+}
+
+// setDuplicateSecretName adds the DuplicateSecretName condition to a
+// Certificate which names the same spec.secretName as the given owner.
+func (c *controller) setDuplicateSecretName(ctx context.Context, crt, owner *cmapi.Certificate) error {
+	message := fmt.Sprintf("Secret %q is already used by Certificate %q, which was created first. Issuance is paused until spec.secretName is unique", crt.Spec.SecretName, owner.Name)
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionDuplicateSecretName); cond != nil &&
+		cond.Status == cmmeta.ConditionTrue && cond.Message == message && cond.ObservedGeneration == crt.Generation {
+		return nil
+	}
+
+	logf.FromContext(ctx).V(logf.InfoLevel).Info("Certificate names the same Secret as an older Certificate, not issuing", "owner", owner.Name)
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionDuplicateSecretName, cmmeta.ConditionTrue, reasonDuplicateSecretName, message)
+	if err := c.applyStatus(ctx, crt); err != nil {
 		return err
 	}
+	c.recorder.Event(crt, corev1.EventTypeWarning, reasonDuplicateSecretName, message)
+
+	return nil
+}
+
+// applyStatus applies the fields of the status which are managed by this
+// controller.
+func (c *controller) applyStatus(ctx context.Context, crt *cmapi.Certificate) error {
+	var conditions []cmapi.CertificateCondition
+	for _, conditionType := range []cmapi.CertificateConditionType{cmapi.CertificateConditionIssuing, cmapi.CertificateConditionDuplicateSecretName} {
+		if cond := apiutil.GetCertificateCondition(crt, conditionType); cond != nil {
+			conditions = append(conditions, *cond)
+		}
+	}
+	return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+		Status:     cmapi.CertificateStatus{Conditions: conditions},
+	})
 }
 
 // shouldBackOffReissuingOnFailure returns true if an issuance needs to be
@@ -234,6 +354,11 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 // failure occured,
 // so the returned delay will be backoff_period - (current_time - last_failure_time)
 //
+// For short-lived certificates the backoff periods are scaled down so that a
+// failing certificate still gets retried before it expires: the initial period
+// is at most 1/12th of the certificate duration and the periods never exceed
+// 1/3rd of the certificate duration.
+//
 // Notably, it returns no back-off when the certificate doesn't
 // match the "next" certificate (since a mismatch means that this certificate
 // gets re-issued immediately).
@@ -272,7 +397,19 @@ func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.
 	now := c.Now()
 	durationSinceFailure := now.Sub(crt.Status.LastFailureTime.Time)
 
+	// Short-lived certificates would expire long before the default backoff
+	// periods have elapsed, so both the initial and the maximum backoff
+	// periods are scaled down with the certificate duration.
+	certDuration := apiutil.DefaultCertDuration(crt.Spec.Duration)
 	initialDelay := time.Hour
+	if d := certDuration / 12; d < initialDelay {
+		initialDelay = d
+	}
+	delayCap := maxDelay
+	if d := certDuration / 3; d < delayCap {
+		delayCap = d
+	}
+
 	delay := initialDelay
 	failedIssuanceAttempts := 0
 	// It is possible that crt.Status.LastFailureTime != nil &&
@@ -281,19 +418,20 @@ func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.
 	// attempts were introduced). In such case delay = initialDelay.
 	if crt.Status.FailedIssuanceAttempts != nil {
 		failedIssuanceAttempts = *crt.Status.FailedIssuanceAttempts
-		delay = time.Hour * time.Duration(math.Pow(2, float64(failedIssuanceAttempts-1)))
+		delay = initialDelay * time.Duration(math.Pow(2, float64(failedIssuanceAttempts-1)))
 	}
 
-	// Ensure that maximum returned delay is 32 hours
+	// Ensure that maximum returned delay is 32 hours (or 1/3rd of the
+	// certificate duration for short-lived certificates).
 	// delay cannot be calculated for large issuance numbers, so we
 	// cannot reliably check if delay > maxDelay directly
 	// (see i.e the result of time.Duration(math.Pow(2, 99)))
-	if failedIssuanceAttempts > stopIncreaseBackoff {
-		delay = maxDelay
+	if failedIssuanceAttempts > stopIncreaseBackoff || delay > delayCap {
+		delay = delayCap
 	}
 
-	// Ensure that minimum returned delay is 1 hour. This is here to guard
-	// against an edge case where the delay duration got messed
+	// Ensure that minimum returned delay is the initial delay. This is here
+	// to guard against an edge case where the delay duration got messed
 	// up as a result of maths misuse in the previous calculations
 	if delay < initialDelay {
 		delay = initialDelay
@@ -307,6 +445,90 @@ func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.
 	return true, delay - durationSinceFailure
 }
 
+// deferRenewal returns true if the renewal of the Certificate must wait for
+// its renewal window to open, and the time at which it may be renewed. This
+// is the next opening of the window, or the time at which the expiry
+// override is reached if that is earlier.
+func (c *controller) deferRenewal(log logr.Logger, crt *cmapi.Certificate) (time.Time, bool) {
+	if crt.Status.NotAfter == nil || crt.Status.RenewalTime == nil {
+		return time.Time{}, false
+	}
+	window := c.renewalWindow(log, crt)
+	if window == nil {
+		return time.Time{}, false
+	}
+	schedule, err := apiutil.NewRenewalWindowSchedule(window)
+	if err != nil {
+		log.Error(err, "ignoring invalid renewal window")
+		return time.Time{}, false
+	}
+
+	notAfter := crt.Status.NotAfter.Time
+	renewalTime := crt.Status.RenewalTime.Time
+	override := renewalTime.Add(notAfter.Sub(renewalTime) / 2)
+	if window.ExpiryOverride != nil {
+		override = notAfter.Add(-window.ExpiryOverride.Duration)
+	}
+
+	now := c.clock.Now()
+	if !now.Before(override) || schedule.Contains(now) {
+		return time.Time{}, false
+	}
+	next := schedule.Next(now)
+	if next.After(override) {
+		next = override
+	}
+	return next, true
+}
+
+// deferRenewalForPropagation returns the time until which the renewal of a
+// Certificate is deferred because fewer than `spec.propagationQuorum`
+// consumers of its Secret have acknowledged the current revision. The Secret
+// triggers a resync when it is annotated, so the returned time is only the
+// latest point at which the renewal goes ahead regardless: the midpoint
+// between the renewal time and the expiry of the certificate. Returns false if
+// the renewal should not be deferred.
+func (c *controller) deferRenewalForPropagation(log logr.Logger, crt *cmapi.Certificate, secret *corev1.Secret) (time.Time, bool) {
+	if crt.Status.NotAfter == nil || crt.Status.RenewalTime == nil {
+		return time.Time{}, false
+	}
+	acks, reached := internalcertificates.PropagationAcknowledgements(crt, secret)
+	if reached {
+		return time.Time{}, false
+	}
+
+	notAfter := crt.Status.NotAfter.Time
+	renewalTime := crt.Status.RenewalTime.Time
+	override := renewalTime.Add(notAfter.Sub(renewalTime) / 2)
+	if !c.clock.Now().Before(override) {
+		log.V(logf.InfoLevel).Info("Renewing although consumers have not acknowledged the current revision, as the certificate is close to expiry",
+			"acknowledgements", acks, "quorum", *crt.Spec.PropagationQuorum)
+		return time.Time{}, false
+	}
+
+	log.V(logf.InfoLevel).Info("Renewal deferred until consumers acknowledge the current revision",
+		"acknowledgements", acks, "quorum", *crt.Spec.PropagationQuorum, "next_attempt", override)
+	return override, true
+}
+
+// renewalWindow returns the renewal window of the Certificate, or of its
+// issuer if the Certificate does not set one.
+func (c *controller) renewalWindow(log logr.Logger, crt *cmapi.Certificate) *cmapi.RenewalWindow {
+	if crt.Spec.RenewalWindow != nil {
+		return crt.Spec.RenewalWindow
+	}
+	if c.helper == nil || !(crt.Spec.IssuerRef.Group == "" || crt.Spec.IssuerRef.Group == certmanager.GroupName) {
+		return nil
+	}
+	iss, err := c.helper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if err != nil {
+		// The issuance itself will report the missing issuer.
+		log.V(logf.DebugLevel).Info("failed to get issuer to check its renewal window", "error", err.Error())
+		return nil
+	}
+	return iss.GetSpec().RenewalWindow
+}
+
 // scheduleRecheckOfCertificateIfRequired will schedule the resource with the
 // given key to be re-queued for processing after the given amount of time
 // has elapsed.
@@ -344,16 +566,31 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.SharedInformerFactory,
 		ctx.Recorder,
 		ctx.Clock,
-		policies.NewTriggerPolicyChain(ctx.Clock).Evaluate,
+		policies.NewTriggerPolicyChain(clocksource.WithSkewTolerance(ctx.Clock, ctx.ClockSkewTolerance)).Evaluate,
 		ctx.FieldManager,
+		ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30),
 	)
+
+	// Issuers are watched to look up their renewal windows.
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	issuerAliasInformer := ctx.SharedInformerFactory.Certmanager().V1().IssuerAliases()
+	mustSync = append(mustSync, issuerInformer.Informer().HasSynced, issuerAliasInformer.Informer().HasSynced)
+	// ClusterIssuers are only watched if we are not scoped to a single
+	// namespace.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+	ctrl.helper = issuer.NewAliasingHelper(issuerInformer.Lister(), clusterIssuerLister, issuerAliasInformer.Lister(), ctx.IssuerOptions.ClusterResourceNamespace)
 	c.controller = ctrl
 
 	return queue, mustSync, nil
 }
 
 func init() {
-	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+	controllerpkg.RegisterSharded(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
 		return controllerpkg.NewBuilder(ctx, ControllerName).
 			For(&controllerWrapper{}).
 			Complete()
@@ -24,13 +24,14 @@ import (
 
 	logtesting "github.com/go-logr/logr/testing"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
@@ -46,6 +47,34 @@ func Test_controller_ProcessItem(t *testing.T) {
 		return testcrypto.MustCreateCryptoBundle(t, crt, fixedClock).CertificateRequest
 	}
 
+	// Renewal windows which are open and closed at fixedNow.
+	hour := fixedNow.UTC().Hour()
+	openWindow := cmapi.RenewalWindow{Hours: fmt.Sprintf("%d-%d", hour, hour+1)}
+	closedWindow := cmapi.RenewalWindow{Hours: fmt.Sprintf("%d-%d", (hour+1)%24, (hour+2)%24)}
+	renewing := func(*testing.T) policies.Func {
+		return func(policies.Input) (string, string, bool) {
+			return policies.Renewing, "Renewing certificate as renewal was scheduled", true
+		}
+	}
+	renewingCondition := []cmapi.CertificateCondition{{
+		Type:               "Issuing",
+		Status:             "True",
+		Reason:             policies.Renewing,
+		Message:            "Renewing certificate as renewal was scheduled",
+		LastTransitionTime: &fixedNow,
+		ObservedGeneration: 42,
+	}}
+	// renewable returns a Certificate whose renewal time has passed.
+	renewable := func(mods ...gen.CertificateModifier) *cmapi.Certificate {
+		return gen.Certificate("cert-1", append([]gen.CertificateModifier{
+			gen.SetCertificateNamespace("testns"),
+			gen.SetCertificateGeneration(42),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer-1"}),
+			gen.SetCertificateRenewalTime(metav1.NewTime(fixedNow.Add(-time.Hour))),
+			gen.SetCertificateNotAfter(metav1.NewTime(fixedNow.Add(30 * 24 * time.Hour))),
+		}, mods...)...)
+	}
+
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem. If not set, the
 		// 'namespace/name' of the 'Certificate' field will be used. If neither
@@ -56,6 +85,13 @@ func Test_controller_ProcessItem(t *testing.T) {
 		// passed to ProcessItem instead.
 		existingCertificate *cmapi.Certificate
 
+		// otherCertificates are additional Certificates in the lister, for
+		// example Certificates naming the same Secret.
+		otherCertificates []*cmapi.Certificate
+
+		// issuer, if set, is an Issuer in the lister.
+		issuer *cmapi.Issuer
+
 		mockDataForCertificateReturn    policies.Input
 		mockDataForCertificateReturnErr error
 		wantDataForCertificateCalled    bool
@@ -95,6 +131,12 @@ func Test_controller_ProcessItem(t *testing.T) {
 				}),
 			),
 		},
+		"should do nothing if Certificate is in issuance dry-run mode": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.AddCertificateAnnotations(map[string]string{"cert-manager.io/issuance-dry-run": "true"}),
+			),
+		},
 		"should call shouldReissue with the correct cert, secret and current CR": {
 			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
 				gen.SetCertificateSecretName("secret-1"),
@@ -159,6 +201,137 @@ func Test_controller_ProcessItem(t *testing.T) {
 				ObservedGeneration: 42,
 			}},
 		},
+		"should set DuplicateSecretName=True if an older Certificate names the same Secret": {
+			existingCertificate: gen.Certificate("cert-2", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateCreationTimestamp(fixedNow),
+			),
+			otherCertificates: []*cmapi.Certificate{
+				gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateSecretName("secret-1"),
+					gen.SetCertificateCreationTimestamp(metav1.NewTime(fixedNow.Add(-time.Hour))),
+				),
+			},
+			wantEvent: `Warning DuplicateSecretName Secret "secret-1" is already used by Certificate "cert-1", which was created first. Issuance is paused until spec.secretName is unique`,
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "DuplicateSecretName",
+				Status:             "True",
+				Reason:             "DuplicateSecretName",
+				Message:            `Secret "secret-1" is already used by Certificate "cert-1", which was created first. Issuance is paused until spec.secretName is unique`,
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
+		"should use the Certificate name to order Certificates created at the same time": {
+			existingCertificate: gen.Certificate("cert-2", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateCreationTimestamp(fixedNow),
+			),
+			otherCertificates: []*cmapi.Certificate{
+				gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateSecretName("secret-1"),
+					gen.SetCertificateCreationTimestamp(fixedNow),
+				),
+			},
+			wantEvent: `Warning DuplicateSecretName Secret "secret-1" is already used by Certificate "cert-1", which was created first. Issuance is paused until spec.secretName is unique`,
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "DuplicateSecretName",
+				Status:             "True",
+				Reason:             "DuplicateSecretName",
+				Message:            `Secret "secret-1" is already used by Certificate "cert-1", which was created first. Issuance is paused until spec.secretName is unique`,
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
+		"should do nothing if DuplicateSecretName=True is already up to date": {
+			existingCertificate: gen.Certificate("cert-2", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateCreationTimestamp(fixedNow),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "DuplicateSecretName",
+					Status:             "True",
+					Reason:             "DuplicateSecretName",
+					Message:            `Secret "secret-1" is already used by Certificate "cert-1", which was created first. Issuance is paused until spec.secretName is unique`,
+					ObservedGeneration: 42,
+				}),
+			),
+			otherCertificates: []*cmapi.Certificate{
+				gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateSecretName("secret-1"),
+					gen.SetCertificateCreationTimestamp(metav1.NewTime(fixedNow.Add(-time.Hour))),
+				),
+			},
+		},
+		"should ignore an older Certificate naming the same Secret which is being deleted": {
+			existingCertificate: gen.Certificate("cert-2", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateCreationTimestamp(fixedNow),
+			),
+			otherCertificates: []*cmapi.Certificate{
+				func() *cmapi.Certificate {
+					crt := gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+						gen.SetCertificateSecretName("secret-1"),
+						gen.SetCertificateCreationTimestamp(metav1.NewTime(fixedNow.Add(-time.Hour))),
+					)
+					crt.DeletionTimestamp = &fixedNow
+					return crt
+				}(),
+			},
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{},
+			wantShouldReissueCalled:      true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "", "", false
+				}
+			},
+		},
+		"should call shouldReissue for the oldest Certificate naming a Secret": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateCreationTimestamp(metav1.NewTime(fixedNow.Add(-time.Hour))),
+			),
+			otherCertificates: []*cmapi.Certificate{
+				gen.Certificate("cert-2", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateSecretName("secret-1"),
+					gen.SetCertificateCreationTimestamp(fixedNow),
+				),
+			},
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{},
+			wantShouldReissueCalled:      true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "", "", false
+				}
+			},
+		},
+		"should remove DuplicateSecretName once the Certificate is the only one naming the Secret": {
+			existingCertificate: gen.Certificate("cert-2", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateCreationTimestamp(fixedNow),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "DuplicateSecretName",
+					Status:             "True",
+					Reason:             "DuplicateSecretName",
+					Message:            `Secret "secret-1" is already used by Certificate "cert-1", which was created first. Issuance is paused until spec.secretName is unique`,
+					ObservedGeneration: 42,
+				}),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{},
+			wantShouldReissueCalled:      true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "", "", false
+				}
+			},
+			wantConditions: []cmapi.CertificateCondition{},
+		},
 		// The combinations of number of failed issuances and last
 		// failed issuance time that do or do not result in re-issuance
 		// are tested in Test_shouldBackoffReissuingOnFailure below
@@ -238,6 +411,107 @@ func Test_controller_ProcessItem(t *testing.T) {
 				ObservedGeneration: 42,
 			}},
 		},
+		"should defer renewal until the Certificate's renewal window opens": {
+			existingCertificate:          renewable(gen.SetCertificateRenewalWindow(closedWindow)),
+			wantDataForCertificateCalled: true,
+			wantShouldReissueCalled:      true,
+			mockShouldReissue:            renewing,
+		},
+		"should defer renewal until the issuer's renewal window opens": {
+			existingCertificate: renewable(),
+			issuer: gen.Issuer("issuer-1", gen.SetIssuerNamespace("testns"),
+				gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}),
+				gen.SetIssuerRenewalWindow(closedWindow),
+			),
+			wantDataForCertificateCalled: true,
+			wantShouldReissueCalled:      true,
+			mockShouldReissue:            renewing,
+		},
+		"should renew if the Certificate's renewal window, which takes precedence over the issuer's, is open": {
+			existingCertificate: renewable(gen.SetCertificateRenewalWindow(openWindow)),
+			issuer: gen.Issuer("issuer-1", gen.SetIssuerNamespace("testns"),
+				gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}),
+				gen.SetIssuerRenewalWindow(closedWindow),
+			),
+			wantDataForCertificateCalled: true,
+			wantShouldReissueCalled:      true,
+			mockShouldReissue:            renewing,
+			wantEvent:                    "Normal Issuing Renewing certificate as renewal was scheduled",
+			wantConditions:               renewingCondition,
+		},
+		"should renew outside of the renewal window once the expiry override has been reached": {
+			existingCertificate: renewable(gen.SetCertificateRenewalWindow(cmapi.RenewalWindow{
+				Hours:          closedWindow.Hours,
+				ExpiryOverride: &metav1.Duration{Duration: 31 * 24 * time.Hour},
+			})),
+			wantDataForCertificateCalled: true,
+			wantShouldReissueCalled:      true,
+			mockShouldReissue:            renewing,
+			wantEvent:                    "Normal Issuing Renewing certificate as renewal was scheduled",
+			wantConditions:               renewingCondition,
+		},
+		"should defer renewal until consumers acknowledge the current revision": {
+			existingCertificate:          renewable(gen.SetCertificatePropagationQuorum(2)),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{Secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					cmapi.CertificateRequestRevisionAnnotationKey:                "1",
+					cmapi.PropagationAcknowledgementAnnotationPrefix + "gateway": "1",
+				},
+			}}},
+			wantShouldReissueCalled: true,
+			mockShouldReissue:       renewing,
+		},
+		"should renew once the propagation quorum has acknowledged the current revision": {
+			existingCertificate:          renewable(gen.SetCertificatePropagationQuorum(2)),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{Secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					cmapi.CertificateRequestRevisionAnnotationKey:                "1",
+					cmapi.PropagationAcknowledgementAnnotationPrefix + "gateway": "1",
+					cmapi.PropagationAcknowledgementAnnotationPrefix + "sidecar": "1",
+				},
+			}}},
+			wantShouldReissueCalled: true,
+			mockShouldReissue:       renewing,
+			wantEvent:               "Normal Issuing Renewing certificate as renewal was scheduled",
+			wantConditions:          renewingCondition,
+		},
+		"should renew without acknowledgements once half of the time between renewal and expiry has passed": {
+			existingCertificate: renewable(
+				gen.SetCertificatePropagationQuorum(2),
+				gen.SetCertificateNotAfter(metav1.NewTime(fixedNow.Add(time.Hour))),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{Secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					cmapi.CertificateRequestRevisionAnnotationKey: "1",
+				},
+			}}},
+			wantShouldReissueCalled: true,
+			mockShouldReissue:       renewing,
+			wantEvent:               "Normal Issuing Renewing certificate as renewal was scheduled",
+			wantConditions:          renewingCondition,
+		},
+		"should not defer issuance outside of the renewal window for reasons other than renewal": {
+			existingCertificate:          renewable(gen.SetCertificateRenewalWindow(closedWindow)),
+			wantDataForCertificateCalled: true,
+			wantShouldReissueCalled:      true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "ForceTriggered", "Re-issuance forced by unit test case", true
+				}
+			},
+			wantEvent: "Normal Issuing Re-issuance forced by unit test case",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "True",
+				Reason:             "ForceTriggered",
+				Message:            "Re-issuance forced by unit test case",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -248,6 +522,12 @@ func Test_controller_ProcessItem(t *testing.T) {
 			if test.existingCertificate != nil {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.existingCertificate)
 			}
+			for _, crt := range test.otherCertificates {
+				builder.CertManagerObjects = append(builder.CertManagerObjects, crt)
+			}
+			if test.issuer != nil {
+				builder.CertManagerObjects = append(builder.CertManagerObjects, test.issuer)
+			}
 			builder.Init()
 
 			w := &controllerWrapper{}
@@ -284,12 +564,11 @@ func Test_controller_ProcessItem(t *testing.T) {
 				expectedCert := test.existingCertificate.DeepCopy()
 				expectedCert.Status.Conditions = test.wantConditions
 				builder.ExpectedActions = append(builder.ExpectedActions,
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
-						"status",
 						test.existingCertificate.Namespace,
 						expectedCert,
-					)),
+					),
 				)
 			}
 			if test.wantEvent != "" {
@@ -669,6 +948,42 @@ func Test_shouldBackoffReissuingOnFailure(t *testing.T) {
 			)),
 			wantBackoff: false,
 		},
+		"should back off from reissuing a 1 hour certificate for 5 minutes if there was 1 failed issuance 0 minutes ago": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(time.Hour),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(clock.Now())),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+			),
+			givenNextCR: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(time.Hour),
+			)),
+			wantBackoff: true,
+			wantDelay:   5 * time.Minute,
+		},
+		"should back off from reissuing a 1 hour certificate for at most 20 minutes if there were 4 failed issuances 0 minutes ago": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(time.Hour),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(clock.Now())),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(4)),
+			),
+			givenNextCR: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDuration(time.Hour),
+			)),
+			wantBackoff: true,
+			wantDelay:   20 * time.Minute,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -32,13 +32,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 // PrivateKeyMatchesSpec returns an error if the private key bit size
-// doesn't match the provided spec. RSA, Ed25519 and ECDSA are supported.
+// doesn't match the provided spec. RSA, Ed25519, ECDSA and composite keys are
+// supported.
 // If any error is returned, a list of violations will also be returned.
 func PrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec) ([]string, error) {
 	spec = *spec.DeepCopy()
@@ -52,6 +54,8 @@ func PrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec) ([]
 		return ed25519PrivateKeyMatchesSpec(pk, spec)
 	case cmapi.ECDSAKeyAlgorithm:
 		return ecdsaPrivateKeyMatchesSpec(pk, spec)
+	case cmapi.MLDSA65ECDSAP256KeyAlgorithm:
+		return compositePrivateKeyMatchesSpec(pk, spec)
 	default:
 		return nil, fmt.Errorf("unrecognised key algorithm type %q", spec.PrivateKey.Algorithm)
 	}
@@ -108,6 +112,14 @@ func ed25519PrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSp
 	return nil, nil
 }
 
+func compositePrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSpec) ([]string, error) {
+	if !pki.IsCompositePrivateKey(pk) {
+		return []string{"spec.privateKey.algorithm"}, nil
+	}
+
+	return nil, nil
+}
+
 // RequestMatchesSpec compares a CertificateRequest with a CertificateSpec
 // and returns a list of field names on the Certificate that do not match their
 // counterpart fields on the CertificateRequest.
@@ -123,6 +135,10 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 	if spec.Subject == nil {
 		spec.Subject = &cmapi.X509Subject{}
 	}
+	// Requests are made for the A-label form of internationalized names.
+	if dnsNames, err := pki.NormalizeDNSNames(spec.DNSNames); err == nil {
+		spec.DNSNames = dnsNames
+	}
 
 	var violations []string
 	if spec.LiteralSubject == "" {
@@ -168,7 +184,7 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 		if req.Spec.IsCA != spec.IsCA {
 			violations = append(violations, "spec.isCA")
 		}
-		if !util.EqualKeyUsagesUnsorted(req.Spec.Usages, spec.Usages) {
+		if !util.EqualKeyUsagesUnsorted(req.Spec.Usages, apiutil.CertificateUsages(&spec)) {
 			violations = append(violations, "spec.usages")
 		}
 		if spec.Duration != nil && req.Spec.Duration != nil &&
@@ -214,6 +230,11 @@ func SecretDataAltNamesMatchSpec(secret *corev1.Secret, spec cmapi.CertificateSp
 
 	var violations []string
 
+	// Certificates are issued for the A-label form of internationalized names.
+	if dnsNames, err := pki.NormalizeDNSNames(spec.DNSNames); err == nil {
+		spec.DNSNames = dnsNames
+	}
+
 	// Perform a 'loose' check on the x509 certificate to determine if the
 	// commonName and dnsNames fields are up to date.
 	// This check allows names to move between the DNSNames and CommonName
@@ -337,3 +358,69 @@ func RenewalTime(notBefore, notAfter time.Time, renewBeforeOverride *metav1.Dura
 	rt := metav1.NewTime(notAfter.Add(-1 * renewBefore).Truncate(time.Second))
 	return &rt
 }
+
+// RenewalTimeWithClockSkewTolerance returns a RenewalTimeFunc which brings
+// the renewal time calculated by RenewalTime forward by the clock skew
+// tolerance, which must be a whole number of seconds so that the renewal
+// time stays truncated to the second.
+// The renewal time is never brought forward by more than half of the time
+// between notBefore and the original renewal time. Otherwise a tolerance
+// that is large compared to the lifetime of a short-lived certificate
+// would cause it to be renewed straight after being issued, over and over.
+func RenewalTimeWithClockSkewTolerance(tolerance time.Duration) RenewalTimeFunc {
+	if tolerance == 0 {
+		return RenewalTime
+	}
+	return func(notBefore, notAfter time.Time, renewBeforeOverride *metav1.Duration) *metav1.Time {
+		rt := RenewalTime(notBefore, notAfter, renewBeforeOverride)
+		earliest := notBefore.Add(rt.Sub(notBefore) / 2).Truncate(time.Second)
+		rt.Time = rt.Add(-tolerance)
+		if rt.Time.Before(earliest) {
+			rt.Time = earliest
+		}
+		return rt
+	}
+}
+
+// PrivateKeyRotationTime returns the time at which the private key of a
+// Certificate using the Scheduled rotation policy reaches the maximum age
+// configured in its rotation schedule. It returns nil if the Certificate does
+// not use the Scheduled rotation policy, has no maximum private key age or
+// if the time of the first issuance with its private key is not known.
+func PrivateKeyRotationTime(crt *cmapi.Certificate) *metav1.Time {
+	schedule := privateKeyRotationSchedule(crt)
+	if schedule == nil || schedule.MaxAge == nil || crt.Status.PrivateKeyIssuedTime == nil {
+		return nil
+	}
+	rt := metav1.NewTime(crt.Status.PrivateKeyIssuedTime.Add(schedule.MaxAge.Duration).Truncate(time.Second))
+	return &rt
+}
+
+// PrivateKeyRotationDue returns true if the private key of a Certificate
+// using the Scheduled rotation policy is due for rotation at the given time,
+// along with a message explaining why.
+// Private keys whose usage has not been recorded on the Certificate's status
+// yet are never due for rotation, as their age is not known.
+func PrivateKeyRotationDue(crt *cmapi.Certificate, now time.Time) (string, bool) {
+	schedule := privateKeyRotationSchedule(crt)
+	if schedule == nil {
+		return "", false
+	}
+	if schedule.Renewals != nil && crt.Status.PrivateKeyIssuances != nil &&
+		*crt.Status.PrivateKeyIssuances >= int(*schedule.Renewals) {
+		return fmt.Sprintf("The private key has been used for %d issuances, the maximum allowed by its rotation schedule is %d",
+			*crt.Status.PrivateKeyIssuances, *schedule.Renewals), true
+	}
+	if rt := PrivateKeyRotationTime(crt); rt != nil && !now.Before(rt.Time) {
+		return fmt.Sprintf("The private key has reached the maximum age of %s allowed by its rotation schedule",
+			schedule.MaxAge.Duration), true
+	}
+	return "", false
+}
+
+func privateKeyRotationSchedule(crt *cmapi.Certificate) *cmapi.PrivateKeyRotationSchedule {
+	if crt.Spec.PrivateKey == nil || crt.Spec.PrivateKey.RotationPolicy != cmapi.RotationPolicyScheduled {
+		return nil
+	}
+	return crt.Spec.PrivateKey.RotationSchedule
+}
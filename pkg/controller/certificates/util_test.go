@@ -168,6 +168,14 @@ func TestSecretDataAltNamesMatchSpec(t *testing.T) {
 				DNSNames:   []string{"least", "one"},
 			}),
 		},
+		"should match if internationalized dnsNames are issued as A-labels": {
+			spec: cmapi.CertificateSpec{
+				DNSNames: []string{"bücher.example"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				DNSNames: []string{"xn--bcher-kva.example"},
+			}),
+		},
 		"should not match if commonName is not present on certificate": {
 			spec: cmapi.CertificateSpec{
 				CommonName: "cn",
@@ -361,3 +369,92 @@ func TestRenewalTime(t *testing.T) {
 		})
 	}
 }
+
+func TestRenewalTimeWithClockSkewTolerance(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	notAfter := now.Add(time.Hour * 3)
+
+	renewalTime := RenewalTimeWithClockSkewTolerance(0)(now, notAfter, nil)
+	assert.Equal(t, &metav1.Time{Time: now.Add(time.Hour * 2)}, renewalTime)
+
+	renewalTime = RenewalTimeWithClockSkewTolerance(time.Minute*5)(now, notAfter, nil)
+	assert.Equal(t, &metav1.Time{Time: now.Add(time.Hour*2 - time.Minute*5)}, renewalTime)
+
+	// A tolerance larger than half of the time until renewal must not bring
+	// the renewal time of a short-lived certificate any closer to notBefore.
+	shortNotAfter := now.Add(time.Minute * 15)
+	renewalTime = RenewalTimeWithClockSkewTolerance(time.Minute*8)(now, shortNotAfter, nil)
+	assert.Equal(t, &metav1.Time{Time: now.Add(time.Minute * 5)}, renewalTime)
+}
+
+func TestPrivateKeyRotationDue(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	scheduled := func(schedule cmapi.PrivateKeyRotationSchedule, issuedTime time.Time, issuances int) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			Spec: cmapi.CertificateSpec{
+				PrivateKey: &cmapi.CertificatePrivateKey{
+					RotationPolicy:   cmapi.RotationPolicyScheduled,
+					RotationSchedule: &schedule,
+				},
+			},
+			Status: cmapi.CertificateStatus{
+				PrivateKeyIssuedTime: &metav1.Time{Time: issuedTime},
+				PrivateKeyIssuances:  &issuances,
+			},
+		}
+	}
+	renewals := int32(3)
+	maxAge := &metav1.Duration{Duration: 90 * 24 * time.Hour}
+
+	tests := map[string]struct {
+		crt    *cmapi.Certificate
+		expDue bool
+	}{
+		"not due before reaching the number of renewals": {
+			crt: scheduled(cmapi.PrivateKeyRotationSchedule{Renewals: &renewals}, now, 2),
+		},
+		"due once the number of renewals is reached": {
+			crt:    scheduled(cmapi.PrivateKeyRotationSchedule{Renewals: &renewals}, now, 3),
+			expDue: true,
+		},
+		"not due before reaching the maximum age": {
+			crt: scheduled(cmapi.PrivateKeyRotationSchedule{MaxAge: maxAge}, now.Add(-89*24*time.Hour), 1),
+		},
+		"due once the maximum age is reached": {
+			crt:    scheduled(cmapi.PrivateKeyRotationSchedule{MaxAge: maxAge}, now.Add(-90*24*time.Hour), 1),
+			expDue: true,
+		},
+		"due once any limit of the schedule is reached": {
+			crt:    scheduled(cmapi.PrivateKeyRotationSchedule{Renewals: &renewals, MaxAge: maxAge}, now.Add(-91*24*time.Hour), 1),
+			expDue: true,
+		},
+		"not due if the private key usage is not known": {
+			crt: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					PrivateKey: &cmapi.CertificatePrivateKey{
+						RotationPolicy:   cmapi.RotationPolicyScheduled,
+						RotationSchedule: &cmapi.PrivateKeyRotationSchedule{Renewals: &renewals, MaxAge: maxAge},
+					},
+				},
+			},
+		},
+		"never due with other rotation policies": {
+			crt: func() *cmapi.Certificate {
+				crt := scheduled(cmapi.PrivateKeyRotationSchedule{Renewals: &renewals}, now, 3)
+				crt.Spec.PrivateKey.RotationPolicy = cmapi.RotationPolicyNever
+				return crt
+			}(),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			msg, due := PrivateKeyRotationDue(test.crt, now)
+			if due != test.expDue {
+				t.Errorf("expected due=%t, got due=%t (%q)", test.expDue, due, msg)
+			}
+			if due && msg == "" {
+				t.Errorf("expected a message explaining why the private key is due for rotation")
+			}
+		})
+	}
+}
@@ -314,9 +314,8 @@ func Test_ProcessItem(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR.DeepCopy(),
 							gen.SetCertificateSigningRequestRequest([]byte("garbage-data")),
@@ -333,7 +332,7 @@ func Test_ProcessItem(t *testing.T) {
 								LastUpdateTime:     metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -374,9 +373,8 @@ func Test_ProcessItem(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR.DeepCopy(),
 							gen.SetCertificateSigningRequestRequest(csrPEMExampleNotPresent),
@@ -393,7 +391,7 @@ func Test_ProcessItem(t *testing.T) {
 								LastUpdateTime:     metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -437,9 +435,8 @@ func Test_ProcessItem(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR.DeepCopy(),
 							gen.SetCertificateSigningRequestDuration("garbage-data"),
@@ -456,7 +453,7 @@ func Test_ProcessItem(t *testing.T) {
 								LastUpdateTime:     metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -598,9 +595,8 @@ func Test_ProcessItem(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR.DeepCopy(),
 							gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
@@ -616,7 +612,7 @@ func Test_ProcessItem(t *testing.T) {
 								LastUpdateTime:     metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -873,9 +869,8 @@ func Test_ProcessItem(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR,
 							gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
@@ -884,7 +879,7 @@ func Test_ProcessItem(t *testing.T) {
 							}),
 							gen.SetCertificateSigningRequestCertificate(certPEM),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -34,9 +34,9 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
+	issuerca "github.com/cert-manager/cert-manager/pkg/issuer/ca"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
-	"github.com/cert-manager/cert-manager/pkg/util/kube"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -78,7 +78,7 @@ func init() {
 func NewCA(ctx *controllerpkg.Context) certificatesigningrequests.Signer {
 	return &CA{
 		issuerOptions:     ctx.IssuerOptions,
-		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		secretsLister:     controllerpkg.NewSecretGetter(ctx.KubeSharedInformerFactory),
 		certClient:        ctx.Client.CertificatesV1().CertificateSigningRequests(),
 		fieldManager:      ctx.FieldManager,
 		recorder:          ctx.Recorder,
@@ -98,7 +98,7 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
 	// get a copy of the CA certificate named on the Issuer
-	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA.SecretName)
+	caCerts, caKey, err := issuerca.SigningKeyPair(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA)
 	if apierrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
 		c.recorder.Event(csr, corev1.EventTypeWarning, "SecretMissing", message)
@@ -114,6 +114,9 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 	if err != nil {
 		// We are probably in a network error here so we should backoff and retry
 		message := fmt.Sprintf("Failed to get certificate key pair from secret %s/%s", resourceNamespace, secretName)
+		if pkcs11 := issuerObj.GetSpec().CA.PKCS11; pkcs11 != nil {
+			message = fmt.Sprintf("Failed to get certificate from secret %s/%s and private key from PKCS#11 token %q", resourceNamespace, secretName, pkcs11.TokenLabel)
+		}
 		c.recorder.Eventf(csr, corev1.EventTypeWarning, "SecretGetError", "%s: %s", message, err)
 		return err
 	}
@@ -123,7 +126,7 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 		message := fmt.Sprintf("Error generating certificate template: %s", err)
 		c.recorder.Event(csr, corev1.EventTypeWarning, "SigningError", message)
 		util.CertificateSigningRequestSetFailed(csr, "SigningError", message)
-		_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+		_, err := util.ApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
 		return err
 	}
 
@@ -135,12 +138,12 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 		message := fmt.Sprintf("Error signing certificate: %s", err)
 		c.recorder.Event(csr, corev1.EventTypeWarning, "SigningError", message)
 		util.CertificateSigningRequestSetFailed(csr, "SigningError", message)
-		_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+		_, err := util.ApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
 		return err
 	}
 
 	csr.Status.Certificate = bundle.ChainPEM
-	csr, err = util.UpdateOrApplyStatus(ctx, c.certClient, csr, "", c.fieldManager)
+	csr, err = util.ApplyStatus(ctx, c.certClient, csr, "", c.fieldManager)
 	if err != nil {
 		message := "Error updating certificate"
 		c.recorder.Eventf(csr, corev1.EventTypeWarning, "SigningError", "%s: %s", message, err)
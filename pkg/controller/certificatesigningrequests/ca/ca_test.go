@@ -387,9 +387,8 @@ func TestSign(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR.DeepCopy(),
 							gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
@@ -401,7 +400,7 @@ func TestSign(t *testing.T) {
 								LastUpdateTime:     metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 			expectedErr: false,
@@ -441,9 +440,8 @@ func TestSign(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR.DeepCopy(),
 							gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
@@ -455,7 +453,7 @@ func TestSign(t *testing.T) {
 								LastUpdateTime:     metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 			expectedErr: false,
@@ -505,14 +503,13 @@ func TestSign(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR,
 							gen.SetCertificateSigningRequestCertificate(certBundle.ChainPEM),
 						),
-					)),
+					),
 				},
 			},
 		},
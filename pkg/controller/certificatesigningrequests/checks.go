@@ -24,7 +24,6 @@ import (
 
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
@@ -64,7 +63,7 @@ func (c *Controller) certificateSigningRequestsForGenericIssuer(iss cmapi.Generi
 
 	var affected []*certificatesv1.CertificateSigningRequest
 	for _, csr := range csrs {
-		ref, ok := util.SignerIssuerRefFromSignerName(csr.Spec.SignerName)
+		ref, ok := c.signerIssuerRef(csr)
 
 		switch {
 		case !ok,
@@ -87,6 +87,10 @@ type Controller struct {
 	// the signer kind to react to when a certificate signing request is synced
 	signerType string
 
+	// kubeletServingSignerName is the signer name of the issuer which signs
+	// kubelet serving certificate signing requests, if any.
+	kubeletServingSignerName string
+
 	//registerExtraInformers is a list of functions that
 	//CertificateSigningRequest controllers can use to register custom informers.
 	registerExtraInformers []RegisterExtraInformerFn
@@ -120,7 +124,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.log = logf.FromContext(ctx.RootContext, componentName)
 
 	// create a queue used to queue up items to be processed
-	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), componentName)
+	c.queue = workqueue.NewNamedRateLimitingQueue(ctx.DefaultRateLimiterFor(componentName), componentName)
 
 	kubeClient := ctx.Client
 	c.sarClient = kubeClient.AuthorizationV1().SubjectAccessReviews()
@@ -172,6 +176,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.recorder = ctx.Recorder
 	c.certClient = kubeClient.CertificatesV1().CertificateSigningRequests()
 	c.fieldManager = ctx.FieldManager
+	c.kubeletServingSignerName = ctx.KubeletServingSignerName
 
 	// Construct the signer implementation with the built component context.
 	c.signer = c.signerConstructor(ctx)
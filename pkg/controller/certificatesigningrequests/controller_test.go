@@ -605,12 +605,11 @@ func TestController(t *testing.T) {
 				expectedCSR := test.existingCSR.DeepCopy()
 				expectedCSR.Status.Conditions = test.wantConditions
 				builder.ExpectedActions = append(builder.ExpectedActions,
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						expectedCSR,
-					)),
+					),
 				)
 			}
 			if test.wantEvent != "" {
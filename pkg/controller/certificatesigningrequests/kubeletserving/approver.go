@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletserving
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	certificatesclient "k8s.io/client-go/kubernetes/typed/certificates/v1"
+	certificateslisters "k8s.io/client-go/listers/certificates/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	ControllerName = "certificatesigningrequests-kubelet-serving-approver"
+)
+
+// Controller approves kubelet serving CertificateSigningRequests whose
+// requester is the node named in the request, and which only request the
+// addresses of that node. Other kubelet serving CertificateSigningRequests
+// are denied. Approved requests are signed by the issuer configured with
+// the KubeletServingSignerName option.
+type Controller struct {
+	// logger to be used by this controller
+	log logr.Logger
+
+	csrLister  certificateslisters.CertificateSigningRequestLister
+	nodeLister corelisters.NodeLister
+	certClient certificatesclient.CertificateSigningRequestInterface
+
+	recorder record.EventRecorder
+
+	queue workqueue.RateLimitingInterface
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(new(Controller)).Complete()
+	})
+}
+
+// Register registers and constructs the controller using the provided context.
+// It returns the workqueue to be used to enqueue items, a list of
+// InformerSynced functions that must be synced, or an error.
+func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	c.log = logf.FromContext(ctx.RootContext, ControllerName)
+	c.queue = workqueue.NewNamedRateLimitingQueue(ctx.DefaultRateLimiterFor(ControllerName), ControllerName)
+
+	csrInformer := ctx.KubeSharedInformerFactory.Certificates().V1().CertificateSigningRequests()
+	nodeInformer := ctx.KubeSharedInformerFactory.Core().V1().Nodes()
+	mustSync := []cache.InformerSynced{
+		csrInformer.Informer().HasSynced,
+		nodeInformer.Informer().HasSynced,
+	}
+	csrInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+
+	c.csrLister = csrInformer.Lister()
+	c.nodeLister = nodeInformer.Lister()
+	c.certClient = ctx.Client.CertificatesV1().CertificateSigningRequests()
+	c.recorder = ctx.Recorder
+
+	c.log.V(logf.DebugLevel).Info("kubelet serving certificate signing request approver controller registered")
+
+	return c.queue, mustSync, nil
+}
+
+func (c *Controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx)
+	dbg := log.V(logf.DebugLevel)
+
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key")
+		return nil
+	}
+
+	csr, err := c.csrLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		dbg.Info("certificate signing request in work queue no longer exists", "error", err.Error())
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	ctx = logf.NewContext(ctx, logf.WithResource(log, csr))
+	return c.Sync(ctx, csr)
+}
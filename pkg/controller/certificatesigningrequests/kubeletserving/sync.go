@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletserving
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// ApprovedReason and DeniedReason are the reasons of the conditions set
+	// on kubelet serving CertificateSigningRequests.
+	ApprovedReason = "KubeletServingApproved"
+	DeniedReason   = "KubeletServingValidationFailed"
+
+	nodeUserPrefix = "system:node:"
+	nodesGroup     = "system:nodes"
+)
+
+var (
+	// allowedUsages are the usages which may be requested by kubelet serving
+	// certificates. Only server auth is required, see
+	// https://kubernetes.io/docs/reference/access-authn-authz/certificate-signing-requests/#kubernetes-signers
+	allowedUsages = sets.NewString(
+		string(certificatesv1.UsageDigitalSignature),
+		string(certificatesv1.UsageKeyEncipherment),
+		string(certificatesv1.UsageServerAuth),
+	)
+)
+
+// Sync approves the kubelet serving CertificateSigningRequest if it was
+// requested by the node it is for, and only requests the addresses of that
+// node, and denies it otherwise. CertificateSigningRequests which have
+// already been approved or denied are ignored.
+func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSigningRequest) error {
+	log := logf.FromContext(ctx, "approver")
+	dbg := log.V(logf.DebugLevel)
+
+	switch {
+	case csr.Spec.SignerName != certificatesv1.KubeletServingSignerName,
+		util.CertificateSigningRequestIsApproved(csr),
+		util.CertificateSigningRequestIsDenied(csr):
+		return nil
+	}
+
+	nodeName, err := validateRequester(csr)
+	if err != nil {
+		return c.deny(ctx, csr, err)
+	}
+
+	node, err := c.nodeLister.Get(nodeName)
+	if apierrors.IsNotFound(err) {
+		// The kubelet requests its serving certificate once it has
+		// registered its Node, so the Node should appear shortly.
+		dbg.Info("node of kubelet serving certificate signing request not found, waiting for it to be observed", "node", nodeName)
+		return fmt.Errorf("node %q not found", nodeName)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := validateRequest(csr, node); err != nil {
+		return c.deny(ctx, csr, err)
+	}
+
+	return c.approve(ctx, csr, fmt.Sprintf("Kubelet serving certificate of node %q has been approved by cert-manager.io", nodeName))
+}
+
+// validateRequester returns the name of the node which requested the
+// certificate signing request, and an error if it was not requested by a
+// node.
+func validateRequester(csr *certificatesv1.CertificateSigningRequest) (string, error) {
+	if !strings.HasPrefix(csr.Spec.Username, nodeUserPrefix) || len(csr.Spec.Username) == len(nodeUserPrefix) {
+		return "", fmt.Errorf("requester %q is not a node", csr.Spec.Username)
+	}
+	if !sets.NewString(csr.Spec.Groups...).Has(nodesGroup) {
+		return "", fmt.Errorf("requester %q is not in the %q group", csr.Spec.Username, nodesGroup)
+	}
+	return strings.TrimPrefix(csr.Spec.Username, nodeUserPrefix), nil
+}
+
+// validateRequest returns an error if the certificate signing request
+// requests anything other than a serving certificate for the addresses of
+// the node.
+func validateRequest(csr *certificatesv1.CertificateSigningRequest, node *corev1.Node) error {
+	req, err := pki.DecodeX509CertificateRequestBytes(csr.Spec.Request)
+	if err != nil {
+		return err
+	}
+	if err := req.CheckSignature(); err != nil {
+		return fmt.Errorf("invalid request signature: %w", err)
+	}
+
+	if req.Subject.CommonName != csr.Spec.Username {
+		return fmt.Errorf("common name %q does not match the requester %q", req.Subject.CommonName, csr.Spec.Username)
+	}
+	if len(req.Subject.Organization) != 1 || req.Subject.Organization[0] != nodesGroup {
+		return fmt.Errorf("organization must be %q, got %q", nodesGroup, req.Subject.Organization)
+	}
+	if len(req.URIs) > 0 || len(req.EmailAddresses) > 0 {
+		return fmt.Errorf("URI and email address SANs must not be requested")
+	}
+	if len(req.DNSNames) == 0 && len(req.IPAddresses) == 0 {
+		return fmt.Errorf("at least one DNS name or IP address must be requested")
+	}
+
+	usages := sets.NewString()
+	for _, usage := range csr.Spec.Usages {
+		usages.Insert(string(usage))
+	}
+	if !usages.Has(string(certificatesv1.UsageServerAuth)) {
+		return fmt.Errorf("usage %q must be requested", certificatesv1.UsageServerAuth)
+	}
+	if extra := usages.Difference(allowedUsages); extra.Len() > 0 {
+		return fmt.Errorf("usages %q must not be requested", extra.List())
+	}
+
+	dnsNames, ips := sets.NewString(), sets.NewString()
+	for _, addr := range node.Status.Addresses {
+		switch addr.Type {
+		case corev1.NodeHostName, corev1.NodeInternalDNS, corev1.NodeExternalDNS:
+			dnsNames.Insert(addr.Address)
+		case corev1.NodeInternalIP, corev1.NodeExternalIP:
+			if ip := net.ParseIP(addr.Address); ip != nil {
+				ips.Insert(ip.String())
+			}
+		}
+	}
+	for _, name := range req.DNSNames {
+		if !dnsNames.Has(name) {
+			return fmt.Errorf("DNS name %q is not an address of node %q", name, node.Name)
+		}
+	}
+	for _, ip := range req.IPAddresses {
+		if !ips.Has(ip.String()) {
+			return fmt.Errorf("IP address %q is not an address of node %q", ip, node.Name)
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) approve(ctx context.Context, csr *certificatesv1.CertificateSigningRequest, message string) error {
+	if err := c.updateApproval(ctx, csr, certificatesv1.CertificateApproved, ApprovedReason, message); err != nil {
+		return err
+	}
+	c.recorder.Event(csr, corev1.EventTypeNormal, ApprovedReason, message)
+	logf.FromContext(ctx).V(logf.DebugLevel).Info("approved kubelet serving certificate signing request")
+	return nil
+}
+
+func (c *Controller) deny(ctx context.Context, csr *certificatesv1.CertificateSigningRequest, reason error) error {
+	message := fmt.Sprintf("Kubelet serving certificate signing request is invalid: %s", reason)
+	if err := c.updateApproval(ctx, csr, certificatesv1.CertificateDenied, DeniedReason, message); err != nil {
+		return err
+	}
+	c.recorder.Event(csr, corev1.EventTypeWarning, DeniedReason, message)
+	logf.FromContext(ctx).V(logf.InfoLevel).Info("denied kubelet serving certificate signing request", "reason", reason.Error())
+	return nil
+}
+
+func (c *Controller) updateApproval(ctx context.Context, csr *certificatesv1.CertificateSigningRequest, condType certificatesv1.RequestConditionType, reason, message string) error {
+	csr = csr.DeepCopy()
+	now := metav1.NewTime(util.Clock.Now())
+	csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:               condType,
+		Status:             corev1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: now,
+		LastUpdateTime:     now,
+	})
+	_, err := c.certClient.UpdateApproval(ctx, csr.Name, csr, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletserving
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"testing"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func setCSROrganization(organization ...string) gen.CSRModifier {
+	return func(c *x509.CertificateRequest) error {
+		c.Subject.Organization = organization
+		return nil
+	}
+}
+
+func mustCSR(t *testing.T, mods ...gen.CSRModifier) []byte {
+	csr, _, err := gen.CSR(x509.ECDSA, mods...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return csr
+}
+
+func TestSync(t *testing.T) {
+	fixedClockStart := time.Now()
+	util.Clock = fakeclock.NewFakeClock(fixedClockStart)
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: "node-1"},
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+			},
+		},
+	}
+	kubeletCSR := func(request []byte, mods ...gen.CertificateSigningRequestModifier) *certificatesv1.CertificateSigningRequest {
+		return gen.CertificateSigningRequest("csr-1", append([]gen.CertificateSigningRequestModifier{
+			gen.SetCertificateSigningRequestSignerName(certificatesv1.KubeletServingSignerName),
+			gen.SetCertificateSigningRequestUsername("system:node:node-1"),
+			gen.SetCertificateSigningRequestGroups([]string{"system:nodes", "system:authenticated"}),
+			gen.SetCertificateSigningRequestUsages([]certificatesv1.KeyUsage{certificatesv1.UsageDigitalSignature, certificatesv1.UsageServerAuth}),
+			gen.SetCertificateSigningRequestRequest(request),
+		}, mods...)...)
+	}
+	validRequest := mustCSR(t,
+		gen.SetCSRCommonName("system:node:node-1"),
+		setCSROrganization("system:nodes"),
+		gen.SetCSRDNSNames("node-1"),
+		gen.SetCSRIPAddressesFromStrings("10.0.0.1"),
+	)
+	withCondition := func(csr *certificatesv1.CertificateSigningRequest, condType certificatesv1.RequestConditionType, reason, message string) *certificatesv1.CertificateSigningRequest {
+		csr = csr.DeepCopy()
+		csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+			Type:               condType,
+			Status:             corev1.ConditionTrue,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: metaFixedClockStart,
+			LastUpdateTime:     metaFixedClockStart,
+		})
+		return csr
+	}
+	denied := func(csr *certificatesv1.CertificateSigningRequest, reason string) ([]testpkg.Action, []string) {
+		message := "Kubelet serving certificate signing request is invalid: " + reason
+		return []testpkg.Action{
+			testpkg.NewAction(coretesting.NewRootUpdateSubresourceAction(certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"), "approval",
+				withCondition(csr, certificatesv1.CertificateDenied, DeniedReason, message))),
+		}, []string{
+			fmt.Sprintf("Warning %s %s", DeniedReason, message),
+		}
+	}
+
+	tests := map[string]struct {
+		csr        *certificatesv1.CertificateSigningRequest
+		node       *corev1.Node
+		expActions func(*certificatesv1.CertificateSigningRequest) ([]testpkg.Action, []string)
+		expErr     bool
+	}{
+		"ignore requests for other signers": {
+			csr:  kubeletCSR(validRequest, gen.SetCertificateSigningRequestSignerName("issuers.cert-manager.io/foo")),
+			node: node,
+		},
+		"ignore requests which have already been approved": {
+			csr: kubeletCSR(validRequest, gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+				Type: certificatesv1.CertificateApproved,
+			})),
+			node: node,
+		},
+		"approve a request of the node for its own addresses": {
+			csr:  kubeletCSR(validRequest),
+			node: node,
+			expActions: func(csr *certificatesv1.CertificateSigningRequest) ([]testpkg.Action, []string) {
+				message := `Kubelet serving certificate of node "node-1" has been approved by cert-manager.io`
+				return []testpkg.Action{
+					testpkg.NewAction(coretesting.NewRootUpdateSubresourceAction(certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"), "approval",
+						withCondition(csr, certificatesv1.CertificateApproved, ApprovedReason, message))),
+				}, []string{
+					"Normal " + ApprovedReason + " " + message,
+				}
+			},
+		},
+		"deny requests which are not from a node": {
+			csr:  kubeletCSR(validRequest, gen.SetCertificateSigningRequestUsername("alice")),
+			node: node,
+			expActions: func(csr *certificatesv1.CertificateSigningRequest) ([]testpkg.Action, []string) {
+				return denied(csr, `requester "alice" is not a node`)
+			},
+		},
+		"deny requests for the addresses of another node": {
+			csr: kubeletCSR(mustCSR(t,
+				gen.SetCSRCommonName("system:node:node-1"),
+				setCSROrganization("system:nodes"),
+				gen.SetCSRIPAddressesFromStrings("10.0.0.2"),
+			)),
+			node: node,
+			expActions: func(csr *certificatesv1.CertificateSigningRequest) ([]testpkg.Action, []string) {
+				return denied(csr, `IP address "10.0.0.2" is not an address of node "node-1"`)
+			},
+		},
+		"deny requests whose common name is not the requester": {
+			csr: kubeletCSR(mustCSR(t,
+				gen.SetCSRCommonName("system:node:node-2"),
+				setCSROrganization("system:nodes"),
+				gen.SetCSRDNSNames("node-1"),
+			)),
+			node: node,
+			expActions: func(csr *certificatesv1.CertificateSigningRequest) ([]testpkg.Action, []string) {
+				return denied(csr, `common name "system:node:node-2" does not match the requester "system:node:node-1"`)
+			},
+		},
+		"deny requests for client auth": {
+			csr: kubeletCSR(validRequest, gen.SetCertificateSigningRequestUsages([]certificatesv1.KeyUsage{
+				certificatesv1.UsageServerAuth, certificatesv1.UsageClientAuth,
+			})),
+			node: node,
+			expActions: func(csr *certificatesv1.CertificateSigningRequest) ([]testpkg.Action, []string) {
+				return denied(csr, `usages ["client auth"] must not be requested`)
+			},
+		},
+		"retry if the node has not been observed": {
+			csr:    kubeletCSR(validRequest),
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:           t,
+				KubeObjects: []runtime.Object{test.csr},
+			}
+			if test.node != nil {
+				builder.KubeObjects = append(builder.KubeObjects, test.node)
+			}
+			if test.expActions != nil {
+				builder.ExpectedActions, builder.ExpectedEvents = test.expActions(test.csr)
+			}
+			builder.Init()
+			defer builder.Stop()
+
+			c := new(Controller)
+			if _, _, err := c.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+
+			err := c.Sync(context.Background(), test.csr)
+			if (err != nil) != test.expErr {
+				t.Errorf("expected error: %v, got: %v", test.expErr, err)
+			}
+			builder.CheckAndFinish(err)
+		})
+	}
+}
@@ -21,8 +21,8 @@ import (
 
 	"github.com/go-logr/logr"
 	certificatesv1 "k8s.io/api/certificates/v1"
-	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	clientv1 "k8s.io/client-go/listers/certificates/v1"
 	"k8s.io/client-go/util/workqueue"
@@ -48,7 +48,7 @@ func handleSecretReferenceWorkFunc(log logr.Logger,
 ) func(obj any) {
 	return func(obj any) {
 		log := log.WithName("handleSecretReference")
-		secret, ok := obj.(*corev1.Secret)
+		secret, ok := obj.(metav1.Object)
 		if !ok {
 			log.Error(nil, "object is not a secret")
 			return
@@ -79,7 +79,7 @@ func handleSecretReferenceWorkFunc(log logr.Logger,
 func certificateSigningRequestsForSecret(log logr.Logger,
 	lister clientv1.CertificateSigningRequestLister,
 	helper issuer.Helper,
-	secret *corev1.Secret,
+	secret metav1.Object,
 	issuerOptions controllerpkg.IssuerOptions,
 ) ([]*certificatesv1.CertificateSigningRequest, error) {
 	dbg := log.V(logf.DebugLevel)
@@ -120,7 +120,7 @@ func certificateSigningRequestsForSecret(log logr.Logger,
 
 		dbg = logf.WithRelatedResource(dbg, issuerObj)
 
-		if secret.Namespace != issuerOptions.ResourceNamespace(issuerObj) {
+		if secret.GetNamespace() != issuerOptions.ResourceNamespace(issuerObj) {
 			dbg.Info("issuer is not in the same namespace scope as the secret, skipping")
 			continue
 		}
@@ -134,7 +134,7 @@ func certificateSigningRequestsForSecret(log logr.Logger,
 		}
 
 		if issuerType == apiutil.IssuerSelfSigned &&
-			request.GetAnnotations()[cmexperimental.CertificateSigningRequestPrivateKeyAnnotationKey] == secret.Name {
+			request.GetAnnotations()[cmexperimental.CertificateSigningRequestPrivateKeyAnnotationKey] == secret.GetName() {
 			dbg.Info("certificate request references secret, syncing")
 			affected = append(affected, request)
 		}
@@ -185,9 +185,8 @@ func TestProcessItem(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR.DeepCopy(),
 							gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
@@ -203,7 +202,7 @@ func TestProcessItem(t *testing.T) {
 								LastUpdateTime:     metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -347,9 +346,8 @@ func TestProcessItem(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR.DeepCopy(),
 							gen.AddCertificateSigningRequestAnnotations(map[string]string{
@@ -369,7 +367,7 @@ func TestProcessItem(t *testing.T) {
 								LastUpdateTime:     metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -414,9 +412,8 @@ func TestProcessItem(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR.DeepCopy(),
 							gen.AddCertificateSigningRequestAnnotations(map[string]string{
@@ -436,7 +433,7 @@ func TestProcessItem(t *testing.T) {
 								LastUpdateTime:     metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -485,9 +482,8 @@ func TestProcessItem(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR.DeepCopy(),
 							gen.AddCertificateSigningRequestAnnotations(map[string]string{
@@ -507,7 +503,7 @@ func TestProcessItem(t *testing.T) {
 								LastUpdateTime:     metaFixedClockStart,
 							}),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -556,9 +552,8 @@ func TestProcessItem(t *testing.T) {
 							},
 						},
 					)),
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequestFrom(baseCSR.DeepCopy(),
 							gen.AddCertificateSigningRequestAnnotations(map[string]string{
@@ -571,7 +566,7 @@ func TestProcessItem(t *testing.T) {
 							}),
 							gen.SetCertificateSigningRequestCertificate([]byte("signed-cert")),
 						),
-					)),
+					),
 				},
 			},
 		},
@@ -45,7 +45,8 @@ func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSi
 	// invalidation by future contributions.
 	csr = csr.DeepCopy()
 
-	ref, ok := util.SignerIssuerRefFromSignerName(csr.Spec.SignerName)
+	isKubeletServing := csr.Spec.SignerName == certificatesv1.KubeletServingSignerName
+	ref, ok := c.signerIssuerRef(csr)
 	if !ok {
 		dbg.Info("certificate signing request has malformed signer name,", "signerName", csr.Spec.SignerName)
 		return nil
@@ -111,7 +112,10 @@ func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSi
 		return nil
 	}
 
-	if kind == cmapi.IssuerKind {
+	// Kubelets may always reference the issuer configured for kubelet
+	// serving certificates, their requests are validated by the kubelet
+	// serving approver instead.
+	if kind == cmapi.IssuerKind && !isKubeletServing {
 		ok, err := c.userCanReferenceSigner(ctx, csr, ref.Namespace, ref.Name)
 		if err != nil {
 			return err
@@ -121,7 +125,7 @@ func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSi
 			message := fmt.Sprintf("Requester may not reference Namespaced Issuer %s/%s", ref.Namespace, ref.Name)
 			c.recorder.Event(csr, corev1.EventTypeWarning, "DeniedReference", message)
 			util.CertificateSigningRequestSetFailed(csr, "DeniedReference", message)
-			_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+			_, err := util.ApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
 			return err
 		}
 	}
@@ -132,7 +136,7 @@ func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSi
 		log.Error(err, message)
 		c.recorder.Event(csr, corev1.EventTypeWarning, "ErrorParseDuration", message)
 		util.CertificateSigningRequestSetFailed(csr, "ErrorParseDuration", message)
-		_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+		_, err := util.ApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
 		return err
 	}
 
@@ -143,7 +147,7 @@ func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSi
 		message := fmt.Sprintf("CertificateSigningRequest minimum allowed duration is %s, requested %s", experimentalapi.CertificateSigningRequestMinimumDuration, duration)
 		c.recorder.Event(csr, corev1.EventTypeWarning, "InvalidDuration", message)
 		util.CertificateSigningRequestSetFailed(csr, "InvalidDuration", message)
-		_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+		_, err := util.ApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
 		return err
 
 	}
@@ -163,6 +167,20 @@ func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSi
 	return c.signer.Sign(ctx, csr, issuerObj)
 }
 
+// signerIssuerRef returns the reference to the issuer which signs the
+// certificate signing request. Kubelet serving certificate signing requests
+// are signed by the issuer configured for them, if any.
+func (c *Controller) signerIssuerRef(csr *certificatesv1.CertificateSigningRequest) (util.SignerIssuerRef, bool) {
+	signerName := csr.Spec.SignerName
+	if signerName == certificatesv1.KubeletServingSignerName {
+		if c.kubeletServingSignerName == "" {
+			return util.SignerIssuerRef{}, false
+		}
+		signerName = c.kubeletServingSignerName
+	}
+	return util.SignerIssuerRefFromSignerName(signerName)
+}
+
 // userCanReferenceSigner will return true if the CSR requester has a bound
 // role that allows them to reference a given Namespaced signer. The user must
 // have the permissions:
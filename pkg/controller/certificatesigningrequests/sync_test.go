@@ -25,7 +25,6 @@ import (
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
 	"github.com/cert-manager/cert-manager/pkg/api/util"
@@ -155,9 +154,8 @@ func TestController_Sync(t *testing.T) {
 				},
 
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequest("test",
 							gen.SetCertificateSigningRequestSignerName("clusterissuers.cert-manager.io/foo-issuer"),
@@ -173,7 +171,7 @@ func TestController_Sync(t *testing.T) {
 								LastTransitionTime: metaFixedTime,
 								LastUpdateTime:     metaFixedTime,
 							})),
-					)),
+					),
 				},
 			},
 			csr: gen.CertificateSigningRequest("test",
@@ -194,9 +192,8 @@ func TestController_Sync(t *testing.T) {
 				},
 
 				ExpectedActions: []testpkg.Action{
-					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					testpkg.NewApplyStatusAction(
 						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
-						"status",
 						"",
 						gen.CertificateSigningRequest("test",
 							gen.SetCertificateSigningRequestSignerName("clusterissuers.cert-manager.io/foo-issuer"),
@@ -212,7 +209,7 @@ func TestController_Sync(t *testing.T) {
 								LastTransitionTime: metaFixedTime,
 								LastUpdateTime:     metaFixedTime,
 							})),
-					)),
+					),
 				},
 			},
 			csr: gen.CertificateSigningRequest("test",
@@ -285,6 +282,49 @@ func TestController_Sync(t *testing.T) {
 				},
 			},
 		},
+		"kubelet serving CertificateSigningRequest is ignored without a configured signer": {
+			builder: &testpkg.Builder{},
+			csr: gen.CertificateSigningRequest("test",
+				gen.SetCertificateSigningRequestSignerName(certificatesv1.KubeletServingSignerName),
+				gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+					Type: certificatesv1.CertificateApproved,
+				})),
+		},
+		"kubelet serving CertificateSigningRequest is signed by the configured Issuer without a reference check": {
+			builder: &testpkg.Builder{
+				Context: &controller.Context{
+					RootContext: context.Background(),
+					ContextOptions: controller.ContextOptions{
+						CertificateSigningRequestOptions: controller.CertificateSigningRequestOptions{
+							KubeletServingSignerName: "issuers.cert-manager.io/default.foo-issuer",
+						},
+					},
+				},
+				CertManagerObjects: []runtime.Object{
+					gen.Issuer("foo-issuer",
+						gen.SetIssuerNamespace("default"),
+						gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}),
+						gen.AddIssuerCondition(cmapi.IssuerCondition{
+							Type:   cmapi.IssuerConditionReady,
+							Status: cmmeta.ConditionTrue,
+						})),
+				},
+			},
+			csr: gen.CertificateSigningRequest("test",
+				gen.SetCertificateSigningRequestSignerName(certificatesv1.KubeletServingSignerName),
+				gen.SetCertificateSigningRequestUsername("system:node:node-1"),
+				gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+					Type: certificatesv1.CertificateApproved,
+				})),
+			signerImpl: &fake.Signer{
+				FakeSign: func(_ context.Context, _ *certificatesv1.CertificateSigningRequest, iss cmapi.GenericIssuer) error {
+					if iss.GetNamespace() != "default" || iss.GetName() != "foo-issuer" {
+						return errors.New("unexpected issuer")
+					}
+					return nil
+				},
+			},
+		},
 		"Signing succeeds with a valid duration annotation": {
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{
@@ -23,42 +23,33 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	certificatesapply "k8s.io/client-go/applyconfigurations/certificates/v1"
 	certificatesclient "k8s.io/client-go/kubernetes/typed/certificates/v1"
-
-	"github.com/cert-manager/cert-manager/internal/controller/feature"
-	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
-// UpdateOrApplyStatus will update a CertificateSigningRequest's status, or
-// Apply if the ServerSideApply feature gate is enabled.
-// When the ServerSideApply feature is enabled; condType is optional, and will
-// only be applied if non-empty and the condition with that type exists on the
-// CertificateSigningRequest.
-func UpdateOrApplyStatus(ctx context.Context,
+// ApplyStatus applies the certificate of a CertificateSigningRequest's
+// status. condType is optional, and will only be applied if non-empty and the
+// condition with that type exists on the CertificateSigningRequest.
+func ApplyStatus(ctx context.Context,
 	cl certificatesclient.CertificateSigningRequestInterface,
 	csr *certificatesv1.CertificateSigningRequest,
 	condType certificatesv1.RequestConditionType,
 	fieldManager string,
 ) (*certificatesv1.CertificateSigningRequest, error) {
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		status := certificatesapply.CertificateSigningRequestStatus().
-			WithCertificate(csr.Status.Certificate...)
-
-		if len(condType) > 0 {
-			cond := certificateSigningRequestGetCondition(csr, condType)
-			if cond != nil {
-				status = status.WithConditions(
-					&certificatesapply.CertificateSigningRequestConditionApplyConfiguration{
-						Type: &cond.Type, Status: &cond.Status, Reason: &cond.Reason, Message: &cond.Message,
-						LastTransitionTime: &cond.LastTransitionTime, LastUpdateTime: &cond.LastUpdateTime,
-					},
-				)
-			}
+	status := certificatesapply.CertificateSigningRequestStatus().
+		WithCertificate(csr.Status.Certificate...)
+
+	if len(condType) > 0 {
+		cond := certificateSigningRequestGetCondition(csr, condType)
+		if cond != nil {
+			status = status.WithConditions(
+				&certificatesapply.CertificateSigningRequestConditionApplyConfiguration{
+					Type: &cond.Type, Status: &cond.Status, Reason: &cond.Reason, Message: &cond.Message,
+					LastTransitionTime: &cond.LastTransitionTime, LastUpdateTime: &cond.LastUpdateTime,
+				},
+			)
 		}
-
-		return cl.ApplyStatus(ctx, certificatesapply.CertificateSigningRequest(csr.Name).WithStatus(status),
-			metav1.ApplyOptions{Force: true, FieldManager: fieldManager},
-		)
-	} else {
-		return cl.UpdateStatus(ctx, csr, metav1.UpdateOptions{})
 	}
+
+	return cl.ApplyStatus(ctx, certificatesapply.CertificateSigningRequest(csr.Name).WithStatus(status),
+		metav1.ApplyOptions{Force: true, FieldManager: fieldManager},
+	)
 }
@@ -54,6 +54,7 @@ type Vault struct {
 	recorder record.EventRecorder
 
 	certClient    certificatesclient.CertificateSigningRequestInterface
+	createTokenFn func(ns string) internalvault.CreateToken
 	clientBuilder internalvault.ClientBuilder
 
 	// fieldManager is the manager name used for the Apply operations.
@@ -71,9 +72,10 @@ func init() {
 func NewVault(ctx *controllerpkg.Context) certificatesigningrequests.Signer {
 	return &Vault{
 		issuerOptions: ctx.IssuerOptions,
-		secretsLister: ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		secretsLister: controllerpkg.NewSecretGetter(ctx.KubeSharedInformerFactory),
 		recorder:      ctx.Recorder,
 		certClient:    ctx.Client.CertificatesV1().CertificateSigningRequests(),
+		createTokenFn: internalvault.CreateTokenFromClient(ctx.Client),
 		clientBuilder: internalvault.New,
 		fieldManager:  ctx.FieldManager,
 	}
@@ -89,13 +91,13 @@ func (v *Vault) Sign(ctx context.Context, csr *certificatesv1.CertificateSigning
 
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
-	client, err := v.clientBuilder(resourceNamespace, v.secretsLister, issuerObj)
+	client, err := v.clientBuilder(resourceNamespace, v.createTokenFn, v.secretsLister, issuerObj)
 	if apierrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 		log.Error(err, message)
 		v.recorder.Event(csr, corev1.EventTypeWarning, "SecretNotFound", message)
 		util.CertificateSigningRequestSetFailed(csr, "SecretNotFound", message)
-		_, err := util.UpdateOrApplyStatus(ctx, v.certClient, csr, certificatesv1.CertificateFailed, v.fieldManager)
+		_, err := util.ApplyStatus(ctx, v.certClient, csr, certificatesv1.CertificateFailed, v.fieldManager)
 		return err
 	}
 
@@ -121,14 +123,14 @@ func (v *Vault) Sign(ctx context.Context, csr *certificatesv1.CertificateSigning
 		log.Error(err, message)
 		v.recorder.Event(csr, corev1.EventTypeWarning, "ErrorSigning", message)
 		util.CertificateSigningRequestSetFailed(csr, "ErrorSigning", message)
-		_, err := util.UpdateOrApplyStatus(ctx, v.certClient, csr, certificatesv1.CertificateFailed, v.fieldManager)
+		_, err := util.ApplyStatus(ctx, v.certClient, csr, certificatesv1.CertificateFailed, v.fieldManager)
 		return err
 	}
 
 	log.V(logf.DebugLevel).Info("certificate issued")
 
 	csr.Status.Certificate = certPEM
-	csr, err = util.UpdateOrApplyStatus(ctx, v.certClient, csr, "", v.fieldManager)
+	csr, err = util.ApplyStatus(ctx, v.certClient, csr, "", v.fieldManager)
 	if err != nil {
 		message := "Error updating certificate"
 		v.recorder.Eventf(csr, corev1.EventTypeWarning, "ErrorUpdate", "%s: %s", message, err)
@@ -19,12 +19,14 @@ package clusterissuers
 import (
 	"fmt"
 
-	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
-func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssuer, error) {
+func (c *controller) issuersForSecret(secret metav1.Object) ([]*v1.ClusterIssuer, error) {
 	issuers, err := c.clusterIssuerLister.List(labels.NewSelector())
 
 	if err != nil {
@@ -33,60 +35,72 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 
 	var affected []*v1.ClusterIssuer
 	for _, iss := range issuers {
-		if secret.Namespace != c.clusterResourceNamespace {
+		if secret.GetNamespace() != c.clusterResourceNamespace {
+			// ClusterIssuers with namespaced ACME accounts also reference
+			// Secrets in the namespaces of requests
+			if accounts.UsesNamespacedAccounts(iss) &&
+				(iss.Spec.ACME.PrivateKey.Name == secret.GetName() || iss.Spec.ACME.ExternalAccountBinding.Key.Name == secret.GetName()) {
+				affected = append(affected, iss)
+			}
 			continue
 		}
 		switch {
 		case iss.Spec.ACME != nil:
-			if iss.Spec.ACME.PrivateKey.Name == secret.Name {
+			if iss.Spec.ACME.PrivateKey.Name == secret.GetName() {
 				affected = append(affected, iss)
 				continue
 			}
 			if iss.Spec.ACME.ExternalAccountBinding != nil {
-				if iss.Spec.ACME.ExternalAccountBinding.Key.Name == secret.Name {
+				if iss.Spec.ACME.ExternalAccountBinding.Key.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
 			}
 		case iss.Spec.CA != nil:
-			if iss.Spec.CA.SecretName == secret.Name {
+			if iss.Spec.CA.SecretName == secret.GetName() {
 				affected = append(affected, iss)
 				continue
 			}
+			if iss.Spec.CA.PKCS11 != nil {
+				if iss.Spec.CA.PKCS11.PINSecretRef.Name == secret.GetName() {
+					affected = append(affected, iss)
+					continue
+				}
+			}
 		case iss.Spec.Venafi != nil:
 			if iss.Spec.Venafi.TPP != nil {
-				if iss.Spec.Venafi.TPP.CredentialsRef.Name == secret.Name {
+				if iss.Spec.Venafi.TPP.CredentialsRef.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
 			}
 			if iss.Spec.Venafi.Cloud != nil {
-				if iss.Spec.Venafi.Cloud.APITokenSecretRef.Name == secret.Name {
+				if iss.Spec.Venafi.Cloud.APITokenSecretRef.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
 			}
 		case iss.Spec.Vault != nil:
 			if iss.Spec.Vault.Auth.TokenSecretRef != nil {
-				if iss.Spec.Vault.Auth.TokenSecretRef.Name == secret.Name {
+				if iss.Spec.Vault.Auth.TokenSecretRef.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
 			}
 			if iss.Spec.Vault.Auth.AppRole != nil {
-				if iss.Spec.Vault.Auth.AppRole.SecretRef.Name == secret.Name {
+				if iss.Spec.Vault.Auth.AppRole.SecretRef.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
 			}
 			if iss.Spec.Vault.Auth.Kubernetes != nil {
-				if iss.Spec.Vault.Auth.Kubernetes.SecretRef.Name == secret.Name {
+				if iss.Spec.Vault.Auth.Kubernetes.SecretRef.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
 			}
 			if iss.Spec.Vault.CABundleSecretRef != nil {
-				if iss.Spec.Vault.CABundleSecretRef.Name == secret.Name {
+				if iss.Spec.Vault.CABundleSecretRef.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
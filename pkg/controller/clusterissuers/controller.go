@@ -20,9 +20,8 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
-	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
-	corelisters "k8s.io/client-go/listers/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -36,7 +35,6 @@ import (
 
 type controller struct {
 	clusterIssuerLister cmlisters.ClusterIssuerLister
-	secretLister        corelisters.SecretLister
 
 	// maintain a reference to the workqueue for this controller
 	// so the handleOwnedResource method can enqueue resources
@@ -71,25 +69,26 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.log = logf.FromContext(ctx.RootContext, ControllerName)
 
 	// create a queue used to queue up items to be processed
-	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
+	c.queue = workqueue.NewNamedRateLimitingQueue(ctx.DefaultRateLimiterFor(ControllerName), ControllerName)
 
 	// obtain references to all the informers used by this controller
 	clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
-	secretInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
+	// only the metadata of Secrets is needed to requeue the issuers which
+	// reference them
+	secretInformer := controllerpkg.SecretsMetadataInformer(ctx.KubeSharedInformerFactory)
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		clusterIssuerInformer.Informer().HasSynced,
-		secretInformer.Informer().HasSynced,
+		secretInformer.HasSynced,
 	}
 
 	// set all the references to the listers for used by the Sync function
 	c.clusterIssuerLister = clusterIssuerInformer.Lister()
-	c.secretLister = secretInformer.Lister()
 
 	// register handler functions
 	clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
-	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.secretDeleted})
+	secretInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.secretDeleted})
 
 	// instantiate additional helpers used by this controller
 	c.issuerFactory = issuer.NewFactory(ctx)
@@ -105,9 +104,9 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 func (c *controller) secretDeleted(obj interface{}) {
 	log := c.log.WithName("secretDeleted")
 
-	var secret *corev1.Secret
+	var secret metav1.Object
 	var ok bool
-	secret, ok = obj.(*corev1.Secret)
+	secret, ok = obj.(metav1.Object)
 	if !ok {
 		log.Error(nil, "object was not a Secret object")
 		return
@@ -21,15 +21,12 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/errors"
 
-	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	internalissuers "github.com/cert-manager/cert-manager/internal/controller/issuers"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/globals"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
-	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
 const (
@@ -71,10 +68,5 @@ func (c *controller) updateIssuerStatus(ctx context.Context, old, new *cmapi.Clu
 	if apiequality.Semantic.DeepEqual(old.Status, new.Status) {
 		return nil
 	}
-	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		return internalissuers.ApplyClusterIssuerStatus(ctx, c.cmClient, c.fieldManager, new)
-	} else {
-		_, err := c.cmClient.CertmanagerV1().ClusterIssuers().UpdateStatus(ctx, new, metav1.UpdateOptions{})
-		return err
-	}
+	return internalissuers.ApplyClusterIssuerStatus(ctx, c.cmClient, c.fieldManager, new)
 }
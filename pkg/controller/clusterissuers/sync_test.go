@@ -24,6 +24,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgotesting "k8s.io/client-go/testing"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -86,20 +87,20 @@ func TestUpdateIssuerStatus(t *testing.T) {
 	assertNumberOfActions(t, fatalf, actions, 2)
 
 	action := actions[1]
-	updateAction := assertIsUpdateAction(t, errorf, action)
+	assertIsApplyStatusAction(t, errorf, action)
 
-	obj := updateAction.GetObject()
+	obj, err := fakeClient.CertmanagerV1().ClusterIssuers().Get(context.TODO(), issuer.Name, metav1.GetOptions{})
+	assertErrIsNil(t, fatalf, err)
 	issuer = assertIsClusterIssuer(t, errorf, obj)
 
 	assertDeepEqual(t, errorf, newStatus, issuer.Status)
 }
 
-func assertIsUpdateAction(t *testing.T, f failfFunc, action clientgotesting.Action) clientgotesting.UpdateAction {
-	updateAction, ok := action.(clientgotesting.UpdateAction)
-	if !ok {
-		f(t, "action %#v does not implement interface UpdateAction")
+func assertIsApplyStatusAction(t *testing.T, f failfFunc, action clientgotesting.Action) {
+	patchAction, ok := action.(clientgotesting.PatchAction)
+	if !ok || patchAction.GetPatchType() != types.ApplyPatchType || patchAction.GetSubresource() != "status" {
+		f(t, "action %#v is not an apply of the status", action)
 	}
-	return updateAction
 }
 
 func assertNumberOfActions(t *testing.T, f failfFunc, actions []clientgotesting.Action, number int) {
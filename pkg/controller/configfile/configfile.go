@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configfile
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	config "github.com/cert-manager/cert-manager/internal/apis/config/controller"
+	"github.com/cert-manager/cert-manager/internal/apis/config/controller/scheme"
+)
+
+// Filesystem is an interface used to mock out calls to ReadFile
+type Filesystem interface {
+	ReadFile(filename string) ([]byte, error)
+}
+
+type realFS struct{}
+
+func (fs realFS) ReadFile(filename string) ([]byte, error) {
+	return ioutil.ReadFile(filename)
+}
+
+// NewRealFS builds a Filesystem that wraps around `ioutil.ReadFile`.
+func NewRealFS() Filesystem {
+	return realFS{}
+}
+
+type Loader interface {
+	Load() (*config.ControllerConfiguration, error)
+}
+
+type fsLoader struct {
+	fs       Filesystem
+	filename string
+	codec    *serializer.CodecFactory
+}
+
+var _ Loader = &fsLoader{}
+
+func (f *fsLoader) Load() (*config.ControllerConfiguration, error) {
+	data, err := f.fs.ReadFile(f.filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read controller config file %q, error: %v", f.filename, err)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("controller config file %q was empty", f.filename)
+	}
+
+	return decodeControllerConfiguration(f.codec, data)
+}
+
+func NewFSLoader(fs Filesystem, name string) (Loader, error) {
+	_, controllerCodec, err := scheme.NewSchemeAndCodecs(serializer.EnableStrict)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fsLoader{
+		fs:       fs,
+		filename: name,
+		codec:    controllerCodec,
+	}, nil
+}
+
+func decodeControllerConfiguration(codec *serializer.CodecFactory, data []byte) (*config.ControllerConfiguration, error) {
+	obj, gvk, err := codec.UniversalDecoder().Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode: %w", err)
+	}
+
+	internalObj, ok := obj.(*config.ControllerConfiguration)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast object to ControllerConfiguration, unexpected type: %v", gvk)
+	}
+
+	return internalObj, nil
+}
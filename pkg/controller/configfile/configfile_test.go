@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configfile
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestFSLoader_Load(t *testing.T) {
+	const expectedFilename = "/path/to/config/file"
+
+	loader, err := NewFSLoader(newFakeFS(func(filename string) ([]byte, error) {
+		if filename != expectedFilename {
+			t.Fatalf("unexpected filename %q passed to ReadFile", filename)
+			return nil, fmt.Errorf("unexpected filename %q", filename)
+		}
+		return []byte(`apiVersion: controller.config.cert-manager.io/v1alpha1
+kind: ControllerConfiguration
+kubernetesAPIQPS: 100
+controllers:
+  certificates-issuing:
+    workers: 10
+    rateLimiter:
+      maxDelay: 1m`), nil
+	}), expectedFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *cfg.KubernetesAPIQPS != 100 {
+		t.Errorf("expected kubernetesAPIQPS to be 100 but got %v", *cfg.KubernetesAPIQPS)
+	}
+	// unset fields are defaulted
+	if *cfg.KubernetesAPIBurst != 50 {
+		t.Errorf("expected kubernetesAPIBurst to default to 50 but got %v", *cfg.KubernetesAPIBurst)
+	}
+	if *cfg.Workers != 5 {
+		t.Errorf("expected workers to default to 5 but got %v", *cfg.Workers)
+	}
+
+	issuing, ok := cfg.Controllers["certificates-issuing"]
+	if !ok {
+		t.Fatalf("expected settings for the certificates-issuing controller")
+	}
+	if *issuing.Workers != 10 {
+		t.Errorf("expected certificates-issuing workers to be 10 but got %v", *issuing.Workers)
+	}
+	if issuing.RateLimiter.MaxDelay.Duration != time.Minute {
+		t.Errorf("expected certificates-issuing maxDelay to be 1m but got %v", issuing.RateLimiter.MaxDelay.Duration)
+	}
+}
+
+func TestFSLoader_LoadStrict(t *testing.T) {
+	loader, err := NewFSLoader(newFakeFS(func(filename string) ([]byte, error) {
+		return []byte(`apiVersion: controller.config.cert-manager.io/v1alpha1
+kind: ControllerConfiguration
+controllers:
+  certificates-issuing:
+    wokers: 10`), nil
+	}), "/path/to/config/file")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loader.Load(); err == nil {
+		t.Errorf("expected an error decoding a config file with an unknown field")
+	}
+}
+
+func newFakeFS(readFileFunc func(string) ([]byte, error)) Filesystem {
+	return fakeFS{readFileFunc: readFileFunc}
+}
+
+type fakeFS struct {
+	readFileFunc func(string) ([]byte, error)
+}
+
+func (f fakeFS) ReadFile(filename string) ([]byte, error) {
+	return f.readFileFunc(filename)
+}
@@ -26,11 +26,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/record"
@@ -41,6 +46,8 @@ import (
 	gwscheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
 	gwinformers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 
+	controllerconfig "github.com/cert-manager/cert-manager/internal/apis/config/controller"
+	"github.com/cert-manager/cert-manager/internal/controller/clocksource"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
@@ -48,6 +55,7 @@ import (
 	informers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/notifications"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
@@ -120,14 +128,37 @@ type ContextOptions struct {
 	// KubernetesAPIBurst is the value of the Maximum burst for throttle.
 	KubernetesAPIBurst int
 
+	// Workers is the number of items each controller processes concurrently,
+	// unless overridden in Controllers.
+	Workers int
+
+	// Controllers overrides the settings of individual controllers, keyed by
+	// controller name.
+	Controllers map[string]controllerconfig.ControllerSettings
+
 	// Namespace is the namespace to operate within.
 	// If unset, operates on all namespaces
 	Namespace string
 
+	// NamespaceSelector limits the namespaced resources which are processed
+	// to those in namespaces whose labels match.
+	// If nil or empty, resources in all namespaces are processed.
+	NamespaceSelector labels.Selector
+
+	// WatchLabelSelector limits the cert-manager resources, Ingresses and
+	// Gateways which are processed to those whose labels match.
+	// If nil or empty, all resources are processed.
+	WatchLabelSelector labels.Selector
+
 	// Clock should be used to access the current time instead of relying on
 	// time.Now, to make it easier to test controllers that utilise time
 	Clock clock.Clock
 
+	// ClockSource selects the clock used by controllers instead of Clock,
+	// either clocksource.System or clocksource.APIServer. If empty, Clock is
+	// used as it is.
+	ClockSource string
+
 	// Metrics is used for exposing Prometheus metrics across the controllers
 	Metrics *metrics.Metrics
 
@@ -135,7 +166,10 @@ type ContextOptions struct {
 	ACMEOptions
 	IngressShimOptions
 	CertificateOptions
+	CertificateSigningRequestOptions
 	SchedulerOptions
+	EventOptions
+	OperatorOptions
 }
 
 type IssuerOptions struct {
@@ -151,6 +185,11 @@ type IssuerOptions struct {
 	// IssuerAmbientCredentials controls whether an issuer should pick up ambient
 	// credentials, such as those from metadata services, to construct clients.
 	IssuerAmbientCredentials bool
+
+	// IssuerPlugins maps the group and kind of issuers to the address of
+	// the issuer plugin which signs the CertificateRequests referencing
+	// them.
+	IssuerPlugins map[schema.GroupKind]string
 }
 
 type ACMEOptions struct {
@@ -210,6 +249,45 @@ type CertificateOptions struct {
 	// CopiedAnnotationPrefixes defines which annotations should be copied
 	// Certificate -> CertificateRequest, CertificateRequest -> Order.
 	CopiedAnnotationPrefixes []string
+	// CertificateShards is the number of shards Certificates are split
+	// between. Controllers registered using RegisterSharded only process the
+	// Certificates of CertificateShard, the index of this shard.
+	CertificateShards int
+	CertificateShard  int
+	// ClockSkewTolerance is how far behind the clock may be. Certificates
+	// are renewed, and considered to have expired, this much earlier.
+	ClockSkewTolerance time.Duration
+	// ExpiryAlertThresholds are the times before a certificate expires at
+	// which the expiry alerts controllers warn that it is about to expire.
+	ExpiryAlertThresholds []time.Duration
+	// Notifier is notified of certificate events, such as issuances and
+	// failures. Events are discarded if nil.
+	Notifier notifications.Notifier
+	// NotificationExpiryWarning is how long before a certificate expires
+	// the Notifier is notified that it is about to expire.
+	NotificationExpiryWarning time.Duration
+}
+
+type CertificateSigningRequestOptions struct {
+	// KubeletServingSignerName is the cert-manager signer name, e.g.
+	// "clusterissuers.cert-manager.io/kubelet-ca", of the issuer which signs
+	// CertificateSigningRequests for the kubernetes.io/kubelet-serving
+	// signer. If empty, kubelet serving CertificateSigningRequests are
+	// ignored.
+	KubeletServingSignerName string
+}
+
+// OperatorOptions configure the operator controller, which reconciles
+// cert-manager's own CustomResourceDefinitions and webhook configurations.
+type OperatorOptions struct {
+	// OperatorWebhookServiceNamespace and OperatorWebhookServiceName identify
+	// the Service of the cert-manager webhook, which the webhook
+	// configurations and conversion webhooks are pointed at.
+	OperatorWebhookServiceNamespace string
+	OperatorWebhookServiceName      string
+
+	// OperatorWebhookTimeout is the timeout of the admission webhooks.
+	OperatorWebhookTimeout time.Duration
 }
 
 type SchedulerOptions struct {
@@ -218,6 +296,25 @@ type SchedulerOptions struct {
 	MaxConcurrentChallenges int
 }
 
+// EventOptions control which Kubernetes Events are emitted by the controllers,
+// and how often.
+type EventOptions struct {
+	// EventTypes is the list of event types, e.g. Warning, which are
+	// emitted. Events of other types are dropped. If empty, events of all
+	// types are emitted.
+	EventTypes []string
+
+	// EventRateLimitQPS is the rate at which events about a single object are
+	// emitted once EventRateLimitBurst has been exceeded. If zero, the
+	// Kubernetes default of one event every 5 minutes is used.
+	EventRateLimitQPS float32
+
+	// EventRateLimitBurst is the number of events about a single object which
+	// are emitted before being rate limited. If zero, the Kubernetes default
+	// of 25 is used.
+	EventRateLimitBurst int
+}
+
 // ContextFactory is used for constructing new Contexts who's clients have been
 // configured with a User Agent built from the component name.
 type ContextFactory struct {
@@ -263,8 +360,46 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 		return nil, err
 	}
 
-	sharedInformerFactory := informers.NewSharedInformerFactoryWithOptions(clients.cmClient, resyncPeriod, informers.WithNamespace(opts.Namespace))
+	if opts.ClockSource == clocksource.APIServer {
+		apiServerClock, err := clocksource.NewAPIServerClock(ctx, restConfig)
+		if err != nil {
+			return nil, err
+		}
+		logf.FromContext(ctx).V(logf.InfoLevel).Info("using the API server clock", "offset", apiServerClock.Offset())
+		opts.Clock = apiServerClock
+	}
+
+	// Only cert-manager resources are filtered by the API server using
+	// WatchLabelSelector, since the other informers are also used to list
+	// resources created by cert-manager, such as HTTP01 solver Ingresses,
+	// which do not have the labels.
+	cmInformerOptions := []informers.SharedInformerOption{informers.WithNamespace(opts.Namespace)}
+	if !selectsEverything(opts.WatchLabelSelector) {
+		selector := opts.WatchLabelSelector.String()
+		cmInformerOptions = append(cmInformerOptions, informers.WithTweakListOptions(func(listOptions *metav1.ListOptions) {
+			listOptions.LabelSelector = selector
+		}))
+	}
+	sharedInformerFactory := informers.NewSharedInformerFactoryWithOptions(clients.cmClient, resyncPeriod, cmInformerOptions...)
 	kubeSharedInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(clients.kubeClient, resyncPeriod, kubeinformers.WithNamespace(opts.Namespace))
+	if utilfeature.DefaultFeatureGate.Enabled(feature.SecretsFilteredCaching) || utilfeature.DefaultFeatureGate.Enabled(feature.MetadataOnlySecretInformers) {
+		metadataClient, err := metadata.NewForConfig(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error creating metadata client: %w", err)
+		}
+
+		metadataInformerFactory := metadatainformer.NewFilteredSharedInformerFactory(metadataClient, resyncPeriod, opts.Namespace, nil)
+		if utilfeature.DefaultFeatureGate.Enabled(feature.SecretsFilteredCaching) {
+			filteredInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(clients.kubeClient, resyncPeriod,
+				kubeinformers.WithNamespace(opts.Namespace),
+				kubeinformers.WithTweakListOptions(func(listOptions *metav1.ListOptions) {
+					listOptions.LabelSelector = managedSecretsSelector
+				}))
+			kubeSharedInformerFactory = newFilteredSecretsFactory(kubeSharedInformerFactory, filteredInformerFactory, metadataInformerFactory, clients.kubeClient.CoreV1())
+		} else {
+			kubeSharedInformerFactory = newMetadataOnlySecretsFactory(kubeSharedInformerFactory, metadataInformerFactory, clients.kubeClient.CoreV1())
+		}
+	}
 	gwSharedInformerFactory := gwinformers.NewSharedInformerFactoryWithOptions(clients.gwClient, resyncPeriod, gwinformers.WithNamespace(opts.Namespace))
 
 	return &ContextFactory{
@@ -286,6 +421,15 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 // derived from the optional component name.
 func (c *ContextFactory) Build(component ...string) (*Context, error) {
 	restConfig := util.RestConfigWithUserAgent(c.baseRestConfig, component...)
+	if len(component) > 0 {
+		// Controllers with their own API client rate limits don't share the
+		// RateLimiter of the other Contexts.
+		if settings := c.ctx.Controllers[component[0]]; settings.KubernetesAPIQPS != nil && settings.KubernetesAPIBurst != nil {
+			restConfig.QPS = *settings.KubernetesAPIQPS
+			restConfig.Burst = *settings.KubernetesAPIBurst
+			restConfig.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(restConfig.QPS, restConfig.Burst)
+		}
+	}
 
 	clients, err := buildClients(restConfig)
 	if err != nil {
@@ -298,10 +442,14 @@ func (c *ContextFactory) Build(component ...string) (*Context, error) {
 	cmscheme.AddToScheme(scheme.Scheme)
 	gwscheme.AddToScheme(scheme.Scheme)
 	c.log.V(logf.DebugLevel).Info("creating event broadcaster")
-	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster := record.NewBroadcasterWithCorrelatorOptions(record.CorrelatorOptions{
+		QPS:       c.ctx.EventRateLimitQPS,
+		BurstSize: c.ctx.EventRateLimitBurst,
+	})
 	eventBroadcaster.StartLogging(logf.WithInfof(c.log.V(logf.DebugLevel)).Infof)
 	eventBroadcaster.StartRecordingToSink(&clientv1.EventSinkImpl{Interface: clients.kubeClient.CoreV1().Events("")})
 	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: util.PrefixFromUserAgent(restConfig.UserAgent)})
+	recorder = filterEventTypes(recorder, c.ctx.EventTypes)
 
 	ctx := *c.ctx
 	ctx.FieldManager = util.PrefixFromUserAgent(restConfig.UserAgent)
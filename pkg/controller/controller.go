@@ -19,19 +19,44 @@ package controller
 import (
 	"context"
 	"fmt"
+	"runtime/pprof"
 	"strings"
 	"sync"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
+// WorkerStallTimeout is the time after which a worker which is still
+// processing the same item is considered to have stalled, causing the
+// controller to fail its health check.
+const WorkerStallTimeout = 10 * time.Minute
+
+// ProfilerLabel is the profiler label holding the name of the controller
+// which started a goroutine.
+const ProfilerLabel = "controller"
+
+// Stats describes the workers and queue of a running controller.
+type Stats struct {
+	// Workers is the number of workers of the controller.
+	Workers int `json:"workers"`
+	// BusyWorkers is the number of workers processing an item.
+	BusyWorkers int `json:"busyWorkers"`
+	// QueueLength is the number of items waiting to be processed.
+	QueueLength int `json:"queueLength"`
+	// LongestProcessing is how long the worker which has been processing
+	// its current item for the longest has been doing so.
+	LongestProcessing metav1.Duration `json:"longestProcessing"`
+}
+
 type runFunc func(context.Context)
 
 type runDurationFunc struct {
@@ -57,10 +82,12 @@ func NewController(
 		ctx:              ctx,
 		name:             name,
 		metrics:          metrics,
+		clock:            clock.RealClock{},
 		syncHandler:      syncFunc,
 		mustSync:         mustSync,
 		runDurationFuncs: runDurationFuncs,
 		queue:            queue,
+		processing:       make(map[int]time.Time),
 	}
 }
 
@@ -91,6 +118,16 @@ type controller struct {
 
 	// metrics is used to expose Prometheus, shared by all controllers
 	metrics *metrics.Metrics
+
+	clock clock.Clock
+
+	// processingLock protects processing and workers
+	processingLock sync.Mutex
+	// workers is the number of workers the controller was started with
+	workers int
+	// processing holds the time at which each busy worker started
+	// processing its current item, keyed by worker number
+	processing map[int]time.Time
 }
 
 // Run starts the controller loop
@@ -99,6 +136,15 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) error {
 	defer cancel()
 	log := logf.FromContext(ctx)
 
+	// Label the goroutines of the controller in profiles, so that they can
+	// be told apart from those of other controllers.
+	ctx = pprof.WithLabels(ctx, pprof.Labels(ProfilerLabel, c.name))
+	pprof.SetGoroutineLabels(ctx)
+
+	c.processingLock.Lock()
+	c.workers = workers
+	c.processingLock.Unlock()
+
 	log.V(logf.DebugLevel).Info("starting control loop")
 	// wait for all the informer caches we depend on are synced
 	if !cache.WaitForCacheSync(stopCh, c.mustSync...) {
@@ -108,10 +154,10 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) error {
 	var wg sync.WaitGroup
 	for i := 0; i < workers; i++ {
 		wg.Add(1)
-		go func() {
+		go func(id int) {
 			defer wg.Done()
-			c.worker(ctx)
-		}()
+			c.worker(ctx, id)
+		}(i)
 	}
 
 	for _, f := range c.runFirstFuncs {
@@ -132,7 +178,74 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) error {
 	return nil
 }
 
-func (c *controller) worker(ctx context.Context) {
+// Check returns an error if any worker has been processing the same item for
+// longer than WorkerStallTimeout.
+func (c *controller) Check() error {
+	c.processingLock.Lock()
+	defer c.processingLock.Unlock()
+
+	now := c.clock.Now()
+	stalled := 0
+	var longest time.Duration
+	for _, start := range c.processing {
+		if d := now.Sub(start); d > WorkerStallTimeout {
+			stalled++
+			if d > longest {
+				longest = d
+			}
+		}
+	}
+	if stalled > 0 {
+		return fmt.Errorf("%d worker(s) of controller %q stalled, the longest has been processing the same item for %s", stalled, c.name, longest.Round(time.Second))
+	}
+	return nil
+}
+
+// HasSynced returns true once all of the informers which must have synced
+// before the controller starts processing items have done so.
+func (c *controller) HasSynced() bool {
+	for _, synced := range c.mustSync {
+		if !synced() {
+			return false
+		}
+	}
+	return true
+}
+
+// Stats returns the state of the workers and queue of the controller.
+func (c *controller) Stats() Stats {
+	c.processingLock.Lock()
+	defer c.processingLock.Unlock()
+
+	stats := Stats{
+		Workers:     c.workers,
+		BusyWorkers: len(c.processing),
+		QueueLength: c.queue.Len(),
+	}
+	now := c.clock.Now()
+	for _, start := range c.processing {
+		if d := now.Sub(start); d > stats.LongestProcessing.Duration {
+			stats.LongestProcessing.Duration = d
+		}
+	}
+	return stats
+}
+
+func (c *controller) startProcessing(id int) time.Time {
+	c.processingLock.Lock()
+	defer c.processingLock.Unlock()
+	now := c.clock.Now()
+	c.processing[id] = now
+	return now
+}
+
+func (c *controller) finishProcessing(id int) {
+	c.processingLock.Lock()
+	defer c.processingLock.Unlock()
+	delete(c.processing, id)
+}
+
+func (c *controller) worker(ctx context.Context, id int) {
 	log := logf.FromContext(c.ctx)
 
 	log.V(logf.DebugLevel).Info("starting worker")
@@ -156,7 +269,10 @@ func (c *controller) worker(ctx context.Context) {
 			// Increase sync count for this controller
 			c.metrics.IncrementSyncCallCount(c.name)
 
+			start := c.startProcessing(id)
 			err := c.syncHandler(ctx, key)
+			c.finishProcessing(id)
+			c.metrics.ObserveSyncDuration(c.name, c.clock.Since(start))
 			if err != nil {
 				if strings.Contains(err.Error(), genericregistry.OptimisticLockErrorMsg) {
 					log.Info("re-queuing item due to optimistic locking on resource", "error", err.Error())
@@ -172,6 +288,7 @@ func (c *controller) worker(ctx context.Context) {
 				return
 			}
 			log.V(logf.DebugLevel).Info("finished processing work item")
+			c.metrics.UpdateLastSuccessfulSync(c.name, c.clock.Now())
 			c.queue.Forget(obj)
 		}()
 	}
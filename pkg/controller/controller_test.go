@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+func TestControllerCheck(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	started := make(chan string)
+	release := make(chan struct{})
+	syncFunc := func(ctx context.Context, key string) error {
+		started <- key
+		<-release
+		return nil
+	}
+
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c := NewController(context.TODO(), "test", metrics.New(logtesting.NewTestLogger(t), clock), syncFunc, nil, nil, queue).(*controller)
+	c.clock = clock
+
+	stopCh := make(chan struct{})
+	errCh := make(chan error)
+	go func() { errCh <- c.Run(1, stopCh) }()
+	defer func() {
+		close(stopCh)
+		if err := <-errCh; err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := c.Check(); err != nil {
+		t.Errorf("expected idle controller to be healthy, got: %v", err)
+	}
+
+	queue.Add("default/test")
+	<-started
+
+	clock.Step(WorkerStallTimeout - time.Second)
+	if err := c.Check(); err != nil {
+		t.Errorf("expected controller to be healthy before the stall timeout, got: %v", err)
+	}
+
+	clock.Step(2 * time.Second)
+	if err := c.Check(); err == nil {
+		t.Error("expected controller with a stalled worker to be unhealthy")
+	}
+	if stats := c.Stats(); stats.Workers != 1 || stats.BusyWorkers != 1 || stats.LongestProcessing.Duration != WorkerStallTimeout+time.Second {
+		t.Errorf("unexpected stats for controller with a stalled worker: %+v", stats)
+	}
+
+	release <- struct{}{}
+	// Wait for the worker to pick up the next item, at which point it has
+	// finished processing the stalled one.
+	queue.Add("default/other")
+	<-started
+	if err := c.Check(); err != nil {
+		t.Errorf("expected controller to be healthy once the worker progressed, got: %v", err)
+	}
+	close(release)
+}
+
+func TestControllerHasSynced(t *testing.T) {
+	synced := false
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	c := NewController(context.TODO(), "test", metrics.New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now())), nil, []cache.InformerSynced{
+		func() bool { return true },
+		func() bool { return synced },
+	}, nil, queue)
+
+	if c.HasSynced() {
+		t.Error("expected controller with an unsynced informer to not have synced")
+	}
+	synced = true
+	if !c.HasSynced() {
+		t.Error("expected controller to have synced once all informers have synced")
+	}
+}
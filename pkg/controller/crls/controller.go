@@ -0,0 +1,511 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crls
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	issuerca "github.com/cert-manager/cert-manager/pkg/issuer/ca"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	ControllerName = "crls"
+
+	// CRLKey is the key of the ConfigMap which holds the DER encoded CRL.
+	CRLKey = "ca.crl"
+
+	// defaultValidity is the validity of CRLs if the issuer doesn't set one.
+	defaultValidity = 24 * time.Hour
+
+	reasonCRLSigned = "CRLSigned"
+	reasonCRLError  = "CRLError"
+)
+
+// oidExtensionReasonCode is the OID of the CRL entry extension holding the
+// reason a certificate was revoked, as defined in RFC 5280 section 5.3.1.
+var oidExtensionReasonCode = asn1.ObjectIdentifier{2, 5, 29, 21}
+
+// The reason codes of CRL entries are the same as those of OCSP responses.
+var revocationReasons = map[cmapi.RevocationReason]int{
+	cmapi.RevocationReasonKeyCompromise:        ocsp.KeyCompromise,
+	cmapi.RevocationReasonCACompromise:         ocsp.CACompromise,
+	cmapi.RevocationReasonAffiliationChanged:   ocsp.AffiliationChanged,
+	cmapi.RevocationReasonSuperseded:           ocsp.Superseded,
+	cmapi.RevocationReasonCessationOfOperation: ocsp.CessationOfOperation,
+	cmapi.RevocationReasonPrivilegeWithdrawn:   ocsp.PrivilegeWithdrawn,
+}
+
+// This controller signs a CRL for each CA issuer which has spec.ca.crl set,
+// listing the certificates revoked by CertificateRevocationRequests, and
+// writes it to the configured ConfigMap. Issuers are queued by their key,
+// which has no namespace for ClusterIssuers.
+// The CRL is signed again when the revoked certificates or the CA change,
+// and before the current CRL expires. The ConfigMap is not watched: changes
+// made to it are overwritten the next time the CRL is signed.
+type controller struct {
+	log                 logr.Logger
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	crrLister           cmlisters.CertificateRevocationRequestLister
+	secretLister        corelisters.SecretLister
+	kubeClient          kubernetes.Interface
+	recorder            record.EventRecorder
+	queue               workqueue.RateLimitingInterface
+	clock               clock.Clock
+
+	clusterResourceNamespace string
+}
+
+func NewController(
+	log logr.Logger,
+	kubeClient kubernetes.Interface,
+	factory kubeinformers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	issuerOptions controllerpkg.IssuerOptions,
+	namespace string,
+	rateLimiter workqueue.RateLimiter,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ControllerName)
+
+	// obtain references to all the informers used by this controller
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	crrInformer := cmFactory.Certmanager().V1().CertificateRevocationRequests()
+	secretInformer := controllerpkg.SecretsMetadataInformer(factory)
+
+	c := &controller{
+		log:                      log,
+		issuerLister:             issuerInformer.Lister(),
+		crrLister:                crrInformer.Lister(),
+		secretLister:             controllerpkg.NewSecretGetter(factory),
+		kubeClient:               kubeClient,
+		recorder:                 recorder,
+		queue:                    queue,
+		clock:                    clock,
+		clusterResourceNamespace: issuerOptions.ClusterResourceNamespace,
+	}
+
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	crrInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleCertificateRevocationRequest})
+	secretInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleSecret})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		issuerInformer.Informer().HasSynced,
+		crrInformer.Informer().HasSynced,
+		secretInformer.HasSynced,
+	}
+
+	// ClusterIssuers are only watched if we are not scoped to a single
+	// namespace.
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		c.clusterIssuerLister = clusterIssuerInformer.Lister()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	return c, queue, mustSync
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	iss, err := c.genericIssuer(namespace, name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("issuer not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ca := iss.GetSpec().CA
+	if ca == nil || ca.CRL == nil {
+		return nil
+	}
+	validity := defaultValidity
+	if ca.CRL.Validity != nil {
+		validity = ca.CRL.Validity.Duration
+	}
+
+	// Resources of ClusterIssuers are in the cluster resource namespace.
+	kind, resourceNamespace := cmapi.IssuerKind, namespace
+	if namespace == "" {
+		kind, resourceNamespace = cmapi.ClusterIssuerKind, c.clusterResourceNamespace
+	}
+
+	certs, caKey, err := issuerca.SigningKeyPair(ctx, c.secretLister, resourceNamespace, ca)
+	if apierrors.IsNotFound(err) {
+		// The issuer is queued again once the Secret is created.
+		log.V(logf.DebugLevel).Info("CA secret not found", "secret", ca.SecretName)
+		return nil
+	}
+	if err != nil && ca.PKCS11 != nil && !cmerrors.IsInvalidData(err) {
+		// Failing to use the PKCS#11 token may be transient, so retry.
+		c.recorder.Eventf(iss, corev1.EventTypeWarning, reasonCRLError, "Failed to use the CA private key in PKCS#11 token %q: %v", ca.PKCS11.TokenLabel, err)
+		return err
+	}
+	if err != nil {
+		c.recorder.Eventf(iss, corev1.EventTypeWarning, reasonCRLError, "Failed to read the CA from Secret %q: %v", ca.SecretName, err)
+		return nil
+	}
+	caCert := certs[0]
+
+	revoked, err := c.revokedCertificates(resourceNamespace, kind, name)
+	if err != nil {
+		return err
+	}
+
+	configMap, err := c.kubeClient.CoreV1().ConfigMaps(resourceNamespace).Get(ctx, ca.CRL.ConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		configMap = nil
+	} else if err != nil {
+		return err
+	}
+
+	now := c.clock.Now()
+	current, number := currentCRL(configMap, caCert)
+	if current != nil && current.NextUpdate.Sub(current.ThisUpdate) == validity && equalRevokedCertificates(current.RevokedCertificates, revoked) {
+		if renewal := renewalTime(current); now.Before(renewal) {
+			log.V(logf.DebugLevel).Info("CRL is up to date, scheduling renewal", "renewal_time", renewal)
+			c.queue.AddAfter(key, renewal.Sub(now))
+			return nil
+		}
+	}
+
+	crl, err := signCRL(caCert, caKey, revoked, number, now, validity)
+	if err != nil {
+		// The CA can only be fixed by changing the Secret, which queues the
+		// issuer again.
+		c.recorder.Eventf(iss, corev1.EventTypeWarning, reasonCRLError, "Failed to sign CRL: %v", err)
+		return nil
+	}
+
+	if configMap == nil {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ca.CRL.ConfigMapName,
+				Namespace: resourceNamespace,
+				// The ConfigMap is garbage collected with the issuer.
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: cmapi.SchemeGroupVersion.String(),
+					Kind:       kind,
+					Name:       name,
+					UID:        iss.GetObjectMeta().UID,
+				}},
+			},
+			BinaryData: map[string][]byte{CRLKey: crl},
+		}
+		if _, err := c.kubeClient.CoreV1().ConfigMaps(resourceNamespace).Create(ctx, configMap, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	} else {
+		configMap = configMap.DeepCopy()
+		if configMap.BinaryData == nil {
+			configMap.BinaryData = make(map[string][]byte)
+		}
+		configMap.BinaryData[CRLKey] = crl
+		if _, err := c.kubeClient.CoreV1().ConfigMaps(resourceNamespace).Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	c.recorder.Eventf(iss, corev1.EventTypeNormal, reasonCRLSigned, "Signed CRL number %s listing %d revoked certificates", number, len(revoked))
+
+	renewal := now.Add(validity * 2 / 3)
+	c.queue.AddAfter(key, renewal.Sub(now))
+
+	return nil
+}
+
+// genericIssuer returns the Issuer or ClusterIssuer with the given key.
+// ClusterIssuers have no namespace.
+func (c *controller) genericIssuer(namespace, name string) (cmapi.GenericIssuer, error) {
+	if namespace != "" {
+		return c.issuerLister.Issuers(namespace).Get(name)
+	}
+	if c.clusterIssuerLister == nil {
+		return nil, apierrors.NewNotFound(cmapi.Resource("clusterissuers"), name)
+	}
+	return c.clusterIssuerLister.Get(name)
+}
+
+// revokedCertificates returns the CRL entries of the certificates revoked
+// by the CertificateRevocationRequests which reference the issuer, sorted by
+// serial number.
+func (c *controller) revokedCertificates(resourceNamespace, kind, name string) ([]pkix.RevokedCertificate, error) {
+	crrs, err := c.crrLister.CertificateRevocationRequests(resourceNamespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var revoked []pkix.RevokedCertificate
+	for _, crr := range crrs {
+		ref := crr.Spec.IssuerRef
+		refKind := ref.Kind
+		if refKind == "" {
+			refKind = cmapi.IssuerKind
+		}
+		if refKind != kind || ref.Name != name {
+			continue
+		}
+		if !apiutil.CertificateRevocationRequestIsRevoked(crr) || crr.Status.RevocationTime == nil {
+			continue
+		}
+		serial, err := pki.ParseSerialNumber(crr.Spec.SerialNumber)
+		if err != nil {
+			continue
+		}
+
+		entry := pkix.RevokedCertificate{
+			SerialNumber:   serial,
+			RevocationTime: crr.Status.RevocationTime.UTC(),
+		}
+		if code, ok := revocationReasons[crr.Spec.Reason]; ok {
+			value, err := asn1.Marshal(asn1.Enumerated(code))
+			if err != nil {
+				return nil, err
+			}
+			entry.Extensions = []pkix.Extension{{Id: oidExtensionReasonCode, Value: value}}
+		}
+		revoked = append(revoked, entry)
+	}
+
+	sort.Slice(revoked, func(i, j int) bool {
+		return revoked[i].SerialNumber.Cmp(revoked[j].SerialNumber) < 0
+	})
+
+	return revoked, nil
+}
+
+// currentCRL returns the CRL held by the ConfigMap if it was signed by the
+// CA, and the number the next CRL must have.
+func currentCRL(configMap *corev1.ConfigMap, caCert *x509.Certificate) (*x509.RevocationList, *big.Int) {
+	if configMap == nil {
+		return nil, big.NewInt(1)
+	}
+	crl, err := x509.ParseRevocationList(configMap.BinaryData[CRLKey])
+	if err != nil {
+		return nil, big.NewInt(1)
+	}
+
+	// CRL numbers must increase for a given CRL issuer, even if the
+	// current CRL was signed by a previous key.
+	number := big.NewInt(1)
+	if crl.Number != nil && bytes.Equal(crl.RawIssuer, caCert.RawSubject) {
+		number.Add(crl.Number, number)
+	}
+
+	if err := crl.CheckSignatureFrom(caCert); err != nil {
+		return nil, number
+	}
+	return crl, number
+}
+
+// equalRevokedCertificates returns true if both lists of CRL entries, sorted
+// by serial number, revoke the same certificates at the same time and for
+// the same reason.
+func equalRevokedCertificates(a, b []pkix.RevokedCertificate) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].SerialNumber.Cmp(b[i].SerialNumber) != 0 ||
+			!a[i].RevocationTime.Equal(b[i].RevocationTime) ||
+			!bytes.Equal(reasonCode(a[i]), reasonCode(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// reasonCode returns the encoded reason code extension of a CRL entry, or
+// nil if it has none.
+func reasonCode(entry pkix.RevokedCertificate) []byte {
+	for _, ext := range entry.Extensions {
+		if ext.Id.Equal(oidExtensionReasonCode) {
+			return ext.Value
+		}
+	}
+	return nil
+}
+
+// renewalTime returns the time at which a CRL is signed again, once two
+// thirds of its validity have elapsed.
+func renewalTime(crl *x509.RevocationList) time.Time {
+	return crl.ThisUpdate.Add(crl.NextUpdate.Sub(crl.ThisUpdate) * 2 / 3)
+}
+
+// signCRL returns a DER encoded CRL, signed by the CA.
+func signCRL(caCert *x509.Certificate, key crypto.Signer, revoked []pkix.RevokedCertificate, number *big.Int, now time.Time, validity time.Duration) ([]byte, error) {
+	template := &x509.RevocationList{
+		RevokedCertificates: revoked,
+		Number:              number,
+		ThisUpdate:          now,
+		NextUpdate:          now.Add(validity),
+	}
+	crl, err := x509.CreateRevocationList(rand.Reader, template, caCert, key)
+	if err != nil {
+		return nil, fmt.Errorf("error signing CRL: %w", err)
+	}
+	return crl, nil
+}
+
+// handleCertificateRevocationRequest queues the issuer referenced by a
+// CertificateRevocationRequest.
+func (c *controller) handleCertificateRevocationRequest(obj interface{}) {
+	log := c.log.WithName("handleCertificateRevocationRequest")
+
+	crr, ok := obj.(*cmapi.CertificateRevocationRequest)
+	if !ok {
+		log.Error(nil, "object is not a CertificateRevocationRequest")
+		return
+	}
+
+	switch crr.Spec.IssuerRef.Kind {
+	case "", cmapi.IssuerKind:
+		c.queue.Add(crr.Namespace + "/" + crr.Spec.IssuerRef.Name)
+	case cmapi.ClusterIssuerKind:
+		// Only the cluster resource namespace may revoke certificates
+		// issued by ClusterIssuers.
+		if crr.Namespace == c.clusterResourceNamespace {
+			c.queue.Add(crr.Spec.IssuerRef.Name)
+		}
+	}
+}
+
+// handleSecret queues the CA issuers whose CA is stored in a Secret.
+func (c *controller) handleSecret(obj interface{}) {
+	log := c.log.WithName("handleSecret")
+
+	secret, ok := obj.(metav1.Object)
+	if !ok {
+		log.Error(nil, "object does not implement metav1.Object")
+		return
+	}
+
+	var issuers []cmapi.GenericIssuer
+	list, err := c.issuerLister.Issuers(secret.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		log.Error(err, "error listing issuers")
+		return
+	}
+	for _, iss := range list {
+		issuers = append(issuers, iss)
+	}
+	if c.clusterIssuerLister != nil && secret.GetNamespace() == c.clusterResourceNamespace {
+		list, err := c.clusterIssuerLister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "error listing clusterissuers")
+			return
+		}
+		for _, iss := range list {
+			issuers = append(issuers, iss)
+		}
+	}
+
+	for _, iss := range issuers {
+		ca := iss.GetSpec().CA
+		if ca == nil || ca.CRL == nil {
+			continue
+		}
+		if ca.SecretName != secret.GetName() && (ca.PKCS11 == nil || ca.PKCS11.PINSecretRef.Name != secret.GetName()) {
+			continue
+		}
+		key, err := controllerpkg.KeyFunc(iss)
+		if err != nil {
+			logf.WithRelatedResource(log, iss).Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.Client,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.IssuerOptions,
+		ctx.Namespace,
+		ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30),
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crls
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// caSecret returns a Secret holding a self signed CA with the given key
+// usages.
+func caSecret(t *testing.T, namespace string, keyUsage x509.KeyUsage) (*corev1.Secret, *x509.Certificate, crypto.Signer) {
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              keyUsage,
+	}
+	certPEM, cert, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodePKCS8PrivateKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ca"},
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	}, cert, pk
+}
+
+func Test_controller_ProcessItem(t *testing.T) {
+	fixedNow := time.Now().Truncate(time.Second)
+	fixedClock := fakeclock.NewFakeClock(fixedNow)
+	revokedAt := metav1.NewTime(fixedNow.Add(-2 * time.Hour))
+
+	secret, caCert, caKey := caSecret(t, "testns", x509.KeyUsageCertSign|x509.KeyUsageCRLSign)
+	clusterSecret := secret.DeepCopy()
+	clusterSecret.Namespace = "cert-manager"
+	noCRLSignSecret, _, _ := caSecret(t, "testns", x509.KeyUsageCertSign)
+
+	crlConfig := &cmapi.CACRL{ConfigMapName: "ca-crl"}
+	issuer := gen.Issuer("ca-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", CRL: crlConfig}),
+	)
+	issuer.UID = "issuer-uid"
+	clusterIssuer := gen.ClusterIssuer("ca-issuer",
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", CRL: crlConfig}),
+	)
+	clusterIssuer.UID = "clusterissuer-uid"
+	issuerWithoutCRL := gen.Issuer("ca-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}),
+	)
+
+	revocation := func(namespace, name string, ref cmmeta.ObjectReference, serial string, revoked bool) *cmapi.CertificateRevocationRequest {
+		crr := &cmapi.CertificateRevocationRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: cmapi.CertificateRevocationRequestSpec{
+				IssuerRef:    ref,
+				SerialNumber: serial,
+				Reason:       cmapi.RevocationReasonKeyCompromise,
+			},
+		}
+		if revoked {
+			crr.Status = cmapi.CertificateRevocationRequestStatus{
+				Conditions: []cmapi.CertificateRevocationRequestCondition{{
+					Type:   cmapi.CertificateRevocationRequestConditionRevoked,
+					Status: cmmeta.ConditionTrue,
+				}},
+				RevocationTime: &revokedAt,
+			}
+		}
+		return crr
+	}
+	issuerRef := cmmeta.ObjectReference{Name: "ca-issuer"}
+	clusterIssuerRef := cmmeta.ObjectReference{Name: "ca-issuer", Kind: cmapi.ClusterIssuerKind}
+
+	keyCompromise, err := asn1.Marshal(asn1.Enumerated(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := func(serial int64) pkix.RevokedCertificate {
+		return pkix.RevokedCertificate{
+			SerialNumber:   big.NewInt(serial),
+			RevocationTime: revokedAt.UTC(),
+			Extensions:     []pkix.Extension{{Id: oidExtensionReasonCode, Value: keyCompromise}},
+		}
+	}
+
+	// configMap returns a ConfigMap holding a CRL signed at the given time.
+	configMap := func(namespace string, signedAt time.Time, number int64, revoked ...pkix.RevokedCertificate) *corev1.ConfigMap {
+		crl, err := signCRL(caCert, caKey, revoked, big.NewInt(number), signedAt, defaultValidity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ca-crl"},
+			Data:       map[string]string{"index.html": "CRLs"},
+			BinaryData: map[string][]byte{CRLKey: crl},
+		}
+	}
+
+	configMapsGVR := corev1.SchemeGroupVersion.WithResource("configmaps")
+	getConfigMap := func(namespace string) testpkg.Action {
+		return testpkg.NewAction(coretesting.NewGetAction(configMapsGVR, namespace, "ca-crl"))
+	}
+	// matchCRL matches a created or updated ConfigMap if its CRL is signed
+	// by the CA, has the given number and lists the given serial numbers.
+	matchCRL := func(ownerReferences []metav1.OwnerReference, otherData map[string]string, number int64, serials ...int64) testpkg.ActionMatchFn {
+		return func(_, act coretesting.Action) error {
+			cm := act.(coretesting.CreateAction).GetObject().(*corev1.ConfigMap)
+			if !reflect.DeepEqual(cm.OwnerReferences, ownerReferences) {
+				return fmt.Errorf("unexpected owner references: %v", cm.OwnerReferences)
+			}
+			if !reflect.DeepEqual(cm.Data, otherData) {
+				return fmt.Errorf("unexpected data: %v", cm.Data)
+			}
+			crl, err := x509.ParseRevocationList(cm.BinaryData[CRLKey])
+			if err != nil {
+				return err
+			}
+			if err := crl.CheckSignatureFrom(caCert); err != nil {
+				return err
+			}
+			if crl.Number.Cmp(big.NewInt(number)) != 0 {
+				return fmt.Errorf("expected CRL number %d, got %s", number, crl.Number)
+			}
+			if !crl.ThisUpdate.Equal(fixedNow) || !crl.NextUpdate.Equal(fixedNow.Add(defaultValidity)) {
+				return fmt.Errorf("unexpected CRL validity: %s to %s", crl.ThisUpdate, crl.NextUpdate)
+			}
+			var expected []pkix.RevokedCertificate
+			for _, serial := range serials {
+				expected = append(expected, entry(serial))
+			}
+			if !equalRevokedCertificates(crl.RevokedCertificates, expected) {
+				return fmt.Errorf("unexpected revoked certificates: %v", crl.RevokedCertificates)
+			}
+			return nil
+		}
+	}
+	issuerOwner := []metav1.OwnerReference{{APIVersion: "cert-manager.io/v1", Kind: "Issuer", Name: "ca-issuer", UID: "issuer-uid"}}
+	clusterIssuerOwner := []metav1.OwnerReference{{APIVersion: "cert-manager.io/v1", Kind: "ClusterIssuer", Name: "ca-issuer", UID: "clusterissuer-uid"}}
+
+	tests := map[string]struct {
+		key             string
+		issuer          runtime.Object
+		crrs            []runtime.Object
+		kubeObjects     []runtime.Object
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+	}{
+		"create the ConfigMap with a CRL listing the revoked certificates of an Issuer": {
+			key:    "testns/ca-issuer",
+			issuer: issuer,
+			crrs: []runtime.Object{
+				revocation("testns", "revoke-2", issuerRef, "02", true),
+				revocation("testns", "revoke-1", issuerRef, "01", true),
+				revocation("testns", "pending", issuerRef, "03", false),
+				revocation("testns", "other-issuer", cmmeta.ObjectReference{Name: "other"}, "04", true),
+				revocation("testns", "cluster-issuer", clusterIssuerRef, "05", true),
+			},
+			kubeObjects: []runtime.Object{secret},
+			expectedActions: []testpkg.Action{
+				getConfigMap("testns"),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(configMapsGVR, "testns", &corev1.ConfigMap{}),
+					matchCRL(issuerOwner, nil, 1, 1, 2)),
+			},
+			expectedEvents: []string{"Normal CRLSigned Signed CRL number 1 listing 2 revoked certificates"},
+		},
+		"create the ConfigMap of a ClusterIssuer in the cluster resource namespace": {
+			key:    "ca-issuer",
+			issuer: clusterIssuer,
+			crrs: []runtime.Object{
+				revocation("cert-manager", "revoke-1", clusterIssuerRef, "01", true),
+				revocation("testns", "revoke-2", clusterIssuerRef, "02", true),
+			},
+			kubeObjects: []runtime.Object{clusterSecret},
+			expectedActions: []testpkg.Action{
+				getConfigMap("cert-manager"),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(configMapsGVR, "cert-manager", &corev1.ConfigMap{}),
+					matchCRL(clusterIssuerOwner, nil, 1, 1)),
+			},
+			expectedEvents: []string{"Normal CRLSigned Signed CRL number 1 listing 1 revoked certificates"},
+		},
+		"do nothing if the CRL is up to date": {
+			key:    "testns/ca-issuer",
+			issuer: issuer,
+			crrs: []runtime.Object{
+				revocation("testns", "revoke-1", issuerRef, "01", true),
+			},
+			kubeObjects:     []runtime.Object{secret, configMap("testns", fixedNow.Add(-time.Hour), 3, entry(1))},
+			expectedActions: []testpkg.Action{getConfigMap("testns")},
+		},
+		"update the CRL if a certificate was revoked": {
+			key:    "testns/ca-issuer",
+			issuer: issuer,
+			crrs: []runtime.Object{
+				revocation("testns", "revoke-1", issuerRef, "01", true),
+				revocation("testns", "revoke-2", issuerRef, "02", true),
+			},
+			kubeObjects: []runtime.Object{secret, configMap("testns", fixedNow.Add(-time.Hour), 3, entry(1))},
+			expectedActions: []testpkg.Action{
+				getConfigMap("testns"),
+				testpkg.NewCustomMatch(coretesting.NewUpdateAction(configMapsGVR, "testns", &corev1.ConfigMap{}),
+					matchCRL(nil, map[string]string{"index.html": "CRLs"}, 4, 1, 2)),
+			},
+			expectedEvents: []string{"Normal CRLSigned Signed CRL number 4 listing 2 revoked certificates"},
+		},
+		"update the CRL once two thirds of its validity have elapsed": {
+			key:    "testns/ca-issuer",
+			issuer: issuer,
+			crrs: []runtime.Object{
+				revocation("testns", "revoke-1", issuerRef, "01", true),
+			},
+			kubeObjects: []runtime.Object{secret, configMap("testns", fixedNow.Add(-17*time.Hour), 3, entry(1))},
+			expectedActions: []testpkg.Action{
+				getConfigMap("testns"),
+				testpkg.NewCustomMatch(coretesting.NewUpdateAction(configMapsGVR, "testns", &corev1.ConfigMap{}),
+					matchCRL(nil, map[string]string{"index.html": "CRLs"}, 4, 1)),
+			},
+			expectedEvents: []string{"Normal CRLSigned Signed CRL number 4 listing 1 revoked certificates"},
+		},
+		"do nothing if the issuer has no CRL configured": {
+			key:         "testns/ca-issuer",
+			issuer:      issuerWithoutCRL,
+			kubeObjects: []runtime.Object{secret},
+		},
+		"do nothing if the CA Secret does not exist": {
+			key:    "testns/ca-issuer",
+			issuer: issuer,
+		},
+		"fire an event if the CA cannot sign CRLs": {
+			key:         "testns/ca-issuer",
+			issuer:      issuer,
+			kubeObjects: []runtime.Object{noCRLSignSecret},
+			expectedActions: []testpkg.Action{
+				getConfigMap("testns"),
+			},
+			expectedEvents: []string{"Warning CRLError Failed to sign CRL: error signing CRL: x509: issuer must have the crlSign key usage bit set"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: append([]runtime.Object{test.issuer}, test.crrs...),
+				KubeObjects:        test.kubeObjects,
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+			builder.Context.IssuerOptions.ClusterResourceNamespace = "cert-manager"
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), test.key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
+
+func Test_controller_handleCertificateRevocationRequest(t *testing.T) {
+	tests := map[string]struct {
+		crr         *cmapi.CertificateRevocationRequest
+		expectedKey string
+	}{
+		"queue the referenced Issuer": {
+			crr: &cmapi.CertificateRevocationRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "revoke"},
+				Spec:       cmapi.CertificateRevocationRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: "ca-issuer"}},
+			},
+			expectedKey: "testns/ca-issuer",
+		},
+		"queue the referenced ClusterIssuer from the cluster resource namespace": {
+			crr: &cmapi.CertificateRevocationRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "cert-manager", Name: "revoke"},
+				Spec:       cmapi.CertificateRevocationRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: "ca-issuer", Kind: cmapi.ClusterIssuerKind}},
+			},
+			expectedKey: "ca-issuer",
+		},
+		"ignore ClusterIssuers referenced from other namespaces": {
+			crr: &cmapi.CertificateRevocationRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "revoke"},
+				Spec:       cmapi.CertificateRevocationRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: "ca-issuer", Kind: cmapi.ClusterIssuerKind}},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{T: t}
+			builder.Init()
+			builder.Context.IssuerOptions.ClusterResourceNamespace = "cert-manager"
+
+			w := &controllerWrapper{}
+			queue, _, err := w.Register(builder.Context)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w.controller.handleCertificateRevocationRequest(test.crr)
+
+			if test.expectedKey == "" {
+				if queue.Len() != 0 {
+					t.Errorf("expected nothing to be queued, got %d items", queue.Len())
+				}
+				return
+			}
+			if queue.Len() != 1 {
+				t.Fatalf("expected one item to be queued, got %d", queue.Len())
+			}
+			key, _ := queue.Get()
+			if key != test.expectedKey {
+				t.Errorf("expected key %q to be queued, got %q", test.expectedKey, key)
+			}
+		})
+	}
+}
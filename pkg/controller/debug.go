@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// InformerCacheSizes returns the number of objects cached by each of the
+// informers started by the shared informer factories of the context, keyed
+// by the type of object. It is meant for debugging.
+func (c *Context) InformerCacheSizes() map[string]int {
+	sizes := make(map[string]int)
+
+	kubeFactory := c.KubeSharedInformerFactory
+	if f, ok := kubeFactory.(*secretsFactory); ok {
+		kubeFactory = f.SharedInformerFactory
+		sizes["metadata/secrets"] = len(f.metadataFactory.ForResource(secretsGVR).Informer().GetStore().ListKeys())
+		if f.filteredFactory != nil {
+			addCacheSizes(sizes, f.filteredFactory.WaitForCacheSync(closedChannel()), func(obj runtime.Object) cache.SharedIndexInformer {
+				return f.filteredFactory.InformerFor(obj, nil)
+			})
+		}
+	}
+	if kubeFactory != nil {
+		addCacheSizes(sizes, kubeFactory.WaitForCacheSync(closedChannel()), func(obj runtime.Object) cache.SharedIndexInformer {
+			return kubeFactory.InformerFor(obj, nil)
+		})
+	}
+	if c.SharedInformerFactory != nil {
+		addCacheSizes(sizes, c.SharedInformerFactory.WaitForCacheSync(closedChannel()), func(obj runtime.Object) cache.SharedIndexInformer {
+			return c.SharedInformerFactory.InformerFor(obj, nil)
+		})
+	}
+	if c.GWShared != nil {
+		addCacheSizes(sizes, c.GWShared.WaitForCacheSync(closedChannel()), func(obj runtime.Object) cache.SharedIndexInformer {
+			return c.GWShared.InformerFor(obj, nil)
+		})
+	}
+	return sizes
+}
+
+// addCacheSizes adds the cache sizes of the given started informers, which
+// are returned by informerFor since they already exist.
+func addCacheSizes(sizes map[string]int, started map[reflect.Type]bool, informerFor func(runtime.Object) cache.SharedIndexInformer) {
+	for typ := range started {
+		obj, ok := reflect.New(typ.Elem()).Interface().(runtime.Object)
+		if !ok {
+			continue
+		}
+		sizes[typ.Elem().PkgPath()+"."+typ.Elem().Name()] = len(informerFor(obj).GetStore().ListKeys())
+	}
+}
+
+// closedChannel returns a closed channel, so that WaitForCacheSync returns
+// without waiting for informers to sync.
+func closedChannel() <-chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	informers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestInformerCacheSizes(t *testing.T) {
+	kubeFactory := kubeinformers.NewSharedInformerFactory(kubefake.NewSimpleClientset(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "a"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "b"}},
+	), 0)
+	cmFactory := informers.NewSharedInformerFactory(cmfake.NewSimpleClientset(gen.Certificate("test", gen.SetCertificateNamespace("ns"))), 0)
+	kubeFactory.Core().V1().Secrets().Informer()
+	cmFactory.Certmanager().V1().Certificates().Informer()
+	cmFactory.Certmanager().V1().Issuers().Informer()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	kubeFactory.Start(stopCh)
+	cmFactory.Start(stopCh)
+	kubeFactory.WaitForCacheSync(stopCh)
+	cmFactory.WaitForCacheSync(stopCh)
+	// Informers which have not been started are not included.
+	cmFactory.Certmanager().V1().CertificateRequests().Informer()
+
+	ctx := &Context{KubeSharedInformerFactory: kubeFactory, SharedInformerFactory: cmFactory}
+	assert.Equal(t, map[string]int{
+		"k8s.io/api/core/v1.Secret": 2,
+		"github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1.Certificate": 1,
+		"github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1.Issuer":      0,
+	}, ctx.InformerCacheSizes())
+}
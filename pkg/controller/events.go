@@ -0,0 +1,57 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+)
+
+// eventTypeFilter is an EventRecorder which drops events whose type is not
+// in the set of allowed types.
+type eventTypeFilter struct {
+	record.EventRecorder
+	types sets.String
+}
+
+// filterEventTypes returns a recorder which only records events of the given
+// types, e.g. Warning. If no types are given, all events are recorded.
+func filterEventTypes(recorder record.EventRecorder, types []string) record.EventRecorder {
+	if len(types) == 0 {
+		return recorder
+	}
+	return &eventTypeFilter{EventRecorder: recorder, types: sets.NewString(types...)}
+}
+
+func (r *eventTypeFilter) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.types.Has(eventtype) {
+		r.EventRecorder.Event(object, eventtype, reason, message)
+	}
+}
+
+func (r *eventTypeFilter) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	if r.types.Has(eventtype) {
+		r.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+	}
+}
+
+func (r *eventTypeFilter) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	if r.types.Has(eventtype) {
+		r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	}
+}
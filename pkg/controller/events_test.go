@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestFilterEventTypes(t *testing.T) {
+	tests := map[string]struct {
+		types []string
+		exp   []string
+	}{
+		"all events are recorded if no types are given": {
+			exp: []string{"Normal Issuing issuing", "Warning Failed failed", "Warning Failed annotated"},
+		},
+		"only warnings are recorded": {
+			types: []string{corev1.EventTypeWarning},
+			exp:   []string{"Warning Failed failed", "Warning Failed annotated"},
+		},
+		"only normal events are recorded": {
+			types: []string{corev1.EventTypeNormal},
+			exp:   []string{"Normal Issuing issuing"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := record.NewFakeRecorder(10)
+			recorder := filterEventTypes(fake, test.types)
+
+			crt := gen.Certificate("test")
+			recorder.Event(crt, corev1.EventTypeNormal, "Issuing", "issuing")
+			recorder.Eventf(crt, corev1.EventTypeWarning, "Failed", "%s", "failed")
+			recorder.AnnotatedEventf(crt, map[string]string{"foo": "bar"}, corev1.EventTypeWarning, "Failed", "annotated")
+			close(fake.Events)
+
+			var got []string
+			for e := range fake.Events {
+				got = append(got, e)
+			}
+			assert.Equal(t, test.exp, got)
+		})
+	}
+}
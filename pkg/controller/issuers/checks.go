@@ -20,11 +20,11 @@ import (
 	"fmt"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
-func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.Issuer, error) {
+func (c *controller) issuersForSecret(secret metav1.Object) ([]*v1.Issuer, error) {
 	issuers, err := c.issuerLister.List(labels.NewSelector())
 
 	if err != nil {
@@ -34,61 +34,67 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.Issuer, erro
 	var affected []*v1.Issuer
 	for _, iss := range issuers {
 		// only applicable for Issuer resources
-		if iss.Namespace != secret.Namespace {
+		if iss.Namespace != secret.GetNamespace() {
 			continue
 		}
 
 		switch {
 		case iss.Spec.ACME != nil:
-			if iss.Spec.ACME.PrivateKey.Name == secret.Name {
+			if iss.Spec.ACME.PrivateKey.Name == secret.GetName() {
 				affected = append(affected, iss)
 				continue
 			}
 			if iss.Spec.ACME.ExternalAccountBinding != nil {
-				if iss.Spec.ACME.ExternalAccountBinding.Key.Name == secret.Name {
+				if iss.Spec.ACME.ExternalAccountBinding.Key.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
 			}
 		case iss.Spec.CA != nil:
-			if iss.Spec.CA.SecretName == secret.Name {
+			if iss.Spec.CA.SecretName == secret.GetName() {
 				affected = append(affected, iss)
 				continue
 			}
+			if iss.Spec.CA.PKCS11 != nil {
+				if iss.Spec.CA.PKCS11.PINSecretRef.Name == secret.GetName() {
+					affected = append(affected, iss)
+					continue
+				}
+			}
 		case iss.Spec.Venafi != nil:
 			if iss.Spec.Venafi.TPP != nil {
-				if iss.Spec.Venafi.TPP.CredentialsRef.Name == secret.Name {
+				if iss.Spec.Venafi.TPP.CredentialsRef.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
 			}
 			if iss.Spec.Venafi.Cloud != nil {
-				if iss.Spec.Venafi.Cloud.APITokenSecretRef.Name == secret.Name {
+				if iss.Spec.Venafi.Cloud.APITokenSecretRef.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
 			}
 		case iss.Spec.Vault != nil:
 			if iss.Spec.Vault.Auth.TokenSecretRef != nil {
-				if iss.Spec.Vault.Auth.TokenSecretRef.Name == secret.Name {
+				if iss.Spec.Vault.Auth.TokenSecretRef.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
 			}
 			if iss.Spec.Vault.Auth.AppRole != nil {
-				if iss.Spec.Vault.Auth.AppRole.SecretRef.Name == secret.Name {
+				if iss.Spec.Vault.Auth.AppRole.SecretRef.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
 			}
 			if iss.Spec.Vault.Auth.Kubernetes != nil {
-				if iss.Spec.Vault.Auth.Kubernetes.SecretRef.Name == secret.Name {
+				if iss.Spec.Vault.Auth.Kubernetes.SecretRef.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
 			}
 			if iss.Spec.Vault.CABundleSecretRef != nil {
-				if iss.Spec.Vault.CABundleSecretRef.Name == secret.Name {
+				if iss.Spec.Vault.CABundleSecretRef.Name == secret.GetName() {
 					affected = append(affected, iss)
 					continue
 				}
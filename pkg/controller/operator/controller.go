@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package operator contains a controller which keeps cert-manager's own
+// CustomResourceDefinitions and webhook configurations in step with the
+// running version of cert-manager, so that upgrading cert-manager does not
+// require applying them separately.
+package operator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/cert-manager/cert-manager/deploy/crds"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// ControllerName is the name of the operator controller.
+	ControllerName = "operator"
+
+	// reconcileKey is the only key queued, as all resources are reconciled
+	// together.
+	reconcileKey = "cert-manager"
+
+	// resyncPeriod is how often the resources are reconciled, to undo
+	// changes made to them by others.
+	resyncPeriod = 10 * time.Minute
+)
+
+// webhookService identifies the cert-manager webhook. The webhook
+// configurations are named after its Service, and the CA of its serving
+// certificate is in the Secret "<name>-ca", as installed by the Helm chart.
+type webhookService struct {
+	namespace string
+	name      string
+	timeout   time.Duration
+}
+
+func (w webhookService) caSecret() string {
+	return w.namespace + "/" + w.name + "-ca"
+}
+
+type controller struct {
+	crdClient     apiextensionsclient.Interface
+	kubeClient    kubernetes.Interface
+	dynamicClient dynamic.Interface
+
+	crds    []*apiextensionsv1.CustomResourceDefinition
+	webhook webhookService
+}
+
+// ProcessItem reconciles the CustomResourceDefinitions and webhook
+// configurations to those of the running version.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	var errs []error
+	for _, crd := range c.crds {
+		if err := c.ensureCRD(ctx, crd); err != nil {
+			errs = append(errs, fmt.Errorf("CustomResourceDefinition %s: %w", crd.Name, err))
+		}
+	}
+	if err := c.ensureValidatingWebhookConfiguration(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("ValidatingWebhookConfiguration %s: %w", c.webhook.name, err))
+	}
+	if err := c.ensureMutatingWebhookConfiguration(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("MutatingWebhookConfiguration %s: %w", c.webhook.name, err))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+	queue workqueue.RateLimitingInterface
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	embedded, err := crds.CustomResourceDefinitions()
+	if err != nil {
+		return nil, nil, err
+	}
+	crdClient, err := apiextensionsclient.NewForConfig(ctx.RESTConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(ctx.RESTConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.controller = &controller{
+		crdClient:     crdClient,
+		kubeClient:    ctx.Client,
+		dynamicClient: dynamicClient,
+		crds:          embedded,
+		webhook: webhookService{
+			namespace: ctx.OperatorWebhookServiceNamespace,
+			name:      ctx.OperatorWebhookServiceName,
+			timeout:   ctx.OperatorWebhookTimeout,
+		},
+	}
+
+	c.queue = workqueue.NewNamedRateLimitingQueue(ctx.RateLimiterFor(ControllerName, time.Second*5, time.Minute*5), ControllerName)
+	c.queue.Add(reconcileKey)
+
+	return c.queue, nil, nil
+}
+
+// resync queues the resources to be reconciled.
+func (c *controllerWrapper) resync(ctx context.Context) {
+	logf.FromContext(ctx).V(logf.DebugLevel).Info("resyncing cert-manager resources")
+	c.queue.Add(reconcileKey)
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		c := &controllerWrapper{}
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(c).
+			With(c.resync, resyncPeriod).
+			Complete()
+	})
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// migrationPageSize is the number of resources listed at a time when
+// migrating resources to the storage version.
+const migrationPageSize = 500
+
+// ensureCRD creates or updates the CustomResourceDefinition to match the
+// embedded one, migrating resources stored in versions other than the
+// storage version. Versions which are still stored are only removed from the
+// CustomResourceDefinition once their resources have been migrated, as the
+// API server forbids removing them before.
+func (c *controller) ensureCRD(ctx context.Context, embedded *apiextensionsv1.CustomResourceDefinition) error {
+	log := logf.FromContext(ctx).WithValues("customresourcedefinition", embedded.Name)
+	desired := c.desiredCRD(embedded)
+
+	existing, err := c.crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, desired.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		log.Info("creating CustomResourceDefinition")
+		_, err := c.crdClient.ApiextensionsV1().CustomResourceDefinitions().Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	existing, err = c.updateCRD(ctx, existing, desired)
+	if err != nil {
+		return err
+	}
+
+	migrated, err := c.migrateStoredVersions(ctx, existing)
+	if err != nil || migrated == nil {
+		return err
+	}
+	// Remove the versions which were only kept until they were migrated.
+	_, err = c.updateCRD(ctx, migrated, desired)
+	return err
+}
+
+// desiredCRD returns the embedded CustomResourceDefinition, with its
+// conversion webhook pointing at the cert-manager webhook. Fields defaulted
+// by the API server are set so that unchanged CustomResourceDefinitions are
+// not updated.
+func (c *controller) desiredCRD(embedded *apiextensionsv1.CustomResourceDefinition) *apiextensionsv1.CustomResourceDefinition {
+	desired := embedded.DeepCopy()
+	if desired.Spec.Conversion == nil {
+		desired.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.NoneConverter}
+	}
+	if desired.Spec.Conversion.Strategy == apiextensionsv1.WebhookConverter {
+		if desired.Spec.Conversion.Webhook == nil {
+			desired.Spec.Conversion.Webhook = &apiextensionsv1.WebhookConversion{ConversionReviewVersions: []string{"v1"}}
+		}
+		desired.Spec.Conversion.Webhook.ClientConfig = &apiextensionsv1.WebhookClientConfig{
+			Service: &apiextensionsv1.ServiceReference{
+				Namespace: c.webhook.namespace,
+				Name:      c.webhook.name,
+				Path:      pointer.String("/convert"),
+				Port:      pointer.Int32(443),
+			},
+		}
+		metav1.SetMetaDataAnnotation(&desired.ObjectMeta, cmapi.WantInjectFromSecretAnnotation, c.webhook.caSecret())
+	}
+	metav1.SetMetaDataLabel(&desired.ObjectMeta, "app.kubernetes.io/name", "cert-manager")
+	return desired
+}
+
+// updateCRD updates the spec of existing to that of desired, keeping the
+// versions which are still stored and the CA bundle of the conversion webhook
+// injected by the cainjector.
+func (c *controller) updateCRD(ctx context.Context, existing, desired *apiextensionsv1.CustomResourceDefinition) (*apiextensionsv1.CustomResourceDefinition, error) {
+	updated := existing.DeepCopy()
+	updated.Spec = *desired.Spec.DeepCopy()
+
+	for _, stored := range existing.Status.StoredVersions {
+		if findVersion(updated.Spec.Versions, stored) != nil {
+			continue
+		}
+		if v := findVersion(existing.Spec.Versions, stored); v != nil {
+			kept := v.DeepCopy()
+			kept.Served, kept.Storage = false, false
+			updated.Spec.Versions = append(updated.Spec.Versions, *kept)
+		}
+	}
+
+	if conversion := updated.Spec.Conversion; conversion.Webhook != nil && conversion.Webhook.ClientConfig != nil {
+		if old := existing.Spec.Conversion; old != nil && old.Webhook != nil && old.Webhook.ClientConfig != nil {
+			conversion.Webhook.ClientConfig.CABundle = old.Webhook.ClientConfig.CABundle
+		}
+	}
+	for k, v := range desired.Annotations {
+		metav1.SetMetaDataAnnotation(&updated.ObjectMeta, k, v)
+	}
+	for k, v := range desired.Labels {
+		metav1.SetMetaDataLabel(&updated.ObjectMeta, k, v)
+	}
+
+	if equality.Semantic.DeepEqual(existing.Spec, updated.Spec) &&
+		equality.Semantic.DeepEqual(existing.Annotations, updated.Annotations) &&
+		equality.Semantic.DeepEqual(existing.Labels, updated.Labels) {
+		return existing, nil
+	}
+
+	logf.FromContext(ctx).Info("updating CustomResourceDefinition", "customresourcedefinition", existing.Name)
+	return c.crdClient.ApiextensionsV1().CustomResourceDefinitions().Update(ctx, updated, metav1.UpdateOptions{})
+}
+
+// migrateStoredVersions rewrites every resource of the CustomResourceDefinition
+// if versions other than the storage version may still be stored, so that
+// they are stored in the storage version, and then sets status.storedVersions
+// to the storage version. It returns the updated CustomResourceDefinition, or
+// nil if no migration was needed.
+func (c *controller) migrateStoredVersions(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) (*apiextensionsv1.CustomResourceDefinition, error) {
+	storage := storageVersion(crd)
+	stored := crd.Status.StoredVersions
+	if len(stored) == 0 || (len(stored) == 1 && stored[0] == storage) {
+		return nil, nil
+	}
+
+	log := logf.FromContext(ctx).WithValues("customresourcedefinition", crd.Name, "storageVersion", storage, "storedVersions", stored)
+	log.Info("migrating resources to the storage version")
+
+	client := c.dynamicClient.Resource(schema.GroupVersionResource{Group: crd.Spec.Group, Version: storage, Resource: crd.Spec.Names.Plural})
+	opts := metav1.ListOptions{Limit: migrationPageSize}
+	for {
+		list, err := client.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			// Writing the resource unchanged stores it in the storage version.
+			_, err := client.Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+			// Resources which were deleted or written by someone else no
+			// longer need migrating.
+			if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+				return nil, fmt.Errorf("failed to migrate %s %s/%s: %w", crd.Spec.Names.Kind, obj.GetNamespace(), obj.GetName(), err)
+			}
+		}
+		if opts.Continue = list.GetContinue(); opts.Continue == "" {
+			break
+		}
+	}
+
+	fresh, err := c.crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, crd.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// Resources may have been written in another version if the storage
+	// version was changed during the migration, in which case it must be
+	// run again.
+	if storageVersion(fresh) != storage {
+		return nil, fmt.Errorf("storage version changed from %q to %q during migration", storage, storageVersion(fresh))
+	}
+	fresh.Status.StoredVersions = []string{storage}
+	fresh, err = c.crdClient.ApiextensionsV1().CustomResourceDefinitions().UpdateStatus(ctx, fresh, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	log.Info("migrated resources to the storage version")
+	return fresh, nil
+}
+
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+func findVersion(versions []apiextensionsv1.CustomResourceDefinitionVersion, name string) *apiextensionsv1.CustomResourceDefinitionVersion {
+	for i := range versions {
+		if versions[i].Name == name {
+			return &versions[i]
+		}
+	}
+	return nil
+}
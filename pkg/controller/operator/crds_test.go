@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var widgets = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+func widgetCRD(storage string, versions ...string) *apiextensionsv1.CustomResourceDefinition {
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Kind: "Widget", ListKind: "WidgetList"},
+			Scope: apiextensionsv1.NamespaceScoped,
+		},
+	}
+	for _, v := range versions {
+		crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{
+			Name:    v,
+			Served:  true,
+			Storage: v == storage,
+		})
+	}
+	return crd
+}
+
+func widget(name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("example.com/v1")
+	u.SetKind("Widget")
+	u.SetNamespace("default")
+	u.SetName(name)
+	return u
+}
+
+func newTestController(crdObjs []runtime.Object, objs ...runtime.Object) *controller {
+	return &controller{
+		crdClient:  apiextensionsfake.NewSimpleClientset(crdObjs...),
+		kubeClient: kubefake.NewSimpleClientset(),
+		dynamicClient: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{widgets: "WidgetList"}, objs...),
+		webhook: webhookService{namespace: "cert-manager", name: "cert-manager-webhook", timeout: 10 * time.Second},
+	}
+}
+
+func TestEnsureCRDCreates(t *testing.T) {
+	c := newTestController(nil)
+	embedded := widgetCRD("v1", "v1")
+	embedded.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.WebhookConverter}
+
+	require.NoError(t, c.ensureCRD(context.TODO(), embedded))
+
+	crd, err := c.crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), embedded.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "cert-manager/cert-manager-webhook-ca", crd.Annotations[cmapi.WantInjectFromSecretAnnotation])
+	service := crd.Spec.Conversion.Webhook.ClientConfig.Service
+	assert.Equal(t, "cert-manager", service.Namespace)
+	assert.Equal(t, "cert-manager-webhook", service.Name)
+	assert.Equal(t, "/convert", *service.Path)
+}
+
+func TestEnsureCRDKeepsCABundle(t *testing.T) {
+	embedded := widgetCRD("v1", "v1")
+	embedded.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.WebhookConverter}
+	c := newTestController(nil)
+	require.NoError(t, c.ensureCRD(context.TODO(), embedded))
+
+	crds := c.crdClient.ApiextensionsV1().CustomResourceDefinitions()
+	crd, err := crds.Get(context.TODO(), embedded.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	crd.Spec.Conversion.Webhook.ClientConfig.CABundle = []byte("ca")
+	_, err = crds.Update(context.TODO(), crd, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	actions := len(c.crdClient.(*apiextensionsfake.Clientset).Actions())
+	require.NoError(t, c.ensureCRD(context.TODO(), embedded))
+	// Only the CustomResourceDefinition is read, as it is unchanged.
+	assert.Len(t, c.crdClient.(*apiextensionsfake.Clientset).Actions(), actions+1)
+
+	crd, err = crds.Get(context.TODO(), embedded.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ca"), crd.Spec.Conversion.Webhook.ClientConfig.CABundle)
+}
+
+func TestEnsureCRDMigratesStoredVersions(t *testing.T) {
+	existing := widgetCRD("v1alpha1", "v1alpha1")
+	existing.Status.StoredVersions = []string{"v1alpha1"}
+	c := newTestController([]runtime.Object{existing}, widget("a"), widget("b"))
+
+	require.NoError(t, c.ensureCRD(context.TODO(), widgetCRD("v1", "v1")))
+
+	crd, err := c.crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), existing.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1"}, crd.Status.StoredVersions)
+	if assert.Len(t, crd.Spec.Versions, 1) {
+		assert.Equal(t, "v1", crd.Spec.Versions[0].Name)
+	}
+
+	var updated []string
+	for _, action := range c.dynamicClient.(*dynamicfake.FakeDynamicClient).Actions() {
+		if action.GetVerb() == "update" {
+			updated = append(updated, action.(interface{ GetObject() runtime.Object }).GetObject().(*unstructured.Unstructured).GetName())
+		}
+	}
+	assert.ElementsMatch(t, []string{"a", "b"}, updated)
+}
+
+func TestEnsureCRDKeepsStoredVersionsUntilMigrated(t *testing.T) {
+	existing := widgetCRD("v1alpha1", "v1alpha1")
+	existing.Status.StoredVersions = []string{"v1alpha1"}
+	c := newTestController([]runtime.Object{existing})
+
+	updated, err := c.updateCRD(context.TODO(), existing, c.desiredCRD(widgetCRD("v1", "v1")))
+	require.NoError(t, err)
+
+	if assert.Len(t, updated.Spec.Versions, 2) {
+		assert.Equal(t, "v1alpha1", updated.Spec.Versions[1].Name)
+		assert.False(t, updated.Spec.Versions[1].Served)
+		assert.False(t, updated.Spec.Versions[1].Storage)
+	}
+}
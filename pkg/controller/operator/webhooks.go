@@ -0,0 +1,224 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// webhookName is the name of the single webhook in each webhook
+// configuration, as installed by the Helm chart.
+const webhookName = "webhook.cert-manager.io"
+
+// webhookRules returns the resources sent to the cert-manager webhook. Only
+// v1 resources are handled by the webhook; the Equivalent match policy
+// ensures requests for other versions are sent to it once converted to v1.
+func webhookRules() []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{"cert-manager.io", "acme.cert-manager.io"},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"*/*"},
+			Scope:       scopePtr(admissionregistrationv1.AllScopes),
+		},
+	}}
+}
+
+func (c *controller) webhookClientConfig(path string) admissionregistrationv1.WebhookClientConfig {
+	return admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{
+			Namespace: c.webhook.namespace,
+			Name:      c.webhook.name,
+			Path:      pointer.String(path),
+			Port:      pointer.Int32(443),
+		},
+	}
+}
+
+func (c *controller) webhookMeta() metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name: c.webhook.name,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":      "webhook",
+			"app.kubernetes.io/component": "webhook",
+		},
+		Annotations: map[string]string{
+			cmapi.WantInjectFromSecretAnnotation: c.webhook.caSecret(),
+		},
+	}
+}
+
+// desiredValidatingWebhookConfiguration mirrors the configuration installed
+// by the Helm chart, with the fields defaulted by the API server set so that
+// unchanged configurations are not updated.
+func (c *controller) desiredValidatingWebhookConfiguration() *admissionregistrationv1.ValidatingWebhookConfiguration {
+	return &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: c.webhookMeta(),
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+			Name: webhookName,
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "cert-manager.io/disable-validation", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"true"}},
+					{Key: "name", Operator: metav1.LabelSelectorOpNotIn, Values: []string{c.webhook.namespace}},
+				},
+			},
+			ObjectSelector:          &metav1.LabelSelector{},
+			Rules:                   webhookRules(),
+			AdmissionReviewVersions: []string{"v1"},
+			MatchPolicy:             matchPolicyPtr(admissionregistrationv1.Equivalent),
+			TimeoutSeconds:          pointer.Int32(int32(c.webhook.timeout.Seconds())),
+			FailurePolicy:           failurePolicyPtr(admissionregistrationv1.Fail),
+			SideEffects:             sideEffectsPtr(admissionregistrationv1.SideEffectClassNone),
+			ClientConfig:            c.webhookClientConfig("/validate"),
+		}},
+	}
+}
+
+// desiredMutatingWebhookConfiguration mirrors the configuration installed by
+// the Helm chart, with the fields defaulted by the API server set so that
+// unchanged configurations are not updated.
+func (c *controller) desiredMutatingWebhookConfiguration() *admissionregistrationv1.MutatingWebhookConfiguration {
+	return &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: c.webhookMeta(),
+		Webhooks: []admissionregistrationv1.MutatingWebhook{{
+			Name:                    webhookName,
+			NamespaceSelector:       &metav1.LabelSelector{},
+			ObjectSelector:          &metav1.LabelSelector{},
+			Rules:                   webhookRules(),
+			AdmissionReviewVersions: []string{"v1"},
+			MatchPolicy:             matchPolicyPtr(admissionregistrationv1.Equivalent),
+			TimeoutSeconds:          pointer.Int32(int32(c.webhook.timeout.Seconds())),
+			FailurePolicy:           failurePolicyPtr(admissionregistrationv1.Fail),
+			SideEffects:             sideEffectsPtr(admissionregistrationv1.SideEffectClassNone),
+			ReinvocationPolicy:      reinvocationPolicyPtr(admissionregistrationv1.NeverReinvocationPolicy),
+			ClientConfig:            c.webhookClientConfig("/mutate"),
+		}},
+	}
+}
+
+func (c *controller) ensureValidatingWebhookConfiguration(ctx context.Context) error {
+	client := c.kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+	desired := c.desiredValidatingWebhookConfiguration()
+
+	existing, err := client.Get(ctx, desired.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		logf.FromContext(ctx).Info("creating ValidatingWebhookConfiguration", "name", desired.Name)
+		_, err := client.Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	updated := existing.DeepCopy()
+	updated.Webhooks = desired.Webhooks
+	for i := range updated.Webhooks {
+		for _, old := range existing.Webhooks {
+			if old.Name == updated.Webhooks[i].Name {
+				updated.Webhooks[i].ClientConfig.CABundle = old.ClientConfig.CABundle
+			}
+		}
+	}
+	if !mergeMeta(&updated.ObjectMeta, desired.ObjectMeta) && equality.Semantic.DeepEqual(existing.Webhooks, updated.Webhooks) {
+		return nil
+	}
+
+	logf.FromContext(ctx).Info("updating ValidatingWebhookConfiguration", "name", desired.Name)
+	_, err = client.Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *controller) ensureMutatingWebhookConfiguration(ctx context.Context) error {
+	client := c.kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations()
+	desired := c.desiredMutatingWebhookConfiguration()
+
+	existing, err := client.Get(ctx, desired.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		logf.FromContext(ctx).Info("creating MutatingWebhookConfiguration", "name", desired.Name)
+		_, err := client.Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	updated := existing.DeepCopy()
+	updated.Webhooks = desired.Webhooks
+	for i := range updated.Webhooks {
+		for _, old := range existing.Webhooks {
+			if old.Name == updated.Webhooks[i].Name {
+				updated.Webhooks[i].ClientConfig.CABundle = old.ClientConfig.CABundle
+			}
+		}
+	}
+	if !mergeMeta(&updated.ObjectMeta, desired.ObjectMeta) && equality.Semantic.DeepEqual(existing.Webhooks, updated.Webhooks) {
+		return nil
+	}
+
+	logf.FromContext(ctx).Info("updating MutatingWebhookConfiguration", "name", desired.Name)
+	_, err = client.Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// mergeMeta sets the labels and annotations of desired on meta, keeping any
+// others, and returns whether meta was changed.
+func mergeMeta(meta *metav1.ObjectMeta, desired metav1.ObjectMeta) bool {
+	changed := false
+	for k, v := range desired.Labels {
+		if meta.Labels[k] != v {
+			metav1.SetMetaDataLabel(meta, k, v)
+			changed = true
+		}
+	}
+	for k, v := range desired.Annotations {
+		if meta.Annotations[k] != v {
+			metav1.SetMetaDataAnnotation(meta, k, v)
+			changed = true
+		}
+	}
+	return changed
+}
+
+func scopePtr(s admissionregistrationv1.ScopeType) *admissionregistrationv1.ScopeType {
+	return &s
+}
+
+func matchPolicyPtr(p admissionregistrationv1.MatchPolicyType) *admissionregistrationv1.MatchPolicyType {
+	return &p
+}
+
+func failurePolicyPtr(p admissionregistrationv1.FailurePolicyType) *admissionregistrationv1.FailurePolicyType {
+	return &p
+}
+
+func sideEffectsPtr(s admissionregistrationv1.SideEffectClass) *admissionregistrationv1.SideEffectClass {
+	return &s
+}
+
+func reinvocationPolicyPtr(p admissionregistrationv1.ReinvocationPolicyType) *admissionregistrationv1.ReinvocationPolicyType {
+	return &p
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestEnsureWebhookConfigurations(t *testing.T) {
+	c := newTestController(nil)
+	ctx := context.TODO()
+	client := c.kubeClient.AdmissionregistrationV1()
+
+	require.NoError(t, c.ensureValidatingWebhookConfiguration(ctx))
+	require.NoError(t, c.ensureMutatingWebhookConfiguration(ctx))
+
+	validating, err := client.ValidatingWebhookConfigurations().Get(ctx, "cert-manager-webhook", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "cert-manager/cert-manager-webhook-ca", validating.Annotations[cmapi.WantInjectFromSecretAnnotation])
+	require.Len(t, validating.Webhooks, 1)
+	assert.Equal(t, "/validate", *validating.Webhooks[0].ClientConfig.Service.Path)
+	assert.Equal(t, int32(10), *validating.Webhooks[0].TimeoutSeconds)
+	assert.Equal(t, []string{"cert-manager"}, validating.Webhooks[0].NamespaceSelector.MatchExpressions[1].Values)
+
+	mutating, err := client.MutatingWebhookConfigurations().Get(ctx, "cert-manager-webhook", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, mutating.Webhooks, 1)
+	assert.Equal(t, "/mutate", *mutating.Webhooks[0].ClientConfig.Service.Path)
+
+	// The CA bundle injected by the cainjector is kept, and the timeout is
+	// reverted.
+	validating.Webhooks[0].ClientConfig.CABundle = []byte("ca")
+	validating.Webhooks[0].TimeoutSeconds = nil
+	_, err = client.ValidatingWebhookConfigurations().Update(ctx, validating, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, c.ensureValidatingWebhookConfiguration(ctx))
+	validating, err = client.ValidatingWebhookConfigurations().Get(ctx, "cert-manager-webhook", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ca"), validating.Webhooks[0].ClientConfig.CABundle)
+	assert.Equal(t, int32(10), *validating.Webhooks[0].TimeoutSeconds)
+
+	// Unchanged configurations are not updated.
+	actions := len(c.kubeClient.(*kubefake.Clientset).Actions())
+	require.NoError(t, c.ensureValidatingWebhookConfiguration(ctx))
+	require.NoError(t, c.ensureMutatingWebhookConfiguration(ctx))
+	assert.Len(t, c.kubeClient.(*kubefake.Clientset).Actions(), actions+2)
+}
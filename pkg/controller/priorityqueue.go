@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+)
+
+var queueMetricsProvider workqueue.MetricsProvider
+
+// SetPriorityQueueMetricsProvider sets the provider of the metrics of the
+// queues created by NewPriorityRateLimitingQueue, the equivalent of
+// workqueue.SetProvider. It must be called before any queues are created.
+func SetPriorityQueueMetricsProvider(p workqueue.MetricsProvider) {
+	queueMetricsProvider = p
+}
+
+// NewPriorityRateLimitingQueue returns a rate limiting queue which hands out
+// the items for which isUrgent returns true before any other items, so that
+// they are not held up by a backlog of routine work. Whether an item is
+// urgent is decided when it is added to the queue, or re-added once it has
+// been processed.
+// As with the queues of client-go, an item is never processed by more than
+// one worker at a time. The given clock is used to measure the time items
+// spend queued and being processed.
+func NewPriorityRateLimitingQueue(rateLimiter workqueue.RateLimiter, name string, clock clock.Clock, isUrgent func(item interface{}) bool) workqueue.RateLimitingInterface {
+	q := newPriorityQueue(clock, name, isUrgent)
+	return workqueue.NewRateLimitingQueueWithDelayingInterface(workqueue.NewDelayingQueueWithCustomQueue(q, name), rateLimiter)
+}
+
+// priorityQueue implements workqueue.Interface with two FIFO lanes, the
+// urgent lane being emptied before the routine lane.
+type priorityQueue struct {
+	clock    clock.Clock
+	isUrgent func(item interface{}) bool
+
+	cond *sync.Cond
+
+	urgent  []interface{}
+	routine []interface{}
+
+	// dirty holds the items which need to be processed, along with the time
+	// at which they were added.
+	dirty map[interface{}]time.Time
+	// processing holds the items being processed, along with the time at
+	// which processing started. Items which are added again while being
+	// processed are only queued once they are done.
+	processing map[interface{}]time.Time
+
+	shuttingDown bool
+	drain        bool
+
+	depth        workqueue.GaugeMetric
+	adds         workqueue.CounterMetric
+	latency      workqueue.HistogramMetric
+	workDuration workqueue.HistogramMetric
+}
+
+func newPriorityQueue(clock clock.Clock, name string, isUrgent func(item interface{}) bool) *priorityQueue {
+	q := &priorityQueue{
+		clock:        clock,
+		isUrgent:     isUrgent,
+		cond:         sync.NewCond(&sync.Mutex{}),
+		dirty:        make(map[interface{}]time.Time),
+		processing:   make(map[interface{}]time.Time),
+		depth:        noopMetric{},
+		adds:         noopMetric{},
+		latency:      noopMetric{},
+		workDuration: noopMetric{},
+	}
+	if p := queueMetricsProvider; p != nil && name != "" {
+		q.depth = p.NewDepthMetric(name)
+		q.adds = p.NewAddsMetric(name)
+		q.latency = p.NewLatencyMetric(name)
+		q.workDuration = p.NewWorkDurationMetric(name)
+	}
+	return q
+}
+
+func (q *priorityQueue) Add(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+	if _, ok := q.dirty[item]; ok {
+		return
+	}
+
+	q.adds.Inc()
+	q.dirty[item] = q.clock.Now()
+	if _, ok := q.processing[item]; ok {
+		return
+	}
+	q.push(item)
+}
+
+// push appends the item to its lane. cond.L must be held.
+func (q *priorityQueue) push(item interface{}) {
+	q.depth.Inc()
+	if q.isUrgent(item) {
+		q.urgent = append(q.urgent, item)
+	} else {
+		q.routine = append(q.routine, item)
+	}
+	q.cond.Signal()
+}
+
+func (q *priorityQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return len(q.urgent) + len(q.routine)
+}
+
+func (q *priorityQueue) Get() (interface{}, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for len(q.urgent)+len(q.routine) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if len(q.urgent)+len(q.routine) == 0 {
+		return nil, true
+	}
+
+	var item interface{}
+	if len(q.urgent) > 0 {
+		item, q.urgent[0] = q.urgent[0], nil
+		q.urgent = q.urgent[1:]
+	} else {
+		item, q.routine[0] = q.routine[0], nil
+		q.routine = q.routine[1:]
+	}
+
+	now := q.clock.Now()
+	q.depth.Dec()
+	q.latency.Observe(now.Sub(q.dirty[item]).Seconds())
+	delete(q.dirty, item)
+	q.processing[item] = now
+	return item, false
+}
+
+func (q *priorityQueue) Done(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if start, ok := q.processing[item]; ok {
+		q.workDuration.Observe(q.clock.Since(start).Seconds())
+		delete(q.processing, item)
+	}
+	if _, ok := q.dirty[item]; ok {
+		q.push(item)
+	} else if len(q.processing) == 0 {
+		// Wake up ShutDownWithDrain.
+		q.cond.Broadcast()
+	}
+}
+
+func (q *priorityQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.drain = false
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+func (q *priorityQueue) ShutDownWithDrain() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.drain = true
+	q.shuttingDown = true
+	q.cond.Broadcast()
+	for len(q.processing) > 0 && q.drain {
+		q.cond.Wait()
+	}
+}
+
+func (q *priorityQueue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return q.shuttingDown
+}
+
+type noopMetric struct{}
+
+func (noopMetric) Inc()            {}
+func (noopMetric) Dec()            {}
+func (noopMetric) Observe(float64) {}
@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	q := newPriorityQueue(fakeclock.NewFakeClock(time.Now()), "", func(item interface{}) bool {
+		return strings.HasPrefix(item.(string), "urgent")
+	})
+
+	for _, item := range []string{"routine-1", "urgent-1", "routine-2", "urgent-2", "routine-1"} {
+		q.Add(item)
+	}
+	assert.Equal(t, 4, q.Len())
+
+	var order []interface{}
+	for i := 0; i < 4; i++ {
+		item, shutdown := q.Get()
+		assert.False(t, shutdown)
+		order = append(order, item)
+	}
+	assert.Equal(t, []interface{}{"urgent-1", "urgent-2", "routine-1", "routine-2"}, order)
+
+	// Items added while being processed are only handed out again once done.
+	q.Add("urgent-1")
+	assert.Equal(t, 0, q.Len())
+	q.Done("urgent-1")
+	assert.Equal(t, 1, q.Len())
+
+	q.ShutDown()
+	item, shutdown := q.Get()
+	assert.Equal(t, "urgent-1", item)
+	assert.False(t, shutdown)
+	_, shutdown = q.Get()
+	assert.True(t, shutdown)
+}
@@ -27,6 +27,18 @@ type Interface interface {
 	// This method should block until all workers have exited cleanly, thus
 	// allowing for graceful shutdown of control loops.
 	Run(workers int, stopCh <-chan struct{}) error
+
+	// Check returns an error if the controller has a worker which has
+	// stalled, and so will not reconcile any further resources.
+	Check() error
+
+	// Stats returns the state of the workers and queue of the controller,
+	// for debugging.
+	Stats() Stats
+
+	// HasSynced returns true once all of the informers used by the
+	// controller have synced.
+	HasSynced() bool
 }
 
 // Constructor is a function that creates a new control loop given a
@@ -34,7 +46,8 @@ type Interface interface {
 type Constructor func(ctx *ContextFactory) (Interface, error)
 
 var (
-	known = make(map[string]Constructor)
+	known   = make(map[string]Constructor)
+	sharded = make(map[string]bool)
 )
 
 // Known returns a map of the registered controller Constructors
@@ -46,3 +59,17 @@ func Known() map[string]Constructor {
 func Register(name string, fn Constructor) {
 	known[name] = fn
 }
+
+// RegisterSharded registers the constructor of a controller whose queue keys
+// are those of Certificates. When the Certificates are split between several
+// shards, each shard runs the controller for its own Certificates.
+func RegisterSharded(name string, fn Constructor) {
+	Register(name, fn)
+	sharded[name] = true
+}
+
+// IsSharded returns true if the named controller was registered using
+// RegisterSharded.
+func IsSharded(name string) bool {
+	return sharded[name]
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/metadata/metadatalister"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var secretsGVR = corev1.SchemeGroupVersion.WithResource("secrets")
+
+// managedSecretsSelector selects the Secrets which are managed by
+// cert-manager.
+var managedSecretsSelector = cmapi.PartOfCertManagerControllerLabelKey + "=true"
+
+// secretsFactory is a SharedInformerFactory which also caches the metadata of
+// all Secrets, for the controllers which only need the metadata of Secrets
+// (see SecretsMetadataInformer). When filtered, its Secret informer only
+// caches the Secrets managed by cert-manager.
+type secretsFactory struct {
+	kubeinformers.SharedInformerFactory
+
+	metadataFactory metadatainformer.SharedInformerFactory
+	// filteredFactory caches the Secrets managed by cert-manager. It is nil
+	// if no Secret is cached.
+	filteredFactory kubeinformers.SharedInformerFactory
+	// client reads the Secrets which are not cached.
+	client corev1client.SecretsGetter
+}
+
+// newMetadataOnlySecretsFactory returns a factory which only caches the
+// metadata of Secrets. Controllers read the data of Secrets with a
+// SecretGetter, which gets them from the API server, so the Secret informer of
+// the wrapped factory is not started.
+func newMetadataOnlySecretsFactory(factory kubeinformers.SharedInformerFactory, metadataFactory metadatainformer.SharedInformerFactory, client corev1client.SecretsGetter) kubeinformers.SharedInformerFactory {
+	return &secretsFactory{
+		SharedInformerFactory: factory,
+		metadataFactory:       metadataFactory,
+		client:                client,
+	}
+}
+
+// newFilteredSecretsFactory returns a factory whose Secret informer is the
+// one of filteredFactory, which must select managedSecretsSelector, and which
+// caches the metadata of all Secrets for the controllers which only need
+// their metadata.
+func newFilteredSecretsFactory(factory, filteredFactory kubeinformers.SharedInformerFactory, metadataFactory metadatainformer.SharedInformerFactory, client corev1client.SecretsGetter) kubeinformers.SharedInformerFactory {
+	return &secretsFactory{
+		SharedInformerFactory: factory,
+		metadataFactory:       metadataFactory,
+		filteredFactory:       filteredFactory,
+		client:                client,
+	}
+}
+
+// SecretsMetadataInformer returns the Secret informer to be used by
+// controllers which only need the metadata of Secrets, such as to requeue the
+// resources which reference them. When the MetadataOnlySecretInformers or
+// SecretsFilteredCaching feature gate is enabled, it only caches the metadata
+// of Secrets and its event handlers are given *metav1.PartialObjectMetadata.
+// Otherwise it is the Secret informer of the factory.
+func SecretsMetadataInformer(factory kubeinformers.SharedInformerFactory) cache.SharedIndexInformer {
+	if f, ok := factory.(*secretsFactory); ok {
+		return f.metadataFactory.ForResource(secretsGVR).Informer()
+	}
+	return factory.Core().V1().Secrets().Informer()
+}
+
+func (f *secretsFactory) Start(stopCh <-chan struct{}) {
+	f.SharedInformerFactory.Start(stopCh)
+	f.metadataFactory.Start(stopCh)
+	if f.filteredFactory != nil {
+		f.filteredFactory.Start(stopCh)
+	}
+}
+
+func (f *secretsFactory) WaitForCacheSync(stopCh <-chan struct{}) map[reflect.Type]bool {
+	synced := f.SharedInformerFactory.WaitForCacheSync(stopCh)
+	for gvr, ok := range f.metadataFactory.WaitForCacheSync(stopCh) {
+		if gvr == secretsGVR {
+			synced[reflect.TypeOf(&metav1.PartialObjectMetadata{})] = ok
+		}
+	}
+	if f.filteredFactory != nil {
+		for typ, ok := range f.filteredFactory.WaitForCacheSync(stopCh) {
+			synced[typ] = ok
+		}
+	}
+	return synced
+}
+
+func (f *secretsFactory) Core() coreinformers.Interface {
+	if f.filteredFactory == nil {
+		return f.SharedInformerFactory.Core()
+	}
+	return coreGroup{Interface: f.SharedInformerFactory.Core(), secrets: f.filteredFactory.Core().V1().Secrets()}
+}
+
+type coreGroup struct {
+	coreinformers.Interface
+	secrets corev1informers.SecretInformer
+}
+
+func (g coreGroup) V1() corev1informers.Interface {
+	return coreV1{Interface: g.Interface.V1(), secrets: g.secrets}
+}
+
+type coreV1 struct {
+	corev1informers.Interface
+	secrets corev1informers.SecretInformer
+}
+
+func (v coreV1) Secrets() corev1informers.SecretInformer {
+	return v.secrets
+}
+
+// SecretGetter reads Secrets for the controllers and issuers which need their
+// data. Secrets are read from the cache of the Secret informer when it holds
+// them. When the MetadataOnlySecretInformers or SecretsFilteredCaching feature
+// gate is enabled, Secrets which are not cached but which exist according to
+// the metadata informer are read from the API server. With
+// MetadataOnlySecretInformers, no Secret is cached, so the full Secret informer
+// is never started.
+// SecretGetter also implements corelisters.SecretLister, so that it can be
+// used wherever Secrets are read from a lister.
+type SecretGetter struct {
+	// lister is nil if no Secret is cached.
+	lister corelisters.SecretLister
+
+	// metadata and client are only set if not every Secret is cached.
+	metadata metadatalister.Lister
+	client   corev1client.SecretsGetter
+}
+
+var _ corelisters.SecretLister = &SecretGetter{}
+
+// NewSecretGetter returns a SecretGetter for the Secret informers of the
+// factory.
+func NewSecretGetter(factory kubeinformers.SharedInformerFactory) *SecretGetter {
+	f, ok := factory.(*secretsFactory)
+	if !ok {
+		return &SecretGetter{lister: factory.Core().V1().Secrets().Lister()}
+	}
+
+	getter := &SecretGetter{
+		metadata: metadatalister.New(f.metadataFactory.ForResource(secretsGVR).Informer().GetIndexer(), secretsGVR),
+		client:   f.client,
+	}
+	if f.filteredFactory != nil {
+		getter.lister = f.filteredFactory.Core().V1().Secrets().Lister()
+	}
+	return getter
+}
+
+// NewCachedSecretGetter returns a SecretGetter which only reads Secrets from
+// the lister.
+func NewCachedSecretGetter(lister corelisters.SecretLister) *SecretGetter {
+	return &SecretGetter{lister: lister}
+}
+
+// Get returns the Secret with the given name.
+func (g *SecretGetter) Get(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	if g.lister != nil {
+		secret, err := g.lister.Secrets(namespace).Get(name)
+		if g.client == nil || !apierrors.IsNotFound(err) {
+			return secret, err
+		}
+	}
+
+	// Only Secrets which are known to exist are read, so that looking up a
+	// missing Secret doesn't make a request. The resource version of the
+	// cached metadata is passed so that the API server may respond from its
+	// own cache.
+	meta, err := g.metadata.Namespace(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return g.client.Secrets(namespace).Get(ctx, name, metav1.GetOptions{ResourceVersion: meta.ResourceVersion})
+}
+
+// List implements corelisters.SecretLister. Secrets which are not cached are
+// read from the API server, so the selector should only match a few of them.
+func (g *SecretGetter) List(selector labels.Selector) ([]*corev1.Secret, error) {
+	return g.list(metav1.NamespaceAll, selector)
+}
+
+// Secrets implements corelisters.SecretLister. The Secrets it gets are read
+// with Get.
+func (g *SecretGetter) Secrets(namespace string) corelisters.SecretNamespaceLister {
+	return secretGetterNamespace{getter: g, namespace: namespace}
+}
+
+// ListMetadata returns the metadata of the Secrets with the given labels,
+// without reading any Secret from the API server.
+func (g *SecretGetter) ListMetadata(selector labels.Selector) ([]metav1.Object, error) {
+	var objs []metav1.Object
+	if g.metadata == nil {
+		secrets, err := g.lister.List(selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range secrets {
+			objs = append(objs, secret)
+		}
+		return objs, nil
+	}
+
+	metas, err := g.metadata.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, meta := range metas {
+		objs = append(objs, meta)
+	}
+	return objs, nil
+}
+
+func (g *SecretGetter) list(namespace string, selector labels.Selector) ([]*corev1.Secret, error) {
+	if g.metadata == nil {
+		return g.lister.Secrets(namespace).List(selector)
+	}
+
+	var metas []*metav1.PartialObjectMetadata
+	var err error
+	if namespace == metav1.NamespaceAll {
+		metas, err = g.metadata.List(selector)
+	} else {
+		metas, err = g.metadata.Namespace(namespace).List(selector)
+	}
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]*corev1.Secret, 0, len(metas))
+	for _, meta := range metas {
+		secret, err := g.Get(context.TODO(), meta.Namespace, meta.Name)
+		if apierrors.IsNotFound(err) {
+			// The Secret was deleted since it was cached.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+// secretGetterNamespace implements corelisters.SecretNamespaceLister with a
+// SecretGetter.
+type secretGetterNamespace struct {
+	getter    *SecretGetter
+	namespace string
+}
+
+func (n secretGetterNamespace) List(selector labels.Selector) ([]*corev1.Secret, error) {
+	return n.getter.list(n.namespace, selector)
+}
+
+func (n secretGetterNamespace) Get(name string) (*corev1.Secret, error) {
+	return n.getter.Get(context.TODO(), n.namespace, name)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/metadata/metadatalister"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestMetadataOnlySecretsFactory(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	scheme := metadatafake.NewTestScheme()
+	if err := metav1.AddMetaToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	metadataClient := metadatafake.NewSimpleMetadataClient(scheme, &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "test"},
+	})
+	kubeClient := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "test"},
+		Data:       map[string][]byte{"key": []byte("value")},
+	})
+
+	factory := newMetadataOnlySecretsFactory(
+		kubeinformers.NewSharedInformerFactory(kubeClient, 0),
+		metadatainformer.NewSharedInformerFactory(metadataClient, 0),
+		kubeClient.CoreV1(),
+	)
+	informer := SecretsMetadataInformer(factory)
+	getter := NewSecretGetter(factory)
+
+	factory.Start(stopCh)
+	for typ, synced := range factory.WaitForCacheSync(stopCh) {
+		assert.True(t, synced, "expected %v informer to sync", typ)
+	}
+
+	objs := informer.GetStore().List()
+	if assert.Len(t, objs, 1) {
+		assert.IsType(t, &metav1.PartialObjectMetadata{}, objs[0])
+	}
+
+	// The data of Secrets is read from the API server, and the full Secret
+	// informer is never started.
+	secret, err := getter.Get(context.Background(), "test", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), secret.Data["key"])
+	_, err = getter.Get(context.Background(), "test", "missing")
+	assert.True(t, apierrors.IsNotFound(err), "expected missing Secret to be not found, got %v", err)
+	actions := kubeClient.Actions()
+	if assert.Len(t, actions, 1, "expected only the existing Secret to be read from the API server") {
+		assert.Equal(t, "get", actions[0].GetVerb())
+	}
+}
+
+func TestSecretGetter(t *testing.T) {
+	managed := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "managed", Labels: map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}},
+		Data:       map[string][]byte{"key": []byte("managed")},
+	}
+	unmanaged := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "unmanaged"},
+		Data:       map[string][]byte{"key": []byte("unmanaged")},
+	}
+
+	managedIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := managedIndexer.Add(managed); err != nil {
+		t.Fatal(err)
+	}
+	metadataIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, s := range []*corev1.Secret{managed, unmanaged} {
+		if err := metadataIndexer.Add(&metav1.PartialObjectMetadata{ObjectMeta: s.ObjectMeta}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client := fake.NewSimpleClientset(managed, unmanaged)
+	getter := &SecretGetter{
+		lister:   corelisters.NewSecretLister(managedIndexer),
+		metadata: metadatalister.New(metadataIndexer, secretsGVR),
+		client:   client.CoreV1(),
+	}
+
+	got, err := getter.Get(context.Background(), "test", "managed")
+	assert.NoError(t, err)
+	assert.Equal(t, managed.Data, got.Data)
+	assert.Empty(t, client.Actions(), "expected managed Secret to be read from the cache")
+
+	got, err = getter.Get(context.Background(), "test", "unmanaged")
+	assert.NoError(t, err)
+	assert.Equal(t, unmanaged.Data, got.Data)
+	assert.Len(t, client.Actions(), 1, "expected unmanaged Secret to be read from the API server")
+
+	_, err = getter.Get(context.Background(), "test", "missing")
+	assert.True(t, apierrors.IsNotFound(err), "expected missing Secret to be not found, got %v", err)
+	assert.Len(t, client.Actions(), 1, "expected missing Secret not to be read from the API server")
+
+	// Without a filtered cache, Secrets are only read from the cache.
+	getter = NewCachedSecretGetter(corelisters.NewSecretLister(managedIndexer))
+	_, err = getter.Get(context.Background(), "test", "unmanaged")
+	assert.True(t, apierrors.IsNotFound(err), "expected Secret missing from the cache to be not found, got %v", err)
+	assert.Len(t, client.Actions(), 1, "expected Secret missing from the cache not to be read from the API server")
+}
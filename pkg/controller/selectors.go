@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"hash/fnv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// selectsEverything returns true if selector is nil or matches all labels.
+func selectsEverything(selector labels.Selector) bool {
+	return selector == nil || selector.Empty()
+}
+
+// MatchesWatchLabelSelector returns true if the labels of a resource created
+// by users, such as an Ingress, match the WatchLabelSelector. cert-manager
+// resources which do not match are already filtered by their informers.
+func (o ContextOptions) MatchesWatchLabelSelector(objLabels map[string]string) bool {
+	return selectsEverything(o.WatchLabelSelector) || o.WatchLabelSelector.Matches(labels.Set(objLabels))
+}
+
+// filterByNamespace wraps processItem so that keys of resources in
+// namespaces whose labels do not match selector are dropped. Keys of
+// cluster scoped resources are always processed.
+func filterByNamespace(namespaceLister corelisters.NamespaceLister, selector labels.Selector, processItem func(context.Context, string) error) func(context.Context, string) error {
+	return func(ctx context.Context, key string) error {
+		namespace, _, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil || namespace == "" {
+			return processItem(ctx, key)
+		}
+
+		ns, err := namespaceLister.Get(namespace)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !selector.Matches(labels.Set(ns.Labels)) {
+			logf.FromContext(ctx).V(logf.DebugLevel).Info("skipping item in namespace which does not match the namespace selector", "key", key)
+			return nil
+		}
+		return processItem(ctx, key)
+	}
+}
+
+// ShardForKey returns the shard, between 0 and shards-1, which owns the
+// resource with the given key. Jump consistent hashing is used so that only
+// about 1/shards of the resources move to another shard when a shard is
+// added.
+func ShardForKey(key string, shards int) int {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	hash := h.Sum64()
+
+	b, j := int64(-1), int64(0)
+	for j < int64(shards) {
+		b = j
+		hash = hash*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((hash>>33)+1)))
+	}
+	return int(b)
+}
+
+// filterByShard wraps processItem so that keys of resources owned by other
+// shards are dropped.
+func filterByShard(shards, shard int, processItem func(context.Context, string) error) func(context.Context, string) error {
+	return func(ctx context.Context, key string) error {
+		if ShardForKey(key, shards) != shard {
+			return nil
+		}
+		return processItem(ctx, key)
+	}
+}
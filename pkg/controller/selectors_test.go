@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestFilterByNamespace(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ns := range []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Labels: map[string]string{"shard": "a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "tenant-b", Labels: map[string]string{"shard": "b"}}},
+	} {
+		if err := indexer.Add(ns); err != nil {
+			t.Fatal(err)
+		}
+	}
+	selector, err := labels.Parse("shard=a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		key       string
+		processed bool
+	}{
+		"resource in a matching namespace is processed": {
+			key:       "tenant-a/test",
+			processed: true,
+		},
+		"resource in a namespace which does not match is skipped": {
+			key:       "tenant-b/test",
+			processed: false,
+		},
+		"resource in a namespace which does not exist is skipped": {
+			key:       "tenant-c/test",
+			processed: false,
+		},
+		"cluster scoped resource is processed": {
+			key:       "test",
+			processed: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			processed := false
+			processItem := filterByNamespace(corelisters.NewNamespaceLister(indexer), selector, func(_ context.Context, key string) error {
+				processed = true
+				return nil
+			})
+			if err := processItem(context.TODO(), test.key); err != nil {
+				t.Fatal(err)
+			}
+			if processed != test.processed {
+				t.Errorf("expected processed=%t, got %t", test.processed, processed)
+			}
+		})
+	}
+}
+
+func TestMatchesWatchLabelSelector(t *testing.T) {
+	selector, err := labels.Parse("shard=a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !(ContextOptions{}).MatchesWatchLabelSelector(nil) {
+		t.Errorf("expected all resources to match when no selector is set")
+	}
+	opts := ContextOptions{WatchLabelSelector: selector}
+	if !opts.MatchesWatchLabelSelector(map[string]string{"shard": "a"}) {
+		t.Errorf("expected resource with matching labels to match")
+	}
+	if opts.MatchesWatchLabelSelector(map[string]string{"shard": "b"}) {
+		t.Errorf("expected resource with other labels not to match")
+	}
+}
+
+func TestShardForKey(t *testing.T) {
+	const keys = 10000
+	counts := make([]int, 4)
+	moved := 0
+	for i := 0; i < keys; i++ {
+		key := fmt.Sprintf("namespace-%d/certificate-%d", i%100, i)
+		shard := ShardForKey(key, 4)
+		if shard != ShardForKey(key, 4) {
+			t.Fatalf("expected shard of %q to be deterministic", key)
+		}
+		counts[shard]++
+
+		// Adding a shard only moves keys to the new shard.
+		if next := ShardForKey(key, 5); next != shard {
+			if next != 4 {
+				t.Errorf("expected %q to move from shard %d to the new shard, got %d", key, shard, next)
+			}
+			moved++
+		}
+	}
+	for shard, count := range counts {
+		if count < keys/4*9/10 || count > keys/4*11/10 {
+			t.Errorf("expected about %d keys in shard %d, got %d", keys/4, shard, count)
+		}
+	}
+	if moved < keys/5*9/10 || moved > keys/5*11/10 {
+		t.Errorf("expected about %d keys to move to the new shard, got %d", keys/5, moved)
+	}
+	if shard := ShardForKey("test/test", 1); shard != 0 {
+		t.Errorf("expected a single shard to own all keys, got %d", shard)
+	}
+}
+
+func TestFilterByShard(t *testing.T) {
+	var processed []string
+	processItem := filterByShard(3, 1, func(_ context.Context, key string) error {
+		processed = append(processed, key)
+		return nil
+	})
+
+	var expected []string
+	for i := 0; i < 30; i++ {
+		key := fmt.Sprintf("test/certificate-%d", i)
+		if ShardForKey(key, 3) == 1 {
+			expected = append(expected, key)
+		}
+		if err := processItem(context.TODO(), key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(expected) == 0 || !reflect.DeepEqual(expected, processed) {
+		t.Errorf("expected only keys of shard 1 to be processed, got %v, want %v", processed, expected)
+	}
+}
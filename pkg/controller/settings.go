@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// defaultWorkers is the number of workers of controllers if Workers is not
+// set.
+const defaultWorkers = 5
+
+// WorkersFor returns the number of items the named controller processes
+// concurrently.
+func (o ContextOptions) WorkersFor(name string) int {
+	if workers := o.Controllers[name].Workers; workers != nil {
+		return *workers
+	}
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return defaultWorkers
+}
+
+// RateLimiterFor returns the workqueue rate limiter of the named controller.
+// Failed items are retried with an exponential backoff between baseDelay and
+// maxDelay, unless the delays are overridden in the controller's settings.
+func (o ContextOptions) RateLimiterFor(name string, baseDelay, maxDelay time.Duration) workqueue.RateLimiter {
+	settings := o.Controllers[name].RateLimiter
+	if settings == nil {
+		return workqueue.NewItemExponentialFailureRateLimiter(baseDelay, maxDelay)
+	}
+
+	if settings.BaseDelay != nil {
+		baseDelay = settings.BaseDelay.Duration
+	}
+	if settings.MaxDelay != nil {
+		maxDelay = settings.MaxDelay.Duration
+	}
+	limiter := workqueue.NewItemExponentialFailureRateLimiter(baseDelay, maxDelay)
+	if settings.QPS == nil || settings.Burst == nil {
+		return limiter
+	}
+	return workqueue.NewMaxOfRateLimiter(limiter,
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(*settings.QPS), *settings.Burst)},
+	)
+}
+
+// DefaultRateLimiterFor returns the workqueue rate limiter of the named
+// controller, with the delays of DefaultItemBasedRateLimiter unless they
+// are overridden in the controller's settings.
+func (o ContextOptions) DefaultRateLimiterFor(name string) workqueue.RateLimiter {
+	return o.RateLimiterFor(name, time.Second*5, time.Minute*5)
+}
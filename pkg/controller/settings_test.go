@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	controllerconfig "github.com/cert-manager/cert-manager/internal/apis/config/controller"
+)
+
+func TestWorkersFor(t *testing.T) {
+	opts := ContextOptions{
+		Workers: 3,
+		Controllers: map[string]controllerconfig.ControllerSettings{
+			"tuned":   {Workers: pointer.Int(10)},
+			"untuned": {},
+		},
+	}
+
+	if workers := opts.WorkersFor("tuned"); workers != 10 {
+		t.Errorf("expected 10 workers for the tuned controller, got %d", workers)
+	}
+	if workers := opts.WorkersFor("untuned"); workers != 3 {
+		t.Errorf("expected 3 workers for the untuned controller, got %d", workers)
+	}
+	if workers := (ContextOptions{}).WorkersFor("other"); workers != defaultWorkers {
+		t.Errorf("expected %d workers when unset, got %d", defaultWorkers, workers)
+	}
+}
+
+func TestRateLimiterFor(t *testing.T) {
+	opts := ContextOptions{
+		Controllers: map[string]controllerconfig.ControllerSettings{
+			"tuned": {RateLimiter: &controllerconfig.RateLimiterConfiguration{
+				MaxDelay: &metav1.Duration{Duration: time.Second * 4},
+			}},
+			"bucket": {RateLimiter: &controllerconfig.RateLimiterConfiguration{
+				QPS:   pointer.Float32(1),
+				Burst: pointer.Int(1),
+			}},
+		},
+	}
+
+	// getDelays returns the delays of the first n retries of an item.
+	getDelays := func(name string, n int) []time.Duration {
+		limiter := opts.RateLimiterFor(name, time.Second, time.Minute)
+		var delays []time.Duration
+		for i := 0; i < n; i++ {
+			delays = append(delays, limiter.When("item"))
+		}
+		return delays
+	}
+
+	assertDelays := func(t *testing.T, exp, got []time.Duration) {
+		t.Helper()
+		if len(exp) != len(got) {
+			t.Fatalf("expected delays %v, got %v", exp, got)
+		}
+		for i := range exp {
+			if exp[i] != got[i] {
+				t.Fatalf("expected delays %v, got %v", exp, got)
+			}
+		}
+	}
+
+	t.Run("controllers without settings use the given delays", func(t *testing.T) {
+		assertDelays(t, []time.Duration{time.Second, time.Second * 2, time.Second * 4}, getDelays("untuned", 3))
+	})
+	t.Run("delays are overridden by the controller settings", func(t *testing.T) {
+		assertDelays(t, []time.Duration{time.Second, time.Second * 2, time.Second * 4, time.Second * 4}, getDelays("tuned", 4))
+	})
+	t.Run("the overall rate is limited if qps is set", func(t *testing.T) {
+		limiter := opts.RateLimiterFor("bucket", time.Millisecond, time.Millisecond)
+		limiter.When("a")
+		// the second item exceeds the burst, and has to wait for the bucket
+		// to refill rather than for its own backoff
+		if delay := limiter.When("b"); delay < time.Millisecond*500 {
+			t.Errorf("expected the second item to wait for the bucket to refill, got %v", delay)
+		}
+	})
+}
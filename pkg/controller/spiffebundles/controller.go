@@ -0,0 +1,450 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spiffebundles
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	jose "gopkg.in/square/go-jose.v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	ControllerName = "spiffebundles"
+
+	// TrustDomainLabelKey is the label of the trust bundle ConfigMaps which
+	// holds their trust domain.
+	TrustDomainLabelKey = "cert-manager.io/spiffe-trust-domain"
+
+	// CAKey is the key of the ConfigMap which holds the PEM encoded trust
+	// anchors.
+	CAKey = "ca.crt"
+	// BundleKey is the key of the ConfigMap which holds the trust bundle in
+	// the SPIFFE bundle format, a JWK set.
+	BundleKey = "bundle.spiffe"
+
+	reasonBundleError = "SPIFFEBundleError"
+)
+
+// This controller publishes the trust bundle of each SPIFFE trust domain of
+// CA issuers in SPIFFE mode to the ConfigMap `spiffe-bundle-<trust domain>`.
+// The trust bundle holds the CA certificates of all issuers of the trust
+// domain in a namespace, including ClusterIssuers in the cluster resource
+// namespace, so that SVIDs remain trusted while a trust domain is moved to a
+// new CA. Trust domains are queued by the key `<namespace>/<trust domain>`.
+// The ConfigMap is owned by the issuers of the trust domain, and is deleted
+// once no issuer uses the trust domain. The ConfigMap is not watched: changes
+// made to it are overwritten the next time the trust bundle changes.
+type controller struct {
+	log                 logr.Logger
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	secretLister        corelisters.SecretLister
+	kubeClient          kubernetes.Interface
+	recorder            record.EventRecorder
+	queue               workqueue.RateLimitingInterface
+
+	clusterResourceNamespace string
+}
+
+func NewController(
+	log logr.Logger,
+	kubeClient kubernetes.Interface,
+	factory kubeinformers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	issuerOptions controllerpkg.IssuerOptions,
+	namespace string,
+	rateLimiter workqueue.RateLimiter,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, ControllerName)
+
+	// obtain references to all the informers used by this controller
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	secretInformer := controllerpkg.SecretsMetadataInformer(factory)
+
+	c := &controller{
+		log:                      log,
+		issuerLister:             issuerInformer.Lister(),
+		secretLister:             controllerpkg.NewSecretGetter(factory),
+		kubeClient:               kubeClient,
+		recorder:                 recorder,
+		queue:                    queue,
+		clusterResourceNamespace: issuerOptions.ClusterResourceNamespace,
+	}
+
+	// Both the old and the new trust domain of an updated issuer are queued,
+	// so that the issuer is removed from the bundle of its old trust domain.
+	issuerHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleIssuer,
+		UpdateFunc: func(old, new interface{}) {
+			c.handleIssuer(old)
+			c.handleIssuer(new)
+		},
+		DeleteFunc: c.handleIssuer,
+	}
+	issuerInformer.Informer().AddEventHandler(issuerHandler)
+	secretInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleSecret})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		issuerInformer.Informer().HasSynced,
+		secretInformer.HasSynced,
+	}
+
+	// ClusterIssuers are only watched if we are not scoped to a single
+	// namespace.
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		c.clusterIssuerLister = clusterIssuerInformer.Lister()
+		clusterIssuerInformer.Informer().AddEventHandler(issuerHandler)
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	return c, queue, mustSync
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+	namespace, trustDomain, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil || namespace == "" {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	issuers, err := c.trustDomainIssuers(namespace, trustDomain)
+	if err != nil {
+		return err
+	}
+
+	name := pki.SPIFFETrustBundleConfigMapName(trustDomain)
+	configMap, err := c.kubeClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		configMap = nil
+	} else if err != nil {
+		return err
+	}
+
+	if len(issuers) == 0 {
+		// Only delete ConfigMaps which were created by this controller.
+		if configMap == nil || configMap.Labels[TrustDomainLabelKey] != trustDomain {
+			return nil
+		}
+		log.V(logf.DebugLevel).Info("deleting trust bundle of unused trust domain")
+		err := c.kubeClient.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	var anchors []*x509.Certificate
+	var owners []metav1.OwnerReference
+	for _, iss := range issuers {
+		kind := cmapi.IssuerKind
+		if iss.GetObjectMeta().Namespace == "" {
+			kind = cmapi.ClusterIssuerKind
+		}
+		owners = append(owners, metav1.OwnerReference{
+			APIVersion: cmapi.SchemeGroupVersion.String(),
+			Kind:       kind,
+			Name:       iss.GetObjectMeta().Name,
+			UID:        iss.GetObjectMeta().UID,
+		})
+
+		certs, err := c.trustAnchors(namespace, iss.GetSpec().CA.SecretName)
+		if apierrors.IsNotFound(err) {
+			// The trust domain is queued again once the Secret is created.
+			log.V(logf.DebugLevel).Info("CA secret not found", "secret", iss.GetSpec().CA.SecretName)
+			continue
+		}
+		if err != nil {
+			c.recorder.Eventf(iss, corev1.EventTypeWarning, reasonBundleError, "Failed to read the CA from Secret %q: %v", iss.GetSpec().CA.SecretName, err)
+			continue
+		}
+		anchors = appendNewCertificates(anchors, certs...)
+	}
+	if len(anchors) == 0 {
+		// Never publish an empty trust bundle, which would make workloads
+		// reject all SVIDs of the trust domain.
+		log.V(logf.DebugLevel).Info("no trust anchors found for trust domain")
+		return nil
+	}
+
+	caPEM, bundle, err := encodeTrustBundle(anchors)
+	if err != nil {
+		return err
+	}
+	data := map[string]string{CAKey: string(caPEM), BundleKey: string(bundle)}
+
+	if configMap == nil {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{TrustDomainLabelKey: trustDomain},
+				// The ConfigMap is garbage collected with the last issuer.
+				OwnerReferences: owners,
+			},
+			Data: data,
+		}
+		_, err := c.kubeClient.CoreV1().ConfigMaps(namespace).Create(ctx, configMap, metav1.CreateOptions{})
+		return err
+	}
+
+	if reflect.DeepEqual(configMap.Data, data) && reflect.DeepEqual(configMap.OwnerReferences, owners) &&
+		configMap.Labels[TrustDomainLabelKey] == trustDomain {
+		log.V(logf.DebugLevel).Info("trust bundle is up to date")
+		return nil
+	}
+
+	configMap = configMap.DeepCopy()
+	if configMap.Labels == nil {
+		configMap.Labels = make(map[string]string)
+	}
+	configMap.Labels[TrustDomainLabelKey] = trustDomain
+	configMap.OwnerReferences = owners
+	configMap.Data = data
+	_, err = c.kubeClient.CoreV1().ConfigMaps(namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	return err
+}
+
+// trustDomainIssuers returns the CA issuers in SPIFFE mode whose resources
+// are in the namespace and which issue SVIDs of the trust domain, sorted so
+// that the trust bundle does not change with the order of the listers.
+func (c *controller) trustDomainIssuers(namespace, trustDomain string) ([]cmapi.GenericIssuer, error) {
+	var issuers []cmapi.GenericIssuer
+	list, err := c.issuerLister.Issuers(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, iss := range list {
+		issuers = append(issuers, iss)
+	}
+	if c.clusterIssuerLister != nil && namespace == c.clusterResourceNamespace {
+		list, err := c.clusterIssuerLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, iss := range list {
+			issuers = append(issuers, iss)
+		}
+	}
+
+	var matching []cmapi.GenericIssuer
+	for _, iss := range issuers {
+		if ca := iss.GetSpec().CA; ca != nil && ca.SPIFFE != nil && ca.SPIFFE.TrustDomain == trustDomain {
+			matching = append(matching, iss)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		mi, mj := matching[i].GetObjectMeta(), matching[j].GetObjectMeta()
+		if mi.Namespace != mj.Namespace {
+			return mi.Namespace < mj.Namespace
+		}
+		return mi.Name < mj.Name
+	})
+	return matching, nil
+}
+
+// trustAnchors returns the certificates an issuer's SVIDs are verified with:
+// the certificates in the `ca.crt` key of the CA Secret if it has any, or
+// else the signing CA certificate itself.
+func (c *controller) trustAnchors(namespace, secretName string) ([]*x509.Certificate, error) {
+	secret, err := c.secretLister.Secrets(namespace).Get(secretName)
+	if err != nil {
+		return nil, err
+	}
+	if caPEM := secret.Data[cmmeta.TLSCAKey]; len(caPEM) > 0 {
+		return pki.DecodeX509CertificateChainBytes(caPEM)
+	}
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+	return []*x509.Certificate{cert}, nil
+}
+
+// appendNewCertificates appends the certificates which are not already in
+// certs.
+func appendNewCertificates(certs []*x509.Certificate, add ...*x509.Certificate) []*x509.Certificate {
+	for _, cert := range add {
+		found := false
+		for _, existing := range certs {
+			if bytes.Equal(existing.Raw, cert.Raw) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			certs = append(certs, cert)
+		}
+	}
+	return certs
+}
+
+// spiffeBundle is a SPIFFE trust bundle, as defined by the SPIFFE Trust
+// Domain and Bundle specification.
+type spiffeBundle struct {
+	Keys []jose.JSONWebKey `json:"keys"`
+}
+
+// encodeTrustBundle returns the trust anchors as PEM, and as a SPIFFE
+// bundle holding one X.509-SVID authority per trust anchor.
+func encodeTrustBundle(anchors []*x509.Certificate) ([]byte, []byte, error) {
+	var caPEM []byte
+	bundle := spiffeBundle{Keys: []jose.JSONWebKey{}}
+	for _, cert := range anchors {
+		certPEM, err := pki.EncodeX509(cert)
+		if err != nil {
+			return nil, nil, err
+		}
+		caPEM = append(caPEM, certPEM...)
+		bundle.Keys = append(bundle.Keys, jose.JSONWebKey{
+			Key:          cert.PublicKey,
+			Certificates: []*x509.Certificate{cert},
+			Use:          "x509-svid",
+		})
+	}
+	bundleJSON, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error encoding SPIFFE bundle: %w", err)
+	}
+	return caPEM, bundleJSON, nil
+}
+
+// handleIssuer queues the trust domain of a CA issuer in SPIFFE mode.
+func (c *controller) handleIssuer(obj interface{}) {
+	log := c.log.WithName("handleIssuer")
+
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	iss, ok := obj.(cmapi.GenericIssuer)
+	if !ok {
+		log.Error(nil, "object is not an issuer")
+		return
+	}
+
+	ca := iss.GetSpec().CA
+	if ca == nil || ca.SPIFFE == nil {
+		return
+	}
+	namespace := iss.GetObjectMeta().Namespace
+	if namespace == "" {
+		namespace = c.clusterResourceNamespace
+	}
+	c.queue.Add(namespace + "/" + ca.SPIFFE.TrustDomain)
+}
+
+// handleSecret queues the trust domains of the CA issuers whose CA is stored
+// in a Secret.
+func (c *controller) handleSecret(obj interface{}) {
+	log := c.log.WithName("handleSecret")
+
+	secret, ok := obj.(metav1.Object)
+	if !ok {
+		log.Error(nil, "object does not implement metav1.Object")
+		return
+	}
+
+	var issuers []cmapi.GenericIssuer
+	list, err := c.issuerLister.Issuers(secret.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		log.Error(err, "error listing issuers")
+		return
+	}
+	for _, iss := range list {
+		issuers = append(issuers, iss)
+	}
+	if c.clusterIssuerLister != nil && secret.GetNamespace() == c.clusterResourceNamespace {
+		list, err := c.clusterIssuerLister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "error listing clusterissuers")
+			return
+		}
+		for _, iss := range list {
+			issuers = append(issuers, iss)
+		}
+	}
+
+	for _, iss := range issuers {
+		if ca := iss.GetSpec().CA; ca != nil && ca.SPIFFE != nil && ca.SecretName == secret.GetName() {
+			c.queue.Add(secret.GetNamespace() + "/" + ca.SPIFFE.TrustDomain)
+		}
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.Client,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.IssuerOptions,
+		ctx.Namespace,
+		ctx.RateLimiterFor(ControllerName, time.Second*1, time.Second*30),
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
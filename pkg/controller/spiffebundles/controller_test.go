@@ -0,0 +1,363 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spiffebundles
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// caSecret returns a Secret holding a self signed CA.
+func caSecret(t *testing.T, namespace, name string) (*corev1.Secret, *x509.Certificate) {
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	certPEM, cert, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodePKCS8PrivateKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	}, cert
+}
+
+func Test_controller_ProcessItem(t *testing.T) {
+	secret, caCert := caSecret(t, "testns", "ca")
+	newSecret, newCACert := caSecret(t, "testns", "new-ca")
+	clusterSecret := secret.DeepCopy()
+	clusterSecret.Namespace = "cert-manager"
+	// A Secret whose ca.crt holds the root of the CA in tls.crt.
+	intermediateSecret := newSecret.DeepCopy()
+	intermediateSecret.Name = "intermediate-ca"
+	intermediateSecret.Data[cmmeta.TLSCAKey] = secret.Data[corev1.TLSCertKey]
+
+	spiffe := &cmapi.CASPIFFE{TrustDomain: "example.org"}
+	issuer := gen.Issuer("ca-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", SPIFFE: spiffe}),
+	)
+	issuer.UID = "issuer-uid"
+	newIssuer := gen.Issuer("new-ca-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "new-ca", SPIFFE: spiffe}),
+	)
+	newIssuer.UID = "new-issuer-uid"
+	intermediateIssuer := gen.Issuer("ca-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "intermediate-ca", SPIFFE: spiffe}),
+	)
+	intermediateIssuer.UID = "issuer-uid"
+	otherTrustDomainIssuer := gen.Issuer("other-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "new-ca", SPIFFE: &cmapi.CASPIFFE{TrustDomain: "other.org"}}),
+	)
+	issuerWithoutSPIFFE := gen.Issuer("plain-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "new-ca"}),
+	)
+	clusterIssuer := gen.ClusterIssuer("ca-issuer",
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", SPIFFE: spiffe}),
+	)
+	clusterIssuer.UID = "clusterissuer-uid"
+
+	issuerOwner := metav1.OwnerReference{APIVersion: "cert-manager.io/v1", Kind: "Issuer", Name: "ca-issuer", UID: "issuer-uid"}
+	newIssuerOwner := metav1.OwnerReference{APIVersion: "cert-manager.io/v1", Kind: "Issuer", Name: "new-ca-issuer", UID: "new-issuer-uid"}
+	clusterIssuerOwner := metav1.OwnerReference{APIVersion: "cert-manager.io/v1", Kind: "ClusterIssuer", Name: "ca-issuer", UID: "clusterissuer-uid"}
+
+	// configMap returns the trust bundle ConfigMap of example.org holding
+	// the given trust anchors.
+	configMap := func(namespace string, owners []metav1.OwnerReference, anchors ...*x509.Certificate) *corev1.ConfigMap {
+		caPEM, bundle, err := encodeTrustBundle(anchors)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       namespace,
+				Name:            "spiffe-bundle-example.org",
+				Labels:          map[string]string{TrustDomainLabelKey: "example.org"},
+				OwnerReferences: owners,
+			},
+			Data: map[string]string{CAKey: string(caPEM), BundleKey: string(bundle)},
+		}
+	}
+	unlabelledConfigMap := configMap("testns", nil, caCert)
+	unlabelledConfigMap.Labels = nil
+
+	configMapsGVR := corev1.SchemeGroupVersion.WithResource("configmaps")
+	getConfigMap := func(namespace string) testpkg.Action {
+		return testpkg.NewAction(coretesting.NewGetAction(configMapsGVR, namespace, "spiffe-bundle-example.org"))
+	}
+
+	tests := map[string]struct {
+		key             string
+		issuers         []runtime.Object
+		kubeObjects     []runtime.Object
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+	}{
+		"create the trust bundle of an Issuer": {
+			key:         "testns/example.org",
+			issuers:     []runtime.Object{issuer, otherTrustDomainIssuer, issuerWithoutSPIFFE},
+			kubeObjects: []runtime.Object{secret, newSecret},
+			expectedActions: []testpkg.Action{
+				getConfigMap("testns"),
+				testpkg.NewAction(coretesting.NewCreateAction(configMapsGVR, "testns",
+					configMap("testns", []metav1.OwnerReference{issuerOwner}, caCert))),
+			},
+		},
+		"create the trust bundle of a ClusterIssuer in the cluster resource namespace": {
+			key:         "cert-manager/example.org",
+			issuers:     []runtime.Object{clusterIssuer, issuer},
+			kubeObjects: []runtime.Object{clusterSecret},
+			expectedActions: []testpkg.Action{
+				getConfigMap("cert-manager"),
+				testpkg.NewAction(coretesting.NewCreateAction(configMapsGVR, "cert-manager",
+					configMap("cert-manager", []metav1.OwnerReference{clusterIssuerOwner}, caCert))),
+			},
+		},
+		"publish the roots in ca.crt instead of the signing CA": {
+			key:         "testns/example.org",
+			issuers:     []runtime.Object{intermediateIssuer},
+			kubeObjects: []runtime.Object{intermediateSecret},
+			expectedActions: []testpkg.Action{
+				getConfigMap("testns"),
+				testpkg.NewAction(coretesting.NewCreateAction(configMapsGVR, "testns",
+					configMap("testns", []metav1.OwnerReference{issuerOwner}, caCert))),
+			},
+		},
+		"do nothing if the trust bundle is up to date": {
+			key:             "testns/example.org",
+			issuers:         []runtime.Object{issuer},
+			kubeObjects:     []runtime.Object{secret, configMap("testns", []metav1.OwnerReference{issuerOwner}, caCert)},
+			expectedActions: []testpkg.Action{getConfigMap("testns")},
+		},
+		"add the CA of a new issuer of the trust domain to the trust bundle": {
+			key:         "testns/example.org",
+			issuers:     []runtime.Object{newIssuer, issuer},
+			kubeObjects: []runtime.Object{secret, newSecret, configMap("testns", []metav1.OwnerReference{issuerOwner}, caCert)},
+			expectedActions: []testpkg.Action{
+				getConfigMap("testns"),
+				testpkg.NewAction(coretesting.NewUpdateAction(configMapsGVR, "testns",
+					configMap("testns", []metav1.OwnerReference{issuerOwner, newIssuerOwner}, caCert, newCACert))),
+			},
+		},
+		"take over an existing ConfigMap": {
+			key:         "testns/example.org",
+			issuers:     []runtime.Object{issuer},
+			kubeObjects: []runtime.Object{secret, unlabelledConfigMap},
+			expectedActions: []testpkg.Action{
+				getConfigMap("testns"),
+				testpkg.NewAction(coretesting.NewUpdateAction(configMapsGVR, "testns",
+					configMap("testns", []metav1.OwnerReference{issuerOwner}, caCert))),
+			},
+		},
+		"delete the trust bundle once no issuer uses the trust domain": {
+			key:         "testns/example.org",
+			issuers:     []runtime.Object{otherTrustDomainIssuer},
+			kubeObjects: []runtime.Object{configMap("testns", []metav1.OwnerReference{issuerOwner}, caCert)},
+			expectedActions: []testpkg.Action{
+				getConfigMap("testns"),
+				testpkg.NewAction(coretesting.NewDeleteAction(configMapsGVR, "testns", "spiffe-bundle-example.org")),
+			},
+		},
+		"do not delete ConfigMaps which were not created for the trust domain": {
+			key:             "testns/example.org",
+			kubeObjects:     []runtime.Object{unlabelledConfigMap},
+			expectedActions: []testpkg.Action{getConfigMap("testns")},
+		},
+		"do nothing if the CA Secret does not exist": {
+			key:             "testns/example.org",
+			issuers:         []runtime.Object{issuer},
+			expectedActions: []testpkg.Action{getConfigMap("testns")},
+		},
+		"fire an event if the CA Secret is invalid": {
+			key:     "testns/example.org",
+			issuers: []runtime.Object{issuer, newIssuer},
+			kubeObjects: []runtime.Object{newSecret, gen.Secret("ca",
+				gen.SetSecretNamespace("testns"),
+				gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: []byte("invalid")}),
+			)},
+			expectedActions: []testpkg.Action{
+				getConfigMap("testns"),
+				testpkg.NewAction(coretesting.NewCreateAction(configMapsGVR, "testns",
+					configMap("testns", []metav1.OwnerReference{issuerOwner, newIssuerOwner}, newCACert))),
+			},
+			expectedEvents: []string{`Warning SPIFFEBundleError Failed to read the CA from Secret "ca": error decoding certificate PEM block`},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				CertManagerObjects: test.issuers,
+				KubeObjects:        test.kubeObjects,
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+			builder.Context.IssuerOptions.ClusterResourceNamespace = "cert-manager"
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), test.key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
+
+func Test_encodeTrustBundle(t *testing.T) {
+	_, caCert := caSecret(t, "testns", "ca")
+
+	caPEM, bundle, err := encodeTrustBundle([]*x509.Certificate{caCert})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certs, err := pki.DecodeX509CertificateChainBytes(caPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 1 || !certs[0].Equal(caCert) {
+		t.Errorf("expected the PEM bundle to hold the CA, got %d certificates", len(certs))
+	}
+
+	var decoded struct {
+		Keys []struct {
+			Use string   `json:"use"`
+			Kty string   `json:"kty"`
+			X5c []string `json:"x5c"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(bundle, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Keys) != 1 {
+		t.Fatalf("expected one key in the SPIFFE bundle, got %d", len(decoded.Keys))
+	}
+	key := decoded.Keys[0]
+	if key.Use != "x509-svid" || key.Kty != "EC" || len(key.X5c) != 1 {
+		t.Errorf("unexpected key in the SPIFFE bundle: %+v", key)
+	}
+}
+
+func Test_controller_handleSecret(t *testing.T) {
+	issuer := gen.Issuer("ca-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", SPIFFE: &cmapi.CASPIFFE{TrustDomain: "example.org"}}),
+	)
+	clusterIssuer := gen.ClusterIssuer("ca-issuer",
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca", SPIFFE: &cmapi.CASPIFFE{TrustDomain: "cluster.example.org"}}),
+	)
+
+	tests := map[string]struct {
+		secret      *corev1.Secret
+		expectedKey string
+	}{
+		"queue the trust domain of an Issuer using the Secret": {
+			secret:      gen.Secret("ca", gen.SetSecretNamespace("testns")),
+			expectedKey: "testns/example.org",
+		},
+		"queue the trust domain of a ClusterIssuer using the Secret": {
+			secret:      gen.Secret("ca", gen.SetSecretNamespace("cert-manager")),
+			expectedKey: "cert-manager/cluster.example.org",
+		},
+		"ignore Secrets which are not used by an issuer": {
+			secret: gen.Secret("other", gen.SetSecretNamespace("testns")),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{T: t}
+			builder.Init()
+			builder.Context.IssuerOptions.ClusterResourceNamespace = "cert-manager"
+
+			w := &controllerWrapper{}
+			queue, _, err := w.Register(builder.Context)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Populate the listers without starting the informers, which
+			// would queue the trust domains of the issuers.
+			if err := builder.SharedInformerFactory.Certmanager().V1().Issuers().Informer().GetIndexer().Add(issuer); err != nil {
+				t.Fatal(err)
+			}
+			if err := builder.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Informer().GetIndexer().Add(clusterIssuer); err != nil {
+				t.Fatal(err)
+			}
+
+			w.controller.handleSecret(test.secret)
+
+			if test.expectedKey == "" {
+				if queue.Len() != 0 {
+					t.Errorf("expected nothing to be queued, got %d items", queue.Len())
+				}
+				return
+			}
+			if queue.Len() != 1 {
+				t.Fatalf("expected one item to be queued, got %d", queue.Len())
+			}
+			key, _ := queue.Get()
+			if key != test.expectedKey {
+				t.Errorf("expected key %q to be queued, got %q", test.expectedKey, key)
+			}
+		})
+	}
+}
@@ -17,10 +17,15 @@ limitations under the License.
 package test
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 
 	"github.com/kr/pretty"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	coretesting "k8s.io/client-go/testing"
 )
 
@@ -95,3 +100,81 @@ func (a *action) Matches(act coretesting.Action) error {
 
 	return fmt.Errorf("unexpected difference between actions: %s", pretty.Diff(objExp.GetObject(), objAct.GetObject()))
 }
+
+// NewApplyStatusAction returns an Action which matches a server-side apply
+// patch of the status subresource of the given object. The action matches if
+// every field of the applied status, including each applied condition, is
+// equal to the corresponding field of the object's status.
+func NewApplyStatusAction(gvr schema.GroupVersionResource, namespace string, obj runtime.Object) Action {
+	name := ""
+	if metaObj, err := meta.Accessor(obj); err == nil {
+		name = metaObj.GetName()
+	}
+	return NewCustomMatch(
+		coretesting.NewPatchSubresourceAction(gvr, namespace, name, types.ApplyPatchType, nil, "status"),
+		func(exp, act coretesting.Action) error {
+			patchAction, ok := act.(coretesting.PatchAction)
+			if !ok || patchAction.GetPatchType() != types.ApplyPatchType {
+				return fmt.Errorf("expected an apply patch action, got %v", act)
+			}
+			if patchAction.GetName() != name {
+				return fmt.Errorf("expected status of %q to be applied, got %q", name, patchAction.GetName())
+			}
+
+			var applied, expected map[string]interface{}
+			if err := json.Unmarshal(patchAction.GetPatch(), &applied); err != nil {
+				return err
+			}
+			objJSON, err := json.Marshal(obj)
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(objJSON, &expected); err != nil {
+				return err
+			}
+
+			if !appliedSubset(applied["status"], expected["status"]) {
+				return fmt.Errorf("unexpected difference between applied and expected status: %s", pretty.Diff(expected["status"], applied["status"]))
+			}
+			return nil
+		},
+	)
+}
+
+// appliedSubset returns true if every field of applied is equal to the
+// corresponding field of expected. Each element of an applied list must be
+// equal to an element of the expected list.
+func appliedSubset(applied, expected interface{}) bool {
+	switch applied := applied.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		expected, ok := expected.(map[string]interface{})
+		if !ok {
+			return len(applied) == 0
+		}
+		for k, v := range applied {
+			if !appliedSubset(v, expected[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		expected, _ := expected.([]interface{})
+		for _, v := range applied {
+			found := false
+			for _, e := range expected {
+				if reflect.DeepEqual(v, e) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(applied, expected)
+	}
+}
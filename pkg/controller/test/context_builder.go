@@ -141,6 +141,9 @@ func (b *Builder) Init() {
 	b.FakeKubeClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.FakeCMClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.FakeGWClient().PrependReactor("create", "*", b.generateNameReactor)
+	b.FakeKubeClient().PrependReactor("patch", "*", ApplyPatchReactor(b.FakeKubeClient().Tracker()))
+	b.FakeCMClient().PrependReactor("patch", "*", ApplyPatchReactor(b.FakeCMClient().Tracker()))
+	b.FakeGWClient().PrependReactor("patch", "*", ApplyPatchReactor(b.FakeGWClient().Tracker()))
 	b.KubeSharedInformerFactory = kubeinformers.NewSharedInformerFactory(b.Client, informerResyncPeriod)
 	b.SharedInformerFactory = informers.NewSharedInformerFactory(b.CMClient, informerResyncPeriod)
 	b.GWShared = gwinformers.NewSharedInformerFactory(b.GWClient, informerResyncPeriod)
@@ -17,12 +17,21 @@ limitations under the License.
 package test
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
 	coretesting "k8s.io/client-go/testing"
+	gwscheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
+
+	cmscheme "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/scheme"
 )
 
 func NTimesReactor(f coretesting.ReactionFunc, numberCalls int) coretesting.ReactionFunc {
@@ -74,3 +83,154 @@ func ObjectDeletedReactor(t *testing.T, b *Builder, obj runtime.Object) coretest
 		return true, obj, nil
 	}
 }
+
+// applyScheme is used to construct the objects created by server-side apply
+// patches.
+var applyScheme = runtime.NewScheme()
+
+func init() {
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		kubescheme.AddToScheme,
+		cmscheme.AddToScheme,
+		gwscheme.AddToScheme,
+	} {
+		if err := addToScheme(applyScheme); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ApplyPatchReactor handles server-side apply patches, which are not supported
+// by the fake clientsets, by merging the applied configuration into the object
+// held by the tracker. Maps are merged, as are lists of objects keyed by
+// "type" or "name" (such as conditions). Field ownership is not tracked, so
+// fields which are no longer applied are not removed.
+func ApplyPatchReactor(tracker coretesting.ObjectTracker) coretesting.ReactionFunc {
+	return func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+		patchAction, ok := action.(coretesting.PatchAction)
+		if !ok || patchAction.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+
+		var applied map[string]interface{}
+		if err := json.Unmarshal(patchAction.GetPatch(), &applied); err != nil {
+			return true, nil, apierrors.NewBadRequest(err.Error())
+		}
+
+		gvr, namespace, name := action.GetResource(), action.GetNamespace(), patchAction.GetName()
+		subresource := action.GetSubresource()
+		existing, err := tracker.Get(gvr, namespace, name)
+		if apierrors.IsNotFound(err) && subresource == "" {
+			apiVersion, _ := applied["apiVersion"].(string)
+			kind, _ := applied["kind"].(string)
+			obj, err := applyScheme.New(schema.FromAPIVersionAndKind(apiVersion, kind))
+			if err != nil {
+				return true, nil, apierrors.NewBadRequest(err.Error())
+			}
+			if err := json.Unmarshal(patchAction.GetPatch(), obj); err != nil {
+				return true, nil, apierrors.NewBadRequest(err.Error())
+			}
+			if err := tracker.Create(gvr, obj, namespace); err != nil {
+				return true, nil, err
+			}
+			return true, obj, nil
+		}
+		if err != nil {
+			return true, nil, err
+		}
+
+		existingJSON, err := json.Marshal(existing)
+		if err != nil {
+			return true, nil, err
+		}
+		var merged map[string]interface{}
+		if err := json.Unmarshal(existingJSON, &merged); err != nil {
+			return true, nil, err
+		}
+		if subresource == "status" {
+			merged["status"] = mergeApplied(merged["status"], applied["status"])
+		} else {
+			delete(applied, "apiVersion")
+			delete(applied, "kind")
+			delete(applied, "status")
+			merged = mergeApplied(merged, applied).(map[string]interface{})
+		}
+
+		mergedJSON, err := json.Marshal(merged)
+		if err != nil {
+			return true, nil, err
+		}
+		obj := reflect.New(reflect.TypeOf(existing).Elem()).Interface().(runtime.Object)
+		if err := json.Unmarshal(mergedJSON, obj); err != nil {
+			return true, nil, err
+		}
+		if status := reflect.ValueOf(obj).Elem().FieldByName("Status"); subresource == "status" && status.IsValid() {
+			// Leave everything but the status untouched, rather than
+			// round-tripping timestamps through JSON.
+			obj = existing.DeepCopyObject()
+			reflect.ValueOf(obj).Elem().FieldByName("Status").Set(status)
+		} else if objMeta, err := meta.Accessor(obj); err == nil {
+			existingMeta, _ := meta.Accessor(existing)
+			objMeta.SetCreationTimestamp(existingMeta.GetCreationTimestamp())
+			objMeta.SetDeletionTimestamp(existingMeta.GetDeletionTimestamp())
+		}
+		if err := tracker.Update(gvr, obj, namespace); err != nil {
+			return true, nil, err
+		}
+		return true, obj, nil
+	}
+}
+
+// mergeApplied merges the applied value into the existing value.
+func mergeApplied(existing, applied interface{}) interface{} {
+	switch applied := applied.(type) {
+	case map[string]interface{}:
+		existing, ok := existing.(map[string]interface{})
+		if !ok {
+			return applied
+		}
+		for k, v := range applied {
+			existing[k] = mergeApplied(existing[k], v)
+		}
+		return existing
+	case []interface{}:
+		existing, ok := existing.([]interface{})
+		if !ok {
+			return applied
+		}
+		for _, v := range applied {
+			key, ok := listMapKey(v)
+			if !ok {
+				return applied
+			}
+			i := 0
+			for ; i < len(existing); i++ {
+				if existingKey, _ := listMapKey(existing[i]); existingKey == key {
+					break
+				}
+			}
+			if i == len(existing) {
+				existing = append(existing, v)
+			} else {
+				existing[i] = mergeApplied(existing[i], v)
+			}
+		}
+		return existing
+	default:
+		return applied
+	}
+}
+
+// listMapKey returns the key of an element of a list which is merged by key.
+func listMapKey(v interface{}) (string, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	for _, k := range []string{"type", "name"} {
+		if key, ok := m[k].(string); ok {
+			return k + "=" + key, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csi
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/client/issuance"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// Annotations identifying the pod volume which a CertificateRequest was
+	// created for, so that approvers can base their decision on the
+	// identity of the pod. The pod's namespace is the namespace of the
+	// CertificateRequest.
+	PodNameAnnotationKey        = "csi.cert-manager.io/pod-name"
+	PodUIDAnnotationKey         = "csi.cert-manager.io/pod-uid"
+	ServiceAccountAnnotationKey = "csi.cert-manager.io/service-account"
+	VolumeIDAnnotationKey       = "csi.cert-manager.io/volume-id"
+
+	// renewalRetryInterval is the time waited before retrying a failed
+	// renewal.
+	renewalRetryInterval = time.Minute
+)
+
+// Options configures a Manager.
+type Options struct {
+	// TrustDomain is the SPIFFE trust domain of the URI SAN requested for
+	// volumes which do not request any other identity.
+	TrustDomain string
+	// IssuanceTimeout is the maximum time waited for a CertificateRequest
+	// to be signed.
+	IssuanceTimeout time.Duration
+	// ProjectSecrets allows volumes to project the Secret of an existing
+	// Certificate by setting SecretNameKey. It is disabled by default, as
+	// the driver then needs to be able to read Secrets in all namespaces.
+	ProjectSecrets bool
+	// SecretRefreshInterval is the interval at which projected Secrets are
+	// checked for renewed certificates. Defaults to one minute.
+	SecretRefreshInterval time.Duration
+}
+
+// Manager issues the certificates of published volumes and renews them in
+// place before they expire. The private key of each volume is generated
+// locally and is never sent to the API server.
+type Manager struct {
+	log        logr.Logger
+	client     cmclient.Interface
+	kubeClient kubernetes.Interface
+	issuance   *issuance.Client
+	clock      clock.Clock
+	opts       Options
+
+	lock    sync.Mutex
+	volumes map[string]*managedVolume
+}
+
+type managedVolume struct {
+	vol    *Volume
+	cancel context.CancelFunc
+}
+
+// NewManager returns a Manager which issues certificates by creating
+// CertificateRequests with the given client. The kubeClient is used to read
+// projected Secrets.
+func NewManager(log logr.Logger, client cmclient.Interface, kubeClient kubernetes.Interface, clock clock.Clock, opts Options) *Manager {
+	return &Manager{
+		log:        log.WithName("csi"),
+		client:     client,
+		kubeClient: kubeClient,
+		issuance:   issuance.NewClient(client, nil),
+		clock:      clock,
+		opts:       opts,
+		volumes:    make(map[string]*managedVolume),
+	}
+}
+
+// Publish issues a certificate for the volume, or projects its Secret,
+// writes it to the volume's target path and starts renewing it. Publishing a
+// volume which has already been published is a no-op, as the kubelet may
+// retry a publish.
+func (m *Manager) Publish(ctx context.Context, vol *Volume) error {
+	m.lock.Lock()
+	_, ok := m.volumes[vol.ID]
+	m.lock.Unlock()
+	if ok {
+		return nil
+	}
+
+	log := logf.WithRelatedResourceName(m.log, vol.PodName, vol.PodNamespace, "Pod").WithValues("volume_id", vol.ID)
+	var renew func(ctx context.Context)
+	if vol.SecretName != "" {
+		if !m.opts.ProjectSecrets {
+			return fmt.Errorf("projecting Secrets is not enabled, %s may not be set", SecretNameKey)
+		}
+		projected, err := m.project(ctx, log, vol, nil)
+		if err != nil {
+			return err
+		}
+		renew = func(ctx context.Context) { m.refresh(ctx, log, vol, projected) }
+	} else {
+		cert, err := m.issue(ctx, log, vol)
+		if err != nil {
+			return err
+		}
+		renew = func(ctx context.Context) { m.renew(ctx, log, vol, cert) }
+	}
+
+	renewCtx, cancel := context.WithCancel(context.Background())
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.volumes[vol.ID]; ok {
+		// Published concurrently, keep the renewal which was started
+		// first.
+		cancel()
+		return nil
+	}
+	m.volumes[vol.ID] = &managedVolume{vol: vol, cancel: cancel}
+	go renew(renewCtx)
+
+	return nil
+}
+
+// Unpublish stops renewing the certificate of the volume and removes its
+// files.
+func (m *Manager) Unpublish(volumeID string) error {
+	m.lock.Lock()
+	mv, ok := m.volumes[volumeID]
+	delete(m.volumes, volumeID)
+	m.lock.Unlock()
+	if !ok {
+		return nil
+	}
+
+	mv.cancel()
+	return removeFiles(mv.vol)
+}
+
+// renew renews the certificate of the volume until ctx is cancelled.
+func (m *Manager) renew(ctx context.Context, log logr.Logger, vol *Volume, cert *x509Times) {
+	var renewBefore *metav1.Duration
+	if vol.RenewBefore != nil {
+		renewBefore = &metav1.Duration{Duration: *vol.RenewBefore}
+	}
+	renewAt := certificates.RenewalTime(cert.notBefore, cert.notAfter, renewBefore).Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.clock.After(renewAt.Sub(m.clock.Now())):
+		}
+
+		next, err := m.issue(ctx, log, vol)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Error(err, "failed to renew certificate, will retry", "retry_after", renewalRetryInterval)
+			renewAt = m.clock.Now().Add(renewalRetryInterval)
+			continue
+		}
+		renewAt = certificates.RenewalTime(next.notBefore, next.notAfter, renewBefore).Time
+	}
+}
+
+// x509Times are the validity period of an issued certificate.
+type x509Times struct {
+	notBefore, notAfter time.Time
+}
+
+// issue generates a new private key for the volume, requests a certificate
+// for it and writes both to the volume.
+func (m *Manager) issue(ctx context.Context, log logr.Logger, vol *Volume) (*x509Times, error) {
+	crt := m.certificateFor(vol)
+	pk, err := pki.GeneratePrivateKeyForCertificate(crt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+	csr, err := pki.GenerateCSR(crt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CSR: %w", err)
+	}
+	csrDER, err := pki.EncodeCSR(csr, pk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign CSR: %w", err)
+	}
+
+	cr := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "csi-",
+			Namespace:    vol.PodNamespace,
+			Annotations: map[string]string{
+				PodNameAnnotationKey:        vol.PodName,
+				PodUIDAnnotationKey:         vol.PodUID,
+				ServiceAccountAnnotationKey: vol.ServiceAccountName,
+				VolumeIDAnnotationKey:       vol.ID,
+			},
+		},
+		Spec: cmapi.CertificateRequestSpec{
+			Request:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}),
+			IssuerRef: vol.IssuerRef,
+			Usages:    vol.Usages,
+			Duration:  crt.Spec.Duration,
+		},
+	}
+	cr, err = m.client.CertmanagerV1().CertificateRequests(cr.Namespace).Create(ctx, cr, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CertificateRequest: %w", err)
+	}
+	log = logf.WithRelatedResource(log, cr)
+	log.V(logf.DebugLevel).Info("created CertificateRequest")
+	defer func() {
+		// The CertificateRequest is of no use once it has been signed or
+		// has failed, and would otherwise accumulate for every volume.
+		if err := m.client.CertmanagerV1().CertificateRequests(cr.Namespace).Delete(context.Background(), cr.Name, metav1.DeleteOptions{}); err != nil {
+			log.Error(err, "failed to delete CertificateRequest")
+		}
+	}()
+
+	signed, err := m.waitForSigned(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+
+	x509Cert, err := pki.DecodeX509CertificateBytes(signed.Status.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signed certificate: %w", err)
+	}
+	if ok, err := pki.PublicKeyMatchesCertificate(pk.Public(), x509Cert); err != nil || !ok {
+		return nil, fmt.Errorf("signed certificate does not match the private key of the CertificateRequest")
+	}
+
+	keyPEM, err := pki.EncodePrivateKey(pk, cmapi.PKCS1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+	if err := writeFiles(vol, keyPEM, signed.Status.Certificate, signed.Status.CA); err != nil {
+		return nil, fmt.Errorf("failed to write certificate to volume: %w", err)
+	}
+	log.V(logf.InfoLevel).Info("issued certificate", "not_after", x509Cert.NotAfter)
+
+	return &x509Times{notBefore: x509Cert.NotBefore, notAfter: x509Cert.NotAfter}, nil
+}
+
+// waitForSigned waits for the CertificateRequest to be signed, and returns
+// an error if it is denied or fails.
+func (m *Manager) waitForSigned(ctx context.Context, cr *cmapi.CertificateRequest) (*cmapi.CertificateRequest, error) {
+	if m.opts.IssuanceTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.opts.IssuanceTimeout)
+		defer cancel()
+	}
+
+	return m.issuance.WaitForCertificateRequestSigned(ctx, cr.Namespace, cr.Name)
+}
+
+// certificateFor returns a Certificate describing the certificate requested
+// for the volume. It is only used to generate the private key and CSR, and
+// is never created.
+func (m *Manager) certificateFor(vol *Volume) *cmapi.Certificate {
+	crt := &cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			CommonName: vol.CommonName,
+			DNSNames:   vol.DNSNames,
+			URIs:       vol.URISANs,
+			Usages:     vol.Usages,
+			IssuerRef:  vol.IssuerRef,
+			PrivateKey: &cmapi.CertificatePrivateKey{
+				Algorithm: cmapi.ECDSAKeyAlgorithm,
+				Size:      256,
+			},
+		},
+	}
+	if vol.Duration != nil {
+		crt.Spec.Duration = &metav1.Duration{Duration: *vol.Duration}
+	}
+	return crt
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csi
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	pem  []byte
+	key  crypto.Signer
+}
+
+func mustTestCA(t *testing.T) *testCA {
+	ca := gen.Certificate("ca", gen.SetCertificateCommonName("ca"), gen.SetCertificateIsCA(true))
+	key, err := pki.GeneratePrivateKeyForCertificate(ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.GenerateTemplate(ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caPEM, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, pem: caPEM, key: key}
+}
+
+// newSigningClient returns a fake client which names CertificateRequests on
+// creation and signs them with sign. It returns the number of created
+// CertificateRequests.
+func newSigningClient(t *testing.T, sign func(cr *cmapi.CertificateRequest)) (*fake.Clientset, *int32) {
+	var created int32
+	cl := fake.NewSimpleClientset()
+	cl.PrependReactor("create", "certificaterequests", func(action coretesting.Action) (bool, runtime.Object, error) {
+		cr := action.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest)
+		cr.Name = cr.GenerateName + string(rune('a'+atomic.AddInt32(&created, 1)))
+		sign(cr)
+		// Fall through to the tracker storing the modified request.
+		return false, nil, nil
+	})
+	return cl, &created
+}
+
+func (ca *testCA) sign(t *testing.T) func(cr *cmapi.CertificateRequest) {
+	return func(cr *cmapi.CertificateRequest) {
+		template, err := pki.GenerateTemplateFromCertificateRequest(cr)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		certPEM, _, err := pki.SignCertificate(template, ca.cert, template.PublicKey, ca.key)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		cr.Status.Certificate, cr.Status.CA = certPEM, ca.pem
+	}
+}
+
+func mustTestVolume(t *testing.T, attrs map[string]string) *Volume {
+	vol, err := NewVolume("vol-id", t.TempDir(), "cluster.local", podAttributes(attrs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vol
+}
+
+func TestManagerPublishUnpublish(t *testing.T) {
+	ca := mustTestCA(t)
+	cl, created := newSigningClient(t, ca.sign(t))
+	m := NewManager(logtesting.NewTestLogger(t), cl, kubefake.NewSimpleClientset(), fakeclock.NewFakeClock(time.Now()), Options{TrustDomain: "cluster.local"})
+	vol := mustTestVolume(t, nil)
+
+	if err := m.Publish(context.TODO(), vol); err != nil {
+		t.Fatal(err)
+	}
+	// Publishing again, e.g. when the kubelet retries, does not reissue.
+	if err := m.Publish(context.TODO(), vol); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(created))
+
+	keyPEM, err := os.ReadFile(filepath.Join(vol.TargetPath, corev1.TLSPrivateKeyKey))
+	assert.NoError(t, err)
+	certPEM, err := os.ReadFile(filepath.Join(vol.TargetPath, corev1.TLSCertKey))
+	assert.NoError(t, err)
+	caPEM, err := os.ReadFile(filepath.Join(vol.TargetPath, cmmeta.TLSCAKey))
+	assert.NoError(t, err)
+	assert.Equal(t, ca.pem, caPEM)
+
+	key, err := pki.DecodePrivateKeyBytes(keyPEM)
+	assert.NoError(t, err)
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	assert.NoError(t, err)
+	matches, err := pki.PublicKeyMatchesCertificate(key.Public(), cert)
+	assert.NoError(t, err)
+	assert.True(t, matches, "expected the private key to match the certificate")
+	assert.Equal(t, []string{"spiffe://cluster.local/ns/my-ns/sa/my-sa"}, pki.URLsToString(cert.URIs))
+
+	crs, err := cl.CertmanagerV1().CertificateRequests("my-ns").List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, crs.Items, "expected the CertificateRequest to be deleted once signed")
+
+	assert.NoError(t, m.Unpublish(vol.ID))
+	entries, err := os.ReadDir(vol.TargetPath)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestManagerPublishDenied(t *testing.T) {
+	cl, _ := newSigningClient(t, func(cr *cmapi.CertificateRequest) {
+		cr.Status.Conditions = []cmapi.CertificateRequestCondition{{
+			Type:   cmapi.CertificateRequestConditionDenied,
+			Status: cmmeta.ConditionTrue,
+		}}
+	})
+	m := NewManager(logtesting.NewTestLogger(t), cl, kubefake.NewSimpleClientset(), fakeclock.NewFakeClock(time.Now()), Options{})
+	vol := mustTestVolume(t, nil)
+
+	err := m.Publish(context.TODO(), vol)
+	assert.Error(t, err)
+	assert.NoError(t, m.Unpublish(vol.ID))
+	_, err = os.Stat(filepath.Join(vol.TargetPath, corev1.TLSCertKey))
+	assert.True(t, errors.Is(err, os.ErrNotExist), "expected no certificate to be written")
+}
+
+func TestManagerRenewsInPlace(t *testing.T) {
+	ca := mustTestCA(t)
+	cl, created := newSigningClient(t, ca.sign(t))
+	clock := fakeclock.NewFakeClock(time.Now())
+	m := NewManager(logtesting.NewTestLogger(t), cl, kubefake.NewSimpleClientset(), clock, Options{})
+	vol := mustTestVolume(t, map[string]string{DurationKey: "1h"})
+
+	if err := m.Publish(context.TODO(), vol); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Unpublish(vol.ID)
+	first, err := os.ReadFile(filepath.Join(vol.TargetPath, corev1.TLSCertKey))
+	assert.NoError(t, err)
+
+	assert.Eventually(t, clock.HasWaiters, 5*time.Second, 10*time.Millisecond)
+	clock.Step(time.Hour)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(created) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		renewed, err := os.ReadFile(filepath.Join(vol.TargetPath, corev1.TLSCertKey))
+		return err == nil && string(renewed) != string(first)
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csi
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// defaultSecretRefreshInterval is the interval at which projected Secrets
+// are checked for renewed certificates if Options.SecretRefreshInterval is
+// not set.
+const defaultSecretRefreshInterval = time.Minute
+
+// projectedSecret is the data of a Secret which was written to a volume.
+type projectedSecret struct {
+	key, cert, ca []byte
+}
+
+// project writes the private key, certificate and CA of the volume's Secret
+// to the volume, unless they are the same as the last projected data. It
+// returns the data now stored in the volume.
+func (m *Manager) project(ctx context.Context, log logr.Logger, vol *Volume, last *projectedSecret) (*projectedSecret, error) {
+	secret, err := m.kubeClient.CoreV1().Secrets(vol.PodNamespace).Get(ctx, vol.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Secret %q: %w", vol.SecretName, err)
+	}
+	// Only Secrets issued for Certificates are projected, as the volumes
+	// are not meant to be a replacement for Secret volumes.
+	if _, ok := secret.Annotations[cmapi.CertificateNameKey]; !ok {
+		return nil, fmt.Errorf("the Secret %q was not issued for a Certificate, it does not have the %s annotation", vol.SecretName, cmapi.CertificateNameKey)
+	}
+
+	next := &projectedSecret{
+		key:  secret.Data[corev1.TLSPrivateKeyKey],
+		cert: secret.Data[corev1.TLSCertKey],
+		ca:   secret.Data[cmmeta.TLSCAKey],
+	}
+	if last != nil && bytes.Equal(last.key, next.key) && bytes.Equal(last.cert, next.cert) && bytes.Equal(last.ca, next.ca) {
+		return last, nil
+	}
+	// Never replace the files with a private key which does not match the
+	// certificate, so that workloads reloading them keep working.
+	if _, err := tls.X509KeyPair(next.cert, next.key); err != nil {
+		return nil, fmt.Errorf("the Secret %q does not contain a valid private key and certificate: %w", vol.SecretName, err)
+	}
+
+	if err := writeFiles(vol, next.key, next.cert, next.ca); err != nil {
+		return nil, fmt.Errorf("failed to write Secret to volume: %w", err)
+	}
+	log.V(logf.InfoLevel).Info("projected Secret", "secret", vol.SecretName)
+
+	return next, nil
+}
+
+// refresh projects the volume's Secret whenever it changes until ctx is
+// cancelled.
+func (m *Manager) refresh(ctx context.Context, log logr.Logger, vol *Volume, last *projectedSecret) {
+	interval := m.opts.SecretRefreshInterval
+	if interval <= 0 {
+		interval = defaultSecretRefreshInterval
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.clock.After(interval):
+		}
+
+		next, err := m.project(ctx, log, vol, last)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Error(err, "failed to refresh projected Secret, the volume keeps the previous certificate")
+			continue
+		}
+		last = next
+	}
+}
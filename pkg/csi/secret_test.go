@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// issuedSecret returns a Secret issued for a Certificate holding the key and
+// self-signed certificate of a new test CA.
+func issuedSecret(t *testing.T, name string) *corev1.Secret {
+	ca := mustTestCA(t)
+	keyPEM, err := pki.EncodePrivateKey(ca.key, cmapi.PKCS8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "my-ns",
+			Annotations: map[string]string{cmapi.CertificateNameKey: name},
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: keyPEM,
+			corev1.TLSCertKey:       ca.pem,
+			cmmeta.TLSCAKey:         ca.pem,
+		},
+	}
+}
+
+func TestManagerProjectsSecret(t *testing.T) {
+	secret := issuedSecret(t, "my-tls")
+	kubeClient := kubefake.NewSimpleClientset(secret)
+	clock := fakeclock.NewFakeClock(time.Now())
+	m := NewManager(logtesting.NewTestLogger(t), fake.NewSimpleClientset(), kubeClient, clock, Options{ProjectSecrets: true})
+	vol, err := NewVolume("vol-id", t.TempDir(), "cluster.local", secretAttributes("my-tls", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Publish(context.TODO(), vol); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Unpublish(vol.ID)
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(vol.TargetPath, name))
+		assert.NoError(t, err)
+		assert.Equal(t, secret.Data[name], data)
+	}
+
+	// Invalid data is not projected.
+	invalid := secret.DeepCopy()
+	invalid.Data[corev1.TLSPrivateKeyKey] = []byte("invalid")
+	if _, err := kubeClient.CoreV1().Secrets("my-ns").Update(context.TODO(), invalid, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Eventually(t, clock.HasWaiters, 5*time.Second, 10*time.Millisecond)
+	clock.Step(time.Minute)
+	assert.Eventually(t, clock.HasWaiters, 5*time.Second, 10*time.Millisecond)
+	key, err := os.ReadFile(filepath.Join(vol.TargetPath, corev1.TLSPrivateKeyKey))
+	assert.NoError(t, err)
+	assert.Equal(t, secret.Data[corev1.TLSPrivateKeyKey], key)
+
+	renewed := issuedSecret(t, "my-tls")
+	if _, err := kubeClient.CoreV1().Secrets("my-ns").Update(context.TODO(), renewed, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	clock.Step(time.Minute)
+	assert.Eventually(t, func() bool {
+		cert, err := os.ReadFile(filepath.Join(vol.TargetPath, corev1.TLSCertKey))
+		return err == nil && string(cert) == string(renewed.Data[corev1.TLSCertKey])
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestManagerProjectSecretErrors(t *testing.T) {
+	notIssued := issuedSecret(t, "not-issued")
+	notIssued.Annotations = nil
+
+	tests := map[string]struct {
+		opts       Options
+		secretName string
+	}{
+		"projecting Secrets is disabled": {
+			secretName: "my-tls",
+		},
+		"the Secret does not exist": {
+			opts:       Options{ProjectSecrets: true},
+			secretName: "missing",
+		},
+		"the Secret was not issued for a Certificate": {
+			opts:       Options{ProjectSecrets: true},
+			secretName: "not-issued",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset(issuedSecret(t, "my-tls"), notIssued)
+			m := NewManager(logtesting.NewTestLogger(t), fake.NewSimpleClientset(), kubeClient, fakeclock.NewFakeClock(time.Now()), test.opts)
+			vol, err := NewVolume("vol-id", t.TempDir(), "cluster.local", secretAttributes(test.secretName, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Error(t, m.Publish(context.TODO(), vol))
+			entries, err := os.ReadDir(vol.TargetPath)
+			assert.NoError(t, err)
+			assert.Empty(t, entries, "expected no files to be written")
+		})
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package csi issues and renews the certificates of the volumes of a CSI
+// driver which delivers a private key and certificate to each pod mounting
+// such a volume. The private key is only ever stored in the volume, so that
+// pods can use mTLS without their keys being stored in Secrets.
+//
+// Volumes may instead project the Secret of an existing Certificate, so that
+// workloads see its renewed certificate files without being restarted or
+// running a sidecar.
+package csi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// Volume attributes configuring the certificate of a volume.
+const (
+	IssuerNameKey  = "csi.cert-manager.io/issuer-name"
+	IssuerKindKey  = "csi.cert-manager.io/issuer-kind"
+	IssuerGroupKey = "csi.cert-manager.io/issuer-group"
+
+	CommonNameKey = "csi.cert-manager.io/common-name"
+	// DNSNamesKey and URISANsKey are comma separated lists.
+	DNSNamesKey = "csi.cert-manager.io/dns-names"
+	URISANsKey  = "csi.cert-manager.io/uri-sans"
+	// KeyUsagesKey is a comma separated list of key usages. Defaults to
+	// "digital signature,key encipherment,server auth,client auth".
+	KeyUsagesKey = "csi.cert-manager.io/key-usages"
+
+	DurationKey    = "csi.cert-manager.io/duration"
+	RenewBeforeKey = "csi.cert-manager.io/renew-before"
+
+	// FSGroupKey is the group which is given read access to the private key.
+	// If not set, the private key is readable by all users of the pod.
+	FSGroupKey = "csi.cert-manager.io/fs-group"
+
+	// SecretNameKey is the name of a Secret in the pod's namespace, issued
+	// for a Certificate, which is projected into the volume instead of
+	// issuing a certificate for the volume. Renewals of the Certificate
+	// are written to the volume in place. It may not be set together with
+	// any of the attributes configuring the certificate, except FSGroupKey,
+	// and is only allowed if the driver enables Options.ProjectSecrets.
+	SecretNameKey = "csi.cert-manager.io/secret-name"
+)
+
+// certificateKeys are the attributes configuring the certificate issued for
+// a volume.
+var certificateKeys = []string{
+	IssuerNameKey, IssuerKindKey, IssuerGroupKey,
+	CommonNameKey, DNSNamesKey, URISANsKey, KeyUsagesKey,
+	DurationKey, RenewBeforeKey,
+}
+
+// Volume attributes set by the kubelet for drivers which request pod info on
+// mount.
+const (
+	podNameKey            = "csi.storage.k8s.io/pod.name"
+	podNamespaceKey       = "csi.storage.k8s.io/pod.namespace"
+	podUIDKey             = "csi.storage.k8s.io/pod.uid"
+	serviceAccountNameKey = "csi.storage.k8s.io/serviceAccount.name"
+)
+
+// Variables which may be used in the common name, DNS names and URI SANs of
+// a volume, and are replaced with the details of the pod mounting the volume.
+const (
+	podNameVar            = "${POD_NAME}"
+	podNamespaceVar       = "${POD_NAMESPACE}"
+	serviceAccountNameVar = "${SERVICE_ACCOUNT_NAME}"
+)
+
+var defaultKeyUsages = []cmapi.KeyUsage{
+	cmapi.UsageDigitalSignature,
+	cmapi.UsageKeyEncipherment,
+	cmapi.UsageServerAuth,
+	cmapi.UsageClientAuth,
+}
+
+// Volume is a volume of a pod which holds a certificate.
+type Volume struct {
+	// ID is the ID of the volume assigned by the kubelet.
+	ID string
+	// TargetPath is the directory in which the volume's files are written.
+	TargetPath string
+
+	PodName            string
+	PodNamespace       string
+	PodUID             string
+	ServiceAccountName string
+
+	// SecretName is the name of the Secret projected into the volume. If
+	// set, no certificate is issued for the volume and the fields
+	// configuring the certificate are unset.
+	SecretName string
+
+	IssuerRef   cmmeta.ObjectReference
+	CommonName  string
+	DNSNames    []string
+	URISANs     []string
+	Usages      []cmapi.KeyUsage
+	Duration    *time.Duration
+	RenewBefore *time.Duration
+	FSGroup     *int64
+}
+
+// NewVolume returns the volume with the given ID, target path and attributes.
+// If the attributes do not request any identity for the certificate, the
+// SPIFFE ID of the pod's service account within trustDomain is used.
+func NewVolume(id, targetPath, trustDomain string, attributes map[string]string) (*Volume, error) {
+	vol := &Volume{
+		ID:                 id,
+		TargetPath:         targetPath,
+		PodName:            attributes[podNameKey],
+		PodNamespace:       attributes[podNamespaceKey],
+		PodUID:             attributes[podUIDKey],
+		ServiceAccountName: attributes[serviceAccountNameKey],
+	}
+	if vol.PodName == "" || vol.PodNamespace == "" || vol.ServiceAccountName == "" {
+		return nil, fmt.Errorf("the pod name, namespace and service account must be set in the volume attributes, the CSIDriver must set podInfoOnMount")
+	}
+
+	var err error
+	if vol.FSGroup, err = parseFSGroup(attributes); err != nil {
+		return nil, err
+	}
+
+	if vol.SecretName = attributes[SecretNameKey]; vol.SecretName != "" {
+		for _, key := range certificateKeys {
+			if _, ok := attributes[key]; ok {
+				return nil, fmt.Errorf("%s may not be set together with %s", key, SecretNameKey)
+			}
+		}
+		return vol, nil
+	}
+
+	vol.IssuerRef = cmmeta.ObjectReference{
+		Name:  attributes[IssuerNameKey],
+		Kind:  attributes[IssuerKindKey],
+		Group: attributes[IssuerGroupKey],
+	}
+	vol.Usages = defaultKeyUsages
+	if vol.IssuerRef.Name == "" {
+		return nil, fmt.Errorf("%s must be set", IssuerNameKey)
+	}
+	if vol.IssuerRef.Kind == "" {
+		vol.IssuerRef.Kind = cmapi.IssuerKind
+	}
+
+	vol.CommonName = vol.expand(attributes[CommonNameKey])
+	for _, name := range splitList(attributes[DNSNamesKey]) {
+		vol.DNSNames = append(vol.DNSNames, vol.expand(name))
+	}
+	for _, uri := range splitList(attributes[URISANsKey]) {
+		vol.URISANs = append(vol.URISANs, vol.expand(uri))
+	}
+	if vol.CommonName == "" && len(vol.DNSNames) == 0 && len(vol.URISANs) == 0 {
+		vol.URISANs = []string{fmt.Sprintf("spiffe://%s/ns/%s/sa/%s", trustDomain, vol.PodNamespace, vol.ServiceAccountName)}
+	}
+
+	if usages := splitList(attributes[KeyUsagesKey]); len(usages) > 0 {
+		vol.Usages = nil
+		for _, usage := range usages {
+			vol.Usages = append(vol.Usages, cmapi.KeyUsage(usage))
+		}
+	}
+
+	if vol.Duration, err = parseDuration(attributes, DurationKey); err != nil {
+		return nil, err
+	}
+	if vol.RenewBefore, err = parseDuration(attributes, RenewBeforeKey); err != nil {
+		return nil, err
+	}
+
+	return vol, nil
+}
+
+// expand replaces the variables in s with the details of the pod.
+func (v *Volume) expand(s string) string {
+	return strings.NewReplacer(
+		podNameVar, v.PodName,
+		podNamespaceVar, v.PodNamespace,
+		serviceAccountNameVar, v.ServiceAccountName,
+	).Replace(s)
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseDuration(attributes map[string]string, key string) (*time.Duration, error) {
+	s, ok := attributes[key]
+	if !ok {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return nil, fmt.Errorf("%s must be a positive duration, got %q", key, s)
+	}
+	return &d, nil
+}
+
+func parseFSGroup(attributes map[string]string) (*int64, error) {
+	s, ok := attributes[FSGroupKey]
+	if !ok {
+		return nil, nil
+	}
+	gid, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || gid < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative integer, got %q", FSGroupKey, s)
+	}
+	return &gid, nil
+}
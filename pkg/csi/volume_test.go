@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func podAttributes(attrs map[string]string) map[string]string {
+	withPod := map[string]string{
+		podNameKey:            "my-pod",
+		podNamespaceKey:       "my-ns",
+		podUIDKey:             "my-uid",
+		serviceAccountNameKey: "my-sa",
+		IssuerNameKey:         "my-issuer",
+	}
+	for k, v := range attrs {
+		withPod[k] = v
+	}
+	return withPod
+}
+
+// secretAttributes returns the attributes of a volume projecting the Secret
+// with the given name.
+func secretAttributes(secretName string, attrs map[string]string) map[string]string {
+	withSecret := podAttributes(attrs)
+	delete(withSecret, IssuerNameKey)
+	withSecret[SecretNameKey] = secretName
+	return withSecret
+}
+
+func TestNewVolume(t *testing.T) {
+	hour, day := time.Hour, 24*time.Hour
+	gid := int64(2000)
+
+	tests := map[string]struct {
+		attrs  map[string]string
+		exp    *Volume
+		expErr bool
+	}{
+		"the SPIFFE ID of the service account is requested if there are no other SANs": {
+			attrs: podAttributes(nil),
+			exp: &Volume{
+				IssuerRef: cmmeta.ObjectReference{Name: "my-issuer", Kind: cmapi.IssuerKind},
+				URISANs:   []string{"spiffe://cluster.local/ns/my-ns/sa/my-sa"},
+				Usages:    defaultKeyUsages,
+			},
+		},
+		"pod variables are expanded in the requested identity": {
+			attrs: podAttributes(map[string]string{
+				IssuerKindKey:  cmapi.ClusterIssuerKind,
+				IssuerGroupKey: "cert-manager.io",
+				CommonNameKey:  "${POD_NAME}",
+				DNSNamesKey:    "${POD_NAME}.${POD_NAMESPACE}.svc, ${SERVICE_ACCOUNT_NAME}.example.com,",
+				KeyUsagesKey:   "digital signature,client auth",
+				DurationKey:    "24h",
+				RenewBeforeKey: "1h",
+				FSGroupKey:     "2000",
+			}),
+			exp: &Volume{
+				IssuerRef:   cmmeta.ObjectReference{Name: "my-issuer", Kind: cmapi.ClusterIssuerKind, Group: "cert-manager.io"},
+				CommonName:  "my-pod",
+				DNSNames:    []string{"my-pod.my-ns.svc", "my-sa.example.com"},
+				Usages:      []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageClientAuth},
+				Duration:    &day,
+				RenewBefore: &hour,
+				FSGroup:     &gid,
+			},
+		},
+		"pod info is required": {
+			attrs:  map[string]string{IssuerNameKey: "my-issuer"},
+			expErr: true,
+		},
+		"the issuer name is required": {
+			attrs:  podAttributes(map[string]string{IssuerNameKey: ""}),
+			expErr: true,
+		},
+		"invalid durations are rejected": {
+			attrs:  podAttributes(map[string]string{DurationKey: "-1h"}),
+			expErr: true,
+		},
+		"invalid fs groups are rejected": {
+			attrs:  podAttributes(map[string]string{FSGroupKey: "root"}),
+			expErr: true,
+		},
+		"a Secret is projected without configuring a certificate": {
+			attrs: secretAttributes("my-tls", map[string]string{FSGroupKey: "2000"}),
+			exp: &Volume{
+				SecretName: "my-tls",
+				FSGroup:    &gid,
+			},
+		},
+		"a projected Secret may not be combined with certificate attributes": {
+			attrs:  secretAttributes("my-tls", map[string]string{DNSNamesKey: "example.com"}),
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			vol, err := NewVolume("vol-id", "/target", "cluster.local", test.attrs)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			test.exp.ID, test.exp.TargetPath = "vol-id", "/target"
+			test.exp.PodName, test.exp.PodNamespace, test.exp.PodUID, test.exp.ServiceAccountName = "my-pod", "my-ns", "my-uid", "my-sa"
+			assert.Equal(t, test.exp, vol)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	// dataDirName is the symlink to the directory holding the current files
+	// of a volume. It is replaced atomically on each write.
+	dataDirName = "..data"
+	// tmpDataDirName is the name of the symlink created before it replaces
+	// dataDirName.
+	tmpDataDirName = "..data_tmp"
+)
+
+// files are the files written to each volume.
+var files = []string{corev1.TLSPrivateKeyKey, corev1.TLSCertKey, cmmeta.TLSCAKey}
+
+// writeFiles writes the private key, certificate and CA to the volume. The
+// files are written in the same way as the kubelet's atomic writer for
+// Secret volumes: a new timestamped directory is populated, then the
+// "..data" symlink is swapped to point at it. Readers of the files
+// therefore never observe a certificate which does not match the private
+// key.
+func writeFiles(vol *Volume, key, cert, ca []byte) error {
+	if err := os.MkdirAll(vol.TargetPath, 0755); err != nil {
+		return err
+	}
+
+	tsDir, err := os.MkdirTemp(vol.TargetPath, time.Now().UTC().Format("..2006_01_02_15_04_05."))
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tsDir, 0755); err != nil {
+		return err
+	}
+	tsDirName := filepath.Base(tsDir)
+
+	keyMode := os.FileMode(0644)
+	if vol.FSGroup != nil {
+		keyMode = 0640
+	}
+	data := map[string][]byte{
+		corev1.TLSPrivateKeyKey: key,
+		corev1.TLSCertKey:       cert,
+		cmmeta.TLSCAKey:         ca,
+	}
+	for _, name := range files {
+		mode := os.FileMode(0644)
+		if name == corev1.TLSPrivateKeyKey {
+			mode = keyMode
+		}
+		path := filepath.Join(tsDir, name)
+		if err := os.WriteFile(path, data[name], mode); err != nil {
+			return err
+		}
+		// The mode passed to WriteFile is subject to the umask.
+		if err := os.Chmod(path, mode); err != nil {
+			return err
+		}
+	}
+	if vol.FSGroup != nil {
+		if err := os.Chown(filepath.Join(tsDir, corev1.TLSPrivateKeyKey), -1, int(*vol.FSGroup)); err != nil {
+			return fmt.Errorf("failed to set the group of the private key to %d: %w", *vol.FSGroup, err)
+		}
+	}
+
+	oldTsDirName, err := os.Readlink(filepath.Join(vol.TargetPath, dataDirName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	tmpLink := filepath.Join(vol.TargetPath, tmpDataDirName)
+	if err := os.Remove(tmpLink); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Symlink(tsDirName, tmpLink); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpLink, filepath.Join(vol.TargetPath, dataDirName)); err != nil {
+		return err
+	}
+
+	for _, name := range files {
+		link := filepath.Join(vol.TargetPath, name)
+		if _, err := os.Lstat(link); err == nil {
+			continue
+		}
+		if err := os.Symlink(filepath.Join(dataDirName, name), link); err != nil {
+			return err
+		}
+	}
+
+	if oldTsDirName != "" && oldTsDirName != tsDirName {
+		return os.RemoveAll(filepath.Join(vol.TargetPath, oldTsDirName))
+	}
+	return nil
+}
+
+// removeFiles removes all files written to the volume by writeFiles.
+func removeFiles(vol *Volume) error {
+	tsDirName, err := os.Readlink(filepath.Join(vol.TargetPath, dataDirName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	paths := []string{dataDirName, tmpDataDirName}
+	if tsDirName != "" {
+		paths = append(paths, tsDirName)
+	}
+	paths = append(paths, files...)
+	for _, path := range paths {
+		if err := os.RemoveAll(filepath.Join(vol.TargetPath, path)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backup exports Certificates, their issued Secrets and the ACME
+// account keys of the issuers they reference into a portable bundle, and
+// restores such a bundle into a cluster without re-issuing the certificates.
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+)
+
+// BundleVersion is the version of the Bundle format written by Export.
+const BundleVersion = 1
+
+// Bundle is the content of a backup.
+type Bundle struct {
+	// Version is the version of the bundle format.
+	Version int `json:"version"`
+	// CreatedAt is the time the bundle was exported.
+	CreatedAt metav1.Time `json:"createdAt"`
+	// Certificates are the exported Certificates, including their status.
+	Certificates []cmapi.Certificate `json:"certificates"`
+	// Secrets are the Secrets issued for the Certificates, and the ACME
+	// account private keys of the issuers the Certificates reference.
+	Secrets []corev1.Secret `json:"secrets"`
+}
+
+// ExportOptions configure which resources are exported.
+type ExportOptions struct {
+	// Namespace to export Certificates from. All namespaces are exported if
+	// empty.
+	Namespace string
+	// LabelSelector restricts the export to matching Certificates.
+	LabelSelector string
+	// ClusterResourceNamespace is the namespace that ClusterIssuers store
+	// their ACME account private keys in.
+	ClusterResourceNamespace string
+	// Clock is used to set the creation time of the bundle.
+	Clock clock.Clock
+}
+
+// Export returns a Bundle of the selected Certificates, the Secrets issued
+// for them and the ACME account private keys of the Issuers and
+// ClusterIssuers they reference. Certificates whose Secret does not exist
+// yet are exported without one, and referenced issuers which do not exist or
+// are not ACME issuers are ignored.
+func Export(ctx context.Context, kubeClient kubernetes.Interface, cmClient cmclient.Interface, opts ExportOptions) (*Bundle, error) {
+	if opts.Clock == nil {
+		opts.Clock = clock.RealClock{}
+	}
+
+	crtList, err := cmClient.CertmanagerV1().Certificates(opts.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: opts.LabelSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Certificates: %w", err)
+	}
+
+	bundle := &Bundle{
+		Version:   BundleVersion,
+		CreatedAt: metav1.NewTime(opts.Clock.Now()),
+	}
+
+	secrets := make(map[string]corev1.Secret)
+	addSecret := func(namespace, name string) error {
+		key := namespace + "/" + name
+		if _, ok := secrets[key]; ok {
+			return nil
+		}
+		secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error when getting Secret %s: %w", key, err)
+		}
+		secrets[key] = *secret
+		return nil
+	}
+
+	for _, crt := range crtList.Items {
+		if err := addSecret(crt.Namespace, crt.Spec.SecretName); err != nil {
+			return nil, err
+		}
+
+		namespace, accountKey, err := acmeAccountKey(ctx, cmClient, &crt, opts.ClusterResourceNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if accountKey != "" {
+			if err := addSecret(namespace, accountKey); err != nil {
+				return nil, err
+			}
+		}
+
+		bundle.Certificates = append(bundle.Certificates, *sanitizeCertificate(&crt))
+	}
+
+	for _, secret := range secrets {
+		bundle.Secrets = append(bundle.Secrets, *sanitizeSecret(&secret))
+	}
+	sort.Slice(bundle.Certificates, func(i, j int) bool {
+		return less(&bundle.Certificates[i].ObjectMeta, &bundle.Certificates[j].ObjectMeta)
+	})
+	sort.Slice(bundle.Secrets, func(i, j int) bool {
+		return less(&bundle.Secrets[i].ObjectMeta, &bundle.Secrets[j].ObjectMeta)
+	})
+
+	return bundle, nil
+}
+
+// acmeAccountKey returns the namespace and name of the Secret containing the
+// ACME account private key of the issuer referenced by the Certificate, or
+// an empty name if the issuer is not an ACME issuer.
+func acmeAccountKey(ctx context.Context, cmClient cmclient.Interface, crt *cmapi.Certificate, clusterResourceNamespace string) (string, string, error) {
+	if crt.Spec.IssuerRef.Group != "" && crt.Spec.IssuerRef.Group != certmanager.GroupName {
+		return "", "", nil
+	}
+
+	var (
+		issuer    cmapi.GenericIssuer
+		namespace string
+		err       error
+	)
+	switch crt.Spec.IssuerRef.Kind {
+	case "", cmapi.IssuerKind:
+		namespace = crt.Namespace
+		issuer, err = cmClient.CertmanagerV1().Issuers(namespace).Get(ctx, crt.Spec.IssuerRef.Name, metav1.GetOptions{})
+	case cmapi.ClusterIssuerKind:
+		namespace = clusterResourceNamespace
+		issuer, err = cmClient.CertmanagerV1().ClusterIssuers().Get(ctx, crt.Spec.IssuerRef.Name, metav1.GetOptions{})
+	default:
+		return "", "", nil
+	}
+	if apierrors.IsNotFound(err) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("error when getting %s %q of Certificate %s/%s: %w", crt.Spec.IssuerRef.Kind, crt.Spec.IssuerRef.Name, crt.Namespace, crt.Name, err)
+	}
+
+	acme := issuer.GetSpec().ACME
+	if acme == nil {
+		return "", "", nil
+	}
+	if acme.ExternalAccountBinding != nil && acme.ExternalAccountBinding.PerNamespace {
+		// ClusterIssuers with per-namespace accounts store the account key
+		// in the namespace of the Certificate.
+		namespace = crt.Namespace
+	}
+	return namespace, acme.PrivateKey.Name, nil
+}
+
+// sanitizeCertificate returns a copy of the Certificate without the metadata
+// which is set by the API server of the cluster it was exported from.
+// Owner references are removed since they refer to UIDs which will not exist
+// in the cluster the bundle is restored into.
+func sanitizeCertificate(crt *cmapi.Certificate) *cmapi.Certificate {
+	crt = crt.DeepCopy()
+	sanitizeObjectMeta(&crt.ObjectMeta)
+	crt.TypeMeta = metav1.TypeMeta{APIVersion: cmapi.SchemeGroupVersion.String(), Kind: cmapi.CertificateKind}
+	return crt
+}
+
+func sanitizeSecret(secret *corev1.Secret) *corev1.Secret {
+	secret = secret.DeepCopy()
+	sanitizeObjectMeta(&secret.ObjectMeta)
+	secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+	return secret
+}
+
+func sanitizeObjectMeta(meta *metav1.ObjectMeta) {
+	*meta = metav1.ObjectMeta{
+		Name:        meta.Name,
+		Namespace:   meta.Namespace,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+	delete(meta.Annotations, corev1.LastAppliedConfigAnnotation)
+}
+
+func less(a, b *metav1.ObjectMeta) bool {
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// Restore creates the Secrets and Certificates of the bundle. Secrets are
+// created before Certificates so that cert-manager finds the issued
+// certificates when it first syncs the Certificates, and keeps them until
+// they are due for renewal instead of re-issuing them. The revision, validity
+// and renewal time of each Certificate are restored to its status.
+// Resources which already exist are left unchanged. Progress is written to
+// out, and an error is returned if any resource could not be restored.
+func Restore(ctx context.Context, kubeClient kubernetes.Interface, cmClient cmclient.Interface, bundle *Bundle, out io.Writer) error {
+	if bundle.Version != BundleVersion {
+		return fmt.Errorf("unsupported backup bundle version %d, expected %d", bundle.Version, BundleVersion)
+	}
+
+	failed := 0
+	for _, secret := range bundle.Secrets {
+		_, err := kubeClient.CoreV1().Secrets(secret.Namespace).Create(ctx, sanitizeSecret(&secret), metav1.CreateOptions{})
+		switch {
+		case apierrors.IsAlreadyExists(err):
+			fmt.Fprintf(out, "Secret %s/%s already exists, skipping\n", secret.Namespace, secret.Name)
+		case err != nil:
+			fmt.Fprintf(out, "Secret %s/%s: failed to restore: %v\n", secret.Namespace, secret.Name, err)
+			failed++
+		default:
+			fmt.Fprintf(out, "Secret %s/%s restored\n", secret.Namespace, secret.Name)
+		}
+	}
+
+	for _, crt := range bundle.Certificates {
+		if err := restoreCertificate(ctx, cmClient, &crt, out); err != nil {
+			fmt.Fprintf(out, "Certificate %s/%s: failed to restore: %v\n", crt.Namespace, crt.Name, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d resources failed to restore", failed)
+	}
+	return nil
+}
+
+func restoreCertificate(ctx context.Context, cmClient cmclient.Interface, crt *cmapi.Certificate, out io.Writer) error {
+	created, err := cmClient.CertmanagerV1().Certificates(crt.Namespace).Create(ctx, sanitizeCertificate(crt), metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		fmt.Fprintf(out, "Certificate %s/%s already exists, skipping\n", crt.Namespace, crt.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Conditions are not restored, since they are re-computed by the
+	// controller from the restored Secret.
+	created.Status = cmapi.CertificateStatus{
+		Revision:    crt.Status.Revision,
+		NotBefore:   crt.Status.NotBefore,
+		NotAfter:    crt.Status.NotAfter,
+		RenewalTime: crt.Status.RenewalTime,
+	}
+	if _, err := cmClient.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, created, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("created, but failed to restore status: %w", err)
+	}
+
+	fmt.Fprintf(out, "Certificate %s/%s restored\n", crt.Namespace, crt.Name)
+	return nil
+}
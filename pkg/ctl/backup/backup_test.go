@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestExportRestore(t *testing.T) {
+	ctx := context.Background()
+	notAfter := metav1.NewTime(time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC))
+	renewalTime := metav1.NewTime(time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC))
+
+	issued := gen.Certificate("issued",
+		gen.SetCertificateNamespace("app"),
+		gen.SetCertificateSecretName("issued-tls"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "acme", Kind: cmapi.ClusterIssuerKind}),
+		gen.SetCertificateUID("old-uid"),
+		gen.SetCertificateRevision(3),
+		gen.SetCertificateNotAfter(notAfter),
+		gen.SetCertificateRenewalTime(renewalTime),
+	)
+	pending := gen.Certificate("pending",
+		gen.SetCertificateNamespace("app"),
+		gen.SetCertificateSecretName("pending-tls"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca", Kind: cmapi.IssuerKind}),
+	)
+	other := gen.Certificate("other",
+		gen.SetCertificateNamespace("other"),
+		gen.SetCertificateSecretName("other-tls"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "acme", Kind: cmapi.ClusterIssuerKind}),
+	)
+	acmeIssuer := gen.ClusterIssuer("acme", gen.SetIssuerACMEURL("https://acme.example.com"), gen.SetIssuerACMEPrivKeyRef("acme-account"))
+	caIssuer := gen.Issuer("ca", gen.SetIssuerNamespace("app"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}))
+
+	issuedSecret := gen.Secret("issued-tls",
+		gen.SetSecretNamespace("app"),
+		gen.SetSecretAnnotations(map[string]string{
+			cmapi.IssuerNameAnnotationKey:      "acme",
+			corev1.LastAppliedConfigAnnotation: "{}",
+		}),
+		gen.SetSecretData(map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")}),
+	)
+	issuedSecret.UID = "old-uid"
+	issuedSecret.ResourceVersion = "42"
+	accountSecret := gen.Secret("acme-account", gen.SetSecretNamespace("cert-manager"),
+		gen.SetSecretData(map[string][]byte{"tls.key": []byte("account-key")}))
+	unrelatedSecret := gen.Secret("unrelated", gen.SetSecretNamespace("app"))
+
+	kubeClient := kubefake.NewSimpleClientset(issuedSecret, accountSecret, unrelatedSecret)
+	cmClient := cmfake.NewSimpleClientset(issued, pending, other, acmeIssuer, caIssuer)
+
+	bundle, err := Export(ctx, kubeClient, cmClient, ExportOptions{
+		Namespace:                "app",
+		ClusterResourceNamespace: "cert-manager",
+		Clock:                    fakeclock.NewFakeClock(notAfter.Time),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, BundleVersion, bundle.Version)
+	assert.Equal(t, notAfter, bundle.CreatedAt)
+	if assert.Len(t, bundle.Certificates, 2) {
+		assert.Equal(t, "issued", bundle.Certificates[0].Name)
+		assert.Empty(t, bundle.Certificates[0].UID)
+		assert.Equal(t, 3, *bundle.Certificates[0].Status.Revision)
+		assert.Equal(t, "pending", bundle.Certificates[1].Name)
+	}
+	if assert.Len(t, bundle.Secrets, 2) {
+		assert.Equal(t, "app/issued-tls", bundle.Secrets[0].Namespace+"/"+bundle.Secrets[0].Name)
+		assert.Empty(t, bundle.Secrets[0].UID)
+		assert.Empty(t, bundle.Secrets[0].ResourceVersion)
+		assert.Equal(t, map[string]string{cmapi.IssuerNameAnnotationKey: "acme"}, bundle.Secrets[0].Annotations)
+		assert.Equal(t, "cert-manager/acme-account", bundle.Secrets[1].Namespace+"/"+bundle.Secrets[1].Name)
+	}
+
+	data, err := Encrypt(bundle, []byte("passphrase"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "account-key")
+
+	_, err = Decrypt(data, []byte("wrong"))
+	assert.Error(t, err)
+
+	decrypted, err := Decrypt(data, []byte("passphrase"))
+	require.NoError(t, err)
+
+	// The pending Certificate already exists in the cluster restored into.
+	restoreKubeClient := kubefake.NewSimpleClientset()
+	restoreCMClient := cmfake.NewSimpleClientset(gen.Certificate("pending", gen.SetCertificateNamespace("app")))
+	out := new(bytes.Buffer)
+	require.NoError(t, Restore(ctx, restoreKubeClient, restoreCMClient, decrypted, out))
+	assert.Equal(t, `Secret app/issued-tls restored
+Secret cert-manager/acme-account restored
+Certificate app/issued restored
+Certificate app/pending already exists, skipping
+`, out.String())
+
+	secret, err := restoreKubeClient.CoreV1().Secrets("app").Get(ctx, "issued-tls", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, issuedSecret.Data, secret.Data)
+
+	crt, err := restoreCMClient.CertmanagerV1().Certificates("app").Get(ctx, "issued", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, issued.Spec, crt.Spec)
+	assert.Equal(t, 3, *crt.Status.Revision)
+	assert.True(t, notAfter.Equal(crt.Status.NotAfter))
+	assert.True(t, renewalTime.Equal(crt.Status.RenewalTime))
+}
+
+func TestRestoreUnsupportedVersion(t *testing.T) {
+	err := Restore(context.Background(), kubefake.NewSimpleClientset(), cmfake.NewSimpleClientset(), &Bundle{Version: 2}, new(bytes.Buffer))
+	assert.EqualError(t, err, "unsupported backup bundle version 2, expected 1")
+}
+
+func TestEncryptRequiresPassphrase(t *testing.T) {
+	_, err := Encrypt(&Bundle{Version: BundleVersion}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// The scrypt parameters used to derive the AES-256 key from the passphrase.
+// They are stored in the envelope so that they can be raised in the future
+// without breaking existing bundles.
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 16
+)
+
+// envelope is the encrypted, portable representation of a Bundle.
+type envelope struct {
+	Version int `json:"version"`
+	// KDF holds the scrypt parameters and salt the key was derived with.
+	KDF struct {
+		N    int    `json:"n"`
+		R    int    `json:"r"`
+		P    int    `json:"p"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	// Nonce is the AES-GCM nonce.
+	Nonce []byte `json:"nonce"`
+	// Data is the AES-GCM encrypted JSON encoding of the Bundle.
+	Data []byte `json:"data"`
+}
+
+// Encrypt encodes the bundle and encrypts it with AES-256-GCM, using a key
+// derived from the passphrase with scrypt.
+func Encrypt(bundle *Bundle, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase must not be empty")
+	}
+
+	plaintext, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode backup bundle: %w", err)
+	}
+
+	env := envelope{Version: BundleVersion}
+	env.KDF.N, env.KDF.R, env.KDF.P = scryptN, scryptR, scryptP
+	env.KDF.Salt = make([]byte, saltLen)
+	if _, err := rand.Read(env.KDF.Salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(passphrase, &env)
+	if err != nil {
+		return nil, err
+	}
+	env.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return nil, err
+	}
+	env.Data = aead.Seal(nil, env.Nonce, plaintext, nil)
+
+	return json.Marshal(env)
+}
+
+// Decrypt decrypts a bundle encrypted by Encrypt.
+func Decrypt(data []byte, passphrase []byte) (*Bundle, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("failed to decode backup bundle: %w", err)
+	}
+	if env.Version != BundleVersion {
+		return nil, fmt.Errorf("unsupported backup bundle version %d, expected %d", env.Version, BundleVersion)
+	}
+
+	aead, err := newAEAD(passphrase, &env)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, errors.New("backup bundle has an invalid nonce")
+	}
+	plaintext, err := aead.Open(nil, env.Nonce, env.Data, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt backup bundle, the passphrase may be incorrect")
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(plaintext, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to decode backup bundle: %w", err)
+	}
+	return &bundle, nil
+}
+
+func newAEAD(passphrase []byte, env *envelope) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, env.KDF.Salt, env.KDF.N, env.KDF.R, env.KDF.P, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
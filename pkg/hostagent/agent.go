@@ -0,0 +1,319 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostagent
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// Agent writes the certificates of the configured Certificates to the host
+// whenever they are issued, and runs the reload hook of each projection after
+// its files have changed.
+type Agent struct {
+	log          logr.Logger
+	kubeClient   kubernetes.Interface
+	cmClient     cmclient.Interface
+	resyncPeriod time.Duration
+
+	projections []*projection
+}
+
+type projection struct {
+	Projection
+	informer cache.SharedIndexInformer
+	// reloadPending is set when the files have changed but the reload hook
+	// failed, so that the hook is retried even though the files are now up
+	// to date.
+	reloadPending bool
+}
+
+// New returns an Agent for the given configuration. Every resyncPeriod the
+// files of each projection are compared with the Certificate's Secret and
+// rewritten if they were changed on the host.
+func New(log logr.Logger, kubeClient kubernetes.Interface, cmClient cmclient.Interface, cfg *Config, resyncPeriod time.Duration) *Agent {
+	a := &Agent{
+		log:          log,
+		kubeClient:   kubeClient,
+		cmClient:     cmClient,
+		resyncPeriod: resyncPeriod,
+	}
+	for _, p := range cfg.Certificates {
+		a.projections = append(a.projections, &projection{Projection: p})
+	}
+	return a
+}
+
+// Run watches the configured Certificates until ctx is cancelled.
+func (a *Agent) Run(ctx context.Context) error {
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+
+	var synced []cache.InformerSynced
+	for i, p := range a.projections {
+		i := i
+		name := p.Name
+		p.informer = cminformers.NewFilteredCertificateInformer(a.cmClient, p.Namespace, a.resyncPeriod, cache.Indexers{}, func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		})
+		enqueue := func(interface{}) { queue.Add(i) }
+		p.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    enqueue,
+			UpdateFunc: func(_, obj interface{}) { enqueue(obj) },
+		})
+		go p.informer.Run(ctx.Done())
+		synced = append(synced, p.informer.HasSynced)
+	}
+
+	if !cache.WaitForCacheSync(ctx.Done(), synced...) {
+		return fmt.Errorf("error waiting for informer caches to sync")
+	}
+	a.log.V(2).Info("watching certificates", "count", len(a.projections))
+
+	// A single worker ensures that the files of a projection are never
+	// written concurrently.
+	go wait.Until(func() {
+		for a.processNextItem(ctx, queue) {
+		}
+	}, time.Second, ctx.Done())
+
+	<-ctx.Done()
+	return nil
+}
+
+func (a *Agent) processNextItem(ctx context.Context, queue workqueue.RateLimitingInterface) bool {
+	item, shutdown := queue.Get()
+	if shutdown {
+		return false
+	}
+	defer queue.Done(item)
+
+	p := a.projections[item.(int)]
+	log := a.log.WithValues("namespace", p.Namespace, "name", p.Name)
+
+	obj, exists, err := p.informer.GetStore().GetByKey(p.Namespace + "/" + p.Name)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	if !exists {
+		log.V(2).Info("certificate not found, leaving files in place")
+		queue.Forget(item)
+		return true
+	}
+
+	if err := a.sync(ctx, log, p, obj.(*cmapi.Certificate)); err != nil {
+		log.Error(err, "failed to project certificate")
+		queue.AddRateLimited(item)
+		return true
+	}
+	queue.Forget(item)
+	return true
+}
+
+// sync writes the files of a projection from the Secret of a Ready
+// Certificate, and runs the reload hook if any of them changed.
+func (a *Agent) sync(ctx context.Context, log logr.Logger, p *projection, crt *cmapi.Certificate) error {
+	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		log.V(2).Info("certificate is not ready, leaving files in place")
+		return nil
+	}
+
+	secret, err := a.kubeClient.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	certPEM, keyPEM, caPEM := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], secret.Data[cmmeta.TLSCAKey]
+	if err := verifyKeyPair(certPEM, keyPEM); err != nil {
+		return fmt.Errorf("secret %s/%s is not valid: %w", secret.Namespace, secret.Name, err)
+	}
+
+	if err := os.MkdirAll(p.Directory, 0755); err != nil {
+		return err
+	}
+
+	type file struct {
+		name string
+		data []byte
+		mode os.FileMode
+	}
+	files := []file{
+		{p.KeyFile, keyPEM, p.keyMode},
+		{p.CertFile, certPEM, 0644},
+	}
+	if len(caPEM) > 0 {
+		files = append(files, file{p.CAFile, caPEM, 0644})
+	}
+
+	changed := false
+	for _, f := range files {
+		written, err := writeFile(filepath.Join(p.Directory, f.name), f.data, f.mode, p.UID, p.GID)
+		if err != nil {
+			return err
+		}
+		changed = changed || written
+	}
+
+	if !changed && !p.reloadPending {
+		return nil
+	}
+	log.Info("certificate files updated", "directory", p.Directory)
+
+	if p.Reload == nil {
+		return nil
+	}
+	if err := reload(ctx, p.Reload); err != nil {
+		p.reloadPending = true
+		return fmt.Errorf("failed to reload: %w", err)
+	}
+	p.reloadPending = false
+	log.Info("reloaded")
+
+	return nil
+}
+
+func verifyKeyPair(certPEM, keyPEM []byte) error {
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return fmt.Errorf("missing %s or %s", corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		return err
+	}
+	key, err := pki.DecodePrivateKeyBytes(keyPEM)
+	if err != nil {
+		return err
+	}
+	matches, err := pki.PublicKeyMatchesCertificate(key.Public(), cert)
+	if err != nil {
+		return err
+	}
+	if !matches {
+		return fmt.Errorf("private key does not match certificate")
+	}
+	return nil
+}
+
+// writeFile atomically replaces the file at path with data, unless it already
+// has the given contents, mode and owner. It returns whether the file was
+// written.
+func writeFile(path string, data []byte, mode os.FileMode, uid, gid *int) (bool, error) {
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm() == mode && ownedBy(info, uid, gid) {
+		existing, err := os.ReadFile(path)
+		if err == nil && bytes.Equal(existing, data) {
+			return false, nil
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return false, err
+	}
+	if uid != nil || gid != nil {
+		if err := os.Chown(tmp.Name(), intOr(uid, -1), intOr(gid, -1)); err != nil {
+			return false, err
+		}
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func ownedBy(info os.FileInfo, uid, gid *int) bool {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return true
+	}
+	return (uid == nil || int(stat.Uid) == *uid) && (gid == nil || int(stat.Gid) == *gid)
+}
+
+func intOr(i *int, def int) int {
+	if i == nil {
+		return def
+	}
+	return *i
+}
+
+// reload runs the reload hook, executing its command and then signalling the
+// process in its PID file.
+func reload(ctx context.Context, r *Reload) error {
+	if len(r.Command) > 0 {
+		out, err := exec.CommandContext(ctx, r.Command[0], r.Command[1:]...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("command %q failed: %w: %s", r.Command[0], err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	if r.PIDFile != "" {
+		data, err := os.ReadFile(r.PIDFile)
+		if err != nil {
+			return err
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil || pid <= 0 {
+			return fmt.Errorf("invalid pid in %s: %q", r.PIDFile, strings.TrimSpace(string(data)))
+		}
+		if err := syscall.Kill(pid, r.signal); err != nil {
+			return fmt.Errorf("failed to send %s to %d: %w", r.signal, pid, err)
+		}
+	}
+
+	return nil
+}
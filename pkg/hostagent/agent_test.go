@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostagent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSync(t *testing.T) {
+	keyPEM := testcrypto.MustCreatePEMPrivateKey(t)
+	certPEM := testcrypto.MustCreateCert(t, keyPEM, gen.Certificate("host", gen.SetCertificateCommonName("host")))
+	otherKeyPEM := testcrypto.MustCreatePEMPrivateKey(t)
+
+	ready := gen.Certificate("host",
+		gen.SetCertificateNamespace("ssh"),
+		gen.SetCertificateSecretName("host-tls"),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+	)
+	notReady := gen.CertificateFrom(ready,
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse}),
+	)
+	secret := func(data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ssh", Name: "host-tls"}, Data: data}
+	}
+
+	tests := map[string]struct {
+		crt    *cmapi.Certificate
+		secret *corev1.Secret
+		// existing files in the directory before syncing
+		existing      map[string]string
+		reloadPending bool
+
+		expFiles  map[string]string
+		expReload bool
+		expErr    bool
+	}{
+		"certificate not ready leaves files unchanged": {
+			crt:      notReady,
+			secret:   secret(map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM}),
+			expFiles: map[string]string{},
+		},
+		"files are written and reload is run": {
+			crt:       ready,
+			secret:    secret(map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM, "ca.crt": certPEM}),
+			expFiles:  map[string]string{"host.crt": string(certPEM), "host.key": string(keyPEM), "ca.crt": string(certPEM)},
+			expReload: true,
+		},
+		"unchanged files do not trigger a reload": {
+			crt:      ready,
+			secret:   secret(map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM}),
+			existing: map[string]string{"host.crt": string(certPEM), "host.key": string(keyPEM)},
+			expFiles: map[string]string{"host.crt": string(certPEM), "host.key": string(keyPEM)},
+		},
+		"a failed reload is retried although the files are unchanged": {
+			crt:           ready,
+			secret:        secret(map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM}),
+			existing:      map[string]string{"host.crt": string(certPEM), "host.key": string(keyPEM)},
+			reloadPending: true,
+			expFiles:      map[string]string{"host.crt": string(certPEM), "host.key": string(keyPEM)},
+			expReload:     true,
+		},
+		"files modified on the host are restored": {
+			crt:       ready,
+			secret:    secret(map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM}),
+			existing:  map[string]string{"host.crt": "modified", "host.key": string(keyPEM)},
+			expFiles:  map[string]string{"host.crt": string(certPEM), "host.key": string(keyPEM)},
+			expReload: true,
+		},
+		"mismatched private key is not written": {
+			crt:      ready,
+			secret:   secret(map[string][]byte{"tls.crt": certPEM, "tls.key": otherKeyPEM}),
+			expFiles: map[string]string{},
+			expErr:   true,
+		},
+		"missing secret returns an error": {
+			crt:      ready,
+			expFiles: map[string]string{},
+			expErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "ssh")
+			for name, data := range test.existing {
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				mode := os.FileMode(0644)
+				if name == "host.key" {
+					mode = 0600
+				}
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(data), mode); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			marker := filepath.Join(t.TempDir(), "reloaded")
+			p := &projection{
+				Projection: Projection{
+					Namespace: "ssh",
+					Name:      "host",
+					Directory: dir,
+					CertFile:  "host.crt",
+					KeyFile:   "host.key",
+					CAFile:    "ca.crt",
+					keyMode:   0600,
+					Reload:    &Reload{Command: []string{"touch", marker}},
+				},
+				reloadPending: test.reloadPending,
+			}
+
+			kubeClient := fake.NewSimpleClientset()
+			if test.secret != nil {
+				kubeClient = fake.NewSimpleClientset(test.secret)
+			}
+			a := &Agent{log: logtesting.NewTestLogger(t), kubeClient: kubeClient}
+
+			err := a.sync(context.TODO(), a.log, p, test.crt)
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			files := map[string]string{}
+			entries, _ := os.ReadDir(dir)
+			for _, e := range entries {
+				data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				files[e.Name()] = string(data)
+			}
+			assert.Equal(t, test.expFiles, files)
+
+			if info, err := os.Stat(filepath.Join(dir, "host.key")); err == nil {
+				assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+			}
+
+			_, err = os.Stat(marker)
+			assert.Equal(t, test.expReload, err == nil, "unexpected reload")
+			assert.False(t, p.reloadPending)
+		})
+	}
+}
+
+func TestReloadFailure(t *testing.T) {
+	keyPEM := testcrypto.MustCreatePEMPrivateKey(t)
+	certPEM := testcrypto.MustCreateCert(t, keyPEM, gen.Certificate("host", gen.SetCertificateCommonName("host")))
+
+	crt := gen.Certificate("host",
+		gen.SetCertificateNamespace("ssh"),
+		gen.SetCertificateSecretName("host-tls"),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+	)
+	a := &Agent{
+		log: logtesting.NewTestLogger(t),
+		kubeClient: fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ssh", Name: "host-tls"},
+			Data:       map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM},
+		}),
+	}
+	p := &projection{Projection: Projection{
+		Directory: t.TempDir(),
+		CertFile:  "tls.crt",
+		KeyFile:   "tls.key",
+		CAFile:    "ca.crt",
+		keyMode:   0600,
+		Reload:    &Reload{PIDFile: filepath.Join(t.TempDir(), "missing.pid")},
+	}}
+
+	err := a.sync(context.TODO(), a.log, p, crt)
+	assert.Error(t, err)
+	assert.True(t, p.reloadPending, "expected failed reload to be retried")
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hostagent projects the certificates of selected Certificates to
+// files on the host, so that daemons which do not run in Kubernetes, such as
+// sshd or the kubelet, can use certificates managed by cert-manager.
+package hostagent
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// nodeNameVar is replaced with the name of the node the agent runs on in the
+// namespace, name and directory of each projection.
+const nodeNameVar = "${NODE_NAME}"
+
+// Config is the configuration of the agent. It is read from a file rather
+// than from annotations on Certificates, as the agent writes to the host
+// and runs reload hooks with its own privileges.
+type Config struct {
+	// Certificates are the Certificates projected to the host.
+	Certificates []Projection `json:"certificates"`
+}
+
+// Projection projects the certificate of a Certificate to a directory.
+type Projection struct {
+	// Namespace and Name of the Certificate.
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// Directory is the directory the files are written to.
+	Directory string `json:"directory"`
+
+	// CertFile, KeyFile and CAFile are the names of the files in Directory
+	// holding the certificate, private key and CA. Default to tls.crt,
+	// tls.key and ca.crt. The CA is not written if the Secret has no CA.
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+	CAFile   string `json:"caFile,omitempty"`
+
+	// KeyMode is the octal file mode of the private key. Defaults to 0600.
+	// The certificate and CA are always readable by all users.
+	KeyMode string `json:"keyMode,omitempty"`
+
+	// UID and GID are the owner of the files. If not set, the files are
+	// owned by the user running the agent.
+	UID *int `json:"uid,omitempty"`
+	GID *int `json:"gid,omitempty"`
+
+	// Reload is run after any of the files have changed.
+	Reload *Reload `json:"reload,omitempty"`
+
+	keyMode os.FileMode
+}
+
+// Reload is a hook which makes a daemon reload its certificate.
+type Reload struct {
+	// Command is executed without a shell.
+	Command []string `json:"command,omitempty"`
+
+	// PIDFile is a file holding the process ID of a process which is sent
+	// Signal. Signalling processes on the host requires the agent to run
+	// in the host's PID namespace.
+	PIDFile string `json:"pidFile,omitempty"`
+	// Signal is the name of the signal sent, e.g. SIGHUP. Defaults to
+	// SIGHUP.
+	Signal string `json:"signal,omitempty"`
+
+	signal syscall.Signal
+}
+
+var signals = map[string]syscall.Signal{
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGUSR1": syscall.SIGUSR1,
+	"SIGUSR2": syscall.SIGUSR2,
+	"SIGTERM": syscall.SIGTERM,
+}
+
+// LoadConfig reads the configuration at path for the named node, applying
+// defaults and validating each projection.
+func LoadConfig(path, nodeName string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for i := range cfg.Certificates {
+		if err := cfg.Certificates[i].complete(nodeName); err != nil {
+			return nil, fmt.Errorf("invalid certificates[%d]: %w", i, err)
+		}
+	}
+
+	return &cfg, nil
+}
+
+func (p *Projection) complete(nodeName string) error {
+	expand := func(s string) string {
+		return strings.ReplaceAll(s, nodeNameVar, nodeName)
+	}
+	p.Namespace, p.Name, p.Directory = expand(p.Namespace), expand(p.Name), expand(p.Directory)
+
+	if p.Namespace == "" || p.Name == "" {
+		return fmt.Errorf("namespace and name must be set")
+	}
+	if !filepath.IsAbs(p.Directory) {
+		return fmt.Errorf("directory must be an absolute path, got %q", p.Directory)
+	}
+
+	if p.CertFile == "" {
+		p.CertFile = corev1.TLSCertKey
+	}
+	if p.KeyFile == "" {
+		p.KeyFile = corev1.TLSPrivateKeyKey
+	}
+	if p.CAFile == "" {
+		p.CAFile = cmmeta.TLSCAKey
+	}
+	for _, name := range []string{p.CertFile, p.KeyFile, p.CAFile} {
+		if name != filepath.Base(name) {
+			return fmt.Errorf("file %q must be a name within the directory", name)
+		}
+	}
+
+	p.keyMode = 0600
+	if p.KeyMode != "" {
+		mode, err := strconv.ParseUint(p.KeyMode, 8, 32)
+		if err != nil || mode > 0777 {
+			return fmt.Errorf("keyMode must be an octal file mode, got %q", p.KeyMode)
+		}
+		p.keyMode = os.FileMode(mode)
+	}
+
+	if r := p.Reload; r != nil {
+		if len(r.Command) == 0 && r.PIDFile == "" {
+			return fmt.Errorf("reload must set a command or pidFile")
+		}
+		r.signal = syscall.SIGHUP
+		if r.Signal != "" {
+			signal, ok := signals[strings.ToUpper(r.Signal)]
+			if !ok {
+				return fmt.Errorf("unsupported reload signal %q", r.Signal)
+			}
+			r.signal = signal
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostagent
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := map[string]struct {
+		config string
+		exp    []Projection
+		expErr string
+	}{
+		"defaults are applied and the node name is expanded": {
+			config: `
+certificates:
+- namespace: kube-system
+  name: kubelet-${NODE_NAME}
+  directory: /var/lib/kubelet/pki/${NODE_NAME}
+  reload:
+    pidFile: /run/kubelet.pid
+`,
+			exp: []Projection{{
+				Namespace: "kube-system",
+				Name:      "kubelet-node-1",
+				Directory: "/var/lib/kubelet/pki/node-1",
+				CertFile:  "tls.crt",
+				KeyFile:   "tls.key",
+				CAFile:    "ca.crt",
+				Reload:    &Reload{PIDFile: "/run/kubelet.pid", signal: syscall.SIGHUP},
+				keyMode:   0600,
+			}},
+		},
+		"file names, mode and signal are read": {
+			config: `
+certificates:
+- namespace: ssh
+  name: host
+  directory: /etc/ssh
+  certFile: host.pem
+  keyFile: host.key
+  caFile: ca.pem
+  keyMode: "0640"
+  reload:
+    command: ["systemctl", "reload", "sshd"]
+    pidFile: /run/sshd.pid
+    signal: sigusr1
+`,
+			exp: []Projection{{
+				Namespace: "ssh",
+				Name:      "host",
+				Directory: "/etc/ssh",
+				CertFile:  "host.pem",
+				KeyFile:   "host.key",
+				CAFile:    "ca.pem",
+				KeyMode:   "0640",
+				Reload: &Reload{
+					Command: []string{"systemctl", "reload", "sshd"},
+					PIDFile: "/run/sshd.pid",
+					Signal:  "sigusr1",
+					signal:  syscall.SIGUSR1,
+				},
+				keyMode: 0640,
+			}},
+		},
+		"unknown fields are rejected": {
+			config: "certificates:\n- namespace: a\n  name: b\n  directory: /c\n  owner: root\n",
+			expErr: "failed to parse",
+		},
+		"relative directory is rejected": {
+			config: "certificates:\n- namespace: a\n  name: b\n  directory: c\n",
+			expErr: "invalid certificates[0]: directory must be an absolute path",
+		},
+		"file names outside the directory are rejected": {
+			config: "certificates:\n- namespace: a\n  name: b\n  directory: /c\n  keyFile: ../key\n",
+			expErr: `invalid certificates[0]: file "../key" must be a name within the directory`,
+		},
+		"invalid key mode is rejected": {
+			config: "certificates:\n- namespace: a\n  name: b\n  directory: /c\n  keyMode: \"rw\"\n",
+			expErr: `invalid certificates[0]: keyMode must be an octal file mode, got "rw"`,
+		},
+		"empty reload is rejected": {
+			config: "certificates:\n- namespace: a\n  name: b\n  directory: /c\n  reload: {}\n",
+			expErr: "invalid certificates[0]: reload must set a command or pidFile",
+		},
+		"unsupported signal is rejected": {
+			config: "certificates:\n- namespace: a\n  name: b\n  directory: /c\n  reload:\n    pidFile: /run/x.pid\n    signal: SIGKILL\n",
+			expErr: `invalid certificates[0]: unsupported reload signal "SIGKILL"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(test.config), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := LoadConfig(path, "node-1")
+			if test.expErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), test.expErr)
+				}
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.exp, cfg.Certificates)
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"crypto"
 	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	core "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/record"
@@ -41,6 +43,7 @@ type Acme struct {
 	issuer v1.GenericIssuer
 
 	secretsClient core.SecretsGetter
+	secretLister  secretMetadataLister
 	recorder      record.EventRecorder
 
 	// keyFromSecret returns a decoded account key from a Kubernetes secret.
@@ -68,13 +71,14 @@ func New(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, er
 		return nil, fmt.Errorf("acme config may not be empty")
 	}
 
-	secretsLister := ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister()
+	secretsLister := controller.NewSecretGetter(ctx.KubeSharedInformerFactory)
 
 	a := &Acme{
 		issuer:                   issuer,
 		keyFromSecret:            newKeyFromSecret(secretsLister),
 		clientBuilder:            accounts.NewClient,
 		secretsClient:            ctx.Client.CoreV1(),
+		secretLister:             secretsLister,
 		recorder:                 ctx.Recorder,
 		clusterResourceNamespace: ctx.IssuerOptions.ClusterResourceNamespace,
 		accountRegistry:          ctx.ACMEOptions.AccountRegistry,
@@ -85,6 +89,12 @@ func New(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, er
 	return a, nil
 }
 
+// secretMetadataLister lists the metadata of Secrets, such as to find the
+// namespaces containing External Account Binding credentials.
+type secretMetadataLister interface {
+	ListMetadata(selector labels.Selector) ([]metav1.Object, error)
+}
+
 // keyFromSecretFunc accepts name, namespace and keyName for secret, verifies
 // and returns a private key stored at keyName.
 type keyFromSecretFunc func(ctx context.Context, namespace, name, keyName string) (crypto.Signer, error)
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/miekg/dns"
 	"github.com/pkg/errors"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -39,6 +40,8 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/clouddns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/digitalocean"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/externaldns"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/hetzner"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/rfc2136"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/route53"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
@@ -94,7 +97,7 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.DNS01Nameservers...)
+	fqdn, err := s.challengeFQDN(ch.Spec.DNSName, providerConfig)
 	if err != nil {
 		return err
 	}
@@ -152,7 +155,7 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.DNS01Nameservers...)
+	fqdn, err := s.challengeFQDN(ch.Spec.DNSName, providerConfig)
 	if err != nil {
 		return err
 	}
@@ -164,6 +167,35 @@ func followCNAME(strategy cmacme.CNAMEStrategy) bool {
 	return strategy == cmacme.FollowStrategy
 }
 
+// challengeFQDN returns the name of the challenge record for dnsName,
+// following CNAME records if configured to do so.
+func (s *Solver) challengeFQDN(dnsName string, cfg *cmacme.ACMEChallengeSolverDNS01) (string, error) {
+	fqdn, err := util.DNS01LookupFQDN(dnsName, followCNAME(cfg.CNAMEStrategy), s.DNS01Nameservers...)
+	if err != nil {
+		return "", err
+	}
+	if err := checkValidationZone(dnsName, fqdn, cfg.ValidationZone); err != nil {
+		return "", err
+	}
+	return fqdn, nil
+}
+
+// checkValidationZone returns an error if a validation zone is configured
+// and the challenge record for dnsName is not within it, which is the case
+// if the CNAME record delegating the challenge to the validation zone is
+// missing or points elsewhere. Challenge records are then not presented, as
+// the solver would otherwise update a zone it was not configured for.
+func checkValidationZone(dnsName, fqdn, zone string) error {
+	if len(zone) == 0 {
+		return nil
+	}
+	if !dns.IsSubDomain(util.ToFqdn(zone), fqdn) {
+		return fmt.Errorf("challenge record %q for %q is not within the validation zone %q: a CNAME record must delegate %q to the validation zone",
+			fqdn, dnsName, zone, fmt.Sprintf("_acme-challenge.%s", dnsName))
+	}
+	return nil
+}
+
 func extractChallengeSolverConfig(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolverDNS01, error) {
 	if ch.Spec.Solver.DNS01 == nil {
 		return nil, fmt.Errorf("no dns01 challenge solver configuration found")
@@ -186,6 +218,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 	if err != nil {
 		return nil, nil, err
 	}
+	providerConfig = configForDomain(providerConfig, ch.Spec.DNSName)
 
 	var impl solver
 	switch {
@@ -427,14 +460,19 @@ func (s *Solver) prepareChallengeRequest(issuer v1.GenericIssuer, ch *cmacme.Cha
 		return nil, nil, err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(dns01Config.CNAMEStrategy), s.DNS01Nameservers...)
+	fqdn, err := s.challengeFQDN(ch.Spec.DNSName, dns01Config)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	zone, err := util.FindZoneByFqdn(fqdn, s.DNS01Nameservers)
-	if err != nil {
-		return nil, nil, err
+	// a dedicated validation zone is used as is, as the SOA records of
+	// delegated zones are often not visible to cert-manager
+	zone := util.ToFqdn(dns01Config.ValidationZone)
+	if len(zone) == 0 {
+		zone, err = util.FindZoneByFqdn(fqdn, s.DNS01Nameservers)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	resourceNamespace := s.ResourceNamespace(issuer)
@@ -474,6 +512,12 @@ func (s *Solver) dns01SolverForConfig(config *cmacme.ACMEChallengeSolverDNS01) (
 	case config.RFC2136 != nil:
 		solverName = "rfc2136"
 		c = config.RFC2136
+	case config.ExternalDNS != nil:
+		solverName = "externaldns"
+		c = config.ExternalDNS
+	case config.Hetzner != nil:
+		solverName = "hetzner"
+		c = config.Hetzner
 	}
 	if solverName == "" {
 		return nil, nil, errNotFound
@@ -491,6 +535,8 @@ func NewSolver(ctx *controller.Context) (*Solver, error) {
 	webhookSolvers := []webhook.Solver{
 		&webhookslv.Webhook{},
 		rfc2136.New(rfc2136.WithNamespace(ctx.Namespace)),
+		externaldns.New(),
+		hetzner.New(hetzner.WithNamespace(ctx.Namespace)),
 	}
 
 	initialized := make(map[string]webhook.Solver)
@@ -510,7 +556,7 @@ func NewSolver(ctx *controller.Context) (*Solver, error) {
 
 	return &Solver{
 		Context:      ctx,
-		secretLister: ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		secretLister: controller.NewSecretGetter(ctx.KubeSharedInformerFactory),
 		dnsProviderConstructors: dnsProviderConstructors{
 			clouddns.NewDNSProvider,
 			cloudflare.NewDNSProviderCredentials,
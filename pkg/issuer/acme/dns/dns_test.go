@@ -655,3 +655,45 @@ func TestRoute53AssumeRole(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckValidationZone(t *testing.T) {
+	tests := map[string]struct {
+		fqdn    string
+		zone    string
+		wantErr bool
+	}{
+		"no validation zone": {
+			fqdn: "_acme-challenge.apps.example.com.",
+		},
+		"record within the validation zone": {
+			fqdn: "apps.acme.example.com.",
+			zone: "acme.example.com",
+		},
+		"record within the validation zone with a trailing dot": {
+			fqdn: "apps.acme.example.com.",
+			zone: "acme.example.com.",
+		},
+		"record within the validation zone with a different case": {
+			fqdn: "apps.ACME.example.com.",
+			zone: "acme.example.com",
+		},
+		"CNAME record is missing": {
+			fqdn:    "_acme-challenge.apps.example.com.",
+			zone:    "acme.example.com",
+			wantErr: true,
+		},
+		"CNAME record points to another zone": {
+			fqdn:    "apps.acme.example.net.",
+			zone:    "acme.example.com",
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkValidationZone("apps.example.com", test.fqdn, test.zone)
+			if test.wantErr != (err != nil) {
+				t.Errorf("expected error %t, got: %v", test.wantErr, err)
+			}
+		})
+	}
+}
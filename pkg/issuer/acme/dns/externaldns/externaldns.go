@@ -0,0 +1,301 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package externaldns implements a DNS01 solver which publishes challenge
+// records through ExternalDNS, by managing DNSEndpoint resources which are
+// read by ExternalDNS' crd source.
+package externaldns
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// DNSEndpointResource is the resource of ExternalDNS' DNSEndpoints.
+var DNSEndpointResource = schema.GroupVersionResource{Group: "externaldns.k8s.io", Version: "v1alpha1", Resource: "dnsendpoints"}
+
+const recordTypeTXT = "TXT"
+
+// dnsEndpoint holds the fields of ExternalDNS' DNSEndpoint which are used by
+// the solver. It is defined here rather than imported to avoid depending on
+// ExternalDNS.
+type dnsEndpoint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   dnsEndpointSpec   `json:"spec,omitempty"`
+	Status dnsEndpointStatus `json:"status,omitempty"`
+}
+
+type dnsEndpointSpec struct {
+	Endpoints []endpoint `json:"endpoints,omitempty"`
+}
+
+type endpoint struct {
+	DNSName    string   `json:"dnsName,omitempty"`
+	Targets    []string `json:"targets,omitempty"`
+	RecordType string   `json:"recordType,omitempty"`
+	RecordTTL  int64    `json:"recordTTL,omitempty"`
+}
+
+type dnsEndpointStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// Solver presents DNS01 challenges by adding their key to the targets of a
+// DNSEndpoint holding a TXT record for the challenge's FQDN. A single
+// DNSEndpoint is used for each FQDN, since ExternalDNS only publishes one
+// endpoint per DNS name and record type.
+type Solver struct {
+	client dynamic.Interface
+}
+
+// New returns a new ExternalDNS solver.
+func New() *Solver {
+	return &Solver{}
+}
+
+func (s *Solver) Name() string {
+	return "externaldns"
+}
+
+func (s *Solver) Initialize(kubeClientConfig *rest.Config, _ <-chan struct{}) error {
+	client, err := dynamic.NewForConfig(kubeClientConfig)
+	if err != nil {
+		return err
+	}
+	s.client = client
+	return nil
+}
+
+// Present ensures the challenge's key is a target of the DNSEndpoint for its
+// FQDN. An error is returned until ExternalDNS has observed the latest
+// generation of the DNSEndpoint, so that the challenge is not checked before
+// its record has been published.
+func (s *Solver) Present(ch *v1alpha1.ChallengeRequest) error {
+	ctx := context.TODO()
+	cfg, err := loadConfig(ch)
+	if err != nil {
+		return err
+	}
+
+	client := s.client.Resource(DNSEndpointResource).Namespace(ch.ResourceNamespace)
+	dnsName := strings.TrimSuffix(ch.ResolvedFQDN, ".")
+	name := endpointName(dnsName)
+
+	u, err := client.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		ep := &dnsEndpoint{
+			TypeMeta:   metav1.TypeMeta{APIVersion: DNSEndpointResource.GroupVersion().String(), Kind: "DNSEndpoint"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ch.ResourceNamespace, Labels: cfg.Labels},
+		}
+		ep.Spec.Endpoints = []endpoint{desiredEndpoint(dnsName, cfg, []string{ch.Key})}
+		if u, err = toUnstructured(ep); err != nil {
+			return err
+		}
+		if u, err = client.Create(ctx, u, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create DNSEndpoint %s/%s: %w", ch.ResourceNamespace, name, err)
+		}
+		logf.Log.V(logf.DebugLevel).Info("created DNSEndpoint", "namespace", ch.ResourceNamespace, "name", name)
+	} else if err != nil {
+		return err
+	}
+
+	ep, err := fromUnstructured(u)
+	if err != nil {
+		return err
+	}
+	targets := targetsOf(ep, dnsName)
+	if !contains(targets, ch.Key) {
+		targets = append(targets, ch.Key)
+	}
+	desired := desiredEndpoint(dnsName, cfg, targets)
+	if len(ep.Spec.Endpoints) != 1 || !equalEndpoints(ep.Spec.Endpoints[0], desired) || !hasLabels(ep.Labels, cfg.Labels) {
+		ep.Spec.Endpoints = []endpoint{desired}
+		if ep.Labels == nil && len(cfg.Labels) > 0 {
+			ep.Labels = make(map[string]string, len(cfg.Labels))
+		}
+		for k, v := range cfg.Labels {
+			ep.Labels[k] = v
+		}
+		if u, err = s.update(ctx, client, ep); err != nil {
+			return err
+		}
+		if ep, err = fromUnstructured(u); err != nil {
+			return err
+		}
+	}
+
+	if ep.Status.ObservedGeneration < ep.Generation {
+		return fmt.Errorf("waiting for ExternalDNS to publish DNSEndpoint %s/%s", ch.ResourceNamespace, name)
+	}
+	return nil
+}
+
+// CleanUp removes the challenge's key from the targets of the DNSEndpoint for
+// its FQDN, deleting the DNSEndpoint once no targets remain.
+func (s *Solver) CleanUp(ch *v1alpha1.ChallengeRequest) error {
+	ctx := context.TODO()
+	cfg, err := loadConfig(ch)
+	if err != nil {
+		return err
+	}
+
+	client := s.client.Resource(DNSEndpointResource).Namespace(ch.ResourceNamespace)
+	dnsName := strings.TrimSuffix(ch.ResolvedFQDN, ".")
+	name := endpointName(dnsName)
+
+	u, err := client.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ep, err := fromUnstructured(u)
+	if err != nil {
+		return err
+	}
+
+	var targets []string
+	for _, t := range targetsOf(ep, dnsName) {
+		if t != ch.Key {
+			targets = append(targets, t)
+		}
+	}
+	if len(targets) == 0 {
+		err := client.Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete DNSEndpoint %s/%s: %w", ch.ResourceNamespace, name, err)
+		}
+		return nil
+	}
+
+	ep.Spec.Endpoints = []endpoint{desiredEndpoint(dnsName, cfg, targets)}
+	_, err = s.update(ctx, client, ep)
+	return err
+}
+
+func (s *Solver) update(ctx context.Context, client dynamic.ResourceInterface, ep *dnsEndpoint) (*unstructured.Unstructured, error) {
+	u, err := toUnstructured(ep)
+	if err != nil {
+		return nil, err
+	}
+	u, err = client.Update(ctx, u, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update DNSEndpoint %s/%s: %w", ep.Namespace, ep.Name, err)
+	}
+	return u, nil
+}
+
+func loadConfig(ch *v1alpha1.ChallengeRequest) (*cmacme.ACMEIssuerDNS01ProviderExternalDNS, error) {
+	cfg := &cmacme.ACMEIssuerDNS01ProviderExternalDNS{}
+	if ch.Config == nil {
+		return cfg, nil
+	}
+	if err := json.Unmarshal(ch.Config.Raw, cfg); err != nil {
+		return nil, fmt.Errorf("error decoding solver config: %v", err)
+	}
+	return cfg, nil
+}
+
+// endpointName returns the name of the DNSEndpoint for the given DNS name.
+// DNS names may be longer than a resource name, so a hash is used.
+func endpointName(dnsName string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(dnsName)))
+	return "cm-acme-dns01-" + hex.EncodeToString(sum[:8])
+}
+
+func desiredEndpoint(dnsName string, cfg *cmacme.ACMEIssuerDNS01ProviderExternalDNS, targets []string) endpoint {
+	ep := endpoint{DNSName: dnsName, RecordType: recordTypeTXT, Targets: targets}
+	if cfg.RecordTTL != nil {
+		ep.RecordTTL = *cfg.RecordTTL
+	}
+	return ep
+}
+
+// targetsOf returns the targets of TXT records for dnsName in the DNSEndpoint.
+func targetsOf(ep *dnsEndpoint, dnsName string) []string {
+	var targets []string
+	for _, e := range ep.Spec.Endpoints {
+		if e.RecordType == recordTypeTXT && strings.EqualFold(e.DNSName, dnsName) {
+			targets = append(targets, e.Targets...)
+		}
+	}
+	return targets
+}
+
+func equalEndpoints(a, b endpoint) bool {
+	if a.DNSName != b.DNSName || a.RecordType != b.RecordType || a.RecordTTL != b.RecordTTL || len(a.Targets) != len(b.Targets) {
+		return false
+	}
+	for i := range a.Targets {
+		if a.Targets[i] != b.Targets[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func hasLabels(labels, want map[string]string) bool {
+	for k, v := range want {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func toUnstructured(ep *dnsEndpoint) (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ep)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+func fromUnstructured(u *unstructured.Unstructured) (*dnsEndpoint, error) {
+	ep := &dnsEndpoint{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, ep); err != nil {
+		return nil, fmt.Errorf("failed to decode DNSEndpoint: %w", err)
+	}
+	return ep, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+)
+
+func newTestSolver() *Solver {
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		DNSEndpointResource: "DNSEndpointList",
+	})
+	return &Solver{client: client}
+}
+
+func challengeRequest(key string) *v1alpha1.ChallengeRequest {
+	return &v1alpha1.ChallengeRequest{
+		ResolvedFQDN:      "_acme-challenge.example.com.",
+		ResourceNamespace: "default",
+		Key:               key,
+		Config:            &apiextensionsv1.JSON{Raw: []byte(`{"labels":{"external-dns":"internal"},"recordTTL":60}`)},
+	}
+}
+
+func (s *Solver) getEndpoint(t *testing.T) (*dnsEndpoint, error) {
+	u, err := s.client.Resource(DNSEndpointResource).Namespace("default").Get(context.TODO(), endpointName("_acme-challenge.example.com"), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ep, err := fromUnstructured(u)
+	require.NoError(t, err)
+	return ep, nil
+}
+
+func TestPresentAndCleanUp(t *testing.T) {
+	s := newTestSolver()
+
+	require.NoError(t, s.Present(challengeRequest("key-1")))
+	// Presenting again, and presenting a second challenge for the same FQDN
+	// such as the one of a wildcard, adds each key once.
+	require.NoError(t, s.Present(challengeRequest("key-1")))
+	require.NoError(t, s.Present(challengeRequest("key-2")))
+
+	ep, err := s.getEndpoint(t)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"external-dns": "internal"}, ep.Labels)
+	assert.Equal(t, []endpoint{{
+		DNSName:    "_acme-challenge.example.com",
+		RecordType: "TXT",
+		RecordTTL:  60,
+		Targets:    []string{"key-1", "key-2"},
+	}}, ep.Spec.Endpoints)
+
+	require.NoError(t, s.CleanUp(challengeRequest("key-1")))
+	ep, err = s.getEndpoint(t)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"key-2"}, ep.Spec.Endpoints[0].Targets)
+
+	require.NoError(t, s.CleanUp(challengeRequest("key-2")))
+	_, err = s.getEndpoint(t)
+	assert.True(t, apierrors.IsNotFound(err), "expected DNSEndpoint to be deleted, got %v", err)
+
+	// Cleaning up a challenge without a DNSEndpoint succeeds.
+	require.NoError(t, s.CleanUp(challengeRequest("key-2")))
+}
+
+func TestPresentWaitsForExternalDNS(t *testing.T) {
+	s := newTestSolver()
+	require.NoError(t, s.Present(challengeRequest("key-1")))
+
+	ep, err := s.getEndpoint(t)
+	require.NoError(t, err)
+	// The fake client does not set the generation, so simulate an update
+	// which ExternalDNS has not yet observed.
+	ep.Generation = 2
+	ep.Status.ObservedGeneration = 1
+	_, err = s.update(context.TODO(), s.client.Resource(DNSEndpointResource).Namespace("default"), ep)
+	require.NoError(t, err)
+
+	assert.EqualError(t, s.Present(challengeRequest("key-1")), "waiting for ExternalDNS to publish DNSEndpoint default/"+ep.Name)
+
+	ep.Status.ObservedGeneration = 2
+	_, err = s.update(context.TODO(), s.client.Resource(DNSEndpointResource).Namespace("default"), ep)
+	require.NoError(t, err)
+	assert.NoError(t, s.Present(challengeRequest("key-1")))
+}
@@ -0,0 +1,330 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hetzner implements a DNS01 solver which manages challenge records
+// using the Hetzner DNS API.
+package hetzner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	restclient "k8s.io/client-go/rest"
+
+	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// DefaultAPIEndpoint is the base URL of the Hetzner DNS API.
+	DefaultAPIEndpoint = "https://dns.hetzner.com/api/v1"
+
+	// recordTTL is the TTL of the TXT records created by the solver.
+	recordTTL = 60
+)
+
+type Solver struct {
+	secretLister corelisters.SecretLister
+
+	// If specified, namespace will cause the hetzner provider to limit the
+	// scope of the lister/watcher to a single namespace, to allow for
+	// namespace restricted instances of cert-manager.
+	namespace string
+
+	apiEndpoint string
+	httpClient  *http.Client
+}
+
+type Option func(*Solver)
+
+func WithNamespace(ns string) Option {
+	return func(s *Solver) {
+		s.namespace = ns
+	}
+}
+
+// WithAPIEndpoint overrides the base URL of the Hetzner DNS API, and is used
+// to run the solver against a fake API in tests.
+func WithAPIEndpoint(endpoint string) Option {
+	return func(s *Solver) {
+		s.apiEndpoint = endpoint
+	}
+}
+
+func New(opts ...Option) *Solver {
+	s := &Solver{
+		apiEndpoint: DefaultAPIEndpoint,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+func (s *Solver) Name() string {
+	return "hetzner"
+}
+
+func (s *Solver) Initialize(kubeClientConfig *restclient.Config, stopCh <-chan struct{}) error {
+	cl, err := kubernetes.NewForConfig(kubeClientConfig)
+	if err != nil {
+		return err
+	}
+
+	// obtain a secret lister and start the informer factory to populate the
+	// secret cache
+	factory := informers.NewSharedInformerFactoryWithOptions(cl, time.Minute*5, informers.WithNamespace(s.namespace))
+	s.secretLister = factory.Core().V1().Secrets().Lister()
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+
+	return nil
+}
+
+// Present creates a TXT record holding the challenge's key, unless one
+// already exists.
+func (s *Solver) Present(ch *whapi.ChallengeRequest) error {
+	c, err := s.client(ch)
+	if err != nil {
+		return err
+	}
+
+	z, err := c.findZone(ch.ResolvedZone)
+	if err != nil {
+		return err
+	}
+
+	name := recordName(ch.ResolvedFQDN, z.Name)
+	records, err := c.findTXTRecords(z.ID, name)
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if unquote(r.Value) == ch.Key {
+			return nil
+		}
+	}
+
+	if err := c.createRecord(record{
+		ZoneID: z.ID,
+		Type:   "TXT",
+		Name:   name,
+		Value:  ch.Key,
+		TTL:    recordTTL,
+	}); err != nil {
+		return err
+	}
+	logf.Log.V(logf.DebugLevel).Info("created Hetzner DNS TXT record", "zone", z.Name, "name", name)
+
+	return nil
+}
+
+// CleanUp deletes the TXT records holding the challenge's key. Other TXT
+// records for the same name, such as those of a challenge for a wildcard of
+// the same domain, are left in place.
+func (s *Solver) CleanUp(ch *whapi.ChallengeRequest) error {
+	c, err := s.client(ch)
+	if err != nil {
+		return err
+	}
+
+	z, err := c.findZone(ch.ResolvedZone)
+	if err != nil {
+		return err
+	}
+
+	name := recordName(ch.ResolvedFQDN, z.Name)
+	records, err := c.findTXTRecords(z.ID, name)
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if unquote(r.Value) != ch.Key {
+			continue
+		}
+		if err := c.deleteRecord(r.ID); err != nil {
+			return err
+		}
+		logf.Log.V(logf.DebugLevel).Info("deleted Hetzner DNS TXT record", "zone", z.Name, "name", name)
+	}
+
+	return nil
+}
+
+func (s *Solver) client(ch *whapi.ChallengeRequest) (*apiClient, error) {
+	cfg, err := loadConfig(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := s.secretLister.Secrets(ch.ResourceNamespace).Get(cfg.APIToken.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting hetzner API token: %w", err)
+	}
+	token, ok := secret.Data[cfg.APIToken.Key]
+	if !ok {
+		return nil, fmt.Errorf("data entry with key %q not found in secret %s/%s", cfg.APIToken.Key, ch.ResourceNamespace, cfg.APIToken.Name)
+	}
+	apiToken := strings.TrimSpace(string(token))
+	if apiToken == "" {
+		return nil, fmt.Errorf("hetzner API token in secret %s/%s is empty", ch.ResourceNamespace, cfg.APIToken.Name)
+	}
+
+	return &apiClient{
+		endpoint:   strings.TrimSuffix(s.apiEndpoint, "/"),
+		token:      apiToken,
+		httpClient: s.httpClient,
+	}, nil
+}
+
+func loadConfig(ch *whapi.ChallengeRequest) (*cmacme.ACMEIssuerDNS01ProviderHetzner, error) {
+	cfg := &cmacme.ACMEIssuerDNS01ProviderHetzner{}
+	if ch.Config == nil {
+		return cfg, nil
+	}
+	if err := json.Unmarshal(ch.Config.Raw, cfg); err != nil {
+		return nil, fmt.Errorf("error decoding solver config: %v", err)
+	}
+	return cfg, nil
+}
+
+// recordName returns the name of the record for fqdn relative to zone, as
+// expected by the Hetzner DNS API.
+func recordName(fqdn, zone string) string {
+	fqdn = strings.TrimSuffix(fqdn, ".")
+	zone = strings.TrimSuffix(zone, ".")
+	if fqdn == zone {
+		return "@"
+	}
+	return strings.TrimSuffix(fqdn, "."+zone)
+}
+
+// unquote strips the quotes which the Hetzner DNS API may add around the
+// values of TXT records.
+func unquote(v string) string {
+	return strings.Trim(v, `"`)
+}
+
+type zone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type record struct {
+	ID     string `json:"id,omitempty"`
+	ZoneID string `json:"zone_id"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	TTL    int    `json:"ttl,omitempty"`
+}
+
+// apiClient is a minimal client for the parts of the Hetzner DNS API used by
+// the solver.
+type apiClient struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+func (c *apiClient) findZone(name string) (*zone, error) {
+	name = strings.TrimSuffix(name, ".")
+	var resp struct {
+		Zones []zone `json:"zones"`
+	}
+	if err := c.do(http.MethodGet, "/zones?"+url.Values{"name": {name}}.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+	for _, z := range resp.Zones {
+		if z.Name == name {
+			return &z, nil
+		}
+	}
+	return nil, fmt.Errorf("zone %q not found in Hetzner DNS", name)
+}
+
+func (c *apiClient) findTXTRecords(zoneID, name string) ([]record, error) {
+	var resp struct {
+		Records []record `json:"records"`
+	}
+	if err := c.do(http.MethodGet, "/records?"+url.Values{"zone_id": {zoneID}}.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+	var records []record
+	for _, r := range resp.Records {
+		if r.Type == "TXT" && r.Name == name {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+func (c *apiClient) createRecord(r record) error {
+	return c.do(http.MethodPost, "/records", r, nil)
+}
+
+func (c *apiClient) deleteRecord(id string) error {
+	return c.do(http.MethodDelete, "/records/"+url.PathEscape(id), nil, nil)
+}
+
+func (c *apiClient) do(method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, c.endpoint+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Auth-API-Token", c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("hetzner DNS API request %s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("hetzner DNS API request %s %s returned %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding hetzner DNS API response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+)
+
+const testToken = "test-token"
+
+// fakeAPI is an in-memory implementation of the parts of the Hetzner DNS API
+// used by the solver.
+type fakeAPI struct {
+	lock    sync.Mutex
+	nextID  int
+	records map[string]record
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("Auth-API-Token") != testToken {
+		http.Error(w, `{"message":"invalid token"}`, http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/zones":
+		var zones []zone
+		if r.URL.Query().Get("name") == "example.com" {
+			zones = append(zones, zone{ID: "zone-1", Name: "example.com"})
+		}
+		_ = json.NewEncoder(w).Encode(map[string][]zone{"zones": zones})
+	case r.Method == http.MethodGet && r.URL.Path == "/records":
+		records := []record{}
+		for _, rec := range f.records {
+			if rec.ZoneID == r.URL.Query().Get("zone_id") {
+				records = append(records, rec)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string][]record{"records": records})
+	case r.Method == http.MethodPost && r.URL.Path == "/records":
+		var rec record
+		if err := json.NewDecoder(r.Body).Decode(&rec); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.nextID++
+		rec.ID = fmt.Sprintf("record-%d", f.nextID)
+		// The API returns the values of TXT records quoted.
+		rec.Value = `"` + rec.Value + `"`
+		f.records[rec.ID] = rec
+		_ = json.NewEncoder(w).Encode(map[string]record{"record": rec})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/records/"):
+		id := strings.TrimPrefix(r.URL.Path, "/records/")
+		if _, ok := f.records[id]; !ok {
+			http.Error(w, `{"message":"record not found"}`, http.StatusNotFound)
+			return
+		}
+		delete(f.records, id)
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func (f *fakeAPI) values(name string) []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	var values []string
+	for _, rec := range f.records {
+		if rec.Name == name {
+			values = append(values, unquote(rec.Value))
+		}
+	}
+	return values
+}
+
+func newTestSolver(t *testing.T, token string) (*Solver, *fakeAPI) {
+	api := &fakeAPI{records: map[string]record{}}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hetzner", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte(token + "\n")},
+	}))
+
+	s := New(WithAPIEndpoint(server.URL))
+	s.secretLister = corelisters.NewSecretLister(indexer)
+	return s, api
+}
+
+func challengeRequest(fqdn, key string) *whapi.ChallengeRequest {
+	return &whapi.ChallengeRequest{
+		ResolvedFQDN:      fqdn,
+		ResolvedZone:      "example.com.",
+		ResourceNamespace: "default",
+		Key:               key,
+		Config:            &apiextensionsv1.JSON{Raw: []byte(`{"apiTokenSecretRef":{"name":"hetzner","key":"token"}}`)},
+	}
+}
+
+func TestPresentAndCleanUp(t *testing.T) {
+	s, api := newTestSolver(t, testToken)
+
+	require.NoError(t, s.Present(challengeRequest("_acme-challenge.www.example.com.", "key-1")))
+	// Presenting again is a no-op, and presenting a second challenge for the
+	// same FQDN such as the one of a wildcard adds a second record.
+	require.NoError(t, s.Present(challengeRequest("_acme-challenge.www.example.com.", "key-1")))
+	require.NoError(t, s.Present(challengeRequest("_acme-challenge.www.example.com.", "key-2")))
+	assert.ElementsMatch(t, []string{"key-1", "key-2"}, api.values("_acme-challenge.www"))
+
+	require.NoError(t, s.CleanUp(challengeRequest("_acme-challenge.www.example.com.", "key-1")))
+	assert.Equal(t, []string{"key-2"}, api.values("_acme-challenge.www"))
+
+	// Cleaning up a record which no longer exists succeeds.
+	require.NoError(t, s.CleanUp(challengeRequest("_acme-challenge.www.example.com.", "key-1")))
+	require.NoError(t, s.CleanUp(challengeRequest("_acme-challenge.www.example.com.", "key-2")))
+	assert.Empty(t, api.values("_acme-challenge.www"))
+}
+
+func TestPresentErrors(t *testing.T) {
+	s, _ := newTestSolver(t, "wrong-token")
+	err := s.Present(challengeRequest("_acme-challenge.example.com.", "key"))
+	assert.ErrorContains(t, err, "401")
+
+	s, _ = newTestSolver(t, testToken)
+	ch := challengeRequest("_acme-challenge.example.org.", "key")
+	ch.ResolvedZone = "example.org."
+	err = s.Present(ch)
+	assert.ErrorContains(t, err, `zone "example.org" not found`)
+
+	ch = challengeRequest("_acme-challenge.example.com.", "key")
+	ch.Config = &apiextensionsv1.JSON{Raw: []byte(`{"apiTokenSecretRef":{"name":"missing","key":"token"}}`)}
+	err = s.Present(ch)
+	assert.ErrorContains(t, err, "error getting hetzner API token")
+}
+
+func TestRecordName(t *testing.T) {
+	assert.Equal(t, "_acme-challenge", recordName("_acme-challenge.example.com.", "example.com."))
+	assert.Equal(t, "_acme-challenge.www", recordName("_acme-challenge.www.example.com.", "example.com"))
+	assert.Equal(t, "@", recordName("example.com.", "example.com."))
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	tkeyModeGSSAPI        = 3
+	tkeyLifetime          = time.Hour
+	gssNegotiationTimeout = 30 * time.Second
+)
+
+// generateKeyName returns a random name for the TSIG key of a security
+// context negotiated with the nameserver host.
+func generateKeyName(host string) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return dns.Fqdn(hex.EncodeToString(b) + "." + host), nil
+}
+
+// newTKEYClient returns the client with which the tokens of a security
+// context are exchanged with the nameserver.
+func newTKEYClient() *dns.Client {
+	return &dns.Client{
+		Net:     "tcp",
+		Timeout: gssNegotiationTimeout,
+		// The last TKEY response is signed with the context being
+		// negotiated, which is only complete once the token of that
+		// response has been processed. Mutual authentication is ensured by
+		// the security mechanism instead.
+		TsigProvider: unverifiedTsigProvider{},
+	}
+}
+
+// exchangeTKEY sends a TKEY query (RFC 3645) carrying token for the context
+// identified by keyName to the nameserver, and returns the token of its
+// response.
+func exchangeTKEY(c *dns.Client, nameserver, keyName string, token []byte) ([]byte, error) {
+	now := time.Now()
+	m := new(dns.Msg)
+	m.SetQuestion(keyName, dns.TypeTKEY)
+	m.Question[0].Qclass = dns.ClassANY
+	m.Extra = append(m.Extra, &dns.TKEY{
+		Hdr:        dns.RR_Header{Name: keyName, Rrtype: dns.TypeTKEY, Class: dns.ClassANY},
+		Algorithm:  gssTSIG,
+		Mode:       tkeyModeGSSAPI,
+		Inception:  uint32(now.Unix()),
+		Expiration: uint32(now.Add(tkeyLifetime).Unix()),
+		KeySize:    uint16(len(token)),
+		Key:        hex.EncodeToString(token),
+	})
+
+	reply, _, err := c.Exchange(m, nameserver)
+	if err != nil {
+		return nil, err
+	}
+	if reply.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("server replied: %s", dns.RcodeToString[reply.Rcode])
+	}
+	for _, rr := range reply.Answer {
+		tkey, ok := rr.(*dns.TKEY)
+		if !ok {
+			continue
+		}
+		if tkey.Error != dns.RcodeSuccess {
+			return nil, fmt.Errorf("server replied with TKEY error: %s", dns.RcodeToString[int(tkey.Error)])
+		}
+		return hex.DecodeString(tkey.Key)
+	}
+	return nil, errors.New("server did not reply with a TKEY record")
+}
+
+// unverifiedTsigProvider is used while negotiating a security context, which
+// cannot yet verify the signature of the responses of the nameserver.
+type unverifiedTsigProvider struct{}
+
+func (unverifiedTsigProvider) Generate(_ []byte, _ *dns.TSIG) ([]byte, error) {
+	return nil, dns.ErrSecret
+}
+
+func (unverifiedTsigProvider) Verify(_ []byte, _ *dns.TSIG) error {
+	return nil
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/crypto"
+	"github.com/jcmturner/gokrb5/v8/gssapi"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/iana/flags"
+	"github.com/jcmturner/gokrb5/v8/iana/keyusage"
+	"github.com/jcmturner/gokrb5/v8/iana/nametype"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/messages"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/jcmturner/gokrb5/v8/types"
+	"github.com/miekg/dns"
+)
+
+// kerberosEtypes are the encryption types used with the KDC, which are those
+// supported by Active Directory.
+var kerberosEtypes = []int32{etypeID.AES256_CTS_HMAC_SHA1_96, etypeID.AES128_CTS_HMAC_SHA1_96}
+
+// kerberosClient obtains the Kerberos tickets of a principal, authenticating
+// with credentials loaded from a Secret rather than with the identity of the
+// process, and so is supported on every platform. The tickets are cached by
+// the underlying client until they expire or the client is invalidated.
+type kerberosClient struct {
+	newClient func() *client.Client
+
+	lock   sync.Mutex
+	client *client.Client
+}
+
+// newKerberosClientWithPassword returns a kerberosClient for the principal
+// username@realm, which authenticates to the KDC at address kdc with the
+// given password.
+func newKerberosClientWithPassword(realm, username, kdc, password string) *kerberosClient {
+	cfg := kerberosConfig(realm, kdc)
+	return &kerberosClient{newClient: func() *client.Client {
+		return client.NewWithPassword(username, realm, password, cfg, client.DisablePAFXFAST(true))
+	}}
+}
+
+// newKerberosClientWithKeytab returns a kerberosClient for the principal
+// username@realm, which authenticates to the KDC at address kdc with the
+// keys of the principal in the given keytab.
+func newKerberosClientWithKeytab(realm, username, kdc string, keytabData []byte) (*kerberosClient, error) {
+	kt := keytab.New()
+	if err := kt.Unmarshal(keytabData); err != nil {
+		return nil, err
+	}
+	found := false
+	principal := types.NewPrincipalName(nametype.KRB_NT_PRINCIPAL, username)
+	for _, etype := range kerberosEtypes {
+		if _, _, err := kt.GetEncryptionKey(principal, realm, 0, etype); err == nil {
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("keytab does not contain an AES key for %s@%s", username, realm)
+	}
+
+	cfg := kerberosConfig(realm, kdc)
+	return &kerberosClient{newClient: func() *client.Client {
+		return client.NewWithKeytab(username, realm, kt, cfg, client.DisablePAFXFAST(true))
+	}}, nil
+}
+
+// kerberosConfig returns the configuration of a client of the given realm,
+// whose KDC is only found at the address kdc.
+func kerberosConfig(realm, kdc string) *config.Config {
+	cfg := config.New()
+	cfg.LibDefaults.DefaultRealm = realm
+	cfg.LibDefaults.DNSLookupKDC = false
+	cfg.LibDefaults.DNSLookupRealm = false
+	// The tickets issued by Active Directory are usually too large for
+	// UDP, so the KDC is always contacted over TCP.
+	cfg.LibDefaults.UDPPreferenceLimit = 1
+	cfg.LibDefaults.DefaultTktEnctypeIDs = kerberosEtypes
+	cfg.LibDefaults.DefaultTGSEnctypeIDs = kerberosEtypes
+	cfg.LibDefaults.PermittedEnctypeIDs = kerberosEtypes
+	cfg.Realms = []config.Realm{{Realm: realm, KDC: []string{kdc}}}
+	return cfg
+}
+
+// login returns the client, authenticating to the KDC unless its
+// ticket-granting ticket is still valid.
+func (k *kerberosClient) login() (*client.Client, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if k.client == nil {
+		k.client = k.newClient()
+	}
+	if err := k.client.AffirmLogin(); err != nil {
+		return nil, err
+	}
+	return k.client, nil
+}
+
+// Invalidate discards the cached tickets, so that new tickets are obtained
+// when the client is next used.
+func (k *kerberosClient) Invalidate() {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if k.client != nil {
+		k.client.Destroy()
+		k.client = nil
+	}
+}
+
+// kerberosContext is a gssContext established with a kerberosClient.
+type kerberosContext struct {
+	keyName string
+
+	// key is the key with which messages are signed and verified: the
+	// subkey of the acceptor if it asserted one, and the session key of the
+	// service ticket otherwise.
+	key            types.EncryptionKey
+	acceptorSubkey bool
+	sequenceNumber uint64
+}
+
+var _ gssContext = &kerberosContext{}
+
+// negotiateKerberosContext negotiates a security context with the nameserver
+// with TKEY queries (RFC 3645), for the given service principal.
+func negotiateKerberosContext(kc *kerberosClient, nameserver, servicePrincipalName string) (gssContext, error) {
+	host, _, err := net.SplitHostPort(nameserver)
+	if err != nil {
+		return nil, err
+	}
+	keyName, err := generateKeyName(host)
+	if err != nil {
+		return nil, err
+	}
+
+	cl, err := kc.login()
+	if err != nil {
+		return nil, err
+	}
+	ticket, sessionKey, err := cl.GetServiceTicket(servicePrincipalName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain a ticket for %s: %w", servicePrincipalName, err)
+	}
+	apReq, err := spnego.NewKRB5TokenAPREQ(cl, ticket, sessionKey,
+		[]int{gssapi.ContextFlagMutual, gssapi.ContextFlagReplay, gssapi.ContextFlagSequence, gssapi.ContextFlagInteg},
+		[]int{flags.APOptionMutualRequired})
+	if err != nil {
+		return nil, err
+	}
+	token, err := apReq.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := exchangeTKEY(newTKEYClient(), nameserver, keyName, token)
+	if err != nil {
+		return nil, err
+	}
+	k, err := acceptKerberosContext(apReq.APReq, sessionKey, reply)
+	if err != nil {
+		return nil, err
+	}
+	k.keyName = keyName
+	return k, nil
+}
+
+// acceptKerberosContext completes a security context with the reply of the
+// service to the AP-REQ, which must authenticate the service by echoing the
+// time of the authenticator (RFC 4120 section 3.2.5).
+func acceptKerberosContext(apReq messages.APReq, sessionKey types.EncryptionKey, reply []byte) (*kerberosContext, error) {
+	var apRep spnego.KRB5Token
+	if err := apRep.Unmarshal(reply); err != nil {
+		return nil, err
+	}
+	if apRep.IsKRBError() {
+		return nil, apRep.KRBError
+	}
+	if !apRep.IsAPRep() {
+		return nil, errors.New("service did not reply with an AP-REP")
+	}
+
+	b, err := crypto.DecryptEncPart(apReq.EncryptedAuthenticator, sessionKey, keyusage.AP_REQ_AUTHENTICATOR)
+	if err != nil {
+		return nil, err
+	}
+	var auth types.Authenticator
+	if err := auth.Unmarshal(b); err != nil {
+		return nil, err
+	}
+	b, err = crypto.DecryptEncPart(apRep.APRep.EncPart, sessionKey, keyusage.AP_REP_ENCPART)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the reply of the service: %w", err)
+	}
+	var part messages.EncAPRepPart
+	if err := part.Unmarshal(b); err != nil {
+		return nil, err
+	}
+	if !part.CTime.Equal(auth.CTime) || part.Cusec != auth.Cusec {
+		return nil, errors.New("mutual authentication of the service failed")
+	}
+
+	k := &kerberosContext{key: sessionKey, sequenceNumber: uint64(auth.SeqNumber)}
+	if part.Subkey.KeyType != 0 {
+		if _, err := crypto.GetEtype(part.Subkey.KeyType); err != nil {
+			return nil, err
+		}
+		k.key = part.Subkey
+		k.acceptorSubkey = true
+	}
+	return k, nil
+}
+
+// KeyName implements gssContext
+func (k *kerberosContext) KeyName() string {
+	return k.keyName
+}
+
+// Generate implements dns.TsigProvider
+func (k *kerberosContext) Generate(msg []byte, _ *dns.TSIG) ([]byte, error) {
+	token := gssapi.MICToken{SndSeqNum: k.sequenceNumber, Payload: msg}
+	if k.acceptorSubkey {
+		token.Flags = gssapi.MICTokenFlagAcceptorSubkey
+	}
+	if err := token.SetChecksum(k.key, keyusage.GSSAPI_INITIATOR_SIGN); err != nil {
+		return nil, err
+	}
+	k.sequenceNumber++
+	return token.Marshal()
+}
+
+// Verify implements dns.TsigProvider
+func (k *kerberosContext) Verify(msg []byte, t *dns.TSIG) error {
+	mac, err := hex.DecodeString(t.MAC)
+	if err != nil {
+		return err
+	}
+	var token gssapi.MICToken
+	if err := token.Unmarshal(mac, true); err != nil {
+		return dns.ErrSig
+	}
+	if token.Flags&gssapi.MICTokenFlagAcceptorSubkey != 0 && !k.acceptorSubkey {
+		return dns.ErrSig
+	}
+	token.Payload = msg
+	if ok, err := token.Verify(k.key, keyusage.GSSAPI_ACCEPTOR_SIGN); err != nil || !ok {
+		return dns.ErrSig
+	}
+	return nil
+}
+
+// Close implements gssContext
+func (k *kerberosContext) Close() error {
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/jcmturner/gofork/encoding/asn1"
+	"github.com/jcmturner/gokrb5/v8/asn1tools"
+	"github.com/jcmturner/gokrb5/v8/crypto"
+	"github.com/jcmturner/gokrb5/v8/gssapi"
+	"github.com/jcmturner/gokrb5/v8/iana/asnAppTag"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/iana/keyusage"
+	"github.com/jcmturner/gokrb5/v8/iana/msgtype"
+	"github.com/jcmturner/gokrb5/v8/iana/nametype"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/messages"
+	"github.com/jcmturner/gokrb5/v8/types"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomKey(t *testing.T) types.EncryptionKey {
+	key := types.EncryptionKey{KeyType: etypeID.AES256_CTS_HMAC_SHA1_96, KeyValue: make([]byte, 32)}
+	_, err := rand.Read(key.KeyValue)
+	require.NoError(t, err)
+	return key
+}
+
+// apRepToken returns the context token of a service replying to apReq with
+// the given time and subkey.
+func apRepToken(t *testing.T, sessionKey types.EncryptionKey, ctime time.Time, cusec int, subkey types.EncryptionKey) []byte {
+	part, err := asn1.Marshal(messages.EncAPRepPart{CTime: ctime, Cusec: cusec, Subkey: subkey})
+	require.NoError(t, err)
+	encPart, err := crypto.GetEncryptedData(asn1tools.AddASNAppTag(part, asnAppTag.EncAPRepPart), sessionKey, keyusage.AP_REP_ENCPART, 0)
+	require.NoError(t, err)
+	apRep, err := asn1.Marshal(messages.APRep{PVNO: 5, MsgType: msgtype.KRB_AP_REP, EncPart: encPart})
+	require.NoError(t, err)
+
+	token, err := asn1.Marshal(gssapi.OIDKRB5.OID())
+	require.NoError(t, err)
+	token = append(token, 0x02, 0x00)
+	token = append(token, asn1tools.AddASNAppTag(apRep, asnAppTag.APREP)...)
+	return asn1tools.AddASNAppTag(token, 0)
+}
+
+func TestAcceptKerberosContext(t *testing.T) {
+	sessionKey := randomKey(t)
+	acceptorSubkey := randomKey(t)
+	auth, err := types.NewAuthenticator("EXAMPLE.COM", types.NewPrincipalName(nametype.KRB_NT_PRINCIPAL, "cert-manager"))
+	require.NoError(t, err)
+	ticket := messages.Ticket{SName: types.NewPrincipalName(nametype.KRB_NT_SRV_INST, "DNS/dc1.example.com")}
+	apReq, err := messages.NewAPReq(ticket, sessionKey, auth)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		reply []byte
+
+		wantErr            string
+		wantKey            types.EncryptionKey
+		wantAcceptorSubkey bool
+	}{
+		"the session key is used if the service asserts no subkey": {
+			reply:   apRepToken(t, sessionKey, auth.CTime, auth.Cusec, types.EncryptionKey{}),
+			wantKey: sessionKey,
+		},
+		"the subkey asserted by the service is used": {
+			reply:              apRepToken(t, sessionKey, auth.CTime, auth.Cusec, acceptorSubkey),
+			wantKey:            acceptorSubkey,
+			wantAcceptorSubkey: true,
+		},
+		"the service must echo the time of the authenticator": {
+			reply:   apRepToken(t, sessionKey, auth.CTime.Add(time.Second), auth.Cusec, types.EncryptionKey{}),
+			wantErr: "mutual authentication of the service failed",
+		},
+		"the reply must be encrypted with the session key": {
+			reply:   apRepToken(t, randomKey(t), auth.CTime, auth.Cusec, types.EncryptionKey{}),
+			wantErr: "failed to decrypt the reply of the service",
+		},
+		"a malformed reply is rejected": {
+			reply:   []byte{0x60, 0x03, 0x06, 0x01},
+			wantErr: "error unmarshalling KRB5Token OID",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			k, err := acceptKerberosContext(apReq, sessionKey, test.reply)
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.wantKey, k.key)
+			assert.Equal(t, test.wantAcceptorSubkey, k.acceptorSubkey)
+			assert.Equal(t, uint64(auth.SeqNumber), k.sequenceNumber)
+		})
+	}
+}
+
+func TestKerberosContextMIC(t *testing.T) {
+	key := randomKey(t)
+	msg := []byte("message")
+	k := &kerberosContext{key: key, acceptorSubkey: true, sequenceNumber: 42}
+
+	// The MIC of the initiator is verified by the service.
+	mic, err := k.Generate(msg, nil)
+	require.NoError(t, err)
+	var token gssapi.MICToken
+	require.NoError(t, token.Unmarshal(mic, false))
+	assert.Equal(t, uint64(42), token.SndSeqNum)
+	assert.Equal(t, byte(gssapi.MICTokenFlagAcceptorSubkey), token.Flags)
+	token.Payload = msg
+	ok, err := token.Verify(key, keyusage.GSSAPI_INITIATOR_SIGN)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(43), k.sequenceNumber, "sequence number is incremented")
+
+	// The MIC of the service is verified.
+	acceptorMIC := func(flags byte, msg []byte) *dns.TSIG {
+		token := gssapi.MICToken{Flags: flags, Payload: msg}
+		require.NoError(t, token.SetChecksum(key, keyusage.GSSAPI_ACCEPTOR_SIGN))
+		b, err := token.Marshal()
+		require.NoError(t, err)
+		return &dns.TSIG{MAC: hex.EncodeToString(b)}
+	}
+	fromAcceptor := byte(gssapi.MICTokenFlagSentByAcceptor | gssapi.MICTokenFlagAcceptorSubkey)
+	assert.NoError(t, k.Verify(msg, acceptorMIC(fromAcceptor, msg)))
+	assert.Equal(t, dns.ErrSig, k.Verify([]byte("tampered"), acceptorMIC(fromAcceptor, msg)))
+	assert.Equal(t, dns.ErrSig, k.Verify(msg, acceptorMIC(gssapi.MICTokenFlagAcceptorSubkey, msg)), "MIC must be sent by the acceptor")
+	assert.Equal(t, dns.ErrSig, k.Verify(msg, &dns.TSIG{MAC: "0404"}), "malformed MIC")
+
+	withoutSubkey := &kerberosContext{key: key}
+	assert.Equal(t, dns.ErrSig, withoutSubkey.Verify(msg, acceptorMIC(fromAcceptor, msg)), "no subkey was asserted by the acceptor")
+}
+
+func TestNewKerberosClientWithKeytab(t *testing.T) {
+	kt := keytab.New()
+	require.NoError(t, kt.AddEntry("cert-manager", "EXAMPLE.COM", "secret", time.Now(), 1, etypeID.AES256_CTS_HMAC_SHA1_96))
+	data, err := kt.Marshal()
+	require.NoError(t, err)
+
+	_, err = newKerberosClientWithKeytab("EXAMPLE.COM", "cert-manager", "dc1.example.com:88", data)
+	assert.NoError(t, err)
+
+	_, err = newKerberosClientWithKeytab("EXAMPLE.COM", "other", "dc1.example.com:88", data)
+	assert.EqualError(t, err, "keytab does not contain an AES key for other@EXAMPLE.COM")
+
+	_, err = newKerberosClientWithKeytab("EXAMPLE.COM", "cert-manager", "dc1.example.com:88", []byte{0x05, 0x02, 0xff})
+	assert.Error(t, err)
+}
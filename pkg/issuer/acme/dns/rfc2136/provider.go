@@ -17,8 +17,12 @@ limitations under the License.
 package rfc2136
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"net"
+	"strings"
+	"sync"
 	"time"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -33,6 +37,9 @@ import (
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+// defaultKDCPort is the port of the KDC if none is configured.
+const defaultKDCPort = "88"
+
 type Solver struct {
 	secretLister corelisters.SecretLister
 
@@ -40,6 +47,19 @@ type Solver struct {
 	// scope of the lister/watcher to a single namespace, to allow for
 	// namespace restricted instances of cert-manager.
 	namespace string
+
+	// kerberosClients caches the Kerberos clients of the issuers which use
+	// GSS-TSIG with credentials from a Secret, so that their tickets are
+	// reused until they expire.
+	kerberosLock    sync.Mutex
+	kerberosClients map[string]*cachedKerberosClient
+}
+
+// cachedKerberosClient is a Kerberos client, and a hash of the credentials
+// it was created with so that it is replaced if they change.
+type cachedKerberosClient struct {
+	credentialsHash [sha256.Size]byte
+	client          *kerberosClient
 }
 
 type Option func(*Solver)
@@ -157,5 +177,92 @@ func (s *Solver) buildDNSProvider(ch *whapi.ChallengeRequest) (*DNSProvider, err
 		key = string(secret)
 	}
 
-	return NewDNSProviderCredentials(cfg.Nameserver, cfg.TSIGAlgorithm, cfg.TSIGKeyName, key)
+	p, err := NewDNSProviderCredentials(cfg.Nameserver, cfg.TSIGAlgorithm, cfg.TSIGKeyName, key)
+	if err != nil {
+		return nil, err
+	}
+	if p.TSIGAlgorithm() != gssTSIG {
+		return p, nil
+	}
+	host, _, err := net.SplitHostPort(p.Nameserver())
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Kerberos == nil {
+		return nil, fmt.Errorf("GSS-TSIG requires Kerberos credentials to be configured")
+	}
+
+	p.kerberos, err = s.kerberosClient(l, ch.ResourceNamespace, host, cfg.Kerberos)
+	if err != nil {
+		return nil, err
+	}
+	p.servicePrincipalName = cfg.Kerberos.ServicePrincipalName
+	if p.servicePrincipalName == "" {
+		p.servicePrincipalName = "DNS/" + host
+	}
+	return p, nil
+}
+
+// kerberosClient returns the Kerberos client for the given configuration,
+// reusing the client created for it previously unless its credentials have
+// changed since.
+func (s *Solver) kerberosClient(l corelisters.SecretNamespaceLister, namespace, nameserverHost string, cfg *cmacme.ACMEIssuerDNS01ProviderRFC2136Kerberos) (*kerberosClient, error) {
+	// The nameservers of Active Directory are usually its domain
+	// controllers, which are also its KDCs.
+	kdc := cfg.KDC
+	if kdc == "" {
+		kdc = nameserverHost
+	}
+	if _, _, err := net.SplitHostPort(kdc); err != nil {
+		kdc = net.JoinHostPort(strings.Trim(kdc, "[]"), defaultKDCPort)
+	}
+
+	var keytab, password []byte
+	var err error
+	switch {
+	case cfg.KeytabSecretRef != nil:
+		keytab, err = loadSecretKeySelector(l, *cfg.KeytabSecretRef, "")
+	case cfg.PasswordSecretRef != nil:
+		password, err = loadSecretKeySelector(l, *cfg.PasswordSecretRef, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(keytab) == 0 && len(password) == 0 {
+		return nil, fmt.Errorf("no Kerberos keytab or password configured")
+	}
+
+	h := sha256.New()
+	h.Write(keytab)
+	h.Write([]byte{0})
+	h.Write(password)
+	var credentialsHash [sha256.Size]byte
+	copy(credentialsHash[:], h.Sum(nil))
+	key := strings.Join([]string{namespace, cfg.Realm, cfg.Username, kdc}, "/")
+
+	s.kerberosLock.Lock()
+	defer s.kerberosLock.Unlock()
+	cached, ok := s.kerberosClients[key]
+	if ok && cached.credentialsHash == credentialsHash {
+		return cached.client, nil
+	}
+	if ok {
+		// Stop the renewal of the tickets of the replaced client.
+		cached.client.Invalidate()
+	}
+
+	var client *kerberosClient
+	if len(keytab) > 0 {
+		client, err = newKerberosClientWithKeytab(cfg.Realm, cfg.Username, kdc, keytab)
+		if err != nil {
+			return nil, fmt.Errorf("error loading Kerberos keytab: %v", err)
+		}
+	} else {
+		client = newKerberosClientWithPassword(cfg.Realm, cfg.Username, kdc, string(password))
+	}
+	if s.kerberosClients == nil {
+		s.kerberosClients = map[string]*cachedKerberosClient{}
+	}
+	s.kerberosClients[key] = &cachedKerberosClient{credentialsHash: credentialsHash, client: client}
+	return client, nil
 }
@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+func challengeRequest(t *testing.T, cfg *cmacme.ACMEIssuerDNS01ProviderRFC2136, allowAmbientCredentials bool) *whapi.ChallengeRequest {
+	raw, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	return &whapi.ChallengeRequest{
+		ResourceNamespace:       "default",
+		AllowAmbientCredentials: allowAmbientCredentials,
+		Config:                  &apiextensionsv1.JSON{Raw: raw},
+	}
+}
+
+func TestBuildDNSProviderGSSTSIG(t *testing.T) {
+	passwordRef := &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "kerberos"}, Key: "password"}
+	secret := &corev1.Secret{Data: map[string][]byte{"password": []byte("secret")}}
+
+	tests := map[string]struct {
+		cfg                      cmacme.ACMEIssuerDNS01ProviderRFC2136
+		allowAmbientCredentials  bool
+		wantErr                  bool
+		wantKerberos             bool
+		wantServicePrincipalName string
+	}{
+		"Kerberos credentials are required even if ambient credentials are allowed": {
+			cfg:                     cmacme.ACMEIssuerDNS01ProviderRFC2136{Nameserver: "dc1.example.com", TSIGAlgorithm: "GSS-TSIG"},
+			allowAmbientCredentials: true,
+			wantErr:                 true,
+		},
+		"Kerberos credentials do not require ambient credentials": {
+			cfg: cmacme.ACMEIssuerDNS01ProviderRFC2136{
+				Nameserver:    "dc1.example.com:53",
+				TSIGAlgorithm: "GSS-TSIG",
+				Kerberos: &cmacme.ACMEIssuerDNS01ProviderRFC2136Kerberos{
+					Realm:             "EXAMPLE.COM",
+					Username:          "cert-manager",
+					PasswordSecretRef: passwordRef,
+				},
+			},
+			wantKerberos:             true,
+			wantServicePrincipalName: "DNS/dc1.example.com",
+		},
+		"service principal name can be configured": {
+			cfg: cmacme.ACMEIssuerDNS01ProviderRFC2136{
+				Nameserver:    "10.0.0.1",
+				TSIGAlgorithm: "GSS-TSIG",
+				Kerberos: &cmacme.ACMEIssuerDNS01ProviderRFC2136Kerberos{
+					Realm:                "EXAMPLE.COM",
+					Username:             "cert-manager",
+					ServicePrincipalName: "DNS/dc1.example.com",
+					PasswordSecretRef:    passwordRef,
+				},
+			},
+			wantKerberos:             true,
+			wantServicePrincipalName: "DNS/dc1.example.com",
+		},
+		"Kerberos credentials must be present in the Secret": {
+			cfg: cmacme.ACMEIssuerDNS01ProviderRFC2136{
+				Nameserver:    "dc1.example.com",
+				TSIGAlgorithm: "GSS-TSIG",
+				Kerberos: &cmacme.ACMEIssuerDNS01ProviderRFC2136Kerberos{
+					Realm:    "EXAMPLE.COM",
+					Username: "cert-manager",
+					PasswordSecretRef: &cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{Name: "kerberos"},
+						Key:                  "missing",
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := New()
+			s.secretLister = testlisters.NewFakeSecretLister(testlisters.SetFakeSecretNamespaceListerGet(secret, nil))
+
+			p, err := s.buildDNSProvider(challengeRequest(t, &test.cfg, test.allowAmbientCredentials))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.wantKerberos, p.kerberos != nil)
+			assert.Equal(t, test.wantServicePrincipalName, p.servicePrincipalName)
+		})
+	}
+}
+
+func TestBuildDNSProviderCachesKerberosClients(t *testing.T) {
+	secret := &corev1.Secret{Data: map[string][]byte{"password": []byte("secret")}}
+	s := New()
+	s.secretLister = testlisters.NewFakeSecretLister(testlisters.SetFakeSecretNamespaceListerGet(secret, nil))
+	ch := challengeRequest(t, &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+		Nameserver:    "dc1.example.com",
+		TSIGAlgorithm: "GSS-TSIG",
+		Kerberos: &cmacme.ACMEIssuerDNS01ProviderRFC2136Kerberos{
+			Realm:    "EXAMPLE.COM",
+			Username: "cert-manager",
+			PasswordSecretRef: &cmmeta.SecretKeySelector{
+				LocalObjectReference: cmmeta.LocalObjectReference{Name: "kerberos"},
+				Key:                  "password",
+			},
+		},
+	}, false)
+
+	first, err := s.buildDNSProvider(ch)
+	require.NoError(t, err)
+	second, err := s.buildDNSProvider(ch)
+	require.NoError(t, err)
+	assert.Same(t, first.kerberos, second.kerberos, "client is reused while the credentials are unchanged")
+
+	secret.Data["password"] = []byte("rotated")
+	third, err := s.buildDNSProvider(ch)
+	require.NoError(t, err)
+	assert.NotSame(t, first.kerberos, third.kerberos, "client is replaced when the credentials change")
+}
@@ -38,8 +38,14 @@ var supportedAlgorithms = map[string]string{
 	"HMACSHA1":   dns.HmacSHA1,
 	"HMACSHA256": dns.HmacSHA256,
 	"HMACSHA512": dns.HmacSHA512,
+	"GSS-TSIG":   gssTSIG,
 }
 
+// gssTSIG is the name of the GSS-TSIG algorithm (RFC 3645), with which
+// updates are signed using a security context negotiated with the nameserver
+// with Kerberos credentials loaded from a Secret.
+const gssTSIG = "gss-tsig."
+
 // DNSProvider is an implementation of the acme.ChallengeProvider interface that
 // uses dynamic DNS updates (RFC 2136) to create TXT records on a nameserver.
 type DNSProvider struct {
@@ -47,6 +53,12 @@ type DNSProvider struct {
 	tsigAlgorithm string
 	tsigKeyName   string
 	tsigSecret    string
+
+	// kerberos is the client with which security contexts for GSS-TSIG are
+	// negotiated, for the service principal servicePrincipalName of the
+	// nameserver.
+	kerberos             *kerberosClient
+	servicePrincipalName string
 }
 
 // NewDNSProviderCredentials uses the supplied credentials to return a
@@ -129,7 +141,18 @@ func (r *DNSProvider) changeRecord(action, fqdn, zone, value string, ttl int) er
 	c.TsigProvider = tsigHMACProvider(r.tsigSecret)
 	c.SingleInflight = true
 	// TSIG authentication / msg signing
-	if len(r.tsigKeyName) > 0 && len(r.tsigSecret) > 0 {
+	if r.tsigAlgorithm == gssTSIG {
+		gss, err := r.newGSSContext()
+		if err != nil {
+			return fmt.Errorf("GSS-TSIG negotiation failed: %v", err)
+		}
+		defer gss.Close()
+		m.SetTsig(gss.KeyName(), gssTSIG, 300, time.Now().Unix())
+		c.TsigProvider = gss
+		// Updates signed with GSS-TSIG are sent over TCP, like the
+		// negotiation, as Windows DNS servers do.
+		c.Net = "tcp"
+	} else if len(r.tsigKeyName) > 0 && len(r.tsigSecret) > 0 {
 		m.SetTsig(dns.Fqdn(r.tsigKeyName), r.tsigAlgorithm, 300, time.Now().Unix())
 		c.TsigSecret = map[string]string{dns.Fqdn(r.tsigKeyName): r.tsigSecret}
 	}
@@ -151,6 +174,35 @@ func (r *DNSProvider) Nameserver() string {
 	return r.nameserver
 }
 
+// newGSSContext negotiates a security context with the nameserver for
+// GSS-TSIG.
+func (r *DNSProvider) newGSSContext() (gssContext, error) {
+	if r.kerberos == nil {
+		return nil, fmt.Errorf("no Kerberos credentials configured")
+	}
+	gss, err := negotiateKerberosContext(r.kerberos, r.nameserver, r.servicePrincipalName)
+	if err != nil {
+		// The cached tickets may no longer be accepted, for example if the
+		// keys of the nameserver have changed, so the negotiation is
+		// retried once with new tickets.
+		r.kerberos.Invalidate()
+		gss, err = negotiateKerberosContext(r.kerberos, r.nameserver, r.servicePrincipalName)
+	}
+	return gss, err
+}
+
+// gssContext is a security context negotiated with a nameserver, which signs
+// and verifies messages with GSS-TSIG.
+type gssContext interface {
+	dns.TsigProvider
+
+	// KeyName returns the name of the TSIG key identifying the context.
+	KeyName() string
+
+	// Close releases the context.
+	Close() error
+}
+
 // TSIGAlgorithm returns the TSIG algorithm configured for this provider when it was created
 func (r *DNSProvider) TSIGAlgorithm() string {
 	return r.tsigAlgorithm
@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+)
+
+const (
+	// maxBatchSize is the maximum number of changes submitted in a single
+	// change batch. Route 53 accepts up to 1000 records per batch.
+	maxBatchSize = 100
+)
+
+var (
+	// batchWindow is how long changes to the same hosted zone are collected
+	// before they are submitted as a single change batch.
+	batchWindow = time.Second
+
+	// batcher is shared by all DNSProviders, since a provider is constructed
+	// for each challenge.
+	batcher = &changeBatcher{pending: make(map[string]*changeBatch)}
+)
+
+// changeBatcher combines the record changes of challenges for the same
+// hosted zone, made using the same credentials, into a single
+// ChangeResourceRecordSets call in order to avoid being throttled by Route 53
+// when many certificates are renewed at once.
+type changeBatcher struct {
+	lock    sync.Mutex
+	pending map[string]*changeBatch
+}
+
+type changeBatch struct {
+	client       *route53.Route53
+	hostedZoneID string
+	changes      []*route53.Change
+
+	// full is closed once the batch reaches maxBatchSize, done once it has
+	// been submitted.
+	full chan struct{}
+	done chan struct{}
+
+	statusIDs []*string
+	errs      []error
+}
+
+// submit adds the change to the pending batch for the hosted zone and waits
+// for the batch to be submitted. It returns the ID of the change which
+// applied it, which can be used to wait for the change to be propagated.
+func (c *changeBatcher) submit(client *route53.Route53, credentialsID, hostedZoneID string, change *route53.Change) (*string, error) {
+	key := credentialsID + "/" + hostedZoneID
+
+	c.lock.Lock()
+	b, ok := c.pending[key]
+	if !ok {
+		b = &changeBatch{
+			client:       client,
+			hostedZoneID: hostedZoneID,
+			full:         make(chan struct{}),
+			done:         make(chan struct{}),
+		}
+		c.pending[key] = b
+	}
+	i := b.add(change)
+	if len(b.changes) == maxBatchSize {
+		delete(c.pending, key)
+		close(b.full)
+	}
+	c.lock.Unlock()
+
+	// The first change of a batch submits it once the window has passed.
+	if !ok {
+		timer := time.NewTimer(batchWindow)
+		select {
+		case <-timer.C:
+		case <-b.full:
+			timer.Stop()
+		}
+
+		c.lock.Lock()
+		if c.pending[key] == b {
+			delete(c.pending, key)
+		}
+		c.lock.Unlock()
+
+		b.send()
+		close(b.done)
+	}
+
+	<-b.done
+	return b.statusIDs[i], b.errs[i]
+}
+
+// add adds the change to the batch unless an identical change is already part
+// of it, and returns its index.
+func (b *changeBatch) add(change *route53.Change) int {
+	for i, existing := range b.changes {
+		if *existing.Action == *change.Action &&
+			*existing.ResourceRecordSet.Name == *change.ResourceRecordSet.Name &&
+			*existing.ResourceRecordSet.SetIdentifier == *change.ResourceRecordSet.SetIdentifier {
+			return i
+		}
+	}
+	b.changes = append(b.changes, change)
+	return len(b.changes) - 1
+}
+
+func (b *changeBatch) send() {
+	b.statusIDs = make([]*string, len(b.changes))
+	b.errs = make([]error, len(b.changes))
+
+	statusID, err := b.changeResourceRecordSets(b.changes...)
+	if err == nil || len(b.changes) == 1 {
+		for i := range b.changes {
+			b.statusIDs[i], b.errs[i] = statusID, err
+		}
+		return
+	}
+
+	// A change batch is applied atomically, so a single invalid change, such
+	// as the deletion of a record which has already been deleted, fails all
+	// of them. Submit each change on its own so that only the changes which
+	// are invalid fail.
+	if awserr, ok := err.(awserr.Error); ok && awserr.Code() == route53.ErrCodeInvalidChangeBatch {
+		for i, change := range b.changes {
+			b.statusIDs[i], b.errs[i] = b.changeResourceRecordSets(change)
+		}
+		return
+	}
+
+	for i := range b.changes {
+		b.errs[i] = err
+	}
+}
+
+func (b *changeBatch) changeResourceRecordSets(changes ...*route53.Change) (*string, error) {
+	resp, err := b.client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(b.hostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String("Managed by cert-manager"),
+			Changes: changes,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.ChangeInfo.Id, nil
+}
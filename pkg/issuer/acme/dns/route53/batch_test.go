@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+func TestChangeBatching(t *testing.T) {
+	defer func(window time.Duration) { batchWindow = window }(batchWindow)
+	batchWindow = 100 * time.Millisecond
+
+	var changeRequests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		switch r.URL.Path {
+		case "/2013-04-01/hostedzone/ABCDEFG/rrset/":
+			atomic.AddInt32(&changeRequests, 1)
+			body, _ := io.ReadAll(r.Body)
+			if strings.Contains(string(body), "gone.example.com") {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(ChangeResourceRecordSetsInvalidChangeBatchResponse))
+				return
+			}
+			_, _ = w.Write([]byte(ChangeResourceRecordSetsResponse))
+		case "/2013-04-01/change/123456":
+			_, _ = w.Write([]byte(GetChangeResponse))
+		default:
+			t.Errorf("unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	provider, err := makeRoute53Provider(ts)
+	assert.NoError(t, err, "Expected to make a Route 53 provider without error")
+	provider.hostedZoneID = "ABCDEFG"
+	provider.log = logf.Log
+
+	run := func(f func(domain string) error, domains ...string) []error {
+		errs := make([]error, len(domains))
+		var wg sync.WaitGroup
+		for i, domain := range domains {
+			wg.Add(1)
+			go func(i int, domain string) {
+				defer wg.Done()
+				errs[i] = f(domain)
+			}(i, domain)
+		}
+		wg.Wait()
+		return errs
+	}
+
+	present := func(domain string) error {
+		return provider.Present(domain, "_acme-challenge."+domain+".", "123456d==")
+	}
+	errs := run(present, "a.example.com", "b.example.com", "c.example.com", "c.example.com")
+	assert.Equal(t, []error{nil, nil, nil, nil}, errs)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&changeRequests), "Expected the changes to be submitted in a single batch")
+
+	// The batch fails as a whole since one of the records has already been
+	// deleted, so each change is submitted on its own.
+	atomic.StoreInt32(&changeRequests, 0)
+	cleanUp := func(domain string) error {
+		return provider.CleanUp(domain, "_acme-challenge."+domain+".", "123456d==")
+	}
+	errs = run(cleanUp, "a.example.com", "gone.example.com")
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&changeRequests), "Expected the failed batch to be retried one change at a time")
+}
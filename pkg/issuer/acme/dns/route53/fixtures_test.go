@@ -75,3 +75,13 @@ var ChangeResourceRecordSets403Response = `<?xml version="1.0"?>
   </Error>
   <RequestId>SOMEREQUESTID</RequestId>
 </ErrorResponse>`
+
+var ChangeResourceRecordSetsInvalidChangeBatchResponse = `<?xml version="1.0"?>
+<ErrorResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+  <Error>
+    <Type>Sender</Type>
+    <Code>InvalidChangeBatch</Code>
+    <Message>Tried to delete resource record set [name='_acme-challenge.gone.example.com.', type='TXT'] but it was not found</Message>
+  </Error>
+  <RequestId>SOMEREQUESTID</RequestId>
+</ErrorResponse>`
@@ -41,6 +41,10 @@ type DNSProvider struct {
 	hostedZoneID     string
 	log              logr.Logger
 
+	// credentialsID identifies the credentials used by client. Changes are
+	// only batched with those of providers using the same credentials.
+	credentialsID string
+
 	userAgent string
 }
 
@@ -165,6 +169,7 @@ func NewDNSProvider(accessKeyID, secretAccessKey, hostedZoneID, region, role str
 		client:           client,
 		hostedZoneID:     hostedZoneID,
 		dns01Nameservers: dns01Nameservers,
+		credentialsID:    fmt.Sprintf("%s/%s/%s/%t", accessKeyID, region, role, ambient),
 		log:              logf.Log.WithName("route53"),
 		userAgent:        userAgent,
 	}, nil
@@ -188,21 +193,11 @@ func (r *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 		return fmt.Errorf("failed to determine Route 53 hosted zone ID: %v", err)
 	}
 
-	recordSet := newTXTRecordSet(fqdn, value, ttl)
-	reqParams := &route53.ChangeResourceRecordSetsInput{
-		HostedZoneId: aws.String(hostedZoneID),
-		ChangeBatch: &route53.ChangeBatch{
-			Comment: aws.String("Managed by cert-manager"),
-			Changes: []*route53.Change{
-				{
-					Action:            &action,
-					ResourceRecordSet: recordSet,
-				},
-			},
-		},
+	change := &route53.Change{
+		Action:            &action,
+		ResourceRecordSet: newTXTRecordSet(fqdn, value, ttl),
 	}
-
-	resp, err := r.client.ChangeResourceRecordSets(reqParams)
+	statusID, err := batcher.submit(r.client, r.credentialsID, hostedZoneID, change)
 	if err != nil {
 		if awserr, ok := err.(awserr.Error); ok {
 			if action == route53.ChangeActionDelete && awserr.Code() == route53.ErrCodeInvalidChangeBatch {
@@ -213,11 +208,8 @@ func (r *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 			}
 		}
 		return fmt.Errorf("failed to change Route 53 record set: %v", removeReqID(err))
-
 	}
 
-	statusID := resp.ChangeInfo.Id
-
 	return util.WaitFor(120*time.Second, 4*time.Second, func() (bool, error) {
 		reqParams := &route53.GetChangeInput{
 			Id: statusID,
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"strings"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+// configForDomain returns the solver configuration to use for dnsName. If
+// the Route53, CloudDNS or AzureDNS provider lists a zone containing
+// dnsName, the returned configuration is a copy of cfg in which the
+// provider is configured with the credentials of the longest matching
+// zone. Otherwise cfg is returned unchanged.
+func configForDomain(cfg *cmacme.ACMEChallengeSolverDNS01, dnsName string) *cmacme.ACMEChallengeSolverDNS01 {
+	out := *cfg
+	switch {
+	case cfg.Route53 != nil:
+		i := matchZone(dnsName, len(cfg.Route53.Zones), func(i int) string { return cfg.Route53.Zones[i].Zone })
+		if i < 0 {
+			return cfg
+		}
+		zone := cfg.Route53.Zones[i]
+		out.Route53 = &cmacme.ACMEIssuerDNS01ProviderRoute53{
+			SecretAccessKeyID: zone.AccessKeyID,
+			Role:              zone.Role,
+			HostedZoneID:      zone.HostedZoneID,
+			Region:            cfg.Route53.Region,
+		}
+		if zone.SecretAccessKey != nil {
+			out.Route53.SecretAccessKey = *zone.SecretAccessKey
+		}
+	case cfg.CloudDNS != nil:
+		i := matchZone(dnsName, len(cfg.CloudDNS.Zones), func(i int) string { return cfg.CloudDNS.Zones[i].Zone })
+		if i < 0 {
+			return cfg
+		}
+		zone := cfg.CloudDNS.Zones[i]
+		out.CloudDNS = &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
+			ServiceAccount: &zone.ServiceAccount,
+			Project:        valueOrDefault(zone.Project, cfg.CloudDNS.Project),
+			HostedZoneName: zone.HostedZoneName,
+		}
+	case cfg.AzureDNS != nil:
+		i := matchZone(dnsName, len(cfg.AzureDNS.Zones), func(i int) string { return cfg.AzureDNS.Zones[i].Zone })
+		if i < 0 {
+			return cfg
+		}
+		zone := cfg.AzureDNS.Zones[i]
+		out.AzureDNS = &cmacme.ACMEIssuerDNS01ProviderAzureDNS{
+			ClientID:          zone.ClientID,
+			ClientSecret:      &zone.ClientSecret,
+			SubscriptionID:    valueOrDefault(zone.SubscriptionID, cfg.AzureDNS.SubscriptionID),
+			TenantID:          valueOrDefault(zone.TenantID, cfg.AzureDNS.TenantID),
+			ResourceGroupName: valueOrDefault(zone.ResourceGroupName, cfg.AzureDNS.ResourceGroupName),
+			HostedZoneName:    zone.HostedZoneName,
+			Environment:       cfg.AzureDNS.Environment,
+		}
+	default:
+		return cfg
+	}
+	return &out
+}
+
+// matchZone returns the index of the longest of the n zones returned by
+// zone which contains dnsName, or -1 if dnsName is not within any of them.
+// Zones are compared case-insensitively and ignoring a trailing dot.
+func matchZone(dnsName string, n int, zone func(int) string) int {
+	dnsName = normalizeZone(dnsName)
+	match := -1
+	matchLen := -1
+	for i := 0; i < n; i++ {
+		z := normalizeZone(zone(i))
+		if len(z) == 0 || len(z) <= matchLen {
+			continue
+		}
+		if dnsName == z || strings.HasSuffix(dnsName, "."+z) {
+			match, matchLen = i, len(z)
+		}
+	}
+	return match
+}
+
+func normalizeZone(zone string) string {
+	return strings.ToLower(strings.TrimSuffix(zone, "."))
+}
+
+func valueOrDefault(value, def string) string {
+	if len(value) > 0 {
+		return value
+	}
+	return def
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+func TestMatchZone(t *testing.T) {
+	zones := []string{"example.com", "sub.example.com.", "EXAMPLE.org", ""}
+	tests := map[string]int{
+		"example.com":           0,
+		"www.example.com":       0,
+		"notexample.com":        -1,
+		"sub.example.com":       1,
+		"a.b.sub.example.com.":  1,
+		"www.Example.ORG":       2,
+		"example.net":           -1,
+		"com":                   -1,
+		"www.sub.example.com.x": -1,
+	}
+	for dnsName, exp := range tests {
+		t.Run(dnsName, func(t *testing.T) {
+			if got := matchZone(dnsName, len(zones), func(i int) string { return zones[i] }); got != exp {
+				t.Errorf("expected zone %d, got %d", exp, got)
+			}
+		})
+	}
+}
+
+func TestSolverForZones(t *testing.T) {
+	secretRef := func(name, key string) cmmeta.SecretKeySelector {
+		return cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: name}, Key: key}
+	}
+	route53 := &cmacme.ACMEChallengeSolverDNS01{
+		Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+			Region:       "us-west-2",
+			Role:         "provider-role",
+			HostedZoneID: "provider-zone",
+			Zones: []cmacme.ACMEIssuerDNS01ProviderRoute53Zone{
+				{
+					Zone:            "example.com",
+					AccessKeyID:     &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "example-com"}, Key: "accessKeyID"},
+					SecretAccessKey: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "example-com"}, Key: "secretAccessKey"},
+					HostedZoneID:    "example-com-zone",
+				},
+				{
+					Zone: "sub.example.com",
+					Role: "sub-role",
+				},
+			},
+		},
+	}
+	azure := &cmacme.ACMEChallengeSolverDNS01{
+		AzureDNS: &cmacme.ACMEIssuerDNS01ProviderAzureDNS{
+			SubscriptionID:    "provider-sub",
+			TenantID:          "provider-tenant",
+			ResourceGroupName: "provider-rg",
+			ManagedIdentity:   &cmacme.AzureManagedIdentity{ClientID: "msi"},
+			Zones: []cmacme.ACMEIssuerDNS01ProviderAzureDNSZone{
+				{
+					Zone:              "example.com",
+					ClientID:          "example-com-client",
+					ClientSecret:      secretRef("example-com", "clientSecret"),
+					ResourceGroupName: "example-com-rg",
+				},
+			},
+		},
+	}
+	cloudDNS := &cmacme.ACMEChallengeSolverDNS01{
+		CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
+			Project: "provider-project",
+			Zones: []cmacme.ACMEIssuerDNS01ProviderCloudDNSZone{
+				{
+					Zone:           "example.com",
+					ServiceAccount: secretRef("example-com", "serviceAccount"),
+					HostedZoneName: "example-com-zone",
+				},
+			},
+		},
+	}
+
+	scenarios := map[string]struct {
+		cfg     *cmacme.ACMEChallengeSolverDNS01
+		dnsName string
+		expCall fakeDNSProviderCall
+	}{
+		"route53 domain outside of all zones uses the provider configuration": {
+			cfg:     route53,
+			dnsName: "example.org",
+			expCall: fakeDNSProviderCall{name: "route53", args: []interface{}{"", "", "provider-zone", "us-west-2", "provider-role", false, util.RecursiveNameservers}},
+		},
+		"route53 domain within a zone uses the credentials of the zone": {
+			cfg:     route53,
+			dnsName: "www.example.com",
+			expCall: fakeDNSProviderCall{name: "route53", args: []interface{}{"EXAMPLEKEYID", "EXAMPLESECRET", "example-com-zone", "us-west-2", "", false, util.RecursiveNameservers}},
+		},
+		"route53 domain within nested zones uses the longest matching zone": {
+			cfg:     route53,
+			dnsName: "www.sub.example.com",
+			expCall: fakeDNSProviderCall{name: "route53", args: []interface{}{"", "", "", "us-west-2", "sub-role", false, util.RecursiveNameservers}},
+		},
+		"azuredns domain within a zone uses the service principal of the zone": {
+			cfg:     azure,
+			dnsName: "example.com",
+			expCall: fakeDNSProviderCall{name: "azuredns", args: []interface{}{"example-com-client", "EXAMPLECLIENTSECRET", "provider-sub", "provider-tenant", "example-com-rg", "", util.RecursiveNameservers, false, (*cmacme.AzureManagedIdentity)(nil)}},
+		},
+		"clouddns domain within a zone uses the service account of the zone": {
+			cfg:     cloudDNS,
+			dnsName: "www.example.com",
+			expCall: fakeDNSProviderCall{name: "clouddns", args: []interface{}{"provider-project", []byte("EXAMPLESERVICEACCOUNT"), util.RecursiveNameservers, false, "example-com-zone"}},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			f := &solverFixture{
+				Builder: &test.Builder{
+					KubeObjects: []runtime.Object{
+						newSecret("example-com", "default", map[string][]byte{
+							"accessKeyID":     []byte("EXAMPLEKEYID"),
+							"secretAccessKey": []byte("EXAMPLESECRET"),
+							"clientSecret":    []byte("EXAMPLECLIENTSECRET"),
+							"serviceAccount":  []byte("EXAMPLESERVICEACCOUNT"),
+						}),
+					},
+				},
+				Issuer: newIssuer("test", "default"),
+				Challenge: &cmacme.Challenge{
+					Spec: cmacme.ChallengeSpec{
+						DNSName: scenario.dnsName,
+						Solver:  cmacme.ACMEChallengeSolver{DNS01: scenario.cfg},
+					},
+				},
+				dnsProviders: newFakeDNSProviders(),
+			}
+			f.Setup(t)
+			defer f.Finish(t)
+
+			if _, _, err := f.Solver.solverForChallenge(context.Background(), f.Issuer, f.Challenge); err != nil {
+				t.Fatalf("expected solverFor to not error, but got: %s", err)
+			}
+			if exp := []fakeDNSProviderCall{scenario.expCall}; !reflect.DeepEqual(exp, f.dnsProviders.calls) {
+				t.Errorf("expected %+v == %+v", exp, f.dnsProviders.calls)
+			}
+		})
+	}
+}
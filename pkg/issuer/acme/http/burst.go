@@ -0,0 +1,474 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	appsv1informers "k8s.io/client-go/informers/apps/v1"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/http/solver"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// Burst solvers serve all pending challenges of an HTTP01 solver with a burst
+// profile from a single Deployment per namespace. The challenges are stored
+// in a ConfigMap of the same name, which is mounted into the solver pods, and
+// the Deployment is scaled by the number of challenges stored in it.
+// Neither are owned by a challenge, as they are shared by all challenges of
+// the solver, but by the issuer so that they are garbage collected with it.
+// Burst solvers which are no longer configured on their issuer are deleted
+// once they have no pending challenges left.
+//
+// Only the Deployments and ConfigMaps labelled as burst solvers are cached,
+// and the controller may only write them in the namespaces it has been
+// granted access to.
+
+const (
+	defaultBurstMinReplicas          = 1
+	defaultBurstChallengesPerReplica = 10
+
+	// burstTemplateHashAnnotationKey is set on the Deployment of a burst
+	// solver to the hash of its pod template, so that the Deployment is only
+	// updated if the template has changed.
+	burstTemplateHashAnnotationKey = "acme.cert-manager.io/http01-burst-solver-template-hash"
+
+	burstChallengesVolumeName = "challenges"
+	burstChallengesDir        = "/var/run/acme-challenges"
+)
+
+// burstSolverInformers returns the informers of the Deployments and
+// ConfigMaps of burst solvers, which only cache the objects with the
+// BurstSolverLabelKey label.
+func burstSolverInformers(ctx *controller.Context) (deployments, configMaps cache.SharedIndexInformer) {
+	tweakListOptions := func(opts *metav1.ListOptions) {
+		opts.LabelSelector = cmacme.BurstSolverLabelKey
+	}
+	indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	deployments = ctx.KubeSharedInformerFactory.InformerFor(&appsv1.Deployment{}, func(client kubernetes.Interface, resync time.Duration) cache.SharedIndexInformer {
+		return appsv1informers.NewFilteredDeploymentInformer(client, ctx.Namespace, resync, indexers, tweakListOptions)
+	})
+	configMaps = ctx.KubeSharedInformerFactory.InformerFor(&corev1.ConfigMap{}, func(client kubernetes.Interface, resync time.Duration) cache.SharedIndexInformer {
+		return corev1informers.NewFilteredConfigMapInformer(client, ctx.Namespace, resync, indexers, tweakListOptions)
+	})
+	return deployments, configMaps
+}
+
+// BurstSolverInformersSynced returns the InformerSynced functions of the
+// informers used by burst solvers, which must be synced before challenges
+// are presented.
+func BurstSolverInformersSynced(ctx *controller.Context) []cache.InformerSynced {
+	deployments, configMaps := burstSolverInformers(ctx)
+	return []cache.InformerSynced{deployments.HasSynced, configMaps.HasSynced}
+}
+
+// burstProfile returns the burst profile of the challenge's solver, or nil if
+// the challenge is solved by a pod of its own.
+func burstProfile(ch *cmacme.Challenge) *cmacme.ACMEChallengeSolverHTTP01BurstProfile {
+	if ch.Spec.Solver.HTTP01 == nil || ch.Spec.Solver.HTTP01.Ingress == nil {
+		return nil
+	}
+	return ch.Spec.Solver.HTTP01.Ingress.BurstProfile
+}
+
+// burstSolverName returns the name of the Deployment and ConfigMap of the
+// burst solver for the given issuer and solver configuration. Only the parts
+// of the configuration used to build the Deployment are taken into account,
+// so that solvers which only differ in how the challenges are routed share a
+// burst solver.
+func burstSolverName(issuerKind, issuerName string, ingress *cmacme.ACMEChallengeSolverHTTP01Ingress) (string, error) {
+	return apiutil.ComputeName("cm-acme-http-solver", struct {
+		Kind         string                                              `json:"kind"`
+		Name         string                                              `json:"name"`
+		PodTemplate  *cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate `json:"podTemplate"`
+		BurstProfile *cmacme.ACMEChallengeSolverHTTP01BurstProfile       `json:"burstProfile"`
+	}{issuerKind, issuerName, ingress.PodTemplate, ingress.BurstProfile})
+}
+
+// burstIssuerHash returns the value of the BurstSolverIssuerLabelKey label of
+// the burst solvers of the given issuer.
+func burstIssuerHash(issuerKind, issuerName string) string {
+	hash := fnv.New32()
+	hash.Write([]byte(issuerKind + "/" + issuerName))
+	return fmt.Sprintf("%d", hash.Sum32())
+}
+
+// issuerKind returns the kind of the given issuer.
+func issuerKind(issuer v1.GenericIssuer) string {
+	if _, ok := issuer.(*v1.ClusterIssuer); ok {
+		return v1.ClusterIssuerKind
+	}
+	return v1.IssuerKind
+}
+
+// burstSolverOwnerReference returns the reference to the issuer set on the
+// Deployment and ConfigMap of its burst solvers. It does not block the
+// deletion of the issuer, so that no permission to update the issuer's
+// finalizers is needed.
+func burstSolverOwnerReference(issuer v1.GenericIssuer) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Kind:       issuerKind(issuer),
+		Name:       issuer.GetName(),
+		UID:        issuer.GetUID(),
+	}
+}
+
+// ensureOwnerReference adds ref to the owner references of obj, and returns
+// whether it was missing.
+func ensureOwnerReference(obj metav1.Object, ref metav1.OwnerReference) bool {
+	refs := obj.GetOwnerReferences()
+	for _, r := range refs {
+		if r.UID == ref.UID {
+			return false
+		}
+	}
+	obj.SetOwnerReferences(append(refs, ref))
+	return true
+}
+
+// challengeIssuerKind returns the kind of the issuer referenced by the
+// challenge, which defaults to Issuer.
+func challengeIssuerKind(ch *cmacme.Challenge) string {
+	if ch.Spec.IssuerRef.Kind == "" {
+		return v1.IssuerKind
+	}
+	return ch.Spec.IssuerRef.Kind
+}
+
+func burstSolverLabels(ch *cmacme.Challenge) (map[string]string, error) {
+	kind := challengeIssuerKind(ch)
+	name, err := burstSolverName(kind, ch.Spec.IssuerRef.Name, ch.Spec.Solver.HTTP01.Ingress)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		cmacme.SolverIdentificationLabelKey: "true",
+		cmacme.BurstSolverLabelKey:          name,
+		cmacme.BurstSolverIssuerLabelKey:    burstIssuerHash(kind, ch.Spec.IssuerRef.Name),
+	}, nil
+}
+
+// burstReplicas returns the number of replicas needed to serve the given
+// number of pending challenges.
+func burstReplicas(p *cmacme.ACMEChallengeSolverHTTP01BurstProfile, pending int) int32 {
+	minReplicas := int32(defaultBurstMinReplicas)
+	if p.MinReplicas != nil {
+		minReplicas = *p.MinReplicas
+	}
+	perReplica := int32(defaultBurstChallengesPerReplica)
+	if p.ChallengesPerReplica != nil && *p.ChallengesPerReplica > 0 {
+		perReplica = *p.ChallengesPerReplica
+	}
+
+	replicas := (int32(pending) + perReplica - 1) / perReplica
+	if replicas < minReplicas {
+		replicas = minReplicas
+	}
+	if replicas > p.MaxReplicas {
+		replicas = p.MaxReplicas
+	}
+	return replicas
+}
+
+// ensureBurstSolver adds the challenge to the ConfigMap of its burst solver
+// and ensures that the solver's Deployment is up to date and scaled for the
+// pending challenges.
+func (s *Solver) ensureBurstSolver(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx).WithName("ensureBurstSolver")
+
+	if issuer == nil {
+		return fmt.Errorf("the issuer of challenge %q is required to create its burst solver", ch.Name)
+	}
+	if !solver.ValidToken(ch.Spec.Token) {
+		return fmt.Errorf("challenge token %q cannot be served by a burst solver", ch.Spec.Token)
+	}
+	lbls, err := burstSolverLabels(ch)
+	if err != nil {
+		return err
+	}
+	name := lbls[cmacme.BurstSolverLabelKey]
+
+	owner := burstSolverOwnerReference(issuer)
+	data := solver.FormatChallenge(ch.Spec.DNSName, ch.Spec.Key)
+	cm, err := s.configMapLister.ConfigMaps(ch.Namespace).Get(name)
+	switch {
+	case apierrors.IsNotFound(err):
+		log.V(logf.DebugLevel).Info("creating HTTP01 burst solver challenges configmap", "name", name)
+		cm, err = s.Client.CoreV1().ConfigMaps(ch.Namespace).Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       ch.Namespace,
+				Labels:          lbls,
+				OwnerReferences: []metav1.OwnerReference{owner},
+			},
+			Data: map[string]string{ch.Spec.Token: data},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		cm = cm.DeepCopy()
+		ownerAdded := ensureOwnerReference(cm, owner)
+		if !ownerAdded && cm.Data[ch.Spec.Token] == data {
+			break
+		}
+		log.V(logf.DebugLevel).Info("adding challenge to HTTP01 burst solver challenges configmap", "name", name)
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[ch.Spec.Token] = data
+		cm, err = s.Client.CoreV1().ConfigMaps(ch.Namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.ensureBurstDeployment(ctx, ch, lbls, owner, len(cm.Data))
+}
+
+// ensureBurstDeployment creates or updates the Deployment of the challenge's
+// burst solver, scaled for the given number of pending challenges.
+func (s *Solver) ensureBurstDeployment(ctx context.Context, ch *cmacme.Challenge, lbls map[string]string, owner metav1.OwnerReference, pending int) error {
+	log := logf.FromContext(ctx).WithName("ensureBurstDeployment")
+
+	desired, err := s.buildBurstDeployment(ch, lbls, burstReplicas(burstProfile(ch), pending))
+	if err != nil {
+		return err
+	}
+	desired.OwnerReferences = []metav1.OwnerReference{owner}
+
+	existing, err := s.deploymentLister.Deployments(ch.Namespace).Get(desired.Name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.InfoLevel).Info("creating HTTP01 burst solver deployment", "name", desired.Name, "replicas", *desired.Spec.Replicas)
+		_, err = s.Client.AppsV1().Deployments(ch.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	existing = existing.DeepCopy()
+	ownerAdded := ensureOwnerReference(existing, owner)
+	templateHash := desired.Annotations[burstTemplateHashAnnotationKey]
+	if !ownerAdded && existing.Spec.Replicas != nil && *existing.Spec.Replicas == *desired.Spec.Replicas &&
+		existing.Annotations[burstTemplateHashAnnotationKey] == templateHash {
+		return nil
+	}
+
+	log.V(logf.InfoLevel).Info("updating HTTP01 burst solver deployment", "name", desired.Name, "replicas", *desired.Spec.Replicas)
+	if existing.Annotations == nil {
+		existing.Annotations = make(map[string]string)
+	}
+	existing.Annotations[burstTemplateHashAnnotationKey] = templateHash
+	existing.Spec.Replicas = desired.Spec.Replicas
+	existing.Spec.Template = desired.Spec.Template
+	_, err = s.Client.AppsV1().Deployments(ch.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// buildBurstDeployment builds the Deployment of the challenge's burst solver.
+// Its pods are built like the pods solving a single challenge, but serve the
+// challenges mounted from the burst solver's ConfigMap instead.
+func (s *Solver) buildBurstDeployment(ch *cmacme.Challenge, lbls map[string]string, replicas int32) (*appsv1.Deployment, error) {
+	name := lbls[cmacme.BurstSolverLabelKey]
+
+	pod := s.buildPod(ch)
+	delete(pod.Labels, cmacme.DomainLabelKey)
+	delete(pod.Labels, cmacme.TokenLabelKey)
+	for k, v := range lbls {
+		pod.Labels[k] = v
+	}
+	pod.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: burstChallengesVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			},
+		},
+	})
+	container := &pod.Spec.Containers[0]
+	container.Args = []string{
+		fmt.Sprintf("--listen-port=%d", acmeSolverListenPort),
+		fmt.Sprintf("--challenges-dir=%s", burstChallengesDir),
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      burstChallengesVolumeName,
+		MountPath: burstChallengesDir,
+		ReadOnly:  true,
+	})
+
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      pod.Labels,
+			Annotations: pod.Annotations,
+		},
+		Spec: pod.Spec,
+	}
+	templateBytes, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+	hash := fnv.New32()
+	hash.Write(templateBytes)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ch.Namespace,
+			Labels:    lbls,
+			Annotations: map[string]string{
+				burstTemplateHashAnnotationKey: fmt.Sprintf("%d", hash.Sum32()),
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: lbls},
+			Template: template,
+		},
+	}, nil
+}
+
+// cleanupBurstSolver removes the challenge from the ConfigMap of its burst
+// solver and scales the solver's Deployment down accordingly.
+func (s *Solver) cleanupBurstSolver(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanupBurstSolver")
+
+	lbls, err := burstSolverLabels(ch)
+	if err != nil {
+		return err
+	}
+	name := lbls[cmacme.BurstSolverLabelKey]
+
+	cm, err := s.configMapLister.ConfigMaps(ch.Namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, ok := cm.Data[ch.Spec.Token]; ok {
+		log.V(logf.DebugLevel).Info("removing challenge from HTTP01 burst solver challenges configmap", "name", name)
+		cm = cm.DeepCopy()
+		delete(cm.Data, ch.Spec.Token)
+		cm, err = s.Client.CoreV1().ConfigMaps(ch.Namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	deploy, err := s.deploymentLister.Deployments(ch.Namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	replicas := burstReplicas(burstProfile(ch), len(cm.Data))
+	if deploy.Spec.Replicas != nil && *deploy.Spec.Replicas == replicas {
+		return nil
+	}
+
+	log.V(logf.InfoLevel).Info("scaling HTTP01 burst solver deployment", "name", name, "replicas", replicas)
+	deploy = deploy.DeepCopy()
+	deploy.Spec.Replicas = &replicas
+	_, err = s.Client.AppsV1().Deployments(ch.Namespace).Update(ctx, deploy, metav1.UpdateOptions{})
+	return err
+}
+
+// cleanupStaleBurstSolvers deletes the burst solvers of the issuer in the
+// given namespace which are no longer configured on the issuer and have no
+// pending challenges left.
+func (s *Solver) cleanupStaleBurstSolvers(ctx context.Context, issuer v1.GenericIssuer, namespace string) error {
+	log := logf.FromContext(ctx, "cleanupStaleBurstSolvers")
+
+	if issuer == nil || issuer.GetSpec().ACME == nil {
+		return nil
+	}
+	kind := issuerKind(issuer)
+
+	configured := sets.NewString()
+	for _, sol := range issuer.GetSpec().ACME.Solvers {
+		if sol.HTTP01 == nil || sol.HTTP01.Ingress == nil || sol.HTTP01.Ingress.BurstProfile == nil {
+			continue
+		}
+		name, err := burstSolverName(kind, issuer.GetName(), sol.HTTP01.Ingress)
+		if err != nil {
+			return err
+		}
+		configured.Insert(name)
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{
+		cmacme.BurstSolverIssuerLabelKey: burstIssuerHash(kind, issuer.GetName()),
+	})
+	deploys, err := s.deploymentLister.Deployments(namespace).List(selector)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, deploy := range deploys {
+		name := deploy.Labels[cmacme.BurstSolverLabelKey]
+		if name == "" || configured.Has(name) {
+			continue
+		}
+		log := log.WithValues("name", name)
+
+		cm, err := s.configMapLister.ConfigMaps(namespace).Get(name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+			continue
+		}
+		if err == nil && len(cm.Data) > 0 {
+			log.V(logf.DebugLevel).Info("not deleting stale HTTP01 burst solver as it still has pending challenges")
+			continue
+		}
+
+		log.V(logf.InfoLevel).Info("deleting stale HTTP01 burst solver")
+		if err := s.Client.AppsV1().Deployments(namespace).Delete(ctx, deploy.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+			continue
+		}
+		if err := s.Client.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/http/solver"
+)
+
+func burstChallenge(token string) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-" + token,
+			Namespace: defaultTestNamespace,
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName:   token + ".example.com",
+			Token:     token,
+			Key:       token + ".key",
+			IssuerRef: cmmeta.ObjectReference{Name: "acme", Kind: v1.ClusterIssuerKind},
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+						BurstProfile: &cmacme.ACMEChallengeSolverHTTP01BurstProfile{
+							MinReplicas:          pointer.Int32(1),
+							MaxReplicas:          3,
+							ChallengesPerReplica: pointer.Int32(2),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func burstIssuer(solvers ...cmacme.ACMEChallengeSolver) *v1.ClusterIssuer {
+	return &v1.ClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "acme", UID: "issuer-uid"},
+		Spec: v1.IssuerSpec{IssuerConfig: v1.IssuerConfig{ACME: &cmacme.ACMEIssuer{
+			Solvers: solvers,
+		}}},
+	}
+}
+
+func TestBurstReplicas(t *testing.T) {
+	profile := &cmacme.ACMEChallengeSolverHTTP01BurstProfile{
+		MinReplicas:          pointer.Int32(1),
+		MaxReplicas:          3,
+		ChallengesPerReplica: pointer.Int32(2),
+	}
+	tests := map[string]struct {
+		profile *cmacme.ACMEChallengeSolverHTTP01BurstProfile
+		pending int
+		exp     int32
+	}{
+		"should keep the minimum replicas without pending challenges":    {profile: profile, pending: 0, exp: 1},
+		"should add a replica for every challengesPerReplica challenges": {profile: profile, pending: 3, exp: 2},
+		"should not scale above maxReplicas":                             {profile: profile, pending: 100, exp: 3},
+		"should default minReplicas and challengesPerReplica": {
+			profile: &cmacme.ACMEChallengeSolverHTTP01BurstProfile{MaxReplicas: 5},
+			pending: 11,
+			exp:     2,
+		},
+		"should scale to zero if minReplicas is zero": {
+			profile: &cmacme.ACMEChallengeSolverHTTP01BurstProfile{MinReplicas: pointer.Int32(0), MaxReplicas: 5},
+			pending: 0,
+			exp:     0,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if replicas := burstReplicas(test.profile, test.pending); replicas != test.exp {
+				t.Errorf("expected %d replicas, got %d", test.exp, replicas)
+			}
+		})
+	}
+}
+
+func TestBurstSolver(t *testing.T) {
+	s := &solverFixture{Challenge: burstChallenge("token-1")}
+	s.Setup(t)
+	defer s.Finish(t)
+	ctx := context.TODO()
+
+	lbls, err := burstSolverLabels(s.Challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := lbls[cmacme.BurstSolverLabelKey]
+
+	getReplicas := func() int32 {
+		deploy, err := s.FakeKubeClient().AppsV1().Deployments(defaultTestNamespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get burst solver deployment: %v", err)
+		}
+		return *deploy.Spec.Replicas
+	}
+	getChallenges := func() map[string]string {
+		cm, err := s.FakeKubeClient().CoreV1().ConfigMaps(defaultTestNamespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get burst solver configmap: %v", err)
+		}
+		return cm.Data
+	}
+
+	issuer := burstIssuer(s.Challenge.Spec.Solver)
+	challenges := []*cmacme.Challenge{burstChallenge("token-1"), burstChallenge("token-2"), burstChallenge("token-3")}
+	for _, ch := range challenges {
+		if err := s.Solver.ensureBurstSolver(ctx, issuer, ch); err != nil {
+			t.Fatalf("unexpected error presenting %s: %v", ch.Name, err)
+		}
+		s.Builder.Sync()
+	}
+
+	data := getChallenges()
+	if len(data) != 3 || data["token-2"] != solver.FormatChallenge("token-2.example.com", "token-2.key") {
+		t.Errorf("unexpected challenges in burst solver configmap: %v", data)
+	}
+	if replicas := getReplicas(); replicas != 2 {
+		t.Errorf("expected burst solver to be scaled to 2 replicas, got %d", replicas)
+	}
+
+	deploy, err := s.FakeKubeClient().AppsV1().Deployments(defaultTestNamespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm, err := s.FakeKubeClient().CoreV1().ConfigMaps(defaultTestNamespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, obj := range []metav1.Object{deploy, cm} {
+		refs := obj.GetOwnerReferences()
+		if len(refs) != 1 || refs[0].Kind != v1.ClusterIssuerKind || refs[0].Name != "acme" || refs[0].UID != "issuer-uid" {
+			t.Errorf("expected burst solver to be owned by its issuer, got owner references %v", refs)
+		}
+	}
+	podSpec := deploy.Spec.Template.Spec
+	if podSpec.RestartPolicy != corev1.RestartPolicyAlways {
+		t.Errorf("expected burst solver pods to always restart, got %q", podSpec.RestartPolicy)
+	}
+	if len(podSpec.Volumes) != 1 || podSpec.Volumes[0].ConfigMap == nil || podSpec.Volumes[0].ConfigMap.Name != name {
+		t.Errorf("expected burst solver pods to mount the challenges configmap, got volumes %v", podSpec.Volumes)
+	}
+	if _, ok := deploy.Spec.Template.Labels[cmacme.TokenLabelKey]; ok {
+		t.Errorf("expected burst solver pods not to be labelled with a challenge token, got %v", deploy.Spec.Template.Labels)
+	}
+
+	svc, err := buildService(challenges[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc.Spec.Selector[cmacme.BurstSolverLabelKey] != name {
+		t.Errorf("expected challenge service to select the burst solver pods, got selector %v", svc.Spec.Selector)
+	}
+
+	for _, ch := range challenges[:2] {
+		if err := s.Solver.cleanupBurstSolver(ctx, ch); err != nil {
+			t.Fatalf("unexpected error cleaning up %s: %v", ch.Name, err)
+		}
+		s.Builder.Sync()
+	}
+	if data := getChallenges(); len(data) != 1 {
+		t.Errorf("expected a single pending challenge after cleanup, got %v", data)
+	}
+	if replicas := getReplicas(); replicas != 1 {
+		t.Errorf("expected burst solver to be scaled down to 1 replica, got %d", replicas)
+	}
+}
+
+func TestCleanupStaleBurstSolvers(t *testing.T) {
+	s := &solverFixture{Challenge: burstChallenge("token-1")}
+	s.Setup(t)
+	defer s.Finish(t)
+	ctx := context.TODO()
+
+	issuer := burstIssuer(s.Challenge.Spec.Solver)
+	if err := s.Solver.ensureBurstSolver(ctx, issuer, s.Challenge); err != nil {
+		t.Fatal(err)
+	}
+	s.Builder.Sync()
+	lbls, err := burstSolverLabels(s.Challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := lbls[cmacme.BurstSolverLabelKey]
+
+	exists := func() bool {
+		_, err := s.FakeKubeClient().AppsV1().Deployments(defaultTestNamespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			t.Fatal(err)
+		}
+		return err == nil
+	}
+
+	if err := s.Solver.cleanupStaleBurstSolvers(ctx, issuer, defaultTestNamespace); err != nil {
+		t.Fatal(err)
+	}
+	if !exists() {
+		t.Errorf("expected burst solver configured on the issuer not to be deleted")
+	}
+
+	issuer.Spec.ACME.Solvers = nil
+	if err := s.Solver.cleanupStaleBurstSolvers(ctx, issuer, defaultTestNamespace); err != nil {
+		t.Fatal(err)
+	}
+	if !exists() {
+		t.Errorf("expected burst solver with pending challenges not to be deleted")
+	}
+
+	if err := s.Solver.cleanupBurstSolver(ctx, s.Challenge); err != nil {
+		t.Fatal(err)
+	}
+	s.Builder.Sync()
+	if err := s.Solver.cleanupStaleBurstSolvers(ctx, issuer, defaultTestNamespace); err != nil {
+		t.Fatal(err)
+	}
+	if exists() {
+		t.Errorf("expected stale burst solver without pending challenges to be deleted")
+	}
+	if _, err := s.FakeKubeClient().CoreV1().ConfigMaps(defaultTestNamespace).Get(ctx, name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected configmap of stale burst solver to be deleted, got: %v", err)
+	}
+}
@@ -29,6 +29,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	networkingv1listers "k8s.io/client-go/listers/networking/v1"
 	k8snet "k8s.io/utils/net"
@@ -64,6 +65,11 @@ type Solver struct {
 	ingressLister   networkingv1listers.IngressLister
 	httpRouteLister gwapilisters.HTTPRouteLister
 
+	// deploymentLister and configMapLister only list the Deployments and
+	// ConfigMaps of burst solvers.
+	deploymentLister appsv1listers.DeploymentLister
+	configMapLister  corev1listers.ConfigMapLister
+
 	testReachability reachabilityTest
 	requiredPasses   int
 }
@@ -72,12 +78,15 @@ type reachabilityTest func(ctx context.Context, url *url.URL, key string, dnsSer
 
 // NewSolver returns a new ACME HTTP01 solver for the given *controller.Context.
 func NewSolver(ctx *controller.Context) (*Solver, error) {
+	deployments, configMaps := burstSolverInformers(ctx)
 	return &Solver{
 		Context:          ctx,
 		podLister:        ctx.KubeSharedInformerFactory.Core().V1().Pods().Lister(),
 		serviceLister:    ctx.KubeSharedInformerFactory.Core().V1().Services().Lister(),
 		ingressLister:    ctx.KubeSharedInformerFactory.Networking().V1().Ingresses().Lister(),
 		httpRouteLister:  ctx.GWShared.Gateway().V1alpha2().HTTPRoutes().Lister(),
+		deploymentLister: appsv1listers.NewDeploymentLister(deployments.GetIndexer()),
+		configMapLister:  corev1listers.NewConfigMapLister(configMaps.GetIndexer()),
 		testReachability: testReachability,
 		requiredPasses:   5,
 	}, nil
@@ -108,7 +117,12 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	log := logf.FromContext(ctx).WithName(loggerName)
 	ctx = logf.NewContext(ctx, log)
 
-	_, podErr := s.ensurePod(ctx, ch)
+	var podErr error
+	if burstProfile(ch) != nil {
+		podErr = s.ensureBurstSolver(ctx, issuer, ch)
+	} else {
+		_, podErr = s.ensurePod(ctx, ch)
+	}
 	svc, svcErr := s.ensureService(ctx, ch)
 	if svcErr != nil {
 		return utilerrors.NewAggregate([]error{podErr, svcErr})
@@ -174,13 +188,22 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 	return nil
 }
 
-// CleanUp will ensure the created service, ingress and pod are clean/deleted of any
-// cert-manager created data.
+// CleanUp will ensure the created service, ingress or HTTPRoute and pod are
+// clean/deleted of any cert-manager created data. Challenges solved by a burst
+// solver are removed from it instead, and burst solvers of the issuer which
+// are no longer needed are deleted.
 func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
 	var errs []error
+	if burstProfile(ch) != nil {
+		errs = append(errs, s.cleanupBurstSolver(ctx, ch))
+	}
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.Ingress != nil {
+		errs = append(errs, s.cleanupStaleBurstSolvers(ctx, issuer, ch.Namespace))
+	}
 	errs = append(errs, s.cleanupPods(ctx, ch))
 	errs = append(errs, s.cleanupServices(ctx, ch))
 	errs = append(errs, s.cleanupIngresses(ctx, ch))
+	errs = append(errs, s.cleanupGatewayHTTPRoutes(ctx, ch))
 	return utilerrors.NewAggregate(errs)
 }
 
@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"reflect"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/pointer"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -121,7 +123,7 @@ func (s *Solver) checkAndUpdateGatewayHTTPRoute(ctx context.Context, ch *cmacme.
 			expectedLabels[k] = v
 		}
 	}
-	actualLabels := ch.Labels
+	actualLabels := httpRoute.Labels
 	if reflect.DeepEqual(expectedSpec, actualSpec) && reflect.DeepEqual(expectedLabels, actualLabels) {
 		return httpRoute, nil
 	}
@@ -183,7 +185,33 @@ func generateHTTPRouteSpec(ch *cmacme.Challenge, svcName string) gwapi.HTTPRoute
 	}
 }
 
-func (s *Solver) cleanupGatewayHTTPRoutes(_ context.Context, _ *cmacme.Challenge) error {
-	// Unlike Ingress, we don't modify existing HTTPRoutes so there is nothing to do here.
-	return nil
+// cleanupGatewayHTTPRoutes deletes any HTTPRoutes that were created to solve
+// the given challenge. Unlike Ingress, we never modify existing HTTPRoutes, so
+// only routes carrying the challenge's solver labels are considered.
+func (s *Solver) cleanupGatewayHTTPRoutes(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanupGatewayHTTPRoutes")
+
+	if ch.Spec.Solver.HTTP01 == nil || ch.Spec.Solver.HTTP01.GatewayHTTPRoute == nil {
+		return nil
+	}
+
+	httpRoutes, err := s.httpRouteLister.HTTPRoutes(ch.Namespace).List(labels.Set(podLabels(ch)).AsSelector())
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, httpRoute := range httpRoutes {
+		log := logf.WithRelatedResource(log, httpRoute).V(logf.DebugLevel)
+		log.V(logf.InfoLevel).Info("deleting httpRoute resource")
+
+		err := s.GWClient.GatewayV1alpha2().HTTPRoutes(httpRoute.Namespace).Delete(ctx, httpRoute.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.V(logf.WarnLevel).Info("failed to delete httpRoute resource", "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		log.V(logf.InfoLevel).Info("successfully deleted httpRoute resource")
+	}
+
+	return utilerrors.NewAggregate(errs)
 }
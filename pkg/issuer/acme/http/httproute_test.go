@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func gatewayHTTPRouteChallenge() *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: defaultTestNamespace,
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "abcd",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+						Labels: map[string]string{"gateway": "acme"},
+						ParentRefs: []gwapi.ParentReference{
+							{Name: "acme-gateway"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEnsureGatewayHTTPRoute(t *testing.T) {
+	tests := map[string]solverFixture{
+		"should create an HTTPRoute attached to the configured parentRefs": {
+			Challenge: gatewayHTTPRouteChallenge(),
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				httpRoute := args[0].(*gwapi.HTTPRoute)
+				if len(httpRoute.Spec.ParentRefs) != 1 || httpRoute.Spec.ParentRefs[0].Name != "acme-gateway" {
+					t.Errorf("unexpected parentRefs on HTTPRoute: %+v", httpRoute.Spec.ParentRefs)
+				}
+				if httpRoute.Labels["gateway"] != "acme" {
+					t.Errorf("expected HTTPRoute to carry the solver labels, got: %v", httpRoute.Labels)
+				}
+				if len(httpRoute.Spec.Hostnames) != 1 || httpRoute.Spec.Hostnames[0] != "example.com" {
+					t.Errorf("unexpected hostnames on HTTPRoute: %v", httpRoute.Spec.Hostnames)
+				}
+			},
+		},
+		"should not update an HTTPRoute that is already up to date": {
+			Challenge: gatewayHTTPRouteChallenge(),
+			PreFn: func(t *testing.T, s *solverFixture) {
+				_, err := s.Solver.createGatewayHTTPRoute(context.TODO(), s.Challenge, "fakeservice")
+				if err != nil {
+					t.Errorf("error preparing test: %v", err)
+				}
+				s.Builder.Sync()
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				for _, action := range s.Builder.FakeGWClient().Actions() {
+					if action.Matches("update", "httproutes") {
+						t.Errorf("expected HTTPRoute not to be updated, but got action: %v", action)
+					}
+				}
+			},
+		},
+		"should update an HTTPRoute whose parentRefs are out of date": {
+			Challenge: gatewayHTTPRouteChallenge(),
+			PreFn: func(t *testing.T, s *solverFixture) {
+				ch := s.Challenge.DeepCopy()
+				ch.Spec.Solver.HTTP01.GatewayHTTPRoute.ParentRefs = []gwapi.ParentReference{{Name: "old-gateway"}}
+				_, err := s.Solver.createGatewayHTTPRoute(context.TODO(), ch, "fakeservice")
+				if err != nil {
+					t.Errorf("error preparing test: %v", err)
+				}
+				s.Builder.Sync()
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				httpRoute := args[0].(*gwapi.HTTPRoute)
+				if len(httpRoute.Spec.ParentRefs) != 1 || httpRoute.Spec.ParentRefs[0].Name != "acme-gateway" {
+					t.Errorf("expected HTTPRoute parentRefs to be updated, got: %+v", httpRoute.Spec.ParentRefs)
+				}
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Setup(t)
+			resp, err := test.Solver.ensureGatewayHTTPRoute(context.TODO(), test.Challenge, "fakeservice")
+			if err != nil && !test.Err {
+				t.Errorf("Expected function to not error, but got: %v", err)
+			}
+			if err == nil && test.Err {
+				t.Errorf("Expected function to get an error, but got: %v", err)
+			}
+			test.Finish(t, resp, err)
+		})
+	}
+}
+
+func TestCleanupGatewayHTTPRoutes(t *testing.T) {
+	const createdHTTPRouteKey = "createdHTTPRoute"
+	tests := map[string]solverFixture{
+		"should delete HTTPRoute resource": {
+			Challenge: gatewayHTTPRouteChallenge(),
+			PreFn: func(t *testing.T, s *solverFixture) {
+				httpRoute, err := s.Solver.createGatewayHTTPRoute(context.TODO(), s.Challenge, "fakeservice")
+				if err != nil {
+					t.Errorf("error preparing test: %v", err)
+				}
+				s.testResources[createdHTTPRouteKey] = httpRoute
+				s.Builder.Sync()
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				createdHTTPRoute := s.testResources[createdHTTPRouteKey].(*gwapi.HTTPRoute)
+				httpRoute, err := s.Builder.FakeGWClient().GatewayV1alpha2().HTTPRoutes(s.Challenge.Namespace).Get(context.TODO(), createdHTTPRoute.Name, metav1.GetOptions{})
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("expected HTTPRoute %q to not exist, but got: %+v, %v", createdHTTPRoute.Name, httpRoute, err)
+				}
+			},
+		},
+		"should not delete HTTPRoute resources without appropriate labels": {
+			Challenge: gatewayHTTPRouteChallenge(),
+			PreFn: func(t *testing.T, s *solverFixture) {
+				httpRoute, err := s.Builder.FakeGWClient().GatewayV1alpha2().HTTPRoutes(s.Challenge.Namespace).Create(context.TODO(), &gwapi.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "unrelated",
+						Namespace: s.Challenge.Namespace,
+						Labels:    map[string]string{"gateway": "acme"},
+					},
+				}, metav1.CreateOptions{})
+				if err != nil {
+					t.Errorf("error preparing test: %v", err)
+				}
+				s.testResources[createdHTTPRouteKey] = httpRoute
+				s.Builder.Sync()
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				createdHTTPRoute := s.testResources[createdHTTPRouteKey].(*gwapi.HTTPRoute)
+				_, err := s.Builder.FakeGWClient().GatewayV1alpha2().HTTPRoutes(s.Challenge.Namespace).Get(context.TODO(), createdHTTPRoute.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Errorf("expected HTTPRoute %q to still exist, but got: %v", createdHTTPRoute.Name, err)
+				}
+			},
+		},
+		"should return an error if a delete fails": {
+			Challenge: gatewayHTTPRouteChallenge(),
+			Err:       true,
+			PreFn: func(t *testing.T, s *solverFixture) {
+				_, err := s.Solver.createGatewayHTTPRoute(context.TODO(), s.Challenge, "fakeservice")
+				if err != nil {
+					t.Errorf("error preparing test: %v", err)
+				}
+				s.Builder.FakeGWClient().PrependReactor("delete", "httproutes", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+					return true, nil, errors.New("simulated error")
+				})
+				s.Builder.Sync()
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Setup(t)
+			err := test.Solver.cleanupGatewayHTTPRoutes(context.TODO(), test.Challenge)
+			if err != nil && !test.Err {
+				t.Errorf("Expected function to not error, but got: %v", err)
+			}
+			if err == nil && test.Err {
+				t.Errorf("Expected function to get an error, but got: %v", err)
+			}
+			test.Finish(t)
+		})
+	}
+}
@@ -152,9 +152,31 @@ func (s *Solver) buildPod(ch *cmacme.Challenge) *corev1.Pod {
 		}
 	}
 
+	// The pod template may select Windows nodes, on which the Linux specific
+	// security settings of the default pod are not allowed.
+	if pod.Spec.NodeSelector[corev1.LabelOSStable] == string(corev1.Windows) {
+		setWindowsPodSpec(pod)
+	}
+
 	return pod
 }
 
+// setWindowsPodSpec marks the pod as running on Windows and removes the
+// security settings which are only supported on Linux, which the API server
+// rejects for Windows pods.
+func setWindowsPodSpec(pod *corev1.Pod) {
+	pod.Spec.OS = &corev1.PodOS{Name: corev1.Windows}
+	if pod.Spec.SecurityContext != nil {
+		pod.Spec.SecurityContext.SeccompProfile = nil
+	}
+	for i := range pod.Spec.Containers {
+		if sc := pod.Spec.Containers[i].SecurityContext; sc != nil {
+			sc.AllowPrivilegeEscalation = nil
+			sc.Capabilities = nil
+		}
+	}
+}
+
 // Note: this function builds pod spec using defaults and any configuration
 // options passed via flags to cert-manager controller.
 // Solver pod configuration via flags is a now deprecated
@@ -274,5 +296,9 @@ func (s *Solver) mergePodObjectMetaWithPodTemplate(pod *corev1.Pod, podTempl *cm
 		pod.Spec.ServiceAccountName = podTempl.Spec.ServiceAccountName
 	}
 
+	if podTempl.Spec.Resources != nil {
+		pod.Spec.Containers[0].Resources = *podTempl.Spec.Resources.DeepCopy()
+	}
+
 	return pod
 }
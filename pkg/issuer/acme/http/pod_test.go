@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
@@ -297,6 +298,11 @@ func TestMergePodObjectMetaWithPodTemplate(t *testing.T) {
 											},
 										},
 										ServiceAccountName: "cert-manager",
+										Resources: &corev1.ResourceRequirements{
+											Requests: corev1.ResourceList{
+												corev1.ResourceCPU: resource.MustParse("5m"),
+											},
+										},
 									},
 								},
 							},
@@ -329,6 +335,63 @@ func TestMergePodObjectMetaWithPodTemplate(t *testing.T) {
 				}
 				resultingPod.Spec.PriorityClassName = "high"
 				resultingPod.Spec.ServiceAccountName = "cert-manager"
+				resultingPod.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("5m"),
+					},
+				}
+				s.testResources[createdPodKey] = resultingPod
+
+				s.Builder.Sync()
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				resultingPod := s.testResources[createdPodKey].(*corev1.Pod)
+
+				resp, ok := args[0].(*corev1.Pod)
+				if !ok {
+					t.Errorf("expected pod to be returned, but got %v", args[0])
+					t.Fail()
+					return
+				}
+
+				// ignore pointer differences here
+				resultingPod.OwnerReferences = resp.OwnerReferences
+
+				if resp.String() != resultingPod.String() {
+					t.Errorf("unexpected pod generated from merge\nexp=%s\ngot=%s",
+						resultingPod, resp)
+					t.Fail()
+				}
+			},
+		},
+		"should remove Linux specific settings if the template selects Windows nodes": {
+			Challenge: &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "example.com",
+					Solver: cmacme.ACMEChallengeSolver{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+								PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+									Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+										NodeSelector: map[string]string{
+											"kubernetes.io/os": "windows",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			PreFn: func(t *testing.T, s *solverFixture) {
+				resultingPod := s.Solver.buildDefaultPod(s.Challenge)
+				resultingPod.Spec.NodeSelector = map[string]string{
+					"kubernetes.io/os": "windows",
+				}
+				resultingPod.Spec.Tolerations = []corev1.Toleration{}
+				resultingPod.Spec.OS = &corev1.PodOS{Name: corev1.Windows}
+				resultingPod.Spec.SecurityContext.SeccompProfile = nil
+				resultingPod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{}
 				s.testResources[createdPodKey] = resultingPod
 
 				s.Builder.Sync()
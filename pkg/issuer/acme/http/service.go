@@ -101,6 +101,14 @@ func (s *Solver) createService(ctx context.Context, ch *cmacme.Challenge) (*core
 
 func buildService(ch *cmacme.Challenge) (*corev1.Service, error) {
 	podLabels := podLabels(ch)
+	selector := podLabels
+	if burstProfile(ch) != nil {
+		var err error
+		selector, err = burstSolverLabels(ch)
+		if err != nil {
+			return nil, err
+		}
+	}
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "cm-acme-http-solver-",
@@ -120,7 +128,7 @@ func buildService(ch *cmacme.Challenge) (*corev1.Service, error) {
 					TargetPort: intstr.FromInt(acmeSolverListenPort),
 				},
 			},
-			Selector: podLabels,
+			Selector: selector,
 		},
 	}
 
@@ -19,7 +19,10 @@ package solver
 import (
 	"fmt"
 	"net/http"
+	"os"
 	"path"
+	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -32,16 +35,68 @@ type HTTP01Solver struct {
 	Token  string
 	Key    string
 
+	// ChallengesDir is a directory containing a file for each challenge to be
+	// served, named after the challenge token. It is used by the solvers of
+	// burst profiles, which serve many challenges at once. If set, Domain,
+	// Token and Key are ignored.
+	ChallengesDir string
+
 	http.Server
 }
 
+// tokenRegexp matches the base64url encoded tokens of ACME challenges, which
+// can safely be used as the names of files and ConfigMap keys.
+var tokenRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ValidToken returns true if the token can be served from a challenges
+// directory.
+func ValidToken(token string) bool {
+	return tokenRegexp.MatchString(token)
+}
+
+// FormatChallenge returns the content of the file used to serve a challenge
+// for the given domain from a challenges directory.
+func FormatChallenge(domain, key string) string {
+	return domain + "\n" + key
+}
+
+// challenge returns the domain and key of the challenge with the given token,
+// and false if there is no such challenge.
+func (h *HTTP01Solver) challenge(token string) (string, string, bool) {
+	if h.ChallengesDir == "" {
+		if token != h.Token {
+			return "", "", false
+		}
+		return h.Domain, h.Key, true
+	}
+	if !ValidToken(token) {
+		return "", "", false
+	}
+	data, err := os.ReadFile(filepath.Join(h.ChallengesDir, token))
+	if err != nil {
+		return "", "", false
+	}
+	domain, key, ok := strings.Cut(string(data), "\n")
+	if !ok {
+		return "", "", false
+	}
+	return domain, key, true
+}
+
 func (h *HTTP01Solver) Listen(log logr.Logger) error {
-	log.Info("starting listener",
-		"expected_domain", h.Domain,
-		"expected_token", h.Token,
-		"expected_key", h.Key,
-		"listen_port", h.ListenPort,
-	)
+	if h.ChallengesDir != "" {
+		log.Info("starting listener",
+			"challenges_dir", h.ChallengesDir,
+			"listen_port", h.ListenPort,
+		)
+	} else {
+		log.Info("starting listener",
+			"expected_domain", h.Domain,
+			"expected_token", h.Token,
+			"expected_key", h.Key,
+			"listen_port", h.ListenPort,
+		)
+	}
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// extract vars from the request
@@ -69,17 +124,17 @@ func (h *HTTP01Solver) Listen(log logr.Logger) error {
 			return
 		}
 
-		log.Info("comparing host", "expected_host", h.Domain)
-		if h.Domain != host {
-			log.Info("invalid host", "expected_host", h.Domain)
+		domain, key, ok := h.challenge(token)
+		if !ok {
+			// if nothing else, we return a 404 here
+			log.Info("invalid token")
 			http.NotFound(w, r)
 			return
 		}
 
-		log.Info("comparing token", "expected_token", h.Token)
-		if h.Token != token {
-			// if nothing else, we return a 404 here
-			log.Info("invalid token", "expected_token", h.Token)
+		log.Info("comparing host", "expected_host", domain)
+		if domain != host {
+			log.Info("invalid host", "expected_host", domain)
 			http.NotFound(w, r)
 			return
 		}
@@ -87,7 +142,7 @@ func (h *HTTP01Solver) Listen(log logr.Logger) error {
 		log.Info("got successful challenge request, writing key")
 		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
 		w.WriteHeader(http.StatusOK)
-		fmt.Fprint(w, h.Key)
+		fmt.Fprint(w, key)
 	})
 
 	h.Server = http.Server{
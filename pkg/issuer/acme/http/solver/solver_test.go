@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChallenge(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "token-1"), []byte(FormatChallenge("example.com", "token-1.key")), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "malformed"), []byte("example.com"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		solver *HTTP01Solver
+		token  string
+
+		expDomain string
+		expKey    string
+		expOK     bool
+	}{
+		"should serve the configured challenge": {
+			solver:    &HTTP01Solver{Domain: "example.com", Token: "token-1", Key: "token-1.key"},
+			token:     "token-1",
+			expDomain: "example.com",
+			expKey:    "token-1.key",
+			expOK:     true,
+		},
+		"should not serve a different token than the configured one": {
+			solver: &HTTP01Solver{Domain: "example.com", Token: "token-1", Key: "token-1.key"},
+			token:  "token-2",
+		},
+		"should serve a challenge from the challenges directory": {
+			solver:    &HTTP01Solver{ChallengesDir: dir},
+			token:     "token-1",
+			expDomain: "example.com",
+			expKey:    "token-1.key",
+			expOK:     true,
+		},
+		"should not serve a token missing from the challenges directory": {
+			solver: &HTTP01Solver{ChallengesDir: dir},
+			token:  "token-2",
+		},
+		"should not serve a malformed challenge file": {
+			solver: &HTTP01Solver{ChallengesDir: dir},
+			token:  "malformed",
+		},
+		"should not read files outside of the challenges directory": {
+			solver: &HTTP01Solver{ChallengesDir: filepath.Join(dir, "sub")},
+			token:  "..",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			domain, key, ok := test.solver.challenge(test.token)
+			if domain != test.expDomain || key != test.expKey || ok != test.expOK {
+				t.Errorf("unexpected challenge, exp=(%q, %q, %t) got=(%q, %q, %t)",
+					test.expDomain, test.expKey, test.expOK, domain, key, ok)
+			}
+		})
+	}
+}
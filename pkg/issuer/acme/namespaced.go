@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"strings"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util"
+	"github.com/cert-manager/cert-manager/pkg/util/errors"
+)
+
+// setupNamespacedAccounts verifies or registers the ACME accounts of a
+// ClusterIssuer which resolves External Account Binding credentials in the
+// namespace of each request, one for every namespace containing them. It
+// returns the number of namespaces with an account, and whether any failure
+// should be retried.
+// Failures are recorded for each namespace, and do not prevent the accounts
+// of other namespaces from being used.
+func (a *Acme) setupNamespacedAccounts(ctx context.Context) (int, bool, error) {
+	config := a.issuer.GetSpec().ACME
+	uid := string(a.issuer.GetUID())
+
+	secrets, err := a.secretLister.ListMetadata(labels.Everything())
+	if err != nil {
+		return 0, true, err
+	}
+	namespaces := sets.NewString()
+	for _, secret := range secrets {
+		if secret.GetName() == config.ExternalAccountBinding.Key.Name {
+			namespaces.Insert(secret.GetNamespace())
+		}
+	}
+
+	// the accounts of namespaces which no longer contain credentials are no
+	// longer used
+	prefix := accounts.NamespacedUID(uid, "")
+	for clientUID := range a.accountRegistry.ListClients() {
+		if ns := strings.TrimPrefix(clientUID, prefix); ns != clientUID && !namespaces.Has(ns) {
+			a.accountRegistry.RemoveClient(clientUID)
+		}
+	}
+
+	httpClient := accounts.BuildHTTPClient(a.metrics, config.SkipTLSVerify)
+	httpClient.Transport = util.IssuerHTTPClientRoundTripper(httpClient.Transport, a.issuer.GetSpec().HTTPClient)
+	var errs []error
+	retry := false
+	for _, ns := range namespaces.List() {
+		err := a.setupNamespacedAccount(ctx, httpClient, ns)
+		if err == nil {
+			continue
+		}
+		retry = retry || isRetryableAccountError(err)
+		a.recorder.Eventf(a.issuer, corev1.EventTypeWarning, errorAccountRegistrationFailed, messageTemplateNamespacedAccountFailed, ns, err)
+		errs = append(errs, fmt.Errorf("namespace %q: %v", ns, err))
+	}
+
+	return namespaces.Len() - len(errs), retry, utilerrors.NewAggregate(errs)
+}
+
+// setupNamespacedAccount verifies or registers the ACME account of the
+// ClusterIssuer for namespace, and adds its client to the account registry.
+func (a *Acme) setupNamespacedAccount(ctx context.Context, httpClient *http.Client, ns string) error {
+	log := logf.FromContext(ctx).WithValues("namespace", ns)
+	config := a.issuer.GetSpec().ACME
+
+	privateKeySelector := acme.PrivateKeySelector(config.PrivateKey)
+	pk, err := a.keyFromSecret(ctx, ns, privateKeySelector.Name, privateKeySelector.Key)
+	if !config.DisableAccountKeyGeneration && apierrors.IsNotFound(err) {
+		log.V(logf.InfoLevel).Info("generating acme account private key")
+		pk, err = a.createAccountPrivateKey(ctx, privateKeySelector, ns)
+	}
+	if err != nil {
+		return err
+	}
+	rsaPk, ok := pk.(*rsa.PrivateKey)
+	if !ok {
+		return errors.NewInvalidData(messageTemplateNotRSA, privateKeySelector.Name)
+	}
+
+	cl := a.clientBuilder(httpClient, *config, rsaPk, a.userAgent)
+
+	// No account URI is recorded for namespaced accounts, so an existing
+	// account is always looked up first, which does not need the External
+	// Account Binding credentials.
+	account, err := cl.GetReg(ctx, "")
+	if err == acmeapi.ErrNoAccount {
+		var eabKey []byte
+		eabKey, err = a.getEABKey(ctx, ns)
+		if err != nil {
+			return err
+		}
+		account, err = a.registerAccount(ctx, cl, &acmeapi.ExternalAccountBinding{
+			KID: config.ExternalAccountBinding.KeyID,
+			Key: eabKey,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if _, _, err := ensureEmailUpToDate(ctx, cl, account, config.Email); err != nil {
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("verified namespaced registration with ACME server")
+	a.accountRegistry.AddClient(httpClient, accounts.NamespacedUID(string(a.issuer.GetUID()), ns), *config, rsaPk, a.userAgent)
+	return nil
+}
+
+// isRetryableAccountError returns false for errors which retrying will not
+// resolve, such as missing or invalid Secrets and requests rejected by the
+// ACME server.
+func isRetryableAccountError(err error) bool {
+	if apierrors.IsNotFound(err) || errors.IsInvalidData(err) {
+		return false
+	}
+	if acmeErr, ok := err.(*acmeapi.Error); ok {
+		return acmeErr.StatusCode < 400 || acmeErr.StatusCode >= 500
+	}
+	return true
+}
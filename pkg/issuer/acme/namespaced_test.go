@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	fakeregistry "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/coreclients"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestAcme_SetupNamespacedAccounts(t *testing.T) {
+	issuer := gen.ClusterIssuer("test-issuer",
+		gen.SetIssuerACMEURL(acmev2Prod),
+		gen.SetIssuerACMEEAB("kid", "eab"),
+		func(iss cmapi.GenericIssuer) {
+			iss.GetSpec().ACME.ExternalAccountBinding.PerNamespace = true
+		})
+	issuer.UID = "uid"
+
+	// 'ZEdWemRBbz0K' is 'test' double base64-encoded.
+	eabSecret := gen.Secret("eab", gen.SetSecretData(map[string][]byte{"key": []byte("ZEdWemRBbz0K")}))
+	secrets := []*corev1.Secret{
+		gen.SecretFrom(eabSecret, gen.SetSecretNamespace("tenant-a")),
+		gen.SecretFrom(eabSecret, gen.SetSecretNamespace("tenant-b")),
+		gen.Secret("unrelated", gen.SetSecretNamespace("tenant-c")),
+	}
+	keys := map[string]crypto.Signer{
+		"tenant-a": mustGenerateRSAKey(t),
+		"tenant-b": mustGenerateRSAKey(t),
+	}
+
+	tests := map[string]struct {
+		// Error returned by cl.Register for the account of each namespace.
+		registerErrs map[string]error
+
+		expectedAddedClients   []string
+		expectedRemovedClients []string
+		expectedRegistered     []string
+		expectedCondition      cmapi.IssuerCondition
+		expectedEvents         []string
+		wantsErr               bool
+	}{
+		"accounts are registered for every namespace with credentials": {
+			expectedAddedClients:   []string{"uid/tenant-a", "uid/tenant-b"},
+			expectedRemovedClients: []string{"uid", "uid/stale"},
+			expectedRegistered:     []string{"tenant-a", "tenant-b"},
+			expectedCondition: cmapi.IssuerCondition{
+				Type:    cmapi.IssuerConditionReady,
+				Status:  cmmeta.ConditionTrue,
+				Reason:  successAccountRegistered,
+				Message: fmt.Sprintf(messageTemplateNamespacedAccountsRegistered, 2),
+			},
+		},
+		"rejected registrations do not prevent other namespaces from being registered": {
+			registerErrs:           map[string]error{"tenant-b": &acmeapi.Error{StatusCode: 403}},
+			expectedAddedClients:   []string{"uid/tenant-a"},
+			expectedRemovedClients: []string{"uid", "uid/stale"},
+			expectedRegistered:     []string{"tenant-a", "tenant-b"},
+			expectedCondition: cmapi.IssuerCondition{
+				Type:    cmapi.IssuerConditionReady,
+				Status:  cmmeta.ConditionFalse,
+				Reason:  errorAccountRegistrationFailed,
+				Message: messageAccountRegistrationFailed + `namespace "tenant-b": 403 : `,
+			},
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, errorAccountRegistrationFailed, fmt.Sprintf(messageTemplateNamespacedAccountFailed, "tenant-b", "403 : ")),
+			},
+		},
+		"failed registrations are retried": {
+			registerErrs:           map[string]error{"tenant-a": &acmeapi.Error{StatusCode: 500}},
+			expectedAddedClients:   []string{"uid/tenant-b"},
+			expectedRemovedClients: []string{"uid", "uid/stale"},
+			expectedRegistered:     []string{"tenant-a", "tenant-b"},
+			expectedCondition: cmapi.IssuerCondition{
+				Type:    cmapi.IssuerConditionReady,
+				Status:  cmmeta.ConditionFalse,
+				Reason:  errorAccountRegistrationFailed,
+				Message: messageAccountRegistrationFailed + `namespace "tenant-a": 500 : `,
+			},
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, errorAccountRegistrationFailed, fmt.Sprintf(messageTemplateNamespacedAccountFailed, "tenant-a", "500 : ")),
+			},
+			wantsErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var added, removed, registered []string
+			ar := &fakeregistry.FakeRegistry{
+				AddClientFunc: func(uid string, _ cmacme.ACMEIssuer, _ *rsa.PrivateKey, _ string) {
+					added = append(added, uid)
+				},
+				RemoveClientFunc: func(uid string) {
+					removed = append(removed, uid)
+				},
+				ListClientsFunc: func() map[string]acmecl.Interface {
+					return map[string]acmecl.Interface{
+						"uid":          nil,
+						"uid/stale":    nil,
+						"uid/tenant-a": nil,
+						"other-uid/ns": nil,
+					}
+				},
+			}
+
+			// each namespace has its own account key, which identifies the
+			// namespace of the ACME client built with it
+			clientBuilder := func(_ *http.Client, _ cmacme.ACMEIssuer, pk *rsa.PrivateKey, _ string) acmecl.Interface {
+				var ns string
+				for n, key := range keys {
+					if pk.Equal(key) {
+						ns = n
+					}
+				}
+				return &acmecl.FakeACME{
+					FakeGetReg: func(context.Context, string) (*acmeapi.Account, error) {
+						return nil, acmeapi.ErrNoAccount
+					},
+					FakeRegister: func(_ context.Context, a *acmeapi.Account, _ func(string) bool) (*acmeapi.Account, error) {
+						if a.ExternalAccountBinding == nil || a.ExternalAccountBinding.KID != "kid" {
+							t.Errorf("unexpected external account binding: %+v", a.ExternalAccountBinding)
+						}
+						registered = append(registered, ns)
+						return a, test.registerErrs[ns]
+					},
+				}
+			}
+
+			recorder := new(controllertest.FakeRecorder)
+			a := Acme{
+				issuer:          issuer.DeepCopy(),
+				secretLister:    fakeSecretMetadataLister(secrets),
+				secretsClient:   coreclients.NewFakeSecretsGetter(coreclients.SetFakeSecretsGetterGet(eabSecret, nil)),
+				accountRegistry: ar,
+				keyFromSecret: func(_ context.Context, namespace, _, _ string) (crypto.Signer, error) {
+					return keys[namespace], nil
+				},
+				clientBuilder: clientBuilder,
+				recorder:      recorder,
+			}
+
+			err := a.Setup(context.Background())
+			if (err != nil) != test.wantsErr {
+				t.Errorf("expected error %t, got: %v", test.wantsErr, err)
+			}
+
+			sort.Strings(added)
+			sort.Strings(removed)
+			sort.Strings(registered)
+			if !reflect.DeepEqual(added, test.expectedAddedClients) {
+				t.Errorf("expected clients %v to be added, got %v", test.expectedAddedClients, added)
+			}
+			if !reflect.DeepEqual(removed, test.expectedRemovedClients) {
+				t.Errorf("expected clients %v to be removed, got %v", test.expectedRemovedClients, removed)
+			}
+			if !reflect.DeepEqual(registered, test.expectedRegistered) {
+				t.Errorf("expected accounts of %v to be registered, got %v", test.expectedRegistered, registered)
+			}
+
+			conditions := a.issuer.GetStatus().Conditions
+			if len(conditions) != 1 {
+				t.Fatalf("expected a single condition, got %v", conditions)
+			}
+			got := conditions[0]
+			if got.Type != test.expectedCondition.Type || got.Status != test.expectedCondition.Status ||
+				got.Reason != test.expectedCondition.Reason || got.Message != test.expectedCondition.Message {
+				t.Errorf("expected condition %+v, got %+v", test.expectedCondition, got)
+			}
+			if !reflect.DeepEqual(recorder.Events, test.expectedEvents) {
+				t.Errorf("expected events %v, got %v", test.expectedEvents, recorder.Events)
+			}
+		})
+	}
+}
+
+// fakeSecretMetadataLister lists the metadata of the given Secrets.
+type fakeSecretMetadataLister []*corev1.Secret
+
+func (l fakeSecretMetadataLister) ListMetadata(labels.Selector) ([]metav1.Object, error) {
+	var objs []metav1.Object
+	for _, secret := range l {
+		objs = append(objs, secret)
+	}
+	return objs, nil
+}
@@ -36,6 +36,7 @@ import (
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util"
 	"github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
@@ -63,6 +64,9 @@ const (
 	messageTemplateFailedToParseURL        = "Failed to parse existing ACME server URI %q: %v"
 	messageTemplateFailedToParseAccountURL = "Failed to parse existing ACME account URI %q: %v"
 	messageTemplateFailedToGetEABKey       = "failed to get External Account Binding key from secret: %v"
+
+	messageTemplateNamespacedAccountsRegistered = "The ACME accounts of %d namespaces were registered with the ACME server"
+	messageTemplateNamespacedAccountFailed      = "Failed to register ACME account for namespace %q: %v"
 )
 
 // Setup will verify an existing ACME registration, or create one if not
@@ -91,6 +95,25 @@ func (a *Acme) Setup(ctx context.Context) error {
 		return nil
 	}
 
+	// ClusterIssuers with per-namespace External Account Binding credentials
+	// have no account of their own.
+	if accounts.UsesNamespacedAccounts(a.issuer) {
+		a.accountRegistry.RemoveClient(string(a.issuer.GetUID()))
+		registered, retry, err := a.setupNamespacedAccounts(ctx)
+		if err != nil {
+			reason = errorAccountRegistrationFailed
+			msg = messageAccountRegistrationFailed + err.Error()
+			if retry {
+				return err
+			}
+			return nil
+		}
+		status = cmmeta.ConditionTrue
+		reason = successAccountRegistered
+		msg = fmt.Sprintf(messageTemplateNamespacedAccountsRegistered, registered)
+		return nil
+	}
+
 	// if the namespace field is not set, we are working on a ClusterIssuer resource
 	// therefore we should check for the ACME private key in the 'cluster resource namespace'.
 	ns := a.issuer.GetObjectMeta().Namespace
@@ -156,6 +179,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 	// this function.
 	a.accountRegistry.RemoveClient(string(a.issuer.GetUID()))
 	httpClient := accounts.BuildHTTPClient(a.metrics, a.issuer.GetSpec().ACME.SkipTLSVerify)
+	httpClient.Transport = util.IssuerHTTPClientRoundTripper(httpClient.Transport, a.issuer.GetSpec().HTTPClient)
 	cl := a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
 
 	// TODO: perform a complex check to determine whether we need to verify
@@ -221,35 +245,42 @@ func (a *Acme) Setup(ctx context.Context) error {
 		a.issuer.GetStatus().ACMEStatus().URI = ""
 	}
 
-	var eabAccount *acmeapi.ExternalAccountBinding
-	if eabObj := a.issuer.GetSpec().ACME.ExternalAccountBinding; eabObj != nil {
-		eabKey, err := a.getEABKey(ctx, ns)
-		switch {
-		// Do not re-try if we fail to get the MAC key as it does not exist at the reference.
-		case apierrors.IsNotFound(err), errors.IsInvalidData(err):
-			log.Error(err, "failed to verify ACME account")
-			reason = errorAccountRegistrationFailed
-			msg = messageAccountRegistrationFailed + err.Error()
-			a.recorder.Event(a.issuer, corev1.EventTypeWarning,
-				errorAccountRegistrationFailed,
-				msg)
-			return nil
-
-		case err != nil:
-			reason = errorAccountRegistrationFailed
-			msg = messageAccountRegistrationFailed + err.Error()
-			return fmt.Errorf(msg)
+	// An account that was registered before is looked up without the
+	// External Account Binding, which is only needed to register a new
+	// account. This allows its credentials to be rotated, or removed once
+	// used, without recreating the account.
+	account, err := existingAccount(ctx, cl, a.issuer.GetStatus().ACMEStatus().URI)
+	if err == nil && account == nil {
+		var eabAccount *acmeapi.ExternalAccountBinding
+		if eabObj := a.issuer.GetSpec().ACME.ExternalAccountBinding; eabObj != nil {
+			eabKey, err := a.getEABKey(ctx, ns)
+			switch {
+			// Do not re-try if we fail to get the MAC key as it does not exist at the reference.
+			case apierrors.IsNotFound(err), errors.IsInvalidData(err):
+				log.Error(err, "failed to verify ACME account")
+				reason = errorAccountRegistrationFailed
+				msg = messageAccountRegistrationFailed + err.Error()
+				a.recorder.Event(a.issuer, corev1.EventTypeWarning,
+					errorAccountRegistrationFailed,
+					msg)
+				return nil
+
+			case err != nil:
+				reason = errorAccountRegistrationFailed
+				msg = messageAccountRegistrationFailed + err.Error()
+				return fmt.Errorf(msg)
+			}
+
+			// set the external account binding
+			eabAccount = &acmeapi.ExternalAccountBinding{
+				KID: eabObj.KeyID,
+				Key: eabKey,
+			}
 		}
 
-		// set the external account binding
-		eabAccount = &acmeapi.ExternalAccountBinding{
-			KID: eabObj.KeyID,
-			Key: eabKey,
-		}
+		// register an ACME account or retrieve it if it already exists.
+		account, err = a.registerAccount(ctx, cl, eabAccount)
 	}
-
-	// register an ACME account or retrieve it if it already exists.
-	account, err := a.registerAccount(ctx, cl, eabAccount)
 	if err != nil {
 		// TODO: this error could be from an account registration or an attempt
 		// to retrieve an existing account- perhaps we should log different
@@ -349,6 +380,21 @@ func ensureEmailUpToDate(ctx context.Context, cl client.Interface, acc *acmeapi.
 	return acc, registeredEmail, nil
 }
 
+// existingAccount returns the ACME account of the client's private key if an
+// account was registered with it before, as recorded by accountURI. It
+// returns nil if no account was registered, or if the account no longer
+// exists.
+func existingAccount(ctx context.Context, cl client.Interface, accountURI string) (*acmeapi.Account, error) {
+	if accountURI == "" {
+		return nil, nil
+	}
+	acc, err := cl.GetReg(ctx, "")
+	if err == acmeapi.ErrNoAccount {
+		return nil, nil
+	}
+	return acc, err
+}
+
 // registerAccount will register a new ACME account with the server. If an
 // account with the clients private key already exists, it will attempt to look
 // up and verify the corresponding account, and will return that. If this fails
@@ -423,6 +469,9 @@ func (a *Acme) createAccountPrivateKey(ctx context.Context, sel cmmeta.SecretKey
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      sel.Name,
 			Namespace: ns,
+			Labels: map[string]string{
+				v1.PartOfCertManagerControllerLabelKey: "true",
+			},
 		},
 		Data: map[string][]byte{
 			sel.Key: pki.EncodePKCS1PrivateKey(accountPrivKey),
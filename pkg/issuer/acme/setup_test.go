@@ -364,6 +364,56 @@ func TestAcme_Setup(t *testing.T) {
 					gen.SetIssuerConditionMessage(messageAccountRegistered)),
 			},
 		},
+		"Registered ACME account is verified without the rotated EAB secret": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMEEAB(someString, someString)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			getRegAcc:                  &acmeapi.Account{URI: acmev2Prod},
+			eabSecretGetErr:            notFoundErr,
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition,
+					gen.SetIssuerConditionStatus(cmmeta.ConditionTrue),
+					gen.SetIssuerConditionReason(successAccountRegistered),
+					gen.SetIssuerConditionMessage(messageAccountRegistered)),
+			},
+		},
+		"Registered ACME account no longer exists and is registered again with EAB": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMEEAB(someString, someString)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			getRegErr:                  acmeapi.ErrNoAccount,
+			eabSecret:                  eabSecret,
+			expectedRegisteredAcc: &acmeapi.Account{ExternalAccountBinding: &acmeapi.ExternalAccountBinding{
+				KID: someString,
+				Key: []byte(eabKey),
+			}},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition,
+					gen.SetIssuerConditionStatus(cmmeta.ConditionTrue),
+					gen.SetIssuerConditionReason(successAccountRegistered),
+					gen.SetIssuerConditionMessage(messageAccountRegistered)),
+			},
+		},
+		"Looking up the registered ACME account fails with an unknown error": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMEEAB(someString, someString)),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			getRegErr:                  someErr,
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountRegistrationFailed),
+					gen.SetIssuerConditionMessage(messageAccountRegistrationFailed+someErr.Error())),
+			},
+			wantsErr: true,
+		},
 		"ACME account with legacy EAB key algorithm set and with an email is registered successfully": {
 			issuer: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerACMEEmail(someEmail),
@@ -40,7 +40,7 @@ type CA struct {
 }
 
 func NewCA(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, error) {
-	secretsLister := ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister()
+	secretsLister := controller.NewSecretGetter(ctx.KubeSharedInformerFactory)
 
 	return &CA{
 		Context:           ctx,
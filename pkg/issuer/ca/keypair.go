@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/hex"
+	"strings"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/errors"
+	"github.com/cert-manager/cert-manager/pkg/util/kube"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// SigningKeyPair returns the certificate chain and the private key of the CA
+// issuer, read from the issuer's Secret in namespace. If the issuer uses a
+// PKCS#11 token, the Secret only holds the certificate chain, and the
+// returned private key signs with the token.
+// The certificate chain includes the ca.crt field of the Secret, if it exists.
+func SigningKeyPair(ctx context.Context, secretsLister corelisters.SecretLister, namespace string, spec *cmapi.CAIssuer) ([]*x509.Certificate, crypto.Signer, error) {
+	if spec.PKCS11 == nil {
+		return kube.SecretTLSKeyPairAndCA(ctx, secretsLister, namespace, spec.SecretName)
+	}
+
+	certs, err := kube.SecretTLSCertChainAndCA(ctx, secretsLister, namespace, spec.SecretName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := pkcs11Signer(secretsLister, namespace, spec.PKCS11, certs[0].PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return certs, key, nil
+}
+
+func pkcs11Signer(secretsLister corelisters.SecretLister, namespace string, spec *cmapi.CAPKCS11, public crypto.PublicKey) (crypto.Signer, error) {
+	secret, err := secretsLister.Secrets(namespace).Get(spec.PINSecretRef.Name)
+	if err != nil {
+		return nil, err
+	}
+	pin, ok := secret.Data[spec.PINSecretRef.Key]
+	if !ok {
+		return nil, errors.NewInvalidData("no data for %q in secret '%s/%s'", spec.PINSecretRef.Key, namespace, spec.PINSecretRef.Name)
+	}
+
+	keyID, err := hex.DecodeString(spec.KeyID)
+	if err != nil {
+		return nil, errors.NewInvalidData("invalid PKCS#11 key ID %q: %v", spec.KeyID, err)
+	}
+
+	return pki.NewPKCS11Signer(pki.PKCS11Config{
+		Module:     spec.Module,
+		TokenLabel: spec.TokenLabel,
+		KeyLabel:   spec.KeyLabel,
+		KeyID:      keyID,
+		// PINs are often written to files with a trailing newline.
+		PIN: strings.TrimSpace(string(pin)),
+	}, public)
+}
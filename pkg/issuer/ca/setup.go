@@ -52,7 +52,11 @@ func (c *CA) Setup(ctx context.Context) error {
 		return err
 	}
 
-	_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	if pkcs11 := c.issuer.GetSpec().CA.PKCS11; pkcs11 != nil {
+		_, err = pkcs11Signer(c.secretsLister, c.resourceNamespace, pkcs11, cert.PublicKey)
+	} else {
+		_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	}
 	if err != nil {
 		log.Error(err, "error getting signing CA private key")
 		s := messageErrorGetKeyPair + err.Error()
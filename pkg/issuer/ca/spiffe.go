@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// serviceAccountUsernamePrefix is the prefix of the usernames of Kubernetes
+// service accounts, followed by `<namespace>:<name>`.
+const serviceAccountUsernamePrefix = "system:serviceaccount:"
+
+// SVIDTemplate turns the template of a certificate requested in namespace
+// by the given user into an X.509-SVID, as defined by the X509-SVID
+// specification, for a CA issuer in SPIFFE mode.
+// The template must have exactly one URI SAN, the SPIFFE ID of a service
+// account in namespace, and must not be for a CA. If the request was
+// created by a service account in namespace, the SPIFFE ID must be its own.
+// Requests created by other users, such as cert-manager itself on behalf of a
+// Certificate, may request the SPIFFE ID of any service account in the
+// namespace, since they are allowed to create requests there.
+// DNS names, IP addresses and email addresses are removed from the template,
+// and the key usages are restricted to those allowed for leaf SVIDs.
+func SVIDTemplate(template *x509.Certificate, spiffe *v1.CASPIFFE, namespace, username string) error {
+	if template.IsCA {
+		return errors.New("SVIDs must not be CA certificates")
+	}
+	if len(template.URIs) != 1 {
+		return fmt.Errorf("SVIDs must have exactly one URI SAN, the SPIFFE ID, but %d were requested", len(template.URIs))
+	}
+
+	id := template.URIs[0].String()
+	idNamespace, idServiceAccount, err := pki.ParseServiceAccountSPIFFEID(id, spiffe.TrustDomain)
+	if err != nil {
+		return fmt.Errorf("invalid SPIFFE ID %q: %w", id, err)
+	}
+	if idNamespace != namespace {
+		return fmt.Errorf("SPIFFE ID %q must be for a service account in the namespace %q of the request", id, namespace)
+	}
+	if user := strings.TrimPrefix(username, serviceAccountUsernamePrefix); user != username {
+		userNamespace, userServiceAccount, _ := strings.Cut(user, ":")
+		if userNamespace == namespace && userServiceAccount != idServiceAccount {
+			return fmt.Errorf("service account %q may only request an SVID for its own SPIFFE ID, not %q", userServiceAccount, id)
+		}
+	}
+
+	template.DNSNames = nil
+	template.IPAddresses = nil
+	template.EmailAddresses = nil
+
+	// Leaf SVIDs must have the digital signature key usage, and must not be
+	// able to sign certificates or CRLs.
+	template.KeyUsage = (template.KeyUsage | x509.KeyUsageDigitalSignature) &^ (x509.KeyUsageCertSign | x509.KeyUsageCRLSign)
+
+	return nil
+}
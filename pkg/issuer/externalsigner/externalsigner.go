@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsigner
+
+import (
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+)
+
+// ExternalSigner Issuer which delegates signing to an external gRPC signer
+type ExternalSigner struct {
+	*controller.Context
+	issuer v1.GenericIssuer
+
+	secretsLister corelisters.SecretLister
+
+	// Namespace in which to read resources related to this Issuer from.
+	// For Issuers, this will be the namespace of the Issuer.
+	// For ClusterIssuers, this will be the cluster resource namespace.
+	resourceNamespace string
+}
+
+// NewExternalSigner returns a new ExternalSigner
+func NewExternalSigner(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, error) {
+	return &ExternalSigner{
+		Context:           ctx,
+		issuer:            issuer,
+		secretsLister:     controller.NewSecretGetter(ctx.KubeSharedInformerFactory),
+		resourceNamespace: ctx.IssuerOptions.ResourceNamespace(issuer),
+	}, nil
+}
+
+// Register this Issuer with the issuer factory
+func init() {
+	issuer.RegisterIssuer(apiutil.IssuerExternalSigner, NewExternalSigner)
+}
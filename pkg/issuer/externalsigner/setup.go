@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsigner
+
+import (
+	"context"
+
+	externalsignerinternal "github.com/cert-manager/cert-manager/internal/externalsigner"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	successTLSVerified = "TLSVerified"
+	messageTLSVerified = "External signer TLS credentials verified"
+
+	errorExternalSigner = "ExternalSignerError"
+
+	messageTLSInvalid = "Failed to load the TLS credentials of the external signer: "
+)
+
+// Setup verifies that the TLS credentials used to connect to the external
+// signer can be loaded, and sets the issuer's conditions to reflect the
+// result. The external signer itself is only called when signing, so that
+// issuers do not become unready while the signer is briefly unavailable.
+func (e *ExternalSigner) Setup(ctx context.Context) error {
+	log := logf.FromContext(ctx, "setup")
+
+	if _, err := externalsignerinternal.TLSConfig(e.resourceNamespace, e.secretsLister, e.issuer); err != nil {
+		s := messageTLSInvalid + err.Error()
+		log.V(logf.WarnLevel).Info(s)
+		apiutil.SetIssuerCondition(e.issuer, e.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorExternalSigner, s)
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info(messageTLSVerified)
+	apiutil.SetIssuerCondition(e.issuer, e.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successTLSVerified, messageTLSVerified)
+	return nil
+}
@@ -19,6 +19,9 @@ package issuer
 import (
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
@@ -36,6 +39,10 @@ type Helper interface {
 type helperImpl struct {
 	issuerLister        cmlisters.IssuerLister
 	clusterIssuerLister cmlisters.ClusterIssuerLister
+
+	// issuerAliasLister is nil if the helper does not resolve IssuerAliases.
+	issuerAliasLister        cmlisters.IssuerAliasLister
+	clusterResourceNamespace string
 }
 
 var _ Helper = &helperImpl{}
@@ -49,6 +56,20 @@ func NewHelper(issuerLister cmlisters.IssuerLister, clusterIssuerLister cmlister
 	}
 }
 
+// NewAliasingHelper constructs a Helper which, in addition to Issuers and
+// ClusterIssuers, resolves references to IssuerAliases. An IssuerAlias is
+// looked up in the namespace of the referencing resource first, and then in
+// the cluster resource namespace.
+func NewAliasingHelper(issuerLister cmlisters.IssuerLister, clusterIssuerLister cmlisters.ClusterIssuerLister,
+	issuerAliasLister cmlisters.IssuerAliasLister, clusterResourceNamespace string) Helper {
+	return &helperImpl{
+		issuerLister:             issuerLister,
+		clusterIssuerLister:      clusterIssuerLister,
+		issuerAliasLister:        issuerAliasLister,
+		clusterResourceNamespace: clusterResourceNamespace,
+	}
+}
+
 // GetGenericIssuer will return an Issuer for the given IssuerRef.
 // The namespace parameter must be provided if an 'Issuer' is referenced.
 // This namespace will be used to read the Issuer resource.
@@ -68,7 +89,47 @@ func (h *helperImpl) GetGenericIssuer(ref cmmeta.ObjectReference, ns string) (cm
 			return nil, fmt.Errorf("cannot get ClusterIssuer named %q as cert-manager is scoped to a single namespace", ref.Name)
 		}
 		return h.clusterIssuerLister.Get(ref.Name)
+	case cmapi.IssuerAliasKind:
+		if h.issuerAliasLister == nil {
+			return nil, fmt.Errorf("cannot resolve IssuerAlias named %q as IssuerAliases are not supported for this resource", ref.Name)
+		}
+		alias, err := h.getIssuerAlias(ref.Name, ns)
+		if err != nil {
+			return nil, err
+		}
+		// an alias must refer to an actual issuer, which is enforced by the
+		// webhook. Check again here so that a chain of aliases can never loop.
+		if alias.Spec.IssuerRef.Kind == cmapi.IssuerAliasKind {
+			return nil, fmt.Errorf("IssuerAlias %s/%s refers to another IssuerAlias", alias.Namespace, alias.Name)
+		}
+		return h.GetGenericIssuer(alias.Spec.IssuerRef, ns)
 	default:
-		return nil, fmt.Errorf(`invalid value %q for issuerRef.kind. Must be empty, %q or %q`, ref.Kind, cmapi.IssuerKind, cmapi.ClusterIssuerKind)
+		return nil, fmt.Errorf(`invalid value %q for issuerRef.kind. Must be empty, %q, %q or %q`, ref.Kind, cmapi.IssuerKind, cmapi.ClusterIssuerKind, cmapi.IssuerAliasKind)
+	}
+}
+
+// getIssuerAlias returns the IssuerAlias with the given name in the namespace
+// ns, falling back to the IssuerAlias of the same name in the cluster resource
+// namespace.
+func (h *helperImpl) getIssuerAlias(name, ns string) (*cmapi.IssuerAlias, error) {
+	alias, err := h.issuerAliasLister.IssuerAliases(ns).Get(name)
+	if !apierrors.IsNotFound(err) || len(h.clusterResourceNamespace) == 0 || ns == h.clusterResourceNamespace {
+		return alias, err
+	}
+	return h.issuerAliasLister.IssuerAliases(h.clusterResourceNamespace).Get(name)
+}
+
+// ReferenceFor returns an ObjectReference which refers directly to the given
+// Issuer or ClusterIssuer. It is used where a resolved IssuerAlias must be
+// replaced by the issuer it points to.
+func ReferenceFor(iss cmapi.GenericIssuer) cmmeta.ObjectReference {
+	kind := cmapi.IssuerKind
+	if _, ok := iss.(*cmapi.ClusterIssuer); ok {
+		kind = cmapi.ClusterIssuerKind
+	}
+	return cmmeta.ObjectReference{
+		Name:  iss.GetObjectMeta().Name,
+		Kind:  kind,
+		Group: certmanager.GroupName,
 	}
 }
@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -29,8 +30,15 @@ import (
 )
 
 func TestGetGenericIssuer(t *testing.T) {
+	const clusterResourceNamespace = "cert-manager"
 	var nilIssuer *v1.Issuer
 	var nilClusterIssuer *v1.ClusterIssuer
+	issuerAlias := func(name, namespace string, ref cmmeta.ObjectReference) *v1.IssuerAlias {
+		return &v1.IssuerAlias{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       v1.IssuerAliasSpec{IssuerRef: ref},
+		}
+	}
 	type testT struct {
 		Name                   string
 		Kind                   string
@@ -77,6 +85,53 @@ func TestGetGenericIssuer(t *testing.T) {
 			NilClusterIssuerLister: true,
 			Err:                    true,
 		},
+		{
+			Name:      "default",
+			Kind:      "IssuerAlias",
+			Namespace: gen.DefaultTestNamespace,
+			CMObjects: []runtime.Object{
+				issuerAlias("default", gen.DefaultTestNamespace, cmmeta.ObjectReference{Name: "name-of-issuer"}),
+				gen.Issuer("name-of-issuer"),
+			},
+			Expected: gen.Issuer("name-of-issuer"),
+		},
+		{
+			Name:      "default",
+			Kind:      "IssuerAlias",
+			Namespace: gen.DefaultTestNamespace,
+			CMObjects: []runtime.Object{
+				issuerAlias("default", clusterResourceNamespace, cmmeta.ObjectReference{Name: "name-of-clusterissuer", Kind: "ClusterIssuer"}),
+				gen.ClusterIssuer("name-of-clusterissuer"),
+			},
+			Expected: gen.ClusterIssuer("name-of-clusterissuer"),
+		},
+		{
+			Name:      "default",
+			Kind:      "IssuerAlias",
+			Namespace: gen.DefaultTestNamespace,
+			CMObjects: []runtime.Object{
+				issuerAlias("default", gen.DefaultTestNamespace, cmmeta.ObjectReference{Name: "name-of-issuer"}),
+				issuerAlias("default", clusterResourceNamespace, cmmeta.ObjectReference{Name: "name-of-clusterissuer", Kind: "ClusterIssuer"}),
+				gen.Issuer("name-of-issuer"),
+				gen.ClusterIssuer("name-of-clusterissuer"),
+			},
+			Expected: gen.Issuer("name-of-issuer"),
+		},
+		{
+			Name:      "default",
+			Kind:      "IssuerAlias",
+			Namespace: gen.DefaultTestNamespace,
+			CMObjects: []runtime.Object{
+				issuerAlias("default", gen.DefaultTestNamespace, cmmeta.ObjectReference{Name: "default", Kind: "IssuerAlias"}),
+			},
+			Err: true,
+		},
+		{
+			Name:      "default",
+			Kind:      "IssuerAlias",
+			Namespace: gen.DefaultTestNamespace,
+			Err:       true,
+		},
 	}
 
 	for _, row := range tests {
@@ -88,6 +143,9 @@ func TestGetGenericIssuer(t *testing.T) {
 			c := &helperImpl{
 				issuerLister:        b.FakeCMInformerFactory().Certmanager().V1().Issuers().Lister(),
 				clusterIssuerLister: b.FakeCMInformerFactory().Certmanager().V1().ClusterIssuers().Lister(),
+
+				issuerAliasLister:        b.FakeCMInformerFactory().Certmanager().V1().IssuerAliases().Lister(),
+				clusterResourceNamespace: clusterResourceNamespace,
 			}
 			b.Start()
 			defer b.Stop()
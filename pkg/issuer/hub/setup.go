@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	hubinternal "github.com/cert-manager/cert-manager/internal/hub"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	successHubVerified = "HubVerified"
+	messageHubVerified = "Hub cluster verified"
+
+	errorHub = "HubError"
+
+	messageHubClientInitFailed = "Failed to initialize hub cluster client: "
+	messageHubAccessReview     = "Failed to verify access to the hub cluster: "
+	messageHubAccessDenied     = "Not allowed to create CertificateRequests in the hub cluster namespace %q"
+)
+
+// Setup verifies that the issuer's token is allowed to create
+// CertificateRequests in the hub cluster, and sets the issuer's conditions
+// to reflect the result.
+func (h *Hub) Setup(ctx context.Context) error {
+	log := logf.FromContext(ctx, "setup")
+	spec := h.issuer.GetSpec().Hub
+
+	cfg, err := hubinternal.RESTConfig(h.resourceNamespace, h.secretsLister, h.issuer)
+	var client kubernetes.Interface
+	if err == nil {
+		client, err = kubernetes.NewForConfig(cfg)
+	}
+	if err != nil {
+		s := messageHubClientInitFailed + err.Error()
+		log.V(logf.WarnLevel).Info(s)
+		apiutil.SetIssuerCondition(h.issuer, h.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorHub, s)
+		return err
+	}
+
+	if err := h.reviewAccess(ctx, client, spec.Namespace); err != nil {
+		log.V(logf.WarnLevel).Info(err.Error())
+		apiutil.SetIssuerCondition(h.issuer, h.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorHub, err.Error())
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info(messageHubVerified)
+	apiutil.SetIssuerCondition(h.issuer, h.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successHubVerified, messageHubVerified)
+	return nil
+}
+
+// reviewAccess returns an error unless the client may create
+// CertificateRequests in namespace.
+func (h *Hub) reviewAccess(ctx context.Context, client kubernetes.Interface, namespace string) error {
+	review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "create",
+				Group:     v1.SchemeGroupVersion.Group,
+				Resource:  "certificaterequests",
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("%s%w", messageHubAccessReview, err)
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf(messageHubAccessDenied, namespace)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin defines the gRPC protocol between cert-manager and issuer
+// plugins. An issuer plugin signs the CertificateRequests which reference
+// one kind of issuer, so that new CAs can be supported by running a small
+// signing process, typically as a sidecar of the cert-manager controller
+// listening on a unix socket, rather than a complete external issuer
+// controller.
+//
+// Plugins implement Signer and serve it with Register. The cert-manager
+// controller is told which issuer kinds each plugin signs for with its
+// --issuer-plugins flag, and calls the plugin once each CertificateRequest
+// for those kinds has been approved. The plugin is responsible for reading
+// the configuration of the referenced issuer, if it needs one.
+package plugin
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// SignRequest is an approved CertificateRequest to be signed by a plugin.
+type SignRequest struct {
+	// Namespace, Name and UID identify the CertificateRequest. A plugin
+	// which signs asynchronously should use the UID to recognise requests
+	// it has already seen.
+	Namespace string
+	Name      string
+	UID       string
+
+	// IssuerRef is the issuer referenced by the CertificateRequest. Its
+	// Group and Kind are always those the plugin was registered for.
+	IssuerRef cmmeta.ObjectReference
+
+	// Request is the PEM encoded x509 certificate signing request.
+	Request []byte
+	// Duration is the requested duration of the certificate, or zero if
+	// the issuer's default should be used.
+	Duration time.Duration
+	// IsCA is true if a CA certificate is requested.
+	IsCA bool
+	// Usages are the requested key usages.
+	Usages []cmapi.KeyUsage
+}
+
+// SignResponse is the result of signing a SignRequest.
+type SignResponse struct {
+	// Certificate is the PEM encoded signed certificate, optionally
+	// followed by its intermediates. If empty, the request is still being
+	// signed and the plugin is asked again later.
+	Certificate []byte
+	// CA is the PEM encoded certificate of the CA which signed the
+	// certificate, if known.
+	CA []byte
+	// PendingMessage is reported on the CertificateRequest while it is
+	// being signed.
+	PendingMessage string
+}
+
+// Signer is implemented by issuer plugins. Sign is called for each
+// approved CertificateRequest until it returns a certificate or fails.
+//
+// Errors with the gRPC status code InvalidArgument or PermissionDenied,
+// for example those returned by Failed, fail the CertificateRequest
+// permanently. Any other error is retried with a backoff.
+type Signer interface {
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
+}
+
+// Failed returns an error which fails the CertificateRequest being signed
+// permanently, rather than retrying it.
+func Failed(message string) error {
+	return status.Error(codes.InvalidArgument, message)
+}
+
+// IsFailed returns true if the error returned by a Signer should fail the
+// CertificateRequest permanently.
+func IsFailed(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.PermissionDenied:
+		return true
+	}
+	return false
+}
+
+// Register registers the Signer service of the plugin with the gRPC
+// server.
+func Register(srv *grpc.Server, signer Signer) {
+	srv.RegisterService(&serviceDesc, signer)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*Signer)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: signMethodName,
+		Handler:    signHandler,
+	}},
+	Metadata: "certmanager/issuer/plugin/v1/signer.proto",
+}
+
+func signHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := dynamicpb.NewMessage(requestDescriptor)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		resp, err := srv.(Signer).Sign(ctx, signRequestMessage{req.(*dynamicpb.Message)}.signRequest())
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			resp = &SignResponse{}
+		}
+		return newSignResponseMessage(resp).Message, nil
+	}
+	if interceptor == nil {
+		return handler(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + serviceName + "/" + signMethodName,
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+// Client calls the Signer service of a plugin. It implements Signer.
+type Client struct {
+	conn *grpc.ClientConn
+}
+
+// Dial returns a Client for the plugin listening on address, either a
+// unix socket such as unix:///plugins/example.sock or a host:port. The
+// connection is not encrypted unless transport credentials are given in
+// opts, so plugins should only be reachable from the cert-manager
+// controller. The connection is established lazily, so Dial does not fail
+// if the plugin has not started yet.
+func Dial(address string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn}, nil
+}
+
+// Sign calls the Sign method of the plugin.
+func (c *Client) Sign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
+	resp := dynamicpb.NewMessage(responseDescriptor)
+	if err := c.conn.Invoke(ctx, "/"+serviceName+"/"+signMethodName, newSignRequestMessage(req).Message, resp); err != nil {
+		return nil, err
+	}
+	return signResponseMessage{resp}.signResponse(), nil
+}
+
+// Close closes the connection to the plugin.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+type signerFunc func(context.Context, *SignRequest) (*SignResponse, error)
+
+func (f signerFunc) Sign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
+	return f(ctx, req)
+}
+
+// newTestClient serves signer on an in-memory listener and returns a Client
+// connected to it.
+func newTestClient(t *testing.T, signer Signer) *Client {
+	srv := grpc.NewServer()
+	Register(srv, signer)
+
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	client, err := Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestSign(t *testing.T) {
+	req := &SignRequest{
+		Namespace: "my-ns",
+		Name:      "my-cr",
+		UID:       "1234",
+		IssuerRef: cmmeta.ObjectReference{Name: "my-issuer", Kind: "ExampleIssuer", Group: "example.com"},
+		Request:   []byte("csr"),
+		Duration:  time.Hour,
+		IsCA:      true,
+		Usages:    []cmapi.KeyUsage{cmapi.UsageServerAuth, cmapi.UsageDigitalSignature},
+	}
+	resp := &SignResponse{Certificate: []byte("cert"), CA: []byte("ca")}
+
+	client := newTestClient(t, signerFunc(func(_ context.Context, got *SignRequest) (*SignResponse, error) {
+		assert.Equal(t, req, got)
+		return resp, nil
+	}))
+
+	got, err := client.Sign(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, resp, got)
+}
+
+func TestSignPending(t *testing.T) {
+	client := newTestClient(t, signerFunc(func(context.Context, *SignRequest) (*SignResponse, error) {
+		return &SignResponse{PendingMessage: "waiting for an operator"}, nil
+	}))
+
+	got, err := client.Sign(context.TODO(), &SignRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, got.Certificate)
+	assert.Equal(t, "waiting for an operator", got.PendingMessage)
+}
+
+func TestSignErrors(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		expFailed bool
+	}{
+		"Failed fails the request": {
+			err:       Failed("subject not allowed"),
+			expFailed: true,
+		},
+		"PermissionDenied fails the request": {
+			err:       status.Error(codes.PermissionDenied, "denied"),
+			expFailed: true,
+		},
+		"Unavailable is retried": {
+			err:       status.Error(codes.Unavailable, "CA is down"),
+			expFailed: false,
+		},
+		"plain errors are retried": {
+			err:       errors.New("boom"),
+			expFailed: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, signerFunc(func(context.Context, *SignRequest) (*SignResponse, error) {
+				return nil, test.err
+			}))
+
+			_, err := client.Sign(context.TODO(), &SignRequest{})
+			assert.Error(t, err)
+			assert.Equal(t, test.expFailed, IsFailed(err))
+		})
+	}
+}
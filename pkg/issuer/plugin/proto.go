@@ -0,0 +1,208 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// The Signer service is described here rather than generated from a .proto
+// file, in the same way as the IstioCertificateService of pkg/istioca. Its
+// definition is:
+//
+//	syntax = "proto3";
+//	package certmanager.issuer.plugin.v1;
+//
+//	service Signer {
+//	  rpc Sign(SignRequest) returns (SignResponse);
+//	}
+//
+//	message SignRequest {
+//	  string namespace = 1;
+//	  string name = 2;
+//	  string uid = 3;
+//	  string issuer_group = 4;
+//	  string issuer_kind = 5;
+//	  string issuer_name = 6;
+//	  bytes request = 7;
+//	  int64 duration_seconds = 8;
+//	  bool is_ca = 9;
+//	  repeated string usages = 10;
+//	}
+//
+//	message SignResponse {
+//	  bytes certificate = 1;
+//	  bytes ca = 2;
+//	  string pending_message = 3;
+//	}
+//
+// Field numbers must never be reused, so that plugins built against older
+// versions of this package keep working.
+const (
+	serviceName    = "certmanager.issuer.plugin.v1.Signer"
+	signMethodName = "Sign"
+
+	namespaceFieldNumber       protoreflect.FieldNumber = 1
+	nameFieldNumber            protoreflect.FieldNumber = 2
+	uidFieldNumber             protoreflect.FieldNumber = 3
+	issuerGroupFieldNumber     protoreflect.FieldNumber = 4
+	issuerKindFieldNumber      protoreflect.FieldNumber = 5
+	issuerNameFieldNumber      protoreflect.FieldNumber = 6
+	requestFieldNumber         protoreflect.FieldNumber = 7
+	durationSecondsFieldNumber protoreflect.FieldNumber = 8
+	isCAFieldNumber            protoreflect.FieldNumber = 9
+	usagesFieldNumber          protoreflect.FieldNumber = 10
+
+	certificateFieldNumber    protoreflect.FieldNumber = 1
+	caFieldNumber             protoreflect.FieldNumber = 2
+	pendingMessageFieldNumber protoreflect.FieldNumber = 3
+)
+
+var (
+	requestDescriptor  protoreflect.MessageDescriptor
+	responseDescriptor protoreflect.MessageDescriptor
+)
+
+func init() {
+	field := func(name string, number protoreflect.FieldNumber, label descriptorpb.FieldDescriptorProto_Label, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(int32(number)),
+			Label:    label.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+	optional, repeated := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	str, byts := descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_TYPE_BYTES
+
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("certmanager/issuer/plugin/v1/signer.proto"),
+		Package: proto.String("certmanager.issuer.plugin.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("SignRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("namespace", namespaceFieldNumber, optional, str),
+					field("name", nameFieldNumber, optional, str),
+					field("uid", uidFieldNumber, optional, str),
+					field("issuer_group", issuerGroupFieldNumber, optional, str),
+					field("issuer_kind", issuerKindFieldNumber, optional, str),
+					field("issuer_name", issuerNameFieldNumber, optional, str),
+					field("request", requestFieldNumber, optional, byts),
+					field("duration_seconds", durationSecondsFieldNumber, optional, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+					field("is_ca", isCAFieldNumber, optional, descriptorpb.FieldDescriptorProto_TYPE_BOOL),
+					field("usages", usagesFieldNumber, repeated, str),
+				},
+			},
+			{
+				Name: proto.String("SignResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("certificate", certificateFieldNumber, optional, byts),
+					field("ca", caFieldNumber, optional, byts),
+					field("pending_message", pendingMessageFieldNumber, optional, str),
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		panic(fmt.Sprintf("invalid Signer descriptor: %s", err))
+	}
+	requestDescriptor = file.Messages().ByName("SignRequest")
+	responseDescriptor = file.Messages().ByName("SignResponse")
+}
+
+// signRequestMessage is a SignRequest message.
+type signRequestMessage struct {
+	*dynamicpb.Message
+}
+
+func (m signRequestMessage) field(number protoreflect.FieldNumber) protoreflect.FieldDescriptor {
+	return requestDescriptor.Fields().ByNumber(number)
+}
+
+func newSignRequestMessage(req *SignRequest) signRequestMessage {
+	m := signRequestMessage{dynamicpb.NewMessage(requestDescriptor)}
+	m.Set(m.field(namespaceFieldNumber), protoreflect.ValueOfString(req.Namespace))
+	m.Set(m.field(nameFieldNumber), protoreflect.ValueOfString(req.Name))
+	m.Set(m.field(uidFieldNumber), protoreflect.ValueOfString(req.UID))
+	m.Set(m.field(issuerGroupFieldNumber), protoreflect.ValueOfString(req.IssuerRef.Group))
+	m.Set(m.field(issuerKindFieldNumber), protoreflect.ValueOfString(req.IssuerRef.Kind))
+	m.Set(m.field(issuerNameFieldNumber), protoreflect.ValueOfString(req.IssuerRef.Name))
+	m.Set(m.field(requestFieldNumber), protoreflect.ValueOfBytes(req.Request))
+	m.Set(m.field(durationSecondsFieldNumber), protoreflect.ValueOfInt64(int64(req.Duration.Seconds())))
+	m.Set(m.field(isCAFieldNumber), protoreflect.ValueOfBool(req.IsCA))
+	usages := m.Mutable(m.field(usagesFieldNumber)).List()
+	for _, usage := range req.Usages {
+		usages.Append(protoreflect.ValueOfString(string(usage)))
+	}
+	return m
+}
+
+func (m signRequestMessage) signRequest() *SignRequest {
+	req := &SignRequest{
+		Namespace: m.Get(m.field(namespaceFieldNumber)).String(),
+		Name:      m.Get(m.field(nameFieldNumber)).String(),
+		UID:       m.Get(m.field(uidFieldNumber)).String(),
+		Request:   m.Get(m.field(requestFieldNumber)).Bytes(),
+		Duration:  time.Duration(m.Get(m.field(durationSecondsFieldNumber)).Int()) * time.Second,
+		IsCA:      m.Get(m.field(isCAFieldNumber)).Bool(),
+	}
+	req.IssuerRef.Group = m.Get(m.field(issuerGroupFieldNumber)).String()
+	req.IssuerRef.Kind = m.Get(m.field(issuerKindFieldNumber)).String()
+	req.IssuerRef.Name = m.Get(m.field(issuerNameFieldNumber)).String()
+	usages := m.Get(m.field(usagesFieldNumber)).List()
+	for i := 0; i < usages.Len(); i++ {
+		req.Usages = append(req.Usages, cmapi.KeyUsage(usages.Get(i).String()))
+	}
+	return req
+}
+
+// signResponseMessage is a SignResponse message.
+type signResponseMessage struct {
+	*dynamicpb.Message
+}
+
+func (m signResponseMessage) field(number protoreflect.FieldNumber) protoreflect.FieldDescriptor {
+	return responseDescriptor.Fields().ByNumber(number)
+}
+
+func newSignResponseMessage(resp *SignResponse) signResponseMessage {
+	m := signResponseMessage{dynamicpb.NewMessage(responseDescriptor)}
+	m.Set(m.field(certificateFieldNumber), protoreflect.ValueOfBytes(resp.Certificate))
+	m.Set(m.field(caFieldNumber), protoreflect.ValueOfBytes(resp.CA))
+	m.Set(m.field(pendingMessageFieldNumber), protoreflect.ValueOfString(resp.PendingMessage))
+	return m
+}
+
+func (m signResponseMessage) signResponse() *SignResponse {
+	return &SignResponse{
+		Certificate:    m.Get(m.field(certificateFieldNumber)).Bytes(),
+		CA:             m.Get(m.field(caFieldNumber)).Bytes(),
+		PendingMessage: m.Get(m.field(pendingMessageFieldNumber)).String(),
+	}
+}
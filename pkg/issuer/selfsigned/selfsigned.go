@@ -34,7 +34,7 @@ type SelfSigned struct {
 }
 
 func NewSelfSigned(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, error) {
-	secretsLister := ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister()
+	secretsLister := controller.NewSecretGetter(ctx.KubeSharedInformerFactory)
 
 	return &SelfSigned{
 		Context:       ctx,
@@ -40,7 +40,8 @@ const (
 	messageAuthFieldsRequired            = "Vault tokenSecretRef, appRole, or kubernetes is required"
 	messageMultipleAuthFieldsSet         = "Multiple auth methods cannot be set on the same Vault issuer"
 
-	messageKubeAuthFieldsRequired    = "Vault Kubernetes auth requires both role and secretRef.name"
+	messageKubeAuthFieldsRequired    = "Vault Kubernetes auth requires both role and either secretRef.name or serviceAccountRef.name"
+	messageKubeAuthMultipleTokensSet = "Vault Kubernetes auth cannot be used with both secretRef.name and serviceAccountRef.name"
 	messageTokenAuthNameRequired     = "Vault Token auth requires tokenSecretRef.name"
 	messageAppRoleAuthFieldsRequired = "Vault AppRole auth requires both roleId and tokenSecretRef.name"
 )
@@ -96,13 +97,22 @@ func (v *Vault) Setup(ctx context.Context) error {
 	}
 
 	// check if all mandatory Vault Kubernetes fields are set.
-	if kubeAuth != nil && (len(kubeAuth.SecretRef.Name) == 0 || len(kubeAuth.Role) == 0) {
-		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageKubeAuthFieldsRequired)
-		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageKubeAuthFieldsRequired)
-		return nil
+	if kubeAuth != nil {
+		hasSecretRef := len(kubeAuth.SecretRef.Name) > 0
+		hasServiceAccountRef := kubeAuth.ServiceAccountRef != nil && len(kubeAuth.ServiceAccountRef.Name) > 0
+		if len(kubeAuth.Role) == 0 || (!hasSecretRef && !hasServiceAccountRef) {
+			logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageKubeAuthFieldsRequired)
+			apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageKubeAuthFieldsRequired)
+			return nil
+		}
+		if hasSecretRef && hasServiceAccountRef {
+			logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageKubeAuthMultipleTokensSet)
+			apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageKubeAuthMultipleTokensSet)
+			return nil
+		}
 	}
 
-	client, err := vaultinternal.New(v.resourceNamespace, v.secretsLister, v.issuer)
+	client, err := vaultinternal.New(v.resourceNamespace, vaultinternal.CreateTokenFromClient(v.Client), v.secretsLister, v.issuer)
 	if err != nil {
 		s := messageVaultClientInitFailed + err.Error()
 		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, s)
@@ -40,7 +40,7 @@ type Vault struct {
 
 // NewVault returns a new Vault
 func NewVault(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, error) {
-	secretsLister := ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister()
+	secretsLister := controller.NewSecretGetter(ctx.KubeSharedInformerFactory)
 
 	return &Vault{
 		Context:           ctx,
@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
+	"k8s.io/utils/clock"
+)
+
+// zoneCache is shared by all Venafi clients. A new client is built for every
+// certificate request, so the zone configurations have to be cached outside
+// of them to avoid reading the zone for every request during mass renewals.
+var zoneCache = newZoneConfigurationCache(clock.RealClock{})
+
+// zoneConfigurationCache caches the zone configurations read from the Venafi
+// platform until they expire.
+type zoneConfigurationCache struct {
+	clock clock.PassiveClock
+
+	lock    sync.Mutex
+	entries map[string]zoneConfigurationCacheEntry
+}
+
+type zoneConfigurationCacheEntry struct {
+	config  *endpoint.ZoneConfiguration
+	expires time.Time
+}
+
+func newZoneConfigurationCache(clock clock.PassiveClock) *zoneConfigurationCache {
+	return &zoneConfigurationCache{
+		clock:   clock,
+		entries: make(map[string]zoneConfigurationCacheEntry),
+	}
+}
+
+// get returns the cached zone configuration for key, or reads it with read
+// and caches it for ttl if there is none or it has expired. Errors are not
+// cached.
+func (c *zoneConfigurationCache) get(key string, ttl time.Duration, read func() (*endpoint.ZoneConfiguration, error)) (*endpoint.ZoneConfiguration, error) {
+	now := c.clock.Now()
+
+	c.lock.Lock()
+	entry, ok := c.entries[key]
+	c.lock.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.config, nil
+	}
+
+	config, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// Drop expired entries while holding the lock, so that zones of deleted
+	// issuers do not accumulate.
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = zoneConfigurationCacheEntry{config: config, expires: now.Add(ttl)}
+	return config, nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
+	"github.com/stretchr/testify/assert"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	internalfake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+)
+
+func TestZoneConfigurationCache(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	cache := newZoneConfigurationCache(clock)
+
+	var reads int
+	read := func() (*endpoint.ZoneConfiguration, error) {
+		reads++
+		return &endpoint.ZoneConfiguration{Organization: "org"}, nil
+	}
+	failingRead := func() (*endpoint.ZoneConfiguration, error) {
+		reads++
+		return nil, errors.New("zone configuration error")
+	}
+
+	config, err := cache.get("issuer-a", time.Minute, read)
+	assert.NoError(t, err)
+	assert.Equal(t, "org", config.Organization)
+	assert.Equal(t, 1, reads, "the zone should be read if it is not cached")
+
+	_, err = cache.get("issuer-a", time.Minute, read)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, reads, "the zone should not be read again before it expires")
+
+	_, err = cache.get("issuer-b", time.Minute, read)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, reads, "zones should be cached per key")
+
+	clock.Step(time.Minute)
+	_, err = cache.get("issuer-a", time.Minute, failingRead)
+	assert.EqualError(t, err, "zone configuration error")
+	assert.Equal(t, 3, reads, "the zone should be read again once it expires")
+
+	_, err = cache.get("issuer-a", time.Minute, read)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, reads, "errors should not be cached")
+}
+
+func TestVenafi_ReadZoneConfiguration(t *testing.T) {
+	var reads int
+	newClient := func(zoneCacheDuration time.Duration) *Venafi {
+		return &Venafi{
+			vcertClient: internalfake.Connector{
+				ReadZoneConfigurationFunc: func() (*endpoint.ZoneConfiguration, error) {
+					reads++
+					return &endpoint.ZoneConfiguration{}, nil
+				},
+			}.Default(),
+			// Use a key which no other test uses, as the zone cache is
+			// shared by all clients.
+			zoneCacheKey:      t.Name(),
+			zoneCacheDuration: zoneCacheDuration,
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := newClient(0).ReadZoneConfiguration()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, reads, "the zone should be read every time if caching is disabled")
+
+	reads = 0
+	for i := 0; i < 2; i++ {
+		_, err := newClient(time.Hour).ReadZoneConfiguration()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, reads, "the zone should be shared between clients if caching is enabled")
+}
@@ -24,7 +24,9 @@ import (
 	"time"
 
 	"github.com/Venafi/vcert/v4/pkg/certificate"
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
@@ -78,7 +80,7 @@ func (v *Venafi) buildVReq(csrPEM []byte, duration time.Duration, customFields [
 	// Retrieve a copy of the Venafi zone.
 	// This contains default values and policy control info that we can apply
 	// and check against locally.
-	zoneCfg, err := v.vcertClient.ReadZoneConfiguration()
+	zoneCfg, err := v.ReadZoneConfiguration()
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +90,39 @@ func (v *Venafi) buildVReq(csrPEM []byte, duration time.Duration, customFields [
 		return nil, err
 	}
 
+	vreq, err := newValidatedVRequest(zoneCfg, tmpl, mergeCustomFields(v.customFields, customFields))
+	if err != nil {
+		return nil, err
+	}
+
+	friendlyName, err := getVcertFriendlyName(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	vreq.FriendlyName = friendlyName
+
+	// Set options on the request
+	vreq.CsrOrigin = certificate.UserProvidedCSR
+
+	// Set the request CSR with the passed value
+	if err := vreq.SetCSR(csrPEM); err != nil {
+		return nil, err
+	}
+
+	return vreq, nil
+}
+
+// ValidateTemplate checks the given certificate template and custom fields
+// against the policy of a Venafi zone, after applying the zone's defaults,
+// without requesting a certificate.
+func ValidateTemplate(zoneCfg *endpoint.ZoneConfiguration, tmpl *x509.Certificate, customFields []api.CustomField) error {
+	_, err := newValidatedVRequest(zoneCfg, tmpl, customFields)
+	return err
+}
+
+// newValidatedVRequest creates a vcert Request for the given template and
+// custom fields, and validates it against the zone configuration policy.
+func newValidatedVRequest(zoneCfg *endpoint.ZoneConfiguration, tmpl *x509.Certificate, customFields []api.CustomField) (*certificate.Request, error) {
 	if tmpl.Subject.String() == "" {
 		return nil, ErrorMissingSubject
 	}
@@ -108,26 +143,56 @@ func (v *Venafi) buildVReq(csrPEM []byte, duration time.Duration, customFields [
 	// Here we are validating the request using the current policy with
 	// defaulting applied to the CSR. The CSR we send will not be defaulted
 	// however, as this will be done again server side.
-	err = zoneCfg.ValidateCertificateRequest(vreq)
-	if err != nil {
+	if err := zoneCfg.ValidateCertificateRequest(vreq); err != nil {
 		return nil, err
 	}
 
-	friendlyName, err := getVcertFriendlyName(tmpl)
-	if err != nil {
-		return nil, err
+	return vreq, nil
+}
+
+// CustomFieldsForIssuer returns the custom fields which are sent with a
+// certificate request to the given Venafi issuer: the custom fields of the
+// issuer, merged with the given custom fields of the request.
+func CustomFieldsForIssuer(issuer cmapi.GenericIssuer, customFields []api.CustomField) []api.CustomField {
+	return mergeCustomFields(issuerCustomFields(issuer.GetSpec().Venafi), customFields)
+}
+
+// issuerCustomFields returns the custom fields of a Venafi issuer.
+func issuerCustomFields(venCfg *cmapi.VenafiIssuer) []api.CustomField {
+	if venCfg == nil || len(venCfg.CustomFields) == 0 {
+		return nil
 	}
-	vreq.FriendlyName = friendlyName
+	out := make([]api.CustomField, 0, len(venCfg.CustomFields))
+	for _, field := range venCfg.CustomFields {
+		out = append(out, api.CustomField{
+			Type:  api.CustomFieldType(field.Type),
+			Name:  field.Name,
+			Value: field.Value,
+		})
+	}
+	return out
+}
 
-	// Set options on the request
-	vreq.CsrOrigin = certificate.UserProvidedCSR
+// mergeCustomFields returns the custom fields of an issuer followed by the
+// custom fields of a request. A custom field of the request replaces the
+// issuer's custom field of the same name.
+func mergeCustomFields(issuerFields, requestFields []api.CustomField) []api.CustomField {
+	if len(issuerFields) == 0 {
+		return requestFields
+	}
 
-	// Set the request CSR with the passed value
-	if err := vreq.SetCSR(csrPEM); err != nil {
-		return nil, err
+	overridden := make(map[string]struct{}, len(requestFields))
+	for _, field := range requestFields {
+		overridden[field.Name] = struct{}{}
 	}
 
-	return vreq, nil
+	var out []api.CustomField
+	for _, field := range issuerFields {
+		if _, ok := overridden[field.Name]; !ok {
+			out = append(out, field)
+		}
+	}
+	return append(out, requestFields...)
 }
 
 func convertCustomFieldsToVcert(customFields []api.CustomField) ([]certificate.CustomField, error) {
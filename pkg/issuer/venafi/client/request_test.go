@@ -18,6 +18,8 @@ package client
 
 import (
 	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"testing"
 	"time"
@@ -25,6 +27,7 @@ import (
 	"github.com/Venafi/vcert/v4/pkg/certificate"
 	"github.com/Venafi/vcert/v4/pkg/endpoint"
 	"github.com/Venafi/vcert/v4/pkg/venafi/fake"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
 	internalfake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
@@ -227,6 +230,66 @@ func TestVenafi_RequestCertificate(t *testing.T) {
 	}
 }
 
+func TestVenafi_RequestCertificateWithIssuerCustomFields(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotFields []certificate.CustomField
+	v := &Venafi{
+		vcertClient: internalfake.Connector{
+			RequestCertificateFunc: func(r *certificate.Request) (string, error) {
+				gotFields = r.CustomFields
+				return "pickup-id", nil
+			},
+		}.Default(),
+		customFields: []api.CustomField{
+			{Name: "cost-center", Value: "1234"},
+			{Name: "owner", Value: "issuer-team"},
+		},
+	}
+
+	_, err = v.RequestCertificate(generateCSR(t, privateKey, "common-name", nil), time.Minute, []api.CustomField{
+		{Name: "owner", Value: "certificate-team"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []certificate.CustomField{
+		{Type: certificate.CustomFieldOrigin, Value: "cert-manager"},
+		{Type: certificate.CustomFieldPlain, Name: "cost-center", Value: "1234"},
+		{Type: certificate.CustomFieldPlain, Name: "owner", Value: "certificate-team"},
+	}, gotFields)
+}
+
+func TestMergeCustomFields(t *testing.T) {
+	tests := map[string]struct {
+		issuerFields, requestFields, exp []api.CustomField
+	}{
+		"no issuer fields returns the request fields": {
+			requestFields: []api.CustomField{{Name: "a", Value: "1"}},
+			exp:           []api.CustomField{{Name: "a", Value: "1"}},
+		},
+		"no request fields returns the issuer fields": {
+			issuerFields: []api.CustomField{{Name: "a", Value: "1"}},
+			exp:          []api.CustomField{{Name: "a", Value: "1"}},
+		},
+		"request fields replace issuer fields of the same name": {
+			issuerFields:  []api.CustomField{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}},
+			requestFields: []api.CustomField{{Name: "b", Value: "3"}, {Name: "c", Value: "4"}},
+			exp:           []api.CustomField{{Name: "a", Value: "1"}, {Name: "b", Value: "3"}, {Name: "c", Value: "4"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, mergeCustomFields(test.issuerFields, test.requestFields))
+		})
+	}
+}
+
 func TestVenafi_RetrieveCertificate(t *testing.T) {
 	privateKey, err := pki.GenerateRSAPrivateKey(2048)
 	if err != nil {
@@ -295,3 +358,54 @@ func TestVenafi_RetrieveCertificate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTemplate(t *testing.T) {
+	zoneCfg := &endpoint.ZoneConfiguration{
+		Policy: endpoint.Policy{
+			SubjectCNRegexes: []string{`^.*\.example\.com$`},
+			SubjectORegexes:  []string{".*"},
+			SubjectOURegexes: []string{".*"},
+			SubjectSTRegexes: []string{".*"},
+			SubjectLRegexes:  []string{".*"},
+			SubjectCRegexes:  []string{".*"},
+			DnsSanRegExs:     []string{`^.*\.example\.com$`},
+		},
+	}
+	template := func(commonName string, dnsNames ...string) *x509.Certificate {
+		return &x509.Certificate{
+			Subject:  pkix.Name{CommonName: commonName},
+			DNSNames: dnsNames,
+		}
+	}
+
+	tests := map[string]struct {
+		tmpl         *x509.Certificate
+		customFields []api.CustomField
+		wantErr      bool
+	}{
+		"template allowed by the zone policy": {
+			tmpl: template("foo.example.com", "foo.example.com", "bar.example.com"),
+		},
+		"template with a DNS name not allowed by the zone policy": {
+			tmpl:    template("foo.example.com", "foo.example.org"),
+			wantErr: true,
+		},
+		"template with an empty subject": {
+			tmpl:    template("", "foo.example.com"),
+			wantErr: true,
+		},
+		"template with an invalid custom field type": {
+			tmpl:         template("foo.example.com"),
+			customFields: []api.CustomField{{Name: "test", Value: "ok", Type: "Bool"}},
+			wantErr:      true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateTemplate(zoneCfg, test.tmpl, test.customFields)
+			if (err != nil) != test.wantErr {
+				t.Errorf("ValidateTemplate() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
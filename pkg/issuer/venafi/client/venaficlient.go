@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	vcert "github.com/Venafi/vcert/v4"
@@ -35,6 +36,7 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util"
 )
 
 const (
@@ -70,6 +72,16 @@ type Venafi struct {
 	tppClient   *tpp.Connector
 	cloudClient *cloud.Connector
 	config      *vcert.Config
+
+	// customFields are the custom fields of the issuer, which are sent with
+	// every certificate request.
+	customFields []api.CustomField
+
+	// zoneCacheKey identifies the zone of the issuer in the zone cache, and
+	// zoneCacheDuration is how long it is cached for. The zone is not cached
+	// if zoneCacheDuration is zero.
+	zoneCacheKey      string
+	zoneCacheDuration time.Duration
 }
 
 // connector exposes a subset of the vcert Connector interface to make stubbing
@@ -114,16 +126,32 @@ func New(namespace string, secretsLister corelisters.SecretLister, issuer cmapi.
 
 	instrumentedVCertClient := newInstumentedConnector(vcertClient, metrics, logger)
 
+	venCfg := issuer.GetSpec().Venafi
+	var zoneCacheDuration time.Duration
+	if venCfg.ZoneCacheDuration != nil {
+		zoneCacheDuration = venCfg.ZoneCacheDuration.Duration
+	}
+
 	return &Venafi{
-		namespace:     namespace,
-		secretsLister: secretsLister,
-		vcertClient:   instrumentedVCertClient,
-		cloudClient:   cc,
-		tppClient:     tppc,
-		config:        cfg,
+		namespace:         namespace,
+		secretsLister:     secretsLister,
+		vcertClient:       instrumentedVCertClient,
+		cloudClient:       cc,
+		tppClient:         tppc,
+		config:            cfg,
+		customFields:      issuerCustomFields(venCfg),
+		zoneCacheKey:      zoneCacheKey(issuer, cfg),
+		zoneCacheDuration: zoneCacheDuration,
 	}, nil
 }
 
+// zoneCacheKey returns the key of the issuer's zone in the zone cache. It
+// includes the URL and zone of the issuer, so that changing either of them
+// reads the new zone immediately.
+func zoneCacheKey(issuer cmapi.GenericIssuer, cfg *vcert.Config) string {
+	return strings.Join([]string{issuer.GetObjectMeta().Namespace, issuer.GetObjectMeta().Name, cfg.BaseUrl, cfg.Zone}, "/")
+}
+
 // configForIssuer will convert a cert-manager Venafi issuer into a vcert.Config
 // that can be used to instantiate an API client.
 func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLister, namespace string) (*vcert.Config, error) {
@@ -159,7 +187,7 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 				Password:    password,
 				AccessToken: accessToken,
 			},
-			Client: httpClientForVcertTPP(tpp.CABundle),
+			Client: withIssuerHTTPClient(httpClientForVcertTPP(tpp.CABundle), iss.GetSpec().HTTPClient),
 		}, nil
 	case venCfg.Cloud != nil:
 		cloud := venCfg.Cloud
@@ -183,6 +211,7 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 			Credentials: &endpoint.Authentication{
 				APIKey: apiKey,
 			},
+			Client: httpClientForVcertCloud(iss.GetSpec().HTTPClient),
 		}, nil
 	}
 	// API validation in webhook and in the ClusterIssuer and Issuer controller
@@ -190,6 +219,28 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 	return nil, fmt.Errorf("neither Venafi Cloud or TPP configuration found")
 }
 
+// httpClientForVcertCloud returns nil, so that vcert uses its default HTTP
+// client, unless the issuer configures its HTTP client. In that case a copy of
+// vcert's default client is returned which applies the configuration.
+// https://github.com/Venafi/vcert/blob/89645a7710a7b529765274cb60dc5e28066217a1/pkg/venafi/cloud/cloud.go#L265-L297
+func httpClientForVcertCloud(config *cmapi.IssuerHTTPClient) *http.Client {
+	if config == nil {
+		return nil
+	}
+	return withIssuerHTTPClient(&http.Client{
+		Timeout:   time.Second * 30,
+		Transport: http.DefaultTransport.(*http.Transport).Clone(),
+	}, config)
+}
+
+// withIssuerHTTPClient wraps the transport of client so that the User-Agent
+// suffix and headers of the issuer's HTTP client configuration are added to
+// every request.
+func withIssuerHTTPClient(client *http.Client, config *cmapi.IssuerHTTPClient) *http.Client {
+	client.Transport = util.IssuerHTTPClientRoundTripper(client.Transport, config)
+	return client
+}
+
 // httpClientForVcertTPP creates an HTTP client and customises it to allow client TLS renegotiation.
 //
 // Here's why:
@@ -272,8 +323,13 @@ func (v *Venafi) Ping() error {
 	return v.vcertClient.Ping()
 }
 
+// ReadZoneConfiguration reads the configuration and policy of the issuer's
+// zone, or returns it from the zone cache if the issuer enables caching.
 func (v *Venafi) ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error) {
-	return v.vcertClient.ReadZoneConfiguration()
+	if v.zoneCacheDuration <= 0 {
+		return v.vcertClient.ReadZoneConfiguration()
+	}
+	return zoneCache.get(v.zoneCacheKey, v.zoneCacheDuration, v.vcertClient.ReadZoneConfiguration)
 }
 
 func (v *Venafi) SetClient(client endpoint.Connector) {
@@ -28,12 +28,19 @@ import (
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+const (
+	successVenafiVerified = "VenafiVerified"
+	messageVenafiVerified = "Venafi issuer started"
+
+	errorSetup = "ErrorSetup"
+)
+
 func (v *Venafi) Setup(ctx context.Context) (err error) {
 	defer func() {
 		if err != nil {
 			errorMessage := "Failed to setup Venafi issuer"
 			v.log.Error(err, errorMessage)
-			apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, "ErrorSetup", fmt.Sprintf("%s: %v", errorMessage, err))
+			apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, errorSetup, fmt.Sprintf("%s: %v", errorMessage, err))
 			err = fmt.Errorf("%s: %v", errorMessage, err)
 		}
 	}()
@@ -61,7 +68,7 @@ func (v *Venafi) Setup(ctx context.Context) (err error) {
 		v.Recorder.Eventf(v.issuer, corev1.EventTypeNormal, "Ready", "Verified issuer with Venafi server")
 	}
 	v.log.V(logf.DebugLevel).Info("Venafi issuer started")
-	apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionTrue, "Venafi issuer started", "Venafi issuer started")
+	apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionTrue, successVenafiVerified, messageVenafiVerified)
 
 	return nil
 }
@@ -115,7 +115,7 @@ func TestSetup(t *testing.T) {
 			expectedErr:   false,
 			expectedCondition: &cmapi.IssuerCondition{
 				Message: "Venafi issuer started",
-				Reason:  "Venafi issuer started",
+				Reason:  "VenafiVerified",
 				Status:  "True",
 			},
 			expectedEvents: []string{
@@ -128,7 +128,7 @@ func TestSetup(t *testing.T) {
 			expectedErr:   false,
 			expectedCondition: &cmapi.IssuerCondition{
 				Message: "Venafi issuer started",
-				Reason:  "Venafi issuer started",
+				Reason:  "VenafiVerified",
 				Status:  "True",
 			},
 			expectedEvents: []string{
@@ -52,7 +52,7 @@ type Venafi struct {
 func NewVenafi(ctx *controller.Context, issuer cmapi.GenericIssuer) (issuer.Interface, error) {
 	return &Venafi{
 		issuer:            issuer,
-		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		secretsLister:     controller.NewSecretGetter(ctx.KubeSharedInformerFactory),
 		resourceNamespace: ctx.IssuerOptions.ResourceNamespace(issuer),
 		clientBuilder:     client.New,
 		Context:           ctx,
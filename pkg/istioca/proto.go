@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istioca
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// The IstioCertificateService of Istio's security/v1alpha1/ca.proto is
+// described here rather than imported from istio.io/api, which would pull
+// in all of Istio's APIs for two messages. The field numbers match the
+// upstream definitions so that the service is wire compatible with istiod
+// clients. The metadata field of IstioCertificateRequest, used to
+// impersonate identities of other clusters, is not supported and is ignored
+// as an unknown field.
+const (
+	serviceName      = "istio.v1.auth.IstioCertificateService"
+	createMethodName = "CreateCertificate"
+
+	csrFieldNumber              protoreflect.FieldNumber = 1
+	validityDurationFieldNumber protoreflect.FieldNumber = 3
+	certChainFieldNumber        protoreflect.FieldNumber = 1
+)
+
+var (
+	requestDescriptor  protoreflect.MessageDescriptor
+	responseDescriptor protoreflect.MessageDescriptor
+)
+
+func init() {
+	field := func(name string, number protoreflect.FieldNumber, label descriptorpb.FieldDescriptorProto_Label, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(int32(number)),
+			Label:    label.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+	optional, repeated := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("security/v1alpha1/ca.proto"),
+		Package: proto.String("istio.v1.auth"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("IstioCertificateRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("csr", csrFieldNumber, optional, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					field("validity_duration", validityDurationFieldNumber, optional, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+				},
+			},
+			{
+				Name: proto.String("IstioCertificateResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("cert_chain", certChainFieldNumber, repeated, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		panic(fmt.Sprintf("invalid IstioCertificateService descriptor: %s", err))
+	}
+	requestDescriptor = file.Messages().ByName("IstioCertificateRequest")
+	responseDescriptor = file.Messages().ByName("IstioCertificateResponse")
+}
+
+// certificateRequest is an IstioCertificateRequest.
+type certificateRequest struct {
+	*dynamicpb.Message
+}
+
+func newCertificateRequest(csr string, validityDuration int64) certificateRequest {
+	req := certificateRequest{dynamicpb.NewMessage(requestDescriptor)}
+	req.Set(requestDescriptor.Fields().ByNumber(csrFieldNumber), protoreflect.ValueOfString(csr))
+	req.Set(requestDescriptor.Fields().ByNumber(validityDurationFieldNumber), protoreflect.ValueOfInt64(validityDuration))
+	return req
+}
+
+// csr returns the PEM encoded CSR of the request.
+func (r certificateRequest) csr() string {
+	return r.Get(requestDescriptor.Fields().ByNumber(csrFieldNumber)).String()
+}
+
+// validityDuration returns the requested validity of the certificate in
+// seconds.
+func (r certificateRequest) validityDuration() int64 {
+	return r.Get(requestDescriptor.Fields().ByNumber(validityDurationFieldNumber)).Int()
+}
+
+// certificateResponse is an IstioCertificateResponse.
+type certificateResponse struct {
+	*dynamicpb.Message
+}
+
+func newCertificateResponse(certChain []string) certificateResponse {
+	resp := certificateResponse{dynamicpb.NewMessage(responseDescriptor)}
+	list := resp.Mutable(responseDescriptor.Fields().ByNumber(certChainFieldNumber)).List()
+	for _, cert := range certChain {
+		list.Append(protoreflect.ValueOfString(cert))
+	}
+	return resp
+}
+
+// certChain returns the PEM encoded certificates of the response, leaf
+// first.
+func (r certificateResponse) certChain() []string {
+	list := r.Get(responseDescriptor.Fields().ByNumber(certChainFieldNumber)).List()
+	certs := make([]string, list.Len())
+	for i := range certs {
+		certs[i] = list.Get(i).String()
+	}
+	return certs
+}
@@ -0,0 +1,330 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package istioca implements Istio's IstioCertificateService, so that the
+// workloads of an Istio mesh can be issued certificates by any cert-manager
+// issuer. Each workload CSR is authenticated with the workload's service
+// account token and signed by creating a CertificateRequest.
+package istioca
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/dynamicpb"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/client-go/kubernetes"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// IdentitiesAnnotationKey is the annotation of the CertificateRequests
+	// created for workloads, holding the SPIFFE ID of the workload which was
+	// authenticated.
+	IdentitiesAnnotationKey = "istio.cert-manager.io/identities"
+
+	// DefaultAudience is the audience of the tokens which workloads
+	// authenticate with unless configured otherwise.
+	DefaultAudience = "istio-ca"
+
+	// pollInterval is the interval at which CertificateRequests are polled
+	// while waiting for them to be signed.
+	pollInterval = time.Second
+)
+
+// Options configures a Server.
+type Options struct {
+	// IssuerRef is the issuer which signs workload certificates.
+	IssuerRef cmmeta.ObjectReference
+	// Namespace is the namespace in which CertificateRequests are created.
+	Namespace string
+	// TrustDomain is the SPIFFE trust domain of the mesh.
+	TrustDomain string
+	// Audiences are the audiences which workload tokens must be valid for.
+	// Defaults to DefaultAudience.
+	Audiences []string
+	// MaxDuration is the maximum duration of workload certificates.
+	// Workloads requesting no duration, or a longer one, are issued
+	// certificates of this duration.
+	MaxDuration time.Duration
+	// IssuanceTimeout is the maximum time waited for a CertificateRequest
+	// to be signed.
+	IssuanceTimeout time.Duration
+}
+
+// Server serves Istio's IstioCertificateService.
+type Server struct {
+	log        logr.Logger
+	kubeClient kubernetes.Interface
+	client     cmclient.Interface
+	opts       Options
+}
+
+// NewServer returns a Server which authenticates workloads with kubeClient
+// and signs their certificates by creating CertificateRequests with client.
+func NewServer(log logr.Logger, kubeClient kubernetes.Interface, client cmclient.Interface, opts Options) *Server {
+	if len(opts.Audiences) == 0 {
+		opts.Audiences = []string{DefaultAudience}
+	}
+	return &Server{
+		log:        log.WithName("istio-ca"),
+		kubeClient: kubeClient,
+		client:     client,
+		opts:       opts,
+	}
+}
+
+// Register registers the IstioCertificateService with the gRPC server.
+func (s *Server) Register(srv *grpc.Server) {
+	srv.RegisterService(&serviceDesc, s)
+}
+
+// certificateService is the interface implemented by the handlers of the
+// IstioCertificateService.
+type certificateService interface {
+	createCertificate(context.Context, certificateRequest) (certificateResponse, error)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*certificateService)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: createMethodName,
+		Handler:    createCertificateHandler,
+	}},
+	Metadata: "security/v1alpha1/ca.proto",
+}
+
+func createCertificateHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := dynamicpb.NewMessage(requestDescriptor)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		resp, err := srv.(certificateService).createCertificate(ctx, certificateRequest{req.(*dynamicpb.Message)})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Message, nil
+	}
+	if interceptor == nil {
+		return handler(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + serviceName + "/" + createMethodName,
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+func (s *Server) createCertificate(ctx context.Context, req certificateRequest) (certificateResponse, error) {
+	identity, err := s.authenticate(ctx)
+	if err != nil {
+		s.log.V(logf.DebugLevel).Info("failed to authenticate workload", "error", err.Error())
+		return certificateResponse{}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	log := s.log.WithValues("identity", identity)
+
+	csrPEM := []byte(req.csr())
+	if err := validateCSR(csrPEM, identity); err != nil {
+		log.V(logf.DebugLevel).Info("rejecting CSR", "error", err.Error())
+		return certificateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	duration := s.opts.MaxDuration
+	if requested := time.Duration(req.validityDuration()) * time.Second; requested > 0 && (duration == 0 || requested < duration) {
+		duration = requested
+	}
+
+	chain, err := s.sign(ctx, log, csrPEM, identity, duration)
+	if err != nil {
+		log.Error(err, "failed to sign workload certificate")
+		return certificateResponse{}, status.Error(codes.Internal, "failed to sign certificate")
+	}
+
+	return newCertificateResponse(chain), nil
+}
+
+// authenticate returns the SPIFFE ID of the service account whose token
+// authenticates the request.
+func (s *Server) authenticate(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) != 1 || !strings.HasPrefix(values[0], "Bearer ") {
+		return "", fmt.Errorf("request does not carry a bearer token")
+	}
+
+	review, err := s.kubeClient.AuthenticationV1().TokenReviews().Create(ctx, &authv1.TokenReview{
+		Spec: authv1.TokenReviewSpec{
+			Token:     strings.TrimPrefix(values[0], "Bearer "),
+			Audiences: s.opts.Audiences,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return "", fmt.Errorf("token is not authenticated: %s", review.Status.Error)
+	}
+
+	namespace, name, err := serviceaccount.SplitUsername(review.Status.User.Username)
+	if err != nil {
+		return "", fmt.Errorf("token is not a service account token: %w", err)
+	}
+	return fmt.Sprintf("spiffe://%s/ns/%s/sa/%s", s.opts.TrustDomain, namespace, name), nil
+}
+
+// validateCSR checks that the CSR is signed and only requests the identity
+// of the authenticated workload.
+func validateCSR(csrPEM []byte, identity string) error {
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return err
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return fmt.Errorf("invalid CSR signature: %w", err)
+	}
+	if len(csr.DNSNames) > 0 || len(csr.IPAddresses) > 0 || len(csr.EmailAddresses) > 0 {
+		return fmt.Errorf("CSR must not request DNS names, IP addresses or email addresses")
+	}
+	if len(csr.URIs) != 1 || csr.URIs[0].String() != identity {
+		return fmt.Errorf("CSR must request exactly the identity %q, requested %q", identity, pki.URLsToString(csr.URIs))
+	}
+	return nil
+}
+
+// sign creates a CertificateRequest for the CSR, waits for it to be signed
+// and returns the signed chain, leaf first and ending with the root CA.
+func (s *Server) sign(ctx context.Context, log logr.Logger, csrPEM []byte, identity string, duration time.Duration) ([]string, error) {
+	cr := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "istio-csr-",
+			Namespace:    s.opts.Namespace,
+			Annotations: map[string]string{
+				IdentitiesAnnotationKey: identity,
+			},
+		},
+		Spec: cmapi.CertificateRequestSpec{
+			Request:   csrPEM,
+			IssuerRef: s.opts.IssuerRef,
+			Usages: []cmapi.KeyUsage{
+				cmapi.UsageDigitalSignature,
+				cmapi.UsageKeyEncipherment,
+				cmapi.UsageServerAuth,
+				cmapi.UsageClientAuth,
+			},
+		},
+	}
+	if duration > 0 {
+		cr.Spec.Duration = &metav1.Duration{Duration: duration}
+	}
+
+	cr, err := s.client.CertmanagerV1().CertificateRequests(cr.Namespace).Create(ctx, cr, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CertificateRequest: %w", err)
+	}
+	log = logf.WithRelatedResource(log, cr)
+	defer func() {
+		// Workload certificates are short lived and requested often, so
+		// CertificateRequests are not kept once they have been signed.
+		if err := s.client.CertmanagerV1().CertificateRequests(cr.Namespace).Delete(context.Background(), cr.Name, metav1.DeleteOptions{}); err != nil {
+			log.Error(err, "failed to delete CertificateRequest")
+		}
+	}()
+
+	signed, err := s.waitForSigned(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	log.V(logf.DebugLevel).Info("signed workload certificate")
+
+	return certChain(signed.Status.Certificate, signed.Status.CA)
+}
+
+// waitForSigned polls the CertificateRequest until it has been signed, and
+// returns an error if it is denied or fails.
+func (s *Server) waitForSigned(ctx context.Context, cr *cmapi.CertificateRequest) (*cmapi.CertificateRequest, error) {
+	if s.opts.IssuanceTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.IssuanceTimeout)
+		defer cancel()
+	}
+
+	var signed *cmapi.CertificateRequest
+	err := wait.PollImmediateUntilWithContext(ctx, pollInterval, func(ctx context.Context) (bool, error) {
+		cr, err := s.client.CertmanagerV1().CertificateRequests(cr.Namespace).Get(ctx, cr.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if apiutil.CertificateRequestIsDenied(cr) {
+			return false, fmt.Errorf("CertificateRequest %s/%s has been denied", cr.Namespace, cr.Name)
+		}
+		if cond := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady); cond != nil && cond.Reason == cmapi.CertificateRequestReasonFailed {
+			return false, fmt.Errorf("CertificateRequest %s/%s has failed: %s", cr.Namespace, cr.Name, cond.Message)
+		}
+		if len(cr.Status.Certificate) == 0 {
+			return false, nil
+		}
+		signed = cr
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for CertificateRequest %s/%s to be signed: %w", cr.Namespace, cr.Name, err)
+	}
+	return signed, nil
+}
+
+// certChain returns the PEM encoded certificates of the signed chain,
+// appending the CA if the chain does not already end with it. Istio expects
+// the last certificate of the chain to be the root of trust.
+func certChain(certPEM, caPEM []byte) ([]string, error) {
+	certs, err := pki.DecodeX509CertificateChainBytes(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signed certificate: %w", err)
+	}
+	if len(caPEM) > 0 {
+		ca, err := pki.DecodeX509CertificateBytes(caPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode CA: %w", err)
+		}
+		if !certs[len(certs)-1].Equal(ca) {
+			certs = append(certs, ca)
+		}
+	}
+
+	chain := make([]string, len(certs))
+	for i, cert := range certs {
+		chain[i] = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	}
+	return chain, nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istioca
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const testIdentity = "spiffe://cluster.local/ns/my-ns/sa/my-sa"
+
+type testCA struct {
+	cert *x509.Certificate
+	pem  []byte
+	key  crypto.Signer
+}
+
+func mustTestCA(t *testing.T) *testCA {
+	ca := gen.Certificate("ca", gen.SetCertificateCommonName("ca"), gen.SetCertificateIsCA(true))
+	key, err := pki.GeneratePrivateKeyForCertificate(ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.GenerateTemplate(ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caPEM, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, pem: caPEM, key: key}
+}
+
+func mustCSR(t *testing.T, uris ...string) string {
+	crt := gen.Certificate("workload", gen.SetCertificateURIs(uris...))
+	key, err := pki.GeneratePrivateKeyForCertificate(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := pki.GenerateCSR(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := pki.EncodeCSR(csr, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+}
+
+// newTestServer serves a Server on an in-memory listener and returns a
+// connection to it. Tokens are authenticated as the service account
+// my-ns/my-sa if they are "valid", and CertificateRequests are signed by ca.
+func newTestServer(t *testing.T, ca *testCA) (*grpc.ClientConn, *cmfake.Clientset) {
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "tokenreviews", func(action coretesting.Action) (bool, runtime.Object, error) {
+		review := action.(coretesting.CreateAction).GetObject().(*authv1.TokenReview)
+		assert.Equal(t, []string{DefaultAudience}, review.Spec.Audiences)
+		if review.Spec.Token == "valid" {
+			review.Status.Authenticated = true
+			review.Status.User.Username = "system:serviceaccount:my-ns:my-sa"
+		}
+		return true, review, nil
+	})
+
+	client := cmfake.NewSimpleClientset()
+	client.PrependReactor("create", "certificaterequests", func(action coretesting.Action) (bool, runtime.Object, error) {
+		cr := action.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest)
+		cr.Name = cr.GenerateName + "abcde"
+		assert.Equal(t, testIdentity, cr.Annotations[IdentitiesAnnotationKey])
+		template, err := pki.GenerateTemplateFromCertificateRequest(cr)
+		if err != nil {
+			return true, nil, err
+		}
+		certPEM, _, err := pki.SignCertificate(template, ca.cert, template.PublicKey, ca.key)
+		if err != nil {
+			return true, nil, err
+		}
+		cr.Status.Certificate, cr.Status.CA = certPEM, ca.pem
+		return false, nil, nil
+	})
+
+	srv := grpc.NewServer()
+	NewServer(logtesting.NewTestLogger(t), kubeClient, client, Options{
+		IssuerRef:   cmmeta.ObjectReference{Name: "mesh-ca", Kind: cmapi.IssuerKind},
+		Namespace:   "istio-system",
+		TrustDomain: "cluster.local",
+		MaxDuration: time.Hour,
+	}).Register(srv)
+
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, client
+}
+
+func createCertificate(conn *grpc.ClientConn, token string, req certificateRequest) (certificateResponse, error) {
+	ctx := context.TODO()
+	if token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	resp := newCertificateResponse(nil)
+	err := conn.Invoke(ctx, "/"+serviceName+"/"+createMethodName, req.Message, resp.Message)
+	return resp, err
+}
+
+func TestCreateCertificate(t *testing.T) {
+	ca := mustTestCA(t)
+	conn, client := newTestServer(t, ca)
+
+	resp, err := createCertificate(conn, "valid", newCertificateRequest(mustCSR(t, testIdentity), int64((24*time.Hour).Seconds())))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	chain := resp.certChain()
+	if assert.Len(t, chain, 2) {
+		leaf, err := pki.DecodeX509CertificateBytes([]byte(chain[0]))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{testIdentity}, pki.URLsToString(leaf.URIs))
+		assert.Equal(t, time.Hour, leaf.NotAfter.Sub(leaf.NotBefore), "expected the duration to be capped to the maximum")
+		assert.Equal(t, string(ca.pem), chain[1])
+	}
+
+	crs, err := client.CertmanagerV1().CertificateRequests("istio-system").List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, crs.Items, "expected the CertificateRequest to be deleted once signed")
+}
+
+func TestCreateCertificateRejected(t *testing.T) {
+	otherIdentity := "spiffe://cluster.local/ns/my-ns/sa/other"
+
+	tests := map[string]struct {
+		token   string
+		csr     string
+		expCode codes.Code
+	}{
+		"no token": {
+			csr:     testIdentity,
+			expCode: codes.Unauthenticated,
+		},
+		"invalid token": {
+			token:   "invalid",
+			csr:     testIdentity,
+			expCode: codes.Unauthenticated,
+		},
+		"CSR for the identity of another service account": {
+			token:   "valid",
+			csr:     otherIdentity,
+			expCode: codes.InvalidArgument,
+		},
+	}
+
+	conn, _ := newTestServer(t, mustTestCA(t))
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := createCertificate(conn, test.token, newCertificateRequest(mustCSR(t, test.csr), 0))
+			assert.Equal(t, test.expCode, status.Code(err))
+		})
+	}
+}
@@ -18,6 +18,17 @@ package metrics
 
 import (
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// ChallengeRetryReasonPresent is the reason label value of challenges
+	// retried because they could not be presented.
+	ChallengeRetryReasonPresent = "present"
+	// ChallengeRetryReasonSelfCheck is the reason label value of challenges
+	// retried because their self check did not pass.
+	ChallengeRetryReasonSelfCheck = "self_check"
 )
 
 // ObserveACMERequestDuration increases bucket counters for that ACME client duration.
@@ -29,3 +40,45 @@ func (m *Metrics) ObserveACMERequestDuration(duration time.Duration, labels ...s
 func (m *Metrics) IncrementACMERequestCount(labels ...string) {
 	m.acmeClientRequestCount.WithLabelValues(labels...).Inc()
 }
+
+// ObserveACMEChallengeSelfCheck records the duration of a self check of a
+// challenge of the given type, solved by the given provider. The check is
+// recorded as failed if err is not nil.
+func (m *Metrics) ObserveACMEChallengeSelfCheck(challengeType, provider string, duration time.Duration, err error) {
+	result := IssuanceResultSuccess
+	if err != nil {
+		result = IssuanceResultFailure
+	}
+	m.acmeChallengeSelfCheckDuration.With(prometheus.Labels{
+		"type":     challengeType,
+		"provider": provider,
+		"result":   result,
+	}).Observe(duration.Seconds())
+}
+
+// ObserveACMEChallengePropagationWait records the time between a challenge
+// being presented and its self check passing.
+func (m *Metrics) ObserveACMEChallengePropagationWait(challengeType, provider string, duration time.Duration) {
+	m.acmeChallengePropagationWait.With(prometheus.Labels{
+		"type":     challengeType,
+		"provider": provider,
+	}).Observe(duration.Seconds())
+}
+
+// ObserveACMEChallengeSolverPodPending records the time taken for an HTTP01
+// solver pod to become ready.
+func (m *Metrics) ObserveACMEChallengeSolverPodPending(provider string, duration time.Duration) {
+	m.acmeChallengeSolverPodPending.With(prometheus.Labels{
+		"provider": provider,
+	}).Observe(duration.Seconds())
+}
+
+// IncrementACMEChallengeRetryCount increases the number of times a challenge
+// was retried for the given reason.
+func (m *Metrics) IncrementACMEChallengeRetryCount(challengeType, provider, reason string) {
+	m.acmeChallengeRetryCount.With(prometheus.Labels{
+		"type":     challengeType,
+		"provider": provider,
+		"reason":   reason,
+	}).Inc()
+}
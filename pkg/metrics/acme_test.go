@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+)
+
+func TestACMEChallengeMetrics(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	m.ObserveACMEChallengeSelfCheck("DNS-01", "route53", 2*time.Second, errors.New("not propagated"))
+	m.IncrementACMEChallengeRetryCount("DNS-01", "route53", ChallengeRetryReasonSelfCheck)
+	m.ObserveACMEChallengeSelfCheck("DNS-01", "route53", 200*time.Millisecond, nil)
+	m.ObserveACMEChallengePropagationWait("DNS-01", "route53", 45*time.Second)
+	m.IncrementACMEChallengeRetryCount("HTTP-01", "ingress", ChallengeRetryReasonPresent)
+	m.ObserveACMEChallengeSolverPodPending("ingress", 3*time.Second)
+
+	if err := testutil.CollectAndCompare(m.acmeChallengeSelfCheckDuration,
+		strings.NewReader(`
+# HELP certmanager_acme_challenge_self_check_duration_seconds The time taken to run the self check of an ACME challenge.
+# TYPE certmanager_acme_challenge_self_check_duration_seconds histogram
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="failure",type="DNS-01",le="0.1"} 0
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="failure",type="DNS-01",le="0.5"} 0
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="failure",type="DNS-01",le="1"} 0
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="failure",type="DNS-01",le="5"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="failure",type="DNS-01",le="10"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="failure",type="DNS-01",le="15"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="failure",type="DNS-01",le="30"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="failure",type="DNS-01",le="60"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="failure",type="DNS-01",le="120"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="failure",type="DNS-01",le="+Inf"} 1
+certmanager_acme_challenge_self_check_duration_seconds_sum{provider="route53",result="failure",type="DNS-01"} 2
+certmanager_acme_challenge_self_check_duration_seconds_count{provider="route53",result="failure",type="DNS-01"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="success",type="DNS-01",le="0.1"} 0
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="success",type="DNS-01",le="0.5"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="success",type="DNS-01",le="1"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="success",type="DNS-01",le="5"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="success",type="DNS-01",le="10"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="success",type="DNS-01",le="15"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="success",type="DNS-01",le="30"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="success",type="DNS-01",le="60"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="success",type="DNS-01",le="120"} 1
+certmanager_acme_challenge_self_check_duration_seconds_bucket{provider="route53",result="success",type="DNS-01",le="+Inf"} 1
+certmanager_acme_challenge_self_check_duration_seconds_sum{provider="route53",result="success",type="DNS-01"} 0.2
+certmanager_acme_challenge_self_check_duration_seconds_count{provider="route53",result="success",type="DNS-01"} 1
+`)); err != nil {
+		t.Error(err)
+	}
+
+	if err := testutil.CollectAndCompare(m.acmeChallengeRetryCount,
+		strings.NewReader(`
+# HELP certmanager_acme_challenge_retry_count The number of times presenting or self checking an ACME challenge failed and was retried.
+# TYPE certmanager_acme_challenge_retry_count counter
+certmanager_acme_challenge_retry_count{provider="ingress",reason="present",type="HTTP-01"} 1
+certmanager_acme_challenge_retry_count{provider="route53",reason="self_check",type="DNS-01"} 1
+`)); err != nil {
+		t.Error(err)
+	}
+
+	if count := testutil.CollectAndCount(m.acmeChallengePropagationWait); count != 1 {
+		t.Errorf("expected 1 propagation wait series, got %d", count)
+	}
+	if count := testutil.CollectAndCount(m.acmeChallengeSolverPodPending); count != 1 {
+		t.Errorf("expected 1 solver pod pending series, got %d", count)
+	}
+}
@@ -18,6 +18,7 @@ package metrics
 
 import (
 	"context"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/client-go/tools/cache"
@@ -40,6 +41,7 @@ func (m *Metrics) UpdateCertificate(ctx context.Context, crt *cmapi.Certificate)
 	m.updateCertificateStatus(key, crt)
 	m.updateCertificateExpiry(ctx, key, crt)
 	m.updateCertificateRenewalTime(crt)
+	m.renewalSchedule.set(key, crt)
 }
 
 // updateCertificateExpiry updates the expiry time of a certificate
@@ -107,6 +109,38 @@ func (m *Metrics) updateCertificateReadyStatus(crt *cmapi.Certificate, current c
 	}
 }
 
+// SecretCertificate is the validity of the certificate stored under a key of
+// a Certificate's Secret. For keys holding a certificate chain this is the
+// leaf certificate, and for keys holding CA certificates this is the CA
+// certificate which expires first.
+type SecretCertificate struct {
+	Key       string
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// UpdateCertificateSecret replaces the metrics for the certificates stored in
+// the given Certificate's Secret.
+func (m *Metrics) UpdateCertificateSecret(crt *cmapi.Certificate, certs []SecretCertificate) {
+	crtLabels := prometheus.Labels{"name": crt.Name, "namespace": crt.Namespace}
+	m.certificateSecretNotBeforeSeconds.DeletePartialMatch(crtLabels)
+	m.certificateSecretNotAfterSeconds.DeletePartialMatch(crtLabels)
+
+	for _, cert := range certs {
+		labels := prometheus.Labels{
+			"name":         crt.Name,
+			"namespace":    crt.Namespace,
+			"secret_name":  crt.Spec.SecretName,
+			"key":          cert.Key,
+			"issuer_name":  crt.Spec.IssuerRef.Name,
+			"issuer_kind":  crt.Spec.IssuerRef.Kind,
+			"issuer_group": crt.Spec.IssuerRef.Group,
+		}
+		m.certificateSecretNotBeforeSeconds.With(labels).Set(float64(cert.NotBefore.Unix()))
+		m.certificateSecretNotAfterSeconds.With(labels).Set(float64(cert.NotAfter.Unix()))
+	}
+}
+
 // RemoveCertificate will delete the Certificate metrics from continuing to be
 // exposed.
 func (m *Metrics) RemoveCertificate(key string) {
@@ -118,5 +152,8 @@ func (m *Metrics) RemoveCertificate(key string) {
 
 	m.certificateExpiryTimeSeconds.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
 	m.certificateRenewalTimeSeconds.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
+	m.renewalSchedule.remove(key)
 	m.certificateReadyStatus.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
+	m.certificateSecretNotBeforeSeconds.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
+	m.certificateSecretNotAfterSeconds.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
 }
@@ -38,7 +38,7 @@ const expiryMetadata = `
 `
 
 const renewalTimeMetadata = `
-	# HELP certmanager_certificate_renewal_timestamp_seconds The number of seconds before expiration time the certificate should renew.
+	# HELP certmanager_certificate_renewal_timestamp_seconds The time after which the certificate should be renewed. Expressed as a Unix Epoch Time.
 	# TYPE certmanager_certificate_renewal_timestamp_seconds gauge
 `
 
@@ -364,3 +364,71 @@ func TestCertificateCache(t *testing.T) {
 		t.Errorf("unexpected collecting result:\n%s", err)
 	}
 }
+
+const secretNotAfterMetadata = `
+	# HELP certmanager_certificate_secret_not_after_timestamp_seconds The date after which the certificate stored under the key of the Certificate's Secret expires. Expressed as a Unix Epoch Time.
+	# TYPE certmanager_certificate_secret_not_after_timestamp_seconds gauge
+`
+
+const secretNotBeforeMetadata = `
+	# HELP certmanager_certificate_secret_not_before_timestamp_seconds The date before which the certificate stored under the key of the Certificate's Secret is not valid. Expressed as a Unix Epoch Time.
+	# TYPE certmanager_certificate_secret_not_before_timestamp_seconds gauge
+`
+
+func TestCertificateSecretMetrics(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	crt := gen.Certificate("crt1",
+		gen.SetCertificateSecretName("crt1-tls"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{
+			Name:  "test-issuer",
+			Kind:  "test-issuer-kind",
+			Group: "test-issuer-group",
+		}),
+	)
+
+	m.UpdateCertificateSecret(crt, []SecretCertificate{
+		{Key: "tls.crt", NotBefore: time.Unix(100, 0), NotAfter: time.Unix(200, 0)},
+		{Key: "ca.crt", NotBefore: time.Unix(10, 0), NotAfter: time.Unix(150, 0)},
+	})
+
+	if err := testutil.CollectAndCompare(m.certificateSecretNotAfterSeconds,
+		strings.NewReader(secretNotAfterMetadata+`
+        certmanager_certificate_secret_not_after_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",key="ca.crt",name="crt1",namespace="default-unit-test-ns",secret_name="crt1-tls"} 150
+        certmanager_certificate_secret_not_after_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",key="tls.crt",name="crt1",namespace="default-unit-test-ns",secret_name="crt1-tls"} 200
+`),
+		"certmanager_certificate_secret_not_after_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	if err := testutil.CollectAndCompare(m.certificateSecretNotBeforeSeconds,
+		strings.NewReader(secretNotBeforeMetadata+`
+        certmanager_certificate_secret_not_before_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",key="ca.crt",name="crt1",namespace="default-unit-test-ns",secret_name="crt1-tls"} 10
+        certmanager_certificate_secret_not_before_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",key="tls.crt",name="crt1",namespace="default-unit-test-ns",secret_name="crt1-tls"} 100
+`),
+		"certmanager_certificate_secret_not_before_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Keys no longer present in the Secret should be removed
+	m.UpdateCertificateSecret(crt, []SecretCertificate{
+		{Key: "tls.crt", NotBefore: time.Unix(300, 0), NotAfter: time.Unix(400, 0)},
+	})
+	if err := testutil.CollectAndCompare(m.certificateSecretNotAfterSeconds,
+		strings.NewReader(secretNotAfterMetadata+`
+        certmanager_certificate_secret_not_after_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",key="tls.crt",name="crt1",namespace="default-unit-test-ns",secret_name="crt1-tls"} 400
+`),
+		"certmanager_certificate_secret_not_after_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	m.RemoveCertificate("default-unit-test-ns/crt1")
+	if err := testutil.CollectAndCompare(m.certificateSecretNotAfterSeconds,
+		strings.NewReader(secretNotAfterMetadata),
+		"certmanager_certificate_secret_not_after_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
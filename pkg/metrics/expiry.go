@@ -0,0 +1,45 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UpdateExpiringSoon records that the certificate of the resource of the
+// given kind, either a Certificate or an unmanaged TLS Secret, expires within
+// the expiry alert threshold.
+func (m *Metrics) UpdateExpiringSoon(kind, namespace, name string, threshold time.Duration) {
+	m.expiringSoonThresholdSeconds.With(prometheus.Labels{
+		"kind":      kind,
+		"name":      name,
+		"namespace": namespace,
+	}).Set(threshold.Seconds())
+}
+
+// RemoveExpiringSoon removes the expiry alert metric of the resource, once
+// its certificate no longer expires within any threshold or the resource is
+// deleted.
+func (m *Metrics) RemoveExpiringSoon(kind, namespace, name string) {
+	m.expiringSoonThresholdSeconds.Delete(prometheus.Labels{
+		"kind":      kind,
+		"name":      name,
+		"namespace": namespace,
+	})
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+)
+
+func TestExpiringSoon(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	m.UpdateExpiringSoon("Certificate", "default", "test", 30*24*time.Hour)
+	m.UpdateExpiringSoon("Certificate", "default", "test", 7*24*time.Hour)
+	m.UpdateExpiringSoon("Secret", "default", "test", 0)
+	m.UpdateExpiringSoon("Secret", "default", "other", 14*24*time.Hour)
+	m.RemoveExpiringSoon("Secret", "default", "other")
+
+	if err := testutil.CollectAndCompare(m.expiringSoonThresholdSeconds,
+		strings.NewReader(`
+	# HELP certmanager_expiring_soon_threshold_seconds The smallest expiry alert threshold within which the certificate of the Certificate or unmanaged TLS Secret expires, in seconds, or 0 if it has expired.
+	# TYPE certmanager_expiring_soon_threshold_seconds gauge
+	certmanager_expiring_soon_threshold_seconds{kind="Certificate",name="test",namespace="default"} 604800
+	certmanager_expiring_soon_threshold_seconds{kind="Secret",name="test",namespace="default"} 0
+`),
+		"certmanager_expiring_soon_threshold_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	// IssuanceResultSuccess is the result label value of successful issuances.
+	IssuanceResultSuccess = "success"
+	// IssuanceResultFailure is the result label value of failed issuances.
+	IssuanceResultFailure = "failure"
+)
+
+// ObserveCertificateIssuance records the result of an issuance by the given
+// issuer. The reason is the reason of the final CertificateRequest condition,
+// e.g. Issued, Failed or Denied. The duration is observed if it is positive.
+func (m *Metrics) ObserveCertificateIssuance(issuerRef cmmeta.ObjectReference, result, reason string, duration time.Duration) {
+	m.certificateIssuanceCount.With(prometheus.Labels{
+		"issuer_name":  issuerRef.Name,
+		"issuer_kind":  issuerRef.Kind,
+		"issuer_group": issuerRef.Group,
+		"result":       result,
+		"reason":       reason,
+	}).Inc()
+
+	if duration > 0 {
+		m.certificateIssuanceDuration.With(prometheus.Labels{
+			"issuer_name":  issuerRef.Name,
+			"issuer_kind":  issuerRef.Kind,
+			"issuer_group": issuerRef.Group,
+			"result":       result,
+		}).Observe(duration.Seconds())
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestObserveCertificateIssuance(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	issuerRef := cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer", Group: "cert-manager.io"}
+
+	m.ObserveCertificateIssuance(issuerRef, IssuanceResultSuccess, "Issued", 3*time.Second)
+	m.ObserveCertificateIssuance(issuerRef, IssuanceResultSuccess, "Issued", 45*time.Second)
+	m.ObserveCertificateIssuance(issuerRef, IssuanceResultFailure, "Denied", 0)
+
+	if err := testutil.CollectAndCompare(m.certificateIssuanceCount,
+		strings.NewReader(`
+	# HELP certmanager_certificate_issuance_count The number of Certificate issuances which succeeded or failed.
+	# TYPE certmanager_certificate_issuance_count counter
+	certmanager_certificate_issuance_count{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",reason="Denied",result="failure"} 1
+	certmanager_certificate_issuance_count{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",reason="Issued",result="success"} 2
+`),
+		"certmanager_certificate_issuance_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	if err := testutil.CollectAndCompare(m.certificateIssuanceDuration,
+		strings.NewReader(`
+	# HELP certmanager_certificate_issuance_duration_seconds The time taken from a Certificate being marked for issuance until the issuance succeeded or failed.
+	# TYPE certmanager_certificate_issuance_duration_seconds histogram
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success",le="1"} 0
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success",le="5"} 1
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success",le="10"} 1
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success",le="30"} 1
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success",le="60"} 2
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success",le="120"} 2
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success",le="300"} 2
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success",le="600"} 2
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success",le="1800"} 2
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success",le="3600"} 2
+	certmanager_certificate_issuance_duration_seconds_bucket{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success",le="+Inf"} 2
+	certmanager_certificate_issuance_duration_seconds_sum{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success"} 48
+	certmanager_certificate_issuance_duration_seconds_count{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="ca",result="success"} 2
+`),
+		"certmanager_certificate_issuance_duration_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
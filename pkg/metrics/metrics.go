@@ -18,16 +18,36 @@ limitations under the License.
 // cert-manager exposes the following metrics:
 // certificate_expiration_timestamp_seconds{name, namespace, issuer_name, issuer_kind, issuer_group}
 // certificate_renewal_timestamp_seconds{name, namespace, issuer_name, issuer_kind, issuer_group}
+// certificate_renewals_scheduled{issuer_name, issuer_kind, issuer_group, window}
 // certificate_ready_status{name, namespace, condition, issuer_name, issuer_kind, issuer_group}
+// certificate_secret_not_before_timestamp_seconds{name, namespace, secret_name, key, issuer_name, issuer_kind, issuer_group}
+// certificate_secret_not_after_timestamp_seconds{name, namespace, secret_name, key, issuer_name, issuer_kind, issuer_group}
+// certificate_issuance_duration_seconds{issuer_name, issuer_kind, issuer_group, result}
+// certificate_issuance_count{issuer_name, issuer_kind, issuer_group, result, reason}
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
+// acme_challenge_self_check_duration_seconds{type, provider, result}
+// acme_challenge_propagation_wait_seconds{type, provider}
+// acme_challenge_solver_pod_pending_seconds{provider}
+// acme_challenge_retry_count{type, provider, reason}
 // venafi_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // controller_sync_call_count{"controller"}
+// controller_sync_error_count{"controller"}
+// controller_sync_duration_seconds{"controller"}
+// controller_last_successful_sync_timestamp_seconds{"controller"}
+// workqueue_depth{"controller"}
+// workqueue_adds_count{"controller"}
+// workqueue_queue_duration_seconds{"controller"}
+// workqueue_work_duration_seconds{"controller"}
+// workqueue_unfinished_work_seconds{"controller"}
+// workqueue_longest_running_processor_seconds{"controller"}
+// workqueue_retries_count{"controller"}
 package metrics
 
 import (
 	"net"
 	"net/http"
+	"regexp"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -57,11 +77,31 @@ type Metrics struct {
 	certificateExpiryTimeSeconds       *prometheus.GaugeVec
 	certificateRenewalTimeSeconds      *prometheus.GaugeVec
 	certificateReadyStatus             *prometheus.GaugeVec
+	certificateSecretNotBeforeSeconds  *prometheus.GaugeVec
+	certificateSecretNotAfterSeconds   *prometheus.GaugeVec
+	expiringSoonThresholdSeconds       *prometheus.GaugeVec
+	certificateIssuanceDuration        *prometheus.HistogramVec
+	certificateIssuanceCount           *prometheus.CounterVec
 	acmeClientRequestDurationSeconds   *prometheus.SummaryVec
 	acmeClientRequestCount             *prometheus.CounterVec
+	acmeChallengeSelfCheckDuration     *prometheus.HistogramVec
+	acmeChallengePropagationWait       *prometheus.HistogramVec
+	acmeChallengeSolverPodPending      *prometheus.HistogramVec
+	acmeChallengeRetryCount            *prometheus.CounterVec
 	venafiClientRequestDurationSeconds *prometheus.SummaryVec
 	controllerSyncCallCount            *prometheus.CounterVec
 	controllerSyncErrorCount           *prometheus.CounterVec
+	controllerSyncDuration             *prometheus.SummaryVec
+	controllerLastSuccessfulSync       *prometheus.GaugeVec
+	workqueueDepth                     *prometheus.GaugeVec
+	workqueueAddsCount                 *prometheus.CounterVec
+	workqueueQueueDuration             *prometheus.HistogramVec
+	workqueueWorkDuration              *prometheus.HistogramVec
+	workqueueUnfinishedWork            *prometheus.GaugeVec
+	workqueueLongestRunningProcessor   *prometheus.GaugeVec
+	workqueueRetriesCount              *prometheus.CounterVec
+
+	renewalSchedule *renewalSchedule
 }
 
 var readyConditionStatuses = [...]cmmeta.ConditionStatus{cmmeta.ConditionTrue, cmmeta.ConditionFalse, cmmeta.ConditionUnknown}
@@ -113,7 +153,7 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			prometheus.GaugeOpts{
 				Namespace: namespace,
 				Name:      "certificate_renewal_timestamp_seconds",
-				Help:      "The number of seconds before expiration time the certificate should renew.",
+				Help:      "The time after which the certificate should be renewed. Expressed as a Unix Epoch Time.",
 			},
 			[]string{"name", "namespace", "issuer_name", "issuer_kind", "issuer_group"},
 		)
@@ -127,6 +167,65 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"name", "namespace", "condition", "issuer_name", "issuer_kind", "issuer_group"},
 		)
 
+		// certificateSecretNotBeforeSeconds and certificateSecretNotAfterSeconds
+		// expose the validity of the certificates actually stored in a
+		// Certificate's Secret, for every key holding a leaf or CA certificate
+		// including the keystores.
+		certificateSecretNotBeforeSeconds = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_secret_not_before_timestamp_seconds",
+				Help:      "The date before which the certificate stored under the key of the Certificate's Secret is not valid. Expressed as a Unix Epoch Time.",
+			},
+			[]string{"name", "namespace", "secret_name", "key", "issuer_name", "issuer_kind", "issuer_group"},
+		)
+
+		certificateSecretNotAfterSeconds = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_secret_not_after_timestamp_seconds",
+				Help:      "The date after which the certificate stored under the key of the Certificate's Secret expires. Expressed as a Unix Epoch Time.",
+			},
+			[]string{"name", "namespace", "secret_name", "key", "issuer_name", "issuer_kind", "issuer_group"},
+		)
+
+		// expiringSoonThresholdSeconds is only exposed for Certificates and
+		// unmanaged TLS Secrets whose certificate expires within one of the
+		// expiry alert thresholds, so that its presence can be alerted on.
+		expiringSoonThresholdSeconds = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "expiring_soon_threshold_seconds",
+				Help:      "The smallest expiry alert threshold within which the certificate of the Certificate or unmanaged TLS Secret expires, in seconds, or 0 if it has expired.",
+			},
+			[]string{"kind", "name", "namespace"},
+		)
+
+		// certificateIssuanceDuration is a Prometheus histogram of the time
+		// taken from a Certificate being marked as Issuing until the issuance
+		// succeeded or failed.
+		certificateIssuanceDuration = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "certificate_issuance_duration_seconds",
+				Help:      "The time taken from a Certificate being marked for issuance until the issuance succeeded or failed.",
+				Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+			},
+			[]string{"issuer_name", "issuer_kind", "issuer_group", "result"},
+		)
+
+		// certificateIssuanceCount is a Prometheus counter of completed
+		// issuances, by result and the reason of the final CertificateRequest
+		// condition.
+		certificateIssuanceCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "certificate_issuance_count",
+				Help:      "The number of Certificate issuances which succeeded or failed.",
+			},
+			[]string{"issuer_name", "issuer_kind", "issuer_group", "result", "reason"},
+		)
+
 		// acmeClientRequestCount is a Prometheus summary to collect the number of
 		// requests made to each endpoint with the ACME client.
 		acmeClientRequestCount = prometheus.NewCounterVec(
@@ -152,6 +251,54 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"scheme", "host", "path", "method", "status"},
 		)
 
+		// acmeChallengeSelfCheckDuration is a Prometheus histogram of the
+		// time taken by each self check of an ACME challenge.
+		acmeChallengeSelfCheckDuration = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "acme_challenge_self_check_duration_seconds",
+				Help:      "The time taken to run the self check of an ACME challenge.",
+				Buckets:   []float64{0.1, 0.5, 1, 5, 10, 15, 30, 60, 120},
+			},
+			[]string{"type", "provider", "result"},
+		)
+
+		// acmeChallengePropagationWait is a Prometheus histogram of the time
+		// between an ACME challenge being presented and its self check first
+		// passing, i.e. the time taken for the challenge to propagate.
+		acmeChallengePropagationWait = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "acme_challenge_propagation_wait_seconds",
+				Help:      "The time taken from an ACME challenge being presented until its self check passed.",
+				Buckets:   []float64{5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+			},
+			[]string{"type", "provider"},
+		)
+
+		// acmeChallengeSolverPodPending is a Prometheus histogram of the time
+		// taken for HTTP01 solver pods to become ready after being created.
+		acmeChallengeSolverPodPending = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "acme_challenge_solver_pod_pending_seconds",
+				Help:      "The time taken from an HTTP01 challenge solver pod being created until it was ready.",
+				Buckets:   []float64{1, 2, 5, 10, 30, 60, 120, 300, 600},
+			},
+			[]string{"provider"},
+		)
+
+		// acmeChallengeRetryCount is a Prometheus counter of the number of
+		// times ACME challenges had to be retried, by the step which failed.
+		acmeChallengeRetryCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "acme_challenge_retry_count",
+				Help:      "The number of times presenting or self checking an ACME challenge failed and was retried.",
+			},
+			[]string{"type", "provider", "reason"},
+		)
+
 		// venafiClientRequestDurationSeconds is a Prometheus summary to
 		// collect api call latencies for the Venafi client. This
 		// metric is in alpha since cert-manager 1.9. Move it to GA once
@@ -184,6 +331,94 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			},
 			[]string{"controller"},
 		)
+
+		controllerSyncDuration = prometheus.NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace:  namespace,
+				Name:       "controller_sync_duration_seconds",
+				Help:       "The time taken by controller sync() calls, whether or not they succeeded.",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+			[]string{"controller"},
+		)
+
+		// controllerLastSuccessfulSync can be used to alert on controllers
+		// which are running but are no longer reconciling.
+		controllerLastSuccessfulSync = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "controller_last_successful_sync_timestamp_seconds",
+				Help:      "The time at which a controller last completed a sync() call without error, in seconds since 1970/01/01 UTC.",
+			},
+			[]string{"controller"},
+		)
+
+		// The workqueue metrics are populated by the workqueue package of
+		// client-go, see WorkqueueMetricsProvider.
+		workqueueDepth = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "workqueue_depth",
+				Help:      "The number of items waiting in the workqueue of a controller.",
+			},
+			[]string{"controller"},
+		)
+
+		workqueueAddsCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "workqueue_adds_count",
+				Help:      "The number of items added to the workqueue of a controller.",
+			},
+			[]string{"controller"},
+		)
+
+		workqueueQueueDuration = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "workqueue_queue_duration_seconds",
+				Help:      "The time items spend in the workqueue of a controller before being processed.",
+				Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 10),
+			},
+			[]string{"controller"},
+		)
+
+		workqueueWorkDuration = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "workqueue_work_duration_seconds",
+				Help:      "The time taken to process an item from the workqueue of a controller.",
+				Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 10),
+			},
+			[]string{"controller"},
+		)
+
+		workqueueUnfinishedWork = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "workqueue_unfinished_work_seconds",
+				Help:      "The total time spent on items of the workqueue of a controller which are still being processed. Large values indicate stuck workers.",
+			},
+			[]string{"controller"},
+		)
+
+		workqueueLongestRunningProcessor = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "workqueue_longest_running_processor_seconds",
+				Help:      "The time for which the longest running worker of a controller has been processing its current item.",
+			},
+			[]string{"controller"},
+		)
+
+		workqueueRetriesCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "workqueue_retries_count",
+				Help:      "The number of items re-queued with a rate limit by a controller after failing to be processed.",
+			},
+			[]string{"controller"},
+		)
 	)
 
 	// Create server and register Prometheus metrics handler
@@ -191,16 +426,36 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 		log:      log.WithName("metrics"),
 		registry: prometheus.NewRegistry(),
 
+		renewalSchedule: newRenewalSchedule(c),
+
 		clockTimeSeconds:                   clockTimeSeconds,
 		clockTimeSecondsGauge:              clockTimeSecondsGauge,
 		certificateExpiryTimeSeconds:       certificateExpiryTimeSeconds,
 		certificateRenewalTimeSeconds:      certificateRenewalTimeSeconds,
 		certificateReadyStatus:             certificateReadyStatus,
+		certificateSecretNotBeforeSeconds:  certificateSecretNotBeforeSeconds,
+		certificateSecretNotAfterSeconds:   certificateSecretNotAfterSeconds,
+		expiringSoonThresholdSeconds:       expiringSoonThresholdSeconds,
+		certificateIssuanceDuration:        certificateIssuanceDuration,
+		certificateIssuanceCount:           certificateIssuanceCount,
 		acmeClientRequestCount:             acmeClientRequestCount,
 		acmeClientRequestDurationSeconds:   acmeClientRequestDurationSeconds,
+		acmeChallengeSelfCheckDuration:     acmeChallengeSelfCheckDuration,
+		acmeChallengePropagationWait:       acmeChallengePropagationWait,
+		acmeChallengeSolverPodPending:      acmeChallengeSolverPodPending,
+		acmeChallengeRetryCount:            acmeChallengeRetryCount,
 		venafiClientRequestDurationSeconds: venafiClientRequestDurationSeconds,
 		controllerSyncCallCount:            controllerSyncCallCount,
 		controllerSyncErrorCount:           controllerSyncErrorCount,
+		controllerSyncDuration:             controllerSyncDuration,
+		controllerLastSuccessfulSync:       controllerLastSuccessfulSync,
+		workqueueDepth:                     workqueueDepth,
+		workqueueAddsCount:                 workqueueAddsCount,
+		workqueueQueueDuration:             workqueueQueueDuration,
+		workqueueWorkDuration:              workqueueWorkDuration,
+		workqueueUnfinishedWork:            workqueueUnfinishedWork,
+		workqueueLongestRunningProcessor:   workqueueLongestRunningProcessor,
+		workqueueRetriesCount:              workqueueRetriesCount,
 	}
 
 	return m
@@ -208,19 +463,11 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 
 // NewServer registers Prometheus metrics and returns a new Prometheus metrics HTTP server.
 func (m *Metrics) NewServer(ln net.Listener) *http.Server {
-	m.registry.MustRegister(m.clockTimeSeconds)
-	m.registry.MustRegister(m.clockTimeSecondsGauge)
-	m.registry.MustRegister(m.certificateExpiryTimeSeconds)
-	m.registry.MustRegister(m.certificateRenewalTimeSeconds)
-	m.registry.MustRegister(m.certificateReadyStatus)
-	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
-	m.registry.MustRegister(m.venafiClientRequestDurationSeconds)
-	m.registry.MustRegister(m.acmeClientRequestCount)
-	m.registry.MustRegister(m.controllerSyncCallCount)
-	m.registry.MustRegister(m.controllerSyncErrorCount)
+	m.registry.MustRegister(m.collectors()...)
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	mux.Handle("/debug/renewals", m.RenewalsHandler())
 
 	server := &http.Server{
 		Addr:           ln.Addr().String(),
@@ -233,6 +480,66 @@ func (m *Metrics) NewServer(ln net.Listener) *http.Server {
 	return server
 }
 
+// collectors returns the collectors of all metrics exposed by cert-manager.
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.clockTimeSeconds,
+		m.clockTimeSecondsGauge,
+		m.certificateExpiryTimeSeconds,
+		m.certificateRenewalTimeSeconds,
+		m.renewalSchedule,
+		m.certificateReadyStatus,
+		m.certificateSecretNotBeforeSeconds,
+		m.certificateSecretNotAfterSeconds,
+		m.expiringSoonThresholdSeconds,
+		m.certificateIssuanceDuration,
+		m.certificateIssuanceCount,
+		m.acmeClientRequestDurationSeconds,
+		m.venafiClientRequestDurationSeconds,
+		m.acmeClientRequestCount,
+		m.acmeChallengeSelfCheckDuration,
+		m.acmeChallengePropagationWait,
+		m.acmeChallengeSolverPodPending,
+		m.acmeChallengeRetryCount,
+		m.controllerSyncCallCount,
+		m.controllerSyncErrorCount,
+		m.controllerSyncDuration,
+		m.controllerLastSuccessfulSync,
+		m.workqueueDepth,
+		m.workqueueAddsCount,
+		m.workqueueQueueDuration,
+		m.workqueueWorkDuration,
+		m.workqueueUnfinishedWork,
+		m.workqueueLongestRunningProcessor,
+		m.workqueueRetriesCount,
+	}
+}
+
+// Names returns the fully qualified names of the metrics exposed by
+// cert-manager, so that consumers of the metrics such as the generated
+// alerting rules can be checked against them.
+func (m *Metrics) Names() []string {
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		defer close(ch)
+		for _, c := range m.collectors() {
+			c.Describe(ch)
+		}
+	}()
+
+	var names []string
+	for desc := range ch {
+		// prometheus.Desc does not expose its name other than through
+		// String.
+		if match := descNameRegexp.FindStringSubmatch(desc.String()); match != nil {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+var descNameRegexp = regexp.MustCompile(`fqName: "([^"]+)"`)
+
 // IncrementSyncCallCount will increase the sync counter for that controller.
 func (m *Metrics) IncrementSyncCallCount(controllerName string) {
 	m.controllerSyncCallCount.WithLabelValues(controllerName).Inc()
@@ -242,3 +549,14 @@ func (m *Metrics) IncrementSyncCallCount(controllerName string) {
 func (m *Metrics) IncrementSyncErrorCount(controllerName string) {
 	m.controllerSyncErrorCount.WithLabelValues(controllerName).Inc()
 }
+
+// ObserveSyncDuration records the time taken by a sync of that controller.
+func (m *Metrics) ObserveSyncDuration(controllerName string, duration time.Duration) {
+	m.controllerSyncDuration.WithLabelValues(controllerName).Observe(duration.Seconds())
+}
+
+// UpdateLastSuccessfulSync records the time of the last sync of that
+// controller which completed without error.
+func (m *Metrics) UpdateLastSuccessfulSync(controllerName string, t time.Time) {
+	m.controllerLastSuccessfulSync.WithLabelValues(controllerName).Set(float64(t.Unix()))
+}
@@ -68,3 +68,13 @@ certmanager_clock_time_seconds_gauge %f
 		})
 	}
 }
+
+func TestNames(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+	names := m.Names()
+
+	assert.Len(t, names, len(m.collectors()))
+	assert.Contains(t, names, "certmanager_certificate_expiration_timestamp_seconds")
+	assert.Contains(t, names, "certmanager_certificate_renewals_scheduled")
+	assert.Contains(t, names, "certmanager_http_acme_client_request_count")
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// renewalForecastWindows are the windows, starting now, for which the number
+// of scheduled certificate renewals is exposed, along with the value of their
+// window label.
+var renewalForecastWindows = []struct {
+	label    string
+	duration time.Duration
+}{
+	{"1h", time.Hour},
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+	{"30d", 30 * 24 * time.Hour},
+}
+
+// CertificateRenewal is the next renewal of a Certificate as scheduled by the
+// controller.
+type CertificateRenewal struct {
+	Namespace   string                 `json:"namespace"`
+	Name        string                 `json:"name"`
+	IssuerRef   cmmeta.ObjectReference `json:"issuerRef"`
+	RenewalTime time.Time              `json:"renewalTime"`
+}
+
+// renewalSchedule holds the renewal time of every Certificate known to the
+// metrics, and exposes the number of renewals scheduled within each forecast
+// window when collected.
+type renewalSchedule struct {
+	clock clock.Clock
+	desc  *prometheus.Desc
+
+	lock     sync.RWMutex
+	renewals map[string]CertificateRenewal
+}
+
+var _ prometheus.Collector = &renewalSchedule{}
+
+func newRenewalSchedule(c clock.Clock) *renewalSchedule {
+	return &renewalSchedule{
+		clock: c,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "certificate_renewals_scheduled"),
+			"The number of certificates scheduled to be renewed within the window starting now, including certificates which are overdue for renewal.",
+			[]string{"issuer_name", "issuer_kind", "issuer_group", "window"}, nil,
+		),
+		renewals: make(map[string]CertificateRenewal),
+	}
+}
+
+func (s *renewalSchedule) set(key string, crt *cmapi.Certificate) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if crt.Status.RenewalTime == nil {
+		delete(s.renewals, key)
+		return
+	}
+	s.renewals[key] = CertificateRenewal{
+		Namespace:   crt.Namespace,
+		Name:        crt.Name,
+		IssuerRef:   crt.Spec.IssuerRef,
+		RenewalTime: crt.Status.RenewalTime.Time,
+	}
+}
+
+func (s *renewalSchedule) remove(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.renewals, key)
+}
+
+// list returns the renewals scheduled before the given time, ordered by their
+// renewal time. All renewals are returned if before is zero.
+func (s *renewalSchedule) list(before time.Time) []CertificateRenewal {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	renewals := make([]CertificateRenewal, 0, len(s.renewals))
+	for _, r := range s.renewals {
+		if before.IsZero() || r.RenewalTime.Before(before) {
+			renewals = append(renewals, r)
+		}
+	}
+	sort.Slice(renewals, func(i, j int) bool {
+		if !renewals[i].RenewalTime.Equal(renewals[j].RenewalTime) {
+			return renewals[i].RenewalTime.Before(renewals[j].RenewalTime)
+		}
+		if renewals[i].Namespace != renewals[j].Namespace {
+			return renewals[i].Namespace < renewals[j].Namespace
+		}
+		return renewals[i].Name < renewals[j].Name
+	})
+	return renewals
+}
+
+func (s *renewalSchedule) Describe(ch chan<- *prometheus.Desc) {
+	ch <- s.desc
+}
+
+func (s *renewalSchedule) Collect(ch chan<- prometheus.Metric) {
+	now := s.clock.Now()
+	counts := make(map[cmmeta.ObjectReference][]int)
+	s.lock.RLock()
+	for _, r := range s.renewals {
+		if _, ok := counts[r.IssuerRef]; !ok {
+			counts[r.IssuerRef] = make([]int, len(renewalForecastWindows))
+		}
+		for i, window := range renewalForecastWindows {
+			if r.RenewalTime.Before(now.Add(window.duration)) {
+				counts[r.IssuerRef][i]++
+			}
+		}
+	}
+	s.lock.RUnlock()
+
+	for ref, windowCounts := range counts {
+		for i, window := range renewalForecastWindows {
+			ch <- prometheus.MustNewConstMetric(s.desc, prometheus.GaugeValue, float64(windowCounts[i]),
+				ref.Name, ref.Kind, ref.Group, window.label)
+		}
+	}
+}
+
+// RenewalsHandler serves the renewal schedule of all Certificates as JSON,
+// ordered by renewal time. The optional `within` query parameter, a duration
+// such as `24h`, limits the response to renewals scheduled within that
+// duration from now, including renewals which are overdue.
+func (m *Metrics) RenewalsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var before time.Time
+		if within := r.URL.Query().Get("within"); within != "" {
+			d, err := time.ParseDuration(within)
+			if err != nil {
+				http.Error(w, "invalid within duration: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			before = m.renewalSchedule.clock.Now().Add(d)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(m.renewalSchedule.list(before)); err != nil {
+			m.log.Error(err, "failed to write renewal schedule")
+		}
+	})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRenewalSchedule(t *testing.T) {
+	now := time.Unix(1_600_000_000, 0)
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(now))
+
+	issuer := cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer", Group: "cert-manager.io"}
+	renewingIn := func(name string, d time.Duration) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateIssuer(issuer),
+			gen.SetCertificateRenewalTime(metav1.NewTime(now.Add(d))),
+		)
+	}
+	m.UpdateCertificate(context.TODO(), renewingIn("overdue", -time.Minute))
+	m.UpdateCertificate(context.TODO(), renewingIn("in-two-days", 48*time.Hour))
+	m.UpdateCertificate(context.TODO(), renewingIn("in-two-weeks", 14*24*time.Hour))
+	m.UpdateCertificate(context.TODO(), renewingIn("removed", time.Minute))
+	m.RemoveCertificate("test-ns/removed")
+	// Certificates without a renewal time are not scheduled to be renewed.
+	m.UpdateCertificate(context.TODO(), gen.Certificate("not-issued", gen.SetCertificateNamespace("test-ns"), gen.SetCertificateIssuer(issuer)))
+
+	if err := testutil.CollectAndCompare(m.renewalSchedule, strings.NewReader(`
+# HELP certmanager_certificate_renewals_scheduled The number of certificates scheduled to be renewed within the window starting now, including certificates which are overdue for renewal.
+# TYPE certmanager_certificate_renewals_scheduled gauge
+certmanager_certificate_renewals_scheduled{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",window="1h"} 1
+certmanager_certificate_renewals_scheduled{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",window="24h"} 1
+certmanager_certificate_renewals_scheduled{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",window="7d"} 2
+certmanager_certificate_renewals_scheduled{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="test-issuer",window="30d"} 3
+`), "certmanager_certificate_renewals_scheduled"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	tests := map[string]struct {
+		query     string
+		expStatus int
+		expNames  []string
+	}{
+		"all renewals are listed in order": {
+			expStatus: http.StatusOK,
+			expNames:  []string{"overdue", "in-two-days", "in-two-weeks"},
+		},
+		"renewals are limited to the window": {
+			query:     "?within=72h",
+			expStatus: http.StatusOK,
+			expNames:  []string{"overdue", "in-two-days"},
+		},
+		"invalid window is rejected": {
+			query:     "?within=soon",
+			expStatus: http.StatusBadRequest,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			m.RenewalsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/renewals"+test.query, nil))
+			assert.Equal(t, test.expStatus, rec.Code)
+			if test.expStatus != http.StatusOK {
+				return
+			}
+
+			var renewals []CertificateRenewal
+			if err := json.Unmarshal(rec.Body.Bytes(), &renewals); err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, r := range renewals {
+				assert.Equal(t, issuer, r.IssuerRef)
+				names = append(names, r.Name)
+			}
+			assert.Equal(t, test.expNames, names)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"k8s.io/client-go/util/workqueue"
+)
+
+// WorkqueueMetricsProvider returns a workqueue.MetricsProvider which exposes
+// the metrics of named workqueues, labelled with the name of the queue. As
+// controllers name their queue after themselves, this gives the queue depth
+// and retries of each controller.
+// It must be installed using workqueue.SetProvider before any queues are
+// created.
+func (m *Metrics) WorkqueueMetricsProvider() workqueue.MetricsProvider {
+	return workqueueMetricsProvider{m}
+}
+
+type workqueueMetricsProvider struct {
+	m *Metrics
+}
+
+func (p workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return p.m.workqueueDepth.WithLabelValues(name)
+}
+
+func (p workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return p.m.workqueueAddsCount.WithLabelValues(name)
+}
+
+func (p workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return p.m.workqueueQueueDuration.WithLabelValues(name)
+}
+
+func (p workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return p.m.workqueueWorkDuration.WithLabelValues(name)
+}
+
+func (p workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return p.m.workqueueUnfinishedWork.WithLabelValues(name)
+}
+
+func (p workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return p.m.workqueueLongestRunningProcessor.WithLabelValues(name)
+}
+
+func (p workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return p.m.workqueueRetriesCount.WithLabelValues(name)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notifications sends certificate events, such as issuances and
+// failures, to HTTP sinks so that external systems can react to them
+// without polling the Kubernetes API.
+package notifications
+
+import (
+	"crypto/x509"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// EventType is the type of a certificate event.
+type EventType string
+
+const (
+	// EventIssued is sent when the first certificate of a Certificate has
+	// been issued.
+	EventIssued EventType = "Issued"
+	// EventRenewed is sent when a Certificate has been issued a new
+	// certificate, replacing a previous one.
+	EventRenewed EventType = "Renewed"
+	// EventFailed is sent when the issuance of a certificate has failed.
+	EventFailed EventType = "Failed"
+	// EventExpiring is sent when the certificate of a Certificate is about
+	// to expire.
+	EventExpiring EventType = "Expiring"
+)
+
+// DefaultExpiryWarning is how long before a certificate expires the
+// EventExpiring event is sent by default.
+const DefaultExpiryWarning = 7 * 24 * time.Hour
+
+// EventTypes are all the types of events.
+var EventTypes = []EventType{EventIssued, EventRenewed, EventFailed, EventExpiring}
+
+// Event is a certificate event, which is sent as JSON to the sinks.
+type Event struct {
+	Type EventType `json:"type"`
+	// Time is when the event happened.
+	Time time.Time `json:"time"`
+
+	Certificate CertificateReference `json:"certificate"`
+
+	// Reason and Message describe the event, for example why an issuance
+	// failed.
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// CertificateReference identifies a Certificate and its current
+// certificate.
+type CertificateReference struct {
+	Namespace  string    `json:"namespace"`
+	Name       string    `json:"name"`
+	UID        types.UID `json:"uid"`
+	SecretName string    `json:"secretName"`
+	Revision   int       `json:"revision,omitempty"`
+
+	// SerialNumber, NotBefore and NotAfter describe the certificate issued
+	// for the Certificate, if any.
+	SerialNumber string     `json:"serialNumber,omitempty"`
+	NotBefore    *time.Time `json:"notBefore,omitempty"`
+	NotAfter     *time.Time `json:"notAfter,omitempty"`
+}
+
+// NewEvent returns an event about the Certificate. x509Cert is the
+// certificate of the Certificate. If it is nil, the details of the
+// certificate are taken from the status of the Certificate.
+func NewEvent(eventType EventType, now time.Time, crt *cmapi.Certificate, x509Cert *x509.Certificate) Event {
+	ref := CertificateReference{
+		Namespace:  crt.Namespace,
+		Name:       crt.Name,
+		UID:        crt.UID,
+		SecretName: crt.Spec.SecretName,
+	}
+	if crt.Status.Revision != nil {
+		ref.Revision = *crt.Status.Revision
+	}
+	if x509Cert != nil {
+		ref.SerialNumber = x509Cert.SerialNumber.Text(16)
+		ref.NotBefore = &x509Cert.NotBefore
+		ref.NotAfter = &x509Cert.NotAfter
+	} else {
+		ref.SerialNumber = crt.Status.SerialNumber
+		if crt.Status.NotBefore != nil {
+			ref.NotBefore = &crt.Status.NotBefore.Time
+		}
+		if crt.Status.NotAfter != nil {
+			ref.NotAfter = &crt.Status.NotAfter.Time
+		}
+	}
+	return Event{
+		Type:        eventType,
+		Time:        metav1.NewTime(now).Rfc3339Copy().Time,
+		Certificate: ref,
+	}
+}
+
+// Notifier is notified of certificate events.
+type Notifier interface {
+	// Notify sends the event to the sinks. It must not block, as it is
+	// called by controllers while they process Certificates.
+	Notify(Event)
+}
+
+// Discard is a Notifier which discards all events.
+var Discard Notifier = discard{}
+
+type discard struct{}
+
+func (discard) Notify(Event) {}
@@ -0,0 +1,284 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// Format is the format of the payloads sent to a sink.
+type Format string
+
+const (
+	// FormatJSON sends the Event as JSON.
+	FormatJSON Format = "JSON"
+	// FormatCloudEvents sends a CloudEvents 1.0 event in structured content
+	// mode, with the Event as its data.
+	FormatCloudEvents Format = "CloudEvents"
+)
+
+const (
+	// SignatureHeader is the header holding the signature of the payload,
+	// as 'sha256=<hex encoded HMAC-SHA256>'.
+	SignatureHeader = "X-Cert-Manager-Signature"
+
+	// cloudEventsSource is the source of CloudEvents, identifying the
+	// producer of the events.
+	cloudEventsSource = "cert-manager.io/controller"
+	// cloudEventsTypePrefix is prepended to the lower-cased event type to
+	// build the type of CloudEvents, e.g. io.cert-manager.certificate.issued.
+	cloudEventsTypePrefix = "io.cert-manager.certificate."
+
+	defaultTimeout = 10 * time.Second
+	// queueLength is the number of events buffered for each sink. Events
+	// are dropped while the buffer is full.
+	queueLength = 256
+	// maxAttempts is the number of times an event is sent before giving up.
+	maxAttempts = 5
+)
+
+// Sink is an HTTP endpoint which events are POSTed to.
+type Sink struct {
+	Name string
+	URL  string
+	// Format defaults to FormatJSON.
+	Format Format
+	// SigningKey is the HMAC key payloads are signed with. Payloads are not
+	// signed if it is empty.
+	SigningKey []byte
+	// Events are the types of events sent to the sink. All events are sent
+	// if empty.
+	Events []EventType
+	// Timeout of each request. Defaults to 10s.
+	Timeout time.Duration
+}
+
+// Dispatcher is a Notifier which sends events to sinks in the background.
+// Events are retried with an exponential backoff when a sink is
+// unavailable, so they are delivered at least once unless the controller
+// restarts or a sink's buffer fills up.
+type Dispatcher struct {
+	log    logr.Logger
+	sinks  []*sinkWorker
+	client *http.Client
+
+	// backoff is the backoff between attempts to send an event.
+	backoff wait.Backoff
+}
+
+type sinkWorker struct {
+	Sink
+	queue chan Event
+}
+
+// NewDispatcher returns a Dispatcher sending events to the sinks. Events
+// are only sent once Run has been called.
+func NewDispatcher(log logr.Logger, sinks []Sink) *Dispatcher {
+	d := &Dispatcher{
+		log:    log.WithName("notifications"),
+		client: &http.Client{},
+		backoff: wait.Backoff{
+			Duration: time.Second,
+			Factor:   2,
+			Steps:    maxAttempts,
+		},
+	}
+	for _, sink := range sinks {
+		if sink.Format == "" {
+			sink.Format = FormatJSON
+		}
+		if sink.Timeout == 0 {
+			sink.Timeout = defaultTimeout
+		}
+		d.sinks = append(d.sinks, &sinkWorker{Sink: sink, queue: make(chan Event, queueLength)})
+	}
+	return d
+}
+
+// Notify queues the event for the sinks which accept events of its type.
+func (d *Dispatcher) Notify(event Event) {
+	for _, sink := range d.sinks {
+		if !sink.accepts(event.Type) {
+			continue
+		}
+		select {
+		case sink.queue <- event:
+		default:
+			d.log.Error(nil, "dropping event, too many events are waiting to be sent to the sink",
+				"sink", sink.Name, "type", event.Type, "namespace", event.Certificate.Namespace, "name", event.Certificate.Name)
+		}
+	}
+}
+
+// Run sends queued events to the sinks until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	done := make(chan struct{})
+	for _, sink := range d.sinks {
+		go func(sink *sinkWorker) {
+			defer func() { done <- struct{}{} }()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event := <-sink.queue:
+					d.deliver(ctx, sink, event)
+				}
+			}
+		}(sink)
+	}
+	for range d.sinks {
+		<-done
+	}
+}
+
+// deliver sends the event to the sink, retrying until it is accepted, the
+// sink rejects it, or the attempts are exhausted.
+func (d *Dispatcher) deliver(ctx context.Context, sink *sinkWorker, event Event) {
+	log := d.log.WithValues("sink", sink.Name, "type", event.Type, "namespace", event.Certificate.Namespace, "name", event.Certificate.Name)
+
+	body, contentType, err := sink.payload(event)
+	if err != nil {
+		log.Error(err, "failed to encode event")
+		return
+	}
+
+	var lastErr error
+	err = wait.ExponentialBackoffWithContext(ctx, d.backoff, func() (bool, error) {
+		retry, err := d.send(ctx, sink, body, contentType)
+		if err == nil {
+			return true, nil
+		}
+		lastErr = err
+		if !retry {
+			return false, err
+		}
+		log.V(logf.DebugLevel).Info("failed to send event, will retry", "error", err.Error())
+		return false, nil
+	})
+	if err != nil {
+		if lastErr != nil && err != lastErr {
+			err = fmt.Errorf("%w: %v", err, lastErr)
+		}
+		log.Error(err, "failed to send event")
+	}
+}
+
+// send POSTs a payload to the sink. It returns whether the request should be
+// retried if it failed.
+func (d *Dispatcher) send(ctx context.Context, sink *sinkWorker, body []byte, contentType string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, sink.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if len(sink.SigningKey) > 0 {
+		req.Header.Set(SignatureHeader, Sign(sink.SigningKey, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("sink responded with status %d", resp.StatusCode)
+	default:
+		// The sink rejected the event, which will not change by retrying.
+		return false, fmt.Errorf("sink responded with status %d", resp.StatusCode)
+	}
+}
+
+func (s *sinkWorker) accepts(eventType EventType) bool {
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, t := range s.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// cloudEvent is a CloudEvents 1.0 event in structured content mode.
+type cloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Event     `json:"data"`
+}
+
+// payload returns the body and content type of the request sending the
+// event to the sink.
+func (s *sinkWorker) payload(event Event) ([]byte, string, error) {
+	if s.Format != FormatCloudEvents {
+		body, err := json.Marshal(event)
+		return body, "application/json", err
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, "", err
+	}
+	body, err := json.Marshal(cloudEvent{
+		SpecVersion:     "1.0",
+		ID:              hex.EncodeToString(id),
+		Source:          cloudEventsSource,
+		Type:            cloudEventsTypePrefix + strings.ToLower(string(event.Type)),
+		Subject:         fmt.Sprintf("namespaces/%s/certificates/%s", event.Certificate.Namespace, event.Certificate.Name),
+		Time:            event.Time,
+		DataContentType: "application/json",
+		Data:            event,
+	})
+	return body, "application/cloudevents+json", err
+}
+
+// Sign returns the value of the signature header of a payload, which
+// receivers compare to the value they compute with their copy of the key.
+func Sign(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// request is a request received by a test sink.
+type request struct {
+	contentType string
+	signature   string
+	body        []byte
+}
+
+// testSink returns a server recording the requests it receives, and
+// responding with the given status codes in turn, then 200.
+func testSink(t *testing.T, statuses ...int) (*httptest.Server, func() []request) {
+	var lock sync.Mutex
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		requests = append(requests, request{
+			contentType: r.Header.Get("Content-Type"),
+			signature:   r.Header.Get(SignatureHeader),
+			body:        body,
+		})
+		if len(requests) <= len(statuses) {
+			w.WriteHeader(statuses[len(requests)-1])
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []request {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]request(nil), requests...)
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	crt := gen.Certificate("crt", gen.SetCertificateSecretName("crt-tls"), gen.SetCertificateRevision(2))
+	event := NewEvent(EventRenewed, time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC), crt, nil)
+
+	tests := map[string]struct {
+		sink     Sink
+		statuses []int
+		event    Event
+
+		expectedRequests    int
+		expectedContentType string
+	}{
+		"send the event as JSON": {
+			sink:                Sink{Name: "json"},
+			event:               event,
+			expectedRequests:    1,
+			expectedContentType: "application/json",
+		},
+		"send the event as a CloudEvent": {
+			sink:                Sink{Name: "cloudevents", Format: FormatCloudEvents},
+			event:               event,
+			expectedRequests:    1,
+			expectedContentType: "application/cloudevents+json",
+		},
+		"sign the payload": {
+			sink:                Sink{Name: "signed", SigningKey: []byte("key")},
+			event:               event,
+			expectedRequests:    1,
+			expectedContentType: "application/json",
+		},
+		"retry if the sink is unavailable": {
+			sink:                Sink{Name: "retry"},
+			statuses:            []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			event:               event,
+			expectedRequests:    3,
+			expectedContentType: "application/json",
+		},
+		"do not retry if the sink rejects the event": {
+			sink:                Sink{Name: "rejected"},
+			statuses:            []int{http.StatusBadRequest},
+			event:               event,
+			expectedRequests:    1,
+			expectedContentType: "application/json",
+		},
+		"do not send events of other types": {
+			sink:  Sink{Name: "filtered", Events: []EventType{EventFailed, EventExpiring}},
+			event: event,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server, requests := testSink(t, test.statuses...)
+			test.sink.URL = server.URL
+
+			d := NewDispatcher(logf.Log, []Sink{test.sink})
+			d.backoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: maxAttempts}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go d.Run(ctx)
+
+			d.Notify(test.event)
+
+			// Wait for the expected requests, and a little longer to catch
+			// unexpected ones.
+			deadline := time.Now().Add(5 * time.Second)
+			for len(requests()) < test.expectedRequests && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			time.Sleep(50 * time.Millisecond)
+
+			got := requests()
+			if len(got) != test.expectedRequests {
+				t.Fatalf("expected %d requests, got %d", test.expectedRequests, len(got))
+			}
+			for _, req := range got {
+				if req.contentType != test.expectedContentType {
+					t.Errorf("expected content type %q, got %q", test.expectedContentType, req.contentType)
+				}
+
+				expectedSignature := ""
+				if len(test.sink.SigningKey) > 0 {
+					expectedSignature = Sign(test.sink.SigningKey, req.body)
+				}
+				if req.signature != expectedSignature {
+					t.Errorf("expected signature %q, got %q", expectedSignature, req.signature)
+				}
+
+				var sent Event
+				if test.sink.Format == FormatCloudEvents {
+					var ce cloudEvent
+					if err := json.Unmarshal(req.body, &ce); err != nil {
+						t.Fatal(err)
+					}
+					if ce.SpecVersion != "1.0" || ce.Type != "io.cert-manager.certificate.renewed" || ce.Subject != "namespaces/default-unit-test-ns/certificates/crt" || ce.ID == "" {
+						t.Errorf("unexpected CloudEvent attributes: %+v", ce)
+					}
+					sent = ce.Data
+				} else if err := json.Unmarshal(req.body, &sent); err != nil {
+					t.Fatal(err)
+				}
+				if sent.Type != EventRenewed || sent.Certificate.Name != "crt" || sent.Certificate.SecretName != "crt-tls" || sent.Certificate.Revision != 2 || !sent.Time.Equal(event.Time) {
+					t.Errorf("unexpected event: %+v", sent)
+				}
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	// Computed with: echo -n '{"type":"Issued"}' | openssl dgst -sha256 -hmac key
+	const expected = "sha256=455fd461230ac1bf8682437d692d8088b5ac7c528f21f26afd78b16f2af5758c"
+	got := Sign([]byte("key"), []byte(`{"type":"Issued"}`))
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
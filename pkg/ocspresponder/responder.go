@@ -0,0 +1,606 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ocspresponder implements an OCSP responder (RFC 6960) for the
+// certificates issued by CA and SelfSigned issuers. Certificates are reported
+// as revoked if a CertificateRevocationRequest marks them as revoked, and as
+// good otherwise.
+package ocspresponder
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// maxRequestSize is the maximum size of an OCSP request. Requests for a
+// single certificate are around 100 bytes.
+const maxRequestSize = 10 * 1024
+
+var revocationReasons = map[cmapi.RevocationReason]int{
+	"":                                         ocsp.Unspecified,
+	cmapi.RevocationReasonUnspecified:          ocsp.Unspecified,
+	cmapi.RevocationReasonKeyCompromise:        ocsp.KeyCompromise,
+	cmapi.RevocationReasonCACompromise:         ocsp.CACompromise,
+	cmapi.RevocationReasonAffiliationChanged:   ocsp.AffiliationChanged,
+	cmapi.RevocationReasonSuperseded:           ocsp.Superseded,
+	cmapi.RevocationReasonCessationOfOperation: ocsp.CessationOfOperation,
+	cmapi.RevocationReasonPrivilegeWithdrawn:   ocsp.PrivilegeWithdrawn,
+}
+
+// Responder is an http.Handler which answers OCSP requests over HTTP, as
+// GET requests with the base64 encoded request in the path, or as POST
+// requests with the DER encoded request in the body. Responses are signed
+// directly by the key of the issuing CA.
+type Responder struct {
+	log logr.Logger
+
+	issuerLister      cmlisters.IssuerLister
+	clusterIssuers    cmlisters.ClusterIssuerLister
+	certificateLister cmlisters.CertificateLister
+	crrLister         cmlisters.CertificateRevocationRequestLister
+	secretLister      corelisters.SecretLister
+	helper            issuer.Helper
+	hasSynced         []cache.InformerSynced
+
+	clusterResourceNamespace string
+	// validity is how long a response may be cached for by clients, which is
+	// the longest a revocation may take to be noticed.
+	validity time.Duration
+	clock    clock.Clock
+
+	// indexed is used to build the index from the listers once the
+	// informers have synced, since event handlers may still be catching up.
+	indexed sync.Once
+
+	// lock guards the index below, which is maintained by the event
+	// handlers of the informers so that requests don't have to walk every
+	// issuer and Certificate. It is held for writing while a source is
+	// synced, so that concurrent events for the same source are applied in
+	// order.
+	lock sync.RWMutex
+	// signers holds the signer of each CA issuer and of each Certificate
+	// issued by a SelfSigned issuer.
+	signers map[signerSource]*signer
+	// byKeyHash holds the signers by the hashes of their public key.
+	byKeyHash map[keyHash][]*signer
+	// bySecret holds the sources which read each Secret, by the namespaced
+	// name of the Secret, so that they are synced when it changes, and
+	// secretOf holds the reverse.
+	bySecret map[string]map[signerSource]struct{}
+	secretOf map[signerSource]string
+}
+
+// keyHashAlgorithms are the hash algorithms which OCSP requests may use to
+// identify the key of the issuer.
+var keyHashAlgorithms = []crypto.Hash{crypto.SHA1, crypto.SHA256, crypto.SHA384, crypto.SHA512}
+
+// keyHash is the hash of the public key of an issuer, as sent in an OCSP
+// request.
+type keyHash struct {
+	hash crypto.Hash
+	sum  string
+}
+
+// signerSource is the object which a signer is read from: an Issuer or
+// ClusterIssuer with a CA, or a Certificate issued by a SelfSigned issuer.
+type signerSource struct {
+	kind, namespace, name string
+}
+
+// keyPair is a CA certificate and its key, read from a Secret.
+type keyPair struct {
+	resourceVersion string
+	cert            *x509.Certificate
+	key             crypto.Signer
+	keyHashes       []keyHash
+}
+
+// signer is an issuer whose key can sign the responses for the certificates
+// it issued.
+type signer struct {
+	// ref is the issuer that CertificateRevocationRequests reference, and
+	// namespace is the namespace they must be in.
+	ref       cmmeta.ObjectReference
+	namespace string
+
+	*keyPair
+}
+
+// New returns a Responder reading issuers, Certificates,
+// CertificateRevocationRequests and Secrets from the given informer
+// factories. The informers must be started before the Responder can answer
+// requests.
+func New(log logr.Logger, kubeFactory kubeinformers.SharedInformerFactory, cmFactory cminformers.SharedInformerFactory, namespace, clusterResourceNamespace string, validity time.Duration, clock clock.Clock) *Responder {
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	crrInformer := cmFactory.Certmanager().V1().CertificateRevocationRequests()
+	secretsInformer := controllerpkg.SecretsMetadataInformer(kubeFactory)
+
+	r := &Responder{
+		log:                      log,
+		issuerLister:             issuerInformer.Lister(),
+		certificateLister:        certificateInformer.Lister(),
+		crrLister:                crrInformer.Lister(),
+		secretLister:             controllerpkg.NewSecretGetter(kubeFactory),
+		clusterResourceNamespace: clusterResourceNamespace,
+		validity:                 validity,
+		clock:                    clock,
+		signers:                  make(map[signerSource]*signer),
+		byKeyHash:                make(map[keyHash][]*signer),
+		bySecret:                 make(map[string]map[signerSource]struct{}),
+		secretOf:                 make(map[signerSource]string),
+		hasSynced: []cache.InformerSynced{
+			issuerInformer.Informer().HasSynced,
+			certificateInformer.Informer().HasSynced,
+			crrInformer.Informer().HasSynced,
+			secretsInformer.HasSynced,
+		},
+	}
+
+	// ClusterIssuers are only watched if we are not scoped to a single
+	// namespace.
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		r.clusterIssuers = clusterIssuerInformer.Lister()
+		r.hasSynced = append(r.hasSynced, clusterIssuerInformer.Informer().HasSynced)
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: r.handleIssuer(cmapi.ClusterIssuerKind)})
+	}
+	r.helper = issuer.NewHelper(r.issuerLister, r.clusterIssuers)
+
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: r.handleIssuer(cmapi.IssuerKind)})
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: r.handleCertificate})
+	secretsInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: r.handleSecret})
+
+	return r
+}
+
+func (r *Responder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	resp, err := r.respond(req)
+	if err != nil {
+		r.log.V(logf.DebugLevel).Info("failed to answer OCSP request", "error", err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/ocsp-response")
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d, public, no-transform, must-revalidate", int(r.validity.Seconds())))
+	if _, err := w.Write(resp); err != nil {
+		r.log.V(logf.DebugLevel).Info("failed to write OCSP response", "error", err.Error())
+	}
+}
+
+// respond returns the DER encoded OCSP response to an HTTP request. OCSP
+// errors are returned as error responses, as required by RFC 6960.
+func (r *Responder) respond(httpReq *http.Request) ([]byte, error) {
+	for _, hasSynced := range r.hasSynced {
+		if !hasSynced() {
+			return ocsp.TryLaterErrorResponse, fmt.Errorf("informers have not synced yet")
+		}
+	}
+	r.indexed.Do(r.syncAll)
+
+	der, err := readRequest(httpReq)
+	if err != nil {
+		return ocsp.MalformedRequestErrorResponse, err
+	}
+	req, err := ocsp.ParseRequest(der)
+	if err != nil {
+		return ocsp.MalformedRequestErrorResponse, err
+	}
+	if !req.HashAlgorithm.Available() {
+		return ocsp.MalformedRequestErrorResponse, fmt.Errorf("unsupported hash algorithm %v", req.HashAlgorithm)
+	}
+
+	signers := r.signersFor(req)
+	if len(signers) == 0 {
+		// We are not authoritative for certificates of unknown issuers.
+		return ocsp.UnauthorizedErrorResponse, fmt.Errorf("no CA or SelfSigned issuer has the key with hash %x", req.IssuerKeyHash)
+	}
+
+	now := r.clock.Now()
+	template := ocsp.Response{
+		Status:       ocsp.Good,
+		SerialNumber: req.SerialNumber,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(r.validity),
+		IssuerHash:   req.HashAlgorithm,
+	}
+	crr, err := r.revocationFor(signers, req.SerialNumber)
+	if err != nil {
+		return ocsp.InternalErrorErrorResponse, err
+	}
+	if crr != nil {
+		template.Status = ocsp.Revoked
+		template.RevokedAt = crr.Status.RevocationTime.Time
+		template.RevocationReason = revocationReasons[crr.Spec.Reason]
+	}
+
+	resp, err := ocsp.CreateResponse(signers[0].cert, signers[0].cert, template, signers[0].key)
+	if err != nil {
+		return ocsp.InternalErrorErrorResponse, fmt.Errorf("failed to sign OCSP response: %w", err)
+	}
+	return resp, nil
+}
+
+// readRequest returns the DER encoded OCSP request sent as described in
+// RFC 6960 Appendix A.1.
+func readRequest(req *http.Request) ([]byte, error) {
+	switch req.Method {
+	case http.MethodGet:
+		// The request may have been URL encoded by the client, and some
+		// clients do not pad the base64 encoding.
+		path, err := url.PathUnescape(strings.TrimPrefix(req.URL.EscapedPath(), "/"))
+		if err != nil {
+			return nil, err
+		}
+		return base64.RawStdEncoding.DecodeString(strings.TrimRight(path, "="))
+	case http.MethodPost:
+		if ct := req.Header.Get("Content-Type"); ct != "application/ocsp-request" {
+			return nil, fmt.Errorf("unsupported content type %q", ct)
+		}
+		body, err := io.ReadAll(io.LimitReader(req.Body, maxRequestSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(body) > maxRequestSize {
+			return nil, fmt.Errorf("request larger than %d bytes", maxRequestSize)
+		}
+		return body, nil
+	default:
+		return nil, fmt.Errorf("unsupported method %s", req.Method)
+	}
+}
+
+// signersFor returns the CA and SelfSigned issuers which have the key
+// identified by the OCSP request. Several issuers may share a key, for
+// example Issuers in different namespaces using copies of the same CA.
+func (r *Responder) signersFor(req *ocsp.Request) []*signer {
+	r.lock.RLock()
+	candidates := r.byKeyHash[keyHash{hash: req.HashAlgorithm, sum: string(req.IssuerKeyHash)}]
+	r.lock.RUnlock()
+
+	var signers []*signer
+	for _, s := range candidates {
+		nameHash, _, err := issuerHashes(s.cert, req.HashAlgorithm)
+		if err == nil && bytes.Equal(nameHash, req.IssuerNameHash) {
+			signers = append(signers, s)
+		}
+	}
+	return signers
+}
+
+// handleIssuer returns an event handler which syncs the signer of an Issuer
+// or ClusterIssuer, and of the Certificates it issued.
+func (r *Responder) handleIssuer(kind string) func(obj interface{}) {
+	return func(obj interface{}) {
+		iss, err := meta.Accessor(obj)
+		if err != nil {
+			return
+		}
+		r.sync(signerSource{kind: kind, namespace: iss.GetNamespace(), name: iss.GetName()})
+
+		// The Certificates issued by the issuer are signers if it is, or
+		// was, a SelfSigned issuer.
+		lister := r.certificateLister.List
+		if kind == cmapi.IssuerKind {
+			lister = r.certificateLister.Certificates(iss.GetNamespace()).List
+		}
+		crts, err := lister(labels.Everything())
+		if err != nil {
+			return
+		}
+		for _, crt := range crts {
+			ref := crt.Spec.IssuerRef
+			refKind := ref.Kind
+			if refKind == "" {
+				refKind = cmapi.IssuerKind
+			}
+			if refKind == kind && ref.Name == iss.GetName() {
+				r.sync(signerSource{kind: cmapi.CertificateKind, namespace: crt.Namespace, name: crt.Name})
+			}
+		}
+	}
+}
+
+func (r *Responder) handleCertificate(obj interface{}) {
+	crt, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	r.sync(signerSource{kind: cmapi.CertificateKind, namespace: crt.GetNamespace(), name: crt.GetName()})
+}
+
+// handleSecret syncs the signers read from a Secret, so that they are
+// updated when their key pair changes and evicted when it is deleted.
+func (r *Responder) handleSecret(obj interface{}) {
+	secret, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	r.lock.RLock()
+	var sources []signerSource
+	for source := range r.bySecret[secret.GetNamespace()+"/"+secret.GetName()] {
+		sources = append(sources, source)
+	}
+	r.lock.RUnlock()
+
+	for _, source := range sources {
+		r.sync(source)
+	}
+}
+
+// syncAll syncs the signers of every issuer and Certificate.
+func (r *Responder) syncAll() {
+	issuers, err := r.issuerLister.List(labels.Everything())
+	if err != nil {
+		r.log.Error(err, "failed to list issuers")
+	}
+	for _, iss := range issuers {
+		r.sync(signerSource{kind: cmapi.IssuerKind, namespace: iss.Namespace, name: iss.Name})
+	}
+
+	if r.clusterIssuers != nil {
+		clusterIssuers, err := r.clusterIssuers.List(labels.Everything())
+		if err != nil {
+			r.log.Error(err, "failed to list cluster issuers")
+		}
+		for _, iss := range clusterIssuers {
+			r.sync(signerSource{kind: cmapi.ClusterIssuerKind, name: iss.Name})
+		}
+	}
+
+	crts, err := r.certificateLister.List(labels.Everything())
+	if err != nil {
+		r.log.Error(err, "failed to list certificates")
+	}
+	for _, crt := range crts {
+		r.sync(signerSource{kind: cmapi.CertificateKind, namespace: crt.Namespace, name: crt.Name})
+	}
+}
+
+// sync updates the index with the current signer of the source, if it has
+// one: the CA issuers, signing with the key of their CA, and the
+// Certificates issued by a SelfSigned issuer, which are signed with their own
+// key.
+func (r *Responder) sync(source signerSource) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var s *signer
+	var secretNamespace, secretName string
+	switch source.kind {
+	case cmapi.IssuerKind:
+		iss, err := r.issuerLister.Issuers(source.namespace).Get(source.name)
+		if err == nil && iss.Spec.CA != nil {
+			secretNamespace, secretName = iss.Namespace, iss.Spec.CA.SecretName
+			s = &signer{
+				ref:       cmmeta.ObjectReference{Name: iss.Name, Kind: cmapi.IssuerKind},
+				namespace: iss.Namespace,
+			}
+		}
+
+	case cmapi.ClusterIssuerKind:
+		if r.clusterIssuers == nil {
+			break
+		}
+		iss, err := r.clusterIssuers.Get(source.name)
+		if err == nil && iss.Spec.CA != nil {
+			secretNamespace, secretName = r.clusterResourceNamespace, iss.Spec.CA.SecretName
+			s = &signer{
+				ref:       cmmeta.ObjectReference{Name: iss.Name, Kind: cmapi.ClusterIssuerKind},
+				namespace: r.clusterResourceNamespace,
+			}
+		}
+
+	case cmapi.CertificateKind:
+		crt, err := r.certificateLister.Certificates(source.namespace).Get(source.name)
+		if err != nil {
+			break
+		}
+		ref := crt.Spec.IssuerRef
+		if ref.Group != "" && ref.Group != cmapi.SchemeGroupVersion.Group {
+			break
+		}
+		iss, err := r.helper.GetGenericIssuer(ref, crt.Namespace)
+		if err != nil || iss.GetSpec().SelfSigned == nil {
+			break
+		}
+		namespace := crt.Namespace
+		if ref.Kind == cmapi.ClusterIssuerKind {
+			namespace = r.clusterResourceNamespace
+		} else {
+			ref.Kind = cmapi.IssuerKind
+		}
+		secretNamespace, secretName = crt.Namespace, crt.Spec.SecretName
+		s = &signer{ref: ref, namespace: namespace}
+	}
+
+	previous := r.signers[source]
+	r.removeSigner(source)
+	if s == nil {
+		return
+	}
+
+	// The source is synced again when its Secret is created or changes,
+	// even if it doesn't currently hold a valid key pair.
+	secretKey := secretNamespace + "/" + secretName
+	if r.bySecret[secretKey] == nil {
+		r.bySecret[secretKey] = make(map[signerSource]struct{})
+	}
+	r.bySecret[secretKey][source] = struct{}{}
+	r.secretOf[source] = secretKey
+
+	var previousKeyPair *keyPair
+	if previous != nil {
+		previousKeyPair = previous.keyPair
+	}
+	s.keyPair = r.keyPairFor(secretNamespace, secretName, previousKeyPair)
+	if s.keyPair == nil {
+		return
+	}
+	r.signers[source] = s
+	for _, h := range s.keyHashes {
+		r.byKeyHash[h] = append(r.byKeyHash[h], s)
+	}
+}
+
+// removeSigner removes the signer of the source and its Secret from the
+// index. It must be called with the lock held.
+func (r *Responder) removeSigner(source signerSource) {
+	if secretKey, ok := r.secretOf[source]; ok {
+		delete(r.secretOf, source)
+		delete(r.bySecret[secretKey], source)
+		if len(r.bySecret[secretKey]) == 0 {
+			delete(r.bySecret, secretKey)
+		}
+	}
+
+	s, ok := r.signers[source]
+	if !ok {
+		return
+	}
+	delete(r.signers, source)
+	for _, h := range s.keyHashes {
+		signers := r.byKeyHash[h][:0]
+		for _, other := range r.byKeyHash[h] {
+			if other != s {
+				signers = append(signers, other)
+			}
+		}
+		if len(signers) == 0 {
+			delete(r.byKeyHash, h)
+		} else {
+			r.byKeyHash[h] = signers
+		}
+	}
+}
+
+// keyPairFor returns the certificate and key in a Secret, or nil if it does
+// not exist or does not contain a valid key pair. The previous key pair read
+// from the Secret is reused if the Secret hasn't changed.
+func (r *Responder) keyPairFor(namespace, name string, previous *keyPair) *keyPair {
+	secret, err := r.secretLister.Secrets(namespace).Get(name)
+	if err != nil {
+		return nil
+	}
+	if previous != nil && previous.resourceVersion == secret.ResourceVersion {
+		return previous
+	}
+
+	certs, err := pki.DecodeX509CertificateChainBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil
+	}
+	pk, err := pki.DecodePrivateKeyBytes(secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil
+	}
+	if matches, err := pki.PublicKeyMatchesCertificate(pk.Public(), certs[0]); err != nil || !matches {
+		return nil
+	}
+
+	kp := &keyPair{resourceVersion: secret.ResourceVersion, cert: certs[0], key: pk}
+	for _, hash := range keyHashAlgorithms {
+		_, sum, err := issuerHashes(kp.cert, hash)
+		if err != nil {
+			return nil
+		}
+		kp.keyHashes = append(kp.keyHashes, keyHash{hash: hash, sum: string(sum)})
+	}
+	return kp
+}
+
+// revocationFor returns the CertificateRevocationRequest which revokes the
+// certificate with the given serial number, issued by one of the signers, or
+// nil if it is not revoked.
+func (r *Responder) revocationFor(signers []*signer, serialNumber *big.Int) (*cmapi.CertificateRevocationRequest, error) {
+	for _, s := range signers {
+		crrs, err := r.crrLister.CertificateRevocationRequests(s.namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, crr := range crrs {
+			kind := crr.Spec.IssuerRef.Kind
+			if kind == "" {
+				kind = cmapi.IssuerKind
+			}
+			if kind != s.ref.Kind || crr.Spec.IssuerRef.Name != s.ref.Name {
+				continue
+			}
+			// The controller only sets the Revoked condition once it has
+			// checked the issuer, and always sets the revocation time.
+			if !apiutil.CertificateRevocationRequestIsRevoked(crr) || crr.Status.RevocationTime == nil {
+				continue
+			}
+			serial, err := pki.ParseSerialNumber(crr.Spec.SerialNumber)
+			if err != nil || serial.Cmp(serialNumber) != 0 {
+				continue
+			}
+			return crr, nil
+		}
+	}
+	return nil, nil
+}
+
+// issuerHashes returns the hashes of the subject and public key of an issuer
+// certificate, which identify it in OCSP requests.
+func issuerHashes(cert *x509.Certificate, hash crypto.Hash) ([]byte, []byte, error) {
+	var publicKeyInfo struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(cert.RawSubjectPublicKeyInfo, &publicKeyInfo); err != nil {
+		return nil, nil, err
+	}
+
+	h := hash.New()
+	h.Write(cert.RawSubject)
+	nameHash := h.Sum(nil)
+
+	h.Reset()
+	h.Write(publicKeyInfo.PublicKey.RightAlign())
+	return nameHash, h.Sum(nil), nil
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocspresponder
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// keyPairSecret returns a Secret containing a self-signed CA certificate and
+// its key, and the certificate.
+func keyPairSecret(t *testing.T, namespace, name, commonName string) (*corev1.Secret, *x509.Certificate, crypto.Signer) {
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	certPEM, cert, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodePKCS8PrivateKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, ResourceVersion: "1"},
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	}, cert, pk
+}
+
+// leaf returns a certificate with the given serial number signed by the CA.
+func leaf(t *testing.T, ca *x509.Certificate, caKey crypto.Signer, serial int64) *x509.Certificate {
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	_, cert, err := pki.SignCertificate(template, ca, pk.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func revocation(namespace string, ref cmmeta.ObjectReference, serial string, revokedAt *metav1.Time) *cmapi.CertificateRevocationRequest {
+	crr := &cmapi.CertificateRevocationRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "revoke-" + serial},
+		Spec: cmapi.CertificateRevocationRequestSpec{
+			IssuerRef:    ref,
+			SerialNumber: serial,
+			Reason:       cmapi.RevocationReasonKeyCompromise,
+		},
+	}
+	if revokedAt != nil {
+		crr.Status = cmapi.CertificateRevocationRequestStatus{
+			Conditions: []cmapi.CertificateRevocationRequestCondition{{
+				Type:   cmapi.CertificateRevocationRequestConditionRevoked,
+				Status: cmmeta.ConditionTrue,
+			}},
+			RevocationTime: revokedAt,
+		}
+	}
+	return crr
+}
+
+func TestResponder(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	revokedAt := metav1.NewTime(now.Add(-time.Hour))
+	clock := fakeclock.NewFakeClock(now)
+
+	caSecret, caCert, caKey := keyPairSecret(t, "testns", "ca", "CA")
+	clusterCASecret, clusterCACert, clusterCAKey := keyPairSecret(t, "cert-manager", "cluster-ca", "Cluster CA")
+	selfSignedSecret, selfSignedCert, _ := keyPairSecret(t, "testns", "selfsigned-tls", "Self-signed")
+	_, unknownCACert, unknownCAKey := keyPairSecret(t, "testns", "unknown", "Unknown CA")
+
+	kubeObjects := []runtime.Object{caSecret, clusterCASecret, selfSignedSecret}
+	cmObjects := []runtime.Object{
+		gen.Issuer("ca", gen.SetIssuerNamespace("testns"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"})),
+		gen.ClusterIssuer("cluster-ca", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "cluster-ca"})),
+		gen.Issuer("selfsigned", gen.SetIssuerNamespace("testns"), gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})),
+		gen.Certificate("selfsigned", gen.SetCertificateNamespace("testns"), gen.SetCertificateSecretName("selfsigned-tls"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "selfsigned"})),
+		revocation("testns", cmmeta.ObjectReference{Name: "ca"}, "0a", &revokedAt),
+		revocation("testns", cmmeta.ObjectReference{Name: "ca"}, "0b", nil),
+		revocation("cert-manager", cmmeta.ObjectReference{Name: "cluster-ca", Kind: "ClusterIssuer"}, "0c", &revokedAt),
+		// Only revocations in the cluster resource namespace apply to
+		// ClusterIssuers.
+		revocation("testns", cmmeta.ObjectReference{Name: "cluster-ca", Kind: "ClusterIssuer"}, "0d", &revokedAt),
+		revocation("testns", cmmeta.ObjectReference{Name: "selfsigned"}, "01", &revokedAt),
+	}
+
+	kubeFactory := kubeinformers.NewSharedInformerFactory(kubefake.NewSimpleClientset(kubeObjects...), 0)
+	cmFactory := cminformers.NewSharedInformerFactory(cmfake.NewSimpleClientset(cmObjects...), 0)
+	r := New(logf.Log, kubeFactory, cmFactory, "", "cert-manager", time.Hour, clock)
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	tests := map[string]struct {
+		cert, issuer *x509.Certificate
+		useGET       bool
+
+		wantStatus int
+		wantError  error
+	}{
+		"a certificate issued by a CA Issuer which is not revoked is good": {
+			cert: leaf(t, caCert, caKey, 0x09), issuer: caCert,
+			wantStatus: ocsp.Good,
+		},
+		"a certificate issued by a CA Issuer which is revoked is revoked": {
+			cert: leaf(t, caCert, caKey, 0x0a), issuer: caCert,
+			wantStatus: ocsp.Revoked,
+		},
+		"a revoked certificate is reported for GET requests": {
+			cert: leaf(t, caCert, caKey, 0x0a), issuer: caCert, useGET: true,
+			wantStatus: ocsp.Revoked,
+		},
+		"a certificate is not revoked until the controller marks it as revoked": {
+			cert: leaf(t, caCert, caKey, 0x0b), issuer: caCert,
+			wantStatus: ocsp.Good,
+		},
+		"a certificate issued by a CA ClusterIssuer is revoked from the cluster resource namespace": {
+			cert: leaf(t, clusterCACert, clusterCAKey, 0x0c), issuer: clusterCACert,
+			wantStatus: ocsp.Revoked,
+		},
+		"a certificate issued by a CA ClusterIssuer cannot be revoked from another namespace": {
+			cert: leaf(t, clusterCACert, clusterCAKey, 0x0d), issuer: clusterCACert,
+			wantStatus: ocsp.Good,
+		},
+		"a certificate issued by a SelfSigned Issuer is revoked": {
+			cert: selfSignedCert, issuer: selfSignedCert,
+			wantStatus: ocsp.Revoked,
+		},
+		"a certificate of an unknown issuer is rejected": {
+			cert: leaf(t, unknownCACert, unknownCAKey, 0x0a), issuer: unknownCACert,
+			wantError: ocsp.ResponseError{Status: ocsp.Unauthorized},
+		},
+	}
+
+	// Requests are answered with tryLater until the informers have synced.
+	req, err := ocsp.CreateRequest(leaf(t, caCert, caKey, 0x09), caCert, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ocsp.ParseResponse(post(t, server.URL, req), caCert); err != (ocsp.ResponseError{Status: ocsp.TryLater}) {
+		t.Fatalf("expected tryLater before the informers have synced, got %v", err)
+	}
+
+	kubeFactory.Start(stopCh)
+	cmFactory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, r.hasSynced...) {
+		t.Fatal("informers did not sync")
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := ocsp.CreateRequest(test.cert, test.issuer, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var body []byte
+			if test.useGET {
+				body = get(t, server.URL+"/"+base64.StdEncoding.EncodeToString(req))
+			} else {
+				body = post(t, server.URL, req)
+			}
+
+			resp, err := ocsp.ParseResponseForCert(body, test.cert, test.issuer)
+			if test.wantError != nil {
+				if err != test.wantError {
+					t.Fatalf("expected error %v, got %v", test.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Status != test.wantStatus {
+				t.Errorf("expected status %d, got %d", test.wantStatus, resp.Status)
+			}
+			if !resp.ThisUpdate.Equal(now) || !resp.NextUpdate.Equal(now.Add(time.Hour)) {
+				t.Errorf("unexpected validity %v - %v", resp.ThisUpdate, resp.NextUpdate)
+			}
+			if resp.Status == ocsp.Revoked {
+				if !resp.RevokedAt.Equal(revokedAt.Time) {
+					t.Errorf("expected revocation time %v, got %v", revokedAt.Time, resp.RevokedAt)
+				}
+				if resp.RevocationReason != ocsp.KeyCompromise {
+					t.Errorf("expected reason keyCompromise, got %d", resp.RevocationReason)
+				}
+			}
+		})
+	}
+
+	t.Run("a malformed request is rejected", func(t *testing.T) {
+		if _, err := ocsp.ParseResponse(post(t, server.URL, []byte("not a request")), caCert); err != (ocsp.ResponseError{Status: ocsp.Malformed}) {
+			t.Errorf("expected malformed error, got %v", err)
+		}
+	})
+}
+
+func TestResponderEvictsSigners(t *testing.T) {
+	caSecret, caCert, caKey := keyPairSecret(t, "testns", "ca", "CA")
+	kubeClient := kubefake.NewSimpleClientset(caSecret)
+	cmClient := cmfake.NewSimpleClientset(
+		gen.Issuer("ca", gen.SetIssuerNamespace("testns"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"})),
+	)
+	kubeFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	cmFactory := cminformers.NewSharedInformerFactory(cmClient, 0)
+	r := New(logf.Log, kubeFactory, cmFactory, "", "cert-manager", time.Hour, fakeclock.NewFakeClock(time.Now()))
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	kubeFactory.Start(stopCh)
+	cmFactory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, r.hasSynced...) {
+		t.Fatal("informers did not sync")
+	}
+
+	req, err := ocsp.CreateRequest(leaf(t, caCert, caKey, 0x09), caCert, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// waitFor waits until the responder answers the request with the
+	// expected error, as the index is updated asynchronously.
+	waitFor := func(want error) {
+		t.Helper()
+		err := wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+			_, err := ocsp.ParseResponse(post(t, server.URL, req), caCert)
+			return err == want, nil
+		})
+		if err != nil {
+			t.Fatalf("responder did not answer with %v", want)
+		}
+	}
+	unauthorized := ocsp.ResponseError{Status: ocsp.Unauthorized}
+
+	waitFor(nil)
+
+	ctx := context.Background()
+	if err := kubeClient.CoreV1().Secrets("testns").Delete(ctx, "ca", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(unauthorized)
+
+	if _, err := kubeClient.CoreV1().Secrets("testns").Create(ctx, caSecret, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(nil)
+
+	if err := cmClient.CertmanagerV1().Issuers("testns").Delete(ctx, "ca", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(unauthorized)
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if len(r.signers) != 0 || len(r.byKeyHash) != 0 || len(r.bySecret) != 0 || len(r.secretOf) != 0 {
+		t.Errorf("expected the index to be empty once the issuer is deleted, got %d signers, %d key hashes and %d secrets",
+			len(r.signers), len(r.byKeyHash), len(r.bySecret))
+	}
+}
+
+func post(t *testing.T, url string, req []byte) []byte {
+	resp, err := http.Post(url, "application/ocsp-request", bytes.NewReader(req))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return readResponse(t, resp)
+}
+
+func get(t *testing.T, url string) []byte {
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return readResponse(t, resp)
+}
+
+func readResponse(t *testing.T, resp *http.Response) []byte {
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/ocsp-response" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
@@ -0,0 +1,429 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+// This file contains the parts of the experimental composite key support
+// which don't depend on the Go version. Composite keys combine an ML-DSA-65
+// key with an ECDSA P-256 key, and produce signatures which are only valid
+// if both component signatures are, as described in
+// https://datatracker.ietf.org/doc/draft-ietf-lamps-pq-composite-sigs/
+//
+// The crypto/x509 package does not support composite keys, so certificates
+// and CSRs are first created with a throwaway ECDSA placeholder key, after
+// which the public key and signature algorithm are replaced, and the result is
+// signed again.
+
+// oidSignatureMLDSA65ECDSAP256SHA512 identifies both the composite public key
+// algorithm and the composite signature algorithm.
+var oidSignatureMLDSA65ECDSAP256SHA512 = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 6, 45}
+
+var errCompositeKeysDisabled = errors.New("feature gate ExperimentalCompositeKeys must be enabled to use composite private keys")
+
+// compositePrivateKey is implemented by composite private keys.
+type compositePrivateKey interface {
+	crypto.Signer
+
+	// marshalPKCS8PrivateKey returns the PKCS#8 DER encoding of the key.
+	marshalPKCS8PrivateKey() ([]byte, error)
+}
+
+// compositePublicKey is implemented by composite public keys.
+type compositePublicKey interface {
+	Equal(crypto.PublicKey) bool
+
+	// marshalPKIXPublicKey returns the DER encoded SubjectPublicKeyInfo of the
+	// key.
+	marshalPKIXPublicKey() ([]byte, error)
+
+	// verify returns an error if signature isn't a valid composite signature
+	// of the signed message.
+	verify(signed, signature []byte) error
+}
+
+// IsCompositePrivateKey returns true if the given private key is an
+// experimental composite key, as generated for the `MLDSA65-ECDSA-P256`
+// private key algorithm.
+func IsCompositePrivateKey(pk crypto.PrivateKey) bool {
+	_, ok := pk.(compositePrivateKey)
+	return ok
+}
+
+func compositeKeysEnabled() bool {
+	return utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalCompositeKeys)
+}
+
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type publicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// signedObject is the outer structure shared by certificates and CSRs.
+type signedObject struct {
+	TBS                asn1.RawValue
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          asn1.BitString
+}
+
+type tbsCertificate struct {
+	Version            int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber       *big.Int
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Issuer             asn1.RawValue
+	Validity           asn1.RawValue
+	Subject            asn1.RawValue
+	PublicKey          asn1.RawValue
+	UniqueID           asn1.BitString   `asn1:"optional,tag:1"`
+	SubjectUniqueID    asn1.BitString   `asn1:"optional,tag:2"`
+	Extensions         []pkix.Extension `asn1:"omitempty,optional,explicit,tag:3"`
+}
+
+type tbsCertificateRequest struct {
+	Version       int
+	Subject       asn1.RawValue
+	PublicKey     asn1.RawValue
+	RawAttributes []asn1.RawValue `asn1:"tag:0"`
+}
+
+func marshalCompositePKCS8PrivateKey(key []byte) ([]byte, error) {
+	return asn1.Marshal(pkcs8{
+		Algo:       pkix.AlgorithmIdentifier{Algorithm: oidSignatureMLDSA65ECDSAP256SHA512},
+		PrivateKey: key,
+	})
+}
+
+func marshalCompositePKIXPublicKey(key []byte) ([]byte, error) {
+	return asn1.Marshal(publicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidSignatureMLDSA65ECDSAP256SHA512},
+		PublicKey: asn1.BitString{Bytes: key, BitLength: 8 * len(key)},
+	})
+}
+
+// parseCompositePKCS8PrivateKey parses a PKCS#8 DER encoded composite private
+// key. The returned bool is false if the key isn't a composite key, in which
+// case it should be parsed by crypto/x509.
+func parseCompositePKCS8PrivateKey(der []byte) (crypto.Signer, bool, error) {
+	var key pkcs8
+	if _, err := asn1.Unmarshal(der, &key); err != nil || !key.Algo.Algorithm.Equal(oidSignatureMLDSA65ECDSAP256SHA512) {
+		return nil, false, nil
+	}
+
+	signer, err := parseCompositePrivateKey(key.PrivateKey)
+	return signer, true, err
+}
+
+// parseCompositePublicKey parses a DER encoded SubjectPublicKeyInfo containing
+// a composite public key.
+func parseCompositePublicKey(der []byte) (compositePublicKey, error) {
+	var info publicKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after public key")
+	}
+	if !info.Algorithm.Algorithm.Equal(oidSignatureMLDSA65ECDSAP256SHA512) {
+		return nil, fmt.Errorf("unsupported public key algorithm: %s", info.Algorithm.Algorithm)
+	}
+
+	return newCompositePublicKey(info.PublicKey.RightAlign())
+}
+
+// certificatePublicKey returns the public key of the given certificate,
+// parsing composite public keys which crypto/x509 leaves unset.
+func certificatePublicKey(crt *x509.Certificate) (crypto.PublicKey, error) {
+	if crt.PublicKey != nil {
+		return crt.PublicKey, nil
+	}
+	pub, err := parseCompositePublicKey(crt.RawSubjectPublicKeyInfo)
+	if err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+// certificateRequestPublicKey returns the public key of the given CSR,
+// parsing composite public keys which crypto/x509 leaves unset.
+func certificateRequestPublicKey(csr *x509.CertificateRequest) (crypto.PublicKey, error) {
+	if csr.PublicKey != nil {
+		return csr.PublicKey, nil
+	}
+	pub, err := parseCompositePublicKey(csr.RawSubjectPublicKeyInfo)
+	if err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+// checkSignatureFrom is like cert.CheckSignatureFrom(parent), but also
+// verifies composite signatures.
+func checkSignatureFrom(cert, parent *x509.Certificate) error {
+	if cert.SignatureAlgorithm != x509.UnknownSignatureAlgorithm {
+		return cert.CheckSignatureFrom(parent)
+	}
+
+	// These are the same constraints as checked by crypto/x509.
+	if parent.Version == 3 && !parent.BasicConstraintsValid ||
+		parent.BasicConstraintsValid && !parent.IsCA {
+		return x509.ConstraintViolationError{}
+	}
+	if parent.KeyUsage != 0 && parent.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return x509.ConstraintViolationError{}
+	}
+
+	return checkCompositeSignature(cert.Raw, parent.RawSubjectPublicKeyInfo)
+}
+
+// checkCertificateRequestSignature is like csr.CheckSignature(), but also
+// verifies composite signatures.
+func checkCertificateRequestSignature(csr *x509.CertificateRequest) error {
+	if csr.SignatureAlgorithm != x509.UnknownSignatureAlgorithm {
+		return csr.CheckSignature()
+	}
+
+	return checkCompositeSignature(csr.Raw, csr.RawSubjectPublicKeyInfo)
+}
+
+func checkCompositeSignature(der, signerPublicKeyInfo []byte) error {
+	var obj signedObject
+	if _, err := asn1.Unmarshal(der, &obj); err != nil {
+		return err
+	}
+	if !obj.SignatureAlgorithm.Algorithm.Equal(oidSignatureMLDSA65ECDSAP256SHA512) {
+		return x509.ErrUnsupportedAlgorithm
+	}
+
+	pub, err := parseCompositePublicKey(signerPublicKeyInfo)
+	if err != nil {
+		return err
+	}
+
+	return pub.verify(obj.TBS.FullBytes, obj.Signature.RightAlign())
+}
+
+// createCertificate is like x509.CreateCertificate, but also supports
+// composite keys, both as the public key of the certificate and as the key
+// signing it.
+func createCertificate(template, parent *x509.Certificate, publicKey crypto.PublicKey, signerKey interface{}) ([]byte, error) {
+	compositePub, isCompositePub := publicKey.(compositePublicKey)
+	compositeSigner, isCompositeSigner := signerKey.(compositePrivateKey)
+	if !isCompositePub && !isCompositeSigner {
+		return x509.CreateCertificate(rand.Reader, template, parent, publicKey, signerKey)
+	}
+	if !compositeKeysEnabled() {
+		return nil, errCompositeKeysDisabled
+	}
+
+	placeholder, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := *template
+	if parent == template {
+		parent = &tmpl
+	} else {
+		parentCopy := *parent
+		parent = &parentCopy
+	}
+
+	var publicKeyInfoDER []byte
+	if isCompositePub {
+		publicKeyInfoDER, err = compositePub.marshalPKIXPublicKey()
+		if err != nil {
+			return nil, err
+		}
+
+		// crypto/x509 would otherwise derive the subject key identifier of CA
+		// certificates from the placeholder key.
+		if len(tmpl.SubjectKeyId) == 0 && tmpl.IsCA {
+			skid := sha1.Sum(compositePublicKeyBytes(publicKeyInfoDER))
+			tmpl.SubjectKeyId = skid[:]
+		}
+
+		publicKey = placeholder.Public()
+	}
+
+	signer, ok := signerKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type: %T", signerKey)
+	}
+	if isCompositeSigner {
+		// crypto/x509 checks that the signer matches the parent's public key,
+		// which the placeholder never does.
+		parent.PublicKey = nil
+		tmpl.SignatureAlgorithm = x509.UnknownSignatureAlgorithm
+		signerKey = placeholder
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, parent, publicKey, signerKey)
+	if err != nil {
+		return nil, err
+	}
+	placeholderCert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	var tbs tbsCertificate
+	if _, err := asn1.Unmarshal(placeholderCert.RawTBSCertificate, &tbs); err != nil {
+		return nil, err
+	}
+	if isCompositePub {
+		tbs.PublicKey = asn1.RawValue{FullBytes: publicKeyInfoDER}
+	}
+
+	signatureAlgorithm := placeholderCert.SignatureAlgorithm
+	if isCompositeSigner {
+		signer = compositeSigner
+		signatureAlgorithm = x509.UnknownSignatureAlgorithm
+		tbs.SignatureAlgorithm = pkix.AlgorithmIdentifier{Algorithm: oidSignatureMLDSA65ECDSAP256SHA512}
+	}
+
+	return signAndMarshal(signer, signatureAlgorithm, tbs.SignatureAlgorithm, tbs)
+}
+
+// createCertificateRequest is like x509.CreateCertificateRequest, but also
+// supports composite keys.
+func createCertificateRequest(template *x509.CertificateRequest, key crypto.Signer) ([]byte, error) {
+	compositeKey, ok := key.(compositePrivateKey)
+	if !ok {
+		return x509.CreateCertificateRequest(rand.Reader, template, key)
+	}
+	if !compositeKeysEnabled() {
+		return nil, errCompositeKeysDisabled
+	}
+
+	placeholder, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := *template
+	tmpl.SignatureAlgorithm = x509.UnknownSignatureAlgorithm
+	der, err := x509.CreateCertificateRequest(rand.Reader, &tmpl, placeholder)
+	if err != nil {
+		return nil, err
+	}
+	placeholderCSR, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return nil, err
+	}
+
+	var tbs tbsCertificateRequest
+	if _, err := asn1.Unmarshal(placeholderCSR.RawTBSCertificateRequest, &tbs); err != nil {
+		return nil, err
+	}
+
+	compositePub, ok := compositeKey.Public().(compositePublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type: %T", compositeKey.Public())
+	}
+	publicKeyInfoDER, err := compositePub.marshalPKIXPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	tbs.PublicKey = asn1.RawValue{FullBytes: publicKeyInfoDER}
+
+	return signAndMarshal(compositeKey, x509.UnknownSignatureAlgorithm, pkix.AlgorithmIdentifier{Algorithm: oidSignatureMLDSA65ECDSAP256SHA512}, tbs)
+}
+
+// signAndMarshal encodes tbs, signs it with the given signer and returns the
+// DER encoded signed object. signatureAlgorithm is used to determine how
+// classical signers hash the message, and is ignored for composite signers
+// which sign the message itself.
+func signAndMarshal(signer crypto.Signer, signatureAlgorithm x509.SignatureAlgorithm, algorithmIdentifier pkix.AlgorithmIdentifier, tbs interface{}) ([]byte, error) {
+	tbsDER, err := asn1.Marshal(tbs)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := signerOptsForAlgorithm(signatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	signed := tbsDER
+	if hash := opts.HashFunc(); hash != 0 {
+		h := hash.New()
+		h.Write(tbsDER)
+		signed = h.Sum(nil)
+	}
+
+	signature, err := signer.Sign(rand.Reader, signed, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(signedObject{
+		TBS:                asn1.RawValue{FullBytes: tbsDER},
+		SignatureAlgorithm: algorithmIdentifier,
+		Signature:          asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)},
+	})
+}
+
+func signerOptsForAlgorithm(signatureAlgorithm x509.SignatureAlgorithm) (crypto.SignerOpts, error) {
+	switch signatureAlgorithm {
+	case x509.UnknownSignatureAlgorithm, x509.PureEd25519:
+		return crypto.Hash(0), nil
+	case x509.SHA256WithRSA, x509.ECDSAWithSHA256:
+		return crypto.SHA256, nil
+	case x509.SHA384WithRSA, x509.ECDSAWithSHA384:
+		return crypto.SHA384, nil
+	case x509.SHA512WithRSA, x509.ECDSAWithSHA512:
+		return crypto.SHA512, nil
+	case x509.SHA256WithRSAPSS:
+		return &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}, nil
+	case x509.SHA384WithRSAPSS:
+		return &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA384}, nil
+	case x509.SHA512WithRSAPSS:
+		return &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA512}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", signatureAlgorithm)
+	}
+}
+
+// compositePublicKeyBytes returns the contents of the public key bit string
+// of the given DER encoded SubjectPublicKeyInfo.
+func compositePublicKeyBytes(publicKeyInfoDER []byte) []byte {
+	var info publicKeyInfo
+	if _, err := asn1.Unmarshal(publicKeyInfoDER, &info); err != nil {
+		return nil
+	}
+	return info.PublicKey.RightAlign()
+}
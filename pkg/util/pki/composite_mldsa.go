@@ -0,0 +1,198 @@
+//go:build go1.27
+
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/mldsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"errors"
+	"io"
+)
+
+const (
+	// mldsaSeedSize is the size of the ML-DSA private key seed, which is
+	// used as the encoding of the ML-DSA component of composite private keys.
+	mldsaSeedSize = 32
+
+	// compositeSignaturePrefix is prepended to the message signed by both
+	// components of a composite key.
+	compositeSignaturePrefix = "CompositeAlgorithmSignatures2025"
+
+	// compositeSignatureLabel identifies the composite algorithm in signed
+	// messages, and is used as the ML-DSA context string.
+	compositeSignatureLabel = "COMPSIG-MLDSA65-ECDSA-P256-SHA512"
+)
+
+// mldsa65ECDSAP256PrivateKey is a composite ML-DSA-65 and ECDSA P-256 private
+// key.
+type mldsa65ECDSAP256PrivateKey struct {
+	mldsa *mldsa.PrivateKey
+	ecdsa *ecdsa.PrivateKey
+}
+
+// mldsa65ECDSAP256PublicKey is a composite ML-DSA-65 and ECDSA P-256 public
+// key.
+type mldsa65ECDSAP256PublicKey struct {
+	mldsa *mldsa.PublicKey
+	ecdsa *ecdsa.PublicKey
+}
+
+func generateCompositePrivateKey() (crypto.Signer, error) {
+	mldsaKey, err := mldsa.GenerateKey(mldsa.MLDSA65())
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mldsa65ECDSAP256PrivateKey{mldsa: mldsaKey, ecdsa: ecdsaKey}, nil
+}
+
+// parseCompositePrivateKey parses the concatenation of the ML-DSA seed and
+// the DER encoded ECDSA private key.
+func parseCompositePrivateKey(der []byte) (crypto.Signer, error) {
+	if len(der) <= mldsaSeedSize {
+		return nil, errors.New("composite private key is too short")
+	}
+
+	mldsaKey, err := mldsa.NewPrivateKey(mldsa.MLDSA65(), der[:mldsaSeedSize])
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, err := x509.ParseECPrivateKey(der[mldsaSeedSize:])
+	if err != nil {
+		return nil, err
+	}
+	if ecdsaKey.Curve != elliptic.P256() {
+		return nil, errors.New("composite private key has an ECDSA component which doesn't use the P-256 curve")
+	}
+
+	return &mldsa65ECDSAP256PrivateKey{mldsa: mldsaKey, ecdsa: ecdsaKey}, nil
+}
+
+// newCompositePublicKey parses the concatenation of the ML-DSA public key and
+// the uncompressed ECDSA public point.
+func newCompositePublicKey(key []byte) (compositePublicKey, error) {
+	if len(key) <= mldsa.MLDSA65PublicKeySize {
+		return nil, errors.New("composite public key is too short")
+	}
+
+	mldsaKey, err := mldsa.NewPublicKey(mldsa.MLDSA65(), key[:mldsa.MLDSA65PublicKeySize])
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), key[mldsa.MLDSA65PublicKeySize:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &mldsa65ECDSAP256PublicKey{mldsa: mldsaKey, ecdsa: ecdsaKey}, nil
+}
+
+// compositeMessage returns the message signed by both components, which
+// binds the signature to the composite algorithm.
+func compositeMessage(message []byte) []byte {
+	digest := sha512.Sum512(message)
+
+	m := make([]byte, 0, len(compositeSignaturePrefix)+len(compositeSignatureLabel)+1+len(digest))
+	m = append(m, compositeSignaturePrefix...)
+	m = append(m, compositeSignatureLabel...)
+	// An empty context string is always used.
+	m = append(m, 0)
+	return append(m, digest[:]...)
+}
+
+func (k *mldsa65ECDSAP256PrivateKey) Public() crypto.PublicKey {
+	return &mldsa65ECDSAP256PublicKey{
+		mldsa: k.mldsa.PublicKey(),
+		ecdsa: &k.ecdsa.PublicKey,
+	}
+}
+
+// Sign signs the given message, which must not be hashed.
+func (k *mldsa65ECDSAP256PrivateKey) Sign(random io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts != nil && opts.HashFunc() != 0 {
+		return nil, errors.New("composite signatures must be created over the unhashed message")
+	}
+
+	m := compositeMessage(message)
+	mldsaSignature, err := k.mldsa.Sign(random, m, &mldsa.Options{Context: compositeSignatureLabel})
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(m)
+	ecdsaSignature, err := ecdsa.SignASN1(random, k.ecdsa, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return append(mldsaSignature, ecdsaSignature...), nil
+}
+
+func (k *mldsa65ECDSAP256PrivateKey) marshalPKCS8PrivateKey() ([]byte, error) {
+	ecdsaKey, err := x509.MarshalECPrivateKey(k.ecdsa)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalCompositePKCS8PrivateKey(append(k.mldsa.Bytes(), ecdsaKey...))
+}
+
+func (k *mldsa65ECDSAP256PublicKey) Equal(x crypto.PublicKey) bool {
+	other, ok := x.(*mldsa65ECDSAP256PublicKey)
+	if !ok {
+		return false
+	}
+
+	return k.mldsa.Equal(other.mldsa) && k.ecdsa.Equal(other.ecdsa)
+}
+
+func (k *mldsa65ECDSAP256PublicKey) marshalPKIXPublicKey() ([]byte, error) {
+	ecdsaKey, err := k.ecdsa.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalCompositePKIXPublicKey(append(k.mldsa.Bytes(), ecdsaKey...))
+}
+
+func (k *mldsa65ECDSAP256PublicKey) verify(signed, signature []byte) error {
+	if len(signature) <= mldsa.MLDSA65SignatureSize {
+		return errors.New("composite signature is too short")
+	}
+
+	m := compositeMessage(signed)
+	if err := mldsa.Verify(k.mldsa, m, signature[:mldsa.MLDSA65SignatureSize], &mldsa.Options{Context: compositeSignatureLabel}); err != nil {
+		return err
+	}
+	digest := sha256.Sum256(m)
+	if !ecdsa.VerifyASN1(k.ecdsa, digest[:], signature[mldsa.MLDSA65SignatureSize:]) {
+		return errors.New("ECDSA verification failure")
+	}
+
+	return nil
+}
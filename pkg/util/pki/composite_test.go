@@ -0,0 +1,170 @@
+//go:build go1.27
+
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+func certificateWithKeyAlgorithm(commonName string, isCA bool, algorithm v1.PrivateKeyAlgorithm) *v1.Certificate {
+	return &v1.Certificate{
+		Spec: v1.CertificateSpec{
+			CommonName: commonName,
+			IsCA:       isCA,
+			PrivateKey: &v1.CertificatePrivateKey{Algorithm: algorithm},
+		},
+	}
+}
+
+func TestGenerateCompositePrivateKeyFeatureGate(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.ExperimentalCompositeKeys, false)()
+
+	_, err := GeneratePrivateKeyForCertificate(certificateWithKeyAlgorithm("composite", false, v1.MLDSA65ECDSAP256KeyAlgorithm))
+	assert.ErrorIs(t, err, errCompositeKeysDisabled)
+}
+
+func TestCompositePrivateKeyEncoding(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.ExperimentalCompositeKeys, true)()
+
+	pk, err := GeneratePrivateKeyForCertificate(certificateWithKeyAlgorithm("composite", false, v1.MLDSA65ECDSAP256KeyAlgorithm))
+	require.NoError(t, err)
+	assert.True(t, IsCompositePrivateKey(pk))
+
+	for _, encoding := range []v1.PrivateKeyEncoding{v1.PKCS1, v1.PKCS8} {
+		keyPEM, err := EncodePrivateKey(pk, encoding)
+		require.NoError(t, err)
+
+		decoded, err := DecodePrivateKeyBytes(keyPEM)
+		require.NoError(t, err)
+
+		equal, err := PublicKeysEqual(pk.Public(), decoded.Public())
+		require.NoError(t, err)
+		assert.True(t, equal, "decoded %s key does not match the encoded key", encoding)
+	}
+}
+
+func TestCompositeSigning(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.ExperimentalCompositeKeys, true)()
+
+	tests := map[string]struct {
+		caAlgorithm, leafAlgorithm v1.PrivateKeyAlgorithm
+	}{
+		"composite CA signing a composite leaf": {
+			caAlgorithm:   v1.MLDSA65ECDSAP256KeyAlgorithm,
+			leafAlgorithm: v1.MLDSA65ECDSAP256KeyAlgorithm,
+		},
+		"composite CA signing an ECDSA leaf": {
+			caAlgorithm:   v1.MLDSA65ECDSAP256KeyAlgorithm,
+			leafAlgorithm: v1.ECDSAKeyAlgorithm,
+		},
+		"RSA CA signing a composite leaf": {
+			caAlgorithm:   v1.RSAKeyAlgorithm,
+			leafAlgorithm: v1.MLDSA65ECDSAP256KeyAlgorithm,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Self-sign the CA, as the SelfSigned issuer would
+			caCrt := certificateWithKeyAlgorithm("ca", true, test.caAlgorithm)
+			caPK, err := GeneratePrivateKeyForCertificate(caCrt)
+			require.NoError(t, err)
+			caTemplate, err := GenerateTemplate(caCrt)
+			require.NoError(t, err)
+			_, caCert, err := SignCertificate(caTemplate, caTemplate, caPK.Public(), caPK)
+			require.NoError(t, err)
+			assert.True(t, isSelfSignedCertificate(caCert))
+			assert.NotEmpty(t, caCert.SubjectKeyId)
+
+			// Request and sign the leaf, as the CA issuer would
+			leafCrt := certificateWithKeyAlgorithm("leaf", false, test.leafAlgorithm)
+			leafPK, err := GeneratePrivateKeyForCertificate(leafCrt)
+			require.NoError(t, err)
+			csrTemplate, err := GenerateCSR(leafCrt)
+			require.NoError(t, err)
+			csrDER, err := EncodeCSR(csrTemplate, leafPK)
+			require.NoError(t, err)
+			csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+			csr, err := DecodeX509CertificateRequestBytes(csrPEM)
+			require.NoError(t, err)
+			matches, err := PublicKeyMatchesCSR(leafPK.Public(), csr)
+			require.NoError(t, err)
+			assert.True(t, matches)
+
+			leafTemplate, err := GenerateTemplateFromCSRPEM(csrPEM, time.Hour, false)
+			require.NoError(t, err)
+			bundle, err := SignCSRTemplate([]*x509.Certificate{caCert}, caPK, leafTemplate)
+			require.NoError(t, err)
+
+			leafCert, err := DecodeX509CertificateBytes(bundle.ChainPEM)
+			require.NoError(t, err)
+			assert.Equal(t, "leaf", leafCert.Subject.CommonName)
+			assert.Equal(t, caCert.SubjectKeyId, leafCert.AuthorityKeyId)
+			require.NoError(t, checkSignatureFrom(leafCert, caCert))
+			matches, err = PublicKeyMatchesCertificate(leafPK.Public(), leafCert)
+			require.NoError(t, err)
+			assert.True(t, matches)
+			assert.Equal(t, caCert.Raw, mustDecodeCertificate(t, bundle.CAPEM).Raw)
+		})
+	}
+}
+
+func TestCompositeCertificateRequestSignature(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.ExperimentalCompositeKeys, true)()
+
+	crt := certificateWithKeyAlgorithm("composite", false, v1.MLDSA65ECDSAP256KeyAlgorithm)
+	pk, err := GeneratePrivateKeyForCertificate(crt)
+	require.NoError(t, err)
+	otherPK, err := GeneratePrivateKeyForCertificate(crt)
+	require.NoError(t, err)
+
+	csrTemplate, err := GenerateCSR(crt)
+	require.NoError(t, err)
+	csrDER, err := EncodeCSR(csrTemplate, pk)
+	require.NoError(t, err)
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	require.NoError(t, err)
+	require.NoError(t, checkCertificateRequestSignature(csr))
+
+	// Replace the public key with another composite key, which mustn't
+	// verify the signature.
+	otherPub, err := otherPK.Public().(compositePublicKey).marshalPKIXPublicKey()
+	require.NoError(t, err)
+	csr.RawSubjectPublicKeyInfo = otherPub
+	assert.Error(t, checkCertificateRequestSignature(csr))
+
+}
+
+func mustDecodeCertificate(t *testing.T, certPEM []byte) *x509.Certificate {
+	cert, err := DecodeX509CertificateBytes(certPEM)
+	require.NoError(t, err)
+	return cert
+}
@@ -0,0 +1,39 @@
+//go:build !go1.27
+
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"errors"
+)
+
+// ML-DSA is only available in the standard library from Go 1.27.
+var errCompositeKeysUnsupported = errors.New("composite private keys are only supported if cert-manager is built with Go 1.27 or later")
+
+func generateCompositePrivateKey() (crypto.Signer, error) {
+	return nil, errCompositeKeysUnsupported
+}
+
+func parseCompositePrivateKey([]byte) (crypto.Signer, error) {
+	return nil, errCompositeKeysUnsupported
+}
+
+func newCompositePublicKey([]byte) (compositePublicKey, error) {
+	return nil, errCompositeKeysUnsupported
+}
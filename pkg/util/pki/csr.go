@@ -65,7 +65,12 @@ func DNSNamesForCertificate(crt *v1.Certificate) ([]string, error) {
 		return nil, fmt.Errorf("failed to parse DNSNames: %s", err)
 	}
 
-	return crt.Spec.DNSNames, nil
+	dnsNames, err := NormalizeDNSNames(crt.Spec.DNSNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize DNSNames: %w", err)
+	}
+
+	return dnsNames, nil
 }
 
 func URLsFromStrings(urlStrs []string) ([]*url.URL, error) {
@@ -145,6 +150,15 @@ func SubjectForCertificate(crt *v1.Certificate) v1.X509Subject {
 
 var serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
 
+// GenerateSerialNumber returns a random serial number for a new certificate.
+func GenerateSerialNumber() (*big.Int, error) {
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %s", err.Error())
+	}
+	return serialNumber, nil
+}
+
 func BuildKeyUsages(usages []v1.KeyUsage, isCA bool) (ku x509.KeyUsage, eku []x509.ExtKeyUsage, err error) {
 	var unk []v1.KeyUsage
 	if isCA {
@@ -275,7 +289,7 @@ func GenerateCSR(crt *v1.Certificate) (*x509.CertificateRequest, error) {
 }
 
 func buildKeyUsagesExtensionsForCertificate(crt *v1.Certificate) ([]pkix.Extension, error) {
-	ku, ekus, err := BuildKeyUsages(crt.Spec.Usages, crt.Spec.IsCA)
+	ku, ekus, err := BuildKeyUsages(apiutil.CertificateUsages(&crt.Spec), crt.Spec.IsCA)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build key usages: %w", err)
 	}
@@ -337,7 +351,10 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 		return nil, err
 	}
 
-	dnsNames := crt.Spec.DNSNames
+	dnsNames, err := DNSNamesForCertificate(crt)
+	if err != nil {
+		return nil, err
+	}
 	ipAddresses := IPAddressesForCertificate(crt)
 	organization := OrganizationForCertificate(crt)
 	subject := SubjectForCertificate(crt)
@@ -345,7 +362,7 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 	if err != nil {
 		return nil, err
 	}
-	keyUsages, extKeyUsages, err := BuildKeyUsages(crt.Spec.Usages, crt.Spec.IsCA)
+	keyUsages, extKeyUsages, err := BuildKeyUsages(apiutil.CertificateUsages(&crt.Spec), crt.Spec.IsCA)
 	if err != nil {
 		return nil, err
 	}
@@ -354,9 +371,9 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 		return nil, fmt.Errorf("no common name or subject alt names requested on certificate")
 	}
 
-	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	serialNumber, err := GenerateSerialNumber()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate serial number: %s", err.Error())
+		return nil, err
 	}
 
 	certDuration := apiutil.DefaultCertDuration(crt.Spec.Duration)
@@ -459,13 +476,18 @@ func GenerateTemplateFromCSRPEMWithUsages(csrPEM []byte, duration time.Duration,
 		return nil, err
 	}
 
-	if err := csr.CheckSignature(); err != nil {
+	if err := checkCertificateRequestSignature(csr); err != nil {
 		return nil, err
 	}
 
-	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	publicKey, err := certificateRequestPublicKey(csr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate serial number: %s", err.Error())
+		return nil, err
+	}
+
+	serialNumber, err := GenerateSerialNumber()
+	if err != nil {
+		return nil, err
 	}
 
 	return &x509.Certificate{
@@ -477,7 +499,7 @@ func GenerateTemplateFromCSRPEMWithUsages(csrPEM []byte, duration time.Duration,
 		BasicConstraintsValid: true,
 		SerialNumber:          serialNumber,
 		PublicKeyAlgorithm:    csr.PublicKeyAlgorithm,
-		PublicKey:             csr.PublicKey,
+		PublicKey:             publicKey,
 		IsCA:                  isCA,
 		Subject:               csr.Subject,
 		RawSubject:            csr.RawSubject,
@@ -500,7 +522,7 @@ func GenerateTemplateFromCSRPEMWithUsages(csrPEM []byte, duration time.Duration,
 // It returns a PEM encoded copy of the Certificate as well as a *x509.Certificate
 // which can be used for reading the encoded values.
 func SignCertificate(template *x509.Certificate, issuerCert *x509.Certificate, publicKey crypto.PublicKey, signerKey interface{}) ([]byte, *x509.Certificate, error) {
-	derBytes, err := x509.CreateCertificate(rand.Reader, template, issuerCert, publicKey, signerKey)
+	derBytes, err := createCertificate(template, issuerCert, publicKey, signerKey)
 
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating x509 certificate: %s", err.Error())
@@ -547,7 +569,7 @@ func SignCSRTemplate(caCerts []*x509.Certificate, caKey crypto.Signer, template
 // EncodeCSR calls x509.CreateCertificateRequest to sign the given CSR template.
 // It returns a DER encoded signed CSR.
 func EncodeCSR(template *x509.CertificateRequest, key crypto.Signer) ([]byte, error) {
-	derBytes, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	derBytes, err := createCertificateRequest(template, key)
 	if err != nil {
 		return nil, fmt.Errorf("error creating x509 certificate: %s", err.Error())
 	}
@@ -579,7 +601,7 @@ func EncodeX509Chain(certs []*x509.Certificate) ([]byte, error) {
 			continue
 		}
 
-		if cert.CheckSignatureFrom(cert) == nil {
+		if isSelfSignedCertificate(cert) {
 			// Don't include self-signed certificate
 			continue
 		}
@@ -626,6 +648,11 @@ func SignatureAlgorithm(crt *v1.Certificate) (x509.PublicKeyAlgorithm, x509.Sign
 	case v1.Ed25519KeyAlgorithm:
 		pubKeyAlgo = x509.Ed25519
 		sigAlgo = x509.PureEd25519
+	case v1.MLDSA65ECDSAP256KeyAlgorithm:
+		// crypto/x509 has no constants for composite keys, which are instead
+		// handled when the CSR is encoded.
+		pubKeyAlgo = x509.UnknownPublicKeyAlgorithm
+		sigAlgo = x509.UnknownSignatureAlgorithm
 	case v1.ECDSAKeyAlgorithm:
 		pubKeyAlgo = x509.ECDSA
 		switch crt.Spec.PrivateKey.Size {
@@ -641,7 +668,7 @@ func SignatureAlgorithm(crt *v1.Certificate) (x509.PublicKeyAlgorithm, x509.Sign
 			return x509.UnknownPublicKeyAlgorithm, x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported ecdsa keysize specified: %d", crt.Spec.PrivateKey.Size)
 		}
 	default:
-		return x509.UnknownPublicKeyAlgorithm, x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported algorithm specified: %s. should be either 'ecdsa' or 'rsa", crt.Spec.PrivateKey.Algorithm)
+		return x509.UnknownPublicKeyAlgorithm, x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported algorithm specified: %s. should be one of 'rsa', 'ecdsa', 'ed25519' or 'mldsa65-ecdsa-p256'", crt.Spec.PrivateKey.Algorithm)
 	}
 	return pubKeyAlgo, sigAlgo, nil
 }
@@ -50,7 +50,8 @@ const (
 // GeneratePrivateKeyForCertificate will generate a private key suitable for
 // the provided cert-manager Certificate resource, taking into account the
 // parameters on the provided resource.
-// The returned key will either be RSA or ECDSA.
+// The returned key will either be RSA, ECDSA, Ed25519, or an experimental
+// composite key if the ExperimentalCompositeKeys feature gate is enabled.
 func GeneratePrivateKeyForCertificate(crt *v1.Certificate) (crypto.Signer, error) {
 	crt = crt.DeepCopy()
 	if crt.Spec.PrivateKey == nil {
@@ -75,6 +76,12 @@ func GeneratePrivateKeyForCertificate(crt *v1.Certificate) (crypto.Signer, error
 		return GenerateECPrivateKey(keySize)
 	case v1.Ed25519KeyAlgorithm:
 		return GenerateEd25519PrivateKey()
+	case v1.MLDSA65ECDSAP256KeyAlgorithm:
+		if !compositeKeysEnabled() {
+			return nil, errCompositeKeysDisabled
+		}
+
+		return generateCompositePrivateKey()
 	default:
 		return nil, fmt.Errorf("unsupported private key algorithm specified: %s", crt.Spec.PrivateKey.Algorithm)
 	}
@@ -124,7 +131,7 @@ func GenerateEd25519PrivateKey() (ed25519.PrivateKey, error) {
 
 // EncodePrivateKey will encode a given crypto.PrivateKey by first inspecting
 // the type of key encoding and then inspecting the type of key provided.
-// It only supports encoding RSA or ECDSA keys.
+// It supports encoding RSA, ECDSA and Ed25519 keys.
 func EncodePrivateKey(pk crypto.PrivateKey, keyEncoding v1.PrivateKeyEncoding) ([]byte, error) {
 	switch keyEncoding {
 	case v1.PrivateKeyEncoding(""), v1.PKCS1:
@@ -133,7 +140,7 @@ func EncodePrivateKey(pk crypto.PrivateKey, keyEncoding v1.PrivateKeyEncoding) (
 			return EncodePKCS1PrivateKey(k), nil
 		case *ecdsa.PrivateKey:
 			return EncodeECPrivateKey(k)
-		case ed25519.PrivateKey:
+		case ed25519.PrivateKey, compositePrivateKey:
 			return EncodePKCS8PrivateKey(k)
 		default:
 			return nil, fmt.Errorf("error encoding private key: unknown key type: %T", pk)
@@ -154,7 +161,13 @@ func EncodePKCS1PrivateKey(pk *rsa.PrivateKey) []byte {
 
 // EncodePKCS8PrivateKey will marshal a private key into x509 PEM format.
 func EncodePKCS8PrivateKey(pk interface{}) ([]byte, error) {
-	keyBytes, err := x509.MarshalPKCS8PrivateKey(pk)
+	var keyBytes []byte
+	var err error
+	if k, ok := pk.(compositePrivateKey); ok {
+		keyBytes, err = k.marshalPKCS8PrivateKey()
+	} else {
+		keyBytes, err = x509.MarshalPKCS8PrivateKey(pk)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -184,6 +197,8 @@ func PublicKeyForPrivateKey(pk crypto.PrivateKey) (crypto.PublicKey, error) {
 		return k.Public(), nil
 	case ed25519.PrivateKey:
 		return k.Public(), nil
+	case compositePrivateKey:
+		return k.Public(), nil
 	default:
 		return nil, fmt.Errorf("unknown private key type: %T", pk)
 	}
@@ -195,7 +210,11 @@ func PublicKeyForPrivateKey(pk crypto.PrivateKey) (crypto.PublicKey, error) {
 // Returns true and no error if the public key *is* the same as the certificate's key
 // Returns an error if the certificate's key type cannot be determined (i.e. non RSA/ECDSA keys)
 func PublicKeyMatchesCertificate(check crypto.PublicKey, crt *x509.Certificate) (bool, error) {
-	return PublicKeysEqual(crt.PublicKey, check)
+	pub, err := certificatePublicKey(crt)
+	if err != nil {
+		return false, err
+	}
+	return PublicKeysEqual(pub, check)
 }
 
 // PublicKeyMatchesCSR can be used to verify the given public key matches the
@@ -204,7 +223,11 @@ func PublicKeyMatchesCertificate(check crypto.PublicKey, crt *x509.Certificate)
 // Returns true and no error if the given public key *is* the same as the CSR's key
 // Returns an error if the CSR's key type cannot be determined (i.e. non RSA/ECDSA keys)
 func PublicKeyMatchesCSR(check crypto.PublicKey, csr *x509.CertificateRequest) (bool, error) {
-	return PublicKeysEqual(csr.PublicKey, check)
+	pub, err := certificateRequestPublicKey(csr)
+	if err != nil {
+		return false, err
+	}
+	return PublicKeysEqual(pub, check)
 }
 
 // PublicKeysEqual compares two given public keys for equality.
@@ -219,6 +242,8 @@ func PublicKeysEqual(a, b crypto.PublicKey) (bool, error) {
 		return pub.Equal(b), nil
 	case ed25519.PublicKey:
 		return pub.Equal(b), nil
+	case compositePublicKey:
+		return pub.Equal(b), nil
 	default:
 		return false, fmt.Errorf("unrecognised public key type: %T", a)
 	}
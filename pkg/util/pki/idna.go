@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// aLabelPrefix is the ACE prefix which marks a punycode encoded label.
+const aLabelPrefix = "xn--"
+
+// NormalizeDNSName returns the A-label (punycode) form of an internationalized
+// DNS name, which is the form used in X.509 certificates and ACME orders.
+// A leading wildcard label is preserved. Names which are entirely ASCII are
+// returned unchanged, but any punycode labels in them must be valid.
+// An error is returned if a name is malformed, or if it mixes U-labels and
+// A-labels.
+func NormalizeDNSName(name string) (string, error) {
+	wildcard := strings.HasPrefix(name, "*.")
+	labels := strings.Split(strings.TrimPrefix(name, "*."), ".")
+
+	var hasULabel, hasALabel bool
+	for _, label := range labels {
+		if !isASCII(label) {
+			hasULabel = true
+		} else if strings.HasPrefix(strings.ToLower(label), aLabelPrefix) {
+			hasALabel = true
+		}
+	}
+	if hasULabel && hasALabel {
+		return "", fmt.Errorf("%q mixes unicode and punycode labels", name)
+	}
+
+	for i, label := range labels {
+		switch {
+		case !isASCII(label):
+			aLabel, err := idna.Lookup.ToASCII(label)
+			if err != nil {
+				return "", fmt.Errorf("%q is not a valid internationalized name: %w", name, err)
+			}
+			labels[i] = aLabel
+		case strings.HasPrefix(strings.ToLower(label), aLabelPrefix):
+			if _, err := idna.Lookup.ToUnicode(label); err != nil {
+				return "", fmt.Errorf("%q contains an invalid punycode label %q: %w", name, label, err)
+			}
+		}
+	}
+
+	normalized := strings.Join(labels, ".")
+	if wildcard {
+		normalized = "*." + normalized
+	}
+	return normalized, nil
+}
+
+// NormalizeDNSNames returns the result of NormalizeDNSName for each of names.
+func NormalizeDNSNames(names []string) ([]string, error) {
+	if names == nil {
+		return nil, nil
+	}
+	normalized := make([]string, len(names))
+	for i, name := range names {
+		n, err := NormalizeDNSName(name)
+		if err != nil {
+			return nil, err
+		}
+		normalized[i] = n
+	}
+	return normalized, nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
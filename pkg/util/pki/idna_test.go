@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"testing"
+)
+
+func TestNormalizeDNSName(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		"ascii name": {
+			name: "example.com",
+			want: "example.com",
+		},
+		"ascii names are not lowercased": {
+			name: "Example.com",
+			want: "Example.com",
+		},
+		"ascii names may contain underscores": {
+			name: "my_host.example.com",
+			want: "my_host.example.com",
+		},
+		"unicode name": {
+			name: "bücher.example",
+			want: "xn--bcher-kva.example",
+		},
+		"unicode wildcard name": {
+			name: "*.Bücher.example",
+			want: "*.xn--bcher-kva.example",
+		},
+		"fullwidth characters are mapped": {
+			name: "ｂüｃｈｅｒ.example",
+			want: "xn--bcher-kva.example",
+		},
+		"valid punycode name": {
+			name: "xn--bcher-kva.example",
+			want: "xn--bcher-kva.example",
+		},
+		"invalid punycode label": {
+			name:    "xn--zz.example",
+			wantErr: true,
+		},
+		"unicode label with a leading hyphen": {
+			name:    "-bücher.example",
+			wantErr: true,
+		},
+		"mixed unicode and punycode labels": {
+			name:    "xn--bcher-kva.bücher.example",
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NormalizeDNSName(test.name)
+			if test.wantErr != (err != nil) {
+				t.Fatalf("expected error: %v, got: %v", test.wantErr, err)
+			}
+			if got != test.want {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
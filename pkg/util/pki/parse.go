@@ -23,6 +23,9 @@ import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/go-ldap/ldap/v3"
@@ -39,6 +42,13 @@ func DecodePrivateKeyBytes(keyBytes []byte) (crypto.Signer, error) {
 
 	switch block.Type {
 	case "PRIVATE KEY":
+		if signer, ok, err := parseCompositePKCS8PrivateKey(block.Bytes); ok {
+			if err != nil {
+				return nil, errors.NewInvalidData("error parsing composite pkcs#8 private key: %s", err.Error())
+			}
+			return signer, nil
+		}
+
 		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
 		if err != nil {
 			return nil, errors.NewInvalidData("error parsing pkcs#8 private key: %s", err.Error())
@@ -333,14 +343,14 @@ func (c *chainNode) toBundleAndCA() (PEMBundle, error) {
 func (c *chainNode) tryMergeChain(chain *chainNode) (*chainNode, bool) {
 	// The given chain's root has been signed by this node. Add this node on top
 	// of the given chain.
-	if chain.root().cert.CheckSignatureFrom(c.cert) == nil {
+	if checkSignatureFrom(chain.root().cert, c.cert) == nil {
 		chain.root().issuer = c
 		return chain, true
 	}
 
 	// The given chain is the issuer of the root of this node. Add the given
 	// chain on top of the root of this node.
-	if c.root().cert.CheckSignatureFrom(chain.cert) == nil {
+	if checkSignatureFrom(c.root().cert, chain.cert) == nil {
 		c.root().issuer = chain
 		return c, true
 	}
@@ -358,10 +368,43 @@ func (c *chainNode) root() *chainNode {
 	return c
 }
 
+// VerifyCertificateChain checks that each certificate in the given chain,
+// which must start with the leaf certificate, is signed by the certificate
+// that follows it. If any CAs are given, one of the certificates in the chain
+// must also be one of the CAs or be signed by one of them.
+// Only signatures and basic constraints are checked: the validity periods
+// and the extended key usages of the certificates are not.
+func VerifyCertificateChain(chain []*x509.Certificate, cas []*x509.Certificate) error {
+	if len(chain) == 0 {
+		return errors.NewInvalidData("certificate chain is empty")
+	}
+
+	for i := 0; i < len(chain)-1; i++ {
+		if err := checkSignatureFrom(chain[i], chain[i+1]); err != nil {
+			return errors.NewInvalidData("certificate %q is not signed by the next certificate in the chain %q: %s",
+				chain[i].Subject.String(), chain[i+1].Subject.String(), err)
+		}
+	}
+
+	if len(cas) == 0 {
+		return nil
+	}
+
+	for _, cert := range chain {
+		for _, ca := range cas {
+			if cert.Equal(ca) || checkSignatureFrom(cert, ca) == nil {
+				return nil
+			}
+		}
+	}
+
+	return errors.NewInvalidData("certificate chain does not chain to any of the %d CA certificates", len(cas))
+}
+
 // isSelfSignedCertificate returns true if the given X.509 certificate has been
 // signed by itself, which would make it a "root" certificate.
 func isSelfSignedCertificate(cert *x509.Certificate) bool {
-	return cert.CheckSignatureFrom(cert) == nil
+	return checkSignatureFrom(cert, cert) == nil
 }
 
 var OIDConstants = struct {
@@ -434,3 +477,15 @@ func ParseSubjectStringToRawDerBytes(subject string) ([]byte, error) {
 	return asn1.Marshal(rdnSequenceFromLiteralString)
 
 }
+
+// ParseSerialNumber parses a certificate serial number given as a hexadecimal
+// string. The bytes of the serial number may be separated by colons, as
+// printed by `openssl x509 -serial` or `kubectl cert-manager inspect`.
+func ParseSerialNumber(serial string) (*big.Int, error) {
+	hex := strings.ReplaceAll(strings.TrimPrefix(strings.ToLower(serial), "0x"), ":", "")
+	n, ok := new(big.Int).SetString(hex, 16)
+	if !ok || hex == "" || strings.HasPrefix(hex, "-") || strings.HasPrefix(hex, "+") {
+		return nil, fmt.Errorf("invalid serial number %q: must be a hexadecimal string", serial)
+	}
+	return n, nil
+}
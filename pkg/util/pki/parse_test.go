@@ -364,6 +364,70 @@ func TestParseSingleCertificateChain(t *testing.T) {
 	}
 }
 
+func TestVerifyCertificateChain(t *testing.T) {
+	root := mustCreateBundle(t, nil, "root")
+	intA1 := mustCreateBundle(t, root, "intA-1")
+	intA2 := mustCreateBundle(t, intA1, "intA-2")
+	leaf := mustCreateBundle(t, intA2, "leaf")
+	random := mustCreateBundle(t, nil, "random")
+
+	tests := map[string]struct {
+		chain  []*x509.Certificate
+		cas    []*x509.Certificate
+		expErr bool
+	}{
+		"empty chain should error": {
+			chain:  nil,
+			expErr: true,
+		},
+		"chain in order without CAs should not error": {
+			chain: []*x509.Certificate{leaf.cert, intA2.cert, intA1.cert},
+		},
+		"chain in order signed by the CA should not error": {
+			chain: []*x509.Certificate{leaf.cert, intA2.cert, intA1.cert},
+			cas:   []*x509.Certificate{root.cert},
+		},
+		"chain in order including the CA should not error": {
+			chain: []*x509.Certificate{leaf.cert, intA2.cert, intA1.cert, root.cert},
+			cas:   []*x509.Certificate{root.cert},
+		},
+		"chain in order with an intermediate as the CA should not error": {
+			chain: []*x509.Certificate{leaf.cert, intA2.cert, intA1.cert, root.cert},
+			cas:   []*x509.Certificate{intA1.cert},
+		},
+		"chain signed by one of many CAs should not error": {
+			chain: []*x509.Certificate{leaf.cert, intA2.cert, intA1.cert},
+			cas:   []*x509.Certificate{random.cert, root.cert},
+		},
+		"self signed certificate which is also the CA should not error": {
+			chain: []*x509.Certificate{root.cert},
+			cas:   []*x509.Certificate{root.cert},
+		},
+		"chain out of order should error": {
+			chain:  []*x509.Certificate{leaf.cert, intA1.cert, intA2.cert},
+			expErr: true,
+		},
+		"chain with a break should error": {
+			chain:  []*x509.Certificate{leaf.cert, intA1.cert},
+			expErr: true,
+		},
+		"chain not signed by the CA should error": {
+			chain:  []*x509.Certificate{leaf.cert, intA2.cert, intA1.cert},
+			cas:    []*x509.Certificate{random.cert},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyCertificateChain(test.chain, test.cas)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+		})
+	}
+}
+
 func TestMustParseRDN(t *testing.T) {
 	subject := "SERIALNUMBER=42, L=some-locality, ST=some-state-or-province, STREET=some-street, CN=foo-long.com, OU=FooLong, OU=Barq, OU=Baz, OU=Dept., O=Corp., C=US"
 	rdnSeq, err := ParseSubjectStringToRdnSequence(subject)
@@ -454,3 +518,30 @@ func TestMustKeepOrderInRawDerBytes(t *testing.T) {
 	assert.Equal(t, expectedRdnSeq, rdnSeq)
 	assert.Equal(t, subject, rdnSeq.String())
 }
+
+func TestParseSerialNumber(t *testing.T) {
+	tests := map[string]struct {
+		serial   string
+		expected string
+		wantErr  bool
+	}{
+		"hexadecimal":          {serial: "1A2b3C", expected: "1a2b3c"},
+		"colon separated":      {serial: "1a:2b:3c", expected: "1a2b3c"},
+		"0x prefix":            {serial: "0x1a2b3c", expected: "1a2b3c"},
+		"empty":                {serial: "", wantErr: true},
+		"not hexadecimal":      {serial: "1a2g", wantErr: true},
+		"negative":             {serial: "-1a", wantErr: true},
+		"only colon separator": {serial: ":", wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			n, err := ParseSerialNumber(test.serial)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, n.Text(16))
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// PKCS11Config identifies a private key stored in a PKCS#11 token, such as
+// a hardware security module.
+type PKCS11Config struct {
+	// Module is the path of the PKCS#11 module to load.
+	Module string
+	// TokenLabel is the label of the token holding the private key.
+	TokenLabel string
+	// KeyLabel and KeyID are the CKA_LABEL and CKA_ID attributes of the
+	// private key. At least one of them must be set.
+	KeyLabel string
+	KeyID    []byte
+	// PIN is the user PIN of the token.
+	PIN string
+}
+
+// NewPKCS11Signer returns a crypto.Signer which signs with the private key
+// identified by config, without the key ever leaving the token. public is
+// the public key of the private key, usually taken from the certificate of
+// the key, and must be an RSA or ECDSA public key.
+// The module is loaded and logged into once, and the session with the token
+// is reused by all the signers for the same key.
+func NewPKCS11Signer(config PKCS11Config, public crypto.PublicKey) (crypto.Signer, error) {
+	if len(config.KeyLabel) == 0 && len(config.KeyID) == 0 {
+		return nil, errors.New("either the label or the ID of the PKCS#11 private key must be set")
+	}
+	switch public.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported public key type for a PKCS#11 private key: %T", public)
+	}
+	return newPKCS11Signer(config, public)
+}
+
+// rsaDigestInfoPrefixes are the DER encoded DigestInfo prefixes of the
+// digests signed with RSA PKCS #1 v1.5. Unlike crypto/rsa, PKCS#11 tokens
+// expect the DigestInfo to be given to them.
+var rsaDigestInfoPrefixes = map[crypto.Hash][]byte{
+	crypto.SHA1:   {0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14},
+	crypto.SHA224: {0x30, 0x2d, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x04, 0x05, 0x00, 0x04, 0x1c},
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+}
+
+// rsaDigestInfo returns the DigestInfo of the digest, which is signed by
+// the CKM_RSA_PKCS mechanism.
+func rsaDigestInfo(hash crypto.Hash, digest []byte) ([]byte, error) {
+	prefix, ok := rsaDigestInfoPrefixes[hash]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash function for RSA PKCS #1 v1.5 signatures: %v", hash)
+	}
+	if len(digest) != hash.Size() {
+		return nil, fmt.Errorf("digest is %d bytes long, expected %d bytes for %v", len(digest), hash.Size(), hash)
+	}
+	return append(append([]byte{}, prefix...), digest...), nil
+}
+
+// rsaPSSSaltLength returns the salt length of an RSA PSS signature, which
+// PKCS#11 tokens require to be given explicitly.
+func rsaPSSSaltLength(opts *rsa.PSSOptions, public *rsa.PublicKey) (int, error) {
+	switch opts.SaltLength {
+	case rsa.PSSSaltLengthEqualsHash:
+		return opts.Hash.Size(), nil
+	case rsa.PSSSaltLengthAuto:
+		// The maximum salt length, as used by crypto/rsa.
+		return (public.N.BitLen()-1+7)/8 - 2 - opts.Hash.Size(), nil
+	default:
+		if opts.SaltLength < 0 {
+			return 0, fmt.Errorf("invalid RSA PSS salt length: %d", opts.SaltLength)
+		}
+		return opts.SaltLength, nil
+	}
+}
+
+// ecdsaSignatureToASN1 converts an ECDSA signature returned by the CKM_ECDSA
+// mechanism, the concatenation of r and s, to the ASN.1 encoding used by
+// Go and X.509.
+func ecdsaSignatureToASN1(signature []byte) ([]byte, error) {
+	if len(signature) == 0 || len(signature)%2 != 0 {
+		return nil, fmt.Errorf("invalid ECDSA signature length: %d", len(signature))
+	}
+	half := len(signature) / 2
+	return asn1.Marshal(struct {
+		R, S *big.Int
+	}{
+		R: new(big.Int).SetBytes(signature[:half]),
+		S: new(big.Int).SetBytes(signature[half:]),
+	})
+}
@@ -0,0 +1,253 @@
+//go:build cgo
+
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+var (
+	pkcs11Lock sync.Mutex
+	// pkcs11Modules holds the PKCS#11 modules which have been loaded. A
+	// module can only be initialized once by a process, so modules are
+	// never unloaded.
+	pkcs11Modules = map[string]*pkcs11.Ctx{}
+	// pkcs11Keys holds the private keys which have been found in tokens,
+	// so that the session they were found in is reused.
+	pkcs11Keys = map[pkcs11KeyRef]*pkcs11Key{}
+)
+
+// pkcs11KeyRef identifies a private key in a token, logged into with a PIN.
+type pkcs11KeyRef struct {
+	module, tokenLabel, keyLabel, keyID, pin string
+}
+
+// pkcs11Key is a private key found in a token.
+type pkcs11Key struct {
+	ref pkcs11KeyRef
+	ctx *pkcs11.Ctx
+
+	// lock serializes the operations of the session, as a PKCS#11 session
+	// can only perform one operation at a time.
+	lock    sync.Mutex
+	session pkcs11.SessionHandle
+	handle  pkcs11.ObjectHandle
+}
+
+type pkcs11Signer struct {
+	key    *pkcs11Key
+	public crypto.PublicKey
+}
+
+func newPKCS11Signer(config PKCS11Config, public crypto.PublicKey) (crypto.Signer, error) {
+	key, err := findPKCS11Key(pkcs11KeyRef{
+		module:     config.Module,
+		tokenLabel: config.TokenLabel,
+		keyLabel:   config.KeyLabel,
+		keyID:      string(config.KeyID),
+		pin:        config.PIN,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pkcs11Signer{key: key, public: public}, nil
+}
+
+// findPKCS11Key returns the private key, loading the module and logging
+// into the token if they have not already been.
+func findPKCS11Key(ref pkcs11KeyRef) (*pkcs11Key, error) {
+	pkcs11Lock.Lock()
+	defer pkcs11Lock.Unlock()
+
+	if key, ok := pkcs11Keys[ref]; ok {
+		return key, nil
+	}
+
+	ctx, ok := pkcs11Modules[ref.module]
+	if !ok {
+		ctx = pkcs11.New(ref.module)
+		if ctx == nil {
+			return nil, fmt.Errorf("failed to load PKCS#11 module %q", ref.module)
+		}
+		if err := ctx.Initialize(); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED)) {
+			ctx.Destroy()
+			return nil, fmt.Errorf("failed to initialize PKCS#11 module %q: %w", ref.module, err)
+		}
+		pkcs11Modules[ref.module] = ctx
+	}
+
+	slot, err := findPKCS11Slot(ctx, ref.tokenLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a session with PKCS#11 token %q: %w", ref.tokenLabel, err)
+	}
+	// Logging in applies to all the sessions with the token, so another
+	// session may already have logged in.
+	if err := ctx.Login(session, pkcs11.CKU_USER, ref.pin); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		_ = ctx.CloseSession(session)
+		return nil, fmt.Errorf("failed to log into PKCS#11 token %q: %w", ref.tokenLabel, err)
+	}
+
+	handle, err := findPKCS11PrivateKey(ctx, session, ref)
+	if err != nil {
+		_ = ctx.CloseSession(session)
+		return nil, err
+	}
+
+	key := &pkcs11Key{ref: ref, ctx: ctx, session: session, handle: handle}
+	pkcs11Keys[ref] = key
+	return key, nil
+}
+
+func findPKCS11Slot(ctx *pkcs11.Ctx, tokenLabel string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list PKCS#11 slots: %w", err)
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get the information of the token in PKCS#11 slot %d: %w", slot, err)
+		}
+		if info.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("no PKCS#11 token with label %q", tokenLabel)
+}
+
+func findPKCS11PrivateKey(ctx *pkcs11.Ctx, session pkcs11.SessionHandle, ref pkcs11KeyRef) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+	}
+	if len(ref.keyLabel) > 0 {
+		template = append(template, pkcs11.NewAttribute(pkcs11.CKA_LABEL, ref.keyLabel))
+	}
+	if len(ref.keyID) > 0 {
+		template = append(template, pkcs11.NewAttribute(pkcs11.CKA_ID, []byte(ref.keyID)))
+	}
+
+	if err := ctx.FindObjectsInit(session, template); err != nil {
+		return 0, fmt.Errorf("failed to search PKCS#11 token %q: %w", ref.tokenLabel, err)
+	}
+	handles, _, err := ctx.FindObjects(session, 2)
+	if finalErr := ctx.FindObjectsFinal(session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to search PKCS#11 token %q: %w", ref.tokenLabel, err)
+	}
+
+	switch len(handles) {
+	case 0:
+		return 0, fmt.Errorf("no private key with label %q and ID %x in PKCS#11 token %q", ref.keyLabel, ref.keyID, ref.tokenLabel)
+	case 1:
+		return handles[0], nil
+	default:
+		return 0, fmt.Errorf("more than one private key with label %q and ID %x in PKCS#11 token %q", ref.keyLabel, ref.keyID, ref.tokenLabel)
+	}
+}
+
+// sign signs the data with the mechanism. If signing fails, the session is
+// closed and the key forgotten, so that the next signer for the key opens a
+// new session, in case the token was reset or the session has expired.
+func (k *pkcs11Key) sign(mechanism *pkcs11.Mechanism, data []byte) ([]byte, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	err := k.ctx.SignInit(k.session, []*pkcs11.Mechanism{mechanism}, k.handle)
+	if err == nil {
+		var signature []byte
+		signature, err = k.ctx.Sign(k.session, data)
+		if err == nil {
+			return signature, nil
+		}
+	}
+
+	pkcs11Lock.Lock()
+	if pkcs11Keys[k.ref] == k {
+		delete(pkcs11Keys, k.ref)
+	}
+	pkcs11Lock.Unlock()
+	_ = k.ctx.CloseSession(k.session)
+
+	return nil, fmt.Errorf("failed to sign with PKCS#11 token %q: %w", k.ref.tokenLabel, err)
+}
+
+func (s *pkcs11Signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+func (s *pkcs11Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	switch public := s.public.(type) {
+	case *rsa.PublicKey:
+		if pssOpts, ok := opts.(*rsa.PSSOptions); ok {
+			mechanism, err := rsaPSSMechanism(pssOpts, public)
+			if err != nil {
+				return nil, err
+			}
+			return s.key.sign(mechanism, digest)
+		}
+		digestInfo, err := rsaDigestInfo(opts.HashFunc(), digest)
+		if err != nil {
+			return nil, err
+		}
+		return s.key.sign(pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS, nil), digestInfo)
+	case *ecdsa.PublicKey:
+		signature, err := s.key.sign(pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil), digest)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsaSignatureToASN1(signature)
+	default:
+		return nil, fmt.Errorf("unsupported public key type for a PKCS#11 private key: %T", s.public)
+	}
+}
+
+// rsaPSSHashes are the PKCS#11 hash and mask generation function mechanisms
+// of the hash functions supported for RSA PSS signatures.
+var rsaPSSHashes = map[crypto.Hash]struct{ hash, mgf uint }{
+	crypto.SHA256: {pkcs11.CKM_SHA256, pkcs11.CKG_MGF1_SHA256},
+	crypto.SHA384: {pkcs11.CKM_SHA384, pkcs11.CKG_MGF1_SHA384},
+	crypto.SHA512: {pkcs11.CKM_SHA512, pkcs11.CKG_MGF1_SHA512},
+}
+
+func rsaPSSMechanism(opts *rsa.PSSOptions, public *rsa.PublicKey) (*pkcs11.Mechanism, error) {
+	hash, ok := rsaPSSHashes[opts.Hash]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash function for RSA PSS signatures: %v", opts.Hash)
+	}
+	saltLength, err := rsaPSSSaltLength(opts, public)
+	if err != nil {
+		return nil, err
+	}
+	return pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS_PSS, pkcs11.NewPSSParams(hash.hash, hash.mgf, uint(saltLength))), nil
+}
@@ -0,0 +1,32 @@
+//go:build !cgo
+
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"errors"
+)
+
+// PKCS#11 modules are shared libraries, which can only be loaded by
+// binaries linked to the libc.
+var errPKCS11Unsupported = errors.New("PKCS#11 tokens are only supported if cert-manager is built with cgo")
+
+func newPKCS11Signer(PKCS11Config, crypto.PublicKey) (crypto.Signer, error) {
+	return nil, errPKCS11Unsupported
+}
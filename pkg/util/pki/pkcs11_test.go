@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"testing"
+)
+
+func TestNewPKCS11SignerValidation(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		config PKCS11Config
+		public crypto.PublicKey
+	}{
+		"neither the key label nor the key ID are set": {
+			config: PKCS11Config{Module: "/usr/lib/softhsm/libsofthsm2.so", TokenLabel: "ca"},
+			public: &rsaKey.PublicKey,
+		},
+		"Ed25519 keys are not supported": {
+			config: PKCS11Config{Module: "/usr/lib/softhsm/libsofthsm2.so", TokenLabel: "ca", KeyLabel: "ca"},
+			public: edPublic,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewPKCS11Signer(test.config, test.public); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestRSADigestInfo(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, hash := range []crypto.Hash{crypto.SHA1, crypto.SHA224, crypto.SHA256, crypto.SHA384, crypto.SHA512} {
+		t.Run(hash.String(), func(t *testing.T) {
+			h := hash.New()
+			h.Write([]byte("cert-manager"))
+			digest := h.Sum(nil)
+
+			digestInfo, err := rsaDigestInfo(hash, digest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Signing the raw DigestInfo is what the CKM_RSA_PKCS mechanism
+			// does, and must produce a standard PKCS #1 v1.5 signature.
+			signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.Hash(0), digestInfo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := rsa.VerifyPKCS1v15(&key.PublicKey, hash, digest, signature); err != nil {
+				t.Errorf("signature of the DigestInfo does not verify: %v", err)
+			}
+		})
+	}
+
+	if _, err := rsaDigestInfo(crypto.SHA256, make([]byte, sha512.Size)); err == nil {
+		t.Errorf("expected an error for a digest of the wrong length")
+	}
+	if _, err := rsaDigestInfo(crypto.MD5, make([]byte, crypto.MD5.Size())); err == nil {
+		t.Errorf("expected an error for an unsupported hash function")
+	}
+}
+
+func TestRSAPSSSaltLength(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		opts    *rsa.PSSOptions
+		want    int
+		wantErr bool
+	}{
+		"equals hash": {
+			opts: &rsa.PSSOptions{Hash: crypto.SHA256, SaltLength: rsa.PSSSaltLengthEqualsHash},
+			want: sha256.Size,
+		},
+		"auto uses the maximum salt length": {
+			opts: &rsa.PSSOptions{Hash: crypto.SHA256, SaltLength: rsa.PSSSaltLengthAuto},
+			want: 256 - 2 - sha256.Size,
+		},
+		"explicit": {
+			opts: &rsa.PSSOptions{Hash: crypto.SHA384, SaltLength: 20},
+			want: 20,
+		},
+		"negative": {
+			opts:    &rsa.PSSOptions{Hash: crypto.SHA384, SaltLength: -3},
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := rsaPSSSaltLength(test.opts, &key.PublicKey)
+			if test.wantErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("got salt length %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestECDSASignatureToASN1(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			key, err := ecdsa.GenerateKey(curve, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			digest := sha256.Sum256([]byte("cert-manager"))
+			r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The CKM_ECDSA mechanism returns r and s padded to the size
+			// of the curve order, and concatenated.
+			size := (curve.Params().N.BitLen() + 7) / 8
+			raw := make([]byte, 2*size)
+			r.FillBytes(raw[:size])
+			s.FillBytes(raw[size:])
+
+			signature, err := ecdsaSignatureToASN1(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ecdsa.VerifyASN1(&key.PublicKey, digest[:], signature) {
+				t.Errorf("converted signature does not verify")
+			}
+		})
+	}
+
+	if _, err := ecdsaSignatureToASN1([]byte{1, 2, 3}); err == nil {
+		t.Errorf("expected an error for a signature of odd length")
+	}
+}
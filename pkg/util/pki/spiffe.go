@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SPIFFEScheme is the URI scheme of SPIFFE IDs.
+const SPIFFEScheme = "spiffe"
+
+// maxSPIFFEIDLength is the maximum length of a SPIFFE ID in bytes.
+const maxSPIFFEIDLength = 2048
+
+// ValidateSPIFFEID returns an error if id is not a valid SPIFFE ID, as defined
+// by the SPIFFE-ID specification: `spiffe://<trust domain>/<path>`.
+// The trust domain may only contain lowercase letters, digits, dots, dashes
+// and underscores. The path is optional, and each of its segments may only
+// contain letters, digits, dots, dashes and underscores.
+func ValidateSPIFFEID(id string) error {
+	if len(id) > maxSPIFFEIDLength {
+		return fmt.Errorf("SPIFFE ID must not be longer than %d bytes", maxSPIFFEIDLength)
+	}
+	if !strings.HasPrefix(id, SPIFFEScheme+"://") {
+		return fmt.Errorf("SPIFFE ID must begin with %q", SPIFFEScheme+"://")
+	}
+
+	trustDomain, path, hasPath := strings.Cut(strings.TrimPrefix(id, SPIFFEScheme+"://"), "/")
+	if len(trustDomain) == 0 {
+		return errors.New("SPIFFE ID must have a trust domain")
+	}
+	for _, c := range trustDomain {
+		if !isSPIFFETrustDomainChar(c) {
+			return fmt.Errorf("SPIFFE ID trust domain %q may only contain lowercase letters, digits, dots, dashes and underscores", trustDomain)
+		}
+	}
+
+	if !hasPath {
+		return nil
+	}
+	for _, segment := range strings.Split(path, "/") {
+		switch segment {
+		case "":
+			return errors.New("SPIFFE ID path must not contain empty segments or a trailing slash")
+		case ".", "..":
+			return errors.New("SPIFFE ID path must not contain '.' or '..' segments")
+		}
+		for _, c := range segment {
+			if !isSPIFFEPathChar(c) {
+				return fmt.Errorf("SPIFFE ID path segment %q may only contain letters, digits, dots, dashes and underscores", segment)
+			}
+		}
+	}
+	return nil
+}
+
+// SPIFFETrustBundleConfigMapName returns the name of the ConfigMap the trust
+// bundle of a SPIFFE trust domain is published in by CA issuers.
+func SPIFFETrustBundleConfigMapName(trustDomain string) string {
+	return "spiffe-bundle-" + trustDomain
+}
+
+// ParseServiceAccountSPIFFEID returns the namespace and name of the
+// Kubernetes service account identified by a SPIFFE ID of the form
+// `spiffe://<trust domain>/ns/<namespace>/sa/<service account>` in the given
+// trust domain.
+func ParseServiceAccountSPIFFEID(id, trustDomain string) (namespace, serviceAccount string, err error) {
+	if err := ValidateSPIFFEID(id); err != nil {
+		return "", "", err
+	}
+	prefix := SPIFFEScheme + "://" + trustDomain + "/"
+	if !strings.HasPrefix(id, prefix) {
+		return "", "", fmt.Errorf("SPIFFE ID must be in the trust domain %q", trustDomain)
+	}
+	segments := strings.Split(strings.TrimPrefix(id, prefix), "/")
+	if len(segments) != 4 || segments[0] != "ns" || segments[2] != "sa" {
+		return "", "", errors.New("SPIFFE ID path must be of the form /ns/<namespace>/sa/<service account>")
+	}
+	return segments[1], segments[3], nil
+}
+
+// IsSPIFFEID returns true if uri uses the SPIFFE scheme, in which case it
+// should be a valid SPIFFE ID.
+func IsSPIFFEID(uri *url.URL) bool {
+	return strings.EqualFold(uri.Scheme, SPIFFEScheme)
+}
+
+func isSPIFFETrustDomainChar(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '.' || c == '-' || c == '_'
+}
+
+func isSPIFFEPathChar(c rune) bool {
+	return isSPIFFETrustDomainChar(c) || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"testing"
+)
+
+func TestValidateSPIFFEID(t *testing.T) {
+	tests := map[string]struct {
+		id      string
+		wantErr bool
+	}{
+		"trust domain only": {
+			id: "spiffe://cluster.local",
+		},
+		"trust domain and path": {
+			id: "spiffe://cluster.local/ns/default/sa/my_Service-1.0",
+		},
+		"wrong scheme": {
+			id:      "https://cluster.local/ns/default",
+			wantErr: true,
+		},
+		"uppercase scheme": {
+			id:      "SPIFFE://cluster.local/ns/default",
+			wantErr: true,
+		},
+		"missing trust domain": {
+			id:      "spiffe:///ns/default",
+			wantErr: true,
+		},
+		"uppercase trust domain": {
+			id:      "spiffe://Cluster.local/ns/default",
+			wantErr: true,
+		},
+		"trust domain with a port": {
+			id:      "spiffe://cluster.local:8443/ns/default",
+			wantErr: true,
+		},
+		"trust domain with userinfo": {
+			id:      "spiffe://user@cluster.local/ns/default",
+			wantErr: true,
+		},
+		"trailing slash": {
+			id:      "spiffe://cluster.local/ns/default/",
+			wantErr: true,
+		},
+		"empty path segment": {
+			id:      "spiffe://cluster.local/ns//default",
+			wantErr: true,
+		},
+		"dot segment": {
+			id:      "spiffe://cluster.local/ns/../default",
+			wantErr: true,
+		},
+		"query": {
+			id:      "spiffe://cluster.local/ns/default?sa=foo",
+			wantErr: true,
+		},
+		"fragment": {
+			id:      "spiffe://cluster.local/ns/default#foo",
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateSPIFFEID(test.id)
+			if test.wantErr != (err != nil) {
+				t.Errorf("expected error: %v, got: %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseServiceAccountSPIFFEID(t *testing.T) {
+	tests := map[string]struct {
+		id                 string
+		wantNamespace      string
+		wantServiceAccount string
+		wantErr            bool
+	}{
+		"service account": {
+			id:                 "spiffe://cluster.local/ns/default/sa/my-app",
+			wantNamespace:      "default",
+			wantServiceAccount: "my-app",
+		},
+		"other trust domain": {
+			id:      "spiffe://example.com/ns/default/sa/my-app",
+			wantErr: true,
+		},
+		"trust domain with the same prefix": {
+			id:      "spiffe://cluster.local.example.com/ns/default/sa/my-app",
+			wantErr: true,
+		},
+		"no path": {
+			id:      "spiffe://cluster.local",
+			wantErr: true,
+		},
+		"not a service account": {
+			id:      "spiffe://cluster.local/workload/my-app",
+			wantErr: true,
+		},
+		"trailing segments": {
+			id:      "spiffe://cluster.local/ns/default/sa/my-app/extra",
+			wantErr: true,
+		},
+		"invalid SPIFFE ID": {
+			id:      "spiffe://cluster.local/ns/default/sa/my app",
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			namespace, serviceAccount, err := ParseServiceAccountSPIFFEID(test.id, "cluster.local")
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error, wantErr=%t, got=%v", test.wantErr, err)
+			}
+			if namespace != test.wantNamespace || serviceAccount != test.wantServiceAccount {
+				t.Errorf("unexpected service account, exp=%s/%s, got=%s/%s", test.wantNamespace, test.wantServiceAccount, namespace, serviceAccount)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiling
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+)
+
+// GoroutinesByLabel returns the number of goroutines which have each value of
+// the given profiler label, along with the total number of goroutines.
+// Goroutines without the label are counted under the empty value.
+func GoroutinesByLabel(key string) (map[string]int, int, error) {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return nil, 0, err
+	}
+	return countGoroutinesByLabel(&buf, key)
+}
+
+// countGoroutinesByLabel parses a goroutine profile written with debug=1, in
+// which each stack is preceded by the number of goroutines with that stack
+// and labels, and followed by the labels if there are any:
+//
+//	3 @ 0x43a0f6 0x44b1b2
+//	# labels: {"controller":"issuers"}
+func countGoroutinesByLabel(profile *bytes.Buffer, key string) (map[string]int, int, error) {
+	labelRegexp := regexp.MustCompile(regexp.QuoteMeta(strconv.Quote(key)) + `:("(?:[^"\\]|\\.)*")`)
+
+	counts := make(map[string]int)
+	total := 0
+	count := 0
+	value := ""
+	flush := func() {
+		counts[value] += count
+		total += count
+		count, value = 0, ""
+	}
+
+	scanner := bufio.NewScanner(profile)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.Contains(line, " @ ") && !strings.HasPrefix(line, "#"):
+			flush()
+			n, err := strconv.Atoi(strings.SplitN(line, " ", 2)[0])
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to parse goroutine profile: %w", err)
+			}
+			count = n
+		case strings.HasPrefix(line, "# labels: "):
+			if m := labelRegexp.FindStringSubmatch(line); m != nil {
+				if v, err := strconv.Unquote(m[1]); err == nil {
+					value = v
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+	flush()
+	return counts, total, nil
+}
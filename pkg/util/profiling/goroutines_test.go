@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoroutinesByLabel(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	started := make(chan struct{})
+	pprof.Do(context.Background(), pprof.Labels("controller", `a "quoted" name`), func(context.Context) {
+		for i := 0; i < 3; i++ {
+			go func() {
+				started <- struct{}{}
+				<-stop
+			}()
+		}
+	})
+	for i := 0; i < 3; i++ {
+		<-started
+	}
+
+	counts, total, err := GoroutinesByLabel("controller")
+	require.NoError(t, err)
+	assert.Equal(t, 3, counts[`a "quoted" name`])
+	assert.Equal(t, total, counts[`a "quoted" name`]+counts[""])
+}
+
+func TestCountGoroutinesByLabel(t *testing.T) {
+	profile := bytes.NewBufferString(`goroutine profile: total 6
+3 @ 0x43a0f6 0x44b1b2
+# labels: {"controller":"issuers", "other":"x"}
+#	0x43a0f5	runtime.gopark+0xd5	/usr/local/go/src/runtime/proc.go:363
+
+2 @ 0x43a0f6
+#	0x43a0f5	runtime.gopark+0xd5	/usr/local/go/src/runtime/proc.go:363
+
+1 @ 0x43a0f6
+# labels: {"other":"y"}
+`)
+	counts, total, err := countGoroutinesByLabel(profile, "controller")
+	require.NoError(t, err)
+	assert.Equal(t, 6, total)
+	assert.Equal(t, map[string]int{"issuers": 3, "": 3}, counts)
+}
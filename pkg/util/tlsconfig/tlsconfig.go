@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tlsconfig provides a tls.Config for Go servers which serve a
+// certificate issued by cert-manager. The certificate is read from the
+// Secret it is stored in, and renewed certificates are served as soon as the
+// Secret is updated, without restarting the server or dropping connections.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	servertls "github.com/cert-manager/cert-manager/pkg/webhook/server/tls"
+)
+
+// readyPollInterval is the interval at which ForSecret checks whether the
+// certificate has been loaded.
+const readyPollInterval = 100 * time.Millisecond
+
+// Reloader serves the certificate stored in the `tls.crt` and `tls.key` keys
+// of a Secret, and reloads it whenever the Secret is updated. If the Secret
+// is deleted or updated with invalid data, the previous certificate
+// continues to be served.
+type Reloader struct {
+	source *servertls.SecretCertificateSource
+}
+
+// NewReloader returns a Reloader for the Secret with the given namespace and
+// name. The Secret is not watched until Run is called.
+func NewReloader(client kubernetes.Interface, namespace, name string) *Reloader {
+	return &Reloader{
+		source: &servertls.SecretCertificateSource{
+			SecretNamespace: namespace,
+			SecretName:      name,
+			Client:          client,
+		},
+	}
+}
+
+// Run watches the Secret until ctx is cancelled.
+func (r *Reloader) Run(ctx context.Context) error {
+	return r.source.Run(ctx)
+}
+
+// Ready returns true once a certificate has been loaded from the Secret.
+func (r *Reloader) Ready() bool {
+	return r.source.Healthy()
+}
+
+// GetCertificate returns the certificate currently stored in the Secret. It
+// can be used as the GetCertificate function of a tls.Config.
+func (r *Reloader) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.source.GetCertificate(hello)
+}
+
+// TLSConfig returns a tls.Config which serves the certificate currently
+// stored in the Secret. The returned tls.Config may be customised, as long
+// as GetCertificate is not replaced.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+	}
+}
+
+// ForSecret watches the Secret with the given namespace and name until ctx is
+// cancelled, and returns a tls.Config serving its certificate. It blocks
+// until the certificate has been loaded, or returns an error if ctx is
+// cancelled before.
+func ForSecret(ctx context.Context, client kubernetes.Interface, namespace, name string) (*tls.Config, error) {
+	r := NewReloader(client, namespace, name)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- r.Run(ctx)
+	}()
+
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+	for !r.Ready() {
+		select {
+		case err := <-errCh:
+			if err == nil {
+				err = ctx.Err()
+			}
+			return nil, err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	return r.TLSConfig(), nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsconfig
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	logtesting "github.com/go-logr/logr/testing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func generatePrivateKeyAndCertificate(t *testing.T, serial string) ([]byte, []byte) {
+	pk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkBytes, err := pki.EncodePrivateKey(pk, cmapi.PKCS8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{SerialNumber: serial, CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	_, cert, err := pki.SignCertificate(tmpl, tmpl, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certBytes, err := pki.EncodeX509(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkBytes, certBytes
+}
+
+func TestForSecret(t *testing.T) {
+	pkBytes, certBytes := generatePrivateKeyAndCertificate(t, "serial1")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "app-tls"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certBytes,
+			corev1.TLSPrivateKeyKey: pkBytes,
+		},
+	}
+	client := fake.NewSimpleClientset(secret)
+	ctx, cancel := context.WithCancel(logr.NewContext(context.Background(), logtesting.NewTestLogger(t)))
+	defer cancel()
+
+	config, err := ForSecret(ctx, client, "app", "app-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	servedSerial := func() (string, error) {
+		cert, err := config.GetCertificate(nil)
+		if err != nil {
+			return "", err
+		}
+		x509Crt, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return "", err
+		}
+		return x509Crt.Subject.SerialNumber, nil
+	}
+	if serial, err := servedSerial(); err != nil || serial != "serial1" {
+		t.Fatalf("expected certificate serial1 to be served once ForSecret returned, got %q: %v", serial, err)
+	}
+
+	pkBytes, certBytes = generatePrivateKeyAndCertificate(t, "serial2")
+	secret = secret.DeepCopy()
+	secret.Data[corev1.TLSCertKey] = certBytes
+	secret.Data[corev1.TLSPrivateKeyKey] = pkBytes
+	if _, err := client.CoreV1().Secrets("app").Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := wait.PollImmediate(time.Millisecond*50, time.Second*5, func() (bool, error) {
+		serial, err := servedSerial()
+		return serial == "serial2", err
+	}); err != nil {
+		t.Fatalf("renewed certificate was not served: %v", err)
+	}
+}
+
+func TestForSecret_NotAvailable(t *testing.T) {
+	ctx, cancel := context.WithTimeout(logr.NewContext(context.Background(), logtesting.NewTestLogger(t)), time.Millisecond*500)
+	defer cancel()
+
+	_, err := ForSecret(ctx, fake.NewSimpleClientset(), "app", "app-tls")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected ForSecret to fail once the context is done, got: %v", err)
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing emits OpenTelemetry spans for the phases of certificate
+// issuance. Spans of different controllers are joined into a single trace by
+// propagating the span context in the annotations of the resources created
+// during issuance: Certificate -> CertificateRequest -> Order -> Challenge.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util"
+)
+
+const (
+	// TraceParentAnnotationKey is the annotation holding the W3C traceparent
+	// of the span which created or last propagated to the resource.
+	TraceParentAnnotationKey = "tracing.cert-manager.io/traceparent"
+	// TraceStateAnnotationKey is the annotation holding the W3C tracestate
+	// accompanying TraceParentAnnotationKey.
+	TraceStateAnnotationKey = "tracing.cert-manager.io/tracestate"
+
+	instrumentationName = "github.com/cert-manager/cert-manager"
+)
+
+// propagator is used to encode span contexts in annotations. The global
+// propagator is not used so that nothing is propagated unless a tracer
+// provider has been configured with Setup.
+var propagator = propagation.TraceContext{}
+
+// Options configures the export of spans.
+type Options struct {
+	// ServiceName is the name of the component emitting spans.
+	ServiceName string
+	// Endpoint is the host and port of the OTLP gRPC receiver.
+	Endpoint string
+	// Insecure disables TLS when connecting to the receiver.
+	Insecure bool
+	// SampleRatio is the fraction of traces started by cert-manager that
+	// are sampled. Traces propagated from annotations follow the sampling
+	// decision of their parent.
+	SampleRatio float64
+}
+
+// Setup installs a tracer provider which exports spans to the OTLP receiver
+// configured in opts. The returned function flushes any remaining spans and
+// must be called on shutdown.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	driverOpts := []otlpgrpc.Option{otlpgrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		driverOpts = append(driverOpts, otlpgrpc.WithInsecure())
+	}
+	exporter, err := otlp.NewExporter(ctx, otlpgrpc.NewDriver(driverOpts...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+		sdktrace.WithResource(sdkresource.NewWithAttributes(
+			semconv.ServiceNameKey.String(opts.ServiceName),
+			semconv.ServiceVersionKey.String(util.AppVersion),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span for a phase of issuance of the given resource. The span
+// is a child of the span in ctx if there is one, otherwise of the span
+// context propagated in the resource's annotations.
+func Start(ctx context.Context, obj metav1.Object, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = Extract(ctx, obj.GetAnnotations())
+	}
+	attrs = append([]attribute.KeyValue{
+		semconv.K8SNamespaceNameKey.String(obj.GetNamespace()),
+		attribute.String("cert-manager.name", obj.GetName()),
+	}, attrs...)
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// IssuerRefAttributes returns the span attributes identifying the issuer.
+func IssuerRefAttributes(ref cmmeta.ObjectReference) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("cert-manager.issuer.name", ref.Name),
+		attribute.String("cert-manager.issuer.kind", ref.Kind),
+		attribute.String("cert-manager.issuer.group", ref.Group),
+	}
+}
+
+// End ends the span, recording err if it is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Inject stores the span context of ctx in the given annotations, so that
+// the controller of the annotated resource continues the trace. Nothing is
+// stored if tracing has not been set up.
+func Inject(ctx context.Context, annotations map[string]string) {
+	propagator.Inject(ctx, annotationCarrier(annotations))
+}
+
+// AnnotationsWithTraceContext returns a copy of the annotations which also
+// stores the span context of ctx. The annotations are returned unchanged if
+// tracing has not been set up.
+func AnnotationsWithTraceContext(ctx context.Context, annotations map[string]string) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return annotations
+	}
+	withTraceContext := make(map[string]string, len(annotations)+len(annotationKeys))
+	for k, v := range annotations {
+		withTraceContext[k] = v
+	}
+	Inject(ctx, withTraceContext)
+	return withTraceContext
+}
+
+// Extract returns a copy of ctx containing the span context stored in the
+// given annotations, if any.
+func Extract(ctx context.Context, annotations map[string]string) context.Context {
+	if annotations == nil {
+		return ctx
+	}
+	return propagator.Extract(ctx, annotationCarrier(annotations))
+}
+
+// annotationCarrier stores the W3C trace context fields under the tracing
+// annotation keys.
+type annotationCarrier map[string]string
+
+var annotationKeys = map[string]string{
+	"traceparent": TraceParentAnnotationKey,
+	"tracestate":  TraceStateAnnotationKey,
+}
+
+func (c annotationCarrier) Get(key string) string {
+	return c[annotationKeys[key]]
+}
+
+func (c annotationCarrier) Set(key, value string) {
+	if annotationKey, ok := annotationKeys[key]; ok && value != "" {
+		c[annotationKey] = value
+	}
+}
+
+func (c annotationCarrier) Keys() []string {
+	var keys []string
+	for field, annotationKey := range annotationKeys {
+		if _, ok := c[annotationKey]; ok {
+			keys = append(keys, field)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func setupTestProvider(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(trace.NewNoopTracerProvider()) })
+	return exporter
+}
+
+func TestPropagationThroughAnnotations(t *testing.T) {
+	exporter := setupTestProvider(t)
+
+	crt := gen.Certificate("test")
+	ctx, crtSpan := Start(context.TODO(), crt, "Certificate.CreateCertificateRequest")
+	annotations := AnnotationsWithTraceContext(ctx, map[string]string{"foo": "bar"})
+	End(crtSpan, nil)
+
+	assert.Equal(t, "bar", annotations["foo"])
+	assert.NotEmpty(t, annotations[TraceParentAnnotationKey])
+
+	cr := gen.CertificateRequest("test", gen.SetCertificateRequestAnnotations(annotations))
+	_, crSpan := Start(context.TODO(), cr, "CertificateRequest.Sign")
+	End(crSpan, errors.New("sign failed"))
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 2) {
+		assert.False(t, spans[0].Parent.IsValid(), "expected Certificate span to be a root span")
+		assert.Equal(t, spans[0].SpanContext.TraceID(), spans[1].SpanContext.TraceID())
+		assert.Equal(t, spans[0].SpanContext.SpanID(), spans[1].Parent.SpanID())
+		assert.True(t, spans[1].Parent.IsRemote())
+		assert.Equal(t, codes.Error, spans[1].StatusCode)
+		assert.Equal(t, "sign failed", spans[1].StatusMessage)
+	}
+}
+
+func TestStartPrefersSpanInContext(t *testing.T) {
+	exporter := setupTestProvider(t)
+
+	ch := gen.Challenge("test")
+	ctx, syncSpan := Start(context.TODO(), ch, "Challenge.Sync")
+	// Annotations of the resource are ignored when ctx already has a span.
+	ch.Annotations = AnnotationsWithTraceContext(ctx, nil)
+	_, presentSpan := Start(ctx, ch, "Challenge.Present")
+	End(presentSpan, nil)
+	End(syncSpan, nil)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "Challenge.Present", spans[0].Name)
+		assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+		assert.False(t, spans[0].Parent.IsRemote())
+	}
+}
+
+func TestTracingDisabled(t *testing.T) {
+	crt := gen.Certificate("test")
+	ctx, span := Start(context.TODO(), crt, "Certificate.CreateCertificateRequest")
+	defer End(span, nil)
+
+	annotations := map[string]string{"foo": "bar"}
+	assert.Equal(t, annotations, AnnotationsWithTraceContext(ctx, annotations))
+	assert.Nil(t, AnnotationsWithTraceContext(ctx, nil))
+
+	Inject(ctx, annotations)
+	assert.Equal(t, map[string]string{"foo": "bar"}, annotations)
+}
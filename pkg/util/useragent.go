@@ -19,12 +19,15 @@ package util
 import (
 	"bytes"
 	"fmt"
+	"net/http"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/client-go/rest"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
 // RestConfigWithUserAgent returns a copy of the Kubernetes REST config with
@@ -58,3 +61,39 @@ func PrefixFromUserAgent(u string) string {
 	}
 	return buf.String()
 }
+
+// IssuerHTTPClientRoundTripper returns a RoundTripper which appends the
+// User-Agent suffix and adds the headers configured by an issuer's
+// HTTPClient to every request before passing it to rt. If config is nil, rt
+// is returned unchanged.
+func IssuerHTTPClientRoundTripper(rt http.RoundTripper, config *cmapi.IssuerHTTPClient) http.RoundTripper {
+	if config == nil {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &issuerHTTPClientRoundTripper{wrapped: rt, config: config.DeepCopy()}
+}
+
+type issuerHTTPClientRoundTripper struct {
+	wrapped http.RoundTripper
+	config  *cmapi.IssuerHTTPClient
+}
+
+// RoundTrip implements http.RoundTripper. The request is cloned before its
+// headers are modified, as required of RoundTrippers.
+func (rt *issuerHTTPClientRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for _, h := range rt.config.Headers {
+		req.Header.Set(h.Name, h.Value)
+	}
+	if rt.config.UserAgentSuffix != "" {
+		userAgent := req.Header.Get("User-Agent")
+		if userAgent == "" {
+			userAgent = "cert-manager/" + version()
+		}
+		req.Header.Set("User-Agent", userAgent+" "+rt.config.UserAgentSuffix)
+	}
+	return rt.wrapped.RoundTrip(req)
+}
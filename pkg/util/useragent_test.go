@@ -17,11 +17,16 @@ limitations under the License.
 package util
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/client-go/rest"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
 func Test_RestConfigWithUserAgent(t *testing.T) {
@@ -86,3 +91,64 @@ func Test_PrefixFromUserAgent(t *testing.T) {
 		})
 	}
 }
+
+func Test_IssuerHTTPClientRoundTripper(t *testing.T) {
+	AppGitCommit = "test-commit"
+
+	tests := map[string]struct {
+		config       *cmapi.IssuerHTTPClient
+		userAgent    string
+		expUserAgent string
+		expHeaders   map[string]string
+	}{
+		"if no config is given, the request is not modified": {
+			userAgent:    "acme-client",
+			expUserAgent: "acme-client",
+		},
+		"if a suffix is given, it is appended to the User-Agent of the request": {
+			config:       &cmapi.IssuerHTTPClient{UserAgentSuffix: "team-a/1.0"},
+			userAgent:    "acme-client",
+			expUserAgent: "acme-client team-a/1.0",
+		},
+		"if the request has no User-Agent, the suffix is appended to the cert-manager version": {
+			config:       &cmapi.IssuerHTTPClient{UserAgentSuffix: "team-a/1.0"},
+			expUserAgent: "cert-manager/canary-test-commit team-a/1.0",
+		},
+		"headers are added to the request": {
+			config: &cmapi.IssuerHTTPClient{Headers: []cmapi.HTTPHeader{
+				{Name: "X-Trace-Id", Value: "abc"},
+				{Name: "X-Route", Value: "ca"},
+			}},
+			userAgent:    "acme-client",
+			expUserAgent: "acme-client",
+			expHeaders:   map[string]string{"X-Trace-Id": "abc", "X-Route": "ca"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+			// An empty User-Agent header stops the Go client from setting its
+			// own default.
+			req.Header["User-Agent"] = []string{test.userAgent}
+
+			client := &http.Client{Transport: IssuerHTTPClientRoundTripper(http.DefaultTransport, test.config)}
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, test.expUserAgent, got.Get("User-Agent"))
+			for k, v := range test.expHeaders {
+				assert.Equal(t, v, got.Get(k))
+			}
+			assert.Equal(t, []string{test.userAgent}, req.Header["User-Agent"], "the original request must not be modified")
+		})
+	}
+}
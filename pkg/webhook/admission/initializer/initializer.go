@@ -27,25 +27,34 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/component-base/featuregate"
 
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 )
 
 type pluginInitializer struct {
 	externalClient    kubernetes.Interface
 	externalInformers informers.SharedInformerFactory
+	cmClient          cmclient.Interface
 	authorizer        authorizer.Authorizer
 	featureGates      featuregate.FeatureGate
+
+	clusterResourceNamespace   string
+	disablePolicyCoveredChecks bool
 }
 
 // New creates an instance of admission plugins initializer.
 // This constructor is public with a long param list so that callers immediately know that new information can be expected
 // during compilation when they update a level.
-func New(extClientset kubernetes.Interface, extInformers informers.SharedInformerFactory, authz authorizer.Authorizer, featureGates featuregate.FeatureGate) pluginInitializer {
+func New(extClientset kubernetes.Interface, extInformers informers.SharedInformerFactory, cmClientset cmclient.Interface, authz authorizer.Authorizer, featureGates featuregate.FeatureGate, clusterResourceNamespace string, disablePolicyCoveredChecks bool) pluginInitializer {
 	return pluginInitializer{
 		externalClient:    extClientset,
 		externalInformers: extInformers,
+		cmClient:          cmClientset,
 		authorizer:        authz,
 		featureGates:      featureGates,
+
+		clusterResourceNamespace:   clusterResourceNamespace,
+		disablePolicyCoveredChecks: disablePolicyCoveredChecks,
 	}
 }
 
@@ -65,9 +74,21 @@ func (i pluginInitializer) Initialize(plugin admission.Interface) {
 		wants.SetExternalKubeInformerFactory(i.externalInformers)
 	}
 
+	if wants, ok := plugin.(WantsCertManagerClientSet); ok {
+		wants.SetCertManagerClientSet(i.cmClient)
+	}
+
 	if wants, ok := plugin.(WantsAuthorizer); ok {
 		wants.SetAuthorizer(i.authorizer)
 	}
+
+	if wants, ok := plugin.(WantsClusterResourceNamespace); ok {
+		wants.SetClusterResourceNamespace(i.clusterResourceNamespace)
+	}
+
+	if wants, ok := plugin.(WantsDisablePolicyCoveredChecks); ok {
+		wants.SetDisablePolicyCoveredChecks(i.disablePolicyCoveredChecks)
+	}
 }
 
 var _ admission.PluginInitializer = pluginInitializer{}
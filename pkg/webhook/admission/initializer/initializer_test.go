@@ -34,6 +34,8 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/component-base/featuregate"
 
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
 )
@@ -41,7 +43,7 @@ import (
 // TestWantsFeature ensures that the feature gates are injected
 // when the WantsFeatures interface is implemented by a plugin.
 func TestWantsFeatures(t *testing.T) {
-	target := initializer.New(nil, nil, nil, featuregate.NewFeatureGate())
+	target := initializer.New(nil, nil, nil, nil, featuregate.NewFeatureGate(), "", false)
 	wantFeaturesAdmission := &WantsFeaturesAdmission{}
 	target.Initialize(wantFeaturesAdmission)
 	if wantFeaturesAdmission.features == nil {
@@ -52,7 +54,7 @@ func TestWantsFeatures(t *testing.T) {
 // TestWantsAuthorizer ensures that the authorizer is injected
 // when the WantsAuthorizer interface is implemented by a plugin.
 func TestWantsAuthorizer(t *testing.T) {
-	target := initializer.New(nil, nil, &TestAuthorizer{}, nil)
+	target := initializer.New(nil, nil, nil, &TestAuthorizer{}, nil, "", false)
 	wantAuthorizerAdmission := &WantAuthorizerAdmission{}
 	target.Initialize(wantAuthorizerAdmission)
 	if wantAuthorizerAdmission.auth == nil {
@@ -64,7 +66,7 @@ func TestWantsAuthorizer(t *testing.T) {
 // when the WantsExternalKubeClientSet interface is implemented by a plugin.
 func TestWantsExternalKubeClientSet(t *testing.T) {
 	cs := &fake.Clientset{}
-	target := initializer.New(cs, nil, &TestAuthorizer{}, nil)
+	target := initializer.New(cs, nil, nil, &TestAuthorizer{}, nil, "", false)
 	wantExternalKubeClientSet := &WantExternalKubeClientSet{}
 	target.Initialize(wantExternalKubeClientSet)
 	if wantExternalKubeClientSet.cs != cs {
@@ -77,7 +79,7 @@ func TestWantsExternalKubeClientSet(t *testing.T) {
 func TestWantsExternalKubeInformerFactory(t *testing.T) {
 	cs := &fake.Clientset{}
 	sf := informers.NewSharedInformerFactory(cs, time.Duration(1)*time.Second)
-	target := initializer.New(cs, sf, &TestAuthorizer{}, nil)
+	target := initializer.New(cs, sf, nil, &TestAuthorizer{}, nil, "", false)
 	wantExternalKubeInformerFactory := &WantExternalKubeInformerFactory{}
 	target.Initialize(wantExternalKubeInformerFactory)
 	if wantExternalKubeInformerFactory.sf != sf {
@@ -85,6 +87,94 @@ func TestWantsExternalKubeInformerFactory(t *testing.T) {
 	}
 }
 
+// TestWantsCertManagerClientSet ensures that the cert-manager clientset is
+// injected when the WantsCertManagerClientSet interface is implemented by a
+// plugin.
+func TestWantsCertManagerClientSet(t *testing.T) {
+	cs := cmfake.NewSimpleClientset()
+	target := initializer.New(nil, nil, cs, &TestAuthorizer{}, nil, "", false)
+	wantCertManagerClientSet := &WantCertManagerClientSet{}
+	target.Initialize(wantCertManagerClientSet)
+	if wantCertManagerClientSet.cs != cs {
+		t.Errorf("expected cert-manager clientset to be initialized")
+	}
+}
+
+// TestWantsClusterResourceNamespace ensures that the cluster resource namespace
+// is injected when the WantsClusterResourceNamespace interface is implemented
+// by a plugin.
+func TestWantsClusterResourceNamespace(t *testing.T) {
+	target := initializer.New(nil, nil, nil, &TestAuthorizer{}, nil, "cert-manager", false)
+	wantClusterResourceNamespace := &WantClusterResourceNamespace{}
+	target.Initialize(wantClusterResourceNamespace)
+	if wantClusterResourceNamespace.namespace != "cert-manager" {
+		t.Errorf("expected cluster resource namespace to be initialized")
+	}
+}
+
+// WantClusterResourceNamespace is a test stub that fulfills the WantsClusterResourceNamespace interface
+type WantClusterResourceNamespace struct {
+	namespace string
+}
+
+func (self *WantClusterResourceNamespace) SetClusterResourceNamespace(namespace string) {
+	self.namespace = namespace
+}
+func (self *WantClusterResourceNamespace) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (warnings []string, err error) {
+	return nil, nil
+}
+func (self *WantClusterResourceNamespace) Handles(o admissionv1.Operation) bool { return false }
+func (self *WantClusterResourceNamespace) ValidateInitialization() error        { return nil }
+
+var _ admission.Interface = &WantClusterResourceNamespace{}
+var _ initializer.WantsClusterResourceNamespace = &WantClusterResourceNamespace{}
+
+// TestWantsDisablePolicyCoveredChecks ensures that whether to disable policy
+// covered checks is injected when the WantsDisablePolicyCoveredChecks
+// interface is implemented by a plugin.
+func TestWantsDisablePolicyCoveredChecks(t *testing.T) {
+	target := initializer.New(nil, nil, nil, &TestAuthorizer{}, nil, "", true)
+	wantDisablePolicyCoveredChecks := &WantDisablePolicyCoveredChecks{}
+	target.Initialize(wantDisablePolicyCoveredChecks)
+	if !wantDisablePolicyCoveredChecks.disabled {
+		t.Errorf("expected policy covered checks to be disabled")
+	}
+}
+
+// WantDisablePolicyCoveredChecks is a test stub that fulfills the WantsDisablePolicyCoveredChecks interface
+type WantDisablePolicyCoveredChecks struct {
+	disabled bool
+}
+
+func (self *WantDisablePolicyCoveredChecks) SetDisablePolicyCoveredChecks(disabled bool) {
+	self.disabled = disabled
+}
+func (self *WantDisablePolicyCoveredChecks) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (warnings []string, err error) {
+	return nil, nil
+}
+func (self *WantDisablePolicyCoveredChecks) Handles(o admissionv1.Operation) bool { return false }
+func (self *WantDisablePolicyCoveredChecks) ValidateInitialization() error        { return nil }
+
+var _ admission.Interface = &WantDisablePolicyCoveredChecks{}
+var _ initializer.WantsDisablePolicyCoveredChecks = &WantDisablePolicyCoveredChecks{}
+
+// WantCertManagerClientSet is a test stub that fulfills the WantsCertManagerClientSet interface
+type WantCertManagerClientSet struct {
+	cs cmclient.Interface
+}
+
+func (self *WantCertManagerClientSet) SetCertManagerClientSet(cs cmclient.Interface) {
+	self.cs = cs
+}
+func (self *WantCertManagerClientSet) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (warnings []string, err error) {
+	return nil, nil
+}
+func (self *WantCertManagerClientSet) Handles(o admissionv1.Operation) bool { return false }
+func (self *WantCertManagerClientSet) ValidateInitialization() error        { return nil }
+
+var _ admission.Interface = &WantCertManagerClientSet{}
+var _ initializer.WantsCertManagerClientSet = &WantCertManagerClientSet{}
+
 // WantExternalKubeInformerFactory is a test stub that fulfills the WantsExternalKubeInformerFactory interface
 type WantExternalKubeInformerFactory struct {
 	sf informers.SharedInformerFactory
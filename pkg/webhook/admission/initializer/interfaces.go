@@ -28,6 +28,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/component-base/featuregate"
 
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 )
 
@@ -43,12 +44,33 @@ type WantsExternalKubeInformerFactory interface {
 	admission.InitializationValidator
 }
 
+// WantsCertManagerClientSet defines a function which sets the cert-manager ClientSet for admission plugins that need it
+type WantsCertManagerClientSet interface {
+	SetCertManagerClientSet(cmclient.Interface)
+	admission.InitializationValidator
+}
+
 // WantsAuthorizer defines a function which sets Authorizer for admission plugins that need it.
 type WantsAuthorizer interface {
 	SetAuthorizer(authorizer.Authorizer)
 	admission.InitializationValidator
 }
 
+// WantsClusterResourceNamespace defines a function which sets the namespace of
+// the Secrets referenced by ClusterIssuers for admission plugins that need it.
+type WantsClusterResourceNamespace interface {
+	SetClusterResourceNamespace(string)
+	admission.InitializationValidator
+}
+
+// WantsDisablePolicyCoveredChecks defines a function which tells admission
+// plugins whether to skip the checks which are also made by the
+// ValidatingAdmissionPolicies of cert-manager.
+type WantsDisablePolicyCoveredChecks interface {
+	SetDisablePolicyCoveredChecks(bool)
+	admission.InitializationValidator
+}
+
 // WantsQuotaConfiguration defines a function which sets quota configuration for admission plugins that need it.
 type WantsQuotaConfiguration interface {
 	SetQuotaConfiguration(quota.Configuration)
@@ -18,8 +18,12 @@ package handlers
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	goruntime "runtime"
+	"sync"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
@@ -29,14 +33,54 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	apijson "k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"k8s.io/apimachinery/pkg/runtime/serializer/versioning"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/lru"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+// parallelConversionThreshold is the number of objects in a ConversionRequest
+// above which the objects are converted in parallel. The apiserver sends all
+// of the objects of a list request which need converting in a single
+// ConversionRequest, so converting them in sequence dominates the latency of
+// listing large numbers of resources.
+const parallelConversionThreshold = 32
+
+// convertedObjectCacheSize is the maximum number of converted objects which
+// are kept in memory, so that unchanged objects returned by repeated list
+// requests do not need to be decoded and encoded again.
+const convertedObjectCacheSize = 4096
+
+// convertedObjectKey identifies the result of converting a particular
+// revision of an object to a GroupVersion.
+type convertedObjectKey struct {
+	uid             types.UID
+	resourceVersion string
+	desiredGV       schema.GroupVersion
+}
+
+// objectHeader holds the fields of an object which identify it in the
+// cache, so that they can be read without decoding the whole object.
+type objectHeader struct {
+	Metadata struct {
+		UID             types.UID `json:"uid"`
+		ResourceVersion string    `json:"resourceVersion"`
+	} `json:"metadata"`
+}
+
 type SchemeBackedConverter struct {
 	log        logr.Logger
 	scheme     *runtime.Scheme
 	serializer *apijson.Serializer
+
+	// codecs caches the codec used to convert objects to each desired
+	// GroupVersion, so that it is not built for every ConversionRequest.
+	codecs sync.Map // map[schema.GroupVersion]runtime.Codec
+
+	// converted caches the result of converting objects, keyed by their UID,
+	// resourceVersion and the desired GroupVersion.
+	converted *lru.Cache
 }
 
 var _ ConversionHook = &SchemeBackedConverter{}
@@ -47,18 +91,16 @@ func NewSchemeBackedConverter(log logr.Logger, scheme *runtime.Scheme) *SchemeBa
 		log:        log,
 		scheme:     scheme,
 		serializer: serializer,
+		converted:  lru.New(convertedObjectCacheSize),
 	}
 }
 
-func (c *SchemeBackedConverter) convertObjects(desiredAPIVersion string, objects []runtime.RawExtension) ([]runtime.RawExtension, error) {
-	desiredGV, err := schema.ParseGroupVersion(desiredAPIVersion)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to parse desired apiVersion: %v", err)
+// codecFor returns the codec which converts objects to the desired
+// GroupVersion.
+func (c *SchemeBackedConverter) codecFor(desiredGV schema.GroupVersion) runtime.Codec {
+	if codec, ok := c.codecs.Load(desiredGV); ok {
+		return codec.(runtime.Codec)
 	}
-
-	c.log.V(logf.DebugLevel).Info("Parsed desired groupVersion", "desired_group_version", desiredGV)
-
-	groupVersioner := schema.GroupVersions([]schema.GroupVersion{desiredGV})
 	codec := versioning.NewCodec(
 		c.serializer,
 		c.serializer,
@@ -66,26 +108,82 @@ func (c *SchemeBackedConverter) convertObjects(desiredAPIVersion string, objects
 		c.scheme,
 		c.scheme,
 		nil,
-		groupVersioner,
+		schema.GroupVersions([]schema.GroupVersion{desiredGV}),
 		runtime.InternalGroupVersioner, c.scheme.Name(),
 	)
+	cached, _ := c.codecs.LoadOrStore(desiredGV, codec)
+	return cached.(runtime.Codec)
+}
+
+func (c *SchemeBackedConverter) convertObjects(desiredAPIVersion string, objects []runtime.RawExtension) ([]runtime.RawExtension, error) {
+	desiredGV, err := schema.ParseGroupVersion(desiredAPIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse desired apiVersion: %v", err)
+	}
+
+	c.log.V(logf.DebugLevel).Info("Parsed desired groupVersion", "desired_group_version", desiredGV, "objects", len(objects))
 
+	codec := c.codecFor(desiredGV)
 	convertedObjects := make([]runtime.RawExtension, len(objects))
-	for i, raw := range objects {
-		decodedObject, currentGVK, err := codec.Decode(raw.Raw, nil, nil)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to decode into apiVersion: %v", err)
+	errs := make([]error, len(objects))
+	convert := func(i int) {
+		convertedObjects[i], errs[i] = c.convertObject(codec, desiredGV, objects[i])
+	}
+
+	if len(objects) > parallelConversionThreshold {
+		workqueue.ParallelizeUntil(context.Background(), goruntime.GOMAXPROCS(0), len(objects), convert)
+	} else {
+		for i := range objects {
+			convert(i)
 		}
-		c.log.V(logf.DebugLevel).Info("Decoded resource", "decoded_group_version_kind", currentGVK)
-		buf := bytes.Buffer{}
-		if err := codec.Encode(decodedObject, &buf); err != nil {
-			return nil, fmt.Errorf("Failed to convert to desired apiVersion: %v", err)
+	}
+
+	// Return the error of the first object which failed to convert, as
+	// would have been returned if the objects had been converted in sequence.
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
 		}
-		convertedObjects[i] = runtime.RawExtension{Raw: buf.Bytes()}
 	}
 	return convertedObjects, nil
 }
 
+// convertObject converts a single object to the desired GroupVersion. Objects
+// which have already been converted at the same resourceVersion are returned
+// from the cache rather than being decoded and encoded again.
+func (c *SchemeBackedConverter) convertObject(codec runtime.Codec, desiredGV schema.GroupVersion, raw runtime.RawExtension) (runtime.RawExtension, error) {
+	// Objects without a UID or resourceVersion (e.g. objects which are being
+	// created) can't be identified, so are always converted. Objects which
+	// fail to parse are left for the codec to report the error.
+	var header objectHeader
+	_ = json.Unmarshal(raw.Raw, &header)
+	cacheable := len(header.Metadata.UID) > 0 && len(header.Metadata.ResourceVersion) > 0
+	key := convertedObjectKey{
+		uid:             header.Metadata.UID,
+		resourceVersion: header.Metadata.ResourceVersion,
+		desiredGV:       desiredGV,
+	}
+	if cacheable {
+		if converted, ok := c.converted.Get(key); ok {
+			return runtime.RawExtension{Raw: converted.([]byte)}, nil
+		}
+	}
+
+	decodedObject, currentGVK, err := codec.Decode(raw.Raw, nil, nil)
+	if err != nil {
+		return runtime.RawExtension{}, fmt.Errorf("Failed to decode into apiVersion: %v", err)
+	}
+	c.log.V(logf.DebugLevel).Info("Decoded resource", "decoded_group_version_kind", currentGVK)
+	buf := bytes.NewBuffer(make([]byte, 0, len(raw.Raw)))
+	if err := codec.Encode(decodedObject, buf); err != nil {
+		return runtime.RawExtension{}, fmt.Errorf("Failed to convert to desired apiVersion: %v", err)
+	}
+	if cacheable {
+		c.converted.Add(key, buf.Bytes())
+	}
+	return runtime.RawExtension{Raw: buf.Bytes()}, nil
+}
+
 func (c *SchemeBackedConverter) Convert(conversionSpec *apiextensionsv1.ConversionRequest) *apiextensionsv1.ConversionResponse {
 	result := metav1.Status{Status: metav1.StatusSuccess}
 	convertedObjects, err := c.convertObjects(conversionSpec.DesiredAPIVersion, conversionSpec.Objects)
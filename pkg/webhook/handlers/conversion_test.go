@@ -17,6 +17,7 @@ limitations under the License.
 package handlers
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -224,3 +225,102 @@ func TestConvertTestType(t *testing.T) {
 		})
 	}
 }
+
+func testTypeObjects(n int) []runtime.RawExtension {
+	objects := make([]runtime.RawExtension, n)
+	for i := range objects {
+		objects[i] = runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"apiVersion":"testgroup.testing.cert-manager.io/v1","kind":"TestType","metadata":{"name":"testing-%d","namespace":"abc","creationTimestamp":null},"testField":"atest","testFieldPtr":"something"}`, i))}
+	}
+	return objects
+}
+
+func TestConvertTestTypeInParallel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	c := NewSchemeBackedConverter(klogr.New(), scheme)
+
+	objects := testTypeObjects(parallelConversionThreshold * 4)
+	resp := c.Convert(&apiextensionsv1.ConversionRequest{
+		DesiredAPIVersion: testgroup.GroupName + "/v2",
+		Objects:           objects,
+	})
+	if resp.Result.Status != metav1.StatusSuccess {
+		t.Fatalf("unexpected failure: %s", resp.Result.Message)
+	}
+	if len(resp.ConvertedObjects) != len(objects) {
+		t.Fatalf("expected %d converted objects, got %d", len(objects), len(resp.ConvertedObjects))
+	}
+	for i, obj := range resp.ConvertedObjects {
+		exp := fmt.Sprintf(`{"kind":"TestType","apiVersion":"testgroup.testing.cert-manager.io/v2","metadata":{"name":"testing-%d","namespace":"abc","creationTimestamp":null},"testField":"atest","testFieldPtrAlt":"something","testFieldImmutable":""}
+`, i)
+		if string(obj.Raw) != exp {
+			t.Errorf("unexpected converted object %d: %s", i, obj.Raw)
+		}
+	}
+
+	// The error of the first object which fails to convert is returned.
+	objects[50] = runtime.RawExtension{Raw: []byte(`{"apiVersion":"testgroup.testing.cert-manager.io/v1","kind":"UnknownType"}`)}
+	objects[70] = runtime.RawExtension{Raw: []byte(`{`)}
+	expResp := c.Convert(&apiextensionsv1.ConversionRequest{
+		DesiredAPIVersion: testgroup.GroupName + "/v2",
+		Objects:           objects[50:51],
+	})
+	resp = c.Convert(&apiextensionsv1.ConversionRequest{
+		DesiredAPIVersion: testgroup.GroupName + "/v2",
+		Objects:           objects,
+	})
+	if !reflect.DeepEqual(expResp, resp) {
+		t.Errorf("Response was not as expected: %v", diff.ObjectGoPrintSideBySide(expResp, resp))
+	}
+}
+
+func TestConvertTestTypeCachesConvertedObjects(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	c := NewSchemeBackedConverter(klogr.New(), scheme)
+
+	convert := func(resourceVersion, testField string) string {
+		resp := c.Convert(&apiextensionsv1.ConversionRequest{
+			DesiredAPIVersion: testgroup.GroupName + "/v2",
+			Objects:           []runtime.RawExtension{{Raw: []byte(fmt.Sprintf(`{"apiVersion":"testgroup.testing.cert-manager.io/v1","kind":"TestType","metadata":{"name":"testing","namespace":"abc","uid":"abc-123","resourceVersion":%q,"creationTimestamp":null},"testField":%q}`, resourceVersion, testField))}},
+		})
+		if resp.Result.Status != metav1.StatusSuccess {
+			t.Fatalf("unexpected failure: %s", resp.Result.Message)
+		}
+		return string(resp.ConvertedObjects[0].Raw)
+	}
+	expected := func(resourceVersion, testField string) string {
+		return fmt.Sprintf(`{"kind":"TestType","apiVersion":"testgroup.testing.cert-manager.io/v2","metadata":{"name":"testing","namespace":"abc","uid":"abc-123","resourceVersion":%q,"creationTimestamp":null},"testField":%q,"testFieldImmutable":""}
+`, resourceVersion, testField)
+	}
+
+	if got, exp := convert("1", "first"), expected("1", "first"); got != exp {
+		t.Errorf("unexpected converted object: %s", got)
+	}
+	// An object at the same resourceVersion cannot have changed, so the
+	// cached result is returned without converting it again.
+	if got, exp := convert("1", "second"), expected("1", "first"); got != exp {
+		t.Errorf("expected cached converted object, got: %s", got)
+	}
+	// A new resourceVersion is converted again.
+	if got, exp := convert("2", "second"), expected("2", "second"); got != exp {
+		t.Errorf("unexpected converted object: %s", got)
+	}
+}
+
+func BenchmarkConvertTestType(b *testing.B) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	c := NewSchemeBackedConverter(klogr.New(), scheme)
+	request := &apiextensionsv1.ConversionRequest{
+		DesiredAPIVersion: testgroup.GroupName + "/v2",
+		Objects:           testTypeObjects(1000),
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if resp := c.Convert(request); resp.Result.Status != metav1.StatusSuccess {
+			b.Fatalf("unexpected failure: %s", resp.Result.Message)
+		}
+	}
+}
@@ -309,9 +309,9 @@ func (s *Server) handle(inner handleFunc) func(w http.ResponseWriter, req *http.
 			return
 		}
 
-		codec := json.NewSerializerWithOptions(json.DefaultMetaFactory, s.scheme(), s.scheme(), json.SerializerOptions{
-			Pretty: true,
-		})
+		// Responses are not pretty printed, as indenting ConversionReviews
+		// containing thousands of objects adds noticeable latency.
+		codec := json.NewSerializerWithOptions(json.DefaultMetaFactory, s.scheme(), s.scheme(), json.SerializerOptions{})
 		obj, _, err := codec.Decode(data, nil, nil)
 		if err != nil {
 			s.log.Error(err, "failed to decode request body")
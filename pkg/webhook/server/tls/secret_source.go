@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tls
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// SecretCertificateSource provides certificate data for a golang HTTP server
+// by watching a Secret resource containing a certificate which is issued and
+// renewed externally, such as by a central PKI. The certificate is reloaded
+// whenever the Secret is updated.
+type SecretCertificateSource struct {
+	// Namespace and Name of the Secret resource containing the certificate
+	// and private key in its `tls.crt` and `tls.key` keys.
+	SecretNamespace, SecretName string
+
+	// RESTConfig used to connect to the apiserver.
+	RESTConfig *rest.Config
+
+	// Client is used to connect to the apiserver instead of RESTConfig if
+	// set.
+	Client kubernetes.Interface
+
+	log logr.Logger
+
+	cachedCertificate *tls.Certificate
+	cachedCertBytes   []byte
+	cachedKeyBytes    []byte
+	lock              sync.Mutex
+}
+
+var _ CertificateSource = &SecretCertificateSource{}
+
+func (f *SecretCertificateSource) Run(ctx context.Context) error {
+	f.log = logf.FromContext(ctx).WithValues("secret_namespace", f.SecretNamespace, "secret_name", f.SecretName)
+	if f.SecretNamespace == "" {
+		return fmt.Errorf("SecretNamespace must be set")
+	}
+	if f.SecretName == "" {
+		return fmt.Errorf("SecretName must be set")
+	}
+
+	cl := f.Client
+	if cl == nil {
+		var err error
+		if cl, err = kubernetes.NewForConfig(f.RESTConfig); err != nil {
+			return err
+		}
+	}
+
+	escapedName := fields.EscapeValue(f.SecretName)
+	factory := informers.NewSharedInformerFactoryWithOptions(cl, time.Minute,
+		informers.WithNamespace(f.SecretNamespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = "metadata.name=" + escapedName
+		}),
+	)
+	informer := factory.Core().V1().Secrets().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    f.handleAddOrUpdate,
+		UpdateFunc: func(_, obj interface{}) { f.handleAddOrUpdate(obj) },
+		DeleteFunc: f.handleDelete,
+	})
+
+	// start the informers and wait for the cache to sync
+	factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("failed waiting for informer caches to sync")
+	}
+
+	if !f.Healthy() {
+		f.log.Error(nil, "serving certificate Secret does not exist or is invalid, waiting for it to be updated")
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+func (f *SecretCertificateSource) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.cachedCertificate == nil {
+		return nil, ErrNotAvailable
+	}
+	return f.cachedCertificate, nil
+}
+
+func (f *SecretCertificateSource) Healthy() bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.cachedCertificate != nil
+}
+
+func (f *SecretCertificateSource) handleAddOrUpdate(obj interface{}) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return
+	}
+	if err := f.updateCertificateFromSecret(secret); err != nil {
+		f.log.Error(err, "failed to update certificate from Secret, continuing to serve the previous certificate")
+	}
+}
+
+func (f *SecretCertificateSource) handleDelete(obj interface{}) {
+	f.log.Error(nil, "serving certificate Secret has been deleted, continuing to serve the previous certificate")
+}
+
+// updateCertificateFromSecret updates the cached tls.Certificate if the data
+// in the Secret has changed.
+func (f *SecretCertificateSource) updateCertificateFromSecret(secret *corev1.Secret) error {
+	certData := secret.Data[corev1.TLSCertKey]
+	keyData := secret.Data[corev1.TLSPrivateKeyKey]
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if bytes.Equal(keyData, f.cachedKeyBytes) && bytes.Equal(certData, f.cachedCertBytes) {
+		f.log.V(logf.DebugLevel).Info("key and certificate in Secret have not changed")
+		return nil
+	}
+	f.log.V(logf.InfoLevel).Info("detected private key or certificate data in Secret has changed. reloading certificate")
+
+	cert, err := tls.X509KeyPair(certData, keyData)
+	if err != nil {
+		return err
+	}
+
+	f.cachedCertBytes = certData
+	f.cachedKeyBytes = keyData
+	f.cachedCertificate = &cert
+
+	return nil
+}
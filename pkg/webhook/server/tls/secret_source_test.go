@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tls
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	logtesting "github.com/go-logr/logr/testing"
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSecretSource_UpdatesCertificate(t *testing.T) {
+	pkBytes, certBytes := generatePrivateKeyAndCertificate(t, "serial1")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cert-manager", Name: "webhook-tls"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certBytes,
+			corev1.TLSPrivateKeyKey: pkBytes,
+		},
+	}
+	client := fake.NewSimpleClientset(secret)
+
+	source := SecretCertificateSource{
+		SecretNamespace: "cert-manager",
+		SecretName:      "webhook-tls",
+		Client:          client,
+	}
+	ctx, cancel := context.WithCancel(logr.NewContext(context.Background(), logtesting.NewTestLogger(t)))
+	errGroup := new(errgroup.Group)
+	errGroup.Go(func() error {
+		return source.Run(ctx)
+	})
+	defer func() {
+		cancel()
+		if err := errGroup.Wait(); err != nil {
+			t.Errorf("SecretCertificateSource failed %v", err)
+		}
+	}()
+
+	waitForSerial := func(serial string) {
+		if err := wait.PollImmediate(time.Millisecond*50, time.Second*5, func() (bool, error) {
+			cert, err := source.GetCertificate(nil)
+			if err != nil {
+				return false, nil
+			}
+			x509Crt, err := x509.ParseCertificate(cert.Certificate[0])
+			if err != nil {
+				return false, err
+			}
+			return x509Crt.Subject.SerialNumber == serial, nil
+		}); err != nil {
+			t.Fatalf("certificate with serial number %s was not served: %v", serial, err)
+		}
+	}
+
+	waitForSerial("serial1")
+	if !source.Healthy() {
+		t.Errorf("expected source to be healthy")
+	}
+
+	pkBytes, certBytes = generatePrivateKeyAndCertificate(t, "serial2")
+	secret = secret.DeepCopy()
+	secret.Data[corev1.TLSCertKey] = certBytes
+	secret.Data[corev1.TLSPrivateKeyKey] = pkBytes
+	if _, err := client.CoreV1().Secrets("cert-manager").Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitForSerial("serial2")
+
+	// Invalid data is ignored and the previous certificate is still served.
+	secret = secret.DeepCopy()
+	secret.Data[corev1.TLSPrivateKeyKey] = []byte("invalid")
+	if _, err := client.CoreV1().Secrets("cert-manager").Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond * 200)
+	waitForSerial("serial2")
+}
+
+func TestSecretSource_NotAvailable(t *testing.T) {
+	source := SecretCertificateSource{
+		SecretNamespace: "cert-manager",
+		SecretName:      "webhook-tls",
+		Client:          fake.NewSimpleClientset(),
+	}
+	ctx, cancel := context.WithCancel(logr.NewContext(context.Background(), logtesting.NewTestLogger(t)))
+	errGroup := new(errgroup.Group)
+	errGroup.Go(func() error {
+		return source.Run(ctx)
+	})
+
+	if _, err := source.GetCertificate(nil); err != ErrNotAvailable {
+		t.Errorf("expected ErrNotAvailable, got %v", err)
+	}
+	if source.Healthy() {
+		t.Errorf("expected source to not be healthy")
+	}
+	cancel()
+	if err := errGroup.Wait(); err != nil {
+		t.Errorf("SecretCertificateSource failed %v", err)
+	}
+}
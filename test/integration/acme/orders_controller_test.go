@@ -26,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
 	accountstest "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
@@ -129,6 +130,7 @@ func TestAcmeOrdersController(t *testing.T) {
 		clock.RealClock{},
 		false,
 		"cert-manager-test",
+		workqueue.DefaultControllerRateLimiter(),
 	)
 	c := controllerpkg.NewController(
 		ctx,
@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 	"k8s.io/utils/pointer"
 
@@ -72,7 +73,7 @@ func TestIssuingController(t *testing.T) {
 
 	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient,
 		cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
-		controllerOptions, "cert-manage-certificates-issuing-test")
+		metrics.New(logf.Log, clock.RealClock{}), controllerOptions, "cert-manage-certificates-issuing-test", workqueue.DefaultControllerRateLimiter())
 	c := controllerpkg.NewController(
 		ctx,
 		"issuing_test",
@@ -205,7 +206,7 @@ func TestIssuingController(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Wait for the Certificate to have the 'Issuing' condition removed, and
+	// Wait for the Certificate to have the 'Issuing' condition set to False, and
 	// for the signed certificate, ca, and private key stored in the Secret.
 	err = wait.PollImmediateUntil(time.Millisecond*100, func() (done bool, err error) {
 		crt, err = cmCl.CertmanagerV1().Certificates(namespace).Get(ctx, crtName, metav1.GetOptions{})
@@ -214,7 +215,7 @@ func TestIssuingController(t *testing.T) {
 			return false, nil
 		}
 
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
+		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond == nil || cond.Status != cmmeta.ConditionFalse {
 			t.Logf("Certificate does not have expected condition, got=%#v", cond)
 			return false, nil
 		}
@@ -278,7 +279,7 @@ func TestIssuingController_PKCS8_PrivateKey(t *testing.T) {
 
 	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient,
 		cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
-		controllerOptions, "cert-manage-certificates-issuing-test")
+		metrics.New(logf.Log, clock.RealClock{}), controllerOptions, "cert-manage-certificates-issuing-test", workqueue.DefaultControllerRateLimiter())
 	c := controllerpkg.NewController(
 		ctx,
 		"issuing_test",
@@ -418,7 +419,7 @@ func TestIssuingController_PKCS8_PrivateKey(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Wait for the Certificate to have the 'Issuing' condition removed, and for
+	// Wait for the Certificate to have the 'Issuing' condition set to False, and for
 	// the signed certificate, ca, and private key stored in the Secret.
 	err = wait.PollImmediateUntil(time.Millisecond*100, func() (done bool, err error) {
 		crt, err = cmCl.CertmanagerV1().Certificates(namespace).Get(ctx, crtName, metav1.GetOptions{})
@@ -427,7 +428,7 @@ func TestIssuingController_PKCS8_PrivateKey(t *testing.T) {
 			return false, nil
 		}
 
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
+		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond == nil || cond.Status != cmmeta.ConditionFalse {
 			t.Logf("Certificate does not have expected condition, got=%#v", cond)
 			return false, nil
 		}
@@ -493,7 +494,7 @@ func Test_IssuingController_SecretTemplate(t *testing.T) {
 
 	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient,
 		cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
-		controllerOptions, "cert-manage-certificates-issuing-test")
+		metrics.New(logf.Log, clock.RealClock{}), controllerOptions, "cert-manage-certificates-issuing-test", workqueue.DefaultControllerRateLimiter())
 	c := controllerpkg.NewController(
 		ctx,
 		"issuing_test",
@@ -626,7 +627,7 @@ func Test_IssuingController_SecretTemplate(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Wait for the Certificate to have the 'Issuing' condition removed, and for
+	// Wait for the Certificate to have the 'Issuing' condition set to False, and for
 	// the signed certificate, ca, and private key stored in the Secret.
 	err = wait.PollImmediateUntil(time.Millisecond*100, func() (done bool, err error) {
 		crt, err = cmCl.CertmanagerV1().Certificates(namespace).Get(ctx, crtName, metav1.GetOptions{})
@@ -635,7 +636,7 @@ func Test_IssuingController_SecretTemplate(t *testing.T) {
 			return false, nil
 		}
 
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
+		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond == nil || cond.Status != cmmeta.ConditionFalse {
 			t.Logf("Certificate does not have expected condition, got=%#v", cond)
 			return false, nil
 		}
@@ -726,7 +727,7 @@ func Test_IssuingController_AdditionalOutputFormats(t *testing.T) {
 		EnableOwnerRef: true,
 	}
 
-	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient, cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{}, controllerOptions, "cert-manager-issuing-test")
+	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient, cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{}, metrics.New(logf.Log, clock.RealClock{}), controllerOptions, "cert-manager-issuing-test", workqueue.DefaultControllerRateLimiter())
 	c := controllerpkg.NewController(
 		ctx,
 		"issuing_test",
@@ -859,7 +860,7 @@ func Test_IssuingController_AdditionalOutputFormats(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Wait for the Certificate to have the 'Issuing' condition removed, and for
+	// Wait for the Certificate to have the 'Issuing' condition set to False, and for
 	// the signed certificate, ca, and private key stored in the Secret.
 	err = wait.PollImmediateUntil(time.Millisecond*100, func() (done bool, err error) {
 		crt, err = cmCl.CertmanagerV1().Certificates(namespace).Get(ctx, crtName, metav1.GetOptions{})
@@ -868,7 +869,7 @@ func Test_IssuingController_AdditionalOutputFormats(t *testing.T) {
 			return false, nil
 		}
 
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
+		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond == nil || cond.Status != cmmeta.ConditionFalse {
 			t.Logf("Certificate does not have expected condition, got=%#v", cond)
 			return false, nil
 		}
@@ -951,7 +952,8 @@ func Test_IssuingController_OwnerRefernece(t *testing.T) {
 	}
 	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient, cmClient,
 		factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
-		controllerOptions, fieldManager,
+		metrics.New(logf.Log, clock.RealClock{}), controllerOptions, fieldManager,
+		workqueue.DefaultControllerRateLimiter(),
 	)
 	c := controllerpkg.NewController(ctx, fieldManager, metrics.New(logf.Log, clock.RealClock{}), ctrl.ProcessItem, mustSync, nil, queue)
 	stopControllerNoOwnerRef := framework.StartInformersAndController(t, factory, cmFactory, c)
@@ -1038,7 +1040,8 @@ func Test_IssuingController_OwnerRefernece(t *testing.T) {
 	controllerOptions.EnableOwnerRef = true
 	ctrl, queue, mustSync = issuing.NewController(logf.Log, kubeClient, cmClient,
 		factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
-		controllerOptions, fieldManager,
+		metrics.New(logf.Log, clock.RealClock{}), controllerOptions, fieldManager,
+		workqueue.DefaultControllerRateLimiter(),
 	)
 	c = controllerpkg.NewController(ctx, fieldManager, metrics.New(logf.Log, clock.RealClock{}), ctrl.ProcessItem, mustSync, nil, queue)
 	stopControllerOwnerRef := framework.StartInformersAndController(t, factory, cmFactory, c)
@@ -26,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
@@ -53,7 +54,7 @@ func TestRevisionManagerController(t *testing.T) {
 	// Build, instantiate and run the revision manager controller.
 	kubeClient, factory, cmCl, cmFactory := framework.NewClients(t, config)
 
-	ctrl, queue, mustSync := revisionmanager.NewController(logf.Log, cmCl, cmFactory)
+	ctrl, queue, mustSync := revisionmanager.NewController(logf.Log, cmCl, factory, cmFactory, workqueue.DefaultControllerRateLimiter())
 
 	c := controllerpkg.NewController(
 		ctx,
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
@@ -71,7 +72,7 @@ func TestTriggerController(t *testing.T) {
 	shouldReissue := policies.NewTriggerPolicyChain(fakeClock).Evaluate
 	ctrl, queue, mustSync := trigger.NewController(logf.Log, cmCl, factory,
 		cmFactory, framework.NewEventRecorder(t), fakeClock, shouldReissue,
-		"cert-manage-certificates-trigger-test")
+		"cert-manage-certificates-trigger-test", workqueue.DefaultControllerRateLimiter())
 	c := controllerpkg.NewController(
 		ctx,
 		"trigger_test",
@@ -168,7 +169,7 @@ func TestTriggerController_RenewNearExpiry(t *testing.T) {
 	// Start the trigger controller
 	ctrl, queue, mustSync := trigger.NewController(logf.Log, cmCl, factory,
 		cmFactory, framework.NewEventRecorder(t), fakeClock, shoudReissue,
-		"cert-manage-certificates-trigger-test")
+		"cert-manage-certificates-trigger-test", workqueue.DefaultControllerRateLimiter())
 	c := controllerpkg.NewController(
 		logf.NewContext(ctx, logf.Log, "trigger_controller_RenewNearExpiry"),
 		"trigger_test",
@@ -252,7 +253,7 @@ func TestTriggerController_ExpBackoff(t *testing.T) {
 	}
 
 	// Start the trigger controller
-	ctrl, queue, mustSync := trigger.NewController(logf.Log, cmCl, factory, cmFactory, framework.NewEventRecorder(t), fakeClock, shoudReissue, "cert-manger-certificates-trigger-test")
+	ctrl, queue, mustSync := trigger.NewController(logf.Log, cmCl, factory, cmFactory, framework.NewEventRecorder(t), fakeClock, shoudReissue, "cert-manger-certificates-trigger-test", workqueue.DefaultControllerRateLimiter())
 	c := controllerpkg.NewController(
 		logf.NewContext(ctx, logf.Log, "trigger_controller_RenewNearExpiry"),
 		"trigger_test",
@@ -18,6 +18,7 @@ package ctl
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -30,8 +31,10 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/reference"
+	uexec "k8s.io/utils/exec"
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/output"
 	statuscertcmd "github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/certificate"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
@@ -529,6 +532,12 @@ CertificateRequest:
 			}
 
 			err = opts.Run(ctx, test.inputArgs)
+			// The command signals a Certificate which is not Ready with a
+			// non-zero exit code, after the status has been printed.
+			var exitErr uexec.ExitError
+			if errors.As(err, &exitErr) && exitErr.ExitStatus() == output.ExitCodeNotReady {
+				err = nil
+			}
 			if err != nil {
 				if !test.expErr {
 					t.Errorf("got unexpected error: %v", err)
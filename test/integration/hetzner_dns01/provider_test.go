@@ -0,0 +1,185 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/miekg/dns"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/hetzner"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	acmedns "github.com/cert-manager/cert-manager/test/acme/dns"
+	testserver "github.com/cert-manager/cert-manager/test/acme/dns/server"
+)
+
+const (
+	hetznerTestZone  = "example.com."
+	hetznerTestFqdn  = "_acme-challenge.123456789.www.example.com."
+	hetznerTestToken = "test-token"
+)
+
+type record struct {
+	ID     string `json:"id,omitempty"`
+	ZoneID string `json:"zone_id"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	TTL    int    `json:"ttl,omitempty"`
+}
+
+// fakeAPI implements the parts of the Hetzner DNS API used by the solver,
+// publishing the TXT records it holds to a test DNS server using RFC2136
+// updates so that the conformance suite can check their propagation.
+type fakeAPI struct {
+	dnsServer string
+
+	lock    sync.Mutex
+	nextID  int
+	records map[string]record
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("Auth-API-Token") != hetznerTestToken {
+		http.Error(w, `{"message":"invalid token"}`, http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/zones":
+		zones := []map[string]string{}
+		if r.URL.Query().Get("name")+"." == hetznerTestZone {
+			zones = append(zones, map[string]string{"id": "zone", "name": r.URL.Query().Get("name")})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"zones": zones})
+	case r.Method == http.MethodGet && r.URL.Path == "/records":
+		records := []record{}
+		for _, rec := range f.records {
+			records = append(records, rec)
+		}
+		_ = json.NewEncoder(w).Encode(map[string][]record{"records": records})
+	case r.Method == http.MethodPost && r.URL.Path == "/records":
+		var rec record
+		if err := json.NewDecoder(r.Body).Decode(&rec); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.nextID++
+		rec.ID = fmt.Sprintf("%d", f.nextID)
+		f.records[rec.ID] = rec
+		if err := f.publish(rec.Name); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]record{"record": rec})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/records/"):
+		rec, ok := f.records[strings.TrimPrefix(r.URL.Path, "/records/")]
+		if !ok {
+			http.Error(w, `{"message":"record not found"}`, http.StatusNotFound)
+			return
+		}
+		delete(f.records, rec.ID)
+		if err := f.publish(rec.Name); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+// publish replaces the TXT records for name on the test DNS server with
+// those currently held by the fake API.
+func (f *fakeAPI) publish(name string) error {
+	fqdn := name + "." + hetznerTestZone
+	var values []string
+	for _, rec := range f.records {
+		if rec.Type == "TXT" && rec.Name == name {
+			values = append(values, rec.Value)
+		}
+	}
+
+	rr := &dns.TXT{Hdr: dns.RR_Header{Name: fqdn, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 60}, Txt: values}
+	m := new(dns.Msg)
+	m.SetUpdate(hetznerTestZone)
+	if len(values) == 0 {
+		m.Remove([]dns.RR{rr})
+	} else {
+		m.Insert([]dns.RR{rr})
+	}
+	reply, _, err := new(dns.Client).Exchange(m, f.dnsServer)
+	if err != nil {
+		return err
+	}
+	if reply.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("DNS update failed: %s", dns.RcodeToString[reply.Rcode])
+	}
+	return nil
+}
+
+func TestRunSuite(t *testing.T) {
+	ctx := logf.NewContext(context.TODO(), logtesting.NewTestLogger(t), t.Name())
+	server := &testserver.BasicServer{
+		Zones: []string{hetznerTestZone},
+	}
+	if err := server.Run(ctx); err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer func() {
+		if err := server.Shutdown(); err != nil {
+			t.Errorf("failed to gracefully shut down test server: %v", err)
+		}
+	}()
+
+	api := httptest.NewServer(&fakeAPI{dnsServer: server.ListenAddr(), records: map[string]record{}})
+	defer api.Close()
+
+	var validConfig = cmacme.ACMEIssuerDNS01ProviderHetzner{
+		APIToken: cmmeta.SecretKeySelector{
+			LocalObjectReference: cmmeta.LocalObjectReference{
+				Name: "hetzner",
+			},
+			Key: "token",
+		},
+	}
+
+	fixture := acmedns.NewFixture(hetzner.New(hetzner.WithAPIEndpoint(api.URL)),
+		acmedns.SetResolvedZone(hetznerTestZone),
+		acmedns.SetResolvedFQDN(hetznerTestFqdn),
+		acmedns.SetAllowAmbientCredentials(false),
+		acmedns.SetConfig(validConfig),
+		acmedns.SetDNSServer(server.ListenAddr()),
+		acmedns.SetManifestPath("testdata"),
+		// Disable recursive NS lookups as we run a single authoritative NS per test
+		acmedns.SetUseAuthoritative(false),
+	)
+
+	fixture.RunConformance(t)
+}
@@ -225,6 +225,12 @@ func SetCertificateGeneration(gen int64) CertificateModifier {
 	}
 }
 
+func SetCertificateCreationTimestamp(creationTimestamp metav1.Time) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.CreationTimestamp = creationTimestamp
+	}
+}
+
 func AddCertificateAnnotations(annotations map[string]string) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		if crt.Annotations == nil {
@@ -273,8 +279,32 @@ func SetCertificateRevisionHistoryLimit(limit int32) CertificateModifier {
 	}
 }
 
+func SetCertificatePropagationQuorum(quorum int32) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.PropagationQuorum = &quorum
+	}
+}
+
 func SetCertificateAdditionalOutputFormats(additionalOutputFormats ...v1.CertificateAdditionalOutputFormat) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Spec.AdditionalOutputFormats = additionalOutputFormats
 	}
 }
+
+func SetCertificateSecretFormatProfile(profile v1.SecretFormatProfile) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.SecretFormatProfile = profile
+	}
+}
+
+func SetCertificateRenewalWindow(w v1.RenewalWindow) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.RenewalWindow = &w
+	}
+}
+
+func SetCertificateAdditionalPrivateKeys(keys ...v1.CertificateAdditionalPrivateKey) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.AdditionalPrivateKeys = keys
+	}
+}
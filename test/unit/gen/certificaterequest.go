@@ -17,6 +17,9 @@ limitations under the License.
 package gen
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -56,6 +59,20 @@ func SetCertificateRequestCSR(csr []byte) CertificateRequestModifier {
 	}
 }
 
+// SetCertificateRequestCSRSecretRef references the CSR held in the given key
+// of a Secret instead of inlining it, pinning it to the given CSR.
+func SetCertificateRequestCSRSecretRef(name, key string, csr []byte) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		cr.Spec.Request = nil
+		cr.Spec.RequestSecretRef = &cmmeta.SecretKeySelector{
+			LocalObjectReference: cmmeta.LocalObjectReference{Name: name},
+			Key:                  key,
+		}
+		digest := sha256.Sum256(csr)
+		cr.Spec.RequestSHA256 = hex.EncodeToString(digest[:])
+	}
+}
+
 func SetCertificateRequestIsCA(isCA bool) CertificateRequestModifier {
 	return func(cr *v1.CertificateRequest) {
 		cr.Spec.IsCA = isCA
@@ -157,6 +174,17 @@ func SetCertificateRequestAnnotations(annotations map[string]string) Certificate
 	}
 }
 
+func SetCertificateRequestLabels(labels map[string]string) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		if cr.Labels == nil {
+			cr.Labels = make(map[string]string)
+		}
+		for k, v := range labels {
+			cr.Labels[k] = v
+		}
+	}
+}
+
 func DeleteCertificateRequestAnnotation(key string) CertificateRequestModifier {
 	return func(cr *v1.CertificateRequest) {
 		if cr.Annotations == nil {
@@ -356,6 +356,18 @@ func SetIssuerVenafi(a v1.VenafiIssuer) IssuerModifier {
 	}
 }
 
+func SetIssuerRenewalWindow(w v1.RenewalWindow) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().RenewalWindow = &w
+	}
+}
+
+func SetIssuerHTTPClient(c v1.IssuerHTTPClient) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().HTTPClient = &c
+	}
+}
+
 func AddIssuerCondition(c v1.IssuerCondition) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetStatus().Conditions = append(iss.GetStatus().Conditions, c)
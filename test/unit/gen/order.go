@@ -70,6 +70,18 @@ func SetOrderURL(url string) OrderModifier {
 	}
 }
 
+func SetOrderAccountURI(uri string) OrderModifier {
+	return func(order *cmacme.Order) {
+		order.Status.AccountURI = uri
+	}
+}
+
+func SetOrderCertificateURL(url string) OrderModifier {
+	return func(order *cmacme.Order) {
+		order.Status.CertificateURL = url
+	}
+}
+
 func SetOrderState(s cmacme.State) OrderModifier {
 	return func(order *cmacme.Order) {
 		order.Status.State = s
@@ -82,12 +94,31 @@ func SetOrderReason(reason string) OrderModifier {
 	}
 }
 
+func SetOrderFinalizeAttempts(attempts int, lastAttemptTime metav1.Time) OrderModifier {
+	return func(order *cmacme.Order) {
+		order.Status.FinalizeAttempts = attempts
+		order.Status.LastFinalizeAttemptTime = &lastAttemptTime
+	}
+}
+
+func SetOrderFailureTime(failureTime metav1.Time) OrderModifier {
+	return func(order *cmacme.Order) {
+		order.Status.FailureTime = &failureTime
+	}
+}
+
 func SetOrderStatus(s cmacme.OrderStatus) OrderModifier {
 	return func(order *cmacme.Order) {
 		order.Status = s
 	}
 }
 
+func SetOrderCondition(c metav1.Condition) OrderModifier {
+	return func(order *cmacme.Order) {
+		order.Status.Conditions = append(order.Status.Conditions, c)
+	}
+}
+
 func SetOrderCertificate(d []byte) OrderModifier {
 	return func(order *cmacme.Order) {
 		order.Status.Certificate = d